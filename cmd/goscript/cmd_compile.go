@@ -2,6 +2,8 @@ package main
 
 import (
 	"context"
+	"os"
+	"os/signal"
 	"slices"
 
 	"github.com/aperturerobotics/cli"
@@ -15,6 +17,7 @@ var (
 	cliCompilerConfig     compiler.Config
 	cliCompilerPkg        cli.StringSlice
 	cliCompilerBuildFlags cli.StringSlice
+	cliCompilerWatch      bool
 )
 
 // CompileCommands are commands related to compiling code.
@@ -74,6 +77,43 @@ var CompileCommands = []*cli.Command{{
 			Value:       false,
 			EnvVars:     []string{"GOSCRIPT_ALL_DEPENDENCIES"},
 		},
+		&cli.BoolFlag{
+			Name:        "bigint64",
+			Usage:       "represent int64 and uint64 as bigint for exact 64-bit integer semantics",
+			Destination: &cliCompilerConfig.BigInt64,
+			Value:       false,
+			EnvVars:     []string{"GOSCRIPT_BIGINT64"},
+		},
+		&cli.BoolFlag{
+			Name:        "source-locations",
+			Usage:       "record the Go source locations of the generated code for runtime.Caller and stack traces",
+			Destination: &cliCompilerConfig.SourceLocations,
+			Value:       false,
+			EnvVars:     []string{"GOSCRIPT_SOURCE_LOCATIONS"},
+		},
+		&cli.BoolFlag{
+			Name:        "yield-loops",
+			Usage:       "yield to the JavaScript event loop from long-running loops in async functions",
+			Destination: &cliCompilerConfig.YieldLoops,
+			Value:       false,
+			EnvVars:     []string{"GOSCRIPT_YIELD_LOOPS"},
+		},
+		&cli.BoolFlag{
+			Name:        "disable-cache",
+			Usage:       "compile all packages instead of skipping those unchanged since the last compile to the output path",
+			Aliases:     []string{"no-cache"},
+			Destination: &cliCompilerConfig.DisableCache,
+			Value:       false,
+			EnvVars:     []string{"GOSCRIPT_DISABLE_CACHE"},
+		},
+		&cli.BoolFlag{
+			Name:        "watch",
+			Usage:       "watch the sources and recompile the packages that changed",
+			Aliases:     []string{"w"},
+			Destination: &cliCompilerWatch,
+			Value:       false,
+			EnvVars:     []string{"GOSCRIPT_WATCH"},
+		},
 	},
 }}
 
@@ -87,6 +127,12 @@ func compilePackage(c *cli.Context) error {
 	// build flags
 	cliCompilerConfig.BuildFlags = slices.Clone(cliCompilerBuildFlags.Value())
 
+	if cliCompilerWatch {
+		ctx, cancel := signal.NotifyContext(context.Background(), os.Interrupt)
+		defer cancel()
+		return compiler.NewWatcher(cliCompiler, pkgs...).Run(ctx)
+	}
+
 	_, err := cliCompiler.CompilePackages(context.Background(), pkgs...)
 	return err
 }
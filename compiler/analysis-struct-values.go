@@ -0,0 +1,358 @@
+package compiler
+
+import (
+	"go/ast"
+	"go/token"
+	"go/types"
+)
+
+// structValueVisitor finds the struct values converted to interfaces. Struct
+// values and pointers to structs are both represented by the struct object,
+// so the converted values are marked at runtime to tell them apart, see
+// IsStructValueInInterface. Byte arrays converted to interfaces are marked
+// too, as they are represented like other arrays, see IsByteArrayInInterface.
+// Values of named non-struct types with methods are boxed with their methods,
+// see IsNamedValueInInterface, and slices, arrays and maps whose type
+// cannot be told from their elements are marked with it, see
+// IsTypeMarkedInInterface. Basic values converted to the interface key of a
+// map are tagged with their type, see IsTypedMapKey.
+type structValueVisitor struct {
+	analysis *Analysis
+	info     *types.Info
+	// results are the results of the enclosing function, if any.
+	results *types.Tuple
+}
+
+// Visit implements ast.Visitor.
+func (v *structValueVisitor) Visit(node ast.Node) ast.Visitor {
+	switch n := node.(type) {
+	case *ast.FuncDecl:
+		if fn, ok := v.info.Defs[n.Name].(*types.Func); ok {
+			return v.inFunc(fn.Type())
+		}
+	case *ast.FuncLit:
+		return v.inFunc(v.info.TypeOf(n))
+	case *ast.AssignStmt:
+		if n.Tok == token.ASSIGN && len(n.Lhs) == len(n.Rhs) {
+			for i, rhs := range n.Rhs {
+				v.convert(rhs, v.info.TypeOf(n.Lhs[i]))
+			}
+		}
+	case *ast.ValueSpec:
+		if n.Type != nil {
+			for _, value := range n.Values {
+				v.convert(value, v.info.TypeOf(n.Type))
+			}
+		}
+	case *ast.ReturnStmt:
+		if v.results != nil && len(n.Results) == v.results.Len() {
+			for i, result := range n.Results {
+				v.convert(result, v.results.At(i).Type())
+			}
+		}
+	case *ast.SendStmt:
+		if ch, ok := underlyingOf(v.info.TypeOf(n.Chan)).(*types.Chan); ok {
+			v.convert(n.Value, ch.Elem())
+		}
+	case *ast.IndexExpr:
+		if m, ok := underlyingOf(v.info.TypeOf(n.X)).(*types.Map); ok {
+			v.convertMapKey(n.Index, m.Key())
+		}
+	case *ast.BinaryExpr:
+		if n.Op == token.EQL || n.Op == token.NEQ {
+			// Comparing an interface with a value converts the value
+			v.convert(n.X, v.info.TypeOf(n.Y))
+			v.convert(n.Y, v.info.TypeOf(n.X))
+		}
+	case *ast.CallExpr:
+		v.visitCallExpr(n)
+	case *ast.CompositeLit:
+		v.visitCompositeLit(n)
+	}
+	return v
+}
+
+// inFunc returns a visitor for the body of a function with signature typ.
+func (v *structValueVisitor) inFunc(typ types.Type) ast.Visitor {
+	sig, ok := typ.(*types.Signature)
+	if !ok {
+		return v
+	}
+	return &structValueVisitor{analysis: v.analysis, info: v.info, results: sig.Results()}
+}
+
+// visitCallExpr finds the arguments of a call or conversion converted to
+// interfaces.
+func (v *structValueVisitor) visitCallExpr(n *ast.CallExpr) {
+	if fun, ok := n.Fun.(*ast.Ident); ok && len(n.Args) == 2 {
+		if builtin, ok := v.info.Uses[fun].(*types.Builtin); ok && builtin.Name() == "delete" {
+			if m, ok := underlyingOf(v.info.TypeOf(n.Args[0])).(*types.Map); ok {
+				v.convertMapKey(n.Args[1], m.Key())
+			}
+			return
+		}
+	}
+	tv := v.info.Types[n.Fun]
+	if tv.IsType() {
+		if len(n.Args) == 1 {
+			v.convert(n.Args[0], tv.Type)
+		}
+		return
+	}
+	sig, ok := underlyingOf(tv.Type).(*types.Signature)
+	if !ok {
+		return
+	}
+	params := sig.Params()
+	for i, arg := range n.Args {
+		switch {
+		case sig.Variadic() && i >= params.Len()-1:
+			if n.Ellipsis.IsValid() {
+				continue
+			}
+			if slice, ok := params.At(params.Len() - 1).Type().Underlying().(*types.Slice); ok {
+				v.convert(arg, slice.Elem())
+			}
+		case i < params.Len():
+			v.convert(arg, params.At(i).Type())
+		}
+	}
+}
+
+// visitCompositeLit finds the elements of a composite literal converted to
+// interfaces.
+func (v *structValueVisitor) visitCompositeLit(n *ast.CompositeLit) {
+	typ := underlyingOf(v.info.TypeOf(n))
+	if ptr, ok := typ.(*types.Pointer); ok {
+		typ = ptr.Elem().Underlying()
+	}
+	switch t := typ.(type) {
+	case *types.Struct:
+		for i, elt := range n.Elts {
+			if kv, ok := elt.(*ast.KeyValueExpr); ok {
+				if key, ok := kv.Key.(*ast.Ident); ok {
+					if field, ok := v.info.Uses[key].(*types.Var); ok {
+						v.convert(kv.Value, field.Type())
+					}
+				}
+			} else if i < t.NumFields() {
+				v.convert(elt, t.Field(i).Type())
+			}
+		}
+	case *types.Slice:
+		v.convertElements(n.Elts, t.Elem())
+	case *types.Array:
+		v.convertElements(n.Elts, t.Elem())
+	case *types.Map:
+		for _, elt := range n.Elts {
+			if kv, ok := elt.(*ast.KeyValueExpr); ok {
+				v.convertMapKey(kv.Key, t.Key())
+				v.convert(kv.Value, t.Elem())
+			}
+		}
+	}
+}
+
+// convertElements records the elements of a slice or array literal with
+// element type elem.
+func (v *structValueVisitor) convertElements(elts []ast.Expr, elem types.Type) {
+	for _, elt := range elts {
+		if kv, ok := elt.(*ast.KeyValueExpr); ok {
+			elt = kv.Value
+		}
+		v.convert(elt, elem)
+	}
+}
+
+// convertMapKey records expr converted to the key type of a map. Basic
+// values do not carry their type, int(1) and float64(1) are both the number
+// 1, so those converted to an interface key are tagged with it.
+func (v *structValueVisitor) convertMapKey(expr ast.Expr, key types.Type) {
+	v.convert(expr, key)
+	if !usesInterfaceKeyMap(key) {
+		return
+	}
+	typ := v.info.TypeOf(expr)
+	if typ == nil || isNamedValueType(typ) {
+		return
+	}
+	if basic, ok := typ.Underlying().(*types.Basic); ok && basic.Kind() != types.UntypedNil {
+		v.analysis.ensureNodeData(expr).IsTypedMapKey = true
+	}
+}
+
+// convert records expr if it is a struct value, a byte array, a value of a
+// named type with methods or a value needing a type mark converted to the
+// type target.
+func (v *structValueVisitor) convert(expr ast.Expr, target types.Type) {
+	if target == nil || !types.IsInterface(target) {
+		return
+	}
+	if _, isTypeParam := target.(*types.TypeParam); isTypeParam {
+		return
+	}
+	typ := v.info.TypeOf(expr)
+	if typ == nil || types.IsInterface(typ) {
+		return
+	}
+	if isNamedValueType(typ) {
+		v.analysis.ensureNodeData(expr).IsNamedValueInInterface = true
+		return
+	}
+	switch t := typ.Underlying().(type) {
+	case *types.Struct:
+		v.analysis.ensureNodeData(expr).IsStructValueInInterface = true
+	case *types.Array:
+		if elem, ok := t.Elem().Underlying().(*types.Basic); ok && elem.Kind() == types.Uint8 {
+			v.analysis.ensureNodeData(expr).IsByteArrayInInterface = true
+			return
+		}
+	}
+	if needsTypeMark(typ) {
+		v.analysis.ensureNodeData(expr).IsTypeMarkedInInterface = true
+	}
+}
+
+// isNamedValueType reports whether the values of typ are boxed with their
+// methods when converted to an interface: typ is a named type other than a
+// struct, declared in a compiled package, with methods on value receivers.
+// Such values are represented by their underlying type, which does not
+// carry the methods.
+func isNamedValueType(typ types.Type) bool {
+	named, ok := types.Unalias(typ).(*types.Named)
+	if !ok || named.TypeArgs().Len() != 0 {
+		return false
+	}
+	if pkg := named.Obj().Pkg(); pkg == nil || isHandwrittenPackage(pkg.Path()) {
+		return false
+	}
+	if _, isStruct := named.Underlying().(*types.Struct); isStruct {
+		return false
+	}
+	for i := range named.NumMethods() {
+		recv := named.Method(i).Type().(*types.Signature).Recv()
+		if _, isPtr := recv.Type().(*types.Pointer); !isPtr {
+			return true
+		}
+	}
+	return false
+}
+
+// needsTypeMark reports whether typ is a slice, array or map type whose
+// values are marked with it when converted to an interface, as fmt
+// cannot infer it from their elements, see hidesType. Types with type
+// parameters are not known until run time and are not marked.
+func needsTypeMark(typ types.Type) bool {
+	if mentionsTypeParam(typ) {
+		return false
+	}
+	switch t := typ.Underlying().(type) {
+	case *types.Slice:
+		return isNamed(typ) || hidesType(t.Elem())
+	case *types.Array:
+		return isNamed(typ) || hidesType(t.Elem())
+	case *types.Map:
+		return isNamed(typ) || hidesType(t.Key()) || hidesType(t.Elem())
+	}
+	return false
+}
+
+// hidesType reports whether the values of typ do not tell their type:
+// values of named non-struct types, interfaces and pointers, and slices,
+// arrays and maps of them.
+func hidesType(typ types.Type) bool {
+	if isNamed(typ) {
+		_, isStruct := typ.Underlying().(*types.Struct)
+		return !isStruct
+	}
+	switch t := typ.Underlying().(type) {
+	case *types.Interface, *types.Pointer:
+		return true
+	case *types.Slice:
+		return hidesType(t.Elem())
+	case *types.Array:
+		return hidesType(t.Elem())
+	case *types.Map:
+		return hidesType(t.Key()) || hidesType(t.Elem())
+	}
+	return false
+}
+
+// isNamed reports whether typ is a named type.
+func isNamed(typ types.Type) bool {
+	_, ok := types.Unalias(typ).(*types.Named)
+	return ok
+}
+
+// mentionsTypeParam reports whether typ is or is composed of a type
+// parameter.
+func mentionsTypeParam(typ types.Type) bool {
+	switch t := types.Unalias(typ).(type) {
+	case *types.TypeParam:
+		return true
+	case *types.Named:
+		for i := range t.TypeArgs().Len() {
+			if mentionsTypeParam(t.TypeArgs().At(i)) {
+				return true
+			}
+		}
+		return false
+	case *types.Pointer:
+		return mentionsTypeParam(t.Elem())
+	case *types.Slice:
+		return mentionsTypeParam(t.Elem())
+	case *types.Array:
+		return mentionsTypeParam(t.Elem())
+	case *types.Map:
+		return mentionsTypeParam(t.Key()) || mentionsTypeParam(t.Elem())
+	case *types.Chan:
+		return mentionsTypeParam(t.Elem())
+	}
+	return false
+}
+
+// underlyingOf returns the underlying type of typ, or nil if typ is nil.
+func underlyingOf(typ types.Type) types.Type {
+	if typ == nil {
+		return nil
+	}
+	return typ.Underlying()
+}
+
+// IsStructValueInInterface reports whether expr is a struct value converted
+// to an interface, which is written as a copy marked with
+// $.markStructValue.
+func (a *Analysis) IsStructValueInInterface(expr ast.Expr) bool {
+	info := a.NodeData[expr]
+	return info != nil && info.IsStructValueInInterface
+}
+
+// IsNamedValueInInterface reports whether expr is a value of a named
+// non-struct type with methods converted to an interface, which is written
+// boxed with the methods by $.namedValue, see isNamedValueType.
+func (a *Analysis) IsNamedValueInInterface(expr ast.Expr) bool {
+	info := a.NodeData[expr]
+	return info != nil && info.IsNamedValueInInterface
+}
+
+// IsTypeMarkedInInterface reports whether expr is a slice, array or map
+// converted to an interface that is marked with its type by
+// $.markType, see needsTypeMark.
+func (a *Analysis) IsTypeMarkedInInterface(expr ast.Expr) bool {
+	info := a.NodeData[expr]
+	return info != nil && info.IsTypeMarkedInInterface
+}
+
+// IsTypedMapKey reports whether expr is a basic value converted to the
+// interface key of a map, which is tagged with its type by $.typedMapKey.
+func (a *Analysis) IsTypedMapKey(expr ast.Expr) bool {
+	info := a.NodeData[expr]
+	return info != nil && info.IsTypedMapKey
+}
+
+// IsByteArrayInInterface reports whether expr is a byte array converted to
+// an interface, which is written as a copy marked with $.markByteArray.
+func (a *Analysis) IsByteArrayInInterface(expr ast.Expr) bool {
+	info := a.NodeData[expr]
+	return info != nil && info.IsByteArrayInInterface
+}
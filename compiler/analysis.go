@@ -6,7 +6,9 @@ import (
 	"go/ast"
 	"go/token"
 	"go/types"
+	"maps"
 	"path/filepath"
+	"slices"
 	"strings"
 
 	"github.com/aperturerobotics/goscript"
@@ -84,12 +86,37 @@ type NodeInfo struct {
 	IsMethodValue     bool           // true if this SelectorExpr is a method value that needs binding
 	ShadowingInfo     *ShadowingInfo // variable shadowing information for if statements
 	IdentifierMapping string         // replacement name for this identifier (e.g., receiver -> "receiver")
+	NeedsPanicFrame   bool           // true if this function body must catch panics for its deferred calls
+	IsDeferredRecover bool           // true if this recover() call can recover, as it is made directly by a deferred function literal or a function taking the defer frame
+	TakesDeferFrame   bool           // true if the function declared with this type takes the defer frame of its deferred calls, see takesDeferFrame
+	PassesDeferFrame  bool           // true if this deferred call passes its defer frame to the function called
+	GotoBlock         *GotoBlockInfo // state machine of a block or case body containing goto targets, also set on the gotos jumping into it
+	BranchLabel       string         // label for an unlabeled break/continue that must escape a goto state machine
+	SyntheticLabel    string         // label to add to a loop or switch targeted by such a break/continue
+
+	IsStructValueInInterface bool // true if this struct value expression is converted to an interface
+	IsByteArrayInInterface   bool // true if this byte array expression is converted to an interface
+	IsNamedValueInInterface  bool // true if this value of a named type with methods is converted to an interface
+	IsTypeMarkedInInterface  bool // true if this slice, array or map is converted to an interface with a type mark
+	IsTypedMapKey            bool // true if this basic value is converted to the interface key of a map
+}
+
+// GotoBlockInfo describes a block or case clause body whose statements are
+// lowered into a state machine because it contains the targets of goto
+// statements.
+type GotoBlockInfo struct {
+	StateVar  string               // variable holding the state to run next
+	LoopLabel string               // label of the loop dispatching the states
+	States    map[*types.Label]int // state of each goto target label, starting at 1
 }
 
 // GsMetadata represents the structure of a meta.json file in gs/ packages
 type GsMetadata struct {
 	Dependencies []string        `json:"dependencies,omitempty"`
 	AsyncMethods map[string]bool `json:"asyncMethods,omitempty"`
+	// BigInt64 marks packages accepting int64 and uint64 values as either
+	// number or bigint, and returning them as bigint in the BigInt64 mode.
+	BigInt64 bool `json:"bigInt64,omitempty"`
 }
 
 // InterfaceMethodKey uniquely identifies an interface method
@@ -161,6 +188,23 @@ type Analysis struct {
 	// MethodAsyncStatus stores the async status of all methods analyzed
 	// This is computed once during analysis and reused during code generation
 	MethodAsyncStatus map[MethodKey]bool
+
+	// gotoBlockCount and syntheticLabelCount number the generated goto state
+	// machines and labels to keep their names unique within the package
+	gotoBlockCount      int
+	syntheticLabelCount int
+
+	// gotoCapturedVars holds the variables hoisted out of goto state machines
+	// that function literals capture. They are variable referenced, so that
+	// each execution of their declaration creates a new VarRef, and
+	// gotoCaptures lists them for each function literal capturing them, which
+	// binds the VarRefs current when it is created.
+	gotoCapturedVars map[types.Object]bool
+	gotoCaptures     map[*ast.FuncLit][]types.Object
+
+	// bigInt64Packages caches which handwritten packages support bigint
+	// 64-bit integers, see IsBigInt64Package
+	bigInt64Packages map[string]bool
 }
 
 // PackageAnalysis holds cross-file analysis data for a package
@@ -202,6 +246,8 @@ func NewAnalysis(allPackages map[string]*packages.Package) *Analysis {
 		InterfaceImplementations:   make(map[InterfaceMethodKey][]ImplementationInfo),
 		InterfaceMethodAsyncStatus: make(map[InterfaceMethodKey]bool),
 		MethodAsyncStatus:          make(map[MethodKey]bool),
+		gotoCapturedVars:           make(map[types.Object]bool),
+		gotoCaptures:               make(map[*ast.FuncLit][]types.Object),
 	}
 }
 
@@ -249,6 +295,49 @@ func (a *Analysis) NeedsDefer(node ast.Node) bool {
 	return nodeInfo.NeedsDefer
 }
 
+// NeedsPanicFrame returns whether the given function body must be wrapped in a
+// panic frame: its deferred calls may recover from a panic, or they run with
+// named results in scope and may change them after the body returns.
+func (a *Analysis) NeedsPanicFrame(node ast.Node) bool {
+	if node == nil {
+		return false
+	}
+	nodeInfo := a.NodeData[node]
+	if nodeInfo == nil {
+		return false
+	}
+	return nodeInfo.NeedsPanicFrame
+}
+
+// IsDeferredRecover returns whether the given recover() call is made directly
+// by a deferred function literal or by a function taking the defer frame, and
+// recovers from the panic of the frame passed as `__frame`.
+func (a *Analysis) IsDeferredRecover(node ast.Node) bool {
+	if node == nil {
+		return false
+	}
+	nodeInfo := a.NodeData[node]
+	if nodeInfo == nil {
+		return false
+	}
+	return nodeInfo.IsDeferredRecover
+}
+
+// TakesDeferFrame returns whether the function declared with the given type
+// takes the defer frame of its deferred calls as a trailing `__frame`
+// parameter, see takesDeferFrame.
+func (a *Analysis) TakesDeferFrame(funcType *ast.FuncType) bool {
+	nodeInfo := a.NodeData[funcType]
+	return nodeInfo != nil && nodeInfo.TakesDeferFrame
+}
+
+// PassesDeferFrame returns whether the given deferred call passes its defer
+// frame to the function it calls, which takes it, see TakesDeferFrame.
+func (a *Analysis) PassesDeferFrame(call *ast.CallExpr) bool {
+	nodeInfo := a.NodeData[call]
+	return nodeInfo != nil && nodeInfo.PassesDeferFrame
+}
+
 // IsInAsyncFunction returns whether the given node is inside an async function.
 func (a *Analysis) IsInAsyncFunction(node ast.Node) bool {
 	if node == nil {
@@ -336,6 +425,10 @@ func (a *Analysis) NeedsVarRef(obj types.Object) bool {
 		return false
 	}
 
+	if a.gotoCapturedVars[obj] {
+		return true
+	}
+
 	usageInfo, exists := a.VariableUsage[obj]
 	if !exists {
 		return false
@@ -581,6 +674,9 @@ func (v *analysisVisitor) Visit(node ast.Node) ast.Visitor {
 	case *ast.ReturnStmt:
 		return v.visitReturnStmt(n)
 
+	case *ast.DeferStmt:
+		return v.visitDeferStmt(n)
+
 	case *ast.DeclStmt:
 		return v.visitDeclStmt(n)
 
@@ -667,6 +763,25 @@ func (v *analysisVisitor) visitFuncDecl(n *ast.FuncDecl) ast.Visitor {
 			nodeInfo.NeedsDefer = true
 		}
 
+		// recover() made directly by the function recovers when it is
+		// deferred, from the defer frame passed to it
+		if takesDeferFrame(n, v.pkg.TypesInfo) {
+			v.analysis.ensureNodeData(n.Type).TakesDeferFrame = true
+			for _, call := range findRecoverCalls(n.Body, v.pkg.TypesInfo) {
+				v.analysis.ensureNodeData(call).IsDeferredRecover = true
+			}
+		}
+
+		// Check if the deferred calls need to observe panics or named results
+		if v.needsPanicFrame(n.Body, n.Type) {
+			bodyInfo := v.analysis.ensureNodeData(n.Body)
+			bodyInfo.NeedsPanicFrame = true
+			bodyInfo.EnclosingFuncDecl = n
+		}
+
+		// Lower blocks containing goto targets into state machines
+		v.analyzeGotoBlocks(n.Body)
+
 		// Visit the body with updated state
 		ast.Walk(v, n.Body)
 	}
@@ -718,6 +833,18 @@ func (v *analysisVisitor) visitFuncLit(n *ast.FuncLit) ast.Visitor {
 		nodeInfo.NeedsDefer = true
 	}
 
+	// Check if the deferred calls need to observe panics or named results
+	if n.Body != nil && v.needsPanicFrame(n.Body, n.Type) {
+		bodyInfo := v.analysis.ensureNodeData(n.Body)
+		bodyInfo.NeedsPanicFrame = true
+		bodyInfo.EnclosingFuncLit = n
+	}
+
+	// Lower blocks containing goto targets into state machines
+	if n.Body != nil {
+		v.analyzeGotoBlocks(n.Body)
+	}
+
 	// Visit the body with updated state
 	ast.Walk(v, n.Body)
 
@@ -753,9 +880,41 @@ func (v *analysisVisitor) visitCallExpr(n *ast.CallExpr) ast.Visitor {
 	// Track interface implementations from function call arguments
 	v.trackInterfaceCallArguments(n)
 
+	// Track variables whose address is passed as an argument
+	for _, arg := range n.Args {
+		v.trackAddressOfArgument(arg)
+	}
+
 	return v
 }
 
+// trackAddressOfArgument marks a variable as variable referenced if its
+// address is passed directly as a call argument, as in json.Unmarshal(data, &m),
+// so the callee can assign through the pointer. Pointers to structs and arrays
+// are represented by the values themselves and need no variable reference.
+func (v *analysisVisitor) trackAddressOfArgument(arg ast.Expr) {
+	unaryExpr, ok := ast.Unparen(arg).(*ast.UnaryExpr)
+	if !ok || unaryExpr.Op != token.AND {
+		return
+	}
+	ident, ok := ast.Unparen(unaryExpr.X).(*ast.Ident)
+	if !ok {
+		return
+	}
+	obj, ok := v.pkg.TypesInfo.ObjectOf(ident).(*types.Var)
+	if !ok || obj.IsField() {
+		return
+	}
+	switch obj.Type().Underlying().(type) {
+	case *types.Struct, *types.Array:
+		return
+	}
+	usageInfo := v.getOrCreateUsageInfo(obj)
+	usageInfo.Destinations = append(usageInfo.Destinations, AssignmentInfo{
+		Type: AddressOfAssignment,
+	})
+}
+
 // visitSelectorExpr handles selector expression analysis
 func (v *analysisVisitor) visitSelectorExpr(n *ast.SelectorExpr) ast.Visitor {
 	// Check if this is a method value (method being used as a value, not called immediately)
@@ -835,6 +994,22 @@ func (v *analysisVisitor) visitReturnStmt(n *ast.ReturnStmt) ast.Visitor {
 	return v
 }
 
+// visitDeferStmt handles defer statement analysis
+func (v *analysisVisitor) visitDeferStmt(n *ast.DeferStmt) ast.Visitor {
+	// recover() called directly by a deferred function literal reads the
+	// panic state of the defer frame passed to the deferred call.
+	if funcLit, ok := n.Call.Fun.(*ast.FuncLit); ok {
+		for _, call := range findRecoverCalls(funcLit.Body, v.pkg.TypesInfo) {
+			v.analysis.ensureNodeData(call).IsDeferredRecover = true
+		}
+		return v
+	}
+	if decl, info := v.deferredFuncDecl(n.Call); decl != nil && takesDeferFrame(decl, info) {
+		v.analysis.ensureNodeData(n.Call).PassesDeferFrame = true
+	}
+	return v
+}
+
 // visitDeclStmt handles declaration statement analysis
 func (v *analysisVisitor) visitDeclStmt(n *ast.DeclStmt) ast.Visitor {
 	// Handle declarations inside functions (const, var, type declarations within function bodies)
@@ -896,6 +1071,305 @@ func (v *analysisVisitor) containsDefer(block *ast.BlockStmt) bool {
 	return hasDefer
 }
 
+// needsPanicFrame checks if a function body must catch panics around its
+// statements. This is the case when one of its own deferred calls may call
+// recover(), or when it has named results that deferred calls can modify.
+func (v *analysisVisitor) needsPanicFrame(body *ast.BlockStmt, funcType *ast.FuncType) bool {
+	hasDefer := false
+	mayRecover := false
+
+	ast.Inspect(body, func(n ast.Node) bool {
+		switch s := n.(type) {
+		case *ast.FuncLit:
+			// Nested function literals have their own defer stacks.
+			return false
+		case *ast.DeferStmt:
+			hasDefer = true
+			if v.deferredCallMayRecover(s.Call) {
+				mayRecover = true
+			}
+			return false
+		}
+		return true
+	})
+
+	if mayRecover {
+		return true
+	}
+	if !hasDefer || funcType == nil || funcType.Results == nil {
+		return false
+	}
+	for _, field := range funcType.Results.List {
+		if len(field.Names) > 0 {
+			return true
+		}
+	}
+	return false
+}
+
+// deferredCallMayRecover checks if a deferred call may call recover() directly,
+// either as a function literal or as a declared function or method.
+func (v *analysisVisitor) deferredCallMayRecover(call *ast.CallExpr) bool {
+	if fun, ok := call.Fun.(*ast.FuncLit); ok {
+		return len(findRecoverCalls(fun.Body, v.pkg.TypesInfo)) > 0
+	}
+	decl, info := v.deferredFuncDecl(call)
+	return decl != nil && len(findRecoverCalls(decl.Body, info)) > 0
+}
+
+// deferredFuncDecl returns the declaration of the function or method called
+// by a deferred call, and the type information of its package, if it is
+// declared in a compiled package.
+func (v *analysisVisitor) deferredFuncDecl(call *ast.CallExpr) (*ast.FuncDecl, *types.Info) {
+	var ident *ast.Ident
+	switch fun := call.Fun.(type) {
+	case *ast.Ident:
+		ident = fun
+	case *ast.SelectorExpr:
+		ident = fun.Sel
+	case *ast.IndexExpr:
+		if id, ok := fun.X.(*ast.Ident); ok {
+			ident = id
+		}
+	}
+	if ident == nil {
+		return nil, nil
+	}
+
+	funcObj, ok := v.pkg.TypesInfo.Uses[ident].(*types.Func)
+	if !ok || funcObj.Pkg() == nil {
+		return nil, nil
+	}
+	funcObj = funcObj.Origin()
+
+	targetPkg := v.pkg
+	if funcObj.Pkg() != v.pkg.Types {
+		targetPkg = v.analysis.AllPackages[funcObj.Pkg().Path()]
+		if targetPkg == nil {
+			return nil, nil
+		}
+	}
+
+	for _, file := range targetPkg.Syntax {
+		for _, decl := range file.Decls {
+			funcDecl, ok := decl.(*ast.FuncDecl)
+			if !ok || funcDecl.Body == nil || targetPkg.TypesInfo.Defs[funcDecl.Name] != funcObj {
+				continue
+			}
+			return funcDecl, targetPkg.TypesInfo
+		}
+	}
+	return nil, nil
+}
+
+// takesDeferFrame checks if a declared function or method takes the defer
+// frame of the deferred calls to it as a trailing `__frame` parameter, which
+// is the case if it calls recover() directly. Only the deferred call passes
+// the frame, so recover() returns nil when the function is called otherwise,
+// as in Go. Variadic functions end with a rest parameter and cannot take it.
+func takesDeferFrame(decl *ast.FuncDecl, info *types.Info) bool {
+	obj := info.Defs[decl.Name]
+	if decl.Body == nil || obj == nil {
+		return false
+	}
+	if sig, ok := obj.Type().(*types.Signature); !ok || sig.Variadic() {
+		return false
+	}
+	return len(findRecoverCalls(decl.Body, info)) > 0
+}
+
+// findRecoverCalls returns the calls to the builtin recover() made directly by
+// the given function body, excluding those inside nested function literals.
+func findRecoverCalls(body *ast.BlockStmt, info *types.Info) []*ast.CallExpr {
+	var calls []*ast.CallExpr
+	if body == nil || info == nil {
+		return nil
+	}
+
+	ast.Inspect(body, func(n ast.Node) bool {
+		switch expr := n.(type) {
+		case *ast.FuncLit:
+			return false
+		case *ast.CallExpr:
+			if ident, ok := expr.Fun.(*ast.Ident); ok && ident.Name == "recover" {
+				if _, isBuiltin := info.Uses[ident].(*types.Builtin); isBuiltin {
+					calls = append(calls, expr)
+				}
+			}
+		}
+		return true
+	})
+	return calls
+}
+
+// analyzeGotoBlocks finds the blocks and case clause bodies of a function
+// body that contain the targets of goto statements. Each of them is lowered
+// into a state machine:
+// a labeled loop around a switch with one case per goto target, so that a goto
+// sets the next state and continues the loop.
+func (v *analysisVisitor) analyzeGotoBlocks(body *ast.BlockStmt) {
+	// Collect the labels targeted by goto statements. Labels are scoped to
+	// the function, so nested function literals are analyzed separately.
+	targets := make(map[*types.Label]bool)
+	var gotos []*ast.BranchStmt
+	ast.Inspect(body, func(n ast.Node) bool {
+		switch s := n.(type) {
+		case *ast.FuncLit:
+			return false
+		case *ast.BranchStmt:
+			if s.Tok == token.GOTO && s.Label != nil {
+				if label, ok := v.pkg.TypesInfo.Uses[s.Label].(*types.Label); ok {
+					targets[label] = true
+					gotos = append(gotos, s)
+				}
+			}
+		}
+		return true
+	})
+	if len(gotos) == 0 {
+		return
+	}
+
+	// Assign a state to each target label, grouped by the block or case
+	// clause body holding it
+	labelBlocks := make(map[*types.Label]*GotoBlockInfo)
+	gotoBlocks := make(map[ast.Node]bool)
+	hoisted := make(map[types.Object]bool)
+	ast.Inspect(body, func(n ast.Node) bool {
+		var stmts []ast.Stmt
+		switch s := n.(type) {
+		case *ast.FuncLit:
+			return false
+		case *ast.BlockStmt:
+			stmts = s.List
+		case *ast.CaseClause:
+			stmts = s.Body
+		case *ast.CommClause:
+			stmts = s.Body
+		default:
+			return true
+		}
+
+		var info *GotoBlockInfo
+		for _, stmt := range stmts {
+			labeled, ok := stmt.(*ast.LabeledStmt)
+			if !ok {
+				continue
+			}
+			label, ok := v.pkg.TypesInfo.Defs[labeled.Label].(*types.Label)
+			if !ok || !targets[label] {
+				continue
+			}
+			if info == nil {
+				id := v.analysis.gotoBlockCount
+				v.analysis.gotoBlockCount++
+				info = &GotoBlockInfo{
+					StateVar:  fmt.Sprintf("__goto%d", id),
+					LoopLabel: fmt.Sprintf("__gotoLoop%d", id),
+					States:    make(map[*types.Label]int),
+				}
+				v.analysis.ensureNodeData(n).GotoBlock = info
+				gotoBlocks[n] = true
+				for _, obj := range gotoBlockVars(stmts, v.pkg.TypesInfo) {
+					hoisted[obj] = true
+				}
+			}
+			info.States[label] = len(info.States) + 1
+			labelBlocks[label] = info
+		}
+		return true
+	})
+
+	// A hoisted variable is shared by every execution of its declaration, so
+	// the variables captured by function literals get a new VarRef each time
+	// instead, which the function literals bind when created (see
+	// writeGotoBlockRebind and writeGotoCapturesStart).
+	ast.Inspect(body, func(n ast.Node) bool {
+		funcLit, ok := n.(*ast.FuncLit)
+		if !ok {
+			return true
+		}
+		ast.Inspect(funcLit.Body, func(n ast.Node) bool {
+			ident, ok := n.(*ast.Ident)
+			if !ok {
+				return true
+			}
+			obj := v.pkg.TypesInfo.Uses[ident]
+			if obj == nil || !hoisted[obj] || slices.Contains(v.analysis.gotoCaptures[funcLit], obj) {
+				return true
+			}
+			v.analysis.gotoCapturedVars[obj] = true
+			v.analysis.gotoCaptures[funcLit] = append(v.analysis.gotoCaptures[funcLit], obj)
+			return true
+		})
+		return false
+	})
+
+	for _, stmt := range gotos {
+		label := v.pkg.TypesInfo.Uses[stmt.Label].(*types.Label)
+		if info := labelBlocks[label]; info != nil {
+			v.analysis.ensureNodeData(stmt).GotoBlock = info
+		}
+	}
+
+	// An unlabeled break or continue inside a state machine would target the
+	// state machine itself, so label the statement it actually targets.
+	var stack []ast.Node
+	ast.Inspect(body, func(n ast.Node) bool {
+		if n == nil {
+			stack = stack[:len(stack)-1]
+			return true
+		}
+		if _, ok := n.(*ast.FuncLit); ok {
+			return false
+		}
+		stack = append(stack, n)
+
+		branch, ok := n.(*ast.BranchStmt)
+		if !ok || branch.Label != nil || (branch.Tok != token.BREAK && branch.Tok != token.CONTINUE) {
+			return true
+		}
+
+		crossesGotoBlock := false
+		for i := len(stack) - 2; i >= 0; i-- {
+			isTarget := false
+			if gotoBlocks[stack[i]] {
+				crossesGotoBlock = true
+			}
+			switch stack[i].(type) {
+			case *ast.ForStmt, *ast.RangeStmt:
+				isTarget = true
+			case *ast.SwitchStmt, *ast.TypeSwitchStmt, *ast.SelectStmt:
+				isTarget = branch.Tok == token.BREAK
+			}
+			if !isTarget {
+				continue
+			}
+			if crossesGotoBlock {
+				v.analysis.ensureNodeData(branch).BranchLabel = v.targetLabel(stack, i)
+			}
+			break
+		}
+		return true
+	})
+}
+
+// targetLabel returns the label of the statement at stack[i], adding a
+// synthetic label to it if it has none.
+func (v *analysisVisitor) targetLabel(stack []ast.Node, i int) string {
+	if i > 0 {
+		if labeled, ok := stack[i-1].(*ast.LabeledStmt); ok {
+			return labeled.Label.Name
+		}
+	}
+	nodeInfo := v.analysis.ensureNodeData(stack[i])
+	if nodeInfo.SyntheticLabel == "" {
+		nodeInfo.SyntheticLabel = fmt.Sprintf("__label%d", v.analysis.syntheticLabelCount)
+		v.analysis.syntheticLabelCount++
+	}
+	return nodeInfo.SyntheticLabel
+}
+
 // containsReceiverUsage checks if a method body contains any references to the receiver variable.
 func (v *analysisVisitor) containsReceiverUsage(node ast.Node, receiver *types.Var) bool {
 	if receiver == nil {
@@ -936,10 +1410,18 @@ func (v *analysisVisitor) containsReceiverUsage(node ast.Node, receiver *types.V
 // with information that will be used during code generation to properly handle pointers,
 // variables that need varRefing, receiver usage, etc. This replaces the old file-by-file analysis.
 func AnalyzePackageFiles(pkg *packages.Package, allPackages map[string]*packages.Package) *Analysis {
+	return analyzePackageFiles(pkg, allPackages, nil)
+}
+
+// analyzePackageFiles implements AnalyzePackageFiles. The async status of the
+// functions and methods in knownAsync is used instead of analyzing them again,
+// it holds results of compiling the dependencies of pkg.
+func analyzePackageFiles(pkg *packages.Package, allPackages map[string]*packages.Package, knownAsync map[MethodKey]bool) *Analysis {
 	analysis := NewAnalysis(allPackages)
 
 	// Load package metadata for async function detection
 	analysis.LoadPackageMetadata()
+	maps.Copy(analysis.MethodAsyncStatus, knownAsync)
 
 	// Process imports from all files in the package
 	for _, file := range pkg.Syntax {
@@ -1034,6 +1516,15 @@ func AnalyzePackageFiles(pkg *packages.Package, allPackages map[string]*packages
 		ast.Walk(interfaceVisitor, file)
 	}
 
+	// Find the struct values and byte arrays converted to interfaces
+	structValues := &structValueVisitor{
+		analysis: analysis,
+		info:     pkg.TypesInfo,
+	}
+	for _, file := range pkg.Syntax {
+		ast.Walk(structValues, file)
+	}
+
 	// Third pass: comprehensive async analysis for all methods
 	// Interface implementation async status is now updated on-demand in IsInterfaceMethodAsync
 	visitor.analyzeAllMethodsAsync()
@@ -1288,6 +1779,21 @@ func (a *Analysis) loadGsMetadata(metaFilePath string) *GsMetadata {
 	return &metadata
 }
 
+// IsBigInt64Package checks if a handwritten gs package supports int64 and
+// uint64 values represented as bigint, as declared in its meta.json.
+func (a *Analysis) IsBigInt64Package(pkgPath string) bool {
+	if supported, ok := a.bigInt64Packages[pkgPath]; ok {
+		return supported
+	}
+	metadata := a.loadGsMetadata(filepath.Join("gs", pkgPath, "meta.json"))
+	supported := metadata != nil && metadata.BigInt64
+	if a.bigInt64Packages == nil {
+		a.bigInt64Packages = make(map[string]bool)
+	}
+	a.bigInt64Packages[pkgPath] = supported
+	return supported
+}
+
 // IsMethodAsync checks if a method call is async based on package metadata
 func (a *Analysis) IsMethodAsync(pkgPath, typeName, methodName string) bool {
 	// First, check pre-computed method async status
@@ -2270,6 +2776,9 @@ func (v *analysisVisitor) isCallAsync(callExpr *ast.CallExpr, pkg *packages.Pack
 		if ident, ok := fun.X.(*ast.Ident); ok {
 			if obj := pkg.TypesInfo.Uses[ident]; obj != nil {
 				if pkgName, isPkg := obj.(*types.PkgName); isPkg {
+					if funcObj, ok := pkg.TypesInfo.Uses[fun.Sel].(*types.Func); ok {
+						return v.isFunctionAsync(funcObj, pkg)
+					}
 					methodName := fun.Sel.Name
 					pkgPath := pkgName.Imported().Path()
 					// Check if this package-level function is async (empty TypeName)
@@ -2303,7 +2812,14 @@ func (v *analysisVisitor) isCallAsync(callExpr *ast.CallExpr, pkg *packages.Pack
 func (v *analysisVisitor) isFunctionAsync(funcObj *types.Func, pkg *packages.Package) bool {
 	// Check if it's from external package metadata
 	if funcObj.Pkg() != nil && funcObj.Pkg() != pkg.Types {
-		return v.analysis.IsMethodAsync(funcObj.Pkg().Path(), "", funcObj.Name())
+		pkgPath := funcObj.Pkg().Path()
+		// Functions of packages compiled from Go are analyzed on demand, so
+		// the result does not depend on the order packages are analyzed in
+		targetPkg := v.analysis.AllPackages[pkgPath]
+		if targetPkg != nil && targetPkg.Types == funcObj.Pkg() && !isHandwrittenPackage(pkgPath) {
+			return v.isFunctionAsync(funcObj, targetPkg)
+		}
+		return v.analysis.IsMethodAsync(pkgPath, "", funcObj.Name())
 	}
 
 	// Check internal method status
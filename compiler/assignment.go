@@ -1,6 +1,7 @@
 package compiler
 
 import (
+	"fmt"
 	"go/ast"
 	"go/token"
 	"go/types"
@@ -34,6 +35,14 @@ import (
 // - Pointer dereference assignments (*p = v)
 // - Blank identifier assignments (_ = v)
 func (c *GoToTSCompiler) writeAssignmentCore(lhs, rhs []ast.Expr, tok token.Token, addDeclaration bool) error {
+	// Compound assignments to bigints and fixed-width integers, and integer
+	// divisions, are written as `x = x op y` to wrap the result
+	if len(lhs) == 1 && len(rhs) == 1 {
+		if bin := c.assignOpExpr(lhs[0], tok, rhs[0]); bin != nil {
+			return c.writeOpAssign(lhs[0], bin)
+		}
+	}
+
 	// Handle blank identifier (_) on the LHS for single assignments
 	if len(lhs) == 1 && len(rhs) == 1 {
 		if ident, ok := lhs[0].(*ast.Ident); ok && ident.Name == "_" {
@@ -45,8 +54,9 @@ func (c *GoToTSCompiler) writeAssignmentCore(lhs, rhs []ast.Expr, tok token.Toke
 			return nil
 		}
 
-		// Handle the special case of "*p = val" (assignment to dereferenced pointer)
-		if starExpr, ok := lhs[0].(*ast.StarExpr); ok {
+		// Handle the special case of "*p = val" (assignment to dereferenced pointer).
+		// Compound assignments like "*p += val" are written by the general path.
+		if starExpr, ok := lhs[0].(*ast.StarExpr); ok && tok == token.ASSIGN {
 			// For *p = val, we need to set p's .value property
 			// Write "p!.value = " for the underlying value
 			if err := c.WriteValueExpr(starExpr.X); err != nil { // p in *p
@@ -55,7 +65,7 @@ func (c *GoToTSCompiler) writeAssignmentCore(lhs, rhs []ast.Expr, tok token.Toke
 			c.tsw.WriteLiterally("!.value = ") // Add non-null assertion for TS safety
 
 			// Handle the RHS expression (potentially adding .clone() for structs)
-			if shouldApplyClone(c.pkg, rhs[0]) {
+			if c.shouldCloneValue(rhs[0]) {
 				if err := c.WriteValueExpr(rhs[0]); err != nil {
 					return err
 				}
@@ -339,7 +349,7 @@ func (c *GoToTSCompiler) writeAssignmentCore(lhs, rhs []ast.Expr, tok token.Toke
 		}
 
 		// Handle different cases for struct cloning
-		if shouldApplyClone(c.pkg, r) {
+		if c.shouldCloneValue(r) {
 			// For other expressions, we need to handle variable referenced access differently
 			if _, isIdent := r.(*ast.Ident); isIdent {
 				// For identifiers, WriteValueExpr already adds .value if needed
@@ -448,3 +458,151 @@ func shouldApplyClone(pkg *packages.Package, rhs ast.Expr) bool {
 
 	return false // Not a struct, do not apply clone
 }
+
+// shouldCloneValue reports whether the value of expr is copied with
+// `.clone()` when assigned, see shouldApplyClone. Struct values converted to
+// interfaces are copied when marked instead, see writeMarkedStructValue.
+func (c *GoToTSCompiler) shouldCloneValue(expr ast.Expr) bool {
+	return shouldApplyClone(c.pkg, expr) && !c.analysis.IsStructValueInInterface(expr)
+}
+
+// writeOpAssign writes the compound assignment or increment of lhs as the
+// assignment `lhs = bin`, where bin reads lhs, see assignOpExpr and
+// incDecExpr. The operands of the index expressions and pointer indirections
+// of lhs that may have side effects are held in temporaries, so that they are
+// evaluated once, like in Go:
+//
+//	{
+//		const _tmp0 = next()
+//		buf![_tmp0] = ((buf![_tmp0] + 10) & 0xff)
+//	}
+//
+// In the header of a for loop, the block is written as an immediately
+// invoked arrow function.
+func (c *GoToTSCompiler) writeOpAssign(lhs ast.Expr, bin *ast.BinaryExpr) error {
+	var temps []*ast.Ident
+	var operands []ast.Expr
+	restore := c.hoistOperands(lhs, &temps, &operands)
+	defer restore()
+	if len(temps) == 0 {
+		return c.writeAssignmentCore([]ast.Expr{lhs}, []ast.Expr{bin}, token.ASSIGN, false)
+	}
+
+	if c.forHeader {
+		if c.canAwait() {
+			c.tsw.WriteLiterally("await (async () => ")
+		} else {
+			c.tsw.WriteLiterally("(() => ")
+		}
+	}
+	c.tsw.WriteLine("{")
+	c.tsw.Indent(1)
+	for i, temp := range temps {
+		c.tsw.WriteLiterallyf("const %s = ", temp.Name)
+		if err := c.WriteValueExpr(operands[i]); err != nil {
+			return err
+		}
+		c.tsw.WriteLine("")
+	}
+	if err := c.writeAssignmentCore([]ast.Expr{lhs}, []ast.Expr{bin}, token.ASSIGN, false); err != nil {
+		return err
+	}
+	c.tsw.WriteLine("")
+	c.tsw.Indent(-1)
+	c.tsw.WriteLiterally("}")
+	if c.forHeader {
+		c.tsw.WriteLiterally(")()")
+	}
+	return nil
+}
+
+// hoistOperands replaces the operands of the index expressions and pointer
+// indirections of the assignable expression lhs that may have side effects
+// by temporaries, appending the temporaries and the replaced operands to
+// temps and operands. The returned function puts the operands back.
+func (c *GoToTSCompiler) hoistOperands(lhs ast.Expr, temps *[]*ast.Ident, operands *[]ast.Expr) func() {
+	var restores []func()
+	hoist := func(operand *ast.Expr) {
+		if c.isPureExpr(*operand) {
+			return
+		}
+		orig := *operand
+		temp := ast.NewIdent(fmt.Sprintf("_tmp%d", len(*temps)))
+		temp.NamePos = orig.Pos()
+		c.pkg.TypesInfo.Uses[temp] = types.NewVar(orig.Pos(), c.pkg.Types, temp.Name, c.pkg.TypesInfo.TypeOf(orig))
+		c.pkg.TypesInfo.Types[temp] = c.pkg.TypesInfo.Types[orig]
+		*temps = append(*temps, temp)
+		*operands = append(*operands, orig)
+		*operand = temp
+		restores = append(restores, func() {
+			*operand = orig
+			delete(c.pkg.TypesInfo.Uses, temp)
+			delete(c.pkg.TypesInfo.Types, temp)
+		})
+	}
+
+	var visit func(expr ast.Expr)
+	visit = func(expr ast.Expr) {
+		switch e := expr.(type) {
+		case *ast.ParenExpr:
+			visit(e.X)
+		case *ast.IndexExpr:
+			if c.isPureExpr(e.X) {
+				visit(e.X)
+			} else {
+				hoist(&e.X)
+			}
+			hoist(&e.Index)
+		case *ast.StarExpr:
+			hoist(&e.X)
+		case *ast.SelectorExpr:
+			if c.pkg.TypesInfo.Selections[e] == nil {
+				// Qualified identifier
+				return
+			}
+			if c.isPureExpr(e.X) {
+				visit(e.X)
+			} else {
+				hoist(&e.X)
+			}
+		}
+	}
+	visit(lhs)
+
+	return func() {
+		for i := len(restores) - 1; i >= 0; i-- {
+			restores[i]()
+		}
+	}
+}
+
+// isPureExpr reports whether evaluating expr has no side effects, so that it
+// can be evaluated more than once, see hoistOperands.
+func (c *GoToTSCompiler) isPureExpr(expr ast.Expr) bool {
+	if tv, ok := c.pkg.TypesInfo.Types[expr]; ok && tv.Value != nil {
+		return true
+	}
+	switch e := expr.(type) {
+	case *ast.Ident, *ast.BasicLit:
+		return true
+	case *ast.ParenExpr:
+		return c.isPureExpr(e.X)
+	case *ast.SelectorExpr:
+		return c.pkg.TypesInfo.Selections[e] == nil || c.isPureExpr(e.X)
+	case *ast.StarExpr:
+		return c.isPureExpr(e.X)
+	case *ast.UnaryExpr:
+		return e.Op != token.ARROW && c.isPureExpr(e.X)
+	case *ast.BinaryExpr:
+		return c.isPureExpr(e.X) && c.isPureExpr(e.Y)
+	case *ast.IndexExpr:
+		return c.isPureExpr(e.X) && c.isPureExpr(e.Index)
+	case *ast.CallExpr:
+		// Conversions
+		if tv, ok := c.pkg.TypesInfo.Types[e.Fun]; !ok || !tv.IsType() || len(e.Args) != 1 {
+			return false
+		}
+		return c.isPureExpr(e.Args[0])
+	}
+	return false
+}
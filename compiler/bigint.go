@@ -0,0 +1,344 @@
+package compiler
+
+import (
+	"fmt"
+	"go/ast"
+	"go/constant"
+	"go/token"
+	"go/types"
+	"path"
+	"strconv"
+
+	gs "github.com/aperturerobotics/goscript"
+)
+
+// isBigIntType checks if values of a Go type are represented as bigint.
+// This is the case for int64, uint64 and the types derived from them when
+// Config.BigInt64 is enabled. Named types declared in handwritten packages,
+// such as time.Duration, keep the number representation of their
+// implementation.
+func (c *GoToTSCompiler) isBigIntType(t types.Type) bool {
+	if t == nil || c.config == nil || !c.config.BigInt64 {
+		return false
+	}
+	t = types.Unalias(t)
+	if named, ok := t.(*types.Named); ok {
+		if pkg := named.Obj().Pkg(); pkg != nil && isHandwrittenPackage(pkg.Path()) {
+			return false
+		}
+	}
+	basic, ok := t.Underlying().(*types.Basic)
+	return ok && (basic.Kind() == types.Int64 || basic.Kind() == types.Uint64)
+}
+
+// isHandwrittenPackage checks if a package is implemented by a handwritten
+// TypeScript package in gs/ instead of being compiled from Go.
+func isHandwrittenPackage(pkgPath string) bool {
+	_, err := gs.GsOverrides.ReadDir(path.Join("gs", pkgPath))
+	return err == nil
+}
+
+// bigIntWrap returns the call that wraps a bigint to the width of the given
+// 64-bit integer type, like Go's two's complement overflow.
+func bigIntWrap(t types.Type) string {
+	if basic, ok := t.Underlying().(*types.Basic); ok && basic.Info()&types.IsUnsigned != 0 {
+		return "BigInt.asUintN(64, "
+	}
+	return "BigInt.asIntN(64, "
+}
+
+// writeBigIntConstant writes a constant expression of a bigint type as a
+// bigint literal, such as `1 << 40` folded to `1099511627776n`, including
+// untyped constants used as 64-bit integers. It reports whether the
+// expression was written.
+func (c *GoToTSCompiler) writeBigIntConstant(expr ast.Expr) bool {
+	tv, ok := c.pkg.TypesInfo.Types[expr]
+	if !ok || tv.Value == nil || !c.isBigIntType(tv.Type) {
+		return false
+	}
+	val := constant.ToInt(tv.Value)
+	if val.Kind() != constant.Int {
+		return false
+	}
+	c.tsw.WriteLiterallyf("%sn", val.ExactString())
+	return true
+}
+
+// writeNumberConstant writes a numeric constant as a number literal.
+func (c *GoToTSCompiler) writeNumberConstant(val constant.Value) {
+	if val.Kind() == constant.Int {
+		c.tsw.WriteLiterally(val.ExactString())
+		return
+	}
+	f, _ := constant.Float64Val(val)
+	c.tsw.WriteLiterally(strconv.FormatFloat(f, 'g', -1, 64))
+}
+
+// writeBigIntOperand writes an operand of a bigint expression. Integer
+// literals synthesized by the compiler, which have no type information, are
+// written as bigint literals.
+func (c *GoToTSCompiler) writeBigIntOperand(expr ast.Expr) error {
+	if lit, ok := expr.(*ast.BasicLit); ok && lit.Kind == token.INT {
+		if _, known := c.pkg.TypesInfo.Types[expr]; !known {
+			c.tsw.WriteLiterallyf("%sn", lit.Value)
+			return nil
+		}
+	}
+	return c.WriteValueExpr(expr)
+}
+
+// writeBigIntShiftCount writes the count of a shift of a bigint, which must
+// be a bigint too.
+func (c *GoToTSCompiler) writeBigIntShiftCount(expr ast.Expr) error {
+	tv := c.pkg.TypesInfo.Types[expr]
+	if tv.Value != nil {
+		if val := constant.ToInt(tv.Value); val.Kind() == constant.Int {
+			c.tsw.WriteLiterallyf("%sn", val.ExactString())
+			return nil
+		}
+	}
+	if c.isBigIntType(tv.Type) {
+		return c.WriteValueExpr(expr)
+	}
+	c.tsw.WriteLiterally("BigInt(")
+	if err := c.WriteValueExpr(expr); err != nil {
+		return err
+	}
+	c.tsw.WriteLiterally(")")
+	return nil
+}
+
+// writeBigIntBinaryExpr writes an arithmetic, bitwise or shift expression on
+// bigint operands. Results that may overflow are wrapped to 64 bits; the
+// others stay in range by construction. Comparisons need no special handling,
+// so it reports whether the expression was written.
+//
+//	a / b  // BigInt.asIntN(64, $.bigIntDiv(a, b)): panics on zero
+//	a % b  // $.bigIntRem(a, b): panics on zero
+//	a / 2n // BigInt.asIntN(64, a / 2n)
+func (c *GoToTSCompiler) writeBigIntBinaryExpr(exp *ast.BinaryExpr) (bool, error) {
+	xType := c.pkg.TypesInfo.TypeOf(exp.X)
+	if !c.isBigIntType(xType) {
+		if (exp.Op == token.SHL || exp.Op == token.SHR) && c.isBigIntType(c.pkg.TypesInfo.TypeOf(exp.Y)) {
+			// A number shifted by a bigint count
+			c.tsw.WriteLiterally("(")
+			if err := c.WriteValueExpr(exp.X); err != nil {
+				return true, err
+			}
+			c.tsw.WriteLiterallyf(" %s Number(", exp.Op.String())
+			if err := c.WriteValueExpr(exp.Y); err != nil {
+				return true, err
+			}
+			c.tsw.WriteLiterally("))")
+			return true, nil
+		}
+		return false, nil
+	}
+
+	var prefix, op, suffix string
+	switch exp.Op {
+	case token.ADD, token.SUB, token.MUL, token.QUO, token.SHL:
+		prefix, op, suffix = bigIntWrap(xType), " "+exp.Op.String()+" ", ")"
+	case token.REM, token.AND, token.OR, token.XOR, token.SHR:
+		prefix, op, suffix = "(", " "+exp.Op.String()+" ", ")"
+	case token.AND_NOT:
+		prefix, op, suffix = "(", " & ~", ")"
+	default:
+		return false, nil
+	}
+	// The bigint operators throw a RangeError when dividing by zero
+	if (exp.Op == token.QUO || exp.Op == token.REM) && !c.isNonZeroConstant(exp.Y) {
+		if exp.Op == token.QUO {
+			prefix, suffix = prefix+"$.bigIntDiv(", "))"
+		} else {
+			prefix = "$.bigIntRem("
+		}
+		op = ", "
+	}
+
+	c.tsw.WriteLiterally(prefix)
+	if err := c.writeBigIntOperand(exp.X); err != nil {
+		return true, fmt.Errorf("failed to write bigint binary expression left operand: %w", err)
+	}
+	c.tsw.WriteLiterally(op)
+	if exp.Op == token.SHL || exp.Op == token.SHR {
+		if err := c.writeBigIntShiftCount(exp.Y); err != nil {
+			return true, fmt.Errorf("failed to write bigint shift count: %w", err)
+		}
+	} else {
+		if exp.Op == token.AND_NOT {
+			c.tsw.WriteLiterally("(")
+		}
+		if err := c.writeBigIntOperand(exp.Y); err != nil {
+			return true, fmt.Errorf("failed to write bigint binary expression right operand: %w", err)
+		}
+		if exp.Op == token.AND_NOT {
+			c.tsw.WriteLiterally(")")
+		}
+	}
+	c.tsw.WriteLiterally(suffix)
+	return true, nil
+}
+
+// writeBigIntUnaryExpr writes a negation or bitwise complement of a bigint,
+// wrapping the result to 64 bits. It reports whether the expression was written.
+func (c *GoToTSCompiler) writeBigIntUnaryExpr(exp *ast.UnaryExpr) (bool, error) {
+	typ := c.pkg.TypesInfo.TypeOf(exp.X)
+	if !c.isBigIntType(typ) || (exp.Op != token.SUB && exp.Op != token.XOR) {
+		return false, nil
+	}
+	c.tsw.WriteLiterally(bigIntWrap(typ))
+	if exp.Op == token.SUB {
+		c.tsw.WriteLiterally("-")
+	} else {
+		c.tsw.WriteLiterally("~")
+	}
+	c.tsw.WriteLiterally("(")
+	if err := c.WriteValueExpr(exp.X); err != nil {
+		return true, fmt.Errorf("failed to write bigint unary expression operand: %w", err)
+	}
+	c.tsw.WriteLiterally("))")
+	return true, nil
+}
+
+// writeBigIntConversion writes a conversion between a bigint type and another
+// numeric type, or between signed and unsigned bigint types. It reports
+// whether the conversion was written.
+//
+//	int64(x)   // $.int64(x): truncates floats and wraps to 64 bits
+//	uint64(x)  // $.uint64(x)
+//	int32(x)   // Number(BigInt.asIntN(32, x)) for a bigint x
+//	float64(x) // Number(x) for a bigint x
+func (c *GoToTSCompiler) writeBigIntConversion(exp *ast.CallExpr) (bool, error) {
+	if len(exp.Args) != 1 || !c.pkg.TypesInfo.Types[exp.Fun].IsType() {
+		return false, nil
+	}
+	target := c.pkg.TypesInfo.TypeOf(exp.Fun)
+	source := c.pkg.TypesInfo.TypeOf(exp.Args[0])
+	targetBig, sourceBig := c.isBigIntType(target), c.isBigIntType(source)
+	if !targetBig && !sourceBig {
+		return false, nil
+	}
+	targetBasic, ok := target.Underlying().(*types.Basic)
+	if !ok || targetBasic.Info()&types.IsNumeric == 0 {
+		return false, nil
+	}
+
+	// Constant conversions are folded into a literal of the target representation
+	if tv := c.pkg.TypesInfo.Types[exp]; tv.Value != nil && !targetBig {
+		c.writeNumberConstant(tv.Value)
+		return true, nil
+	}
+
+	arg := exp.Args[0]
+	switch {
+	case targetBig && sourceBig:
+		if bigIntWrap(target) == bigIntWrap(source) {
+			return true, c.WriteValueExpr(arg)
+		}
+		c.tsw.WriteLiterally(bigIntWrap(target))
+	case targetBig:
+		if targetBasic.Info()&types.IsUnsigned != 0 {
+			c.tsw.WriteLiterally("$.uint64(")
+		} else {
+			c.tsw.WriteLiterally("$.int64(")
+		}
+	default:
+		bits := 0
+		switch targetBasic.Kind() {
+		case types.Int8, types.Uint8:
+			bits = 8
+		case types.Int16, types.Uint16:
+			bits = 16
+		case types.Int32, types.Uint32:
+			bits = 32
+		}
+		if bits != 0 {
+			if targetBasic.Info()&types.IsUnsigned != 0 {
+				c.tsw.WriteLiterallyf("Number(BigInt.asUintN(%d, ", bits)
+			} else {
+				c.tsw.WriteLiterallyf("Number(BigInt.asIntN(%d, ", bits)
+			}
+			if err := c.WriteValueExpr(arg); err != nil {
+				return true, err
+			}
+			c.tsw.WriteLiterally("))")
+			return true, nil
+		}
+		c.tsw.WriteLiterally("Number(")
+	}
+	if err := c.WriteValueExpr(arg); err != nil {
+		return true, fmt.Errorf("failed to write argument for bigint conversion: %w", err)
+	}
+	c.tsw.WriteLiterally(")")
+	return true, nil
+}
+
+// writeIndexValue writes an index or length operand, which is always a
+// number in TypeScript.
+func (c *GoToTSCompiler) writeIndexValue(expr ast.Expr) error {
+	if !c.isBigIntType(c.pkg.TypesInfo.TypeOf(expr)) {
+		return c.WriteValueExpr(expr)
+	}
+	if tv := c.pkg.TypesInfo.Types[expr]; tv.Value != nil {
+		c.writeNumberConstant(tv.Value)
+		return nil
+	}
+	c.tsw.WriteLiterally("Number(")
+	if err := c.WriteValueExpr(expr); err != nil {
+		return err
+	}
+	c.tsw.WriteLiterally(")")
+	return nil
+}
+
+// calledFunc returns the function or method statically called by a call
+// expression, or nil if it is not known.
+func (c *GoToTSCompiler) calledFunc(exp *ast.CallExpr) *types.Func {
+	var obj types.Object
+	switch fun := ast.Unparen(exp.Fun).(type) {
+	case *ast.Ident:
+		obj = c.pkg.TypesInfo.Uses[fun]
+	case *ast.SelectorExpr:
+		if sel := c.pkg.TypesInfo.Selections[fun]; sel != nil {
+			obj = sel.Obj()
+		} else {
+			obj = c.pkg.TypesInfo.Uses[fun.Sel]
+		}
+	}
+	fn, _ := obj.(*types.Func)
+	return fn
+}
+
+// isNumberBoundaryCall checks if a call crosses into a handwritten package
+// that represents int64 and uint64 as number even when BigInt64 is enabled.
+// The bigint arguments of such calls are converted to number, and their
+// 64-bit integer results back to bigint. Handwritten packages declaring
+// "bigInt64" in their meta.json handle both representations themselves.
+func (c *GoToTSCompiler) isNumberBoundaryCall(exp *ast.CallExpr) bool {
+	if c.config == nil || !c.config.BigInt64 {
+		return false
+	}
+	fn := c.calledFunc(exp)
+	if fn == nil || fn.Pkg() == nil || fn.Pkg() == c.pkg.Types {
+		return false
+	}
+	pkgPath := fn.Pkg().Path()
+	return isHandwrittenPackage(pkgPath) && !c.analysis.IsBigInt64Package(pkgPath)
+}
+
+// bigIntResultConversion returns the runtime function converting the result
+// of a call to a number boundary package to bigint, or "" if none is needed.
+func (c *GoToTSCompiler) bigIntResultConversion(exp *ast.CallExpr) string {
+	if !c.isNumberBoundaryCall(exp) {
+		return ""
+	}
+	typ := c.pkg.TypesInfo.TypeOf(exp)
+	if _, isTuple := typ.(*types.Tuple); isTuple || !c.isBigIntType(typ) {
+		return ""
+	}
+	if bigIntWrap(typ) == "BigInt.asUintN(64, " {
+		return "uint64"
+	}
+	return "int64"
+}
@@ -0,0 +1,337 @@
+package compiler
+
+import (
+	"cmp"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"hash"
+	"io"
+	"maps"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+	"sync"
+
+	"golang.org/x/tools/go/packages"
+)
+
+// cacheManifestName is the name of the compile cache manifest in the output
+// path.
+const cacheManifestName = ".goscript-cache.json"
+
+// cacheManifestVersion is the version of the manifest format. Manifests with
+// another version are ignored.
+const cacheManifestVersion = 1
+
+// cacheManifest records the packages compiled to an output path.
+type cacheManifest struct {
+	Version  int                    `json:"version"`
+	Packages map[string]*cacheEntry `json:"packages"`
+}
+
+// cacheEntry records the compilation of a package.
+type cacheEntry struct {
+	// Key identifies the sources of the package and its dependencies, the
+	// compiler and its configuration.
+	Key string `json:"key"`
+	// Fingerprint identifies the async functions of all packages compiled
+	// along with the package. Their async status affects how calls through
+	// interfaces are compiled.
+	Fingerprint string `json:"fingerprint"`
+	// Outputs lists the files written, relative to the package output path.
+	Outputs []string `json:"outputs"`
+	// Methods holds the async status of the functions and methods declared
+	// in the package.
+	Methods []cachedMethod `json:"methods,omitempty"`
+}
+
+// cachedMethod is the async status of a function or method.
+type cachedMethod struct {
+	// Receiver is the receiver type name, empty for functions.
+	Receiver string `json:"receiver,omitempty"`
+	Name     string `json:"name"`
+	Async    bool   `json:"async,omitempty"`
+}
+
+// buildCache skips compiling packages that did not change since they were
+// compiled to the output path. A nil buildCache compiles every package.
+type buildCache struct {
+	outputPath string
+	manifest   *cacheManifest
+	// base is the hash of the compiler and its configuration.
+	base []byte
+	// keys caches the keys of the packages by ID.
+	keys map[string]string
+}
+
+// compilerHash returns the hash of the running compiler executable, which
+// includes the handwritten packages. It is empty if it can not be read.
+var compilerHash = sync.OnceValue(func() string {
+	exe, err := os.Executable()
+	if err != nil {
+		return ""
+	}
+	f, err := os.Open(exe)
+	if err != nil {
+		return ""
+	}
+	defer f.Close()
+	h := sha256.New()
+	if _, err := io.Copy(h, f); err != nil {
+		return ""
+	}
+	return hex.EncodeToString(h.Sum(nil))
+})
+
+// openCache reads the cache manifest in the output path. It returns nil if the
+// cache is disabled or the compiler can not be identified.
+func (c *Compiler) openCache() *buildCache {
+	if c.config.DisableCache {
+		return nil
+	}
+	compiler := compilerHash()
+	if compiler == "" {
+		return nil
+	}
+
+	h := sha256.New()
+	fmt.Fprintf(h, "%s\n%q\n%v %v %v %v %v\n",
+		compiler,
+		c.config.BuildFlags,
+		c.config.AllDependencies,
+		c.config.DisableEmitBuiltin,
+		c.config.BigInt64,
+		c.config.SourceLocations,
+		c.config.YieldLoops,
+	)
+	b := &buildCache{
+		outputPath: c.config.OutputPath,
+		base:       h.Sum(nil),
+		keys:       make(map[string]string),
+	}
+
+	manifest := &cacheManifest{}
+	data, err := os.ReadFile(filepath.Join(c.config.OutputPath, cacheManifestName))
+	if err == nil {
+		if err := json.Unmarshal(data, manifest); err != nil {
+			c.le.WithError(err).Warn("ignoring invalid compile cache manifest")
+		}
+	}
+	if manifest.Version != cacheManifestVersion || manifest.Packages == nil {
+		manifest = &cacheManifest{
+			Version:  cacheManifestVersion,
+			Packages: make(map[string]*cacheEntry),
+		}
+	}
+	b.manifest = manifest
+	return b
+}
+
+// key returns the key of pkg, the hash of its sources, the keys of its
+// imports and the base hash.
+func (b *buildCache) key(pkg *packages.Package) string {
+	if key, ok := b.keys[pkg.ID]; ok {
+		return key
+	}
+
+	h := sha256.New()
+	h.Write(b.base)
+	fmt.Fprintf(h, "%s\n", pkg.PkgPath)
+	for _, fileName := range pkg.CompiledGoFiles {
+		hashFile(h, fileName)
+		// A .pb.ts file next to a .pb.go file replaces its output.
+		if pbGo, ok := strings.CutSuffix(fileName, ".pb.go"); ok {
+			hashFile(h, pbGo+".pb.ts")
+		}
+	}
+	for _, importPath := range slices.Sorted(maps.Keys(pkg.Imports)) {
+		fmt.Fprintf(h, "%s %s\n", importPath, b.key(pkg.Imports[importPath]))
+	}
+
+	key := hex.EncodeToString(h.Sum(nil))
+	b.keys[pkg.ID] = key
+	return key
+}
+
+// hashFile writes the name and the contents of a file to h, or a marker if it
+// does not exist.
+func hashFile(h hash.Hash, fileName string) {
+	fmt.Fprintf(h, "%s\n", filepath.Base(fileName))
+	f, err := os.Open(fileName)
+	if err != nil {
+		fmt.Fprintf(h, "!%v\n", os.IsNotExist(err))
+		return
+	}
+	defer f.Close()
+	fi, err := f.Stat()
+	if err == nil {
+		fmt.Fprintf(h, "%d\n", fi.Size())
+	}
+	_, _ = io.Copy(h, f)
+}
+
+// lookup returns the entry of pkg if it was compiled with the same key and
+// its outputs still exist.
+func (b *buildCache) lookup(pkg *packages.Package) *cacheEntry {
+	if b == nil {
+		return nil
+	}
+	entry := b.manifest.Packages[pkg.PkgPath]
+	if entry == nil || entry.Key != b.key(pkg) {
+		return nil
+	}
+	outputPath := ComputeModulePath(b.outputPath, pkg.PkgPath)
+	for _, output := range entry.Outputs {
+		if _, err := os.Stat(filepath.Join(outputPath, output)); err != nil {
+			return nil
+		}
+	}
+	return entry
+}
+
+// store records the compilation of a package.
+func (b *buildCache) store(pkg *packages.Package, pc *PackageCompiler) {
+	if b == nil {
+		return
+	}
+	b.manifest.Packages[pkg.PkgPath] = &cacheEntry{
+		Key:     b.key(pkg),
+		Outputs: pc.outputFiles,
+		Methods: pc.methods,
+	}
+}
+
+// save writes the manifest to the output path.
+func (b *buildCache) save() error {
+	if b == nil {
+		return nil
+	}
+	data, err := json.MarshalIndent(b.manifest, "", "\t")
+	if err != nil {
+		return err
+	}
+	if err := os.MkdirAll(b.outputPath, 0o755); err != nil {
+		return err
+	}
+	return writeFileIfChanged(filepath.Join(b.outputPath, cacheManifestName), append(data, '\n'))
+}
+
+// compileCached compiles the packages whose cache entries are missing or
+// stale, and records them in the cache.
+//
+// The async status of the functions of up to date packages is reused while
+// analyzing the other packages. If the async functions differ from those the
+// up to date packages were compiled with, all packages are compiled again, as
+// calls through interfaces may be compiled differently.
+func (c *Compiler) compileCached(
+	ctx context.Context,
+	cache *buildCache,
+	pkgs []*packages.Package,
+	allPackages map[string]*packages.Package,
+	result *CompilationResult,
+) error {
+	methods := make(map[string][]cachedMethod)
+	knownAsync := make(map[MethodKey]bool)
+	var cached, compiled []*packages.Package
+	for _, pkg := range pkgs {
+		entry := cache.lookup(pkg)
+		if entry == nil {
+			compiled = append(compiled, pkg)
+			continue
+		}
+		cached = append(cached, pkg)
+		methods[pkg.PkgPath] = entry.Methods
+		for _, m := range entry.Methods {
+			knownAsync[MethodKey{PackagePath: pkg.PkgPath, ReceiverType: m.Receiver, MethodName: m.Name}] = m.Async
+		}
+	}
+
+	compile := func(pkg *packages.Package, knownAsync map[MethodKey]bool) error {
+		pc, err := c.compilePackage(ctx, pkg, allPackages, knownAsync)
+		if err != nil {
+			return err
+		}
+		methods[pkg.PkgPath] = pc.methods
+		cache.store(pkg, pc)
+		return nil
+	}
+	for _, pkg := range compiled {
+		if err := compile(pkg, knownAsync); err != nil {
+			return err
+		}
+	}
+
+	if cache == nil {
+		result.CompiledPackages = appendPkgPaths(result.CompiledPackages, compiled)
+		return nil
+	}
+
+	fingerprint := asyncFingerprint(allPackages, methods)
+	stale := slices.ContainsFunc(cached, func(pkg *packages.Package) bool {
+		return cache.manifest.Packages[pkg.PkgPath].Fingerprint != fingerprint
+	})
+	if stale {
+		// Compile every package again without reusing analysis results.
+		c.le.Debug("async functions changed, compiling all packages")
+		compiled = pkgs
+		cached = nil
+		for _, pkg := range compiled {
+			if err := compile(pkg, nil); err != nil {
+				return err
+			}
+		}
+		fingerprint = asyncFingerprint(allPackages, methods)
+	}
+	for _, pkg := range compiled {
+		cache.manifest.Packages[pkg.PkgPath].Fingerprint = fingerprint
+	}
+	for _, pkg := range cached {
+		c.le.Debugf("%s is up to date", pkg.PkgPath)
+	}
+
+	result.CompiledPackages = appendPkgPaths(result.CompiledPackages, compiled)
+	result.CachedPackages = appendPkgPaths(result.CachedPackages, cached)
+	return nil
+}
+
+// packageMethods returns the async status of the functions and methods
+// declared in the package with the given path.
+func packageMethods(analysis *Analysis, pkgPath string) []cachedMethod {
+	var methods []cachedMethod
+	for key, async := range analysis.MethodAsyncStatus {
+		if key.PackagePath == pkgPath {
+			methods = append(methods, cachedMethod{Receiver: key.ReceiverType, Name: key.MethodName, Async: async})
+		}
+	}
+	slices.SortFunc(methods, func(a, b cachedMethod) int {
+		return cmp.Or(cmp.Compare(a.Receiver, b.Receiver), cmp.Compare(a.Name, b.Name))
+	})
+	return methods
+}
+
+// asyncFingerprint returns the hash of the loaded packages and the async
+// functions of the compiled packages.
+func asyncFingerprint(allPackages map[string]*packages.Package, methods map[string][]cachedMethod) string {
+	h := sha256.New()
+	for _, pkgPath := range slices.Sorted(maps.Keys(allPackages)) {
+		fmt.Fprintf(h, "%s\n", pkgPath)
+		for _, m := range methods[pkgPath] {
+			if m.Async {
+				fmt.Fprintf(h, "\t%s.%s\n", m.Receiver, m.Name)
+			}
+		}
+	}
+	return hex.EncodeToString(h.Sum(nil))
+}
+
+// appendPkgPaths appends the paths of pkgs to paths.
+func appendPkgPaths(paths []string, pkgs []*packages.Package) []string {
+	for _, pkg := range pkgs {
+		paths = append(paths, pkg.PkgPath)
+	}
+	return paths
+}
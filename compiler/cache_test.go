@@ -0,0 +1,101 @@
+package compiler
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+	"testing"
+
+	"github.com/sirupsen/logrus"
+)
+
+func TestCompileCache(t *testing.T) {
+	dir := t.TempDir()
+	files := map[string]string{
+		"go.mod": "module example.com/w\n\ngo 1.24\n",
+		"a/a.go": "package a\n\nimport \"example.com/w/b\"\n\nfunc A() int { return b.B() }\n",
+		"b/b.go": "package b\n\nvar ch = make(chan int, 1)\n\nfunc B() int { ch <- 1; return <-ch }\n",
+		"c/c.go": "package c\n\nfunc C() int { return 3 }\n",
+	}
+	for name, content := range files {
+		writeTestFile(t, filepath.Join(dir, name), content)
+	}
+
+	logger := logrus.New()
+	logger.SetLevel(logrus.WarnLevel)
+	outputDir := filepath.Join(dir, "output")
+	conf := &Config{
+		OutputPath:         outputDir,
+		Dir:                dir,
+		DisableEmitBuiltin: true,
+	}
+	compile := func() *CompilationResult {
+		t.Helper()
+		comp, err := NewCompiler(conf, logrus.NewEntry(logger), nil)
+		if err != nil {
+			t.Fatalf("failed to create compiler: %v", err)
+		}
+		result, err := comp.CompilePackages(context.Background(), "./...")
+		if err != nil {
+			t.Fatalf("compile failed: %v", err)
+		}
+		slices.Sort(result.CompiledPackages)
+		slices.Sort(result.CachedPackages)
+		return result
+	}
+	expect := func(result *CompilationResult, compiled, cached []string) {
+		t.Helper()
+		if !slices.Equal(result.CompiledPackages, compiled) {
+			t.Errorf("compiled %v, expected %v", result.CompiledPackages, compiled)
+		}
+		if !slices.Equal(result.CachedPackages, cached) {
+			t.Errorf("cached %v, expected %v", result.CachedPackages, cached)
+		}
+	}
+	readOutput := func(pkg string) string {
+		t.Helper()
+		data, err := os.ReadFile(filepath.Join(outputDir, "@goscript/example.com/w", pkg, pkg+".gs.ts"))
+		if err != nil {
+			t.Fatal(err)
+		}
+		return string(data)
+	}
+	all := []string{"example.com/w/a", "example.com/w/b", "example.com/w/c"}
+
+	expect(compile(), all, nil)
+	expect(compile(), nil, all)
+
+	// A changed package is compiled along with its importers. The async
+	// functions of the cached packages are reused.
+	writeTestFile(t, filepath.Join(dir, "a/a.go"), "package a\n\nimport \"example.com/w/b\"\n\nfunc A() int { return b.B() + 1 }\n")
+	expect(compile(), []string{"example.com/w/a"}, []string{"example.com/w/b", "example.com/w/c"})
+	if out := readOutput("a"); !strings.Contains(out, "await b.B()") {
+		t.Errorf("expected async call to b.B:\n%s", out)
+	}
+
+	writeTestFile(t, filepath.Join(dir, "b/b.go"), "package b\n\nfunc B() int { return 2 }\n")
+	// b is no longer async, so every package is compiled again.
+	expect(compile(), all, nil)
+	if out := readOutput("a"); strings.Contains(out, "await") {
+		t.Errorf("expected sync call to b.B:\n%s", out)
+	}
+	expect(compile(), nil, all)
+
+	// Missing outputs are written again.
+	if err := os.Remove(filepath.Join(outputDir, "@goscript/example.com/w/c/c.gs.ts")); err != nil {
+		t.Fatal(err)
+	}
+	expect(compile(), []string{"example.com/w/c"}, []string{"example.com/w/a", "example.com/w/b"})
+
+	// Changing the configuration invalidates the cache.
+	conf.BigInt64 = true
+	expect(compile(), all, nil)
+	conf.SourceLocations = true
+	expect(compile(), all, nil)
+	conf.YieldLoops = true
+	expect(compile(), all, nil)
+	conf.DisableCache = true
+	expect(compile(), all, nil)
+}
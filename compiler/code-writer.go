@@ -1,6 +1,7 @@
 package compiler
 
 import (
+	"bytes"
 	"fmt"
 	"io"
 	"strings"
@@ -12,6 +13,8 @@ type TSCodeWriter struct {
 	indentLevel        int
 	sectionWrittenFlag bool
 	lineWritten        bool
+	// lines counts the lines written.
+	lines int
 }
 
 // NewTSCodeWriter builds a new TypeScript code writer.
@@ -19,12 +22,23 @@ func NewTSCodeWriter(w io.Writer) *TSCodeWriter {
 	return &TSCodeWriter{w: w}
 }
 
+// write writes b to the output, counting the lines.
+func (w *TSCodeWriter) write(b []byte) {
+	w.lines += bytes.Count(b, []byte{'\n'})
+	w.w.Write(b) //nolint:errcheck
+}
+
+// Line returns the number of the line being written, starting at 1.
+func (w *TSCodeWriter) Line() int {
+	return w.lines + 1
+}
+
 // WriteLinePreamble writes the indentation.
 func (w *TSCodeWriter) WriteLinePreamble() {
 	w.sectionWrittenFlag = true
 	w.lineWritten = false
 	for range w.indentLevel {
-		w.w.Write([]byte{byte('\t')}) //nolint:errcheck
+		w.write([]byte{byte('\t')})
 	}
 }
 
@@ -33,8 +47,8 @@ func (w *TSCodeWriter) WriteLine(line string) {
 	if line != "" && w.lineWritten {
 		w.WriteLinePreamble()
 	}
-	w.w.Write([]byte(line))       //nolint:errcheck
-	w.w.Write([]byte{byte('\n')}) //nolint:errcheck
+	w.write([]byte(line))
+	w.write([]byte{byte('\n')})
 	w.lineWritten = true
 }
 
@@ -76,9 +90,9 @@ func (w *TSCodeWriter) WriteCommentLinef(format string, args ...any) {
 
 // WriteCommentInline write a comment within /* */.
 func (w *TSCodeWriter) WriteCommentInline(commentText string) {
-	w.w.Write([]byte("/* "))       //nolint:errcheck
-	w.w.Write([]byte(commentText)) //nolint:errcheck
-	w.w.Write([]byte(" */"))       //nolint:errcheck
+	w.write([]byte("/* "))
+	w.write([]byte(commentText))
+	w.write([]byte(" */"))
 }
 
 // WriteCommentInlinef writes a formatted comment within /* */.
@@ -92,7 +106,7 @@ func (w *TSCodeWriter) WriteLiterally(literal string) {
 	if w.lineWritten {
 		w.WriteLinePreamble()
 	}
-	w.w.Write([]byte(literal)) //nolint:errcheck
+	w.write([]byte(literal))
 }
 
 // WriteLiterallyf writes something to the output with formatting.
@@ -103,7 +117,7 @@ func (w *TSCodeWriter) WriteLiterallyf(literal string, args ...any) {
 	}
 
 	l := fmt.Sprintf(literal, args...)
-	w.w.Write([]byte(l)) //nolint:errcheck
+	w.write([]byte(l))
 }
 
 // WriteSectionTail writes the end of a section.
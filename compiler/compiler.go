@@ -1,6 +1,7 @@
 package compiler
 
 import (
+	"bytes"
 	"context"
 	"crypto/sha256"
 	"encoding/hex"
@@ -11,6 +12,7 @@ import (
 	"go/token"
 	"go/types"
 	"os"
+	"path"
 	"path/filepath"
 	"slices"
 	"sort"
@@ -62,6 +64,7 @@ func NewCompiler(conf *Config, le *logrus.Entry, opts *packages.Config) (*Compil
 	// NeedSyntax adds Syntax.
 	// NeedTypesInfo adds TypesInfo.
 	// NeedTypesSizes adds TypesSizes.
+	// NeedModule adds Module.
 	// TODO: disable these if not needed
 	opts.Mode |= packages.NeedName |
 		packages.NeedFiles |
@@ -72,7 +75,8 @@ func NewCompiler(conf *Config, le *logrus.Entry, opts *packages.Config) (*Compil
 		packages.NeedTypes |
 		packages.NeedSyntax |
 		packages.NeedTypesInfo |
-		packages.NeedTypesSizes
+		packages.NeedTypesSizes |
+		packages.NeedModule
 
 	return &Compiler{
 		config: *conf,
@@ -89,6 +93,8 @@ type CompilationResult struct {
 	CopiedPackages []string
 	// OriginalPackages contains the package paths that were explicitly requested for compilation
 	OriginalPackages []string
+	// CachedPackages contains the package paths of all packages that were not compiled because their outputs were up to date
+	CachedPackages []string
 }
 
 // CompilePackages loads Go packages based on the provided patterns and
@@ -100,6 +106,16 @@ type CompilationResult struct {
 // of the requested packages, including standard library dependencies.
 // Returns a CompilationResult with information about what was compiled.
 func (c *Compiler) CompilePackages(ctx context.Context, patterns ...string) (*CompilationResult, error) {
+	result, _, err := c.compilePackages(ctx, nil, patterns...)
+	return result, err
+}
+
+// compilePackages implements CompilePackages. If only is not nil, just the
+// packages it contains are compiled to TypeScript, handwritten packages are
+// still copied. It also returns the packages matched by the patterns, with
+// their imports, so callers can inspect the loaded package graph. They are
+// returned even if compiling fails, unless loading them failed.
+func (c *Compiler) compilePackages(ctx context.Context, only map[string]bool, patterns ...string) (*CompilationResult, []*packages.Package, error) {
 	opts := c.opts
 	opts.Context = ctx
 
@@ -107,9 +123,11 @@ func (c *Compiler) CompilePackages(ctx context.Context, patterns ...string) (*Co
 	opts.Mode |= packages.NeedImports
 	pkgs, err := packages.Load(&opts, patterns...)
 	if err != nil {
-		return nil, fmt.Errorf("failed to load packages: %w", err)
+		return nil, nil, fmt.Errorf("failed to load packages: %w", err)
 	}
 
+	rootPkgs := pkgs
+
 	// build a list of packages that patterns matched
 	patternPkgPaths := make([]string, 0, len(pkgs))
 	for _, pkg := range pkgs {
@@ -193,7 +211,7 @@ func (c *Compiler) CompilePackages(ctx context.Context, patterns ...string) (*Co
 
 			reloadedPkgs, err := packages.Load(&fullOpts, pkgPaths...)
 			if err != nil {
-				return nil, fmt.Errorf("failed to reload packages with complete type information: %w", err)
+				return nil, rootPkgs, fmt.Errorf("failed to reload packages with complete type information: %w", err)
 			}
 
 			// Replace the minimal packages with the fully loaded ones
@@ -210,7 +228,7 @@ func (c *Compiler) CompilePackages(ctx context.Context, patterns ...string) (*Co
 		builtinPath := "gs/builtin"
 		outputPath := ComputeModulePath(c.config.OutputPath, "builtin")
 		if err := c.copyEmbeddedPackage(builtinPath, outputPath); err != nil {
-			return nil, fmt.Errorf("failed to copy builtin package to output directory: %w", err)
+			return nil, rootPkgs, fmt.Errorf("failed to copy builtin package to output directory: %w", err)
 		}
 		result.CopiedPackages = append(result.CopiedPackages, "builtin")
 	}
@@ -224,7 +242,8 @@ func (c *Compiler) CompilePackages(ctx context.Context, patterns ...string) (*Co
 		allPackages[pkg.PkgPath] = pkg
 	}
 
-	// Compile all packages
+	// Collect the packages to compile
+	var toCompile []*packages.Package
 	for _, pkg := range pkgs {
 		// Check if the package has a handwritten equivalent
 		// If the package was explicitly requested, skip this logic
@@ -232,7 +251,7 @@ func (c *Compiler) CompilePackages(ctx context.Context, patterns ...string) (*Co
 			gsSourcePath := "gs/" + pkg.PkgPath
 			_, gsErr := gs.GsOverrides.ReadDir(gsSourcePath)
 			if gsErr != nil && !os.IsNotExist(gsErr) {
-				return nil, gsErr
+				return nil, rootPkgs, gsErr
 			}
 			if gsErr == nil {
 				if c.config.DisableEmitBuiltin {
@@ -242,34 +261,58 @@ func (c *Compiler) CompilePackages(ctx context.Context, patterns ...string) (*Co
 				} else {
 					// If DisableEmitBuiltin is false, we need to copy the handwritten package and its dependencies
 					if err := c.copyGsPackageWithDependencies(pkg.PkgPath, processedGsPackages, result); err != nil {
-						return nil, fmt.Errorf("failed to copy handwritten package %s with dependencies: %w", pkg.PkgPath, err)
+						return nil, rootPkgs, fmt.Errorf("failed to copy handwritten package %s with dependencies: %w", pkg.PkgPath, err)
 					}
 					continue
 				}
 			}
 		}
 
+		// Skip packages that were not selected for compilation
+		if only != nil && !only[pkg.PkgPath] {
+			continue
+		}
+
 		// Skip packages that failed to load
 		if len(pkg.Errors) > 0 {
 			c.le.WithError(pkg.Errors[0]).Warnf("Skipping package %s due to errors", pkg.PkgPath)
 			continue
 		}
 
-		pkgCompiler, err := NewPackageCompiler(c.le, &c.config, pkg, allPackages)
-		if err != nil {
-			return nil, fmt.Errorf("failed to create package compiler for %s: %w", pkg.PkgPath, err)
-		}
+		toCompile = append(toCompile, pkg)
+	}
 
-		if err := pkgCompiler.Compile(ctx); err != nil {
-			return nil, fmt.Errorf("failed to compile package %s: %w", pkg.PkgPath, err)
-		}
+	cache := c.openCache()
+	if err := c.compileCached(ctx, cache, toCompile, allPackages, result); err != nil {
+		return nil, rootPkgs, err
+	}
+	if err := cache.save(); err != nil {
+		return nil, rootPkgs, fmt.Errorf("failed to write compile cache: %w", err)
+	}
+
+	return result, rootPkgs, nil
+}
 
-		c.le.Info(pkg.PkgPath)
+// compilePackage compiles pkg to TypeScript. The async status of functions in
+// knownAsync is not analyzed again.
+func (c *Compiler) compilePackage(
+	ctx context.Context,
+	pkg *packages.Package,
+	allPackages map[string]*packages.Package,
+	knownAsync map[MethodKey]bool,
+) (*PackageCompiler, error) {
+	pkgCompiler, err := NewPackageCompiler(c.le, &c.config, pkg, allPackages)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create package compiler for %s: %w", pkg.PkgPath, err)
+	}
+	pkgCompiler.knownAsync = knownAsync
 
-		result.CompiledPackages = append(result.CompiledPackages, pkg.PkgPath)
+	if err := pkgCompiler.Compile(ctx); err != nil {
+		return nil, fmt.Errorf("failed to compile package %s: %w", pkg.PkgPath, err)
 	}
 
-	return result, nil
+	c.le.Info(pkg.PkgPath)
+	return pkgCompiler, nil
 }
 
 // PackageCompiler is responsible for compiling an entire Go package into
@@ -281,6 +324,15 @@ type PackageCompiler struct {
 	outputPath   string
 	pkg          *packages.Package
 	allPackages  map[string]*packages.Package
+
+	// knownAsync holds the async status of functions in dependencies that
+	// were determined before, they are not analyzed again.
+	knownAsync map[MethodKey]bool
+	// outputFiles lists the files written by Compile, relative to outputPath.
+	outputFiles []string
+	// methods holds the async status of the functions and methods declared
+	// in the package, determined by Compile.
+	methods []cachedMethod
 }
 
 // NewPackageCompiler creates a new `PackageCompiler` for a given Go package.
@@ -328,7 +380,8 @@ func (c *PackageCompiler) Compile(ctx context.Context) error {
 	packageAnalysis := AnalyzePackageImports(c.pkg)
 
 	// Perform comprehensive package-level analysis for code generation
-	analysis := AnalyzePackageFiles(c.pkg, c.allPackages)
+	analysis := analyzePackageFiles(c.pkg, c.allPackages, c.knownAsync)
+	c.methods = packageMethods(analysis, c.pkg.PkgPath)
 
 	// Track all compiled files for later generating the index.ts
 	compiledFiles := make([]string, 0, len(c.pkg.CompiledGoFiles))
@@ -381,6 +434,12 @@ func (c *PackageCompiler) Compile(ctx context.Context) error {
 		return err
 	}
 
+	c.outputFiles = make([]string, 0, len(compiledFiles)+1)
+	for _, fileName := range compiledFiles {
+		c.outputFiles = append(c.outputFiles, fileName+".ts")
+	}
+	c.outputFiles = append(c.outputFiles, "index.ts")
+
 	return nil
 }
 
@@ -391,12 +450,8 @@ func (c *PackageCompiler) Compile(ctx context.Context) error {
 func (c *PackageCompiler) generateIndexFile(compiledFiles []string) error {
 	indexFilePath := filepath.Join(c.outputPath, "index.ts")
 
-	// Open the file for writing
-	indexFile, err := os.OpenFile(indexFilePath, os.O_RDWR|os.O_CREATE|os.O_TRUNC, 0o644)
-	if err != nil {
-		return err
-	}
-	defer indexFile.Close() //nolint:errcheck
+	// Build the file in memory, it is only written if it changed
+	indexFile := &bytes.Buffer{}
 
 	// Write selective re-exports for each compiled file
 	for _, fileName := range compiledFiles {
@@ -448,6 +503,10 @@ func (c *PackageCompiler) generateIndexFile(compiledFiles []string) error {
 									// Other type declarations (interfaces, type definitions, type aliases)
 									// become TypeScript types and must be exported with "export type"
 									typeSymbols = append(typeSymbols, sanitizeIdentifier(s.Name.Name))
+									// Methods of named non-struct types are functions declared with the type
+									if _, isInterface := s.Type.(*ast.InterfaceType); !isInterface && !s.Assign.IsValid() {
+										valueSymbols = append(valueSymbols, c.namedTypeMethodSymbols(s.Name.Name)...)
+									}
 								}
 							}
 						case *ast.ValueSpec:
@@ -494,7 +553,32 @@ func (c *PackageCompiler) generateIndexFile(compiledFiles []string) error {
 		}
 	}
 
-	return nil
+	return writeFileIfChanged(indexFilePath, indexFile.Bytes())
+}
+
+// namedTypeMethodSymbols returns the names of the functions implementing the
+// exported methods of a named non-struct type, as in "FileMode_IsDir".
+func (c *PackageCompiler) namedTypeMethodSymbols(typeName string) []string {
+	var symbols []string
+	for _, syntax := range c.pkg.Syntax {
+		for _, decl := range syntax.Decls {
+			funcDecl, ok := decl.(*ast.FuncDecl)
+			if !ok || funcDecl.Recv == nil || len(funcDecl.Recv.List) == 0 || !funcDecl.Name.IsExported() {
+				continue
+			}
+			recvType := funcDecl.Recv.List[0].Type
+			if starExpr, ok := recvType.(*ast.StarExpr); ok {
+				recvType = starExpr.X
+			}
+			if indexExpr, ok := recvType.(*ast.IndexExpr); ok {
+				recvType = indexExpr.X
+			}
+			if ident, ok := recvType.(*ast.Ident); ok && ident.Name == typeName {
+				symbols = append(symbols, typeName+"_"+funcDecl.Name.Name)
+			}
+		}
+	}
+	return symbols
 }
 
 // CompileFile handles the compilation of a single Go source file to TypeScript.
@@ -563,16 +647,12 @@ func (c *FileCompiler) Compile(ctx context.Context) error {
 		return err
 	}
 
-	of, err := os.OpenFile(outputFilePathAbs, os.O_RDWR|os.O_CREATE|os.O_TRUNC, 0o644)
-	if err != nil {
-		return err
-	}
-	defer of.Close() //nolint:errcheck
-
-	c.codeWriter = NewTSCodeWriter(of)
+	// Generate the file in memory, it is only written if it changed
+	var of bytes.Buffer
+	c.codeWriter = NewTSCodeWriter(&of)
 
 	// Pass analysis to compiler
-	goWriter := NewGoToTSCompiler(c.codeWriter, c.pkg, c.Analysis)
+	goWriter := NewGoToTSCompiler(c.codeWriter, c.pkg, c.compilerConfig, c.Analysis)
 
 	// Add import for the goscript runtime using namespace import and alias
 	c.codeWriter.WriteLinef("import * as $ from %q;", "@goscript/builtin/index.js")
@@ -670,11 +750,30 @@ func (c *FileCompiler) Compile(ctx context.Context) error {
 
 	c.codeWriter.WriteLine("") // Add a newline after imports
 
+	// Make handwritten packages return bigint for 64-bit integers
+	if c.compilerConfig.BigInt64 {
+		c.codeWriter.WriteLine("$.setBigInt64Mode(true)")
+		c.codeWriter.WriteLine("")
+	}
+
+	// The table of source locations is registered before the declarations,
+	// once they are written, so that it covers package initialization.
+	registerOffset, registerLine := of.Len(), c.codeWriter.Line()
+	if c.compilerConfig.SourceLocations {
+		goWriter.lines = newLineTable(c.pkg.Name, pkgPath)
+	}
+
 	if err := goWriter.WriteDecls(f.Decls); err != nil {
 		return fmt.Errorf("failed to write declarations: %w", err)
 	}
 
-	return nil
+	out := of.Bytes()
+	if goWriter.lines != nil {
+		register := goWriter.lines.registration(registerLine, 2) + "\n\n"
+		out = slices.Concat(out[:registerOffset], []byte(register), out[registerOffset:])
+	}
+
+	return writeFileIfChanged(outputFilePathAbs, out)
 }
 
 // GoToTSCompiler is the core component responsible for translating Go AST nodes
@@ -682,23 +781,61 @@ func (c *FileCompiler) Compile(ctx context.Context) error {
 // the generated TypeScript and relies on `Analysis` data to make informed
 // decisions about code generation (e.g., varRefing, async behavior).
 type GoToTSCompiler struct {
-	tsw *TSCodeWriter
-	pkg *packages.Package
+	tsw    *TSCodeWriter
+	pkg    *packages.Package
+	config *Config
 
 	analysis *Analysis
+
+	// lines records the Go source locations of the generated code, if
+	// Config.SourceLocations is enabled.
+	lines *lineTable
+
+	// bodies holds whether the function bodies being written can await,
+	// innermost last, see enterBody.
+	bodies []bool
+	// yieldBlock is the loop body to start with a yield point, see
+	// markLoopBody.
+	yieldBlock *ast.BlockStmt
+	// markedValue is the value converted to an interface being written by
+	// writeMarkedValue.
+	markedValue ast.Expr
+	// deferFrameCall is the deferred call being written that passes its
+	// defer frame, see writeDeferFrameArg.
+	deferFrameCall *ast.CallExpr
+	// forHeader is set while the init and post statements of a for loop are
+	// written, which are expressions in TypeScript.
+	forHeader bool
 }
 
 // It initializes the compiler with a `TSCodeWriter` for output,
-// Go package information (`packages.Package`), and pre-computed
-// analysis results (`Analysis`) to guide the translation process.
-func NewGoToTSCompiler(tsw *TSCodeWriter, pkg *packages.Package, analysis *Analysis) *GoToTSCompiler {
+// Go package information (`packages.Package`), the compiler configuration,
+// and pre-computed analysis results (`Analysis`) to guide the translation process.
+func NewGoToTSCompiler(tsw *TSCodeWriter, pkg *packages.Package, config *Config, analysis *Analysis) *GoToTSCompiler {
 	return &GoToTSCompiler{
 		tsw:      tsw,
 		pkg:      pkg,
+		config:   config,
 		analysis: analysis,
 	}
 }
 
+// sourceLocation returns the position pos as "file.go:line", with the base
+// name of the file, so that the location does not depend on the build
+// environment. The file is qualified by the package path if the source
+// locations are recorded, like the files of the line table.
+func (c *GoToTSCompiler) sourceLocation(pos token.Pos) string {
+	if !pos.IsValid() {
+		return ""
+	}
+	position := c.pkg.Fset.Position(pos)
+	file := filepath.Base(position.Filename)
+	if c.lines != nil {
+		file = path.Join(c.lines.pkgPath, file)
+	}
+	return fmt.Sprintf("%s:%d", file, position.Line)
+}
+
 // getDeterministicID generates a deterministic unique ID based on file position
 // This replaces the non-deterministic Pos() values to ensure reproducible builds
 func (c *GoToTSCompiler) getDeterministicID(pos token.Pos) string {
@@ -763,6 +900,16 @@ func (c *GoToTSCompiler) WriteIdent(exp *ast.Ident, accessVarRefedValue bool) {
 		obj = c.pkg.TypesInfo.Defs[exp]
 	}
 
+	// Constants used as 64-bit integers represented as bigint
+	if c.writeBigIntConstant(exp) {
+		return
+	}
+
+	// Constants used as complex numbers
+	if c.writeComplexConstant(exp) {
+		return
+	}
+
 	// Check if this identifier refers to a constant
 	if obj != nil {
 		if constObj, isConst := obj.(*types.Const); isConst {
@@ -843,10 +990,8 @@ func (c *GoToTSCompiler) WriteCaseClause(exp *ast.CaseClause) error {
 	// The body is written once, after all case labels for this clause.
 	// Indentation for the body starts here.
 	c.tsw.Indent(1)
-	for _, stmt := range exp.Body {
-		if err := c.WriteStmt(stmt); err != nil {
-			return fmt.Errorf("failed to write statement in case clause body: %w", err)
-		}
+	if err := c.writeGotoBlockStmts(exp, exp.Body); err != nil {
+		return fmt.Errorf("failed to write statement in case clause body: %w", err)
 	}
 	// Add break statement (Go's switch has implicit breaks, TS needs explicit break)
 	c.tsw.WriteLine("break")
@@ -937,7 +1082,11 @@ func (c *GoToTSCompiler) writeChannelReceiveWithOk(lhs []ast.Expr, unaryExpr *as
 
 	destructuringPattern := fmt.Sprintf("{ %s }", strings.Join(patternParts, ", "))
 
-	// Write the destructuring assignment/declaration
+	// Write the destructuring assignment/declaration.
+	// A destructuring assignment must be parenthesized to not parse as a block.
+	if keyword == "" {
+		c.tsw.WriteLiterally(";(")
+	}
 	c.tsw.WriteLiterally(keyword) // "const " or ""
 	c.tsw.WriteLiterally(destructuringPattern)
 	c.tsw.WriteLiterally(" = await $.chanRecvWithOk(")
@@ -945,6 +1094,9 @@ func (c *GoToTSCompiler) writeChannelReceiveWithOk(lhs []ast.Expr, unaryExpr *as
 		return fmt.Errorf("failed to write channel expression in receive: %w", err)
 	}
 	c.tsw.WriteLiterally(")")
+	if keyword == "" {
+		c.tsw.WriteLiterally(")")
+	}
 	c.tsw.WriteLine("")
 
 	return nil
@@ -1017,9 +1169,9 @@ func (c *GoToTSCompiler) writeConstantValue(constObj *types.Const) {
 	case constant.String:
 		// For string constants, write as a quoted string literal
 		// Use constant.StringVal to get the full string value without truncation,
-		// then manually add quotes since StringVal returns the unquoted string
+		// then quote it since StringVal returns the unquoted string
 		stringValue := constant.StringVal(val)
-		c.tsw.WriteLiterallyf("%q", stringValue) // %q adds proper quotes and escaping
+		c.tsw.WriteLiterally(quoteString(stringValue))
 	case constant.Bool:
 		// For boolean constants, write true/false
 		if constant.BoolVal(val) {
@@ -1028,9 +1180,8 @@ func (c *GoToTSCompiler) writeConstantValue(constObj *types.Const) {
 			c.tsw.WriteLiterally("false")
 		}
 	case constant.Complex:
-		// For complex constants, we need to handle them specially
-		// For now, write as a comment indicating unsupported
-		c.tsw.WriteLiterally("/* complex constant: " + val.String() + " */")
+		// For complex constants, write a $.Complex value
+		c.writeComplexValue(val)
 	default:
 		// For unknown constant types, write as a comment
 		c.tsw.WriteLiterally("/* unknown constant: " + val.String() + " */")
@@ -1081,13 +1232,6 @@ func (c *Compiler) copyEmbeddedPackage(embeddedPath string, outputPath string) e
 				continue
 			}
 
-			// Remove existing file if it exists (but preserve directories)
-			if stat, err := os.Stat(outputEntryPath); err == nil && !stat.IsDir() {
-				if err := os.Remove(outputEntryPath); err != nil {
-					return fmt.Errorf("failed to remove existing file %s: %w", outputEntryPath, err)
-				}
-			}
-
 			// Read the file content from the embedded FS
 			content, err := gs.GsOverrides.ReadFile(entryPath)
 			if err != nil {
@@ -1095,7 +1239,7 @@ func (c *Compiler) copyEmbeddedPackage(embeddedPath string, outputPath string) e
 			}
 
 			// Write the content to the output file
-			if err := os.WriteFile(outputEntryPath, content, 0o644); err != nil {
+			if err := writeFileIfChanged(outputEntryPath, content); err != nil {
 				return fmt.Errorf("failed to write file %s: %w", outputEntryPath, err)
 			}
 		}
@@ -0,0 +1,132 @@
+package compiler
+
+import (
+	"fmt"
+	"go/ast"
+	"go/constant"
+	"go/token"
+	"go/types"
+)
+
+// isComplexType checks if values of a Go type are complex numbers, which are
+// represented as an immutable $.Complex. This includes untyped complex
+// constants and the types derived from complex64 and complex128.
+func isComplexType(t types.Type) bool {
+	if t == nil {
+		return false
+	}
+	basic, ok := t.Underlying().(*types.Basic)
+	return ok && basic.Info()&types.IsComplex != 0
+}
+
+// complexOps maps the operators on complex numbers to the runtime functions
+// implementing them.
+var complexOps = map[token.Token]string{
+	token.ADD: "$.complexAdd(",
+	token.SUB: "$.complexSub(",
+	token.MUL: "$.complexMul(",
+	token.QUO: "$.complexDiv(",
+	token.EQL: "$.complexEqual(",
+	token.NEQ: "!$.complexEqual(",
+}
+
+// writeComplexValue writes a constant value as a complex number.
+func (c *GoToTSCompiler) writeComplexValue(val constant.Value) {
+	c.tsw.WriteLiterally("$.complex(")
+	c.writeNumberConstant(constant.Real(val))
+	c.tsw.WriteLiterally(", ")
+	c.writeNumberConstant(constant.Imag(val))
+	c.tsw.WriteLiterally(")")
+}
+
+// writeComplexConstant writes a constant expression of a complex type, such
+// as `1 + 2i` or an untyped constant used as a complex number, as a
+// $.Complex. It reports whether the expression was written.
+func (c *GoToTSCompiler) writeComplexConstant(expr ast.Expr) bool {
+	tv, ok := c.pkg.TypesInfo.Types[expr]
+	if !ok || tv.Value == nil || !isComplexType(tv.Type) {
+		return false
+	}
+	c.writeComplexValue(tv.Value)
+	return true
+}
+
+// writeComplexOperand writes an operand of a complex expression. Integer
+// literals synthesized by the compiler, which have no type information, are
+// written as complex numbers.
+func (c *GoToTSCompiler) writeComplexOperand(expr ast.Expr) error {
+	if lit, ok := expr.(*ast.BasicLit); ok && lit.Kind == token.INT {
+		if _, known := c.pkg.TypesInfo.Types[expr]; !known {
+			c.tsw.WriteLiterallyf("$.complex(%s, 0)", lit.Value)
+			return nil
+		}
+	}
+	return c.WriteValueExpr(expr)
+}
+
+// writeComplexBinaryExpr writes an arithmetic operation or comparison of
+// complex numbers as a call of the runtime function implementing it. It
+// reports whether the expression was written.
+//
+//	x * y   // $.complexMul(x, y)
+//	x != y  // !$.complexEqual(x, y)
+func (c *GoToTSCompiler) writeComplexBinaryExpr(exp *ast.BinaryExpr) (bool, error) {
+	fn, ok := complexOps[exp.Op]
+	if !ok || !isComplexType(c.pkg.TypesInfo.TypeOf(exp.X)) {
+		return false, nil
+	}
+	c.tsw.WriteLiterally(fn)
+	if err := c.writeComplexOperand(exp.X); err != nil {
+		return true, fmt.Errorf("failed to write complex binary expression left operand: %w", err)
+	}
+	c.tsw.WriteLiterally(", ")
+	if err := c.writeComplexOperand(exp.Y); err != nil {
+		return true, fmt.Errorf("failed to write complex binary expression right operand: %w", err)
+	}
+	c.tsw.WriteLiterally(")")
+	return true, nil
+}
+
+// writeComplexUnaryExpr writes the negation of a complex number as
+// $.complexNeg(x), and drops a unary plus. It reports whether the
+// expression was written.
+func (c *GoToTSCompiler) writeComplexUnaryExpr(exp *ast.UnaryExpr) (bool, error) {
+	if (exp.Op != token.SUB && exp.Op != token.ADD) || !isComplexType(c.pkg.TypesInfo.TypeOf(exp.X)) {
+		return false, nil
+	}
+	if exp.Op == token.ADD {
+		return true, c.WriteValueExpr(exp.X)
+	}
+	c.tsw.WriteLiterally("$.complexNeg(")
+	if err := c.WriteValueExpr(exp.X); err != nil {
+		return true, fmt.Errorf("failed to write complex unary expression operand: %w", err)
+	}
+	c.tsw.WriteLiterally(")")
+	return true, nil
+}
+
+// writeComplexConversion writes a conversion between complex types, which
+// share their representation. Constant conversions are written by
+// writeComplexConstant. It reports whether the conversion was written.
+func (c *GoToTSCompiler) writeComplexConversion(exp *ast.CallExpr) (bool, error) {
+	if len(exp.Args) != 1 || !c.pkg.TypesInfo.Types[exp.Fun].IsType() {
+		return false, nil
+	}
+	if !isComplexType(c.pkg.TypesInfo.TypeOf(exp.Fun)) {
+		return false, nil
+	}
+	if err := c.WriteValueExpr(exp.Args[0]); err != nil {
+		return true, fmt.Errorf("failed to write argument for complex conversion: %w", err)
+	}
+	return true, nil
+}
+
+// hasComplexArg checks if any argument of a call is a complex number.
+func (c *GoToTSCompiler) hasComplexArg(exp *ast.CallExpr) bool {
+	for _, arg := range exp.Args {
+		if isComplexType(c.pkg.TypesInfo.TypeOf(arg)) {
+			return true
+		}
+	}
+	return false
+}
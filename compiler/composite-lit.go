@@ -13,7 +13,8 @@ import (
 // TypeScript equivalent.
 //
 // It handles several types of composite literals:
-//   - Map literals (e.g., `map[K]V{k1: v1}`): Translated to `new Map([[k1_ts, v1_ts]])`.
+//   - Map literals (e.g., `map[K]V{k1: v1}`): Translated to `new Map([[k1_ts, v1_ts]])`,
+//     or `new $.HashMap(...)` for keys compared by value (see mapClass).
 //     Values are processed by `WriteVarRefedValue`.
 //   - Array/Slice literals (e.g., `[]T{e1, e2}`, `[N]T{idx: val}`):
 //   - For `[]byte{...}`, translated to `new Uint8Array([...])`.
@@ -37,9 +38,9 @@ func (c *GoToTSCompiler) WriteCompositeLit(exp *ast.CompositeLit) error {
 	litType := c.pkg.TypesInfo.TypeOf(exp)
 
 	if exp.Type != nil {
-		// Handle map literals: map[K]V{k1: v1, k2: v2}
-		if _, isMapType := exp.Type.(*ast.MapType); isMapType {
-			c.tsw.WriteLiterally("new Map([")
+		// Handle map literals: map[K]V{k1: v1, k2: v2}, including named map types
+		if mapType, isMapType := litType.Underlying().(*types.Map); isMapType {
+			c.tsw.WriteLiterallyf("new %s([", mapClass(mapType.Key()))
 
 			// Add each key-value pair as an entry
 			for i, elm := range exp.Elts {
@@ -66,14 +67,32 @@ func (c *GoToTSCompiler) WriteCompositeLit(exp *ast.CompositeLit) error {
 			return nil
 		}
 
-		// Handle array literals
-		if arrType, isArrayType := exp.Type.(*ast.ArrayType); isArrayType {
+		// Handle array literals, including named slice and array types whose
+		// element type is only known from the type information
+		arrType, isArrayType := exp.Type.(*ast.ArrayType)
+		var namedElemType types.Type
+		if !isArrayType {
+			switch t := litType.Underlying().(type) {
+			case *types.Slice:
+				namedElemType = t.Elem()
+			case *types.Array:
+				namedElemType = t.Elem()
+			}
+		}
+		if isArrayType || namedElemType != nil {
 			// Check if this is a slice of slices (multi-dimensional array)
 			isMultiDimensional := false
-			if _, ok := arrType.Elt.(*ast.ArrayType); ok {
-				// It's a slice of slices (multi-dimensional array)
-				isMultiDimensional = true
-				// We'll handle this with depth parameter to arrayToSlice
+			if isArrayType {
+				if _, ok := arrType.Elt.(*ast.ArrayType); ok {
+					// It's a slice of slices (multi-dimensional array)
+					isMultiDimensional = true
+					// We'll handle this with depth parameter to arrayToSlice
+				}
+			} else {
+				switch namedElemType.(type) {
+				case *types.Slice, *types.Array:
+					isMultiDimensional = true
+				}
 			}
 
 			// Check if it's a []byte literal
@@ -94,7 +113,11 @@ func (c *GoToTSCompiler) WriteCompositeLit(exp *ast.CompositeLit) error {
 				// write the type annotation
 				c.tsw.WriteLiterally("<")
 				// Write the element type using the existing function
-				c.WriteTypeExpr(arrType.Elt)
+				if isArrayType {
+					c.WriteTypeExpr(arrType.Elt)
+				} else {
+					c.WriteGoType(namedElemType, GoTypeContextGeneral)
+				}
 				c.tsw.WriteLiterally(">")
 			}
 
@@ -114,7 +137,7 @@ func (c *GoToTSCompiler) WriteCompositeLit(exp *ast.CompositeLit) error {
 					goElemType = st.Elem()
 				}
 			}
-			if arrType.Len != nil {
+			if isArrayType && arrType.Len != nil {
 				// Try to evaluate the length from the AST if not available from type info
 				if bl, ok := arrType.Len.(*ast.BasicLit); ok && bl.Kind == token.INT {
 					if _, err := fmt.Sscan(bl.Value, &arrayLen); err != nil {
@@ -129,7 +152,9 @@ func (c *GoToTSCompiler) WriteCompositeLit(exp *ast.CompositeLit) error {
 					}
 				}
 			}
-			elemType = arrType.Elt
+			if isArrayType {
+				elemType = arrType.Elt
+			}
 
 			// Map of index -> value
 			elements := make(map[int]ast.Expr)
@@ -338,9 +363,9 @@ func (c *GoToTSCompiler) WriteCompositeLit(exp *ast.CompositeLit) error {
 					}
 				}
 
-				// Handle the case where an anonymous struct has values without keys
+				// Handle the case where a struct has values without keys
 				// This block processes non-key-value elements and associates them with struct fields.
-				if isAnonymousStruct && len(exp.Elts) > 0 && len(directFields) == 0 {
+				if len(exp.Elts) > 0 && len(directFields) == 0 && len(explicitEmbedded) == 0 {
 					// Check if any elements in the composite literal are not key-value pairs.
 					hasNonKeyValueElts := false
 					for _, elt := range exp.Elts {
@@ -359,7 +384,11 @@ func (c *GoToTSCompiler) WriteCompositeLit(exp *ast.CompositeLit) error {
 							if i < len(exp.Elts) {
 								// Check if it's not a key-value pair
 								if _, isKV := exp.Elts[i].(*ast.KeyValueExpr); !isKV {
-									directFields[field.Name()] = exp.Elts[i]
+									if _, isEmbedded := embeddedFields[field.Name()]; isEmbedded && !isAnonymousStruct {
+										explicitEmbedded[field.Name()] = exp.Elts[i]
+									} else {
+										directFields[field.Name()] = exp.Elts[i]
+									}
 								}
 							}
 						}
@@ -415,9 +444,9 @@ func (c *GoToTSCompiler) WriteCompositeLit(exp *ast.CompositeLit) error {
 					c.tsw.WriteLiterally(embeddedName)
 					c.tsw.WriteLiterally(": ")
 
-					// Check if the embedded value is a composite literal for a struct
+					// Check if the embedded value is a composite literal for a struct with keyed fields
 					// If so, extract the fields and write them directly
-					if compLit, ok := explicitEmbedded[embeddedName].(*ast.CompositeLit); ok {
+					if compLit, ok := explicitEmbedded[embeddedName].(*ast.CompositeLit); ok && isKeyedLit(compLit) {
 						// Write initialization fields directly without the 'new Constructor'
 						c.tsw.WriteLiterally("{")
 						for i, elem := range compLit.Elts {
@@ -510,7 +539,7 @@ func (c *GoToTSCompiler) WriteCompositeLit(exp *ast.CompositeLit) error {
 		case *types.Map, *types.Struct:
 			// Handle struct directly with the struct literal logic
 			if structType, ok := underlying.(*types.Struct); ok {
-				return c.writeUntypedStructLiteral(exp, structType) // true = anonymous
+				return c.writeUntypedStructLiteral(exp, tv.Type, structType)
 			}
 			// Map case would be handled here
 			return fmt.Errorf("untyped map composite literals not yet supported")
@@ -525,7 +554,7 @@ func (c *GoToTSCompiler) WriteCompositeLit(exp *ast.CompositeLit) error {
 				// This is an anonymous struct literal with inferred pointer type
 				// Just create the struct object directly - no var-refing needed
 				// Anonymous literals are not variables, so they don't get var-refed
-				return c.writeUntypedStructLiteral(exp, elemType) // true = anonymous
+				return c.writeUntypedStructLiteral(exp, ptrType.Elem(), elemType)
 			default:
 				return fmt.Errorf("unhandled pointer composite literal element type: %T", elemType)
 			}
@@ -552,8 +581,16 @@ func (c *GoToTSCompiler) writeUntypedArrayLiteral(exp *ast.CompositeLit) error {
 	return nil
 }
 
-// writeUntypedStructLiteral handles untyped composite literals that are structs or pointers to structs
-func (c *GoToTSCompiler) writeUntypedStructLiteral(exp *ast.CompositeLit, structType *types.Struct) error {
+// writeUntypedStructLiteral handles untyped composite literals that are structs or pointers to structs.
+// Literals of named struct types, such as the elements of `[]Point{{1, 2}}`, are constructed
+// like their typed form so that they have the methods and value semantics of the type.
+func (c *GoToTSCompiler) writeUntypedStructLiteral(exp *ast.CompositeLit, typ types.Type, structType *types.Struct) error {
+	named, isNamed := types.Unalias(typ).(*types.Named)
+	if isNamed && (c.isProtobufType(named) || named.Obj().Pkg() == nil || isHandwrittenPackage(named.Obj().Pkg().Path())) {
+		// Handwritten packages may declare struct types as plain interfaces
+		isNamed = false
+	}
+
 	// Create field mapping like the typed struct case
 	directFields := make(map[string]ast.Expr)
 
@@ -577,7 +614,12 @@ func (c *GoToTSCompiler) writeUntypedStructLiteral(exp *ast.CompositeLit, struct
 		}
 	}
 
-	// Write the object literal (always anonymous for untyped)
+	// Write the object literal, as the constructor argument for named types
+	if isNamed {
+		c.tsw.WriteLiterally("new ")
+		c.WriteGoType(named, GoTypeContextGeneral)
+		c.tsw.WriteLiterally("(")
+	}
 	c.tsw.WriteLiterally("{")
 
 	firstFieldWritten := false
@@ -604,6 +646,9 @@ func (c *GoToTSCompiler) writeUntypedStructLiteral(exp *ast.CompositeLit, struct
 	}
 
 	c.tsw.WriteLiterally("}")
+	if isNamed {
+		c.tsw.WriteLiterally(")")
+	}
 	return nil
 }
 
@@ -653,6 +698,9 @@ func (c *GoToTSCompiler) WriteVarRefedValue(expr ast.Expr) error {
 	if expr == nil {
 		return fmt.Errorf("nil expression passed to write var refed value")
 	}
+	if ok, err := c.writeMarkedValue(expr); ok {
+		return err
+	}
 
 	// Handle different expression types
 	switch e := expr.(type) {
@@ -697,3 +745,14 @@ func (c *GoToTSCompiler) evaluateConstantExpr(expr ast.Expr) interface{} {
 	}
 	return nil
 }
+
+// isKeyedLit reports whether all elements of a composite literal are
+// key-value pairs, such as `Point{X: 1, Y: 2}`.
+func isKeyedLit(lit *ast.CompositeLit) bool {
+	for _, elt := range lit.Elts {
+		if _, ok := elt.(*ast.KeyValueExpr); !ok {
+			return false
+		}
+	}
+	return true
+}
@@ -24,6 +24,24 @@ type Config struct {
 	// If true, builtin packages will not be emitted; if false, they will be emitted if referenced.
 	// Default is false (emit builtin packages).
 	DisableEmitBuiltin bool
+	// BigInt64 controls whether int64 and uint64 are represented as bigint.
+	// If true, 64-bit integers get exact wrapping arithmetic at some runtime cost;
+	// if false, they are represented as number and lose precision above 2^53.
+	BigInt64 bool
+	// SourceLocations controls whether the generated code records the Go
+	// source locations it was generated from. If true, runtime.Caller,
+	// runtime.Stack and goroutine tracebacks report Go functions, files and
+	// lines where the generated TypeScript runs with its own line numbers.
+	SourceLocations bool
+	// YieldLoops controls whether loops in async functions yield to the
+	// JavaScript event loop. If true, each iteration checks whether the
+	// goroutine ran past the time budget of the runtime, and if so lets
+	// timers, I/O callbacks and rendering run before continuing.
+	YieldLoops bool
+	// DisableCache disables the compile cache in the output path.
+	// If false, packages whose sources, dependencies and configuration did not
+	// change since they were last compiled to OutputPath are not compiled again.
+	DisableCache bool
 }
 
 // Validate checks the config.
@@ -32,6 +32,11 @@ func (c *GoToTSCompiler) WriteDecls(decls []ast.Decl) error {
 			}
 		case *ast.GenDecl:
 			for _, spec := range d.Specs {
+				if _, isValue := spec.(*ast.ValueSpec); isValue {
+					c.recordLine(spec.Pos())
+				} else {
+					c.clearLine()
+				}
 				if err := c.WriteSpec(spec); err != nil {
 					return err
 				}
@@ -69,6 +74,9 @@ func (c *GoToTSCompiler) WriteFuncDeclAsFunction(decl *ast.FuncDecl) error {
 		c.WriteDoc(decl.Doc)
 	}
 
+	c.enterFunc(decl)
+	defer c.leaveFunc()
+
 	// Export all functions for intra-package visibility
 	// This allows other files in the same package to import functions
 	c.tsw.WriteLiterally("export ")
@@ -87,6 +95,8 @@ func (c *GoToTSCompiler) WriteFuncDeclAsFunction(decl *ast.FuncDecl) error {
 	if isAsync {
 		c.tsw.WriteLiterally("async ")
 	}
+	c.enterBody(isAsync)
+	defer c.leaveBody()
 
 	c.tsw.WriteLiterally("function ")
 	if err := c.WriteValueExpr(decl.Name); err != nil { // Function name is a value identifier
@@ -143,10 +153,15 @@ func (c *GoToTSCompiler) WriteFuncDeclAsFunction(decl *ast.FuncDecl) error {
 //
 // This function assumes it is called only for `FuncDecl` nodes that are methods.
 func (c *GoToTSCompiler) WriteFuncDeclAsMethod(decl *ast.FuncDecl) error {
-	_, err := c.writeMethodSignature(decl)
+	c.enterFunc(decl)
+	defer c.leaveFunc()
+
+	isAsync, err := c.writeMethodSignature(decl)
 	if err != nil {
 		return err
 	}
+	c.enterBody(isAsync)
+	defer c.leaveBody()
 
 	return c.writeMethodBodyWithReceiverBinding(decl, "this")
 }
@@ -236,6 +251,7 @@ func (c *GoToTSCompiler) writeMethodSignature(decl *ast.FuncDecl) (bool, error)
 	if funcType.Params != nil {
 		c.WriteFieldList(funcType.Params, true) // true = arguments
 	}
+	c.writeDeferFrameParam(funcType, funcType.Params.NumFields() != 0)
 	c.tsw.WriteLiterally(")")
 
 	// Handle return type
@@ -297,9 +313,11 @@ func (c *GoToTSCompiler) writeMethodBodyWithReceiverBinding(decl *ast.FuncDecl,
 				c.tsw.WriteLinef("const %s = %s", sanitizedRecvName, receiverTarget)
 			}
 
-			// Add using statement if needed
-			if c.analysis.NeedsDefer(decl.Body) {
-				if c.analysis.IsInAsyncFunction(decl) {
+			// Add using statement if needed, or a panic frame if deferred calls may recover
+			isAsync := c.analysis.IsInAsyncFunction(decl)
+			panicFrame := c.analysis.NeedsPanicFrame(decl.Body)
+			if !panicFrame && c.analysis.NeedsDefer(decl.Body) {
+				if isAsync {
 					c.tsw.WriteLine("await using __defer = new $.AsyncDisposableStack();")
 				} else {
 					c.tsw.WriteLine("using __defer = new $.DisposableStack();")
@@ -311,12 +329,42 @@ func (c *GoToTSCompiler) writeMethodBodyWithReceiverBinding(decl *ast.FuncDecl,
 				return fmt.Errorf("failed to write named return declarations: %w", err)
 			}
 
+			if panicFrame {
+				c.writePanicFrameStart(isAsync)
+			}
+
+			// Lower a body containing goto targets into a state machine
+			var gotoBlock *GotoBlockInfo
+			if nodeInfo := c.analysis.NodeData[decl.Body]; nodeInfo != nil {
+				gotoBlock = nodeInfo.GotoBlock
+			}
+			if gotoBlock != nil {
+				c.writeGotoBlockStart(decl.Body.List, gotoBlock)
+			}
+
 			// write method body without outer braces
 			for _, stmt := range decl.Body.List {
+				if gotoBlock != nil {
+					c.writeGotoBlockCase(stmt, gotoBlock)
+					if err := c.writeGotoBlockStmt(stmt, gotoBlock); err != nil {
+						return fmt.Errorf("failed to write statement in function body: %w", err)
+					}
+					continue
+				}
 				if err := c.WriteStmt(stmt); err != nil {
 					return fmt.Errorf("failed to write statement in function body: %w", err)
 				}
 			}
+
+			if gotoBlock != nil {
+				c.writeGotoBlockEnd(decl.Body.List)
+			}
+
+			if panicFrame {
+				if err := c.writePanicFrameEnd(decl.Body, isAsync); err != nil {
+					return err
+				}
+			}
 			c.tsw.Indent(-1)
 			c.tsw.WriteLine("}")
 
@@ -15,7 +15,13 @@ func (c *GoToTSCompiler) writeBuiltinFunction(exp *ast.CallExpr, funName string)
 		c.tsw.WriteLiterally("$.panic")
 		return true, nil
 	case "println":
-		c.tsw.WriteLiterally("console.log")
+		if (c.config != nil && c.config.BigInt64) || c.hasComplexArg(exp) {
+			// console.log prints bigints with an n suffix and complex
+			// numbers as objects
+			c.tsw.WriteLiterally("$.println")
+		} else {
+			c.tsw.WriteLiterally("console.log")
+		}
 		return true, nil
 	case "len":
 		if len(exp.Args) != 1 {
@@ -53,7 +59,13 @@ func (c *GoToTSCompiler) writeBuiltinFunction(exp *ast.CallExpr, funName string)
 		if len(exp.Args) != 0 {
 			return true, errors.Errorf("unhandled recover call with incorrect number of arguments: %d != 0", len(exp.Args))
 		}
-		c.tsw.WriteLiterally("$.recover")
+		// Only deferred calls receive the defer frame, so recover() made
+		// elsewhere returns nil.
+		if c.analysis.IsDeferredRecover(exp) {
+			c.tsw.WriteLiterally("$.recover(__frame)")
+		} else {
+			c.tsw.WriteLiterally("$.recover()")
+		}
 		return true, nil
 	case "make":
 		return true, c.WriteCallExprMake(exp)
@@ -82,6 +94,12 @@ func (c *GoToTSCompiler) writeBuiltinFunction(exp *ast.CallExpr, funName string)
 		return true, nil
 	case "int":
 		return true, c.writeIntConversion(exp)
+	case "complex", "real", "imag":
+		if (funName == "complex" && len(exp.Args) != 2) || (funName != "complex" && len(exp.Args) != 1) {
+			return true, errors.Errorf("unhandled %s call with incorrect number of arguments: %d", funName, len(exp.Args))
+		}
+		c.tsw.WriteLiterallyf("$.%s", funName)
+		return true, nil
 	default:
 		return false, nil
 	}
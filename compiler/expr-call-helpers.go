@@ -35,6 +35,23 @@ func (c *GoToTSCompiler) isStringType(t types.Type) bool {
 	return false
 }
 
+// writeConversionArgument writes the argument of a conversion to a named
+// type, converting between strings and byte slices, which have different
+// representations, as in json.RawMessage("{}").
+func (c *GoToTSCompiler) writeConversionArgument(arg ast.Expr, target types.Type) error {
+	if argType := c.pkg.TypesInfo.TypeOf(arg); argType != nil {
+		switch {
+		case c.isStringType(argType) && c.isByteSliceType(target):
+			c.tsw.WriteLiterally("$.stringToBytes(")
+			defer c.tsw.WriteLiterally(")")
+		case c.isByteSliceType(argType) && c.isStringType(target):
+			c.tsw.WriteLiterally("$.bytesToString(")
+			defer c.tsw.WriteLiterally(")")
+		}
+	}
+	return c.WriteValueExpr(arg)
+}
+
 // writeByteSliceCreation handles the creation of []byte slices with proper Uint8Array handling
 func (c *GoToTSCompiler) writeByteSliceCreation(lengthArg, capacityArg interface{}) error {
 	return c.writeSliceCreationForType(lengthArg, capacityArg, true)
@@ -8,6 +8,59 @@ import (
 	"github.com/pkg/errors"
 )
 
+// usesHashMap checks if the keys of maps with the given key type need Go
+// equality, which the JavaScript Map only provides for primitive values.
+// Structs, arrays and complex numbers compare by value, and interfaces and
+// type parameters may hold them.
+func usesHashMap(key types.Type) bool {
+	if key == nil {
+		return false
+	}
+	switch key.Underlying().(type) {
+	case *types.Struct, *types.Array, *types.Interface:
+		return true
+	}
+	return isComplexType(key)
+}
+
+// usesInterfaceKeyMap checks if maps with the given key type have interface
+// keys, which hold pointers to structs and struct values that share their
+// representation. Their maps tell them apart by the struct values marked
+// when converted to the interface. Type parameters are not interfaces here,
+// as their values are not converted.
+func usesInterfaceKeyMap(key types.Type) bool {
+	if key == nil {
+		return false
+	}
+	if _, isTypeParam := key.(*types.TypeParam); isTypeParam {
+		return false
+	}
+	return types.IsInterface(key)
+}
+
+// makeMapFunc returns the runtime function creating a map with the given key
+// type.
+func makeMapFunc(key types.Type) string {
+	switch {
+	case usesInterfaceKeyMap(key):
+		return "$.makeInterfaceKeyMap"
+	case usesHashMap(key):
+		return "$.makeHashMap"
+	}
+	return "$.makeMap"
+}
+
+// mapClass returns the runtime class of maps with the given key type.
+func mapClass(key types.Type) string {
+	switch {
+	case usesInterfaceKeyMap(key):
+		return "$.InterfaceKeyMap"
+	case usesHashMap(key):
+		return "$.HashMap"
+	}
+	return "Map"
+}
+
 // hasSliceConstraint checks if an interface constraint includes slice types
 // For constraints like ~[]E, this returns true
 func hasSliceConstraint(iface *types.Interface) bool {
@@ -100,11 +153,16 @@ func hasMixedStringByteConstraint(iface *types.Interface) bool {
 // getTypeHintForSliceElement returns the appropriate type hint for makeSlice based on the Go element type
 func (c *GoToTSCompiler) getTypeHintForSliceElement(elemType types.Type) string {
 	if basicType, isBasic := elemType.(*types.Basic); isBasic {
+		if c.isBigIntType(basicType) {
+			return "bigint"
+		}
 		switch basicType.Kind() {
 		case types.Int, types.Int8, types.Int16, types.Int32, types.Int64,
 			types.Uint, types.Uint8, types.Uint16, types.Uint32, types.Uint64,
-			types.Float32, types.Float64, types.Complex64, types.Complex128:
+			types.Float32, types.Float64:
 			return "number"
+		case types.Complex64, types.Complex128:
+			return "complex"
 		case types.Bool:
 			return "boolean"
 		case types.String:
@@ -172,7 +230,8 @@ func (c *GoToTSCompiler) WriteCallExprMake(exp *ast.CallExpr) error {
 	if len(exp.Args) >= 1 {
 		// Handle map creation: make(map[K]V)
 		if mapType, ok := exp.Args[0].(*ast.MapType); ok {
-			c.tsw.WriteLiterally("$.makeMap<")
+			c.tsw.WriteLiterally(makeMapFunc(c.pkg.TypesInfo.TypeOf(mapType.Key)))
+			c.tsw.WriteLiterally("<")
 			c.WriteTypeExpr(mapType.Key) // Write the key type
 			c.tsw.WriteLiterally(", ")
 			c.WriteTypeExpr(mapType.Value) // Write the value type
@@ -323,7 +382,8 @@ func (c *GoToTSCompiler) WriteCallExprMake(exp *ast.CallExpr) error {
 
 						// Handle named types with map underlying types: make(NamedMapType)
 						if mapType, isMap := namedType.Underlying().(*types.Map); isMap {
-							c.tsw.WriteLiterally("$.makeMap<")
+							c.tsw.WriteLiterally(makeMapFunc(mapType.Key()))
+							c.tsw.WriteLiterally("<")
 							c.WriteGoType(mapType.Key(), GoTypeContextGeneral) // Write the key type
 							c.tsw.WriteLiterally(", ")
 							c.WriteGoType(mapType.Elem(), GoTypeContextGeneral) // Write the value type
@@ -389,7 +449,8 @@ func (c *GoToTSCompiler) WriteCallExprMake(exp *ast.CallExpr) error {
 
 			// Handle instantiated generic map types: make(GenericMap[K, V])
 			if mapType, isMap := underlying.(*types.Map); isMap {
-				c.tsw.WriteLiterally("$.makeMap<")
+				c.tsw.WriteLiterally(makeMapFunc(mapType.Key()))
+				c.tsw.WriteLiterally("<")
 				c.WriteGoType(mapType.Key(), GoTypeContextGeneral) // Write the key type
 				c.tsw.WriteLiterally(", ")
 				c.WriteGoType(mapType.Elem(), GoTypeContextGeneral) // Write the value type
@@ -481,7 +542,8 @@ func (c *GoToTSCompiler) WriteCallExprMake(exp *ast.CallExpr) error {
 
 			// Handle selector expression map types: make(pkg.MapType)
 			if mapType, isMap := underlying.(*types.Map); isMap {
-				c.tsw.WriteLiterally("$.makeMap<")
+				c.tsw.WriteLiterally(makeMapFunc(mapType.Key()))
+				c.tsw.WriteLiterally("<")
 				c.WriteGoType(mapType.Key(), GoTypeContextGeneral) // Write the key type
 				c.tsw.WriteLiterally(", ")
 				c.WriteGoType(mapType.Elem(), GoTypeContextGeneral) // Write the value type
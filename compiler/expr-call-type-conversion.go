@@ -7,7 +7,8 @@ import (
 	"go/types"
 )
 
-// writeNilConversion handles type conversions with nil argument
+// writeNilConversion handles type conversions with nil argument, such as
+// (*T)(nil) or []byte(nil), which are written as null.
 func (c *GoToTSCompiler) writeNilConversion(exp *ast.CallExpr) (handled bool, err error) {
 	if len(exp.Args) != 1 {
 		return false, nil
@@ -18,6 +19,11 @@ func (c *GoToTSCompiler) writeNilConversion(exp *ast.CallExpr) (handled bool, er
 		return false, nil
 	}
 
+	// Calls of functions with a nil argument are not conversions
+	if tv, ok := c.pkg.TypesInfo.Types[exp.Fun]; !ok || !tv.IsType() {
+		return false, nil
+	}
+
 	// Handle nil pointer to struct type conversions: (*struct{})(nil)
 	if starExpr, isStarExpr := exp.Fun.(*ast.StarExpr); isStarExpr {
 		if _, isStructType := starExpr.X.(*ast.StructType); isStructType {
@@ -150,8 +156,9 @@ func (c *GoToTSCompiler) writeStringConversion(exp *ast.CallExpr) error {
 			return nil
 		}
 
-		if basic, isBasic := tv.Type.Underlying().(*types.Basic); isBasic && (basic.Kind() == types.Int32 || basic.Kind() == types.UntypedRune) {
-			// Translate string(rune_val) to $.runeOrStringToString(rune_val)
+		if basic, isBasic := tv.Type.Underlying().(*types.Basic); isBasic && basic.Info()&types.IsInteger != 0 {
+			// Translate string(rune_val) to $.runeOrStringToString(rune_val),
+			// which also converts bytes and other integers to their rune
 			c.tsw.WriteLiterally("$.runeOrStringToString(")
 			if err := c.WriteValueExpr(arg); err != nil {
 				return fmt.Errorf("failed to write argument for string(int32) conversion: %w", err)
@@ -243,7 +250,7 @@ func (c *GoToTSCompiler) writeTypeConversion(exp *ast.CallExpr, funIdent *ast.Id
 					}
 
 					// Add the __goTypeName property with the function type name
-					c.tsw.WriteLiterallyf(", { __goTypeName: '%s' })", funIdent.String())
+					c.tsw.WriteLiterallyf(", { __goTypeName: '%s' })", qualifiedTypeName(typeName))
 					return true, nil
 				} else {
 					// Check if this is a wrapper type
@@ -251,7 +258,7 @@ func (c *GoToTSCompiler) writeTypeConversion(exp *ast.CallExpr, funIdent *ast.Id
 					if isWrapperType {
 						// For wrapper types, use type casting instead of constructor calls
 						c.tsw.WriteLiterally("(")
-						if err := c.WriteValueExpr(exp.Args[0]); err != nil {
+						if err := c.writeConversionArgument(exp.Args[0], typeName.Type()); err != nil {
 							return true, fmt.Errorf("failed to write argument for wrapper type cast: %w", err)
 						}
 						c.tsw.WriteLiterally(" as ")
@@ -269,7 +276,7 @@ func (c *GoToTSCompiler) writeTypeConversion(exp *ast.CallExpr, funIdent *ast.Id
 								if _, isInterface := namedType.Underlying().(*types.Interface); !isInterface {
 									// Simple named type without methods - use type casting
 									c.tsw.WriteLiterally("(")
-									if err := c.WriteValueExpr(exp.Args[0]); err != nil {
+									if err := c.writeConversionArgument(exp.Args[0], typeName.Type()); err != nil {
 										return true, fmt.Errorf("failed to write argument for simple named type cast: %w", err)
 									}
 									c.tsw.WriteLiterally(" as ")
@@ -341,7 +348,7 @@ func (c *GoToTSCompiler) writeTypeConversion(exp *ast.CallExpr, funIdent *ast.Id
 						} else {
 							// For types that don't need constructors, use the TypeScript "as" operator
 							c.tsw.WriteLiterally("(")
-							if err := c.WriteValueExpr(exp.Args[0]); err != nil {
+							if err := c.writeConversionArgument(exp.Args[0], typeName.Type()); err != nil {
 								return true, fmt.Errorf("failed to write argument for type cast: %w", err)
 							}
 
@@ -436,7 +443,7 @@ func (c *GoToTSCompiler) writeQualifiedTypeConversion(exp *ast.CallExpr, selecto
 					}
 
 					// Add the __goTypeName property with the function type name
-					c.tsw.WriteLiterallyf(", { __goTypeName: '%s' })", selectorExpr.Sel.Name)
+					c.tsw.WriteLiterallyf(", { __goTypeName: '%s' })", qualifiedTypeName(typeName))
 					return true, nil
 				} else {
 					// Check if this is a wrapper type
@@ -444,7 +451,7 @@ func (c *GoToTSCompiler) writeQualifiedTypeConversion(exp *ast.CallExpr, selecto
 					if isWrapperType {
 						// For wrapper types, use type casting instead of constructor calls
 						c.tsw.WriteLiterally("(")
-						if err := c.WriteValueExpr(exp.Args[0]); err != nil {
+						if err := c.writeConversionArgument(exp.Args[0], typeName.Type()); err != nil {
 							return true, fmt.Errorf("failed to write argument for wrapper type cast: %w", err)
 						}
 						c.tsw.WriteLiterally(" as ")
@@ -462,7 +469,7 @@ func (c *GoToTSCompiler) writeQualifiedTypeConversion(exp *ast.CallExpr, selecto
 								if _, isInterface := namedType.Underlying().(*types.Interface); !isInterface {
 									// Simple named type without methods - use type casting
 									c.tsw.WriteLiterally("(")
-									if err := c.WriteValueExpr(exp.Args[0]); err != nil {
+									if err := c.writeConversionArgument(exp.Args[0], typeName.Type()); err != nil {
 										return true, fmt.Errorf("failed to write argument for simple named type cast: %w", err)
 									}
 									c.tsw.WriteLiterally(" as ")
@@ -490,7 +497,7 @@ func (c *GoToTSCompiler) writeQualifiedTypeConversion(exp *ast.CallExpr, selecto
 					} else {
 						// For types that don't need constructors, use the TypeScript "as" operator
 						c.tsw.WriteLiterally("(")
-						if err := c.WriteValueExpr(exp.Args[0]); err != nil {
+						if err := c.writeConversionArgument(exp.Args[0], typeName.Type()); err != nil {
 							return true, fmt.Errorf("failed to write argument for type cast: %w", err)
 						}
 
@@ -17,7 +17,7 @@ import (
 // - `cap(arg)` becomes `$.cap(arg)`.
 // - `delete(m, k)` becomes `$.deleteMapEntry(m, k)`.
 // - `make(chan T, size)` becomes `$.makeChannel<T_ts>(size, zeroValueForT)`.
-// - `make(map[K]V)` becomes `$.makeMap<K_ts, V_ts>()`.
+// - `make(map[K]V)` becomes `$.makeMap<K_ts, V_ts>()` (`$.makeHashMap` for struct keys).
 // - `make([]T, len, cap)` becomes `$.makeSlice<T_ts>(len, cap)`.
 // - `make([]byte, len, cap)` becomes `new Uint8Array(len)`.
 // - `string(runeVal)` becomes `$.runeOrStringToString(runeVal)`.
@@ -27,7 +27,8 @@ import (
 // - `[]byte(stringVal)` becomes `$.stringToBytes(stringVal)`.
 // - `close(ch)` becomes `ch.close()`.
 // - `append(slice, elems...)` becomes `$.append(slice, elems...)`.
-// - `byte(val)` becomes `$.byte(val)`.
+// - `byte(val)` becomes `((val) & 0xff)`.
+// - `complex(re, im)`, `real(c)` and `imag(c)` become `$.complex(re, im)`, `$.real(c)` and `$.imag(c)`.
 // For other function calls:
 //   - If the `Analysis` data indicates the function is asynchronous (e.g., due to
 //     channel operations or `go`/`defer` usage within it), the call is prefixed with `await`.
@@ -37,6 +38,27 @@ import (
 func (c *GoToTSCompiler) WriteCallExpr(exp *ast.CallExpr) error {
 	expFun := exp.Fun
 
+	// Handle conversions from and to 64-bit integers represented as bigint
+	if handled, err := c.writeBigIntConversion(exp); handled {
+		return err
+	}
+
+	// Handle conversions truncating to an integer type
+	if handled, err := c.writeIntegerConversion(exp); handled {
+		return err
+	}
+
+	// Handle conversions between complex types
+	if handled, err := c.writeComplexConversion(exp); handled {
+		return err
+	}
+
+	// Convert 64-bit integer results of handwritten packages using number to bigint
+	if conv := c.bigIntResultConversion(exp); conv != "" {
+		c.tsw.WriteLiterallyf("$.%s(", conv)
+		defer c.tsw.WriteLiterally(")")
+	}
+
 	// Handle protobuf method calls
 	if handled, err := c.writeProtobufMethodCall(exp); handled {
 		return err
@@ -62,7 +84,7 @@ func (c *GoToTSCompiler) WriteCallExpr(exp *ast.CallExpr) error {
 			// For built-ins that don't return early, write the arguments
 			if funIdent.String() != "new" && funIdent.String() != "close" && funIdent.String() != "make" &&
 				funIdent.String() != "string" && funIdent.String() != "append" && funIdent.String() != "byte" &&
-				funIdent.String() != "int" {
+				funIdent.String() != "int" && funIdent.String() != "recover" {
 				return c.writeCallArguments(exp)
 			}
 			return nil
@@ -140,7 +162,10 @@ func (c *GoToTSCompiler) writeCallArguments(exp *ast.CallExpr) error {
 		}
 	}
 
-	for i, arg := range exp.Args {
+	// Handwritten packages using number for 64-bit integers get number arguments
+	numberBoundary := c.isNumberBoundaryCall(exp)
+
+	for i := range exp.Args {
 		if i != 0 {
 			c.tsw.WriteLiterally(", ")
 		}
@@ -148,7 +173,7 @@ func (c *GoToTSCompiler) writeCallArguments(exp *ast.CallExpr) error {
 		if exp.Ellipsis != token.NoPos && i == len(exp.Args)-1 {
 			c.tsw.WriteLiterally("...(")
 			// Write the argument
-			if err := c.writeArgumentWithTypeHandling(arg, funcSig, i); err != nil {
+			if err := c.writeCallArgument(exp, funcSig, numberBoundary, i); err != nil {
 				return err
 			}
 			// Add null coalescing for slice spread to prevent TypeScript errors
@@ -156,15 +181,34 @@ func (c *GoToTSCompiler) writeCallArguments(exp *ast.CallExpr) error {
 			continue
 		}
 
-		if err := c.writeArgumentWithTypeHandling(arg, funcSig, i); err != nil {
+		if err := c.writeCallArgument(exp, funcSig, numberBoundary, i); err != nil {
 			return err
 		}
 	}
+	c.writeDeferFrameArg(exp)
+
+	// reflect.TypeOf, binary.Write and the like get the static type of their
+	// data argument
+	if argType := c.staticArgType(exp); argType != nil {
+		c.tsw.WriteLiterally(", ")
+		c.writeReflectTypeInfo(argType)
+	}
 
 	c.tsw.WriteLiterally(")")
 	return nil
 }
 
+// writeCallArgument writes the argument i of a call. Handwritten packages
+// using number for 64-bit integers get number arguments, see
+// isNumberBoundaryCall.
+func (c *GoToTSCompiler) writeCallArgument(exp *ast.CallExpr, funcSig *types.Signature, numberBoundary bool, i int) error {
+	arg := exp.Args[i]
+	if numberBoundary && funcSig != nil && i < funcSig.Params().Len() && c.isBigIntType(funcSig.Params().At(i).Type()) {
+		return c.writeIndexValue(arg)
+	}
+	return c.writeArgumentWithTypeHandling(arg, funcSig, i)
+}
+
 // writeArgumentWithTypeHandling writes a single argument with proper type handling
 func (c *GoToTSCompiler) writeArgumentWithTypeHandling(arg ast.Expr, funcSig *types.Signature, argIndex int) error {
 	if funcSig != nil && argIndex < funcSig.Params().Len() {
@@ -366,6 +410,7 @@ func (c *GoToTSCompiler) writeWrapperTypeMethodCall(exp *ast.CallExpr, selectorE
 			}
 		}
 	}
+	c.writeDeferFrameArg(exp)
 
 	c.tsw.WriteLiterally(")")
 	return true, nil
@@ -82,7 +82,7 @@ func (c *GoToTSCompiler) writeTypeDescription(typeExpr ast.Expr) {
 					// It's a named function type (e.g. type MyFunc func())
 					c.tsw.WriteLiterally("{")
 					c.tsw.WriteLiterally("kind: $.TypeKind.Function, ")
-					c.tsw.WriteLiterallyf("name: '%s'", namedType.Obj().Name()) // Use the original defined name
+					c.tsw.WriteLiterallyf("name: '%s'", qualifiedTypeName(namedType.Obj())) // Use the original defined name
 
 					// Add params if present
 					if sig.Params() != nil && sig.Params().Len() > 0 {
@@ -121,7 +121,9 @@ func (c *GoToTSCompiler) writeTypeDescription(typeExpr ast.Expr) {
 		}
 
 		if isPrimitiveType(t.Name) {
-			if tsType, ok := GoBuiltinToTypescript(t.Name); ok {
+			if c.isBigIntType(c.pkg.TypesInfo.TypeOf(t)) {
+				c.tsw.WriteLiterally("{kind: $.TypeKind.Basic, name: 'bigint'}")
+			} else if tsType, ok := GoBuiltinToTypescript(t.Name); ok {
 				c.tsw.WriteLiterally("{")
 				c.tsw.WriteLiterally("kind: $.TypeKind.Basic, ")
 				c.tsw.WriteLiterallyf("name: '%s'", tsType)
@@ -135,12 +137,10 @@ func (c *GoToTSCompiler) writeTypeDescription(typeExpr ast.Expr) {
 			}
 		} else {
 			// For named types, just use the name string
-			c.tsw.WriteLiterallyf("'%s'", t.Name)
+			c.tsw.WriteLiterallyf("'%s'", c.getTypeNameString(t))
 		}
 	case *ast.SelectorExpr:
-		if ident, ok := t.X.(*ast.Ident); ok {
-			c.tsw.WriteLiterallyf("'%s.%s'", ident.Name, t.Sel.Name)
-		}
+		c.tsw.WriteLiterallyf("'%s'", c.getTypeNameString(t))
 	case *ast.ArrayType:
 		typeKind := "$.TypeKind.Slice"
 		if t.Len != nil {
@@ -211,7 +211,7 @@ func (c *GoToTSCompiler) writeTypeDescription(typeExpr ast.Expr) {
 			if named, ok := resolvedGoType.(*types.Named); ok {
 				// Ensure it's actually a function type that's named
 				if _, isFuncSig := named.Underlying().(*types.Signature); isFuncSig {
-					c.tsw.WriteLiterallyf(", name: '%s'", named.Obj().Name())
+					c.tsw.WriteLiterallyf(", name: '%s'", qualifiedTypeName(named.Obj()))
 				}
 			}
 		}
@@ -2,6 +2,7 @@ package compiler
 
 import (
 	"go/ast"
+	"go/types"
 )
 
 // WriteValueExpr translates a Go abstract syntax tree (AST) expression (`ast.Expr`)
@@ -26,6 +27,21 @@ import (
 // - Function literals (`ast.FuncLit`): Delegates to `WriteFuncLitValue`.
 // Unhandled value expressions result in a comment.
 func (c *GoToTSCompiler) WriteValueExpr(a ast.Expr) error {
+	// Values converted to interfaces may be marked or boxed
+	if ok, err := c.writeMarkedValue(a); ok {
+		return err
+	}
+
+	// Constants of bigint types are written as bigint literals
+	if c.writeBigIntConstant(a) {
+		return nil
+	}
+
+	// Constants of complex types are written as $.Complex values
+	if c.writeComplexConstant(a) {
+		return nil
+	}
+
 	switch exp := a.(type) {
 	case *ast.Ident:
 		c.WriteIdent(exp, true) // adds .value accessor
@@ -87,3 +103,116 @@ func (c *GoToTSCompiler) WriteValueExpr(a ast.Expr) error {
 		return nil
 	}
 }
+
+// writeMarkedValue writes expr if it is a value converted to an interface
+// that is marked or boxed, and reports whether it did.
+func (c *GoToTSCompiler) writeMarkedValue(expr ast.Expr) (bool, error) {
+	if expr == c.markedValue {
+		return false, nil
+	}
+	switch {
+	case c.analysis.IsStructValueInInterface(expr):
+		return true, c.writeMarkedStructValue(expr)
+	case c.analysis.IsByteArrayInInterface(expr):
+		return true, c.writeMarkedByteArray(expr)
+	case c.analysis.IsNamedValueInInterface(expr):
+		return true, c.writeNamedValue(expr)
+	case c.analysis.IsTypeMarkedInInterface(expr):
+		return true, c.writeTypeMarkedValue(expr)
+	case c.analysis.IsTypedMapKey(expr):
+		return true, c.writeTypedMapKey(expr)
+	}
+	return false, nil
+}
+
+// writeMarkedStructValue writes a struct value converted to an interface as
+// a copy marked with `$.markStructValue`, which tells it apart from a pointer
+// to the struct in maps with interface keys.
+func (c *GoToTSCompiler) writeMarkedStructValue(expr ast.Expr) error {
+	outer := c.markedValue
+	c.markedValue = expr
+	defer func() { c.markedValue = outer }()
+
+	c.tsw.WriteLiterally("$.markStructValue(")
+	if err := c.WriteValueExpr(expr); err != nil {
+		return err
+	}
+	if shouldApplyClone(c.pkg, expr) {
+		c.tsw.WriteLiterally(".clone()")
+	}
+	c.tsw.WriteLiterally(")")
+	return nil
+}
+
+// writeMarkedByteArray writes a byte array converted to an interface as a
+// copy marked with `$.markByteArray`, which tells fmt to format it as bytes,
+// as in `fmt.Sprintf("%x", sha256.Sum256(data))`.
+func (c *GoToTSCompiler) writeMarkedByteArray(expr ast.Expr) error {
+	outer := c.markedValue
+	c.markedValue = expr
+	defer func() { c.markedValue = outer }()
+
+	c.tsw.WriteLiterally("$.markByteArray(")
+	if err := c.WriteValueExpr(expr); err != nil {
+		return err
+	}
+	c.tsw.WriteLiterally(")")
+	return nil
+}
+
+// writeNamedValue writes a value of a named non-struct type with methods
+// converted to an interface boxed with the methods by `$.namedValue`, as in
+// `$.namedValue(c, 'main.Color')`, so that fmt calls its String method.
+func (c *GoToTSCompiler) writeNamedValue(expr ast.Expr) error {
+	outer := c.markedValue
+	c.markedValue = expr
+	defer func() { c.markedValue = outer }()
+
+	named := types.Unalias(c.pkg.TypesInfo.TypeOf(expr)).(*types.Named)
+	c.tsw.WriteLiterally("$.namedValue(")
+	if err := c.WriteValueExpr(expr); err != nil {
+		return err
+	}
+	c.tsw.WriteLiterallyf(", %q)", qualifiedTypeName(named.Obj()))
+	return nil
+}
+
+// writeTypeMarkedValue writes a slice, array or map converted to an
+// interface marked with its type by `$.markType`, which tells fmt the type of
+// values like `[]any{1}`, see needsTypeMark.
+func (c *GoToTSCompiler) writeTypeMarkedValue(expr ast.Expr) error {
+	outer := c.markedValue
+	c.markedValue = expr
+	defer func() { c.markedValue = outer }()
+
+	c.tsw.WriteLiterally("$.markType(")
+	if err := c.WriteValueExpr(expr); err != nil {
+		return err
+	}
+	c.tsw.WriteLiterally(", ")
+	c.writeReflectTypeInfo(c.pkg.TypesInfo.TypeOf(expr))
+	c.tsw.WriteLiterally(")")
+	return nil
+}
+
+// writeTypedMapKey writes a basic value converted to the interface key of a
+// map tagged with its type by `$.typedMapKey`, as in
+// `$.typedMapKey(1, 'float64')`, so that keys of different types with the
+// same representation are different keys.
+func (c *GoToTSCompiler) writeTypedMapKey(expr ast.Expr) error {
+	outer := c.markedValue
+	c.markedValue = expr
+	defer func() { c.markedValue = outer }()
+
+	typ := types.Unalias(c.pkg.TypesInfo.TypeOf(expr))
+	name := typ.String()
+	if named, ok := typ.(*types.Named); ok {
+		name = qualifiedTypeName(named.Obj())
+	}
+	c.tsw.WriteLiterally("$.typedMapKey(")
+	if err := c.WriteValueExpr(expr); err != nil {
+		return err
+	}
+	c.tsw.WriteLiterallyf(", %q)", name)
+	return nil
+}
@@ -63,7 +63,7 @@ func (c *GoToTSCompiler) WriteIndexExpr(exp *ast.IndexExpr) error {
 				return err
 			}
 			c.tsw.WriteLiterally(", ")
-			if err := c.WriteValueExpr(exp.Index); err != nil {
+			if err := c.writeIndexValue(exp.Index); err != nil {
 				return err
 			}
 			c.tsw.WriteLiterally(")")
@@ -153,7 +153,7 @@ func (c *GoToTSCompiler) WriteIndexExpr(exp *ast.IndexExpr) error {
 		return err
 	}
 	c.tsw.WriteLiterally("![") // non-null assertion
-	if err := c.WriteValueExpr(exp.Index); err != nil {
+	if err := c.writeIndexValue(exp.Index); err != nil {
 		return err
 	}
 	c.tsw.WriteLiterally("]")
@@ -233,11 +233,16 @@ func (c *GoToTSCompiler) isPointerComparison(exp *ast.BinaryExpr) bool {
 }
 
 // getTypeNameString returns a string representation of a Go type expression (`ast.Expr`).
-// It handles simple identifiers (e.g., `MyType`) and selector expressions
-// (e.g., `pkg.Type`). For more complex or unrecognized type expressions,
+// Named types are written with the qualified name they are registered under
+// (e.g., `io/fs.PathError`), other simple identifiers and selector expressions
+// as written. For more complex or unrecognized type expressions,
 // it returns "unknown". This string is primarily used for runtime error messages,
 // such as in type assertions.
 func (c *GoToTSCompiler) getTypeNameString(typeExpr ast.Expr) string {
+	// Named types are referred to by the name they are registered under
+	if named, ok := types.Unalias(c.pkg.TypesInfo.TypeOf(typeExpr)).(*types.Named); ok && named.TypeArgs() == nil {
+		return qualifiedTypeName(named.Obj())
+	}
 	switch t := typeExpr.(type) {
 	case *ast.Ident:
 		return t.Name
@@ -327,6 +332,26 @@ func (c *GoToTSCompiler) WriteBinaryExpr(exp *ast.BinaryExpr) error {
 		return nil
 	}
 
+	// Handle arithmetic and comparisons of complex numbers
+	if handled, err := c.writeComplexBinaryExpr(exp); handled {
+		return err
+	}
+
+	// Handle integer arithmetic that differs from the JavaScript operators
+	if handled, err := c.writeIntegerBinaryExpr(exp); handled {
+		return err
+	}
+
+	// Handle arithmetic on 64-bit integers represented as bigint
+	if handled, err := c.writeBigIntBinaryExpr(exp); handled {
+		return err
+	}
+
+	// Handle concatenation of strings that may hold escaped bytes
+	if handled, err := c.writeStringBinaryExpr(exp); handled {
+		return err
+	}
+
 	// Check if this is a nil comparison for a pointer
 	isNilComparison := false
 	var ptrExpr ast.Expr
@@ -412,24 +437,6 @@ func (c *GoToTSCompiler) WriteBinaryExpr(exp *ast.BinaryExpr) error {
 		isBitwise = true
 	}
 
-	// Handle large bit shift expressions that would overflow in JavaScript
-	if exp.Op == token.SHL {
-		// Check if this is 1 << 63 pattern using constant evaluation
-		leftValue := c.evaluateConstantExpr(exp.X)
-		rightValue := c.evaluateConstantExpr(exp.Y)
-
-		if leftValue != nil && rightValue != nil {
-			if leftInt, leftOk := leftValue.(int); leftOk && leftInt == 1 {
-				if rightInt, rightOk := rightValue.(int); rightOk && rightInt == 63 {
-					// Replace 1 << 63 with Number.MAX_SAFE_INTEGER (9007199254740991)
-					// This is the largest integer that can be exactly represented in JavaScript
-					c.tsw.WriteLiterally("Number.MAX_SAFE_INTEGER")
-					return nil
-				}
-			}
-		}
-	}
-
 	if isBitwise {
 		c.tsw.WriteLiterally("(") // Add opening parenthesis for bitwise operations
 	}
@@ -503,6 +510,16 @@ func (c *GoToTSCompiler) WriteUnaryExpr(exp *ast.UnaryExpr) error {
 			}
 		}
 
+		// &s.Field -> s._fields.Field (the VarRef backing the field)
+		if sel, ok := exp.X.(*ast.SelectorExpr); ok && c.isFieldVarRef(sel) {
+			fields := &ast.SelectorExpr{X: sel.X, Sel: ast.NewIdent("_fields")}
+			if err := c.WriteSelectorExpr(fields); err != nil {
+				return fmt.Errorf("failed to write &-operand: %w", err)
+			}
+			c.tsw.WriteLiterallyf(".%s", c.sanitizeIdentifier(sel.Sel.Name))
+			return nil
+		}
+
 		// Otherwise (&unvarrefedVar, &CompositeLit{}, &FuncCall(), etc.),
 		// the address-of operator in Go, when used to create a pointer,
 		// translates to simply evaluating the operand in TypeScript.
@@ -515,6 +532,21 @@ func (c *GoToTSCompiler) WriteUnaryExpr(exp *ast.UnaryExpr) error {
 		return nil
 	}
 
+	// Handle negation and complement of 64-bit integers represented as bigint
+	if handled, err := c.writeBigIntUnaryExpr(exp); handled {
+		return err
+	}
+
+	// Handle negation and complement of fixed-width integers
+	if handled, err := c.writeIntegerUnaryExpr(exp); handled {
+		return err
+	}
+
+	// Handle negation of complex numbers
+	if handled, err := c.writeComplexUnaryExpr(exp); handled {
+		return err
+	}
+
 	// Handle other unary operators (+, -, !, ^)
 	tokStr, ok := TokenToTs(exp.Op)
 	if !ok {
@@ -559,6 +591,30 @@ func (c *GoToTSCompiler) WriteUnaryExpr(exp *ast.UnaryExpr) error {
 	return nil
 }
 
+// isFieldVarRef reports whether sel selects a field of a named struct whose
+// value is held in a VarRef in the struct's _fields, so that &sel can refer
+// to that VarRef. Struct-typed fields are excluded since a pointer to a
+// struct is the struct instance itself.
+func (c *GoToTSCompiler) isFieldVarRef(sel *ast.SelectorExpr) bool {
+	selection := c.pkg.TypesInfo.Selections[sel]
+	if selection == nil || selection.Kind() != types.FieldVal || len(selection.Index()) != 1 {
+		return false
+	}
+	if _, isStruct := selection.Type().Underlying().(*types.Struct); isStruct {
+		return false
+	}
+	recv := selection.Recv()
+	if ptr, ok := recv.(*types.Pointer); ok {
+		recv = ptr.Elem()
+	}
+	named, ok := recv.(*types.Named)
+	if !ok {
+		return false
+	}
+	_, isStruct := named.Underlying().(*types.Struct)
+	return isStruct
+}
+
 // WriteSliceExpr translates a Go slice expression (e.g., `s[low:high:max]`) to its TypeScript equivalent.
 // If `s` is a string and it's not a 3-index slice, it uses `s.substring(low, high)`.
 // If `s` is `[]byte` (Uint8Array) and it's not a 3-index slice, it uses $.goSlice.
@@ -586,7 +642,7 @@ func (c *GoToTSCompiler) WriteSliceExpr(exp *ast.SliceExpr) error {
 		}
 		c.tsw.WriteLiterally(", ")
 		if exp.Low != nil {
-			if err := c.WriteValueExpr(exp.Low); err != nil {
+			if err := c.writeIndexValue(exp.Low); err != nil {
 				return err
 			}
 		} else {
@@ -594,7 +650,7 @@ func (c *GoToTSCompiler) WriteSliceExpr(exp *ast.SliceExpr) error {
 		}
 		c.tsw.WriteLiterally(", ")
 		if exp.High != nil {
-			if err := c.WriteValueExpr(exp.High); err != nil {
+			if err := c.writeIndexValue(exp.High); err != nil {
 				return err
 			}
 		} else {
@@ -603,7 +659,7 @@ func (c *GoToTSCompiler) WriteSliceExpr(exp *ast.SliceExpr) error {
 		if exp.Slice3 {
 			c.tsw.WriteLiterally(", ")
 			if exp.Max != nil {
-				if err := c.WriteValueExpr(exp.Max); err != nil {
+				if err := c.writeIndexValue(exp.Max); err != nil {
 					return err
 				}
 			} else {
@@ -622,7 +678,7 @@ func (c *GoToTSCompiler) WriteSliceExpr(exp *ast.SliceExpr) error {
 		}
 		c.tsw.WriteLiterally(", ")
 		if exp.Low != nil {
-			if err := c.WriteValueExpr(exp.Low); err != nil {
+			if err := c.writeIndexValue(exp.Low); err != nil {
 				return err
 			}
 		} else {
@@ -632,7 +688,7 @@ func (c *GoToTSCompiler) WriteSliceExpr(exp *ast.SliceExpr) error {
 		}
 		c.tsw.WriteLiterally(", ")
 		if exp.High != nil {
-			if err := c.WriteValueExpr(exp.High); err != nil {
+			if err := c.writeIndexValue(exp.High); err != nil {
 				return err
 			}
 		} else {
@@ -650,7 +706,7 @@ func (c *GoToTSCompiler) WriteSliceExpr(exp *ast.SliceExpr) error {
 		}
 		c.tsw.WriteLiterally(", ")
 		if exp.Low != nil {
-			if err := c.WriteValueExpr(exp.Low); err != nil {
+			if err := c.writeIndexValue(exp.Low); err != nil {
 				return err
 			}
 		} else {
@@ -658,7 +714,7 @@ func (c *GoToTSCompiler) WriteSliceExpr(exp *ast.SliceExpr) error {
 		}
 		c.tsw.WriteLiterally(", ")
 		if exp.High != nil {
-			if err := c.WriteValueExpr(exp.High); err != nil {
+			if err := c.writeIndexValue(exp.High); err != nil {
 				return err
 			}
 		} else {
@@ -667,7 +723,7 @@ func (c *GoToTSCompiler) WriteSliceExpr(exp *ast.SliceExpr) error {
 		if exp.Slice3 {
 			c.tsw.WriteLiterally(", ")
 			if exp.Max != nil {
-				if err := c.WriteValueExpr(exp.Max); err != nil {
+				if err := c.writeIndexValue(exp.Max); err != nil {
 					return err
 				}
 			} else {
@@ -0,0 +1,426 @@
+package compiler
+
+import (
+	"fmt"
+	"go/ast"
+	"go/constant"
+	"go/token"
+	"go/types"
+)
+
+// intWidth returns the bit width and signedness of a fixed-width integer
+// type of at most 32 bits, such as uint8 or int32. These are represented as
+// numbers and truncated to their width after each operation that may
+// overflow. It returns 0 for all other types.
+func intWidth(t types.Type) (bits int, signed bool) {
+	if t == nil {
+		return 0, false
+	}
+	basic, ok := t.Underlying().(*types.Basic)
+	if !ok {
+		return 0, false
+	}
+	switch basic.Kind() {
+	case types.Int8:
+		return 8, true
+	case types.Int16:
+		return 16, true
+	case types.Int32:
+		return 32, true
+	case types.Uint8:
+		return 8, false
+	case types.Uint16:
+		return 16, false
+	case types.Uint32:
+		return 32, false
+	}
+	return 0, false
+}
+
+// intWrap returns the operator that truncates a number to the width of a
+// fixed-width integer type, like Go's two's complement overflow. It binds
+// looser than the arithmetic operators, so `a + b` is wrapped as `(a + b | 0)`.
+func intWrap(bits int, signed bool) string {
+	switch {
+	case bits == 8 && signed:
+		return " << 24 >> 24"
+	case bits == 16 && signed:
+		return " << 16 >> 16"
+	case bits == 32 && signed:
+		return " | 0"
+	case bits == 8:
+		return " & 0xff"
+	case bits == 16:
+		return " & 0xffff"
+	default:
+		return " >>> 0"
+	}
+}
+
+// isNumberIntegerType checks if a type is an integer type represented as a
+// number, which is every integer type except the bigint ones.
+func (c *GoToTSCompiler) isNumberIntegerType(t types.Type) bool {
+	if t == nil || c.isBigIntType(t) {
+		return false
+	}
+	basic, ok := t.Underlying().(*types.Basic)
+	return ok && basic.Info()&types.IsInteger != 0
+}
+
+// needsIntegerOp checks if a binary operation with operands of the given
+// type differs from the JavaScript operator on numbers: integer division and
+// remainder, and the operations on fixed-width integers that may overflow.
+func (c *GoToTSCompiler) needsIntegerOp(t types.Type, op token.Token) bool {
+	if !c.isNumberIntegerType(t) {
+		return false
+	}
+	if op == token.QUO || op == token.REM {
+		return true
+	}
+	bits, signed := intWidth(t)
+	if bits == 0 {
+		return false
+	}
+	switch op {
+	case token.ADD, token.SUB, token.MUL, token.SHL, token.SHR:
+		return true
+	case token.AND, token.OR, token.XOR, token.AND_NOT:
+		// The JavaScript bitwise operators return signed 32-bit integers
+		return bits == 32 && !signed
+	}
+	return false
+}
+
+// isNonZeroConstant checks if an expression is a constant other than zero.
+func (c *GoToTSCompiler) isNonZeroConstant(expr ast.Expr) bool {
+	tv := c.pkg.TypesInfo.Types[expr]
+	return tv.Value != nil && constant.Sign(tv.Value) != 0
+}
+
+// isMaskConstant checks if an expression is a constant that fits in 31 bits
+// and is not negative.
+func (c *GoToTSCompiler) isMaskConstant(expr ast.Expr) bool {
+	tv := c.pkg.TypesInfo.Types[expr]
+	if tv.Value == nil {
+		return false
+	}
+	n, ok := constant.Int64Val(constant.ToInt(tv.Value))
+	return ok && n >= 0 && n < 1<<31
+}
+
+// constantShiftCount returns the value of a constant shift count, or -1 if
+// the count is not constant.
+func (c *GoToTSCompiler) constantShiftCount(expr ast.Expr) int64 {
+	tv := c.pkg.TypesInfo.Types[expr]
+	if tv.Value == nil {
+		return -1
+	}
+	n, ok := constant.Int64Val(constant.ToInt(tv.Value))
+	if !ok {
+		return 64
+	}
+	return n
+}
+
+// writeIntegerBinaryExpr writes a binary expression on integers represented
+// as numbers whose result differs from the JavaScript operator. It reports
+// whether the expression was written.
+//
+//	a / b   // $.intDiv(a, b): truncates toward zero, panics on zero
+//	a % b   // $.intRem(a, b): panics on zero
+//	a + b   // ((a + b) & 0xff) for uint8 operands
+//	a * b   // (Math.imul(a, b) | 0) for int32 operands
+//	a << 3  // ((a << 3) >>> 0) for uint32 operands
+//
+// Constant integer expressions are folded, since JavaScript divides them as
+// floats and its bitwise operators are limited to 32 bits.
+func (c *GoToTSCompiler) writeIntegerBinaryExpr(exp *ast.BinaryExpr) (bool, error) {
+	if tv := c.pkg.TypesInfo.Types[exp]; tv.Value != nil && tv.Value.Kind() == constant.Int && !c.isBigIntType(tv.Type) {
+		switch exp.Op {
+		case token.QUO, token.REM, token.AND, token.OR, token.XOR, token.SHL, token.SHR, token.AND_NOT:
+			c.writeNumberConstant(tv.Value)
+			return true, nil
+		}
+		return false, nil
+	}
+
+	typ := c.pkg.TypesInfo.TypeOf(exp.X)
+	if !c.needsIntegerOp(typ, exp.Op) {
+		return false, nil
+	}
+	if exp.Op == token.AND && (c.isMaskConstant(exp.X) || c.isMaskConstant(exp.Y)) {
+		// Masking with a positive 31-bit constant cannot set the sign bit
+		return false, nil
+	}
+	bits, signed := intWidth(typ)
+
+	wrap := bits != 0
+	if exp.Op == token.REM || exp.Op == token.SHR || (exp.Op == token.QUO && !signed) {
+		// The result is always in range
+		wrap = false
+	}
+	if wrap {
+		c.tsw.WriteLiterally("(")
+	}
+
+	var err error
+	switch exp.Op {
+	case token.QUO:
+		if c.isNonZeroConstant(exp.Y) {
+			err = c.writeIntegerCall("Math.trunc(", exp.X, " / ", exp.Y)
+		} else {
+			err = c.writeIntegerCall("$.intDiv(", exp.X, ", ", exp.Y)
+		}
+	case token.REM:
+		if c.isNonZeroConstant(exp.Y) {
+			err = c.writeIntegerCall("(", exp.X, " % ", exp.Y)
+		} else {
+			err = c.writeIntegerCall("$.intRem(", exp.X, ", ", exp.Y)
+		}
+	case token.MUL:
+		// Math.imul keeps the low 32 bits of products beyond 2^53
+		err = c.writeIntegerCall("Math.imul(", exp.X, ", ", exp.Y)
+	case token.SHL, token.SHR:
+		err = c.writeIntegerShift(exp, signed)
+	case token.AND_NOT:
+		err = c.writeIntegerCall("(", exp.X, " & ~", exp.Y)
+	default:
+		err = c.writeIntegerCall("(", exp.X, " "+exp.Op.String()+" ", exp.Y)
+	}
+	if err != nil {
+		return true, fmt.Errorf("failed to write integer binary expression: %w", err)
+	}
+
+	if wrap {
+		c.tsw.WriteLiterally(intWrap(bits, signed))
+		c.tsw.WriteLiterally(")")
+	}
+	return true, nil
+}
+
+// writeIntegerCall writes `open x sep y)`, the form shared by the integer
+// operators and their runtime helpers.
+func (c *GoToTSCompiler) writeIntegerCall(open string, x ast.Expr, sep string, y ast.Expr) error {
+	c.tsw.WriteLiterally(open)
+	if err := c.WriteValueExpr(x); err != nil {
+		return err
+	}
+	c.tsw.WriteLiterally(sep)
+	if err := c.writeIndexValue(y); err != nil {
+		return err
+	}
+	c.tsw.WriteLiterally(")")
+	return nil
+}
+
+// writeIntegerShift writes a shift of a fixed-width integer. JavaScript uses
+// the shift count modulo 32, so counts that are not constants below 32 are
+// shifted by the runtime, which also panics on negative counts.
+func (c *GoToTSCompiler) writeIntegerShift(exp *ast.BinaryExpr, signed bool) error {
+	if n := c.constantShiftCount(exp.Y); n >= 0 && n < 32 {
+		op := " << "
+		if exp.Op == token.SHR {
+			op = " >> "
+			if !signed {
+				op = " >>> "
+			}
+		}
+		return c.writeIntegerCall("(", exp.X, op, exp.Y)
+	}
+	fn := "$.shl32("
+	if exp.Op == token.SHR {
+		fn = "$.shr32("
+		if !signed {
+			fn = "$.ushr32("
+		}
+	}
+	return c.writeIntegerCall(fn, exp.X, ", ", exp.Y)
+}
+
+// writeIntegerUnaryExpr writes a negation or bitwise complement of a
+// fixed-width integer, truncating the result to its width, and folds the
+// complement of constants. It reports whether the expression was written.
+func (c *GoToTSCompiler) writeIntegerUnaryExpr(exp *ast.UnaryExpr) (bool, error) {
+	if exp.Op != token.SUB && exp.Op != token.XOR {
+		return false, nil
+	}
+	if tv := c.pkg.TypesInfo.Types[exp]; tv.Value != nil {
+		// The complement of a constant depends on the width of its type
+		if exp.Op != token.XOR || tv.Value.Kind() != constant.Int || c.isBigIntType(tv.Type) {
+			return false, nil
+		}
+		c.writeNumberConstant(tv.Value)
+		return true, nil
+	}
+	typ := c.pkg.TypesInfo.TypeOf(exp.X)
+	bits, signed := intWidth(typ)
+	if bits == 0 || c.isBigIntType(typ) {
+		return false, nil
+	}
+	c.tsw.WriteLiterally("(")
+	if exp.Op == token.SUB {
+		c.tsw.WriteLiterally("-")
+	} else {
+		c.tsw.WriteLiterally("~")
+	}
+	c.tsw.WriteLiterally("(")
+	if err := c.WriteValueExpr(exp.X); err != nil {
+		return true, fmt.Errorf("failed to write integer unary expression operand: %w", err)
+	}
+	c.tsw.WriteLiterally(")")
+	c.tsw.WriteLiterally(intWrap(bits, signed))
+	c.tsw.WriteLiterally(")")
+	return true, nil
+}
+
+// intRangeContains checks if every value of the source integer type is in
+// range of the fixed-width target type, so that a conversion needs no
+// truncation.
+func intRangeContains(targetBits int, targetSigned bool, source types.Type) bool {
+	sourceBits, sourceSigned := intWidth(source)
+	if sourceBits == 0 {
+		return false
+	}
+	switch {
+	case targetSigned && sourceSigned, !targetSigned && !sourceSigned:
+		return sourceBits <= targetBits
+	case targetSigned:
+		return sourceBits < targetBits
+	default:
+		return false
+	}
+}
+
+// writeIntegerConversion writes a conversion to an integer type represented
+// as a number that may change the value. It reports whether the conversion
+// was written.
+//
+//	uint8(x)   // ((x) & 0xff) for an int x
+//	int32(f)   // ((f) | 0) for a float64 f, truncating toward zero
+//	uint(f)    // $.int(f) for a float64 f
+func (c *GoToTSCompiler) writeIntegerConversion(exp *ast.CallExpr) (bool, error) {
+	if len(exp.Args) != 1 || !c.pkg.TypesInfo.Types[exp.Fun].IsType() {
+		return false, nil
+	}
+	target := c.pkg.TypesInfo.TypeOf(exp.Fun)
+	if !c.isNumberIntegerType(target) {
+		return false, nil
+	}
+	_, targetIsBasic := types.Unalias(target).(*types.Basic)
+	if named, ok := types.Unalias(target).(*types.Named); ok {
+		// Only named types written as a cast of their value are handled
+		if !c.analysis.IsNamedBasicType(named) && named.NumMethods() != 0 {
+			return false, nil
+		}
+	}
+
+	// Constants are converted by the type checker
+	if tv := c.pkg.TypesInfo.Types[exp]; tv.Value != nil {
+		if !targetIsBasic {
+			return false, nil
+		}
+		c.writeNumberConstant(tv.Value)
+		return true, nil
+	}
+
+	arg := exp.Args[0]
+	source := c.pkg.TypesInfo.TypeOf(arg)
+	sourceBasic, ok := source.Underlying().(*types.Basic)
+	if !ok || sourceBasic.Info()&types.IsNumeric == 0 || c.isBigIntType(source) {
+		return false, nil
+	}
+
+	bits, signed := intWidth(target)
+	var prefix, suffix string
+	switch {
+	case bits != 0 && !intRangeContains(bits, signed, source):
+		prefix, suffix = "((", ")"+intWrap(bits, signed)+")"
+	case bits == 0 && sourceBasic.Info()&types.IsFloat != 0:
+		prefix, suffix = "$.int(", ")"
+	default:
+		return false, nil
+	}
+
+	if !targetIsBasic {
+		c.tsw.WriteLiterally("(")
+	}
+	c.tsw.WriteLiterally(prefix)
+	if err := c.WriteValueExpr(arg); err != nil {
+		return true, fmt.Errorf("failed to write argument for integer conversion: %w", err)
+	}
+	c.tsw.WriteLiterally(suffix)
+	if !targetIsBasic {
+		c.tsw.WriteLiterally(" as ")
+		c.WriteGoType(target, GoTypeContextGeneral)
+		c.tsw.WriteLiterally(")")
+	}
+	return true, nil
+}
+
+// assignOps maps the compound assignment operators to their binary operator.
+var assignOps = map[token.Token]token.Token{
+	token.ADD_ASSIGN:     token.ADD,
+	token.SUB_ASSIGN:     token.SUB,
+	token.MUL_ASSIGN:     token.MUL,
+	token.QUO_ASSIGN:     token.QUO,
+	token.REM_ASSIGN:     token.REM,
+	token.AND_ASSIGN:     token.AND,
+	token.OR_ASSIGN:      token.OR,
+	token.XOR_ASSIGN:     token.XOR,
+	token.SHL_ASSIGN:     token.SHL,
+	token.SHR_ASSIGN:     token.SHR,
+	token.AND_NOT_ASSIGN: token.AND_NOT,
+}
+
+// assignOpExpr returns the binary expression that replaces a compound
+// assignment `x op= y` whose operation differs from the JavaScript operator,
+// on a bigint, a number shifted by a bigint count, an integer operation
+// handled by writeIntegerBinaryExpr, a complex number or a string
+// concatenation handled by writeStringBinaryExpr, or whose target is a map
+// element set with $.mapSet, so that it is written as `x = x op y`. It
+// returns nil for other assignments.
+func (c *GoToTSCompiler) assignOpExpr(lhs ast.Expr, tok token.Token, rhs ast.Expr) *ast.BinaryExpr {
+	op, ok := assignOps[tok]
+	if !ok {
+		return nil
+	}
+	bin := &ast.BinaryExpr{X: lhs, OpPos: rhs.Pos(), Op: op, Y: rhs}
+	lhsType := c.pkg.TypesInfo.TypeOf(lhs)
+	isShift := op == token.SHL || op == token.SHR
+	if !c.isBigIntType(lhsType) && !(isShift && c.isBigIntType(c.pkg.TypesInfo.TypeOf(rhs))) &&
+		!c.needsIntegerOp(lhsType, op) && !isComplexType(lhsType) && !c.isStringConcat(bin) &&
+		!c.isMapIndexExpr(lhs) {
+		return nil
+	}
+	return bin
+}
+
+// incDecExpr returns the binary expression that replaces `x++` or `x--` on
+// a bigint, a fixed-width integer, a complex number or a map element, so that
+// it is written as `x = x + 1` with the operation of its type. It returns nil for other expressions.
+func (c *GoToTSCompiler) incDecExpr(stmt *ast.IncDecStmt) *ast.BinaryExpr {
+	typ := c.pkg.TypesInfo.TypeOf(stmt.X)
+	if !c.isBigIntType(typ) && !c.needsIntegerOp(typ, token.ADD) && !isComplexType(typ) && !c.isMapIndexExpr(stmt.X) {
+		return nil
+	}
+	op := token.ADD
+	if stmt.Tok == token.DEC {
+		op = token.SUB
+	}
+	return &ast.BinaryExpr{X: stmt.X, OpPos: stmt.TokPos, Op: op, Y: &ast.BasicLit{ValuePos: stmt.TokPos, Kind: token.INT, Value: "1"}}
+}
+
+// isMapIndexExpr checks if an expression is an index into a map, which is
+// read with $.mapGet and written with $.mapSet.
+func (c *GoToTSCompiler) isMapIndexExpr(expr ast.Expr) bool {
+	indexExpr, ok := ast.Unparen(expr).(*ast.IndexExpr)
+	if !ok {
+		return false
+	}
+	typ := c.pkg.TypesInfo.TypeOf(indexExpr.X)
+	if typ == nil {
+		return false
+	}
+	_, isMap := typ.Underlying().(*types.Map)
+	return isMap
+}
@@ -7,6 +7,8 @@ import (
 	"regexp"
 	"strconv"
 	"strings"
+	"unicode/utf16"
+	"unicode/utf8"
 )
 
 // WriteBasicLit translates a Go basic literal (`ast.BasicLit`) into its
@@ -20,6 +22,16 @@ import (
 //   - Legacy octal literals (e.g., `0777`) are converted to modern TypeScript
 //     octal syntax (e.g., `0o777`) to avoid ES module compatibility issues.
 func (c *GoToTSCompiler) WriteBasicLit(exp *ast.BasicLit) {
+	// Literals used as 64-bit integers represented as bigint
+	if c.writeBigIntConstant(exp) {
+		return
+	}
+
+	// Imaginary literals and other literals used as complex numbers
+	if c.writeComplexConstant(exp) {
+		return
+	}
+
 	if exp.Kind == token.CHAR {
 		// Go char literal 'x' is a rune (int32). Translate to its numeric code point.
 		// Use strconv.UnquoteChar to handle escape sequences correctly.
@@ -76,7 +88,7 @@ func (c *GoToTSCompiler) WriteBasicLit(exp *ast.BasicLit) {
 			// Check if the raw string contains backslashes that would be problematic in template literals
 			if strings.Contains(content, `\`) {
 				// Convert to a regular string literal with proper escaping
-				c.tsw.WriteLiterallyf("%q", content)
+				c.tsw.WriteLiterally(quoteString(content))
 			} else {
 				// No backslashes, safe to use template literal
 				// Escape invalid \x, \u, and \U sequences that would cause TS1125 errors
@@ -84,6 +96,14 @@ func (c *GoToTSCompiler) WriteBasicLit(exp *ast.BasicLit) {
 				// Write as template literal with corrected content
 				c.tsw.WriteLiterallyf("`%s`", content)
 			}
+		} else if byteEscapeRegex.MatchString(value) {
+			// Byte escapes such as \xff or \377 are bytes in Go but code
+			// points (or invalid) in TypeScript, so write the string value
+			// instead.
+			if unquoted, err := strconv.Unquote(value); err == nil {
+				value = quoteString(unquoted)
+			}
+			c.tsw.WriteLiterally(value)
 		} else {
 			// Regular string literal (double quotes) - write as-is
 			c.tsw.WriteLiterally(value)
@@ -107,6 +127,9 @@ func (c *GoToTSCompiler) WriteBasicLit(exp *ast.BasicLit) {
 //   - Wrapped in `Promise<>` if `async`.
 //   - The function body (`exp.Body`) is translated using `WriteStmt`.
 func (c *GoToTSCompiler) WriteFuncLitValue(exp *ast.FuncLit) error {
+	c.writeGotoCapturesStart(exp)
+	defer c.writeGotoCapturesEnd(exp)
+
 	// Check if this function needs reflection metadata
 	needsReflection := c.analysis.NeedsReflectionMetadata(exp)
 
@@ -175,7 +198,12 @@ func (c *GoToTSCompiler) WriteFuncLitValue(exp *ast.FuncLit) error {
 	}
 
 	// Write function body
-	if err := c.WriteStmtBlock(exp.Body, true); err != nil {
+	c.enterFunc(nil)
+	c.enterBody(isAsync)
+	err := c.WriteStmtBlock(exp.Body, true)
+	c.leaveBody()
+	c.leaveFunc()
+	if err != nil {
 		return fmt.Errorf("failed to write block statement: %w", err)
 	}
 
@@ -259,6 +287,37 @@ func (c *GoToTSCompiler) writeReflectionMetadata(exp *ast.FuncLit) error {
 	return nil
 }
 
+// byteEscapeRegex matches the escapes of Go string literals that TypeScript
+// string literals lack or interpret differently.
+var byteEscapeRegex = regexp.MustCompile(`\\[xU0-7a]`)
+
+// quoteString returns a TypeScript string literal holding the bytes of the Go
+// string s. It escapes like strconv.Quote, except that the escapes TypeScript
+// lacks are spelled out and each byte of invalid UTF-8 is written as the lone
+// surrogate U+DC00 + byte, which is how the runtime holds such bytes.
+func quoteString(s string) string {
+	var b strings.Builder
+	b.WriteByte('"')
+	for i := 0; i < len(s); {
+		r, size := utf8.DecodeRuneInString(s[i:])
+		switch {
+		case r == utf8.RuneError && size == 1:
+			fmt.Fprintf(&b, `\u%04x`, 0xdc00|int(s[i]))
+		case r == '\a':
+			b.WriteString(`\x07`)
+		case r > 0xffff && !strconv.IsPrint(r):
+			r1, r2 := utf16.EncodeRune(r)
+			fmt.Fprintf(&b, `\u%04x\u%04x`, r1, r2)
+		default:
+			q := strconv.Quote(s[i : i+size])
+			b.WriteString(q[1 : len(q)-1])
+		}
+		i += size
+	}
+	b.WriteByte('"')
+	return b.String()
+}
+
 // escapeInvalidEscapeSequences escapes \x, \u, and \U sequences in raw strings that would be invalid in JavaScript template literals.
 // JavaScript template literals expect:
 // - \x to be followed by exactly 2 hexadecimal digits
@@ -1,7 +1,9 @@
 package compiler
 
 import (
+	"bytes"
 	"fmt"
+	"os"
 	"path/filepath"
 	"strings"
 
@@ -40,3 +42,21 @@ func TranslateGoFilePathToTypescriptFilePath(goPkgPath, goCodeFilename string) s
 	baseFilename = fmt.Sprintf("%s.gs.ts", baseFilename)
 	return filepath.Join(op, baseFilename)
 }
+
+// writeFileIfChanged writes content to the file at path unless the file
+// already holds exactly that content. Leaving unchanged outputs alone keeps
+// their modification times, so tools watching the output directory only see
+// files that actually changed. An existing file is replaced rather than
+// written through, so links into other trees are not modified.
+func writeFileIfChanged(path string, content []byte) error {
+	existing, err := os.ReadFile(path)
+	if err == nil && bytes.Equal(existing, content) {
+		return nil
+	}
+	if stat, err := os.Lstat(path); err == nil && !stat.IsDir() {
+		if err := os.Remove(path); err != nil {
+			return fmt.Errorf("failed to remove existing file %s: %w", path, err)
+		}
+	}
+	return os.WriteFile(path, content, 0o644)
+}
@@ -1,6 +1,7 @@
 package compiler
 
 import (
+	"bytes"
 	"fmt"
 	"go/ast"
 	"go/token"
@@ -110,7 +111,7 @@ func (c *PackageCompiler) copyProtobufTSFile(sourcePath, fileName string) error
 
 	// Write to output directory
 	outputPath := filepath.Join(c.outputPath, fileName)
-	if err := os.WriteFile(outputPath, content, 0o644); err != nil {
+	if err := writeFileIfChanged(outputPath, content); err != nil {
 		return fmt.Errorf("failed to write protobuf .pb.ts file to %s: %w", outputPath, err)
 	}
 
@@ -118,7 +119,7 @@ func (c *PackageCompiler) copyProtobufTSFile(sourcePath, fileName string) error
 }
 
 // writeProtobufExports writes exports for a protobuf file to the index.ts file
-func (c *PackageCompiler) writeProtobufExports(indexFile *os.File, fileName string) error {
+func (c *PackageCompiler) writeProtobufExports(indexFile *bytes.Buffer, fileName string) error {
 	// For protobuf files, we know they typically export message types
 	// For now, we'll use a simple heuristic: export all types that end with "Msg"
 	// In a full implementation, we would parse the .pb.ts file to extract actual exports
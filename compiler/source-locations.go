@@ -0,0 +1,215 @@
+package compiler
+
+import (
+	"fmt"
+	"go/ast"
+	"go/token"
+	"path"
+	"path/filepath"
+	"strconv"
+	"strings"
+)
+
+// lineTable maps the lines of a generated TypeScript file to the Go lines and
+// functions they were generated from, see Config.SourceLocations. The table
+// is registered with the runtime by the generated file, which maps the frames
+// of JavaScript stack traces back to Go with it.
+type lineTable struct {
+	// pkgName prefixes the function names, like in Go tracebacks.
+	pkgName string
+	// pkgPath prefixes the file names.
+	pkgPath string
+
+	files   []string
+	fileIdx map[string]int
+	funcs   []string
+	funcIdx map[string]int
+	// entries holds a TypeScript line, the Go line and the indexes of the
+	// function and the file for each recorded statement, or -1 indexes for
+	// code without a Go location.
+	entries []int
+
+	// scopes are the functions enclosing the code being written, innermost
+	// last. The first is the package initialization.
+	scopes []*funcScope
+	inits  int
+}
+
+// funcScope is a function of the Go source.
+type funcScope struct {
+	name string
+	// lit reports whether the function is a function literal.
+	lit bool
+	// lits counts the function literals in the function.
+	lits int
+}
+
+// newLineTable creates an empty line table for a file of the package with
+// the given name and path.
+func newLineTable(pkgName, pkgPath string) *lineTable {
+	if pkgName != "main" {
+		pkgName = pkgPath
+	}
+	return &lineTable{
+		pkgName: pkgName,
+		pkgPath: pkgPath,
+		fileIdx: make(map[string]int),
+		funcIdx: make(map[string]int),
+		scopes:  []*funcScope{{name: pkgName + ".init"}},
+	}
+}
+
+// enterFunc records that the code of the function declared by decl is being
+// written, or of the function literal if decl is nil.
+func (c *GoToTSCompiler) enterFunc(decl *ast.FuncDecl) {
+	t := c.lines
+	if t == nil {
+		return
+	}
+	if decl == nil {
+		// Function literals are numbered within the outermost function, like
+		// main.main.func1, and then within each other, like main.main.func1.1.
+		parent := t.scopes[len(t.scopes)-1]
+		parent.lits++
+		name := parent.name + ".func" + strconv.Itoa(parent.lits)
+		if parent.lit {
+			name = parent.name + "." + strconv.Itoa(parent.lits)
+		}
+		t.scopes = append(t.scopes, &funcScope{name: name, lit: true})
+		return
+	}
+
+	name := decl.Name.Name
+	switch {
+	case decl.Recv != nil && len(decl.Recv.List) != 0:
+		recv := decl.Recv.List[0].Type
+		star, isPtr := recv.(*ast.StarExpr)
+		if isPtr {
+			recv = star.X
+		}
+		var typeName string
+		switch r := recv.(type) {
+		case *ast.Ident:
+			typeName = r.Name
+		case *ast.IndexExpr:
+			typeName = fmt.Sprintf("%s[...]", r.X)
+		case *ast.IndexListExpr:
+			typeName = fmt.Sprintf("%s[...]", r.X)
+		}
+		if isPtr {
+			typeName = "(*" + typeName + ")"
+		}
+		name = typeName + "." + name
+	case name == "init":
+		name = "init." + strconv.Itoa(t.inits)
+		t.inits++
+	case decl.Type.TypeParams != nil:
+		name += "[...]"
+	}
+	t.scopes = append(t.scopes, &funcScope{name: t.pkgName + "." + name})
+	c.recordLine(decl.Pos())
+}
+
+// leaveFunc records that the code of the innermost function is written.
+func (c *GoToTSCompiler) leaveFunc() {
+	t := c.lines
+	if t == nil || len(t.scopes) < 2 {
+		return
+	}
+	scope := t.scopes[len(t.scopes)-1]
+	t.scopes = t.scopes[:len(t.scopes)-1]
+	if !scope.lit {
+		// The code following a declared function, such as the rest of a
+		// class, is not part of a Go function.
+		c.clearLine()
+	}
+}
+
+// clearLine records that the code being written has no Go source location.
+func (c *GoToTSCompiler) clearLine() {
+	if c.lines != nil {
+		c.lines.add([4]int{c.tsw.Line(), 0, -1, -1})
+	}
+}
+
+// recordLine records that the code being written is generated from the Go
+// source at pos.
+func (c *GoToTSCompiler) recordLine(pos token.Pos) {
+	t := c.lines
+	if t == nil || !pos.IsValid() {
+		return
+	}
+	position := c.pkg.Fset.Position(pos)
+	file := path.Join(t.pkgPath, filepath.Base(position.Filename))
+	fileIdx, ok := t.fileIdx[file]
+	if !ok {
+		fileIdx = len(t.files)
+		t.files = append(t.files, file)
+		t.fileIdx[file] = fileIdx
+	}
+	fn := t.scopes[len(t.scopes)-1].name
+	funcIdx, ok := t.funcIdx[fn]
+	if !ok {
+		funcIdx = len(t.funcs)
+		t.funcs = append(t.funcs, fn)
+		t.funcIdx[fn] = funcIdx
+	}
+
+	t.add([4]int{c.tsw.Line(), position.Line, funcIdx, fileIdx})
+}
+
+// add adds an entry to the table.
+func (t *lineTable) add(entry [4]int) {
+	n := len(t.entries)
+	if n == 0 && entry[2] < 0 {
+		// Code before the first entry has no Go location anyway.
+		return
+	}
+	if n != 0 {
+		last := t.entries[n-4:]
+		switch {
+		case last[1] == entry[1] && last[2] == entry[2] && last[3] == entry[3]:
+			// The line continues the previous statement.
+			return
+		case last[0] == entry[0]:
+			// A statement nested on the line of the previous one, which it
+			// replaces as the innermost.
+			copy(last, entry[:])
+			return
+		}
+	}
+	t.entries = append(t.entries, entry[:]...)
+}
+
+// registration returns the statement registering the table with the runtime
+// when written at line of the generated file, whose following lines are
+// shifted by shift lines.
+func (t *lineTable) registration(line, shift int) string {
+	var b strings.Builder
+	fmt.Fprintf(&b, "$.registerSourceFile(%d, [", line)
+	for i, file := range t.files {
+		if i != 0 {
+			b.WriteString(", ")
+		}
+		b.WriteString(strconv.Quote(file))
+	}
+	b.WriteString("], [")
+	for i, fn := range t.funcs {
+		if i != 0 {
+			b.WriteString(", ")
+		}
+		b.WriteString(strconv.Quote(fn))
+	}
+	b.WriteString("], [")
+	for i, v := range t.entries {
+		if i != 0 {
+			b.WriteString(", ")
+		}
+		if i%4 == 0 {
+			v += shift
+		}
+		b.WriteString(strconv.Itoa(v))
+	}
+	b.WriteString("])")
+	return b.String()
+}
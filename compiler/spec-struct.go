@@ -426,7 +426,7 @@ func (c *GoToTSCompiler) WriteStructTypeSpec(a *ast.TypeSpec, t *ast.StructType)
 	c.tsw.WriteLine("")
 	c.tsw.WriteLinef("// Register this type with the runtime type system")
 	c.tsw.WriteLinef("static __typeInfo = $.registerStructType(")
-	c.tsw.WriteLinef("  '%s',", className)
+	c.tsw.WriteLinef("  '%s',", qualifiedTypeName(goStructType.Obj()))
 	c.tsw.WriteLinef("  new %s(),", className)
 	c.tsw.WriteLiterally("  [")
 	// Collect methods for the struct type
@@ -449,9 +449,10 @@ func (c *GoToTSCompiler) WriteStructTypeSpec(a *ast.TypeSpec, t *ast.StructType)
 	c.tsw.WriteLine("")
 
 	c.tsw.WriteLinef("  %s,", className)
-	// Add field type information for type assertions
-	c.tsw.WriteLiterally("  {")
-	firstField := true
+	// Add field information for type assertions and reflection
+	// Blank fields have no storage, but are listed so that the layout of the
+	// type matches Go for reflection and encoding/binary
+	c.tsw.WriteLiterally("  [")
 	for i := 0; i < underlyingStruct.NumFields(); i++ {
 		field := underlyingStruct.Field(i)
 		var fieldKeyName string
@@ -460,19 +461,12 @@ func (c *GoToTSCompiler) WriteStructTypeSpec(a *ast.TypeSpec, t *ast.StructType)
 		} else {
 			fieldKeyName = field.Name()
 		}
-		// Skip underscore fields
-		if fieldKeyName == "_" {
-			continue
-		}
-		// fieldTsType := c.getTypeString(field.Type())
-		if !firstField {
+		if i > 0 {
 			c.tsw.WriteLiterally(", ")
 		}
-		firstField = false
-		c.tsw.WriteLiterallyf("%q: ", fieldKeyName)
-		c.writeTypeInfoObject(field.Type()) // Use writeTypeInfoObject for field types
+		c.writeStructFieldInfo(fieldKeyName, field, underlyingStruct.Tag(i))
 	}
-	c.tsw.WriteLiterally("}")
+	c.tsw.WriteLiterally("]")
 	c.tsw.WriteLine("")
 	c.tsw.WriteLinef(");")
 
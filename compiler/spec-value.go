@@ -26,8 +26,8 @@ import (
 //   - It uses TypeScript array destructuring: `let [a, b] = [val1_ts, val2_ts];`.
 //   - If initialized from a single multi-return function call (`a, b := func()`),
 //     it becomes `let [a, b] = func_ts();`.
-//   - If no initializers are provided, it defaults to `let [a,b] = []` (with a TODO
-//     to assign correct individual zero values).
+//   - If no initializers are provided (`var a, b T`), each variable is declared
+//     on its own line with its zero value, as for single variable declarations.
 //
 // Documentation comments associated with the `ValueSpec` are preserved.
 func (c *GoToTSCompiler) WriteValueSpec(a *ast.ValueSpec) error {
@@ -240,7 +240,7 @@ func (c *GoToTSCompiler) WriteValueSpec(a *ast.ValueSpec) error {
 						isWrapperType := c.analysis.IsNamedBasicType(namedType)
 						if isWrapperType {
 							// For wrapper types, no constructor wrapping needed
-							if shouldApplyClone(c.pkg, initializerExpr) {
+							if c.shouldCloneValue(initializerExpr) {
 								if err := c.WriteValueExpr(initializerExpr); err != nil {
 									return err
 								}
@@ -291,7 +291,7 @@ func (c *GoToTSCompiler) WriteValueSpec(a *ast.ValueSpec) error {
 									c.tsw.WriteLiterally(")")
 								} else {
 									// Regular initializer for named type (e.g., function call that returns the type)
-									if shouldApplyClone(c.pkg, initializerExpr) {
+									if c.shouldCloneValue(initializerExpr) {
 										if err := c.WriteValueExpr(initializerExpr); err != nil {
 											return err
 										}
@@ -304,7 +304,7 @@ func (c *GoToTSCompiler) WriteValueSpec(a *ast.ValueSpec) error {
 								}
 							} else {
 								// Named type without methods, handle normally
-								if shouldApplyClone(c.pkg, initializerExpr) {
+								if c.shouldCloneValue(initializerExpr) {
 									if err := c.WriteValueExpr(initializerExpr); err != nil {
 										return err
 									}
@@ -318,7 +318,7 @@ func (c *GoToTSCompiler) WriteValueSpec(a *ast.ValueSpec) error {
 						}
 					} else {
 						// Regular initializer, clone if needed
-						if shouldApplyClone(c.pkg, initializerExpr) {
+						if c.shouldCloneValue(initializerExpr) {
 							if err := c.WriteValueExpr(initializerExpr); err != nil {
 								return err
 							}
@@ -358,6 +358,16 @@ func (c *GoToTSCompiler) WriteValueSpec(a *ast.ValueSpec) error {
 		return nil
 	}
 
+	// Without initializers each variable gets its own zero value (and varRef)
+	if len(a.Values) == 0 {
+		for _, name := range a.Names {
+			if err := c.WriteValueSpec(&ast.ValueSpec{Names: []*ast.Ident{name}, Type: a.Type}); err != nil {
+				return err
+			}
+		}
+		return nil
+	}
+
 	// --- Multi-variable declaration (existing logic seems okay, but less common for pointers) ---
 	c.tsw.WriteLiterally("let ")
 	c.tsw.WriteLiterally("[") // Use array destructuring for multi-assign
@@ -369,32 +379,25 @@ func (c *GoToTSCompiler) WriteValueSpec(a *ast.ValueSpec) error {
 		// TODO: Add type annotations for multi-var declarations if possible/needed
 	}
 	c.tsw.WriteLiterally("]")
-	if len(a.Values) > 0 {
-		// TODO: handle other kinds of assignment += -= etc.
-		c.tsw.WriteLiterally(" = ")
-		if len(a.Values) == 1 && len(a.Names) > 1 {
-			// Assign from a single multi-return value
-			if err := c.WriteValueExpr(a.Values[0]); err != nil {
-				return err
+	// TODO: handle other kinds of assignment += -= etc.
+	c.tsw.WriteLiterally(" = ")
+	if len(a.Values) == 1 && len(a.Names) > 1 {
+		// Assign from a single multi-return value
+		if err := c.WriteValueExpr(a.Values[0]); err != nil {
+			return err
+		}
+	} else {
+		// Assign from multiple values
+		c.tsw.WriteLiterally("[")
+		for i, val := range a.Values {
+			if i != 0 {
+				c.tsw.WriteLiterally(", ")
 			}
-		} else {
-			// Assign from multiple values
-			c.tsw.WriteLiterally("[")
-			for i, val := range a.Values {
-				if i != 0 {
-					c.tsw.WriteLiterally(", ")
-				}
-				if err := c.WriteValueExpr(val); err != nil { // Initializers are values
-					return err
-				}
+			if err := c.WriteValueExpr(val); err != nil { // Initializers are values
+				return err
 			}
-			c.tsw.WriteLiterally("]")
 		}
-	} else {
-		// No initializer, assign default values (complex for multi-assign)
-		// For simplicity, assign default array based on context (needs improvement)
-		c.tsw.WriteLiterally(" = []") // Placeholder
-		// TODO: Assign correct zero values based on types
+		c.tsw.WriteLiterally("]")
 	}
 	c.tsw.WriteLine("") // Use WriteLine instead of WriteLine(";")
 	return nil
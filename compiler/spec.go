@@ -265,12 +265,10 @@ func (c *GoToTSCompiler) writeNamedTypeZeroValue(named *types.Named) {
 		return
 	}
 
-	// For non-struct, non-interface named types, use constructor
-	c.tsw.WriteLiterally("new ")
-	c.WriteNamedType(named)
-	c.tsw.WriteLiterally("(")
+	// Other named types are type aliases of their underlying type
 	c.WriteZeroValueForType(named.Underlying())
-	c.tsw.WriteLiterally(")")
+	c.tsw.WriteLiterally(" as ")
+	c.WriteGoType(named, GoTypeContextGeneral)
 }
 
 func (c *GoToTSCompiler) writeTypeAliasZeroValue(alias *types.Alias, astType ast.Expr) {
@@ -295,17 +293,15 @@ func (c *GoToTSCompiler) writeTypeAliasZeroValue(alias *types.Alias, astType ast
 		return
 	}
 
-	// For non-struct, non-interface type aliases, use constructor
-	c.tsw.WriteLiterally("new ")
+	// Other type aliases are written as the zero value of their underlying type
+	c.WriteZeroValueForType(alias.Underlying())
+	c.tsw.WriteLiterally(" as ")
 	// Use AST type information if available to preserve qualified names
 	if astType != nil {
 		c.WriteTypeExpr(astType)
 	} else {
 		c.WriteGoType(alias, GoTypeContextGeneral)
 	}
-	c.tsw.WriteLiterally("(")
-	c.WriteZeroValueForType(alias.Underlying())
-	c.tsw.WriteLiterally(")")
 }
 
 // hasReceiverMethods checks if a type declaration has any receiver methods defined
@@ -420,6 +416,7 @@ func (c *GoToTSCompiler) WriteNamedTypeWithMethods(a *ast.TypeSpec) error {
 					c.tsw.WriteLiterally(", ")
 					c.WriteFieldList(funcDecl.Type.Params, true) // true = arguments
 				}
+				c.writeDeferFrameParam(funcDecl.Type, true)
 
 				c.tsw.WriteLiterally(")")
 
@@ -446,7 +443,12 @@ func (c *GoToTSCompiler) WriteNamedTypeWithMethods(a *ast.TypeSpec) error {
 				c.tsw.Indent(1)
 
 				// Write method body with receiver as first parameter
-				if err := c.writeWrapperFunctionBody(funcDecl, className); err != nil {
+				c.enterFunc(funcDecl)
+				c.enterBody(false)
+				err := c.writeWrapperFunctionBody(funcDecl, className)
+				c.leaveBody()
+				c.leaveFunc()
+				if err != nil {
 					return err
 				}
 
@@ -457,9 +459,78 @@ func (c *GoToTSCompiler) WriteNamedTypeWithMethods(a *ast.TypeSpec) error {
 		}
 	}
 
+	c.writeNamedTypeRegistration(a)
 	return nil
 }
 
+// writeNamedTypeRegistration registers a named type declared with a type other
+// than a struct or interface, such as `type Status int`, with the runtime type
+// system so type assertions and reflection can resolve it by name.
+func (c *GoToTSCompiler) writeNamedTypeRegistration(a *ast.TypeSpec) {
+	if a.Assign.IsValid() || a.TypeParams != nil {
+		return
+	}
+	obj, ok := c.pkg.TypesInfo.Defs[a.Name].(*types.TypeName)
+	if !ok {
+		return
+	}
+	named, ok := obj.Type().(*types.Named)
+	if !ok {
+		return
+	}
+	underlying := named.Underlying()
+	if _, isStruct := underlying.(*types.Struct); isStruct {
+		return
+	}
+
+	c.tsw.WriteLine("")
+	c.tsw.WriteLinef("$.registerNamedType(")
+	c.tsw.WriteLinef("  '%s',", qualifiedTypeName(obj))
+	c.tsw.WriteLiterally("  ")
+	if _, isBasic := underlying.(*types.Basic); isBasic {
+		c.WriteZeroValueForType(underlying)
+	} else {
+		c.tsw.WriteLiterally("null")
+	}
+	c.tsw.WriteLine(",")
+	var methods []*types.Func
+	for i := range named.NumMethods() {
+		methods = append(methods, named.Method(i))
+	}
+	c.tsw.WriteLiterally("  [")
+	c.writeMethodSignatures(methods)
+	c.tsw.WriteLine("],")
+	c.tsw.WriteLiterally("  ")
+	if basic, isBasic := underlying.(*types.Basic); isBasic {
+		// Use the Go type name, so reflection can tell the kinds of numbers apart
+		c.tsw.WriteLiterallyf("{ kind: $.TypeKind.Basic, name: %q }", types.Typ[basic.Kind()].Name())
+	} else {
+		c.writeTypeInfoObject(underlying)
+	}
+	// The values of the type do not carry its methods, so pass the functions
+	// implementing the methods with value receivers, see WriteNamedTypeWithMethods.
+	var valueMethods []string
+	for _, method := range methods {
+		recv := method.Type().(*types.Signature).Recv()
+		if _, isPtr := recv.Type().(*types.Pointer); !isPtr {
+			valueMethods = append(valueMethods, method.Name())
+		}
+	}
+	if len(valueMethods) != 0 && c.hasReceiverMethods(a.Name.Name) {
+		c.tsw.WriteLine(",")
+		c.tsw.WriteLiterally("  { ")
+		for i, name := range valueMethods {
+			if i != 0 {
+				c.tsw.WriteLiterally(", ")
+			}
+			c.tsw.WriteLiterallyf("%s: %s_%s", name, a.Name.Name, name)
+		}
+		c.tsw.WriteLiterally(" }")
+	}
+	c.tsw.WriteLine("")
+	c.tsw.WriteLinef(");")
+}
+
 // writeWrapperFunctionBody writes the body of a wrapper function, treating the receiver as the first parameter
 func (c *GoToTSCompiler) writeWrapperFunctionBody(decl *ast.FuncDecl, typeName string) error {
 	// Write function body statements directly - identifier mapping is handled by pre-computed analysis
@@ -515,6 +586,7 @@ func (c *GoToTSCompiler) WriteTypeSpec(a *ast.TypeSpec) error {
 		c.tsw.WriteLiterally(" = ")
 		c.WriteTypeExpr(a.Type) // The aliased type
 		c.tsw.WriteLine(";")
+		c.writeNamedTypeRegistration(a)
 	}
 	return nil
 }
@@ -558,6 +630,9 @@ func (c *GoToTSCompiler) WriteInterfaceTypeSpec(a *ast.TypeSpec, t *ast.Interfac
 
 	// Add code to register the interface with the runtime system
 	interfaceName := a.Name.Name
+	if obj, ok := c.pkg.TypesInfo.Defs[a.Name].(*types.TypeName); ok {
+		interfaceName = qualifiedTypeName(obj)
+	}
 	c.tsw.WriteLine("")
 	c.tsw.WriteLinef("$.registerInterfaceType(")
 	c.tsw.WriteLinef("  '%s',", interfaceName)
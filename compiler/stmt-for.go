@@ -24,7 +24,10 @@ import (
 func (c *GoToTSCompiler) WriteStmtFor(exp *ast.ForStmt) error {
 	c.tsw.WriteLiterally("for (")
 	if exp.Init != nil {
-		if err := c.WriteStmtForInit(exp.Init); err != nil { // Use WriteStmtForInit
+		c.forHeader = true
+		err := c.WriteStmtForInit(exp.Init) // Use WriteStmtForInit
+		c.forHeader = false
+		if err != nil {
 			return fmt.Errorf("failed to write for loop initialization: %w", err)
 		}
 	}
@@ -36,11 +39,15 @@ func (c *GoToTSCompiler) WriteStmtFor(exp *ast.ForStmt) error {
 	}
 	c.tsw.WriteLiterally("; ")
 	if exp.Post != nil {
-		if err := c.WriteStmtForPost(exp.Post); err != nil { // Use WriteStmtForPost
+		c.forHeader = true
+		err := c.WriteStmtForPost(exp.Post) // Use WriteStmtForPost
+		c.forHeader = false
+		if err != nil {
 			return fmt.Errorf("failed to write for loop post statement: %w", err)
 		}
 	}
 	c.tsw.WriteLiterally(") ")
+	c.markLoopBody(exp.Body)
 	if err := c.WriteStmtBlock(exp.Body, false); err != nil {
 		return fmt.Errorf("failed to write for loop body: %w", err)
 	}
@@ -213,6 +220,11 @@ func (c *GoToTSCompiler) writeDestructuringValue(expr ast.Expr) error {
 func (c *GoToTSCompiler) WriteStmtForPost(stmt ast.Stmt) error {
 	switch s := stmt.(type) {
 	case *ast.IncDecStmt:
+		// Increments of bigints and fixed-width integers are written as
+		// `i = i + 1` to wrap the result
+		if bin := c.incDecExpr(s); bin != nil {
+			return c.writeOpAssign(s.X, bin)
+		}
 		// Handle increment/decrement (e.g., i++)
 		if err := c.WriteValueExpr(s.X); err != nil { // The expression (e.g., i)
 			return err
@@ -0,0 +1,330 @@
+package compiler
+
+import (
+	"go/ast"
+	"go/token"
+	"go/types"
+
+	"github.com/pkg/errors"
+)
+
+// writeGotoBlockStart opens the state machine of a block containing the
+// targets of goto statements. The block is lowered into a labeled loop around
+// a switch with one case per target label; execution starts at state 0 and
+// falls through the cases in source order:
+//
+//	let __goto0 = 0
+//	__gotoLoop0: for (;;) {
+//		switch (__goto0) {
+//			case 0:
+//				...statements before the first label...
+//			case 1: // label
+//				...statements after the label...
+//		}
+//		break
+//	}
+//
+// A goto sets the state variable and continues the loop (see WriteStmtBranch).
+// Variables declared directly in the block are hoisted above the loop, since a
+// jump re-enters the switch and would otherwise lose their bindings. The
+// statements are those of a block or of a case clause body.
+func (c *GoToTSCompiler) writeGotoBlockStart(stmts []ast.Stmt, info *GotoBlockInfo) {
+	for _, obj := range gotoBlockVars(stmts, c.pkg.TypesInfo) {
+		c.tsw.WriteLiterallyf("let %s: ", c.sanitizeIdentifier(obj.Name()))
+		if c.analysis.NeedsVarRef(obj) {
+			c.tsw.WriteLiterally("$.VarRef<")
+			c.WriteGoType(obj.Type(), GoTypeContextGeneral)
+			c.tsw.WriteLiterally("> = $.varRef(")
+			c.WriteZeroValueForType(obj.Type())
+			c.tsw.WriteLiterally(")")
+		} else {
+			c.WriteGoType(obj.Type(), GoTypeContextGeneral)
+			c.tsw.WriteLiterally(" = ")
+			c.WriteZeroValueForType(obj.Type())
+		}
+		c.tsw.WriteLine("")
+	}
+
+	c.tsw.WriteLinef("let %s = 0", info.StateVar)
+	c.tsw.WriteLinef("%s: for (;;) {", info.LoopLabel)
+	c.tsw.Indent(1)
+	c.tsw.WriteLinef("switch (%s) {", info.StateVar)
+	c.tsw.Indent(1)
+	c.tsw.WriteLine("case 0:")
+	c.tsw.Indent(1)
+}
+
+// gotoTargetState returns the state of a statement labeled with a goto
+// target in the given state machine, or 0 if it is not a target.
+func (c *GoToTSCompiler) gotoTargetState(stmt ast.Stmt, info *GotoBlockInfo) int {
+	labeled, ok := stmt.(*ast.LabeledStmt)
+	if !ok {
+		return 0
+	}
+	label, ok := c.pkg.TypesInfo.Defs[labeled.Label].(*types.Label)
+	if !ok {
+		return 0
+	}
+	return info.States[label]
+}
+
+// writeGotoBlockCase starts a new case of the state machine if the statement
+// is labeled with a goto target.
+func (c *GoToTSCompiler) writeGotoBlockCase(stmt ast.Stmt, info *GotoBlockInfo) {
+	if state := c.gotoTargetState(stmt, info); state != 0 {
+		c.tsw.Indent(-1)
+		c.tsw.WriteLinef("case %d: // %s", state, stmt.(*ast.LabeledStmt).Label.Name)
+		c.tsw.Indent(1)
+	}
+}
+
+// writeGotoBlockStmt writes a statement of a block lowered into a state
+// machine. Declarations of hoisted variables become assignments, and goto
+// target labels are dropped unless a break or continue may refer to them.
+func (c *GoToTSCompiler) writeGotoBlockStmt(stmt ast.Stmt, info *GotoBlockInfo) error {
+	if c.gotoTargetState(stmt, info) != 0 {
+		labeled := stmt.(*ast.LabeledStmt)
+		switch labeled.Stmt.(type) {
+		case *ast.ForStmt, *ast.RangeStmt, *ast.SwitchStmt, *ast.TypeSwitchStmt, *ast.SelectStmt:
+			return c.WriteStmt(labeled)
+		case *ast.EmptyStmt:
+			return nil
+		}
+		stmt = labeled.Stmt
+	}
+
+	switch s := stmt.(type) {
+	case *ast.AssignStmt:
+		if s.Tok == token.DEFINE {
+			for _, lhs := range s.Lhs {
+				if ident, ok := lhs.(*ast.Ident); ok {
+					c.writeGotoBlockRebind(ident)
+				}
+			}
+			return c.WriteStmt(&ast.AssignStmt{
+				Lhs:    s.Lhs,
+				TokPos: s.TokPos,
+				Tok:    token.ASSIGN,
+				Rhs:    s.Rhs,
+			})
+		}
+	case *ast.DeclStmt:
+		if genDecl, ok := s.Decl.(*ast.GenDecl); ok && genDecl.Tok == token.VAR {
+			for _, spec := range genDecl.Specs {
+				if err := c.writeGotoBlockVarSpec(spec.(*ast.ValueSpec)); err != nil {
+					return err
+				}
+			}
+			return nil
+		}
+	}
+	return c.WriteStmt(stmt)
+}
+
+// writeGotoBlockVarSpec writes a hoisted var declaration as an assignment of
+// its initial values, or of the zero value if it has none.
+func (c *GoToTSCompiler) writeGotoBlockVarSpec(spec *ast.ValueSpec) error {
+	if len(spec.Values) != 0 {
+		lhs := make([]ast.Expr, len(spec.Names))
+		for i, name := range spec.Names {
+			lhs[i] = name
+			c.writeGotoBlockRebind(name)
+		}
+		return c.WriteStmt(&ast.AssignStmt{
+			Lhs:    lhs,
+			TokPos: spec.Pos(),
+			Tok:    token.ASSIGN,
+			Rhs:    spec.Values,
+		})
+	}
+
+	for _, name := range spec.Names {
+		obj := c.pkg.TypesInfo.Defs[name]
+		if obj == nil || name.Name == "_" {
+			continue
+		}
+		c.tsw.WriteLiterallyf("%s = ", c.sanitizeIdentifier(name.Name))
+		if c.analysis.NeedsVarRef(obj) {
+			c.tsw.WriteLiterally("$.varRef(")
+			c.WriteZeroValueForType(obj.Type())
+			c.tsw.WriteLiterally(")")
+		} else {
+			c.WriteZeroValueForType(obj.Type())
+		}
+		c.tsw.WriteLine("")
+	}
+	return nil
+}
+
+// writeGotoBlockRebind gives a hoisted variable declared by ident a new
+// VarRef if function literals capture it, since each execution of a
+// declaration creates a new variable in Go. The value is assigned after.
+func (c *GoToTSCompiler) writeGotoBlockRebind(ident *ast.Ident) {
+	obj := c.pkg.TypesInfo.Defs[ident]
+	if obj == nil || ident.Name == "_" || !c.analysis.gotoCapturedVars[obj] {
+		return
+	}
+	c.tsw.WriteLiterallyf("%s = $.varRef(", c.sanitizeIdentifier(ident.Name))
+	c.WriteZeroValueForType(obj.Type())
+	c.tsw.WriteLine(")")
+}
+
+// writeGotoCapturesStart starts binding the hoisted variables captured by
+// lit, if any, as parameters of an arrow function returning it, so that lit
+// keeps the VarRefs current when it is created:
+//
+//	((j: $.VarRef<number>) => () => j!.value)(j)
+func (c *GoToTSCompiler) writeGotoCapturesStart(lit *ast.FuncLit) {
+	vars := c.analysis.gotoCaptures[lit]
+	if len(vars) == 0 {
+		return
+	}
+	c.tsw.WriteLiterally("((")
+	for i, obj := range vars {
+		if i != 0 {
+			c.tsw.WriteLiterally(", ")
+		}
+		c.tsw.WriteLiterallyf("%s: $.VarRef<", c.sanitizeIdentifier(obj.Name()))
+		c.WriteGoType(obj.Type(), GoTypeContextGeneral)
+		c.tsw.WriteLiterally(">")
+	}
+	c.tsw.WriteLiterally(") => ")
+}
+
+// writeGotoCapturesEnd ends the binding started by writeGotoCapturesStart.
+func (c *GoToTSCompiler) writeGotoCapturesEnd(lit *ast.FuncLit) {
+	vars := c.analysis.gotoCaptures[lit]
+	if len(vars) == 0 {
+		return
+	}
+	c.tsw.WriteLiterally(")(")
+	for i, obj := range vars {
+		if i != 0 {
+			c.tsw.WriteLiterally(", ")
+		}
+		c.tsw.WriteLiterally(c.sanitizeIdentifier(obj.Name()))
+	}
+	c.tsw.WriteLiterally(")")
+}
+
+// writeGotoBlockStmts writes the statements of a case clause body, as a
+// state machine if it contains goto targets.
+func (c *GoToTSCompiler) writeGotoBlockStmts(body ast.Node, stmts []ast.Stmt) error {
+	var info *GotoBlockInfo
+	if nodeInfo := c.analysis.NodeData[body]; nodeInfo != nil {
+		info = nodeInfo.GotoBlock
+	}
+	if info == nil {
+		for _, stmt := range stmts {
+			if err := c.WriteStmt(stmt); err != nil {
+				return err
+			}
+		}
+		return nil
+	}
+
+	c.writeGotoBlockStart(stmts, info)
+	for _, stmt := range stmts {
+		c.writeGotoBlockCase(stmt, info)
+		if err := c.writeGotoBlockStmt(stmt, info); err != nil {
+			return err
+		}
+	}
+	c.writeGotoBlockEnd(stmts)
+	return nil
+}
+
+// writeGotoBlockEnd closes the state machine opened by writeGotoBlockStart.
+// The loop exits after the last case, unless the block cannot complete
+// normally, in which case the exit is omitted so TypeScript sees it too.
+func (c *GoToTSCompiler) writeGotoBlockEnd(stmts []ast.Stmt) {
+	c.tsw.Indent(-2)
+	c.tsw.WriteLine("}")
+	if !isGotoBlockTerminated(stmts) {
+		c.tsw.WriteLine("break")
+	}
+	c.tsw.Indent(-1)
+	c.tsw.WriteLine("}")
+}
+
+// writeGotoJump writes a goto statement as a jump to the state of its target.
+func (c *GoToTSCompiler) writeGotoJump(stmt *ast.BranchStmt) error {
+	var info *GotoBlockInfo
+	if nodeInfo := c.analysis.NodeData[stmt]; nodeInfo != nil {
+		info = nodeInfo.GotoBlock
+	}
+	label, _ := c.pkg.TypesInfo.Uses[stmt.Label].(*types.Label)
+	if info == nil || label == nil || info.States[label] == 0 {
+		return errors.Errorf("unsupported goto target: %s", stmt.Label.Name)
+	}
+	c.tsw.WriteLinef("%s = %d", info.StateVar, info.States[label])
+	c.tsw.WriteLinef("continue %s", info.LoopLabel)
+	return nil
+}
+
+// gotoBlockVars returns the variables declared directly by the statements
+// of a block lowered into a state machine, which are hoisted out of it.
+func gotoBlockVars(stmts []ast.Stmt, info *types.Info) []types.Object {
+	var vars []types.Object
+	addVar := func(ident *ast.Ident) {
+		if ident.Name == "_" {
+			return
+		}
+		if obj := info.Defs[ident]; obj != nil {
+			vars = append(vars, obj)
+		}
+	}
+
+	for _, stmt := range stmts {
+		if labeled, ok := stmt.(*ast.LabeledStmt); ok {
+			stmt = labeled.Stmt
+		}
+		switch s := stmt.(type) {
+		case *ast.AssignStmt:
+			if s.Tok != token.DEFINE {
+				continue
+			}
+			for _, lhs := range s.Lhs {
+				if ident, ok := lhs.(*ast.Ident); ok {
+					addVar(ident)
+				}
+			}
+		case *ast.DeclStmt:
+			genDecl, ok := s.Decl.(*ast.GenDecl)
+			if !ok || genDecl.Tok != token.VAR {
+				continue
+			}
+			for _, spec := range genDecl.Specs {
+				for _, name := range spec.(*ast.ValueSpec).Names {
+					addVar(name)
+				}
+			}
+		}
+	}
+	return vars
+}
+
+// isGotoBlockTerminated checks if the last statement of a block never
+// completes normally: a return, a goto, or a call to panic.
+func isGotoBlockTerminated(stmts []ast.Stmt) bool {
+	if len(stmts) == 0 {
+		return false
+	}
+	last := stmts[len(stmts)-1]
+	if labeled, ok := last.(*ast.LabeledStmt); ok {
+		last = labeled.Stmt
+	}
+	switch s := last.(type) {
+	case *ast.ReturnStmt:
+		return true
+	case *ast.BranchStmt:
+		return s.Tok == token.GOTO
+	case *ast.ExprStmt:
+		if call, ok := s.X.(*ast.CallExpr); ok {
+			if ident, ok := call.Fun.(*ast.Ident); ok && ident.Name == "panic" {
+				return true
+			}
+		}
+	}
+	return false
+}
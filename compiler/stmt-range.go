@@ -13,15 +13,14 @@ import (
 // the expression being ranged over (`exp.X`), determined using `go/types` info.
 //
 //   - **Maps (`*types.Map`):**
-//     `for k, v := range myMap` becomes `for (const [k_ts, v_ts] of myMap_ts.entries()) { const k = k_ts; const v = v_ts; ...body... }`.
+//     `for k, v := range myMap` becomes `for (const [k_ts, v_ts] of $.mapEntries(myMap_ts)) { const k = k_ts; const v = v_ts; ...body... }`.
+//     The runtime may randomize the iteration order like Go (see setMapIterationSeed).
 //     If only `k` or `v` (or neither) is used, the corresponding TypeScript const declaration is adjusted.
 //
 //   - **Strings (`*types.Basic` with `IsString` info):**
-//     `for i, r := range myString` becomes:
-//     `const _runes = $.stringToRunes(myString_ts);`
-//     `for (let i_ts = 0; i_ts < _runes.length; i_ts++) { const r_ts = _runes[i_ts]; ...body... }`.
-//     The index variable `i_ts` uses the Go key variable name if provided (and not `_`).
-//     The rune variable `r_ts` uses the Go value variable name.
+//     `for i, r := range myString` becomes `for (const [i_ts, r_ts] of $.rangeString(myString_ts)) { ...body... }`.
+//     `$.rangeString` yields the byte index and the rune of each UTF-8 sequence,
+//     and U+FFFD for each byte of invalid UTF-8, like Go.
 //
 //   - **Integers (`*types.Basic` with `IsInteger` info, Go 1.22+):**
 //     `for i := range N` becomes `for (let i_ts = 0; i_ts < N_ts; i_ts++) { ...body... }`.
@@ -42,6 +41,8 @@ import (
 // translated using `WriteStmtBlock` (or `WriteStmt` for array/slice with key and value).
 // If the ranged type is not supported, a comment is written, and an error is returned.
 func (c *GoToTSCompiler) WriteStmtRange(exp *ast.RangeStmt) error {
+	c.markLoopBody(exp.Body)
+
 	// Get the type of the iterable expression
 	iterType := c.pkg.TypesInfo.TypeOf(exp.X)
 	underlying := iterType.Underlying()
@@ -153,11 +154,11 @@ func (c *GoToTSCompiler) writeMapRange(exp *ast.RangeStmt) error {
 		}
 	}
 
-	c.tsw.WriteLiterallyf("for (const [%s, %s] of ", keyVarName, valueVarName)
+	c.tsw.WriteLiterallyf("for (const [%s, %s] of $.mapEntries(", keyVarName, valueVarName)
 	if err := c.WriteValueExpr(exp.X); err != nil {
 		return fmt.Errorf("failed to write range loop map expression: %w", err)
 	}
-	c.tsw.WriteLiterally("?.entries() ?? []) {")
+	c.tsw.WriteLiterally(")) {")
 	c.tsw.Indent(1)
 	c.tsw.WriteLine("")
 
@@ -170,37 +171,33 @@ func (c *GoToTSCompiler) writeMapRange(exp *ast.RangeStmt) error {
 }
 
 func (c *GoToTSCompiler) writeStringRange(exp *ast.RangeStmt) error {
-	c.tsw.WriteLine("{")
-	c.tsw.Indent(1)
+	keyVarName := "_i"
+	valueVarName := "_r"
 
-	c.tsw.WriteLiterally("const _runes = $.stringToRunes(")
-	if err := c.WriteValueExpr(exp.X); err != nil {
-		return fmt.Errorf("failed to write range loop string conversion expression: %w", err)
+	if exp.Key != nil {
+		if ident, ok := exp.Key.(*ast.Ident); ok && ident.Name != "_" {
+			keyVarName = c.sanitizeIdentifier(ident.Name)
+		}
 	}
-	c.tsw.WriteLiterally(")")
-	c.tsw.WriteLine("")
-
-	indexVarName := c.getIndexVarName(exp, "i")
-	c.tsw.WriteLiterallyf("for (let %s = 0; %s < _runes.length; %s++) {", indexVarName, indexVarName, indexVarName)
-	c.tsw.Indent(1)
-	c.tsw.WriteLine("")
-
 	if exp.Value != nil {
 		if ident, ok := exp.Value.(*ast.Ident); ok && ident.Name != "_" {
-			c.tsw.WriteLiterally("const ")
-			c.WriteIdent(ident, false)
-			c.tsw.WriteLiterally(" = _runes[i]") // TODO: should be indexVarName?
-			c.tsw.WriteLine("")
+			valueVarName = c.sanitizeIdentifier(ident.Name)
 		}
 	}
 
+	c.tsw.WriteLiterallyf("for (const [%s, %s] of $.rangeString(", keyVarName, valueVarName)
+	if err := c.WriteValueExpr(exp.X); err != nil {
+		return fmt.Errorf("failed to write range loop string expression: %w", err)
+	}
+	c.tsw.WriteLiterally(")) {")
+	c.tsw.Indent(1)
+	c.tsw.WriteLine("")
+
 	if err := c.WriteStmtBlock(exp.Body, false); err != nil {
 		return fmt.Errorf("failed to write range loop string body: %w", err)
 	}
 	c.tsw.Indent(-1)
 	c.tsw.WriteLine("}")
-	c.tsw.Indent(-1)
-	c.tsw.WriteLine("}")
 	return nil
 }
 
@@ -352,7 +349,10 @@ func (c *GoToTSCompiler) writeIteratorRange(exp *ast.RangeStmt, sig *types.Signa
 
 	c.tsw.Indent(1)
 	c.tsw.WriteLine("")
-	if err := c.WriteStmtBlock(exp.Body, false); err != nil {
+	c.enterBody(false)
+	err := c.WriteStmtBlock(exp.Body, false)
+	c.leaveBody()
+	if err != nil {
 		return fmt.Errorf("failed to write iterator body: %w", err)
 	}
 	c.tsw.WriteLiterally("return shouldContinue")
@@ -406,7 +406,10 @@ func (c *GoToTSCompiler) writeInterfaceIteratorRange(exp *ast.RangeStmt) error {
 
 	c.tsw.Indent(1)
 	c.tsw.WriteLine("")
-	if err := c.WriteStmtBlock(exp.Body, false); err != nil {
+	c.enterBody(false)
+	err := c.WriteStmtBlock(exp.Body, false)
+	c.leaveBody()
+	if err != nil {
 		return fmt.Errorf("failed to write interface iterator body: %w", err)
 	}
 	c.tsw.WriteLiterally("return shouldContinue")
@@ -96,10 +96,8 @@ func (c *GoToTSCompiler) WriteStmtSelect(exp *ast.SelectStmt) error {
 				c.tsw.Indent(1)
 				c.tsw.WriteLine("")
 				// Write the case body
-				for _, bodyStmt := range commClause.Body {
-					if err := c.WriteStmt(bodyStmt); err != nil {
-						return fmt.Errorf("failed to write statement in select default case body (onSelected): %w", err)
-					}
+				if err := c.writeGotoBlockStmts(commClause, commClause.Body); err != nil {
+					return fmt.Errorf("failed to write statement in select default case body (onSelected): %w", err)
 				}
 				c.tsw.Indent(-1)
 				c.tsw.WriteLine("}") // Close onSelected handler
@@ -216,10 +214,8 @@ func (c *GoToTSCompiler) WriteStmtSelect(exp *ast.SelectStmt) error {
 			// as the operation was already performed by selectReceive/selectSend and the result is in 'result'.
 
 			// Write the case body
-			for _, bodyStmt := range commClause.Body {
-				if err := c.WriteStmt(bodyStmt); err != nil {
-					return fmt.Errorf("failed to write statement in select case body (onSelected): %w", err)
-				}
+			if err := c.writeGotoBlockStmts(commClause, commClause.Body); err != nil {
+				return fmt.Errorf("failed to write statement in select case body (onSelected): %w", err)
 			}
 
 			c.tsw.Indent(-1)
@@ -66,6 +66,7 @@ func (c *GoToTSCompiler) WriteStmtTypeSwitch(stmt *ast.TypeSwitchStmt) error {
 	c.tsw.WriteLiterally(", [")
 
 	stmtBodyList := stmt.Body.List
+	var defaultClause *ast.CaseClause
 	var defaultCaseBody []ast.Stmt
 
 	for i, caseClauseStmt := range stmtBodyList {
@@ -75,6 +76,7 @@ func (c *GoToTSCompiler) WriteStmtTypeSwitch(stmt *ast.TypeSwitchStmt) error {
 		}
 
 		if len(caseClause.List) == 0 { // Default case
+			defaultClause = caseClause
 			defaultCaseBody = caseClause.Body
 			continue // Process default case after type cases
 		}
@@ -108,10 +110,11 @@ func (c *GoToTSCompiler) WriteStmtTypeSwitch(stmt *ast.TypeSwitchStmt) error {
 			c.tsw.WriteLine("")
 		}
 
-		for _, bodyStmt := range caseClauseBody {
-			if err := c.WriteStmt(bodyStmt); err != nil {
-				return fmt.Errorf("failed to write statement in type switch case body: %w", err)
-			}
+		c.enterBody(false)
+		err := c.writeGotoBlockStmts(caseClause, caseClauseBody)
+		c.leaveBody()
+		if err != nil {
+			return fmt.Errorf("failed to write statement in type switch case body: %w", err)
 		}
 
 		if len(caseClauseBody) != 0 {
@@ -128,10 +131,11 @@ func (c *GoToTSCompiler) WriteStmtTypeSwitch(stmt *ast.TypeSwitchStmt) error {
 		c.tsw.WriteLiterally(", () => {")
 		c.tsw.Indent(1)
 		c.tsw.WriteLine("")
-		for _, bodyStmt := range defaultCaseBody {
-			if err := c.WriteStmt(bodyStmt); err != nil {
-				return fmt.Errorf("failed to write statement in type switch default case body: %w", err)
-			}
+		c.enterBody(false)
+		err := c.writeGotoBlockStmts(defaultClause, defaultCaseBody)
+		c.leaveBody()
+		if err != nil {
+			return fmt.Errorf("failed to write statement in type switch default case body: %w", err)
 		}
 		c.tsw.Indent(-1)
 		c.tsw.WriteLiterally("}") // Close default case function
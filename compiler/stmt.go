@@ -5,6 +5,7 @@ import (
 	"go/ast"
 	"go/token"
 	"go/types"
+	"strconv"
 	"strings"
 
 	"github.com/pkg/errors"
@@ -34,6 +35,13 @@ import (
 //
 // If an unknown statement type is encountered, it returns an error.
 func (c *GoToTSCompiler) WriteStmt(a ast.Stmt) error {
+	c.recordLine(a.Pos())
+
+	// Label loops and switches targeted by branches escaping a goto state machine
+	if nodeInfo := c.analysis.NodeData[a]; nodeInfo != nil && nodeInfo.SyntheticLabel != "" {
+		c.tsw.WriteLiterallyf("%s: ", nodeInfo.SyntheticLabel)
+	}
+
 	switch exp := a.(type) {
 	case *ast.BlockStmt:
 		if err := c.WriteStmtBlock(exp, false); err != nil {
@@ -151,6 +159,15 @@ func (c *GoToTSCompiler) WriteStmtDecl(stmt *ast.DeclStmt) error {
 // WriteStmtIncDec handles increment and decrement statements (`ast.IncDecStmt`).
 // It writes the expression followed by `++` or `--`.
 func (c *GoToTSCompiler) WriteStmtIncDec(stmt *ast.IncDecStmt) error {
+	// Increments of bigints and fixed-width integers are written as
+	// `x = x + 1` to wrap the result
+	if bin := c.incDecExpr(stmt); bin != nil {
+		if err := c.writeOpAssign(stmt.X, bin); err != nil {
+			return err
+		}
+		c.tsw.WriteLine("")
+		return nil
+	}
 	if err := c.WriteValueExpr(stmt.X); err != nil { // The expression (e.g., i)
 		return fmt.Errorf("failed to write increment/decrement expression: %w", err)
 	}
@@ -163,17 +180,27 @@ func (c *GoToTSCompiler) WriteStmtIncDec(stmt *ast.IncDecStmt) error {
 	return nil
 }
 
-// WriteStmtBranch handles branch statements (`ast.BranchStmt`), such as `break` and `continue`.
+// WriteStmtBranch handles branch statements (`ast.BranchStmt`), such as `break`, `continue`
+// and `goto`. A `goto` jumps to its target within the enclosing goto state machine
+// (see `writeGotoBlockStart`).
 func (c *GoToTSCompiler) WriteStmtBranch(stmt *ast.BranchStmt) error {
 	switch stmt.Tok {
-	case token.BREAK:
-		c.tsw.WriteLine("break") // No semicolon needed
-	case token.CONTINUE:
-		c.tsw.WriteLine("continue") // No semicolon needed
+	case token.BREAK, token.CONTINUE:
+		// Keep the label, or use the one added when the branch must escape a goto state machine
+		label := ""
+		if stmt.Label != nil {
+			label = stmt.Label.Name
+		} else if nodeInfo := c.analysis.NodeData[stmt]; nodeInfo != nil {
+			label = nodeInfo.BranchLabel
+		}
+		if label != "" {
+			c.tsw.WriteLinef("%s %s", stmt.Tok.String(), label)
+		} else {
+			c.tsw.WriteLine(stmt.Tok.String()) // No semicolon needed
+		}
 	case token.GOTO:
-		// TypeScript doesn't support goto, but we can handle it by skipping it
-		// since the labeled statement restructuring should handle the control flow
-		c.tsw.WriteCommentLinef("goto %s // goto statement skipped", stmt.Label.Name)
+		// Jump to the target's state in the enclosing goto state machine
+		return c.writeGotoJump(stmt)
 	case token.FALLTHROUGH:
 		// Fallthrough is handled in switch statements, should not appear elsewhere
 		c.tsw.WriteCommentLinef("fallthrough // fallthrough statement skipped")
@@ -187,159 +214,208 @@ func (c *GoToTSCompiler) WriteStmtBranch(stmt *ast.BranchStmt) error {
 
 // WriteStmtGo translates a Go statement (`ast.GoStmt`) into its TypeScript equivalent.
 // It handles `go func(){...}()`, `go namedFunc(args)`, and `go x.Method(args)`.
+// The goroutine is started with `$.go`, which registers it with the runtime
+// scheduler along with the location of the go statement.
 func (c *GoToTSCompiler) WriteStmtGo(exp *ast.GoStmt) error {
 	// Handle goroutine statement
-	// Translate 'go func() { ... }()' to 'queueMicrotask(() => { ... compiled body ... })'
+	// Translate 'go func() { ... }()' to '$.go(() => { ... compiled body ... }, "file.go:line")'
+	// and 'go func(x T) { ... }(v)' to '$.go(((x: T) => () => { ... })(v), "file.go:line")'
 	callExpr := exp.Call
+	location := strconv.Quote(c.sourceLocation(exp.Go))
 
 	switch fun := callExpr.Fun.(type) {
 	case *ast.FuncLit:
 		// For function literals, we need to check if the function literal itself is async
 		// This happens during analysis in analysisVisitor.Visit for FuncLit nodes
 		isAsync := c.analysis.IsFuncLitAsync(fun)
+		c.tsw.WriteLiterally("$.go(")
+		c.writeGotoCapturesStart(fun)
+		// Arguments are evaluated at the go statement, so the parameters are
+		// bound by an immediately applied arrow returning the goroutine body
+		hasParams := fun.Type.Params.NumFields() != 0
+		if hasParams {
+			c.tsw.WriteLiterally("((")
+			c.WriteFieldList(fun.Type.Params, true)
+			c.tsw.WriteLiterally(") => ")
+		}
 		if isAsync {
-			c.tsw.WriteLiterally("queueMicrotask(async () => ")
+			c.tsw.WriteLiterally("async () => ")
 		} else {
-			c.tsw.WriteLiterally("queueMicrotask(() => ")
+			c.tsw.WriteLiterally("() => ")
 		}
 
 		// Compile the function literal's body directly
-		if err := c.WriteStmtBlock(fun.Body, true); err != nil {
+		c.enterFunc(nil)
+		c.enterBody(isAsync)
+		err := c.WriteStmtBlock(fun.Body, true)
+		c.leaveBody()
+		c.leaveFunc()
+		if err != nil {
 			return fmt.Errorf("failed to write goroutine function literal body: %w", err)
 		}
+		if hasParams {
+			c.tsw.WriteLiterally(")")
+			if err := c.writeCallArguments(callExpr); err != nil {
+				return fmt.Errorf("failed to write goroutine function literal arguments: %w", err)
+			}
+		}
+		c.writeGotoCapturesEnd(fun)
 
-		c.tsw.WriteLine(")") // Close the queueMicrotask statement
+		c.tsw.WriteLinef(", %s)", location) // Close the $.go statement
 
+	case *ast.Ident, *ast.SelectorExpr, *ast.TypeAssertExpr:
+		// Handle named functions, methods and function values:
+		// go namedFunc(args), go x.Method(args) and go x.(func())()
+		return c.writeGoCall(callExpr, location)
+	default:
+		return errors.Errorf("unhandled goroutine function type: %T", callExpr.Fun)
+	}
+	return nil
+}
+
+// writeGoCall writes a go statement calling a named function, a method or a
+// function value. Go evaluates the function value, the receiver and the
+// arguments when the go statement runs, so they are bound to constants used
+// by the goroutine:
+//
+//	{
+//		const __recv = w
+//		const __arg0 = n
+//		$.go(async () => {
+//			await __recv!.Run(__arg0)
+//		}, "main.go:12")
+//	}
+func (c *GoToTSCompiler) writeGoCall(callExpr *ast.CallExpr, location string) error {
+	var isAsync bool
+	var recv, fn ast.Expr
+	cloneRecv := false
+	switch fun := callExpr.Fun.(type) {
 	case *ast.Ident:
-		// Handle named functions: go namedFunc(args)
-		// Get the object for this function
 		obj := c.pkg.TypesInfo.Uses[fun]
 		if obj == nil {
 			return errors.Errorf("could not find object for function: %s", fun.Name)
 		}
-
-		// Check if the function is async
-		isAsync := c.analysis.IsAsyncFunc(obj)
-		if isAsync {
-			c.tsw.WriteLiterally("queueMicrotask(async () => {")
-		} else {
-			c.tsw.WriteLiterally("queueMicrotask(() => {")
+		// A function value may be async, so its result is awaited to keep
+		// the goroutine alive until it returns.
+		_, isFunc := obj.(*types.Func)
+		isAsync = !isFunc || c.analysis.IsAsyncFunc(obj)
+		if _, isVar := obj.(*types.Var); isVar {
+			fn = fun
 		}
-
-		c.tsw.Indent(1)
-		c.tsw.WriteLine("")
-
-		// Write the function call, using await if the function is async
-		if isAsync {
-			c.tsw.WriteLiterally("await ")
-		}
-
-		// Write the function name
-		c.tsw.WriteLiterally(fun.Name)
-
-		// Write the function arguments
-		c.tsw.WriteLiterally("(")
-		for i, arg := range callExpr.Args {
-			if i != 0 {
-				c.tsw.WriteLiterally(", ")
-			}
-			if err := c.WriteValueExpr(arg); err != nil {
-				return fmt.Errorf("failed to write argument %d in goroutine function call: %w", i, err)
-			}
-		}
-		c.tsw.WriteLiterally(")")
-		c.tsw.WriteLine("")
-
-		c.tsw.Indent(-1)
-		c.tsw.WriteLine("})") // Close the queueMicrotask callback and the statement
 	case *ast.SelectorExpr:
-		// Handle selector expressions: go x.Method(args)
-		// Get the object for the selected method
 		obj := c.pkg.TypesInfo.Uses[fun.Sel]
 		if obj == nil {
 			return errors.Errorf("could not find object for selected method: %s", fun.Sel.Name)
 		}
-
-		// Check if the function is async
-		isAsync := c.analysis.IsAsyncFunc(obj)
-		if isAsync {
-			c.tsw.WriteLiterally("queueMicrotask(async () => {")
-		} else {
-			c.tsw.WriteLiterally("queueMicrotask(() => {")
+		_, isFunc := obj.(*types.Func)
+		isAsync = !isFunc || c.analysis.IsAsyncFunc(obj)
+		if sel := c.pkg.TypesInfo.Selections[fun]; sel != nil {
+			if sel.Kind() == types.MethodVal {
+				recv = fun.X
+				// A value receiver is copied at the go statement
+				if f, ok := obj.(*types.Func); ok {
+					recvVar := f.Type().(*types.Signature).Recv()
+					if recvVar != nil {
+						_, isPtr := recvVar.Type().(*types.Pointer)
+						cloneRecv = !isPtr && c.shouldCloneValue(recv)
+					}
+				}
+			} else {
+				fn = fun
+			}
 		}
+	case *ast.TypeAssertExpr:
+		// We assume this is always synchronous (no async function returned by type assertion)
+		fn = fun
+	}
 
-		c.tsw.Indent(1)
-		c.tsw.WriteLine("")
+	var funcSig *types.Signature
+	if sig, ok := c.pkg.TypesInfo.TypeOf(callExpr.Fun).(*types.Signature); ok {
+		funcSig = sig
+	}
+	numberBoundary := c.isNumberBoundaryCall(callExpr)
 
-		// Write the function call, using await if the function is async
-		if isAsync {
-			c.tsw.WriteLiterally("await ")
+	// Bind the values evaluated at the go statement
+	hasBindings := recv != nil || fn != nil || len(callExpr.Args) != 0
+	if hasBindings {
+		c.tsw.WriteLine("{")
+		c.tsw.Indent(1)
+	}
+	if fn != nil {
+		c.tsw.WriteLiterally("const __fn = ")
+		if err := c.WriteValueExpr(fn); err != nil {
+			return fmt.Errorf("failed to write function value in goroutine: %w", err)
 		}
-
-		// Write the selector expression (e.g., f.Bar)
-		// Note: callExpr.Fun is the *ast.SelectorExpr itself
-		// For method calls, we need to add null assertion since Go would panic on nil receiver
-		if selectorExpr, ok := callExpr.Fun.(*ast.SelectorExpr); ok {
-			if err := c.WriteValueExpr(selectorExpr.X); err != nil {
-				return fmt.Errorf("failed to write selector base expression in goroutine: %w", err)
-			}
-			// Add null assertion for method calls - Go would panic if receiver is nil
-			c.tsw.WriteLiterally("!.")
-			c.WriteIdent(selectorExpr.Sel, true)
-		} else {
-			if err := c.WriteValueExpr(callExpr.Fun); err != nil {
-				return fmt.Errorf("failed to write selector expression in goroutine: %w", err)
-			}
+		c.tsw.WriteLine("")
+	}
+	if recv != nil {
+		c.tsw.WriteLiterally("const __recv = ")
+		if err := c.WriteValueExpr(recv); err != nil {
+			return fmt.Errorf("failed to write selector base expression in goroutine: %w", err)
 		}
-
-		// Write the function arguments
-		c.tsw.WriteLiterally("(")
-		for i, arg := range callExpr.Args {
-			if i != 0 {
-				c.tsw.WriteLiterally(", ")
-			}
-			if err := c.WriteValueExpr(arg); err != nil {
-				return fmt.Errorf("failed to write argument %d in goroutine selector function call: %w", i, err)
-			}
+		if cloneRecv {
+			c.tsw.WriteLiterally(".clone()")
 		}
-		c.tsw.WriteLiterally(")")
 		c.tsw.WriteLine("")
-
-		c.tsw.Indent(-1)
-		c.tsw.WriteLine("})") // Close the queueMicrotask callback and the statement
-	case *ast.TypeAssertExpr:
-		// Handle type assertion expressions: go x.(func())()
-		// We assume this is always synchronous (no async function returned by type assertion)
-		c.tsw.WriteLiterally("queueMicrotask(() => {")
-
-		c.tsw.Indent(1)
+	}
+	for i := range callExpr.Args {
+		c.tsw.WriteLiterallyf("const __arg%d = ", i)
+		if err := c.writeCallArgument(callExpr, funcSig, numberBoundary, i); err != nil {
+			return fmt.Errorf("failed to write argument %d in goroutine function call: %w", i, err)
+		}
+		// Struct values are copied at the go statement, as in an assignment
+		if c.shouldCloneValue(callExpr.Args[i]) {
+			c.tsw.WriteLiterally(".clone()")
+		}
 		c.tsw.WriteLine("")
+	}
 
-		// Write the type assertion call
-		if err := c.WriteTypeAssertExpr(fun); err != nil {
-			return fmt.Errorf("failed to write type assertion expression in goroutine: %w", err)
+	if isAsync {
+		c.tsw.WriteLiterally("$.go(async () => {")
+	} else {
+		c.tsw.WriteLiterally("$.go(() => {")
+	}
+	c.tsw.Indent(1)
+	c.tsw.WriteLine("")
+	if isAsync {
+		c.tsw.WriteLiterally("await ")
+	}
+	switch {
+	case fn != nil:
+		// Add a non-null assertion since Go panics when calling a nil function
+		c.tsw.WriteLiterally("__fn!")
+	case recv != nil:
+		// Add a non-null assertion since Go panics if the receiver is nil
+		c.tsw.WriteLiterally("__recv!.")
+		c.WriteIdent(callExpr.Fun.(*ast.SelectorExpr).Sel, true)
+	default:
+		if err := c.WriteValueExpr(callExpr.Fun); err != nil {
+			return fmt.Errorf("failed to write function in goroutine: %w", err)
 		}
-
-		// Add non-null assertion since mustTypeAssert throws on failure rather than returning null
-		c.tsw.WriteLiterally("!")
-
-		// Write the function arguments
-		c.tsw.WriteLiterally("(")
-		for i, arg := range callExpr.Args {
-			if i != 0 {
-				c.tsw.WriteLiterally(", ")
-			}
-			if err := c.WriteValueExpr(arg); err != nil {
-				return fmt.Errorf("failed to write argument %d in goroutine type assertion function call: %w", i, err)
-			}
+	}
+	c.tsw.WriteLiterally("(")
+	for i := range callExpr.Args {
+		if i != 0 {
+			c.tsw.WriteLiterally(", ")
 		}
-		c.tsw.WriteLiterally(")")
-		c.tsw.WriteLine("")
+		if callExpr.Ellipsis != token.NoPos && i == len(callExpr.Args)-1 {
+			c.tsw.WriteLiterallyf("...(__arg%d ?? [])", i)
+		} else {
+			c.tsw.WriteLiterallyf("__arg%d", i)
+		}
+	}
+	if argType := c.staticArgType(callExpr); argType != nil {
+		c.tsw.WriteLiterally(", ")
+		c.writeReflectTypeInfo(argType)
+	}
+	c.tsw.WriteLiterally(")")
+	c.tsw.WriteLine("")
+	c.tsw.Indent(-1)
+	c.tsw.WriteLinef("}, %s)", location) // Close the $.go callback and the statement
 
+	if hasBindings {
 		c.tsw.Indent(-1)
-		c.tsw.WriteLine("})") // Close the queueMicrotask callback and the statement
-	default:
-		return errors.Errorf("unhandled goroutine function type: %T", callExpr.Fun)
+		c.tsw.WriteLine("}")
 	}
 	return nil
 }
@@ -368,6 +444,14 @@ func (c *GoToTSCompiler) WriteStmtExpr(exp *ast.ExprStmt) error {
 		return nil
 	}
 
+	// An immediately-invoked function literal starts with "(", which would
+	// continue the previous line as a call without a leading semicolon.
+	if callExpr, ok := exp.X.(*ast.CallExpr); ok {
+		if funcLit, ok := callExpr.Fun.(*ast.FuncLit); ok && !c.analysis.IsFuncLitAsync(funcLit) {
+			c.tsw.WriteLiterally(";")
+		}
+	}
+
 	// Handle other expression statements
 	if err := c.WriteValueExpr(exp.X); err != nil { // Expression statement evaluates a value
 		return err
@@ -564,38 +648,27 @@ func (c *GoToTSCompiler) WriteStmtIf(exp *ast.IfStmt) error {
 //
 // The statement is terminated with a newline.
 func (c *GoToTSCompiler) WriteStmtReturn(exp *ast.ReturnStmt) error {
-	c.tsw.WriteLiterally("return ")
-
-	// Check if it's a bare named return
 	nodeInfo := c.analysis.NodeData[exp]
-	if nodeInfo != nil && nodeInfo.IsBareReturn {
-		var namedReturns []string
+
+	// Inside a panic frame with named results, the results are assigned to the
+	// named variables first so that deferred calls observe and may modify them.
+	if nodeInfo != nil && !nodeInfo.IsBareReturn && len(exp.Results) != 0 {
+		var enclosingBody *ast.BlockStmt
 		if nodeInfo.EnclosingFuncDecl != nil {
-			if obj := c.pkg.TypesInfo.ObjectOf(nodeInfo.EnclosingFuncDecl.Name); obj != nil {
-				if funcInfo := c.analysis.FunctionData[obj]; funcInfo != nil {
-					namedReturns = funcInfo.NamedReturns
-				}
-			}
+			enclosingBody = nodeInfo.EnclosingFuncDecl.Body
 		} else if nodeInfo.EnclosingFuncLit != nil {
-			if funcInfo := c.analysis.FuncLitData[nodeInfo.EnclosingFuncLit]; funcInfo != nil {
-				namedReturns = funcInfo.NamedReturns
-			}
+			enclosingBody = nodeInfo.EnclosingFuncLit.Body
 		}
-
-		if len(namedReturns) == 1 {
-			// Single named return - don't wrap in array
-			c.tsw.WriteLiterally(c.sanitizeIdentifier(namedReturns[0]))
-		} else if len(namedReturns) > 1 {
-			// Multiple named returns - wrap in array
-			c.tsw.WriteLiterally("[")
-			for i, name := range namedReturns {
-				if i != 0 {
-					c.tsw.WriteLiterally(", ")
-				}
-				c.tsw.WriteLiterally(c.sanitizeIdentifier(name))
-			}
-			c.tsw.WriteLiterally("]")
+		if namedReturns := c.enclosingNamedReturns(nodeInfo); len(namedReturns) != 0 && c.analysis.NeedsPanicFrame(enclosingBody) {
+			return c.writeReturnToNamedResults(exp, namedReturns)
 		}
+	}
+
+	c.tsw.WriteLiterally("return ")
+
+	// Check if it's a bare named return
+	if nodeInfo != nil && nodeInfo.IsBareReturn {
+		c.writeNamedReturnValues(c.enclosingNamedReturns(nodeInfo))
 	} else {
 		// Handle explicit return values
 		if len(exp.Results) > 1 {
@@ -636,6 +709,43 @@ func (c *GoToTSCompiler) WriteStmtReturn(exp *ast.ReturnStmt) error {
 	return nil
 }
 
+// writeReturnToNamedResults writes a return statement with explicit results in
+// a function with named results, as an assignment to the named results followed
+// by a bare return:
+//
+//	;[a, b] = [x, y]
+//	return [a, b]
+func (c *GoToTSCompiler) writeReturnToNamedResults(exp *ast.ReturnStmt, namedReturns []string) error {
+	if len(namedReturns) == 1 {
+		c.tsw.WriteLiterally(c.sanitizeIdentifier(namedReturns[0]))
+	} else {
+		c.tsw.WriteLiterally(";")
+		c.writeNamedReturnValues(namedReturns)
+	}
+	c.tsw.WriteLiterally(" = ")
+
+	if len(exp.Results) > 1 {
+		c.tsw.WriteLiterally("[")
+	}
+	for i, res := range exp.Results {
+		if i != 0 {
+			c.tsw.WriteLiterally(", ")
+		}
+		if err := c.WriteValueExpr(res); err != nil {
+			return err
+		}
+	}
+	if len(exp.Results) > 1 {
+		c.tsw.WriteLiterally("]")
+	}
+	c.tsw.WriteLine("")
+
+	c.tsw.WriteLiterally("return ")
+	c.writeNamedReturnValues(namedReturns)
+	c.tsw.WriteLine("")
+	return nil
+}
+
 // WriteStmtBlock translates a Go block statement (`ast.BlockStmt`), typically
 // `{ ...stmts... }`, into its TypeScript equivalent, carefully preserving
 // comments and blank lines to maintain code readability and structure.
@@ -670,6 +780,7 @@ func (c *GoToTSCompiler) WriteStmtBlock(exp *ast.BlockStmt, suppressNewline bool
 	// Opening brace
 	c.tsw.WriteLine("{")
 	c.tsw.Indent(1)
+	c.writeYieldPoint(exp)
 
 	// Determine if there is any defer to an async function literal in this block
 	hasAsyncDefer := false
@@ -684,15 +795,29 @@ func (c *GoToTSCompiler) WriteStmtBlock(exp *ast.BlockStmt, suppressNewline bool
 		}
 	}
 
-	// Add using statement if needed, considering async function or async defer
-	if c.analysis.NeedsDefer(exp) {
-		if c.analysis.IsInAsyncFunction(exp) || hasAsyncDefer {
+	// Add using statement if needed, considering async function or async defer.
+	// Function bodies whose defers can recover use an explicit panic frame instead.
+	isAsyncDefer := c.analysis.IsInAsyncFunction(exp) || hasAsyncDefer
+	panicFrame := c.analysis.NeedsPanicFrame(exp)
+	if panicFrame {
+		c.writePanicFrameStart(isAsyncDefer)
+	} else if c.analysis.NeedsDefer(exp) {
+		if isAsyncDefer {
 			c.tsw.WriteLine("await using __defer = new $.AsyncDisposableStack();")
 		} else {
 			c.tsw.WriteLine("using __defer = new $.DisposableStack();")
 		}
 	}
 
+	// Lower blocks containing goto targets into a state machine
+	var gotoBlock *GotoBlockInfo
+	if nodeInfo := c.analysis.NodeData[exp]; nodeInfo != nil {
+		gotoBlock = nodeInfo.GotoBlock
+	}
+	if gotoBlock != nil {
+		c.writeGotoBlockStart(exp.List, gotoBlock)
+	}
+
 	// Prepare line info
 	var file *token.File
 	if c.pkg != nil && c.pkg.Fset != nil && exp.Lbrace.IsValid() {
@@ -724,6 +849,21 @@ func (c *GoToTSCompiler) WriteStmtBlock(exp *ast.BlockStmt, suppressNewline bool
 
 		// Process leading comments for stmt
 		comments := c.analysis.Cmap.Filter(stmt).Comments()
+
+		// Start a new state machine case before the comments of a goto target
+		if gotoBlock != nil && c.gotoTargetState(stmt, gotoBlock) != 0 {
+			start := 0
+			if len(comments) != 0 && file != nil && comments[0].Pos().IsValid() {
+				start = file.Line(comments[0].Pos())
+			} else if file != nil && stmt.Pos().IsValid() {
+				start = file.Line(stmt.Pos())
+			}
+			writeBlank(lastLine, start)
+			c.writeGotoBlockCase(stmt, gotoBlock)
+			if start > 0 {
+				lastLine = start - 1
+			}
+		}
 		for _, cg := range comments {
 			// Check if this comment group is an inline comment for the current statement
 			isInlineComment := false
@@ -758,7 +898,11 @@ func (c *GoToTSCompiler) WriteStmtBlock(exp *ast.BlockStmt, suppressNewline bool
 		writeBlank(lastLine, stmtStart)
 		// Call the specific statement writer (e.g., WriteStmtAssign).
 		// It is responsible for handling its own inline comment.
-		if err := c.WriteStmt(stmt); err != nil {
+		if gotoBlock != nil {
+			if err := c.writeGotoBlockStmt(stmt, gotoBlock); err != nil {
+				return fmt.Errorf("failed to write statement in block: %w", err)
+			}
+		} else if err := c.WriteStmt(stmt); err != nil {
 			return fmt.Errorf("failed to write statement in block: %w", err)
 		}
 
@@ -785,6 +929,16 @@ func (c *GoToTSCompiler) WriteStmtBlock(exp *ast.BlockStmt, suppressNewline bool
 		}
 	}
 
+	if gotoBlock != nil {
+		c.writeGotoBlockEnd(exp.List)
+	}
+
+	if panicFrame {
+		if err := c.writePanicFrameEnd(exp, isAsyncDefer); err != nil {
+			return err
+		}
+	}
+
 	// 3. Blank lines before closing brace
 	closing := 0
 	if file != nil && exp.Rbrace.IsValid() {
@@ -872,11 +1026,23 @@ func (c *GoToTSCompiler) WriteStmtSwitch(exp *ast.SwitchStmt) error {
 // function scope (see `WriteStmtBlock` or `WriteFuncDeclAsMethod`) using
 // `await using __defer = new $.AsyncDisposableStack();` for async functions/contexts
 // or `using __defer = new $.DisposableStack();` for sync contexts.
+//
+// The stack passes itself to the registered function. A deferred function
+// literal that calls recover() recovers from it as `__frame`, and a deferred
+// call to a function taking the frame passes it on, see takesDeferFrame:
+// `__defer.defer((__frame) => { handler(__frame) });`.
 func (c *GoToTSCompiler) WriteStmtDefer(exp *ast.DeferStmt) error {
 	// Determine if the deferred call is to an async function literal using analysis
 	isAsyncDeferred := false
-	if funcLit, ok := exp.Call.Fun.(*ast.FuncLit); ok {
-		isAsyncDeferred = c.analysis.IsFuncLitAsync(funcLit)
+	takesFrame := c.analysis.PassesDeferFrame(exp.Call)
+	inlinedLit, _ := exp.Call.Fun.(*ast.FuncLit)
+	if inlinedLit != nil {
+		isAsyncDeferred = c.analysis.IsFuncLitAsync(inlinedLit)
+		takesFrame = len(findRecoverCalls(inlinedLit.Body, c.pkg.TypesInfo)) != 0
+		// Literals with their own deferred calls need their own defer stack.
+		if len(exp.Call.Args) != 0 || c.analysis.NeedsDefer(inlinedLit.Body) {
+			inlinedLit = nil
+		}
 	}
 
 	// Set async prefix based on pre-computed async status
@@ -887,33 +1053,222 @@ func (c *GoToTSCompiler) WriteStmtDefer(exp *ast.DeferStmt) error {
 
 	// Set stack variable based on whether we are in an async function
 	stackVar := "__defer"
-	c.tsw.WriteLiterallyf("%s.defer(%s() => {", stackVar, asyncPrefix)
+	c.tsw.WriteLiterallyf("%s.defer(", stackVar)
+	if inlinedLit != nil {
+		c.writeGotoCapturesStart(inlinedLit)
+	}
+	if takesFrame {
+		c.tsw.WriteLiterallyf("%s(__frame) => {", asyncPrefix)
+	} else {
+		c.tsw.WriteLiterallyf("%s() => {", asyncPrefix)
+	}
+	c.enterBody(isAsyncDeferred)
+	defer c.leaveBody()
 	c.tsw.Indent(1)
 	c.tsw.WriteLine("")
 
 	// Write the deferred call or inline the body when it's an immediately-invoked
 	// function literal (defer func(){ ... }()).
-	if funcLit, ok := exp.Call.Fun.(*ast.FuncLit); ok && len(exp.Call.Args) == 0 {
+	if inlinedLit != nil {
 		// Inline the function literal's body to avoid nested arrow invocation.
-		for _, stmt := range funcLit.Body.List {
+		c.enterFunc(nil)
+		defer c.leaveFunc()
+		for _, stmt := range inlinedLit.Body.List {
 			if err := c.WriteStmt(stmt); err != nil {
 				return fmt.Errorf("failed to write statement in deferred function body: %w", err)
 			}
 		}
 	} else {
 		// Write the call expression as-is.
-		if err := c.WriteValueExpr(exp.Call); err != nil {
+		outer := c.deferFrameCall
+		if c.analysis.PassesDeferFrame(exp.Call) {
+			c.deferFrameCall = exp.Call
+		}
+		err := c.WriteValueExpr(exp.Call)
+		c.deferFrameCall = outer
+		if err != nil {
 			return fmt.Errorf("failed to write deferred call: %w", err)
 		}
 		c.tsw.WriteLine("")
 	}
 
 	c.tsw.Indent(-1)
-	c.tsw.WriteLine("});")
+	c.tsw.WriteLiterally("}")
+	if inlinedLit != nil {
+		c.writeGotoCapturesEnd(inlinedLit)
+	}
+	c.tsw.WriteLine(");")
 
 	return nil
 }
 
+// writeDeferFrameParam writes the trailing `__frame` parameter of a function
+// declared with funcType if it takes the defer frame of the deferred calls to
+// it, see takesDeferFrame. hasParams reports whether parameters precede it.
+func (c *GoToTSCompiler) writeDeferFrameParam(funcType *ast.FuncType, hasParams bool) {
+	if !c.analysis.TakesDeferFrame(funcType) {
+		return
+	}
+	if hasParams {
+		c.tsw.WriteLiterally(", ")
+	}
+	c.tsw.WriteLiterally("__frame?: $.DeferFrame")
+}
+
+// writeDeferFrameArg passes the defer frame as the trailing argument of the
+// call being written if it is a deferred call to a function taking it, see
+// WriteStmtDefer.
+func (c *GoToTSCompiler) writeDeferFrameArg(exp *ast.CallExpr) {
+	if exp != c.deferFrameCall {
+		return
+	}
+	if len(exp.Args) != 0 {
+		c.tsw.WriteLiterally(", ")
+	}
+	c.tsw.WriteLiterally("__frame")
+}
+
+// writePanicFrameStart opens a panic frame for a function body whose deferred
+// calls may recover. Unlike a `using` declaration, the frame catches panics
+// raised by the body so that deferred calls can observe them:
+//
+//	const __defer = new $.DisposableStack();
+//	try {
+//
+// The body statements follow, and `writePanicFrameEnd` closes the frame.
+func (c *GoToTSCompiler) writePanicFrameStart(isAsync bool) {
+	if isAsync {
+		c.tsw.WriteLine("const __defer = new $.AsyncDisposableStack();")
+	} else {
+		c.tsw.WriteLine("const __defer = new $.DisposableStack();")
+	}
+	c.tsw.WriteLine("try {")
+	c.tsw.Indent(1)
+}
+
+// writePanicFrameEnd closes a panic frame opened by `writePanicFrameStart`.
+// A panic raised by the body is recorded on the defer stack, and the deferred
+// calls run in the `finally` block. `dispose()` rethrows the panic if no
+// deferred call recovered it.
+//
+// With named results, the `finally` block returns them so that changes made
+// by deferred calls, including after a recovery, become the results. Without
+// named results, a recovered function returns the zero values of its results.
+func (c *GoToTSCompiler) writePanicFrameEnd(body *ast.BlockStmt, isAsync bool) error {
+	c.tsw.Indent(-1)
+	c.tsw.WriteLine("} catch (__e) {")
+	c.tsw.Indent(1)
+	c.tsw.WriteLine("__defer.panic(__e)")
+	c.tsw.Indent(-1)
+	c.tsw.WriteLine("} finally {")
+	c.tsw.Indent(1)
+	if isAsync {
+		c.tsw.WriteLine("await __defer.dispose()")
+	} else {
+		c.tsw.WriteLine("__defer.dispose()")
+	}
+
+	results := c.panicFrameResults(body)
+	namedReturns := c.panicFrameNamedReturns(body)
+	if len(namedReturns) != 0 {
+		c.tsw.WriteLiterally("return ")
+		c.writeNamedReturnValues(namedReturns)
+		c.tsw.WriteLine("")
+	}
+	c.tsw.Indent(-1)
+	c.tsw.WriteLine("}")
+
+	// A recovered panic falls through to the zero values of unnamed results.
+	if len(namedReturns) == 0 && results != nil && len(results.List) != 0 {
+		var resultTypes []types.Type
+		for _, field := range results.List {
+			typ := c.pkg.TypesInfo.TypeOf(field.Type)
+			count := max(len(field.Names), 1)
+			for range count {
+				resultTypes = append(resultTypes, typ)
+			}
+		}
+		c.tsw.WriteLiterally("return ")
+		if len(resultTypes) > 1 {
+			c.tsw.WriteLiterally("[")
+		}
+		for i, typ := range resultTypes {
+			if i != 0 {
+				c.tsw.WriteLiterally(", ")
+			}
+			c.WriteZeroValueForType(typ)
+		}
+		if len(resultTypes) > 1 {
+			c.tsw.WriteLiterally("]")
+		}
+		c.tsw.WriteLine("")
+	}
+	return nil
+}
+
+// panicFrameResults returns the result list of the function whose body is the
+// given panic frame.
+func (c *GoToTSCompiler) panicFrameResults(body *ast.BlockStmt) *ast.FieldList {
+	nodeInfo := c.analysis.NodeData[body]
+	if nodeInfo == nil {
+		return nil
+	}
+	if nodeInfo.EnclosingFuncDecl != nil {
+		return nodeInfo.EnclosingFuncDecl.Type.Results
+	}
+	if nodeInfo.EnclosingFuncLit != nil {
+		return nodeInfo.EnclosingFuncLit.Type.Results
+	}
+	return nil
+}
+
+// panicFrameNamedReturns returns the named results of the function whose body
+// is the given panic frame, or nil if the results are unnamed.
+func (c *GoToTSCompiler) panicFrameNamedReturns(body *ast.BlockStmt) []string {
+	nodeInfo := c.analysis.NodeData[body]
+	if nodeInfo == nil {
+		return nil
+	}
+	return c.enclosingNamedReturns(nodeInfo)
+}
+
+// enclosingNamedReturns returns the named results of the function recorded as
+// enclosing the node described by nodeInfo.
+func (c *GoToTSCompiler) enclosingNamedReturns(nodeInfo *NodeInfo) []string {
+	if nodeInfo.EnclosingFuncDecl != nil {
+		if obj := c.pkg.TypesInfo.ObjectOf(nodeInfo.EnclosingFuncDecl.Name); obj != nil {
+			if funcInfo := c.analysis.FunctionData[obj]; funcInfo != nil {
+				return funcInfo.NamedReturns
+			}
+		}
+	} else if nodeInfo.EnclosingFuncLit != nil {
+		if funcInfo := c.analysis.FuncLitData[nodeInfo.EnclosingFuncLit]; funcInfo != nil {
+			return funcInfo.NamedReturns
+		}
+	}
+	return nil
+}
+
+// writeNamedReturnValues writes the named results as a return value: the name
+// itself for a single result, or an array of names for multiple results.
+func (c *GoToTSCompiler) writeNamedReturnValues(namedReturns []string) {
+	if len(namedReturns) == 0 {
+		return
+	}
+	if len(namedReturns) == 1 {
+		c.tsw.WriteLiterally(c.sanitizeIdentifier(namedReturns[0]))
+		return
+	}
+	c.tsw.WriteLiterally("[")
+	for i, name := range namedReturns {
+		if i != 0 {
+			c.tsw.WriteLiterally(", ")
+		}
+		c.tsw.WriteLiterally(c.sanitizeIdentifier(name))
+	}
+	c.tsw.WriteLiterally("]")
+}
+
 // WriteStmtLabeled handles labeled statements (ast.LabeledStmt), such as "label: statement".
 // In TypeScript, labels cannot be used with variable declarations, so we need to handle this case specially.
 func (c *GoToTSCompiler) WriteStmtLabeled(stmt *ast.LabeledStmt) error {
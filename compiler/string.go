@@ -0,0 +1,61 @@
+package compiler
+
+import (
+	"fmt"
+	"go/ast"
+	"go/constant"
+	"go/token"
+	"go/types"
+	"unicode/utf8"
+)
+
+// isStringConcat checks if a binary expression concatenates strings whose
+// UTF-8 sequences may be split between the operands. Strings hold the bytes
+// of invalid UTF-8 as escaped surrogates, so such operands are joined with
+// $.concatString, which decodes the split sequence into its rune, instead of
+// the + operator. Constant operands that do not end (on the left) or start
+// (on the right) with an invalid byte never need joining.
+func (c *GoToTSCompiler) isStringConcat(exp *ast.BinaryExpr) bool {
+	if exp.Op != token.ADD {
+		return false
+	}
+	if tv, ok := c.pkg.TypesInfo.Types[exp]; ok && tv.Value != nil {
+		return false
+	}
+	basic, ok := c.pkg.TypesInfo.TypeOf(exp.X).Underlying().(*types.Basic)
+	if !ok || basic.Info()&types.IsString == 0 {
+		return false
+	}
+	if tv, ok := c.pkg.TypesInfo.Types[exp.X]; ok && tv.Value != nil {
+		r, size := utf8.DecodeLastRuneInString(constant.StringVal(tv.Value))
+		if r != utf8.RuneError || size != 1 {
+			return false
+		}
+	}
+	if tv, ok := c.pkg.TypesInfo.Types[exp.Y]; ok && tv.Value != nil {
+		r, size := utf8.DecodeRuneInString(constant.StringVal(tv.Value))
+		if r != utf8.RuneError || size != 1 {
+			return false
+		}
+	}
+	return true
+}
+
+// writeStringBinaryExpr writes a string concatenation that may join a split
+// UTF-8 sequence as `$.concatString(x, y)`, see isStringConcat. It reports
+// whether the expression was written.
+func (c *GoToTSCompiler) writeStringBinaryExpr(exp *ast.BinaryExpr) (bool, error) {
+	if !c.isStringConcat(exp) {
+		return false, nil
+	}
+	c.tsw.WriteLiterally("$.concatString(")
+	if err := c.WriteValueExpr(exp.X); err != nil {
+		return true, fmt.Errorf("failed to write string concatenation left operand: %w", err)
+	}
+	c.tsw.WriteLiterally(", ")
+	if err := c.WriteValueExpr(exp.Y); err != nil {
+		return true, fmt.Errorf("failed to write string concatenation right operand: %w", err)
+	}
+	c.tsw.WriteLiterally(")")
+	return true, nil
+}
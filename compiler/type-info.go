@@ -1,23 +1,59 @@
 package compiler
 
-import "go/types"
+import (
+	"go/ast"
+	"go/types"
+)
+
+// qualifiedTypeName returns the name a named type is registered under with the
+// runtime type system: the package path and the type name, as in
+// "io/fs.PathError". Types of a main package are qualified with "main@" and
+// its import path, as in "main@example.com/cmd/tool.Config", so that the
+// types of several main packages loaded together do not collide. The runtime
+// reports their package path as "main", as reflect does in Go.
+func qualifiedTypeName(obj *types.TypeName) string {
+	pkg := obj.Pkg()
+	if pkg == nil {
+		return obj.Name()
+	}
+	if pkg.Name() == "main" {
+		return "main@" + pkg.Path() + "." + obj.Name()
+	}
+	return pkg.Path() + "." + obj.Name()
+}
 
 // writeTypeInfoObject writes a TypeScript TypeInfo object literal for a given Go type.
 func (c *GoToTSCompiler) writeTypeInfoObject(typ types.Type) {
+	c.writeTypeInfo(typ, false)
+}
+
+// writeReflectTypeInfo writes a TypeInfo object literal for a Go type using
+// the Go names of basic types, so reflection can tell the kinds of numbers
+// apart.
+func (c *GoToTSCompiler) writeReflectTypeInfo(typ types.Type) {
+	c.writeTypeInfo(typ, true)
+}
+
+// writeTypeInfo writes a TypeInfo object literal for a Go type. Basic types
+// are named by their TypeScript representation unless goNames is set.
+func (c *GoToTSCompiler) writeTypeInfo(typ types.Type, goNames bool) {
 	if typ == nil {
 		c.tsw.WriteLiterally("{ kind: $.TypeKind.Basic, name: 'any' }") // Or handle as error
 		return
 	}
 
+	// Aliases of named types refer to the named type itself.
+	typ = types.Unalias(typ)
+
 	// If typ is a *types.Named, handle it by reference to break recursion.
 	if namedType, ok := typ.(*types.Named); ok {
 		if namedType.Obj().Name() == "error" && namedType.Obj().Pkg() == nil { // Check for builtin error
 			c.tsw.WriteLiterally("{ kind: $.TypeKind.Interface, name: 'GoError', methods: [{ name: 'Error', args: [], returns: [{ type: { kind: $.TypeKind.Basic, name: 'string' } }] }] }")
 		} else {
-			// For all other named types, output their name as a string literal.
+			// For all other named types, output their qualified name as a string literal.
 			// This relies on the type being registered elsewhere (e.g., via registerStructType or registerInterfaceType)
 			// so the TypeScript runtime can resolve the reference.
-			c.tsw.WriteLiterallyf("%q", namedType.Obj().Name())
+			c.tsw.WriteLiterallyf("%q", qualifiedTypeName(namedType.Obj()))
 		}
 		return // Return after handling the named type by reference.
 	}
@@ -27,7 +63,11 @@ func (c *GoToTSCompiler) writeTypeInfoObject(typ types.Type) {
 	switch t := underlying.(type) {
 	case *types.Basic:
 		tsTypeName, _ := GoBuiltinToTypescript(t.Name())
-		if tsTypeName == "" {
+		if goNames {
+			tsTypeName = t.Name()
+		} else if c.isBigIntType(typ) {
+			tsTypeName = "bigint"
+		} else if tsTypeName == "" {
 			tsTypeName = t.Name() // Fallback
 		}
 		c.tsw.WriteLiterallyf("{ kind: $.TypeKind.Basic, name: %q }", tsTypeName)
@@ -37,21 +77,21 @@ func (c *GoToTSCompiler) writeTypeInfoObject(typ types.Type) {
 	// and that call would handle it via the top-level *types.Named check.
 	case *types.Pointer:
 		c.tsw.WriteLiterally("{ kind: $.TypeKind.Pointer, elemType: ")
-		c.writeTypeInfoObject(t.Elem()) // Recursive call
+		c.writeTypeInfo(t.Elem(), goNames) // Recursive call
 		c.tsw.WriteLiterally(" }")
 	case *types.Slice:
 		c.tsw.WriteLiterally("{ kind: $.TypeKind.Slice, elemType: ")
-		c.writeTypeInfoObject(t.Elem()) // Recursive call
+		c.writeTypeInfo(t.Elem(), goNames) // Recursive call
 		c.tsw.WriteLiterally(" }")
 	case *types.Array:
 		c.tsw.WriteLiterallyf("{ kind: $.TypeKind.Array, length: %d, elemType: ", t.Len())
-		c.writeTypeInfoObject(t.Elem()) // Recursive call
+		c.writeTypeInfo(t.Elem(), goNames) // Recursive call
 		c.tsw.WriteLiterally(" }")
 	case *types.Map:
 		c.tsw.WriteLiterally("{ kind: $.TypeKind.Map, keyType: ")
-		c.writeTypeInfoObject(t.Key()) // Recursive call
+		c.writeTypeInfo(t.Key(), goNames) // Recursive call
 		c.tsw.WriteLiterally(", elemType: ")
-		c.writeTypeInfoObject(t.Elem()) // Recursive call
+		c.writeTypeInfo(t.Elem(), goNames) // Recursive call
 		c.tsw.WriteLiterally(" }")
 	case *types.Chan:
 		dir := "both"
@@ -61,7 +101,7 @@ func (c *GoToTSCompiler) writeTypeInfoObject(typ types.Type) {
 			dir = "receive"
 		}
 		c.tsw.WriteLiterallyf("{ kind: $.TypeKind.Channel, direction: %q, elemType: ", dir)
-		c.writeTypeInfoObject(t.Elem()) // Recursive call
+		c.writeTypeInfo(t.Elem(), goNames) // Recursive call
 		c.tsw.WriteLiterally(" }")
 	case *types.Interface: // Anonymous interface or underlying of a non-named type alias
 		c.tsw.WriteLiterally("{ kind: $.TypeKind.Interface, methods: [")
@@ -78,33 +118,47 @@ func (c *GoToTSCompiler) writeTypeInfoObject(typ types.Type) {
 			if i > 0 {
 				c.tsw.WriteLiterally(", ")
 			}
-			c.writeTypeInfoObject(t.Params().At(i).Type()) // Recursive call
+			c.writeTypeInfo(t.Params().At(i).Type(), goNames) // Recursive call
 		}
 		c.tsw.WriteLiterally("], results: [")
 		for i := 0; i < t.Results().Len(); i++ {
 			if i > 0 {
 				c.tsw.WriteLiterally(", ")
 			}
-			c.writeTypeInfoObject(t.Results().At(i).Type()) // Recursive call
+			c.writeTypeInfo(t.Results().At(i).Type(), goNames) // Recursive call
 		}
 		c.tsw.WriteLiterally("] }")
 	case *types.Struct: // Anonymous struct or underlying of a non-named type alias
-		c.tsw.WriteLiterally("{ kind: $.TypeKind.Struct, fields: {")
+		c.tsw.WriteLiterally("{ kind: $.TypeKind.Struct, fields: [")
 		for i := 0; i < t.NumFields(); i++ {
 			if i > 0 {
 				c.tsw.WriteLiterally(", ")
 			}
-			field := t.Field(i)
-			c.tsw.WriteLiterallyf("%q: ", field.Name())
-			c.writeTypeInfoObject(field.Type()) // Recursive call
+			c.writeStructFieldInfo(t.Field(i).Name(), t.Field(i), t.Tag(i))
 		}
-		c.tsw.WriteLiterally("}, methods: [] }") // Anonymous structs don't have methods in this context
+		c.tsw.WriteLiterally("], methods: [] }") // Anonymous structs don't have methods in this context
 	default:
 		// Fallback, e.g. for types whose underlying isn't one of the above like *types.Tuple or other complex cases.
 		c.tsw.WriteLiterallyf("{ kind: $.TypeKind.Basic, name: %q }", typ.String()) // Fallback using the type's string representation
 	}
 }
 
+// writeStructFieldInfo writes a TypeScript StructFieldInfo object literal for
+// a struct field stored in the property with the given name.
+func (c *GoToTSCompiler) writeStructFieldInfo(name string, field *types.Var, tag string) {
+	c.tsw.WriteLiterallyf("{ name: %q, type: ", name)
+	// Use the Go type names, so reflection and fmt can tell the kinds of
+	// numbers apart, also in the elements of slices and maps
+	c.writeReflectTypeInfo(field.Type())
+	if tag != "" {
+		c.tsw.WriteLiterallyf(", tag: %q", tag)
+	}
+	if field.Anonymous() {
+		c.tsw.WriteLiterally(", embedded: true")
+	}
+	c.tsw.WriteLiterally(" }")
+}
+
 // writeMethodSignatures writes an array of TypeScript MethodSignature objects.
 func (c *GoToTSCompiler) writeMethodSignatures(methods []*types.Func) {
 	firstMethod := true
@@ -139,3 +193,60 @@ func (c *GoToTSCompiler) writeMethodSignatures(methods []*types.Func) {
 		c.tsw.WriteLiterally("] }")
 	}
 }
+
+// staticTypeArgs lists the functions of handwritten packages that are passed
+// the static type of one of their arguments, by package path, function name
+// and argument index.
+var staticTypeArgs = map[string]map[string]int{
+	"errors":  {"As": 1},
+	"reflect": {"TypeOf": 0, "ValueOf": 0},
+	"encoding/binary": {
+		"Append": 2,
+		"Decode": 2,
+		"Encode": 2,
+		"Read":   2,
+		"Size":   0,
+		"Write":  2,
+	},
+}
+
+// staticArgType returns the static type of the argument of a call to a
+// function listed in staticTypeArgs, such as reflect.TypeOf or binary.Write,
+// which is passed to them as runtime type information since values do not
+// carry their Go type at runtime. Pointers to structs share their
+// representation with struct values and named basic types with their
+// underlying types. It returns nil for other calls and for arguments whose
+// type is only known at runtime.
+func (c *GoToTSCompiler) staticArgType(exp *ast.CallExpr) types.Type {
+	fn := c.calledFunc(exp)
+	if fn == nil || fn.Pkg() == nil {
+		return nil
+	}
+	if sig, ok := fn.Type().(*types.Signature); !ok || sig.Recv() != nil {
+		return nil
+	}
+	idx, ok := staticTypeArgs[fn.Pkg().Path()][fn.Name()]
+	if !ok || idx >= len(exp.Args) || exp.Ellipsis.IsValid() {
+		return nil
+	}
+	typ := c.pkg.TypesInfo.TypeOf(exp.Args[idx])
+	if typ == nil || types.IsInterface(typ) {
+		return nil
+	}
+	if basic, ok := typ.(*types.Basic); ok && basic.Info()&types.IsUntyped != 0 {
+		return nil
+	}
+	// Functions carry their own type information, and instantiated generic
+	// types are not registered.
+	base := typ
+	if ptr, ok := base.(*types.Pointer); ok {
+		base = ptr.Elem()
+	}
+	if _, ok := base.Underlying().(*types.Signature); ok {
+		return nil
+	}
+	if named, ok := types.Unalias(base).(*types.Named); ok && named.TypeArgs().Len() != 0 {
+		return nil
+	}
+	return typ
+}
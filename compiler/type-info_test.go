@@ -0,0 +1,57 @@
+package compiler
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/sirupsen/logrus"
+)
+
+func TestQualifiedTypeNameMainPackages(t *testing.T) {
+	dir := t.TempDir()
+	files := map[string]string{
+		"go.mod":        "module example.com/m\n\ngo 1.24\n",
+		"cmd/a/main.go": "package main\n\nimport \"example.com/m/lib\"\n\ntype Config struct{ A lib.Config }\n\nfunc main() {}\n",
+		"cmd/b/main.go": "package main\n\ntype Config struct{ B string }\n\nfunc main() {}\n",
+		"lib/lib.go":    "package lib\n\ntype Config struct{}\n",
+	}
+	for name, content := range files {
+		writeTestFile(t, filepath.Join(dir, name), content)
+	}
+
+	logger := logrus.New()
+	logger.SetLevel(logrus.WarnLevel)
+	outputDir := filepath.Join(dir, "output")
+	comp, err := NewCompiler(&Config{
+		OutputPath:         outputDir,
+		Dir:                dir,
+		DisableEmitBuiltin: true,
+	}, logrus.NewEntry(logger), nil)
+	if err != nil {
+		t.Fatalf("failed to create compiler: %v", err)
+	}
+	if _, err := comp.CompilePackages(context.Background(), "./..."); err != nil {
+		t.Fatalf("compile failed: %v", err)
+	}
+
+	// The types of main packages are registered under their import paths.
+	for file, name := range map[string]string{
+		"cmd/a/main.gs.ts": "'main@example.com/m/cmd/a.Config'",
+		"cmd/b/main.gs.ts": "'main@example.com/m/cmd/b.Config'",
+		"lib/lib.gs.ts":    "'example.com/m/lib.Config'",
+	} {
+		content, err := os.ReadFile(filepath.Join(outputDir, "@goscript/example.com/m", file))
+		if err != nil {
+			t.Fatal(err)
+		}
+		if !strings.Contains(string(content), name) {
+			t.Errorf("%s does not register %s:\n%s", file, name, content)
+		}
+		if strings.Contains(string(content), "'main.Config'") {
+			t.Errorf("%s registers main.Config:\n%s", file, content)
+		}
+	}
+}
@@ -185,8 +185,14 @@ func (c *GoToTSCompiler) WriteZeroValueForType(typ any) {
 			c.tsw.WriteLiterally("false")
 		case types.String:
 			c.tsw.WriteLiterally(`""`)
+		case types.Complex64, types.Complex128:
+			c.tsw.WriteLiterally("$.complex(0, 0)")
 		default:
-			c.tsw.WriteLiterally("0")
+			if c.isBigIntType(t) {
+				c.tsw.WriteLiterally("0n")
+			} else {
+				c.tsw.WriteLiterally("0")
+			}
 		}
 	case *types.Named:
 		// Handle named types, especially struct types
@@ -198,6 +204,11 @@ func (c *GoToTSCompiler) WriteZeroValueForType(typ any) {
 			c.tsw.WriteLiterally("()")
 			return
 		}
+		// Named 64-bit integers of handwritten packages are numbers, see isBigIntType
+		if _, isBasic := t.Underlying().(*types.Basic); isBasic && !c.isBigIntType(t) && c.isBigIntType(t.Underlying()) {
+			c.tsw.WriteLiterally("0")
+			return
+		}
 		// For other named types, use the zero value of the underlying type
 		c.WriteZeroValueForType(t.Underlying())
 	case *types.Slice:
@@ -233,9 +244,12 @@ func (c *GoToTSCompiler) WriteBasicType(t *types.Basic) {
 		case types.UntypedBool:
 			c.tsw.WriteLiterally("boolean")
 			return
-		case types.UntypedInt, types.UntypedFloat, types.UntypedComplex, types.UntypedRune:
+		case types.UntypedInt, types.UntypedFloat, types.UntypedRune:
 			c.tsw.WriteLiterally("number")
 			return
+		case types.UntypedComplex:
+			c.tsw.WriteLiterally("$.Complex")
+			return
 		case types.UntypedString:
 			c.tsw.WriteLiterally("string")
 			return
@@ -245,6 +259,17 @@ func (c *GoToTSCompiler) WriteBasicType(t *types.Basic) {
 		}
 	}
 
+	// 64-bit integers may be represented as bigint, see Config.BigInt64
+	if c.isBigIntType(t) {
+		c.tsw.WriteLiterally("bigint")
+		return
+	}
+
+	if isComplexType(t) {
+		c.tsw.WriteLiterally("$.Complex")
+		return
+	}
+
 	// For typed basic types, use the existing mapping
 	if tsType, ok := GoBuiltinToTypescript(name); ok {
 		c.tsw.WriteLiterally(tsType)
@@ -439,6 +464,7 @@ func (c *GoToTSCompiler) WriteChannelType(t *types.Chan) {
 func (c *GoToTSCompiler) WriteFuncType(exp *ast.FuncType, isAsync bool) {
 	c.tsw.WriteLiterally("(")
 	c.WriteFieldList(exp.Params, true) // true = arguments
+	c.writeDeferFrameParam(exp, exp.Params.NumFields() != 0)
 	c.tsw.WriteLiterally(")")
 	if exp.Results != nil && len(exp.Results.List) > 0 {
 		// Use colon for return type annotation
@@ -734,7 +760,7 @@ func (c *GoToTSCompiler) writeInterfaceStructure(iface *types.Interface, astNode
 func (c *GoToTSCompiler) getTypeString(goType types.Type) string {
 	var typeStr strings.Builder
 	writer := NewTSCodeWriter(&typeStr)
-	tempCompiler := NewGoToTSCompiler(writer, c.pkg, c.analysis)
+	tempCompiler := NewGoToTSCompiler(writer, c.pkg, c.config, c.analysis)
 	tempCompiler.WriteGoType(goType, GoTypeContextGeneral)
 	return typeStr.String()
 }
@@ -746,7 +772,7 @@ func (c *GoToTSCompiler) getTypeString(goType types.Type) string {
 func (c *GoToTSCompiler) getASTTypeString(astType ast.Expr, goType types.Type) string {
 	var typeStr strings.Builder
 	writer := NewTSCodeWriter(&typeStr)
-	tempCompiler := NewGoToTSCompiler(writer, c.pkg, c.analysis)
+	tempCompiler := NewGoToTSCompiler(writer, c.pkg, c.config, c.analysis)
 
 	if astType != nil {
 		// Use AST-based type writing to preserve qualified names
@@ -0,0 +1,258 @@
+package compiler
+
+import (
+	"context"
+	"maps"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/sirupsen/logrus"
+	"golang.org/x/tools/go/packages"
+)
+
+// DefaultWatchInterval is the default interval between polls of the watched
+// files.
+const DefaultWatchInterval = 500 * time.Millisecond
+
+// Watcher recompiles packages when their sources change.
+//
+// It watches the Go files of the requested packages and of the packages they
+// import from the main module or from modules replaced by a local directory,
+// along with the go.mod files of those modules. When files change, only the
+// packages containing them and the packages importing those are compiled
+// again. Outputs are only rewritten if their content changed.
+type Watcher struct {
+	c        *Compiler
+	le       *logrus.Entry
+	patterns []string
+
+	// Interval is the time between polls of the watched files.
+	Interval time.Duration
+
+	// files holds the state of each watched file.
+	files map[string]fileState
+	// dirs maps the package paths to their directories.
+	dirs map[string]string
+	// importers maps a package path to the paths of the watched packages
+	// that import it.
+	importers map[string][]string
+	// modFiles is the set of watched go.mod files.
+	modFiles map[string]bool
+}
+
+// fileState is the state of a watched file used to detect changes.
+type fileState struct {
+	modTime time.Time
+	size    int64
+}
+
+// NewWatcher builds a new Watcher compiling the packages matching patterns
+// with c.
+func NewWatcher(c *Compiler, patterns ...string) *Watcher {
+	return &Watcher{
+		c:        c,
+		le:       c.le,
+		patterns: patterns,
+		Interval: DefaultWatchInterval,
+	}
+}
+
+// Run compiles the packages and then recompiles them as their files change,
+// until ctx is canceled. Compile errors are logged and do not stop watching.
+// It returns an error only if the packages can not be loaded initially.
+func (w *Watcher) Run(ctx context.Context) error {
+	pkgs, err := w.compile(ctx, nil)
+	if pkgs == nil {
+		return err
+	}
+	if err != nil {
+		w.le.WithError(err).Warn("compile failed")
+	}
+	w.le.Infof("watching %d packages for changes", len(w.dirs))
+
+	ticker := time.NewTicker(w.Interval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-ticker.C:
+		}
+
+		changed := w.poll()
+		if len(changed) == 0 {
+			continue
+		}
+		w.rebuild(ctx, changed)
+	}
+}
+
+// rebuild recompiles the packages affected by the changed files.
+func (w *Watcher) rebuild(ctx context.Context, changed []string) {
+	only := w.affected(changed)
+	if only == nil {
+		w.le.Info("go.mod changed, recompiling all packages")
+	} else {
+		w.le.Infof("recompiling %s", strings.Join(slices.Sorted(maps.Keys(only)), ", "))
+	}
+
+	if _, err := w.compile(ctx, only); err != nil {
+		w.le.WithError(err).Warn("compile failed")
+	}
+}
+
+// compile compiles the packages in only, or all packages if only is nil, and
+// tracks the loaded packages, as imports may have changed. It returns the
+// loaded packages, which are nil if they could not be loaded.
+func (w *Watcher) compile(ctx context.Context, only map[string]bool) ([]*packages.Package, error) {
+	start := time.Now()
+	_, pkgs, err := w.c.compilePackages(ctx, only, w.patterns...)
+	if pkgs != nil {
+		w.track(pkgs)
+		w.files = w.rescan(start)
+	}
+	return pkgs, err
+}
+
+// track records the packages to watch from the loaded package graph.
+func (w *Watcher) track(roots []*packages.Package) {
+	w.dirs = make(map[string]string)
+	w.importers = make(map[string][]string)
+	w.modFiles = make(map[string]bool)
+
+	packages.Visit(roots, nil, func(pkg *packages.Package) {
+		if !isLocalPackage(pkg) {
+			return
+		}
+		if len(pkg.GoFiles) != 0 {
+			w.dirs[pkg.PkgPath] = filepath.Dir(pkg.GoFiles[0])
+		}
+		if pkg.Module.GoMod != "" {
+			w.modFiles[pkg.Module.GoMod] = true
+		}
+		for _, imp := range pkg.Imports {
+			w.importers[imp.PkgPath] = append(w.importers[imp.PkgPath], pkg.PkgPath)
+		}
+	})
+}
+
+// isLocalPackage reports whether pkg belongs to the main module or to a
+// module replaced by a local directory, where its sources may be edited.
+func isLocalPackage(pkg *packages.Package) bool {
+	mod := pkg.Module
+	if mod == nil {
+		return false
+	}
+	return mod.Main || (mod.Replace != nil && mod.Replace.Version == "")
+}
+
+// scan returns the state of the watched files, including the Go files
+// added to the watched directories.
+func (w *Watcher) scan() map[string]fileState {
+	files := make(map[string]fileState)
+	add := func(path string) {
+		info, err := os.Stat(path)
+		if err != nil {
+			return
+		}
+		files[path] = fileState{modTime: info.ModTime(), size: info.Size()}
+	}
+	for _, dir := range w.dirs {
+		entries, err := os.ReadDir(dir)
+		if err != nil {
+			continue
+		}
+		for _, entry := range entries {
+			name := entry.Name()
+			if entry.IsDir() || !strings.HasSuffix(name, ".go") || strings.HasSuffix(name, "_test.go") {
+				continue
+			}
+			add(filepath.Join(dir, name))
+		}
+	}
+	for modFile := range w.modFiles {
+		add(modFile)
+	}
+	return files
+}
+
+// rescan returns the state of the watched files after a compile started at
+// start, such that the next poll reports the files changed during the
+// compile. Files seen by the last poll keep the state they had then, and
+// other files modified since start are left out.
+func (w *Watcher) rescan(start time.Time) map[string]fileState {
+	files := w.scan()
+	for path, state := range files {
+		if prev, ok := w.files[path]; ok {
+			files[path] = prev
+		} else if !state.modTime.Before(start) {
+			delete(files, path)
+		}
+	}
+	// Keep the files removed during the compile, so they are reported too.
+	watched := make(map[string]bool, len(w.dirs))
+	for _, dir := range w.dirs {
+		watched[dir] = true
+	}
+	for path, prev := range w.files {
+		if _, ok := files[path]; !ok && (watched[filepath.Dir(path)] || w.modFiles[path]) {
+			files[path] = prev
+		}
+	}
+	return files
+}
+
+// poll scans the watched files and returns those that were added, changed or
+// removed since the last scan.
+func (w *Watcher) poll() []string {
+	files := w.scan()
+	var changed []string
+	for path, state := range files {
+		if prev, ok := w.files[path]; !ok || prev != state {
+			changed = append(changed, path)
+		}
+	}
+	for path := range w.files {
+		if _, ok := files[path]; !ok {
+			changed = append(changed, path)
+		}
+	}
+	w.files = files
+	return changed
+}
+
+// affected returns the paths of the packages containing the changed files and
+// of the packages importing them, directly or indirectly. It returns nil if a
+// go.mod file changed, as then any package may be affected.
+func (w *Watcher) affected(changed []string) map[string]bool {
+	byDir := make(map[string]string, len(w.dirs))
+	for pkgPath, dir := range w.dirs {
+		byDir[dir] = pkgPath
+	}
+
+	only := make(map[string]bool)
+	var queue []string
+	for _, path := range changed {
+		if w.modFiles[path] {
+			return nil
+		}
+		if pkgPath, ok := byDir[filepath.Dir(path)]; ok && !only[pkgPath] {
+			only[pkgPath] = true
+			queue = append(queue, pkgPath)
+		}
+	}
+	for len(queue) != 0 {
+		pkgPath := queue[0]
+		queue = queue[1:]
+		for _, importer := range w.importers[pkgPath] {
+			if !only[importer] {
+				only[importer] = true
+				queue = append(queue, importer)
+			}
+		}
+	}
+	return only
+}
@@ -0,0 +1,172 @@
+package compiler
+
+import (
+	"context"
+	"maps"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/sirupsen/logrus"
+)
+
+func TestWatcherRecompilesChangedPackages(t *testing.T) {
+	dir := t.TempDir()
+	files := map[string]string{
+		"go.mod": "module example.com/w\n\ngo 1.24\n",
+		"a/a.go": "package a\n\nimport \"example.com/w/b\"\n\nfunc A() int { return b.B() }\n",
+		"b/b.go": "package b\n\nfunc B() int { return 1 }\n",
+		"c/c.go": "package c\n\nfunc C() int { return 3 }\n",
+	}
+	for name, content := range files {
+		writeTestFile(t, filepath.Join(dir, name), content)
+	}
+
+	logger := logrus.New()
+	logger.SetLevel(logrus.WarnLevel)
+	outputDir := filepath.Join(dir, "output")
+	comp, err := NewCompiler(&Config{
+		OutputPath:         outputDir,
+		Dir:                dir,
+		DisableEmitBuiltin: true,
+	}, logrus.NewEntry(logger), nil)
+	if err != nil {
+		t.Fatalf("failed to create compiler: %v", err)
+	}
+
+	ctx := context.Background()
+	w := NewWatcher(comp, "./...")
+	if _, err := w.compile(ctx, nil); err != nil {
+		t.Fatalf("initial compile failed: %v", err)
+	}
+
+	output := func(pkg string) string {
+		return filepath.Join(outputDir, "@goscript/example.com/w", pkg, pkg+".gs.ts")
+	}
+	// Backdate the outputs so rewrites are visible in their modification time.
+	past := time.Now().Add(-time.Hour)
+	for _, pkg := range []string{"a", "b", "c"} {
+		if err := os.Chtimes(output(pkg), past, past); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	if changed := w.poll(); len(changed) != 0 {
+		t.Fatalf("expected no changes, got %v", changed)
+	}
+
+	bFile := filepath.Join(dir, "b/b.go")
+	writeTestFile(t, bFile, "package b\n\nfunc B() int { return 2 }\n")
+	future := time.Now().Add(time.Hour)
+	if err := os.Chtimes(bFile, future, future); err != nil {
+		t.Fatal(err)
+	}
+
+	changed := w.poll()
+	if !slices.Equal(changed, []string{bFile}) {
+		t.Fatalf("expected %s to change, got %v", bFile, changed)
+	}
+	only := w.affected(changed)
+	if got := slices.Sorted(maps.Keys(only)); !slices.Equal(got, []string{"example.com/w/a", "example.com/w/b"}) {
+		t.Fatalf("unexpected affected packages: %v", got)
+	}
+
+	w.rebuild(ctx, changed)
+
+	content, err := os.ReadFile(output("b"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !strings.Contains(string(content), "return 2") {
+		t.Errorf("b was not recompiled:\n%s", content)
+	}
+	for _, pkg := range []string{"a", "b", "c"} {
+		info, err := os.Stat(output(pkg))
+		if err != nil {
+			t.Fatal(err)
+		}
+		rewritten := info.ModTime().After(past.Add(time.Minute))
+		if rewritten != (pkg == "b") {
+			t.Errorf("output of %s rewritten: %v", pkg, rewritten)
+		}
+	}
+
+	// A change to go.mod affects every package.
+	if w.affected([]string{filepath.Join(dir, "go.mod")}) != nil {
+		t.Error("expected go.mod change to affect all packages")
+	}
+}
+
+func TestWatcherReportsChangesDuringCompile(t *testing.T) {
+	dir := t.TempDir()
+	files := map[string]string{
+		"go.mod": "module example.com/w\n\ngo 1.24\n",
+		"a/a.go": "package a\n\nfunc A() int { return 1 }\n",
+		"a/b.go": "package a\n\nfunc B() int { return 2 }\n",
+		"a/c.go": "package a\n\nfunc C() int { return 3 }\n",
+	}
+	for name, content := range files {
+		writeTestFile(t, filepath.Join(dir, name), content)
+	}
+
+	logger := logrus.New()
+	logger.SetLevel(logrus.WarnLevel)
+	comp, err := NewCompiler(&Config{
+		OutputPath:         filepath.Join(dir, "output"),
+		Dir:                dir,
+		DisableEmitBuiltin: true,
+	}, logrus.NewEntry(logger), nil)
+	if err != nil {
+		t.Fatalf("failed to create compiler: %v", err)
+	}
+
+	ctx := context.Background()
+	w := NewWatcher(comp, "./...")
+	if _, err := w.compile(ctx, nil); err != nil {
+		t.Fatalf("initial compile failed: %v", err)
+	}
+
+	// Saves made during the initial compile, before any poll.
+	start := time.Now()
+	future := start.Add(time.Hour)
+	bFile := filepath.Join(dir, "a/b.go")
+	writeTestFile(t, bFile, "package a\n\nfunc B() int { return 4 }\n")
+	if err := os.Chtimes(bFile, future, future); err != nil {
+		t.Fatal(err)
+	}
+	w.files = nil // as before the initial compile
+	w.files = w.rescan(start)
+	if changed := w.poll(); !slices.Equal(changed, []string{bFile}) {
+		t.Fatalf("expected %s to change, got %v", bFile, changed)
+	}
+
+	// Saves made while compiling, after the state seen by the last poll.
+	aFile := filepath.Join(dir, "a/a.go")
+	writeTestFile(t, aFile, "package a\n\nfunc A() int { return 5 }\n")
+	if err := os.Chtimes(aFile, future, future); err != nil {
+		t.Fatal(err)
+	}
+	cFile := filepath.Join(dir, "a/c.go")
+	if err := os.Remove(cFile); err != nil {
+		t.Fatal(err)
+	}
+	w.files = w.rescan(time.Now())
+	changed := w.poll()
+	slices.Sort(changed)
+	if !slices.Equal(changed, []string{aFile, cFile}) {
+		t.Fatalf("expected %s and %s to change, got %v", aFile, cFile, changed)
+	}
+}
+
+func writeTestFile(t *testing.T, path, content string) {
+	t.Helper()
+	if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(path, []byte(content), 0o644); err != nil {
+		t.Fatal(err)
+	}
+}
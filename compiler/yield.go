@@ -0,0 +1,47 @@
+package compiler
+
+import "go/ast"
+
+// enterBody records that the statements being written are in the body of a
+// function, which can await if async is true, until leaveBody is called.
+// Generated closures wrapping Go statements, such as the case bodies of a
+// type switch, count as functions.
+func (c *GoToTSCompiler) enterBody(async bool) {
+	c.bodies = append(c.bodies, async)
+}
+
+// leaveBody records that the statements of the innermost function body are
+// written.
+func (c *GoToTSCompiler) leaveBody() {
+	c.bodies = c.bodies[:len(c.bodies)-1]
+}
+
+// canAwait reports whether the statements being written can await, which
+// they can in async function bodies.
+func (c *GoToTSCompiler) canAwait() bool {
+	return len(c.bodies) != 0 && c.bodies[len(c.bodies)-1]
+}
+
+// markLoopBody marks the body of a loop to start with a yield point, if
+// Config.YieldLoops is enabled. The yield point is written by WriteStmtBlock
+// if the loop is in an async function.
+func (c *GoToTSCompiler) markLoopBody(body *ast.BlockStmt) {
+	if c.config != nil && c.config.YieldLoops {
+		c.yieldBlock = body
+	}
+}
+
+// writeYieldPoint writes a yield point at the start of the block being
+// written, if it is a marked loop body. The goroutine yields to the
+// macrotask queue there once it ran for longer than the time budget of the
+// runtime, so that a CPU-bound loop does not starve timers, I/O and
+// rendering.
+func (c *GoToTSCompiler) writeYieldPoint(block *ast.BlockStmt) {
+	if c.yieldBlock != block {
+		return
+	}
+	c.yieldBlock = nil
+	if c.canAwait() {
+		c.tsw.WriteLine("if ($.shouldYield()) await $.gosched()")
+	}
+}
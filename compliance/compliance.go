@@ -8,6 +8,7 @@ import (
 	"io"
 	"io/fs"
 	"maps"
+	"math/rand/v2"
 	"os"
 	"os/exec"
 	"path/filepath"
@@ -158,11 +159,43 @@ func CompileGoToTypeScript(t *testing.T, parentModulePath, testDir, tempDir, out
 		t.Fatalf("failed to check for no-all-deps file in %s: %v", testDir, err)
 	}
 
+	// Check if int64 and uint64 should be represented as bigint for this test
+	bigInt64 := false
+	bigInt64Path := filepath.Join(testDir, "bigint64")
+	if _, err := os.Stat(bigInt64Path); err == nil {
+		bigInt64 = true
+		t.Logf("Enabling BigInt64 for %s: bigint64 file found", filepath.Base(testDir))
+	} else if !os.IsNotExist(err) {
+		t.Fatalf("failed to check for bigint64 file in %s: %v", testDir, err)
+	}
+
+	// Check if the generated code should record its Go source locations
+	sourceLocations := false
+	sourceLocationsPath := filepath.Join(testDir, "source-locations")
+	if _, err := os.Stat(sourceLocationsPath); err == nil {
+		sourceLocations = true
+		t.Logf("Enabling SourceLocations for %s: source-locations file found", filepath.Base(testDir))
+	} else if !os.IsNotExist(err) {
+		t.Fatalf("failed to check for source-locations file in %s: %v", testDir, err)
+	}
+
+	yieldLoops := false
+	yieldLoopsPath := filepath.Join(testDir, "yield-loops")
+	if _, err := os.Stat(yieldLoopsPath); err == nil {
+		yieldLoops = true
+		t.Logf("Enabling YieldLoops for %s: yield-loops file found", filepath.Base(testDir))
+	} else if !os.IsNotExist(err) {
+		t.Fatalf("failed to check for yield-loops file in %s: %v", testDir, err)
+	}
+
 	conf := &compiler.Config{
 		Dir:                testDir,
 		OutputPath:         outputDir,
 		AllDependencies:    allDependencies,
 		DisableEmitBuiltin: true, // We want to use the handwritten gs/ packages in compliance tests
+		BigInt64:           bigInt64,
+		SourceLocations:    sourceLocations,
+		YieldLoops:         yieldLoops,
 	}
 	if err := conf.Validate(); err != nil {
 		t.Fatalf("invalid compiler config: %v", err)
@@ -446,7 +479,7 @@ func copyDependenciesToDepsFromResult(t *testing.T, parentModulePath, testDir st
 
 // WriteTypeScriptRunner generates a "runner.ts" file in the tempDir.
 // This runner script imports the main function from the compiled TypeScript output
-// of the test and executes it.
+// of the test and executes it as the main goroutine with $.runMain.
 //
 // Parameters:
 //   - t: The testing.T instance for logging and assertions.
@@ -540,7 +573,9 @@ func WriteTypeScriptRunner(t *testing.T, parentModulePath, testDir, tempDir stri
 //   - tsRunner: The path to the "runner.ts" file, typically within tempDir.
 //
 // The function sets up the PATH environment variable to include the local `node_modules/.bin`
-// directory so `tsx` can be found. It then runs the script and returns its stdout.
+// directory so `tsx` can be found. Map iteration order is randomized like in Go, using the
+// seed from GOSCRIPT_MAP_SEED or a random seed that is logged to reproduce failures.
+// It then runs the script and returns its stdout.
 // If the script execution fails, it calls t.Fatalf.
 func RunTypeScriptRunner(t *testing.T, workspaceDir, tempDir, tsRunner string) string {
 	t.Helper()
@@ -551,6 +586,11 @@ func RunTypeScriptRunner(t *testing.T, workspaceDir, tempDir, tsRunner string) s
 	currentPath := os.Getenv("PATH")
 	newPath := nodeBinDir + string(os.PathListSeparator) + currentPath
 	cmd.Env = append(os.Environ(), "PATH="+newPath)
+	if os.Getenv("GOSCRIPT_MAP_SEED") == "" {
+		seed := rand.Uint32()
+		t.Logf("Randomizing map iteration order with GOSCRIPT_MAP_SEED=%d", seed)
+		cmd.Env = append(cmd.Env, fmt.Sprintf("GOSCRIPT_MAP_SEED=%d", seed))
+	}
 
 	var outBuf, errBuf bytes.Buffer
 	cmd.Stdout = io.MultiWriter(&outBuf, os.Stdout) // Changed to os.Stdout for easier debugging
@@ -722,10 +762,11 @@ func RunTypeScriptTypeCheck(t *testing.T, workspaceDir, testDir string, tsconfig
 	})
 }
 
-const runnerContentTemplate = `import { main } from %q;
+const runnerContentTemplate = `import * as $ from "@goscript/builtin/index.js";
+import { main } from %q;
 // NOTE: To debug: add a breakpoint, open a JavaScript Debug Terminal, and tsx runner.ts
 await (async () => {
-  await main();
+  await $.runMain(main); // Reports a deadlock of the goroutines like Go
   await new Promise(resolve => setTimeout(resolve, 100)); // Allow microtasks to settle
 })();
 `
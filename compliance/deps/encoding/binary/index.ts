@@ -0,0 +1,4 @@
+export { Append, BigEndian, Decode, Encode, LittleEndian, Read, Size, Write } from "./binary.gs.js"
+export type { AppendByteOrder, ByteOrder } from "./binary.gs.js"
+export { NativeEndian } from "./native_endian_little.gs.js"
+export { AppendUvarint, AppendVarint, MaxVarintLen16, MaxVarintLen32, MaxVarintLen64, PutUvarint, PutVarint, ReadUvarint, ReadVarint, Uvarint, Varint } from "./varint.gs.js"
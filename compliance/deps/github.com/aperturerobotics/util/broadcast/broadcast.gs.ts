@@ -92,9 +92,13 @@ export class Broadcast {
 		}
 		 else {
 			// slow path: use separate goroutine
-			queueMicrotask(() => {
-				holdBroadcastLock(true)
-			})
+			{
+				const __fn = holdBroadcastLock
+				const __arg0 = true
+				$.go(async () => {
+					await __fn!(__arg0)
+				}, "broadcast.go:55")
+			}
 		}
 	}
 
@@ -170,11 +174,11 @@ export class Broadcast {
 
 	// Register this type with the runtime type system
 	static __typeInfo = $.registerStructType(
-	  'Broadcast',
+	  'github.com/aperturerobotics/util/broadcast.Broadcast',
 	  new Broadcast(),
-	  [{ name: "HoldLock", args: [{ name: "cb", type: { kind: $.TypeKind.Function, params: [{ kind: $.TypeKind.Function, params: [], results: [] }, { kind: $.TypeKind.Function, params: [], results: [{ kind: $.TypeKind.Channel, direction: "receive", elemType: { kind: $.TypeKind.Struct, fields: {}, methods: [] } }] }], results: [] } }], returns: [] }, { name: "TryHoldLock", args: [{ name: "cb", type: { kind: $.TypeKind.Function, params: [{ kind: $.TypeKind.Function, params: [], results: [] }, { kind: $.TypeKind.Function, params: [], results: [{ kind: $.TypeKind.Channel, direction: "receive", elemType: { kind: $.TypeKind.Struct, fields: {}, methods: [] } }] }], results: [] } }], returns: [{ type: { kind: $.TypeKind.Basic, name: "boolean" } }] }, { name: "HoldLockMaybeAsync", args: [{ name: "cb", type: { kind: $.TypeKind.Function, params: [{ kind: $.TypeKind.Function, params: [], results: [] }, { kind: $.TypeKind.Function, params: [], results: [{ kind: $.TypeKind.Channel, direction: "receive", elemType: { kind: $.TypeKind.Struct, fields: {}, methods: [] } }] }], results: [] } }], returns: [] }, { name: "Wait", args: [{ name: "ctx", type: "Context" }, { name: "cb", type: { kind: $.TypeKind.Function, params: [{ kind: $.TypeKind.Function, params: [], results: [] }, { kind: $.TypeKind.Function, params: [], results: [{ kind: $.TypeKind.Channel, direction: "receive", elemType: { kind: $.TypeKind.Struct, fields: {}, methods: [] } }] }], results: [{ kind: $.TypeKind.Basic, name: "boolean" }, { kind: $.TypeKind.Interface, name: 'GoError', methods: [{ name: 'Error', args: [], returns: [{ type: { kind: $.TypeKind.Basic, name: 'string' } }] }] }] } }], returns: [{ type: { kind: $.TypeKind.Interface, name: 'GoError', methods: [{ name: 'Error', args: [], returns: [{ type: { kind: $.TypeKind.Basic, name: 'string' } }] }] } }] }, { name: "broadcastLocked", args: [], returns: [] }, { name: "getWaitChLocked", args: [], returns: [{ type: { kind: $.TypeKind.Channel, direction: "receive", elemType: { kind: $.TypeKind.Struct, fields: {}, methods: [] } } }] }],
+	  [{ name: "HoldLock", args: [{ name: "cb", type: { kind: $.TypeKind.Function, params: [{ kind: $.TypeKind.Function, params: [], results: [] }, { kind: $.TypeKind.Function, params: [], results: [{ kind: $.TypeKind.Channel, direction: "receive", elemType: { kind: $.TypeKind.Struct, fields: [], methods: [] } }] }], results: [] } }], returns: [] }, { name: "TryHoldLock", args: [{ name: "cb", type: { kind: $.TypeKind.Function, params: [{ kind: $.TypeKind.Function, params: [], results: [] }, { kind: $.TypeKind.Function, params: [], results: [{ kind: $.TypeKind.Channel, direction: "receive", elemType: { kind: $.TypeKind.Struct, fields: [], methods: [] } }] }], results: [] } }], returns: [{ type: { kind: $.TypeKind.Basic, name: "boolean" } }] }, { name: "HoldLockMaybeAsync", args: [{ name: "cb", type: { kind: $.TypeKind.Function, params: [{ kind: $.TypeKind.Function, params: [], results: [] }, { kind: $.TypeKind.Function, params: [], results: [{ kind: $.TypeKind.Channel, direction: "receive", elemType: { kind: $.TypeKind.Struct, fields: [], methods: [] } }] }], results: [] } }], returns: [] }, { name: "Wait", args: [{ name: "ctx", type: "context.Context" }, { name: "cb", type: { kind: $.TypeKind.Function, params: [{ kind: $.TypeKind.Function, params: [], results: [] }, { kind: $.TypeKind.Function, params: [], results: [{ kind: $.TypeKind.Channel, direction: "receive", elemType: { kind: $.TypeKind.Struct, fields: [], methods: [] } }] }], results: [{ kind: $.TypeKind.Basic, name: "boolean" }, { kind: $.TypeKind.Interface, name: 'GoError', methods: [{ name: 'Error', args: [], returns: [{ type: { kind: $.TypeKind.Basic, name: 'string' } }] }] }] } }], returns: [{ type: { kind: $.TypeKind.Interface, name: 'GoError', methods: [{ name: 'Error', args: [], returns: [{ type: { kind: $.TypeKind.Basic, name: 'string' } }] }] } }] }, { name: "broadcastLocked", args: [], returns: [] }, { name: "getWaitChLocked", args: [], returns: [{ type: { kind: $.TypeKind.Channel, direction: "receive", elemType: { kind: $.TypeKind.Struct, fields: [], methods: [] } } }] }],
 	  Broadcast,
-	  {"mtx": "Mutex", "ch": { kind: $.TypeKind.Channel, direction: "both", elemType: { kind: $.TypeKind.Struct, fields: {}, methods: [] } }}
+	  [{ name: "mtx", type: "sync.Mutex" }, { name: "ch", type: { kind: $.TypeKind.Channel, direction: "both", elemType: { kind: $.TypeKind.Struct, fields: [], methods: [] } } }]
 	);
 }
 
@@ -180,11 +180,11 @@ export class Mutex {
 
 	// Register this type with the runtime type system
 	static __typeInfo = $.registerStructType(
-	  'Mutex',
+	  'github.com/aperturerobotics/util/csync.Mutex',
 	  new Mutex(),
-	  [{ name: "Lock", args: [{ name: "ctx", type: "Context" }], returns: [{ type: { kind: $.TypeKind.Function, params: [], results: [] } }, { type: { kind: $.TypeKind.Interface, name: 'GoError', methods: [{ name: 'Error', args: [], returns: [{ type: { kind: $.TypeKind.Basic, name: 'string' } }] }] } }] }, { name: "TryLock", args: [], returns: [{ type: { kind: $.TypeKind.Function, params: [], results: [] } }, { type: { kind: $.TypeKind.Basic, name: "boolean" } }] }, { name: "Locker", args: [], returns: [{ type: "Locker" }] }],
+	  [{ name: "Lock", args: [{ name: "ctx", type: "context.Context" }], returns: [{ type: { kind: $.TypeKind.Function, params: [], results: [] } }, { type: { kind: $.TypeKind.Interface, name: 'GoError', methods: [{ name: 'Error', args: [], returns: [{ type: { kind: $.TypeKind.Basic, name: 'string' } }] }] } }] }, { name: "TryLock", args: [], returns: [{ type: { kind: $.TypeKind.Function, params: [], results: [] } }, { type: { kind: $.TypeKind.Basic, name: "boolean" } }] }, { name: "Locker", args: [], returns: [{ type: "sync.Locker" }] }],
 	  Mutex,
-	  {"bcast": "Broadcast", "locked": { kind: $.TypeKind.Basic, name: "boolean" }}
+	  [{ name: "bcast", type: "github.com/aperturerobotics/util/broadcast.Broadcast" }, { name: "locked", type: { kind: $.TypeKind.Basic, name: "bool" } }]
 	);
 }
 
@@ -237,7 +237,7 @@ export class MutexLocker {
 	// Unlock implements the sync.Locker interface.
 	public Unlock(): void {
 		const l = this
-		let rel = null
+		let rel = l.rel.Swap(null)
 		if (rel == null) {
 			$.panic("csync: unlock of unlocked MutexLocker")
 		}
@@ -246,11 +246,11 @@ export class MutexLocker {
 
 	// Register this type with the runtime type system
 	static __typeInfo = $.registerStructType(
-	  'MutexLocker',
+	  'github.com/aperturerobotics/util/csync.MutexLocker',
 	  new MutexLocker(),
 	  [{ name: "Lock", args: [], returns: [] }, { name: "Unlock", args: [], returns: [] }],
 	  MutexLocker,
-	  {"m": { kind: $.TypeKind.Pointer, elemType: "Mutex" }, "rel": "Pointer"}
+	  [{ name: "m", type: { kind: $.TypeKind.Pointer, elemType: "github.com/aperturerobotics/util/csync.Mutex" } }, { name: "rel", type: "sync/atomic.Pointer" }]
 	);
 }
 
@@ -240,11 +240,11 @@ export class RWMutex {
 
 	// Register this type with the runtime type system
 	static __typeInfo = $.registerStructType(
-	  'RWMutex',
+	  'github.com/aperturerobotics/util/csync.RWMutex',
 	  new RWMutex(),
-	  [{ name: "Lock", args: [{ name: "ctx", type: "Context" }, { name: "write", type: { kind: $.TypeKind.Basic, name: "boolean" } }], returns: [{ type: { kind: $.TypeKind.Function, params: [], results: [] } }, { type: { kind: $.TypeKind.Interface, name: 'GoError', methods: [{ name: 'Error', args: [], returns: [{ type: { kind: $.TypeKind.Basic, name: 'string' } }] }] } }] }, { name: "TryLock", args: [{ name: "write", type: { kind: $.TypeKind.Basic, name: "boolean" } }], returns: [{ type: { kind: $.TypeKind.Function, params: [], results: [] } }, { type: { kind: $.TypeKind.Basic, name: "boolean" } }] }, { name: "Locker", args: [], returns: [{ type: "Locker" }] }, { name: "RLocker", args: [], returns: [{ type: "Locker" }] }],
+	  [{ name: "Lock", args: [{ name: "ctx", type: "context.Context" }, { name: "write", type: { kind: $.TypeKind.Basic, name: "boolean" } }], returns: [{ type: { kind: $.TypeKind.Function, params: [], results: [] } }, { type: { kind: $.TypeKind.Interface, name: 'GoError', methods: [{ name: 'Error', args: [], returns: [{ type: { kind: $.TypeKind.Basic, name: 'string' } }] }] } }] }, { name: "TryLock", args: [{ name: "write", type: { kind: $.TypeKind.Basic, name: "boolean" } }], returns: [{ type: { kind: $.TypeKind.Function, params: [], results: [] } }, { type: { kind: $.TypeKind.Basic, name: "boolean" } }] }, { name: "Locker", args: [], returns: [{ type: "sync.Locker" }] }, { name: "RLocker", args: [], returns: [{ type: "sync.Locker" }] }],
 	  RWMutex,
-	  {"bcast": "Broadcast", "nreaders": { kind: $.TypeKind.Basic, name: "number" }, "writing": { kind: $.TypeKind.Basic, name: "boolean" }, "writeWaiting": { kind: $.TypeKind.Basic, name: "number" }}
+	  [{ name: "bcast", type: "github.com/aperturerobotics/util/broadcast.Broadcast" }, { name: "nreaders", type: { kind: $.TypeKind.Basic, name: "int" } }, { name: "writing", type: { kind: $.TypeKind.Basic, name: "bool" } }, { name: "writeWaiting", type: { kind: $.TypeKind.Basic, name: "int" } }]
 	);
 }
 
@@ -338,11 +338,11 @@ export class RWMutexLocker {
 
 	// Register this type with the runtime type system
 	static __typeInfo = $.registerStructType(
-	  'RWMutexLocker',
+	  'github.com/aperturerobotics/util/csync.RWMutexLocker',
 	  new RWMutexLocker(),
 	  [{ name: "Lock", args: [], returns: [] }, { name: "Unlock", args: [], returns: [] }],
 	  RWMutexLocker,
-	  {"m": { kind: $.TypeKind.Pointer, elemType: "RWMutex" }, "write": { kind: $.TypeKind.Basic, name: "boolean" }, "mtx": "Mutex", "rels": { kind: $.TypeKind.Slice, elemType: { kind: $.TypeKind.Function, params: [], results: [] } }}
+	  [{ name: "m", type: { kind: $.TypeKind.Pointer, elemType: "github.com/aperturerobotics/util/csync.RWMutex" } }, { name: "write", type: { kind: $.TypeKind.Basic, name: "bool" } }, { name: "mtx", type: "sync.Mutex" }, { name: "rels", type: { kind: $.TypeKind.Slice, elemType: { kind: $.TypeKind.Function, params: [], results: [] } } }]
 	);
 }
 
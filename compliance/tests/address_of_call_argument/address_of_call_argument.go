@@ -0,0 +1,24 @@
+package main
+
+func setInt(p *int) { *p = 5 }
+
+func setMap(p *map[string]int) { *p = map[string]int{"a": 1} }
+
+func setSlice(p *[]string) { *p = append(*p, "x") }
+
+func setAny(p *any) { *p = "set" }
+
+func main() {
+	var n int
+	setInt(&n)
+	var m map[string]int
+	setMap(&m)
+	s := []string{"w"}
+	setSlice(&s)
+	setSlice(&s)
+	var a any
+	setAny(&a)
+	println(n, len(m), m["a"], len(s), s[2], a.(string))
+	n++
+	println(n)
+}
@@ -0,0 +1,36 @@
+// Generated file based on address_of_call_argument.go
+// Updated when compliance tests are re-run, DO NOT EDIT!
+
+import * as $ from "@goscript/builtin/index.js";
+
+export function setInt(p: $.VarRef<number> | null): void {
+	p!.value = 5
+}
+
+export function setMap(p: $.VarRef<Map<string, number> | null> | null): void {
+	p!.value = new Map([["a", 1]])
+}
+
+export function setSlice(p: $.VarRef<$.Slice<string>> | null): void {
+	p!.value = $.append(p!.value, "x")
+}
+
+export function setAny(p: null | any | null): void {
+	p!.value = "set"
+}
+
+export async function main(): Promise<void> {
+	let n: $.VarRef<number> = $.varRef(0)
+	setInt(n)
+	let m: $.VarRef<Map<string, number> | null> = $.varRef(null)
+	setMap(m)
+	let s = $.varRef($.arrayToSlice<string>(["w"]))
+	setSlice(s)
+	setSlice(s)
+	let a: $.VarRef<null | any> = $.varRef(null)
+	setAny(a)
+	console.log(n!.value, $.len(m!.value), $.mapGet(m!.value, "a", 0)[0], $.len(s!.value), s!.value![2], $.mustTypeAssert<string>(a!.value, {kind: $.TypeKind.Basic, name: 'string'}))
+	n!.value++
+	console.log(n!.value)
+}
+
@@ -13,7 +13,7 @@ export async function main(): Promise<void> {
 	console.log(b) // Expected output: 8
 
 	let c: number = 16
-	c /= 4
+	c = Math.trunc(c / 4)
 	console.log(c) // Expected output: 4
 
 	let d: number = 3
@@ -21,7 +21,7 @@ export async function main(): Promise<void> {
 	console.log(d) // Expected output: 15
 
 	let e: number = 10
-	e %= 3
+	e = (e % 3)
 	console.log(e) // Expected output: 1
 
 	let f: number = 5
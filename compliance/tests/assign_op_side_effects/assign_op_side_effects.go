@@ -0,0 +1,81 @@
+package main
+
+var calls int
+
+func next() int {
+	calls++
+	return calls - 1
+}
+
+type point struct {
+	x uint8
+}
+
+func ptr(p *uint16) *uint16 {
+	calls++
+	return p
+}
+
+var points = []*point{{x: 250}, {x: 1}}
+
+func pick() *point {
+	calls++
+	return points[0]
+}
+
+func key() string {
+	calls++
+	return "k"
+}
+
+func main() {
+	// Index expressions of slices are evaluated once
+	buf := []uint8{250, 250, 250}
+	buf[next()] += 10
+	buf[next()]++
+	buf[next()]--
+	println(buf[0], buf[1], buf[2], "calls", calls)
+
+	calls = 0
+	xs := []int{10, 20, 30, 40}
+	xs[next()+3] /= 3
+	xs[next()] %= 7
+	println(xs[0], xs[1], xs[2], xs[3], "calls", calls)
+
+	// Arrays
+	calls = 0
+	var arr [3]int8
+	arr[next()] -= 100
+	arr[next()] -= 100
+	arr[0] -= 100
+	println(arr[0], arr[1], arr[2], "calls", calls)
+
+	// Map keys are evaluated once
+	calls = 0
+	m := map[string]uint8{"k": 255}
+	m[key()]++
+	m[key()] += 3
+	println(m["k"], "calls", calls)
+
+	// Pointer indirections and selectors through pointers
+	calls = 0
+	var counter uint16 = 65535
+	*ptr(&counter) += 2
+	pick().x += 10
+	pick().x++
+	println(counter, points[0].x, "calls", calls)
+
+	// Nested index expressions
+	calls = 0
+	grid := [][]uint8{{1, 2}, {3, 4}}
+	grid[next()][next()] *= 200
+	println(grid[0][0], grid[0][1], grid[1][0], grid[1][1], "calls", calls)
+
+	// Post statements of for loops
+	calls = 0
+	counts := []uint8{0, 0, 0}
+	for i := 0; i < 3; counts[next()]++ {
+		i++
+	}
+	println(counts[0], counts[1], counts[2], "calls", calls)
+}
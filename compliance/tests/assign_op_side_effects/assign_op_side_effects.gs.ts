@@ -0,0 +1,160 @@
+// Generated file based on assign_op_side_effects.go
+// Updated when compliance tests are re-run, DO NOT EDIT!
+
+import * as $ from "@goscript/builtin/index.js";
+
+let calls: number = 0
+
+export function next(): number {
+	calls++
+	return calls - 1
+}
+
+export class point {
+	public get x(): number {
+		return this._fields.x.value
+	}
+	public set x(value: number) {
+		this._fields.x.value = value
+	}
+
+	public _fields: {
+		x: $.VarRef<number>;
+	}
+
+	constructor(init?: Partial<{x?: number}>) {
+		this._fields = {
+			x: $.varRef(init?.x ?? 0)
+		}
+	}
+
+	public clone(): point {
+		const cloned = new point()
+		cloned._fields = {
+			x: $.varRef(this._fields.x.value)
+		}
+		return cloned
+	}
+
+	// Register this type with the runtime type system
+	static __typeInfo = $.registerStructType(
+	  'main@github.com/aperturerobotics/goscript/compliance/tests/assign_op_side_effects.point',
+	  new point(),
+	  [],
+	  point,
+	  [{ name: "x", type: { kind: $.TypeKind.Basic, name: "uint8" } }]
+	);
+}
+
+export function ptr(p: $.VarRef<number> | null): $.VarRef<number> | null {
+	calls++
+	return p
+}
+
+let points = $.arrayToSlice<point | null>([new point({x: 250}), new point({x: 1})])
+
+export function pick(): point | null {
+	calls++
+	return points![0]
+}
+
+export function key(): string {
+	calls++
+	return "k"
+}
+
+export async function main(): Promise<void> {
+	// Index expressions of slices are evaluated once
+	let buf = new Uint8Array([250, 250, 250])
+	{
+		const _tmp0 = next()
+		buf![_tmp0] = ((buf![_tmp0] + 10) & 0xff)
+	}
+	{
+		const _tmp0 = next()
+		buf![_tmp0] = ((buf![_tmp0] + 1) & 0xff)
+	}
+	{
+		const _tmp0 = next()
+		buf![_tmp0] = ((buf![_tmp0] - 1) & 0xff)
+	}
+	console.log(buf![0], buf![1], buf![2], "calls", calls)
+
+	calls = 0
+	let xs = $.arrayToSlice<number>([10, 20, 30, 40])
+	{
+		const _tmp0 = next() + 3
+		xs![_tmp0] = Math.trunc(xs![_tmp0] / 3)
+	}
+	{
+		const _tmp0 = next()
+		xs![_tmp0] = (xs![_tmp0] % 7)
+	}
+	console.log(xs![0], xs![1], xs![2], xs![3], "calls", calls)
+
+	// Arrays
+	calls = 0
+	let arr: number[] = [0, 0, 0]
+	{
+		const _tmp0 = next()
+		arr![_tmp0] = ((arr![_tmp0] - 100) << 24 >> 24)
+	}
+	{
+		const _tmp0 = next()
+		arr![_tmp0] = ((arr![_tmp0] - 100) << 24 >> 24)
+	}
+	arr![0] = ((arr![0] - 100) << 24 >> 24)
+	console.log(arr![0], arr![1], arr![2], "calls", calls)
+
+	// Map keys are evaluated once
+	calls = 0
+	let m = new Map([["k", 255]])
+	{
+		const _tmp0 = key()
+		$.mapSet(m, _tmp0, (($.mapGet(m, _tmp0, 0)[0] + 1) & 0xff))
+	}
+	{
+		const _tmp0 = key()
+		$.mapSet(m, _tmp0, (($.mapGet(m, _tmp0, 0)[0] + 3) & 0xff))
+	}
+	console.log($.mapGet(m, "k", 0)[0], "calls", calls)
+
+	// Pointer indirections and selectors through pointers
+	calls = 0
+	let counter: $.VarRef<number> = $.varRef(65535)
+	{
+		const _tmp0 = ptr(counter)
+		_tmp0!.value = ((_tmp0!.value + 2) & 0xffff)
+	}
+	{
+		const _tmp0 = pick()
+		_tmp0!.x = ((_tmp0!.x + 10) & 0xff)
+	}
+	{
+		const _tmp0 = pick()
+		_tmp0!.x = ((_tmp0!.x + 1) & 0xff)
+	}
+	console.log(counter!.value, points![0]!.x, "calls", calls)
+
+	// Nested index expressions
+	calls = 0
+	let grid = $.arrayToSlice<$.Slice<number>>([[ 1, 2 ], [ 3, 4 ]], 2)
+	{
+		const _tmp0 = grid![next()]
+		const _tmp1 = next()
+		_tmp0![_tmp1] = (Math.imul(_tmp0![_tmp1], 200) & 0xff)
+	}
+	console.log(grid![0]![0], grid![0]![1], grid![1]![0], grid![1]![1], "calls", calls)
+
+	// Post statements of for loops
+	calls = 0
+	let counts = new Uint8Array([0, 0, 0])
+	for (let i = 0; i < 3; await (async () => {
+		const _tmp0 = next()
+		counts![_tmp0] = ((counts![_tmp0] + 1) & 0xff)
+	})()) {
+		i++
+	}
+	console.log(counts![0], counts![1], counts![2], "calls", calls)
+}
+
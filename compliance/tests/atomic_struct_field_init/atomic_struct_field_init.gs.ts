@@ -53,11 +53,11 @@ export class MyStruct {
 
 	// Register this type with the runtime type system
 	static __typeInfo = $.registerStructType(
-	  'MyStruct',
+	  'main@github.com/aperturerobotics/goscript/compliance/tests/atomic_struct_field_init.MyStruct',
 	  new MyStruct(),
 	  [],
 	  MyStruct,
-	  {"closed": "Bool", "count": "Int32", "flag": "Uint32"}
+	  [{ name: "closed", type: "sync/atomic.Bool" }, { name: "count", type: "sync/atomic.Int32" }, { name: "flag", type: "sync/atomic.Uint32" }]
 	);
 }
 
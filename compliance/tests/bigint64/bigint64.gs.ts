@@ -0,0 +1,289 @@
+// Generated file based on bigint64.go
+// Updated when compliance tests are re-run, DO NOT EDIT!
+
+import * as $ from "@goscript/builtin/index.js";
+
+$.setBigInt64Mode(true)
+
+import * as fmt from "@goscript/fmt/index.js"
+
+import * as math from "@goscript/math/index.js"
+
+import * as strconv from "@goscript/strconv/index.js"
+
+import * as time from "@goscript/time/index.js"
+
+let fnvOffset64: bigint = 14695981039346656037n
+
+let fnvPrime64: bigint = 1099511628211n
+
+// fnv1a computes the 64-bit FNV-1a hash of s.
+export function fnv1a(s: string): bigint {
+	let h = 14695981039346656037n
+	for (let i = 0; i < $.len(s); i++) {
+		h = (h ^ $.uint64($.indexString(s, i)))
+		h = BigInt.asUintN(64, h * 1099511628211n)
+	}
+	return h
+}
+
+// mix is the 64-bit finalizer of MurmurHash3.
+export function mix(k: bigint): bigint {
+	k = (k ^ (k >> 33n))
+	k = BigInt.asUintN(64, k * 18397679294719823053n)
+	k = (k ^ (k >> 33n))
+	k = BigInt.asUintN(64, k * 14181476777654086739n)
+	k = (k ^ (k >> 33n))
+	return k
+}
+
+export type ID = bigint;
+
+export function ID_Node(id: ID): number {
+	return (Number((id >> 12n)) & 0x3ff)
+}
+
+
+$.registerNamedType(
+  'main@github.com/aperturerobotics/goscript/compliance/tests/bigint64.ID',
+  0n,
+  [{ name: "Node", args: [], returns: [{ type: { kind: $.TypeKind.Basic, name: "number" } }] }],
+  { kind: $.TypeKind.Basic, name: "int64" },
+  { Node: ID_Node }
+);
+
+// snowflake composes an ID from a timestamp, a node and a sequence number.
+export function snowflake(ms: bigint, node: number, seq: number): ID {
+	return ((BigInt.asIntN(64, ms << 22n) | BigInt.asIntN(64, $.int64(node) << 12n)) | $.int64((seq & 0xfff)))
+}
+
+export class counter {
+	public get total(): bigint {
+		return this._fields.total.value
+	}
+	public set total(value: bigint) {
+		this._fields.total.value = value
+	}
+
+	public get count(): bigint {
+		return this._fields.count.value
+	}
+	public set count(value: bigint) {
+		this._fields.count.value = value
+	}
+
+	public _fields: {
+		total: $.VarRef<bigint>;
+		count: $.VarRef<bigint>;
+	}
+
+	constructor(init?: Partial<{count?: bigint, total?: bigint}>) {
+		this._fields = {
+			total: $.varRef(init?.total ?? 0n),
+			count: $.varRef(init?.count ?? 0n)
+		}
+	}
+
+	public clone(): counter {
+		const cloned = new counter()
+		cloned._fields = {
+			total: $.varRef(this._fields.total.value),
+			count: $.varRef(this._fields.count.value)
+		}
+		return cloned
+	}
+
+	// Register this type with the runtime type system
+	static __typeInfo = $.registerStructType(
+	  'main@github.com/aperturerobotics/goscript/compliance/tests/bigint64.counter',
+	  new counter(),
+	  [],
+	  counter,
+	  [{ name: "total", type: { kind: $.TypeKind.Basic, name: "int64" } }, { name: "count", type: { kind: $.TypeKind.Basic, name: "uint64" } }]
+	);
+}
+
+export class limitError {
+	public get max(): bigint {
+		return this._fields.max.value
+	}
+	public set max(value: bigint) {
+		this._fields.max.value = value
+	}
+
+	public _fields: {
+		max: $.VarRef<bigint>;
+	}
+
+	constructor(init?: Partial<{max?: bigint}>) {
+		this._fields = {
+			max: $.varRef(init?.max ?? 0n)
+		}
+	}
+
+	public clone(): limitError {
+		const cloned = new limitError()
+		cloned._fields = {
+			max: $.varRef(this._fields.max.value)
+		}
+		return cloned
+	}
+
+	public Error(): string {
+		const e = this
+		return "over limit " + strconv.FormatUint(e.max, 10)
+	}
+
+	public String(): string {
+		return "limitError"
+	}
+
+	// Register this type with the runtime type system
+	static __typeInfo = $.registerStructType(
+	  'main@github.com/aperturerobotics/goscript/compliance/tests/bigint64.limitError',
+	  new limitError(),
+	  [{ name: "Error", args: [], returns: [{ type: { kind: $.TypeKind.Basic, name: "string" } }] }, { name: "String", args: [], returns: [{ type: { kind: $.TypeKind.Basic, name: "string" } }] }],
+	  limitError,
+	  [{ name: "max", type: { kind: $.TypeKind.Basic, name: "uint64" } }]
+	);
+}
+
+// divide returns a/b and a%b, or the panic message when b is zero.
+export function divide(a: bigint, b: bigint): [bigint, string] {
+	let q: bigint = 0n
+	let r: bigint = 0n
+	let msg: string = ""
+	{
+		const __defer = new $.DisposableStack();
+		try {
+			__defer.defer((__frame) => {
+				{
+					let e = $.recover(__frame)
+					if (e != null) {
+						msg = $.mustTypeAssert<$.GoError>(e, 'error')!.Error()
+					}
+				}
+			});
+			;[q, r] = [a, a]
+			q = BigInt.asIntN(64, $.bigIntDiv(q, b))
+			r = $.bigIntRem(r, b)
+			;[q, r, msg] = [q, r, ""]
+			return [q, r, msg]
+		} catch (__e) {
+			__defer.panic(__e)
+		} finally {
+			__defer.dispose()
+			return [q, r, msg]
+		}
+	}
+}
+
+// divideUnsigned is divide for uint64.
+export function divideUnsigned(a: bigint, b: bigint): [bigint, string] {
+	let q: bigint = 0n
+	let r: bigint = 0n
+	let msg: string = ""
+	{
+		const __defer = new $.DisposableStack();
+		try {
+			__defer.defer((__frame) => {
+				{
+					let e = $.recover(__frame)
+					if (e != null) {
+						msg = $.mustTypeAssert<$.GoError>(e, 'error')!.Error()
+					}
+				}
+			});
+			;[q, r, msg] = [BigInt.asUintN(64, $.bigIntDiv(a, b)), $.bigIntRem(a, b), ""]
+			return [q, r, msg]
+		} catch (__e) {
+			__defer.panic(__e)
+		} finally {
+			__defer.dispose()
+			return [q, r, msg]
+		}
+	}
+}
+
+export async function main(): Promise<void> {
+	// Wrapping arithmetic
+	$.println("fnv1a:", fnv1a("hello world"))
+	$.println("mix:", mix(42n))
+	let max: bigint = 9223372036854775807n
+	max = BigInt.asIntN(64, max + 1n)
+	$.println("overflow:", max, max == -9223372036854775808n)
+	let u: bigint = 0n
+	u = BigInt.asUintN(64, u - 1n)
+	$.println("underflow:", u)
+	$.println("negate:", BigInt.asIntN(64, -(max)), BigInt.asUintN(64, ~(u)))
+	let x: bigint = -7n
+	$.println("div/rem:", BigInt.asIntN(64, x / 2n), (x % 2n), (x >> 1n), (BigInt.asUintN(64, x) >> 60n))
+	x = (x & ~(3n))
+	x = BigInt.asIntN(64, x << 3n)
+	$.println("and not, shift:", x)
+	let [q, r, msg] = divide(x, -3n)
+	$.println("divide:", q, r, msg)
+	;[q, r, msg] = divide(x, 0n)
+	$.println("divide by zero:", q, r, msg)
+	;[q, r, msg] = divide(-9223372036854775808n, -1n)
+	$.println("divide min:", q, r, msg)
+	let uq: bigint
+	let ur: bigint
+	[uq, ur, msg] = divideUnsigned(18446744073709551615n, 10n)
+	$.println("divide unsigned:", uq, ur, msg)
+	;[uq, ur, msg] = divideUnsigned(18446744073709551615n, 0n)
+	$.println("divide unsigned by zero:", uq, ur, msg)
+
+	// Conversions
+	let f = 3.9e18
+	$.println("from float:", $.int64(f), $.int64(-f))
+	let big = 9223372036854775813n
+	$.println("narrowing:", Number(BigInt.asIntN(32, big)), Number(BigInt.asUintN(8, big)), Number((big & 65535n)), Number(big) > 9e18)
+	let minusOne = -1n
+	$.println("sign:", BigInt.asIntN(64, big), BigInt.asUintN(64, minusOne))
+
+	// Named types and methods
+	let id = snowflake(1700000000123n, 513, 7)
+	$.println("snowflake:", id, ID_Node(id))
+
+	// Struct fields and slices
+	let c = new counter({})
+	let values = $.arrayToSlice<bigint>([1099511627776n, -2199023255552n, 5n])
+	for (let i = 0; i < $.len(values); i++) {
+		const v = values![i]
+		{
+			c.total = BigInt.asIntN(64, c.total + v)
+			c.count = BigInt.asUintN(64, c.count + 1n)
+			$.println("values:", i, values![i])
+		}
+	}
+	$.println("counter:", c.total, c.count)
+	let zeros = $.makeSlice<bigint>(2, undefined, 'bigint')
+	zeros![1] = BigInt.asIntN(64, zeros![1] + 1099511627776n)
+	$.println("zeros:", zeros![0], zeros![1])
+	let grown = $.makeSlice<bigint>(1, 4, 'bigint')
+	grown = $.append(grown, 9223372036854775808n)
+	let fixed: bigint[] = [0n, 0n]
+	fixed![0] = BigInt.asUintN(64, fixed![0] - 1n)
+	$.println("grown:", grown![0], grown![1], $.len(grown), fixed![0], fixed![1])
+
+	// strconv and fmt
+	let s = strconv.FormatUint(fnv1a("goscript"), 16)
+	fmt.Println("hex:", s)
+	let [parsed, err] = strconv.ParseUint(s, 16, 64)
+	fmt.Println("parsed:", parsed == fnv1a("goscript"), err)
+	let n: bigint
+	[n, err] = strconv.ParseInt("-9223372036854775808", 10, 64)
+	fmt.Println("min int64:", n, err)
+	;[, err] = strconv.ParseInt("9223372036854775808", 10, 64)
+	fmt.Println("range:", err)
+	err = new limitError({max: math.MaxUint64})
+	fmt.Println("limit:", err)
+	fmt.Printf("%d %x %v\n", -4611686018427387904n, 18446744073709551615n, $.namedValue(42n, "main@github.com/aperturerobotics/goscript/compliance/tests/bigint64.ID"))
+	fmt.Println(strconv.Itoa(Number((fnv1a("a") % 1000n))), strconv.FormatInt(-9223372036854775808n, 10))
+
+	// Handwritten packages using number
+	let t = time.UnixMilli(1700000000123).clone()
+	$.println("time:", BigInt.asIntN(64, $.int64(t.UnixMilli()) + 1n))
+}
+
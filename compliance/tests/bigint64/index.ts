@@ -0,0 +1,2 @@
+export { ID_Node } from "./bigint64.gs.js"
+export type { ID } from "./bigint64.gs.js"
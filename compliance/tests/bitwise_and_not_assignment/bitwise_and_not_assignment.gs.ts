@@ -5,15 +5,15 @@ import * as $ from "@goscript/builtin/index.js";
 
 export async function main(): Promise<void> {
 	// Test the &^= operator (bit clear assignment)
-	let x = (0x7FF0000000000000 as number) // Some bits set
-	let mask = ((2047 << 52) as number) // Mask to clear
+	let x = 9218868437227405312 // Some bits set
+	let mask = 9218868437227405312 // Mask to clear
 
 	console.log("Before:", x)
 	x &= ~(mask) // This should generate valid TypeScript
 	console.log("After:", x)
 
 	// Also test regular &^ operator
-	let y = (0x7FF0000000000000 as number)
+	let y = 9218868437227405312
 	let result = (y & ~ mask)
 	console.log("Result:", result)
 }
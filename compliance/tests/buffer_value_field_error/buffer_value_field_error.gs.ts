@@ -46,11 +46,11 @@ export class buffer {
 
 	// Register this type with the runtime type system
 	static __typeInfo = $.registerStructType(
-	  'buffer',
+	  'main@github.com/aperturerobotics/goscript/compliance/tests/buffer_value_field_error.buffer',
 	  new buffer(),
 	  [{ name: "write", args: [{ name: "p", type: { kind: $.TypeKind.Slice, elemType: { kind: $.TypeKind.Basic, name: "number" } } }], returns: [] }, { name: "writeString", args: [{ name: "s", type: { kind: $.TypeKind.Basic, name: "string" } }], returns: [] }, { name: "writeByte", args: [{ name: "c", type: { kind: $.TypeKind.Basic, name: "number" } }], returns: [] }],
 	  buffer,
-	  {"data": { kind: $.TypeKind.Slice, elemType: { kind: $.TypeKind.Basic, name: "number" } }}
+	  [{ name: "data", type: { kind: $.TypeKind.Slice, elemType: { kind: $.TypeKind.Basic, name: "byte" } } }]
 	);
 }
 
@@ -0,0 +1,41 @@
+package main
+
+type Node struct {
+	Name string
+}
+
+// describe is called with a nil argument, which is not a conversion.
+func describe(n *Node) string {
+	if n == nil {
+		return "no node"
+	}
+	return "node " + n.Name
+}
+
+type Buffer struct {
+	data []byte
+}
+
+// Append is a method called with a nil argument.
+func (b *Buffer) Append(p []byte) []byte {
+	return append(p, b.data...)
+}
+
+type Handler func(string) string
+
+func main() {
+	println(describe(nil))
+	println(describe(&Node{Name: "a"}))
+
+	b := &Buffer{data: []byte("xy")}
+	println(string(b.Append(nil)), len(b.Append(nil)))
+
+	f := func(err error) bool { return err == nil }
+	println(f(nil))
+
+	// Conversions of nil are still nil.
+	println((*Node)(nil) == nil, []byte(nil) == nil, Handler(nil) == nil)
+	var p any = (*Node)(nil)
+	_, ok := p.(*Node)
+	println(ok)
+}
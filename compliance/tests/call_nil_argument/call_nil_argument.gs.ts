@@ -0,0 +1,119 @@
+// Generated file based on call_nil_argument.go
+// Updated when compliance tests are re-run, DO NOT EDIT!
+
+import * as $ from "@goscript/builtin/index.js";
+
+export class Node {
+	public get Name(): string {
+		return this._fields.Name.value
+	}
+	public set Name(value: string) {
+		this._fields.Name.value = value
+	}
+
+	public _fields: {
+		Name: $.VarRef<string>;
+	}
+
+	constructor(init?: Partial<{Name?: string}>) {
+		this._fields = {
+			Name: $.varRef(init?.Name ?? "")
+		}
+	}
+
+	public clone(): Node {
+		const cloned = new Node()
+		cloned._fields = {
+			Name: $.varRef(this._fields.Name.value)
+		}
+		return cloned
+	}
+
+	// Register this type with the runtime type system
+	static __typeInfo = $.registerStructType(
+	  'main@github.com/aperturerobotics/goscript/compliance/tests/call_nil_argument.Node',
+	  new Node(),
+	  [],
+	  Node,
+	  [{ name: "Name", type: { kind: $.TypeKind.Basic, name: "string" } }]
+	);
+}
+
+// describe is called with a nil argument, which is not a conversion.
+export function describe(n: Node | null): string {
+	if (n == null) {
+		return "no node"
+	}
+	return "node " + n.Name
+}
+
+export class Buffer {
+	public get data(): $.Bytes {
+		return this._fields.data.value
+	}
+	public set data(value: $.Bytes) {
+		this._fields.data.value = value
+	}
+
+	public _fields: {
+		data: $.VarRef<$.Bytes>;
+	}
+
+	constructor(init?: Partial<{data?: $.Bytes}>) {
+		this._fields = {
+			data: $.varRef(init?.data ?? new Uint8Array(0))
+		}
+	}
+
+	public clone(): Buffer {
+		const cloned = new Buffer()
+		cloned._fields = {
+			data: $.varRef(this._fields.data.value)
+		}
+		return cloned
+	}
+
+	// Append is a method called with a nil argument.
+	public Append(p: $.Bytes): $.Bytes {
+		const b = this
+		return $.append(p, b.data)
+	}
+
+	// Register this type with the runtime type system
+	static __typeInfo = $.registerStructType(
+	  'main@github.com/aperturerobotics/goscript/compliance/tests/call_nil_argument.Buffer',
+	  new Buffer(),
+	  [{ name: "Append", args: [{ name: "p", type: { kind: $.TypeKind.Slice, elemType: { kind: $.TypeKind.Basic, name: "number" } } }], returns: [{ type: { kind: $.TypeKind.Slice, elemType: { kind: $.TypeKind.Basic, name: "number" } } }] }],
+	  Buffer,
+	  [{ name: "data", type: { kind: $.TypeKind.Slice, elemType: { kind: $.TypeKind.Basic, name: "byte" } } }]
+	);
+}
+
+export type Handler = ((p0: string) => string) | null;
+
+$.registerNamedType(
+  'main@github.com/aperturerobotics/goscript/compliance/tests/call_nil_argument.Handler',
+  null,
+  [],
+  { kind: $.TypeKind.Function, params: [{ kind: $.TypeKind.Basic, name: "string" }], results: [{ kind: $.TypeKind.Basic, name: "string" }] }
+);
+
+export async function main(): Promise<void> {
+	console.log(describe(null))
+	console.log(describe(new Node({Name: "a"})))
+
+	let b = new Buffer({data: $.stringToBytes("xy")})
+	console.log($.bytesToString(b.Append(null)), $.len(b.Append(null)))
+
+	let f = (err: $.GoError): boolean => {
+		return err == null
+	}
+	console.log(f!(null))
+
+	// Conversions of nil are still nil.
+	console.log(null == null, null == null, null == null)
+	let p: null | any = null
+	let { ok: ok } = $.typeAssert<Node | null>(p, {kind: $.TypeKind.Pointer, elemType: 'main@github.com/aperturerobotics/goscript/compliance/tests/call_nil_argument.Node'})
+	console.log(ok)
+}
+
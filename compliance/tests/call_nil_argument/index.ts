@@ -0,0 +1,2 @@
+export { Buffer, Node } from "./call_nil_argument.gs.js"
+export type { Handler } from "./call_nil_argument.gs.js"
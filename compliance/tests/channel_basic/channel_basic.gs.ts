@@ -6,9 +6,9 @@ import * as $ from "@goscript/builtin/index.js";
 export async function main(): Promise<void> {
 	let messages = $.makeChannel<string>(0, "", 'both')
 
-	queueMicrotask(async () => {
+	$.go(async () => {
 		await $.chanSend(messages, "ping")
-	})
+	}, "channel_basic.go:6")
 
 	let msg = await $.chanRecv(messages)
 	console.log(msg)
@@ -43,11 +43,11 @@ export class TestStruct {
 
 	// Register this type with the runtime type system
 	static __typeInfo = $.registerStructType(
-	  'TestStruct',
+	  'main@github.com/aperturerobotics/goscript/compliance/tests/comments_struct.TestStruct',
 	  new TestStruct(),
 	  [],
 	  TestStruct,
-	  {"IntField": { kind: $.TypeKind.Basic, name: "number" }, "StringField": { kind: $.TypeKind.Basic, name: "string" }}
+	  [{ name: "IntField", type: { kind: $.TypeKind.Basic, name: "int" } }, { name: "StringField", type: { kind: $.TypeKind.Basic, name: "string" } }]
 	);
 }
 
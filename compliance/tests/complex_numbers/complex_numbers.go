@@ -0,0 +1,108 @@
+package main
+
+import (
+	"fmt"
+	"math"
+	"math/cmplx"
+)
+
+type Signal []complex128
+
+type Pair [2]complex64
+
+type Roots map[complex128]string
+
+type Sample struct {
+	At    int
+	Value complex64
+}
+
+const unit = 1i
+
+// fft computes the discrete Fourier transform of a signal whose length is a
+// power of two.
+func fft(x Signal) Signal {
+	n := len(x)
+	if n == 1 {
+		return Signal{x[0]}
+	}
+	even := make(Signal, n/2)
+	odd := make(Signal, n/2)
+	for i := 0; i < n/2; i++ {
+		even[i] = x[2*i]
+		odd[i] = x[2*i+1]
+	}
+	e, o := fft(even), fft(odd)
+	out := make(Signal, n)
+	for k := 0; k < n/2; k++ {
+		t := cmplx.Exp(complex(0, -2*math.Pi*float64(k)/float64(n))) * o[k]
+		out[k] = e[k] + t
+		out[k+n/2] = e[k] - t
+	}
+	return out
+}
+
+func round(c complex128) complex128 {
+	r := math.Round(real(c)*1000) / 1000
+	i := math.Round(imag(c)*1000) / 1000
+	return complex(r+0, i+0)
+}
+
+func main() {
+	a := 1 + 2i
+	b := complex(3, -4)
+	fmt.Println(a, b)
+	fmt.Println(a+b, a-b, a*b, a/b)
+	fmt.Println(-a, real(a), imag(b))
+	fmt.Println(a == 1+2i, a != b)
+
+	// Compound assignment and increments
+	c := a
+	c *= unit
+	c += 2
+	c++
+	fmt.Println(c)
+
+	// Zero values
+	var z complex128
+	var s Sample
+	zs := make([]complex64, 2)
+	fmt.Println(z, s.Value, zs, z == 0)
+
+	// complex64 and conversions
+	var f complex64 = 1.5 + 0.5i
+	g := complex128(f) * 2
+	s.Value = complex64(g)
+	fmt.Println(f, g, s.Value, real(f))
+
+	// Map keys compare by value
+	m := map[complex128]string{}
+	m[complex(1, 1)] = "one"
+	m[1+1i] += "!"
+	fmt.Println(len(m), m[1+1i])
+
+	// Division by zero yields infinities
+	fmt.Println(cmplx.IsInf(a/0), cmplx.Abs(3+4i))
+
+	// Interfaces
+	var v interface{} = a
+	if cv, ok := v.(complex128); ok {
+		fmt.Println("complex128", cv)
+	}
+
+	// Formatting verbs
+	fmt.Printf("%.2f %v\n", a/b, b)
+
+	signal := Signal{1, 1, 1, 1, 0, 0, 0, 0}
+	spectrum := fft(signal)
+	for i, bin := range spectrum {
+		spectrum[i] = round(bin)
+	}
+	fmt.Println(spectrum)
+	fmt.Println(round(cmplx.Sqrt(-1)), round(cmplx.Pow(unit, 2)))
+
+	// Literals of named array and map types
+	pair := Pair{1 + 2i, 3}
+	roots := Roots{unit: "i", -unit: "-i"}
+	fmt.Println(pair[0]*pair[1], len(roots), roots[round(cmplx.Sqrt(-1))], roots[-1i])
+}
@@ -0,0 +1,180 @@
+// Generated file based on complex_numbers.go
+// Updated when compliance tests are re-run, DO NOT EDIT!
+
+import * as $ from "@goscript/builtin/index.js";
+
+import * as fmt from "@goscript/fmt/index.js"
+
+import * as math from "@goscript/math/index.js"
+
+import * as cmplx from "@goscript/math/cmplx/index.js"
+
+export type Signal = $.Slice<$.Complex>;
+
+$.registerNamedType(
+  'main@github.com/aperturerobotics/goscript/compliance/tests/complex_numbers.Signal',
+  null,
+  [],
+  { kind: $.TypeKind.Slice, elemType: { kind: $.TypeKind.Basic, name: "complex128" } }
+);
+
+export type Pair = $.Complex[];
+
+$.registerNamedType(
+  'main@github.com/aperturerobotics/goscript/compliance/tests/complex_numbers.Pair',
+  null,
+  [],
+  { kind: $.TypeKind.Array, length: 2, elemType: { kind: $.TypeKind.Basic, name: "complex64" } }
+);
+
+export type Roots = Map<$.Complex, string> | null;
+
+$.registerNamedType(
+  'main@github.com/aperturerobotics/goscript/compliance/tests/complex_numbers.Roots',
+  null,
+  [],
+  { kind: $.TypeKind.Map, keyType: { kind: $.TypeKind.Basic, name: "complex128" }, elemType: { kind: $.TypeKind.Basic, name: "string" } }
+);
+
+export class Sample {
+	public get At(): number {
+		return this._fields.At.value
+	}
+	public set At(value: number) {
+		this._fields.At.value = value
+	}
+
+	public get Value(): $.Complex {
+		return this._fields.Value.value
+	}
+	public set Value(value: $.Complex) {
+		this._fields.Value.value = value
+	}
+
+	public _fields: {
+		At: $.VarRef<number>;
+		Value: $.VarRef<$.Complex>;
+	}
+
+	constructor(init?: Partial<{At?: number, Value?: $.Complex}>) {
+		this._fields = {
+			At: $.varRef(init?.At ?? 0),
+			Value: $.varRef(init?.Value ?? $.complex(0, 0))
+		}
+	}
+
+	public clone(): Sample {
+		const cloned = new Sample()
+		cloned._fields = {
+			At: $.varRef(this._fields.At.value),
+			Value: $.varRef(this._fields.Value.value)
+		}
+		return cloned
+	}
+
+	// Register this type with the runtime type system
+	static __typeInfo = $.registerStructType(
+	  'main@github.com/aperturerobotics/goscript/compliance/tests/complex_numbers.Sample',
+	  new Sample(),
+	  [],
+	  Sample,
+	  [{ name: "At", type: { kind: $.TypeKind.Basic, name: "int" } }, { name: "Value", type: { kind: $.TypeKind.Basic, name: "complex64" } }]
+	);
+}
+
+let unit: $.Complex = $.complex(0, 1)
+
+// fft computes the discrete Fourier transform of a signal whose length is a
+// power of two.
+export function fft(x: Signal): Signal {
+	let n = $.len(x)
+	if (n == 1) {
+		return $.arrayToSlice<$.Complex>([x![0]])
+	}
+	let even = $.makeSlice<$.Complex>(Math.trunc(n / 2), undefined, 'complex')
+	let odd = $.makeSlice<$.Complex>(Math.trunc(n / 2), undefined, 'complex')
+	for (let i = 0; i < Math.trunc(n / 2); i++) {
+		even![i] = x![2 * i]
+		odd![i] = x![2 * i + 1]
+	}
+	let [e, o] = [fft(even), fft(odd)]
+	let out = $.makeSlice<$.Complex>(n, undefined, 'complex')
+	for (let k = 0; k < Math.trunc(n / 2); k++) {
+		let t = $.complexMul(cmplx.Exp($.complex(0, -2 * math.Pi * (k as number) / (n as number))), o![k])
+		out![k] = $.complexAdd(e![k], t)
+		out![k + Math.trunc(n / 2)] = $.complexSub(e![k], t)
+	}
+	return out
+}
+
+export function round(c: $.Complex): $.Complex {
+	let r = math.Round($.real(c) * 1000) / 1000
+	let i = math.Round($.imag(c) * 1000) / 1000
+	return $.complex(r + 0, i + 0)
+}
+
+export async function main(): Promise<void> {
+	let a = $.complex(1, 2)
+	let b = $.complex(3, -4)
+	fmt.Println(a, b)
+	fmt.Println($.complexAdd(a, b), $.complexSub(a, b), $.complexMul(a, b), $.complexDiv(a, b))
+	fmt.Println($.complexNeg(a), $.real(a), $.imag(b))
+	fmt.Println($.complexEqual(a, $.complex(1, 2)), !$.complexEqual(a, b))
+
+	// Compound assignment and increments
+	let c = a
+	c = $.complexMul(c, $.complex(0, 1))
+	c = $.complexAdd(c, $.complex(2, 0))
+	c = $.complexAdd(c, $.complex(1, 0))
+	fmt.Println(c)
+
+	// Zero values
+	let z: $.Complex = $.complex(0, 0)
+	let s: Sample = new Sample()
+	let zs = $.makeSlice<$.Complex>(2, undefined, 'complex')
+	fmt.Println(z, s.Value, zs, $.complexEqual(z, $.complex(0, 0)))
+
+	// complex64 and conversions
+	let f: $.Complex = $.complex(1.5, 0.5)
+	let g = $.complexMul(f, $.complex(2, 0))
+	s.Value = g
+	fmt.Println(f, g, s.Value, $.real(f))
+
+	// Map keys compare by value
+	let m = new $.HashMap([])
+	$.mapSet(m, $.complex(1, 1), "one")
+	$.mapSet(m, $.complex(1, 1), $.mapGet(m, $.complex(1, 1), "")[0] + "!")
+	fmt.Println($.len(m), $.mapGet(m, $.complex(1, 1), "")[0])
+
+	// Division by zero yields infinities
+	fmt.Println(cmplx.IsInf($.complexDiv(a, $.complex(0, 0))), cmplx.Abs($.complex(3, 4)))
+
+	// Interfaces
+	let v: null | any = a
+	{
+		let { value: cv, ok: ok } = $.typeAssert<$.Complex>(v, 'complex128')
+		if (ok) {
+			fmt.Println("complex128", cv)
+		}
+	}
+
+	// Formatting verbs
+	fmt.Printf("%.2f %v\n", $.complexDiv(a, b), b)
+
+	let signal = $.arrayToSlice<$.Complex>([$.complex(1, 0), $.complex(1, 0), $.complex(1, 0), $.complex(1, 0), $.complex(0, 0), $.complex(0, 0), $.complex(0, 0), $.complex(0, 0)])
+	let spectrum = fft(signal)
+	for (let i = 0; i < $.len(spectrum); i++) {
+		const bin = spectrum![i]
+		{
+			spectrum![i] = round(bin)
+		}
+	}
+	fmt.Println($.markType(spectrum, "main@github.com/aperturerobotics/goscript/compliance/tests/complex_numbers.Signal"))
+	fmt.Println(round(cmplx.Sqrt($.complex(-1, 0))), round(cmplx.Pow($.complex(0, 1), $.complex(2, 0))))
+
+	// Literals of named array and map types
+	let pair = $.arrayToSlice<$.Complex>([$.complex(1, 2), $.complex(3, 0)])
+	let roots = new $.HashMap([[$.complex(0, 1), "i"], [$.complex(0, -1), "-i"]])
+	fmt.Println($.complexMul(pair![0], pair![1]), $.len(roots), $.mapGet(roots, round(cmplx.Sqrt($.complex(-1, 0))), "")[0], $.mapGet(roots, $.complex(0, -1), "")[0])
+}
+
@@ -0,0 +1,2 @@
+export { Sample } from "./complex_numbers.gs.js"
+export type { Pair, Roots, Signal } from "./complex_numbers.gs.js"
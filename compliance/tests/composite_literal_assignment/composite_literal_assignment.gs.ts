@@ -52,11 +52,11 @@ export class MyStruct {
 
 	// Register this type with the runtime type system
 	static __typeInfo = $.registerStructType(
-	  'MyStruct',
+	  'main@github.com/aperturerobotics/goscript/compliance/tests/composite_literal_assignment.MyStruct',
 	  new MyStruct(),
 	  [],
 	  MyStruct,
-	  {"MyInt": { kind: $.TypeKind.Basic, name: "number" }, "MyString": { kind: $.TypeKind.Basic, name: "string" }, "myBool": { kind: $.TypeKind.Basic, name: "boolean" }}
+	  [{ name: "MyInt", type: { kind: $.TypeKind.Basic, name: "int" } }, { name: "MyString", type: { kind: $.TypeKind.Basic, name: "string" } }, { name: "myBool", type: { kind: $.TypeKind.Basic, name: "bool" } }]
 	);
 }
 
@@ -20,6 +20,6 @@ export async function main(): Promise<void> {
 	// println(Big) // Commented out until large integer handling is implemented
 	// println(Small) // Commented out as it depends on Big
 	console.log("Hello, Constants!")
-	console.log($.byte(4))
+	console.log(4)
 }
 
@@ -5,9 +5,16 @@ import * as $ from "@goscript/builtin/index.js";
 
 export type ByteSize = number;
 
+$.registerNamedType(
+  'main@github.com/aperturerobotics/goscript/compliance/tests/constants_iota.ByteSize',
+  0,
+  [],
+  { kind: $.TypeKind.Basic, name: "int" }
+);
+
 // ignore first value by assigning to blank identifier
 
-export let KB: ByteSize = (1 << (10 * 0))
+export let KB: ByteSize = 1099511627776
 
 export let MB: ByteSize = 0
 
@@ -17,6 +24,13 @@ export let TB: ByteSize = 0
 
 export type Direction = number;
 
+$.registerNamedType(
+  'main@github.com/aperturerobotics/goscript/compliance/tests/constants_iota.Direction',
+  0,
+  [],
+  { kind: $.TypeKind.Basic, name: "int" }
+);
+
 export let North: Direction = 0
 
 export let East: Direction = 0
@@ -59,16 +73,16 @@ export let C: number = 0
 
 export async function main(): Promise<void> {
 	console.log("ByteSize constants:")
-	console.log("KB:", $.int(1024))
-	console.log("MB:", $.int(1048576))
-	console.log("GB:", $.int(1073741824))
-	console.log("TB:", $.int(1099511627776))
+	console.log("KB:", 1024)
+	console.log("MB:", 1048576)
+	console.log("GB:", 1073741824)
+	console.log("TB:", 1099511627776)
 
 	console.log("Direction constants:")
-	console.log("North:", $.int(0))
-	console.log("East:", $.int(1))
-	console.log("South:", $.int(2))
-	console.log("West:", $.int(3))
+	console.log("North:", 0)
+	console.log("East:", 1)
+	console.log("South:", 2)
+	console.log("West:", 3)
 
 	console.log("Color constants:")
 	console.log("Red:", 0)
@@ -41,11 +41,11 @@ export class MyStruct {
 
 	// Register this type with the runtime type system
 	static __typeInfo = $.registerStructType(
-	  'MyStruct',
+	  'main@github.com/aperturerobotics/goscript/compliance/tests/copy_independence.MyStruct',
 	  new MyStruct(),
 	  [],
 	  MyStruct,
-	  {"MyInt": { kind: $.TypeKind.Basic, name: "number" }, "MyString": { kind: $.TypeKind.Basic, name: "string" }}
+	  [{ name: "MyInt", type: { kind: $.TypeKind.Basic, name: "int" } }, { name: "MyString", type: { kind: $.TypeKind.Basic, name: "string" } }]
 	);
 }
 
@@ -9,7 +9,7 @@ export type Reader = null | {
 }
 
 $.registerInterfaceType(
-  'Reader',
+  'main@github.com/aperturerobotics/goscript/compliance/tests/embedded_interface_assertion.Reader',
   null, // Zero value for interface is null
   [{ name: "Read", args: [{ name: "", type: { kind: $.TypeKind.Slice, elemType: { kind: $.TypeKind.Basic, name: "number" } } }], returns: [{ type: { kind: $.TypeKind.Basic, name: "number" } }, { type: { kind: $.TypeKind.Interface, name: 'GoError', methods: [{ name: 'Error', args: [], returns: [{ type: { kind: $.TypeKind.Basic, name: 'string' } }] }] } }] }]
 );
@@ -19,7 +19,7 @@ export type Closer = null | {
 }
 
 $.registerInterfaceType(
-  'Closer',
+  'main@github.com/aperturerobotics/goscript/compliance/tests/embedded_interface_assertion.Closer',
   null, // Zero value for interface is null
   [{ name: "Close", args: [], returns: [{ type: { kind: $.TypeKind.Interface, name: 'GoError', methods: [{ name: 'Error', args: [], returns: [{ type: { kind: $.TypeKind.Basic, name: 'string' } }] }] } }] }]
 );
@@ -27,7 +27,7 @@ $.registerInterfaceType(
 export type ReadCloser = null | Reader & Closer
 
 $.registerInterfaceType(
-  'ReadCloser',
+  'main@github.com/aperturerobotics/goscript/compliance/tests/embedded_interface_assertion.ReadCloser',
   null, // Zero value for interface is null
   []
 );
@@ -57,20 +57,20 @@ export class MyStruct {
 
 	// Register this type with the runtime type system
 	static __typeInfo = $.registerStructType(
-	  'MyStruct',
+	  'main@github.com/aperturerobotics/goscript/compliance/tests/embedded_interface_assertion.MyStruct',
 	  new MyStruct(),
 	  [{ name: "Read", args: [{ name: "p", type: { kind: $.TypeKind.Slice, elemType: { kind: $.TypeKind.Basic, name: "number" } } }], returns: [{ type: { kind: $.TypeKind.Basic, name: "number" } }, { type: { kind: $.TypeKind.Interface, name: 'GoError', methods: [{ name: 'Error', args: [], returns: [{ type: { kind: $.TypeKind.Basic, name: 'string' } }] }] } }] }, { name: "Close", args: [], returns: [{ type: { kind: $.TypeKind.Interface, name: 'GoError', methods: [{ name: 'Error', args: [], returns: [{ type: { kind: $.TypeKind.Basic, name: 'string' } }] }] } }] }],
 	  MyStruct,
-	  {}
+	  []
 	);
 }
 
 export async function main(): Promise<void> {
 	let rwc: ReadCloser = null
 	let s = new MyStruct({})
-	rwc = s.clone()
+	rwc = $.markStructValue(s.clone())
 
-	let { ok: ok } = $.typeAssert<ReadCloser>(rwc, 'ReadCloser')
+	let { ok: ok } = $.typeAssert<ReadCloser>(rwc, 'main@github.com/aperturerobotics/goscript/compliance/tests/embedded_interface_assertion.ReadCloser')
 	if (ok) {
 		console.log("Embedded interface assertion successful")
 	}
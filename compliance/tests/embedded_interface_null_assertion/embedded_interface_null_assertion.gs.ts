@@ -8,7 +8,7 @@ export type Reader = null | {
 }
 
 $.registerInterfaceType(
-  'Reader',
+  'main@github.com/aperturerobotics/goscript/compliance/tests/embedded_interface_null_assertion.Reader',
   null, // Zero value for interface is null
   [{ name: "Read", args: [{ name: "p", type: { kind: $.TypeKind.Slice, elemType: { kind: $.TypeKind.Basic, name: "number" } } }], returns: [{ type: { kind: $.TypeKind.Basic, name: "number" } }, { type: { kind: $.TypeKind.Interface, name: 'GoError', methods: [{ name: 'Error', args: [], returns: [{ type: { kind: $.TypeKind.Basic, name: 'string' } }] }] } }] }]
 );
@@ -55,11 +55,11 @@ export class MyReader {
 
 	// Register this type with the runtime type system
 	static __typeInfo = $.registerStructType(
-	  'MyReader',
+	  'main@github.com/aperturerobotics/goscript/compliance/tests/embedded_interface_null_assertion.MyReader',
 	  new MyReader(),
 	  [],
 	  MyReader,
-	  {"Reader": "Reader", "name": { kind: $.TypeKind.Basic, name: "string" }}
+	  [{ name: "Reader", type: "main@github.com/aperturerobotics/goscript/compliance/tests/embedded_interface_null_assertion.Reader", embedded: true }, { name: "name", type: { kind: $.TypeKind.Basic, name: "string" } }]
 	);
 }
 
@@ -111,11 +111,11 @@ export class StringReader {
 
 	// Register this type with the runtime type system
 	static __typeInfo = $.registerStructType(
-	  'StringReader',
+	  'main@github.com/aperturerobotics/goscript/compliance/tests/embedded_interface_null_assertion.StringReader',
 	  new StringReader(),
 	  [{ name: "Read", args: [{ name: "p", type: { kind: $.TypeKind.Slice, elemType: { kind: $.TypeKind.Basic, name: "number" } } }], returns: [{ type: { kind: $.TypeKind.Basic, name: "number" } }, { type: { kind: $.TypeKind.Interface, name: 'GoError', methods: [{ name: 'Error', args: [], returns: [{ type: { kind: $.TypeKind.Basic, name: 'string' } }] }] } }] }],
 	  StringReader,
-	  {"data": { kind: $.TypeKind.Basic, name: "string" }, "pos": { kind: $.TypeKind.Basic, name: "number" }}
+	  [{ name: "data", type: { kind: $.TypeKind.Basic, name: "string" } }, { name: "pos", type: { kind: $.TypeKind.Basic, name: "int" } }]
 	);
 }
 
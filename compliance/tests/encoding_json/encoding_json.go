@@ -0,0 +1,212 @@
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"strings"
+)
+
+type Status string
+
+type Base struct {
+	ID      int    `json:"id"`
+	Created string `json:"created,omitempty"`
+}
+
+type Address struct {
+	Street string `json:"street"`
+	City   string `json:"city,omitempty"`
+}
+
+type User struct {
+	Base
+	Name     string            `json:"name"`
+	Email    string            `json:"email,omitempty"`
+	Age      int               `json:"age,string"`
+	Score    float64           `json:"score"`
+	Active   bool              `json:"active"`
+	Status   Status            `json:"status"`
+	Tags     []string          `json:"tags"`
+	Labels   map[string]int    `json:"labels,omitempty"`
+	Home     *Address          `json:"home,omitempty"`
+	Work     Address           `json:"work"`
+	Data     []byte            `json:"data,omitempty"`
+	Extra    json.RawMessage   `json:"extra,omitempty"`
+	Ignored  string            `json:"-"`
+	Dash     string            `json:"-,"`
+	password string
+	Meta     map[string]any    `json:"meta,omitempty"`
+}
+
+// Temp implements json.Marshaler and json.Unmarshaler.
+type Temp struct {
+	C float64
+}
+
+type tempJSON struct {
+	Celsius float64 `json:"celsius"`
+}
+
+func (t Temp) MarshalJSON() ([]byte, error) {
+	return []byte(`{"celsius": ` + json.Number("1").String() + `}`), nil
+}
+
+func (t *Temp) UnmarshalJSON(data []byte) error {
+	var v tempJSON
+	if err := json.Unmarshal(data, &v); err != nil {
+		return err
+	}
+	t.C = v.Celsius * 10
+	return nil
+}
+
+// Level implements json.Marshaler on a named integer type.
+type Level int
+
+func (l Level) MarshalJSON() ([]byte, error) {
+	return []byte(`"level-` + strings.Repeat("+", int(l)) + `"`), nil
+}
+
+// Color implements encoding.TextMarshaler on a named integer type.
+type Color int
+
+func (c Color) MarshalText() ([]byte, error) {
+	return []byte([]string{"red", "green", "blue"}[c]), nil
+}
+
+type Settings struct {
+	Level  Level         `json:"level"`
+	Levels []Level       `json:"levels"`
+	Color  Color         `json:"color"`
+	Counts map[Color]int `json:"counts"`
+}
+
+type Big struct {
+	N json.Number `json:"n"`
+	S json.Number `json:"s"`
+}
+
+type Reading struct {
+	Where string `json:"where"`
+	Temp  Temp   `json:"temp"`
+}
+
+func main() {
+	u := User{
+		Base:     Base{ID: 7},
+		Name:     "Ada <Lovelace> & co",
+		Age:      36,
+		Score:    99.5,
+		Active:   true,
+		Status:   "ok",
+		Tags:     []string{"a", "b"},
+		Labels:   map[string]int{"z": 1, "a": 2},
+		Work:     Address{Street: "Main St"},
+		Data:     []byte("hi"),
+		Extra:    json.RawMessage(`{"x": [1, 2]}`),
+		Ignored:  "no",
+		Dash:     "dash",
+		password: "secret",
+	}
+	b, err := json.Marshal(u)
+	println(string(b), err == nil)
+
+	var u2 User
+	err = json.Unmarshal(b, &u2)
+	println(err == nil, u2.ID, u2.Name, u2.Age, u2.Score, u2.Active, string(u2.Status))
+	println(len(u2.Tags), u2.Tags[1], u2.Labels["a"], u2.Home == nil, u2.Work.Street, string(u2.Data), string(u2.Extra), u2.Dash)
+
+	b, _ = json.MarshalIndent(Address{Street: "x", City: "y"}, "", "  ")
+	println(string(b))
+
+	// Marshalers
+	b, err = json.Marshal(Reading{Where: "lab", Temp: Temp{C: 1}})
+	println(string(b), err == nil)
+	var r Reading
+	err = json.Unmarshal([]byte(`{"where":"home","temp":{"celsius":2.5}}`), &r)
+	println(err == nil, r.Where, r.Temp.C)
+	b, err = json.Marshal(Settings{
+		Level:  2,
+		Levels: []Level{0, 1},
+		Color:  1,
+		Counts: map[Color]int{0: 3, 2: 1},
+	})
+	println(string(b), err == nil)
+
+	// Generic values
+	var anyv any
+	err = json.Unmarshal([]byte(`{"a":[1,"two",true,null,{"b":1.5}]}`), &anyv)
+	m := anyv.(map[string]any)
+	arr := m["a"].([]any)
+	println(err == nil, len(arr), arr[0].(float64), arr[1].(string), arr[2].(bool), arr[3] == nil)
+	b, _ = json.Marshal(anyv)
+	println(string(b))
+
+	// Maps and slices
+	var mm map[string]int
+	err = json.Unmarshal([]byte(`{"one":1,"two":2}`), &mm)
+	println(err == nil, len(mm), mm["two"])
+	var nums []float64
+	err = json.Unmarshal([]byte(` [1.5, 2, 3e2] `), &nums)
+	println(err == nil, len(nums), nums[2])
+
+	// Errors
+	err = json.Unmarshal([]byte(`{"id": "x"}`), &u2)
+	println(err.Error())
+	err = json.Unmarshal([]byte(`{"id": 1`), &u2)
+	println(err.Error())
+	err = json.Unmarshal([]byte(`[1, x]`), &nums)
+	println(err.Error())
+	var target any
+	err = json.Unmarshal([]byte(`{}`), target)
+	println(err.Error())
+	println(json.Valid([]byte(`{"a":1}`)), json.Valid([]byte(`{a:1}`)))
+
+	// Encoder and Decoder
+	var buf bytes.Buffer
+	enc := json.NewEncoder(&buf)
+	enc.SetIndent("", " ")
+	_ = enc.Encode(map[string]bool{"ok": true})
+	_ = enc.Encode([]int{1, 2})
+	println(strings.TrimSpace(buf.String()))
+
+	dec := json.NewDecoder(strings.NewReader(`{"street":"a"} {"street":"b"}` + "\n" + `{"street":"c","zip":1}`))
+	for dec.More() {
+		var a Address
+		if err := dec.Decode(&a); err != nil {
+			println("decode error:", err.Error())
+			break
+		}
+		println("street:", a.Street)
+	}
+	dec = json.NewDecoder(strings.NewReader(`{"street":"a","zip":1}`))
+	dec.DisallowUnknownFields()
+	var a Address
+	println(dec.Decode(&a).Error())
+
+	dec = json.NewDecoder(strings.NewReader(`{"n": 12345678901234567890}`))
+	dec.UseNumber()
+	var nm map[string]any
+	_ = dec.Decode(&nm)
+	_, isFloat := nm["n"].(float64)
+	println(isFloat)
+
+	var big Big
+	err = json.Unmarshal([]byte(`{"n": 12345678901234567890, "s": "42"}`), &big)
+	println(err == nil, big.N.String(), big.S.String())
+	_, err = big.N.Int64()
+	println(err != nil)
+	i, err := big.S.Int64()
+	println(i, err == nil)
+
+	var out bytes.Buffer
+	_ = json.Indent(&out, []byte(`{"a":[1,2],"b":{}}`), ">", "\t")
+	println(out.String())
+	out.Reset()
+	_ = json.Compact(&out, []byte("{ \"a\" : [ 1 , 2 ] }"))
+	println(out.String())
+	b, _ = json.Marshal(" \x01\"\\\n")
+	println(string(b))
+	b, _ = json.Marshal([]any{1e21, 1e-7, 0.000001, 100, -0.5, nil})
+	println(string(b))
+}
@@ -0,0 +1,703 @@
+// Generated file based on encoding_json.go
+// Updated when compliance tests are re-run, DO NOT EDIT!
+
+import * as $ from "@goscript/builtin/index.js";
+
+import * as bytes from "@goscript/bytes/index.js"
+
+import * as json from "@goscript/encoding/json/index.js"
+
+import * as strings from "@goscript/strings/index.js"
+
+export type Status = string;
+
+$.registerNamedType(
+  'main@github.com/aperturerobotics/goscript/compliance/tests/encoding_json.Status',
+  "",
+  [],
+  { kind: $.TypeKind.Basic, name: "string" }
+);
+
+export class Base {
+	public get ID(): number {
+		return this._fields.ID.value
+	}
+	public set ID(value: number) {
+		this._fields.ID.value = value
+	}
+
+	public get Created(): string {
+		return this._fields.Created.value
+	}
+	public set Created(value: string) {
+		this._fields.Created.value = value
+	}
+
+	public _fields: {
+		ID: $.VarRef<number>;
+		Created: $.VarRef<string>;
+	}
+
+	constructor(init?: Partial<{Created?: string, ID?: number}>) {
+		this._fields = {
+			ID: $.varRef(init?.ID ?? 0),
+			Created: $.varRef(init?.Created ?? "")
+		}
+	}
+
+	public clone(): Base {
+		const cloned = new Base()
+		cloned._fields = {
+			ID: $.varRef(this._fields.ID.value),
+			Created: $.varRef(this._fields.Created.value)
+		}
+		return cloned
+	}
+
+	// Register this type with the runtime type system
+	static __typeInfo = $.registerStructType(
+	  'main@github.com/aperturerobotics/goscript/compliance/tests/encoding_json.Base',
+	  new Base(),
+	  [],
+	  Base,
+	  [{ name: "ID", type: { kind: $.TypeKind.Basic, name: "int" }, tag: "json:\"id\"" }, { name: "Created", type: { kind: $.TypeKind.Basic, name: "string" }, tag: "json:\"created,omitempty\"" }]
+	);
+}
+
+export class Address {
+	public get Street(): string {
+		return this._fields.Street.value
+	}
+	public set Street(value: string) {
+		this._fields.Street.value = value
+	}
+
+	public get City(): string {
+		return this._fields.City.value
+	}
+	public set City(value: string) {
+		this._fields.City.value = value
+	}
+
+	public _fields: {
+		Street: $.VarRef<string>;
+		City: $.VarRef<string>;
+	}
+
+	constructor(init?: Partial<{City?: string, Street?: string}>) {
+		this._fields = {
+			Street: $.varRef(init?.Street ?? ""),
+			City: $.varRef(init?.City ?? "")
+		}
+	}
+
+	public clone(): Address {
+		const cloned = new Address()
+		cloned._fields = {
+			Street: $.varRef(this._fields.Street.value),
+			City: $.varRef(this._fields.City.value)
+		}
+		return cloned
+	}
+
+	// Register this type with the runtime type system
+	static __typeInfo = $.registerStructType(
+	  'main@github.com/aperturerobotics/goscript/compliance/tests/encoding_json.Address',
+	  new Address(),
+	  [],
+	  Address,
+	  [{ name: "Street", type: { kind: $.TypeKind.Basic, name: "string" }, tag: "json:\"street\"" }, { name: "City", type: { kind: $.TypeKind.Basic, name: "string" }, tag: "json:\"city,omitempty\"" }]
+	);
+}
+
+export class User {
+	public get Name(): string {
+		return this._fields.Name.value
+	}
+	public set Name(value: string) {
+		this._fields.Name.value = value
+	}
+
+	public get Email(): string {
+		return this._fields.Email.value
+	}
+	public set Email(value: string) {
+		this._fields.Email.value = value
+	}
+
+	public get Age(): number {
+		return this._fields.Age.value
+	}
+	public set Age(value: number) {
+		this._fields.Age.value = value
+	}
+
+	public get Score(): number {
+		return this._fields.Score.value
+	}
+	public set Score(value: number) {
+		this._fields.Score.value = value
+	}
+
+	public get Active(): boolean {
+		return this._fields.Active.value
+	}
+	public set Active(value: boolean) {
+		this._fields.Active.value = value
+	}
+
+	public get Status(): Status {
+		return this._fields.Status.value
+	}
+	public set Status(value: Status) {
+		this._fields.Status.value = value
+	}
+
+	public get Tags(): $.Slice<string> {
+		return this._fields.Tags.value
+	}
+	public set Tags(value: $.Slice<string>) {
+		this._fields.Tags.value = value
+	}
+
+	public get Labels(): Map<string, number> | null {
+		return this._fields.Labels.value
+	}
+	public set Labels(value: Map<string, number> | null) {
+		this._fields.Labels.value = value
+	}
+
+	public get Home(): Address | null {
+		return this._fields.Home.value
+	}
+	public set Home(value: Address | null) {
+		this._fields.Home.value = value
+	}
+
+	public get Work(): Address {
+		return this._fields.Work.value
+	}
+	public set Work(value: Address) {
+		this._fields.Work.value = value
+	}
+
+	public get Data(): $.Bytes {
+		return this._fields.Data.value
+	}
+	public set Data(value: $.Bytes) {
+		this._fields.Data.value = value
+	}
+
+	public get Extra(): json.RawMessage {
+		return this._fields.Extra.value
+	}
+	public set Extra(value: json.RawMessage) {
+		this._fields.Extra.value = value
+	}
+
+	public get Ignored(): string {
+		return this._fields.Ignored.value
+	}
+	public set Ignored(value: string) {
+		this._fields.Ignored.value = value
+	}
+
+	public get Dash(): string {
+		return this._fields.Dash.value
+	}
+	public set Dash(value: string) {
+		this._fields.Dash.value = value
+	}
+
+	public get password(): string {
+		return this._fields.password.value
+	}
+	public set password(value: string) {
+		this._fields.password.value = value
+	}
+
+	public get Meta(): Map<string, null | any> | null {
+		return this._fields.Meta.value
+	}
+	public set Meta(value: Map<string, null | any> | null) {
+		this._fields.Meta.value = value
+	}
+
+	public get Base(): Base {
+		return this._fields.Base.value
+	}
+	public set Base(value: Base) {
+		this._fields.Base.value = value
+	}
+
+	public _fields: {
+		Base: $.VarRef<Base>;
+		Name: $.VarRef<string>;
+		Email: $.VarRef<string>;
+		Age: $.VarRef<number>;
+		Score: $.VarRef<number>;
+		Active: $.VarRef<boolean>;
+		Status: $.VarRef<Status>;
+		Tags: $.VarRef<$.Slice<string>>;
+		Labels: $.VarRef<Map<string, number> | null>;
+		Home: $.VarRef<Address | null>;
+		Work: $.VarRef<Address>;
+		Data: $.VarRef<$.Bytes>;
+		Extra: $.VarRef<json.RawMessage>;
+		Ignored: $.VarRef<string>;
+		Dash: $.VarRef<string>;
+		password: $.VarRef<string>;
+		Meta: $.VarRef<Map<string, null | any> | null>;
+	}
+
+	constructor(init?: Partial<{Active?: boolean, Age?: number, Base?: Partial<ConstructorParameters<typeof Base>[0]>, Dash?: string, Data?: $.Bytes, Email?: string, Extra?: json.RawMessage, Home?: Address | null, Ignored?: string, Labels?: Map<string, number> | null, Meta?: Map<string, null | any> | null, Name?: string, Score?: number, Status?: Status, Tags?: $.Slice<string>, Work?: Address, password?: string}>) {
+		this._fields = {
+			Base: $.varRef(new Base(init?.Base)),
+			Name: $.varRef(init?.Name ?? ""),
+			Email: $.varRef(init?.Email ?? ""),
+			Age: $.varRef(init?.Age ?? 0),
+			Score: $.varRef(init?.Score ?? 0),
+			Active: $.varRef(init?.Active ?? false),
+			Status: $.varRef(init?.Status ?? "" as Status),
+			Tags: $.varRef(init?.Tags ?? null),
+			Labels: $.varRef(init?.Labels ?? null),
+			Home: $.varRef(init?.Home ?? null),
+			Work: $.varRef(init?.Work?.clone() ?? new Address()),
+			Data: $.varRef(init?.Data ?? new Uint8Array(0)),
+			Extra: $.varRef(init?.Extra ?? new Uint8Array(0) as json.RawMessage),
+			Ignored: $.varRef(init?.Ignored ?? ""),
+			Dash: $.varRef(init?.Dash ?? ""),
+			password: $.varRef(init?.password ?? ""),
+			Meta: $.varRef(init?.Meta ?? null)
+		}
+	}
+
+	public clone(): User {
+		const cloned = new User()
+		cloned._fields = {
+			Base: $.varRef(this._fields.Base.value.clone()),
+			Name: $.varRef(this._fields.Name.value),
+			Email: $.varRef(this._fields.Email.value),
+			Age: $.varRef(this._fields.Age.value),
+			Score: $.varRef(this._fields.Score.value),
+			Active: $.varRef(this._fields.Active.value),
+			Status: $.varRef(this._fields.Status.value),
+			Tags: $.varRef(this._fields.Tags.value),
+			Labels: $.varRef(this._fields.Labels.value),
+			Home: $.varRef(this._fields.Home.value),
+			Work: $.varRef(this._fields.Work.value?.clone() ?? null),
+			Data: $.varRef(this._fields.Data.value),
+			Extra: $.varRef(this._fields.Extra.value),
+			Ignored: $.varRef(this._fields.Ignored.value),
+			Dash: $.varRef(this._fields.Dash.value),
+			password: $.varRef(this._fields.password.value),
+			Meta: $.varRef(this._fields.Meta.value)
+		}
+		return cloned
+	}
+
+	public get ID(): number {
+		return this.Base.ID
+	}
+	public set ID(value: number) {
+		this.Base.ID = value
+	}
+
+	public get Created(): string {
+		return this.Base.Created
+	}
+	public set Created(value: string) {
+		this.Base.Created = value
+	}
+
+	// Register this type with the runtime type system
+	static __typeInfo = $.registerStructType(
+	  'main@github.com/aperturerobotics/goscript/compliance/tests/encoding_json.User',
+	  new User(),
+	  [],
+	  User,
+	  [{ name: "Base", type: "main@github.com/aperturerobotics/goscript/compliance/tests/encoding_json.Base", embedded: true }, { name: "Name", type: { kind: $.TypeKind.Basic, name: "string" }, tag: "json:\"name\"" }, { name: "Email", type: { kind: $.TypeKind.Basic, name: "string" }, tag: "json:\"email,omitempty\"" }, { name: "Age", type: { kind: $.TypeKind.Basic, name: "int" }, tag: "json:\"age,string\"" }, { name: "Score", type: { kind: $.TypeKind.Basic, name: "float64" }, tag: "json:\"score\"" }, { name: "Active", type: { kind: $.TypeKind.Basic, name: "bool" }, tag: "json:\"active\"" }, { name: "Status", type: "main@github.com/aperturerobotics/goscript/compliance/tests/encoding_json.Status", tag: "json:\"status\"" }, { name: "Tags", type: { kind: $.TypeKind.Slice, elemType: { kind: $.TypeKind.Basic, name: "string" } }, tag: "json:\"tags\"" }, { name: "Labels", type: { kind: $.TypeKind.Map, keyType: { kind: $.TypeKind.Basic, name: "string" }, elemType: { kind: $.TypeKind.Basic, name: "int" } }, tag: "json:\"labels,omitempty\"" }, { name: "Home", type: { kind: $.TypeKind.Pointer, elemType: "main@github.com/aperturerobotics/goscript/compliance/tests/encoding_json.Address" }, tag: "json:\"home,omitempty\"" }, { name: "Work", type: "main@github.com/aperturerobotics/goscript/compliance/tests/encoding_json.Address", tag: "json:\"work\"" }, { name: "Data", type: { kind: $.TypeKind.Slice, elemType: { kind: $.TypeKind.Basic, name: "byte" } }, tag: "json:\"data,omitempty\"" }, { name: "Extra", type: "encoding/json/jsontext.Value", tag: "json:\"extra,omitempty\"" }, { name: "Ignored", type: { kind: $.TypeKind.Basic, name: "string" }, tag: "json:\"-\"" }, { name: "Dash", type: { kind: $.TypeKind.Basic, name: "string" }, tag: "json:\"-,\"" }, { name: "password", type: { kind: $.TypeKind.Basic, name: "string" } }, { name: "Meta", type: { kind: $.TypeKind.Map, keyType: { kind: $.TypeKind.Basic, name: "string" }, elemType: { kind: $.TypeKind.Interface, methods: [] } }, tag: "json:\"meta,omitempty\"" }]
+	);
+}
+
+export class Temp {
+	public get C(): number {
+		return this._fields.C.value
+	}
+	public set C(value: number) {
+		this._fields.C.value = value
+	}
+
+	public _fields: {
+		C: $.VarRef<number>;
+	}
+
+	constructor(init?: Partial<{C?: number}>) {
+		this._fields = {
+			C: $.varRef(init?.C ?? 0)
+		}
+	}
+
+	public clone(): Temp {
+		const cloned = new Temp()
+		cloned._fields = {
+			C: $.varRef(this._fields.C.value)
+		}
+		return cloned
+	}
+
+	public MarshalJSON(): [$.Bytes, $.GoError] {
+		return [$.stringToBytes(`{"celsius": ` + json.Number_String(("1" as json.Number)) + `}`), null]
+	}
+
+	public UnmarshalJSON(data: $.Bytes): $.GoError {
+		const t = this
+		let v: tempJSON = new tempJSON()
+		{
+			let err = json.Unmarshal(data, v)
+			if (err != null) {
+				return err
+			}
+		}
+		t.C = v.Celsius * 10
+		return null
+	}
+
+	// Register this type with the runtime type system
+	static __typeInfo = $.registerStructType(
+	  'main@github.com/aperturerobotics/goscript/compliance/tests/encoding_json.Temp',
+	  new Temp(),
+	  [{ name: "MarshalJSON", args: [], returns: [{ type: { kind: $.TypeKind.Slice, elemType: { kind: $.TypeKind.Basic, name: "number" } } }, { type: { kind: $.TypeKind.Interface, name: 'GoError', methods: [{ name: 'Error', args: [], returns: [{ type: { kind: $.TypeKind.Basic, name: 'string' } }] }] } }] }, { name: "UnmarshalJSON", args: [{ name: "data", type: { kind: $.TypeKind.Slice, elemType: { kind: $.TypeKind.Basic, name: "number" } } }], returns: [{ type: { kind: $.TypeKind.Interface, name: 'GoError', methods: [{ name: 'Error', args: [], returns: [{ type: { kind: $.TypeKind.Basic, name: 'string' } }] }] } }] }],
+	  Temp,
+	  [{ name: "C", type: { kind: $.TypeKind.Basic, name: "float64" } }]
+	);
+}
+
+export class tempJSON {
+	public get Celsius(): number {
+		return this._fields.Celsius.value
+	}
+	public set Celsius(value: number) {
+		this._fields.Celsius.value = value
+	}
+
+	public _fields: {
+		Celsius: $.VarRef<number>;
+	}
+
+	constructor(init?: Partial<{Celsius?: number}>) {
+		this._fields = {
+			Celsius: $.varRef(init?.Celsius ?? 0)
+		}
+	}
+
+	public clone(): tempJSON {
+		const cloned = new tempJSON()
+		cloned._fields = {
+			Celsius: $.varRef(this._fields.Celsius.value)
+		}
+		return cloned
+	}
+
+	// Register this type with the runtime type system
+	static __typeInfo = $.registerStructType(
+	  'main@github.com/aperturerobotics/goscript/compliance/tests/encoding_json.tempJSON',
+	  new tempJSON(),
+	  [],
+	  tempJSON,
+	  [{ name: "Celsius", type: { kind: $.TypeKind.Basic, name: "float64" }, tag: "json:\"celsius\"" }]
+	);
+}
+
+export type Level = number;
+
+export function Level_MarshalJSON(l: Level): [$.Bytes, $.GoError] {
+	return [$.stringToBytes(`"level-` + strings.Repeat("+", l) + `"`), null]
+}
+
+
+$.registerNamedType(
+  'main@github.com/aperturerobotics/goscript/compliance/tests/encoding_json.Level',
+  0,
+  [{ name: "MarshalJSON", args: [], returns: [{ type: { kind: $.TypeKind.Slice, elemType: { kind: $.TypeKind.Basic, name: "number" } } }, { type: { kind: $.TypeKind.Interface, name: 'GoError', methods: [{ name: 'Error', args: [], returns: [{ type: { kind: $.TypeKind.Basic, name: 'string' } }] }] } }] }],
+  { kind: $.TypeKind.Basic, name: "int" },
+  { MarshalJSON: Level_MarshalJSON }
+);
+
+export type Color = number;
+
+export function Color_MarshalText(c: Color): [$.Bytes, $.GoError] {
+	return [$.stringToBytes($.arrayToSlice<string>(["red", "green", "blue"])![c]), null]
+}
+
+
+$.registerNamedType(
+  'main@github.com/aperturerobotics/goscript/compliance/tests/encoding_json.Color',
+  0,
+  [{ name: "MarshalText", args: [], returns: [{ type: { kind: $.TypeKind.Slice, elemType: { kind: $.TypeKind.Basic, name: "number" } } }, { type: { kind: $.TypeKind.Interface, name: 'GoError', methods: [{ name: 'Error', args: [], returns: [{ type: { kind: $.TypeKind.Basic, name: 'string' } }] }] } }] }],
+  { kind: $.TypeKind.Basic, name: "int" },
+  { MarshalText: Color_MarshalText }
+);
+
+export class Settings {
+	public get Level(): Level {
+		return this._fields.Level.value
+	}
+	public set Level(value: Level) {
+		this._fields.Level.value = value
+	}
+
+	public get Levels(): $.Slice<Level> {
+		return this._fields.Levels.value
+	}
+	public set Levels(value: $.Slice<Level>) {
+		this._fields.Levels.value = value
+	}
+
+	public get Color(): Color {
+		return this._fields.Color.value
+	}
+	public set Color(value: Color) {
+		this._fields.Color.value = value
+	}
+
+	public get Counts(): Map<Color, number> | null {
+		return this._fields.Counts.value
+	}
+	public set Counts(value: Map<Color, number> | null) {
+		this._fields.Counts.value = value
+	}
+
+	public _fields: {
+		Level: $.VarRef<Level>;
+		Levels: $.VarRef<$.Slice<Level>>;
+		Color: $.VarRef<Color>;
+		Counts: $.VarRef<Map<Color, number> | null>;
+	}
+
+	constructor(init?: Partial<{Color?: Color, Counts?: Map<Color, number> | null, Level?: Level, Levels?: $.Slice<Level>}>) {
+		this._fields = {
+			Level: $.varRef(init?.Level ?? 0 as Level),
+			Levels: $.varRef(init?.Levels ?? null),
+			Color: $.varRef(init?.Color ?? 0 as Color),
+			Counts: $.varRef(init?.Counts ?? null)
+		}
+	}
+
+	public clone(): Settings {
+		const cloned = new Settings()
+		cloned._fields = {
+			Level: $.varRef(this._fields.Level.value),
+			Levels: $.varRef(this._fields.Levels.value),
+			Color: $.varRef(this._fields.Color.value),
+			Counts: $.varRef(this._fields.Counts.value)
+		}
+		return cloned
+	}
+
+	// Register this type with the runtime type system
+	static __typeInfo = $.registerStructType(
+	  'main@github.com/aperturerobotics/goscript/compliance/tests/encoding_json.Settings',
+	  new Settings(),
+	  [],
+	  Settings,
+	  [{ name: "Level", type: "main@github.com/aperturerobotics/goscript/compliance/tests/encoding_json.Level", tag: "json:\"level\"" }, { name: "Levels", type: { kind: $.TypeKind.Slice, elemType: "main@github.com/aperturerobotics/goscript/compliance/tests/encoding_json.Level" }, tag: "json:\"levels\"" }, { name: "Color", type: "main@github.com/aperturerobotics/goscript/compliance/tests/encoding_json.Color", tag: "json:\"color\"" }, { name: "Counts", type: { kind: $.TypeKind.Map, keyType: "main@github.com/aperturerobotics/goscript/compliance/tests/encoding_json.Color", elemType: { kind: $.TypeKind.Basic, name: "int" } }, tag: "json:\"counts\"" }]
+	);
+}
+
+export class Big {
+	public get N(): json.Number {
+		return this._fields.N.value
+	}
+	public set N(value: json.Number) {
+		this._fields.N.value = value
+	}
+
+	public get S(): json.Number {
+		return this._fields.S.value
+	}
+	public set S(value: json.Number) {
+		this._fields.S.value = value
+	}
+
+	public _fields: {
+		N: $.VarRef<json.Number>;
+		S: $.VarRef<json.Number>;
+	}
+
+	constructor(init?: Partial<{N?: json.Number, S?: json.Number}>) {
+		this._fields = {
+			N: $.varRef(init?.N ?? "" as json.Number),
+			S: $.varRef(init?.S ?? "" as json.Number)
+		}
+	}
+
+	public clone(): Big {
+		const cloned = new Big()
+		cloned._fields = {
+			N: $.varRef(this._fields.N.value),
+			S: $.varRef(this._fields.S.value)
+		}
+		return cloned
+	}
+
+	// Register this type with the runtime type system
+	static __typeInfo = $.registerStructType(
+	  'main@github.com/aperturerobotics/goscript/compliance/tests/encoding_json.Big',
+	  new Big(),
+	  [],
+	  Big,
+	  [{ name: "N", type: "encoding/json.Number", tag: "json:\"n\"" }, { name: "S", type: "encoding/json.Number", tag: "json:\"s\"" }]
+	);
+}
+
+export class Reading {
+	public get Where(): string {
+		return this._fields.Where.value
+	}
+	public set Where(value: string) {
+		this._fields.Where.value = value
+	}
+
+	public get Temp(): Temp {
+		return this._fields.Temp.value
+	}
+	public set Temp(value: Temp) {
+		this._fields.Temp.value = value
+	}
+
+	public _fields: {
+		Where: $.VarRef<string>;
+		Temp: $.VarRef<Temp>;
+	}
+
+	constructor(init?: Partial<{Temp?: Temp, Where?: string}>) {
+		this._fields = {
+			Where: $.varRef(init?.Where ?? ""),
+			Temp: $.varRef(init?.Temp?.clone() ?? new Temp())
+		}
+	}
+
+	public clone(): Reading {
+		const cloned = new Reading()
+		cloned._fields = {
+			Where: $.varRef(this._fields.Where.value),
+			Temp: $.varRef(this._fields.Temp.value?.clone() ?? null)
+		}
+		return cloned
+	}
+
+	// Register this type with the runtime type system
+	static __typeInfo = $.registerStructType(
+	  'main@github.com/aperturerobotics/goscript/compliance/tests/encoding_json.Reading',
+	  new Reading(),
+	  [],
+	  Reading,
+	  [{ name: "Where", type: { kind: $.TypeKind.Basic, name: "string" }, tag: "json:\"where\"" }, { name: "Temp", type: "main@github.com/aperturerobotics/goscript/compliance/tests/encoding_json.Temp", tag: "json:\"temp\"" }]
+	);
+}
+
+export async function main(): Promise<void> {
+	let u = new User({Active: true, Age: 36, Dash: "dash", Data: $.stringToBytes("hi"), Extra: ($.stringToBytes(`{"x": [1, 2]}`) as json.RawMessage), Ignored: "no", Labels: new Map([["z", 1], ["a", 2]]), Name: "Ada <Lovelace> & co", Score: 99.5, Status: "ok", Tags: $.arrayToSlice<string>(["a", "b"]), Work: new Address({Street: "Main St"}), password: "secret", Base: {ID: 7}})
+	let [b, err] = json.Marshal($.markStructValue(u.clone()))
+	console.log($.bytesToString(b), err == null)
+
+	let u2: User = new User()
+	err = json.Unmarshal(b, u2)
+	console.log(err == null, u2.ID, u2.Name, u2.Age, u2.Score, u2.Active, u2.Status)
+	console.log($.len(u2.Tags), u2.Tags![1], $.mapGet(u2.Labels, "a", 0)[0], u2.Home == null, u2.Work.Street, $.bytesToString(u2.Data), $.bytesToString(u2.Extra), u2.Dash)
+
+	;[b] = await json.MarshalIndent($.markStructValue(new Address({City: "y", Street: "x"})), "", "  ")
+	console.log($.bytesToString(b))
+
+	// Marshalers
+	;[b, err] = json.Marshal($.markStructValue(new Reading({Temp: new Temp({C: 1}), Where: "lab"})))
+	console.log($.bytesToString(b), err == null)
+	let r: Reading = new Reading()
+	err = json.Unmarshal($.stringToBytes(`{"where":"home","temp":{"celsius":2.5}}`), r)
+	console.log(err == null, r.Where, r.Temp.C)
+	;[b, err] = json.Marshal($.markStructValue(new Settings({Color: 1, Counts: new Map([[0, 3], [2, 1]]), Level: 2, Levels: $.arrayToSlice<Level>([0, 1])})))
+	console.log($.bytesToString(b), err == null)
+
+	// Generic values
+	let anyv: $.VarRef<null | any> = $.varRef(null)
+	err = json.Unmarshal($.stringToBytes(`{"a":[1,"two",true,null,{"b":1.5}]}`), anyv)
+	let m = $.mustTypeAssert<Map<string, null | any> | null>(anyv!.value, {kind: $.TypeKind.Map, keyType: {kind: $.TypeKind.Basic, name: 'string'}, elemType: 'any'})
+	let arr = $.mustTypeAssert<$.Slice<null | any>>($.mapGet(m, "a", null)[0], {kind: $.TypeKind.Slice, elemType: 'any'})
+	console.log(err == null, $.len(arr), $.mustTypeAssert<number>(arr![0], {kind: $.TypeKind.Basic, name: 'number'}), $.mustTypeAssert<string>(arr![1], {kind: $.TypeKind.Basic, name: 'string'}), $.mustTypeAssert<boolean>(arr![2], {kind: $.TypeKind.Basic, name: 'boolean'}), arr![3] == null)
+	;[b] = json.Marshal(anyv!.value)
+	console.log($.bytesToString(b))
+
+	// Maps and slices
+	let mm: $.VarRef<Map<string, number> | null> = $.varRef(null)
+	err = json.Unmarshal($.stringToBytes(`{"one":1,"two":2}`), mm)
+	console.log(err == null, $.len(mm!.value), $.mapGet(mm!.value, "two", 0)[0])
+	let nums: $.VarRef<$.Slice<number>> = $.varRef(null)
+	err = json.Unmarshal($.stringToBytes(` [1.5, 2, 3e2] `), nums)
+	console.log(err == null, $.len(nums!.value), nums!.value![2])
+
+	// Errors
+	err = json.Unmarshal($.stringToBytes(`{"id": "x"}`), u2)
+	console.log(err!.Error())
+	err = json.Unmarshal($.stringToBytes(`{"id": 1`), u2)
+	console.log(err!.Error())
+	err = json.Unmarshal($.stringToBytes(`[1, x]`), nums)
+	console.log(err!.Error())
+	let target: null | any = null
+	err = json.Unmarshal($.stringToBytes(`{}`), target)
+	console.log(err!.Error())
+	console.log(await json.Valid($.stringToBytes(`{"a":1}`)), await json.Valid($.stringToBytes(`{a:1}`)))
+
+	// Encoder and Decoder
+	let buf: bytes.Buffer = new bytes.Buffer()
+	let enc = json.NewEncoder(buf)
+	enc!.SetIndent("", " ")
+	/* _ = */ await enc!.Encode(new Map([["ok", true]]))
+	/* _ = */ await enc!.Encode($.arrayToSlice<number>([1, 2]))
+	console.log(strings.TrimSpace(buf.String()))
+
+	let dec = json.NewDecoder(strings.NewReader(`{"street":"a"} {"street":"b"}` + "\n" + `{"street":"c","zip":1}`))
+	for (; await dec!.More(); ) {
+		let a: Address = new Address()
+		{
+			let err = await dec!.Decode(a)
+			if (err != null) {
+				console.log("decode error:", err!.Error())
+				break
+			}
+		}
+		console.log("street:", a.Street)
+	}
+	dec = json.NewDecoder(strings.NewReader(`{"street":"a","zip":1}`))
+	dec!.DisallowUnknownFields()
+	let a: Address = new Address()
+	console.log(await dec!.Decode(a)!.Error())
+
+	dec = json.NewDecoder(strings.NewReader(`{"n": 12345678901234567890}`))
+	dec!.UseNumber()
+	let nm: $.VarRef<Map<string, null | any> | null> = $.varRef(null)
+	/* _ = */ await dec!.Decode(nm)
+	let { ok: isFloat } = $.typeAssert<number>($.mapGet(nm!.value, "n", null)[0], {kind: $.TypeKind.Basic, name: 'number'})
+	console.log(isFloat)
+
+	let big: Big = new Big()
+	err = json.Unmarshal($.stringToBytes(`{"n": 12345678901234567890, "s": "42"}`), big)
+	console.log(err == null, json.Number_String(big.N), json.Number_String(big.S))
+	;[, err] = json.Number_Int64(big.N)
+	console.log(err != null)
+	let i: number
+	[i, err] = json.Number_Int64(big.S)
+	console.log(i, err == null)
+
+	let out: bytes.Buffer = new bytes.Buffer()
+	/* _ = */ await json.Indent(out, $.stringToBytes(`{"a":[1,2],"b":{}}`), ">", "\t")
+	console.log(out.String())
+	out.Reset()
+	/* _ = */ await json.Compact(out, $.stringToBytes("{ \"a\" : [ 1 , 2 ] }"))
+	console.log(out.String())
+	;[b] = json.Marshal("\u2028\x01\"\\\n")
+	console.log($.bytesToString(b))
+	;[b] = json.Marshal($.markType($.arrayToSlice<null | any>([1e21, 1e-7, 0.000001, 100, -0.5, null]), { kind: $.TypeKind.Slice, elemType: { kind: $.TypeKind.Interface, methods: [] } }))
+	console.log($.bytesToString(b))
+}
+
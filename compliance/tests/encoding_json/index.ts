@@ -0,0 +1,3 @@
+export { Color_MarshalText, Level_MarshalJSON } from "./encoding_json.gs.js"
+export { Address, Base, Big, Reading, Settings, Temp, User } from "./encoding_json.gs.js"
+export type { Color, Level, Status } from "./encoding_json.gs.js"
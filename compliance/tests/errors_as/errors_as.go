@@ -0,0 +1,53 @@
+package main
+
+import (
+	"errors"
+	"fmt"
+)
+
+// Code is an error of a named basic type.
+type Code int
+
+func (c Code) Error() string {
+	return fmt.Sprintf("code %d", int(c))
+}
+
+// PathError is an error returned by pointer.
+type PathError struct {
+	Path string
+}
+
+func (e *PathError) Error() string {
+	return "bad path " + e.Path
+}
+
+// Temporary is implemented by temporary errors.
+type Temporary interface {
+	Temporary() bool
+}
+
+type timeout struct{}
+
+func (timeout) Error() string   { return "timeout" }
+func (timeout) Temporary() bool { return true }
+
+func main() {
+	err := fmt.Errorf("wrapped: %w", Code(404))
+	var code Code
+	fmt.Println(errors.As(err, &code), int(code))
+
+	var pe *PathError
+	fmt.Println(errors.As(err, &pe), pe == nil)
+	err = fmt.Errorf("open: %w", &PathError{Path: "/tmp"})
+	fmt.Println(errors.As(err, &pe), pe.Path)
+	code = 0
+	fmt.Println(errors.As(err, &code), int(code))
+
+	var tmp Temporary
+	fmt.Println(errors.As(err, &tmp), tmp == nil)
+	err = errors.Join(errors.New("first"), timeout{})
+	fmt.Println(errors.As(err, &tmp), tmp.Temporary())
+
+	var target error
+	fmt.Println(errors.As(err, &target), target)
+}
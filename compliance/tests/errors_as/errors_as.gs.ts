@@ -0,0 +1,129 @@
+// Generated file based on errors_as.go
+// Updated when compliance tests are re-run, DO NOT EDIT!
+
+import * as $ from "@goscript/builtin/index.js";
+
+import * as errors from "@goscript/errors/index.js"
+
+import * as fmt from "@goscript/fmt/index.js"
+
+export type Code = number;
+
+export function Code_Error(c: Code): string {
+	return fmt.Sprintf("code %d", c)
+}
+
+
+$.registerNamedType(
+  'main@github.com/aperturerobotics/goscript/compliance/tests/errors_as.Code',
+  0,
+  [{ name: "Error", args: [], returns: [{ type: { kind: $.TypeKind.Basic, name: "string" } }] }],
+  { kind: $.TypeKind.Basic, name: "int" },
+  { Error: Code_Error }
+);
+
+export class PathError {
+	public get Path(): string {
+		return this._fields.Path.value
+	}
+	public set Path(value: string) {
+		this._fields.Path.value = value
+	}
+
+	public _fields: {
+		Path: $.VarRef<string>;
+	}
+
+	constructor(init?: Partial<{Path?: string}>) {
+		this._fields = {
+			Path: $.varRef(init?.Path ?? "")
+		}
+	}
+
+	public clone(): PathError {
+		const cloned = new PathError()
+		cloned._fields = {
+			Path: $.varRef(this._fields.Path.value)
+		}
+		return cloned
+	}
+
+	public Error(): string {
+		const e = this
+		return "bad path " + e.Path
+	}
+
+	// Register this type with the runtime type system
+	static __typeInfo = $.registerStructType(
+	  'main@github.com/aperturerobotics/goscript/compliance/tests/errors_as.PathError',
+	  new PathError(),
+	  [{ name: "Error", args: [], returns: [{ type: { kind: $.TypeKind.Basic, name: "string" } }] }],
+	  PathError,
+	  [{ name: "Path", type: { kind: $.TypeKind.Basic, name: "string" } }]
+	);
+}
+
+export type Temporary = null | {
+	Temporary(): boolean
+}
+
+$.registerInterfaceType(
+  'main@github.com/aperturerobotics/goscript/compliance/tests/errors_as.Temporary',
+  null, // Zero value for interface is null
+  [{ name: "Temporary", args: [], returns: [{ type: { kind: $.TypeKind.Basic, name: "boolean" } }] }]
+);
+
+export class timeout {
+	public _fields: {
+	}
+
+	constructor(init?: Partial<{}>) {
+		this._fields = {}
+	}
+
+	public clone(): timeout {
+		const cloned = new timeout()
+		cloned._fields = {
+		}
+		return cloned
+	}
+
+	public Error(): string {
+		return "timeout"
+	}
+
+	public Temporary(): boolean {
+		return true
+	}
+
+	// Register this type with the runtime type system
+	static __typeInfo = $.registerStructType(
+	  'main@github.com/aperturerobotics/goscript/compliance/tests/errors_as.timeout',
+	  new timeout(),
+	  [{ name: "Error", args: [], returns: [{ type: { kind: $.TypeKind.Basic, name: "string" } }] }, { name: "Temporary", args: [], returns: [{ type: { kind: $.TypeKind.Basic, name: "boolean" } }] }],
+	  timeout,
+	  []
+	);
+}
+
+export async function main(): Promise<void> {
+	let err = fmt.Errorf("wrapped: %w", $.namedValue((404 as Code), "main@github.com/aperturerobotics/goscript/compliance/tests/errors_as.Code"))
+	let code: $.VarRef<Code> = $.varRef(0)
+	fmt.Println(errors.As(err, code, { kind: $.TypeKind.Pointer, elemType: "main@github.com/aperturerobotics/goscript/compliance/tests/errors_as.Code" }), code!.value)
+
+	let pe: $.VarRef<PathError | null> = $.varRef(null)
+	fmt.Println(errors.As(err, pe, { kind: $.TypeKind.Pointer, elemType: { kind: $.TypeKind.Pointer, elemType: "main@github.com/aperturerobotics/goscript/compliance/tests/errors_as.PathError" } }), pe!.value == null)
+	err = fmt.Errorf("open: %w", new PathError({Path: "/tmp"}))
+	fmt.Println(errors.As(err, pe, { kind: $.TypeKind.Pointer, elemType: { kind: $.TypeKind.Pointer, elemType: "main@github.com/aperturerobotics/goscript/compliance/tests/errors_as.PathError" } }), pe!.value!.Path)
+	code!.value = 0
+	fmt.Println(errors.As(err, code, { kind: $.TypeKind.Pointer, elemType: "main@github.com/aperturerobotics/goscript/compliance/tests/errors_as.Code" }), code!.value)
+
+	let tmp: $.VarRef<Temporary> = $.varRef(null)
+	fmt.Println(errors.As(err, tmp, { kind: $.TypeKind.Pointer, elemType: "main@github.com/aperturerobotics/goscript/compliance/tests/errors_as.Temporary" }), tmp!.value == null)
+	err = errors.Join(errors.New("first"), $.markStructValue(new timeout({})))
+	fmt.Println(errors.As(err, tmp, { kind: $.TypeKind.Pointer, elemType: "main@github.com/aperturerobotics/goscript/compliance/tests/errors_as.Temporary" }), tmp!.value!.Temporary())
+
+	let target: $.VarRef<$.GoError> = $.varRef(null)
+	fmt.Println(errors.As(err, target, { kind: $.TypeKind.Pointer, elemType: { kind: $.TypeKind.Interface, name: 'GoError', methods: [{ name: 'Error', args: [], returns: [{ type: { kind: $.TypeKind.Basic, name: 'string' } }] }] } }), target!.value)
+}
+
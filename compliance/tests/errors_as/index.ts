@@ -0,0 +1,3 @@
+export { Code_Error } from "./errors_as.gs.js"
+export { PathError } from "./errors_as.gs.js"
+export type { Code, Temporary } from "./errors_as.gs.js"
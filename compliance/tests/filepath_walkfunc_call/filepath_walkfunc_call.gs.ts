@@ -14,9 +14,9 @@ export type Filesystem = null | {
 }
 
 $.registerInterfaceType(
-  'Filesystem',
+  'main@github.com/aperturerobotics/goscript/compliance/tests/filepath_walkfunc_call.Filesystem',
   null, // Zero value for interface is null
-  [{ name: "ReadDir", args: [{ name: "path", type: { kind: $.TypeKind.Basic, name: "string" } }], returns: [{ type: { kind: $.TypeKind.Slice, elemType: { kind: $.TypeKind.Interface, methods: [{ name: "IsDir", args: [], returns: [{ type: { kind: $.TypeKind.Basic, name: "boolean" } }] }, { name: "ModTime", args: [], returns: [{ type: "Time" }] }, { name: "Mode", args: [], returns: [{ type: "FileMode" }] }, { name: "Name", args: [], returns: [{ type: { kind: $.TypeKind.Basic, name: "string" } }] }, { name: "Size", args: [], returns: [{ type: { kind: $.TypeKind.Basic, name: "number" } }] }, { name: "Sys", args: [], returns: [{ type: { kind: $.TypeKind.Interface, methods: [] } }] }] } } }, { type: { kind: $.TypeKind.Interface, name: 'GoError', methods: [{ name: 'Error', args: [], returns: [{ type: { kind: $.TypeKind.Basic, name: 'string' } }] }] } }] }]
+  [{ name: "ReadDir", args: [{ name: "path", type: { kind: $.TypeKind.Basic, name: "string" } }], returns: [{ type: { kind: $.TypeKind.Slice, elemType: "io/fs.FileInfo" } }, { type: { kind: $.TypeKind.Interface, name: 'GoError', methods: [{ name: 'Error', args: [], returns: [{ type: { kind: $.TypeKind.Basic, name: 'string' } }] }] } }] }]
 );
 
 export class MockFileInfo {
@@ -94,11 +94,11 @@ export class MockFileInfo {
 
 	// Register this type with the runtime type system
 	static __typeInfo = $.registerStructType(
-	  'MockFileInfo',
+	  'main@github.com/aperturerobotics/goscript/compliance/tests/filepath_walkfunc_call.MockFileInfo',
 	  new MockFileInfo(),
-	  [{ name: "Name", args: [], returns: [{ type: { kind: $.TypeKind.Basic, name: "string" } }] }, { name: "Size", args: [], returns: [{ type: { kind: $.TypeKind.Basic, name: "number" } }] }, { name: "Mode", args: [], returns: [{ type: { kind: $.TypeKind.Basic, name: "number" } }] }, { name: "ModTime", args: [], returns: [{ type: "Time" }] }, { name: "IsDir", args: [], returns: [{ type: { kind: $.TypeKind.Basic, name: "boolean" } }] }, { name: "Sys", args: [], returns: [{ type: { kind: $.TypeKind.Interface, methods: [] } }] }],
+	  [{ name: "Name", args: [], returns: [{ type: { kind: $.TypeKind.Basic, name: "string" } }] }, { name: "Size", args: [], returns: [{ type: { kind: $.TypeKind.Basic, name: "number" } }] }, { name: "Mode", args: [], returns: [{ type: "io/fs.FileMode" }] }, { name: "ModTime", args: [], returns: [{ type: "time.Time" }] }, { name: "IsDir", args: [], returns: [{ type: { kind: $.TypeKind.Basic, name: "boolean" } }] }, { name: "Sys", args: [], returns: [{ type: { kind: $.TypeKind.Interface, methods: [] } }] }],
 	  MockFileInfo,
-	  {"name": { kind: $.TypeKind.Basic, name: "string" }, "size": { kind: $.TypeKind.Basic, name: "number" }, "dir": { kind: $.TypeKind.Basic, name: "boolean" }}
+	  [{ name: "name", type: { kind: $.TypeKind.Basic, name: "string" } }, { name: "size", type: { kind: $.TypeKind.Basic, name: "int64" } }, { name: "dir", type: { kind: $.TypeKind.Basic, name: "bool" } }]
 	);
 }
 
@@ -118,23 +118,23 @@ export class MockFilesystem {
 	}
 
 	public ReadDir(path: string): [$.Slice<os.FileInfo>, $.GoError] {
-		return [$.arrayToSlice<os.FileInfo>([new MockFileInfo({dir: false, name: "file1.txt", size: 100}), new MockFileInfo({dir: true, name: "subdir", size: 0})]), null]
+		return [$.arrayToSlice<os.FileInfo>([$.markStructValue(new MockFileInfo({dir: false, name: "file1.txt", size: 100})), $.markStructValue(new MockFileInfo({dir: true, name: "subdir", size: 0}))]), null]
 	}
 
 	// Register this type with the runtime type system
 	static __typeInfo = $.registerStructType(
-	  'MockFilesystem',
+	  'main@github.com/aperturerobotics/goscript/compliance/tests/filepath_walkfunc_call.MockFilesystem',
 	  new MockFilesystem(),
-	  [{ name: "ReadDir", args: [{ name: "path", type: { kind: $.TypeKind.Basic, name: "string" } }], returns: [{ type: { kind: $.TypeKind.Slice, elemType: { kind: $.TypeKind.Interface, methods: [{ name: "IsDir", args: [], returns: [{ type: { kind: $.TypeKind.Basic, name: "boolean" } }] }, { name: "ModTime", args: [], returns: [{ type: "Time" }] }, { name: "Mode", args: [], returns: [{ type: "FileMode" }] }, { name: "Name", args: [], returns: [{ type: { kind: $.TypeKind.Basic, name: "string" } }] }, { name: "Size", args: [], returns: [{ type: { kind: $.TypeKind.Basic, name: "number" } }] }, { name: "Sys", args: [], returns: [{ type: { kind: $.TypeKind.Interface, methods: [] } }] }] } } }, { type: { kind: $.TypeKind.Interface, name: 'GoError', methods: [{ name: 'Error', args: [], returns: [{ type: { kind: $.TypeKind.Basic, name: 'string' } }] }] } }] }],
+	  [{ name: "ReadDir", args: [{ name: "path", type: { kind: $.TypeKind.Basic, name: "string" } }], returns: [{ type: { kind: $.TypeKind.Slice, elemType: "io/fs.FileInfo" } }, { type: { kind: $.TypeKind.Interface, name: 'GoError', methods: [{ name: 'Error', args: [], returns: [{ type: { kind: $.TypeKind.Basic, name: 'string' } }] }] } }] }],
 	  MockFilesystem,
-	  {}
+	  []
 	);
 }
 
 // This is the exact function signature from the user's example
 // walkFn is filepath.WalkFunc which should be nullable and need ! operator
 export function walk(fs: Filesystem, path: string, info: os.FileInfo, walkFn: filepath.WalkFunc | null): $.GoError {
-	let filename = path + "/" + info!.Name()
+	let filename = $.concatString(path + "/", info!.Name())
 	let fileInfo = info
 
 	// This is the exact call that should generate walkFn!(filename, fileInfo, err)
@@ -195,7 +195,7 @@ export async function main(): Promise<void> {
 	}
 
 	// Test the walk function
-	let err = walk(fs, "/test", fileInfo, walkFunc)
+	let err = walk($.markStructValue(fs.clone()), "/test", $.markStructValue(fileInfo.clone()), walkFunc)
 	if (err != null) {
 		console.log("Walk error:", err!.Error())
 	}
@@ -8,7 +8,7 @@ export async function main(): Promise<void> {
 	let O_CREATE: number = 0x40
 	let O_APPEND: number = 0x400
 	let O_TRUNC: number = 0x200
-	let flag = ((1 | 64) | 1024)
+	let flag = 1089
 	if ((flag & 1024) != 0) {
 		console.log("O_APPEND is set: Expected: O_APPEND is set, Actual: O_APPEND is set")
 	}
@@ -22,7 +22,7 @@ export async function main(): Promise<void> {
 		console.log("O_TRUNC is not set: Expected: O_TRUNC is not set, Actual: O_TRUNC is not set")
 	}
 
-	flag = (1 | 64)
+	flag = 65
 	if ((flag & 1024) != 0) {
 		console.log("O_APPEND is set: Expected: (no output)")
 	}
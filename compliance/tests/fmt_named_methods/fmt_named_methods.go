@@ -0,0 +1,131 @@
+package main
+
+import (
+	"errors"
+	"fmt"
+)
+
+// Color implements fmt.Stringer.
+type Color int
+
+func (c Color) String() string {
+	switch c {
+	case 0:
+		return "red"
+	case 1:
+		return "green"
+	}
+	return fmt.Sprintf("Color(%d)", int(c))
+}
+
+// Code implements error.
+type Code string
+
+func (c Code) Error() string {
+	return "code " + string(c)
+}
+
+// Amount implements fmt.Formatter.
+type Amount float64
+
+func (a Amount) Format(f fmt.State, verb rune) {
+	fmt.Fprintf(f, "$%.2f", float64(a))
+}
+
+// Flag implements fmt.GoStringer.
+type Flag bool
+
+func (f Flag) GoString() string {
+	if f {
+		return "Flag(on)"
+	}
+	return "Flag(off)"
+}
+
+// Names implements fmt.Stringer on a slice type.
+type Names []string
+
+func (n Names) String() string {
+	return fmt.Sprintf("%d names", len(n))
+}
+
+// Plain has no methods.
+type Plain int
+
+type Palette struct {
+	Main   Color
+	Accent Color
+	Err    Code
+}
+
+type P struct {
+	X int
+}
+
+func main() {
+	c := Color(1)
+
+	// Direct arguments
+	fmt.Println(c, Color(0), Color(7))
+	fmt.Printf("%v %s %d %q %x\n", c, c, c, c, c)
+	fmt.Printf("%+v %#v %T\n", c, c, c)
+	fmt.Println(fmt.Sprint(c), fmt.Sprintf("[%8s]", c))
+
+	// Errors, Formatters and GoStringers
+	var code Code = "E42"
+	fmt.Println(code)
+	fmt.Printf("%v %s %T\n", code, code, code)
+	fmt.Println(fmt.Errorf("wrapped: %w", code))
+	fmt.Println(Amount(3.5))
+	fmt.Printf("%v %8.1f %s\n", Amount(2), Amount(1), Amount(0.25))
+	fmt.Printf("%#v %v\n", Flag(true), Flag(false))
+	fmt.Println(Names{"a", "b"}, Plain(3))
+
+	// Inside a struct
+	p := Palette{Main: 0, Accent: 1, Err: "E1"}
+	fmt.Println(p)
+	fmt.Printf("%+v\n", p)
+
+	// Inside slices, arrays and maps
+	fmt.Println([]Color{0, 1, 2})
+	fmt.Printf("%v %s\n", [2]Color{1, 0}, []Color{1})
+	fmt.Println(map[string]Color{"bg": 0, "fg": 1})
+	fmt.Println([]Code{"a", "b"}, []Palette{p})
+
+	// Inside an interface
+	var a any = c
+	fmt.Println(a)
+	fmt.Printf("%v %s %T\n", a, a, a)
+	var s fmt.Stringer = Color(0)
+	fmt.Println(s, s.String())
+	var err error = Code("E9")
+	fmt.Println(err, err.Error())
+	list := []any{Color(1), Code("x"), 3, "s"}
+	fmt.Println(list)
+	fmt.Printf("%v\n", map[string]any{"c": Color(0)})
+
+	// Interface values keep their dynamic type
+	if got, ok := a.(Color); ok {
+		fmt.Println("assert:", int(got), got)
+	}
+	if _, ok := a.(int); !ok {
+		fmt.Println("not an int")
+	}
+	switch v := a.(type) {
+	case int:
+		fmt.Println("int", v)
+	case Color:
+		fmt.Println("Color", int(v)+1)
+	}
+	if st, ok := a.(fmt.Stringer); ok {
+		fmt.Println("stringer:", st.String())
+	}
+	fmt.Println(a == Color(1), a == Color(0), a == any(Color(1)))
+	fmt.Println(errors.Is(fmt.Errorf("w: %w", Code("E9")), Code("E9")))
+
+	// Type names
+	pp := &P{X: 1}
+	fmt.Printf("%T %T %T %T\n", pp, *pp, []any{1}, map[string]any{})
+	fmt.Printf("%T %T\n", []Color{}, []*P{pp})
+	fmt.Printf("%v %+v\n", pp, pp)
+}
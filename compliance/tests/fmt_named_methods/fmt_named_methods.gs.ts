@@ -0,0 +1,273 @@
+// Generated file based on fmt_named_methods.go
+// Updated when compliance tests are re-run, DO NOT EDIT!
+
+import * as $ from "@goscript/builtin/index.js";
+
+import * as errors from "@goscript/errors/index.js"
+
+import * as fmt from "@goscript/fmt/index.js"
+
+export type Color = number;
+
+export function Color_String(c: Color): string {
+	switch (c) {
+		case 0:
+			return "red"
+			break
+		case 1:
+			return "green"
+			break
+	}
+	return fmt.Sprintf("Color(%d)", c)
+}
+
+
+$.registerNamedType(
+  'main@github.com/aperturerobotics/goscript/compliance/tests/fmt_named_methods.Color',
+  0,
+  [{ name: "String", args: [], returns: [{ type: { kind: $.TypeKind.Basic, name: "string" } }] }],
+  { kind: $.TypeKind.Basic, name: "int" },
+  { String: Color_String }
+);
+
+export type Code = string;
+
+export function Code_Error(c: Code): string {
+	return "code " + c
+}
+
+
+$.registerNamedType(
+  'main@github.com/aperturerobotics/goscript/compliance/tests/fmt_named_methods.Code',
+  "",
+  [{ name: "Error", args: [], returns: [{ type: { kind: $.TypeKind.Basic, name: "string" } }] }],
+  { kind: $.TypeKind.Basic, name: "string" },
+  { Error: Code_Error }
+);
+
+export type Amount = number;
+
+export function Amount_Format(a: Amount, f: fmt.State, verb: number): void {
+	fmt.Fprintf(f, "$%.2f", a)
+}
+
+
+$.registerNamedType(
+  'main@github.com/aperturerobotics/goscript/compliance/tests/fmt_named_methods.Amount',
+  0,
+  [{ name: "Format", args: [{ name: "f", type: "fmt.State" }, { name: "verb", type: { kind: $.TypeKind.Basic, name: "number" } }], returns: [] }],
+  { kind: $.TypeKind.Basic, name: "float64" },
+  { Format: Amount_Format }
+);
+
+export type Flag = boolean;
+
+export function Flag_GoString(f: Flag): string {
+	if (f) {
+		return "Flag(on)"
+	}
+	return "Flag(off)"
+}
+
+
+$.registerNamedType(
+  'main@github.com/aperturerobotics/goscript/compliance/tests/fmt_named_methods.Flag',
+  false,
+  [{ name: "GoString", args: [], returns: [{ type: { kind: $.TypeKind.Basic, name: "string" } }] }],
+  { kind: $.TypeKind.Basic, name: "bool" },
+  { GoString: Flag_GoString }
+);
+
+export type Names = $.Slice<string>;
+
+export function Names_String(n: Names): string {
+	return fmt.Sprintf("%d names", $.len(n))
+}
+
+
+$.registerNamedType(
+  'main@github.com/aperturerobotics/goscript/compliance/tests/fmt_named_methods.Names',
+  null,
+  [{ name: "String", args: [], returns: [{ type: { kind: $.TypeKind.Basic, name: "string" } }] }],
+  { kind: $.TypeKind.Slice, elemType: { kind: $.TypeKind.Basic, name: "string" } },
+  { String: Names_String }
+);
+
+export type Plain = number;
+
+$.registerNamedType(
+  'main@github.com/aperturerobotics/goscript/compliance/tests/fmt_named_methods.Plain',
+  0,
+  [],
+  { kind: $.TypeKind.Basic, name: "int" }
+);
+
+export class Palette {
+	public get Main(): Color {
+		return this._fields.Main.value
+	}
+	public set Main(value: Color) {
+		this._fields.Main.value = value
+	}
+
+	public get Accent(): Color {
+		return this._fields.Accent.value
+	}
+	public set Accent(value: Color) {
+		this._fields.Accent.value = value
+	}
+
+	public get Err(): Code {
+		return this._fields.Err.value
+	}
+	public set Err(value: Code) {
+		this._fields.Err.value = value
+	}
+
+	public _fields: {
+		Main: $.VarRef<Color>;
+		Accent: $.VarRef<Color>;
+		Err: $.VarRef<Code>;
+	}
+
+	constructor(init?: Partial<{Accent?: Color, Err?: Code, Main?: Color}>) {
+		this._fields = {
+			Main: $.varRef(init?.Main ?? 0 as Color),
+			Accent: $.varRef(init?.Accent ?? 0 as Color),
+			Err: $.varRef(init?.Err ?? "" as Code)
+		}
+	}
+
+	public clone(): Palette {
+		const cloned = new Palette()
+		cloned._fields = {
+			Main: $.varRef(this._fields.Main.value),
+			Accent: $.varRef(this._fields.Accent.value),
+			Err: $.varRef(this._fields.Err.value)
+		}
+		return cloned
+	}
+
+	// Register this type with the runtime type system
+	static __typeInfo = $.registerStructType(
+	  'main@github.com/aperturerobotics/goscript/compliance/tests/fmt_named_methods.Palette',
+	  new Palette(),
+	  [],
+	  Palette,
+	  [{ name: "Main", type: "main@github.com/aperturerobotics/goscript/compliance/tests/fmt_named_methods.Color" }, { name: "Accent", type: "main@github.com/aperturerobotics/goscript/compliance/tests/fmt_named_methods.Color" }, { name: "Err", type: "main@github.com/aperturerobotics/goscript/compliance/tests/fmt_named_methods.Code" }]
+	);
+}
+
+export class P {
+	public get X(): number {
+		return this._fields.X.value
+	}
+	public set X(value: number) {
+		this._fields.X.value = value
+	}
+
+	public _fields: {
+		X: $.VarRef<number>;
+	}
+
+	constructor(init?: Partial<{X?: number}>) {
+		this._fields = {
+			X: $.varRef(init?.X ?? 0)
+		}
+	}
+
+	public clone(): P {
+		const cloned = new P()
+		cloned._fields = {
+			X: $.varRef(this._fields.X.value)
+		}
+		return cloned
+	}
+
+	// Register this type with the runtime type system
+	static __typeInfo = $.registerStructType(
+	  'main@github.com/aperturerobotics/goscript/compliance/tests/fmt_named_methods.P',
+	  new P(),
+	  [],
+	  P,
+	  [{ name: "X", type: { kind: $.TypeKind.Basic, name: "int" } }]
+	);
+}
+
+export async function main(): Promise<void> {
+	let c = (1 as Color)
+
+	// Direct arguments
+	fmt.Println($.namedValue(c, "main@github.com/aperturerobotics/goscript/compliance/tests/fmt_named_methods.Color"), $.namedValue((0 as Color), "main@github.com/aperturerobotics/goscript/compliance/tests/fmt_named_methods.Color"), $.namedValue((7 as Color), "main@github.com/aperturerobotics/goscript/compliance/tests/fmt_named_methods.Color"))
+	fmt.Printf("%v %s %d %q %x\n", $.namedValue(c, "main@github.com/aperturerobotics/goscript/compliance/tests/fmt_named_methods.Color"), $.namedValue(c, "main@github.com/aperturerobotics/goscript/compliance/tests/fmt_named_methods.Color"), $.namedValue(c, "main@github.com/aperturerobotics/goscript/compliance/tests/fmt_named_methods.Color"), $.namedValue(c, "main@github.com/aperturerobotics/goscript/compliance/tests/fmt_named_methods.Color"), $.namedValue(c, "main@github.com/aperturerobotics/goscript/compliance/tests/fmt_named_methods.Color"))
+	fmt.Printf("%+v %#v %T\n", $.namedValue(c, "main@github.com/aperturerobotics/goscript/compliance/tests/fmt_named_methods.Color"), $.namedValue(c, "main@github.com/aperturerobotics/goscript/compliance/tests/fmt_named_methods.Color"), $.namedValue(c, "main@github.com/aperturerobotics/goscript/compliance/tests/fmt_named_methods.Color"))
+	fmt.Println(fmt.Sprint($.namedValue(c, "main@github.com/aperturerobotics/goscript/compliance/tests/fmt_named_methods.Color")), fmt.Sprintf("[%8s]", $.namedValue(c, "main@github.com/aperturerobotics/goscript/compliance/tests/fmt_named_methods.Color")))
+
+	// Errors, Formatters and GoStringers
+	let code: Code = "E42"
+	fmt.Println($.namedValue(code, "main@github.com/aperturerobotics/goscript/compliance/tests/fmt_named_methods.Code"))
+	fmt.Printf("%v %s %T\n", $.namedValue(code, "main@github.com/aperturerobotics/goscript/compliance/tests/fmt_named_methods.Code"), $.namedValue(code, "main@github.com/aperturerobotics/goscript/compliance/tests/fmt_named_methods.Code"), $.namedValue(code, "main@github.com/aperturerobotics/goscript/compliance/tests/fmt_named_methods.Code"))
+	fmt.Println(fmt.Errorf("wrapped: %w", $.namedValue(code, "main@github.com/aperturerobotics/goscript/compliance/tests/fmt_named_methods.Code")))
+	fmt.Println($.namedValue((3.5 as Amount), "main@github.com/aperturerobotics/goscript/compliance/tests/fmt_named_methods.Amount"))
+	fmt.Printf("%v %8.1f %s\n", $.namedValue((2 as Amount), "main@github.com/aperturerobotics/goscript/compliance/tests/fmt_named_methods.Amount"), $.namedValue((1 as Amount), "main@github.com/aperturerobotics/goscript/compliance/tests/fmt_named_methods.Amount"), $.namedValue((0.25 as Amount), "main@github.com/aperturerobotics/goscript/compliance/tests/fmt_named_methods.Amount"))
+	fmt.Printf("%#v %v\n", $.namedValue((true as Flag), "main@github.com/aperturerobotics/goscript/compliance/tests/fmt_named_methods.Flag"), $.namedValue((false as Flag), "main@github.com/aperturerobotics/goscript/compliance/tests/fmt_named_methods.Flag"))
+	fmt.Println($.namedValue($.arrayToSlice<string>(["a", "b"]), "main@github.com/aperturerobotics/goscript/compliance/tests/fmt_named_methods.Names"), (3 as Plain))
+
+	// Inside a struct
+	let p = new Palette({Accent: 1, Err: "E1", Main: 0})
+	fmt.Println($.markStructValue(p.clone()))
+	fmt.Printf("%+v\n", $.markStructValue(p.clone()))
+
+	// Inside slices, arrays and maps
+	fmt.Println($.markType($.arrayToSlice<Color>([0, 1, 2]), { kind: $.TypeKind.Slice, elemType: "main@github.com/aperturerobotics/goscript/compliance/tests/fmt_named_methods.Color" }))
+	fmt.Printf("%v %s\n", $.markType($.arrayToSlice<Color>([1, 0]), { kind: $.TypeKind.Array, length: 2, elemType: "main@github.com/aperturerobotics/goscript/compliance/tests/fmt_named_methods.Color" }), $.markType($.arrayToSlice<Color>([1]), { kind: $.TypeKind.Slice, elemType: "main@github.com/aperturerobotics/goscript/compliance/tests/fmt_named_methods.Color" }))
+	fmt.Println($.markType(new Map([["bg", 0], ["fg", 1]]), { kind: $.TypeKind.Map, keyType: { kind: $.TypeKind.Basic, name: "string" }, elemType: "main@github.com/aperturerobotics/goscript/compliance/tests/fmt_named_methods.Color" }))
+	fmt.Println($.markType($.arrayToSlice<Code>(["a", "b"]), { kind: $.TypeKind.Slice, elemType: "main@github.com/aperturerobotics/goscript/compliance/tests/fmt_named_methods.Code" }), $.arrayToSlice<Palette>([p]))
+
+	// Inside an interface
+	let a: null | any = $.namedValue(c, "main@github.com/aperturerobotics/goscript/compliance/tests/fmt_named_methods.Color")
+	fmt.Println(a)
+	fmt.Printf("%v %s %T\n", a, a, a)
+	let s: fmt.Stringer = $.namedValue((0 as Color), "main@github.com/aperturerobotics/goscript/compliance/tests/fmt_named_methods.Color")
+	fmt.Println(s, s!.String())
+	let err: $.GoError = $.namedValue(("E9" as Code), "main@github.com/aperturerobotics/goscript/compliance/tests/fmt_named_methods.Code")
+	fmt.Println(err, err!.Error())
+	let list = $.arrayToSlice<null | any>([$.namedValue((1 as Color), "main@github.com/aperturerobotics/goscript/compliance/tests/fmt_named_methods.Color"), $.namedValue(("x" as Code), "main@github.com/aperturerobotics/goscript/compliance/tests/fmt_named_methods.Code"), 3, "s"])
+	fmt.Println($.markType(list, { kind: $.TypeKind.Slice, elemType: { kind: $.TypeKind.Interface, methods: [] } }))
+	fmt.Printf("%v\n", $.markType(new Map([["c", $.namedValue((0 as Color), "main@github.com/aperturerobotics/goscript/compliance/tests/fmt_named_methods.Color")]]), { kind: $.TypeKind.Map, keyType: { kind: $.TypeKind.Basic, name: "string" }, elemType: { kind: $.TypeKind.Interface, methods: [] } }))
+
+	// Interface values keep their dynamic type
+	{
+		let { value: got, ok: ok } = $.typeAssert<Color>(a, 'main@github.com/aperturerobotics/goscript/compliance/tests/fmt_named_methods.Color')
+		if (ok) {
+			fmt.Println("assert:", got, $.namedValue(got, "main@github.com/aperturerobotics/goscript/compliance/tests/fmt_named_methods.Color"))
+		}
+	}
+	{
+		let { ok: ok } = $.typeAssert<number>(a, {kind: $.TypeKind.Basic, name: 'number'})
+		if (!ok) {
+			fmt.Println("not an int")
+		}
+	}
+	$.typeSwitch(a, [{ types: [{kind: $.TypeKind.Basic, name: 'number'}], body: (v) => {
+		fmt.Println("int", v)
+	}},
+	{ types: ['main@github.com/aperturerobotics/goscript/compliance/tests/fmt_named_methods.Color'], body: (v) => {
+		fmt.Println("Color", v + 1)
+	}}])
+	{
+		let { value: st, ok: ok } = $.typeAssert<fmt.Stringer>(a, 'fmt.Stringer')
+		if (ok) {
+			fmt.Println("stringer:", st!.String())
+		}
+	}
+	fmt.Println(a == $.namedValue((1 as Color), "main@github.com/aperturerobotics/goscript/compliance/tests/fmt_named_methods.Color"), a == $.namedValue((0 as Color), "main@github.com/aperturerobotics/goscript/compliance/tests/fmt_named_methods.Color"), a == ($.namedValue((1 as Color), "main@github.com/aperturerobotics/goscript/compliance/tests/fmt_named_methods.Color") as null | any))
+	fmt.Println(errors.Is(fmt.Errorf("w: %w", $.namedValue(("E9" as Code), "main@github.com/aperturerobotics/goscript/compliance/tests/fmt_named_methods.Code")), $.namedValue(("E9" as Code), "main@github.com/aperturerobotics/goscript/compliance/tests/fmt_named_methods.Code")))
+
+	// Type names
+	let pp = new P({X: 1})
+	fmt.Printf("%T %T %T %T\n", pp, $.markStructValue(pp!.clone()), $.markType($.arrayToSlice<null | any>([1]), { kind: $.TypeKind.Slice, elemType: { kind: $.TypeKind.Interface, methods: [] } }), $.markType(new Map([]), { kind: $.TypeKind.Map, keyType: { kind: $.TypeKind.Basic, name: "string" }, elemType: { kind: $.TypeKind.Interface, methods: [] } }))
+	fmt.Printf("%T %T\n", $.markType($.arrayToSlice<Color>([]), { kind: $.TypeKind.Slice, elemType: "main@github.com/aperturerobotics/goscript/compliance/tests/fmt_named_methods.Color" }), $.markType($.arrayToSlice<P | null>([pp]), { kind: $.TypeKind.Slice, elemType: { kind: $.TypeKind.Pointer, elemType: "main@github.com/aperturerobotics/goscript/compliance/tests/fmt_named_methods.P" } }))
+	fmt.Printf("%v %+v\n", pp, pp)
+}
+
@@ -0,0 +1,3 @@
+export { Amount_Format, Code_Error, Color_String, Flag_GoString, Names_String } from "./fmt_named_methods.gs.js"
+export { P, Palette } from "./fmt_named_methods.gs.js"
+export type { Amount, Code, Color, Flag, Names, Plain } from "./fmt_named_methods.gs.js"
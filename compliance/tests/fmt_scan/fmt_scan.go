@@ -0,0 +1,137 @@
+package main
+
+import (
+	"fmt"
+	"io"
+	"strings"
+)
+
+// byteReader only implements io.Reader, so Fscan reads it a byte at a time.
+type byteReader struct {
+	data []byte
+	pos  int
+}
+
+func (r *byteReader) Read(p []byte) (int, error) {
+	if r.pos >= len(r.data) {
+		return 0, io.EOF
+	}
+	n := copy(p, r.data[r.pos:])
+	r.pos += n
+	return n, nil
+}
+
+// point implements fmt.Scanner.
+type point struct {
+	X, Y int
+}
+
+func (p *point) Scan(state fmt.ScanState, verb rune) error {
+	_, err := fmt.Fscanf(state, "(%d,%d)", &p.X, &p.Y)
+	return err
+}
+
+type record struct {
+	Name  string
+	Count int
+}
+
+func main() {
+	// Sscanf with a small text protocol
+	var cmd string
+	var id int
+	var ratio float64
+	n, err := fmt.Sscanf("SET 42 0.75", "%s %d %f", &cmd, &id, &ratio)
+	fmt.Println(n, err, cmd, id, ratio)
+
+	// Literals in the format must match
+	var major, minor int
+	n, err = fmt.Sscanf("v1.22", "v%d.%d", &major, &minor)
+	fmt.Println(n, err, major, minor)
+
+	// Bases, widths and characters
+	var hex, oct, bin int
+	n, err = fmt.Sscanf("ff 17 101", "%x %o %b", &hex, &oct, &bin)
+	fmt.Println(n, err, hex, oct, bin)
+
+	var a, b int
+	n, err = fmt.Sscanf("12345", "%2d%3d", &a, &b)
+	fmt.Println(n, err, a, b)
+
+	var c rune
+	n, err = fmt.Sscanf("xyz", "x%c", &c)
+	fmt.Println(n, err, string(c))
+
+	// Quoted and hex strings
+	var q, h string
+	n, err = fmt.Sscanf(`"hello world" 676f`, "%q %x", &q, &h)
+	fmt.Println(n, err, q, h)
+
+	// Booleans, int64 and byte slices
+	var ok bool
+	var big int64
+	var raw []byte
+	n, err = fmt.Sscan("true -1099511627776 bytes", &ok, &big, &raw)
+	fmt.Println(n, err, ok, big, string(raw))
+
+	// %v accepts base prefixes and float syntax
+	var v1, v2 int
+	var f1 float64
+	n, err = fmt.Sscan("0x1F 0b11 2.5e3", &v1, &v2, &f1)
+	fmt.Println(n, err, v1, v2, f1)
+
+	// Sscan treats newlines as space, Sscanln stops at them
+	var x, y int
+	n, err = fmt.Sscan("1\n2", &x, &y)
+	fmt.Println(n, err, x, y)
+	n, err = fmt.Sscanln("3\n4", &x, &y)
+	fmt.Println(n, err, x, y)
+	n, err = fmt.Sscanln("5 6 7", &x, &y)
+	fmt.Println(n, err, x, y)
+
+	// Errors
+	n, err = fmt.Sscanf("abc", "%d", &x)
+	fmt.Println(n, err)
+	n, err = fmt.Sscan("", &x)
+	fmt.Println(n, err, err == io.EOF)
+	n, err = fmt.Sscan("7", &x, &y)
+	fmt.Println(n, err, err == io.ErrUnexpectedEOF)
+	n, err = fmt.Sscanf("1 2", "%d", &x, &y)
+	fmt.Println(n, err)
+	n, err = fmt.Sscanf("1-2", "%d+%d", &x, &y)
+	fmt.Println(n, err)
+	n, err = fmt.Sscanf("maybe", "%t", &ok)
+	fmt.Println(n, err)
+	n, err = fmt.Sscanf("99999999999999999999", "%d", &big)
+	fmt.Println(n, err)
+
+	// Fscan from an io.RuneScanner, line by line
+	r := strings.NewReader("alice 30\nbob 25\n")
+	for {
+		var name string
+		var age int
+		n, err := fmt.Fscanln(r, &name, &age)
+		if err != nil {
+			fmt.Println("done:", n, err)
+			break
+		}
+		fmt.Println(name, age)
+	}
+
+	// Fscan from a plain io.Reader
+	br := &byteReader{data: []byte("héllo 3.5")}
+	var word string
+	var num float64
+	n, err = fmt.Fscan(br, &word, &num)
+	fmt.Println(n, err, word, num)
+
+	// Types implementing fmt.Scanner
+	var p point
+	n, err = fmt.Sscan("(3,4)", &p)
+	fmt.Println(n, err, p.X, p.Y)
+
+	// Struct fields
+	var rec record
+	n, err = fmt.Sscanf("widget=12", "%6s=%d", &rec.Name, &rec.Count)
+	fmt.Println(n, err, rec.Name, rec.Count)
+}
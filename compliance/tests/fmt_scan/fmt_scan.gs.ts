@@ -0,0 +1,272 @@
+// Generated file based on fmt_scan.go
+// Updated when compliance tests are re-run, DO NOT EDIT!
+
+import * as $ from "@goscript/builtin/index.js";
+
+import * as fmt from "@goscript/fmt/index.js"
+
+import * as io from "@goscript/io/index.js"
+
+import * as strings from "@goscript/strings/index.js"
+
+export class byteReader {
+	public get data(): $.Bytes {
+		return this._fields.data.value
+	}
+	public set data(value: $.Bytes) {
+		this._fields.data.value = value
+	}
+
+	public get pos(): number {
+		return this._fields.pos.value
+	}
+	public set pos(value: number) {
+		this._fields.pos.value = value
+	}
+
+	public _fields: {
+		data: $.VarRef<$.Bytes>;
+		pos: $.VarRef<number>;
+	}
+
+	constructor(init?: Partial<{data?: $.Bytes, pos?: number}>) {
+		this._fields = {
+			data: $.varRef(init?.data ?? new Uint8Array(0)),
+			pos: $.varRef(init?.pos ?? 0)
+		}
+	}
+
+	public clone(): byteReader {
+		const cloned = new byteReader()
+		cloned._fields = {
+			data: $.varRef(this._fields.data.value),
+			pos: $.varRef(this._fields.pos.value)
+		}
+		return cloned
+	}
+
+	public Read(p: $.Bytes): [number, $.GoError] {
+		const r = this
+		if (r.pos >= $.len(r.data)) {
+			return [0, io.EOF]
+		}
+		let n = $.copy(p, $.goSlice(r.data, r.pos, undefined))
+		r.pos += n
+		return [n, null]
+	}
+
+	// Register this type with the runtime type system
+	static __typeInfo = $.registerStructType(
+	  'main@github.com/aperturerobotics/goscript/compliance/tests/fmt_scan.byteReader',
+	  new byteReader(),
+	  [{ name: "Read", args: [{ name: "p", type: { kind: $.TypeKind.Slice, elemType: { kind: $.TypeKind.Basic, name: "number" } } }], returns: [{ type: { kind: $.TypeKind.Basic, name: "number" } }, { type: { kind: $.TypeKind.Interface, name: 'GoError', methods: [{ name: 'Error', args: [], returns: [{ type: { kind: $.TypeKind.Basic, name: 'string' } }] }] } }] }],
+	  byteReader,
+	  [{ name: "data", type: { kind: $.TypeKind.Slice, elemType: { kind: $.TypeKind.Basic, name: "byte" } } }, { name: "pos", type: { kind: $.TypeKind.Basic, name: "int" } }]
+	);
+}
+
+export class point {
+	public get X(): number {
+		return this._fields.X.value
+	}
+	public set X(value: number) {
+		this._fields.X.value = value
+	}
+
+	public get Y(): number {
+		return this._fields.Y.value
+	}
+	public set Y(value: number) {
+		this._fields.Y.value = value
+	}
+
+	public _fields: {
+		X: $.VarRef<number>;
+		Y: $.VarRef<number>;
+	}
+
+	constructor(init?: Partial<{X?: number, Y?: number}>) {
+		this._fields = {
+			X: $.varRef(init?.X ?? 0),
+			Y: $.varRef(init?.Y ?? 0)
+		}
+	}
+
+	public clone(): point {
+		const cloned = new point()
+		cloned._fields = {
+			X: $.varRef(this._fields.X.value),
+			Y: $.varRef(this._fields.Y.value)
+		}
+		return cloned
+	}
+
+	public Scan(state: fmt.ScanState, verb: number): $.GoError {
+		const p = this
+		let [, err] = fmt.Fscanf(state, "(%d,%d)", p._fields.X, p._fields.Y)
+		return err
+	}
+
+	// Register this type with the runtime type system
+	static __typeInfo = $.registerStructType(
+	  'main@github.com/aperturerobotics/goscript/compliance/tests/fmt_scan.point',
+	  new point(),
+	  [{ name: "Scan", args: [{ name: "state", type: "fmt.ScanState" }, { name: "verb", type: { kind: $.TypeKind.Basic, name: "number" } }], returns: [{ type: { kind: $.TypeKind.Interface, name: 'GoError', methods: [{ name: 'Error', args: [], returns: [{ type: { kind: $.TypeKind.Basic, name: 'string' } }] }] } }] }],
+	  point,
+	  [{ name: "X", type: { kind: $.TypeKind.Basic, name: "int" } }, { name: "Y", type: { kind: $.TypeKind.Basic, name: "int" } }]
+	);
+}
+
+export class record {
+	public get Name(): string {
+		return this._fields.Name.value
+	}
+	public set Name(value: string) {
+		this._fields.Name.value = value
+	}
+
+	public get Count(): number {
+		return this._fields.Count.value
+	}
+	public set Count(value: number) {
+		this._fields.Count.value = value
+	}
+
+	public _fields: {
+		Name: $.VarRef<string>;
+		Count: $.VarRef<number>;
+	}
+
+	constructor(init?: Partial<{Count?: number, Name?: string}>) {
+		this._fields = {
+			Name: $.varRef(init?.Name ?? ""),
+			Count: $.varRef(init?.Count ?? 0)
+		}
+	}
+
+	public clone(): record {
+		const cloned = new record()
+		cloned._fields = {
+			Name: $.varRef(this._fields.Name.value),
+			Count: $.varRef(this._fields.Count.value)
+		}
+		return cloned
+	}
+
+	// Register this type with the runtime type system
+	static __typeInfo = $.registerStructType(
+	  'main@github.com/aperturerobotics/goscript/compliance/tests/fmt_scan.record',
+	  new record(),
+	  [],
+	  record,
+	  [{ name: "Name", type: { kind: $.TypeKind.Basic, name: "string" } }, { name: "Count", type: { kind: $.TypeKind.Basic, name: "int" } }]
+	);
+}
+
+export async function main(): Promise<void> {
+	// Sscanf with a small text protocol
+	let cmd: $.VarRef<string> = $.varRef("")
+	let id: $.VarRef<number> = $.varRef(0)
+	let ratio: $.VarRef<number> = $.varRef(0)
+	let [n, err] = fmt.Sscanf("SET 42 0.75", "%s %d %f", cmd, id, ratio)
+	fmt.Println(n, err, cmd!.value, id!.value, ratio!.value)
+
+	// Literals in the format must match
+	let major: $.VarRef<number> = $.varRef(0)
+	let minor: $.VarRef<number> = $.varRef(0)
+	;[n, err] = fmt.Sscanf("v1.22", "v%d.%d", major, minor)
+	fmt.Println(n, err, major!.value, minor!.value)
+
+	// Bases, widths and characters
+	let hex: $.VarRef<number> = $.varRef(0)
+	let oct: $.VarRef<number> = $.varRef(0)
+	let bin: $.VarRef<number> = $.varRef(0)
+	;[n, err] = fmt.Sscanf("ff 17 101", "%x %o %b", hex, oct, bin)
+	fmt.Println(n, err, hex!.value, oct!.value, bin!.value)
+
+	let a: $.VarRef<number> = $.varRef(0)
+	let b: $.VarRef<number> = $.varRef(0)
+	;[n, err] = fmt.Sscanf("12345", "%2d%3d", a, b)
+	fmt.Println(n, err, a!.value, b!.value)
+
+	let c: $.VarRef<number> = $.varRef(0)
+	;[n, err] = fmt.Sscanf("xyz", "x%c", c)
+	fmt.Println(n, err, $.runeOrStringToString(c!.value))
+
+	// Quoted and hex strings
+	let q: $.VarRef<string> = $.varRef("")
+	let h: $.VarRef<string> = $.varRef("")
+	;[n, err] = fmt.Sscanf(`"hello world" 676f`, "%q %x", q, h)
+	fmt.Println(n, err, q!.value, h!.value)
+
+	// Booleans, int64 and byte slices
+	let ok: $.VarRef<boolean> = $.varRef(false)
+	let big: $.VarRef<number> = $.varRef(0)
+	let raw: $.VarRef<$.Bytes> = $.varRef(new Uint8Array(0))
+	;[n, err] = fmt.Sscan("true -1099511627776 bytes", ok, big, raw)
+	fmt.Println(n, err, ok!.value, big!.value, $.bytesToString(raw!.value))
+
+	// %v accepts base prefixes and float syntax
+	let v1: $.VarRef<number> = $.varRef(0)
+	let v2: $.VarRef<number> = $.varRef(0)
+	let f1: $.VarRef<number> = $.varRef(0)
+	;[n, err] = fmt.Sscan("0x1F 0b11 2.5e3", v1, v2, f1)
+	fmt.Println(n, err, v1!.value, v2!.value, f1!.value)
+
+	// Sscan treats newlines as space, Sscanln stops at them
+	let x: $.VarRef<number> = $.varRef(0)
+	let y: $.VarRef<number> = $.varRef(0)
+	;[n, err] = fmt.Sscan("1\n2", x, y)
+	fmt.Println(n, err, x!.value, y!.value)
+	;[n, err] = fmt.Sscanln("3\n4", x, y)
+	fmt.Println(n, err, x!.value, y!.value)
+	;[n, err] = fmt.Sscanln("5 6 7", x, y)
+	fmt.Println(n, err, x!.value, y!.value)
+
+	// Errors
+	;[n, err] = fmt.Sscanf("abc", "%d", x)
+	fmt.Println(n, err)
+	;[n, err] = fmt.Sscan("", x)
+	fmt.Println(n, err, err == io.EOF)
+	;[n, err] = fmt.Sscan("7", x, y)
+	fmt.Println(n, err, err == io.ErrUnexpectedEOF)
+	;[n, err] = fmt.Sscanf("1 2", "%d", x, y)
+	fmt.Println(n, err)
+	;[n, err] = fmt.Sscanf("1-2", "%d+%d", x, y)
+	fmt.Println(n, err)
+	;[n, err] = fmt.Sscanf("maybe", "%t", ok)
+	fmt.Println(n, err)
+	;[n, err] = fmt.Sscanf("99999999999999999999", "%d", big)
+	fmt.Println(n, err)
+
+	// Fscan from an io.RuneScanner, line by line
+	let r = strings.NewReader("alice 30\nbob 25\n")
+	for (; ; ) {
+		let name: $.VarRef<string> = $.varRef("")
+		let age: $.VarRef<number> = $.varRef(0)
+		let [n, err] = fmt.Fscanln(r, name, age)
+		if (err != null) {
+			fmt.Println("done:", n, err)
+			break
+		}
+		fmt.Println(name!.value, age!.value)
+	}
+
+	// Fscan from a plain io.Reader
+	let br = new byteReader({data: $.stringToBytes("héllo 3.5")})
+	let word: $.VarRef<string> = $.varRef("")
+	let num: $.VarRef<number> = $.varRef(0)
+	;[n, err] = fmt.Fscan(br, word, num)
+	fmt.Println(n, err, word!.value, num!.value)
+
+	// Types implementing fmt.Scanner
+	let p: point = new point({})
+	;[n, err] = fmt.Sscan("(3,4)", p)
+	fmt.Println(n, err, p.X, p.Y)
+
+	// Struct fields
+	let rec: record = new record()
+	;[n, err] = fmt.Sscanf("widget=12", "%6s=%d", rec._fields.Name, rec._fields.Count)
+	fmt.Println(n, err, rec.Name, rec.Count)
+}
+
@@ -0,0 +1,228 @@
+package main
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+)
+
+type Level int
+
+type inner struct {
+	Name string
+	Tags []string
+}
+
+type record struct {
+	ID     int
+	Score  float64
+	Ok     bool
+	Inner  inner
+	Ptr    *inner
+	Items  []int
+	Lookup map[string]int
+	Err    error
+	Any    interface{}
+	Lvl    Level
+	hidden string
+}
+
+// celsius implements fmt.Stringer.
+type celsius struct {
+	deg float64
+}
+
+func (c celsius) String() string {
+	return fmt.Sprintf("%.1f°C", c.deg)
+}
+
+// codeError implements error.
+type codeError struct {
+	Code int
+}
+
+func (e codeError) Error() string {
+	return fmt.Sprintf("code %d", e.Code)
+}
+
+// money implements fmt.Formatter.
+type money struct {
+	Cents int
+}
+
+func (m money) Format(f fmt.State, verb rune) {
+	switch verb {
+	case 'v', 's':
+		if f.Flag('+') {
+			fmt.Fprintf(f, "USD ")
+		}
+		fmt.Fprintf(f, "$%d.%02d", m.Cents/100, m.Cents%100)
+	default:
+		fmt.Fprintf(f, "%%!%c(money)", verb)
+	}
+	if w, ok := f.Width(); ok {
+		fmt.Fprintf(f, "[w=%d]", w)
+	}
+}
+
+// token implements fmt.GoStringer.
+type token struct {
+	kind string
+}
+
+func (t token) GoString() string {
+	return "token(" + t.kind + ")"
+}
+
+// broken panics in String.
+type broken struct{}
+
+func (b broken) String() string {
+	panic("boom")
+}
+
+// badFormats are formats with verbs that don't match their arguments.
+var badFormats = []struct {
+	format string
+	args   []any
+}{
+	{"%d\n", []any{celsius{21.5}}},
+	{"%d\n", []any{"str"}},
+	{"%s\n", []any{42}},
+	{"%d %d\n", []any{1}},
+	{"%d\n", []any{1, "extra", 2.5}},
+	{"%!\n", nil},
+	{"%z\n", []any{3}},
+	{"%[5]d\n", []any{1}},
+	{"%[x]d\n", []any{1}},
+	{"%*d\n", []any{"w", 1}},
+	{"%.*d\n", []any{"p", 1}},
+	{"%t\n", []any{1}},
+	{"%d\n", []any{nil}},
+	{"%v %s\n", []any{nil, nil}},
+	{"trailing %", nil},
+}
+
+// wrapFormat wraps a value that is not an error.
+var wrapFormat = "%w"
+
+func main() {
+	// Width, precision and flags on integers
+	fmt.Printf("[%5d] [%-5d] [%05d] [%+d] [% d] [%x] [%X] [%#x] [%o] [%#o] [%O] [%b]\n",
+		42, 42, 42, 42, 42, 255, 255, 255, 8, 8, 8, 5)
+	fmt.Printf("[%08.3d] [%.0d] [%6.2d] [%-08d] [%+05d]\n", 7, 0, 3, -12, 12)
+	fmt.Printf("[%c] [%q] [%U] [%#U] [%x]\n", 'G', 'x', 0x1F600, 'é', -255)
+	var big int64 = -1099511627776
+	var ubig uint64 = 1 << 40
+	fmt.Printf("[%d] [%x] [%v]\n", big, big, ubig)
+
+	// Floats
+	fmt.Printf("[%f] [%.2f] [%8.3f] [%-8.2f] [%08.3f] [%+.1f]\n", 3.14159, 3.14159, 3.14159, 2.5, -3.14159, 2.0)
+	fmt.Printf("[%e] [%E] [%.3e] [%g] [%G] [%.3g] [%g]\n", 123456.789, 0.000123, 1e21, 1e21, 1e-7, 3.14159, 100000.0)
+	fmt.Printf("[%v] [%v] [%v] [%#g] [%#.3f] [%x]\n", 2.0, 1e-6, 1e21, 1.0, 1.0, 1.5)
+	fmt.Printf("[%6.2f] [%06.2f] [%+v] [% .2f]\n", -1.5, -1.5, 1.5, 1.5)
+
+	// Strings and bytes
+	fmt.Printf("[%s] [%10s] [%-10s] [%.2s] [%q] [%#q] [%+q] [%x] [% x] [%X]\n",
+		"hi", "right", "left", "truncate", "a\"b\n", "raw", "héllo", "hi", "hi", "hi")
+	fmt.Printf("[%s] [%v] [%x] [%q] [%d]\n", []byte("abc"), []byte("abc"), []byte("abc"), []byte("abc"), []byte("ab"))
+	fmt.Printf("[%5.1s] [%-4s|] [%08s]\n", "héllo", "é", "pad")
+	digest := [4]byte{0xde, 0xad, 0xbe, 0xef}
+	fmt.Printf("[%x] [%X] [% x] [%v] [%d] [%T]\n", digest, digest, digest, digest, digest, digest)
+	fmt.Println(fmt.Sprintf("%x", [2]uint8{1, 255}), fmt.Sprintf("%#v", [2]byte{1, 2}))
+
+	// Booleans
+	fmt.Printf("[%t] [%v] [%6t]\n", true, false, true)
+
+	// Composite values
+	r := record{
+		ID:     7,
+		Score:  9.5,
+		Ok:     true,
+		Inner:  inner{Name: "in", Tags: []string{"a", "b"}},
+		Items:  []int{1, 2, 3},
+		Lookup: map[string]int{"z": 26, "a": 1, "m": 13},
+		Err:    errors.New("bad"),
+		Any:    3,
+		Lvl:    2,
+		hidden: "h",
+	}
+	fmt.Printf("%v\n", r)
+	fmt.Printf("%+v\n", r)
+	fmt.Printf("%#v\n", inner{Name: "x", Tags: []string{"t"}})
+	fmt.Printf("%#v\n", inner{Name: "y"})
+	var empty record
+	fmt.Printf("%v\n", empty)
+	fmt.Printf("%+v\n", empty)
+	fmt.Println([]string{"a", "b"}, map[int]string{3: "c", 1: "a", 2: "b"}, [2]bool{true, false})
+	fmt.Printf("%#v %#v\n", []string{"a"}, map[string]int{"b": 2, "a": 1})
+	fmt.Printf("%v %d %x %s\n", []int{10, 11}, []int{10, 11}, []int{10, 11}, []string{"p", "q"})
+	nums := []int{1}
+	lookup := map[string]int{"k": 1}
+	fmt.Printf("%v %+v\n", &nums, &lookup)
+	fmt.Printf("%#v %#v %#v %#v\n", 42, "str", true, 1.5)
+	fmt.Printf("%#v\n", struct {
+		A int
+		B string
+	}{1, "x"})
+
+	// Type names
+	fmt.Printf("%T %T %T %T %T %T %T\n", 1, "s", 2.5, true, []int{1}, map[string]bool{"k": true}, r)
+	fmt.Printf("%T %T\n", inner{}, []byte("x"))
+
+	// Methods
+	c := celsius{21.5}
+	fmt.Println(c)
+	fmt.Printf("%v|%s|%q|%10s\n", c, c, c, c)
+	e := codeError{404}
+	fmt.Println(e)
+	fmt.Printf("%v|%+v|%s\n", e, e, e)
+	fmt.Println([]celsius{{1}, {2}})
+	fmt.Printf("%v|%+v|%s|%8v|%d\n", money{1234}, money{5}, money{99}, money{100}, money{1})
+	fmt.Printf("%v|%#v\n", token{"ident"}, token{"ident"})
+	fmt.Printf("%v|%s\n", broken{}, broken{})
+
+	// Argument indexes and star width/precision
+	fmt.Printf("%[2]d %[1]d %d\n", 10, 20)
+	fmt.Printf("%[3]*.[2]*[1]f|\n", 12.0, 2, 6)
+	fmt.Printf("%*d|%-*d|%.*f\n", 5, 1, 5, 2, 2, 3.14159)
+	fmt.Printf("%[1]d %[1]x %#[1]o\n", 64)
+
+	// Errors in the format, kept in a table to keep them from vet
+	for _, b := range badFormats {
+		fmt.Printf(b.format, b.args...)
+	}
+	fmt.Println()
+
+	// Print spacing rules
+	fmt.Print("a", "b", 1, 2, "c", 3.5, true, "\n")
+	s := fmt.Sprint(1, 2, "x", 3)
+	fmt.Println(s, len(s))
+	n, err := fmt.Printf("héllo %d\n", 5)
+	fmt.Println(n, err)
+
+	// Errorf wrapping
+	base := errors.New("base")
+	w := fmt.Errorf("wrap: %w", base)
+	fmt.Println(w, errors.Is(w, base), errors.Unwrap(w) == base)
+	other := errors.New("other")
+	w2 := fmt.Errorf("two: %w and %w", base, other)
+	fmt.Println(w2, errors.Is(w2, base), errors.Is(w2, other), errors.Unwrap(w2) == nil)
+	w3 := fmt.Errorf("code: %v", codeError{500})
+	fmt.Println(w3, errors.Unwrap(w3) == nil)
+	var ce codeError
+	w4 := fmt.Errorf("ctx: %w", codeError{418})
+	fmt.Println(errors.As(w4, &ce), ce.Code)
+	fmt.Println(fmt.Errorf("no args").Error(), fmt.Errorf(wrapFormat, 5))
+
+	// Fprintf and Sprintln
+	var sb strings.Builder
+	fmt.Fprintf(&sb, "%-6s|%6.2f|", "name", 1.234)
+	fmt.Fprint(&sb, "x", 1)
+	fmt.Fprintln(&sb, "end", 2)
+	fmt.Print(sb.String())
+	fmt.Print(fmt.Sprintln("line", 1, true))
+	fmt.Println(fmt.Sprintf("%6.2f%%", 12.345))
+	b := fmt.Appendf([]byte("pre:"), "%03d", 7)
+	fmt.Println(string(b))
+}
@@ -0,0 +1,524 @@
+// Generated file based on fmt_verbs.go
+// Updated when compliance tests are re-run, DO NOT EDIT!
+
+import * as $ from "@goscript/builtin/index.js";
+
+import * as errors from "@goscript/errors/index.js"
+
+import * as fmt from "@goscript/fmt/index.js"
+
+import * as strings from "@goscript/strings/index.js"
+
+export type Level = number;
+
+$.registerNamedType(
+  'main@github.com/aperturerobotics/goscript/compliance/tests/fmt_verbs.Level',
+  0,
+  [],
+  { kind: $.TypeKind.Basic, name: "int" }
+);
+
+export class inner {
+	public get Name(): string {
+		return this._fields.Name.value
+	}
+	public set Name(value: string) {
+		this._fields.Name.value = value
+	}
+
+	public get Tags(): $.Slice<string> {
+		return this._fields.Tags.value
+	}
+	public set Tags(value: $.Slice<string>) {
+		this._fields.Tags.value = value
+	}
+
+	public _fields: {
+		Name: $.VarRef<string>;
+		Tags: $.VarRef<$.Slice<string>>;
+	}
+
+	constructor(init?: Partial<{Name?: string, Tags?: $.Slice<string>}>) {
+		this._fields = {
+			Name: $.varRef(init?.Name ?? ""),
+			Tags: $.varRef(init?.Tags ?? null)
+		}
+	}
+
+	public clone(): inner {
+		const cloned = new inner()
+		cloned._fields = {
+			Name: $.varRef(this._fields.Name.value),
+			Tags: $.varRef(this._fields.Tags.value)
+		}
+		return cloned
+	}
+
+	// Register this type with the runtime type system
+	static __typeInfo = $.registerStructType(
+	  'main@github.com/aperturerobotics/goscript/compliance/tests/fmt_verbs.inner',
+	  new inner(),
+	  [],
+	  inner,
+	  [{ name: "Name", type: { kind: $.TypeKind.Basic, name: "string" } }, { name: "Tags", type: { kind: $.TypeKind.Slice, elemType: { kind: $.TypeKind.Basic, name: "string" } } }]
+	);
+}
+
+export class record {
+	public get ID(): number {
+		return this._fields.ID.value
+	}
+	public set ID(value: number) {
+		this._fields.ID.value = value
+	}
+
+	public get Score(): number {
+		return this._fields.Score.value
+	}
+	public set Score(value: number) {
+		this._fields.Score.value = value
+	}
+
+	public get Ok(): boolean {
+		return this._fields.Ok.value
+	}
+	public set Ok(value: boolean) {
+		this._fields.Ok.value = value
+	}
+
+	public get Inner(): inner {
+		return this._fields.Inner.value
+	}
+	public set Inner(value: inner) {
+		this._fields.Inner.value = value
+	}
+
+	public get Ptr(): inner | null {
+		return this._fields.Ptr.value
+	}
+	public set Ptr(value: inner | null) {
+		this._fields.Ptr.value = value
+	}
+
+	public get Items(): $.Slice<number> {
+		return this._fields.Items.value
+	}
+	public set Items(value: $.Slice<number>) {
+		this._fields.Items.value = value
+	}
+
+	public get Lookup(): Map<string, number> | null {
+		return this._fields.Lookup.value
+	}
+	public set Lookup(value: Map<string, number> | null) {
+		this._fields.Lookup.value = value
+	}
+
+	public get Err(): $.GoError {
+		return this._fields.Err.value
+	}
+	public set Err(value: $.GoError) {
+		this._fields.Err.value = value
+	}
+
+	public get Any(): null | any {
+		return this._fields.Any.value
+	}
+	public set Any(value: null | any) {
+		this._fields.Any.value = value
+	}
+
+	public get Lvl(): Level {
+		return this._fields.Lvl.value
+	}
+	public set Lvl(value: Level) {
+		this._fields.Lvl.value = value
+	}
+
+	public get hidden(): string {
+		return this._fields.hidden.value
+	}
+	public set hidden(value: string) {
+		this._fields.hidden.value = value
+	}
+
+	public _fields: {
+		ID: $.VarRef<number>;
+		Score: $.VarRef<number>;
+		Ok: $.VarRef<boolean>;
+		Inner: $.VarRef<inner>;
+		Ptr: $.VarRef<inner | null>;
+		Items: $.VarRef<$.Slice<number>>;
+		Lookup: $.VarRef<Map<string, number> | null>;
+		Err: $.VarRef<$.GoError>;
+		Any: $.VarRef<null | any>;
+		Lvl: $.VarRef<Level>;
+		hidden: $.VarRef<string>;
+	}
+
+	constructor(init?: Partial<{Any?: null | any, Err?: $.GoError, ID?: number, Inner?: inner, Items?: $.Slice<number>, Lookup?: Map<string, number> | null, Lvl?: Level, Ok?: boolean, Ptr?: inner | null, Score?: number, hidden?: string}>) {
+		this._fields = {
+			ID: $.varRef(init?.ID ?? 0),
+			Score: $.varRef(init?.Score ?? 0),
+			Ok: $.varRef(init?.Ok ?? false),
+			Inner: $.varRef(init?.Inner?.clone() ?? new inner()),
+			Ptr: $.varRef(init?.Ptr ?? null),
+			Items: $.varRef(init?.Items ?? null),
+			Lookup: $.varRef(init?.Lookup ?? null),
+			Err: $.varRef(init?.Err ?? null),
+			Any: $.varRef(init?.Any ?? null),
+			Lvl: $.varRef(init?.Lvl ?? 0 as Level),
+			hidden: $.varRef(init?.hidden ?? "")
+		}
+	}
+
+	public clone(): record {
+		const cloned = new record()
+		cloned._fields = {
+			ID: $.varRef(this._fields.ID.value),
+			Score: $.varRef(this._fields.Score.value),
+			Ok: $.varRef(this._fields.Ok.value),
+			Inner: $.varRef(this._fields.Inner.value?.clone() ?? null),
+			Ptr: $.varRef(this._fields.Ptr.value),
+			Items: $.varRef(this._fields.Items.value),
+			Lookup: $.varRef(this._fields.Lookup.value),
+			Err: $.varRef(this._fields.Err.value),
+			Any: $.varRef(this._fields.Any.value),
+			Lvl: $.varRef(this._fields.Lvl.value),
+			hidden: $.varRef(this._fields.hidden.value)
+		}
+		return cloned
+	}
+
+	// Register this type with the runtime type system
+	static __typeInfo = $.registerStructType(
+	  'main@github.com/aperturerobotics/goscript/compliance/tests/fmt_verbs.record',
+	  new record(),
+	  [],
+	  record,
+	  [{ name: "ID", type: { kind: $.TypeKind.Basic, name: "int" } }, { name: "Score", type: { kind: $.TypeKind.Basic, name: "float64" } }, { name: "Ok", type: { kind: $.TypeKind.Basic, name: "bool" } }, { name: "Inner", type: "main@github.com/aperturerobotics/goscript/compliance/tests/fmt_verbs.inner" }, { name: "Ptr", type: { kind: $.TypeKind.Pointer, elemType: "main@github.com/aperturerobotics/goscript/compliance/tests/fmt_verbs.inner" } }, { name: "Items", type: { kind: $.TypeKind.Slice, elemType: { kind: $.TypeKind.Basic, name: "int" } } }, { name: "Lookup", type: { kind: $.TypeKind.Map, keyType: { kind: $.TypeKind.Basic, name: "string" }, elemType: { kind: $.TypeKind.Basic, name: "int" } } }, { name: "Err", type: { kind: $.TypeKind.Interface, name: 'GoError', methods: [{ name: 'Error', args: [], returns: [{ type: { kind: $.TypeKind.Basic, name: 'string' } }] }] } }, { name: "Any", type: { kind: $.TypeKind.Interface, methods: [] } }, { name: "Lvl", type: "main@github.com/aperturerobotics/goscript/compliance/tests/fmt_verbs.Level" }, { name: "hidden", type: { kind: $.TypeKind.Basic, name: "string" } }]
+	);
+}
+
+export class celsius {
+	public get deg(): number {
+		return this._fields.deg.value
+	}
+	public set deg(value: number) {
+		this._fields.deg.value = value
+	}
+
+	public _fields: {
+		deg: $.VarRef<number>;
+	}
+
+	constructor(init?: Partial<{deg?: number}>) {
+		this._fields = {
+			deg: $.varRef(init?.deg ?? 0)
+		}
+	}
+
+	public clone(): celsius {
+		const cloned = new celsius()
+		cloned._fields = {
+			deg: $.varRef(this._fields.deg.value)
+		}
+		return cloned
+	}
+
+	public String(): string {
+		const c = this
+		return fmt.Sprintf("%.1f°C", c.deg)
+	}
+
+	// Register this type with the runtime type system
+	static __typeInfo = $.registerStructType(
+	  'main@github.com/aperturerobotics/goscript/compliance/tests/fmt_verbs.celsius',
+	  new celsius(),
+	  [{ name: "String", args: [], returns: [{ type: { kind: $.TypeKind.Basic, name: "string" } }] }],
+	  celsius,
+	  [{ name: "deg", type: { kind: $.TypeKind.Basic, name: "float64" } }]
+	);
+}
+
+export class codeError {
+	public get Code(): number {
+		return this._fields.Code.value
+	}
+	public set Code(value: number) {
+		this._fields.Code.value = value
+	}
+
+	public _fields: {
+		Code: $.VarRef<number>;
+	}
+
+	constructor(init?: Partial<{Code?: number}>) {
+		this._fields = {
+			Code: $.varRef(init?.Code ?? 0)
+		}
+	}
+
+	public clone(): codeError {
+		const cloned = new codeError()
+		cloned._fields = {
+			Code: $.varRef(this._fields.Code.value)
+		}
+		return cloned
+	}
+
+	public Error(): string {
+		const e = this
+		return fmt.Sprintf("code %d", e.Code)
+	}
+
+	// Register this type with the runtime type system
+	static __typeInfo = $.registerStructType(
+	  'main@github.com/aperturerobotics/goscript/compliance/tests/fmt_verbs.codeError',
+	  new codeError(),
+	  [{ name: "Error", args: [], returns: [{ type: { kind: $.TypeKind.Basic, name: "string" } }] }],
+	  codeError,
+	  [{ name: "Code", type: { kind: $.TypeKind.Basic, name: "int" } }]
+	);
+}
+
+export class money {
+	public get Cents(): number {
+		return this._fields.Cents.value
+	}
+	public set Cents(value: number) {
+		this._fields.Cents.value = value
+	}
+
+	public _fields: {
+		Cents: $.VarRef<number>;
+	}
+
+	constructor(init?: Partial<{Cents?: number}>) {
+		this._fields = {
+			Cents: $.varRef(init?.Cents ?? 0)
+		}
+	}
+
+	public clone(): money {
+		const cloned = new money()
+		cloned._fields = {
+			Cents: $.varRef(this._fields.Cents.value)
+		}
+		return cloned
+	}
+
+	public Format(f: fmt.State, verb: number): void {
+		const m = this
+		switch (verb) {
+			case 118:
+			case 115:
+				if (f!.Flag(43)) {
+					fmt.Fprintf(f, "USD ")
+				}
+				fmt.Fprintf(f, "$%d.%02d", Math.trunc(m.Cents / 100), (m.Cents % 100))
+				break
+			default:
+				fmt.Fprintf(f, "%%!%c(money)", verb)
+				break
+		}
+		{
+			let [w, ok] = f!.Width()
+			if (ok) {
+				fmt.Fprintf(f, "[w=%d]", w)
+			}
+		}
+	}
+
+	// Register this type with the runtime type system
+	static __typeInfo = $.registerStructType(
+	  'main@github.com/aperturerobotics/goscript/compliance/tests/fmt_verbs.money',
+	  new money(),
+	  [{ name: "Format", args: [{ name: "f", type: "fmt.State" }, { name: "verb", type: { kind: $.TypeKind.Basic, name: "number" } }], returns: [] }],
+	  money,
+	  [{ name: "Cents", type: { kind: $.TypeKind.Basic, name: "int" } }]
+	);
+}
+
+export class token {
+	public get kind(): string {
+		return this._fields.kind.value
+	}
+	public set kind(value: string) {
+		this._fields.kind.value = value
+	}
+
+	public _fields: {
+		kind: $.VarRef<string>;
+	}
+
+	constructor(init?: Partial<{kind?: string}>) {
+		this._fields = {
+			kind: $.varRef(init?.kind ?? "")
+		}
+	}
+
+	public clone(): token {
+		const cloned = new token()
+		cloned._fields = {
+			kind: $.varRef(this._fields.kind.value)
+		}
+		return cloned
+	}
+
+	public GoString(): string {
+		const t = this
+		return "token(" + t.kind + ")"
+	}
+
+	// Register this type with the runtime type system
+	static __typeInfo = $.registerStructType(
+	  'main@github.com/aperturerobotics/goscript/compliance/tests/fmt_verbs.token',
+	  new token(),
+	  [{ name: "GoString", args: [], returns: [{ type: { kind: $.TypeKind.Basic, name: "string" } }] }],
+	  token,
+	  [{ name: "kind", type: { kind: $.TypeKind.Basic, name: "string" } }]
+	);
+}
+
+export class broken {
+	public _fields: {
+	}
+
+	constructor(init?: Partial<{}>) {
+		this._fields = {}
+	}
+
+	public clone(): broken {
+		const cloned = new broken()
+		cloned._fields = {
+		}
+		return cloned
+	}
+
+	public String(): string {
+		$.panic("boom")
+	}
+
+	// Register this type with the runtime type system
+	static __typeInfo = $.registerStructType(
+	  'main@github.com/aperturerobotics/goscript/compliance/tests/fmt_verbs.broken',
+	  new broken(),
+	  [{ name: "String", args: [], returns: [{ type: { kind: $.TypeKind.Basic, name: "string" } }] }],
+	  broken,
+	  []
+	);
+}
+
+let badFormats = $.arrayToSlice<{ format?: string; args?: $.Slice<null | any> }>([{args: $.arrayToSlice<null | any>([$.markStructValue(new celsius({deg: 21.5}))]), format: "%d\n"}, {args: $.arrayToSlice<null | any>(["str"]), format: "%d\n"}, {args: $.arrayToSlice<null | any>([42]), format: "%s\n"}, {args: $.arrayToSlice<null | any>([1]), format: "%d %d\n"}, {args: $.arrayToSlice<null | any>([1, "extra", 2.5]), format: "%d\n"}, {args: null, format: "%!\n"}, {args: $.arrayToSlice<null | any>([3]), format: "%z\n"}, {args: $.arrayToSlice<null | any>([1]), format: "%[5]d\n"}, {args: $.arrayToSlice<null | any>([1]), format: "%[x]d\n"}, {args: $.arrayToSlice<null | any>(["w", 1]), format: "%*d\n"}, {args: $.arrayToSlice<null | any>(["p", 1]), format: "%.*d\n"}, {args: $.arrayToSlice<null | any>([1]), format: "%t\n"}, {args: $.arrayToSlice<null | any>([null]), format: "%d\n"}, {args: $.arrayToSlice<null | any>([null, null]), format: "%v %s\n"}, {args: null, format: "trailing %"}])
+
+let wrapFormat: string = "%w"
+
+export async function main(): Promise<void> {
+	// Width, precision and flags on integers
+	fmt.Printf("[%5d] [%-5d] [%05d] [%+d] [% d] [%x] [%X] [%#x] [%o] [%#o] [%O] [%b]\n", 42, 42, 42, 42, 42, 255, 255, 255, 8, 8, 8, 5)
+	fmt.Printf("[%08.3d] [%.0d] [%6.2d] [%-08d] [%+05d]\n", 7, 0, 3, -12, 12)
+	fmt.Printf("[%c] [%q] [%U] [%#U] [%x]\n", 71, 120, 0x1F600, 233, -255)
+	let big: number = -1099511627776
+	let ubig: number = 1099511627776
+	fmt.Printf("[%d] [%x] [%v]\n", big, big, ubig)
+
+	// Floats
+	fmt.Printf("[%f] [%.2f] [%8.3f] [%-8.2f] [%08.3f] [%+.1f]\n", 3.14159, 3.14159, 3.14159, 2.5, -3.14159, 2.0)
+	fmt.Printf("[%e] [%E] [%.3e] [%g] [%G] [%.3g] [%g]\n", 123456.789, 0.000123, 1e21, 1e21, 1e-7, 3.14159, 100000.0)
+	fmt.Printf("[%v] [%v] [%v] [%#g] [%#.3f] [%x]\n", 2.0, 1e-6, 1e21, 1.0, 1.0, 1.5)
+	fmt.Printf("[%6.2f] [%06.2f] [%+v] [% .2f]\n", -1.5, -1.5, 1.5, 1.5)
+
+	// Strings and bytes
+	fmt.Printf("[%s] [%10s] [%-10s] [%.2s] [%q] [%#q] [%+q] [%x] [% x] [%X]\n", "hi", "right", "left", "truncate", "a\"b\n", "raw", "héllo", "hi", "hi", "hi")
+	fmt.Printf("[%s] [%v] [%x] [%q] [%d]\n", $.stringToBytes("abc"), $.stringToBytes("abc"), $.stringToBytes("abc"), $.stringToBytes("abc"), $.stringToBytes("ab"))
+	fmt.Printf("[%5.1s] [%-4s|] [%08s]\n", "héllo", "é", "pad")
+	let digest = $.arrayToSlice<number>([0xde, 0xad, 0xbe, 0xef])
+	fmt.Printf("[%x] [%X] [% x] [%v] [%d] [%T]\n", $.markByteArray(digest), $.markByteArray(digest), $.markByteArray(digest), $.markByteArray(digest), $.markByteArray(digest), $.markByteArray(digest))
+	fmt.Println(fmt.Sprintf("%x", $.markByteArray($.arrayToSlice<number>([1, 255]))), fmt.Sprintf("%#v", $.markByteArray($.arrayToSlice<number>([1, 2]))))
+
+	// Booleans
+	fmt.Printf("[%t] [%v] [%6t]\n", true, false, true)
+
+	// Composite values
+	let r = new record({Any: 3, Err: errors.New("bad"), ID: 7, Inner: new inner({Name: "in", Tags: $.arrayToSlice<string>(["a", "b"])}), Items: $.arrayToSlice<number>([1, 2, 3]), Lookup: new Map([["z", 26], ["a", 1], ["m", 13]]), Lvl: 2, Ok: true, Score: 9.5, hidden: "h"})
+	fmt.Printf("%v\n", $.markStructValue(r.clone()))
+	fmt.Printf("%+v\n", $.markStructValue(r.clone()))
+	fmt.Printf("%#v\n", $.markStructValue(new inner({Name: "x", Tags: $.arrayToSlice<string>(["t"])})))
+	fmt.Printf("%#v\n", $.markStructValue(new inner({Name: "y"})))
+	let empty: record = new record()
+	fmt.Printf("%v\n", $.markStructValue(empty.clone()))
+	fmt.Printf("%+v\n", $.markStructValue(empty.clone()))
+	fmt.Println($.arrayToSlice<string>(["a", "b"]), new Map([[3, "c"], [1, "a"], [2, "b"]]), $.arrayToSlice<boolean>([true, false]))
+	fmt.Printf("%#v %#v\n", $.arrayToSlice<string>(["a"]), new Map([["b", 2], ["a", 1]]))
+	fmt.Printf("%v %d %x %s\n", $.arrayToSlice<number>([10, 11]), $.arrayToSlice<number>([10, 11]), $.arrayToSlice<number>([10, 11]), $.arrayToSlice<string>(["p", "q"]))
+	let nums = $.varRef($.arrayToSlice<number>([1]))
+	let lookup = $.varRef(new Map([["k", 1]]))
+	fmt.Printf("%v %+v\n", nums, lookup)
+	fmt.Printf("%#v %#v %#v %#v\n", 42, "str", true, 1.5)
+	fmt.Printf("%#v\n", $.markStructValue({A: 1, B: "x"}))
+
+	// Type names
+	fmt.Printf("%T %T %T %T %T %T %T\n", 1, "s", 2.5, true, $.arrayToSlice<number>([1]), new Map([["k", true]]), $.markStructValue(r.clone()))
+	fmt.Printf("%T %T\n", $.markStructValue(new inner({})), $.stringToBytes("x"))
+
+	// Methods
+	let c = new celsius({deg: 21.5})
+	fmt.Println($.markStructValue(c.clone()))
+	fmt.Printf("%v|%s|%q|%10s\n", $.markStructValue(c.clone()), $.markStructValue(c.clone()), $.markStructValue(c.clone()), $.markStructValue(c.clone()))
+	let e = new codeError({Code: 404})
+	fmt.Println($.markStructValue(e.clone()))
+	fmt.Printf("%v|%+v|%s\n", $.markStructValue(e.clone()), $.markStructValue(e.clone()), $.markStructValue(e.clone()))
+	fmt.Println($.arrayToSlice<celsius>([new celsius({deg: 1}), new celsius({deg: 2})]))
+	fmt.Printf("%v|%+v|%s|%8v|%d\n", $.markStructValue(new money({Cents: 1234})), $.markStructValue(new money({Cents: 5})), $.markStructValue(new money({Cents: 99})), $.markStructValue(new money({Cents: 100})), $.markStructValue(new money({Cents: 1})))
+	fmt.Printf("%v|%#v\n", $.markStructValue(new token({kind: "ident"})), $.markStructValue(new token({kind: "ident"})))
+	fmt.Printf("%v|%s\n", $.markStructValue(new broken({})), $.markStructValue(new broken({})))
+
+	// Argument indexes and star width/precision
+	fmt.Printf("%[2]d %[1]d %d\n", 10, 20)
+	fmt.Printf("%[3]*.[2]*[1]f|\n", 12.0, 2, 6)
+	fmt.Printf("%*d|%-*d|%.*f\n", 5, 1, 5, 2, 2, 3.14159)
+	fmt.Printf("%[1]d %[1]x %#[1]o\n", 64)
+
+	// Errors in the format, kept in a table to keep them from vet
+	for (let _i = 0; _i < $.len(badFormats); _i++) {
+		const b = badFormats![_i]
+		{
+			fmt.Printf(b.format, ...(b.args ?? []))
+		}
+	}
+	fmt.Println()
+
+	// Print spacing rules
+	fmt.Print("a", "b", 1, 2, "c", 3.5, true, "\n")
+	let s = fmt.Sprint(1, 2, "x", 3)
+	fmt.Println(s, $.len(s))
+	let [n, err] = fmt.Printf("héllo %d\n", 5)
+	fmt.Println(n, err)
+
+	// Errorf wrapping
+	let base = errors.New("base")
+	let w = fmt.Errorf("wrap: %w", base)
+	fmt.Println(w, errors.Is(w, base), errors.Unwrap(w) == base)
+	let other = errors.New("other")
+	let w2 = fmt.Errorf("two: %w and %w", base, other)
+	fmt.Println(w2, errors.Is(w2, base), errors.Is(w2, other), errors.Unwrap(w2) == null)
+	let w3 = fmt.Errorf("code: %v", $.markStructValue(new codeError({Code: 500})))
+	fmt.Println(w3, errors.Unwrap(w3) == null)
+	let ce: codeError = new codeError({})
+	let w4 = fmt.Errorf("ctx: %w", $.markStructValue(new codeError({Code: 418})))
+	fmt.Println(errors.As(w4, ce, { kind: $.TypeKind.Pointer, elemType: "main@github.com/aperturerobotics/goscript/compliance/tests/fmt_verbs.codeError" }), ce.Code)
+	fmt.Println(fmt.Errorf("no args")!.Error(), fmt.Errorf(wrapFormat, 5))
+
+	// Fprintf and Sprintln
+	let sb: strings.Builder = new strings.Builder()
+	fmt.Fprintf(sb, "%-6s|%6.2f|", "name", 1.234)
+	fmt.Fprint(sb, "x", 1)
+	fmt.Fprintln(sb, "end", 2)
+	fmt.Print(sb.String())
+	fmt.Print(fmt.Sprintln("line", 1, true))
+	fmt.Println(fmt.Sprintf("%6.2f%%", 12.345))
+	let b = fmt.Appendf($.stringToBytes("pre:"), "%03d", 7)
+	fmt.Println($.bytesToString(b))
+}
+
@@ -0,0 +1 @@
+export type { Level } from "./fmt_verbs.gs.js"
@@ -34,13 +34,9 @@ export async function main(): Promise<void> {
 	let str = "go"
 
 	// Note: c will be a rune (int32)
-	{
-		const _runes = $.stringToRunes(str)
-		for (let i = 0; i < _runes.length; i++) {
-			const c = _runes[i]
-			{
-				console.log("index:", i, "value:", c) // Note: c will be a rune (int32)
-			}
+	for (const [i, c] of $.rangeString(str)) {
+		{
+			console.log("index:", i, "value:", c) // Note: c will be a rune (int32)
 		}
 	}
 
@@ -62,12 +58,9 @@ export async function main(): Promise<void> {
 
 	// Test ranging over a string without key or value
 	console.log("Ranging over string (no key/value):")
-	{
-		const _runes = $.stringToRunes(str)
-		for (let i = 0; i < _runes.length; i++) {
-			{
-				console.log("Iterating string")
-			}
+	for (const [_i, _r] of $.rangeString(str)) {
+		{
+			console.log("Iterating string")
 		}
 	}
 }
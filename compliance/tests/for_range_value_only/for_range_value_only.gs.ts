@@ -20,7 +20,7 @@ export async function main(): Promise<void> {
 	for (let _i = 0; _i < $.len(arr); _i++) {
 		const val = arr![_i]
 		{
-			concat += val
+			concat = $.concatString(concat, val)
 			console.log(val)
 		}
 	}
@@ -12,9 +12,9 @@ export type Filesystem = null | {
 }
 
 $.registerInterfaceType(
-  'Filesystem',
+  'main@github.com/aperturerobotics/goscript/compliance/tests/function_call_variable_shadowing.Filesystem',
   null, // Zero value for interface is null
-  [{ name: "Lstat", args: [{ name: "filename", type: { kind: $.TypeKind.Basic, name: "string" } }], returns: [{ type: { kind: $.TypeKind.Interface, methods: [{ name: "IsDir", args: [], returns: [{ type: { kind: $.TypeKind.Basic, name: "boolean" } }] }, { name: "ModTime", args: [], returns: [{ type: "Time" }] }, { name: "Mode", args: [], returns: [{ type: "FileMode" }] }, { name: "Name", args: [], returns: [{ type: { kind: $.TypeKind.Basic, name: "string" } }] }, { name: "Size", args: [], returns: [{ type: { kind: $.TypeKind.Basic, name: "number" } }] }, { name: "Sys", args: [], returns: [{ type: { kind: $.TypeKind.Interface, methods: [] } }] }] } }, { type: { kind: $.TypeKind.Interface, name: 'GoError', methods: [{ name: 'Error', args: [], returns: [{ type: { kind: $.TypeKind.Basic, name: 'string' } }] }] } }] }]
+  [{ name: "Lstat", args: [{ name: "filename", type: { kind: $.TypeKind.Basic, name: "string" } }], returns: [{ type: "io/fs.FileInfo" }, { type: { kind: $.TypeKind.Interface, name: 'GoError', methods: [{ name: 'Error', args: [], returns: [{ type: { kind: $.TypeKind.Basic, name: 'string' } }] }] } }] }]
 );
 
 export class MockFilesystem {
@@ -38,11 +38,11 @@ export class MockFilesystem {
 
 	// Register this type with the runtime type system
 	static __typeInfo = $.registerStructType(
-	  'MockFilesystem',
+	  'main@github.com/aperturerobotics/goscript/compliance/tests/function_call_variable_shadowing.MockFilesystem',
 	  new MockFilesystem(),
-	  [{ name: "Lstat", args: [{ name: "filename", type: { kind: $.TypeKind.Basic, name: "string" } }], returns: [{ type: { kind: $.TypeKind.Interface, methods: [{ name: "IsDir", args: [], returns: [{ type: { kind: $.TypeKind.Basic, name: "boolean" } }] }, { name: "ModTime", args: [], returns: [{ type: "Time" }] }, { name: "Mode", args: [], returns: [{ type: "FileMode" }] }, { name: "Name", args: [], returns: [{ type: { kind: $.TypeKind.Basic, name: "string" } }] }, { name: "Size", args: [], returns: [{ type: { kind: $.TypeKind.Basic, name: "number" } }] }, { name: "Sys", args: [], returns: [{ type: { kind: $.TypeKind.Interface, methods: [] } }] }] } }, { type: { kind: $.TypeKind.Interface, name: 'GoError', methods: [{ name: 'Error', args: [], returns: [{ type: { kind: $.TypeKind.Basic, name: 'string' } }] }] } }] }],
+	  [{ name: "Lstat", args: [{ name: "filename", type: { kind: $.TypeKind.Basic, name: "string" } }], returns: [{ type: "io/fs.FileInfo" }, { type: { kind: $.TypeKind.Interface, name: 'GoError', methods: [{ name: 'Error', args: [], returns: [{ type: { kind: $.TypeKind.Basic, name: 'string' } }] }] } }] }],
 	  MockFilesystem,
-	  {}
+	  []
 	);
 }
 
@@ -136,7 +136,7 @@ export async function main(): Promise<void> {
 	}
 
 	// Test the shadowing scenario
-	let err = walkWithShadowing(fs, "/test", null, walkFunc)
+	let err = walkWithShadowing($.markStructValue(fs.clone()), "/test", null, walkFunc)
 	if (err != null) {
 		console.log("Error:", err!.Error())
 	}
@@ -5,18 +5,46 @@ import * as $ from "@goscript/builtin/index.js";
 
 export type Func1 = ((a: number, b: string) => [boolean, $.GoError]) | null;
 
+$.registerNamedType(
+  'main@github.com/aperturerobotics/goscript/compliance/tests/function_signature_type.Func1',
+  null,
+  [],
+  { kind: $.TypeKind.Function, params: [{ kind: $.TypeKind.Basic, name: "number" }, { kind: $.TypeKind.Basic, name: "string" }], results: [{ kind: $.TypeKind.Basic, name: "boolean" }, { kind: $.TypeKind.Interface, name: 'GoError', methods: [{ name: 'Error', args: [], returns: [{ type: { kind: $.TypeKind.Basic, name: 'string' } }] }] }] }
+);
+
 let fn1: Func1 | null = null
 
 export type Func2 = ((p0: number, p1: string) => boolean) | null;
 
+$.registerNamedType(
+  'main@github.com/aperturerobotics/goscript/compliance/tests/function_signature_type.Func2',
+  null,
+  [],
+  { kind: $.TypeKind.Function, params: [{ kind: $.TypeKind.Basic, name: "number" }, { kind: $.TypeKind.Basic, name: "string" }], results: [{ kind: $.TypeKind.Basic, name: "boolean" }] }
+);
+
 let fn2: Func2 | null = null
 
 export type Func3 = (() => void) | null;
 
+$.registerNamedType(
+  'main@github.com/aperturerobotics/goscript/compliance/tests/function_signature_type.Func3',
+  null,
+  [],
+  { kind: $.TypeKind.Function, params: [], results: [] }
+);
+
 let fn3: Func3 | null = null
 
 export type Func4 = ((a: number, ...b: string[]) => void) | null;
 
+$.registerNamedType(
+  'main@github.com/aperturerobotics/goscript/compliance/tests/function_signature_type.Func4',
+  null,
+  [],
+  { kind: $.TypeKind.Function, params: [{ kind: $.TypeKind.Basic, name: "number" }, { kind: $.TypeKind.Slice, elemType: { kind: $.TypeKind.Basic, name: "string" } }], results: [] }
+);
+
 let fn4: Func4 | null = null
 
 export class MyError {
@@ -52,11 +80,11 @@ export class MyError {
 
 	// Register this type with the runtime type system
 	static __typeInfo = $.registerStructType(
-	  'MyError',
+	  'main@github.com/aperturerobotics/goscript/compliance/tests/function_signature_type.MyError',
 	  new MyError(),
 	  [{ name: "Error", args: [], returns: [{ type: { kind: $.TypeKind.Basic, name: "string" } }] }],
 	  MyError,
-	  {"s": { kind: $.TypeKind.Basic, name: "string" }}
+	  [{ name: "s", type: { kind: $.TypeKind.Basic, name: "string" } }]
 	);
 }
 
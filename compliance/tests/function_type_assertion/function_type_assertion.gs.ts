@@ -5,8 +5,22 @@ import * as $ from "@goscript/builtin/index.js";
 
 export type Greeter = ((name: string) => string) | null;
 
+$.registerNamedType(
+  'main@github.com/aperturerobotics/goscript/compliance/tests/function_type_assertion.Greeter',
+  null,
+  [],
+  { kind: $.TypeKind.Function, params: [{ kind: $.TypeKind.Basic, name: "string" }], results: [{ kind: $.TypeKind.Basic, name: "string" }] }
+);
+
 export type Adder = ((a: number, b: number) => number) | null;
 
+$.registerNamedType(
+  'main@github.com/aperturerobotics/goscript/compliance/tests/function_type_assertion.Adder',
+  null,
+  [],
+  { kind: $.TypeKind.Function, params: [{ kind: $.TypeKind.Basic, name: "number" }, { kind: $.TypeKind.Basic, name: "number" }], results: [{ kind: $.TypeKind.Basic, name: "number" }] }
+);
+
 export function greet(name: string): string {
 	return "Hello, " + name
 }
@@ -16,11 +30,11 @@ export function add(a: number, b: number): number {
 }
 
 export function getGreeter(): null | any {
-	return Object.assign(greet, { __goTypeName: 'Greeter' })
+	return Object.assign(greet, { __goTypeName: 'main@github.com/aperturerobotics/goscript/compliance/tests/function_type_assertion.Greeter' })
 }
 
 export function getAdder(): null | any {
-	return Object.assign(add, { __goTypeName: 'Adder' })
+	return Object.assign(add, { __goTypeName: 'main@github.com/aperturerobotics/goscript/compliance/tests/function_type_assertion.Adder' })
 }
 
 export class FuncContainer {
@@ -51,18 +65,18 @@ export class FuncContainer {
 
 	// Register this type with the runtime type system
 	static __typeInfo = $.registerStructType(
-	  'FuncContainer',
+	  'main@github.com/aperturerobotics/goscript/compliance/tests/function_type_assertion.FuncContainer',
 	  new FuncContainer(),
 	  [],
 	  FuncContainer,
-	  {"myFunc": { kind: $.TypeKind.Interface, methods: [] }}
+	  [{ name: "myFunc", type: { kind: $.TypeKind.Interface, methods: [] } }]
 	);
 }
 
 export async function main(): Promise<void> {
 	// 1. Simple function type assertion
-	let i: null | any = Object.assign(greet, { __goTypeName: 'Greeter' })
-	let { value: fn, ok: ok } = $.typeAssert<Greeter | null>(i, {kind: $.TypeKind.Function, name: 'Greeter', params: [{ kind: $.TypeKind.Basic, name: "string" }], results: [{ kind: $.TypeKind.Basic, name: "string" }]})
+	let i: null | any = Object.assign(greet, { __goTypeName: 'main@github.com/aperturerobotics/goscript/compliance/tests/function_type_assertion.Greeter' })
+	let { value: fn, ok: ok } = $.typeAssert<Greeter | null>(i, {kind: $.TypeKind.Function, name: 'main@github.com/aperturerobotics/goscript/compliance/tests/function_type_assertion.Greeter', params: [{ kind: $.TypeKind.Basic, name: "string" }], results: [{ kind: $.TypeKind.Basic, name: "string" }]})
 	if (ok) {
 		console.log(fn!("World"))
 	}
@@ -70,9 +84,9 @@ export async function main(): Promise<void> {
 		console.log("Simple assertion failed")
 	}
 
-	let j: null | any = Object.assign(add, { __goTypeName: 'Adder' })
+	let j: null | any = Object.assign(add, { __goTypeName: 'main@github.com/aperturerobotics/goscript/compliance/tests/function_type_assertion.Adder' })
 	let addFn: Adder | null
-	({ value: addFn, ok: ok } = $.typeAssert<Adder | null>(j, {kind: $.TypeKind.Function, name: 'Adder', params: [{ kind: $.TypeKind.Basic, name: "number" }, { kind: $.TypeKind.Basic, name: "number" }], results: [{ kind: $.TypeKind.Basic, name: "number" }]}))
+	({ value: addFn, ok: ok } = $.typeAssert<Adder | null>(j, {kind: $.TypeKind.Function, name: 'main@github.com/aperturerobotics/goscript/compliance/tests/function_type_assertion.Adder', params: [{ kind: $.TypeKind.Basic, name: "number" }, { kind: $.TypeKind.Basic, name: "number" }], results: [{ kind: $.TypeKind.Basic, name: "number" }]}))
 	if (ok) {
 		console.log(addFn!(5, 3))
 	}
@@ -83,7 +97,7 @@ export async function main(): Promise<void> {
 	// 2. Type assertion of a function returned from another function
 	let returnedFn = getGreeter()
 	let greetFn: Greeter | null
-	({ value: greetFn, ok: ok } = $.typeAssert<Greeter | null>(returnedFn, {kind: $.TypeKind.Function, name: 'Greeter', params: [{ kind: $.TypeKind.Basic, name: "string" }], results: [{ kind: $.TypeKind.Basic, name: "string" }]}))
+	({ value: greetFn, ok: ok } = $.typeAssert<Greeter | null>(returnedFn, {kind: $.TypeKind.Function, name: 'main@github.com/aperturerobotics/goscript/compliance/tests/function_type_assertion.Greeter', params: [{ kind: $.TypeKind.Basic, name: "string" }], results: [{ kind: $.TypeKind.Basic, name: "string" }]}))
 	if (ok) {
 		console.log(greetFn!("Gopher"))
 	}
@@ -93,7 +107,7 @@ export async function main(): Promise<void> {
 
 	let returnedAdder = getAdder()
 	let addFnFromFunc: Adder | null
-	({ value: addFnFromFunc, ok: ok } = $.typeAssert<Adder | null>(returnedAdder, {kind: $.TypeKind.Function, name: 'Adder', params: [{ kind: $.TypeKind.Basic, name: "number" }, { kind: $.TypeKind.Basic, name: "number" }], results: [{ kind: $.TypeKind.Basic, name: "number" }]}))
+	({ value: addFnFromFunc, ok: ok } = $.typeAssert<Adder | null>(returnedAdder, {kind: $.TypeKind.Function, name: 'main@github.com/aperturerobotics/goscript/compliance/tests/function_type_assertion.Adder', params: [{ kind: $.TypeKind.Basic, name: "number" }, { kind: $.TypeKind.Basic, name: "number" }], results: [{ kind: $.TypeKind.Basic, name: "number" }]}))
 	if (ok) {
 		console.log(addFnFromFunc!(10, 20))
 	}
@@ -102,9 +116,9 @@ export async function main(): Promise<void> {
 	}
 
 	// 3. Type assertion of a function in a struct field
-	let container = new FuncContainer({myFunc: Object.assign(greet, { __goTypeName: 'Greeter' })})
+	let container = new FuncContainer({myFunc: Object.assign(greet, { __goTypeName: 'main@github.com/aperturerobotics/goscript/compliance/tests/function_type_assertion.Greeter' })})
 	let structFn: Greeter | null
-	({ value: structFn, ok: ok } = $.typeAssert<Greeter | null>(container.myFunc, {kind: $.TypeKind.Function, name: 'Greeter', params: [{ kind: $.TypeKind.Basic, name: "string" }], results: [{ kind: $.TypeKind.Basic, name: "string" }]}))
+	({ value: structFn, ok: ok } = $.typeAssert<Greeter | null>(container.myFunc, {kind: $.TypeKind.Function, name: 'main@github.com/aperturerobotics/goscript/compliance/tests/function_type_assertion.Greeter', params: [{ kind: $.TypeKind.Basic, name: "string" }], results: [{ kind: $.TypeKind.Basic, name: "string" }]}))
 	if (ok) {
 		console.log(structFn!("Struct"))
 	}
@@ -112,9 +126,9 @@ export async function main(): Promise<void> {
 		console.log("Struct function assertion failed")
 	}
 
-	let adderContainer = new FuncContainer({myFunc: Object.assign(add, { __goTypeName: 'Adder' })})
+	let adderContainer = new FuncContainer({myFunc: Object.assign(add, { __goTypeName: 'main@github.com/aperturerobotics/goscript/compliance/tests/function_type_assertion.Adder' })})
 	let structAdderFn: Adder | null
-	({ value: structAdderFn, ok: ok } = $.typeAssert<Adder | null>(adderContainer.myFunc, {kind: $.TypeKind.Function, name: 'Adder', params: [{ kind: $.TypeKind.Basic, name: "number" }, { kind: $.TypeKind.Basic, name: "number" }], results: [{ kind: $.TypeKind.Basic, name: "number" }]}))
+	({ value: structAdderFn, ok: ok } = $.typeAssert<Adder | null>(adderContainer.myFunc, {kind: $.TypeKind.Function, name: 'main@github.com/aperturerobotics/goscript/compliance/tests/function_type_assertion.Adder', params: [{ kind: $.TypeKind.Basic, name: "number" }, { kind: $.TypeKind.Basic, name: "number" }], results: [{ kind: $.TypeKind.Basic, name: "number" }]}))
 	if (ok) {
 		console.log(structAdderFn!(7, 8))
 	}
@@ -124,11 +138,11 @@ export async function main(): Promise<void> {
 
 	// 4. Type assertion of a function in a map
 	let funcMap = $.makeMap<string, null | any>()
-	$.mapSet(funcMap, "greeter", Object.assign(greet, { __goTypeName: 'Greeter' }))
-	$.mapSet(funcMap, "adder", Object.assign(add, { __goTypeName: 'Adder' }))
+	$.mapSet(funcMap, "greeter", Object.assign(greet, { __goTypeName: 'main@github.com/aperturerobotics/goscript/compliance/tests/function_type_assertion.Greeter' }))
+	$.mapSet(funcMap, "adder", Object.assign(add, { __goTypeName: 'main@github.com/aperturerobotics/goscript/compliance/tests/function_type_assertion.Adder' }))
 
 	let mapFn: Greeter | null
-	({ value: mapFn, ok: ok } = $.typeAssert<Greeter | null>($.mapGet(funcMap, "greeter", null)[0], {kind: $.TypeKind.Function, name: 'Greeter', params: [{ kind: $.TypeKind.Basic, name: "string" }], results: [{ kind: $.TypeKind.Basic, name: "string" }]}))
+	({ value: mapFn, ok: ok } = $.typeAssert<Greeter | null>($.mapGet(funcMap, "greeter", null)[0], {kind: $.TypeKind.Function, name: 'main@github.com/aperturerobotics/goscript/compliance/tests/function_type_assertion.Greeter', params: [{ kind: $.TypeKind.Basic, name: "string" }], results: [{ kind: $.TypeKind.Basic, name: "string" }]}))
 	if (ok) {
 		console.log(mapFn!("Map"))
 	}
@@ -137,7 +151,7 @@ export async function main(): Promise<void> {
 	}
 
 	let mapAdderFn: Adder | null
-	({ value: mapAdderFn, ok: ok } = $.typeAssert<Adder | null>($.mapGet(funcMap, "adder", null)[0], {kind: $.TypeKind.Function, name: 'Adder', params: [{ kind: $.TypeKind.Basic, name: "number" }, { kind: $.TypeKind.Basic, name: "number" }], results: [{ kind: $.TypeKind.Basic, name: "number" }]}))
+	({ value: mapAdderFn, ok: ok } = $.typeAssert<Adder | null>($.mapGet(funcMap, "adder", null)[0], {kind: $.TypeKind.Function, name: 'main@github.com/aperturerobotics/goscript/compliance/tests/function_type_assertion.Adder', params: [{ kind: $.TypeKind.Basic, name: "number" }, { kind: $.TypeKind.Basic, name: "number" }], results: [{ kind: $.TypeKind.Basic, name: "number" }]}))
 	if (ok) {
 		console.log(mapAdderFn!(1, 2))
 	}
@@ -147,11 +161,11 @@ export async function main(): Promise<void> {
 
 	// 5. Type assertion of a function in a slice
 	let funcSlice = $.makeSlice<null | any>(2)
-	funcSlice![0] = Object.assign(greet, { __goTypeName: 'Greeter' })
-	funcSlice![1] = Object.assign(add, { __goTypeName: 'Adder' })
+	funcSlice![0] = Object.assign(greet, { __goTypeName: 'main@github.com/aperturerobotics/goscript/compliance/tests/function_type_assertion.Greeter' })
+	funcSlice![1] = Object.assign(add, { __goTypeName: 'main@github.com/aperturerobotics/goscript/compliance/tests/function_type_assertion.Adder' })
 
 	let sliceFn: Greeter | null
-	({ value: sliceFn, ok: ok } = $.typeAssert<Greeter | null>(funcSlice![0], {kind: $.TypeKind.Function, name: 'Greeter', params: [{ kind: $.TypeKind.Basic, name: "string" }], results: [{ kind: $.TypeKind.Basic, name: "string" }]}))
+	({ value: sliceFn, ok: ok } = $.typeAssert<Greeter | null>(funcSlice![0], {kind: $.TypeKind.Function, name: 'main@github.com/aperturerobotics/goscript/compliance/tests/function_type_assertion.Greeter', params: [{ kind: $.TypeKind.Basic, name: "string" }], results: [{ kind: $.TypeKind.Basic, name: "string" }]}))
 	if (ok) {
 		console.log(sliceFn!("Slice"))
 	}
@@ -159,7 +173,7 @@ export async function main(): Promise<void> {
 		console.log("Slice function assertion failed")
 	}
 	let sliceAdderFn: Adder | null
-	({ value: sliceAdderFn, ok: ok } = $.typeAssert<Adder | null>(funcSlice![1], {kind: $.TypeKind.Function, name: 'Adder', params: [{ kind: $.TypeKind.Basic, name: "number" }, { kind: $.TypeKind.Basic, name: "number" }], results: [{ kind: $.TypeKind.Basic, name: "number" }]}))
+	({ value: sliceAdderFn, ok: ok } = $.typeAssert<Adder | null>(funcSlice![1], {kind: $.TypeKind.Function, name: 'main@github.com/aperturerobotics/goscript/compliance/tests/function_type_assertion.Adder', params: [{ kind: $.TypeKind.Basic, name: "number" }, { kind: $.TypeKind.Basic, name: "number" }], results: [{ kind: $.TypeKind.Basic, name: "number" }]}))
 	if (ok) {
 		console.log(sliceAdderFn!(9, 9))
 	}
@@ -168,15 +182,15 @@ export async function main(): Promise<void> {
 	}
 
 	// 6. Type assertion with ok variable (successful and failing)
-	let k: null | any = Object.assign(greet, { __goTypeName: 'Greeter' })
-	let { ok: ok1 } = $.typeAssert<Greeter | null>(k, {kind: $.TypeKind.Function, name: 'Greeter', params: [{ kind: $.TypeKind.Basic, name: "string" }], results: [{ kind: $.TypeKind.Basic, name: "string" }]})
+	let k: null | any = Object.assign(greet, { __goTypeName: 'main@github.com/aperturerobotics/goscript/compliance/tests/function_type_assertion.Greeter' })
+	let { ok: ok1 } = $.typeAssert<Greeter | null>(k, {kind: $.TypeKind.Function, name: 'main@github.com/aperturerobotics/goscript/compliance/tests/function_type_assertion.Greeter', params: [{ kind: $.TypeKind.Basic, name: "string" }], results: [{ kind: $.TypeKind.Basic, name: "string" }]})
 	console.log(ok1) // true
 
-	let { ok: ok2 } = $.typeAssert<Adder | null>(k, {kind: $.TypeKind.Function, name: 'Adder', params: [{ kind: $.TypeKind.Basic, name: "number" }, { kind: $.TypeKind.Basic, name: "number" }], results: [{ kind: $.TypeKind.Basic, name: "number" }]})
+	let { ok: ok2 } = $.typeAssert<Adder | null>(k, {kind: $.TypeKind.Function, name: 'main@github.com/aperturerobotics/goscript/compliance/tests/function_type_assertion.Adder', params: [{ kind: $.TypeKind.Basic, name: "number" }, { kind: $.TypeKind.Basic, name: "number" }], results: [{ kind: $.TypeKind.Basic, name: "number" }]})
 	console.log(ok2) // false
 
 	let l: null | any = "not a function"
-	let { ok: ok3 } = $.typeAssert<Greeter | null>(l, {kind: $.TypeKind.Function, name: 'Greeter', params: [{ kind: $.TypeKind.Basic, name: "string" }], results: [{ kind: $.TypeKind.Basic, name: "string" }]})
+	let { ok: ok3 } = $.typeAssert<Greeter | null>(l, {kind: $.TypeKind.Function, name: 'main@github.com/aperturerobotics/goscript/compliance/tests/function_type_assertion.Greeter', params: [{ kind: $.TypeKind.Basic, name: "string" }], results: [{ kind: $.TypeKind.Basic, name: "string" }]})
 	console.log(ok3) // false
 
 	// 7. Type assertion that should panic (commented out for now to allow test to run)
@@ -191,7 +205,7 @@ export async function main(): Promise<void> {
 
 	// Test with nil interface
 	let nilInterface: null | any = null
-	let { value: nilFn, ok: okNil } = $.typeAssert<Greeter | null>(nilInterface, {kind: $.TypeKind.Function, name: 'Greeter', params: [{ kind: $.TypeKind.Basic, name: "string" }], results: [{ kind: $.TypeKind.Basic, name: "string" }]})
+	let { value: nilFn, ok: okNil } = $.typeAssert<Greeter | null>(nilInterface, {kind: $.TypeKind.Function, name: 'main@github.com/aperturerobotics/goscript/compliance/tests/function_type_assertion.Greeter', params: [{ kind: $.TypeKind.Basic, name: "string" }], results: [{ kind: $.TypeKind.Basic, name: "string" }]})
 	if (!okNil && nilFn == null) {
 		console.log("Nil interface assertion correct")
 	}
@@ -200,8 +214,8 @@ export async function main(): Promise<void> {
 	}
 
 	// Test assertion to wrong function type
-	let wrongFnInterface: null | any = Object.assign(greet, { __goTypeName: 'Greeter' })
-	let { value: wrongFn, ok: okWrong } = $.typeAssert<Adder | null>(wrongFnInterface, {kind: $.TypeKind.Function, name: 'Adder', params: [{ kind: $.TypeKind.Basic, name: "number" }, { kind: $.TypeKind.Basic, name: "number" }], results: [{ kind: $.TypeKind.Basic, name: "number" }]})
+	let wrongFnInterface: null | any = Object.assign(greet, { __goTypeName: 'main@github.com/aperturerobotics/goscript/compliance/tests/function_type_assertion.Greeter' })
+	let { value: wrongFn, ok: okWrong } = $.typeAssert<Adder | null>(wrongFnInterface, {kind: $.TypeKind.Function, name: 'main@github.com/aperturerobotics/goscript/compliance/tests/function_type_assertion.Adder', params: [{ kind: $.TypeKind.Basic, name: "number" }, { kind: $.TypeKind.Basic, name: "number" }], results: [{ kind: $.TypeKind.Basic, name: "number" }]})
 	if (!okWrong && wrongFn == null) {
 		console.log("Wrong function type assertion correct")
 	}
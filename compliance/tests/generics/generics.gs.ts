@@ -61,11 +61,11 @@ export class Pair<T extends any> {
 
 	// Register this type with the runtime type system
 	static __typeInfo = $.registerStructType(
-	  'Pair',
+	  'main@github.com/aperturerobotics/goscript/compliance/tests/generics.Pair',
 	  new Pair(),
 	  [{ name: "GetFirst", args: [], returns: [{ type: { kind: $.TypeKind.Interface, methods: [] } }] }],
 	  Pair,
-	  {"First": { kind: $.TypeKind.Interface, methods: [] }, "Second": { kind: $.TypeKind.Interface, methods: [] }}
+	  [{ name: "First", type: { kind: $.TypeKind.Interface, methods: [] } }, { name: "Second", type: { kind: $.TypeKind.Interface, methods: [] } }]
 	);
 }
 
@@ -10,7 +10,7 @@ export type Container<T extends any> = null | {
 }
 
 $.registerInterfaceType(
-  'Container',
+  'main@github.com/aperturerobotics/goscript/compliance/tests/generics_interface.Container',
   null, // Zero value for interface is null
   [{ name: "Get", args: [], returns: [{ type: { kind: $.TypeKind.Interface, methods: [] } }] }, { name: "Set", args: [{ name: "", type: { kind: $.TypeKind.Interface, methods: [] } }], returns: [] }, { name: "Size", args: [], returns: [{ type: { kind: $.TypeKind.Basic, name: "number" } }] }]
 );
@@ -21,7 +21,7 @@ export type Comparable<T extends $.Comparable> = null | {
 }
 
 $.registerInterfaceType(
-  'Comparable',
+  'main@github.com/aperturerobotics/goscript/compliance/tests/generics_interface.Comparable',
   null, // Zero value for interface is null
   [{ name: "Compare", args: [{ name: "", type: { kind: $.TypeKind.Interface, methods: [] } }], returns: [{ type: { kind: $.TypeKind.Basic, name: "number" } }] }, { name: "Equal", args: [{ name: "", type: { kind: $.TypeKind.Interface, methods: [] } }], returns: [{ type: { kind: $.TypeKind.Basic, name: "boolean" } }] }]
 );
@@ -80,11 +80,11 @@ export class ValueContainer<T extends any> {
 
 	// Register this type with the runtime type system
 	static __typeInfo = $.registerStructType(
-	  'ValueContainer',
+	  'main@github.com/aperturerobotics/goscript/compliance/tests/generics_interface.ValueContainer',
 	  new ValueContainer(),
 	  [{ name: "Get", args: [], returns: [{ type: { kind: $.TypeKind.Interface, methods: [] } }] }, { name: "Set", args: [{ name: "v", type: { kind: $.TypeKind.Interface, methods: [] } }], returns: [] }, { name: "Size", args: [], returns: [{ type: { kind: $.TypeKind.Basic, name: "number" } }] }],
 	  ValueContainer,
-	  {"value": { kind: $.TypeKind.Interface, methods: [] }, "count": { kind: $.TypeKind.Basic, name: "number" }}
+	  [{ name: "value", type: { kind: $.TypeKind.Interface, methods: [] } }, { name: "count", type: { kind: $.TypeKind.Basic, name: "int" } }]
 	);
 }
 
@@ -132,11 +132,11 @@ export class StringValueContainer {
 
 	// Register this type with the runtime type system
 	static __typeInfo = $.registerStructType(
-	  'StringValueContainer',
+	  'main@github.com/aperturerobotics/goscript/compliance/tests/generics_interface.StringValueContainer',
 	  new StringValueContainer(),
 	  [{ name: "Compare", args: [{ name: "other", type: { kind: $.TypeKind.Basic, name: "string" } }], returns: [{ type: { kind: $.TypeKind.Basic, name: "number" } }] }, { name: "Equal", args: [{ name: "other", type: { kind: $.TypeKind.Basic, name: "string" } }], returns: [{ type: { kind: $.TypeKind.Basic, name: "boolean" } }] }],
 	  StringValueContainer,
-	  {"value": { kind: $.TypeKind.Basic, name: "string" }}
+	  [{ name: "value", type: { kind: $.TypeKind.Basic, name: "string" } }]
 	);
 }
 
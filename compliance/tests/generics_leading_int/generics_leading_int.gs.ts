@@ -21,14 +21,14 @@ export function leadingInt<bytes extends $.Bytes | string>(s: bytes): [number, b
 			}
 
 			// overflow
-			if (x > Number.MAX_SAFE_INTEGER / 10) {
+			if (x > 922337203685477580) {
 				// overflow
 				return [0, $.sliceStringOrBytes(s, $.len(s), undefined), true]
 			}
 			x = x * 10 + (c as number) - 48
 
 			// overflow
-			if (x > Number.MAX_SAFE_INTEGER) {
+			if (x > 9223372036854775808) {
 				// overflow
 				return [0, $.sliceStringOrBytes(s, $.len(s), undefined), true]
 			}
@@ -7,9 +7,12 @@ export async function main(): Promise<void> {
 	let x: null | any = (): void => {
 		console.log("goroutine executed")
 	}
-	queueMicrotask(() => {
-		$.mustTypeAssert<(() => void) | null>(x, {kind: $.TypeKind.Function})!()
-	})
+	{
+		const __fn = $.mustTypeAssert<(() => void) | null>(x, {kind: $.TypeKind.Function})
+		$.go(() => {
+			__fn!()
+		}, "go_type_assertion.go:7")
+	}
 	console.log("main finished")
 }
 
@@ -41,11 +41,11 @@ export class Message {
 
 	// Register this type with the runtime type system
 	static __typeInfo = $.registerStructType(
-	  'Message',
+	  'main@github.com/aperturerobotics/goscript/compliance/tests/goroutines.Message',
 	  new Message(),
 	  [],
 	  Message,
-	  {"priority": { kind: $.TypeKind.Basic, name: "number" }, "text": { kind: $.TypeKind.Basic, name: "string" }}
+	  [{ name: "priority", type: { kind: $.TypeKind.Basic, name: "int" } }, { name: "text", type: { kind: $.TypeKind.Basic, name: "string" } }]
 	);
 }
 
@@ -78,21 +78,27 @@ export async function main(): Promise<void> {
 
 	// This will trigger a past error with *ast.Ident
 	for (let i = 0; i < 3; i++) {{
-		queueMicrotask(async () => {
-			await worker(i)
-		})
+		{
+			const __arg0 = i
+			$.go(async () => {
+				await worker(__arg0)
+			}, "goroutines.go:51")
+		}
 	}
 }
 
 // Start another worker goroutine
-queueMicrotask(async () => {
-	await anotherWorker("test")
-})
+{
+	const __arg0 = "test"
+	$.go(async () => {
+		await anotherWorker(__arg0)
+	}, "goroutines.go:55")
+}
 
 // Start an anonymous function worker
-queueMicrotask(async () => {
+$.go(async () => {
 	await $.chanSend(messages, new Message({priority: 50, text: "Anonymous function worker"}))
-})
+}, "goroutines.go:58")
 
 // Add status message
 allMessages = $.append(allMessages, new Message({priority: 1, text: "Main: Workers started"}))
@@ -6,9 +6,9 @@ import * as $ from "@goscript/builtin/index.js";
 export async function main(): Promise<void> {
 	// Start an anonymous function worker
 	let msgs = $.makeChannel<string>(1, "", 'both')
-	queueMicrotask(async () => {
+	$.go(async () => {
 		await $.chanSend(msgs, "anonymous function worker")
-	})
+	}, "goroutines_anonymous.go:6")
 	console.log(await $.chanRecv(msgs))
 }
 
@@ -0,0 +1,92 @@
+package main
+
+import (
+	"sync"
+	"time"
+)
+
+func main() {
+	// Workers block sending on an unbuffered channel until main receives.
+	results := make(chan int)
+	var wg sync.WaitGroup
+	for i := 1; i <= 3; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			results <- i * i
+		}()
+	}
+	go func() {
+		wg.Wait()
+		close(results)
+	}()
+	sum := 0
+	for {
+		r, ok := <-results
+		if !ok {
+			break
+		}
+		sum += r
+	}
+	println("sum:", sum)
+
+	// Goroutines block on a locked mutex.
+	var mu sync.Mutex
+	var done sync.WaitGroup
+	count := 0
+	mu.Lock()
+	for i := 0; i < 4; i++ {
+		done.Add(1)
+		go func() {
+			defer done.Done()
+			mu.Lock()
+			count++
+			mu.Unlock()
+		}()
+	}
+	mu.Unlock()
+	done.Wait()
+	println("count:", count)
+
+	// A goroutine waits on a condition variable.
+	cond := sync.NewCond(&mu)
+	ready := false
+	woken := make(chan bool)
+	go func() {
+		mu.Lock()
+		for !ready {
+			cond.Wait()
+		}
+		mu.Unlock()
+		woken <- true
+	}()
+	mu.Lock()
+	ready = true
+	cond.Signal()
+	mu.Unlock()
+	println("woken:", <-woken)
+
+	// Main blocks in a select until a timer fires.
+	stop := make(chan bool)
+	select {
+	case <-stop:
+		println("stopped")
+	case <-time.After(10 * time.Millisecond):
+		println("timed out")
+	}
+
+	// Main blocks on a channel while the sender sleeps.
+	wake := make(chan string)
+	go func() {
+		time.Sleep(10 * time.Millisecond)
+		wake <- "awake"
+	}()
+	println(<-wake)
+
+	// Main blocks on a channel sent to by a timer function.
+	fired := make(chan bool)
+	time.AfterFunc(10*time.Millisecond, func() {
+		fired <- true
+	})
+	println("fired:", <-fired)
+}
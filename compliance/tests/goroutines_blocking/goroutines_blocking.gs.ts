@@ -0,0 +1,120 @@
+// Generated file based on goroutines_blocking.go
+// Updated when compliance tests are re-run, DO NOT EDIT!
+
+import * as $ from "@goscript/builtin/index.js";
+
+import * as sync from "@goscript/sync/index.js"
+
+import * as time from "@goscript/time/index.js"
+
+export async function main(): Promise<void> {
+	using __defer = new $.DisposableStack();
+	// Workers block sending on an unbuffered channel until main receives.
+	let results = $.makeChannel<number>(0, 0, 'both')
+	let wg: sync.WaitGroup = new sync.WaitGroup()
+	for (let i = 1; i <= 3; i++) {
+		using __defer = new $.DisposableStack();
+		wg.Add(1)
+		$.go(async () => {
+			using __defer = new $.DisposableStack();
+			__defer.defer(() => {
+				wg.Done()
+			});
+			await $.chanSend(results, i * i)
+		}, "goroutines_blocking.go:14")
+	}
+	$.go(async () => {
+		await wg.Wait()
+		results.close()
+	}, "goroutines_blocking.go:19")
+	let sum = 0
+	for (; ; ) {
+		const { value: r, ok: ok } = await $.chanRecvWithOk(results)
+		if (!ok) {
+			break
+		}
+		sum += r
+	}
+	console.log("sum:", sum)
+
+	// Goroutines block on a locked mutex.
+	let mu: sync.Mutex = new sync.Mutex()
+	let done: sync.WaitGroup = new sync.WaitGroup()
+	let count = 0
+	await mu.Lock()
+	for (let i = 0; i < 4; i++) {
+		using __defer = new $.DisposableStack();
+		done.Add(1)
+		$.go(async () => {
+			using __defer = new $.DisposableStack();
+			__defer.defer(() => {
+				done.Done()
+			});
+			await mu.Lock()
+			count++
+			mu.Unlock()
+		}, "goroutines_blocking.go:40")
+	}
+	mu.Unlock()
+	await done.Wait()
+	console.log("count:", count)
+
+	// A goroutine waits on a condition variable.
+	let cond = sync.NewCond(mu)
+	let ready = false
+	let woken = $.makeChannel<boolean>(0, false, 'both')
+	$.go(async () => {
+		await mu.Lock()
+		for (; !ready; ) {
+			await cond!.Wait()
+		}
+		mu.Unlock()
+		await $.chanSend(woken, true)
+	}, "goroutines_blocking.go:55")
+	await mu.Lock()
+	ready = true
+	cond!.Signal()
+	mu.Unlock()
+	console.log("woken:", await $.chanRecv(woken))
+
+	// Main blocks in a select until a timer fires.
+	let stop = $.makeChannel<boolean>(0, false, 'both')
+	const [_select_has_return_47a9, _select_value_47a9] = await $.selectStatement([
+		{
+			id: 0,
+			isSend: false,
+			channel: stop,
+			onSelected: async (result) => {
+				console.log("stopped")
+			}
+		},
+		{
+			id: 1,
+			isSend: false,
+			channel: time.After(10 * time.Millisecond),
+			onSelected: async (result) => {
+				console.log("timed out")
+			}
+		},
+	], false)
+	if (_select_has_return_47a9) {
+		return _select_value_47a9!
+	}
+	// If _select_has_return_47a9 is false, continue execution
+
+	// Main blocks on a channel while the sender sleeps.
+	let wake = $.makeChannel<string>(0, "", 'both')
+	$.go(async () => {
+		await time.Sleep(10 * time.Millisecond)
+		await $.chanSend(wake, "awake")
+	}, "goroutines_blocking.go:80")
+	console.log(await $.chanRecv(wake))
+
+	// Main blocks on a channel sent to by a timer function.
+	let fired = $.makeChannel<boolean>(0, false, 'both')
+	time.AfterFunc(10 * time.Millisecond, async (): Promise<void> => {
+		await $.chanSend(fired, true)
+	})
+	console.log("fired:", await $.chanRecv(fired))
+}
+
@@ -0,0 +1,123 @@
+package main
+
+// send is a named function started as a goroutine.
+func send(out chan string, s string, n int) {
+	for i := 0; i < n; i++ {
+		out <- s
+	}
+}
+
+// addAll sends the sum of xs.
+func addAll(out chan int, xs ...int) {
+	t := 0
+	for _, x := range xs {
+		t += x
+	}
+	out <- t
+}
+
+type Worker struct {
+	name string
+}
+
+// Run is a method started as a goroutine.
+func (w *Worker) Run(out chan string, tag string) {
+	out <- w.name + ":" + tag
+}
+
+type Point struct {
+	X, Y int
+}
+
+// report takes a struct value, which is copied at the go statement.
+func report(out chan string, p Point) {
+	out <- "point"
+	<-out
+	println("point:", p.X, p.Y)
+	out <- "reported"
+}
+
+// Report is a value receiver method, its receiver is copied at the go
+// statement.
+func (p Point) Report(out chan string) {
+	report(out, p)
+}
+
+func main() {
+	// Arguments are evaluated when the go statement runs
+	results := make(chan int, 3)
+	for i := 0; i < 3; i++ {
+		go func(n int) {
+			results <- n * 10
+		}(i)
+	}
+	sum := 0
+	for i := 0; i < 3; i++ {
+		sum += <-results
+	}
+	println("sum:", sum)
+
+	msg := "first"
+	done := make(chan string)
+	go func(m string, suffix string) {
+		done <- m + suffix
+	}(msg, "!")
+	msg = "second"
+	println(<-done, msg)
+
+	// Variadic parameters
+	total := make(chan int)
+	nums := []int{4, 5, 6}
+	go func(xs ...int) {
+		t := 0
+		for _, x := range xs {
+			t += x
+		}
+		total <- t
+	}(nums...)
+	println("total:", <-total)
+	go func(prefix string, xs ...int) {
+		total <- len(prefix) + len(xs)
+	}("ab", 1, 2, 3)
+	println("count:", <-total)
+
+	// Named functions and methods
+	strs := make(chan string)
+	word := "a"
+	n := 2
+	go send(strs, word, n)
+	word, n = "b", 0
+	println(<-strs, <-strs, word, n)
+
+	go addAll(total, nums...)
+	println("sum nums:", <-total)
+	go addAll(total, 7, 8)
+	println("sum args:", <-total)
+
+	w := &Worker{name: "w1"}
+	tag := "first"
+	go w.Run(strs, tag)
+	tag = "second"
+	w = &Worker{name: "w2"}
+	println(<-strs, tag, w.name)
+
+	p := Point{X: 1, Y: 2}
+	go report(strs, p)
+	println(<-strs)
+	p.X = 10
+	strs <- "go"
+	println(<-strs, p.X)
+
+	go p.Report(strs)
+	println(<-strs)
+	p.Y = 20
+	strs <- "go"
+	println(<-strs, p.Y)
+
+	run := func(s string) {
+		strs <- "func " + s
+	}
+	go run("value")
+	run = nil
+	println(<-strs, run == nil)
+}
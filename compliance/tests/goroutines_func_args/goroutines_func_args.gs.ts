@@ -0,0 +1,254 @@
+// Generated file based on goroutines_func_args.go
+// Updated when compliance tests are re-run, DO NOT EDIT!
+
+import * as $ from "@goscript/builtin/index.js";
+
+// send is a named function started as a goroutine.
+export async function send(out: $.Channel<string> | null, s: string, n: number): Promise<void> {
+	for (let i = 0; i < n; i++) {
+		await $.chanSend(out, s)
+	}
+}
+
+// addAll sends the sum of xs.
+export async function addAll(out: $.Channel<number> | null, ...xs: number[]): Promise<void> {
+	let t = 0
+	for (let _i = 0; _i < $.len(xs); _i++) {
+		const x = xs![_i]
+		{
+			t += x
+		}
+	}
+	await $.chanSend(out, t)
+}
+
+export class Worker {
+	public get name(): string {
+		return this._fields.name.value
+	}
+	public set name(value: string) {
+		this._fields.name.value = value
+	}
+
+	public _fields: {
+		name: $.VarRef<string>;
+	}
+
+	constructor(init?: Partial<{name?: string}>) {
+		this._fields = {
+			name: $.varRef(init?.name ?? "")
+		}
+	}
+
+	public clone(): Worker {
+		const cloned = new Worker()
+		cloned._fields = {
+			name: $.varRef(this._fields.name.value)
+		}
+		return cloned
+	}
+
+	// Run is a method started as a goroutine.
+	public async Run(out: $.Channel<string> | null, tag: string): Promise<void> {
+		const w = this
+		await $.chanSend(out, $.concatString(w.name + ":", tag))
+	}
+
+	// Register this type with the runtime type system
+	static __typeInfo = $.registerStructType(
+	  'main@github.com/aperturerobotics/goscript/compliance/tests/goroutines_func_args.Worker',
+	  new Worker(),
+	  [{ name: "Run", args: [{ name: "out", type: { kind: $.TypeKind.Channel, direction: "both", elemType: { kind: $.TypeKind.Basic, name: "string" } } }, { name: "tag", type: { kind: $.TypeKind.Basic, name: "string" } }], returns: [] }],
+	  Worker,
+	  [{ name: "name", type: { kind: $.TypeKind.Basic, name: "string" } }]
+	);
+}
+
+export class Point {
+	public get X(): number {
+		return this._fields.X.value
+	}
+	public set X(value: number) {
+		this._fields.X.value = value
+	}
+
+	public get Y(): number {
+		return this._fields.Y.value
+	}
+	public set Y(value: number) {
+		this._fields.Y.value = value
+	}
+
+	public _fields: {
+		X: $.VarRef<number>;
+		Y: $.VarRef<number>;
+	}
+
+	constructor(init?: Partial<{X?: number, Y?: number}>) {
+		this._fields = {
+			X: $.varRef(init?.X ?? 0),
+			Y: $.varRef(init?.Y ?? 0)
+		}
+	}
+
+	public clone(): Point {
+		const cloned = new Point()
+		cloned._fields = {
+			X: $.varRef(this._fields.X.value),
+			Y: $.varRef(this._fields.Y.value)
+		}
+		return cloned
+	}
+
+	// Report is a value receiver method, its receiver is copied at the go
+	// statement.
+	public async Report(out: $.Channel<string> | null): Promise<void> {
+		const p = this
+		await report(out, p)
+	}
+
+	// Register this type with the runtime type system
+	static __typeInfo = $.registerStructType(
+	  'main@github.com/aperturerobotics/goscript/compliance/tests/goroutines_func_args.Point',
+	  new Point(),
+	  [{ name: "Report", args: [{ name: "out", type: { kind: $.TypeKind.Channel, direction: "both", elemType: { kind: $.TypeKind.Basic, name: "string" } } }], returns: [] }],
+	  Point,
+	  [{ name: "X", type: { kind: $.TypeKind.Basic, name: "int" } }, { name: "Y", type: { kind: $.TypeKind.Basic, name: "int" } }]
+	);
+}
+
+// report takes a struct value, which is copied at the go statement.
+export async function report(out: $.Channel<string> | null, p: Point): Promise<void> {
+	await $.chanSend(out, "point")
+	await $.chanRecv(out)
+	console.log("point:", p.X, p.Y)
+	await $.chanSend(out, "reported")
+}
+
+export async function main(): Promise<void> {
+	// Arguments are evaluated when the go statement runs
+	let results = $.makeChannel<number>(3, 0, 'both')
+	for (let i = 0; i < 3; i++) {
+		$.go(((n: number) => async () => {
+			await $.chanSend(results, n * 10)
+		})(i), "goroutines_func_args.go:50")
+	}
+	let sum = 0
+	for (let i = 0; i < 3; i++) {
+		sum += await $.chanRecv(results)
+	}
+	console.log("sum:", sum)
+
+	let msg = "first"
+	let done = $.makeChannel<string>(0, "", 'both')
+	$.go(((m: string, suffix: string) => async () => {
+		await $.chanSend(done, $.concatString(m, suffix))
+	})(msg, "!"), "goroutines_func_args.go:62")
+	msg = "second"
+	console.log(await $.chanRecv(done), msg)
+
+	// Variadic parameters
+	let total = $.makeChannel<number>(0, 0, 'both')
+	let nums = $.arrayToSlice<number>([4, 5, 6])
+	$.go(((...xs: number[]) => async () => {
+		let t = 0
+		for (let _i = 0; _i < $.len(xs); _i++) {
+			const x = xs![_i]
+			{
+				t += x
+			}
+		}
+		await $.chanSend(total, t)
+	})(...(nums ?? [])), "goroutines_func_args.go:71")
+	console.log("total:", await $.chanRecv(total))
+	$.go(((prefix: string, ...xs: number[]) => async () => {
+		await $.chanSend(total, $.len(prefix) + $.len(xs))
+	})("ab", 1, 2, 3), "goroutines_func_args.go:79")
+	console.log("count:", await $.chanRecv(total))
+
+	// Named functions and methods
+	let strs = $.makeChannel<string>(0, "", 'both')
+	let word = "a"
+	let n = 2
+	{
+		const __arg0 = strs
+		const __arg1 = word
+		const __arg2 = n
+		$.go(async () => {
+			await send(__arg0, __arg1, __arg2)
+		}, "goroutines_func_args.go:88")
+	}
+	;[word, n] = ["b", 0]
+	console.log(await $.chanRecv(strs), await $.chanRecv(strs), word, n)
+
+	{
+		const __arg0 = total
+		const __arg1 = nums
+		$.go(async () => {
+			await addAll(__arg0, ...(__arg1 ?? []))
+		}, "goroutines_func_args.go:92")
+	}
+	console.log("sum nums:", await $.chanRecv(total))
+	{
+		const __arg0 = total
+		const __arg1 = 7
+		const __arg2 = 8
+		$.go(async () => {
+			await addAll(__arg0, __arg1, __arg2)
+		}, "goroutines_func_args.go:94")
+	}
+	console.log("sum args:", await $.chanRecv(total))
+
+	let w = new Worker({name: "w1"})
+	let tag = "first"
+	{
+		const __recv = w
+		const __arg0 = strs
+		const __arg1 = tag
+		$.go(async () => {
+			await __recv!.Run(__arg0, __arg1)
+		}, "goroutines_func_args.go:99")
+	}
+	tag = "second"
+	w = new Worker({name: "w2"})
+	console.log(await $.chanRecv(strs), tag, w.name)
+
+	let p = new Point({X: 1, Y: 2})
+	{
+		const __arg0 = strs
+		const __arg1 = p.clone()
+		$.go(async () => {
+			await report(__arg0, __arg1)
+		}, "goroutines_func_args.go:105")
+	}
+	console.log(await $.chanRecv(strs))
+	p.X = 10
+	await $.chanSend(strs, "go")
+	console.log(await $.chanRecv(strs), p.X)
+
+	{
+		const __recv = p.clone()
+		const __arg0 = strs
+		$.go(async () => {
+			await __recv!.Report(__arg0)
+		}, "goroutines_func_args.go:111")
+	}
+	console.log(await $.chanRecv(strs))
+	p.Y = 20
+	await $.chanSend(strs, "go")
+	console.log(await $.chanRecv(strs), p.Y)
+
+	let run = async (s: string): Promise<void> => {
+		await $.chanSend(strs, "func " + s)
+	}
+	{
+		const __fn = run
+		const __arg0 = "value"
+		$.go(async () => {
+			await __fn!(__arg0)
+		}, "goroutines_func_args.go:120")
+	}
+	run = null
+	console.log(await $.chanRecv(strs), run == null)
+}
+
@@ -0,0 +1 @@
+export { Point, Worker } from "./goroutines_func_args.gs.js"
@@ -37,11 +37,11 @@ export class Foo {
 
 	// Register this type with the runtime type system
 	static __typeInfo = $.registerStructType(
-	  'Foo',
+	  'main@github.com/aperturerobotics/goscript/compliance/tests/goroutines_selector.Foo',
 	  new Foo(),
 	  [{ name: "Bar", args: [], returns: [] }],
 	  Foo,
-	  {"done": { kind: $.TypeKind.Channel, direction: "both", elemType: { kind: $.TypeKind.Basic, name: "boolean" } }}
+	  [{ name: "done", type: { kind: $.TypeKind.Channel, direction: "both", elemType: { kind: $.TypeKind.Basic, name: "bool" } } }]
 	);
 }
 
@@ -51,9 +51,12 @@ export function NewFoo(): Foo | null {
 
 export async function main(): Promise<void> {
 	let f = NewFoo()
-	queueMicrotask(async () => {
-		await f!.Bar()
-	})
+	{
+		const __recv = f
+		$.go(async () => {
+			await __recv!.Bar()
+		}, "goroutines_selector.go:18")
+	}
 	await $.chanRecv(f!.done)
 	console.log("main done")
 }
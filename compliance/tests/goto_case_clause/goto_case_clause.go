@@ -0,0 +1,56 @@
+package main
+
+// classify jumps between labels inside a switch case body.
+func classify(n int) string {
+	switch {
+	case n < 0:
+		return "negative"
+	default:
+		result := ""
+		i := n
+	next:
+		if i >= 10 {
+			result += "x"
+			i -= 10
+			goto next
+		}
+		if i == 0 {
+			goto done
+		}
+		result += "+"
+	done:
+		return result
+	}
+}
+
+func main() {
+	println("classify:", classify(-1), classify(25), classify(30))
+
+	// A goto inside a select case body.
+	ch := make(chan int, 1)
+	ch <- 3
+	select {
+	case v := <-ch:
+		n := v
+	retry:
+		if n > 0 {
+			println("select:", n)
+			n--
+			goto retry
+		}
+		println("select done")
+	}
+
+	// A goto inside a type switch case body.
+	var x any = 2
+	switch v := x.(type) {
+	case int:
+		count := 0
+	more:
+		count++
+		if count < v {
+			goto more
+		}
+		println("type switch:", count)
+	}
+}
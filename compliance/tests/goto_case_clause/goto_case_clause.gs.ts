@@ -0,0 +1,100 @@
+// Generated file based on goto_case_clause.go
+// Updated when compliance tests are re-run, DO NOT EDIT!
+
+import * as $ from "@goscript/builtin/index.js";
+
+// classify jumps between labels inside a switch case body.
+export function classify(n: number): string {
+	switch (true) {
+		case n < 0:
+			return "negative"
+			break
+		default:
+			let result: string = ""
+			let i: number = 0
+			let __goto0 = 0
+			__gotoLoop0: for (;;) {
+				switch (__goto0) {
+					case 0:
+						result = ""
+						i = n
+					case 1: // next
+						if (i >= 10) {
+							result += "x"
+							i -= 10
+							__goto0 = 1
+							continue __gotoLoop0
+						}
+						if (i == 0) {
+							__goto0 = 2
+							continue __gotoLoop0
+						}
+						result += "+"
+					case 2: // done
+						return result
+				}
+			}
+			break
+	}
+}
+
+export async function main(): Promise<void> {
+	console.log("classify:", classify(-1), classify(25), classify(30))
+
+	// A goto inside a select case body.
+	let ch = $.makeChannel<number>(1, 0, 'both')
+	await $.chanSend(ch, 3)
+	const [_select_has_return_0e65, _select_value_0e65] = await $.selectStatement([
+		{
+			id: 0,
+			isSend: false,
+			channel: ch,
+			onSelected: async (result) => {
+				const v = result.value
+				let n: number = 0
+				let __goto1 = 0
+				__gotoLoop1: for (;;) {
+					switch (__goto1) {
+						case 0:
+							n = v
+						case 1: // retry
+							if (n > 0) {
+								console.log("select:", n)
+								n--
+								__goto1 = 1
+								continue __gotoLoop1
+							}
+							console.log("select done")
+					}
+					break
+				}
+			}
+		},
+	], false)
+	if (_select_has_return_0e65) {
+		return _select_value_0e65!
+	}
+	// If _select_has_return_0e65 is false, continue execution
+
+	// A goto inside a type switch case body.
+	let x: null | any = 2
+	$.typeSwitch(x, [{ types: [{kind: $.TypeKind.Basic, name: 'number'}], body: (v) => {
+		let count: number = 0
+		let __goto2 = 0
+		__gotoLoop2: for (;;) {
+			switch (__goto2) {
+				case 0:
+					count = 0
+				case 1: // more
+					count++
+					if (count < v) {
+						__goto2 = 1
+						continue __gotoLoop2
+					}
+					console.log("type switch:", count)
+			}
+			break
+		}
+	}}])
+}
+
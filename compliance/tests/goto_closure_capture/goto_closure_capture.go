@@ -0,0 +1,32 @@
+package main
+
+func main() {
+	// Each execution of a declaration creates a new variable, so the
+	// closures capture different variables across a backward goto.
+	var funcs []func() int
+	i := 0
+loop:
+	j := i
+	funcs = append(funcs, func() int { return j })
+	i++
+	if i < 3 {
+		goto loop
+	}
+	for _, f := range funcs {
+		println("captured:", f())
+	}
+
+	// A closure sees later changes to the variable it captured.
+	var incs []func()
+	n := 0
+again:
+	var k int
+	k = n * 10
+	incs = append(incs, func() { k++ })
+	incs[len(incs)-1]()
+	println("k:", k)
+	n++
+	if n < 2 {
+		goto again
+	}
+}
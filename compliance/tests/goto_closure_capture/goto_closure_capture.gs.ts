@@ -0,0 +1,59 @@
+// Generated file based on goto_closure_capture.go
+// Updated when compliance tests are re-run, DO NOT EDIT!
+
+import * as $ from "@goscript/builtin/index.js";
+
+export async function main(): Promise<void> {
+	let funcs: $.Slice<(() => number) | null> = null
+	let i: number = 0
+	let j: $.VarRef<number> = $.varRef(0)
+	let incs: $.Slice<(() => void) | null> = null
+	let n: number = 0
+	let k: $.VarRef<number> = $.varRef(0)
+	let __goto0 = 0
+	__gotoLoop0: for (;;) {
+		switch (__goto0) {
+			case 0:
+				// Each execution of a declaration creates a new variable, so the
+				// closures capture different variables across a backward goto.
+				funcs = null
+				i = 0
+			case 1: // loop
+				j = $.varRef(0)
+				j!.value = i
+				funcs = $.append(funcs, ((j: $.VarRef<number>) => (): number => {
+					return j!.value
+				})(j))
+				i++
+				if (i < 3) {
+					__goto0 = 1
+					continue __gotoLoop0
+				}
+				for (let _i = 0; _i < $.len(funcs); _i++) {
+					const f = funcs![_i]
+					{
+						console.log("captured:", f!())
+					}
+				}
+
+				// A closure sees later changes to the variable it captured.
+				incs = null
+				n = 0
+			case 2: // again
+				k = $.varRef(0)
+				k!.value = n * 10
+				incs = $.append(incs, ((k: $.VarRef<number>) => (): void => {
+					k!.value++
+				})(k))
+				incs![$.len(incs) - 1]()
+				console.log("k:", k!.value)
+				n++
+				if (n < 2) {
+					__goto0 = 2
+					continue __gotoLoop0
+				}
+		}
+		break
+	}
+}
+
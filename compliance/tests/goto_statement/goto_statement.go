@@ -0,0 +1,132 @@
+package main
+
+// countdown uses a backward goto as a loop.
+func countdown(n int) {
+	i := n
+loop:
+	if i > 0 {
+		println("countdown:", i)
+		i--
+		goto loop
+	}
+	println("liftoff")
+}
+
+// parseDigits uses forward gotos to an error exit.
+func parseDigits(s string) (int, bool) {
+	n := 0
+	if len(s) == 0 {
+		goto fail
+	}
+	for i := 0; i < len(s); i++ {
+		c := s[i]
+		if c < '0' || c > '9' {
+			goto fail
+		}
+		n = n*10 + int(c-'0')
+	}
+	return n, true
+
+fail:
+	println("invalid input:", s)
+	return 0, false
+}
+
+// retry jumps backward past a declaration made after the label.
+func retry() int {
+	attempts := 0
+again:
+	attempts++
+	result := attempts * 10
+	if result < 30 {
+		goto again
+	}
+	return result
+}
+
+// gotoInLoop breaks out of a loop and skips statements, with
+// unlabeled break and continue inside the state machine.
+func gotoInLoop() {
+	for i := 0; i < 4; i++ {
+		if i == 1 {
+			continue
+		}
+		if i == 2 {
+			goto skip
+		}
+		println("loop body:", i)
+	skip:
+		println("after skip:", i)
+		if i == 3 {
+			break
+		}
+	}
+	println("loop done")
+}
+
+// nestedGoto jumps out of nested loops.
+func nestedGoto() {
+	for i := 0; i < 3; i++ {
+		for j := 0; j < 3; j++ {
+			if i*j == 2 {
+				goto done
+			}
+			println("nested:", i, j)
+		}
+	}
+done:
+	println("nested done")
+}
+
+// drain uses goto inside an async function.
+func drain(ch chan int) int {
+	sum := 0
+next:
+	v, ok := <-ch
+	if ok {
+		sum += v
+		goto next
+	}
+	return sum
+}
+
+type Machine struct {
+	state int
+}
+
+// Run uses goto inside a method with a receiver.
+func (m *Machine) Run() int {
+	steps := 0
+start:
+	steps++
+	m.state++
+	if m.state < 3 {
+		goto start
+	}
+	return steps
+}
+
+func main() {
+	countdown(3)
+
+	n, ok := parseDigits("1234")
+	println("parseDigits:", n, ok)
+	n, ok = parseDigits("12a4")
+	println("parseDigits:", n, ok)
+
+	println("retry:", retry())
+
+	gotoInLoop()
+
+	nestedGoto()
+
+	ch := make(chan int, 3)
+	ch <- 1
+	ch <- 2
+	ch <- 3
+	close(ch)
+	println("drain:", drain(ch))
+
+	m := &Machine{}
+	println("machine steps:", m.Run())
+}
@@ -0,0 +1,230 @@
+// Generated file based on goto_statement.go
+// Updated when compliance tests are re-run, DO NOT EDIT!
+
+import * as $ from "@goscript/builtin/index.js";
+
+// countdown uses a backward goto as a loop.
+export function countdown(n: number): void {
+	let i: number = 0
+	let __goto0 = 0
+	__gotoLoop0: for (;;) {
+		switch (__goto0) {
+			case 0:
+				i = n
+			case 1: // loop
+				if (i > 0) {
+					console.log("countdown:", i)
+					i--
+					__goto0 = 1
+					continue __gotoLoop0
+				}
+				console.log("liftoff")
+		}
+		break
+	}
+}
+
+// parseDigits uses forward gotos to an error exit.
+export function parseDigits(s: string): [number, boolean] {
+	let n: number = 0
+	let __goto1 = 0
+	__gotoLoop1: for (;;) {
+		switch (__goto1) {
+			case 0:
+				n = 0
+				if ($.len(s) == 0) {
+					__goto1 = 1
+					continue __gotoLoop1
+				}
+				for (let i = 0; i < $.len(s); i++) {
+					let c = $.indexString(s, i)
+					if (c < 48 || c > 57) {
+						__goto1 = 1
+						continue __gotoLoop1
+					}
+					n = n * 10 + $.int(((c - 48) & 0xff))
+				}
+				return [n, true]
+
+			case 1: // fail
+				console.log("invalid input:", s)
+				return [0, false]
+		}
+	}
+}
+
+// retry jumps backward past a declaration made after the label.
+export function retry(): number {
+	let attempts: number = 0
+	let result: number = 0
+	let __goto2 = 0
+	__gotoLoop2: for (;;) {
+		switch (__goto2) {
+			case 0:
+				attempts = 0
+			case 1: // again
+				attempts++
+				result = attempts * 10
+				if (result < 30) {
+					__goto2 = 1
+					continue __gotoLoop2
+				}
+				return result
+		}
+	}
+}
+
+// gotoInLoop breaks out of a loop and skips statements, with
+// unlabeled break and continue inside the state machine.
+export function gotoInLoop(): void {
+	__label0: for (let i = 0; i < 4; i++) {
+		let __goto3 = 0
+		__gotoLoop3: for (;;) {
+			switch (__goto3) {
+				case 0:
+					if (i == 1) {
+						continue __label0
+					}
+					if (i == 2) {
+						__goto3 = 1
+						continue __gotoLoop3
+					}
+					console.log("loop body:", i)
+				case 1: // skip
+					console.log("after skip:", i)
+					if (i == 3) {
+						break __label0
+					}
+			}
+			break
+		}
+	}
+	console.log("loop done")
+}
+
+// nestedGoto jumps out of nested loops.
+export function nestedGoto(): void {
+	let __goto4 = 0
+	__gotoLoop4: for (;;) {
+		switch (__goto4) {
+			case 0:
+				for (let i = 0; i < 3; i++) {
+					for (let j = 0; j < 3; j++) {
+						if (i * j == 2) {
+							__goto4 = 1
+							continue __gotoLoop4
+						}
+						console.log("nested:", i, j)
+					}
+				}
+			case 1: // done
+				console.log("nested done")
+		}
+		break
+	}
+}
+
+// drain uses goto inside an async function.
+export async function drain(ch: $.Channel<number> | null): Promise<number> {
+	let sum: number = 0
+	let v: number = 0
+	let ok: boolean = false
+	let __goto5 = 0
+	__gotoLoop5: for (;;) {
+		switch (__goto5) {
+			case 0:
+				sum = 0
+			case 1: // next
+				;({ value: v, ok: ok } = await $.chanRecvWithOk(ch))
+				if (ok) {
+					sum += v
+					__goto5 = 1
+					continue __gotoLoop5
+				}
+				return sum
+		}
+	}
+}
+
+export class Machine {
+	public get state(): number {
+		return this._fields.state.value
+	}
+	public set state(value: number) {
+		this._fields.state.value = value
+	}
+
+	public _fields: {
+		state: $.VarRef<number>;
+	}
+
+	constructor(init?: Partial<{state?: number}>) {
+		this._fields = {
+			state: $.varRef(init?.state ?? 0)
+		}
+	}
+
+	public clone(): Machine {
+		const cloned = new Machine()
+		cloned._fields = {
+			state: $.varRef(this._fields.state.value)
+		}
+		return cloned
+	}
+
+	// Run uses goto inside a method with a receiver.
+	public Run(): number {
+		const m = this
+		let steps: number = 0
+		let __goto6 = 0
+		__gotoLoop6: for (;;) {
+			switch (__goto6) {
+				case 0:
+					steps = 0
+				case 1: // start
+					steps++
+					m.state++
+					if (m.state < 3) {
+						__goto6 = 1
+						continue __gotoLoop6
+					}
+					return steps
+			}
+		}
+	}
+
+	// Register this type with the runtime type system
+	static __typeInfo = $.registerStructType(
+	  'main@github.com/aperturerobotics/goscript/compliance/tests/goto_statement.Machine',
+	  new Machine(),
+	  [{ name: "Run", args: [], returns: [{ type: { kind: $.TypeKind.Basic, name: "number" } }] }],
+	  Machine,
+	  [{ name: "state", type: { kind: $.TypeKind.Basic, name: "int" } }]
+	);
+}
+
+export async function main(): Promise<void> {
+	countdown(3)
+
+	let [n, ok] = parseDigits("1234")
+	console.log("parseDigits:", n, ok)
+	;[n, ok] = parseDigits("12a4")
+	console.log("parseDigits:", n, ok)
+
+	console.log("retry:", retry())
+
+	gotoInLoop()
+
+	nestedGoto()
+
+	let ch = $.makeChannel<number>(3, 0, 'both')
+	await $.chanSend(ch, 1)
+	await $.chanSend(ch, 2)
+	await $.chanSend(ch, 3)
+	ch.close()
+	console.log("drain:", await drain(ch))
+
+	let m = new Machine({})
+	console.log("machine steps:", m.Run())
+}
+
@@ -0,0 +1 @@
+export { Machine } from "./goto_statement.gs.js"
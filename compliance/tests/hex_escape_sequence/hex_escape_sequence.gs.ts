@@ -21,7 +21,7 @@ export async function main(): Promise<void> {
 	console.log("Raw string with \\xG:", s2)
 
 	// Interpreted string with \x escape sequence
-	let s3 = "\x41" // This should be treated as hex escape for 'A'
+	let s3 = "A" // This should be treated as hex escape for 'A'
 	console.log("Interpreted string:", s3)
 }
 
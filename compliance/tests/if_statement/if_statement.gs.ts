@@ -6,7 +6,7 @@ import * as $ from "@goscript/builtin/index.js";
 export async function main(): Promise<void> {
 	// === If Statement ===
 	let n = 7
-	if (n % 2 == 0) {
+	if ((n % 2) == 0) {
 		console.log("Even: Expected: (no output)")
 	}
 	 else {
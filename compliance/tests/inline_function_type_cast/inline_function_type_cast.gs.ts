@@ -5,6 +5,13 @@ import * as $ from "@goscript/builtin/index.js";
 
 export type Greeter = ((name: string) => string) | null;
 
+$.registerNamedType(
+  'main@github.com/aperturerobotics/goscript/compliance/tests/inline_function_type_cast.Greeter',
+  null,
+  [],
+  { kind: $.TypeKind.Function, params: [{ kind: $.TypeKind.Basic, name: "string" }], results: [{ kind: $.TypeKind.Basic, name: "string" }] }
+);
+
 export async function main(): Promise<void> {
 	// 2. Create an inline variable with the inline function satisfying that type.
 	let theInlineVar = (name: string): string => {
@@ -12,17 +19,24 @@ export async function main(): Promise<void> {
 	}
 
 	// 3. Use Greeter(theInlineVar) to cast to the Greeter declared function type.
-	let castedGreeter = Object.assign(theInlineVar, { __goTypeName: 'Greeter' })
+	let castedGreeter = Object.assign(theInlineVar, { __goTypeName: 'main@github.com/aperturerobotics/goscript/compliance/tests/inline_function_type_cast.Greeter' })
 
 	// 4. Call that
 	console.log(castedGreeter!("Inline World"))
 
 	// Test with a different signature
 	type Adder = ((a: number, b: number) => number) | null;
+
+	$.registerNamedType(
+	  'main@github.com/aperturerobotics/goscript/compliance/tests/inline_function_type_cast.Adder',
+	  null,
+	  [],
+	  { kind: $.TypeKind.Function, params: [{ kind: $.TypeKind.Basic, name: "number" }, { kind: $.TypeKind.Basic, name: "number" }], results: [{ kind: $.TypeKind.Basic, name: "number" }] }
+	);
 	let theInlineAdder = (a: number, b: number): number => {
 		return a + b
 	}
-	let castedAdder = Object.assign(theInlineAdder, { __goTypeName: 'Adder' })
+	let castedAdder = Object.assign(theInlineAdder, { __goTypeName: 'main@github.com/aperturerobotics/goscript/compliance/tests/inline_function_type_cast.Adder' })
 	console.log(castedAdder!(5, 7))
 }
 
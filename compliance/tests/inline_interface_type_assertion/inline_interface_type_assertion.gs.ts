@@ -24,11 +24,11 @@ export class Greeter {
 
 	// Register this type with the runtime type system
 	static __typeInfo = $.registerStructType(
-	  'Greeter',
+	  'main@github.com/aperturerobotics/goscript/compliance/tests/inline_interface_type_assertion.Greeter',
 	  new Greeter(),
 	  [{ name: "Greet", args: [], returns: [{ type: { kind: $.TypeKind.Basic, name: "string" } }] }],
 	  Greeter,
-	  {}
+	  []
 	);
 }
 
@@ -37,7 +37,7 @@ export type Stringer = null | {
 }
 
 $.registerInterfaceType(
-  'Stringer',
+  'main@github.com/aperturerobotics/goscript/compliance/tests/inline_interface_type_assertion.Stringer',
   null, // Zero value for interface is null
   [{ name: "String", args: [], returns: [{ type: { kind: $.TypeKind.Basic, name: "string" } }] }]
 );
@@ -63,17 +63,17 @@ export class MyStringer {
 
 	// Register this type with the runtime type system
 	static __typeInfo = $.registerStructType(
-	  'MyStringer',
+	  'main@github.com/aperturerobotics/goscript/compliance/tests/inline_interface_type_assertion.MyStringer',
 	  new MyStringer(),
 	  [{ name: "String", args: [], returns: [{ type: { kind: $.TypeKind.Basic, name: "string" } }] }],
 	  MyStringer,
-	  {}
+	  []
 	);
 }
 
 export async function main(): Promise<void> {
 	let i: null | any = null
-	i = new Greeter({})
+	i = $.markStructValue(new Greeter({}))
 
 	// Successful type assertion to an inline interface
 	let { value: g, ok: ok } = $.typeAssert<null | {
@@ -99,7 +99,7 @@ export async function main(): Promise<void> {
 
 	// Successful type assertion to a named interface, where the asserted value also implements an inline interface method
 	let j: null | any = null
-	j = new MyStringer({})
+	j = $.markStructValue(new MyStringer({}))
 
 	// Assert 'j' (which holds MyStringer) to an inline interface that MyStringer satisfies.
 	let { value: inlineMs, ok: ok4 } = $.typeAssert<null | {
@@ -114,7 +114,7 @@ export async function main(): Promise<void> {
 
 	// Test case: variable of named interface type, asserted to inline interface
 	let k: Stringer = null
-	k = new MyStringer({})
+	k = $.markStructValue(new MyStringer({}))
 
 	let { value: inlineK, ok: ok5 } = $.typeAssert<null | {
 		String(): string
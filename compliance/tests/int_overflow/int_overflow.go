@@ -0,0 +1,140 @@
+package main
+
+// crc32 computes the IEEE CRC-32 checksum bit by bit.
+func crc32(data []byte) uint32 {
+	crc := ^uint32(0)
+	for _, b := range data {
+		crc ^= uint32(b)
+		for i := 0; i < 8; i++ {
+			if crc&1 == 1 {
+				crc = (crc >> 1) ^ 0xedb88320
+			} else {
+				crc >>= 1
+			}
+		}
+	}
+	return ^crc
+}
+
+// putUvarint encodes x as a varint.
+func putUvarint(buf []byte, x uint32) int {
+	i := 0
+	for x >= 0x80 {
+		buf[i] = byte(x) | 0x80
+		x >>= 7
+		i++
+	}
+	buf[i] = byte(x)
+	return i + 1
+}
+
+// fnv32 computes the FNV-1 hash, which relies on overflowing multiplication.
+func fnv32(s string) uint32 {
+	h := uint32(2166136261)
+	for i := 0; i < len(s); i++ {
+		h *= 16777619
+		h ^= uint32(s[i])
+	}
+	return h
+}
+
+func divide(a, b int) (q int, err string) {
+	defer func() {
+		if r := recover(); r != nil {
+			err = r.(error).Error()
+		}
+	}()
+	return a / b, ""
+}
+
+func main() {
+	// Addition and subtraction wrap around
+	var b byte = 250
+	b += 10
+	println("byte 250+10:", b)
+	b = 3
+	b -= 5
+	println("byte 3-5:", b)
+	var u8 uint8 = 255
+	u8++
+	println("uint8 255++:", u8)
+
+	var i8 int8 = 127
+	i8++
+	println("int8 127++:", i8)
+	i8 = -128
+	i8--
+	println("int8 -128--:", i8)
+	i8 = -128
+	println("int8 -(-128):", -i8)
+
+	var i16 int16 = 32767
+	i16 += 2
+	println("int16 32767+2:", i16)
+	var u16 uint16 = 65535
+	u16 = u16 * u16
+	println("uint16 65535*65535:", u16)
+
+	var i32 int32 = 2147483647
+	i32++
+	println("int32 max+1:", i32)
+	i32 = 123456789
+	println("int32 123456789*1000:", i32*1000)
+	var u32 uint32 = 4294967295
+	u32 += 1
+	println("uint32 max+1:", u32)
+	u32 = 0
+	u32--
+	println("uint32 0-1:", u32)
+
+	// Bitwise operations on uint32 stay unsigned
+	u32 = 0x80000000
+	println("uint32 high bit:", u32|1, u32&^0x80000000, u32^0xffffffff)
+	println("uint32 complement:", ^uint32(5))
+	println("uint8 complement:", ^uint8(5))
+
+	// Shifts
+	var s uint8 = 0x81
+	println("uint8 << 1:", s<<1)
+	var n uint = 33
+	println("uint32 << 33:", uint32(1)<<n)
+	println("int32 >> 33:", int32(-8)>>n)
+	println("uint32 >> 31:", uint32(0x80000000)>>31)
+	one := int8(1)
+	println("int8 << 7:", one<<7)
+
+	// Conversions truncate
+	x := 300
+	println("byte(300):", byte(x))
+	println("int8(200):", int8(x-100))
+	println("uint16(-1):", uint16(x-301))
+	println("int32(1<<31):", int32(x*0+2147483648))
+	f := -3.9
+	println("int32(-3.9):", int32(f))
+	println("int(-3.9):", int(f))
+	f = 3.9
+	println("uint(3.9):", uint(f))
+
+	// Integer division truncates toward zero
+	a, c := 7, 2
+	println("7/2:", a/c, "-7/2:", -a/c, "-7%2:", -a%c)
+	println("const 7/2:", 7/2)
+	q := 17
+	q /= 5
+	println("17/=5:", q)
+	var m8 int8 = -128
+	var d8 int8 = -1
+	println("int8 -128/-1:", m8/d8)
+	println("uint8 200/3:", uint8(200)/uint8(c+1))
+
+	// Division by zero panics
+	_, err := divide(1, 0)
+	println("divide by zero:", err)
+
+	// Bit-twiddling code depending on wrapping
+	println("crc32:", crc32([]byte("hello world")))
+	println("fnv32:", fnv32("hello"))
+	buf := make([]byte, 5)
+	l := putUvarint(buf, 300)
+	println("varint:", l, buf[0], buf[1])
+}
@@ -0,0 +1,164 @@
+// Generated file based on int_overflow.go
+// Updated when compliance tests are re-run, DO NOT EDIT!
+
+import * as $ from "@goscript/builtin/index.js";
+
+// crc32 computes the IEEE CRC-32 checksum bit by bit.
+export function crc32(data: $.Bytes): number {
+	let crc = 4294967295
+	for (let _i = 0; _i < $.len(data); _i++) {
+		const b = data![_i]
+		{
+			crc = ((crc ^ (b as number)) >>> 0)
+			for (let i = 0; i < 8; i++) {
+				if ((crc & 1) == 1) {
+					crc = ((((crc >>> 1)) ^ 0xedb88320) >>> 0)
+				}
+				 else {
+					crc = (crc >>> 1)
+				}
+			}
+		}
+	}
+	return (~(crc) >>> 0)
+}
+
+// putUvarint encodes x as a varint.
+export function putUvarint(buf: $.Bytes, x: number): number {
+	let i = 0
+	for (; x >= 0x80; ) {
+		buf![i] = (((x) & 0xff) | 0x80)
+		x = (x >>> 7)
+		i++
+	}
+	buf![i] = ((x) & 0xff)
+	return i + 1
+}
+
+// fnv32 computes the FNV-1 hash, which relies on overflowing multiplication.
+export function fnv32(s: string): number {
+	let h = 2166136261
+	for (let i = 0; i < $.len(s); i++) {
+		h = (Math.imul(h, 16777619) >>> 0)
+		h = ((h ^ ($.indexString(s, i) as number)) >>> 0)
+	}
+	return h
+}
+
+export function divide(a: number, b: number): [number, string] {
+	let q: number = 0
+	let err: string = ""
+	{
+		const __defer = new $.DisposableStack();
+		try {
+			__defer.defer((__frame) => {
+				{
+					let r = $.recover(__frame)
+					if (r != null) {
+						err = $.mustTypeAssert<$.GoError>(r, 'error')!.Error()
+					}
+				}
+			});
+			;[q, err] = [$.intDiv(a, b), ""]
+			return [q, err]
+		} catch (__e) {
+			__defer.panic(__e)
+		} finally {
+			__defer.dispose()
+			return [q, err]
+		}
+	}
+}
+
+export async function main(): Promise<void> {
+	// Addition and subtraction wrap around
+	let b: number = 250
+	b = ((b + 10) & 0xff)
+	console.log("byte 250+10:", b)
+	b = 3
+	b = ((b - 5) & 0xff)
+	console.log("byte 3-5:", b)
+	let u8: number = 255
+	u8 = ((u8 + 1) & 0xff)
+	console.log("uint8 255++:", u8)
+
+	let i8: number = 127
+	i8 = ((i8 + 1) << 24 >> 24)
+	console.log("int8 127++:", i8)
+	i8 = -128
+	i8 = ((i8 - 1) << 24 >> 24)
+	console.log("int8 -128--:", i8)
+	i8 = -128
+	console.log("int8 -(-128):", (-(i8) << 24 >> 24))
+
+	let i16: number = 32767
+	i16 = ((i16 + 2) << 16 >> 16)
+	console.log("int16 32767+2:", i16)
+	let u16: number = 65535
+	u16 = (Math.imul(u16, u16) & 0xffff)
+	console.log("uint16 65535*65535:", u16)
+
+	let i32: number = 2147483647
+	i32 = ((i32 + 1) | 0)
+	console.log("int32 max+1:", i32)
+	i32 = 123456789
+	console.log("int32 123456789*1000:", (Math.imul(i32, 1000) | 0))
+	let u32: number = 4294967295
+	u32 = ((u32 + 1) >>> 0)
+	console.log("uint32 max+1:", u32)
+	u32 = 0
+	u32 = ((u32 - 1) >>> 0)
+	console.log("uint32 0-1:", u32)
+
+	// Bitwise operations on uint32 stay unsigned
+	u32 = 0x80000000
+	console.log("uint32 high bit:", ((u32 | 1) >>> 0), ((u32 & ~0x80000000) >>> 0), ((u32 ^ 0xffffffff) >>> 0))
+	console.log("uint32 complement:", 4294967290)
+	console.log("uint8 complement:", 250)
+
+	// Shifts
+	let s: number = 0x81
+	console.log("uint8 << 1:", ((s << 1) & 0xff))
+	let n: number = 33
+	console.log("uint32 << 33:", ($.shl32(1, n) >>> 0))
+	console.log("int32 >> 33:", $.shr32(-8, n))
+	console.log("uint32 >> 31:", 1)
+	let one = 1
+	console.log("int8 << 7:", ((one << 7) << 24 >> 24))
+
+	// Conversions truncate
+	let x = 300
+	console.log("byte(300):", ((x) & 0xff))
+	console.log("int8(200):", ((x - 100) << 24 >> 24))
+	console.log("uint16(-1):", ((x - 301) & 0xffff))
+	console.log("int32(1<<31):", ((x * 0 + 2147483648) | 0))
+	let f = -3.9
+	console.log("int32(-3.9):", ((f) | 0))
+	console.log("int(-3.9):", $.int(f))
+	f = 3.9
+	console.log("uint(3.9):", $.int(f))
+
+	// Integer division truncates toward zero
+	let [a, c] = [7, 2]
+	console.log("7/2:", $.intDiv(a, c), "-7/2:", $.intDiv(-a, c), "-7%2:", $.intRem(-a, c))
+	console.log("const 7/2:", 3)
+	let q = 17
+	q = Math.trunc(q / 5)
+	console.log("17/=5:", q)
+	let m8: number = -128
+	let d8: number = -1
+	console.log("int8 -128/-1:", ($.intDiv(m8, d8) << 24 >> 24))
+	console.log("uint8 200/3:", $.intDiv(200, ((c + 1) & 0xff)))
+
+	// Division by zero panics
+	let [, err] = divide(1, 0)
+	console.log("divide by zero:", err)
+
+	// Bit-twiddling code depending on wrapping
+	console.log("crc32:", crc32($.stringToBytes("hello world")))
+	console.log("fnv32:", fnv32("hello"))
+	let buf = new Uint8Array(5)
+	let l = putUvarint(buf, 300)
+	console.log("varint:", l, buf![0], buf![1])
+}
+
@@ -9,7 +9,7 @@ export type AsyncProcessor = null | {
 }
 
 $.registerInterfaceType(
-  'AsyncProcessor',
+  'main@github.com/aperturerobotics/goscript/compliance/tests/interface_async_method_call.AsyncProcessor',
   null, // Zero value for interface is null
   [{ name: "GetResult", args: [], returns: [{ type: { kind: $.TypeKind.Basic, name: "number" } }] }, { name: "Process", args: [{ name: "data", type: { kind: $.TypeKind.Basic, name: "number" } }], returns: [{ type: { kind: $.TypeKind.Basic, name: "number" } }] }]
 );
@@ -53,11 +53,11 @@ export class ChannelProcessor {
 
 	// Register this type with the runtime type system
 	static __typeInfo = $.registerStructType(
-	  'ChannelProcessor',
+	  'main@github.com/aperturerobotics/goscript/compliance/tests/interface_async_method_call.ChannelProcessor',
 	  new ChannelProcessor(),
 	  [{ name: "Process", args: [{ name: "data", type: { kind: $.TypeKind.Basic, name: "number" } }], returns: [{ type: { kind: $.TypeKind.Basic, name: "number" } }] }, { name: "GetResult", args: [], returns: [{ type: { kind: $.TypeKind.Basic, name: "number" } }] }],
 	  ChannelProcessor,
-	  {"ch": { kind: $.TypeKind.Channel, direction: "both", elemType: { kind: $.TypeKind.Basic, name: "number" } }}
+	  [{ name: "ch", type: { kind: $.TypeKind.Channel, direction: "both", elemType: { kind: $.TypeKind.Basic, name: "int" } } }]
 	);
 }
 
@@ -98,11 +98,11 @@ export class SimpleProcessor {
 
 	// Register this type with the runtime type system
 	static __typeInfo = $.registerStructType(
-	  'SimpleProcessor',
+	  'main@github.com/aperturerobotics/goscript/compliance/tests/interface_async_method_call.SimpleProcessor',
 	  new SimpleProcessor(),
 	  [{ name: "Process", args: [{ name: "data", type: { kind: $.TypeKind.Basic, name: "number" } }], returns: [{ type: { kind: $.TypeKind.Basic, name: "number" } }] }, { name: "GetResult", args: [], returns: [{ type: { kind: $.TypeKind.Basic, name: "number" } }] }],
 	  SimpleProcessor,
-	  {"value": { kind: $.TypeKind.Basic, name: "number" }}
+	  [{ name: "value", type: { kind: $.TypeKind.Basic, name: "int" } }]
 	);
 }
 
@@ -20,7 +20,7 @@ export type File = null | {
 } & io.Writer & io.Reader & io.ReaderAt & io.Seeker & io.Closer
 
 $.registerInterfaceType(
-  'File',
+  'main@github.com/aperturerobotics/goscript/compliance/tests/interface_embedding.File',
   null, // Zero value for interface is null
   [{ name: "Lock", args: [], returns: [{ type: { kind: $.TypeKind.Interface, name: 'GoError', methods: [{ name: 'Error', args: [], returns: [{ type: { kind: $.TypeKind.Basic, name: 'string' } }] }] } }] }, { name: "Name", args: [], returns: [{ type: { kind: $.TypeKind.Basic, name: "string" } }] }, { name: "Truncate", args: [{ name: "size", type: { kind: $.TypeKind.Basic, name: "number" } }], returns: [{ type: { kind: $.TypeKind.Interface, name: 'GoError', methods: [{ name: 'Error', args: [], returns: [{ type: { kind: $.TypeKind.Basic, name: 'string' } }] }] } }] }, { name: "Unlock", args: [], returns: [{ type: { kind: $.TypeKind.Interface, name: 'GoError', methods: [{ name: 'Error', args: [], returns: [{ type: { kind: $.TypeKind.Basic, name: 'string' } }] }] } }] }]
 );
@@ -140,11 +140,11 @@ export class MockFile {
 
 	// Register this type with the runtime type system
 	static __typeInfo = $.registerStructType(
-	  'MockFile',
+	  'main@github.com/aperturerobotics/goscript/compliance/tests/interface_embedding.MockFile',
 	  new MockFile(),
 	  [{ name: "Name", args: [], returns: [{ type: { kind: $.TypeKind.Basic, name: "string" } }] }, { name: "Write", args: [{ name: "p", type: { kind: $.TypeKind.Slice, elemType: { kind: $.TypeKind.Basic, name: "number" } } }], returns: [{ type: { kind: $.TypeKind.Basic, name: "number" } }, { type: { kind: $.TypeKind.Interface, name: 'GoError', methods: [{ name: 'Error', args: [], returns: [{ type: { kind: $.TypeKind.Basic, name: 'string' } }] }] } }] }, { name: "Read", args: [{ name: "p", type: { kind: $.TypeKind.Slice, elemType: { kind: $.TypeKind.Basic, name: "number" } } }], returns: [{ type: { kind: $.TypeKind.Basic, name: "number" } }, { type: { kind: $.TypeKind.Interface, name: 'GoError', methods: [{ name: 'Error', args: [], returns: [{ type: { kind: $.TypeKind.Basic, name: 'string' } }] }] } }] }, { name: "ReadAt", args: [{ name: "p", type: { kind: $.TypeKind.Slice, elemType: { kind: $.TypeKind.Basic, name: "number" } } }, { name: "off", type: { kind: $.TypeKind.Basic, name: "number" } }], returns: [{ type: { kind: $.TypeKind.Basic, name: "number" } }, { type: { kind: $.TypeKind.Interface, name: 'GoError', methods: [{ name: 'Error', args: [], returns: [{ type: { kind: $.TypeKind.Basic, name: 'string' } }] }] } }] }, { name: "Seek", args: [{ name: "offset", type: { kind: $.TypeKind.Basic, name: "number" } }, { name: "whence", type: { kind: $.TypeKind.Basic, name: "number" } }], returns: [{ type: { kind: $.TypeKind.Basic, name: "number" } }, { type: { kind: $.TypeKind.Interface, name: 'GoError', methods: [{ name: 'Error', args: [], returns: [{ type: { kind: $.TypeKind.Basic, name: 'string' } }] }] } }] }, { name: "Close", args: [], returns: [{ type: { kind: $.TypeKind.Interface, name: 'GoError', methods: [{ name: 'Error', args: [], returns: [{ type: { kind: $.TypeKind.Basic, name: 'string' } }] }] } }] }, { name: "Lock", args: [], returns: [{ type: { kind: $.TypeKind.Interface, name: 'GoError', methods: [{ name: 'Error', args: [], returns: [{ type: { kind: $.TypeKind.Basic, name: 'string' } }] }] } }] }, { name: "Unlock", args: [], returns: [{ type: { kind: $.TypeKind.Interface, name: 'GoError', methods: [{ name: 'Error', args: [], returns: [{ type: { kind: $.TypeKind.Basic, name: 'string' } }] }] } }] }, { name: "Truncate", args: [{ name: "size", type: { kind: $.TypeKind.Basic, name: "number" } }], returns: [{ type: { kind: $.TypeKind.Interface, name: 'GoError', methods: [{ name: 'Error', args: [], returns: [{ type: { kind: $.TypeKind.Basic, name: 'string' } }] }] } }] }],
 	  MockFile,
-	  {"filename": { kind: $.TypeKind.Basic, name: "string" }, "content": { kind: $.TypeKind.Slice, elemType: { kind: $.TypeKind.Basic, name: "number" } }, "position": { kind: $.TypeKind.Basic, name: "number" }}
+	  [{ name: "filename", type: { kind: $.TypeKind.Basic, name: "string" } }, { name: "content", type: { kind: $.TypeKind.Slice, elemType: { kind: $.TypeKind.Basic, name: "byte" } } }, { name: "position", type: { kind: $.TypeKind.Basic, name: "int64" } }]
 	);
 }
 
@@ -223,11 +223,11 @@ export class file {
 
 	// Register this type with the runtime type system
 	static __typeInfo = $.registerStructType(
-	  'file',
+	  'main@github.com/aperturerobotics/goscript/compliance/tests/interface_embedding.file',
 	  new file(),
 	  [{ name: "Name", args: [], returns: [{ type: { kind: $.TypeKind.Basic, name: "string" } }] }],
 	  file,
-	  {"File": "File", "name": { kind: $.TypeKind.Basic, name: "string" }}
+	  [{ name: "File", type: "main@github.com/aperturerobotics/goscript/compliance/tests/interface_embedding.File", embedded: true }, { name: "name", type: { kind: $.TypeKind.Basic, name: "string" } }]
 	);
 }
 
@@ -281,11 +281,11 @@ export class qualifiedFile {
 
 	// Register this type with the runtime type system
 	static __typeInfo = $.registerStructType(
-	  'qualifiedFile',
+	  'main@github.com/aperturerobotics/goscript/compliance/tests/interface_embedding.qualifiedFile',
 	  new qualifiedFile(),
 	  [],
 	  qualifiedFile,
-	  {"File": "File", "metadata": { kind: $.TypeKind.Basic, name: "string" }}
+	  [{ name: "File", type: "github.com/aperturerobotics/goscript/compliance/tests/interface_embedding/subpkg.File", embedded: true }, { name: "metadata", type: { kind: $.TypeKind.Basic, name: "string" } }]
 	);
 }
 
@@ -8,7 +8,7 @@ export type MultiParamReturner = null | {
 }
 
 $.registerInterfaceType(
-  'MultiParamReturner',
+  'main@github.com/aperturerobotics/goscript/compliance/tests/interface_multi_param_return.MultiParamReturner',
   null, // Zero value for interface is null
   [{ name: "Process", args: [{ name: "data", type: { kind: $.TypeKind.Slice, elemType: { kind: $.TypeKind.Basic, name: "number" } } }, { name: "count", type: { kind: $.TypeKind.Basic, name: "number" } }, { name: "_", type: { kind: $.TypeKind.Basic, name: "string" } }], returns: [{ type: { kind: $.TypeKind.Basic, name: "boolean" } }, { type: { kind: $.TypeKind.Interface, name: 'GoError', methods: [{ name: 'Error', args: [], returns: [{ type: { kind: $.TypeKind.Basic, name: 'string' } }] }] } }] }]
 );
@@ -39,16 +39,16 @@ export class MyProcessor {
 
 	// Register this type with the runtime type system
 	static __typeInfo = $.registerStructType(
-	  'MyProcessor',
+	  'main@github.com/aperturerobotics/goscript/compliance/tests/interface_multi_param_return.MyProcessor',
 	  new MyProcessor(),
 	  [{ name: "Process", args: [{ name: "data", type: { kind: $.TypeKind.Slice, elemType: { kind: $.TypeKind.Basic, name: "number" } } }, { name: "count", type: { kind: $.TypeKind.Basic, name: "number" } }, { name: "_", type: { kind: $.TypeKind.Basic, name: "string" } }], returns: [{ type: { kind: $.TypeKind.Basic, name: "boolean" } }, { type: { kind: $.TypeKind.Interface, name: 'GoError', methods: [{ name: 'Error', args: [], returns: [{ type: { kind: $.TypeKind.Basic, name: 'string' } }] }] } }] }],
 	  MyProcessor,
-	  {}
+	  []
 	);
 }
 
 export async function main(): Promise<void> {
-	let processor: MultiParamReturner = new MyProcessor({})
+	let processor: MultiParamReturner = $.markStructValue(new MyProcessor({}))
 
 	let data = new Uint8Array([1, 2, 3])
 	let [success, ] = processor!.Process(data, 5, "unused")
@@ -8,7 +8,7 @@ export type MyInterface = null | {
 }
 
 $.registerInterfaceType(
-  'MyInterface',
+  'main@github.com/aperturerobotics/goscript/compliance/tests/interface_to_interface_type_assertion.MyInterface',
   null, // Zero value for interface is null
   [{ name: "Method1", args: [], returns: [{ type: { kind: $.TypeKind.Basic, name: "number" } }] }]
 );
@@ -46,11 +46,11 @@ export class MyStruct {
 
 	// Register this type with the runtime type system
 	static __typeInfo = $.registerStructType(
-	  'MyStruct',
+	  'main@github.com/aperturerobotics/goscript/compliance/tests/interface_to_interface_type_assertion.MyStruct',
 	  new MyStruct(),
 	  [{ name: "Method1", args: [], returns: [{ type: { kind: $.TypeKind.Basic, name: "number" } }] }],
 	  MyStruct,
-	  {"Value": { kind: $.TypeKind.Basic, name: "number" }}
+	  [{ name: "Value", type: { kind: $.TypeKind.Basic, name: "int" } }]
 	);
 }
 
@@ -59,7 +59,7 @@ export type MyOtherInterface = null | {
 }
 
 $.registerInterfaceType(
-  'MyOtherInterface',
+  'main@github.com/aperturerobotics/goscript/compliance/tests/interface_to_interface_type_assertion.MyOtherInterface',
   null, // Zero value for interface is null
   [{ name: "Method1", args: [], returns: [{ type: { kind: $.TypeKind.Basic, name: "number" } }] }]
 );
@@ -67,9 +67,9 @@ $.registerInterfaceType(
 export async function main(): Promise<void> {
 	let i: MyInterface = null
 	let s = new MyStruct({Value: 10})
-	i = s.clone()
+	i = $.markStructValue(s.clone())
 
-	let { ok: ok } = $.typeAssert<MyOtherInterface>(i, 'MyOtherInterface')
+	let { ok: ok } = $.typeAssert<MyOtherInterface>(i, 'main@github.com/aperturerobotics/goscript/compliance/tests/interface_to_interface_type_assertion.MyOtherInterface')
 	if (ok) {
 		console.log("Type assertion successful")
 	}
@@ -8,7 +8,7 @@ export type MyInterface = null | {
 }
 
 $.registerInterfaceType(
-  'MyInterface',
+  'main@github.com/aperturerobotics/goscript/compliance/tests/interface_type_assertion.MyInterface',
   null, // Zero value for interface is null
   [{ name: "Method1", args: [], returns: [{ type: { kind: $.TypeKind.Basic, name: "number" } }] }]
 );
@@ -46,20 +46,20 @@ export class MyStruct {
 
 	// Register this type with the runtime type system
 	static __typeInfo = $.registerStructType(
-	  'MyStruct',
+	  'main@github.com/aperturerobotics/goscript/compliance/tests/interface_type_assertion.MyStruct',
 	  new MyStruct(),
 	  [{ name: "Method1", args: [], returns: [{ type: { kind: $.TypeKind.Basic, name: "number" } }] }],
 	  MyStruct,
-	  {"Value": { kind: $.TypeKind.Basic, name: "number" }}
+	  [{ name: "Value", type: { kind: $.TypeKind.Basic, name: "int" } }]
 	);
 }
 
 export async function main(): Promise<void> {
 	let i: MyInterface = null
 	let s = new MyStruct({Value: 10})
-	i = s.clone()
+	i = $.markStructValue(s.clone())
 
-	let { ok: ok } = $.typeAssert<MyStruct>(i, 'MyStruct')
+	let { ok: ok } = $.typeAssert<MyStruct>(i, 'main@github.com/aperturerobotics/goscript/compliance/tests/interface_type_assertion.MyStruct')
 	if (ok) {
 		console.log("Type assertion successful")
 	}
@@ -68,7 +68,7 @@ export async function main(): Promise<void> {
 	}
 
 	// try a second time since this generates something different when using = and not :=
-	({ ok: ok } = $.typeAssert<MyStruct | null>(i, {kind: $.TypeKind.Pointer, elemType: 'MyStruct'}))
+	({ ok: ok } = $.typeAssert<MyStruct | null>(i, {kind: $.TypeKind.Pointer, elemType: 'main@github.com/aperturerobotics/goscript/compliance/tests/interface_type_assertion.MyStruct'}))
 
 	// expected
 	if (ok) {
@@ -80,7 +80,7 @@ export async function main(): Promise<void> {
 	}
 
 	// assign result to a variable
-	let { value: val, ok: ok2 } = $.typeAssert<MyStruct>(i, 'MyStruct')
+	let { value: val, ok: ok2 } = $.typeAssert<MyStruct>(i, 'main@github.com/aperturerobotics/goscript/compliance/tests/interface_type_assertion.MyStruct')
 	if (!ok2) {
 		console.log("type assertion failed")
 	}
@@ -0,0 +1,56 @@
+package main
+
+import (
+	"runtime"
+	"sync"
+	"sync/atomic"
+	"time"
+)
+
+func main() {
+	// A CPU-bound loop lets the timer run, which ends it.
+	var fired atomic.Bool
+	time.AfterFunc(time.Millisecond, func() {
+		fired.Store(true)
+	})
+	n := 0
+	for !fired.Load() {
+		n++
+	}
+	println("for loop yielded:", n > 0)
+
+	// The same in a range loop.
+	fired.Store(false)
+	time.AfterFunc(time.Millisecond, func() {
+		fired.Store(true)
+	})
+	for range 1 << 52 {
+		if fired.Load() {
+			break
+		}
+	}
+	println("range loop yielded:", fired.Load())
+
+	// Goroutines spinning at the same time take turns: each spins until the
+	// other made progress. Only async functions yield, which the call to
+	// Gosched makes these.
+	var wg sync.WaitGroup
+	var counts [2]atomic.Int64
+	for i := range counts {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			runtime.Gosched()
+			for {
+				counts[i].Add(1)
+				if counts[1-i].Load() != 0 {
+					break
+				}
+			}
+		}()
+	}
+	wg.Wait()
+	println("goroutines took turns:", counts[0].Load() > 0, counts[1].Load() > 0)
+
+	println("done")
+}
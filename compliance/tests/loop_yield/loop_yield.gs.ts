@@ -0,0 +1,73 @@
+// Generated file based on loop_yield.go
+// Updated when compliance tests are re-run, DO NOT EDIT!
+
+import * as $ from "@goscript/builtin/index.js";
+
+import * as runtime from "@goscript/runtime/index.js"
+
+import * as sync from "@goscript/sync/index.js"
+
+import * as atomic from "@goscript/sync/atomic/index.js"
+
+import * as time from "@goscript/time/index.js"
+
+export async function main(): Promise<void> {
+	using __defer = new $.DisposableStack();
+	// A CPU-bound loop lets the timer run, which ends it.
+	let fired: atomic.Bool = new atomic.Bool()
+	time.AfterFunc(time.Millisecond, (): void => {
+		fired.Store(true)
+	})
+	let n = 0
+	for (; !fired.Load(); ) {
+		if ($.shouldYield()) await $.gosched()
+		n++
+	}
+	console.log("for loop yielded:", n > 0)
+
+	// The same in a range loop.
+	fired.Store(false)
+	time.AfterFunc(time.Millisecond, (): void => {
+		fired.Store(true)
+	})
+	for (let _i = 0; _i < 4503599627370496; _i++) {{
+		if ($.shouldYield()) await $.gosched()
+		if (fired.Load()) {
+			break
+		}
+	}
+}
+console.log("range loop yielded:", fired.Load())
+
+// Goroutines spinning at the same time take turns: each spins until the
+// other made progress. Only async functions yield, which the call to
+// Gosched makes these.
+let wg: sync.WaitGroup = new sync.WaitGroup()
+let counts: atomic.Int64[] = [new atomic.Int64(), new atomic.Int64()]
+for (let i = 0; i < $.len(counts); i++) {
+	{
+		if ($.shouldYield()) await $.gosched()
+		using __defer = new $.DisposableStack();
+		wg.Add(1)
+		$.go(async () => {
+			using __defer = new $.DisposableStack();
+			__defer.defer(() => {
+				wg.Done()
+			});
+			await runtime.Gosched()
+			for (; ; ) {
+				if ($.shouldYield()) await $.gosched()
+				counts![i].Add(1)
+				if (counts![1 - i].Load() != 0) {
+					break
+				}
+			}
+		}, "loop_yield.go:41")
+	}
+}
+await wg.Wait()
+console.log("goroutines took turns:", counts![0].Load() > 0, counts![1].Load() > 0)
+
+console.log("done")
+}
+
@@ -9,11 +9,25 @@ export async function main(): Promise<void> {
 	// rather than direct type expressions like []int or map[string]int
 
 	type MySlice = $.Slice<number>;
+
+	$.registerNamedType(
+	  'main@github.com/aperturerobotics/goscript/compliance/tests/make_named_types.MySlice',
+	  null,
+	  [],
+	  { kind: $.TypeKind.Slice, elemType: { kind: $.TypeKind.Basic, name: "number" } }
+	);
 	let s: MySlice = $.makeSlice<number>(5, undefined, 'number')
 	console.log("Length:", $.len(s))
 
 	// Test make() with named map type
 	type MyMap = Map<string, number> | null;
+
+	$.registerNamedType(
+	  'main@github.com/aperturerobotics/goscript/compliance/tests/make_named_types.MyMap',
+	  null,
+	  [],
+	  { kind: $.TypeKind.Map, keyType: { kind: $.TypeKind.Basic, name: "string" }, elemType: { kind: $.TypeKind.Basic, name: "number" } }
+	);
 	let m: MyMap = $.makeMap<string, number>()
 	$.mapSet(m, "test", 42)
 	console.log("Value:", $.mapGet(m, "test", 0)[0])
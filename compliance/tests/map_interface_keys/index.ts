@@ -0,0 +1 @@
+export type { Name } from "./map_interface_keys.gs.js"
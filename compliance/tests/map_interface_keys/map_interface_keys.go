@@ -0,0 +1,51 @@
+package main
+
+import "math"
+
+type Name string
+
+func main() {
+	// Keys of different types with the same value are different keys
+	m := map[any]string{1: "int", 1.0: "float64"}
+	m[int64(1)] = "int64"
+	m["a"] = "string"
+	m[Name("a")] = "Name"
+	println("len:", len(m))
+	println("int:", m[1], "float64:", m[1.0], "int64:", m[int64(1)])
+	println("string:", m["a"], "Name:", m[Name("a")])
+
+	_, ok := m[uint8(1)]
+	println("uint8 found:", ok)
+
+	delete(m, Name("a"))
+	println("after delete:", len(m), m["a"])
+
+	// Keys held in an interface before are found by their value
+	var k any = 2.5
+	m[k] = "k"
+	println("k:", m[2.5], m[k])
+	count := 0
+	for key := range m {
+		if _, ok := m[key]; ok {
+			count++
+		}
+	}
+	println("found while ranging:", count)
+	for key := range m {
+		delete(m, key)
+	}
+	println("after clearing:", len(m))
+
+	// NaN is not equal to itself, so each NaN is a different key
+	n := map[any]int{}
+	n[math.NaN()] = 1
+	n[math.NaN()] = 2
+	_, ok = n[math.NaN()]
+	println("NaN keys:", len(n), ok)
+	delete(n, math.NaN())
+	println("after NaN delete:", len(n))
+
+	var nan any = math.NaN()
+	n[nan] = 3
+	println("NaN keys:", len(n))
+}
@@ -0,0 +1,69 @@
+// Generated file based on map_interface_keys.go
+// Updated when compliance tests are re-run, DO NOT EDIT!
+
+import * as $ from "@goscript/builtin/index.js";
+
+import * as math from "@goscript/math/index.js"
+
+export type Name = string;
+
+$.registerNamedType(
+  'main@github.com/aperturerobotics/goscript/compliance/tests/map_interface_keys.Name',
+  "",
+  [],
+  { kind: $.TypeKind.Basic, name: "string" }
+);
+
+export async function main(): Promise<void> {
+	// Keys of different types with the same value are different keys
+	let m = new $.InterfaceKeyMap([[$.typedMapKey(1, "int"), "int"], [$.typedMapKey(1.0, "float64"), "float64"]])
+	$.mapSet(m, $.typedMapKey(1, "int64"), "int64")
+	$.mapSet(m, $.typedMapKey("a", "string"), "string")
+	$.mapSet(m, $.typedMapKey(("a" as Name), "main@github.com/aperturerobotics/goscript/compliance/tests/map_interface_keys.Name"), "Name")
+	console.log("len:", $.len(m))
+	console.log("int:", $.mapGet(m, $.typedMapKey(1, "int"), "")[0], "float64:", $.mapGet(m, $.typedMapKey(1.0, "float64"), "")[0], "int64:", $.mapGet(m, $.typedMapKey(1, "int64"), "")[0])
+	console.log("string:", $.mapGet(m, $.typedMapKey("a", "string"), "")[0], "Name:", $.mapGet(m, $.typedMapKey(("a" as Name), "main@github.com/aperturerobotics/goscript/compliance/tests/map_interface_keys.Name"), "")[0])
+
+	let [, ok] = $.mapGet(m, $.typedMapKey(1, "uint8"), "")
+	console.log("uint8 found:", ok)
+
+	$.deleteMapEntry(m, $.typedMapKey(("a" as Name), "main@github.com/aperturerobotics/goscript/compliance/tests/map_interface_keys.Name"))
+	console.log("after delete:", $.len(m), $.mapGet(m, $.typedMapKey("a", "string"), "")[0])
+
+	// Keys held in an interface before are found by their value
+	let k: null | any = 2.5
+	$.mapSet(m, k, "k")
+	console.log("k:", $.mapGet(m, $.typedMapKey(2.5, "float64"), "")[0], $.mapGet(m, k, "")[0])
+	let count = 0
+	for (const [key, _v] of $.mapEntries(m)) {
+		{
+			{
+				let [, ok] = $.mapGet(m, key, "")
+				if (ok) {
+					count++
+				}
+			}
+		}
+	}
+	console.log("found while ranging:", count)
+	for (const [key, _v] of $.mapEntries(m)) {
+		{
+			$.deleteMapEntry(m, key)
+		}
+	}
+	console.log("after clearing:", $.len(m))
+
+	// NaN is not equal to itself, so each NaN is a different key
+	let n = new $.InterfaceKeyMap([])
+	$.mapSet(n, $.typedMapKey(math.NaN(), "float64"), 1)
+	$.mapSet(n, $.typedMapKey(math.NaN(), "float64"), 2)
+	;[, ok] = $.mapGet(n, $.typedMapKey(math.NaN(), "float64"), 0)
+	console.log("NaN keys:", $.len(n), ok)
+	$.deleteMapEntry(n, $.typedMapKey(math.NaN(), "float64"))
+	console.log("after NaN delete:", $.len(n))
+
+	let nan: null | any = math.NaN()
+	$.mapSet(n, nan, 3)
+	console.log("NaN keys:", $.len(n))
+}
+
@@ -0,0 +1,47 @@
+package main
+
+func main() {
+	m := make(map[int]int)
+	for i := 0; i < 32; i++ {
+		m[i] = i * i
+	}
+
+	// Go does not iterate in insertion order
+	inOrder := 0
+	for round := 0; round < 3; round++ {
+		next, sorted := 0, true
+		for k := range m {
+			if k != next {
+				sorted = false
+			}
+			next++
+		}
+		if sorted {
+			inOrder++
+		}
+	}
+	println("rounds in insertion order:", inOrder)
+
+	// Every entry is visited exactly once
+	sum, count := 0, 0
+	for k, v := range m {
+		if v != k*k {
+			println("wrong value for", k)
+		}
+		sum += k
+		count++
+	}
+	println("visited:", count, "sum:", sum)
+
+	// Entries deleted before they are reached are not produced
+	visited := 0
+	for k := range m {
+		for other := range m {
+			if other != k {
+				delete(m, other)
+			}
+		}
+		visited++
+	}
+	println("visited after deleting:", visited, "remaining:", len(m))
+}
@@ -0,0 +1,59 @@
+// Generated file based on map_iteration_order.go
+// Updated when compliance tests are re-run, DO NOT EDIT!
+
+import * as $ from "@goscript/builtin/index.js";
+
+export async function main(): Promise<void> {
+	let m = $.makeMap<number, number>()
+	for (let i = 0; i < 32; i++) {
+		$.mapSet(m, i, i * i)
+	}
+
+	// Go does not iterate in insertion order
+	let inOrder = 0
+	for (let round = 0; round < 3; round++) {
+		let [next, sorted] = [0, true]
+		for (const [k, _v] of $.mapEntries(m)) {
+			{
+				if (k != next) {
+					sorted = false
+				}
+				next++
+			}
+		}
+		if (sorted) {
+			inOrder++
+		}
+	}
+	console.log("rounds in insertion order:", inOrder)
+
+	// Every entry is visited exactly once
+	let [sum, count] = [0, 0]
+	for (const [k, v] of $.mapEntries(m)) {
+		{
+			if (v != k * k) {
+				console.log("wrong value for", k)
+			}
+			sum += k
+			count++
+		}
+	}
+	console.log("visited:", count, "sum:", sum)
+
+	// Entries deleted before they are reached are not produced
+	let visited = 0
+	for (const [k, _v] of $.mapEntries(m)) {
+		{
+			for (const [other, _v] of $.mapEntries(m)) {
+				{
+					if (other != k) {
+						$.deleteMapEntry(m, other)
+					}
+				}
+			}
+			visited++
+		}
+	}
+	console.log("visited after deleting:", visited, "remaining:", $.len(m))
+}
+
@@ -0,0 +1,2 @@
+export { Edge, Label, Node, Point, Ref } from "./map_struct_keys.gs.js"
+export type { Key } from "./map_struct_keys.gs.js"
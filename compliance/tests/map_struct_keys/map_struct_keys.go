@@ -0,0 +1,104 @@
+package main
+
+type Point struct {
+	X, Y int
+}
+
+type Edge struct {
+	From, To Point
+}
+
+type Node struct {
+	Name string
+}
+
+type Ref struct {
+	N *Node
+}
+
+type Label struct {
+	Point
+	Text string
+}
+
+type Key interface{}
+
+func main() {
+	// Struct keys compare by value
+	dist := make(map[Point]int)
+	dist[Point{1, 2}] = 3
+	dist[Point{X: 1, Y: 2}] += 4
+	p := Point{0, 0}
+	dist[p] = 1
+	p.X = 5 // does not change the stored key
+	v, ok := dist[Point{1, 2}]
+	println("Point{1,2}:", v, ok)
+	_, ok = dist[Point{5, 0}]
+	println("Point{5,0} present:", ok)
+	_, ok = dist[Point{0, 0}]
+	println("Point{0,0} present:", ok)
+	println("len:", len(dist))
+	delete(dist, Point{1, 2})
+	println("len after delete:", len(dist))
+
+	// Nested struct keys
+	edges := map[Edge]string{
+		{Point{0, 0}, Point{1, 1}}: "diagonal",
+	}
+	println("edge:", edges[Edge{From: Point{0, 0}, To: Point{1, 1}}])
+
+	// Positional literals with embedded fields
+	labels := map[Label]int{{Point{1, 2}, "a"}: 1}
+	labels[Label{Point{1, 2}, "a"}]++
+	labels[Label{Point: Point{2, 1}, Text: "a"}] = 5
+	l := Label{Point{2, 1}, "a"}
+	println("labels:", len(labels), labels[Label{Point{1, 2}, "a"}], labels[l], l.X, l.Text)
+
+	// Array keys
+	grid := map[[2]int]bool{}
+	grid[[2]int{3, 4}] = true
+	key := [2]int{3, 4}
+	println("array key:", grid[key], grid[[2]int{4, 3}])
+
+	// Pointer fields compare by identity
+	n1, n2 := &Node{"a"}, &Node{"a"}
+	refs := map[Ref]int{}
+	refs[Ref{n1}] = 1
+	refs[Ref{n2}] = 2
+	refs[Ref{n1}] = 3
+	println("refs:", len(refs), refs[Ref{n1}], refs[Ref{n2}], refs[Ref{nil}])
+
+	// Interface keys holding structs, strings and numbers
+	seen := map[Key]int{}
+	seen[Point{1, 1}] = 1
+	seen[Point{1, 1}]++
+	seen["1"] = 10
+	seen[1] = 20
+	println("interface keys:", len(seen), seen[Point{1, 1}], seen["1"], seen[1])
+
+	// Pointers held in interface keys compare by identity, struct values by value
+	p1, p2 := &Point{7, 7}, &Point{7, 7}
+	boxed := map[any]bool{}
+	boxed[p1] = true
+	boxed[*p2] = true
+	var held any = *p1
+	println("interface pointers:", boxed[p1], boxed[p2], boxed[Point{7, 7}], boxed[held], len(boxed))
+	stored := 0
+	for k := range boxed {
+		if k == any(p1) {
+			stored++
+		}
+	}
+	println("stored pointer keys:", stored)
+
+	// Pointer keys still compare by identity
+	visited := map[*Node]bool{n1: true}
+	println("pointer keys:", visited[n1], visited[n2])
+
+	// Range yields the stored keys
+	sum := 0
+	for k, v := range dist {
+		sum += k.X + k.Y + v
+	}
+	println("sum:", sum)
+}
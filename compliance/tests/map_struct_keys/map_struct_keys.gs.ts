@@ -0,0 +1,328 @@
+// Generated file based on map_struct_keys.go
+// Updated when compliance tests are re-run, DO NOT EDIT!
+
+import * as $ from "@goscript/builtin/index.js";
+
+export class Point {
+	public get X(): number {
+		return this._fields.X.value
+	}
+	public set X(value: number) {
+		this._fields.X.value = value
+	}
+
+	public get Y(): number {
+		return this._fields.Y.value
+	}
+	public set Y(value: number) {
+		this._fields.Y.value = value
+	}
+
+	public _fields: {
+		X: $.VarRef<number>;
+		Y: $.VarRef<number>;
+	}
+
+	constructor(init?: Partial<{X?: number, Y?: number}>) {
+		this._fields = {
+			X: $.varRef(init?.X ?? 0),
+			Y: $.varRef(init?.Y ?? 0)
+		}
+	}
+
+	public clone(): Point {
+		const cloned = new Point()
+		cloned._fields = {
+			X: $.varRef(this._fields.X.value),
+			Y: $.varRef(this._fields.Y.value)
+		}
+		return cloned
+	}
+
+	// Register this type with the runtime type system
+	static __typeInfo = $.registerStructType(
+	  'main@github.com/aperturerobotics/goscript/compliance/tests/map_struct_keys.Point',
+	  new Point(),
+	  [],
+	  Point,
+	  [{ name: "X", type: { kind: $.TypeKind.Basic, name: "int" } }, { name: "Y", type: { kind: $.TypeKind.Basic, name: "int" } }]
+	);
+}
+
+export class Edge {
+	public get From(): Point {
+		return this._fields.From.value
+	}
+	public set From(value: Point) {
+		this._fields.From.value = value
+	}
+
+	public get To(): Point {
+		return this._fields.To.value
+	}
+	public set To(value: Point) {
+		this._fields.To.value = value
+	}
+
+	public _fields: {
+		From: $.VarRef<Point>;
+		To: $.VarRef<Point>;
+	}
+
+	constructor(init?: Partial<{From?: Point, To?: Point}>) {
+		this._fields = {
+			From: $.varRef(init?.From?.clone() ?? new Point()),
+			To: $.varRef(init?.To?.clone() ?? new Point())
+		}
+	}
+
+	public clone(): Edge {
+		const cloned = new Edge()
+		cloned._fields = {
+			From: $.varRef(this._fields.From.value?.clone() ?? null),
+			To: $.varRef(this._fields.To.value?.clone() ?? null)
+		}
+		return cloned
+	}
+
+	// Register this type with the runtime type system
+	static __typeInfo = $.registerStructType(
+	  'main@github.com/aperturerobotics/goscript/compliance/tests/map_struct_keys.Edge',
+	  new Edge(),
+	  [],
+	  Edge,
+	  [{ name: "From", type: "main@github.com/aperturerobotics/goscript/compliance/tests/map_struct_keys.Point" }, { name: "To", type: "main@github.com/aperturerobotics/goscript/compliance/tests/map_struct_keys.Point" }]
+	);
+}
+
+export class Node {
+	public get Name(): string {
+		return this._fields.Name.value
+	}
+	public set Name(value: string) {
+		this._fields.Name.value = value
+	}
+
+	public _fields: {
+		Name: $.VarRef<string>;
+	}
+
+	constructor(init?: Partial<{Name?: string}>) {
+		this._fields = {
+			Name: $.varRef(init?.Name ?? "")
+		}
+	}
+
+	public clone(): Node {
+		const cloned = new Node()
+		cloned._fields = {
+			Name: $.varRef(this._fields.Name.value)
+		}
+		return cloned
+	}
+
+	// Register this type with the runtime type system
+	static __typeInfo = $.registerStructType(
+	  'main@github.com/aperturerobotics/goscript/compliance/tests/map_struct_keys.Node',
+	  new Node(),
+	  [],
+	  Node,
+	  [{ name: "Name", type: { kind: $.TypeKind.Basic, name: "string" } }]
+	);
+}
+
+export class Ref {
+	public get N(): Node | null {
+		return this._fields.N.value
+	}
+	public set N(value: Node | null) {
+		this._fields.N.value = value
+	}
+
+	public _fields: {
+		N: $.VarRef<Node | null>;
+	}
+
+	constructor(init?: Partial<{N?: Node | null}>) {
+		this._fields = {
+			N: $.varRef(init?.N ?? null)
+		}
+	}
+
+	public clone(): Ref {
+		const cloned = new Ref()
+		cloned._fields = {
+			N: $.varRef(this._fields.N.value)
+		}
+		return cloned
+	}
+
+	// Register this type with the runtime type system
+	static __typeInfo = $.registerStructType(
+	  'main@github.com/aperturerobotics/goscript/compliance/tests/map_struct_keys.Ref',
+	  new Ref(),
+	  [],
+	  Ref,
+	  [{ name: "N", type: { kind: $.TypeKind.Pointer, elemType: "main@github.com/aperturerobotics/goscript/compliance/tests/map_struct_keys.Node" } }]
+	);
+}
+
+export class Label {
+	public get Text(): string {
+		return this._fields.Text.value
+	}
+	public set Text(value: string) {
+		this._fields.Text.value = value
+	}
+
+	public get Point(): Point {
+		return this._fields.Point.value
+	}
+	public set Point(value: Point) {
+		this._fields.Point.value = value
+	}
+
+	public _fields: {
+		Point: $.VarRef<Point>;
+		Text: $.VarRef<string>;
+	}
+
+	constructor(init?: Partial<{Point?: Partial<ConstructorParameters<typeof Point>[0]>, Text?: string}>) {
+		this._fields = {
+			Point: $.varRef(new Point(init?.Point)),
+			Text: $.varRef(init?.Text ?? "")
+		}
+	}
+
+	public clone(): Label {
+		const cloned = new Label()
+		cloned._fields = {
+			Point: $.varRef(this._fields.Point.value.clone()),
+			Text: $.varRef(this._fields.Text.value)
+		}
+		return cloned
+	}
+
+	public get X(): number {
+		return this.Point.X
+	}
+	public set X(value: number) {
+		this.Point.X = value
+	}
+
+	public get Y(): number {
+		return this.Point.Y
+	}
+	public set Y(value: number) {
+		this.Point.Y = value
+	}
+
+	// Register this type with the runtime type system
+	static __typeInfo = $.registerStructType(
+	  'main@github.com/aperturerobotics/goscript/compliance/tests/map_struct_keys.Label',
+	  new Label(),
+	  [],
+	  Label,
+	  [{ name: "Point", type: "main@github.com/aperturerobotics/goscript/compliance/tests/map_struct_keys.Point", embedded: true }, { name: "Text", type: { kind: $.TypeKind.Basic, name: "string" } }]
+	);
+}
+
+export type Key = null | any
+
+$.registerInterfaceType(
+  'main@github.com/aperturerobotics/goscript/compliance/tests/map_struct_keys.Key',
+  null, // Zero value for interface is null
+  []
+);
+
+export async function main(): Promise<void> {
+	// Struct keys compare by value
+	let dist = $.makeHashMap<Point, number>()
+	$.mapSet(dist, new Point({X: 1, Y: 2}), 3)
+	{
+		const _tmp0 = new Point({X: 1, Y: 2})
+		$.mapSet(dist, _tmp0, $.mapGet(dist, _tmp0, 0)[0] + 4)
+	}
+	let p = new Point({X: 0, Y: 0})
+	$.mapSet(dist, p, 1)
+	p.X = 5 // does not change the stored key
+	let [v, ok] = $.mapGet(dist, new Point({X: 1, Y: 2}), 0)
+	console.log("Point{1,2}:", v, ok)
+	;[, ok] = $.mapGet(dist, new Point({X: 5, Y: 0}), 0)
+	console.log("Point{5,0} present:", ok)
+	;[, ok] = $.mapGet(dist, new Point({X: 0, Y: 0}), 0)
+	console.log("Point{0,0} present:", ok)
+	console.log("len:", $.len(dist))
+	$.deleteMapEntry(dist, new Point({X: 1, Y: 2}))
+	console.log("len after delete:", $.len(dist))
+
+	// Nested struct keys
+	let edges = new $.HashMap([[new Edge({From: new Point({X: 0, Y: 0}), To: new Point({X: 1, Y: 1})}), "diagonal"]])
+	console.log("edge:", $.mapGet(edges, new Edge({From: new Point({X: 0, Y: 0}), To: new Point({X: 1, Y: 1})}), "")[0])
+
+	// Positional literals with embedded fields
+	let labels = new $.HashMap([[new Label({Point: new Point({X: 1, Y: 2}), Text: "a"}), 1]])
+	{
+		const _tmp0 = new Label({Text: "a", Point: new Point({X: 1, Y: 2})})
+		$.mapSet(labels, _tmp0, $.mapGet(labels, _tmp0, 0)[0] + 1)
+	}
+	$.mapSet(labels, new Label({Text: "a", Point: new Point({X: 2, Y: 1})}), 5)
+	let l = new Label({Text: "a", Point: new Point({X: 2, Y: 1})})
+	console.log("labels:", $.len(labels), $.mapGet(labels, new Label({Text: "a", Point: new Point({X: 1, Y: 2})}), 0)[0], $.mapGet(labels, l, 0)[0], l.X, l.Text)
+
+	// Array keys
+	let grid = new $.HashMap([])
+	$.mapSet(grid, $.arrayToSlice<number>([3, 4]), true)
+	let key = $.arrayToSlice<number>([3, 4])
+	console.log("array key:", $.mapGet(grid, key, false)[0], $.mapGet(grid, $.arrayToSlice<number>([4, 3]), false)[0])
+
+	// Pointer fields compare by identity
+	let [n1, n2] = [new Node({Name: "a"}), new Node({Name: "a"})]
+	let refs = new $.HashMap([])
+	$.mapSet(refs, new Ref({N: n1}), 1)
+	$.mapSet(refs, new Ref({N: n2}), 2)
+	$.mapSet(refs, new Ref({N: n1}), 3)
+	console.log("refs:", $.len(refs), $.mapGet(refs, new Ref({N: n1}), 0)[0], $.mapGet(refs, new Ref({N: n2}), 0)[0], $.mapGet(refs, new Ref({N: null}), 0)[0])
+
+	// Interface keys holding structs, strings and numbers
+	let seen = new $.InterfaceKeyMap([])
+	$.mapSet(seen, $.markStructValue(new Point({X: 1, Y: 1})), 1)
+	{
+		const _tmp0 = $.markStructValue(new Point({X: 1, Y: 1}))
+		$.mapSet(seen, _tmp0, $.mapGet(seen, _tmp0, 0)[0] + 1)
+	}
+	$.mapSet(seen, $.typedMapKey("1", "string"), 10)
+	$.mapSet(seen, $.typedMapKey(1, "int"), 20)
+	console.log("interface keys:", $.len(seen), $.mapGet(seen, $.markStructValue(new Point({X: 1, Y: 1})), 0)[0], $.mapGet(seen, $.typedMapKey("1", "string"), 0)[0], $.mapGet(seen, $.typedMapKey(1, "int"), 0)[0])
+
+	// Pointers held in interface keys compare by identity, struct values by value
+	let [p1, p2] = [new Point({X: 7, Y: 7}), new Point({X: 7, Y: 7})]
+	let boxed = new $.InterfaceKeyMap([])
+	$.mapSet(boxed, p1, true)
+	$.mapSet(boxed, $.markStructValue(p2!.clone()), true)
+	let held: null | any = $.markStructValue(p1!.clone())
+	console.log("interface pointers:", $.mapGet(boxed, p1, false)[0], $.mapGet(boxed, p2, false)[0], $.mapGet(boxed, $.markStructValue(new Point({X: 7, Y: 7})), false)[0], $.mapGet(boxed, held, false)[0], $.len(boxed))
+	let stored = 0
+	for (const [k, _v] of $.mapEntries(boxed)) {
+		{
+			if (k == (p1 as null | any)) {
+				stored++
+			}
+		}
+	}
+	console.log("stored pointer keys:", stored)
+
+	// Pointer keys still compare by identity
+	let visited = new Map([[n1, true]])
+	console.log("pointer keys:", $.mapGet(visited, n1, false)[0], $.mapGet(visited, n2, false)[0])
+
+	// Range yields the stored keys
+	let sum = 0
+	for (const [k, v] of $.mapEntries(dist)) {
+		{
+			sum += k.X + k.Y + v
+		}
+	}
+	console.log("sum:", sum)
+}
+
@@ -49,10 +49,10 @@ export async function main(): Promise<void> {
 	let scoreResults: $.Slice<string> = null
 
 	// Using string concatenation to build the output string
-	for (const [name, grade] of stringMap?.entries() ?? []) {
+	for (const [name, grade] of $.mapEntries(stringMap)) {
 		{
 			// Using string concatenation to build the output string
-			let result = "  - Name: " + name + " Grade: " + grade
+			let result = $.concatString("  - Name: " + name + " Grade: ", grade)
 			scoreResults = $.append(scoreResults, result)
 		}
 	}
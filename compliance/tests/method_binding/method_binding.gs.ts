@@ -51,11 +51,11 @@ export class Counter {
 
 	// Register this type with the runtime type system
 	static __typeInfo = $.registerStructType(
-	  'Counter',
+	  'main@github.com/aperturerobotics/goscript/compliance/tests/method_binding.Counter',
 	  new Counter(),
 	  [{ name: "Increment", args: [], returns: [] }, { name: "GetValue", args: [], returns: [{ type: { kind: $.TypeKind.Basic, name: "number" } }] }, { name: "IncrementValue", args: [], returns: [] }, { name: "GetValueByValue", args: [], returns: [{ type: { kind: $.TypeKind.Basic, name: "number" } }] }],
 	  Counter,
-	  {"value": { kind: $.TypeKind.Basic, name: "number" }}
+	  [{ name: "value", type: { kind: $.TypeKind.Basic, name: "int" } }]
 	);
 }
 
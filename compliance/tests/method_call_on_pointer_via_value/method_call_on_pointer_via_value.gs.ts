@@ -43,11 +43,11 @@ export class MyStruct {
 
 	// Register this type with the runtime type system
 	static __typeInfo = $.registerStructType(
-	  'MyStruct',
+	  'main@github.com/aperturerobotics/goscript/compliance/tests/method_call_on_pointer_via_value.MyStruct',
 	  new MyStruct(),
 	  [{ name: "SetValue", args: [{ name: "v", type: { kind: $.TypeKind.Basic, name: "number" } }], returns: [] }, { name: "GetValue", args: [], returns: [{ type: { kind: $.TypeKind.Basic, name: "number" } }] }],
 	  MyStruct,
-	  {"MyInt": { kind: $.TypeKind.Basic, name: "number" }}
+	  [{ name: "MyInt", type: { kind: $.TypeKind.Basic, name: "int" } }]
 	);
 }
 
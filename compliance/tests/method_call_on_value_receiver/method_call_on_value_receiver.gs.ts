@@ -47,11 +47,11 @@ export class MyStruct {
 
 	// Register this type with the runtime type system
 	static __typeInfo = $.registerStructType(
-	  'MyStruct',
+	  'main@github.com/aperturerobotics/goscript/compliance/tests/method_call_on_value_receiver.MyStruct',
 	  new MyStruct(),
 	  [{ name: "GetMyString", args: [], returns: [{ type: { kind: $.TypeKind.Basic, name: "string" } }] }],
 	  MyStruct,
-	  {"MyInt": { kind: $.TypeKind.Basic, name: "number" }, "MyString": { kind: $.TypeKind.Basic, name: "string" }}
+	  [{ name: "MyInt", type: { kind: $.TypeKind.Basic, name: "int" } }, { name: "MyString", type: { kind: $.TypeKind.Basic, name: "string" } }]
 	);
 }
 
@@ -37,11 +37,11 @@ export class MyStruct {
 
 	// Register this type with the runtime type system
 	static __typeInfo = $.registerStructType(
-	  'MyStruct',
+	  'main@github.com/aperturerobotics/goscript/compliance/tests/method_call_on_value_via_pointer.MyStruct',
 	  new MyStruct(),
 	  [{ name: "GetValue", args: [], returns: [{ type: { kind: $.TypeKind.Basic, name: "number" } }] }],
 	  MyStruct,
-	  {"MyInt": { kind: $.TypeKind.Basic, name: "number" }}
+	  [{ name: "MyInt", type: { kind: $.TypeKind.Basic, name: "int" } }]
 	);
 }
 
@@ -31,11 +31,11 @@ export class buffer {
 
 	// Register this type with the runtime type system
 	static __typeInfo = $.registerStructType(
-	  'buffer',
+	  'main@github.com/aperturerobotics/goscript/compliance/tests/missing_valueof_error.buffer',
 	  new buffer(),
 	  [],
 	  buffer,
-	  {"data": { kind: $.TypeKind.Slice, elemType: { kind: $.TypeKind.Basic, name: "number" } }}
+	  [{ name: "data", type: { kind: $.TypeKind.Slice, elemType: { kind: $.TypeKind.Basic, name: "byte" } } }]
 	);
 }
 
@@ -88,11 +88,11 @@ export class printer {
 
 	// Register this type with the runtime type system
 	static __typeInfo = $.registerStructType(
-	  'printer',
+	  'main@github.com/aperturerobotics/goscript/compliance/tests/missing_valueof_error.printer',
 	  new printer(),
 	  [{ name: "free", args: [], returns: [] }, { name: "checkCapacity", args: [], returns: [{ type: { kind: $.TypeKind.Basic, name: "number" } }] }, { name: "getLength", args: [], returns: [{ type: { kind: $.TypeKind.Basic, name: "number" } }] }],
 	  printer,
-	  {"buf": { kind: $.TypeKind.Pointer, elemType: "buffer" }}
+	  [{ name: "buf", type: { kind: $.TypeKind.Pointer, elemType: "main@github.com/aperturerobotics/goscript/compliance/tests/missing_valueof_error.buffer" } }]
 	);
 }
 
@@ -12,7 +12,7 @@ export type FileInfo = null | {
 }
 
 $.registerInterfaceType(
-  'FileInfo',
+  'main@github.com/aperturerobotics/goscript/compliance/tests/named_function_type_call.FileInfo',
   null, // Zero value for interface is null
   [{ name: "IsDir", args: [], returns: [{ type: { kind: $.TypeKind.Basic, name: "boolean" } }] }, { name: "Name", args: [], returns: [{ type: { kind: $.TypeKind.Basic, name: "string" } }] }, { name: "Size", args: [], returns: [{ type: { kind: $.TypeKind.Basic, name: "number" } }] }]
 );
@@ -22,9 +22,9 @@ export type Filesystem = null | {
 }
 
 $.registerInterfaceType(
-  'Filesystem',
+  'main@github.com/aperturerobotics/goscript/compliance/tests/named_function_type_call.Filesystem',
   null, // Zero value for interface is null
-  [{ name: "ReadDir", args: [{ name: "path", type: { kind: $.TypeKind.Basic, name: "string" } }], returns: [{ type: { kind: $.TypeKind.Slice, elemType: "FileInfo" } }, { type: { kind: $.TypeKind.Interface, name: 'GoError', methods: [{ name: 'Error', args: [], returns: [{ type: { kind: $.TypeKind.Basic, name: 'string' } }] }] } }] }]
+  [{ name: "ReadDir", args: [{ name: "path", type: { kind: $.TypeKind.Basic, name: "string" } }], returns: [{ type: { kind: $.TypeKind.Slice, elemType: "main@github.com/aperturerobotics/goscript/compliance/tests/named_function_type_call.FileInfo" } }, { type: { kind: $.TypeKind.Interface, name: 'GoError', methods: [{ name: 'Error', args: [], returns: [{ type: { kind: $.TypeKind.Basic, name: 'string' } }] }] } }] }]
 );
 
 export class MockFileInfo {
@@ -90,11 +90,11 @@ export class MockFileInfo {
 
 	// Register this type with the runtime type system
 	static __typeInfo = $.registerStructType(
-	  'MockFileInfo',
+	  'main@github.com/aperturerobotics/goscript/compliance/tests/named_function_type_call.MockFileInfo',
 	  new MockFileInfo(),
 	  [{ name: "Name", args: [], returns: [{ type: { kind: $.TypeKind.Basic, name: "string" } }] }, { name: "Size", args: [], returns: [{ type: { kind: $.TypeKind.Basic, name: "number" } }] }, { name: "IsDir", args: [], returns: [{ type: { kind: $.TypeKind.Basic, name: "boolean" } }] }],
 	  MockFileInfo,
-	  {"name": { kind: $.TypeKind.Basic, name: "string" }, "size": { kind: $.TypeKind.Basic, name: "number" }, "isDir": { kind: $.TypeKind.Basic, name: "boolean" }}
+	  [{ name: "name", type: { kind: $.TypeKind.Basic, name: "string" } }, { name: "size", type: { kind: $.TypeKind.Basic, name: "int64" } }, { name: "isDir", type: { kind: $.TypeKind.Basic, name: "bool" } }]
 	);
 }
 
@@ -119,16 +119,23 @@ export class MockFilesystem {
 
 	// Register this type with the runtime type system
 	static __typeInfo = $.registerStructType(
-	  'MockFilesystem',
+	  'main@github.com/aperturerobotics/goscript/compliance/tests/named_function_type_call.MockFilesystem',
 	  new MockFilesystem(),
-	  [{ name: "ReadDir", args: [{ name: "path", type: { kind: $.TypeKind.Basic, name: "string" } }], returns: [{ type: { kind: $.TypeKind.Slice, elemType: "FileInfo" } }, { type: { kind: $.TypeKind.Interface, name: 'GoError', methods: [{ name: 'Error', args: [], returns: [{ type: { kind: $.TypeKind.Basic, name: 'string' } }] }] } }] }],
+	  [{ name: "ReadDir", args: [{ name: "path", type: { kind: $.TypeKind.Basic, name: "string" } }], returns: [{ type: { kind: $.TypeKind.Slice, elemType: "main@github.com/aperturerobotics/goscript/compliance/tests/named_function_type_call.FileInfo" } }, { type: { kind: $.TypeKind.Interface, name: 'GoError', methods: [{ name: 'Error', args: [], returns: [{ type: { kind: $.TypeKind.Basic, name: 'string' } }] }] } }] }],
 	  MockFilesystem,
-	  {}
+	  []
 	);
 }
 
 export type WalkFunc = ((path: string, info: FileInfo, err: $.GoError) => $.GoError) | null;
 
+$.registerNamedType(
+  'main@github.com/aperturerobotics/goscript/compliance/tests/named_function_type_call.WalkFunc',
+  null,
+  [],
+  { kind: $.TypeKind.Function, params: [{ kind: $.TypeKind.Basic, name: "string" }, "main@github.com/aperturerobotics/goscript/compliance/tests/named_function_type_call.FileInfo", { kind: $.TypeKind.Interface, name: 'GoError', methods: [{ name: 'Error', args: [], returns: [{ type: { kind: $.TypeKind.Basic, name: 'string' } }] }] }], results: [{ kind: $.TypeKind.Interface, name: 'GoError', methods: [{ name: 'Error', args: [], returns: [{ type: { kind: $.TypeKind.Basic, name: 'string' } }] }] }] }
+);
+
 // walk demonstrates the issue with named function types
 // This uses filepath.WalkFunc which is a named function type from external package
 export function walk(fs: Filesystem, path: string, info: FileInfo, walkFn: filepath.WalkFunc | null): $.GoError {
@@ -1 +1,2 @@
+export { ByName_Len, ByName_Less, ByName_Swap } from "./named_slice_wrapper.gs.js"
 export type { ByName } from "./named_slice_wrapper.gs.js"
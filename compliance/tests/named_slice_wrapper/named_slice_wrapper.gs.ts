@@ -20,6 +20,14 @@ export function ByName_Swap(a: ByName, i: number, j: number): void {
 }
 
 
+$.registerNamedType(
+  'main@github.com/aperturerobotics/goscript/compliance/tests/named_slice_wrapper.ByName',
+  null,
+  [{ name: "Len", args: [], returns: [{ type: { kind: $.TypeKind.Basic, name: "number" } }] }, { name: "Less", args: [{ name: "i", type: { kind: $.TypeKind.Basic, name: "number" } }, { name: "j", type: { kind: $.TypeKind.Basic, name: "number" } }], returns: [{ type: { kind: $.TypeKind.Basic, name: "boolean" } }] }, { name: "Swap", args: [{ name: "i", type: { kind: $.TypeKind.Basic, name: "number" } }, { name: "j", type: { kind: $.TypeKind.Basic, name: "number" } }], returns: [] }],
+  { kind: $.TypeKind.Slice, elemType: "io/fs.FileInfo" },
+  { Len: ByName_Len, Less: ByName_Less, Swap: ByName_Swap }
+);
+
 export async function main(): Promise<void> {
 	// Create a ByName instance to test the wrapper
 	let files: ByName = $.makeSlice<os.FileInfo>(2)
@@ -1,2 +1,3 @@
+export { MyFileMode_String } from "./named_type_wrapper.gs.js"
 export { FileStatus } from "./named_type_wrapper.gs.js"
 export type { MyFileMode } from "./named_type_wrapper.gs.js"
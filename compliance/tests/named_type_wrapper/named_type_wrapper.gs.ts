@@ -10,6 +10,14 @@ export function MyFileMode_String(m: MyFileMode): string {
 }
 
 
+$.registerNamedType(
+  'main@github.com/aperturerobotics/goscript/compliance/tests/named_type_wrapper.MyFileMode',
+  0,
+  [{ name: "String", args: [], returns: [{ type: { kind: $.TypeKind.Basic, name: "string" } }] }],
+  { kind: $.TypeKind.Basic, name: "int" },
+  { String: MyFileMode_String }
+);
+
 export class FileStatus {
 	public get mode(): MyFileMode {
 		return this._fields.mode.value
@@ -48,11 +56,11 @@ export class FileStatus {
 
 	// Register this type with the runtime type system
 	static __typeInfo = $.registerStructType(
-	  'FileStatus',
+	  'main@github.com/aperturerobotics/goscript/compliance/tests/named_type_wrapper.FileStatus',
 	  new FileStatus(),
 	  [],
 	  FileStatus,
-	  {"mode": "MyFileMode", "size": { kind: $.TypeKind.Basic, name: "number" }}
+	  [{ name: "mode", type: "main@github.com/aperturerobotics/goscript/compliance/tests/named_type_wrapper.MyFileMode" }, { name: "size", type: { kind: $.TypeKind.Basic, name: "int64" } }]
 	);
 }
 
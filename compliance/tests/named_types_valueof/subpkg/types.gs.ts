@@ -5,20 +5,76 @@ import * as $ from "@goscript/builtin/index.js";
 
 export type MyInt = number;
 
+$.registerNamedType(
+  'github.com/aperturerobotics/goscript/compliance/tests/named_types_valueof/subpkg.MyInt',
+  0,
+  [],
+  { kind: $.TypeKind.Basic, name: "int" }
+);
+
 export type MyUint = number;
 
+$.registerNamedType(
+  'github.com/aperturerobotics/goscript/compliance/tests/named_types_valueof/subpkg.MyUint',
+  0,
+  [],
+  { kind: $.TypeKind.Basic, name: "uint32" }
+);
+
 export type MyFloat = number;
 
+$.registerNamedType(
+  'github.com/aperturerobotics/goscript/compliance/tests/named_types_valueof/subpkg.MyFloat',
+  0,
+  [],
+  { kind: $.TypeKind.Basic, name: "float64" }
+);
+
 export type MyString = string;
 
+$.registerNamedType(
+  'github.com/aperturerobotics/goscript/compliance/tests/named_types_valueof/subpkg.MyString',
+  "",
+  [],
+  { kind: $.TypeKind.Basic, name: "string" }
+);
+
 export type MyBool = boolean;
 
+$.registerNamedType(
+  'github.com/aperturerobotics/goscript/compliance/tests/named_types_valueof/subpkg.MyBool',
+  false,
+  [],
+  { kind: $.TypeKind.Basic, name: "bool" }
+);
+
 export type Level1 = Level2;
 
+$.registerNamedType(
+  'github.com/aperturerobotics/goscript/compliance/tests/named_types_valueof/subpkg.Level1',
+  0,
+  [],
+  { kind: $.TypeKind.Basic, name: "uint64" }
+);
+
 export type Level2 = Level3;
 
+$.registerNamedType(
+  'github.com/aperturerobotics/goscript/compliance/tests/named_types_valueof/subpkg.Level2',
+  0,
+  [],
+  { kind: $.TypeKind.Basic, name: "uint64" }
+);
+
 export type Level3 = number;
 
+$.registerNamedType(
+  'github.com/aperturerobotics/goscript/compliance/tests/named_types_valueof/subpkg.Level3',
+  0,
+  [],
+  { kind: $.TypeKind.Basic, name: "uint64" }
+);
+
 export let IntValue: MyInt = 42
 
 export let UintValue: MyUint = 0xFF
@@ -33,11 +89,11 @@ export let LevelValue: Level1 = 0x1000
 
 // Helper function that uses bitwise operations
 export function GetCombinedFlags(): MyUint {
-	return (255 | 0x10)
+	return 255
 }
 
 // Function that tests multi-level indirection
 export function GetLevelValue(): Level1 {
-	return (4096 | 0x0F)
+	return 4111
 }
 
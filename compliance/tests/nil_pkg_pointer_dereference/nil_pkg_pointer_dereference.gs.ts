@@ -43,11 +43,11 @@ export class TestStruct {
 
 	// Register this type with the runtime type system
 	static __typeInfo = $.registerStructType(
-	  'TestStruct',
+	  'main@github.com/aperturerobotics/goscript/compliance/tests/nil_pkg_pointer_dereference.TestStruct',
 	  new TestStruct(),
 	  [],
 	  TestStruct,
-	  {"Mode": { kind: $.TypeKind.Basic, name: "number" }, "File": { kind: $.TypeKind.Pointer, elemType: "File" }}
+	  [{ name: "Mode", type: "io/fs.FileMode" }, { name: "File", type: { kind: $.TypeKind.Pointer, elemType: "os.File" } }]
 	);
 }
 
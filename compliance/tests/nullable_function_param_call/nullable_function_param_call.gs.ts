@@ -12,13 +12,20 @@ export type FileInfo = null | {
 }
 
 $.registerInterfaceType(
-  'FileInfo',
+  'main@github.com/aperturerobotics/goscript/compliance/tests/nullable_function_param_call.FileInfo',
   null, // Zero value for interface is null
   [{ name: "IsDir", args: [], returns: [{ type: { kind: $.TypeKind.Basic, name: "boolean" } }] }, { name: "Name", args: [], returns: [{ type: { kind: $.TypeKind.Basic, name: "string" } }] }, { name: "Size", args: [], returns: [{ type: { kind: $.TypeKind.Basic, name: "number" } }] }]
 );
 
 export type WalkFunc = ((path: string, info: FileInfo, err: $.GoError) => $.GoError) | null;
 
+$.registerNamedType(
+  'main@github.com/aperturerobotics/goscript/compliance/tests/nullable_function_param_call.WalkFunc',
+  null,
+  [],
+  { kind: $.TypeKind.Function, params: [{ kind: $.TypeKind.Basic, name: "string" }, "main@github.com/aperturerobotics/goscript/compliance/tests/nullable_function_param_call.FileInfo", { kind: $.TypeKind.Interface, name: 'GoError', methods: [{ name: 'Error', args: [], returns: [{ type: { kind: $.TypeKind.Basic, name: 'string' } }] }] }], results: [{ kind: $.TypeKind.Interface, name: 'GoError', methods: [{ name: 'Error', args: [], returns: [{ type: { kind: $.TypeKind.Basic, name: 'string' } }] }] }] }
+);
+
 export let SkipDir: $.GoError = os.ErrNotExist
 
 export type Filesystem = null | {
@@ -26,9 +33,9 @@ export type Filesystem = null | {
 }
 
 $.registerInterfaceType(
-  'Filesystem',
+  'main@github.com/aperturerobotics/goscript/compliance/tests/nullable_function_param_call.Filesystem',
   null, // Zero value for interface is null
-  [{ name: "ReadDir", args: [{ name: "path", type: { kind: $.TypeKind.Basic, name: "string" } }], returns: [{ type: { kind: $.TypeKind.Slice, elemType: "FileInfo" } }, { type: { kind: $.TypeKind.Interface, name: 'GoError', methods: [{ name: 'Error', args: [], returns: [{ type: { kind: $.TypeKind.Basic, name: 'string' } }] }] } }] }]
+  [{ name: "ReadDir", args: [{ name: "path", type: { kind: $.TypeKind.Basic, name: "string" } }], returns: [{ type: { kind: $.TypeKind.Slice, elemType: "main@github.com/aperturerobotics/goscript/compliance/tests/nullable_function_param_call.FileInfo" } }, { type: { kind: $.TypeKind.Interface, name: 'GoError', methods: [{ name: 'Error', args: [], returns: [{ type: { kind: $.TypeKind.Basic, name: 'string' } }] }] } }] }]
 );
 
 export class MockFileInfo {
@@ -94,11 +101,11 @@ export class MockFileInfo {
 
 	// Register this type with the runtime type system
 	static __typeInfo = $.registerStructType(
-	  'MockFileInfo',
+	  'main@github.com/aperturerobotics/goscript/compliance/tests/nullable_function_param_call.MockFileInfo',
 	  new MockFileInfo(),
 	  [{ name: "Name", args: [], returns: [{ type: { kind: $.TypeKind.Basic, name: "string" } }] }, { name: "Size", args: [], returns: [{ type: { kind: $.TypeKind.Basic, name: "number" } }] }, { name: "IsDir", args: [], returns: [{ type: { kind: $.TypeKind.Basic, name: "boolean" } }] }],
 	  MockFileInfo,
-	  {"name": { kind: $.TypeKind.Basic, name: "string" }, "size": { kind: $.TypeKind.Basic, name: "number" }, "isDir": { kind: $.TypeKind.Basic, name: "boolean" }}
+	  [{ name: "name", type: { kind: $.TypeKind.Basic, name: "string" } }, { name: "size", type: { kind: $.TypeKind.Basic, name: "int64" } }, { name: "isDir", type: { kind: $.TypeKind.Basic, name: "bool" } }]
 	);
 }
 
@@ -123,11 +130,11 @@ export class MockFilesystem {
 
 	// Register this type with the runtime type system
 	static __typeInfo = $.registerStructType(
-	  'MockFilesystem',
+	  'main@github.com/aperturerobotics/goscript/compliance/tests/nullable_function_param_call.MockFilesystem',
 	  new MockFilesystem(),
-	  [{ name: "ReadDir", args: [{ name: "path", type: { kind: $.TypeKind.Basic, name: "string" } }], returns: [{ type: { kind: $.TypeKind.Slice, elemType: "FileInfo" } }, { type: { kind: $.TypeKind.Interface, name: 'GoError', methods: [{ name: 'Error', args: [], returns: [{ type: { kind: $.TypeKind.Basic, name: 'string' } }] }] } }] }],
+	  [{ name: "ReadDir", args: [{ name: "path", type: { kind: $.TypeKind.Basic, name: "string" } }], returns: [{ type: { kind: $.TypeKind.Slice, elemType: "main@github.com/aperturerobotics/goscript/compliance/tests/nullable_function_param_call.FileInfo" } }, { type: { kind: $.TypeKind.Interface, name: 'GoError', methods: [{ name: 'Error', args: [], returns: [{ type: { kind: $.TypeKind.Basic, name: 'string' } }] }] } }] }],
 	  MockFilesystem,
-	  {}
+	  []
 	);
 }
 
@@ -155,6 +162,13 @@ export function walk(fs: Filesystem, path: string, info: FileInfo, walkFn: WalkF
 
 export type ProcessFunc = ((data: string) => [string, $.GoError]) | null;
 
+$.registerNamedType(
+  'main@github.com/aperturerobotics/goscript/compliance/tests/nullable_function_param_call.ProcessFunc',
+  null,
+  [],
+  { kind: $.TypeKind.Function, params: [{ kind: $.TypeKind.Basic, name: "string" }], results: [{ kind: $.TypeKind.Basic, name: "string" }, { kind: $.TypeKind.Interface, name: 'GoError', methods: [{ name: 'Error', args: [], returns: [{ type: { kind: $.TypeKind.Basic, name: 'string' } }] }] }] }
+);
+
 export function processWithCallback(input: string, processor: ProcessFunc | null): [string, $.GoError] {
 	// Test case 3: Function parameter with return values
 	// This should generate: processor!(input)
@@ -0,0 +1,86 @@
+package main
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"io/fs"
+	"os"
+	"strings"
+)
+
+func main() {
+	dir, err := os.MkdirTemp("", "goscript-os-*")
+	if err != nil {
+		println("MkdirTemp error:", err.Error())
+		return
+	}
+	defer os.RemoveAll(dir)
+
+	// WriteFile and ReadFile
+	name := dir + "/config.txt"
+	if err := os.WriteFile(name, []byte("key=value\n"), 0o644); err != nil {
+		println("WriteFile error:", err.Error())
+	}
+	data, err := os.ReadFile(name)
+	println("ReadFile:", string(data), err == nil)
+
+	// Missing files report *PathError wrapping fs.ErrNotExist
+	_, err = os.ReadFile(dir + "/missing.txt")
+	println("IsNotExist:", os.IsNotExist(err), errors.Is(err, fs.ErrNotExist))
+	println("error:", strings.Replace(err.Error(), dir, "$DIR", 1))
+
+	// Create, Write, Seek and Read through a File
+	f, err := os.Create(dir + "/data.bin")
+	if err != nil {
+		println("Create error:", err.Error())
+		return
+	}
+	n, _ := f.WriteString("hello, world")
+	println("wrote:", n)
+	pos, _ := f.Seek(7, io.SeekStart)
+	println("seek:", pos)
+	buf := make([]byte, 16)
+	n, err = f.Read(buf)
+	println("read:", string(buf[:n]), err == nil)
+	_, err = f.Read(buf)
+	println("read at end is EOF:", err == io.EOF)
+	f.WriteAt([]byte("HELLO"), 0)
+	info, _ := f.Stat()
+	println("stat:", info.Name(), info.Size(), info.IsDir())
+	println("close:", f.Close() == nil, f.Close() != nil)
+
+	// Append to a file
+	af, _ := os.OpenFile(name, os.O_WRONLY|os.O_APPEND, 0)
+	af.WriteString("more=1\n")
+	af.Close()
+	data, _ = os.ReadFile(name)
+	fmt.Printf("appended: %q\n", string(data))
+
+	// Directories
+	os.MkdirAll(dir+"/sub/deep", 0o755)
+	os.WriteFile(dir+"/sub/b.txt", nil, 0o644)
+	os.WriteFile(dir+"/sub/a.txt", nil, 0o644)
+	entries, _ := os.ReadDir(dir + "/sub")
+	for _, e := range entries {
+		println("entry:", e.Name(), e.IsDir())
+	}
+	err = os.Mkdir(dir+"/sub", 0o755)
+	println("Mkdir existing IsExist:", os.IsExist(err))
+
+	// Rename and Remove
+	os.Rename(dir+"/sub/a.txt", dir+"/sub/c.txt")
+	_, err = os.Stat(dir + "/sub/a.txt")
+	println("renamed away:", os.IsNotExist(err))
+	_, err = os.Stat(dir + "/sub/c.txt")
+	println("renamed to:", err == nil)
+	println("Remove non-empty fails:", os.Remove(dir+"/sub") != nil)
+	println("RemoveAll:", os.RemoveAll(dir+"/sub") == nil)
+	_, err = os.Stat(dir + "/sub")
+	println("removed:", os.IsNotExist(err))
+
+	// Files behind fs.FS
+	fsys := os.DirFS(dir)
+	data, err = fs.ReadFile(fsys, "config.txt")
+	println("DirFS:", len(data), err == nil)
+}
@@ -0,0 +1,104 @@
+// Generated file based on os_file_operations.go
+// Updated when compliance tests are re-run, DO NOT EDIT!
+
+import * as $ from "@goscript/builtin/index.js";
+
+import * as errors from "@goscript/errors/index.js"
+
+import * as fmt from "@goscript/fmt/index.js"
+
+import * as io from "@goscript/io/index.js"
+
+import * as fs from "@goscript/io/fs/index.js"
+
+import * as os from "@goscript/os/index.js"
+
+import * as strings from "@goscript/strings/index.js"
+
+export async function main(): Promise<void> {
+	using __defer = new $.DisposableStack();
+	let [dir, err] = os.MkdirTemp("", "goscript-os-*")
+	if (err != null) {
+		console.log("MkdirTemp error:", err!.Error())
+		return 
+	}
+	__defer.defer(() => {
+		os.RemoveAll(dir)
+	});
+
+	// WriteFile and ReadFile
+	let name = dir + "/config.txt"
+	{
+		let err = os.WriteFile(name, $.stringToBytes("key=value\n"), 0o644)
+		if (err != null) {
+			console.log("WriteFile error:", err!.Error())
+		}
+	}
+	let data: $.Bytes
+	[data, err] = os.ReadFile(name)
+	console.log("ReadFile:", $.bytesToString(data), err == null)
+
+	// Missing files report *PathError wrapping fs.ErrNotExist
+	;[, err] = os.ReadFile(dir + "/missing.txt")
+	console.log("IsNotExist:", os.IsNotExist(err), errors.Is(err, fs.ErrNotExist))
+	console.log("error:", strings.Replace(err!.Error(), dir, "$DIR", 1))
+
+	// Create, Write, Seek and Read through a File
+	let f: os.File | null
+	[f, err] = os.Create(dir + "/data.bin")
+	if (err != null) {
+		console.log("Create error:", err!.Error())
+		return 
+	}
+	let [n, ] = f!.WriteString("hello, world")
+	console.log("wrote:", n)
+	let [pos, ] = f!.Seek(7, io.SeekStart)
+	console.log("seek:", pos)
+	let buf = new Uint8Array(16)
+	;[n, err] = f!.Read(buf)
+	console.log("read:", $.bytesToString($.goSlice(buf, undefined, n)), err == null)
+	;[, err] = f!.Read(buf)
+	console.log("read at end is EOF:", err == io.EOF)
+	f!.WriteAt($.stringToBytes("HELLO"), 0)
+	let [info, ] = f!.Stat()
+	console.log("stat:", info!.Name(), info!.Size(), info!.IsDir())
+	console.log("close:", f!.Close() == null, f!.Close() != null)
+
+	// Append to a file
+	let [af, ] = os.OpenFile(name, 1025, 0)
+	af!.WriteString("more=1\n")
+	af!.Close()
+	;[data] = os.ReadFile(name)
+	fmt.Printf("appended: %q\n", $.bytesToString(data))
+
+	// Directories
+	os.MkdirAll(dir + "/sub/deep", 0o755)
+	os.WriteFile(dir + "/sub/b.txt", null, 0o644)
+	os.WriteFile(dir + "/sub/a.txt", null, 0o644)
+	let [entries, ] = os.ReadDir(dir + "/sub")
+	for (let _i = 0; _i < $.len(entries); _i++) {
+		const e = entries![_i]
+		{
+			console.log("entry:", e!.Name(), e!.IsDir())
+		}
+	}
+	err = os.Mkdir(dir + "/sub", 0o755)
+	console.log("Mkdir existing IsExist:", os.IsExist(err))
+
+	// Rename and Remove
+	os.Rename(dir + "/sub/a.txt", dir + "/sub/c.txt")
+	;[, err] = os.Stat(dir + "/sub/a.txt")
+	console.log("renamed away:", os.IsNotExist(err))
+	;[, err] = os.Stat(dir + "/sub/c.txt")
+	console.log("renamed to:", err == null)
+	console.log("Remove non-empty fails:", os.Remove(dir + "/sub") != null)
+	console.log("RemoveAll:", os.RemoveAll(dir + "/sub") == null)
+	;[, err] = os.Stat(dir + "/sub")
+	console.log("removed:", os.IsNotExist(err))
+
+	// Files behind fs.FS
+	let fsys = os.DirFS(dir)
+	;[data, err] = fs.ReadFile(fsys, "config.txt")
+	console.log("DirFS:", $.len(data), err == null)
+}
+
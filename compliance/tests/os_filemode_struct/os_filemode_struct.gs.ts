@@ -43,11 +43,11 @@ export class file {
 
 	// Register this type with the runtime type system
 	static __typeInfo = $.registerStructType(
-	  'file',
+	  'main@github.com/aperturerobotics/goscript/compliance/tests/os_filemode_struct.file',
 	  new file(),
 	  [],
 	  file,
-	  {"mode": { kind: $.TypeKind.Basic, name: "number" }, "name": { kind: $.TypeKind.Basic, name: "string" }}
+	  [{ name: "mode", type: "io/fs.FileMode" }, { name: "name", type: { kind: $.TypeKind.Basic, name: "string" } }]
 	);
 }
 
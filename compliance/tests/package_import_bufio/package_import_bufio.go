@@ -0,0 +1,195 @@
+package main
+
+import (
+	"bufio"
+	"bytes"
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+	"sync"
+)
+
+// slowReader returns at most n bytes per Read.
+type slowReader struct {
+	data []byte
+	n    int
+}
+
+func (r *slowReader) Read(p []byte) (int, error) {
+	if len(r.data) == 0 {
+		return 0, io.EOF
+	}
+	m := r.n
+	if m > len(p) {
+		m = len(p)
+	}
+	if m > len(r.data) {
+		m = len(r.data)
+	}
+	copy(p, r.data[:m])
+	r.data = r.data[m:]
+	return m, nil
+}
+
+// lockedReader guards a reader with a mutex, which makes its Read async.
+type lockedReader struct {
+	mu sync.Mutex
+	r  io.Reader
+}
+
+func (l *lockedReader) Read(p []byte) (int, error) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	return l.r.Read(p)
+}
+
+// upperWriter upper-cases everything written to it.
+type upperWriter struct {
+	out strings.Builder
+}
+
+func (w *upperWriter) Write(p []byte) (int, error) {
+	w.out.WriteString(strings.ToUpper(string(p)))
+	return len(p), nil
+}
+
+var errStop = errors.New("stop")
+
+// scanCSV splits on commas and stops at a semicolon.
+func scanCSV(data []byte, atEOF bool) (int, []byte, error) {
+	if i := bytes.IndexAny(data, ",;"); i >= 0 {
+		if data[i] == ';' {
+			return i + 1, data[:i], bufio.ErrFinalToken
+		}
+		return i + 1, data[:i], nil
+	}
+	if atEOF && len(data) > 0 {
+		return len(data), data, nil
+	}
+	return 0, nil, nil
+}
+
+func main() {
+	// Lines, with a CRLF and no final newline
+	sc := bufio.NewScanner(strings.NewReader("first\nsecond\r\n\nlast"))
+	for sc.Scan() {
+		fmt.Printf("line %q\n", sc.Text())
+	}
+	fmt.Println("err:", sc.Err())
+
+	// Words from a reader that returns a few bytes at a time
+	sc = bufio.NewScanner(&slowReader{data: []byte("  the quick\tbrown\n fox  "), n: 3})
+	sc.Split(bufio.ScanWords)
+	count := 0
+	for sc.Scan() {
+		count++
+		fmt.Println("word", count, sc.Text(), len(sc.Bytes()))
+	}
+
+	// Runes split across reads
+	sc = bufio.NewScanner(&slowReader{data: []byte("añ世🙂"), n: 1})
+	sc.Split(bufio.ScanRunes)
+	for sc.Scan() {
+		fmt.Printf("rune %q ", sc.Text())
+	}
+	fmt.Println()
+
+	// A custom split function ending with ErrFinalToken
+	sc = bufio.NewScanner(strings.NewReader("a,bb,,ccc;ignored,rest"))
+	sc.Split(scanCSV)
+	for sc.Scan() {
+		fmt.Printf("field %q\n", sc.Text())
+	}
+	fmt.Println("err:", sc.Err())
+
+	// A split function returning an error
+	sc = bufio.NewScanner(strings.NewReader("1 2 x 3"))
+	sc.Split(func(data []byte, atEOF bool) (int, []byte, error) {
+		advance, token, err := bufio.ScanWords(data, atEOF)
+		if err == nil && token != nil && string(token) == "x" {
+			return 0, nil, errStop
+		}
+		return advance, token, err
+	})
+	for sc.Scan() {
+		fmt.Println("num", sc.Text())
+	}
+	fmt.Println("err:", sc.Err())
+
+	// Tokens too long for the buffer
+	sc = bufio.NewScanner(strings.NewReader(strings.Repeat("z", 50) + "\n"))
+	sc.Buffer(make([]byte, 8), 16)
+	fmt.Println(sc.Scan(), sc.Err())
+
+	// Scanning through a reader whose Read is async
+	sc = bufio.NewScanner(&lockedReader{r: strings.NewReader("x\ny\n")})
+	for sc.Scan() {
+		fmt.Println("locked", sc.Text())
+	}
+
+	// Reader
+	r := bufio.NewReaderSize(&slowReader{data: []byte("héllo wörld\nsecond line\r\nthird"), n: 4}, 16)
+	peek, err := r.Peek(5)
+	fmt.Printf("peek %q %v\n", peek, err)
+	ch, size, err := r.ReadRune()
+	fmt.Println(string(ch), size, err)
+	ch, size, err = r.ReadRune()
+	fmt.Println(string(ch), size, err)
+	fmt.Println(r.UnreadRune(), r.UnreadRune())
+	s, err := r.ReadString('\n')
+	fmt.Printf("%q %v\n", s, err)
+	line, isPrefix, err := r.ReadLine()
+	fmt.Printf("%q %v %v\n", line, isPrefix, err)
+	b, err := r.ReadByte()
+	fmt.Println(string(b), err, r.Buffered())
+	s, err = r.ReadString('\n')
+	fmt.Printf("%q %v\n", s, err)
+	s, err = r.ReadString('\n')
+	fmt.Printf("%q %v\n", s, err)
+	_, err = r.Peek(100)
+	fmt.Println(err == bufio.ErrBufferFull)
+
+	// ReadBytes and Discard
+	r = bufio.NewReader(strings.NewReader("skip:key=value;next"))
+	n, err := r.Discard(5)
+	fmt.Println(n, err)
+	kv, err := r.ReadBytes(';')
+	fmt.Printf("%s %v\n", kv, err)
+	rest, err := io.ReadAll(r)
+	fmt.Printf("%s %v\n", rest, err)
+
+	// ReadString through a reader whose Read is async
+	r = bufio.NewReader(&lockedReader{r: strings.NewReader("async line\n")})
+	s, err = r.ReadString('\n')
+	fmt.Printf("%q %v\n", s, err)
+
+	// Writer
+	var sb strings.Builder
+	w := bufio.NewWriterSize(&sb, 16)
+	fmt.Fprintf(w, "%s=%d;", "a", 1)
+	w.WriteString("hello ")
+	w.WriteByte('w')
+	w.WriteRune('ö')
+	fmt.Println("buffered", w.Buffered(), w.Available(), len(sb.String()))
+	w.WriteString("rld, this line is longer than the buffer")
+	fmt.Println("buffered", w.Buffered(), len(sb.String()))
+	fmt.Println(w.Flush(), sb.String())
+
+	up := &upperWriter{}
+	w = bufio.NewWriter(up)
+	for i := 0; i < 3; i++ {
+		fmt.Fprintln(w, "row", i)
+	}
+	fmt.Printf("%q\n", up.out.String())
+	w.Flush()
+	fmt.Printf("%q\n", up.out.String())
+
+	// ReadWriter
+	var out bytes.Buffer
+	rw := bufio.NewReadWriter(bufio.NewReader(strings.NewReader("ping\n")), bufio.NewWriter(&out))
+	msg, _ := rw.ReadString('\n')
+	rw.WriteString("got " + msg)
+	rw.Flush()
+	fmt.Print(out.String())
+}
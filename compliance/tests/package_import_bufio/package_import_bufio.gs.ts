@@ -0,0 +1,332 @@
+// Generated file based on package_import_bufio.go
+// Updated when compliance tests are re-run, DO NOT EDIT!
+
+import * as $ from "@goscript/builtin/index.js";
+
+import * as bufio from "@goscript/bufio/index.js"
+
+import * as bytes from "@goscript/bytes/index.js"
+
+import * as errors from "@goscript/errors/index.js"
+
+import * as fmt from "@goscript/fmt/index.js"
+
+import * as io from "@goscript/io/index.js"
+
+import * as strings from "@goscript/strings/index.js"
+
+import * as sync from "@goscript/sync/index.js"
+
+export class slowReader {
+	public get data(): $.Bytes {
+		return this._fields.data.value
+	}
+	public set data(value: $.Bytes) {
+		this._fields.data.value = value
+	}
+
+	public get n(): number {
+		return this._fields.n.value
+	}
+	public set n(value: number) {
+		this._fields.n.value = value
+	}
+
+	public _fields: {
+		data: $.VarRef<$.Bytes>;
+		n: $.VarRef<number>;
+	}
+
+	constructor(init?: Partial<{data?: $.Bytes, n?: number}>) {
+		this._fields = {
+			data: $.varRef(init?.data ?? new Uint8Array(0)),
+			n: $.varRef(init?.n ?? 0)
+		}
+	}
+
+	public clone(): slowReader {
+		const cloned = new slowReader()
+		cloned._fields = {
+			data: $.varRef(this._fields.data.value),
+			n: $.varRef(this._fields.n.value)
+		}
+		return cloned
+	}
+
+	public async Read(p: $.Bytes): Promise<[number, $.GoError]> {
+		const r = this
+		if ($.len(r.data) == 0) {
+			return [0, io.EOF]
+		}
+		let m = r.n
+		if (m > $.len(p)) {
+			m = $.len(p)
+		}
+		if (m > $.len(r.data)) {
+			m = $.len(r.data)
+		}
+		$.copy(p, $.goSlice(r.data, undefined, m))
+		r.data = $.goSlice(r.data, m, undefined)
+		return [m, null]
+	}
+
+	// Register this type with the runtime type system
+	static __typeInfo = $.registerStructType(
+	  'main@github.com/aperturerobotics/goscript/compliance/tests/package_import_bufio.slowReader',
+	  new slowReader(),
+	  [{ name: "Read", args: [{ name: "p", type: { kind: $.TypeKind.Slice, elemType: { kind: $.TypeKind.Basic, name: "number" } } }], returns: [{ type: { kind: $.TypeKind.Basic, name: "number" } }, { type: { kind: $.TypeKind.Interface, name: 'GoError', methods: [{ name: 'Error', args: [], returns: [{ type: { kind: $.TypeKind.Basic, name: 'string' } }] }] } }] }],
+	  slowReader,
+	  [{ name: "data", type: { kind: $.TypeKind.Slice, elemType: { kind: $.TypeKind.Basic, name: "byte" } } }, { name: "n", type: { kind: $.TypeKind.Basic, name: "int" } }]
+	);
+}
+
+export class lockedReader {
+	public get mu(): sync.Mutex {
+		return this._fields.mu.value
+	}
+	public set mu(value: sync.Mutex) {
+		this._fields.mu.value = value
+	}
+
+	public get r(): io.Reader {
+		return this._fields.r.value
+	}
+	public set r(value: io.Reader) {
+		this._fields.r.value = value
+	}
+
+	public _fields: {
+		mu: $.VarRef<sync.Mutex>;
+		r: $.VarRef<io.Reader>;
+	}
+
+	constructor(init?: Partial<{mu?: sync.Mutex, r?: io.Reader}>) {
+		this._fields = {
+			mu: $.varRef(init?.mu?.clone() ?? new sync.Mutex()),
+			r: $.varRef(init?.r ?? null)
+		}
+	}
+
+	public clone(): lockedReader {
+		const cloned = new lockedReader()
+		cloned._fields = {
+			mu: $.varRef(this._fields.mu.value?.clone() ?? null),
+			r: $.varRef(this._fields.r.value)
+		}
+		return cloned
+	}
+
+	public async Read(p: $.Bytes): Promise<[number, $.GoError]> {
+		const l = this
+		using __defer = new $.DisposableStack();
+		await l.mu.Lock()
+		__defer.defer(() => {
+			l.mu.Unlock()
+		});
+		return await l.r!.Read(p)
+	}
+
+	// Register this type with the runtime type system
+	static __typeInfo = $.registerStructType(
+	  'main@github.com/aperturerobotics/goscript/compliance/tests/package_import_bufio.lockedReader',
+	  new lockedReader(),
+	  [{ name: "Read", args: [{ name: "p", type: { kind: $.TypeKind.Slice, elemType: { kind: $.TypeKind.Basic, name: "number" } } }], returns: [{ type: { kind: $.TypeKind.Basic, name: "number" } }, { type: { kind: $.TypeKind.Interface, name: 'GoError', methods: [{ name: 'Error', args: [], returns: [{ type: { kind: $.TypeKind.Basic, name: 'string' } }] }] } }] }],
+	  lockedReader,
+	  [{ name: "mu", type: "sync.Mutex" }, { name: "r", type: "io.Reader" }]
+	);
+}
+
+export class upperWriter {
+	public get out(): strings.Builder {
+		return this._fields.out.value
+	}
+	public set out(value: strings.Builder) {
+		this._fields.out.value = value
+	}
+
+	public _fields: {
+		out: $.VarRef<strings.Builder>;
+	}
+
+	constructor(init?: Partial<{out?: strings.Builder}>) {
+		this._fields = {
+			out: $.varRef(init?.out?.clone() ?? new strings.Builder())
+		}
+	}
+
+	public clone(): upperWriter {
+		const cloned = new upperWriter()
+		cloned._fields = {
+			out: $.varRef(this._fields.out.value?.clone() ?? null)
+		}
+		return cloned
+	}
+
+	public async Write(p: $.Bytes): Promise<[number, $.GoError]> {
+		const w = this
+		w.out.WriteString(strings.ToUpper($.bytesToString(p)))
+		return [$.len(p), null]
+	}
+
+	// Register this type with the runtime type system
+	static __typeInfo = $.registerStructType(
+	  'main@github.com/aperturerobotics/goscript/compliance/tests/package_import_bufio.upperWriter',
+	  new upperWriter(),
+	  [{ name: "Write", args: [{ name: "p", type: { kind: $.TypeKind.Slice, elemType: { kind: $.TypeKind.Basic, name: "number" } } }], returns: [{ type: { kind: $.TypeKind.Basic, name: "number" } }, { type: { kind: $.TypeKind.Interface, name: 'GoError', methods: [{ name: 'Error', args: [], returns: [{ type: { kind: $.TypeKind.Basic, name: 'string' } }] }] } }] }],
+	  upperWriter,
+	  [{ name: "out", type: "strings.Builder" }]
+	);
+}
+
+let errStop: $.GoError = errors.New("stop")
+
+// scanCSV splits on commas and stops at a semicolon.
+export function scanCSV(data: $.Bytes, atEOF: boolean): [number, $.Bytes, $.GoError] {
+	{
+		let i = bytes.IndexAny(data, ",;")
+		if (i >= 0) {
+			if (data![i] == 59) {
+				return [i + 1, $.goSlice(data, undefined, i), bufio.ErrFinalToken]
+			}
+			return [i + 1, $.goSlice(data, undefined, i), null]
+		}
+	}
+	if (atEOF && $.len(data) > 0) {
+		return [$.len(data), data, null]
+	}
+	return [0, null, null]
+}
+
+export async function main(): Promise<void> {
+	// Lines, with a CRLF and no final newline
+	let sc = bufio.NewScanner(strings.NewReader("first\nsecond\r\n\nlast"))
+	for (; await sc!.Scan(); ) {
+		await fmt.Printf("line %q\n", sc!.Text())
+	}
+	await fmt.Println("err:", sc!.Err())
+
+	// Words from a reader that returns a few bytes at a time
+	sc = bufio.NewScanner(new slowReader({data: $.stringToBytes("  the quick\tbrown\n fox  "), n: 3}))
+	sc!.Split(bufio.ScanWords)
+	let count = 0
+	for (; await sc!.Scan(); ) {
+		count++
+		await fmt.Println("word", count, sc!.Text(), $.len(sc!.Bytes()))
+	}
+
+	// Runes split across reads
+	sc = bufio.NewScanner(new slowReader({data: $.stringToBytes("añ世🙂"), n: 1}))
+	sc!.Split(bufio.ScanRunes)
+	for (; await sc!.Scan(); ) {
+		await fmt.Printf("rune %q ", sc!.Text())
+	}
+	await fmt.Println()
+
+	// A custom split function ending with ErrFinalToken
+	sc = bufio.NewScanner(strings.NewReader("a,bb,,ccc;ignored,rest"))
+	sc!.Split(scanCSV)
+	for (; await sc!.Scan(); ) {
+		await fmt.Printf("field %q\n", sc!.Text())
+	}
+	await fmt.Println("err:", sc!.Err())
+
+	// A split function returning an error
+	sc = bufio.NewScanner(strings.NewReader("1 2 x 3"))
+	sc!.Split((data: $.Bytes, atEOF: boolean): [number, $.Bytes, $.GoError] => {
+		let [advance, token, err] = bufio.ScanWords(data, atEOF)
+		if (err == null && token != null && $.bytesToString(token) == "x") {
+			return [0, null, errStop]
+		}
+		return [advance, token, err]
+	})
+	for (; await sc!.Scan(); ) {
+		await fmt.Println("num", sc!.Text())
+	}
+	await fmt.Println("err:", sc!.Err())
+
+	// Tokens too long for the buffer
+	sc = bufio.NewScanner(strings.NewReader(strings.Repeat("z", 50) + "\n"))
+	sc!.Buffer(new Uint8Array(8), 16)
+	await fmt.Println(await sc!.Scan(), sc!.Err())
+
+	// Scanning through a reader whose Read is async
+	sc = bufio.NewScanner(new lockedReader({r: strings.NewReader("x\ny\n")}))
+	for (; await sc!.Scan(); ) {
+		await fmt.Println("locked", sc!.Text())
+	}
+
+	// Reader
+	let r = bufio.NewReaderSize(new slowReader({data: $.stringToBytes("héllo wörld\nsecond line\r\nthird"), n: 4}), 16)
+	let [peek, err] = await r.Peek(5)
+	await fmt.Printf("peek %q %v\n", peek, err)
+	let ch: number
+	let size: number
+	[ch, size, err] = await r.ReadRune()
+	await fmt.Println($.runeOrStringToString(ch), size, err)
+	;[ch, size, err] = await r.ReadRune()
+	await fmt.Println($.runeOrStringToString(ch), size, err)
+	await fmt.Println(r.UnreadRune(), r.UnreadRune())
+	let s: string
+	[s, err] = await r.ReadString(10)
+	await fmt.Printf("%q %v\n", s, err)
+	let line: $.Bytes
+	let isPrefix: boolean
+	[line, isPrefix, err] = await r.ReadLine()
+	await fmt.Printf("%q %v %v\n", line, isPrefix, err)
+	let b: number
+	[b, err] = await r.ReadByte()
+	await fmt.Println($.runeOrStringToString(b), err, r.Buffered())
+	;[s, err] = await r.ReadString(10)
+	await fmt.Printf("%q %v\n", s, err)
+	;[s, err] = await r.ReadString(10)
+	await fmt.Printf("%q %v\n", s, err)
+	;[, err] = await r.Peek(100)
+	await fmt.Println(err == bufio.ErrBufferFull)
+
+	// ReadBytes and Discard
+	r = bufio.NewReader(strings.NewReader("skip:key=value;next"))
+	let n: number
+	[n, err] = await r.Discard(5)
+	await fmt.Println(n, err)
+	let kv: $.Bytes
+	[kv, err] = await r.ReadBytes(59)
+	await fmt.Printf("%s %v\n", kv, err)
+	let rest: $.Bytes
+	[rest, err] = await io.ReadAll(r)
+	await fmt.Printf("%s %v\n", rest, err)
+
+	// ReadString through a reader whose Read is async
+	r = bufio.NewReader(new lockedReader({r: strings.NewReader("async line\n")}))
+	;[s, err] = await r.ReadString(10)
+	await fmt.Printf("%q %v\n", s, err)
+
+	// Writer
+	let sb: strings.Builder = new strings.Builder()
+	let w = bufio.NewWriterSize(sb, 16)
+	await fmt.Fprintf(w, "%s=%d;", "a", 1)
+	await w.WriteString("hello ")
+	await w.WriteByte(119)
+	await w.WriteRune(246)
+	await fmt.Println("buffered", w.Buffered(), w.Available(), $.len(sb.String()))
+	await w.WriteString("rld, this line is longer than the buffer")
+	await fmt.Println("buffered", w.Buffered(), $.len(sb.String()))
+	await fmt.Println(await w.Flush(), sb.String())
+
+	let up = new upperWriter({})
+	w = bufio.NewWriter(up)
+	for (let i = 0; i < 3; i++) {
+		await fmt.Fprintln(w, "row", i)
+	}
+	await fmt.Printf("%q\n", up!.out.String())
+	await w.Flush()
+	await fmt.Printf("%q\n", up!.out.String())
+
+	// ReadWriter
+	let out: bytes.Buffer = new bytes.Buffer()
+	let rw = bufio.NewReadWriter(bufio.NewReader(strings.NewReader("ping\n")), bufio.NewWriter(out))
+	let [msg, ] = await rw!.ReadString(10)
+	await rw!.WriteString("got " + msg)
+	await rw!.Flush()
+	await fmt.Print(out.String())
+}
+
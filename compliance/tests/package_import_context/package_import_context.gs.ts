@@ -14,10 +14,10 @@ export async function run(ctx: context.Context): Promise<void> {
 
 	let myCh = $.makeChannel<{  }>(0, {}, 'both')
 
-	queueMicrotask(async () => {
+	$.go(async () => {
 		await $.chanRecv(sctx!.Done())
 		await $.chanSend(myCh, {})
-	})
+	}, "package_import_context.go:11")
 
 	// Check that myCh is not readable yet
 	const [_select_has_return_5348, _select_value_5348] = await $.selectStatement([
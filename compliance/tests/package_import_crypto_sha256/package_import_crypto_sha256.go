@@ -0,0 +1,64 @@
+package main
+
+import (
+	"bytes"
+	"crypto/hmac"
+	"crypto/md5"
+	"crypto/sha1"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"hash"
+)
+
+func main() {
+	data := []byte("The quick brown fox jumps over the lazy dog")
+
+	sum := sha256.Sum256(data)
+	fmt.Println(hex.EncodeToString(sum[:]), len(sum))
+	fmt.Println(fmt.Sprintf("%x", sha256.Sum256(data)) == hex.EncodeToString(sum[:]))
+	fmt.Printf("%X\n", sum)
+	sum224 := sha256.Sum224(data)
+	fmt.Println(hex.EncodeToString(sum224[:]))
+	s1 := sha1.Sum(data)
+	fmt.Println(hex.EncodeToString(s1[:]))
+	m := md5.Sum(data)
+	fmt.Println(hex.EncodeToString(m[:]))
+	fmt.Printf("%x %x %x\n", sum224, s1, md5.Sum(data))
+	fmt.Println(fmt.Sprintf("%x", sha1.Sum(data)) == hex.EncodeToString(s1[:]), fmt.Sprint(len(m)))
+
+	// Streaming writes split across block boundaries.
+	h := sha256.New()
+	for i := 0; i < len(data); i += 7 {
+		end := i + 7
+		if end > len(data) {
+			end = len(data)
+		}
+		h.Write(data[i:end])
+	}
+	fmt.Printf("%x %d %d\n", h.Sum(nil), h.Size(), h.BlockSize())
+	fmt.Println(bytes.Equal(h.Sum(nil), sum[:]))
+
+	// Large inputs give the same digests.
+	large := bytes.Repeat(data, 200)
+	big := sha256.Sum256(large)
+	h.Reset()
+	h.Write(large)
+	fmt.Println(bytes.Equal(h.Sum(nil), big[:]), hex.EncodeToString(big[:4]))
+
+	news := []func() hash.Hash{sha256.New, sha256.New224, sha1.New, md5.New}
+	for _, newHash := range news {
+		mac := hmac.New(newHash, []byte("key"))
+		mac.Write(data)
+		tag := mac.Sum(nil)
+		fmt.Println(hex.EncodeToString(tag))
+
+		mac.Reset()
+		mac.Write(data)
+		fmt.Println(hmac.Equal(tag, mac.Sum(nil)), hmac.Equal(tag, tag[1:]))
+	}
+
+	long := hmac.New(sha256.New, bytes.Repeat([]byte("k"), 100))
+	long.Write(data)
+	fmt.Printf("%x\n", long.Sum([]byte("mac:")))
+}
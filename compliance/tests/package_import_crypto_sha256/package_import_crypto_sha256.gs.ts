@@ -0,0 +1,76 @@
+// Generated file based on package_import_crypto_sha256.go
+// Updated when compliance tests are re-run, DO NOT EDIT!
+
+import * as $ from "@goscript/builtin/index.js";
+
+import * as bytes from "@goscript/bytes/index.js"
+
+import * as hmac from "@goscript/crypto/hmac/index.js"
+
+import * as md5 from "@goscript/crypto/md5/index.js"
+
+import * as sha1 from "@goscript/crypto/sha1/index.js"
+
+import * as sha256 from "@goscript/crypto/sha256/index.js"
+
+import * as hex from "@goscript/encoding/hex/index.js"
+
+import * as fmt from "@goscript/fmt/index.js"
+
+import * as hash from "@goscript/hash/index.js"
+
+export async function main(): Promise<void> {
+	let data = $.stringToBytes("The quick brown fox jumps over the lazy dog")
+
+	let sum = sha256.Sum256(data)
+	fmt.Println(hex.EncodeToString($.goSlice(sum, undefined, undefined)), $.len(sum))
+	fmt.Println(fmt.Sprintf("%x", $.markByteArray(sha256.Sum256(data))) == hex.EncodeToString($.goSlice(sum, undefined, undefined)))
+	fmt.Printf("%X\n", $.markByteArray(sum))
+	let sum224 = sha256.Sum224(data)
+	fmt.Println(hex.EncodeToString($.goSlice(sum224, undefined, undefined)))
+	let s1 = sha1.Sum(data)
+	fmt.Println(hex.EncodeToString($.goSlice(s1, undefined, undefined)))
+	let m = md5.Sum(data)
+	fmt.Println(hex.EncodeToString($.goSlice(m, undefined, undefined)))
+	fmt.Printf("%x %x %x\n", $.markByteArray(sum224), $.markByteArray(s1), $.markByteArray(md5.Sum(data)))
+	fmt.Println(fmt.Sprintf("%x", $.markByteArray(sha1.Sum(data))) == hex.EncodeToString($.goSlice(s1, undefined, undefined)), fmt.Sprint($.len(m)))
+
+	// Streaming writes split across block boundaries.
+	let h = sha256.New()
+	for (let i = 0; i < $.len(data); i += 7) {
+		let end = i + 7
+		if (end > $.len(data)) {
+			end = $.len(data)
+		}
+		h!.Write($.goSlice(data, i, end))
+	}
+	fmt.Printf("%x %d %d\n", h!.Sum(null), h!.Size(), h!.BlockSize())
+	fmt.Println(bytes.Equal(h!.Sum(null), $.goSlice(sum, undefined, undefined)))
+
+	// Large inputs give the same digests.
+	let large = bytes.Repeat(data, 200)
+	let big = sha256.Sum256(large)
+	h!.Reset()
+	h!.Write(large)
+	fmt.Println(bytes.Equal(h!.Sum(null), $.goSlice(big, undefined, undefined)), hex.EncodeToString($.goSlice(big, undefined, 4)))
+
+	let news = $.arrayToSlice<(() => hash.Hash) | null>([sha256.New, sha256.New224, sha1.New, md5.New])
+	for (let _i = 0; _i < $.len(news); _i++) {
+		const newHash = news![_i]
+		{
+			let mac = hmac.New(newHash, $.stringToBytes("key"))
+			mac!.Write(data)
+			let tag = mac!.Sum(null)
+			fmt.Println(hex.EncodeToString(tag))
+
+			mac!.Reset()
+			mac!.Write(data)
+			fmt.Println(hmac.Equal(tag, mac!.Sum(null)), hmac.Equal(tag, $.goSlice(tag, 1, undefined)))
+		}
+	}
+
+	let long = hmac.New(sha256.New, bytes.Repeat($.stringToBytes("k"), 100))
+	long!.Write(data)
+	fmt.Printf("%x\n", long!.Sum($.stringToBytes("mac:")))
+}
+
@@ -64,17 +64,21 @@ export async function main(): Promise<void> {
 
 	// Start worker goroutines
 	for (let i = 0; i < numWorkers; i++) {
-		queueMicrotask(() => {
-			worker(i)
-		})
+		{
+			const __fn = worker
+			const __arg0 = i
+			$.go(async () => {
+				await __fn!(__arg0)
+			}, "package_import_csync.go:47")
+		}
 	}
 
 	// Wait for all workers to complete or context timeout
 	let done = $.makeChannel<{  }>(0, {}, 'both')
-	queueMicrotask(async () => {
+	$.go(async () => {
 		await wg.Wait()
 		done.close()
-	})
+	}, "package_import_csync.go:52")
 
 	const [_select_has_return_08c5, _select_value_08c5] = await $.selectStatement([
 		{
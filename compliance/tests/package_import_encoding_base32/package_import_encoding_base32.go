@@ -0,0 +1,44 @@
+package main
+
+import (
+	"bytes"
+	"encoding/base32"
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+)
+
+func main() {
+	data := append([]byte{0, 0xff}, "hello"...)
+	for _, enc := range []*base32.Encoding{
+		base32.StdEncoding,
+		base32.HexEncoding,
+		base32.StdEncoding.WithPadding(base32.NoPadding),
+	} {
+		s := enc.EncodeToString(data)
+		b, err := enc.DecodeString(s)
+		fmt.Println(s, enc.EncodedLen(len(data)), bytes.Equal(b, data), err)
+	}
+
+	// Corrupt input reports the offset of the bad byte.
+	b, err := base32.StdEncoding.DecodeString("NBSWY3DPx")
+	fmt.Printf("%q %v\n", b, err)
+	var cie base32.CorruptInputError
+	fmt.Println(errors.As(err, &cie), int64(cie))
+	_, err = base32.StdEncoding.DecodeString("NBSWY3DPEE=====")
+	fmt.Println(err)
+
+	b, err = base32.StdEncoding.DecodeString("NBSW\nY3DPEB3W64TMMQ======")
+	fmt.Println(string(b), err)
+
+	// Streams.
+	var buf bytes.Buffer
+	w := base32.NewEncoder(base32.StdEncoding, &buf)
+	w.Write([]byte("ab"))
+	w.Write([]byte("cdefg"))
+	w.Close()
+	fmt.Println(buf.String())
+	out, err := io.ReadAll(base32.NewDecoder(base32.StdEncoding, strings.NewReader("MFRGGZDF\nMZTQ====")))
+	fmt.Println(string(out), err)
+}
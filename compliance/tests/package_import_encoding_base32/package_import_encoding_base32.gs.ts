@@ -0,0 +1,51 @@
+// Generated file based on package_import_encoding_base32.go
+// Updated when compliance tests are re-run, DO NOT EDIT!
+
+import * as $ from "@goscript/builtin/index.js";
+
+import * as bytes from "@goscript/bytes/index.js"
+
+import * as base32 from "@goscript/encoding/base32/index.js"
+
+import * as errors from "@goscript/errors/index.js"
+
+import * as fmt from "@goscript/fmt/index.js"
+
+import * as io from "@goscript/io/index.js"
+
+import * as strings from "@goscript/strings/index.js"
+
+export async function main(): Promise<void> {
+	let data = $.append(new Uint8Array([0, 0xff]), ...$.stringToBytes("hello"))
+	for (let _i = 0; _i < $.len($.arrayToSlice<base32.Encoding | null>([base32.StdEncoding, base32.HexEncoding, base32.StdEncoding!.WithPadding(base32.NoPadding)])); _i++) {
+		const enc = $.arrayToSlice<base32.Encoding | null>([base32.StdEncoding, base32.HexEncoding, base32.StdEncoding!.WithPadding(base32.NoPadding)])![_i]
+		{
+			let s = enc!.EncodeToString(data)
+			let [b, err] = enc!.DecodeString(s)
+			fmt.Println(s, enc!.EncodedLen($.len(data)), bytes.Equal(b, data), err)
+		}
+	}
+
+	// Corrupt input reports the offset of the bad byte.
+	let [b, err] = base32.StdEncoding!.DecodeString("NBSWY3DPx")
+	fmt.Printf("%q %v\n", b, err)
+	let cie: $.VarRef<base32.CorruptInputError> = $.varRef(0)
+	fmt.Println(errors.As(err, cie, { kind: $.TypeKind.Pointer, elemType: "encoding/base32.CorruptInputError" }), cie!.value)
+	;[, err] = base32.StdEncoding!.DecodeString("NBSWY3DPEE=====")
+	fmt.Println(err)
+
+	;[b, err] = base32.StdEncoding!.DecodeString("NBSW\nY3DPEB3W64TMMQ======")
+	fmt.Println($.bytesToString(b), err)
+
+	// Streams.
+	let buf: bytes.Buffer = new bytes.Buffer()
+	let w = base32.NewEncoder(base32.StdEncoding, buf)
+	w!.Write($.stringToBytes("ab"))
+	w!.Write($.stringToBytes("cdefg"))
+	w!.Close()
+	fmt.Println(buf.String())
+	let out: $.Bytes
+	[out, err] = io.ReadAll(base32.NewDecoder(base32.StdEncoding, strings.NewReader("MFRGGZDF\nMZTQ====")))
+	fmt.Println($.bytesToString(out), err)
+}
+
@@ -0,0 +1,55 @@
+package main
+
+import (
+	"bytes"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+)
+
+func main() {
+	data := append([]byte{0, 0xff}, "goscript?>"...)
+	for _, enc := range []*base64.Encoding{
+		base64.StdEncoding,
+		base64.URLEncoding,
+		base64.RawStdEncoding,
+		base64.RawURLEncoding,
+	} {
+		s := enc.EncodeToString(data)
+		b, err := enc.DecodeString(s)
+		fmt.Println(s, enc.EncodedLen(len(data)), bytes.Equal(b, data), err)
+	}
+
+	// Corrupt input reports the offset of the bad byte.
+	_, err := base64.StdEncoding.DecodeString("aGVsbG8")
+	fmt.Println(err)
+	var cie base64.CorruptInputError
+	fmt.Println(errors.As(err, &cie), int64(cie))
+	b, err := base64.StdEncoding.DecodeString("aGVs!G8=")
+	fmt.Printf("%q %v\n", b, err)
+	_, err = base64.StdEncoding.Strict().DecodeString("aGVsbB==")
+	fmt.Println(err)
+
+	// Newlines are ignored and padding can be changed.
+	b, err = base64.StdEncoding.DecodeString("aGVs\r\nbG8=")
+	fmt.Println(string(b), err)
+	custom := base64.StdEncoding.WithPadding('*')
+	fmt.Println(custom.EncodeToString([]byte("hi")))
+
+	dst := make([]byte, base64.StdEncoding.DecodedLen(8))
+	n, err := base64.StdEncoding.Decode(dst, []byte("aGVsbG8="))
+	fmt.Println(n, string(dst[:n]), err)
+	fmt.Println(string(base64.URLEncoding.AppendEncode([]byte("x:"), []byte{0xfb, 0xff})))
+
+	// Streams.
+	var buf bytes.Buffer
+	w := base64.NewEncoder(base64.StdEncoding, &buf)
+	w.Write([]byte("ab"))
+	w.Write([]byte("cdefg"))
+	w.Close()
+	fmt.Println(buf.String())
+	out, err := io.ReadAll(base64.NewDecoder(base64.StdEncoding, strings.NewReader("YWJj\nZGVmZw==")))
+	fmt.Println(string(out), err)
+}
@@ -0,0 +1,63 @@
+// Generated file based on package_import_encoding_base64.go
+// Updated when compliance tests are re-run, DO NOT EDIT!
+
+import * as $ from "@goscript/builtin/index.js";
+
+import * as bytes from "@goscript/bytes/index.js"
+
+import * as base64 from "@goscript/encoding/base64/index.js"
+
+import * as errors from "@goscript/errors/index.js"
+
+import * as fmt from "@goscript/fmt/index.js"
+
+import * as io from "@goscript/io/index.js"
+
+import * as strings from "@goscript/strings/index.js"
+
+export async function main(): Promise<void> {
+	let data = $.append(new Uint8Array([0, 0xff]), ...$.stringToBytes("goscript?>"))
+	for (let _i = 0; _i < $.len($.arrayToSlice<base64.Encoding | null>([base64.StdEncoding, base64.URLEncoding, base64.RawStdEncoding, base64.RawURLEncoding])); _i++) {
+		const enc = $.arrayToSlice<base64.Encoding | null>([base64.StdEncoding, base64.URLEncoding, base64.RawStdEncoding, base64.RawURLEncoding])![_i]
+		{
+			let s = enc!.EncodeToString(data)
+			let [b, err] = enc!.DecodeString(s)
+			fmt.Println(s, enc!.EncodedLen($.len(data)), bytes.Equal(b, data), err)
+		}
+	}
+
+	// Corrupt input reports the offset of the bad byte.
+	let [, err] = base64.StdEncoding!.DecodeString("aGVsbG8")
+	fmt.Println(err)
+	let cie: $.VarRef<base64.CorruptInputError> = $.varRef(0)
+	fmt.Println(errors.As(err, cie, { kind: $.TypeKind.Pointer, elemType: "encoding/base64.CorruptInputError" }), cie!.value)
+	let b: $.Bytes
+	[b, err] = base64.StdEncoding!.DecodeString("aGVs!G8=")
+	fmt.Printf("%q %v\n", b, err)
+	;[, err] = base64.StdEncoding!.Strict()!.DecodeString("aGVsbB==")
+	fmt.Println(err)
+
+	// Newlines are ignored and padding can be changed.
+	;[b, err] = base64.StdEncoding!.DecodeString("aGVs\r\nbG8=")
+	fmt.Println($.bytesToString(b), err)
+	let custom = base64.StdEncoding!.WithPadding(42)
+	fmt.Println(custom!.EncodeToString($.stringToBytes("hi")))
+
+	let dst = new Uint8Array(base64.StdEncoding!.DecodedLen(8))
+	let n: number
+	[n, err] = base64.StdEncoding!.Decode(dst, $.stringToBytes("aGVsbG8="))
+	fmt.Println(n, $.bytesToString($.goSlice(dst, undefined, n)), err)
+	fmt.Println($.bytesToString(base64.URLEncoding!.AppendEncode($.stringToBytes("x:"), new Uint8Array([0xfb, 0xff]))))
+
+	// Streams.
+	let buf: bytes.Buffer = new bytes.Buffer()
+	let w = base64.NewEncoder(base64.StdEncoding, buf)
+	w!.Write($.stringToBytes("ab"))
+	w!.Write($.stringToBytes("cdefg"))
+	w!.Close()
+	fmt.Println(buf.String())
+	let out: $.Bytes
+	[out, err] = io.ReadAll(base64.NewDecoder(base64.StdEncoding, strings.NewReader("YWJj\nZGVmZw==")))
+	fmt.Println($.bytesToString(out), err)
+}
+
@@ -0,0 +1 @@
+export { Header } from "./package_import_encoding_binary.gs.js"
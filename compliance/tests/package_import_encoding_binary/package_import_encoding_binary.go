@@ -0,0 +1,79 @@
+package main
+
+import (
+	"bytes"
+	"encoding/binary"
+	"fmt"
+	"math"
+)
+
+// Header is a wire-format header with a padding field.
+type Header struct {
+	Magic [4]byte
+	Len   uint32
+	_     uint16
+	Flags int64
+	Vals  [2]float32
+	In    struct{ A, B int8 }
+	OK    bool
+	F     float64
+}
+
+func main() {
+	// Byte orders.
+	b := make([]byte, 8)
+	binary.BigEndian.PutUint32(b, 0xdeadbeef)
+	binary.LittleEndian.PutUint16(b[4:], 0x1234)
+	fmt.Printf("%x %x\n", b, binary.LittleEndian.Uint32(b))
+	fmt.Printf("%x\n", binary.BigEndian.AppendUint16([]byte{1}, 0xabcd))
+	fmt.Println(binary.BigEndian, binary.NativeEndian)
+
+	// Varints.
+	for _, x := range []uint64{0, 127, 128, 300, 1 << 40} {
+		buf := binary.AppendUvarint(nil, x)
+		v, n := binary.Uvarint(buf)
+		fmt.Printf("%d %x %d %d\n", x, buf, v, n)
+	}
+	for _, x := range []int64{-1, 1, -64, 64, math.MinInt32} {
+		buf := make([]byte, binary.MaxVarintLen64)
+		n := binary.PutVarint(buf, x)
+		v, m := binary.Varint(buf[:n])
+		fmt.Printf("%d %x %d %d\n", x, buf[:n], v, m)
+	}
+	v, n := binary.Uvarint([]byte{0x80})
+	fmt.Println(v, n)
+	v, err := binary.ReadUvarint(bytes.NewReader([]byte{0xac}))
+	fmt.Println(v, err)
+
+	// Structs with nested arrays, structs and padding.
+	h := Header{Magic: [4]byte{'G', 'S', 0, 1}, Len: 7, Flags: -2, OK: true, F: 0.1}
+	h.Vals = [2]float32{1.5, -0.25}
+	h.In.A = -3
+	h.In.B = 4
+	var buf bytes.Buffer
+	fmt.Println(binary.Size(h), binary.Write(&buf, binary.BigEndian, &h))
+	enc, err := binary.Append(nil, binary.BigEndian, h)
+	fmt.Printf("%x %v %v\n", enc, err, bytes.Equal(enc, buf.Bytes()))
+
+	var h2 Header
+	err = binary.Read(bytes.NewReader(buf.Bytes()), binary.BigEndian, &h2)
+	fmt.Println(err, h2.Magic, h2.Len, h2.Flags, h2.Vals, h2.In.A, h2.In.B, h2.OK, h2.F)
+
+	// Slices and pointers to basic values.
+	out, err := binary.Append(nil, binary.LittleEndian, []uint16{1, 2})
+	fmt.Printf("%x %v\n", out, err)
+	var x uint16
+	n, err = binary.Decode([]byte{1, 2}, binary.BigEndian, &x)
+	fmt.Println(n, err, x)
+	vals := make([]int32, 2)
+	err = binary.Read(bytes.NewReader([]byte{0, 0, 0, 1, 0xff, 0xff, 0xff, 0xfe}), binary.BigEndian, vals)
+	fmt.Println(err, vals)
+
+	// Errors.
+	fmt.Println(binary.Write(&buf, binary.LittleEndian, []int{1}))
+	fmt.Println(binary.Read(&buf, binary.LittleEndian, h))
+	fmt.Println(binary.Read(bytes.NewReader([]byte{1}), binary.LittleEndian, &x))
+	fmt.Println(binary.Read(bytes.NewReader(nil), binary.LittleEndian, &x))
+	n, err = binary.Encode(make([]byte, 1), binary.LittleEndian, uint16(1))
+	fmt.Println(n, err)
+}
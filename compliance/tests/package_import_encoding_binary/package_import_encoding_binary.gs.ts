@@ -0,0 +1,177 @@
+// Generated file based on package_import_encoding_binary.go
+// Updated when compliance tests are re-run, DO NOT EDIT!
+
+import * as $ from "@goscript/builtin/index.js";
+
+import * as bytes from "@goscript/bytes/index.js"
+
+import * as binary from "@goscript/encoding/binary/index.js"
+
+import * as fmt from "@goscript/fmt/index.js"
+
+import * as math from "@goscript/math/index.js"
+
+export class Header {
+	public get Magic(): number[] {
+		return this._fields.Magic.value
+	}
+	public set Magic(value: number[]) {
+		this._fields.Magic.value = value
+	}
+
+	public get Len(): number {
+		return this._fields.Len.value
+	}
+	public set Len(value: number) {
+		this._fields.Len.value = value
+	}
+
+	public get Flags(): number {
+		return this._fields.Flags.value
+	}
+	public set Flags(value: number) {
+		this._fields.Flags.value = value
+	}
+
+	public get Vals(): number[] {
+		return this._fields.Vals.value
+	}
+	public set Vals(value: number[]) {
+		this._fields.Vals.value = value
+	}
+
+	public get In(): { A?: number; B?: number } {
+		return this._fields.In.value
+	}
+	public set In(value: { A?: number; B?: number }) {
+		this._fields.In.value = value
+	}
+
+	public get OK(): boolean {
+		return this._fields.OK.value
+	}
+	public set OK(value: boolean) {
+		this._fields.OK.value = value
+	}
+
+	public get F(): number {
+		return this._fields.F.value
+	}
+	public set F(value: number) {
+		this._fields.F.value = value
+	}
+
+	public _fields: {
+		Magic: $.VarRef<number[]>;
+		Len: $.VarRef<number>;
+		Flags: $.VarRef<number>;
+		Vals: $.VarRef<number[]>;
+		In: $.VarRef<{ A?: number; B?: number }>;
+		OK: $.VarRef<boolean>;
+		F: $.VarRef<number>;
+	}
+
+	constructor(init?: Partial<{F?: number, Flags?: number, In?: { A?: number; B?: number }, Len?: number, Magic?: number[], OK?: boolean, Vals?: number[]}>) {
+		this._fields = {
+			Magic: $.varRef(init?.Magic ?? [0, 0, 0, 0]),
+			Len: $.varRef(init?.Len ?? 0),
+			Flags: $.varRef(init?.Flags ?? 0),
+			Vals: $.varRef(init?.Vals ?? [0, 0]),
+			In: $.varRef(init?.In ?? {}),
+			OK: $.varRef(init?.OK ?? false),
+			F: $.varRef(init?.F ?? 0)
+		}
+	}
+
+	public clone(): Header {
+		const cloned = new Header()
+		cloned._fields = {
+			Magic: $.varRef(this._fields.Magic.value),
+			Len: $.varRef(this._fields.Len.value),
+			Flags: $.varRef(this._fields.Flags.value),
+			Vals: $.varRef(this._fields.Vals.value),
+			In: $.varRef(this._fields.In.value),
+			OK: $.varRef(this._fields.OK.value),
+			F: $.varRef(this._fields.F.value)
+		}
+		return cloned
+	}
+
+	// Register this type with the runtime type system
+	static __typeInfo = $.registerStructType(
+	  'main@github.com/aperturerobotics/goscript/compliance/tests/package_import_encoding_binary.Header',
+	  new Header(),
+	  [],
+	  Header,
+	  [{ name: "Magic", type: { kind: $.TypeKind.Array, length: 4, elemType: { kind: $.TypeKind.Basic, name: "byte" } } }, { name: "Len", type: { kind: $.TypeKind.Basic, name: "uint32" } }, { name: "_", type: { kind: $.TypeKind.Basic, name: "uint16" } }, { name: "Flags", type: { kind: $.TypeKind.Basic, name: "int64" } }, { name: "Vals", type: { kind: $.TypeKind.Array, length: 2, elemType: { kind: $.TypeKind.Basic, name: "float32" } } }, { name: "In", type: { kind: $.TypeKind.Struct, fields: [{ name: "A", type: { kind: $.TypeKind.Basic, name: "int8" } }, { name: "B", type: { kind: $.TypeKind.Basic, name: "int8" } }], methods: [] } }, { name: "OK", type: { kind: $.TypeKind.Basic, name: "bool" } }, { name: "F", type: { kind: $.TypeKind.Basic, name: "float64" } }]
+	);
+}
+
+export async function main(): Promise<void> {
+	// Byte orders.
+	let b = new Uint8Array(8)
+	binary.BigEndian.PutUint32(b, 0xdeadbeef)
+	binary.LittleEndian.PutUint16($.goSlice(b, 4, undefined), 0x1234)
+	fmt.Printf("%x %x\n", b, binary.LittleEndian.Uint32(b))
+	fmt.Printf("%x\n", binary.BigEndian.AppendUint16(new Uint8Array([1]), 0xabcd))
+	fmt.Println($.markStructValue(binary.BigEndian.clone()), $.markStructValue(binary.NativeEndian.clone()))
+
+	// Varints.
+	for (let _i = 0; _i < $.len($.arrayToSlice<number>([0, 127, 128, 300, 1099511627776])); _i++) {
+		const x = $.arrayToSlice<number>([0, 127, 128, 300, 1099511627776])![_i]
+		{
+			let buf = binary.AppendUvarint(null, x)
+			let [v, n] = binary.Uvarint(buf)
+			fmt.Printf("%d %x %d %d\n", x, buf, v, n)
+		}
+	}
+	for (let _i = 0; _i < $.len($.arrayToSlice<number>([-1, 1, -64, 64, math.MinInt32])); _i++) {
+		const x = $.arrayToSlice<number>([-1, 1, -64, 64, math.MinInt32])![_i]
+		{
+			let buf = new Uint8Array(binary.MaxVarintLen64)
+			let n = binary.PutVarint(buf, x)
+			let [v, m] = binary.Varint($.goSlice(buf, undefined, n))
+			fmt.Printf("%d %x %d %d\n", x, $.goSlice(buf, undefined, n), v, m)
+		}
+	}
+	let [v, n] = binary.Uvarint(new Uint8Array([0x80]))
+	fmt.Println(v, n)
+	let err: $.GoError
+	[v, err] = binary.ReadUvarint(bytes.NewReader(new Uint8Array([0xac])))
+	fmt.Println(v, err)
+
+	// Structs with nested arrays, structs and padding.
+	let h = new Header({F: 0.1, Flags: -2, Len: 7, Magic: $.arrayToSlice<number>([71, 83, 0, 1]), OK: true})
+	h.Vals = $.arrayToSlice<number>([1.5, -0.25])
+	h.In.A = -3
+	h.In.B = 4
+	let buf: bytes.Buffer = new bytes.Buffer()
+	fmt.Println(await binary.Size($.markStructValue(h.clone()), "main@github.com/aperturerobotics/goscript/compliance/tests/package_import_encoding_binary.Header"), await binary.Write(buf, $.markStructValue(binary.BigEndian.clone()), h, { kind: $.TypeKind.Pointer, elemType: "main@github.com/aperturerobotics/goscript/compliance/tests/package_import_encoding_binary.Header" }))
+	let enc: $.Bytes
+	[enc, err] = await binary.Append(null, $.markStructValue(binary.BigEndian.clone()), $.markStructValue(h.clone()), "main@github.com/aperturerobotics/goscript/compliance/tests/package_import_encoding_binary.Header")
+	fmt.Printf("%x %v %v\n", enc, err, bytes.Equal(enc, buf.Bytes()))
+
+	let h2: Header = new Header()
+	err = await binary.Read(bytes.NewReader(buf.Bytes()), $.markStructValue(binary.BigEndian.clone()), h2, { kind: $.TypeKind.Pointer, elemType: "main@github.com/aperturerobotics/goscript/compliance/tests/package_import_encoding_binary.Header" })
+	fmt.Println(err, $.markByteArray(h2.Magic), h2.Len, h2.Flags, h2.Vals, h2.In.A, h2.In.B, h2.OK, h2.F)
+
+	// Slices and pointers to basic values.
+	let out: $.Bytes
+	[out, err] = await binary.Append(null, $.markStructValue(binary.LittleEndian.clone()), $.arrayToSlice<number>([1, 2]), { kind: $.TypeKind.Slice, elemType: { kind: $.TypeKind.Basic, name: "uint16" } })
+	fmt.Printf("%x %v\n", out, err)
+	let x: $.VarRef<number> = $.varRef(0)
+	;[n, err] = await binary.Decode(new Uint8Array([1, 2]), $.markStructValue(binary.BigEndian.clone()), x, { kind: $.TypeKind.Pointer, elemType: { kind: $.TypeKind.Basic, name: "uint16" } })
+	fmt.Println(n, err, x!.value)
+	let vals = $.makeSlice<number>(2, undefined, 'number')
+	err = await binary.Read(bytes.NewReader(new Uint8Array([0, 0, 0, 1, 0xff, 0xff, 0xff, 0xfe])), $.markStructValue(binary.BigEndian.clone()), vals, { kind: $.TypeKind.Slice, elemType: { kind: $.TypeKind.Basic, name: "int32" } })
+	fmt.Println(err, vals)
+
+	// Errors.
+	fmt.Println(await binary.Write(buf, $.markStructValue(binary.LittleEndian.clone()), $.arrayToSlice<number>([1]), { kind: $.TypeKind.Slice, elemType: { kind: $.TypeKind.Basic, name: "int" } }))
+	fmt.Println(await binary.Read(buf, $.markStructValue(binary.LittleEndian.clone()), $.markStructValue(h.clone()), "main@github.com/aperturerobotics/goscript/compliance/tests/package_import_encoding_binary.Header"))
+	fmt.Println(await binary.Read(bytes.NewReader(new Uint8Array([1])), $.markStructValue(binary.LittleEndian.clone()), x, { kind: $.TypeKind.Pointer, elemType: { kind: $.TypeKind.Basic, name: "uint16" } }))
+	fmt.Println(await binary.Read(bytes.NewReader(null), $.markStructValue(binary.LittleEndian.clone()), x, { kind: $.TypeKind.Pointer, elemType: { kind: $.TypeKind.Basic, name: "uint16" } }))
+	;[n, err] = await binary.Encode(new Uint8Array(1), $.markStructValue(binary.LittleEndian.clone()), 1, { kind: $.TypeKind.Basic, name: "uint16" })
+	fmt.Println(n, err)
+}
+
@@ -0,0 +1,50 @@
+package main
+
+import (
+	"bytes"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+)
+
+func main() {
+	src := append([]byte("Hello"), 0, 0xff)
+	s := hex.EncodeToString(src)
+	fmt.Println(s, hex.EncodedLen(len(src)))
+
+	b, err := hex.DecodeString(s)
+	fmt.Println(bytes.Equal(b, src), err)
+
+	dst := make([]byte, hex.EncodedLen(2))
+	n := hex.Encode(dst, []byte{0xde, 0xad})
+	fmt.Println(n, string(dst))
+	fmt.Printf("%x\n", hex.AppendEncode([]byte("0x"), []byte{1, 2}))
+
+	// Errors.
+	_, err = hex.DecodeString("abc")
+	fmt.Println(err, err == hex.ErrLength)
+	_, err = hex.DecodeString("zz")
+	fmt.Println(err)
+	var ibe hex.InvalidByteError
+	fmt.Println(errors.As(err, &ibe), byte(ibe))
+	_, err = hex.DecodeString("0g")
+	fmt.Println(err)
+
+	// Streams.
+	r := hex.NewDecoder(strings.NewReader("48656c6c6f"))
+	out, err := io.ReadAll(r)
+	fmt.Println(string(out), err)
+	var buf bytes.Buffer
+	w := hex.NewEncoder(&buf)
+	w.Write([]byte("hi"))
+	fmt.Println(buf.String())
+
+	fmt.Print(hex.Dump([]byte("goscript hex dump test!")))
+	var dump bytes.Buffer
+	d := hex.Dumper(&dump)
+	d.Write([]byte("abc"))
+	d.Close()
+	fmt.Print(dump.String())
+}
@@ -0,0 +1,58 @@
+// Generated file based on package_import_encoding_hex.go
+// Updated when compliance tests are re-run, DO NOT EDIT!
+
+import * as $ from "@goscript/builtin/index.js";
+
+import * as bytes from "@goscript/bytes/index.js"
+
+import * as hex from "@goscript/encoding/hex/index.js"
+
+import * as errors from "@goscript/errors/index.js"
+
+import * as fmt from "@goscript/fmt/index.js"
+
+import * as io from "@goscript/io/index.js"
+
+import * as strings from "@goscript/strings/index.js"
+
+export async function main(): Promise<void> {
+	let src = $.append($.stringToBytes("Hello"), 0, 0xff)
+	let s = hex.EncodeToString(src)
+	fmt.Println(s, hex.EncodedLen($.len(src)))
+
+	let [b, err] = hex.DecodeString(s)
+	fmt.Println(bytes.Equal(b, src), err)
+
+	let dst = new Uint8Array(hex.EncodedLen(2))
+	let n = hex.Encode(dst, new Uint8Array([0xde, 0xad]))
+	fmt.Println(n, $.bytesToString(dst))
+	fmt.Printf("%x\n", hex.AppendEncode($.stringToBytes("0x"), new Uint8Array([1, 2])))
+
+	// Errors.
+	;[, err] = hex.DecodeString("abc")
+	fmt.Println(err, err == hex.ErrLength)
+	;[, err] = hex.DecodeString("zz")
+	fmt.Println(err)
+	let ibe: $.VarRef<hex.InvalidByteError> = $.varRef(0)
+	fmt.Println(errors.As(err, ibe, { kind: $.TypeKind.Pointer, elemType: "encoding/hex.InvalidByteError" }), $.byte(ibe!.value))
+	;[, err] = hex.DecodeString("0g")
+	fmt.Println(err)
+
+	// Streams.
+	let r = hex.NewDecoder(strings.NewReader("48656c6c6f"))
+	let out: $.Bytes
+	[out, err] = io.ReadAll(r)
+	fmt.Println($.bytesToString(out), err)
+	let buf: bytes.Buffer = new bytes.Buffer()
+	let w = hex.NewEncoder(buf)
+	w!.Write($.stringToBytes("hi"))
+	fmt.Println(buf.String())
+
+	fmt.Print(hex.Dump($.stringToBytes("goscript hex dump test!")))
+	let dump: bytes.Buffer = new bytes.Buffer()
+	let d = hex.Dumper(dump)
+	d!.Write($.stringToBytes("abc"))
+	d!.Close()
+	fmt.Print(dump.String())
+}
+
@@ -0,0 +1,43 @@
+package main
+
+import (
+	"fmt"
+	"hash"
+	"hash/adler32"
+	"hash/crc32"
+	"hash/fnv"
+	"io"
+)
+
+func main() {
+	data := []byte("The quick brown fox jumps over the lazy dog")
+
+	fmt.Println(crc32.ChecksumIEEE(data), crc32.Size)
+	castagnoli := crc32.MakeTable(crc32.Castagnoli)
+	fmt.Println(crc32.Checksum(data, castagnoli))
+	crc := crc32.Update(0, castagnoli, data[:10])
+	fmt.Println(crc32.Update(crc, castagnoli, data[10:]))
+
+	var h32 hash.Hash32 = crc32.NewIEEE()
+	io.WriteString(h32, "The quick brown fox ")
+	h32.Write([]byte("jumps over the lazy dog"))
+	fmt.Printf("%x %d %d %d\n", h32.Sum(nil), h32.Sum32(), h32.Size(), h32.BlockSize())
+
+	fmt.Println(adler32.Checksum(data))
+	a := adler32.New()
+	a.Write(data)
+	fmt.Printf("%x\n", a.Sum([]byte("adler32:")))
+
+	hashes := []hash.Hash{fnv.New32(), fnv.New32a(), fnv.New64(), fnv.New64a(), fnv.New128(), fnv.New128a()}
+	for _, h := range hashes {
+		h.Write(data)
+		fmt.Printf("%d %x\n", h.Size(), h.Sum(nil))
+	}
+
+	var h64 hash.Hash64 = fnv.New64a()
+	h64.Write([]byte("goscript"))
+	sum := h64.Sum64()
+	fmt.Println(sum, sum>>32)
+	h64.Reset()
+	fmt.Println(h64.Sum64())
+}
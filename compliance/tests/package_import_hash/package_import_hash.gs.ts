@@ -0,0 +1,55 @@
+// Generated file based on package_import_hash.go
+// Updated when compliance tests are re-run, DO NOT EDIT!
+
+import * as $ from "@goscript/builtin/index.js";
+
+$.setBigInt64Mode(true)
+
+import * as fmt from "@goscript/fmt/index.js"
+
+import * as hash from "@goscript/hash/index.js"
+
+import * as adler32 from "@goscript/hash/adler32/index.js"
+
+import * as crc32 from "@goscript/hash/crc32/index.js"
+
+import * as fnv from "@goscript/hash/fnv/index.js"
+
+import * as io from "@goscript/io/index.js"
+
+export async function main(): Promise<void> {
+	let data = $.stringToBytes("The quick brown fox jumps over the lazy dog")
+
+	fmt.Println(crc32.ChecksumIEEE(data), crc32.Size)
+	let castagnoli = crc32.MakeTable(crc32.Castagnoli)
+	fmt.Println(crc32.Checksum(data, castagnoli))
+	let crc = crc32.Update(0, castagnoli, $.goSlice(data, undefined, 10))
+	fmt.Println(crc32.Update(crc, castagnoli, $.goSlice(data, 10, undefined)))
+
+	let h32: hash.Hash32 = crc32.NewIEEE()
+	io.WriteString(h32, "The quick brown fox ")
+	h32!.Write($.stringToBytes("jumps over the lazy dog"))
+	fmt.Printf("%x %d %d %d\n", h32!.Sum(null), h32!.Sum32(), h32!.Size(), h32!.BlockSize())
+
+	fmt.Println(adler32.Checksum(data))
+	let a = adler32.New()
+	a!.Write(data)
+	fmt.Printf("%x\n", a!.Sum($.stringToBytes("adler32:")))
+
+	let hashes = $.arrayToSlice<hash.Hash>([fnv.New32(), fnv.New32a(), fnv.New64(), fnv.New64a(), fnv.New128(), fnv.New128a()])
+	for (let _i = 0; _i < $.len(hashes); _i++) {
+		const h = hashes![_i]
+		{
+			h!.Write(data)
+			fmt.Printf("%d %x\n", h!.Size(), h!.Sum(null))
+		}
+	}
+
+	let h64: hash.Hash64 = fnv.New64a()
+	h64!.Write($.stringToBytes("goscript"))
+	let sum = h64!.Sum64()
+	fmt.Println(sum, (sum >> 32n))
+	h64!.Reset()
+	fmt.Println(h64!.Sum64())
+}
+
@@ -27,24 +27,24 @@ export async function main(): Promise<void> {
 	console.log("ErrClosed:", fs.ErrClosed!.Error())
 
 	// Test all FileMode constants
-	console.log("ModeDir:", $.int(fs.ModeDir))
-	console.log("ModeAppend:", $.int(fs.ModeAppend))
-	console.log("ModeExclusive:", $.int(fs.ModeExclusive))
-	console.log("ModeTemporary:", $.int(fs.ModeTemporary))
-	console.log("ModeSymlink:", $.int(fs.ModeSymlink))
-	console.log("ModeDevice:", $.int(fs.ModeDevice))
-	console.log("ModeNamedPipe:", $.int(fs.ModeNamedPipe))
-	console.log("ModeSocket:", $.int(fs.ModeSocket))
-	console.log("ModeSetuid:", $.int(fs.ModeSetuid))
-	console.log("ModeSetgid:", $.int(fs.ModeSetgid))
-	console.log("ModeCharDevice:", $.int(fs.ModeCharDevice))
-	console.log("ModeSticky:", $.int(fs.ModeSticky))
-	console.log("ModeIrregular:", $.int(fs.ModeIrregular))
-	console.log("ModeType:", $.int(fs.ModeType))
-	console.log("ModePerm:", $.int(fs.ModePerm))
+	console.log("ModeDir:", 2147483648)
+	console.log("ModeAppend:", 1073741824)
+	console.log("ModeExclusive:", 536870912)
+	console.log("ModeTemporary:", 268435456)
+	console.log("ModeSymlink:", 134217728)
+	console.log("ModeDevice:", 67108864)
+	console.log("ModeNamedPipe:", 33554432)
+	console.log("ModeSocket:", 16777216)
+	console.log("ModeSetuid:", 8388608)
+	console.log("ModeSetgid:", 4194304)
+	console.log("ModeCharDevice:", 2097152)
+	console.log("ModeSticky:", 1048576)
+	console.log("ModeIrregular:", 524288)
+	console.log("ModeType:", 2401763328)
+	console.log("ModePerm:", 511)
 
 	// Test FileMode methods
-	let mode = ((fs.ModeDir | 0o755) as fs.FileMode)
+	let mode = (2147484141 as fs.FileMode)
 	console.log("FileMode.IsDir():", fs.FileMode_IsDir(mode))
 	console.log("FileMode.IsRegular():", fs.FileMode_IsRegular(mode))
 	console.log("FileMode.Perm():", $.int(fs.FileMode_Perm(mode)))
@@ -14,7 +14,7 @@ export function getValue(): [string, number] {
 // Simple iterator function that mimics maps.All behavior
 export function simpleIterator(m: Map<string, number> | null): ((p0: ((p0: string, p1: number) => boolean) | null) => void) | null {
 	return (_yield: ((p0: string, p1: number) => boolean) | null): void => {
-		for (const [k, v] of m?.entries() ?? []) {
+		for (const [k, v] of $.mapEntries(m)) {
 			{
 				if (!_yield!(k, v)) {
 					break
@@ -40,7 +40,7 @@ export async function main(): Promise<void> {
 			{
 				// Simple assignment that should trigger the error
 				let [x, y] = getValue()
-				let result = k + x + $.runeOrStringToString(v + y)
+				let result = $.concatString($.concatString(k, x), $.runeOrStringToString(v + y))
 				results = $.append(results, result)
 			}
 			return shouldContinue
@@ -53,7 +53,7 @@ export async function main(): Promise<void> {
 		simpleIterator(m)!((k, v) => {
 			{
 				let [x, y] = getValue()
-				let result = k + x + $.runeOrStringToString(v + y) + "_local"
+				let result = $.concatString($.concatString(k, x), $.runeOrStringToString(v + y)) + "_local"
 				results = $.append(results, result)
 			}
 			return shouldContinue
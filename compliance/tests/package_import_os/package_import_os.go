@@ -1,11 +1,14 @@
 package main
 
-import "os"
+import (
+	"os"
+	"strings"
+)
 
 func main() {
-	// Test Getwd - works with mock data
+	// Test Getwd - the directory depends on where the program runs
 	if wd, err := os.Getwd(); err == nil {
-		println("Current working directory:", wd)
+		println("Working directory is absolute:", strings.HasPrefix(wd, "/"))
 	} else {
 		println("Error getting working directory:", err.Error())
 	}
@@ -5,12 +5,14 @@ import * as $ from "@goscript/builtin/index.js";
 
 import * as os from "@goscript/os/index.js"
 
+import * as strings from "@goscript/strings/index.js"
+
 export async function main(): Promise<void> {
-	// Test Getwd - works with mock data
+	// Test Getwd - the directory depends on where the program runs
 	{
 		let [wd, err] = await os.Getwd()
 		if (err == null) {
-			console.log("Current working directory:", wd)
+			console.log("Working directory is absolute:", strings.HasPrefix(wd, "/"))
 		}
 		 else {
 			console.log("Error getting working directory:", err!.Error())
@@ -40,7 +40,7 @@ export async function main(): Promise<void> {
 	console.log("Cause error:", cause!.Error())
 
 	// Test nil handling
-	let nilErr = null
+	let nilErr = errors.WithStack(null)
 	if (nilErr == null) {
 		console.log("WithStack with nil returns nil")
 	}
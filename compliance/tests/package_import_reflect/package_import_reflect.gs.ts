@@ -43,11 +43,11 @@ export class Person {
 
 	// Register this type with the runtime type system
 	static __typeInfo = $.registerStructType(
-	  'Person',
+	  'main@github.com/aperturerobotics/goscript/compliance/tests/package_import_reflect.Person',
 	  new Person(),
 	  [],
 	  Person,
-	  {"Name": { kind: $.TypeKind.Basic, name: "string" }, "Age": { kind: $.TypeKind.Basic, name: "number" }}
+	  [{ name: "Name", type: { kind: $.TypeKind.Basic, name: "string" } }, { name: "Age", type: { kind: $.TypeKind.Basic, name: "int" } }]
 	);
 }
 
@@ -56,7 +56,7 @@ export type Stringer = null | {
 }
 
 $.registerInterfaceType(
-  'Stringer',
+  'main@github.com/aperturerobotics/goscript/compliance/tests/package_import_reflect.Stringer',
   null, // Zero value for interface is null
   [{ name: "String", args: [], returns: [{ type: { kind: $.TypeKind.Basic, name: "string" } }] }]
 );
@@ -64,22 +64,22 @@ $.registerInterfaceType(
 export async function main(): Promise<void> {
 	// Test basic reflect functions
 	let x = 42
-	let v = reflect.ValueOf(x).clone()
-	console.log("Type:", reflect.TypeOf(x)!.String())
+	let v = reflect.ValueOf(x, { kind: $.TypeKind.Basic, name: "int" }).clone()
+	console.log("Type:", reflect.TypeOf(x, { kind: $.TypeKind.Basic, name: "int" })!.String())
 	console.log("Value:", v.Int())
 	console.log("Kind:", reflect.Kind_String(v.Kind()))
 
 	// Test with string
 	let s = "hello"
-	let sv = reflect.ValueOf(s).clone()
-	console.log("String type:", reflect.TypeOf(s)!.String())
+	let sv = reflect.ValueOf(s, { kind: $.TypeKind.Basic, name: "string" }).clone()
+	console.log("String type:", reflect.TypeOf(s, { kind: $.TypeKind.Basic, name: "string" })!.String())
 	console.log("String value:", sv.String())
 	console.log("String kind:", reflect.Kind_String(sv.Kind()))
 
 	// Test with slice
 	let slice = $.arrayToSlice<number>([1, 2, 3])
-	let sliceV = reflect.ValueOf(slice).clone()
-	console.log("Slice type:", reflect.TypeOf(slice)!.String())
+	let sliceV = reflect.ValueOf(slice, { kind: $.TypeKind.Slice, elemType: { kind: $.TypeKind.Basic, name: "int" } }).clone()
+	console.log("Slice type:", reflect.TypeOf(slice, { kind: $.TypeKind.Slice, elemType: { kind: $.TypeKind.Basic, name: "int" } })!.String())
 	console.log("Slice len:", sliceV.Len())
 	console.log("Slice kind:", reflect.Kind_String(sliceV.Kind()))
 
@@ -91,11 +91,11 @@ export async function main(): Promise<void> {
 	console.log("DeepEqual a==c:", await reflect.DeepEqual(a, c))
 
 	// Test Zero value
-	let zeroInt = reflect.Zero(reflect.TypeOf(42)).clone()
+	let zeroInt = reflect.Zero(reflect.TypeOf(42, { kind: $.TypeKind.Basic, name: "int" })).clone()
 	console.log("Zero int:", zeroInt.Int())
 
 	// Test type construction functions
-	let intType = reflect.TypeOf(0)
+	let intType = reflect.TypeOf(0, { kind: $.TypeKind.Basic, name: "int" })
 	let sliceType = await reflect.SliceOf(intType)
 	console.log("SliceOf int:", sliceType!.String())
 	console.log("SliceOf kind:", reflect.Kind_String(sliceType!.Kind()))
@@ -119,10 +119,10 @@ export async function main(): Promise<void> {
 	console.log("Indirect type:", indirectVal.Type()!.String())
 
 	// Test Zero values for different types
-	let zeroString = reflect.Zero(reflect.TypeOf("")).clone()
+	let zeroString = reflect.Zero(reflect.TypeOf("", { kind: $.TypeKind.Basic, name: "string" })).clone()
 	console.log("Zero string:", zeroString.String())
 
-	let zeroBool = reflect.Zero(reflect.TypeOf(true)).clone()
+	let zeroBool = reflect.Zero(reflect.TypeOf(true, { kind: $.TypeKind.Basic, name: "bool" })).clone()
 	console.log("Zero bool:", zeroBool.String()) // Should show the type since it's not a string
 
 	// Test Swapper function
@@ -135,40 +135,40 @@ export async function main(): Promise<void> {
 	// Test Copy function
 	let src = $.arrayToSlice<number>([10, 20, 30])
 	let dst = $.makeSlice<number>(2, undefined, 'number')
-	let srcVal = reflect.ValueOf(src).clone()
-	let dstVal = reflect.ValueOf(dst).clone()
+	let srcVal = reflect.ValueOf(src, { kind: $.TypeKind.Slice, elemType: { kind: $.TypeKind.Basic, name: "int" } }).clone()
+	let dstVal = reflect.ValueOf(dst, { kind: $.TypeKind.Slice, elemType: { kind: $.TypeKind.Basic, name: "int" } }).clone()
 	let copied = reflect.Copy(dstVal, srcVal)
 	console.log("Copied elements:", copied)
 	console.log("Dst after copy:", dst![0], dst![1])
 
 	// Test struct reflection
 	let person = new Person({Age: 30, Name: "Alice"})
-	let personType = reflect.TypeOf(person)
+	let personType = reflect.TypeOf($.markStructValue(person.clone()), "main@github.com/aperturerobotics/goscript/compliance/tests/package_import_reflect.Person")
 	console.log("Struct type:", personType!.String())
 	console.log("Struct kind:", reflect.Kind_String(personType!.Kind()))
 
-	let personVal = reflect.ValueOf(person).clone()
+	let personVal = reflect.ValueOf($.markStructValue(person.clone()), "main@github.com/aperturerobotics/goscript/compliance/tests/package_import_reflect.Person").clone()
 	console.log("Struct value type:", personVal.Type()!.String())
 
 	// Test with different kinds
 	let f: number = 3.14
-	let fVal = reflect.ValueOf(f).clone()
+	let fVal = reflect.ValueOf(f, { kind: $.TypeKind.Basic, name: "float64" }).clone()
 	console.log("Float kind:", reflect.Kind_String(fVal.Kind()))
 
 	let boolVal: boolean = true
-	let bVal = reflect.ValueOf(boolVal).clone()
+	let bVal = reflect.ValueOf(boolVal, { kind: $.TypeKind.Basic, name: "bool" }).clone()
 	console.log("Bool kind:", reflect.Kind_String(bVal.Kind()))
 
 	// Test type equality
-	let intType1 = reflect.TypeOf(1)
-	let intType2 = reflect.TypeOf(2)
+	let intType1 = reflect.TypeOf(1, { kind: $.TypeKind.Basic, name: "int" })
+	let intType2 = reflect.TypeOf(2, { kind: $.TypeKind.Basic, name: "int" })
 	console.log("Same int types:", intType1!.String() == intType2!.String())
 
-	let stringType = reflect.TypeOf("test")
+	let stringType = reflect.TypeOf("test", { kind: $.TypeKind.Basic, name: "string" })
 	console.log("Different types:", intType1!.String() == stringType!.String())
 
 	// Test map type construction
-	let mapType = await reflect.MapOf(reflect.TypeOf(""), reflect.TypeOf(0))
+	let mapType = await reflect.MapOf(reflect.TypeOf("", { kind: $.TypeKind.Basic, name: "string" }), reflect.TypeOf(0, { kind: $.TypeKind.Basic, name: "int" }))
 	console.log("MapOf string->int:", mapType!.String())
 	console.log("MapOf kind:", reflect.Kind_String(mapType!.Kind()))
 
@@ -206,38 +206,38 @@ export async function main(): Promise<void> {
 
 	// Test more complex types
 	let complexSlice = $.arrayToSlice<$.Slice<number>>([[ 1, 2 ], [ 3, 4 ]], 2)
-	let complexVal = reflect.ValueOf(complexSlice).clone()
+	let complexVal = reflect.ValueOf(complexSlice, { kind: $.TypeKind.Slice, elemType: { kind: $.TypeKind.Slice, elemType: { kind: $.TypeKind.Basic, name: "int" } } }).clone()
 	console.log("Complex slice type:", complexVal.Type()!.String())
 	console.log("Complex slice kind:", reflect.Kind_String(complexVal.Kind()))
 	console.log("Complex slice len:", complexVal.Len())
 
 	// Test type methods
 	console.log("Type size methods:")
-	console.log("Int size:", reflect.TypeOf(0)!.Size())
-	console.log("String size:", reflect.TypeOf("")!.Size())
-	console.log("Slice size:", reflect.TypeOf($.arrayToSlice<number>([]))!.Size())
+	console.log("Int size:", reflect.TypeOf(0, { kind: $.TypeKind.Basic, name: "int" })!.Size())
+	console.log("String size:", reflect.TypeOf("", { kind: $.TypeKind.Basic, name: "string" })!.Size())
+	console.log("Slice size:", reflect.TypeOf($.arrayToSlice<number>([]), { kind: $.TypeKind.Slice, elemType: { kind: $.TypeKind.Basic, name: "int" } })!.Size())
 
 	// Test enhanced API surface - functions to implement
 	console.log("Enhanced API tests:")
 
 	// Test MakeSlice
-	let sliceTypeInt = await reflect.SliceOf(reflect.TypeOf(0))
+	let sliceTypeInt = await reflect.SliceOf(reflect.TypeOf(0, { kind: $.TypeKind.Basic, name: "int" }))
 	let newSlice = reflect.MakeSlice(sliceTypeInt, 3, 5).clone()
 	console.log("MakeSlice len:", newSlice.Len())
 	console.log("MakeSlice type:", newSlice.Type()!.String())
 
 	// Test MakeMap
-	let mapTypeStr = await reflect.MapOf(reflect.TypeOf(""), reflect.TypeOf(0))
+	let mapTypeStr = await reflect.MapOf(reflect.TypeOf("", { kind: $.TypeKind.Basic, name: "string" }), reflect.TypeOf(0, { kind: $.TypeKind.Basic, name: "int" }))
 	let newMap = reflect.MakeMap(mapTypeStr).clone()
 	console.log("MakeMap type:", newMap.Type()!.String())
 
 	// Test Append
-	let originalSlice = reflect.ValueOf($.arrayToSlice<number>([1, 2])).clone()
-	let appendedSlice = reflect.Append(originalSlice, reflect.ValueOf(3)).clone()
+	let originalSlice = reflect.ValueOf($.arrayToSlice<number>([1, 2]), { kind: $.TypeKind.Slice, elemType: { kind: $.TypeKind.Basic, name: "int" } }).clone()
+	let appendedSlice = reflect.Append(originalSlice, reflect.ValueOf(3, { kind: $.TypeKind.Basic, name: "int" })).clone()
 	console.log("Append result len:", appendedSlice.Len())
 
 	// Test channel types
-	let chanType = await reflect.ChanOf(reflect.BothDir, reflect.TypeOf(0))
+	let chanType = await reflect.ChanOf(reflect.BothDir, reflect.TypeOf(0, { kind: $.TypeKind.Basic, name: "int" }))
 	console.log("ChanOf type:", chanType!.String())
 	console.log("ChanOf kind:", reflect.Kind_String(chanType!.Kind()))
 
@@ -246,14 +246,14 @@ export async function main(): Promise<void> {
 	console.log("MakeChan type:", newChan.Type()!.String())
 
 	// Test different channel directions
-	let sendOnlyChan = await reflect.ChanOf(reflect.SendDir, reflect.TypeOf(""))
+	let sendOnlyChan = await reflect.ChanOf(reflect.SendDir, reflect.TypeOf("", { kind: $.TypeKind.Basic, name: "string" }))
 	console.log("SendOnly chan type:", sendOnlyChan!.String())
 
-	let recvOnlyChan = await reflect.ChanOf(reflect.RecvDir, reflect.TypeOf(true))
+	let recvOnlyChan = await reflect.ChanOf(reflect.RecvDir, reflect.TypeOf(true, { kind: $.TypeKind.Basic, name: "bool" }))
 	console.log("RecvOnly chan type:", recvOnlyChan!.String())
 
 	// Test channels with different element types
-	let stringChanType = await reflect.ChanOf(reflect.BothDir, reflect.TypeOf(""))
+	let stringChanType = await reflect.ChanOf(reflect.BothDir, reflect.TypeOf("", { kind: $.TypeKind.Basic, name: "string" }))
 	let stringChan = reflect.MakeChan(stringChanType, 5).clone()
 	console.log("String chan type:", stringChan.Type()!.String())
 	console.log("String chan elem type:", stringChan.Type()!.Elem()!.String())
@@ -270,11 +270,11 @@ export async function main(): Promise<void> {
 	console.log("Chan size:", chanType!.Size())
 
 	// Test Select functionality
-	let intChan = reflect.MakeChan(await reflect.ChanOf(reflect.BothDir, reflect.TypeOf(0)), 1).clone()
-	let strChan = reflect.MakeChan(await reflect.ChanOf(reflect.BothDir, reflect.TypeOf("")), 1).clone()
+	let intChan = reflect.MakeChan(await reflect.ChanOf(reflect.BothDir, reflect.TypeOf(0, { kind: $.TypeKind.Basic, name: "int" })), 1).clone()
+	let strChan = reflect.MakeChan(await reflect.ChanOf(reflect.BothDir, reflect.TypeOf("", { kind: $.TypeKind.Basic, name: "string" })), 1).clone()
 
 	// Send values to only the string channel to make select deterministic
-	strChan.Send(reflect.ValueOf("hello"))
+	strChan.Send(reflect.ValueOf("hello", { kind: $.TypeKind.Basic, name: "string" }))
 
 	let cases = $.arrayToSlice<reflect.SelectCase>([{Chan: intChan, Dir: reflect.SelectRecv}, {Chan: strChan, Dir: reflect.SelectRecv}, {Dir: reflect.SelectDefault}])
 	let [chosen, recv, recvOK] = reflect.Select(cases)
@@ -0,0 +1,89 @@
+package main
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+)
+
+var wordRE = regexp.MustCompile(`\p{Greek}+`)
+
+func main() {
+	// Offsets are byte offsets into the UTF-8 input.
+	re := regexp.MustCompile(`b+`)
+	fmt.Println(re.FindStringIndex("héllo wörld bbb"))
+	fmt.Println(wordRE.FindAllStringIndex("αβγ abc δε", -1))
+	fmt.Println(wordRE.FindAllString("αβγ abc δε", -1))
+
+	// Named groups and submatches.
+	kv := regexp.MustCompile(`(?P<key>\w+)=(?<value>\w*)`)
+	fmt.Println(kv.NumSubexp(), kv.SubexpNames(), kv.SubexpIndex("value"))
+	for _, m := range kv.FindAllStringSubmatch("a=1 b= c=xyz", -1) {
+		fmt.Printf("%q\n", m)
+	}
+	fmt.Println(kv.FindStringSubmatchIndex("  k=v"))
+	fmt.Println(kv.FindStringSubmatch("none") == nil)
+
+	// Replacement with templates, literals and functions.
+	email := regexp.MustCompile(`(\w+)@(?P<host>\w+)`)
+	fmt.Println(email.ReplaceAllString("me@home, you@work", "$host:${1}"))
+	fmt.Println(email.ReplaceAllLiteralString("me@home", "$1"))
+	fmt.Println(email.ReplaceAllStringFunc("me@home", strings.ToUpper))
+	tmpl := []byte{}
+	for _, sm := range email.FindAllStringSubmatchIndex("a@b c@d", -1) {
+		tmpl = email.ExpandString(tmpl, "[$2<-$1]", "a@b c@d", sm)
+	}
+	fmt.Println(string(tmpl))
+
+	// Leftmost-first versus leftmost-longest.
+	alt := regexp.MustCompile(`a|ab`)
+	fmt.Println(alt.FindString("ab"))
+	alt.Longest()
+	fmt.Println(alt.FindString("ab"))
+	posix := regexp.MustCompilePOSIX(`a+|a+b`)
+	fmt.Println(posix.FindString("aaab"))
+
+	// Empty matches and Split.
+	star := regexp.MustCompile(`a*`)
+	fmt.Printf("%q\n", star.FindAllString("baaab", -1))
+	fmt.Println(star.ReplaceAllString("baaab", "-"))
+	fmt.Printf("%q\n", star.Split("abaabaccadaaae", 5))
+	fmt.Printf("%q\n", regexp.MustCompile(`\s*,\s*`).Split("a , b,c", -1))
+
+	// Case folding and Perl classes.
+	fold := regexp.MustCompile(`(?i)straße|k`)
+	fmt.Println(fold.MatchString("STRAßE"), fold.MatchString("K"))
+	fmt.Println(regexp.MustCompile(`\bé`).MatchString("é"))
+	fmt.Println(regexp.MustCompile(`(?m)^\w+$`).FindAllString("ab\ncd", -1))
+	fmt.Println(regexp.MustCompile(`[[:alpha:]]+`).FindString("12abc3"))
+
+	// Byte slices.
+	digits := regexp.MustCompile(`[0-9]+`)
+	b := []byte("ab 12 cd 345")
+	fmt.Println(string(digits.Find(b)), digits.FindIndex(b))
+	fmt.Println(string(digits.ReplaceAll(b, []byte("<$0>"))))
+	fmt.Println(digits.Match([]byte("none")))
+
+	// Readers.
+	fmt.Println(digits.FindReaderIndex(strings.NewReader("x 42")))
+
+	// Helpers.
+	fmt.Println(regexp.QuoteMeta("1.5-2.0?"))
+	matched, err := regexp.MatchString(`^\d{3}-\d{4}$`, "555-1234")
+	fmt.Println(matched, err)
+	prefix, complete := regexp.MustCompile(`abc+`).LiteralPrefix()
+	fmt.Println(prefix, complete)
+	fmt.Println(email.String())
+
+	// Errors.
+	for _, expr := range []string{`a(b`, `x**`, `[z-a]`, `\8`, `(?=x)`} {
+		_, err := regexp.Compile(expr)
+		fmt.Println(err)
+	}
+	func() {
+		defer func() {
+			fmt.Println("recovered:", recover())
+		}()
+		regexp.MustCompile(`(`)
+	}()
+}
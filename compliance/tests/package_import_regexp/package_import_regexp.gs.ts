@@ -0,0 +1,110 @@
+// Generated file based on package_import_regexp.go
+// Updated when compliance tests are re-run, DO NOT EDIT!
+
+import * as $ from "@goscript/builtin/index.js";
+
+import * as fmt from "@goscript/fmt/index.js"
+
+import * as regexp from "@goscript/regexp/index.js"
+
+import * as strings from "@goscript/strings/index.js"
+
+let wordRE: regexp.Regexp | null = regexp.MustCompile("\\p{Greek}+")
+
+export async function main(): Promise<void> {
+	using __defer = new $.DisposableStack();
+	// Offsets are byte offsets into the UTF-8 input.
+	let re = regexp.MustCompile(`b+`)
+	fmt.Println(re!.FindStringIndex("héllo wörld bbb"))
+	fmt.Println(wordRE!.FindAllStringIndex("αβγ abc δε", -1))
+	fmt.Println(wordRE!.FindAllString("αβγ abc δε", -1))
+
+	// Named groups and submatches.
+	let kv = regexp.MustCompile("(?P<key>\\w+)=(?<value>\\w*)")
+	fmt.Println(kv!.NumSubexp(), kv!.SubexpNames(), kv!.SubexpIndex("value"))
+	for (let _i = 0; _i < $.len(kv!.FindAllStringSubmatch("a=1 b= c=xyz", -1)); _i++) {
+		const m = kv!.FindAllStringSubmatch("a=1 b= c=xyz", -1)![_i]
+		{
+			fmt.Printf("%q\n", m)
+		}
+	}
+	fmt.Println(kv!.FindStringSubmatchIndex("  k=v"))
+	fmt.Println(kv!.FindStringSubmatch("none") == null)
+
+	// Replacement with templates, literals and functions.
+	let email = regexp.MustCompile("(\\w+)@(?P<host>\\w+)")
+	fmt.Println(email!.ReplaceAllString("me@home, you@work", "$host:${1}"))
+	fmt.Println(email!.ReplaceAllLiteralString("me@home", "$1"))
+	fmt.Println(email!.ReplaceAllStringFunc("me@home", strings.ToUpper))
+	let tmpl = new Uint8Array([])
+	for (let _i = 0; _i < $.len(email!.FindAllStringSubmatchIndex("a@b c@d", -1)); _i++) {
+		const sm = email!.FindAllStringSubmatchIndex("a@b c@d", -1)![_i]
+		{
+			tmpl = email!.ExpandString(tmpl, "[$2<-$1]", "a@b c@d", sm)
+		}
+	}
+	fmt.Println($.bytesToString(tmpl))
+
+	// Leftmost-first versus leftmost-longest.
+	let alt = regexp.MustCompile(`a|ab`)
+	fmt.Println(alt!.FindString("ab"))
+	alt!.Longest()
+	fmt.Println(alt!.FindString("ab"))
+	let posix = regexp.MustCompilePOSIX(`a+|a+b`)
+	fmt.Println(posix!.FindString("aaab"))
+
+	// Empty matches and Split.
+	let star = regexp.MustCompile(`a*`)
+	fmt.Printf("%q\n", star!.FindAllString("baaab", -1))
+	fmt.Println(star!.ReplaceAllString("baaab", "-"))
+	fmt.Printf("%q\n", star!.Split("abaabaccadaaae", 5))
+	fmt.Printf("%q\n", regexp.MustCompile("\\s*,\\s*")!.Split("a , b,c", -1))
+
+	// Case folding and Perl classes.
+	let fold = regexp.MustCompile(`(?i)straße|k`)
+	fmt.Println(fold!.MatchString("STRAßE"), fold!.MatchString("K"))
+	fmt.Println(regexp.MustCompile("\\bé")!.MatchString("é"))
+	fmt.Println(regexp.MustCompile("(?m)^\\w+$")!.FindAllString("ab\ncd", -1))
+	fmt.Println(regexp.MustCompile(`[[:alpha:]]+`)!.FindString("12abc3"))
+
+	// Byte slices.
+	let digits = regexp.MustCompile(`[0-9]+`)
+	let b = $.stringToBytes("ab 12 cd 345")
+	fmt.Println($.bytesToString(digits!.Find(b)), digits!.FindIndex(b))
+	fmt.Println($.bytesToString(digits!.ReplaceAll(b, $.stringToBytes("<$0>"))))
+	fmt.Println(digits!.Match($.stringToBytes("none")))
+
+	// Readers.
+	fmt.Println(digits!.FindReaderIndex(strings.NewReader("x 42")))
+
+	// Helpers.
+	fmt.Println(regexp.QuoteMeta("1.5-2.0?"))
+	let [matched, err] = regexp.MatchString("^\\d{3}-\\d{4}$", "555-1234")
+	fmt.Println(matched, err)
+	let [prefix, complete] = regexp.MustCompile(`abc+`)!.LiteralPrefix()
+	fmt.Println(prefix, complete)
+	fmt.Println(email!.String())
+
+	// Errors.
+	for (let _i = 0; _i < $.len($.arrayToSlice<string>([`a(b`, `x**`, `[z-a]`, "\\8", `(?=x)`])); _i++) {
+		const expr = $.arrayToSlice<string>([`a(b`, `x**`, `[z-a]`, "\\8", `(?=x)`])![_i]
+		{
+			let [, err] = regexp.Compile(expr)
+			fmt.Println(err)
+		}
+	}
+	;((): void => {
+		const __defer = new $.DisposableStack();
+		try {
+			__defer.defer((__frame) => {
+				fmt.Println("recovered:", $.recover(__frame))
+			});
+			regexp.MustCompile(`(`)
+		} catch (__e) {
+			__defer.panic(__e)
+		} finally {
+			__defer.dispose()
+		}
+	})()
+}
+
@@ -52,8 +52,8 @@ export async function main(): Promise<void> {
 
 	// Test atomic.Pointer
 	let ptr: atomic.Pointer<string> = new atomic.Pointer<string>()
-	let str1 = "hello"
-	let str2 = "world"
+	let str1 = $.varRef("hello")
+	let str2 = $.varRef("world")
 
 	ptr.Store(str1)
 	let loaded = ptr.Load()
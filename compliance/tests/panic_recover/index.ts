@@ -0,0 +1 @@
+export { MyError, Worker } from "./panic_recover.gs.js"
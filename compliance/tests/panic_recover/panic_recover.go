@@ -0,0 +1,195 @@
+package main
+
+import "errors"
+
+type MyError struct {
+	Code int
+}
+
+func (e MyError) Error() string {
+	return "my error"
+}
+
+// safeDivide recovers from a runtime panic and reports it via named results.
+func safeDivide(a, b int) (result int, err error) {
+	defer func() {
+		if r := recover(); r != nil {
+			err = errors.New("recovered from division panic")
+		}
+	}()
+	if b == 0 {
+		panic("division by zero")
+	}
+	return a / b, nil
+}
+
+// unnamedRecover returns the zero value after recovering.
+func unnamedRecover() int {
+	defer func() {
+		recover()
+	}()
+	panic("boom")
+}
+
+// handler recovers via a named deferred function.
+func handler() {
+	if r := recover(); r != nil {
+		println("handler recovered:", r.(string))
+	}
+}
+
+func namedHandler() {
+	defer handler()
+	panic("from namedHandler")
+}
+
+// deferModifiesResult changes a named result without panicking.
+func deferModifiesResult() (n int) {
+	defer func() {
+		n *= 2
+	}()
+	return 21
+}
+
+// recoverErrorValue recovers an error value and inspects it.
+func recoverErrorValue() (code int) {
+	defer func() {
+		r := recover()
+		if e, ok := r.(MyError); ok {
+			code = e.Code
+		}
+	}()
+	panic(MyError{Code: 42})
+}
+
+// rePanic recovers and panics again with a new value.
+func rePanic() {
+	defer func() {
+		r := recover()
+		panic("re-panic: " + r.(string))
+	}()
+	panic("first")
+}
+
+// allDefersRun checks that every deferred call runs while panicking.
+func allDefersRun() {
+	defer func() {
+		println("outer recovered:", recover().(string))
+	}()
+	defer println("second defer runs")
+	defer println("first defer runs")
+	panic("panic with several defers")
+}
+
+// recoverNotPanicking returns nil when there is no panic.
+func recoverNotPanicking() {
+	defer func() {
+		println("recover without panic is nil:", recover() == nil)
+	}()
+	println("not panicking")
+}
+
+type Worker struct {
+	name string
+}
+
+// Run recovers inside a method.
+func (w *Worker) Run() (ok bool) {
+	defer func() {
+		if r := recover(); r != nil {
+			println(w.name, "recovered:", r.(string))
+			ok = false
+		}
+	}()
+	panic("worker failed")
+}
+
+// handle recovers via a deferred method call.
+func (w *Worker) handle(tag string) {
+	if r := recover(); r != nil {
+		println(w.name, tag, "recovered:", r.(string))
+	}
+}
+
+func methodHandler() {
+	w := &Worker{name: "w2"}
+	defer w.handle("method")
+	panic("from methodHandler")
+}
+
+// tryRecover is not called directly by a deferred call, so it cannot recover.
+func tryRecover() any {
+	return recover()
+}
+
+func indirectRecover() (r any) {
+	defer func() {
+		r = recover()
+	}()
+	defer func() {
+		println("indirect recover is nil:", tryRecover() == nil)
+	}()
+	panic("indirect")
+}
+
+// nestedDeferRecover recovers in a deferred literal that defers calls itself.
+func nestedDeferRecover() (msg string) {
+	defer func() {
+		defer func() {
+			println("inner recover is nil:", recover() == nil)
+		}()
+		msg = recover().(string)
+	}()
+	panic("nested")
+}
+
+// innerDeferRecover checks that a call deferred by a deferred literal does
+// not recover the panic of the outer function.
+func innerDeferRecover() (msg string) {
+	defer func() {
+		msg = recover().(string)
+	}()
+	defer func() {
+		defer func() {
+			println("inner only recover is nil:", recover() == nil)
+		}()
+	}()
+	panic("inner only")
+}
+
+func main() {
+	r, err := safeDivide(10, 2)
+	println("safeDivide(10, 2):", r, err == nil)
+	r, err = safeDivide(1, 0)
+	println("safeDivide(1, 0):", r, err.Error())
+
+	println("unnamedRecover:", unnamedRecover())
+
+	namedHandler()
+
+	println("deferModifiesResult:", deferModifiesResult())
+
+	println("recoverErrorValue:", recoverErrorValue())
+
+	func() {
+		defer func() {
+			println("caught:", recover().(string))
+		}()
+		rePanic()
+	}()
+
+	allDefersRun()
+
+	recoverNotPanicking()
+
+	w := &Worker{name: "w1"}
+	println("worker ok:", w.Run())
+
+	methodHandler()
+
+	println("indirect:", indirectRecover().(string))
+	println("nested:", nestedDeferRecover())
+	println("inner only:", innerDeferRecover())
+
+	println("done")
+}
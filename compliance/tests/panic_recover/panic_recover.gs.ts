@@ -0,0 +1,435 @@
+// Generated file based on panic_recover.go
+// Updated when compliance tests are re-run, DO NOT EDIT!
+
+import * as $ from "@goscript/builtin/index.js";
+
+import * as errors from "@goscript/errors/index.js"
+
+export class MyError {
+	public get Code(): number {
+		return this._fields.Code.value
+	}
+	public set Code(value: number) {
+		this._fields.Code.value = value
+	}
+
+	public _fields: {
+		Code: $.VarRef<number>;
+	}
+
+	constructor(init?: Partial<{Code?: number}>) {
+		this._fields = {
+			Code: $.varRef(init?.Code ?? 0)
+		}
+	}
+
+	public clone(): MyError {
+		const cloned = new MyError()
+		cloned._fields = {
+			Code: $.varRef(this._fields.Code.value)
+		}
+		return cloned
+	}
+
+	public Error(): string {
+		return "my error"
+	}
+
+	// Register this type with the runtime type system
+	static __typeInfo = $.registerStructType(
+	  'main@github.com/aperturerobotics/goscript/compliance/tests/panic_recover.MyError',
+	  new MyError(),
+	  [{ name: "Error", args: [], returns: [{ type: { kind: $.TypeKind.Basic, name: "string" } }] }],
+	  MyError,
+	  [{ name: "Code", type: { kind: $.TypeKind.Basic, name: "int" } }]
+	);
+}
+
+// safeDivide recovers from a runtime panic and reports it via named results.
+export function safeDivide(a: number, b: number): [number, $.GoError] {
+	let result: number = 0
+	let err: $.GoError = null
+	{
+		const __defer = new $.DisposableStack();
+		try {
+			__defer.defer((__frame) => {
+				{
+					let r = $.recover(__frame)
+					if (r != null) {
+						err = errors.New("recovered from division panic")
+					}
+				}
+			});
+			if (b == 0) {
+				$.panic("division by zero")
+			}
+			;[result, err] = [$.intDiv(a, b), null]
+			return [result, err]
+		} catch (__e) {
+			__defer.panic(__e)
+		} finally {
+			__defer.dispose()
+			return [result, err]
+		}
+	}
+}
+
+// unnamedRecover returns the zero value after recovering.
+export function unnamedRecover(): number {
+	const __defer = new $.DisposableStack();
+	try {
+		__defer.defer((__frame) => {
+			$.recover(__frame)
+		});
+		$.panic("boom")
+	} catch (__e) {
+		__defer.panic(__e)
+	} finally {
+		__defer.dispose()
+	}
+	return 0
+}
+
+// handler recovers via a named deferred function.
+export function handler(__frame?: $.DeferFrame): void {
+	{
+		let r = $.recover(__frame)
+		if (r != null) {
+			console.log("handler recovered:", $.mustTypeAssert<string>(r, {kind: $.TypeKind.Basic, name: 'string'}))
+		}
+	}
+}
+
+export function namedHandler(): void {
+	const __defer = new $.DisposableStack();
+	try {
+		__defer.defer((__frame) => {
+			handler(__frame)
+		});
+		$.panic("from namedHandler")
+	} catch (__e) {
+		__defer.panic(__e)
+	} finally {
+		__defer.dispose()
+	}
+}
+
+// deferModifiesResult changes a named result without panicking.
+export function deferModifiesResult(): number {
+	let n: number = 0
+	{
+		const __defer = new $.DisposableStack();
+		try {
+			__defer.defer(() => {
+				n *= 2
+			});
+			n = 21
+			return n
+		} catch (__e) {
+			__defer.panic(__e)
+		} finally {
+			__defer.dispose()
+			return n
+		}
+	}
+}
+
+// recoverErrorValue recovers an error value and inspects it.
+export function recoverErrorValue(): number {
+	let code: number = 0
+	{
+		const __defer = new $.DisposableStack();
+		try {
+			__defer.defer((__frame) => {
+				let r = $.recover(__frame)
+				{
+					let { value: e, ok: ok } = $.typeAssert<MyError>(r, 'main@github.com/aperturerobotics/goscript/compliance/tests/panic_recover.MyError')
+					if (ok) {
+						code = e.Code
+					}
+				}
+			});
+			$.panic($.markStructValue(new MyError({Code: 42})))
+		} catch (__e) {
+			__defer.panic(__e)
+		} finally {
+			__defer.dispose()
+			return code
+		}
+	}
+}
+
+// rePanic recovers and panics again with a new value.
+export function rePanic(): void {
+	const __defer = new $.DisposableStack();
+	try {
+		__defer.defer((__frame) => {
+			let r = $.recover(__frame)
+			$.panic("re-panic: " + $.mustTypeAssert<string>(r, {kind: $.TypeKind.Basic, name: 'string'}))
+		});
+		$.panic("first")
+	} catch (__e) {
+		__defer.panic(__e)
+	} finally {
+		__defer.dispose()
+	}
+}
+
+// allDefersRun checks that every deferred call runs while panicking.
+export function allDefersRun(): void {
+	const __defer = new $.DisposableStack();
+	try {
+		__defer.defer((__frame) => {
+			console.log("outer recovered:", $.mustTypeAssert<string>($.recover(__frame), {kind: $.TypeKind.Basic, name: 'string'}))
+		});
+		__defer.defer(() => {
+			console.log("second defer runs")
+		});
+		__defer.defer(() => {
+			console.log("first defer runs")
+		});
+		$.panic("panic with several defers")
+	} catch (__e) {
+		__defer.panic(__e)
+	} finally {
+		__defer.dispose()
+	}
+}
+
+// recoverNotPanicking returns nil when there is no panic.
+export function recoverNotPanicking(): void {
+	const __defer = new $.DisposableStack();
+	try {
+		__defer.defer((__frame) => {
+			console.log("recover without panic is nil:", $.recover(__frame) == null)
+		});
+		console.log("not panicking")
+	} catch (__e) {
+		__defer.panic(__e)
+	} finally {
+		__defer.dispose()
+	}
+}
+
+export class Worker {
+	public get name(): string {
+		return this._fields.name.value
+	}
+	public set name(value: string) {
+		this._fields.name.value = value
+	}
+
+	public _fields: {
+		name: $.VarRef<string>;
+	}
+
+	constructor(init?: Partial<{name?: string}>) {
+		this._fields = {
+			name: $.varRef(init?.name ?? "")
+		}
+	}
+
+	public clone(): Worker {
+		const cloned = new Worker()
+		cloned._fields = {
+			name: $.varRef(this._fields.name.value)
+		}
+		return cloned
+	}
+
+	// Run recovers inside a method.
+	public Run(): boolean {
+		const w = this
+		let ok: boolean = false
+		const __defer = new $.DisposableStack();
+		try {
+			__defer.defer((__frame) => {
+				{
+					let r = $.recover(__frame)
+					if (r != null) {
+						console.log(w.name, "recovered:", $.mustTypeAssert<string>(r, {kind: $.TypeKind.Basic, name: 'string'}))
+						ok = false
+					}
+				}
+			});
+			$.panic("worker failed")
+		} catch (__e) {
+			__defer.panic(__e)
+		} finally {
+			__defer.dispose()
+			return ok
+		}
+	}
+
+	// handle recovers via a deferred method call.
+	public handle(tag: string, __frame?: $.DeferFrame): void {
+		const w = this
+		{
+			let r = $.recover(__frame)
+			if (r != null) {
+				console.log(w.name, tag, "recovered:", $.mustTypeAssert<string>(r, {kind: $.TypeKind.Basic, name: 'string'}))
+			}
+		}
+	}
+
+	// Register this type with the runtime type system
+	static __typeInfo = $.registerStructType(
+	  'main@github.com/aperturerobotics/goscript/compliance/tests/panic_recover.Worker',
+	  new Worker(),
+	  [{ name: "Run", args: [], returns: [{ type: { kind: $.TypeKind.Basic, name: "boolean" } }] }, { name: "handle", args: [{ name: "tag", type: { kind: $.TypeKind.Basic, name: "string" } }], returns: [] }],
+	  Worker,
+	  [{ name: "name", type: { kind: $.TypeKind.Basic, name: "string" } }]
+	);
+}
+
+export function methodHandler(): void {
+	const __defer = new $.DisposableStack();
+	try {
+		let w = new Worker({name: "w2"})
+		__defer.defer((__frame) => {
+			w.handle("method", __frame)
+		});
+		$.panic("from methodHandler")
+	} catch (__e) {
+		__defer.panic(__e)
+	} finally {
+		__defer.dispose()
+	}
+}
+
+// tryRecover is not called directly by a deferred call, so it cannot recover.
+export function tryRecover(__frame?: $.DeferFrame): null | any {
+	return $.recover(__frame)
+}
+
+export function indirectRecover(): null | any {
+	let r: null | any = null
+	{
+		const __defer = new $.DisposableStack();
+		try {
+			__defer.defer((__frame) => {
+				r = $.recover(__frame)
+			});
+			__defer.defer(() => {
+				console.log("indirect recover is nil:", tryRecover() == null)
+			});
+			$.panic("indirect")
+		} catch (__e) {
+			__defer.panic(__e)
+		} finally {
+			__defer.dispose()
+			return r
+		}
+	}
+}
+
+// nestedDeferRecover recovers in a deferred literal that defers calls itself.
+export function nestedDeferRecover(): string {
+	let msg: string = ""
+	{
+		const __defer = new $.DisposableStack();
+		try {
+			__defer.defer((__frame) => {
+				((): void => {
+					const __defer = new $.DisposableStack();
+					try {
+						__defer.defer((__frame) => {
+							console.log("inner recover is nil:", $.recover(__frame) == null)
+						});
+						msg = $.mustTypeAssert<string>($.recover(__frame), {kind: $.TypeKind.Basic, name: 'string'})
+					} catch (__e) {
+						__defer.panic(__e)
+					} finally {
+						__defer.dispose()
+					}
+				})()
+			});
+			$.panic("nested")
+		} catch (__e) {
+			__defer.panic(__e)
+		} finally {
+			__defer.dispose()
+			return msg
+		}
+	}
+}
+
+// innerDeferRecover checks that a call deferred by a deferred literal does
+// not recover the panic of the outer function.
+export function innerDeferRecover(): string {
+	let msg: string = ""
+	{
+		const __defer = new $.DisposableStack();
+		try {
+			__defer.defer((__frame) => {
+				msg = $.mustTypeAssert<string>($.recover(__frame), {kind: $.TypeKind.Basic, name: 'string'})
+			});
+			__defer.defer(() => {
+				((): void => {
+					const __defer = new $.DisposableStack();
+					try {
+						__defer.defer((__frame) => {
+							console.log("inner only recover is nil:", $.recover(__frame) == null)
+						});
+					} catch (__e) {
+						__defer.panic(__e)
+					} finally {
+						__defer.dispose()
+					}
+				})()
+			});
+			$.panic("inner only")
+		} catch (__e) {
+			__defer.panic(__e)
+		} finally {
+			__defer.dispose()
+			return msg
+		}
+	}
+}
+
+export async function main(): Promise<void> {
+	using __defer = new $.DisposableStack();
+	let [r, err] = safeDivide(10, 2)
+	console.log("safeDivide(10, 2):", r, err == null)
+	;[r, err] = safeDivide(1, 0)
+	console.log("safeDivide(1, 0):", r, err!.Error())
+
+	console.log("unnamedRecover:", unnamedRecover())
+
+	namedHandler()
+
+	console.log("deferModifiesResult:", deferModifiesResult())
+
+	console.log("recoverErrorValue:", recoverErrorValue())
+
+	;((): void => {
+		const __defer = new $.DisposableStack();
+		try {
+			__defer.defer((__frame) => {
+				console.log("caught:", $.mustTypeAssert<string>($.recover(__frame), {kind: $.TypeKind.Basic, name: 'string'}))
+			});
+			rePanic()
+		} catch (__e) {
+			__defer.panic(__e)
+		} finally {
+			__defer.dispose()
+		}
+	})()
+
+	allDefersRun()
+
+	recoverNotPanicking()
+
+	let w = new Worker({name: "w1"})
+	console.log("worker ok:", w.Run())
+
+	methodHandler()
+
+	console.log("indirect:", $.mustTypeAssert<string>(indirectRecover(), {kind: $.TypeKind.Basic, name: 'string'}))
+	console.log("nested:", nestedDeferRecover())
+	console.log("inner only:", innerDeferRecover())
+
+	console.log("done")
+}
+
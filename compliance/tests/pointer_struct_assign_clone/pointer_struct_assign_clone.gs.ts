@@ -31,11 +31,11 @@ export class MyStruct {
 
 	// Register this type with the runtime type system
 	static __typeInfo = $.registerStructType(
-	  'MyStruct',
+	  'main@github.com/aperturerobotics/goscript/compliance/tests/pointer_struct_assign_clone.MyStruct',
 	  new MyStruct(),
 	  [],
 	  MyStruct,
-	  {"Value": { kind: $.TypeKind.Basic, name: "number" }}
+	  [{ name: "Value", type: { kind: $.TypeKind.Basic, name: "int" } }]
 	);
 }
 
@@ -31,11 +31,11 @@ export class MyStruct {
 
 	// Register this type with the runtime type system
 	static __typeInfo = $.registerStructType(
-	  'MyStruct',
+	  'main@github.com/aperturerobotics/goscript/compliance/tests/pointers.MyStruct',
 	  new MyStruct(),
 	  [],
 	  MyStruct,
-	  {"Val": { kind: $.TypeKind.Basic, name: "number" }}
+	  [{ name: "Val", type: { kind: $.TypeKind.Basic, name: "int" } }]
 	);
 }
 
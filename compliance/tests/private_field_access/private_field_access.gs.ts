@@ -41,11 +41,11 @@ export class MyStruct {
 
 	// Register this type with the runtime type system
 	static __typeInfo = $.registerStructType(
-	  'MyStruct',
+	  'main@github.com/aperturerobotics/goscript/compliance/tests/private_field_access.MyStruct',
 	  new MyStruct(),
 	  [],
 	  MyStruct,
-	  {"publicField": { kind: $.TypeKind.Basic, name: "string" }, "privateField": { kind: $.TypeKind.Basic, name: "number" }}
+	  [{ name: "publicField", type: { kind: $.TypeKind.Basic, name: "string" } }, { name: "privateField", type: { kind: $.TypeKind.Basic, name: "int" } }]
 	);
 }
 
@@ -71,11 +71,11 @@ export class storage {
 
 	// Register this type with the runtime type system
 	static __typeInfo = $.registerStructType(
-	  'storage',
+	  'main@github.com/aperturerobotics/goscript/compliance/tests/receiver_binding.storage',
 	  new storage(),
 	  [{ name: "Len", args: [], returns: [{ type: { kind: $.TypeKind.Basic, name: "number" } }] }, { name: "Truncate", args: [], returns: [] }, { name: "Name", args: [], returns: [{ type: { kind: $.TypeKind.Basic, name: "string" } }] }, { name: "SetName", args: [{ name: "name", type: { kind: $.TypeKind.Basic, name: "string" } }], returns: [] }, { name: "IsEmpty", args: [], returns: [{ type: { kind: $.TypeKind.Basic, name: "boolean" } }] }],
 	  storage,
-	  {"bytes": { kind: $.TypeKind.Slice, elemType: { kind: $.TypeKind.Basic, name: "number" } }, "name": { kind: $.TypeKind.Basic, name: "string" }}
+	  [{ name: "bytes", type: { kind: $.TypeKind.Slice, elemType: { kind: $.TypeKind.Basic, name: "byte" } } }, { name: "name", type: { kind: $.TypeKind.Basic, name: "string" } }]
 	);
 }
 
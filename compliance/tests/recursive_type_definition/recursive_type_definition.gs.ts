@@ -8,9 +8,9 @@ export type A = null | {
 }
 
 $.registerInterfaceType(
-  'A',
+  'main@github.com/aperturerobotics/goscript/compliance/tests/recursive_type_definition.A',
   null, // Zero value for interface is null
-  [{ name: "MethodA", args: [{ name: "a", type: "A" }], returns: [] }]
+  [{ name: "MethodA", args: [{ name: "a", type: "main@github.com/aperturerobotics/goscript/compliance/tests/recursive_type_definition.A" }], returns: [] }]
 );
 
 export class B {
@@ -33,11 +33,11 @@ export class B {
 
 	// Register this type with the runtime type system
 	static __typeInfo = $.registerStructType(
-	  'B',
+	  'main@github.com/aperturerobotics/goscript/compliance/tests/recursive_type_definition.B',
 	  new B(),
-	  [{ name: "MethodB", args: [{ name: "valB", type: { kind: $.TypeKind.Pointer, elemType: "B" } }], returns: [] }],
+	  [{ name: "MethodB", args: [{ name: "valB", type: { kind: $.TypeKind.Pointer, elemType: "main@github.com/aperturerobotics/goscript/compliance/tests/recursive_type_definition.B" } }], returns: [] }],
 	  B,
-	  {}
+	  []
 	);
 }
 
@@ -46,9 +46,9 @@ export type C = null | {
 }
 
 $.registerInterfaceType(
-  'C',
+  'main@github.com/aperturerobotics/goscript/compliance/tests/recursive_type_definition.C',
   null, // Zero value for interface is null
-  [{ name: "MethodC", args: [{ name: "d", type: "D" }], returns: [] }]
+  [{ name: "MethodC", args: [{ name: "d", type: "main@github.com/aperturerobotics/goscript/compliance/tests/recursive_type_definition.D" }], returns: [] }]
 );
 
 export type D = null | {
@@ -56,9 +56,9 @@ export type D = null | {
 }
 
 $.registerInterfaceType(
-  'D',
+  'main@github.com/aperturerobotics/goscript/compliance/tests/recursive_type_definition.D',
   null, // Zero value for interface is null
-  [{ name: "MethodD", args: [{ name: "c", type: "C" }], returns: [] }]
+  [{ name: "MethodD", args: [{ name: "c", type: "main@github.com/aperturerobotics/goscript/compliance/tests/recursive_type_definition.C" }], returns: [] }]
 );
 
 export async function main(): Promise<void> {
@@ -0,0 +1 @@
+export { Base, User } from "./reflect_struct_tags.gs.js"
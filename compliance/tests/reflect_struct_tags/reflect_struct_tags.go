@@ -0,0 +1,45 @@
+package main
+
+import "reflect"
+
+type Base struct {
+	ID int `json:"id"`
+}
+
+type User struct {
+	Base
+	Name    string  `json:"name" validate:"required"`
+	Email   string  `json:"email,omitempty"`
+	Score   float64 `json:"-"`
+	age     int
+	Active  bool `json:""`
+	Aliases []string
+}
+
+func main() {
+	t := reflect.TypeOf(User{})
+	println("type:", t.String(), "kind:", t.Kind() == reflect.Struct)
+	println("fields:", t.NumField())
+	for i := 0; i < t.NumField(); i++ {
+		f := t.Field(i)
+		json, ok := f.Tag.Lookup("json")
+		println(i, f.Name, f.Type.String(), f.Anonymous, f.IsExported(), "json:", json, ok, "validate:", f.Tag.Get("validate"))
+	}
+
+	f := t.Field(1)
+	println("tag:", string(f.Tag))
+	println("index:", len(f.Index), f.Index[0])
+
+	// Tags with quoted values containing spaces and escapes.
+	tag := reflect.StructTag(`a:"x y" b:"q\"uote" c:"" bad`)
+	println(tag.Get("a"), tag.Get("b"))
+	v, ok := tag.Lookup("c")
+	println(v == "", ok)
+	v, ok = tag.Lookup("bad")
+	println(v == "", ok)
+
+	// Promoted fields of embedded structs.
+	for _, vf := range reflect.VisibleFields(t) {
+		println("visible:", vf.Name, len(vf.Index), vf.Tag.Get("json"))
+	}
+}
@@ -0,0 +1,177 @@
+// Generated file based on reflect_struct_tags.go
+// Updated when compliance tests are re-run, DO NOT EDIT!
+
+import * as $ from "@goscript/builtin/index.js";
+
+import * as reflect from "@goscript/reflect/index.js"
+
+export class Base {
+	public get ID(): number {
+		return this._fields.ID.value
+	}
+	public set ID(value: number) {
+		this._fields.ID.value = value
+	}
+
+	public _fields: {
+		ID: $.VarRef<number>;
+	}
+
+	constructor(init?: Partial<{ID?: number}>) {
+		this._fields = {
+			ID: $.varRef(init?.ID ?? 0)
+		}
+	}
+
+	public clone(): Base {
+		const cloned = new Base()
+		cloned._fields = {
+			ID: $.varRef(this._fields.ID.value)
+		}
+		return cloned
+	}
+
+	// Register this type with the runtime type system
+	static __typeInfo = $.registerStructType(
+	  'main@github.com/aperturerobotics/goscript/compliance/tests/reflect_struct_tags.Base',
+	  new Base(),
+	  [],
+	  Base,
+	  [{ name: "ID", type: { kind: $.TypeKind.Basic, name: "int" }, tag: "json:\"id\"" }]
+	);
+}
+
+export class User {
+	public get Name(): string {
+		return this._fields.Name.value
+	}
+	public set Name(value: string) {
+		this._fields.Name.value = value
+	}
+
+	public get Email(): string {
+		return this._fields.Email.value
+	}
+	public set Email(value: string) {
+		this._fields.Email.value = value
+	}
+
+	public get Score(): number {
+		return this._fields.Score.value
+	}
+	public set Score(value: number) {
+		this._fields.Score.value = value
+	}
+
+	public get age(): number {
+		return this._fields.age.value
+	}
+	public set age(value: number) {
+		this._fields.age.value = value
+	}
+
+	public get Active(): boolean {
+		return this._fields.Active.value
+	}
+	public set Active(value: boolean) {
+		this._fields.Active.value = value
+	}
+
+	public get Aliases(): $.Slice<string> {
+		return this._fields.Aliases.value
+	}
+	public set Aliases(value: $.Slice<string>) {
+		this._fields.Aliases.value = value
+	}
+
+	public get Base(): Base {
+		return this._fields.Base.value
+	}
+	public set Base(value: Base) {
+		this._fields.Base.value = value
+	}
+
+	public _fields: {
+		Base: $.VarRef<Base>;
+		Name: $.VarRef<string>;
+		Email: $.VarRef<string>;
+		Score: $.VarRef<number>;
+		age: $.VarRef<number>;
+		Active: $.VarRef<boolean>;
+		Aliases: $.VarRef<$.Slice<string>>;
+	}
+
+	constructor(init?: Partial<{Active?: boolean, Aliases?: $.Slice<string>, Base?: Partial<ConstructorParameters<typeof Base>[0]>, Email?: string, Name?: string, Score?: number, age?: number}>) {
+		this._fields = {
+			Base: $.varRef(new Base(init?.Base)),
+			Name: $.varRef(init?.Name ?? ""),
+			Email: $.varRef(init?.Email ?? ""),
+			Score: $.varRef(init?.Score ?? 0),
+			age: $.varRef(init?.age ?? 0),
+			Active: $.varRef(init?.Active ?? false),
+			Aliases: $.varRef(init?.Aliases ?? null)
+		}
+	}
+
+	public clone(): User {
+		const cloned = new User()
+		cloned._fields = {
+			Base: $.varRef(this._fields.Base.value.clone()),
+			Name: $.varRef(this._fields.Name.value),
+			Email: $.varRef(this._fields.Email.value),
+			Score: $.varRef(this._fields.Score.value),
+			age: $.varRef(this._fields.age.value),
+			Active: $.varRef(this._fields.Active.value),
+			Aliases: $.varRef(this._fields.Aliases.value)
+		}
+		return cloned
+	}
+
+	public get ID(): number {
+		return this.Base.ID
+	}
+	public set ID(value: number) {
+		this.Base.ID = value
+	}
+
+	// Register this type with the runtime type system
+	static __typeInfo = $.registerStructType(
+	  'main@github.com/aperturerobotics/goscript/compliance/tests/reflect_struct_tags.User',
+	  new User(),
+	  [],
+	  User,
+	  [{ name: "Base", type: "main@github.com/aperturerobotics/goscript/compliance/tests/reflect_struct_tags.Base", embedded: true }, { name: "Name", type: { kind: $.TypeKind.Basic, name: "string" }, tag: "json:\"name\" validate:\"required\"" }, { name: "Email", type: { kind: $.TypeKind.Basic, name: "string" }, tag: "json:\"email,omitempty\"" }, { name: "Score", type: { kind: $.TypeKind.Basic, name: "float64" }, tag: "json:\"-\"" }, { name: "age", type: { kind: $.TypeKind.Basic, name: "int" } }, { name: "Active", type: { kind: $.TypeKind.Basic, name: "bool" }, tag: "json:\"\"" }, { name: "Aliases", type: { kind: $.TypeKind.Slice, elemType: { kind: $.TypeKind.Basic, name: "string" } } }]
+	);
+}
+
+export async function main(): Promise<void> {
+	let t = reflect.TypeOf($.markStructValue(new User({})), "main@github.com/aperturerobotics/goscript/compliance/tests/reflect_struct_tags.User")
+	console.log("type:", t!.String(), "kind:", t!.Kind() == reflect.Struct)
+	console.log("fields:", t!.NumField())
+	for (let i = 0; i < t!.NumField(); i++) {
+		let f = t!.Field(i).clone()
+		let [json, ok] = reflect.StructTag_Lookup(f.Tag, "json")
+		console.log(i, f.Name, f.Type!.String(), f.Anonymous, f.IsExported(), "json:", json, ok, "validate:", reflect.StructTag_Get(f.Tag, "validate"))
+	}
+
+	let f = t!.Field(1).clone()
+	console.log("tag:", f.Tag)
+	console.log("index:", $.len(f.Index), f.Index![0])
+
+	// Tags with quoted values containing spaces and escapes.
+	let tag = ("a:\"x y\" b:\"q\\\"uote\" c:\"\" bad" as reflect.StructTag)
+	console.log(reflect.StructTag_Get(tag, "a"), reflect.StructTag_Get(tag, "b"))
+	let [v, ok] = reflect.StructTag_Lookup(tag, "c")
+	console.log(v == "", ok)
+	;[v, ok] = reflect.StructTag_Lookup(tag, "bad")
+	console.log(v == "", ok)
+
+	// Promoted fields of embedded structs.
+	for (let _i = 0; _i < $.len(reflect.VisibleFields(t)); _i++) {
+		const vf = reflect.VisibleFields(t)![_i]
+		{
+			console.log("visible:", vf.Name, $.len(vf.Index), reflect.StructTag_Get(vf.Tag, "json"))
+		}
+	}
+}
+
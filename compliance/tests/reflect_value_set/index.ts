@@ -0,0 +1 @@
+export { Base, Config, Inner } from "./reflect_value_set.gs.js"
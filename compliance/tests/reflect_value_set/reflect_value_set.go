@@ -0,0 +1,164 @@
+package main
+
+import (
+	"reflect"
+	"strconv"
+)
+
+type Inner struct {
+	Label string
+}
+
+type Base struct {
+	ID int
+}
+
+type Config struct {
+	Base
+	Name    string
+	Port    int
+	Ratio   float64
+	Debug   bool
+	Big     int64
+	Small   int8
+	Tags    []string
+	Limits  map[string]int
+	Inner   Inner
+	Next    *Inner
+	private int
+}
+
+// setFields is a small decoder filling a struct through a pointer held in an
+// interface.
+func setFields(dst any, values map[string]string) {
+	v := reflect.ValueOf(dst).Elem()
+	t := v.Type()
+	for i := 0; i < t.NumField(); i++ {
+		f := t.Field(i)
+		s, ok := values[f.Name]
+		if !ok {
+			continue
+		}
+		fv := v.Field(i)
+		switch fv.Kind() {
+		case reflect.String:
+			fv.SetString(s)
+		case reflect.Int, reflect.Int64:
+			n, _ := strconv.Atoi(s)
+			fv.SetInt(int64(n))
+		case reflect.Bool:
+			fv.SetBool(s == "true")
+		}
+	}
+}
+
+func main() {
+	cfg := Config{Name: "a", Tags: []string{"x", "y"}}
+
+	// Fields of a struct reached through a pointer are settable.
+	v := reflect.ValueOf(&cfg)
+	println("kind:", v.Kind().String(), v.Type().String())
+	e := v.Elem()
+	println("elem:", e.Kind().String(), e.Type().String(), e.CanSet(), e.CanAddr())
+	e.Field(1).SetString("server")
+	e.FieldByName("Port").SetInt(8080)
+	e.FieldByName("Ratio").SetFloat(0.5)
+	e.FieldByName("Debug").SetBool(true)
+	e.FieldByName("Big").SetInt(1 << 40)
+	e.FieldByName("Small").SetInt(300)
+	println("cfg:", cfg.Name, cfg.Port, cfg.Ratio, cfg.Debug, cfg.Big, cfg.Small)
+
+	// Promoted fields of embedded structs.
+	e.FieldByName("ID").SetInt(42)
+	println("id:", cfg.ID, cfg.Base.ID)
+
+	// Values not reached through a pointer and unexported fields are not
+	// settable.
+	println("value CanSet:", reflect.ValueOf(cfg).Field(1).CanSet())
+	println("private CanSet:", e.FieldByName("private").CanSet())
+	func() {
+		defer func() {
+			println("recovered:", recover() != nil)
+		}()
+		reflect.ValueOf(cfg).Field(1).SetString("nope")
+	}()
+	println("missing:", e.FieldByName("Missing").IsValid())
+
+	// Set a whole struct field and a pointer field.
+	e.FieldByName("Inner").Set(reflect.ValueOf(Inner{Label: "in"}))
+	e.FieldByName("Next").Set(reflect.ValueOf(&Inner{Label: "next"}))
+	println("inner:", cfg.Inner.Label, cfg.Next.Label)
+	e.FieldByName("Next").Elem().Field(0).SetString("changed")
+	println("next:", cfg.Next.Label)
+
+	// Addr returns pointers sharing the variable.
+	port := e.FieldByName("Port").Addr().Interface().(*int)
+	*port = 9090
+	println("port:", cfg.Port, e.FieldByName("Port").Int())
+
+	// Pointers to basic values.
+	x := 1
+	reflect.ValueOf(&x).Elem().SetInt(5)
+	println("x:", x)
+	s := "s"
+	reflect.ValueOf(&s).Elem().Set(reflect.ValueOf("t"))
+	println("s:", s)
+
+	// Slice elements are settable.
+	tags := e.FieldByName("Tags")
+	tags.Index(1).SetString("z")
+	println("tags:", cfg.Tags[0], cfg.Tags[1], tags.Len())
+	tags.Set(reflect.Append(tags, reflect.ValueOf("w")))
+	println("appended:", len(cfg.Tags), cfg.Tags[2])
+	nums := []int{1, 2, 3}
+	reflect.ValueOf(nums).Index(0).SetInt(10)
+	println("nums:", nums[0])
+
+	// Maps.
+	limits := e.FieldByName("Limits")
+	println("nil map:", limits.IsNil())
+	limits.Set(reflect.MakeMap(limits.Type()))
+	limits.SetMapIndex(reflect.ValueOf("cpu"), reflect.ValueOf(2))
+	limits.SetMapIndex(reflect.ValueOf("mem"), reflect.ValueOf(512))
+	println("limits:", len(cfg.Limits), cfg.Limits["cpu"], limits.MapIndex(reflect.ValueOf("mem")).Int())
+	println("absent:", limits.MapIndex(reflect.ValueOf("disk")).IsValid())
+	limits.SetMapIndex(reflect.ValueOf("cpu"), reflect.Value{})
+	println("deleted:", len(cfg.Limits), len(limits.MapKeys()))
+	total := 0
+	iter := reflect.ValueOf(map[string]int{"a": 1, "b": 2, "c": 3}).MapRange()
+	for iter.Next() {
+		total += int(iter.Value().Int()) + len(iter.Key().String())
+	}
+	println("total:", total)
+
+	// New, MakeSlice and Zero.
+	p := reflect.New(reflect.TypeOf(0))
+	p.Elem().SetInt(7)
+	println("new int:", *p.Interface().(*int), p.Type().String())
+	n := reflect.New(reflect.TypeOf(Inner{}))
+	n.Elem().Field(0).SetString("made")
+	println("new struct:", n.Type().String(), n.Elem().Interface().(Inner).Label)
+	ms := reflect.MakeSlice(reflect.TypeOf([]string{}), 2, 4)
+	ms.Index(0).SetString("first")
+	strs := ms.Interface().([]string)
+	println("make slice:", len(strs), cap(strs), strs[0], strs[1] == "")
+	println("zero:", reflect.Zero(reflect.TypeOf(Inner{})).IsZero(), reflect.ValueOf(Inner{Label: "l"}).IsZero())
+
+	// Call.
+	add := func(a, b int) int { return a + b }
+	out := reflect.ValueOf(add).Call([]reflect.Value{reflect.ValueOf(2), reflect.ValueOf(3)})
+	println("call:", len(out), out[0].Int())
+
+	// A decoder taking the destination as an interface.
+	var dst Config
+	setFields(&dst, map[string]string{"Name": "decoded", "Port": "443", "Debug": "true"})
+	println("decoded:", dst.Name, dst.Port, dst.Debug)
+
+	// Kind mismatches panic.
+	func() {
+		defer func() {
+			println("mismatch recovered:", recover() != nil)
+		}()
+		e.FieldByName("Name").SetInt(1)
+	}()
+}
@@ -0,0 +1,398 @@
+// Generated file based on reflect_value_set.go
+// Updated when compliance tests are re-run, DO NOT EDIT!
+
+import * as $ from "@goscript/builtin/index.js";
+
+import * as reflect from "@goscript/reflect/index.js"
+
+import * as strconv from "@goscript/strconv/index.js"
+
+export class Inner {
+	public get Label(): string {
+		return this._fields.Label.value
+	}
+	public set Label(value: string) {
+		this._fields.Label.value = value
+	}
+
+	public _fields: {
+		Label: $.VarRef<string>;
+	}
+
+	constructor(init?: Partial<{Label?: string}>) {
+		this._fields = {
+			Label: $.varRef(init?.Label ?? "")
+		}
+	}
+
+	public clone(): Inner {
+		const cloned = new Inner()
+		cloned._fields = {
+			Label: $.varRef(this._fields.Label.value)
+		}
+		return cloned
+	}
+
+	// Register this type with the runtime type system
+	static __typeInfo = $.registerStructType(
+	  'main@github.com/aperturerobotics/goscript/compliance/tests/reflect_value_set.Inner',
+	  new Inner(),
+	  [],
+	  Inner,
+	  [{ name: "Label", type: { kind: $.TypeKind.Basic, name: "string" } }]
+	);
+}
+
+export class Base {
+	public get ID(): number {
+		return this._fields.ID.value
+	}
+	public set ID(value: number) {
+		this._fields.ID.value = value
+	}
+
+	public _fields: {
+		ID: $.VarRef<number>;
+	}
+
+	constructor(init?: Partial<{ID?: number}>) {
+		this._fields = {
+			ID: $.varRef(init?.ID ?? 0)
+		}
+	}
+
+	public clone(): Base {
+		const cloned = new Base()
+		cloned._fields = {
+			ID: $.varRef(this._fields.ID.value)
+		}
+		return cloned
+	}
+
+	// Register this type with the runtime type system
+	static __typeInfo = $.registerStructType(
+	  'main@github.com/aperturerobotics/goscript/compliance/tests/reflect_value_set.Base',
+	  new Base(),
+	  [],
+	  Base,
+	  [{ name: "ID", type: { kind: $.TypeKind.Basic, name: "int" } }]
+	);
+}
+
+export class Config {
+	public get Name(): string {
+		return this._fields.Name.value
+	}
+	public set Name(value: string) {
+		this._fields.Name.value = value
+	}
+
+	public get Port(): number {
+		return this._fields.Port.value
+	}
+	public set Port(value: number) {
+		this._fields.Port.value = value
+	}
+
+	public get Ratio(): number {
+		return this._fields.Ratio.value
+	}
+	public set Ratio(value: number) {
+		this._fields.Ratio.value = value
+	}
+
+	public get Debug(): boolean {
+		return this._fields.Debug.value
+	}
+	public set Debug(value: boolean) {
+		this._fields.Debug.value = value
+	}
+
+	public get Big(): number {
+		return this._fields.Big.value
+	}
+	public set Big(value: number) {
+		this._fields.Big.value = value
+	}
+
+	public get Small(): number {
+		return this._fields.Small.value
+	}
+	public set Small(value: number) {
+		this._fields.Small.value = value
+	}
+
+	public get Tags(): $.Slice<string> {
+		return this._fields.Tags.value
+	}
+	public set Tags(value: $.Slice<string>) {
+		this._fields.Tags.value = value
+	}
+
+	public get Limits(): Map<string, number> | null {
+		return this._fields.Limits.value
+	}
+	public set Limits(value: Map<string, number> | null) {
+		this._fields.Limits.value = value
+	}
+
+	public get Inner(): Inner {
+		return this._fields.Inner.value
+	}
+	public set Inner(value: Inner) {
+		this._fields.Inner.value = value
+	}
+
+	public get Next(): Inner | null {
+		return this._fields.Next.value
+	}
+	public set Next(value: Inner | null) {
+		this._fields.Next.value = value
+	}
+
+	public get private(): number {
+		return this._fields.private.value
+	}
+	public set private(value: number) {
+		this._fields.private.value = value
+	}
+
+	public get Base(): Base {
+		return this._fields.Base.value
+	}
+	public set Base(value: Base) {
+		this._fields.Base.value = value
+	}
+
+	public _fields: {
+		Base: $.VarRef<Base>;
+		Name: $.VarRef<string>;
+		Port: $.VarRef<number>;
+		Ratio: $.VarRef<number>;
+		Debug: $.VarRef<boolean>;
+		Big: $.VarRef<number>;
+		Small: $.VarRef<number>;
+		Tags: $.VarRef<$.Slice<string>>;
+		Limits: $.VarRef<Map<string, number> | null>;
+		Inner: $.VarRef<Inner>;
+		Next: $.VarRef<Inner | null>;
+		private: $.VarRef<number>;
+	}
+
+	constructor(init?: Partial<{Base?: Partial<ConstructorParameters<typeof Base>[0]>, Big?: number, Debug?: boolean, Inner?: Inner, Limits?: Map<string, number> | null, Name?: string, Next?: Inner | null, Port?: number, Ratio?: number, Small?: number, Tags?: $.Slice<string>, private?: number}>) {
+		this._fields = {
+			Base: $.varRef(new Base(init?.Base)),
+			Name: $.varRef(init?.Name ?? ""),
+			Port: $.varRef(init?.Port ?? 0),
+			Ratio: $.varRef(init?.Ratio ?? 0),
+			Debug: $.varRef(init?.Debug ?? false),
+			Big: $.varRef(init?.Big ?? 0),
+			Small: $.varRef(init?.Small ?? 0),
+			Tags: $.varRef(init?.Tags ?? null),
+			Limits: $.varRef(init?.Limits ?? null),
+			Inner: $.varRef(init?.Inner?.clone() ?? new Inner()),
+			Next: $.varRef(init?.Next ?? null),
+			private: $.varRef(init?.private ?? 0)
+		}
+	}
+
+	public clone(): Config {
+		const cloned = new Config()
+		cloned._fields = {
+			Base: $.varRef(this._fields.Base.value.clone()),
+			Name: $.varRef(this._fields.Name.value),
+			Port: $.varRef(this._fields.Port.value),
+			Ratio: $.varRef(this._fields.Ratio.value),
+			Debug: $.varRef(this._fields.Debug.value),
+			Big: $.varRef(this._fields.Big.value),
+			Small: $.varRef(this._fields.Small.value),
+			Tags: $.varRef(this._fields.Tags.value),
+			Limits: $.varRef(this._fields.Limits.value),
+			Inner: $.varRef(this._fields.Inner.value?.clone() ?? null),
+			Next: $.varRef(this._fields.Next.value),
+			private: $.varRef(this._fields.private.value)
+		}
+		return cloned
+	}
+
+	public get ID(): number {
+		return this.Base.ID
+	}
+	public set ID(value: number) {
+		this.Base.ID = value
+	}
+
+	// Register this type with the runtime type system
+	static __typeInfo = $.registerStructType(
+	  'main@github.com/aperturerobotics/goscript/compliance/tests/reflect_value_set.Config',
+	  new Config(),
+	  [],
+	  Config,
+	  [{ name: "Base", type: "main@github.com/aperturerobotics/goscript/compliance/tests/reflect_value_set.Base", embedded: true }, { name: "Name", type: { kind: $.TypeKind.Basic, name: "string" } }, { name: "Port", type: { kind: $.TypeKind.Basic, name: "int" } }, { name: "Ratio", type: { kind: $.TypeKind.Basic, name: "float64" } }, { name: "Debug", type: { kind: $.TypeKind.Basic, name: "bool" } }, { name: "Big", type: { kind: $.TypeKind.Basic, name: "int64" } }, { name: "Small", type: { kind: $.TypeKind.Basic, name: "int8" } }, { name: "Tags", type: { kind: $.TypeKind.Slice, elemType: { kind: $.TypeKind.Basic, name: "string" } } }, { name: "Limits", type: { kind: $.TypeKind.Map, keyType: { kind: $.TypeKind.Basic, name: "string" }, elemType: { kind: $.TypeKind.Basic, name: "int" } } }, { name: "Inner", type: "main@github.com/aperturerobotics/goscript/compliance/tests/reflect_value_set.Inner" }, { name: "Next", type: { kind: $.TypeKind.Pointer, elemType: "main@github.com/aperturerobotics/goscript/compliance/tests/reflect_value_set.Inner" } }, { name: "private", type: { kind: $.TypeKind.Basic, name: "int" } }]
+	);
+}
+
+// setFields is a small decoder filling a struct through a pointer held in an
+// interface.
+export function setFields(dst: null | any, values: Map<string, string> | null): void {
+	let v = reflect.ValueOf(dst)!.Elem().clone()
+	let t = v.Type()
+	for (let i = 0; i < t!.NumField(); i++) {
+		let f = t!.Field(i).clone()
+		let [s, ok] = $.mapGet(values, f.Name, "")
+		if (!ok) {
+			continue
+		}
+		let fv = v.Field(i).clone()
+		switch (fv.Kind()) {
+			case reflect.String:
+				fv.SetString(s)
+				break
+			case reflect.Int:
+			case reflect.Int64:
+				let [n, ] = strconv.Atoi(s)
+				fv.SetInt((n as number))
+				break
+			case reflect.Bool:
+				fv.SetBool(s == "true")
+				break
+		}
+	}
+}
+
+export async function main(): Promise<void> {
+	using __defer = new $.DisposableStack();
+	let cfg = new Config({Name: "a", Tags: $.arrayToSlice<string>(["x", "y"])})
+
+	// Fields of a struct reached through a pointer are settable.
+	let v = reflect.ValueOf(cfg, { kind: $.TypeKind.Pointer, elemType: "main@github.com/aperturerobotics/goscript/compliance/tests/reflect_value_set.Config" }).clone()
+	console.log("kind:", reflect.Kind_String(v.Kind()), v.Type()!.String())
+	let e = v.Elem().clone()
+	console.log("elem:", reflect.Kind_String(e.Kind()), e.Type()!.String(), e.CanSet(), e.CanAddr())
+	e.Field(1)!.SetString("server")
+	e.FieldByName("Port")!.SetInt(8080)
+	e.FieldByName("Ratio")!.SetFloat(0.5)
+	e.FieldByName("Debug")!.SetBool(true)
+	e.FieldByName("Big")!.SetInt(1099511627776)
+	e.FieldByName("Small")!.SetInt(300)
+	console.log("cfg:", cfg.Name, cfg.Port, cfg.Ratio, cfg.Debug, cfg.Big, cfg.Small)
+
+	// Promoted fields of embedded structs.
+	e.FieldByName("ID")!.SetInt(42)
+	console.log("id:", cfg.ID, cfg.Base.ID)
+
+	// Values not reached through a pointer and unexported fields are not
+	// settable.
+	console.log("value CanSet:", reflect.ValueOf($.markStructValue(cfg.clone()), "main@github.com/aperturerobotics/goscript/compliance/tests/reflect_value_set.Config")!.Field(1)!.CanSet())
+	console.log("private CanSet:", e.FieldByName("private")!.CanSet())
+	;((): void => {
+		const __defer = new $.DisposableStack();
+		try {
+			__defer.defer((__frame) => {
+				console.log("recovered:", $.recover(__frame) != null)
+			});
+			reflect.ValueOf($.markStructValue(cfg.clone()), "main@github.com/aperturerobotics/goscript/compliance/tests/reflect_value_set.Config")!.Field(1)!.SetString("nope")
+		} catch (__e) {
+			__defer.panic(__e)
+		} finally {
+			__defer.dispose()
+		}
+	})()
+	console.log("missing:", e.FieldByName("Missing")!.IsValid())
+
+	// Set a whole struct field and a pointer field.
+	e.FieldByName("Inner")!.Set(reflect.ValueOf($.markStructValue(new Inner({Label: "in"})), "main@github.com/aperturerobotics/goscript/compliance/tests/reflect_value_set.Inner"))
+	e.FieldByName("Next")!.Set(reflect.ValueOf(new Inner({Label: "next"}), { kind: $.TypeKind.Pointer, elemType: "main@github.com/aperturerobotics/goscript/compliance/tests/reflect_value_set.Inner" }))
+	console.log("inner:", cfg.Inner.Label, cfg.Next!.Label)
+	e.FieldByName("Next")!.Elem()!.Field(0)!.SetString("changed")
+	console.log("next:", cfg.Next!.Label)
+
+	// Addr returns pointers sharing the variable.
+	let port = $.mustTypeAssert<$.VarRef<number> | null>(e.FieldByName("Port")!.Addr()!.Interface(), {kind: $.TypeKind.Pointer, elemType: {kind: $.TypeKind.Basic, name: 'number'}})
+	port!.value = 9090
+	console.log("port:", cfg.Port, e.FieldByName("Port")!.Int())
+
+	// Pointers to basic values.
+	let x = $.varRef(1)
+	reflect.ValueOf(x, { kind: $.TypeKind.Pointer, elemType: { kind: $.TypeKind.Basic, name: "int" } })!.Elem()!.SetInt(5)
+	console.log("x:", x!.value)
+	let s = $.varRef("s")
+	reflect.ValueOf(s, { kind: $.TypeKind.Pointer, elemType: { kind: $.TypeKind.Basic, name: "string" } })!.Elem()!.Set(reflect.ValueOf("t", { kind: $.TypeKind.Basic, name: "string" }))
+	console.log("s:", s!.value)
+
+	// Slice elements are settable.
+	let tags = e.FieldByName("Tags").clone()
+	tags.Index(1)!.SetString("z")
+	console.log("tags:", cfg.Tags![0], cfg.Tags![1], tags.Len())
+	tags.Set(reflect.Append(tags, reflect.ValueOf("w", { kind: $.TypeKind.Basic, name: "string" })))
+	console.log("appended:", $.len(cfg.Tags), cfg.Tags![2])
+	let nums = $.arrayToSlice<number>([1, 2, 3])
+	reflect.ValueOf(nums, { kind: $.TypeKind.Slice, elemType: { kind: $.TypeKind.Basic, name: "int" } })!.Index(0)!.SetInt(10)
+	console.log("nums:", nums![0])
+
+	// Maps.
+	let limits = e.FieldByName("Limits").clone()
+	console.log("nil map:", limits.IsNil())
+	limits.Set(reflect.MakeMap(limits.Type()))
+	limits.SetMapIndex(reflect.ValueOf("cpu", { kind: $.TypeKind.Basic, name: "string" }), reflect.ValueOf(2, { kind: $.TypeKind.Basic, name: "int" }))
+	limits.SetMapIndex(reflect.ValueOf("mem", { kind: $.TypeKind.Basic, name: "string" }), reflect.ValueOf(512, { kind: $.TypeKind.Basic, name: "int" }))
+	console.log("limits:", $.len(cfg.Limits), $.mapGet(cfg.Limits, "cpu", 0)[0], limits.MapIndex(reflect.ValueOf("mem", { kind: $.TypeKind.Basic, name: "string" }))!.Int())
+	console.log("absent:", limits.MapIndex(reflect.ValueOf("disk", { kind: $.TypeKind.Basic, name: "string" }))!.IsValid())
+	limits.SetMapIndex(reflect.ValueOf("cpu", { kind: $.TypeKind.Basic, name: "string" }), new reflect.Value({}))
+	console.log("deleted:", $.len(cfg.Limits), $.len(limits.MapKeys()))
+	let total = 0
+	let iter = reflect.ValueOf(new Map([["a", 1], ["b", 2], ["c", 3]]), { kind: $.TypeKind.Map, keyType: { kind: $.TypeKind.Basic, name: "string" }, elemType: { kind: $.TypeKind.Basic, name: "int" } })!.MapRange()
+	for (; iter!.Next(); ) {
+		total += $.int(iter!.Value()!.Int()) + $.len(iter!.Key()!.String())
+	}
+	console.log("total:", total)
+
+	// New, MakeSlice and Zero.
+	let p = reflect.New(reflect.TypeOf(0, { kind: $.TypeKind.Basic, name: "int" })).clone()
+	p.Elem()!.SetInt(7)
+	console.log("new int:", $.mustTypeAssert<$.VarRef<number> | null>(await p.Interface(), {kind: $.TypeKind.Pointer, elemType: {kind: $.TypeKind.Basic, name: 'number'}})!.value, p.Type()!.String())
+	let n = reflect.New(reflect.TypeOf($.markStructValue(new Inner({})), "main@github.com/aperturerobotics/goscript/compliance/tests/reflect_value_set.Inner")).clone()
+	n.Elem()!.Field(0)!.SetString("made")
+	console.log("new struct:", n.Type()!.String(), $.mustTypeAssert<Inner>(n.Elem()!.Interface(), 'main@github.com/aperturerobotics/goscript/compliance/tests/reflect_value_set.Inner').Label)
+	let ms = reflect.MakeSlice(reflect.TypeOf($.arrayToSlice<string>([]), { kind: $.TypeKind.Slice, elemType: { kind: $.TypeKind.Basic, name: "string" } }), 2, 4).clone()
+	ms.Index(0)!.SetString("first")
+	let strs = $.mustTypeAssert<$.Slice<string>>(await ms.Interface(), {kind: $.TypeKind.Slice, elemType: {kind: $.TypeKind.Basic, name: 'string'}})
+	console.log("make slice:", $.len(strs), $.cap(strs), strs![0], strs![1] == "")
+	console.log("zero:", reflect.Zero(reflect.TypeOf($.markStructValue(new Inner({})), "main@github.com/aperturerobotics/goscript/compliance/tests/reflect_value_set.Inner"))!.IsZero(), reflect.ValueOf($.markStructValue(new Inner({Label: "l"})), "main@github.com/aperturerobotics/goscript/compliance/tests/reflect_value_set.Inner")!.IsZero())
+
+	// Call.
+	let add = (() => {
+		const fn = (a: number, b: number): number => {
+			return a + b
+		}
+		fn.__typeInfo = {
+			kind: $.TypeKind.Function,
+			params: ['int', 'int'],
+			results: ['int'],
+		}
+		return fn
+	})()
+	let out = reflect.ValueOf(add)!.Call($.arrayToSlice<reflect.Value>([reflect.ValueOf(2, { kind: $.TypeKind.Basic, name: "int" }), reflect.ValueOf(3, { kind: $.TypeKind.Basic, name: "int" })]))
+	console.log("call:", $.len(out), out![0].Int())
+
+	// A decoder taking the destination as an interface.
+	let dst: Config = new Config()
+	setFields(dst, new Map([["Name", "decoded"], ["Port", "443"], ["Debug", "true"]]))
+	console.log("decoded:", dst.Name, dst.Port, dst.Debug)
+
+	// Kind mismatches panic.
+	;((): void => {
+		const __defer = new $.DisposableStack();
+		try {
+			__defer.defer((__frame) => {
+				console.log("mismatch recovered:", $.recover(__frame) != null)
+			});
+			e.FieldByName("Name")!.SetInt(1)
+		} catch (__e) {
+			__defer.panic(__e)
+		} finally {
+			__defer.dispose()
+		}
+	})()
+}
+
@@ -16,6 +16,6 @@ export async function main(): Promise<void> {
 }
 
 export function useInFunction(r: number): number {
-	return r + 1
+	return ((r + 1) | 0)
 }
 
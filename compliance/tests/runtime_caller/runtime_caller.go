@@ -0,0 +1,74 @@
+package main
+
+import (
+	"path/filepath"
+	"runtime"
+	"strings"
+	"sync"
+)
+
+type counter struct {
+	mu sync.Mutex
+	n  int
+}
+
+func (c *counter) add() {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.n++
+}
+
+func where() (string, bool) {
+	pc, _, _, ok := runtime.Caller(1)
+	if !ok {
+		return "", false
+	}
+	return runtime.FuncForPC(pc).Name(), true
+}
+
+func self() string {
+	pc, file, line, _ := runtime.Caller(0)
+	fn := runtime.FuncForPC(pc)
+	fnFile, fnLine := fn.FileLine(pc)
+	println("file line:", filepath.Base(fnFile) == filepath.Base(file), fnLine == line)
+	return fn.Name()
+}
+
+func main() {
+	_, file, line, ok := runtime.Caller(0)
+	println("caller:", ok, filepath.Base(file), line)
+
+	name, ok := where()
+	println("where:", ok, name)
+	println("self:", self())
+
+	pcs := make([]uintptr, 8)
+	n := runtime.Callers(1, pcs)
+	println("callers:", n > 0)
+	frames := runtime.CallersFrames(pcs[:n])
+	frame, _ := frames.Next()
+	println("frame:", frame.Function, filepath.Base(frame.File), frame.Line)
+
+	// Stack of the calling goroutine
+	buf := make([]byte, 4096)
+	n = runtime.Stack(buf, false)
+	println("stack:", strings.Split(string(buf[:n]), "\n")[0])
+
+	// Stack of all goroutines, with one blocked on a mutex
+	c := &counter{}
+	c.mu.Lock()
+	var wg sync.WaitGroup
+	wg.Add(1)
+	go func() {
+		defer wg.Done()
+		c.add()
+	}()
+	runtime.Gosched()
+	println("goroutines:", runtime.NumGoroutine())
+	n = runtime.Stack(buf, true)
+	all := string(buf[:n])
+	println("all goroutines:", strings.Count(all, "goroutine ") >= 2)
+	c.mu.Unlock()
+	wg.Wait()
+	println("count:", c.n)
+}
@@ -0,0 +1,131 @@
+// Generated file based on runtime_caller.go
+// Updated when compliance tests are re-run, DO NOT EDIT!
+
+import * as $ from "@goscript/builtin/index.js";
+
+$.registerSourceFile(3, ["github.com/aperturerobotics/goscript/compliance/tests/runtime_caller/runtime_caller.go"], ["main.(*counter).add", "main.where", "main.self", "main.main", "main.main.func1"], [49, 15, 0, 0, 52, 16, 0, 0, 53, 17, 0, 0, 56, 18, 0, 0, 58, 0, -1, -1, 69, 21, 1, 0, 70, 22, 1, 0, 71, 23, 1, 0, 72, 24, 1, 0, 74, 26, 1, 0, 76, 0, -1, -1, 77, 29, 2, 0, 78, 30, 2, 0, 79, 31, 2, 0, 80, 32, 2, 0, 81, 33, 2, 0, 82, 34, 2, 0, 84, 0, -1, -1, 85, 37, 3, 0, 87, 38, 3, 0, 88, 39, 3, 0, 90, 41, 3, 0, 92, 42, 3, 0, 93, 43, 3, 0, 95, 45, 3, 0, 96, 46, 3, 0, 97, 47, 3, 0, 98, 48, 3, 0, 99, 49, 3, 0, 100, 50, 3, 0, 103, 53, 3, 0, 104, 54, 3, 0, 105, 55, 3, 0, 108, 58, 3, 0, 109, 59, 3, 0, 110, 60, 3, 0, 111, 61, 3, 0, 112, 62, 3, 0, 114, 63, 4, 0, 117, 64, 4, 0, 119, 66, 3, 0, 120, 67, 3, 0, 121, 68, 3, 0, 122, 69, 3, 0, 123, 70, 3, 0, 124, 71, 3, 0, 125, 72, 3, 0, 126, 73, 3, 0, 128, 0, -1, -1])
+
+import * as filepath from "@goscript/path/filepath/index.js"
+
+import * as runtime from "@goscript/runtime/index.js"
+
+import * as strings from "@goscript/strings/index.js"
+
+import * as sync from "@goscript/sync/index.js"
+
+export class counter {
+	public get mu(): sync.Mutex {
+		return this._fields.mu.value
+	}
+	public set mu(value: sync.Mutex) {
+		this._fields.mu.value = value
+	}
+
+	public get n(): number {
+		return this._fields.n.value
+	}
+	public set n(value: number) {
+		this._fields.n.value = value
+	}
+
+	public _fields: {
+		mu: $.VarRef<sync.Mutex>;
+		n: $.VarRef<number>;
+	}
+
+	constructor(init?: Partial<{mu?: sync.Mutex, n?: number}>) {
+		this._fields = {
+			mu: $.varRef(init?.mu?.clone() ?? new sync.Mutex()),
+			n: $.varRef(init?.n ?? 0)
+		}
+	}
+
+	public clone(): counter {
+		const cloned = new counter()
+		cloned._fields = {
+			mu: $.varRef(this._fields.mu.value?.clone() ?? null),
+			n: $.varRef(this._fields.n.value)
+		}
+		return cloned
+	}
+
+	public async add(): Promise<void> {
+		const c = this
+		using __defer = new $.DisposableStack();
+		await c.mu.Lock()
+		__defer.defer(() => {
+			c.mu.Unlock()
+		});
+		c.n++
+	}
+
+	// Register this type with the runtime type system
+	static __typeInfo = $.registerStructType(
+	  'main@github.com/aperturerobotics/goscript/compliance/tests/runtime_caller.counter',
+	  new counter(),
+	  [{ name: "add", args: [], returns: [] }],
+	  counter,
+	  [{ name: "mu", type: "sync.Mutex" }, { name: "n", type: { kind: $.TypeKind.Basic, name: "int" } }]
+	);
+}
+
+export function where(): [string, boolean] {
+	let [pc, , , ok] = runtime.Caller(1)
+	if (!ok) {
+		return ["", false]
+	}
+	return [runtime.FuncForPC(pc)!.Name(), true]
+}
+
+export function self(): string {
+	let [pc, file, line, ] = runtime.Caller(0)
+	let fn = runtime.FuncForPC(pc)
+	let [fnFile, fnLine] = fn!.FileLine(pc)
+	console.log("file line:", filepath.Base(fnFile) == filepath.Base(file), fnLine == line)
+	return fn!.Name()
+}
+
+export async function main(): Promise<void> {
+	using __defer = new $.DisposableStack();
+	let [, file, line, ok] = runtime.Caller(0)
+	console.log("caller:", ok, filepath.Base(file), line)
+
+	let name: string
+	[name, ok] = where()
+	console.log("where:", ok, name)
+	console.log("self:", self())
+
+	let pcs = $.makeSlice<uintptr>(8)
+	let n = runtime.Callers(1, pcs)
+	console.log("callers:", n > 0)
+	let frames = runtime.CallersFrames($.goSlice(pcs, undefined, n))
+	let [frame, ] = await frames!.Next()
+	console.log("frame:", frame.Function, filepath.Base(frame.File), frame.Line)
+
+	// Stack of the calling goroutine
+	let buf = new Uint8Array(4096)
+	n = runtime.Stack(buf, false)
+	console.log("stack:", strings.Split($.bytesToString($.goSlice(buf, undefined, n)), "\n")![0])
+
+	// Stack of all goroutines, with one blocked on a mutex
+	let c = new counter({})
+	await c.mu.Lock()
+	let wg: sync.WaitGroup = new sync.WaitGroup()
+	wg.Add(1)
+	$.go(async () => {
+		using __defer = new $.DisposableStack();
+		__defer.defer(() => {
+			wg.Done()
+		});
+		await c.add()
+	}, "github.com/aperturerobotics/goscript/compliance/tests/runtime_caller/runtime_caller.go:62")
+	await runtime.Gosched()
+	console.log("goroutines:", runtime.NumGoroutine())
+	n = runtime.Stack(buf, true)
+	let all = $.bytesToString($.goSlice(buf, undefined, n))
+	console.log("all goroutines:", strings.Count(all, "goroutine ") >= 2)
+	c.mu.Unlock()
+	await wg.Wait()
+	console.log("count:", c.n)
+}
+
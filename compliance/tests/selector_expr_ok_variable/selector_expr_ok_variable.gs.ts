@@ -31,11 +31,11 @@ export class Result {
 
 	// Register this type with the runtime type system
 	static __typeInfo = $.registerStructType(
-	  'Result',
+	  'main@github.com/aperturerobotics/goscript/compliance/tests/selector_expr_ok_variable.Result',
 	  new Result(),
 	  [],
 	  Result,
-	  {"ok": { kind: $.TypeKind.Basic, name: "boolean" }}
+	  [{ name: "ok", type: { kind: $.TypeKind.Basic, name: "bool" } }]
 	);
 }
 
@@ -0,0 +1,62 @@
+package main
+
+import "strings"
+
+// tokenize splits src into words, numbers and punctuation by indexing its
+// bytes, as hand-written lexers do. It returns the number of tokens and the
+// first and last of them.
+func tokenize(src string) (int, string, string) {
+	n := 0
+	first, last := "", ""
+	emit := func(tok string) {
+		if n == 0 {
+			first = tok
+		}
+		last = tok
+		n++
+	}
+	for i := 0; i < len(src); {
+		c := src[i]
+		switch {
+		case c == ' ' || c == '\n':
+			i++
+		case c >= '0' && c <= '9':
+			num := i
+			for i < len(src) && src[i] >= '0' && src[i] <= '9' {
+				i++
+			}
+			emit(src[num:i])
+		case c >= 'a' && c <= 'z' || c >= 0x80:
+			word := i
+			for i < len(src) && (src[i] >= 'a' && src[i] <= 'z' || src[i] >= 0x80) {
+				i++
+			}
+			emit(src[word:i])
+		default:
+			emit(src[i : i+1])
+			i++
+		}
+	}
+	return n, first, last
+}
+
+func main() {
+	// Large inputs would take minutes if each len or index re-encoded the
+	// whole string.
+	ascii := strings.Repeat("let x1 = 42 + y;\n", 20000)
+	n, first, last := tokenize(ascii)
+	println("ascii:", len(ascii), n, first, last)
+
+	unicode := strings.Repeat("café 12 naïve, ", 20000)
+	n, first, last = tokenize(unicode)
+	println("unicode:", len(unicode), n, first, last)
+
+	sum := 0
+	for i := 0; i < len(unicode); i++ {
+		sum += int(unicode[i])
+	}
+	println("sum:", sum)
+
+	// Slices of cached strings are exact too.
+	println(unicode[3:5] == "é", len(unicode[:4]), unicode[len(unicode)-2:])
+}
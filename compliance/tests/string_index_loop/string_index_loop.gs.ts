@@ -0,0 +1,70 @@
+// Generated file based on string_index_loop.go
+// Updated when compliance tests are re-run, DO NOT EDIT!
+
+import * as $ from "@goscript/builtin/index.js";
+
+import * as strings from "@goscript/strings/index.js"
+
+// tokenize splits src into words, numbers and punctuation by indexing its
+// bytes, as hand-written lexers do. It returns the number of tokens and the
+// first and last of them.
+export function tokenize(src: string): [number, string, string] {
+	let n = 0
+	let [first, last] = ["", ""]
+	let emit = (tok: string): void => {
+		if (n == 0) {
+			first = tok
+		}
+		last = tok
+		n++
+	}
+	for (let i = 0; i < $.len(src); ) {
+		let c = $.indexString(src, i)
+		switch (true) {
+			case c == 32 || c == 10:
+				i++
+				break
+			case c >= 48 && c <= 57:
+				let num = i
+				for (; i < $.len(src) && $.indexString(src, i) >= 48 && $.indexString(src, i) <= 57; ) {
+					i++
+				}
+				emit!($.sliceString(src, num, i))
+				break
+			case c >= 97 && c <= 122 || c >= 0x80:
+				let word = i
+				for (; i < $.len(src) && ($.indexString(src, i) >= 97 && $.indexString(src, i) <= 122 || $.indexString(src, i) >= 0x80); ) {
+					i++
+				}
+				emit!($.sliceString(src, word, i))
+				break
+			default:
+				emit!($.sliceString(src, i, i + 1))
+				i++
+				break
+		}
+	}
+	return [n, first, last]
+}
+
+export async function main(): Promise<void> {
+	// Large inputs would take minutes if each len or index re-encoded the
+	// whole string.
+	let ascii = strings.Repeat("let x1 = 42 + y;\n", 20000)
+	let [n, first, last] = tokenize(ascii)
+	console.log("ascii:", $.len(ascii), n, first, last)
+
+	let unicode = strings.Repeat("café 12 naïve, ", 20000)
+	;[n, first, last] = tokenize(unicode)
+	console.log("unicode:", $.len(unicode), n, first, last)
+
+	let sum = 0
+	for (let i = 0; i < $.len(unicode); i++) {
+		sum += $.int($.indexString(unicode, i))
+	}
+	console.log("sum:", sum)
+
+	// Slices of cached strings are exact too.
+	console.log($.sliceString(unicode, 3, 5) == "é", $.len($.sliceString(unicode, undefined, 4)), $.sliceString(unicode, $.len(unicode) - 2, undefined))
+}
+
@@ -0,0 +1,74 @@
+package main
+
+import "fmt"
+
+func main() {
+	// Converting arbitrary bytes to a string and back keeps every byte.
+	b := []byte{0xff, 'a', 0xe2, 0x82, 0x00, 0xc0, 0x80}
+	s := string(b)
+	println("len:", len(s), "s[0]:", s[0], "s[2]:", s[2])
+	back := []byte(s)
+	println("round trip:", len(back) == len(b))
+	for i := range back {
+		if back[i] != b[i] {
+			println("mismatch at", i)
+		}
+	}
+
+	// Literals with byte escapes.
+	lit := "\xff\xfe\x41\377"
+	println("literal len:", len(lit), lit[0], lit[1], lit[2], lit[3])
+	println("equal:", lit == string([]byte{0xff, 0xfe, 'A', 0xff}))
+
+	// Slicing inside a UTF-8 sequence.
+	euro := "€uro"
+	head := euro[:1]
+	tail := euro[1:3]
+	println("head:", len(head), head[0], "tail:", len(tail), tail[0], tail[1])
+	println("rest:", euro[3:])
+	joined := []byte(head + tail)
+	println("joined:", string(joined) == "€", len(joined))
+
+	// Concatenating the bytes of a split UTF-8 sequence gives the rune.
+	println("concat:", euro[:1]+euro[1:] == euro, head+tail+euro[3:] == euro)
+	split := map[string]int{euro: 1}
+	k := euro[:2]
+	k += euro[2:]
+	println("map key:", split[k], split[euro[:1]+euro[1:]], len(k))
+	built := ""
+	for i := 0; i < len(euro); i++ {
+		built += euro[i : i+1]
+	}
+	println("built:", built == euro, len(built), built)
+	bad := "\xe2" + euro[1:3] + "\xff"
+	println("bad:", bad == "€\xff", len(bad))
+
+	// Ranging yields byte offsets and RuneError for invalid bytes.
+	for i, r := range "a\xffé😀\xe2\x82!" {
+		println(i, r)
+	}
+	n := 0
+	for range "h\xe9llo" {
+		n++
+	}
+	println("runes:", n)
+
+	// Rune conversions.
+	runes := []rune("x\xffy")
+	println(len(runes), runes[0], runes[1], runes[2])
+	println(string(rune(0x1F600)) == "😀", len(string(rune(0x1F600))))
+	println(len(string(rune(0xD800))), string(rune(-1)) == "�")
+	println(string([]rune{0x1F600, 0x110000, 'z'}) == "😀�z")
+
+	// Binary map keys.
+	m := map[string]int{}
+	m[string([]byte{0x00, 0xff})] = 1
+	m[string([]byte{0x00, 0xfe})] = 2
+	println(len(m), m["\x00\xff"], m[string([]byte{0x00, 0xfe})])
+
+	// Copying a string into a byte slice.
+	dst := make([]byte, 3)
+	println("copied:", copy(dst, "\xff€"), dst[0], dst[1], dst[2])
+
+	fmt.Println(len("\xff"), []byte("\xfe\xff"))
+}
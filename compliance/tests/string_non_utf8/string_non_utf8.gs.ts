@@ -0,0 +1,84 @@
+// Generated file based on string_non_utf8.go
+// Updated when compliance tests are re-run, DO NOT EDIT!
+
+import * as $ from "@goscript/builtin/index.js";
+
+import * as fmt from "@goscript/fmt/index.js"
+
+export async function main(): Promise<void> {
+	// Converting arbitrary bytes to a string and back keeps every byte.
+	let b = new Uint8Array([0xff, 97, 0xe2, 0x82, 0x00, 0xc0, 0x80])
+	let s = $.bytesToString(b)
+	console.log("len:", $.len(s), "s[0]:", $.indexString(s, 0), "s[2]:", $.indexString(s, 2))
+	let back = $.stringToBytes(s)
+	console.log("round trip:", $.len(back) == $.len(b))
+	for (let i = 0; i < $.len(back); i++) {
+		{
+			if (back![i] != b![i]) {
+				console.log("mismatch at", i)
+			}
+		}
+	}
+
+	// Literals with byte escapes.
+	let lit = "\udcff\udcfeA\udcff"
+	console.log("literal len:", $.len(lit), $.indexString(lit, 0), $.indexString(lit, 1), $.indexString(lit, 2), $.indexString(lit, 3))
+	console.log("equal:", lit == $.bytesToString(new Uint8Array([0xff, 0xfe, 65, 0xff])))
+
+	// Slicing inside a UTF-8 sequence.
+	let euro = "€uro"
+	let head = $.sliceString(euro, undefined, 1)
+	let tail = $.sliceString(euro, 1, 3)
+	console.log("head:", $.len(head), $.indexString(head, 0), "tail:", $.len(tail), $.indexString(tail, 0), $.indexString(tail, 1))
+	console.log("rest:", $.sliceString(euro, 3, undefined))
+	let joined = $.stringToBytes($.concatString(head, tail))
+	console.log("joined:", $.bytesToString(joined) == "€", $.len(joined))
+
+	// Concatenating the bytes of a split UTF-8 sequence gives the rune.
+	console.log("concat:", $.concatString($.sliceString(euro, undefined, 1), $.sliceString(euro, 1, undefined)) == euro, $.concatString($.concatString(head, tail), $.sliceString(euro, 3, undefined)) == euro)
+	let split = new Map([[euro, 1]])
+	let k = $.sliceString(euro, undefined, 2)
+	k = $.concatString(k, $.sliceString(euro, 2, undefined))
+	console.log("map key:", $.mapGet(split, k, 0)[0], $.mapGet(split, $.concatString($.sliceString(euro, undefined, 1), $.sliceString(euro, 1, undefined)), 0)[0], $.len(k))
+	let built = ""
+	for (let i = 0; i < $.len(euro); i++) {
+		built = $.concatString(built, $.sliceString(euro, i, i + 1))
+	}
+	console.log("built:", built == euro, $.len(built), built)
+	let bad = $.concatString($.concatString("\udce2", $.sliceString(euro, 1, 3)), "\udcff")
+	console.log("bad:", bad == "€\udcff", $.len(bad))
+
+	// Ranging yields byte offsets and RuneError for invalid bytes.
+	for (const [i, r] of $.rangeString("a\udcffé😀\udce2\udc82!")) {
+		{
+			console.log(i, r)
+		}
+	}
+	let n = 0
+	for (const [_i, _r] of $.rangeString("h\udce9llo")) {
+		{
+			n++
+		}
+	}
+	console.log("runes:", n)
+
+	// Rune conversions.
+	let runes = $.stringToRunes("x\udcffy")
+	console.log($.len(runes), runes![0], runes![1], runes![2])
+	console.log($.runeOrStringToString(0x1F600) == "😀", $.len($.runeOrStringToString(0x1F600)))
+	console.log($.len($.runeOrStringToString(0xD800)), $.runeOrStringToString(-1) == "�")
+	console.log($.runesToString($.arrayToSlice<number>([0x1F600, 0x110000, 122])) == "😀�z")
+
+	// Binary map keys.
+	let m = new Map([])
+	$.mapSet(m, $.bytesToString(new Uint8Array([0x00, 0xff])), 1)
+	$.mapSet(m, $.bytesToString(new Uint8Array([0x00, 0xfe])), 2)
+	console.log($.len(m), $.mapGet(m, "\x00\udcff", 0)[0], $.mapGet(m, $.bytesToString(new Uint8Array([0x00, 0xfe])), 0)[0])
+
+	// Copying a string into a byte slice.
+	let dst = new Uint8Array(3)
+	console.log("copied:", $.copy(dst, "\udcff€"), dst![0], dst![1], dst![2])
+
+	fmt.Println($.len("\udcff"), $.stringToBytes("\udcfe\udcff"))
+}
+
@@ -0,0 +1 @@
+export { Packet } from "./struct_blank_fields.gs.js"
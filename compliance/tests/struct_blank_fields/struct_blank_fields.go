@@ -0,0 +1,35 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"reflect"
+)
+
+// Packet has blank fields, which take up space but have no storage.
+type Packet struct {
+	Kind uint8
+	_    [3]uint8
+	Len  uint32
+	_    int
+}
+
+func main() {
+	p := Packet{Kind: 1, Len: 8}
+	fmt.Println(p)
+	fmt.Printf("%+v\n", p)
+
+	t := reflect.TypeOf(p)
+	fmt.Println(t.NumField())
+	for i := 0; i < t.NumField(); i++ {
+		f := t.Field(i)
+		fmt.Println(i, f.Name, f.Type, f.Index)
+	}
+
+	v := reflect.ValueOf(p)
+	fmt.Println(v.Field(1).Len(), v.Field(1).Index(0).Uint(), v.Field(3).Int())
+	fmt.Println(v.Field(2).Uint(), v.IsZero(), reflect.ValueOf(Packet{}).IsZero())
+
+	b, err := json.Marshal(p)
+	fmt.Println(string(b), err)
+}
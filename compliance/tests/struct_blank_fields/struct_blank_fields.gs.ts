@@ -0,0 +1,77 @@
+// Generated file based on struct_blank_fields.go
+// Updated when compliance tests are re-run, DO NOT EDIT!
+
+import * as $ from "@goscript/builtin/index.js";
+
+import * as json from "@goscript/encoding/json/index.js"
+
+import * as fmt from "@goscript/fmt/index.js"
+
+import * as reflect from "@goscript/reflect/index.js"
+
+export class Packet {
+	public get Kind(): number {
+		return this._fields.Kind.value
+	}
+	public set Kind(value: number) {
+		this._fields.Kind.value = value
+	}
+
+	public get Len(): number {
+		return this._fields.Len.value
+	}
+	public set Len(value: number) {
+		this._fields.Len.value = value
+	}
+
+	public _fields: {
+		Kind: $.VarRef<number>;
+		Len: $.VarRef<number>;
+	}
+
+	constructor(init?: Partial<{Kind?: number, Len?: number}>) {
+		this._fields = {
+			Kind: $.varRef(init?.Kind ?? 0),
+			Len: $.varRef(init?.Len ?? 0)
+		}
+	}
+
+	public clone(): Packet {
+		const cloned = new Packet()
+		cloned._fields = {
+			Kind: $.varRef(this._fields.Kind.value),
+			Len: $.varRef(this._fields.Len.value)
+		}
+		return cloned
+	}
+
+	// Register this type with the runtime type system
+	static __typeInfo = $.registerStructType(
+	  'main@github.com/aperturerobotics/goscript/compliance/tests/struct_blank_fields.Packet',
+	  new Packet(),
+	  [],
+	  Packet,
+	  [{ name: "Kind", type: { kind: $.TypeKind.Basic, name: "uint8" } }, { name: "_", type: { kind: $.TypeKind.Array, length: 3, elemType: { kind: $.TypeKind.Basic, name: "uint8" } } }, { name: "Len", type: { kind: $.TypeKind.Basic, name: "uint32" } }, { name: "_", type: { kind: $.TypeKind.Basic, name: "int" } }]
+	);
+}
+
+export async function main(): Promise<void> {
+	let p = new Packet({Kind: 1, Len: 8})
+	fmt.Println($.markStructValue(p.clone()))
+	fmt.Printf("%+v\n", $.markStructValue(p.clone()))
+
+	let t = reflect.TypeOf($.markStructValue(p.clone()), "main@github.com/aperturerobotics/goscript/compliance/tests/struct_blank_fields.Packet")
+	fmt.Println(t!.NumField())
+	for (let i = 0; i < t!.NumField(); i++) {
+		let f = t!.Field(i).clone()
+		fmt.Println(i, f.Name, f.Type, f.Index)
+	}
+
+	let v = reflect.ValueOf($.markStructValue(p.clone()), "main@github.com/aperturerobotics/goscript/compliance/tests/struct_blank_fields.Packet").clone()
+	fmt.Println(v.Field(1)!.Len(), v.Field(1)!.Index(0)!.Uint(), v.Field(3)!.Int())
+	fmt.Println(v.Field(2)!.Uint(), v.IsZero(), reflect.ValueOf($.markStructValue(new Packet({})), "main@github.com/aperturerobotics/goscript/compliance/tests/struct_blank_fields.Packet")!.IsZero())
+
+	let [b, err] = json.Marshal($.markStructValue(p.clone()))
+	fmt.Println($.bytesToString(b), err)
+}
+
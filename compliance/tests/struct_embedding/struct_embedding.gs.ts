@@ -46,11 +46,11 @@ export class Person {
 
 	// Register this type with the runtime type system
 	static __typeInfo = $.registerStructType(
-	  'Person',
+	  'main@github.com/aperturerobotics/goscript/compliance/tests/struct_embedding.Person',
 	  new Person(),
 	  [{ name: "Greet", args: [], returns: [] }],
 	  Person,
-	  {"Name": { kind: $.TypeKind.Basic, name: "string" }, "Age": { kind: $.TypeKind.Basic, name: "number" }}
+	  [{ name: "Name", type: { kind: $.TypeKind.Basic, name: "string" } }, { name: "Age", type: { kind: $.TypeKind.Basic, name: "int" } }]
 	);
 }
 
@@ -110,11 +110,11 @@ export class Employee {
 
 	// Register this type with the runtime type system
 	static __typeInfo = $.registerStructType(
-	  'Employee',
+	  'main@github.com/aperturerobotics/goscript/compliance/tests/struct_embedding.Employee',
 	  new Employee(),
 	  [],
 	  Employee,
-	  {"Person": "Person", "ID": { kind: $.TypeKind.Basic, name: "number" }}
+	  [{ name: "Person", type: "main@github.com/aperturerobotics/goscript/compliance/tests/struct_embedding.Person", embedded: true }, { name: "ID", type: { kind: $.TypeKind.Basic, name: "int" } }]
 	);
 }
 
@@ -156,16 +156,16 @@ export class Address {
 
 	public FullAddress(): string {
 		const a = this
-		return a.Street + ", " + a.City
+		return $.concatString(a.Street + ", ", a.City)
 	}
 
 	// Register this type with the runtime type system
 	static __typeInfo = $.registerStructType(
-	  'Address',
+	  'main@github.com/aperturerobotics/goscript/compliance/tests/struct_embedding.Address',
 	  new Address(),
 	  [{ name: "FullAddress", args: [], returns: [{ type: { kind: $.TypeKind.Basic, name: "string" } }] }],
 	  Address,
-	  {"Street": { kind: $.TypeKind.Basic, name: "string" }, "City": { kind: $.TypeKind.Basic, name: "string" }}
+	  [{ name: "Street", type: { kind: $.TypeKind.Basic, name: "string" } }, { name: "City", type: { kind: $.TypeKind.Basic, name: "string" } }]
 	);
 }
 
@@ -202,11 +202,11 @@ export class Contact {
 
 	// Register this type with the runtime type system
 	static __typeInfo = $.registerStructType(
-	  'Contact',
+	  'main@github.com/aperturerobotics/goscript/compliance/tests/struct_embedding.Contact',
 	  new Contact(),
 	  [{ name: "Call", args: [], returns: [] }],
 	  Contact,
-	  {"Phone": { kind: $.TypeKind.Basic, name: "string" }}
+	  [{ name: "Phone", type: { kind: $.TypeKind.Basic, name: "string" } }]
 	);
 }
 
@@ -315,11 +315,11 @@ export class Manager {
 
 	// Register this type with the runtime type system
 	static __typeInfo = $.registerStructType(
-	  'Manager',
+	  'main@github.com/aperturerobotics/goscript/compliance/tests/struct_embedding.Manager',
 	  new Manager(),
 	  [],
 	  Manager,
-	  {"Person": "Person", "Address": "Address", "Contact": "Contact", "Level": { kind: $.TypeKind.Basic, name: "number" }}
+	  [{ name: "Person", type: "main@github.com/aperturerobotics/goscript/compliance/tests/struct_embedding.Person", embedded: true }, { name: "Address", type: "main@github.com/aperturerobotics/goscript/compliance/tests/struct_embedding.Address", embedded: true }, { name: "Contact", type: "main@github.com/aperturerobotics/goscript/compliance/tests/struct_embedding.Contact", embedded: true }, { name: "Level", type: { kind: $.TypeKind.Basic, name: "int" } }]
 	);
 }
 
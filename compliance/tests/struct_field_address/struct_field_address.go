@@ -0,0 +1,39 @@
+package main
+
+type counter struct {
+	Name  string
+	Count int
+}
+
+func bump(n *int) {
+	*n += 10
+}
+
+func setName(s *string, v string) {
+	*s = v
+}
+
+func main() {
+	// Variables declared together without initializers get zero values
+	var a, b int
+	var x, y string
+	println(a, b, x == "", y == "")
+
+	// Pointers to struct fields alias the field
+	c := counter{Name: "a", Count: 1}
+	p := &c.Count
+	*p = 5
+	println(c.Count)
+	bump(&c.Count)
+	println(c.Count, *p)
+
+	// Through a pointer to the struct
+	cp := &counter{Name: "b"}
+	setName(&cp.Name, "renamed")
+	bump(&cp.Count)
+	println(cp.Name, cp.Count)
+
+	// Field writes are seen through the pointer
+	c.Count = 42
+	println(*p)
+}
@@ -0,0 +1,86 @@
+// Generated file based on struct_field_address.go
+// Updated when compliance tests are re-run, DO NOT EDIT!
+
+import * as $ from "@goscript/builtin/index.js";
+
+export class counter {
+	public get Name(): string {
+		return this._fields.Name.value
+	}
+	public set Name(value: string) {
+		this._fields.Name.value = value
+	}
+
+	public get Count(): number {
+		return this._fields.Count.value
+	}
+	public set Count(value: number) {
+		this._fields.Count.value = value
+	}
+
+	public _fields: {
+		Name: $.VarRef<string>;
+		Count: $.VarRef<number>;
+	}
+
+	constructor(init?: Partial<{Count?: number, Name?: string}>) {
+		this._fields = {
+			Name: $.varRef(init?.Name ?? ""),
+			Count: $.varRef(init?.Count ?? 0)
+		}
+	}
+
+	public clone(): counter {
+		const cloned = new counter()
+		cloned._fields = {
+			Name: $.varRef(this._fields.Name.value),
+			Count: $.varRef(this._fields.Count.value)
+		}
+		return cloned
+	}
+
+	// Register this type with the runtime type system
+	static __typeInfo = $.registerStructType(
+	  'main@github.com/aperturerobotics/goscript/compliance/tests/struct_field_address.counter',
+	  new counter(),
+	  [],
+	  counter,
+	  [{ name: "Name", type: { kind: $.TypeKind.Basic, name: "string" } }, { name: "Count", type: { kind: $.TypeKind.Basic, name: "int" } }]
+	);
+}
+
+export function bump(n: $.VarRef<number> | null): void {
+	n!.value += 10
+}
+
+export function setName(s: $.VarRef<string> | null, v: string): void {
+	s!.value = v
+}
+
+export async function main(): Promise<void> {
+	// Variables declared together without initializers get zero values
+	let a: number = 0
+	let b: number = 0
+	let x: string = ""
+	let y: string = ""
+	console.log(a, b, x == "", y == "")
+
+	// Pointers to struct fields alias the field
+	let c = new counter({Count: 1, Name: "a"})
+	let p = c._fields.Count
+	p!.value = 5
+	console.log(c.Count)
+	bump(c._fields.Count)
+	console.log(c.Count, p!.value)
+
+	// Through a pointer to the struct
+	let cp = new counter({Name: "b"})
+	setName(cp!._fields.Name, "renamed")
+	bump(cp!._fields.Count)
+	console.log(cp!.Name, cp!.Count)
+
+	// Field writes are seen through the pointer
+	c.Count = 42
+	console.log(p!.value)
+}
+
@@ -8,7 +8,7 @@ export type MyInterface = null | {
 }
 
 $.registerInterfaceType(
-  'MyInterface',
+  'main@github.com/aperturerobotics/goscript/compliance/tests/struct_pointer_interface_fields.MyInterface',
   null, // Zero value for interface is null
   [{ name: "Method", args: [], returns: [] }]
 );
@@ -51,11 +51,11 @@ export class MyStruct {
 
 	// Register this type with the runtime type system
 	static __typeInfo = $.registerStructType(
-	  'MyStruct',
+	  'main@github.com/aperturerobotics/goscript/compliance/tests/struct_pointer_interface_fields.MyStruct',
 	  new MyStruct(),
 	  [],
 	  MyStruct,
-	  {"PointerField": { kind: $.TypeKind.Pointer, elemType: { kind: $.TypeKind.Basic, name: "number" } }, "interfaceField": "MyInterface"}
+	  [{ name: "PointerField", type: { kind: $.TypeKind.Pointer, elemType: { kind: $.TypeKind.Basic, name: "int" } } }, { name: "interfaceField", type: "main@github.com/aperturerobotics/goscript/compliance/tests/struct_pointer_interface_fields.MyInterface" }]
 	);
 }
 
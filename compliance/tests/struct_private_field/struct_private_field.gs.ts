@@ -31,11 +31,11 @@ export class MyStruct {
 
 	// Register this type with the runtime type system
 	static __typeInfo = $.registerStructType(
-	  'MyStruct',
+	  'main@github.com/aperturerobotics/goscript/compliance/tests/struct_private_field.MyStruct',
 	  new MyStruct(),
 	  [],
 	  MyStruct,
-	  {"myPrivate": { kind: $.TypeKind.Basic, name: "number" }}
+	  [{ name: "myPrivate", type: { kind: $.TypeKind.Basic, name: "int" } }]
 	);
 }
 
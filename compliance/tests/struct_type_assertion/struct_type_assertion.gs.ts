@@ -4,7 +4,7 @@
 import * as $ from "@goscript/builtin/index.js";
 
 export async function main(): Promise<void> {
-	let i: null | any = {Name: "Alice", Number: 8005553424}
+	let i: null | any = $.markStructValue({Name: "Alice", Number: 8005553424})
 
 	let { value: s, ok: ok } = $.typeAssert<{ Name?: string; Number?: number }>(i, {kind: $.TypeKind.Struct, fields: {'Name': {kind: $.TypeKind.Basic, name: 'string'}, 'Number': {kind: $.TypeKind.Basic, name: 'number'}}, methods: []})
 	if (ok) {
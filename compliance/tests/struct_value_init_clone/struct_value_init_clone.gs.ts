@@ -41,11 +41,11 @@ export class Point {
 
 	// Register this type with the runtime type system
 	static __typeInfo = $.registerStructType(
-	  'Point',
+	  'main@github.com/aperturerobotics/goscript/compliance/tests/struct_value_init_clone.Point',
 	  new Point(),
 	  [],
 	  Point,
-	  {"X": { kind: $.TypeKind.Basic, name: "number" }, "Y": { kind: $.TypeKind.Basic, name: "number" }}
+	  [{ name: "X", type: { kind: $.TypeKind.Basic, name: "int" } }, { name: "Y", type: { kind: $.TypeKind.Basic, name: "int" } }]
 	);
 }
 
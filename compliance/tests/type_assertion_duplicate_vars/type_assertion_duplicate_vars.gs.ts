@@ -8,7 +8,7 @@ export type Interface = null | {
 }
 
 $.registerInterfaceType(
-  'Interface',
+  'main@github.com/aperturerobotics/goscript/compliance/tests/type_assertion_duplicate_vars.Interface',
   null, // Zero value for interface is null
   [{ name: "Method", args: [], returns: [{ type: { kind: $.TypeKind.Basic, name: "string" } }] }]
 );
@@ -34,11 +34,11 @@ export class ConcreteA {
 
 	// Register this type with the runtime type system
 	static __typeInfo = $.registerStructType(
-	  'ConcreteA',
+	  'main@github.com/aperturerobotics/goscript/compliance/tests/type_assertion_duplicate_vars.ConcreteA',
 	  new ConcreteA(),
 	  [{ name: "Method", args: [], returns: [{ type: { kind: $.TypeKind.Basic, name: "string" } }] }],
 	  ConcreteA,
-	  {}
+	  []
 	);
 }
 
@@ -63,11 +63,11 @@ export class ConcreteB {
 
 	// Register this type with the runtime type system
 	static __typeInfo = $.registerStructType(
-	  'ConcreteB',
+	  'main@github.com/aperturerobotics/goscript/compliance/tests/type_assertion_duplicate_vars.ConcreteB',
 	  new ConcreteB(),
 	  [{ name: "Method", args: [], returns: [{ type: { kind: $.TypeKind.Basic, name: "string" } }] }],
 	  ConcreteB,
-	  {}
+	  []
 	);
 }
 
@@ -109,27 +109,27 @@ export class Container {
 
 	// Register this type with the runtime type system
 	static __typeInfo = $.registerStructType(
-	  'Container',
+	  'main@github.com/aperturerobotics/goscript/compliance/tests/type_assertion_duplicate_vars.Container',
 	  new Container(),
 	  [],
 	  Container,
-	  {"hasA": { kind: $.TypeKind.Basic, name: "boolean" }, "hasB": { kind: $.TypeKind.Basic, name: "boolean" }}
+	  [{ name: "hasA", type: { kind: $.TypeKind.Basic, name: "bool" } }, { name: "hasB", type: { kind: $.TypeKind.Basic, name: "bool" } }]
 	);
 }
 
 export async function main(): Promise<void> {
-	let iface: Interface = new ConcreteA({})
+	let iface: Interface = $.markStructValue(new ConcreteA({}))
 
 	let c = new Container({})
 
 	// Multiple type assertions that should generate unique variable names
 	let _gs_ta_val_e051: ConcreteA
 	let _gs_ta_ok_e051: boolean
-	({ value: _gs_ta_val_e051, ok: _gs_ta_ok_e051 } = $.typeAssert<ConcreteA>(iface, 'ConcreteA'))
+	({ value: _gs_ta_val_e051, ok: _gs_ta_ok_e051 } = $.typeAssert<ConcreteA>(iface, 'main@github.com/aperturerobotics/goscript/compliance/tests/type_assertion_duplicate_vars.ConcreteA'))
 	c.hasA = _gs_ta_ok_e051
 	let _gs_ta_val_d761: ConcreteB
 	let _gs_ta_ok_d761: boolean
-	({ value: _gs_ta_val_d761, ok: _gs_ta_ok_d761 } = $.typeAssert<ConcreteB>(iface, 'ConcreteB'))
+	({ value: _gs_ta_val_d761, ok: _gs_ta_ok_d761 } = $.typeAssert<ConcreteB>(iface, 'main@github.com/aperturerobotics/goscript/compliance/tests/type_assertion_duplicate_vars.ConcreteB'))
 	c.hasB = _gs_ta_ok_d761
 
 	console.log("hasA:", c.hasA)
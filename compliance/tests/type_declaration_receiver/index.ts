@@ -1 +1,2 @@
+export { CustomString_Length, CustomString_Upper, FileMode_Add, FileMode_IsZero, FileMode_String } from "./type_declaration_receiver.gs.js"
 export type { CustomString, FileMode } from "./type_declaration_receiver.gs.js"
@@ -21,6 +21,14 @@ export function FileMode_Add(fm: FileMode, val: number): FileMode {
 }
 
 
+$.registerNamedType(
+  'main@github.com/aperturerobotics/goscript/compliance/tests/type_declaration_receiver.FileMode',
+  0,
+  [{ name: "String", args: [], returns: [{ type: { kind: $.TypeKind.Basic, name: "string" } }] }, { name: "IsZero", args: [], returns: [{ type: { kind: $.TypeKind.Basic, name: "boolean" } }] }, { name: "Add", args: [{ name: "val", type: { kind: $.TypeKind.Basic, name: "number" } }], returns: [{ type: "main@github.com/aperturerobotics/goscript/compliance/tests/type_declaration_receiver.FileMode" }] }],
+  { kind: $.TypeKind.Basic, name: "int" },
+  { String: FileMode_String, IsZero: FileMode_IsZero, Add: FileMode_Add }
+);
+
 export type CustomString = string;
 
 export function CustomString_Length(cs: CustomString): number {
@@ -30,17 +38,13 @@ export function CustomString_Length(cs: CustomString): number {
 export function CustomString_Upper(cs: CustomString): string {
 	let s = cs
 	let result = ""
-	{
-		const _runes = $.stringToRunes(s)
-		for (let i = 0; i < _runes.length; i++) {
-			const r = _runes[i]
-			{
-				if (r >= 97 && r <= 122) {
-					result += $.runeOrStringToString(r - 32)
-				}
-				 else {
-					result += $.runeOrStringToString(r)
-				}
+	for (const [_i, r] of $.rangeString(s)) {
+		{
+			if (r >= 97 && r <= 122) {
+				result = $.concatString(result, $.runeOrStringToString(((r - 32) | 0)))
+			}
+			 else {
+				result = $.concatString(result, $.runeOrStringToString(r))
 			}
 		}
 	}
@@ -48,6 +52,14 @@ export function CustomString_Upper(cs: CustomString): string {
 }
 
 
+$.registerNamedType(
+  'main@github.com/aperturerobotics/goscript/compliance/tests/type_declaration_receiver.CustomString',
+  "",
+  [{ name: "Length", args: [], returns: [{ type: { kind: $.TypeKind.Basic, name: "number" } }] }, { name: "Upper", args: [], returns: [{ type: { kind: $.TypeKind.Basic, name: "string" } }] }],
+  { kind: $.TypeKind.Basic, name: "string" },
+  { Length: CustomString_Length, Upper: CustomString_Upper }
+);
+
 export async function main(): Promise<void> {
 	// Test FileMode type with receiver methods
 	let fm: FileMode = 0
@@ -41,11 +41,11 @@ export class file {
 
 	// Register this type with the runtime type system
 	static __typeInfo = $.registerStructType(
-	  'file',
+	  'main@github.com/aperturerobotics/goscript/compliance/tests/type_missing_imports.file',
 	  new file(),
 	  [],
 	  file,
-	  {"name": { kind: $.TypeKind.Basic, name: "string" }, "data": { kind: $.TypeKind.Slice, elemType: { kind: $.TypeKind.Basic, name: "number" } }}
+	  [{ name: "name", type: { kind: $.TypeKind.Basic, name: "string" } }, { name: "data", type: { kind: $.TypeKind.Slice, elemType: { kind: $.TypeKind.Basic, name: "byte" } } }]
 	);
 }
 
@@ -87,11 +87,11 @@ export class storage {
 
 	// Register this type with the runtime type system
 	static __typeInfo = $.registerStructType(
-	  'storage',
+	  'main@github.com/aperturerobotics/goscript/compliance/tests/type_missing_imports.storage',
 	  new storage(),
 	  [],
 	  storage,
-	  {"files": { kind: $.TypeKind.Map, keyType: { kind: $.TypeKind.Basic, name: "string" }, elemType: { kind: $.TypeKind.Pointer, elemType: "file" } }, "children": { kind: $.TypeKind.Map, keyType: { kind: $.TypeKind.Basic, name: "string" }, elemType: { kind: $.TypeKind.Map, keyType: { kind: $.TypeKind.Basic, name: "string" }, elemType: { kind: $.TypeKind.Pointer, elemType: "file" } } }}
+	  [{ name: "files", type: { kind: $.TypeKind.Map, keyType: { kind: $.TypeKind.Basic, name: "string" }, elemType: { kind: $.TypeKind.Pointer, elemType: "main@github.com/aperturerobotics/goscript/compliance/tests/type_missing_imports.file" } } }, { name: "children", type: { kind: $.TypeKind.Map, keyType: { kind: $.TypeKind.Basic, name: "string" }, elemType: { kind: $.TypeKind.Map, keyType: { kind: $.TypeKind.Basic, name: "string" }, elemType: { kind: $.TypeKind.Pointer, elemType: "main@github.com/aperturerobotics/goscript/compliance/tests/type_missing_imports.file" } } } }]
 	);
 }
 
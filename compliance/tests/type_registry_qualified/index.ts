@@ -0,0 +1,2 @@
+export { Config } from "./type_registry_qualified.gs.js"
+export type { Names, Status } from "./type_registry_qualified.gs.js"
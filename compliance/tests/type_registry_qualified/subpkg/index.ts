@@ -0,0 +1,3 @@
+export { Level_String, New } from "./subpkg.gs.js"
+export { Config } from "./subpkg.gs.js"
+export type { Level } from "./subpkg.gs.js"
@@ -0,0 +1,18 @@
+package subpkg
+
+// Config has the same name as a type of the main package.
+type Config struct {
+	Addr string
+}
+
+// Level is a named basic type of this package.
+type Level int
+
+func (l Level) String() string {
+	return "level"
+}
+
+// New returns a new Config as an empty interface.
+func New(addr string) any {
+	return Config{Addr: addr}
+}
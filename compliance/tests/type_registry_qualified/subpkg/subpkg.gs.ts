@@ -0,0 +1,61 @@
+// Generated file based on subpkg/subpkg.go
+// Updated when compliance tests are re-run, DO NOT EDIT!
+
+import * as $ from "@goscript/builtin/index.js";
+
+export class Config {
+	public get Addr(): string {
+		return this._fields.Addr.value
+	}
+	public set Addr(value: string) {
+		this._fields.Addr.value = value
+	}
+
+	public _fields: {
+		Addr: $.VarRef<string>;
+	}
+
+	constructor(init?: Partial<{Addr?: string}>) {
+		this._fields = {
+			Addr: $.varRef(init?.Addr ?? "")
+		}
+	}
+
+	public clone(): Config {
+		const cloned = new Config()
+		cloned._fields = {
+			Addr: $.varRef(this._fields.Addr.value)
+		}
+		return cloned
+	}
+
+	// Register this type with the runtime type system
+	static __typeInfo = $.registerStructType(
+	  'github.com/aperturerobotics/goscript/compliance/tests/type_registry_qualified/subpkg.Config',
+	  new Config(),
+	  [],
+	  Config,
+	  [{ name: "Addr", type: { kind: $.TypeKind.Basic, name: "string" } }]
+	);
+}
+
+export type Level = number;
+
+export function Level_String(l: Level): string {
+	return "level"
+}
+
+
+$.registerNamedType(
+  'github.com/aperturerobotics/goscript/compliance/tests/type_registry_qualified/subpkg.Level',
+  0,
+  [{ name: "String", args: [], returns: [{ type: { kind: $.TypeKind.Basic, name: "string" } }] }],
+  { kind: $.TypeKind.Basic, name: "int" },
+  { String: Level_String }
+);
+
+// New returns a new Config as an empty interface.
+export function New(addr: string): null | any {
+	return $.markStructValue(new Config({Addr: addr}))
+}
+
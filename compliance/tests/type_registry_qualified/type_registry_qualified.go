@@ -0,0 +1,63 @@
+package main
+
+import (
+	"io/fs"
+	"reflect"
+
+	"github.com/aperturerobotics/goscript/compliance/tests/type_registry_qualified/subpkg"
+)
+
+// Config has the same name as a type of subpkg.
+type Config struct {
+	Name string
+}
+
+type Status int
+
+type Names []string
+
+func describe(v any) string {
+	desc := "other"
+	switch c := v.(type) {
+	case subpkg.Config:
+		desc = "subpkg.Config " + c.Addr
+	case Config:
+		desc = "main.Config " + c.Name
+	}
+	return desc
+}
+
+func main() {
+	println(describe(subpkg.New("localhost")))
+	println(describe(Config{Name: "local"}))
+	println(describe(1))
+
+	var v any = Config{Name: "x"}
+	_, ok := v.(subpkg.Config)
+	println("main config is subpkg config:", ok)
+	c, ok := v.(Config)
+	println("main config is main config:", ok, c.Name)
+
+	// Named basic types
+	var s any = Status(3)
+	st, ok := s.(Status)
+	println("status:", ok, int(st))
+	var l any = subpkg.Level(2)
+	lv, ok := l.(subpkg.Level)
+	println("level:", ok, int(lv), lv.String())
+	var n any = Names{"a", "b"}
+	names, ok := n.(Names)
+	println("names:", ok, len(names))
+
+	// Types of other packages
+	var m any = fs.ModeDir
+	fm, ok := m.(fs.FileMode)
+	println("file mode:", ok, fm.IsDir())
+
+	// Reflection reports the package path and qualified name
+	mt := reflect.TypeOf(Config{})
+	st2 := reflect.TypeOf(subpkg.Config{})
+	println(mt.String(), mt.PkgPath())
+	println(st2.String(), st2.PkgPath())
+	println(mt == st2)
+}
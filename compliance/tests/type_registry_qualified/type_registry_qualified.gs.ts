@@ -0,0 +1,116 @@
+// Generated file based on type_registry_qualified.go
+// Updated when compliance tests are re-run, DO NOT EDIT!
+
+import * as $ from "@goscript/builtin/index.js";
+
+import * as fs from "@goscript/io/fs/index.js"
+
+import * as reflect from "@goscript/reflect/index.js"
+
+import * as subpkg from "@goscript/github.com/aperturerobotics/goscript/compliance/tests/type_registry_qualified/subpkg/index.js"
+
+export class Config {
+	public get Name(): string {
+		return this._fields.Name.value
+	}
+	public set Name(value: string) {
+		this._fields.Name.value = value
+	}
+
+	public _fields: {
+		Name: $.VarRef<string>;
+	}
+
+	constructor(init?: Partial<{Name?: string}>) {
+		this._fields = {
+			Name: $.varRef(init?.Name ?? "")
+		}
+	}
+
+	public clone(): Config {
+		const cloned = new Config()
+		cloned._fields = {
+			Name: $.varRef(this._fields.Name.value)
+		}
+		return cloned
+	}
+
+	// Register this type with the runtime type system
+	static __typeInfo = $.registerStructType(
+	  'main@github.com/aperturerobotics/goscript/compliance/tests/type_registry_qualified.Config',
+	  new Config(),
+	  [],
+	  Config,
+	  [{ name: "Name", type: { kind: $.TypeKind.Basic, name: "string" } }]
+	);
+}
+
+export type Status = number;
+
+$.registerNamedType(
+  'main@github.com/aperturerobotics/goscript/compliance/tests/type_registry_qualified.Status',
+  0,
+  [],
+  { kind: $.TypeKind.Basic, name: "int" }
+);
+
+export type Names = $.Slice<string>;
+
+$.registerNamedType(
+  'main@github.com/aperturerobotics/goscript/compliance/tests/type_registry_qualified.Names',
+  null,
+  [],
+  { kind: $.TypeKind.Slice, elemType: { kind: $.TypeKind.Basic, name: "string" } }
+);
+
+export function describe(v: null | any): string {
+	let desc = "other"
+	$.typeSwitch(v, [{ types: ['github.com/aperturerobotics/goscript/compliance/tests/type_registry_qualified/subpkg.Config'], body: (c) => {
+		desc = "subpkg.Config " + c.Addr
+	}},
+	{ types: ['main@github.com/aperturerobotics/goscript/compliance/tests/type_registry_qualified.Config'], body: (c) => {
+		desc = "main.Config " + c.Name
+	}}])
+	return desc
+}
+
+export async function main(): Promise<void> {
+	console.log(describe(subpkg.New("localhost")))
+	console.log(describe($.markStructValue(new Config({Name: "local"}))))
+	console.log(describe(1))
+
+	let v: null | any = $.markStructValue(new Config({Name: "x"}))
+	let { ok: ok } = $.typeAssert<subpkg.Config>(v, 'github.com/aperturerobotics/goscript/compliance/tests/type_registry_qualified/subpkg.Config')
+	console.log("main config is subpkg config:", ok)
+	let c: Config
+	({ value: c, ok: ok } = $.typeAssert<Config>(v, 'main@github.com/aperturerobotics/goscript/compliance/tests/type_registry_qualified.Config'))
+	console.log("main config is main config:", ok, c.Name)
+
+	// Named basic types
+	let s: null | any = (3 as Status)
+	let st: Status
+	({ value: st, ok: ok } = $.typeAssert<Status>(s, 'main@github.com/aperturerobotics/goscript/compliance/tests/type_registry_qualified.Status'))
+	console.log("status:", ok, $.int(st))
+	let l: null | any = $.namedValue((2 as subpkg.Level), "github.com/aperturerobotics/goscript/compliance/tests/type_registry_qualified/subpkg.Level")
+	let lv: subpkg.Level
+	({ value: lv, ok: ok } = $.typeAssert<subpkg.Level>(l, 'github.com/aperturerobotics/goscript/compliance/tests/type_registry_qualified/subpkg.Level'))
+	console.log("level:", ok, lv, subpkg.Level_String(lv))
+	let n: null | any = $.markType($.arrayToSlice<string>(["a", "b"]), "main@github.com/aperturerobotics/goscript/compliance/tests/type_registry_qualified.Names")
+	let names: Names
+	({ value: names, ok: ok } = $.typeAssert<Names>(n, 'main@github.com/aperturerobotics/goscript/compliance/tests/type_registry_qualified.Names'))
+	console.log("names:", ok, $.len(names))
+
+	// Types of other packages
+	let m: null | any = fs.ModeDir
+	let fm: fs.FileMode
+	({ value: fm, ok: ok } = $.typeAssert<fs.FileMode>(m, 'io/fs.FileMode'))
+	console.log("file mode:", ok, fs.FileMode_IsDir(fm))
+
+	// Reflection reports the package path and qualified name
+	let mt = reflect.TypeOf($.markStructValue(new Config({})), "main@github.com/aperturerobotics/goscript/compliance/tests/type_registry_qualified.Config")
+	let st2 = reflect.TypeOf($.markStructValue(new subpkg.Config({})), "github.com/aperturerobotics/goscript/compliance/tests/type_registry_qualified/subpkg.Config")
+	console.log(mt!.String(), mt!.PkgPath())
+	console.log(st2!.String(), st2!.PkgPath())
+	console.log(mt == st2)
+}
+
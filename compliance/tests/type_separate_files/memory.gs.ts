@@ -41,11 +41,11 @@ export class file {
 
 	// Register this type with the runtime type system
 	static __typeInfo = $.registerStructType(
-	  'file',
+	  'main@github.com/aperturerobotics/goscript/compliance/tests/type_separate_files.file',
 	  new file(),
 	  [],
 	  file,
-	  {"name": { kind: $.TypeKind.Basic, name: "string" }, "data": { kind: $.TypeKind.Slice, elemType: { kind: $.TypeKind.Basic, name: "number" } }}
+	  [{ name: "name", type: { kind: $.TypeKind.Basic, name: "string" } }, { name: "data", type: { kind: $.TypeKind.Slice, elemType: { kind: $.TypeKind.Basic, name: "byte" } } }]
 	);
 }
 
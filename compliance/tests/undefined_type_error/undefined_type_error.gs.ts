@@ -131,11 +131,11 @@ export class formatter {
 
 	// Register this type with the runtime type system
 	static __typeInfo = $.registerStructType(
-	  'formatter',
+	  'main@github.com/aperturerobotics/goscript/compliance/tests/undefined_type_error.formatter',
 	  new formatter(),
 	  [],
 	  formatter,
-	  {"wid": { kind: $.TypeKind.Basic, name: "number" }, "prec": { kind: $.TypeKind.Basic, name: "number" }, "widPresent": { kind: $.TypeKind.Basic, name: "boolean" }, "precPresent": { kind: $.TypeKind.Basic, name: "boolean" }, "minus": { kind: $.TypeKind.Basic, name: "boolean" }, "plus": { kind: $.TypeKind.Basic, name: "boolean" }, "sharp": { kind: $.TypeKind.Basic, name: "boolean" }, "space": { kind: $.TypeKind.Basic, name: "boolean" }, "zero": { kind: $.TypeKind.Basic, name: "boolean" }, "plusV": { kind: $.TypeKind.Basic, name: "boolean" }, "sharpV": { kind: $.TypeKind.Basic, name: "boolean" }}
+	  [{ name: "wid", type: { kind: $.TypeKind.Basic, name: "int" } }, { name: "prec", type: { kind: $.TypeKind.Basic, name: "int" } }, { name: "widPresent", type: { kind: $.TypeKind.Basic, name: "bool" } }, { name: "precPresent", type: { kind: $.TypeKind.Basic, name: "bool" } }, { name: "minus", type: { kind: $.TypeKind.Basic, name: "bool" } }, { name: "plus", type: { kind: $.TypeKind.Basic, name: "bool" } }, { name: "sharp", type: { kind: $.TypeKind.Basic, name: "bool" } }, { name: "space", type: { kind: $.TypeKind.Basic, name: "bool" } }, { name: "zero", type: { kind: $.TypeKind.Basic, name: "bool" } }, { name: "plusV", type: { kind: $.TypeKind.Basic, name: "bool" } }, { name: "sharpV", type: { kind: $.TypeKind.Basic, name: "bool" } }]
 	);
 }
 
@@ -204,11 +204,11 @@ export class printer {
 
 	// Register this type with the runtime type system
 	static __typeInfo = $.registerStructType(
-	  'printer',
+	  'main@github.com/aperturerobotics/goscript/compliance/tests/undefined_type_error.printer',
 	  new printer(),
 	  [{ name: "init", args: [], returns: [] }, { name: "format", args: [{ name: "verb", type: { kind: $.TypeKind.Basic, name: "number" } }], returns: [] }],
 	  printer,
-	  {"buf": { kind: $.TypeKind.Slice, elemType: { kind: $.TypeKind.Basic, name: "number" } }, "arg": { kind: $.TypeKind.Interface, methods: [] }, "fmt": "formatter"}
+	  [{ name: "buf", type: { kind: $.TypeKind.Slice, elemType: { kind: $.TypeKind.Basic, name: "byte" } } }, { name: "arg", type: { kind: $.TypeKind.Interface, methods: [] } }, { name: "fmt", type: "main@github.com/aperturerobotics/goscript/compliance/tests/undefined_type_error.formatter" }]
 	);
 }
 
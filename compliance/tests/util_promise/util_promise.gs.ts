@@ -112,11 +112,11 @@ export class PromiseType<T extends any> {
 
 	// Register this type with the runtime type system
 	static __typeInfo = $.registerStructType(
-	  'PromiseType',
+	  'main@github.com/aperturerobotics/goscript/compliance/tests/util_promise.Promise',
 	  new PromiseType(),
-	  [{ name: "SetResult", args: [{ name: "val", type: { kind: $.TypeKind.Interface, methods: [] } }, { name: "err", type: { kind: $.TypeKind.Interface, name: 'GoError', methods: [{ name: 'Error', args: [], returns: [{ type: { kind: $.TypeKind.Basic, name: 'string' } }] }] } }], returns: [{ type: { kind: $.TypeKind.Basic, name: "boolean" } }] }, { name: "Await", args: [{ name: "ctx", type: "Context" }], returns: [{ type: { kind: $.TypeKind.Interface, methods: [] } }, { type: { kind: $.TypeKind.Interface, name: 'GoError', methods: [{ name: 'Error', args: [], returns: [{ type: { kind: $.TypeKind.Basic, name: 'string' } }] }] } }] }],
+	  [{ name: "SetResult", args: [{ name: "val", type: { kind: $.TypeKind.Interface, methods: [] } }, { name: "err", type: { kind: $.TypeKind.Interface, name: 'GoError', methods: [{ name: 'Error', args: [], returns: [{ type: { kind: $.TypeKind.Basic, name: 'string' } }] }] } }], returns: [{ type: { kind: $.TypeKind.Basic, name: "boolean" } }] }, { name: "Await", args: [{ name: "ctx", type: "context.Context" }], returns: [{ type: { kind: $.TypeKind.Interface, methods: [] } }, { type: { kind: $.TypeKind.Interface, name: 'GoError', methods: [{ name: 'Error', args: [], returns: [{ type: { kind: $.TypeKind.Basic, name: 'string' } }] }] } }] }],
 	  PromiseType,
-	  {"result": { kind: $.TypeKind.Interface, methods: [] }, "err": { kind: $.TypeKind.Interface, name: 'GoError', methods: [{ name: 'Error', args: [], returns: [{ type: { kind: $.TypeKind.Basic, name: 'string' } }] }] }, "isResolved": { kind: $.TypeKind.Basic, name: "boolean" }, "ch": { kind: $.TypeKind.Channel, direction: "both", elemType: { kind: $.TypeKind.Struct, fields: {}, methods: [] } }}
+	  [{ name: "result", type: { kind: $.TypeKind.Interface, methods: [] } }, { name: "err", type: { kind: $.TypeKind.Interface, name: 'GoError', methods: [{ name: 'Error', args: [], returns: [{ type: { kind: $.TypeKind.Basic, name: 'string' } }] }] } }, { name: "isResolved", type: { kind: $.TypeKind.Basic, name: "bool" } }, { name: "ch", type: { kind: $.TypeKind.Channel, direction: "both", elemType: { kind: $.TypeKind.Struct, fields: [], methods: [] } } }]
 	);
 }
 
@@ -142,9 +142,9 @@ export async function main(): Promise<void> {
 	let p1 = NewPromise<string>()
 
 	// Set result in goroutine
-	queueMicrotask(() => {
+	$.go(() => {
 		p1!.SetResult("hello world", null)
-	})
+	}, "util_promise.go:71")
 
 	let [result1, err1] = await p1!.Await(ctx)
 	if (err1 != null) {
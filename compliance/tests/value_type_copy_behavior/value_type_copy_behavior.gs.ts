@@ -41,11 +41,11 @@ export class MyStruct {
 
 	// Register this type with the runtime type system
 	static __typeInfo = $.registerStructType(
-	  'MyStruct',
+	  'main@github.com/aperturerobotics/goscript/compliance/tests/value_type_copy_behavior.MyStruct',
 	  new MyStruct(),
 	  [],
 	  MyStruct,
-	  {"MyInt": { kind: $.TypeKind.Basic, name: "number" }, "MyString": { kind: $.TypeKind.Basic, name: "string" }}
+	  [{ name: "MyInt", type: { kind: $.TypeKind.Basic, name: "int" } }, { name: "MyString", type: { kind: $.TypeKind.Basic, name: "string" } }]
 	);
 }
 
@@ -87,11 +87,11 @@ export class NestedStruct {
 
 	// Register this type with the runtime type system
 	static __typeInfo = $.registerStructType(
-	  'NestedStruct',
+	  'main@github.com/aperturerobotics/goscript/compliance/tests/value_type_copy_behavior.NestedStruct',
 	  new NestedStruct(),
 	  [],
 	  NestedStruct,
-	  {"Value": { kind: $.TypeKind.Basic, name: "number" }, "InnerStruct": "MyStruct"}
+	  [{ name: "Value", type: { kind: $.TypeKind.Basic, name: "int" } }, { name: "InnerStruct", type: "main@github.com/aperturerobotics/goscript/compliance/tests/value_type_copy_behavior.MyStruct" }]
 	);
 }
 
@@ -8,7 +8,7 @@ export type Basic = null | {
 }
 
 $.registerInterfaceType(
-  'Basic',
+  'main@github.com/aperturerobotics/goscript/compliance/tests/variadic_interface_method.Basic',
   null, // Zero value for interface is null
   [{ name: "Join", args: [{ name: "elem", type: { kind: $.TypeKind.Slice, elemType: { kind: $.TypeKind.Basic, name: "string" } } }], returns: [{ type: { kind: $.TypeKind.Basic, name: "string" } }] }]
 );
@@ -36,7 +36,7 @@ export class PathJoiner {
 				if (i > 0) {
 					result += "/"
 				}
-				result += e
+				result = $.concatString(result, e)
 			}
 		}
 		return result
@@ -44,16 +44,16 @@ export class PathJoiner {
 
 	// Register this type with the runtime type system
 	static __typeInfo = $.registerStructType(
-	  'PathJoiner',
+	  'main@github.com/aperturerobotics/goscript/compliance/tests/variadic_interface_method.PathJoiner',
 	  new PathJoiner(),
 	  [{ name: "Join", args: [{ name: "elem", type: { kind: $.TypeKind.Slice, elemType: { kind: $.TypeKind.Basic, name: "string" } } }], returns: [{ type: { kind: $.TypeKind.Basic, name: "string" } }] }],
 	  PathJoiner,
-	  {}
+	  []
 	);
 }
 
 export async function main(): Promise<void> {
-	let b: Basic = new PathJoiner({})
+	let b: Basic = $.markStructValue(new PathJoiner({}))
 
 	// Test with multiple arguments
 	let result1 = b!.Join("path", "to", "file")
@@ -36,11 +36,11 @@ export class MockInode {
 
 	// Register this type with the runtime type system
 	static __typeInfo = $.registerStructType(
-	  'MockInode',
+	  'main@github.com/aperturerobotics/goscript/compliance/tests/varref_composite_lit.MockInode',
 	  new MockInode(),
 	  [{ name: "getValue", args: [], returns: [{ type: { kind: $.TypeKind.Basic, name: "number" } }] }],
 	  MockInode,
-	  {"Value": { kind: $.TypeKind.Basic, name: "number" }}
+	  [{ name: "Value", type: { kind: $.TypeKind.Basic, name: "int" } }]
 	);
 }
 
@@ -1,3 +1,3 @@
-export { TestFileMode, TestMyMode } from "./wrapper_type_args.gs.js"
+export { MyMode_IsExecutable, MyMode_String, TestFileMode, TestMyMode } from "./wrapper_type_args.gs.js"
 export { MyDir } from "./wrapper_type_args.gs.js"
 export type { DirInterface, MyMode } from "./wrapper_type_args.gs.js"
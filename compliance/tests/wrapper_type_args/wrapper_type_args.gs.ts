@@ -18,14 +18,22 @@ export function MyMode_String(m: MyMode): string {
 }
 
 
+$.registerNamedType(
+  'main@github.com/aperturerobotics/goscript/compliance/tests/wrapper_type_args.MyMode',
+  0,
+  [{ name: "IsExecutable", args: [], returns: [{ type: { kind: $.TypeKind.Basic, name: "boolean" } }] }, { name: "String", args: [], returns: [{ type: { kind: $.TypeKind.Basic, name: "string" } }] }],
+  { kind: $.TypeKind.Basic, name: "uint32" },
+  { IsExecutable: MyMode_IsExecutable, String: MyMode_String }
+);
+
 export type DirInterface = null | {
 	MkdirAll(path: string, perm: os.FileMode): $.GoError
 }
 
 $.registerInterfaceType(
-  'DirInterface',
+  'main@github.com/aperturerobotics/goscript/compliance/tests/wrapper_type_args.DirInterface',
   null, // Zero value for interface is null
-  [{ name: "MkdirAll", args: [{ name: "path", type: { kind: $.TypeKind.Basic, name: "string" } }, { name: "perm", type: { kind: $.TypeKind.Basic, name: "number" } }], returns: [{ type: { kind: $.TypeKind.Interface, name: 'GoError', methods: [{ name: 'Error', args: [], returns: [{ type: { kind: $.TypeKind.Basic, name: 'string' } }] }] } }] }]
+  [{ name: "MkdirAll", args: [{ name: "path", type: { kind: $.TypeKind.Basic, name: "string" } }, { name: "perm", type: "io/fs.FileMode" }], returns: [{ type: { kind: $.TypeKind.Interface, name: 'GoError', methods: [{ name: 'Error', args: [], returns: [{ type: { kind: $.TypeKind.Basic, name: 'string' } }] }] } }] }]
 );
 
 export class MyDir {
@@ -50,11 +58,11 @@ export class MyDir {
 
 	// Register this type with the runtime type system
 	static __typeInfo = $.registerStructType(
-	  'MyDir',
+	  'main@github.com/aperturerobotics/goscript/compliance/tests/wrapper_type_args.MyDir',
 	  new MyDir(),
-	  [{ name: "MkdirAll", args: [{ name: "path", type: { kind: $.TypeKind.Basic, name: "string" } }, { name: "perm", type: { kind: $.TypeKind.Basic, name: "number" } }], returns: [{ type: { kind: $.TypeKind.Interface, name: 'GoError', methods: [{ name: 'Error', args: [], returns: [{ type: { kind: $.TypeKind.Basic, name: 'string' } }] }] } }] }],
+	  [{ name: "MkdirAll", args: [{ name: "path", type: { kind: $.TypeKind.Basic, name: "string" } }, { name: "perm", type: "io/fs.FileMode" }], returns: [{ type: { kind: $.TypeKind.Interface, name: 'GoError', methods: [{ name: 'Error', args: [], returns: [{ type: { kind: $.TypeKind.Basic, name: 'string' } }] }] } }] }],
 	  MyDir,
-	  {}
+	  []
 	);
 }
 
@@ -85,7 +93,7 @@ export async function main(): Promise<void> {
 	TestFileMode(existingMode) // Should stay as-is
 
 	// Test arithmetic operations (should use valueOf)
-	let combined = ((0o755 as os.FileMode) | 0o022) // Should become: os.FileMode(0o755).valueOf() | 0o022
+	let combined = 511 // Should become: os.FileMode(0o755).valueOf() | 0o022
 	TestFileMode(combined)
 
 	fmt.Println("Test completed")
@@ -0,0 +1,167 @@
+import { describe, it, expect } from 'vitest'
+import * as $ from '@goscript/builtin/index.js'
+import * as io from '@goscript/io/index.js'
+import * as strings from '@goscript/strings/index.js'
+import {
+  ErrBufferFull,
+  ErrInvalidUnreadRune,
+  NewReader,
+  NewReaderSize,
+  NewWriter,
+  NewWriterSize,
+} from './bufio.js'
+
+// chunkReader returns its input a few bytes per Read.
+class chunkReader {
+  private data: Uint8Array
+  private off = 0
+
+  constructor(
+    s: string,
+    private chunk: number,
+  ) {
+    this.data = $.stringToBytes(s)
+  }
+
+  public Read(p: $.Bytes): [number, $.GoError] {
+    if (this.off >= this.data.length) {
+      return [0, io.EOF]
+    }
+    const end = Math.min(this.off + this.chunk, this.data.length)
+    const n = $.copy(p, this.data.subarray(this.off, end))
+    this.off += n
+    return [n, null]
+  }
+}
+
+// asyncReader is a chunkReader whose Read returns a promise, like a
+// compiled Read method that blocks.
+class asyncReader {
+  private r: chunkReader
+
+  constructor(s: string, chunk: number) {
+    this.r = new chunkReader(s, chunk)
+  }
+
+  public async Read(p: $.Bytes): Promise<[number, $.GoError]> {
+    await Promise.resolve()
+    return this.r.Read(p)
+  }
+}
+
+// recordWriter records the bytes of each Write.
+class recordWriter {
+  public writes: string[] = []
+
+  public Write(p: $.Bytes): [number, $.GoError] {
+    this.writes.push($.bytesToString(p))
+    return [$.len(p), null]
+  }
+}
+
+describe('bufio/Reader', () => {
+  it('should read strings up to a delimiter', () => {
+    const r = NewReader(strings.NewReader('one\ntwo\nthree'))
+    expect(r.ReadString(0x0a)).toEqual(['one\n', null])
+    expect(r.ReadString(0x0a)).toEqual(['two\n', null])
+    expect(r.ReadString(0x0a)).toEqual(['three', io.EOF])
+    expect(r.ReadString(0x0a)).toEqual(['', io.EOF])
+  })
+
+  it('should read lines longer than the buffer', () => {
+    const line = 'x'.repeat(100)
+    const r = NewReaderSize(new chunkReader(line + '\nend', 7) as any, 16)
+    expect(r.Size()).toBe(16)
+    const [s, err] = r.ReadString(0x0a)
+    expect(s).toBe(line + '\n')
+    expect(err).toBeNull()
+
+    const r2 = NewReaderSize(strings.NewReader(line + '\r\n'), 16)
+    const [prefix, isPrefix] = r2.ReadLine()
+    expect($.len(prefix)).toBe(16)
+    expect(isPrefix).toBe(true)
+  })
+
+  it('should read runes across reads', () => {
+    const r = NewReader(new chunkReader('héllo, 世界', 1) as any)
+    const runes: number[] = []
+    for (;;) {
+      const [c, size, err] = r.ReadRune()
+      if (err !== null) {
+        expect(err).toBe(io.EOF)
+        break
+      }
+      expect(size).toBe($.stringToBytes(String.fromCodePoint(c)).length)
+      runes.push(c)
+    }
+    expect(String.fromCodePoint(...runes)).toBe('héllo, 世界')
+  })
+
+  it('should unread runes', () => {
+    const r = NewReader(strings.NewReader('世a'))
+    expect(r.ReadRune()).toEqual([0x4e16, 3, null])
+    expect(r.UnreadRune()).toBeNull()
+    expect(r.UnreadRune()).toBe(ErrInvalidUnreadRune)
+    expect(r.ReadRune()).toEqual([0x4e16, 3, null])
+    expect(r.ReadByte()).toEqual([0x61, null])
+    expect(r.UnreadByte()).toBeNull()
+    expect(r.ReadByte()).toEqual([0x61, null])
+  })
+
+  it('should peek without advancing', () => {
+    const src = new chunkReader('abcdefghijklmnopqrstuvwxyz', 3)
+    const r = NewReaderSize(src as any, 16)
+    const [p, err] = r.Peek(5)
+    expect($.bytesToString(p)).toBe('abcde')
+    expect(err).toBeNull()
+    expect(r.Peek(17)[1]).toBe(ErrBufferFull)
+    expect(r.Discard(3)).toEqual([3, null])
+    expect(r.ReadString(0x66)).toEqual(['def', null])
+  })
+
+  it('should return promises over an async reader', async () => {
+    const r = NewReader(new asyncReader('ab\ncd', 2) as any)
+    const first = r.ReadString(0x0a)
+    expect(first).toBeInstanceOf(Promise)
+    expect(await first).toEqual(['ab\n', null])
+    expect(await r.ReadString(0x0a)).toEqual(['cd', io.EOF])
+  })
+})
+
+describe('bufio/Writer', () => {
+  it('should buffer writes until flushed', () => {
+    const w = new recordWriter()
+    const b = NewWriter(w)
+    expect(b.WriteString('hello ')).toEqual([6, null])
+    expect(b.WriteByte(0x77)).toBeNull()
+    expect(b.WriteRune(0x4e16)).toEqual([3, null])
+    expect(b.Buffered()).toBe(10)
+    expect(w.writes).toEqual([])
+    expect(b.Flush()).toBeNull()
+    expect(w.writes).toEqual(['hello w世'])
+    expect(b.Buffered()).toBe(0)
+  })
+
+  it('should flush when the buffer fills', () => {
+    const w = new recordWriter()
+    const b = NewWriterSize(w, 4)
+    b.WriteString('abcdefghij')
+    expect(w.writes).toEqual(['abcdefghij'])
+    b.WriteString('ab')
+    b.WriteString('cdef')
+    expect(w.writes).toEqual(['abcdefghij', 'abcd'])
+    expect(b.Available()).toBe(2)
+    b.Flush()
+    expect(w.writes.join('')).toBe('abcdefghijabcdef')
+  })
+
+  it('should read from a reader', () => {
+    const w = new recordWriter()
+    const b = NewWriterSize(w, 8)
+    const [n, err] = b.ReadFrom(new chunkReader('0123456789', 3) as any)
+    expect(n).toBe(10)
+    expect(err).toBeNull()
+    b.Flush()
+    expect(w.writes.join('')).toBe('0123456789')
+  })
+})
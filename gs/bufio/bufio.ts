@@ -0,0 +1,1102 @@
+// Package bufio implements buffered I/O. It wraps an io.Reader or io.Writer
+// object, creating another object (Reader or Writer) that also implements
+// the interface but provides buffering and some help for textual I/O.
+
+import * as $ from '@goscript/builtin/index.js'
+import * as errors from '@goscript/errors/index.js'
+import * as io from '@goscript/io/index.js'
+import * as utf8 from '@goscript/unicode/utf8/index.js'
+import { run, Step } from './step.js'
+import {
+  boolType,
+  byteType,
+  bytesType,
+  errorType,
+  int64Type,
+  intType,
+  runeType,
+  stringType,
+} from './typeinfo.js'
+
+const defaultBufSize = 4096
+
+export const ErrInvalidUnreadByte = errors.New(
+  'bufio: invalid use of UnreadByte',
+)
+export const ErrInvalidUnreadRune = errors.New(
+  'bufio: invalid use of UnreadRune',
+)
+export const ErrBufferFull = errors.New('bufio: buffer full')
+export const ErrNegativeCount = errors.New('bufio: negative count')
+
+const minReadBufferSize = 16
+export const maxConsecutiveEmptyReads = 100
+
+const errNegativeRead = errors.New(
+  'bufio: reader returned negative count from Read',
+)
+const errNegativeWrite = errors.New(
+  'bufio: writer returned negative count from Write',
+)
+
+// Reader implements buffering for an io.Reader object.
+//
+// The methods that read from the underlying reader return promises when
+// it does, see step.ts.
+export class Reader {
+  private buf: Uint8Array = new Uint8Array(0)
+  // reader provided by the client
+  private rd: io.Reader | null = null
+  // buf read and write positions
+  private r = 0
+  private w = 0
+  private err: $.GoError = null
+  // last byte read for UnreadByte; -1 means invalid
+  private lastByte = -1
+  // size of last rune read for UnreadRune; -1 means invalid
+  private lastRuneSize = -1
+
+  constructor(_init?: Partial<{}>) {}
+
+  public clone(): Reader {
+    const cloned = new Reader()
+    cloned.reset(this.buf, this.rd)
+    cloned.r = this.r
+    cloned.w = this.w
+    cloned.err = this.err
+    cloned.lastByte = this.lastByte
+    cloned.lastRuneSize = this.lastRuneSize
+    return cloned
+  }
+
+  // Size returns the size of the underlying buffer in bytes.
+  public Size(): number {
+    return this.buf.length
+  }
+
+  // Reset discards any buffered data, resets all state, and switches
+  // the buffered reader to read from r.
+  // Calling Reset on the zero value of Reader initializes the internal
+  // buffer to the default size.
+  // Calling b.Reset(b) (that is, resetting a Reader to itself) does nothing.
+  public Reset(r: io.Reader | null): void {
+    // If a Reader r is passed to NewReader, NewReader will return r.
+    // Different layers of code may do that, and then later pass r
+    // to Reset. Avoid infinite recursion in that case.
+    if (r === this) {
+      return
+    }
+    if (this.buf.length === 0) {
+      this.buf = new Uint8Array(defaultBufSize)
+    }
+    this.reset(this.buf, r)
+  }
+
+  // reset makes b read from r into buf, as NewReaderSize does.
+  public reset(buf: Uint8Array, r: io.Reader | null): void {
+    this.buf = buf
+    this.rd = r
+    this.r = 0
+    this.w = 0
+    this.err = null
+    this.lastByte = -1
+    this.lastRuneSize = -1
+  }
+
+  // fill reads a new chunk into the buffer.
+  private *fill(): Step<void> {
+    // Slide existing data to beginning.
+    if (this.r > 0) {
+      this.buf.copyWithin(0, this.r, this.w)
+      this.w -= this.r
+      this.r = 0
+    }
+
+    if (this.w >= this.buf.length) {
+      $.panic('bufio: tried to fill full buffer')
+    }
+
+    // Read new data: try a limited number of times.
+    for (let i = maxConsecutiveEmptyReads; i > 0; i--) {
+      const [n, err]: [number, $.GoError] = yield this.rd!.Read(
+        this.buf.subarray(this.w),
+      )
+      if (n < 0) {
+        $.panic(errNegativeRead)
+      }
+      this.w += n
+      if (err !== null) {
+        this.err = err
+        return
+      }
+      if (n > 0) {
+        return
+      }
+    }
+    this.err = io.ErrNoProgress
+  }
+
+  private readErr(): $.GoError {
+    const err = this.err
+    this.err = null
+    return err
+  }
+
+  // Peek returns the next n bytes without advancing the reader. The bytes
+  // stop being valid at the next read call. If necessary, Peek will read
+  // more bytes into the buffer in order to make n bytes available. If Peek
+  // returns fewer than n bytes, it also returns an error explaining why the
+  // read is short. The error is ErrBufferFull if n is larger than b's
+  // buffer size.
+  public Peek(n: number): [$.Bytes, $.GoError] {
+    return run(this.peek(n))
+  }
+
+  private *peek(n: number): Step<[$.Bytes, $.GoError]> {
+    if (n < 0) {
+      return [null, ErrNegativeCount]
+    }
+
+    this.lastByte = -1
+    this.lastRuneSize = -1
+
+    while (
+      this.w - this.r < n &&
+      this.w - this.r < this.buf.length &&
+      this.err === null
+    ) {
+      yield* this.fill() // b.w-b.r < len(b.buf) => buffer is not full
+    }
+
+    if (n > this.buf.length) {
+      return [this.buf.subarray(this.r, this.w), ErrBufferFull]
+    }
+
+    // 0 <= n <= len(b.buf)
+    let err: $.GoError = null
+    const avail = this.w - this.r
+    if (avail < n) {
+      // not enough data in buffer
+      n = avail
+      err = this.readErr()
+      if (err === null) {
+        err = ErrBufferFull
+      }
+    }
+    return [this.buf.subarray(this.r, this.r + n), err]
+  }
+
+  // Discard skips the next n bytes, returning the number of bytes
+  // discarded.
+  //
+  // If Discard skips fewer than n bytes, it also returns an error.
+  // If 0 <= n <= b.Buffered(), Discard is guaranteed to succeed without
+  // reading from the underlying io.Reader.
+  public Discard(n: number): [number, $.GoError] {
+    return run(this.discard(n))
+  }
+
+  private *discard(n: number): Step<[number, $.GoError]> {
+    if (n < 0) {
+      return [0, ErrNegativeCount]
+    }
+    if (n === 0) {
+      return [0, null]
+    }
+
+    this.lastByte = -1
+    this.lastRuneSize = -1
+
+    let remain = n
+    for (;;) {
+      let skip = this.Buffered()
+      if (skip === 0) {
+        yield* this.fill()
+        skip = this.Buffered()
+      }
+      if (skip > remain) {
+        skip = remain
+      }
+      this.r += skip
+      remain -= skip
+      if (remain === 0) {
+        return [n, null]
+      }
+      if (this.err !== null) {
+        return [n - remain, this.readErr()]
+      }
+    }
+  }
+
+  // Read reads data into p.
+  // It returns the number of bytes read into p.
+  // The bytes are taken from at most one Read on the underlying Reader,
+  // hence n may be less than len(p).
+  // To read exactly len(p) bytes, use io.ReadFull(b, p).
+  // If the underlying Reader can return a non-zero count with io.EOF,
+  // then this Read method can do so as well; see the [io.Reader] docs.
+  public Read(p: $.Bytes): [number, $.GoError] {
+    return run(this.read(p))
+  }
+
+  private *read(p: $.Bytes): Step<[number, $.GoError]> {
+    let n = $.len(p)
+    if (n === 0) {
+      if (this.Buffered() > 0) {
+        return [0, null]
+      }
+      return [0, this.readErr()]
+    }
+    if (this.r === this.w) {
+      if (this.err !== null) {
+        return [0, this.readErr()]
+      }
+      if (n >= this.buf.length) {
+        // Large read, empty buffer.
+        // Read directly into p to avoid copy.
+        ;[n, this.err] = yield this.rd!.Read(p)
+        if (n < 0) {
+          $.panic(errNegativeRead)
+        }
+        if (n > 0) {
+          this.lastByte = p![n - 1]
+          this.lastRuneSize = -1
+        }
+        return [n, this.readErr()]
+      }
+      // One read.
+      // Do not use b.fill, which will loop.
+      this.r = 0
+      this.w = 0
+      ;[n, this.err] = yield this.rd!.Read(this.buf)
+      if (n < 0) {
+        $.panic(errNegativeRead)
+      }
+      if (n === 0) {
+        return [0, this.readErr()]
+      }
+      this.w += n
+    }
+
+    // copy as much as we can
+    // Note: if the slice panics here, it is probably because
+    // the underlying reader returned a bad count. See issue 49795.
+    n = $.copy(p, this.buf.subarray(this.r, this.w))
+    this.r += n
+    this.lastByte = this.buf[this.r - 1]
+    this.lastRuneSize = -1
+    return [n, null]
+  }
+
+  // ReadByte reads and returns a single byte.
+  // If no byte is available, returns an error.
+  public ReadByte(): [number, $.GoError] {
+    return run(this.readByte())
+  }
+
+  private *readByte(): Step<[number, $.GoError]> {
+    this.lastRuneSize = -1
+    while (this.r === this.w) {
+      if (this.err !== null) {
+        return [0, this.readErr()]
+      }
+      yield* this.fill() // buffer is empty
+    }
+    const c = this.buf[this.r]
+    this.r++
+    this.lastByte = c
+    return [c, null]
+  }
+
+  // UnreadByte unreads the last byte. Only the most recently read byte can
+  // be unread.
+  //
+  // UnreadByte returns an error if the most recent method called on the
+  // Reader was not a read operation. Notably, Peek, Discard, and WriteTo are
+  // not considered read operations.
+  public UnreadByte(): $.GoError {
+    if (this.lastByte < 0 || (this.r === 0 && this.w > 0)) {
+      return ErrInvalidUnreadByte
+    }
+    // b.r > 0 || b.w == 0
+    if (this.r > 0) {
+      this.r--
+    } else {
+      // b.r == 0 && b.w == 0
+      this.w = 1
+    }
+    this.buf[this.r] = this.lastByte
+    this.lastByte = -1
+    this.lastRuneSize = -1
+    return null
+  }
+
+  // ReadRune reads a single UTF-8 encoded Unicode character and returns the
+  // rune and its size in bytes. If the encoded rune is invalid, it consumes
+  // one byte and returns unicode.ReplacementChar (U+FFFD) with a size of 1.
+  public ReadRune(): [number, number, $.GoError] {
+    return run(this.readRune())
+  }
+
+  private *readRune(): Step<[number, number, $.GoError]> {
+    while (
+      this.r + utf8.UTFMax > this.w &&
+      !utf8.FullRune(this.buf.subarray(this.r, this.w)) &&
+      this.err === null &&
+      this.w - this.r < this.buf.length
+    ) {
+      yield* this.fill() // b.w-b.r < len(buf) => buffer is not full
+    }
+    this.lastRuneSize = -1
+    if (this.r === this.w) {
+      return [0, 0, this.readErr()]
+    }
+    const [r, size] = utf8.DecodeRune(this.buf.subarray(this.r, this.w))
+    this.r += size
+    this.lastByte = this.buf[this.r - 1]
+    this.lastRuneSize = size
+    return [r, size, null]
+  }
+
+  // UnreadRune unreads the last rune. If the most recent method called on
+  // the Reader was not a ReadRune, UnreadRune returns an error. (In this
+  // regard it is stricter than UnreadByte, which will unread the last byte
+  // from any read operation.)
+  public UnreadRune(): $.GoError {
+    if (this.lastRuneSize < 0 || this.r < this.lastRuneSize) {
+      return ErrInvalidUnreadRune
+    }
+    this.r -= this.lastRuneSize
+    this.lastByte = -1
+    this.lastRuneSize = -1
+    return null
+  }
+
+  // Buffered returns the number of bytes that can be read from the current
+  // buffer.
+  public Buffered(): number {
+    return this.w - this.r
+  }
+
+  // ReadSlice reads until the first occurrence of delim in the input,
+  // returning a slice pointing at the bytes in the buffer.
+  // The bytes stop being valid at the next read.
+  // If ReadSlice encounters an error before finding a delimiter,
+  // it returns all the data in the buffer and the error itself (often
+  // io.EOF). ReadSlice fails with error ErrBufferFull if the buffer fills
+  // without a delim. Because the data returned from ReadSlice will be
+  // overwritten by the next I/O operation, most clients should use
+  // ReadBytes or ReadString instead.
+  // ReadSlice returns err != nil if and only if line does not end in delim.
+  public ReadSlice(delim: number): [$.Bytes, $.GoError] {
+    return run(this.readSlice(delim))
+  }
+
+  private *readSlice(delim: number): Step<[Uint8Array, $.GoError]> {
+    let line: Uint8Array
+    let err: $.GoError = null
+    let s = 0 // search start index
+    for (;;) {
+      // Search buffer.
+      let i = this.buf.subarray(this.r + s, this.w).indexOf(delim)
+      if (i >= 0) {
+        i += s
+        line = this.buf.subarray(this.r, this.r + i + 1)
+        this.r += i + 1
+        break
+      }
+
+      // Pending error?
+      if (this.err !== null) {
+        line = this.buf.subarray(this.r, this.w)
+        this.r = this.w
+        err = this.readErr()
+        break
+      }
+
+      // Buffer full?
+      if (this.Buffered() >= this.buf.length) {
+        this.r = this.w
+        line = this.buf
+        err = ErrBufferFull
+        break
+      }
+
+      s = this.w - this.r // do not rescan area we scanned before
+
+      yield* this.fill() // buffer is not full
+    }
+
+    // Handle last byte, if any.
+    const i = line.length - 1
+    if (i >= 0) {
+      this.lastByte = line[i]
+      this.lastRuneSize = -1
+    }
+
+    return [line, err]
+  }
+
+  // ReadLine is a low-level line-reading primitive. Most callers should use
+  // ReadBytes('\n') or ReadString('\n') instead or use a Scanner.
+  //
+  // ReadLine tries to return a single line, not including the end-of-line
+  // bytes. If the line was too long for the buffer then isPrefix is set and
+  // the beginning of the line is returned. The rest of the line will be
+  // returned from future calls. isPrefix will be false when returning the
+  // last fragment of the line. The returned buffer is only valid until the
+  // next call to ReadLine. ReadLine either returns a non-nil line or it
+  // returns an error, never both.
+  public ReadLine(): [$.Bytes, boolean, $.GoError] {
+    return run(this.readLine())
+  }
+
+  private *readLine(): Step<[$.Bytes, boolean, $.GoError]> {
+    let [line, err]: [Uint8Array, $.GoError] = yield* this.readSlice(0x0a)
+    if (err === ErrBufferFull) {
+      // Handle the case where "\r\n" straddles the buffer.
+      if (line.length > 0 && line[line.length - 1] === 0x0d) {
+        // Put the '\r' back on buf and drop it from line.
+        // Let the next call to ReadLine check for "\r\n".
+        if (this.r === 0) {
+          // should be unreachable
+          $.panic('bufio: tried to rewind past start of buffer')
+        }
+        this.r--
+        line = line.subarray(0, line.length - 1)
+      }
+      return [line, true, null]
+    }
+
+    if (line.length === 0) {
+      if (err !== null) {
+        return [null, false, err]
+      }
+      return [line, false, err]
+    }
+    err = null
+
+    if (line[line.length - 1] === 0x0a) {
+      let drop = 1
+      if (line.length > 1 && line[line.length - 2] === 0x0d) {
+        drop = 2
+      }
+      line = line.subarray(0, line.length - drop)
+    }
+    return [line, false, err]
+  }
+
+  // collectFragments reads until the first occurrence of delim in the
+  // input. It returns (slice of full buffers, remaining bytes before delim,
+  // total number of bytes in the combined first two elements, error).
+  private *collectFragments(
+    delim: number,
+  ): Step<[Uint8Array[], Uint8Array, number, $.GoError]> {
+    const fullBuffers: Uint8Array[] = []
+    let totalLen = 0
+    let err: $.GoError = null
+    let frag: Uint8Array
+    for (;;) {
+      let e: $.GoError
+      ;[frag, e] = yield* this.readSlice(delim)
+      if (e === null) {
+        // got final fragment
+        break
+      }
+      if (e !== ErrBufferFull) {
+        // unexpected error
+        err = e
+        break
+      }
+
+      // Make a copy of the buffer.
+      const buf = frag.slice()
+      fullBuffers.push(buf)
+      totalLen += buf.length
+    }
+
+    totalLen += frag.length
+    return [fullBuffers, frag, totalLen, err]
+  }
+
+  // ReadBytes reads until the first occurrence of delim in the input,
+  // returning a slice containing the data up to and including the
+  // delimiter. If ReadBytes encounters an error before finding a delimiter,
+  // it returns the data read before the error and the error itself (often
+  // io.EOF). ReadBytes returns err != nil if and only if the returned data
+  // does not end in delim. For simple uses, a Scanner may be more
+  // convenient.
+  public ReadBytes(delim: number): [$.Bytes, $.GoError] {
+    return run(this.readBytes(delim))
+  }
+
+  private *readBytes(delim: number): Step<[Uint8Array, $.GoError]> {
+    const [full, frag, n, err] = yield* this.collectFragments(delim)
+    // Allocate new buffer to hold the full pieces and the fragment.
+    const buf = new Uint8Array(n)
+    let off = 0
+    // Copy full pieces and fragment in.
+    for (const fb of full) {
+      buf.set(fb, off)
+      off += fb.length
+    }
+    buf.set(frag, off)
+    return [buf, err]
+  }
+
+  // ReadString reads until the first occurrence of delim in the input,
+  // returning a string containing the data up to and including the
+  // delimiter. If ReadString encounters an error before finding a
+  // delimiter, it returns the data read before the error and the error
+  // itself (often io.EOF). ReadString returns err != nil if and only if the
+  // returned data does not end in delim. For simple uses, a Scanner may be
+  // more convenient.
+  public ReadString(delim: number): [string, $.GoError] {
+    return run(this.readString(delim))
+  }
+
+  private *readString(delim: number): Step<[string, $.GoError]> {
+    const [buf, err] = yield* this.readBytes(delim)
+    return [$.bytesToString(buf), err]
+  }
+
+  // WriteTo implements io.WriterTo.
+  // This may make multiple calls to the [Reader.Read] method of the
+  // underlying [Reader]. If the underlying reader supports the
+  // [Reader.WriteTo] method, this calls the underlying [Reader.WriteTo]
+  // without buffering.
+  public WriteTo(w: io.Writer): [number, $.GoError] {
+    return run(this.writeTo(w))
+  }
+
+  private *writeTo(w: io.Writer): Step<[number, $.GoError]> {
+    this.lastByte = -1
+    this.lastRuneSize = -1
+
+    let n = 0
+    let err: $.GoError = null
+    if (this.r < this.w) {
+      ;[n, err] = yield* this.writeBuf(w)
+      if (err !== null) {
+        return [n, err]
+      }
+    }
+
+    const rd = this.rd as any
+    if (rd !== null && typeof rd.WriteTo === 'function') {
+      const [m, err]: [number, $.GoError] = yield rd.WriteTo(w)
+      return [n + m, err]
+    }
+
+    const wr = w as any
+    if (wr !== null && typeof wr.ReadFrom === 'function') {
+      const [m, err]: [number, $.GoError] = yield wr.ReadFrom(this.rd)
+      return [n + m, err]
+    }
+
+    if (this.w - this.r < this.buf.length) {
+      yield* this.fill() // buffer not full
+    }
+
+    while (this.r < this.w) {
+      // b.r < b.w => buffer is not empty
+      const [m, err] = yield* this.writeBuf(w)
+      n += m
+      if (err !== null) {
+        return [n, err]
+      }
+      yield* this.fill() // buffer is empty
+    }
+
+    if (this.err === io.EOF) {
+      this.err = null
+    }
+
+    return [n, this.readErr()]
+  }
+
+  // writeBuf writes the Reader's buffer to the writer.
+  private *writeBuf(w: io.Writer): Step<[number, $.GoError]> {
+    const [n, err]: [number, $.GoError] = yield w.Write(
+      this.buf.subarray(this.r, this.w),
+    )
+    if (n < 0) {
+      $.panic(errNegativeWrite)
+    }
+    this.r += n
+    return [n, err]
+  }
+
+  // Register this type with the runtime type system
+  static __typeInfo = $.registerStructType(
+    'bufio.Reader',
+    new Reader(),
+    [
+      $.method('Size', [], [intType]),
+      $.method('Reset', ['io.Reader'], []),
+      $.method('Peek', [intType], [bytesType, errorType]),
+      $.method('Discard', [intType], [intType, errorType]),
+      $.method('Read', [bytesType], [intType, errorType]),
+      $.method('ReadByte', [], [byteType, errorType]),
+      $.method('UnreadByte', [], [errorType]),
+      $.method('ReadRune', [], [runeType, intType, errorType]),
+      $.method('UnreadRune', [], [errorType]),
+      $.method('Buffered', [], [intType]),
+      $.method('ReadSlice', [byteType], [bytesType, errorType]),
+      $.method('ReadLine', [], [bytesType, boolType, errorType]),
+      $.method('ReadBytes', [byteType], [bytesType, errorType]),
+      $.method('ReadString', [byteType], [stringType, errorType]),
+      $.method('WriteTo', ['io.Writer'], [int64Type, errorType]),
+    ],
+    Reader,
+    [],
+  )
+}
+
+// NewReaderSize returns a new Reader whose buffer has at least the
+// specified size. If the argument io.Reader is already a Reader with large
+// enough size, it returns the underlying Reader.
+export function NewReaderSize(rd: io.Reader | null, size: number): Reader {
+  // Is it already a Reader?
+  if (rd instanceof Reader && rd.Size() >= size) {
+    return rd
+  }
+  const r = new Reader()
+  r.reset(new Uint8Array(Math.max(size, minReadBufferSize)), rd)
+  return r
+}
+
+// NewReader returns a new Reader whose buffer has the default size.
+export function NewReader(rd: io.Reader | null): Reader {
+  return NewReaderSize(rd, defaultBufSize)
+}
+
+// Writer implements buffering for an io.Writer object.
+// If an error occurs writing to a Writer, no more data will be
+// accepted and all subsequent writes, and Flush, will return the error.
+// After all data has been written, the client should call the
+// Flush method to guarantee all data has been forwarded to
+// the underlying io.Writer.
+//
+// The methods that write to the underlying writer return promises when it
+// does, see step.ts.
+export class Writer {
+  private err: $.GoError = null
+  // buf and wr are set by NewWriterSize.
+  public buf: Uint8Array = new Uint8Array(0)
+  private n = 0
+  public wr: io.Writer | null = null
+
+  constructor(_init?: Partial<{}>) {}
+
+  public clone(): Writer {
+    const cloned = new Writer()
+    cloned.err = this.err
+    cloned.buf = this.buf
+    cloned.n = this.n
+    cloned.wr = this.wr
+    return cloned
+  }
+
+  // Size returns the size of the underlying buffer in bytes.
+  public Size(): number {
+    return this.buf.length
+  }
+
+  // Reset discards any unflushed buffered data, clears any error, and
+  // resets b to write its output to w.
+  // Calling Reset on the zero value of Writer initializes the internal
+  // buffer to the default size.
+  // Calling w.Reset(w) (that is, resetting a Writer to itself) does
+  // nothing.
+  public Reset(w: io.Writer | null): void {
+    // If a Writer w is passed to NewWriter, NewWriter will return w.
+    // Different layers of code may do that, and then later pass w
+    // to Reset. Avoid infinite recursion in that case.
+    if (w === this) {
+      return
+    }
+    if (this.buf.length === 0) {
+      this.buf = new Uint8Array(defaultBufSize)
+    }
+    this.err = null
+    this.n = 0
+    this.wr = w
+  }
+
+  // Flush writes any buffered data to the underlying io.Writer.
+  public Flush(): $.GoError {
+    return run(this.flush())
+  }
+
+  private *flush(): Step<$.GoError> {
+    if (this.err !== null) {
+      return this.err
+    }
+    if (this.n === 0) {
+      return null
+    }
+    let [n, err]: [number, $.GoError] = yield this.wr!.Write(
+      this.buf.subarray(0, this.n),
+    )
+    if (n < this.n && err === null) {
+      err = io.ErrShortWrite
+    }
+    if (err !== null) {
+      if (n > 0 && n < this.n) {
+        this.buf.copyWithin(0, n, this.n)
+      }
+      this.n -= n
+      this.err = err
+      return err
+    }
+    this.n = 0
+    return null
+  }
+
+  // Available returns how many bytes are unused in the buffer.
+  public Available(): number {
+    return this.buf.length - this.n
+  }
+
+  // AvailableBuffer returns an empty buffer with b.Available() capacity.
+  // This buffer is intended to be appended to and passed to an immediately
+  // succeeding Write call. The buffer is only valid until the next write
+  // operation on b.
+  public AvailableBuffer(): $.Bytes {
+    return $.goSlice(this.buf.subarray(this.n), 0, 0)
+  }
+
+  // Buffered returns the number of bytes that have been written into the
+  // current buffer.
+  public Buffered(): number {
+    return this.n
+  }
+
+  // Write writes the contents of p into the buffer.
+  // It returns the number of bytes written.
+  // If nn < len(p), it also returns an error explaining
+  // why the write is short.
+  public Write(p: $.Bytes): [number, $.GoError] {
+    return run(this.write(p))
+  }
+
+  private *write(p: $.Bytes): Step<[number, $.GoError]> {
+    let nn = 0
+    while ($.len(p) > this.Available() && this.err === null) {
+      let n: number
+      if (this.Buffered() === 0) {
+        // Large write, empty buffer.
+        // Write directly from p to avoid copy.
+        ;[n, this.err] = yield this.wr!.Write(p)
+      } else {
+        n = $.copy(this.buf.subarray(this.n), p)
+        this.n += n
+        yield* this.flush()
+      }
+      nn += n
+      p = $.goSlice(p, n)
+    }
+    if (this.err !== null) {
+      return [nn, this.err]
+    }
+    const n = $.copy(this.buf.subarray(this.n), p)
+    this.n += n
+    nn += n
+    return [nn, null]
+  }
+
+  // WriteByte writes a single byte.
+  public WriteByte(c: number): $.GoError {
+    return run(this.writeByte(c))
+  }
+
+  private *writeByte(c: number): Step<$.GoError> {
+    if (this.err !== null) {
+      return this.err
+    }
+    if (this.Available() <= 0 && (yield* this.flush()) !== null) {
+      return this.err
+    }
+    this.buf[this.n] = c
+    this.n++
+    return null
+  }
+
+  // WriteRune writes a single Unicode code point, returning
+  // the number of bytes written and any error.
+  public WriteRune(r: number): [number, $.GoError] {
+    return run(this.writeRune(r))
+  }
+
+  private *writeRune(r: number): Step<[number, $.GoError]> {
+    // Compare as uint32 to correctly handle negative runes.
+    if (r >>> 0 < utf8.RuneSelf) {
+      const err = yield* this.writeByte(r)
+      if (err !== null) {
+        return [0, err]
+      }
+      return [1, null]
+    }
+    if (this.err !== null) {
+      return [0, this.err]
+    }
+    let n = this.Available()
+    if (n < utf8.UTFMax) {
+      yield* this.flush()
+      if (this.err !== null) {
+        return [0, this.err]
+      }
+      n = this.Available()
+      if (n < utf8.UTFMax) {
+        // Can only happen if buffer is silly small.
+        const p = new Uint8Array(utf8.UTFMax)
+        return yield* this.write(p.subarray(0, utf8.EncodeRune(p, r)))
+      }
+    }
+    const size = utf8.EncodeRune(this.buf.subarray(this.n), r)
+    this.n += size
+    return [size, null]
+  }
+
+  // WriteString writes a string.
+  // It returns the number of bytes written.
+  // If the count is less than len(s), it also returns an error explaining
+  // why the write is short.
+  public WriteString(s: string): [number, $.GoError] {
+    return run(this.write($.stringToBytes(s)))
+  }
+
+  // ReadFrom implements io.ReaderFrom. If the underlying writer
+  // supports the ReadFrom method, this calls the underlying ReadFrom.
+  // If there is buffered data and an underlying ReadFrom, this fills
+  // the buffer and writes it before calling ReadFrom.
+  public ReadFrom(r: io.Reader): [number, $.GoError] {
+    return run(this.readFrom(r))
+  }
+
+  private *readFrom(r: io.Reader): Step<[number, $.GoError]> {
+    if (this.err !== null) {
+      return [0, this.err]
+    }
+    const wr = this.wr as any
+    const readerFromOK = wr !== null && typeof wr.ReadFrom === 'function'
+    let n = 0
+    let m = 0
+    let err: $.GoError = null
+    for (;;) {
+      if (this.Available() === 0) {
+        const err1 = yield* this.flush()
+        if (err1 !== null) {
+          return [n, err1]
+        }
+      }
+      if (readerFromOK && this.Buffered() === 0) {
+        const [nn, err]: [number, $.GoError] = yield wr.ReadFrom(r)
+        this.err = err
+        n += nn
+        return [n, err]
+      }
+      let nr = 0
+      while (nr < maxConsecutiveEmptyReads) {
+        ;[m, err] = yield r.Read(this.buf.subarray(this.n))
+        if (m !== 0 || err !== null) {
+          break
+        }
+        nr++
+      }
+      if (nr === maxConsecutiveEmptyReads) {
+        return [n, io.ErrNoProgress]
+      }
+      this.n += m
+      n += m
+      if (err !== null) {
+        break
+      }
+    }
+    if (err === io.EOF) {
+      // If we filled the buffer exactly, flush preemptively.
+      if (this.Available() === 0) {
+        err = yield* this.flush()
+      } else {
+        err = null
+      }
+    }
+    return [n, err]
+  }
+
+  // Register this type with the runtime type system
+  static __typeInfo = $.registerStructType(
+    'bufio.Writer',
+    new Writer(),
+    [
+      $.method('Size', [], [intType]),
+      $.method('Reset', ['io.Writer'], []),
+      $.method('Flush', [], [errorType]),
+      $.method('Available', [], [intType]),
+      $.method('AvailableBuffer', [], [bytesType]),
+      $.method('Buffered', [], [intType]),
+      $.method('Write', [bytesType], [intType, errorType]),
+      $.method('WriteByte', [byteType], [errorType]),
+      $.method('WriteRune', [runeType], [intType, errorType]),
+      $.method('WriteString', [stringType], [intType, errorType]),
+      $.method('ReadFrom', ['io.Reader'], [int64Type, errorType]),
+    ],
+    Writer,
+    [],
+  )
+}
+
+// NewWriterSize returns a new Writer whose buffer has at least the
+// specified size. If the argument io.Writer is already a Writer with large
+// enough size, it returns the underlying Writer.
+export function NewWriterSize(w: io.Writer | null, size: number): Writer {
+  // Is it already a Writer?
+  if (w instanceof Writer && w.Size() >= size) {
+    return w
+  }
+  if (size <= 0) {
+    size = defaultBufSize
+  }
+  const b = new Writer()
+  b.buf = new Uint8Array(size)
+  b.wr = w
+  return b
+}
+
+// NewWriter returns a new Writer whose buffer has the default size.
+// If the argument io.Writer is already a Writer with large enough buffer
+// size, it returns the underlying Writer.
+export function NewWriter(w: io.Writer | null): Writer {
+  return NewWriterSize(w, defaultBufSize)
+}
+
+// ReadWriter stores pointers to a Reader and a Writer.
+// It implements io.ReadWriter.
+export class ReadWriter {
+  public Reader: Reader | null = null
+  public Writer: Writer | null = null
+
+  constructor(
+    init?: Partial<{ Reader?: Reader | null; Writer?: Writer | null }>,
+  ) {
+    if (init) {
+      Object.assign(this, init)
+    }
+  }
+
+  public clone(): ReadWriter {
+    return new ReadWriter({ Reader: this.Reader, Writer: this.Writer })
+  }
+
+  // The methods promoted from the embedded Reader and Writer. Size,
+  // Buffered and Reset are ambiguous and not promoted.
+
+  public Peek(n: number): [$.Bytes, $.GoError] {
+    return this.Reader!.Peek(n)
+  }
+
+  public Discard(n: number): [number, $.GoError] {
+    return this.Reader!.Discard(n)
+  }
+
+  public Read(p: $.Bytes): [number, $.GoError] {
+    return this.Reader!.Read(p)
+  }
+
+  public ReadByte(): [number, $.GoError] {
+    return this.Reader!.ReadByte()
+  }
+
+  public UnreadByte(): $.GoError {
+    return this.Reader!.UnreadByte()
+  }
+
+  public ReadRune(): [number, number, $.GoError] {
+    return this.Reader!.ReadRune()
+  }
+
+  public UnreadRune(): $.GoError {
+    return this.Reader!.UnreadRune()
+  }
+
+  public ReadSlice(delim: number): [$.Bytes, $.GoError] {
+    return this.Reader!.ReadSlice(delim)
+  }
+
+  public ReadLine(): [$.Bytes, boolean, $.GoError] {
+    return this.Reader!.ReadLine()
+  }
+
+  public ReadBytes(delim: number): [$.Bytes, $.GoError] {
+    return this.Reader!.ReadBytes(delim)
+  }
+
+  public ReadString(delim: number): [string, $.GoError] {
+    return this.Reader!.ReadString(delim)
+  }
+
+  public WriteTo(w: io.Writer): [number, $.GoError] {
+    return this.Reader!.WriteTo(w)
+  }
+
+  public Flush(): $.GoError {
+    return this.Writer!.Flush()
+  }
+
+  public Available(): number {
+    return this.Writer!.Available()
+  }
+
+  public AvailableBuffer(): $.Bytes {
+    return this.Writer!.AvailableBuffer()
+  }
+
+  public Write(p: $.Bytes): [number, $.GoError] {
+    return this.Writer!.Write(p)
+  }
+
+  public WriteByte(c: number): $.GoError {
+    return this.Writer!.WriteByte(c)
+  }
+
+  public WriteRune(r: number): [number, $.GoError] {
+    return this.Writer!.WriteRune(r)
+  }
+
+  public WriteString(s: string): [number, $.GoError] {
+    return this.Writer!.WriteString(s)
+  }
+
+  public ReadFrom(r: io.Reader): [number, $.GoError] {
+    return this.Writer!.ReadFrom(r)
+  }
+
+  // Register this type with the runtime type system
+  static __typeInfo = $.registerStructType(
+    'bufio.ReadWriter',
+    new ReadWriter(),
+    [],
+    ReadWriter,
+    [
+      {
+        name: 'Reader',
+        type: { kind: $.TypeKind.Pointer, elemType: 'bufio.Reader' },
+        embedded: true,
+      },
+      {
+        name: 'Writer',
+        type: { kind: $.TypeKind.Pointer, elemType: 'bufio.Writer' },
+        embedded: true,
+      },
+    ],
+  )
+}
+
+// NewReadWriter allocates a new ReadWriter that dispatches to r and w.
+export function NewReadWriter(
+  r: Reader | null,
+  w: Writer | null,
+): ReadWriter {
+  return new ReadWriter({ Reader: r, Writer: w })
+}
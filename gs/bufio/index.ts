@@ -0,0 +1,29 @@
+export {
+  ErrBufferFull,
+  ErrInvalidUnreadByte,
+  ErrInvalidUnreadRune,
+  ErrNegativeCount,
+  NewReadWriter,
+  NewReader,
+  NewReaderSize,
+  NewWriter,
+  NewWriterSize,
+  ReadWriter,
+  Reader,
+  Writer,
+} from './bufio.js'
+export {
+  ErrAdvanceTooFar,
+  ErrBadReadCount,
+  ErrFinalToken,
+  ErrNegativeAdvance,
+  ErrTooLong,
+  MaxScanTokenSize,
+  NewScanner,
+  ScanBytes,
+  ScanLines,
+  ScanRunes,
+  ScanWords,
+  Scanner,
+} from './scan.js'
+export type { SplitFunc } from './scan.js'
@@ -0,0 +1,129 @@
+import { describe, it, expect } from 'vitest'
+import * as $ from '@goscript/builtin/index.js'
+import * as errors from '@goscript/errors/index.js'
+import * as io from '@goscript/io/index.js'
+import * as strings from '@goscript/strings/index.js'
+import {
+  ErrFinalToken,
+  ErrTooLong,
+  NewScanner,
+  ScanBytes,
+  ScanLines,
+  ScanRunes,
+  ScanWords,
+  SplitFunc,
+} from './scan.js'
+
+// scanAll returns the text of all tokens of s.
+function scanAll(s: string, split?: SplitFunc): string[] {
+  const sc = NewScanner(strings.NewReader(s))
+  if (split !== undefined) {
+    sc.Split(split)
+  }
+  const tokens: string[] = []
+  while (sc.Scan()) {
+    tokens.push(sc.Text())
+  }
+  expect(sc.Err()).toBeNull()
+  return tokens
+}
+
+describe('bufio/Scanner', () => {
+  it('should scan lines', () => {
+    expect(scanAll('a\nb\r\n\nc')).toEqual(['a', 'b', '', 'c'])
+    expect(scanAll('a\n')).toEqual(['a'])
+    expect(scanAll('')).toEqual([])
+  })
+
+  it('should scan words', () => {
+    expect(scanAll('  hello\tbig   world　x ', ScanWords)).toEqual([
+      'hello',
+      'big',
+      'world',
+      'x',
+    ])
+  })
+
+  it('should scan runes and bytes', () => {
+    expect(scanAll('añ世', ScanRunes)).toEqual(['a', 'ñ', '世'])
+    expect(scanAll('añ', ScanBytes).length).toBe(3)
+  })
+
+  it('should turn bad UTF-8 into error runes', () => {
+    const sc = NewScanner({
+      Read: (() => {
+        let done = false
+        return (p: $.Bytes): [number, $.GoError] => {
+          if (done) {
+            return [0, io.EOF]
+          }
+          done = true
+          return [$.copy(p, new Uint8Array([0x61, 0xff, 0xe4, 0xb8])), null]
+        }
+      })(),
+    })
+    sc.Split(ScanRunes)
+    const tokens: string[] = []
+    while (sc.Scan()) {
+      tokens.push(sc.Text())
+    }
+    expect(tokens).toEqual(['a', '�', '�', '�'])
+  })
+
+  it('should use a custom split function', () => {
+    const commas: SplitFunc = (data, atEOF) => {
+      const i = $.bytesIndexByte(data, 0x2c)
+      if (i >= 0) {
+        return [i + 1, $.goSlice(data, 0, i), null]
+      }
+      if (atEOF && $.len(data) > 0) {
+        return [$.len(data), data, ErrFinalToken]
+      }
+      return [0, null, null]
+    }
+    expect(scanAll('x,yy,,z', commas)).toEqual(['x', 'yy', '', 'z'])
+  })
+
+  it('should stop with the split error', () => {
+    const bad = errors.New('bad token')
+    const sc = NewScanner(strings.NewReader('ok\nbad\n'))
+    sc.Split((data, atEOF) => {
+      if ($.bytesToString(data).startsWith('bad')) {
+        return [0, null, bad]
+      }
+      return ScanLines(data, atEOF)
+    })
+    expect(sc.Scan()).toBe(true)
+    expect(sc.Text()).toBe('ok')
+    expect(sc.Scan()).toBe(false)
+    expect(sc.Err()).toBe(bad)
+  })
+
+  it('should fail on tokens longer than the buffer', () => {
+    const sc = NewScanner(strings.NewReader('x'.repeat(100)))
+    sc.Buffer(new Uint8Array(10), 20)
+    expect(sc.Scan()).toBe(false)
+    expect(sc.Err()).toBe(ErrTooLong)
+  })
+
+  it('should scan lines longer than the initial buffer', () => {
+    const long = 'y'.repeat(10000)
+    expect(scanAll('a\n' + long + '\nb')).toEqual(['a', long, 'b'])
+  })
+
+  it('should return a promise over an async reader', async () => {
+    const r = strings.NewReader('one\ntwo\n')
+    const sc = NewScanner({
+      Read: async (p: $.Bytes): Promise<[number, $.GoError]> => {
+        await Promise.resolve()
+        return r.Read($.goSlice(p, 0, Math.min($.len(p), 3)))
+      },
+    } as any)
+    const tokens: string[] = []
+    while (await sc.Scan()) {
+      tokens.push(sc.Text())
+    }
+    expect(tokens).toEqual(['one', 'two'])
+  })
+})
+
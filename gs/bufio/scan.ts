@@ -0,0 +1,502 @@
+import * as $ from '@goscript/builtin/index.js'
+import * as errors from '@goscript/errors/index.js'
+import * as io from '@goscript/io/index.js'
+import * as utf8 from '@goscript/unicode/utf8/index.js'
+import { maxConsecutiveEmptyReads } from './bufio.js'
+import { run, Step } from './step.js'
+import {
+  boolType,
+  bytesType,
+  errorType,
+  intType,
+  stringType,
+} from './typeinfo.js'
+
+// SplitFunc is the signature of the split function used to tokenize the
+// input. The arguments are an initial substring of the remaining
+// unprocessed data and a flag, atEOF, that reports whether the Reader has
+// no more data to give. The return values are the number of bytes to
+// advance the input and the next token to return to the user, if any, plus
+// an error, if any.
+export type SplitFunc =
+  | ((data: $.Bytes, atEOF: boolean) => [number, $.Bytes, $.GoError])
+  | null
+
+// Errors returned by Scanner.
+export const ErrTooLong = errors.New('bufio.Scanner: token too long')
+export const ErrNegativeAdvance = errors.New(
+  'bufio.Scanner: SplitFunc returns negative advance count',
+)
+export const ErrAdvanceTooFar = errors.New(
+  'bufio.Scanner: SplitFunc returns advance count beyond input',
+)
+export const ErrBadReadCount = errors.New(
+  'bufio.Scanner: Read returned impossible count',
+)
+
+// ErrFinalToken is a special sentinel error value. It is intended to be
+// returned by a Split function to indicate that the scanning should stop
+// with no error. If the token being delivered with this error is not nil,
+// the token is the last token.
+export const ErrFinalToken = errors.New('final token')
+
+// MaxScanTokenSize is the maximum size used to buffer a token
+// unless the user provides an explicit buffer with Scanner.Buffer.
+// The actual maximum token size may be smaller as the buffer
+// may need to include, for instance, a newline.
+export const MaxScanTokenSize = 64 * 1024
+
+// Size of initial allocation for buffer.
+const startBufSize = 4096
+
+// Scanner provides a convenient interface for reading data such as
+// a file of newline-delimited lines of text. Successive calls to
+// the Scan method will step through the 'tokens' of a file, skipping
+// the bytes between the tokens. The specification of a token is
+// defined by a split function of type SplitFunc; the default split
+// function breaks the input into lines with line termination stripped.
+//
+// Scan returns a promise when the underlying reader or the split function
+// does, see step.ts.
+export class Scanner {
+  // The reader provided by the client.
+  private r: io.Reader | null = null
+  // The function to split the tokens.
+  private split: SplitFunc = null
+  // Maximum size of a token; modified by tests.
+  private maxTokenSize = 0
+  // Last token returned by split.
+  private token: $.Bytes = null
+  // Buffer used as argument to split.
+  private buf: Uint8Array = new Uint8Array(0)
+  // First non-processed byte in buf.
+  private start = 0
+  // End of data in buf.
+  private end = 0
+  // Sticky error.
+  private err: $.GoError = null
+  // Count of successive empty tokens.
+  private empties = 0
+  // Scan has been called; buffer is in use.
+  private scanCalled = false
+  // Scan has finished.
+  private done = false
+
+  constructor(
+    init?: Partial<{
+      r?: io.Reader | null
+      split?: SplitFunc
+      maxTokenSize?: number
+    }>,
+  ) {
+    if (init) {
+      Object.assign(this, init)
+    }
+  }
+
+  public clone(): Scanner {
+    const cloned = new Scanner({
+      r: this.r,
+      split: this.split,
+      maxTokenSize: this.maxTokenSize,
+    })
+    cloned.token = this.token
+    cloned.buf = this.buf
+    cloned.start = this.start
+    cloned.end = this.end
+    cloned.err = this.err
+    cloned.empties = this.empties
+    cloned.scanCalled = this.scanCalled
+    cloned.done = this.done
+    return cloned
+  }
+
+  // Err returns the first non-EOF error that was encountered by the
+  // Scanner.
+  public Err(): $.GoError {
+    if (this.err === io.EOF) {
+      return null
+    }
+    return this.err
+  }
+
+  // Bytes returns the most recent token generated by a call to Scan.
+  // The underlying array may point to data that will be overwritten
+  // by a subsequent call to Scan. It does no allocation.
+  public Bytes(): $.Bytes {
+    return this.token
+  }
+
+  // Text returns the most recent token generated by a call to Scan
+  // as a newly allocated string holding its bytes.
+  public Text(): string {
+    return $.bytesToString(this.token)
+  }
+
+  // Scan advances the Scanner to the next token, which will then be
+  // available through the Bytes or Text method. It returns false when
+  // there are no more tokens, either by reaching the end of the input or an
+  // error. After Scan returns false, the Err method will return any error
+  // that occurred during scanning, except that if it was io.EOF, Err
+  // will return nil.
+  // Scan panics if the split function returns too many empty
+  // tokens without advancing the input. This is a common error mode for
+  // scanners.
+  public Scan(): boolean {
+    return run(this.scan())
+  }
+
+  private *scan(): Step<boolean> {
+    if (this.done) {
+      return false
+    }
+    this.scanCalled = true
+    // Loop until we have a token.
+    for (;;) {
+      // See if we can get a token with what we already have.
+      // If we've run out of data but have an error, give the split function
+      // a chance to recover any remaining, possibly empty token.
+      if (this.end > this.start || this.err !== null) {
+        const [advance, token, err]: [number, $.Bytes, $.GoError] =
+          yield this.split!(
+            this.buf.subarray(this.start, this.end),
+            this.err !== null,
+          )
+        if (err !== null) {
+          if (err === ErrFinalToken) {
+            this.token = token
+            this.done = true
+            // When token is not nil, it means the scanning stops
+            // with a trailing token, and thus the return value
+            // should be true to indicate the existence of the token.
+            return token !== null
+          }
+          this.setErr(err)
+          return false
+        }
+        if (!this.advance(advance)) {
+          return false
+        }
+        this.token = token
+        if (token !== null) {
+          if (this.err === null || advance > 0) {
+            this.empties = 0
+          } else {
+            // Returning tokens not advancing input at EOF.
+            this.empties++
+            if (this.empties > maxConsecutiveEmptyReads) {
+              $.panic('bufio.Scan: too many empty tokens without progressing')
+            }
+          }
+          return true
+        }
+      }
+      // We cannot generate a token with what we are holding.
+      // If we've already hit EOF or an I/O error, we are done.
+      if (this.err !== null) {
+        // Shut it down.
+        this.start = 0
+        this.end = 0
+        return false
+      }
+      // Must read more data.
+      // First, shift data to beginning of buffer if there's lots of empty
+      // space or space is needed.
+      if (
+        this.start > 0 &&
+        (this.end === this.buf.length || this.start > this.buf.length / 2)
+      ) {
+        this.buf.copyWithin(0, this.start, this.end)
+        this.end -= this.start
+        this.start = 0
+      }
+      // Is the buffer full? If so, resize.
+      if (this.end === this.buf.length) {
+        if (this.buf.length >= this.maxTokenSize) {
+          this.setErr(ErrTooLong)
+          return false
+        }
+        let newSize = this.buf.length * 2
+        if (newSize === 0) {
+          newSize = startBufSize
+        }
+        newSize = Math.min(newSize, this.maxTokenSize)
+        const newBuf = new Uint8Array(newSize)
+        newBuf.set(this.buf.subarray(this.start, this.end))
+        this.buf = newBuf
+        this.end -= this.start
+        this.start = 0
+      }
+      // Finally we can read some input. Make sure we don't get stuck with
+      // a misbehaving Reader. Officially we don't need to do this, but let's
+      // be extra careful: Scanner is for safe, simple jobs.
+      for (let loop = 0; ; ) {
+        const [n, err]: [number, $.GoError] = yield this.r!.Read(
+          this.buf.subarray(this.end),
+        )
+        if (n < 0 || this.buf.length - this.end < n) {
+          this.setErr(ErrBadReadCount)
+          break
+        }
+        this.end += n
+        if (err !== null) {
+          this.setErr(err)
+          break
+        }
+        if (n > 0) {
+          this.empties = 0
+          break
+        }
+        loop++
+        if (loop > maxConsecutiveEmptyReads) {
+          this.setErr(io.ErrNoProgress)
+          break
+        }
+      }
+    }
+  }
+
+  // advance consumes n bytes of the buffer. It reports whether the advance
+  // was legal.
+  private advance(n: number): boolean {
+    if (n < 0) {
+      this.setErr(ErrNegativeAdvance)
+      return false
+    }
+    if (n > this.end - this.start) {
+      this.setErr(ErrAdvanceTooFar)
+      return false
+    }
+    this.start += n
+    return true
+  }
+
+  // setErr records the first error encountered.
+  private setErr(err: $.GoError): void {
+    if (this.err === null || this.err === io.EOF) {
+      this.err = err
+    }
+  }
+
+  // Buffer controls memory allocation by the Scanner.
+  // It sets the initial buffer to use when scanning
+  // and the maximum size of buffer that may be allocated during scanning.
+  // The contents of the buffer are ignored.
+  //
+  // The maximum token size must be less than the larger of max and
+  // cap(buf). If max <= cap(buf), Scan will use this buffer only and do no
+  // allocation.
+  //
+  // Buffer panics if it is called after scanning has started.
+  public Buffer(buf: $.Bytes, max: number): void {
+    if (this.scanCalled) {
+      $.panic('Buffer called after Scan')
+    }
+    this.buf =
+      buf instanceof Uint8Array ?
+        buf
+      : new Uint8Array(buf === null ? 0 : $.cap(buf))
+    this.maxTokenSize = max
+  }
+
+  // Split sets the split function for the Scanner.
+  // The default split function is ScanLines.
+  //
+  // Split panics if it is called after scanning has started.
+  public Split(split: SplitFunc): void {
+    if (this.scanCalled) {
+      $.panic('Split called after Scan')
+    }
+    this.split = split
+  }
+
+  // Register this type with the runtime type system
+  static __typeInfo = $.registerStructType(
+    'bufio.Scanner',
+    new Scanner(),
+    [
+      $.method('Err', [], [errorType]),
+      $.method('Bytes', [], [bytesType]),
+      $.method('Text', [], [stringType]),
+      $.method('Scan', [], [boolType]),
+      $.method('Buffer', [bytesType, intType], []),
+      $.method('Split', ['bufio.SplitFunc'], []),
+    ],
+    Scanner,
+    [],
+  )
+}
+
+// NewScanner returns a new Scanner to read from r.
+// The split function defaults to ScanLines.
+export function NewScanner(r: io.Reader | null): Scanner {
+  return new Scanner({
+    r,
+    split: ScanLines,
+    maxTokenSize: MaxScanTokenSize,
+  })
+}
+
+// Split functions
+
+// ScanBytes is a split function for a Scanner that returns each byte as a
+// token.
+export function ScanBytes(
+  data: $.Bytes,
+  atEOF: boolean,
+): [number, $.Bytes, $.GoError] {
+  if (atEOF && $.len(data) === 0) {
+    return [0, null, null]
+  }
+  return [1, $.goSlice(data, 0, 1), null]
+}
+
+const errorRune = $.stringToBytes(String.fromCodePoint(utf8.RuneError))
+
+// ScanRunes is a split function for a Scanner that returns each
+// UTF-8-encoded rune as a token. The sequence of runes returned is
+// equivalent to that from a range loop over the input as a string, which
+// means that erroneous UTF-8 encodings translate to U+FFFD = "\xef\xbf\xbd".
+// Because of the Scan interface, this makes it impossible for the client to
+// distinguish correctly encoded replacement runes from encoding errors.
+export function ScanRunes(
+  data: $.Bytes,
+  atEOF: boolean,
+): [number, $.Bytes, $.GoError] {
+  if (atEOF && $.len(data) === 0) {
+    return [0, null, null]
+  }
+
+  // Fast path 1: ASCII.
+  if (data![0] < utf8.RuneSelf) {
+    return [1, $.goSlice(data, 0, 1), null]
+  }
+
+  // Fast path 2: Correct UTF-8 decode without error.
+  const [, width] = utf8.DecodeRune(data)
+  if (width > 1) {
+    // It's a valid encoding. Width cannot be one for a correctly encoded
+    // non-ASCII rune.
+    return [width, $.goSlice(data, 0, width), null]
+  }
+
+  // We know it's an error: we have width==1 and implicitly r==utf8.RuneError.
+  // Is the error because there wasn't a full rune to be decoded?
+  // FullRune distinguishes correctly between erroneous and incomplete
+  // encodings.
+  if (!atEOF && !utf8.FullRune(data)) {
+    // Incomplete; get more bytes.
+    return [0, null, null]
+  }
+
+  // We have a real UTF-8 encoding error. Return a properly encoded error
+  // rune but advance only one byte. This matches the behavior of a range
+  // loop over an incorrectly encoded string.
+  return [1, errorRune, null]
+}
+
+// dropCR drops a terminal \r from the data.
+function dropCR(data: $.Bytes): $.Bytes {
+  const n = $.len(data)
+  if (n > 0 && data![n - 1] === 0x0d) {
+    return $.goSlice(data, 0, n - 1)
+  }
+  return data
+}
+
+// ScanLines is a split function for a Scanner that returns each line of
+// text, stripped of any trailing end-of-line marker. The returned line may
+// be empty. The end-of-line marker is one optional carriage return followed
+// by one mandatory newline. In regular expression notation, it is `\r?\n`.
+// The last non-empty line of input will be returned even if it has no
+// newline.
+export function ScanLines(
+  data: $.Bytes,
+  atEOF: boolean,
+): [number, $.Bytes, $.GoError] {
+  if (atEOF && $.len(data) === 0) {
+    return [0, null, null]
+  }
+  const i = $.bytesIndexByte(data, 0x0a)
+  if (i >= 0) {
+    // We have a full newline-terminated line.
+    return [i + 1, dropCR($.goSlice(data, 0, i)), null]
+  }
+  // If we're at EOF, we have a final, non-terminated line. Return it.
+  if (atEOF) {
+    return [$.len(data), dropCR(data), null]
+  }
+  // Request more data.
+  return [0, null, null]
+}
+
+// isSpace reports whether the character is a Unicode white space character.
+// We avoid dependency on the unicode package, but check validity of the
+// implementation in the tests.
+function isSpace(r: number): boolean {
+  if (r <= 0xff) {
+    // Obvious ASCII ones: \t through \r plus space. Plus two Latin-1 oddballs.
+    switch (r) {
+      case 0x20:
+      case 0x09:
+      case 0x0a:
+      case 0x0b:
+      case 0x0c:
+      case 0x0d:
+        return true
+      case 0x85:
+      case 0xa0:
+        return true
+    }
+    return false
+  }
+  // High-valued ones.
+  if (0x2000 <= r && r <= 0x200a) {
+    return true
+  }
+  switch (r) {
+    case 0x1680:
+    case 0x2028:
+    case 0x2029:
+    case 0x202f:
+    case 0x205f:
+    case 0x3000:
+      return true
+  }
+  return false
+}
+
+// ScanWords is a split function for a Scanner that returns each
+// space-separated word of text, with surrounding spaces deleted. It will
+// never return an empty string. The definition of space is set by
+// unicode.IsSpace.
+export function ScanWords(
+  data: $.Bytes,
+  atEOF: boolean,
+): [number, $.Bytes, $.GoError] {
+  const n = $.len(data)
+  // Skip leading spaces.
+  let start = 0
+  for (let width = 0; start < n; start += width) {
+    let r: number
+    ;[r, width] = utf8.DecodeRune($.goSlice(data, start))
+    if (!isSpace(r)) {
+      break
+    }
+  }
+  // Scan until space, marking end of word.
+  for (let width = 0, i = start; i < n; i += width) {
+    let r: number
+    ;[r, width] = utf8.DecodeRune($.goSlice(data, i))
+    if (isSpace(r)) {
+      return [i + width, $.goSlice(data, start, i), null]
+    }
+  }
+  // If we're at EOF, we have a final, non-empty, non-terminated word.
+  // Return it.
+  if (atEOF && n > start) {
+    return [n, $.goSlice(data, start), null]
+  }
+  // Request more data.
+  return [start, null, null]
+}
@@ -0,0 +1,34 @@
+// The underlying reader or writer of a bufio type may be implemented by
+// compiled Go code that blocks, in which case its methods return promises.
+// The bufio methods are written as generators that yield the results of
+// calls into it, and run drives them synchronously for as long as those
+// results are plain values. Over a synchronous reader or writer every method
+// returns its result directly, so it can still be called synchronously, as
+// fmt.Fprintf and io.Copy do. Once a call returns a promise the method
+// returns one too. Compiled code awaits the methods, see meta.json.
+
+// A Step is the body of a method that yields the results of calls into the
+// underlying reader or writer.
+export type Step<T> = Generator<unknown, T, any>
+
+// run runs step and returns its result, or a promise of it if the step had
+// to wait on one.
+export function run<T>(step: Step<T>): T {
+  let res = step.next()
+  while (!res.done) {
+    if (res.value instanceof Promise) {
+      return resume(step, res.value) as unknown as T
+    }
+    res = step.next(res.value)
+  }
+  return res.value
+}
+
+// resume finishes running step asynchronously once it yields a promise.
+async function resume<T>(step: Step<T>, pending: Promise<unknown>): Promise<T> {
+  let res = step.next(await pending)
+  while (!res.done) {
+    res = step.next(await res.value)
+  }
+  return res.value
+}
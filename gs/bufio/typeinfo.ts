@@ -0,0 +1,22 @@
+import * as $ from '@goscript/builtin/index.js'
+
+// Type information for the methods of the bufio types.
+
+export const intType: $.TypeInfo = { kind: $.TypeKind.Basic, name: 'int' }
+export const int64Type: $.TypeInfo = { kind: $.TypeKind.Basic, name: 'int64' }
+export const byteType: $.TypeInfo = { kind: $.TypeKind.Basic, name: 'byte' }
+export const runeType: $.TypeInfo = { kind: $.TypeKind.Basic, name: 'rune' }
+export const boolType: $.TypeInfo = { kind: $.TypeKind.Basic, name: 'bool' }
+export const stringType: $.TypeInfo = {
+  kind: $.TypeKind.Basic,
+  name: 'string',
+}
+export const bytesType: $.TypeInfo = {
+  kind: $.TypeKind.Slice,
+  elemType: byteType,
+}
+export const errorType: $.TypeInfo = {
+  kind: $.TypeKind.Interface,
+  name: 'GoError',
+  methods: [{ name: 'Error', args: [], returns: [{ type: stringType }] }],
+}
@@ -1,20 +1,27 @@
 import type { Slice, SliceProxy } from './slice.js'
 import { isSliceProxy } from './slice.js'
+import { DeferFrame, GoPanic } from './defer.js'
+import { Complex } from './complex.js'
+import { runeString } from './string.js'
 
 /**
  * Implementation of Go's built-in println function
  * @param args Arguments to print
  */
 export function println(...args: any[]): void {
-  console.log(...args)
+  console.log(
+    ...args.map((arg) =>
+      typeof arg === 'bigint' || arg instanceof Complex ? arg.toString() : arg,
+    ),
+  )
 }
 
 /**
  * Implementation of Go's built-in panic function
- * @param args Arguments passed to panic
+ * @param value The value passed to panic, returned unchanged by recover()
  */
-export function panic(...args: any[]): void {
-  throw new Error(`panic: ${args.map((arg) => String(arg)).join(' ')}`)
+export function panic(value: any): never {
+  throw new GoPanic(value)
 }
 
 // Bytes represents all valid []byte representations in TypeScript
@@ -326,7 +333,8 @@ export function max(a: number, b: number): number {
 
 /**
  * Converts a rune (number) or string to a string.
- * This is used to replace String.fromCharCode() in Go string(rune) conversions.
+ * This is used for Go string(rune) conversions, in which invalid runes become
+ * the replacement character U+FFFD.
  * Since sometimes single-char rune literals are compiled to strings, this function
  * needs to handle both numbers (runes) and strings.
  *
@@ -337,13 +345,11 @@ export function runeOrStringToString(runeOrString: number | string): string {
   if (typeof runeOrString === 'string') {
     return runeOrString
   }
-  // For numbers, use String.fromCharCode to convert the rune to a string
-  return String.fromCharCode(runeOrString)
+  return runeString(runeOrString)
 }
 
-// Panic recovery function (simplified implementation)
-export function recover(): any {
-  // In a real implementation, this would interact with Go's panic/recover mechanism
-  // For now, return null to indicate no panic was recovered
-  return null
+// recover implements Go's built-in recover. Only functions called directly by
+// a deferred call receive its frame; recover() returns null everywhere else.
+export function recover(frame?: DeferFrame): any {
+  return frame ? frame.recover() : null
 }
@@ -1,3 +1,5 @@
+import { park } from './scheduler.js'
+
 /**
  * Represents the result of a channel receive operation with 'ok' value
  */
@@ -106,7 +108,11 @@ export async function selectStatement<T, V = void>(
   if (cases.length === 0 && !hasDefault) {
     // Go spec: If there are no cases, the select statement blocks forever.
     // Emulate blocking forever with a promise that never resolves.
-    return new Promise<[boolean, V]>(() => {}) // Promise never resolves
+    return park(
+      new Promise<[boolean, V]>(() => {}),
+      'select (no cases)',
+      selectStatement,
+    )
   }
 
   // 1. Check for ready (non-blocking) operations
@@ -195,10 +201,18 @@ export async function selectStatement<T, V = void>(
   // If all non-default cases have nil channels, we effectively block forever
   if (blockingPromises.length === 0) {
     // No valid channels to operate on, block forever (unless there's a default)
-    return new Promise<[boolean, V]>(() => {}) // Promise never resolves
+    return park(
+      new Promise<[boolean, V]>(() => {}),
+      'select',
+      selectStatement,
+    )
   }
 
-  const result = await Promise.race(blockingPromises)
+  const result = await park(
+    Promise.race(blockingPromises),
+    'select',
+    selectStatement,
+  )
   // Execute onSelected handler for the selected case
   const selectedCase = cases.find((c) => c.id === result.id)
   if (selectedCase && selectedCase.onSelected) {
@@ -223,9 +237,12 @@ export async function chanSend<T>(
 ): Promise<void> {
   if (channel === null) {
     // In Go, sending to a nil channel blocks forever
-    return new Promise<void>(() => {}) // Promise that never resolves
+    return park(new Promise<void>(() => {}), 'chan send (nil chan)', chanSend)
+  }
+  if (channel.canSendNonBlocking()) {
+    return channel.send(value)
   }
-  return channel.send(value)
+  return park(channel.send(value), 'chan send', chanSend)
 }
 
 /**
@@ -239,9 +256,12 @@ export async function chanRecv<T>(
 ): Promise<T> {
   if (channel === null) {
     // In Go, receiving from a nil channel blocks forever
-    return new Promise<T>(() => {}) // Promise that never resolves
+    return park(new Promise<T>(() => {}), 'chan receive (nil chan)', chanRecv)
   }
-  return channel.receive()
+  if (channel.canReceiveNonBlocking()) {
+    return channel.receive()
+  }
+  return park(channel.receive(), 'chan receive', chanRecv)
 }
 
 /**
@@ -255,9 +275,16 @@ export async function chanRecvWithOk<T>(
 ): Promise<ChannelReceiveResult<T>> {
   if (channel === null) {
     // In Go, receiving from a nil channel blocks forever
-    return new Promise<ChannelReceiveResult<T>>(() => {}) // Promise that never resolves
+    return park(
+      new Promise<ChannelReceiveResult<T>>(() => {}),
+      'chan receive (nil chan)',
+      chanRecvWithOk,
+    )
+  }
+  if (channel.canReceiveNonBlocking()) {
+    return channel.receiveWithOk()
   }
-  return channel.receiveWithOk()
+  return park(channel.receiveWithOk(), 'chan receive', chanRecvWithOk)
 }
 
 /**
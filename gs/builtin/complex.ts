@@ -0,0 +1,160 @@
+// Support for Go's complex64 and complex128 types, which are both
+// represented as an immutable Complex with float64 parts.
+
+/**
+ * Complex is the representation of Go's complex64 and complex128 values.
+ * Values are immutable, so they can be shared like numbers.
+ */
+export class Complex {
+  constructor(
+    public readonly re: number,
+    public readonly im: number,
+  ) {}
+
+  /**
+   * toString formats the value like Go's %v verb, for example (1+2i).
+   */
+  public toString(): string {
+    return `(${formatComplexPart(this.re)}${formatComplexPart(this.im, true)}i)`
+  }
+}
+
+// formatComplexPart formats a part of a complex value for toString.
+function formatComplexPart(v: number, sign = false): string {
+  let s: string
+  if (Number.isNaN(v)) {
+    s = 'NaN'
+  } else if (!Number.isFinite(v)) {
+    s = v > 0 ? '+Inf' : '-Inf'
+  } else {
+    s = String(v)
+    if (Object.is(v, -0)) {
+      s = '-0'
+    }
+  }
+  if (sign && s[0] !== '+' && s[0] !== '-') {
+    s = '+' + s
+  }
+  return s
+}
+
+/**
+ * Implementation of Go's built-in complex function.
+ * @param re The real part.
+ * @param im The imaginary part.
+ */
+export function complex(re: number, im: number): Complex {
+  return new Complex(re, im)
+}
+
+/**
+ * Implementation of Go's built-in real function.
+ * @param c The complex value.
+ */
+export function real(c: Complex): number {
+  return c.re
+}
+
+/**
+ * Implementation of Go's built-in imag function.
+ * @param c The complex value.
+ */
+export function imag(c: Complex): number {
+  return c.im
+}
+
+/**
+ * complexAdd implements x + y on complex values.
+ */
+export function complexAdd(x: Complex, y: Complex): Complex {
+  return new Complex(x.re + y.re, x.im + y.im)
+}
+
+/**
+ * complexSub implements x - y on complex values.
+ */
+export function complexSub(x: Complex, y: Complex): Complex {
+  return new Complex(x.re - y.re, x.im - y.im)
+}
+
+/**
+ * complexMul implements x * y on complex values.
+ */
+export function complexMul(x: Complex, y: Complex): Complex {
+  return new Complex(x.re * y.re - x.im * y.im, x.re * y.im + x.im * y.re)
+}
+
+/**
+ * complexDiv implements n / m on complex values like the Go runtime: it
+ * uses Smith's algorithm and never panics, dividing by zero yields
+ * infinities or NaN.
+ */
+export function complexDiv(n: Complex, m: Complex): Complex {
+  let e: number
+  let f: number
+  if (Math.abs(m.re) >= Math.abs(m.im)) {
+    const ratio = m.im / m.re
+    const denom = m.re + ratio * m.im
+    e = (n.re + n.im * ratio) / denom
+    f = (n.im - n.re * ratio) / denom
+  } else {
+    const ratio = m.re / m.im
+    const denom = m.im + ratio * m.re
+    e = (n.re * ratio + n.im) / denom
+    f = (n.im * ratio - n.re) / denom
+  }
+
+  if (Number.isNaN(e) && Number.isNaN(f)) {
+    // Correct the result to infinities and zeros where C99 requires it
+    let a = n.re
+    let b = n.im
+    let c = m.re
+    let d = m.im
+    if (c === 0 && d === 0 && (!Number.isNaN(a) || !Number.isNaN(b))) {
+      e = copySign(Infinity, c) * a
+      f = copySign(Infinity, c) * b
+    } else if (
+      (isInf(a) || isInf(b)) &&
+      Number.isFinite(c) &&
+      Number.isFinite(d)
+    ) {
+      a = copySign(isInf(a) ? 1 : 0, a)
+      b = copySign(isInf(b) ? 1 : 0, b)
+      e = Infinity * (a * c + b * d)
+      f = Infinity * (b * c - a * d)
+    } else if (
+      (isInf(c) || isInf(d)) &&
+      Number.isFinite(a) &&
+      Number.isFinite(b)
+    ) {
+      c = copySign(isInf(c) ? 1 : 0, c)
+      d = copySign(isInf(d) ? 1 : 0, d)
+      e = 0 * (a * c + b * d)
+      f = 0 * (b * c - a * d)
+    }
+  }
+  return new Complex(e, f)
+}
+
+/**
+ * complexNeg implements -x on a complex value.
+ */
+export function complexNeg(x: Complex): Complex {
+  return new Complex(-x.re, -x.im)
+}
+
+/**
+ * complexEqual implements x == y on complex values.
+ */
+export function complexEqual(x: Complex, y: Complex): boolean {
+  return x.re === y.re && x.im === y.im
+}
+
+function isInf(v: number): boolean {
+  return v === Infinity || v === -Infinity
+}
+
+function copySign(v: number, sign: number): number {
+  const negative = sign < 0 || Object.is(sign, -0)
+  return negative ? -Math.abs(v) : Math.abs(v)
+}
@@ -1,31 +1,147 @@
+/**
+ * GoPanic is the error thrown by panic(). It carries the original Go panic
+ * value so that recover() can return it unchanged.
+ */
+export class GoPanic extends Error {
+  public readonly value: any
+
+  constructor(value: any) {
+    super(`panic: ${formatPanicValue(value)}`)
+    this.name = 'GoPanic'
+    this.value = value
+  }
+}
+
+/**
+ * PanicNilError is the value recovered after panic(nil), matching Go 1.21+.
+ */
+export class PanicNilError {
+  Error(): string {
+    return 'panic called with nil argument'
+  }
+
+  RuntimeError(): void {}
+}
+
+/**
+ * formatPanicValue formats a panic value the way the Go runtime prints it:
+ * errors use Error(), Stringers use String(), everything else is printed as-is.
+ */
+export function formatPanicValue(value: any): string {
+  if (value === null || value === undefined) {
+    return 'nil'
+  }
+  if (typeof value === 'object') {
+    if (typeof value.Error === 'function') {
+      return String(value.Error())
+    }
+    if (typeof value.String === 'function') {
+      return String(value.String())
+    }
+  }
+  return String(value)
+}
+
+/**
+ * panicValue extracts the Go value to return from recover() for a thrown value.
+ * Errors raised by the JavaScript engine or the runtime are surfaced as Go
+ * runtime errors.
+ */
+export function panicValue(thrown: unknown): any {
+  if (thrown instanceof GoPanic) {
+    return thrown.value
+  }
+  if (thrown instanceof Error) {
+    const message = thrown.message
+    return {
+      Error: () => message,
+      RuntimeError: () => {},
+    }
+  }
+  return thrown
+}
+
+/**
+ * DeferFrame holds the panic state shared by both defer stack variants.
+ * Each deferred function receives the frame running it, which is the only
+ * way to recover from its panic: like Go, recover() only stops a panic when
+ * called directly by a deferred function.
+ */
+export abstract class DeferFrame {
+  protected panicking = false
+  protected thrown: unknown = undefined
+
+  /**
+   * Records a panic raised by the function body so that deferred functions
+   * can recover from it.
+   * @param thrown The value thrown by the function body.
+   */
+  panic(thrown: unknown): void {
+    this.panicking = true
+    this.thrown = thrown
+  }
+
+  /**
+   * Stops the current panic and returns its value, or null if the frame is
+   * not panicking.
+   */
+  recover(): any {
+    if (!this.panicking) {
+      return null
+    }
+    const value = panicValue(this.thrown)
+    this.panicking = false
+    this.thrown = undefined
+    return value ?? new PanicNilError()
+  }
+
+  // Rethrows the pending panic, if any, once all deferred functions ran.
+  protected rethrow(): void {
+    if (this.panicking) {
+      const thrown = this.thrown
+      this.panicking = false
+      this.thrown = undefined
+      throw thrown
+    }
+  }
+}
+
 /**
  * DisposableStack manages synchronous disposable resources, mimicking Go's defer behavior.
  * Functions added via `defer` are executed in LIFO order when the stack is disposed.
  * Implements the `Disposable` interface for use with `using` declarations.
  */
-export class DisposableStack implements Disposable {
-  private stack: (() => void)[] = []
+export class DisposableStack extends DeferFrame implements Disposable {
+  private stack: ((frame: DeferFrame) => void)[] = []
 
   /**
    * Adds a function to be executed when the stack is disposed.
-   * @param fn The function to defer.
+   * @param fn The function to defer, called with this frame.
    */
-  defer(fn: () => void): void {
+  defer(fn: (frame: DeferFrame) => void): void {
     this.stack.push(fn)
   }
 
   /**
-   * Disposes of the resources in the stack by executing the deferred functions
-   * in Last-In, First-Out (LIFO) order.
-   * If a deferred function throws an error, disposal stops, and the error is rethrown,
-   * similar to Go's panic behavior during defer execution.
+   * Runs the deferred functions in Last-In, First-Out (LIFO) order.
+   * Like Go, every deferred function runs even if an earlier one panics; a
+   * panic raised by a deferred function replaces the pending one. If a panic
+   * is still pending once the stack is empty, it is rethrown.
    */
-  [Symbol.dispose](): void {
-    // Emulate Go: if a deferred throws, stop and rethrow
+  dispose(): void {
     while (this.stack.length) {
       const fn = this.stack.pop()!
-      fn()
+      try {
+        fn(this)
+      } catch (e) {
+        this.panic(e)
+      }
     }
+    this.rethrow()
+  }
+
+  [Symbol.dispose](): void {
+    this.dispose()
   }
 }
 
@@ -34,25 +150,37 @@ export class DisposableStack implements Disposable {
  * Functions added via `defer` are executed sequentially in LIFO order when the stack is disposed.
  * Implements the `AsyncDisposable` interface for use with `await using` declarations.
  */
-export class AsyncDisposableStack implements AsyncDisposable {
-  private stack: (() => Promise<void> | void)[] = []
+export class AsyncDisposableStack
+  extends DeferFrame
+  implements AsyncDisposable
+{
+  private stack: ((frame: DeferFrame) => Promise<void> | void)[] = []
 
   /**
    * Adds a synchronous or asynchronous function to be executed when the stack is disposed.
-   * @param fn The function to defer. Can return void or a Promise<void>.
+   * @param fn The function to defer, called with this frame. Can return void or a Promise<void>.
    */
-  defer(fn: () => Promise<void> | void): void {
+  defer(fn: (frame: DeferFrame) => Promise<void> | void): void {
     this.stack.push(fn)
   }
 
   /**
-   * Asynchronously disposes of the resources in the stack by executing the deferred functions
-   * sequentially in Last-In, First-Out (LIFO) order. It awaits each function if it returns a promise.
+   * Asynchronously runs the deferred functions sequentially in LIFO order,
+   * awaiting each one. Panics are handled as in DisposableStack.dispose.
    */
-  async [Symbol.asyncDispose](): Promise<void> {
-    // Execute in LIFO order, awaiting each potentially async function
-    for (let i = this.stack.length - 1; i >= 0; --i) {
-      await this.stack[i]()
+  async dispose(): Promise<void> {
+    while (this.stack.length) {
+      const fn = this.stack.pop()!
+      try {
+        await fn(this)
+      } catch (e) {
+        this.panic(e)
+      }
     }
+    this.rethrow()
+  }
+
+  async [Symbol.asyncDispose](): Promise<void> {
+    await this.dispose()
   }
 }
@@ -6,3 +6,9 @@ export * from './type.js'
 export * from './varRef.js'
 export * from './defer.js'
 export * from './errors.js'
+export * from './int64.js'
+export * from './integer.js'
+export * from './complex.js'
+export * from './string.js'
+export * from './scheduler.js'
+export * from './traceback.js'
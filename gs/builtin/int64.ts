@@ -0,0 +1,93 @@
+// Support for code compiled with the BigInt64 option, which represents Go's
+// int64 and uint64 as bigint instead of number.
+
+let bigInt64Mode = false
+
+/**
+ * setBigInt64Mode is called by code compiled with the BigInt64 option so that
+ * handwritten packages return int64 and uint64 results as bigint.
+ * @param enabled Whether 64-bit integers are represented as bigint.
+ */
+export function setBigInt64Mode(enabled: boolean): void {
+  bigInt64Mode = enabled
+}
+
+/**
+ * isBigInt64Mode reports whether 64-bit integers are represented as bigint.
+ */
+export function isBigInt64Mode(): boolean {
+  return bigInt64Mode
+}
+
+// The result of converting NaN or an out of range float to a 64-bit integer,
+// matching Go on amd64.
+const invalidInt64 = -(1n << 63n)
+
+/**
+ * int64 implements the conversion of a number or bigint to int64 when it is
+ * represented as bigint: floats are truncated toward zero and the result
+ * wraps to 64 bits.
+ * @param value The value to convert.
+ */
+export function int64(value: number | bigint): bigint {
+  if (typeof value === 'bigint') {
+    return BigInt.asIntN(64, value)
+  }
+  if (!Number.isFinite(value)) {
+    return invalidInt64
+  }
+  return BigInt.asIntN(64, BigInt(Math.trunc(value)))
+}
+
+/**
+ * uint64 implements the conversion of a number or bigint to uint64 when it is
+ * represented as bigint: floats are truncated toward zero and the result
+ * wraps to 64 bits.
+ * @param value The value to convert.
+ */
+export function uint64(value: number | bigint): bigint {
+  if (typeof value === 'bigint') {
+    return BigInt.asUintN(64, value)
+  }
+  if (!Number.isFinite(value)) {
+    return BigInt.asUintN(64, invalidInt64)
+  }
+  return BigInt.asUintN(64, BigInt(Math.trunc(value)))
+}
+
+/**
+ * int64Result returns a 64-bit integer computed by a handwritten package in
+ * the representation of the current mode. It is typed as any since the
+ * representation is only known at runtime.
+ * @param value The exact value.
+ */
+export function int64Result(value: bigint): any {
+  return bigInt64Mode ? value : Number(value)
+}
+
+/**
+ * bigIntDiv implements Go's division of 64-bit integers represented as
+ * bigint. Like the bigint operator, the quotient is truncated toward zero,
+ * but dividing by zero panics with a Go runtime error.
+ * @param x The dividend.
+ * @param y The divisor.
+ */
+export function bigIntDiv(x: bigint, y: bigint): bigint {
+  if (y === 0n) {
+    throw new Error('runtime error: integer divide by zero')
+  }
+  return x / y
+}
+
+/**
+ * bigIntRem implements Go's remainder of 64-bit integers represented as
+ * bigint, which panics when dividing by zero.
+ * @param x The dividend.
+ * @param y The divisor.
+ */
+export function bigIntRem(x: bigint, y: bigint): bigint {
+  if (y === 0n) {
+    throw new Error('runtime error: integer divide by zero')
+  }
+  return x % y
+}
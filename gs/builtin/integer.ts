@@ -0,0 +1,69 @@
+// Integer operations whose Go semantics differ from the JavaScript operators
+// on numbers. Fixed-width results are truncated by the generated code.
+
+/**
+ * intDiv implements Go's integer division: the quotient is truncated toward
+ * zero and dividing by zero panics.
+ * @param x The dividend.
+ * @param y The divisor.
+ */
+export function intDiv(x: number, y: number): number {
+  if (y === 0) {
+    throw new Error('runtime error: integer divide by zero')
+  }
+  return Math.trunc(x / y)
+}
+
+/**
+ * intRem implements Go's integer remainder, which has the sign of the
+ * dividend like the JavaScript operator. Dividing by zero panics.
+ * @param x The dividend.
+ * @param y The divisor.
+ */
+export function intRem(x: number, y: number): number {
+  if (y === 0) {
+    throw new Error('runtime error: integer divide by zero')
+  }
+  return x % y
+}
+
+// checkShift panics on negative shift counts like Go.
+function checkShift(n: number): void {
+  if (n < 0) {
+    throw new Error('runtime error: negative shift amount')
+  }
+}
+
+/**
+ * shl32 shifts an integer of at most 32 bits left. Unlike the JavaScript
+ * operator, which uses the count modulo 32, counts of 32 or more shift out
+ * all bits.
+ * @param x The value to shift.
+ * @param n The shift count.
+ */
+export function shl32(x: number, n: number): number {
+  checkShift(n)
+  return n >= 32 ? 0 : x << n
+}
+
+/**
+ * shr32 shifts a signed integer of at most 32 bits right, filling with the
+ * sign bit. Counts of 32 or more leave only the sign.
+ * @param x The value to shift.
+ * @param n The shift count.
+ */
+export function shr32(x: number, n: number): number {
+  checkShift(n)
+  return n >= 32 ? (x < 0 ? -1 : 0) : x >> n
+}
+
+/**
+ * ushr32 shifts an unsigned integer of at most 32 bits right, filling with
+ * zeros. Counts of 32 or more shift out all bits.
+ * @param x The value to shift.
+ * @param n The shift count.
+ */
+export function ushr32(x: number, n: number): number {
+  checkShift(n)
+  return n >= 32 ? 0 : x >>> n
+}
@@ -1,3 +1,7 @@
+import { Complex } from './complex.js'
+import { TypeKind, structFields, type TypeInfo } from './type.js'
+import type { VarRef } from './varRef.js'
+
 /**
  * Creates a new map (TypeScript Map).
  * @returns A new TypeScript Map.
@@ -57,3 +61,455 @@ export const deleteMapEntry = <K, V>(map: Map<K, V> | null, key: K): void => {
 export const mapHas = <K, V>(map: Map<K, V> | null, key: K): boolean => {
   return map?.has(key) ?? false
 }
+
+// The state of the pseudo-random generator randomizing map iteration order,
+// or null if maps are iterated in insertion order.
+let mapIterationState: number | null = null
+
+/**
+ * setMapIterationSeed makes range loops over maps iterate in a pseudo-random
+ * order like Go, instead of insertion order, which reveals code depending on
+ * the iteration order. The same seed produces the same orders. It can also
+ * be enabled with the GOSCRIPT_MAP_SEED environment variable.
+ * @param seed The seed of the order, or null to use insertion order.
+ */
+export function setMapIterationSeed(seed: number | null): void {
+  mapIterationState = seed === null ? null : seed >>> 0
+}
+
+{
+  const envSeed = (globalThis as any).process?.env?.GOSCRIPT_MAP_SEED
+  if (envSeed && Number.isFinite(Number(envSeed))) {
+    setMapIterationSeed(Number(envSeed))
+  }
+}
+
+// nextMapRandom returns the next pseudo-random integer below n (mulberry32).
+function nextMapRandom(n: number): number {
+  mapIterationState = ((mapIterationState ?? 0) + 0x6d2b79f5) >>> 0
+  let t = mapIterationState
+  t = Math.imul(t ^ (t >>> 15), t | 1)
+  t ^= t + Math.imul(t ^ (t >>> 7), t | 61)
+  return Math.floor((((t ^ (t >>> 14)) >>> 0) / 4294967296) * n)
+}
+
+// randomMapEntries iterates over a snapshot of the keys of a map in random
+// order. Like Go, entries deleted before they are reached are skipped.
+function* randomMapEntries<K, V>(map: Map<K, V>): Generator<[K, V]> {
+  const keys = Array.from(map.keys())
+  for (let i = keys.length - 1; i > 0; i--) {
+    const j = nextMapRandom(i + 1)
+    const key = keys[i]
+    keys[i] = keys[j]
+    keys[j] = key
+  }
+  for (const key of keys) {
+    if (map.has(key)) {
+      yield [key, map.get(key)!]
+    }
+  }
+}
+
+/**
+ * mapEntries returns the entries of a map for a range loop, in insertion
+ * order or in pseudo-random order if enabled by setMapIterationSeed.
+ * @param map The map to iterate over.
+ */
+export function mapEntries<K, V>(map: Map<K, V> | null): Iterable<[K, V]> {
+  if (!map) {
+    return []
+  }
+  if (mapIterationState === null) {
+    return map.entries()
+  }
+  return randomMapEntries(map)
+}
+
+// Identities assigned to objects compared by reference in hashed map keys.
+const keyObjectIds = new WeakMap<object, number>()
+let nextKeyObjectId = 1
+
+// keyObjectId returns the identity of an object compared by reference.
+function keyObjectId(value: object): string {
+  let id = keyObjectIds.get(value)
+  if (id === undefined) {
+    id = nextKeyObjectId++
+    keyObjectIds.set(value, id)
+  }
+  return '@' + id
+}
+
+// Number of the next NaN key. NaN is not equal to itself, so every NaN key
+// is a different key.
+let nextNaNKey = 1
+
+// nanKey returns a key different from any other key.
+function nanKey(): string {
+  return 'NaN' + nextNaNKey++
+}
+
+// isPointerFieldType checks if a struct field type info is a pointer, which
+// is compared by reference even though pointers to structs are represented
+// by the struct object itself.
+function isPointerFieldType(info: TypeInfo | string | undefined): boolean {
+  return typeof info === 'object' && info.kind === TypeKind.Pointer
+}
+
+// isInterfaceFieldType checks if a struct field type info is an interface,
+// whose structs compare by value only if marked by markStructValue.
+function isInterfaceFieldType(info: TypeInfo | string | undefined): boolean {
+  return typeof info === 'object' && info.kind === TypeKind.Interface
+}
+
+// Marks struct values held in interfaces, see markStructValue.
+const structValueMark = Symbol('goscript.structValue')
+
+/**
+ * markStructValue marks a copy of a struct value converted to an interface,
+ * which tells it apart from a pointer to a struct, as both are represented
+ * by the struct object. Maps with interface keys compare marked structs by
+ * value and other struct objects by reference.
+ * @param value The struct value held in the interface.
+ * @returns The value.
+ */
+export function markStructValue<T>(value: T): T {
+  if (value !== null && typeof value === 'object') {
+    ;(value as any)[structValueMark] = true
+  }
+  return value
+}
+
+/**
+ * isStructValue checks if a struct object is a struct value rather than a
+ * pointer to a struct, given whether it is held in an interface.
+ * @param value The struct object.
+ * @param inInterface Whether the value is held in an interface.
+ */
+export function isStructValue(value: any, inInterface: boolean): boolean {
+  return !inInterface || value[structValueMark] === true
+}
+
+// isStruct checks if a value is a struct object.
+function isStruct(value: any): boolean {
+  return !!value._fields && typeof value.clone === 'function'
+}
+
+// structTypeKey identifies the type of a struct object by its registered
+// package-qualified name, or by its class for unregistered structs.
+function structTypeKey(value: any): string {
+  const name = value.constructor?.__typeInfo?.name
+  return typeof name === 'string' && name !== '' ?
+      JSON.stringify(name)
+    : keyObjectId(value.constructor)
+}
+
+// encodeKey encodes a value as a string that is equal for values that are
+// equal in Go: structs and arrays compare by their fields and elements,
+// pointers, channels and other objects by reference. inInterface reports
+// whether the value is held in an interface, where struct objects are
+// pointers unless marked by markStructValue.
+function encodeKey(value: any, inInterface: boolean): string {
+  if (value === null || value === undefined) {
+    return 'nil'
+  }
+  switch (typeof value) {
+    case 'string':
+      return JSON.stringify(value)
+    case 'number':
+      return Number.isNaN(value) ? nanKey() : 'n' + value
+    case 'bigint':
+      return 'b' + value
+    case 'boolean':
+      return value ? 'true' : 'false'
+    case 'object':
+      break
+    default:
+      return keyObjectId(value)
+  }
+  if (Array.isArray(value) || value instanceof Uint8Array) {
+    const parts: string[] = []
+    for (const elem of value) {
+      parts.push(encodeKey(elem, false))
+    }
+    return '[' + parts.join(',') + ']'
+  }
+  if (value instanceof Complex) {
+    return 'c' + value.re + ',' + value.im
+  }
+  if (isStruct(value) && isStructValue(value, inInterface)) {
+    const fieldTypes = new Map<string, TypeInfo | string>()
+    for (const field of structFields(value.constructor?.__typeInfo?.fields)) {
+      fieldTypes.set(field.name, field.type)
+    }
+    const parts: string[] = []
+    for (const [name, ref] of Object.entries<VarRef<any>>(value._fields)) {
+      const field = ref.value
+      const fieldType = fieldTypes.get(name)
+      parts.push(
+        isPointerFieldType(fieldType) && field !== null ?
+          keyObjectId(field)
+        : encodeKey(field, isInterfaceFieldType(fieldType)),
+      )
+    }
+    return structTypeKey(value) + '{' + parts.join(',') + '}'
+  }
+  return keyObjectId(value)
+}
+
+/**
+ * hashKey returns the key under which a HashMap stores a Go map key.
+ * Primitive keys are used directly; structs and arrays are encoded as
+ * strings distinct from any string key.
+ * @param key The Go map key.
+ * @param inInterface Whether the key type is an interface.
+ */
+export function hashKey(key: any, inInterface: boolean = false): any {
+  if (key !== null && (typeof key === 'object' || typeof key === 'function')) {
+    return '\u0000' + encodeKey(key, inInterface)
+  }
+  if (Number.isNaN(key)) {
+    return '\u0000' + nanKey()
+  }
+  return typeof key === 'string' ? 's' + key : key
+}
+
+// copyKey copies a struct or array key so that later changes to the
+// original do not affect the map, like Go copying keys on insertion.
+// Pointers to structs held in interface keys are not copied.
+// Typed keys are stored as their value, see typedMapKey.
+function copyKey<K>(key: K, inInterface: boolean): K {
+  if (key instanceof TypedMapKey) {
+    return key.value
+  }
+  if (Array.isArray(key)) {
+    return key.map((elem) => copyKey(elem, false)) as K
+  }
+  const value = key as any
+  if (value && isStruct(value) && isStructValue(value, inInterface)) {
+    const copy = value.clone()
+    return value[structValueMark] ? markStructValue(copy) : copy
+  }
+  return key
+}
+
+/**
+ * HashMap is a map whose keys compare by Go equality rather than by
+ * reference, used for maps keyed by structs, arrays, interfaces and type
+ * parameters. It extends Map so that it can be used wherever a map is
+ * expected; the entries are stored in the underlying Map under their hash
+ * key.
+ *
+ * Pointers to structs share their representation with struct values, so
+ * maps with interface keys use InterfaceKeyMap, which tells them apart.
+ */
+export class HashMap<K, V> extends Map<K, V> {
+  // interfaceKeys reports whether the key type is an interface.
+  protected get interfaceKeys(): boolean {
+    return false
+  }
+
+  constructor(entries?: Iterable<readonly [K, V]> | null) {
+    super()
+    if (entries) {
+      for (const [key, value] of entries) {
+        this.set(key, value)
+      }
+    }
+  }
+
+  // storageKey returns the key the entry of key is stored under in the
+  // underlying Map, or would be stored under if there is none.
+  protected storageKey(key: K): any {
+    return hashKey(key, this.interfaceKeys)
+  }
+
+  private entry(key: K): [K, V] | undefined {
+    return super.get(this.storageKey(key)) as [K, V] | undefined
+  }
+
+  get(key: K): V | undefined {
+    return this.entry(key)?.[1]
+  }
+
+  has(key: K): boolean {
+    return super.has(this.storageKey(key))
+  }
+
+  set(key: K, value: V): this {
+    return this.store(this.storageKey(key), key, value)
+  }
+
+  // store sets the value of key, whose entry is stored under storageKey.
+  protected store(storageKey: any, key: K, value: V): this {
+    const entry = super.get(storageKey) as [K, V] | undefined
+    if (entry) {
+      entry[1] = value
+    } else {
+      super.set(storageKey, [copyKey(key, this.interfaceKeys), value] as any)
+    }
+    return this
+  }
+
+  delete(key: K): boolean {
+    return super.delete(this.storageKey(key))
+  }
+
+  *#entries(): Generator<[K, V]> {
+    for (const [key, value] of super.values() as unknown as Iterable<[K, V]>) {
+      yield [key, value]
+    }
+  }
+
+  entries(): ReturnType<Map<K, V>['entries']> {
+    return this.#entries() as any
+  }
+
+  keys(): ReturnType<Map<K, V>['keys']> {
+    const entries = this.#entries()
+    return (function* () {
+      for (const [key] of entries) {
+        yield key
+      }
+    })() as any
+  }
+
+  values(): ReturnType<Map<K, V>['values']> {
+    const entries = this.#entries()
+    return (function* () {
+      for (const [, value] of entries) {
+        yield value
+      }
+    })() as any
+  }
+
+  forEach(
+    callbackfn: (value: V, key: K, map: Map<K, V>) => void,
+    thisArg?: any,
+  ): void {
+    for (const [key, value] of this.#entries()) {
+      callbackfn.call(thisArg, value, key, this)
+    }
+  }
+
+  [Symbol.iterator](): ReturnType<Map<K, V>['entries']> {
+    return this.entries()
+  }
+}
+
+/**
+ * Creates a new map whose keys compare by Go equality.
+ * @returns A new HashMap.
+ */
+export const makeHashMap = <K, V>(): Map<K, V> => {
+  return new HashMap<K, V>()
+}
+
+/**
+ * TypedMapKey is a basic value converted to the interface key of a map
+ * tagged with its type, see typedMapKey.
+ */
+export class TypedMapKey {
+  constructor(
+    public readonly value: any,
+    public readonly type: string,
+  ) {}
+}
+
+/**
+ * typedMapKey tags a basic value converted to the interface key of a map
+ * with its type, which the value does not carry: int(1) and float64(1) are
+ * both the number 1, but different keys.
+ * @param value The basic value.
+ * @param type The Go type of the value, like float64 or main.Name.
+ * @returns The tagged key.
+ */
+export function typedMapKey(value: any, type: string): TypedMapKey {
+  return new TypedMapKey(value, type)
+}
+
+/**
+ * InterfaceKeyMap is a HashMap for maps with interface keys. Pointers to
+ * structs held in the keys compare by reference, and struct values, which
+ * the compiler marks with markStructValue when converted to an interface,
+ * by value.
+ *
+ * Basic keys tagged with their type by typedMapKey are stored under their
+ * type and value. Keys that were converted to an interface before, like
+ * the keys of a range loop, do not carry their type, and match an entry of
+ * their value stored without a type or else with any type.
+ */
+export class InterfaceKeyMap<K, V> extends HashMap<K, V> {
+  // The storage keys of the typed entries by the hash key of their value.
+  #typed = new Map<any, Set<string>>()
+
+  constructor(entries?: Iterable<readonly [K, V]> | null) {
+    // The entries are set once the fields are initialized.
+    super()
+    for (const [key, value] of entries ?? []) {
+      this.set(key, value)
+    }
+  }
+
+  protected get interfaceKeys(): boolean {
+    return true
+  }
+
+  // resolve returns the storage key of key and the hash key of its value.
+  #resolve(key: K): [any, any] {
+    const typed = key instanceof TypedMapKey
+    const hash = hashKey(typed ? key.value : key, true)
+    const hasHash = Map.prototype.has.call(this, hash)
+    if (!typed) {
+      if (!hasHash) {
+        for (const storageKey of this.#typed.get(hash) ?? []) {
+          return [storageKey, hash]
+        }
+      }
+      return [hash, hash]
+    }
+    const storageKey = '\u0001' + key.type + '\u0000' + String(hash)
+    if (hasHash && !Map.prototype.has.call(this, storageKey)) {
+      return [hash, hash]
+    }
+    return [storageKey, hash]
+  }
+
+  protected storageKey(key: K): any {
+    return this.#resolve(key)[0]
+  }
+
+  set(key: K, value: V): this {
+    const [storageKey, hash] = this.#resolve(key)
+    if (storageKey !== hash && !Number.isNaN((key as TypedMapKey).value)) {
+      let typed = this.#typed.get(hash)
+      if (typed === undefined) {
+        typed = new Set()
+        this.#typed.set(hash, typed)
+      }
+      typed.add(storageKey)
+    }
+    return this.store(storageKey, key, value)
+  }
+
+  delete(key: K): boolean {
+    const [storageKey, hash] = this.#resolve(key)
+    const typed = this.#typed.get(hash)
+    if (typed?.delete(storageKey) && typed.size === 0) {
+      this.#typed.delete(hash)
+    }
+    return Map.prototype.delete.call(this, storageKey)
+  }
+
+  clear(): void {
+    this.#typed.clear()
+    super.clear()
+  }
+}
+
+/**
+ * Creates a new map with interface keys.
+ * @returns A new InterfaceKeyMap.
+ */
+export const makeInterfaceKeyMap = <K, V>(): Map<K, V> => {
+  return new InterfaceKeyMap<K, V>()
+}
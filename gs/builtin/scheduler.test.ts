@@ -0,0 +1,117 @@
+import { describe, it, expect } from 'vitest'
+import * as $ from './index.js'
+
+// runUntilExit runs main with runMain and returns the status and output the
+// program exits with, with process.exit and process.stderr stubbed out.
+async function runUntilExit(
+  main: () => Promise<void>,
+): Promise<[number, string]> {
+  const exit = process.exit
+  const write = process.stderr.write
+  let out = ''
+  try {
+    return await new Promise<[number, string]>((resolve) => {
+      process.stderr.write = ((s: string) => {
+        out += s
+        return true
+      }) as typeof process.stderr.write
+      process.exit = ((code: number) => {
+        resolve([code, out])
+      }) as typeof process.exit
+      void $.runMain(main)
+    })
+  } finally {
+    process.exit = exit
+    process.stderr.write = write
+  }
+}
+
+describe('scheduler', () => {
+  it('does not report goroutines woken by a timer', async () => {
+    const ch = $.makeChannel<number>(0, 0)
+    const release = $.addWakeup()
+    setTimeout(() => {
+      release()
+      $.go(async () => {
+        await $.chanSend(ch, 7)
+      })
+    }, 5)
+    let got = 0
+    await $.runMain(async () => {
+      got = await $.chanRecv(ch)
+    })
+    expect(got).toBe(7)
+  })
+
+  it('does not report blocked goroutines without runMain', async () => {
+    const ch = $.makeChannel<number>(0, 0)
+    const recv = $.chanRecv(ch)
+    await new Promise((resolve) => setTimeout(resolve, 5))
+    await $.chanSend(ch, 1)
+    expect(await recv).toBe(1)
+  })
+
+  it('dumps the goroutines on SIGQUIT', async () => {
+    const ch = $.makeChannel<number>(0, 0)
+    const [code, out] = await runUntilExit(async () => {
+      $.go(async () => {
+        await $.chanRecv(ch)
+      }, 'main.go:7')
+      await new Promise((resolve) => setTimeout(resolve, 0))
+      expect($.numGoroutine()).toBe(2)
+      expect($.dumpGoroutines()).toMatch(
+        /^goroutine 1 \[running\]:\n(.|\n)*goroutine \d+ \[chan receive\]:/,
+      )
+      process.emit('SIGQUIT')
+      await $.chanSend(ch, 1)
+    })
+    expect(code).toBe(2)
+    expect(out).toContain('SIGQUIT: quit\n\n')
+    expect(out).toMatch(/goroutine \d+ \[chan receive\]:/)
+    expect(out).toContain('created in goroutine 1\n\tmain.go:7\n')
+  })
+
+  it('formats the stack of the running goroutine', async () => {
+    let stack = ''
+    await $.runMain(async () => {
+      stack = $.goroutineStack(Date.now, false)
+    })
+    expect(stack).toMatch(/^goroutine 1 \[running\]:\n/)
+  })
+
+  it('yields a spinning goroutine to timers', async () => {
+    let fired = false
+    setTimeout(() => {
+      fired = true
+    }, 1)
+    let n = 0
+    while (!fired) {
+      n++
+      if ($.shouldYield()) await $.gosched()
+    }
+    expect(n).toBeGreaterThan(0)
+  })
+
+  it('starts a new time slice after gosched', async () => {
+    await $.gosched()
+    expect($.shouldYield()).toBe(false)
+  })
+
+  // The deadlocked goroutines stay parked, so this test runs last.
+  it('reports a deadlock with the blocked goroutines', async () => {
+    const ch = $.makeChannel<number>(0, 0)
+    const [code, out] = await runUntilExit(async () => {
+      $.go(async () => {
+        await $.chanSend(ch, 1)
+        await $.chanSend(ch, 2)
+      }, 'main.go:12')
+      await $.chanRecv(ch)
+      await $.selectStatement([], false)
+    })
+    expect(code).toBe(2)
+    expect(out).toContain('fatal error: all goroutines are asleep - deadlock!')
+    expect(out).toContain('goroutine 1 [select (no cases)]:')
+    expect(out).toMatch(/goroutine \d+ \[chan send\]:/)
+    expect(out).toContain('created in goroutine 1\n\tmain.go:12\n')
+  })
+})
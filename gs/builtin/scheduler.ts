@@ -0,0 +1,454 @@
+// Goroutines run as asynchronous functions on the JavaScript event loop, and
+// block by awaiting promises. The scheduler keeps a record of the live
+// goroutines and of the operations they are parked in, such as channel
+// operations and locks, so that a global deadlock, in which every goroutine
+// is blocked and nothing outside them can wake one, is reported like the Go
+// runtime does instead of leaving the program hanging.
+//
+// Deadlocks are detected while a main function started with runMain is
+// running. Code embedded in a JavaScript host may be woken by the host at
+// any time, so it is never reported as deadlocked. The goroutines can be
+// dumped at any time with dumpGoroutines, and are when a program started
+// with runMain receives SIGQUIT.
+//
+// Goroutines are not preempted, so a goroutine that runs a CPU-bound loop
+// without awaiting starves the event loop. Code compiled with the YieldLoops
+// option checks shouldYield on each loop iteration, and yields with gosched
+// once the goroutine used up its time slice.
+
+import {
+  type Trace,
+  callers,
+  captureTrace,
+  formatFrames,
+  hasSourceLocations,
+  stackFrames,
+  stackGoroutine,
+} from './traceback.js'
+
+/**
+ * Goroutine describes a goroutine started by a go statement, or the main
+ * goroutine started by runMain.
+ */
+export class Goroutine {
+  constructor(
+    // id is the goroutine number, 1 for the main goroutine.
+    public readonly id: number,
+    // location is the position of the go statement that created the
+    // goroutine.
+    public readonly location: string,
+    // parent is the number of the goroutine that created the goroutine, or
+    // 0 if unknown.
+    public readonly parent: number,
+    // creator is the function that created the goroutine, if known.
+    public readonly creator: string = '',
+  ) {}
+
+  // started reports whether the goroutine started running.
+  public started = false
+}
+
+/**
+ * Wait describes an operation a goroutine is parked in.
+ */
+interface Wait {
+  // goroutine is the goroutine that was running when the operation started.
+  goroutine: Goroutine | null
+  // reason is the wait reason as shown in Go tracebacks, like 'chan send'.
+  reason: string
+  // blocking reports whether the operation waits on other goroutines,
+  // rather than on a timer or the host.
+  blocking: boolean
+  // trace holds the stack of the operation, if captured.
+  trace: Trace | null
+}
+
+const goroutines = new Map<number, Goroutine>()
+const waits = new Set<Wait>()
+
+let lastGoroutineId = 1
+let current: Goroutine | null = null
+let mainRunning = false
+
+// Number of goroutines parked in blocking operations.
+let blockedCount = 0
+// Number of pending wakeups from outside the goroutines, such as timers.
+let wakeupCount = 0
+let checkPending = false
+
+/**
+ * go starts fn as a new goroutine. location is the position of the go
+ * statement, which is shown when the goroutine is reported.
+ */
+export function go(fn: () => unknown, location: string = ''): void {
+  // The creating function is only looked up if the program records its
+  // source locations, as capturing a stack trace for every goroutine is slow.
+  const creator = hasSourceLocations() ? (callers(go)[0]?.function ?? '') : ''
+  const parent = current?.id ?? 0
+  const g = new Goroutine(++lastGoroutineId, location, parent, creator)
+  goroutines.set(g.id, g)
+  queueMicrotask(() => {
+    run(g, fn).finally(() => {
+      goroutines.delete(g.id)
+      checkDeadlock()
+    })
+  })
+}
+
+/**
+ * runMain runs main as the main goroutine of the program. While it runs, a
+ * deadlock of all goroutines is reported with the operations they are
+ * blocked in, and the program exits with status 2.
+ */
+export async function runMain(main: () => unknown): Promise<void> {
+  const g = new Goroutine(1, '', 0)
+  goroutines.set(g.id, g)
+  mainRunning = true
+  const quit = () => {
+    exit('SIGQUIT: quit\n\n' + formatGoroutines())
+  }
+  const signals = typeof process !== 'undefined' && process.platform !== 'win32'
+  if (signals) {
+    process.on('SIGQUIT', quit)
+  }
+  try {
+    await run(g, main)
+  } finally {
+    mainRunning = false
+    goroutines.delete(g.id)
+    if (signals) {
+      process.off('SIGQUIT', quit)
+    }
+  }
+}
+
+/**
+ * numGoroutine returns the number of goroutines that currently exist.
+ */
+export function numGoroutine(): number {
+  // Code not started by runMain runs on a main goroutine of its own.
+  return goroutines.size + (mainRunning ? 0 : 1)
+}
+
+/**
+ * dumpGoroutines formats every live goroutine, with the operation it is
+ * parked in, like the Go runtime does when a program receives SIGQUIT.
+ * Stack traces of parked goroutines are captured while runMain runs or if
+ * the program records its source locations.
+ */
+export function dumpGoroutines(): string {
+  const stack = captureTrace(dumpGoroutines).stack ?? ''
+  if (stackGoroutine(stack) !== 0) {
+    return formatStack(stack, true)
+  }
+  return formatGoroutines()
+}
+
+/**
+ * goroutineStack formats the stack of the running goroutine above caller,
+ * followed by the other goroutines if all is true, like runtime.Stack.
+ */
+export function goroutineStack(
+  caller: (...args: never[]) => unknown,
+  all: boolean,
+): string {
+  return formatStack(captureTrace(caller).stack ?? '', all)
+}
+
+/**
+ * formatStack formats the stack of the running goroutine, followed by the
+ * other goroutines if all is true.
+ */
+function formatStack(stack: string, all: boolean): string {
+  const id = stackGoroutine(stack) || (current?.id ?? 1)
+  let out = `goroutine ${id} [running]:\n` + formatFrames(stackFrames(stack))
+  const g = goroutines.get(id)
+  if (g !== undefined) {
+    out += formatCreator(g)
+  }
+  if (all) {
+    out += '\n' + formatGoroutines(id)
+  }
+  return out
+}
+
+/**
+ * run calls fn as the goroutine g.
+ */
+function run(g: Goroutine, fn: () => unknown): Promise<void> {
+  // The function is named after the goroutine so that the async stack
+  // traces of the operations it parks in identify it.
+  const name = `goroutine ${g.id}`
+  const start = {
+    [name]: async () => {
+      g.started = true
+      current = g
+      await fn()
+    },
+  }[name]
+  return start()
+}
+
+/**
+ * park marks the current goroutine as waiting for p, for the given wait
+ * reason, and returns a promise settling with p. caller is the runtime
+ * function parking the goroutine, whose frames are left out of the trace.
+ * Operations that are not blocking, such as sleeping, never count towards a
+ * deadlock.
+ */
+export function park<T>(
+  p: Promise<T>,
+  reason: string,
+  caller: (...args: never[]) => unknown = park,
+  blocking: boolean = true,
+): Promise<T> {
+  const g = current
+  const w: Wait = {
+    goroutine: g,
+    reason,
+    blocking,
+    trace: mainRunning || hasSourceLocations() ? captureTrace(caller) : null,
+  }
+  waits.add(w)
+  if (blocking) {
+    blockedCount++
+    checkDeadlock()
+  }
+  const unpark = () => {
+    waits.delete(w)
+    if (blocking) {
+      blockedCount--
+    }
+    current = g
+  }
+  return p.then(
+    (value) => {
+      unpark()
+      return value
+    },
+    (err) => {
+      unpark()
+      throw err
+    },
+  )
+}
+
+/**
+ * addWakeup records a pending event from outside the goroutines, such as a
+ * timer, that may unblock a goroutine. No deadlock is reported until the
+ * returned function is called to release it.
+ */
+export function addWakeup(): () => void {
+  wakeupCount++
+  let released = false
+  return () => {
+    if (released) {
+      return
+    }
+    released = true
+    wakeupCount--
+    checkDeadlock()
+  }
+}
+
+/**
+ * isDeadlocked reports whether every goroutine is parked in a blocking
+ * operation and there is no pending wakeup.
+ */
+function isDeadlocked(): boolean {
+  return mainRunning && wakeupCount === 0 && blockedCount >= goroutines.size
+}
+
+/**
+ * checkDeadlock reports a deadlock if one persists once the pending
+ * microtasks, which may still unblock goroutines, have run.
+ */
+function checkDeadlock(): void {
+  if (checkPending || !isDeadlocked()) {
+    return
+  }
+  checkPending = true
+  setTimeout(() => {
+    checkPending = false
+    if (isDeadlocked()) {
+      reportDeadlock()
+    }
+  }, 0)
+}
+
+/**
+ * reportDeadlock prints the blocked goroutines and exits the program.
+ */
+function reportDeadlock(): void {
+  exit(
+    'fatal error: all goroutines are asleep - deadlock!\n\n' +
+      formatGoroutines(),
+  )
+}
+
+/**
+ * exit prints msg and exits the program with status 2, like a fatal error of
+ * the Go runtime.
+ */
+function exit(msg: string): void {
+  if (typeof process !== 'undefined' && typeof process.exit === 'function') {
+    process.stderr.write(msg)
+    process.exit(2)
+  } else {
+    throw new Error(msg)
+  }
+}
+
+/**
+ * waitGoroutine returns the number of the goroutine parked in w, or 0 if
+ * unknown. The goroutine is found in the async stack trace where there is
+ * one, as the running goroutine is not tracked across every await.
+ */
+function waitGoroutine(w: Wait): number {
+  const id = stackGoroutine(w.trace?.stack ?? '')
+  if (id !== 0) {
+    return id
+  }
+  return w.goroutine?.id ?? 0
+}
+
+/**
+ * formatGoroutines formats the live goroutines other than skip, with the
+ * operations they are parked in, like a Go traceback.
+ */
+function formatGoroutines(skip: number = 0): string {
+  const parked = new Map<number, Wait>()
+  for (const w of waits) {
+    const id = waitGoroutine(w)
+    if (!parked.has(id)) {
+      parked.set(id, w)
+    }
+  }
+
+  const ids = [...goroutines.keys()].sort((a, b) => a - b)
+  let out = ''
+  for (const id of ids) {
+    if (id === skip) {
+      continue
+    }
+    const g = goroutines.get(id)!
+    const w = parked.get(id)
+    const state = w?.reason ?? (g.started ? 'unknown' : 'runnable')
+    out += `goroutine ${id} [${state}]:\n`
+    if (w?.trace?.stack !== undefined) {
+      out += formatFrames(stackFrames(w.trace.stack))
+    }
+    out += formatCreator(g) + '\n'
+  }
+  return out
+}
+
+/**
+ * formatCreator formats where the goroutine g was created.
+ */
+function formatCreator(g: Goroutine): string {
+  let out = ''
+  if (g.creator !== '') {
+    out += `created by ${g.creator} in goroutine ${g.parent}\n`
+  } else if (g.parent !== 0) {
+    out += `created in goroutine ${g.parent}\n`
+  }
+  if (g.location !== '') {
+    out += `\t${g.location}\n`
+  }
+  return out
+}
+
+// Time slice of a goroutine in milliseconds, after which shouldYield asks it
+// to yield.
+const yieldBudget = 10
+// Maximum number of shouldYield calls between reads of the clock.
+const maxYieldInterval = 1 << 16
+
+// Start of the current time slice, or -1 if not started.
+let sliceStart = -1
+// Number of shouldYield calls between reads of the clock, and until the next.
+let yieldInterval = 1
+let yieldCountdown = 1
+// Time of the last read of the clock.
+let lastYieldCheck = 0
+
+/**
+ * shouldYield reports whether the running goroutine used up its time slice,
+ * in which case it should yield with gosched. The slice starts at the first
+ * call after the last gosched.
+ */
+export function shouldYield(): boolean {
+  if (--yieldCountdown > 0) {
+    return false
+  }
+  const now = clock()
+  if (sliceStart < 0) {
+    sliceStart = now
+  } else if (now - lastYieldCheck < 1) {
+    // Reading the clock is slow compared to a short loop iteration, so it
+    // is read less often while calls are frequent, and more often again
+    // once they are not.
+    yieldInterval = Math.min(yieldInterval * 2, maxYieldInterval)
+  } else {
+    yieldInterval = Math.max(yieldInterval >>> 1, 1)
+  }
+  lastYieldCheck = now
+  yieldCountdown = yieldInterval
+  return now - sliceStart >= yieldBudget
+}
+
+/**
+ * gosched yields to the macrotask queue, letting timers, I/O callbacks and
+ * rendering run before the goroutine continues, unlike awaiting a resolved
+ * promise, which only lets other microtasks run. It starts a new time slice.
+ */
+export function gosched(): Promise<void> {
+  return new Promise((resolve) => {
+    scheduleMacrotask(() => {
+      sliceStart = -1
+      resolve()
+    })
+  })
+}
+
+// Callbacks waiting for the message posted by scheduleMacrotask.
+let macrotasks: (() => void)[] = []
+let macrotaskChannel: MessageChannel | null = null
+
+/**
+ * scheduleMacrotask runs fn in a new macrotask, with setImmediate where
+ * available, or otherwise with a MessageChannel, which unlike setTimeout is
+ * not clamped to a minimum delay by browsers.
+ */
+function scheduleMacrotask(fn: () => void): void {
+  if (typeof setImmediate === 'function') {
+    setImmediate(fn)
+    return
+  }
+  if (typeof MessageChannel !== 'function') {
+    setTimeout(fn, 0)
+    return
+  }
+  if (macrotaskChannel === null) {
+    macrotaskChannel = new MessageChannel()
+    macrotaskChannel.port1.onmessage = () => {
+      const tasks = macrotasks
+      macrotasks = []
+      for (const task of tasks) {
+        task()
+      }
+    }
+  }
+  if (macrotasks.length === 0) {
+    macrotaskChannel.port2.postMessage(null)
+  }
+  macrotasks.push(fn)
+}
+
+/**
+ * clock returns the current time in milliseconds.
+ */
+function clock(): number {
+  return typeof performance !== 'undefined' ? performance.now() : Date.now()
+}
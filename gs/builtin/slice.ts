@@ -1,3 +1,15 @@
+import { Complex } from './complex.js'
+import {
+  byteAt,
+  byteLength,
+  decodeString,
+  encodeString,
+  runesString,
+  stringRunes,
+  substring,
+  viewBytes,
+} from './string.js'
+
 /**
  * GoSliceObject contains metadata for complex slice views
  */
@@ -174,6 +186,12 @@ export const makeSlice = <T>(
     case 'number':
       zeroVal = 0
       break
+    case 'bigint':
+      zeroVal = 0n
+      break
+    case 'complex':
+      zeroVal = new Complex(0, 0)
+      break
     case 'boolean':
       zeroVal = false
       break
@@ -482,6 +500,31 @@ export const goSlice = <T>( // T can be number for Uint8Array case
   return new Proxy(proxy, handler) as unknown as SliceProxy<T>
 }
 
+// Marks byte arrays held in interfaces, see markByteArray.
+const byteArrayMark = Symbol('goscript.byteArray')
+
+/**
+ * markByteArray marks a copy of a byte array converted to an interface.
+ * Byte arrays are represented like other arrays, so the mark tells fmt to
+ * format them as bytes, as in `fmt.Sprintf("%x", sha256.Sum256(data))`.
+ * @param value The byte array held in the interface.
+ * @returns A marked copy of the array.
+ */
+export function markByteArray(value: number[]): number[] {
+  const copy = value.slice()
+  ;(copy as any)[byteArrayMark] = true
+  return copy
+}
+
+/**
+ * isByteArray checks if a value is a byte array marked by markByteArray.
+ * @param value The value to check.
+ * @returns True if the value is a marked byte array.
+ */
+export function isByteArray(value: any): value is number[] {
+  return Array.isArray(value) && (value as any)[byteArrayMark] === true
+}
+
 /**
  * Converts a JavaScript array to a Go slice.
  * For multi-dimensional arrays, recursively converts nested arrays to slices.
@@ -874,8 +917,7 @@ export function copy<T>(
  * Helper: Copy from string to any destination type
  */
 function copyFromString<T>(dst: Slice<T> | Uint8Array, src: string): number {
-  const encoder = new TextEncoder()
-  const srcBytes = encoder.encode(src)
+  const srcBytes = viewBytes(src)
   const dstLen = dst instanceof Uint8Array ? dst.length : len(dst)
   const count = Math.min(dstLen, srcBytes.length)
 
@@ -1032,11 +1074,12 @@ export function index<T>(
 
 /**
  * Converts a string to an array of Unicode code points (runes).
+ * Bytes of invalid UTF-8 become the replacement character U+FFFD, as in Go.
  * @param str The input string.
  * @returns An array of numbers representing the Unicode code points.
  */
 export const stringToRunes = (str: string): number[] => {
-  return Array.from(str).map((c) => c.codePointAt(0) || 0)
+  return stringRunes(str)
 }
 
 /**
@@ -1054,11 +1097,21 @@ export const stringToRune = (str: string): number => {
 
 /**
  * Converts an array of Unicode code points (runes) to a string.
+ * Invalid runes become the replacement character U+FFFD, as in Go.
  * @param runes The input array of numbers representing Unicode code points.
  * @returns The resulting string.
  */
 export const runesToString = (runes: Slice<number>): string => {
-  return runes?.length ? String.fromCharCode(...runes) : ''
+  if (runes === null) {
+    return ''
+  }
+  if (isComplexSlice(runes)) {
+    const meta = runes.__meta__
+    return runesString(
+      meta.backing.slice(meta.offset, meta.offset + meta.length),
+    )
+  }
+  return runesString(runes)
 }
 
 /**
@@ -1072,7 +1125,7 @@ export const byte = (n: number): number => {
 }
 
 /**
- * Accesses the byte value at a specific index of a UTF-8 encoded string.
+ * Accesses the byte value at a specific index of a string.
  * Mimics Go's string indexing behavior: `myString[index]`
  * @param str The string to access.
  * @param index The byte index.
@@ -1080,80 +1133,55 @@ export const byte = (n: number): number => {
  * @throws Error if index is out of bounds.
  */
 export const indexString = (str: string, index: number): number => {
-  const bytes = new TextEncoder().encode(str)
-  if (index < 0 || index >= bytes.length) {
+  const length = byteLength(str)
+  if (index < 0 || index >= length) {
     throw new Error(
-      `runtime error: index out of range [${index}] with length ${bytes.length}`,
+      `runtime error: index out of range [${index}] with length ${length}`,
     )
   }
-  return bytes[index]
+  return byteAt(str, index)
 }
 
 /**
  * Returns the byte length of a string.
  * Mimics Go's `len(string)` behavior.
  * @param str The string.
- * @returns The number of bytes in the UTF-8 representation of the string.
+ * @returns The number of bytes held by the string.
  */
 export const stringLen = (str: string): number => {
-  return new TextEncoder().encode(str).length
+  return byteLength(str)
 }
 
 /**
  * Slices a string based on byte indices.
- * Mimics Go's string slicing behavior: `myString[low:high]` for valid UTF-8 slices only.
+ * Mimics Go's string slicing behavior: `myString[low:high]`. Slicing inside
+ * a UTF-8 sequence keeps its bytes, which are held as escaped bytes.
  * @param str The string to slice.
  * @param low The starting byte index (inclusive). Defaults to 0.
  * @param high The ending byte index (exclusive). Defaults to string byte length.
  * @returns The sliced string.
- * @throws Error if the slice would create invalid UTF-8.
+ * @throws Error if the indices are out of range.
  */
 export const sliceString = (
   str: string,
   low?: number,
   high?: number,
 ): string => {
-  const bytes = new TextEncoder().encode(str)
+  const length = byteLength(str)
   const actualLow = low === undefined ? 0 : low
-  const actualHigh = high === undefined ? bytes.length : high
-
-  if (actualLow < 0 || actualHigh < actualLow || actualHigh > bytes.length) {
-    // Go's behavior for out-of-bounds slice on string is a panic.
-    // For simple slices like s[len(s):len(s)], it should produce an empty string.
-    // For s[len(s)+1:], it panics.
-    // Let's ensure high <= bytes.length and low <= high.
-    // If low == high, it's an empty string.
-    if (
-      actualLow === actualHigh &&
-      actualLow >= 0 &&
-      actualLow <= bytes.length
-    ) {
-      return ''
-    }
-    throw new Error(
-      `runtime error: slice bounds out of range [${actualLow}:${actualHigh}] with length ${bytes.length}`,
-    )
-  }
-
-  const slicedBytes = bytes.subarray(actualLow, actualHigh)
+  const actualHigh = high === undefined ? length : high
 
-  try {
-    // Attempt to decode with strict UTF-8 validation
-    const result = new TextDecoder('utf-8', { fatal: true }).decode(slicedBytes)
-    return result
-    // eslint-disable-next-line @typescript-eslint/no-unused-vars
-  } catch (_e) {
-    // If we get here, the slice would create invalid UTF-8
-    // This is a fundamental limitation of JavaScript string handling
+  if (actualLow < 0 || actualHigh < actualLow || actualHigh > length) {
     throw new Error(
-      `Cannot slice string at byte indices [${actualLow}:${actualHigh}] because it would create invalid UTF-8. ` +
-        `This is a limitation of JavaScript's string handling.`,
+      `runtime error: slice bounds out of range [${actualLow}:${actualHigh}] with length ${length}`,
     )
   }
+
+  return substring(str, actualLow, actualHigh)
 }
 
 /**
- * Converts a Slice<number> (byte array) to a string using TextDecoder.
+ * Converts a Slice<number> (byte array) to a string holding its bytes.
  * @param bytes The Slice<number> to convert.
  * @returns The resulting string.
  */
@@ -1164,7 +1192,7 @@ export const bytesToString = (
   // If it's already a string, just return it
   if (typeof bytes === 'string') return bytes
   if (bytes instanceof Uint8Array) {
-    return new TextDecoder().decode(bytes)
+    return decodeString(bytes)
   }
   // Ensure we get a plain number[] for Uint8Array.from
   let byteArray: number[]
@@ -1178,16 +1206,16 @@ export const bytesToString = (
     // For simple T[] slices
     byteArray = bytes
   }
-  return new TextDecoder().decode(Uint8Array.from(byteArray))
+  return decodeString(Uint8Array.from(byteArray))
 }
 
 /**
  * Converts a string to a Uint8Array (byte slice).
  * @param s The input string.
- * @returns A Uint8Array representing the UTF-8 bytes of the string.
+ * @returns A Uint8Array holding the bytes of the string.
  */
 export function stringToBytes(s: string): Uint8Array {
-  return new TextEncoder().encode(s)
+  return encodeString(s)
 }
 
 /**
@@ -0,0 +1,395 @@
+// Go strings are immutable sequences of bytes which usually, but not always,
+// hold UTF-8 text. They are represented as JavaScript strings holding the
+// decoded text, in which each byte that is not part of a valid UTF-8 sequence
+// is stored as the lone surrogate U+DC00 + byte (U+DC80 to U+DCFF). Decoding
+// valid UTF-8 never produces lone surrogates, so strings holding text are
+// plain JavaScript strings, while arbitrary bytes still round-trip exactly
+// through len, indexing, slicing, range and the []byte conversions.
+
+const encoder = new TextEncoder()
+const decoder = new TextDecoder('utf-8', { fatal: true, ignoreBOM: true })
+
+// RuneError is the Unicode replacement character, which stands for invalid
+// UTF-8 and invalid runes.
+const runeError = 0xfffd
+
+// Number of code units converted with a single String.fromCharCode call.
+const chunkSize = 8192
+
+// Strings whose bytes are accessed repeatedly, as by len(s) and s[i] in a
+// loop, keep their encoding in a small cache so that each access takes
+// constant time. Shorter strings are not cached, as encoding them is cheap.
+const minCachedLength = 32
+const maxCachedStrings = 64
+const byteCache = new Map<string, Uint8Array | null>()
+
+/**
+ * isEscapedByte reports whether the UTF-16 code unit c holds a byte that is
+ * not part of valid UTF-8.
+ */
+function isEscapedByte(c: number): boolean {
+  return c >= 0xdc80 && c <= 0xdcff
+}
+
+function isHighSurrogate(c: number): boolean {
+  return c >= 0xd800 && c <= 0xdbff
+}
+
+function isLowSurrogate(c: number): boolean {
+  return c >= 0xdc00 && c <= 0xdfff
+}
+
+/**
+ * isWellFormed reports whether str holds no lone surrogates, which means it
+ * holds valid UTF-8 only and TextEncoder can encode it.
+ */
+function isWellFormed(str: string): boolean {
+  const s = str as string & { isWellFormed?: () => boolean }
+  if (s.isWellFormed !== undefined) {
+    return s.isWellFormed()
+  }
+  for (let i = 0; i < str.length; i++) {
+    const c = str.charCodeAt(i)
+    if (isHighSurrogate(c) && isLowSurrogate(str.charCodeAt(i + 1))) {
+      i++
+    } else if (isHighSurrogate(c) || isLowSurrogate(c)) {
+      return false
+    }
+  }
+  return true
+}
+
+/**
+ * isValidRune reports whether r can be legally encoded as UTF-8.
+ */
+function isValidRune(r: number): boolean {
+  return r >= 0 && r <= 0x10ffff && !(r >= 0xd800 && r <= 0xdfff)
+}
+
+/**
+ * decodeRune decodes the UTF-8 sequence at p[i], following the rules of Go's
+ * unicode/utf8 package. It returns the rune and its width in bytes, or -1
+ * and 1 if the sequence is invalid.
+ */
+function decodeRune(p: Uint8Array, i: number): [number, number] {
+  const p0 = p[i]
+  if (p0 < 0x80) {
+    return [p0, 1]
+  }
+  let size: number
+  let r: number
+  let lo = 0x80
+  let hi = 0xbf
+  if (p0 >= 0xc2 && p0 <= 0xdf) {
+    size = 2
+    r = p0 & 0x1f
+  } else if (p0 >= 0xe0 && p0 <= 0xef) {
+    size = 3
+    r = p0 & 0x0f
+    if (p0 === 0xe0) {
+      lo = 0xa0
+    } else if (p0 === 0xed) {
+      hi = 0x9f
+    }
+  } else if (p0 >= 0xf0 && p0 <= 0xf4) {
+    size = 4
+    r = p0 & 0x07
+    if (p0 === 0xf0) {
+      lo = 0x90
+    } else if (p0 === 0xf4) {
+      hi = 0x8f
+    }
+  } else {
+    return [-1, 1]
+  }
+  if (i + size > p.length) {
+    return [-1, 1]
+  }
+  for (let j = 1; j < size; j++) {
+    const c = p[i + j]
+    if (c < lo || c > hi) {
+      return [-1, 1]
+    }
+    lo = 0x80
+    hi = 0xbf
+    r = (r << 6) | (c & 0x3f)
+  }
+  return [r, size]
+}
+
+/**
+ * pushRune appends the UTF-16 code units of the valid rune r to units.
+ */
+function pushRune(units: number[], r: number): void {
+  if (r < 0x10000) {
+    units.push(r)
+  } else {
+    r -= 0x10000
+    units.push(0xd800 | (r >> 10), 0xdc00 | (r & 0x3ff))
+  }
+}
+
+/**
+ * unitsToString converts UTF-16 code units to a string in chunks, since the
+ * number of arguments of a call is limited.
+ */
+function unitsToString(units: number[]): string {
+  let s = ''
+  for (let i = 0; i < units.length; i += chunkSize) {
+    s += String.fromCharCode(...units.slice(i, i + chunkSize))
+  }
+  return s
+}
+
+/**
+ * decodeString converts bytes to the string holding them, escaping the bytes
+ * that are not part of valid UTF-8.
+ * @param p The bytes to convert.
+ * @returns The string holding exactly the bytes of p.
+ */
+export function decodeString(p: Uint8Array): string {
+  try {
+    return decoder.decode(p)
+  } catch {
+    // p holds invalid UTF-8, which is decoded below.
+  }
+  const units: number[] = []
+  for (let i = 0; i < p.length; ) {
+    const [r, size] = decodeRune(p, i)
+    if (r < 0) {
+      units.push(0xdc00 | p[i])
+    } else {
+      pushRune(units, r)
+    }
+    i += size
+  }
+  return unitsToString(units)
+}
+
+/**
+ * encodeString returns the bytes held by the string str. Lone surrogates
+ * other than escaped bytes, which only come from JavaScript, are encoded as
+ * the UTF-8 of the replacement character like TextEncoder does.
+ * @param str The string to convert.
+ * @returns The bytes of str.
+ */
+export function encodeString(str: string): Uint8Array {
+  if (isWellFormed(str)) {
+    return encoder.encode(str)
+  }
+  const out = new Uint8Array(str.length * 3)
+  let n = 0
+  for (let i = 0; i < str.length; i++) {
+    let c = str.charCodeAt(i)
+    if (c < 0x80) {
+      out[n++] = c
+    } else if (c < 0x800) {
+      out[n++] = 0xc0 | (c >> 6)
+      out[n++] = 0x80 | (c & 0x3f)
+    } else if (isHighSurrogate(c) && isLowSurrogate(str.charCodeAt(i + 1))) {
+      c = 0x10000 + ((c & 0x3ff) << 10) + (str.charCodeAt(++i) & 0x3ff)
+      out[n++] = 0xf0 | (c >> 18)
+      out[n++] = 0x80 | ((c >> 12) & 0x3f)
+      out[n++] = 0x80 | ((c >> 6) & 0x3f)
+      out[n++] = 0x80 | (c & 0x3f)
+    } else if (isEscapedByte(c)) {
+      out[n++] = c & 0xff
+    } else {
+      if (isHighSurrogate(c) || isLowSurrogate(c)) {
+        c = runeError
+      }
+      out[n++] = 0xe0 | (c >> 12)
+      out[n++] = 0x80 | ((c >> 6) & 0x3f)
+      out[n++] = 0x80 | (c & 0x3f)
+    }
+  }
+  return out.slice(0, n)
+}
+
+/**
+ * isSingleByte reports whether each code unit of str holds a single byte,
+ * which is the code unit itself for ASCII and its low byte for an escaped
+ * byte.
+ */
+function isSingleByte(str: string): boolean {
+  for (let i = 0; i < str.length; i++) {
+    const c = str.charCodeAt(i)
+    if (c >= 0x80 && !isEscapedByte(c)) {
+      return false
+    }
+  }
+  return true
+}
+
+/**
+ * lookupBytes returns the bytes of str, or null if each of its code units
+ * holds a single byte. The result is shared and must not be modified.
+ */
+function lookupBytes(str: string): Uint8Array | null {
+  if (str.length < minCachedLength) {
+    return isSingleByte(str) ? null : encodeString(str)
+  }
+  let bytes = byteCache.get(str)
+  if (bytes === undefined) {
+    bytes = isSingleByte(str) ? null : encodeString(str)
+    if (byteCache.size >= maxCachedStrings) {
+      byteCache.delete(byteCache.keys().next().value!)
+    }
+    byteCache.set(str, bytes)
+  }
+  return bytes
+}
+
+/**
+ * byteLength returns the number of bytes held by the string str.
+ */
+export function byteLength(str: string): number {
+  if (str.length >= minCachedLength) {
+    const bytes = lookupBytes(str)
+    return bytes === null ? str.length : bytes.length
+  }
+  return countBytes(str)
+}
+
+/**
+ * byteAt returns the byte at index i of the string str, which must be in
+ * range.
+ */
+export function byteAt(str: string, i: number): number {
+  const bytes = lookupBytes(str)
+  return bytes === null ? str.charCodeAt(i) & 0xff : bytes[i]
+}
+
+/**
+ * viewBytes returns the bytes of the string str. The result may be shared
+ * and must not be modified.
+ */
+export function viewBytes(str: string): Uint8Array {
+  return lookupBytes(str) ?? encodeString(str)
+}
+
+/**
+ * substring returns the string holding the bytes low to high of the string
+ * str, which must be in range.
+ */
+export function substring(str: string, low: number, high: number): string {
+  const bytes = lookupBytes(str)
+  if (bytes === null) {
+    return str.slice(low, high)
+  }
+  return decodeString(bytes.subarray(low, high))
+}
+
+/**
+ * countBytes returns the number of bytes held by the string str without
+ * encoding it.
+ */
+function countBytes(str: string): number {
+  let n = 0
+  for (let i = 0; i < str.length; i++) {
+    const c = str.charCodeAt(i)
+    if (c < 0x80 || isEscapedByte(c)) {
+      n += 1
+    } else if (c < 0x800) {
+      n += 2
+    } else if (isHighSurrogate(c) && isLowSurrogate(str.charCodeAt(i + 1))) {
+      n += 4
+      i++
+    } else {
+      n += 3
+    }
+  }
+  return n
+}
+
+/**
+ * rangeString iterates over the runes of a string like Go's `for i, r :=
+ * range s`, yielding the byte index and the rune of each UTF-8 sequence.
+ * Each byte of invalid UTF-8 yields RuneError.
+ * @param str The string to range over.
+ */
+export function* rangeString(str: string): Generator<[number, number]> {
+  let pos = 0
+  for (let i = 0; i < str.length; i++) {
+    const c = str.charCodeAt(i)
+    if (c < 0x80) {
+      yield [pos, c]
+      pos += 1
+    } else if (c < 0x800) {
+      yield [pos, c]
+      pos += 2
+    } else if (isHighSurrogate(c) && isLowSurrogate(str.charCodeAt(i + 1))) {
+      const d = str.charCodeAt(++i)
+      yield [pos, 0x10000 + ((c & 0x3ff) << 10) + (d & 0x3ff)]
+      pos += 4
+    } else if (isEscapedByte(c)) {
+      yield [pos, runeError]
+      pos += 1
+    } else {
+      yield [pos, isLowSurrogate(c) || isHighSurrogate(c) ? runeError : c]
+      pos += 3
+    }
+  }
+}
+
+/**
+ * stringRunes returns the runes of the string str like Go's []rune(str).
+ * Each byte of invalid UTF-8 becomes RuneError.
+ */
+export function stringRunes(str: string): number[] {
+  const runes: number[] = []
+  for (const [, r] of rangeString(str)) {
+    runes.push(r)
+  }
+  return runes
+}
+
+/**
+ * runesString returns the string holding the UTF-8 encoding of runes like
+ * Go's string([]rune). Invalid runes become RuneError.
+ */
+export function runesString(runes: ArrayLike<number>): string {
+  const units: number[] = []
+  for (let i = 0; i < runes.length; i++) {
+    pushRune(units, isValidRune(runes[i]) ? runes[i] : runeError)
+  }
+  return unitsToString(units)
+}
+
+/**
+ * runeString returns the string holding the UTF-8 encoding of r like Go's
+ * string(r). Invalid runes become RuneError.
+ */
+export function runeString(r: number): string {
+  return String.fromCodePoint(isValidRune(r) ? r : runeError)
+}
+
+/**
+ * concatString returns the concatenation of the strings a and b. Unlike the
+ * + operator, it joins a UTF-8 sequence that is split between the end of a
+ * and the start of b, which are held as escaped bytes, into its rune, so the
+ * result is the same as converting the concatenated bytes.
+ * @param a The first string.
+ * @param b The string appended to a.
+ */
+export function concatString(a: string, b: string): string {
+  if (
+    a.length === 0 ||
+    !isEscapedByte(a.charCodeAt(a.length - 1)) ||
+    !isEscapedByte(b.charCodeAt(0))
+  ) {
+    return a + b
+  }
+  // A UTF-8 sequence is at most 4 bytes long, so at most 3 of its bytes
+  // are on either side.
+  let i = a.length - 1
+  while (i > a.length - 3 && isEscapedByte(a.charCodeAt(i - 1))) {
+    i--
+  }
+  let j = 1
+  while (j < 3 && isEscapedByte(b.charCodeAt(j))) {
+    j++
+  }
+  const joint = encodeString(a.slice(i) + b.slice(0, j))
+  return a.slice(0, i) + decodeString(joint) + b.slice(j)
+}
@@ -0,0 +1,67 @@
+import { describe, it, expect } from 'vitest'
+import * as $ from './index.js'
+
+// callerLine returns the line the caller of callerLine runs at.
+function callerLine(): number {
+  return $.callers(callerLine)[0].line
+}
+
+// where returns the frame of the caller of where.
+function where(): $.StackFrame {
+  return $.callers(where)[0]
+}
+
+describe('traceback', () => {
+  it('parses V8 stack traces down to the goroutine', () => {
+    const stack = [
+      'Error: boom',
+      '    at f (file:///m/a.js:3:5)',
+      '    at file:///m/a.js?t=1:7:2',
+      '    at async goroutine 2 (file:///m/s.js:1:1)',
+      '    at g (file:///m/a.js:9:1)',
+    ].join('\n')
+    expect($.stackFrames(stack)).toEqual([
+      { function: 'f', file: 'file:///m/a.js', line: 3, mapped: false },
+      { function: '?', file: 'file:///m/a.js', line: 7, mapped: false },
+    ])
+    expect($.stackGoroutine(stack)).toBe(2)
+  })
+
+  it('parses SpiderMonkey stack traces', () => {
+    const stack = 'f@https://h/a.js:3:5\nasync*goroutine 4@https://h/s.js:1:1\n'
+    expect($.stackFrames(stack)).toEqual([
+      { function: 'f', file: 'https://h/a.js', line: 3, mapped: false },
+    ])
+    expect($.stackGoroutine(stack)).toBe(4)
+  })
+
+  it('formats frames like a Go traceback', () => {
+    const frames = [
+      { function: 'main.main', file: 'm/main.go', line: 5, mapped: true },
+    ]
+    expect($.formatFrames(frames)).toBe('main.main(...)\n\tm/main.go:5\n')
+  })
+
+  // Registering the module's source locations maps all of its frames, so
+  // these tests run last.
+  it('ignores source locations registered from another line', () => {
+    const files = ['example.com/m/main.go']
+    const funcs = ['main.main']
+    $.registerSourceFile(callerLine() + 1, files, funcs, [1, 10, 0, 0])
+    expect(where().function).not.toBe('main.main')
+    expect($.hasSourceLocations()).toBe(true)
+  })
+
+  it('maps frames of registered files to Go', () => {
+    // Every line of this module maps to main.go:10 in main.main.
+    const files = ['example.com/m/main.go']
+    const funcs = ['main.main']
+    $.registerSourceFile(callerLine(), files, funcs, [1, 10, 0, 0])
+    expect(where()).toEqual({
+      function: 'main.main',
+      file: 'example.com/m/main.go',
+      line: 10,
+      mapped: true,
+    })
+  })
+})
@@ -0,0 +1,243 @@
+// Stack traces of the generated code are mapped back to the Go source with
+// the tables of source locations the generated files register when compiled
+// with the SourceLocations option. A table maps the lines of a generated
+// TypeScript file to Go lines and functions, so it applies only where the
+// file runs with its own line numbers, as it does with tsx, Deno, Bun or
+// Node's type stripping. Frames of code that does not, such as bundled or
+// handwritten code, are reported with their JavaScript locations and are not
+// marked as mapped.
+
+/**
+ * StackFrame is a frame of a stack trace.
+ */
+export interface StackFrame {
+  // function is the Go function, like main.main or main.(*T).Method, or the
+  // JavaScript function of a frame without a Go location.
+  function: string
+  // file is the Go file, like example.com/m/main.go, or the URL of the
+  // JavaScript module.
+  file: string
+  line: number
+  // mapped reports whether the frame was mapped to the Go source.
+  mapped: boolean
+}
+
+/**
+ * SourceFile holds the source locations of a generated file.
+ */
+interface SourceFile {
+  files: string[]
+  funcs: string[]
+  // table holds a TypeScript line, the Go line and the indexes of the Go
+  // function and file for each statement, ordered by TypeScript line. The
+  // indexes are -1 for code without a Go location.
+  table: number[]
+}
+
+/**
+ * Trace holds a captured stack trace, which is formatted when first read.
+ */
+export interface Trace {
+  stack?: string
+}
+
+/**
+ * JSFrame is a frame of a JavaScript stack trace.
+ */
+interface JSFrame {
+  name: string
+  url: string
+  line: number
+  column: number
+}
+
+// Source locations of the generated files by module URL.
+const sourceFiles = new Map<string, SourceFile>()
+// Whether a generated file recorded its source locations.
+let registered = false
+
+// Maximum number of frames captured for a stack trace. Async frames count
+// too, and the goroutine is identified by the outermost one.
+const maxTraceFrames = 64
+
+/**
+ * registerSourceFile registers the source locations of the generated file
+ * calling it, which does so from the given line. The locations are ignored
+ * if the file runs with other line numbers than it was generated with.
+ */
+export function registerSourceFile(
+  line: number,
+  files: string[],
+  funcs: string[],
+  table: number[],
+): void {
+  registered = true
+  const [frame] = parseStack(captureTrace(registerSourceFile).stack ?? '')
+  if (frame === undefined || frame.line !== line) {
+    return
+  }
+  sourceFiles.set(frame.url, { files, funcs, table })
+}
+
+/**
+ * hasSourceLocations reports whether the program was compiled to record its
+ * source locations, in which case stack traces are worth capturing.
+ */
+export function hasSourceLocations(): boolean {
+  return registered
+}
+
+/**
+ * captureTrace captures the stack above caller, which is left out.
+ */
+export function captureTrace(caller: (...args: never[]) => unknown): Trace {
+  const trace: Trace = {}
+  const limit = Error.stackTraceLimit
+  Error.stackTraceLimit = maxTraceFrames
+  if (typeof Error.captureStackTrace === 'function') {
+    Error.captureStackTrace(trace, caller)
+  } else {
+    // Without captureStackTrace, as in SpiderMonkey, the stack starts with
+    // the frames of this function and the caller.
+    const stack = new Error().stack ?? ''
+    trace.stack = stack.split('\n').slice(2).join('\n')
+  }
+  Error.stackTraceLimit = limit
+  return trace
+}
+
+/**
+ * callers returns the frames of the stack above caller, down to the start
+ * of the goroutine.
+ */
+export function callers(caller: (...args: never[]) => unknown): StackFrame[] {
+  return stackFrames(captureTrace(caller).stack ?? '')
+}
+
+/**
+ * stackFrames returns the frames of a captured stack, down to the start of
+ * the goroutine, mapped to the Go source where possible.
+ */
+export function stackFrames(stack: string): StackFrame[] {
+  const frames: StackFrame[] = []
+  for (const frame of parseStack(stack)) {
+    if (goroutineOf(frame) !== 0) {
+      break
+    }
+    frames.push(mapFrame(frame))
+  }
+  return frames
+}
+
+/**
+ * stackGoroutine returns the number of the goroutine a captured stack was
+ * captured in, or 0 if the stack does not reach the start of a goroutine.
+ */
+export function stackGoroutine(stack: string): number {
+  for (const frame of parseStack(stack)) {
+    const id = goroutineOf(frame)
+    if (id !== 0) {
+      return id
+    }
+  }
+  return 0
+}
+
+/**
+ * formatFrames formats frames as the lines of a Go traceback.
+ */
+export function formatFrames(frames: StackFrame[]): string {
+  let out = ''
+  for (const f of frames) {
+    out += `${f.function}(...)\n\t${f.file}:${f.line}\n`
+  }
+  return out
+}
+
+/**
+ * goroutineOf returns the number of the goroutine started by the function
+ * of frame, or 0 if it does not start one.
+ */
+function goroutineOf(frame: JSFrame): number {
+  const m = /^goroutine (\d+)$/.exec(frame.name)
+  return m === null ? 0 : Number(m[1])
+}
+
+/**
+ * parseStack parses the frames of a V8, SpiderMonkey or JavaScriptCore
+ * stack trace. Lines that are not frames, like the message, are skipped.
+ */
+function parseStack(stack: string): JSFrame[] {
+  const frames: JSFrame[] = []
+  for (const line of stack.split('\n')) {
+    // V8: "    at name (url:line:column)" or "    at url:line:column"
+    let m = /^\s*at (?:async )?(?:(.*?) \((.*)\)|(.*))$/.exec(line)
+    if (m !== null) {
+      const name = m[1] ?? ''
+      const loc = /^(.*):(\d+):(\d+)$/.exec(m[2] ?? m[3])
+      frames.push({
+        name,
+        url: stripURL(loc?.[1] ?? m[2] ?? m[3]),
+        line: Number(loc?.[2] ?? 0),
+        column: Number(loc?.[3] ?? 0),
+      })
+      continue
+    }
+    // SpiderMonkey and JavaScriptCore: "name@url:line:column"
+    m = /^(.*?)@(.*):(\d+):(\d+)$/.exec(line)
+    if (m !== null) {
+      frames.push({
+        name: m[1].replace(/^async\*/, ''),
+        url: stripURL(m[2]),
+        line: Number(m[3]),
+        column: Number(m[4]),
+      })
+    }
+  }
+  return frames
+}
+
+/**
+ * stripURL strips the query and fragment of a module URL, which may be added
+ * when a module is loaded again.
+ */
+function stripURL(url: string): string {
+  return url.replace(/[?#].*$/, '')
+}
+
+/**
+ * mapFrame maps a JavaScript frame to the Go source, if it is in a
+ * registered generated file.
+ */
+function mapFrame(frame: JSFrame): StackFrame {
+  const src = sourceFiles.get(frame.url)
+  if (src !== undefined) {
+    // Find the last statement starting at or before the line.
+    const { table } = src
+    let lo = 0
+    let hi = table.length / 4
+    while (lo < hi) {
+      const mid = (lo + hi) >>> 1
+      if (table[mid * 4] <= frame.line) {
+        lo = mid + 1
+      } else {
+        hi = mid
+      }
+    }
+    const i = (lo - 1) * 4
+    if (lo > 0 && table[i + 2] >= 0) {
+      return {
+        function: src.funcs[table[i + 2]],
+        file: src.files[table[i + 3]],
+        line: table[i + 1],
+        mapped: true,
+      }
+    }
+  }
+  return {
+    function: frame.name === '' ? '?' : frame.name,
+    file: frame.url,
+    line: frame.line,
+    mapped: false,
+  }
+}
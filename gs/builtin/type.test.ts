@@ -0,0 +1,36 @@
+import { describe, it, expect } from 'vitest'
+import * as $ from './index.js'
+
+describe('main package type names', () => {
+  it('keeps the types of several main packages apart', () => {
+    class a {}
+    class b {}
+    const aInfo = $.registerStructType(
+      'main@example.com/cmd/a.Config',
+      new a(),
+      [],
+      a,
+    )
+    const bInfo = $.registerStructType(
+      'main@example.com/cmd/b.Config',
+      new b(),
+      [],
+      b,
+    )
+    expect($.getTypeByName('main@example.com/cmd/a.Config')).toBe(aInfo)
+    expect($.getTypeByName('main@example.com/cmd/b.Config')).toBe(bInfo)
+  })
+
+  it('reports the package path main', () => {
+    expect($.splitTypeName('main@example.com/cmd/a.Config')).toEqual([
+      'main',
+      'Config',
+    ])
+    expect($.splitTypeName('main@example.com.Config')).toEqual([
+      'main',
+      'Config',
+    ])
+    expect($.typeString('main@example.com/cmd/a.Config')).toBe('main.Config')
+    expect($.splitTypeName('io/fs.PathError')).toEqual(['io/fs', 'PathError'])
+  })
+})
@@ -1,3 +1,5 @@
+import { Complex } from './complex.js'
+
 /**
  * Represents the kinds of Go types that can be registered at runtime.
  */
@@ -17,11 +19,21 @@ export enum TypeKind {
  * Base type information shared by all type kinds
  */
 export interface BaseTypeInfo {
-  name?: string
+  name?: string // Qualified name of a named type, as in "io/fs.PathError"
   kind: TypeKind
   zeroValue?: any
+  methods?: MethodSignature[]
+  underlying?: TypeInfo // Underlying type of named types registered with registerNamedType
+  methodFuncs?: MethodFuncs // Methods of named types registered with registerNamedType
 }
 
+/**
+ * The functions implementing the methods with value receivers of a named type
+ * registered with registerNamedType, which take the receiver as the first
+ * argument, by method name.
+ */
+export type MethodFuncs = Record<string, (receiver: any, ...args: any[]) => any>
+
 /**
  * Represents an argument or a return value of a method.
  */
@@ -39,6 +51,17 @@ export interface MethodSignature {
   returns: MethodArg[]
 }
 
+/**
+ * Describes a field of a struct type.
+ */
+export interface StructFieldInfo {
+  name: string // Field name, the type name for embedded fields
+  type: TypeInfo | string // Field type
+  tag?: string // Raw struct tag, if any
+  embedded?: boolean // True if the field is embedded
+  exported?: boolean // True if the field name is exported
+}
+
 /**
  * Type information for struct types
  */
@@ -46,7 +69,7 @@ export interface StructTypeInfo extends BaseTypeInfo {
   kind: TypeKind.Struct
   methods: MethodSignature[] // Array of method signatures
   ctor?: new (...args: any[]) => any
-  fields: Record<string, TypeInfo | string> // Field names and types for struct fields
+  fields: StructFieldInfo[] // Fields in declaration order
 }
 
 /**
@@ -181,6 +204,99 @@ export interface Comparable {
 // Registry to store runtime type information
 const typeRegistry = new Map<string, TypeInfo>()
 
+/**
+ * Returns the fields of a struct type info in declaration order. Fields may
+ * also be given as a record of field names and their types.
+ *
+ * @param fields The fields of the struct type.
+ * @returns The fields with their exported flags set.
+ */
+export function structFields(
+  fields: StructFieldInfo[] | Record<string, TypeInfo | string> | undefined,
+): StructFieldInfo[] {
+  if (fields === undefined) {
+    return []
+  }
+  const list =
+    Array.isArray(fields) ? fields : (
+      Object.entries(fields).map(([name, type]) => ({ name, type }))
+    )
+  for (const field of list) {
+    field.exported ??= isExportedName(field.name)
+  }
+  return list
+}
+
+/**
+ * Returns the zero value of a type in its runtime representation. Blank
+ * struct fields have no storage and read as zero values.
+ *
+ * @param type The type information or the name of the type.
+ * @returns The zero value of the type.
+ */
+export function zeroValue(type: TypeInfo | string | undefined): any {
+  const info =
+    typeof type === 'string' ?
+      (getTypeByName(type) ?? { kind: TypeKind.Basic, name: type })
+    : type
+  if (info === undefined) {
+    return null
+  }
+  if (info.underlying !== undefined) {
+    return zeroValue(info.underlying)
+  }
+  switch (info.kind) {
+    case TypeKind.Basic:
+      switch (info.name) {
+        case 'string':
+          return ''
+        case 'bool':
+        case 'boolean':
+          return false
+        case 'bigint':
+          return 0n
+        case 'any':
+        case 'error':
+        case 'unsafe.Pointer':
+          return null
+      }
+      return 0
+    case TypeKind.Array:
+      return Array.from({ length: info.length }, () =>
+        zeroValue(info.elemType),
+      )
+    case TypeKind.Struct:
+      return info.ctor ? new info.ctor() : null
+  }
+  return null
+}
+
+// isExportedName reports whether a Go identifier is exported.
+function isExportedName(name: string): boolean {
+  return /^\p{Lu}/u.test(name)
+}
+
+/**
+ * Returns the signature of a method for the type registry, as used by the
+ * handwritten packages to register the methods of their types.
+ *
+ * @param name The name of the method.
+ * @param args The types of the arguments.
+ * @param returns The types of the results.
+ * @returns The method signature.
+ */
+export function method(
+  name: string,
+  args: (TypeInfo | string)[],
+  returns: (TypeInfo | string)[],
+): MethodSignature {
+  return {
+    name,
+    args: args.map((type) => ({ type })),
+    returns: returns.map((type) => ({ type })),
+  }
+}
+
 /**
  * Registers a struct type with the runtime type system.
  *
@@ -188,7 +304,7 @@ const typeRegistry = new Map<string, TypeInfo>()
  * @param zeroValue The zero value for the type.
  * @param methods Array of method signatures for the struct.
  * @param ctor Constructor for the struct.
- * @param fields Record of field names and their types.
+ * @param fields The fields of the struct in declaration order, or a record of field names and their types.
  * @returns The struct type information object.
  */
 export const registerStructType = (
@@ -196,7 +312,7 @@ export const registerStructType = (
   zeroValue: any,
   methods: MethodSignature[],
   ctor: new (...args: any[]) => any,
-  fields: Record<string, TypeInfo | string> = {},
+  fields: StructFieldInfo[] | Record<string, TypeInfo | string> = [],
 ): StructTypeInfo => {
   const typeInfo: StructTypeInfo = {
     name,
@@ -204,12 +320,22 @@ export const registerStructType = (
     zeroValue,
     methods,
     ctor,
-    fields,
+    fields: structFields(fields),
   }
   typeRegistry.set(name, typeInfo)
   return typeInfo
 }
 
+/**
+ * Returns the registered type information for a type name.
+ *
+ * @param name The name of the type.
+ * @returns The type information, or undefined if no type has the name.
+ */
+export function getTypeByName(name: string): TypeInfo | undefined {
+  return typeRegistry.get(name)
+}
+
 /**
  * Registers an interface type with the runtime type system.
  *
@@ -233,6 +359,201 @@ export const registerInterfaceType = (
   return typeInfo
 }
 
+/**
+ * Registers a named type declared with a type other than a struct or
+ * interface, such as `type Status int` or `type HandlerFunc func()`.
+ *
+ * @param name The qualified name of the type.
+ * @param zeroValue The zero value for the type.
+ * @param methods Array of method signatures for the type.
+ * @param underlying The type information of the underlying type.
+ * @param methodFuncs The functions implementing the methods with value receivers.
+ * @returns The type information object.
+ */
+export const registerNamedType = (
+  name: string,
+  zeroValue: any,
+  methods: MethodSignature[],
+  underlying: TypeInfo,
+  methodFuncs?: MethodFuncs,
+): TypeInfo => {
+  const typeInfo = {
+    ...underlying,
+    name,
+    zeroValue,
+    methods,
+    underlying,
+    methodFuncs,
+  } as TypeInfo
+  typeRegistry.set(name, typeInfo)
+  return typeInfo
+}
+
+/**
+ * NamedValue holds a value of a named non-struct type with methods, such as
+ * `type Color int` with a String method, converted to an interface. The
+ * values of such types are represented by their underlying type, which does
+ * not carry the methods, so the box binds the methods registered for the
+ * type to the value. Type assertions to the named type unbox the value.
+ */
+export class NamedValue {
+  [method: string]: any
+
+  constructor(
+    public readonly value: any,
+    public readonly type: TypeInfo,
+  ) {
+    for (const [name, fn] of Object.entries(type.methodFuncs ?? {})) {
+      this[name] = fn.bind(undefined, value)
+    }
+  }
+}
+
+// The boxes of the values of each type, so that equal values converted to
+// interfaces are the same box and compare equal. Primitive values are held
+// weakly and their entries removed once the box is collected.
+const primitiveBoxes = new WeakMap<TypeInfo, Map<any, WeakRef<NamedValue>>>()
+const objectBoxes = new WeakMap<TypeInfo, WeakMap<object, NamedValue>>()
+const collectedBoxes = new FinalizationRegistry(
+  ({ boxes, value }: { boxes: Map<any, WeakRef<NamedValue>>; value: any }) => {
+    if (boxes.get(value)?.deref() === undefined) {
+      boxes.delete(value)
+    }
+  },
+)
+
+/**
+ * Boxes a value of a named non-struct type converted to an interface with
+ * the methods of the type, see NamedValue. Values of types registered
+ * without method functions are returned as is.
+ *
+ * @param value The value of the named type.
+ * @param type The named type or its name.
+ * @returns The boxed value.
+ */
+export function namedValue(value: any, type: TypeInfo | string): any {
+  const info = typeof type === 'string' ? typeRegistry.get(type) : type
+  if (info?.methodFuncs === undefined || value instanceof NamedValue) {
+    return value
+  }
+  if (
+    value !== null &&
+    (typeof value === 'object' || typeof value === 'function')
+  ) {
+    let boxes = objectBoxes.get(info)
+    if (boxes === undefined) {
+      boxes = new WeakMap()
+      objectBoxes.set(info, boxes)
+    }
+    let box = boxes.get(value)
+    if (box === undefined) {
+      box = new NamedValue(value, info)
+      boxes.set(value, box)
+    }
+    return box
+  }
+  let boxes = primitiveBoxes.get(info)
+  if (boxes === undefined) {
+    boxes = new Map()
+    primitiveBoxes.set(info, boxes)
+  }
+  let box = boxes.get(value)?.deref()
+  if (box === undefined) {
+    box = new NamedValue(value, info)
+    boxes.set(value, new WeakRef(box))
+    collectedBoxes.register(box, { boxes, value })
+  }
+  return box
+}
+
+// Marks slices, arrays and maps held in interfaces with their type, see
+// markType.
+const typeMark = Symbol('goscript.type')
+
+/**
+ * Marks a slice, array or map converted to an interface with its
+ * type, which cannot be inferred from its elements when they are values of
+ * interfaces or named non-struct types, as in `[]any{1}`.
+ *
+ * @param value The value held in the interface.
+ * @param type The type of the value.
+ * @returns The value.
+ */
+export function markType<T>(value: T, type: TypeInfo | string): T {
+  if (value !== null && typeof value === 'object') {
+    ;(value as any)[typeMark] = type
+  }
+  return value
+}
+
+/**
+ * Returns the type a value was marked with by markType, if any.
+ *
+ * @param value The value held in an interface.
+ * @returns The type of the value, or undefined if it is not marked.
+ */
+export function markedType(value: any): TypeInfo | string | undefined {
+  if (value === null || typeof value !== 'object') {
+    return undefined
+  }
+  return value[typeMark]
+}
+
+/**
+ * Returns the name of the basic type of a basic type info, following the
+ * underlying type of named types like `type Status int`.
+ *
+ * @param info The basic type info.
+ * @returns The name of the basic type, as in "int" or "string".
+ */
+export function basicTypeName(info: TypeInfo): string | undefined {
+  return info.underlying?.name ?? info.name
+}
+
+/**
+ * Returns the package path and the name of a qualified type name, as in
+ * ["io/fs", "PathError"] for "io/fs.PathError". Predeclared and unqualified
+ * names have an empty package path. The types of main packages are qualified
+ * with "main@" and the import path of the package, as in
+ * "main@example.com/cmd/tool.Config", and have the package path "main".
+ *
+ * @param name The qualified name of the type.
+ * @returns The package path and the type name.
+ */
+export function splitTypeName(name: string): [string, string] {
+  const dot = name.lastIndexOf('.')
+  if (dot === -1 || dot < name.lastIndexOf('/')) {
+    return ['', name]
+  }
+  const pkgPath = name.slice(0, dot)
+  if (pkgPath.startsWith('main@')) {
+    return ['main', name.slice(dot + 1)]
+  }
+  return [pkgPath, name.slice(dot + 1)]
+}
+
+/**
+ * Returns a qualified type name as Go prints it, with the package name in
+ * place of the package path, as in "fs.PathError" for "io/fs.PathError".
+ * The package name is taken to be the last element of the path, without a
+ * major version suffix.
+ *
+ * @param name The qualified name of the type.
+ * @returns The type name as Go prints it.
+ */
+export function typeString(name: string): string {
+  const [pkgPath, typeName] = splitTypeName(name)
+  if (pkgPath === '') {
+    return typeName
+  }
+  const elems = pkgPath.split('/')
+  let pkgName = elems[elems.length - 1]
+  if (/^v[0-9]+$/.test(pkgName) && elems.length > 1) {
+    pkgName = elems[elems.length - 2]
+  }
+  return pkgName.replace(/\.v[0-9]+$/, '') + '.' + typeName
+}
+
 /**
  * Represents the result of a type assertion.
  */
@@ -241,6 +562,19 @@ export interface TypeAssertResult<T> {
   ok: boolean
 }
 
+// The predeclared error interface, referenced by name in type assertions.
+const errorTypeInfo: TypeInfo = {
+  kind: TypeKind.Interface,
+  name: 'GoError',
+  methods: [
+    {
+      name: 'Error',
+      args: [],
+      returns: [{ type: { kind: TypeKind.Basic, name: 'string' } }],
+    },
+  ],
+}
+
 /**
  * Normalizes a type info to a structured TypeInfo object.
  *
@@ -253,6 +587,12 @@ function normalizeTypeInfo(info: string | TypeInfo): TypeInfo {
     if (typeInfo) {
       return typeInfo
     }
+    if (info === 'error') {
+      return errorTypeInfo
+    }
+    if (info === 'any') {
+      return { kind: TypeKind.Interface, methods: [] }
+    }
     return {
       kind: TypeKind.Basic,
       name: info,
@@ -399,14 +739,11 @@ export function areTypeInfosIdentical(
  */
 function validateMapKey(key: any, keyTypeInfo: TypeInfo): boolean {
   if (keyTypeInfo.kind === TypeKind.Basic) {
+    const name = basicTypeName(keyTypeInfo)
     // For string keys
-    if (keyTypeInfo.name === 'string') {
+    if (name === 'string') {
       return typeof key === 'string'
-    } else if (
-      keyTypeInfo.name === 'int' ||
-      keyTypeInfo.name === 'float64' ||
-      keyTypeInfo.name === 'number'
-    ) {
+    } else if (name === 'int' || name === 'float64' || name === 'number') {
       if (typeof key === 'string') {
         return /^-?\d+(\.\d+)?$/.test(key)
       } else {
@@ -425,14 +762,35 @@ function validateMapKey(key: any, keyTypeInfo: TypeInfo): boolean {
  * @returns True if the value matches the basic type, false otherwise.
  */
 function matchesBasicType(value: any, info: TypeInfo): boolean {
-  if (info.name === 'string') return typeof value === 'string'
-  if (info.name === 'number' || info.name === 'int' || info.name === 'float64')
+  // Values of named basic types are represented by their underlying type
+  const name = basicTypeName(info)
+  if (name === 'string') return typeof value === 'string'
+  if (name === 'number' || (name && numberTypeNames.has(name)))
     return typeof value === 'number'
-  if (info.name === 'boolean' || info.name === 'bool')
-    return typeof value === 'boolean'
+  if (name === 'bigint') return typeof value === 'bigint'
+  if (name === 'int64' || name === 'uint64')
+    return typeof value === 'number' || typeof value === 'bigint'
+  if (name === 'complex128' || name === 'complex64')
+    return value instanceof Complex
+  if (name === 'boolean' || name === 'bool') return typeof value === 'boolean'
   return false
 }
 
+// The Go basic types represented as number, struct field types use Go names.
+const numberTypeNames = new Set([
+  'int',
+  'int8',
+  'int16',
+  'int32',
+  'uint',
+  'uint8',
+  'uint16',
+  'uint32',
+  'uintptr',
+  'float32',
+  'float64',
+])
+
 /**
  * Checks if a value matches a struct type info.
  *
@@ -456,7 +814,8 @@ function matchesStructType(value: any, info: TypeInfo): boolean {
 
   // For anonymous struct types (no constructor), use structural matching
   if (typeof value === 'object' && value !== null && info.fields) {
-    const fieldNames = Object.keys(info.fields || {})
+    const fields = structFields(info.fields)
+    const fieldNames = fields.map((field) => field.name)
     const valueFields = Object.keys(value)
 
     const fieldsExist = fieldNames.every((field) => field in value)
@@ -466,11 +825,8 @@ function matchesStructType(value: any, info: TypeInfo): boolean {
     )
 
     if (fieldsExist && sameFieldCount && allFieldsInStruct) {
-      return Object.entries(info.fields).every(([fieldName, fieldType]) => {
-        return matchesType(
-          value[fieldName],
-          normalizeTypeInfo(fieldType as TypeInfo | string),
-        )
+      return fields.every((field) => {
+        return matchesType(value[field.name], normalizeTypeInfo(field.type))
       })
     }
 
@@ -578,6 +934,29 @@ function matchesInterfaceType(value: any, info: TypeInfo): boolean {
   })
 }
 
+/**
+ * Checks if an element of a map, slice or array matches the element type.
+ * Any element matches the empty interface, and nil elements match the types
+ * that have a nil value.
+ */
+function matchesElementType(
+  value: any,
+  elemType: string | TypeInfo,
+): boolean {
+  const info = normalizeTypeInfo(elemType)
+  if (isInterfaceTypeInfo(info) && info.methods.length === 0) {
+    return true
+  }
+  if (value === null || value === undefined) {
+    return (
+      info.kind !== TypeKind.Basic &&
+      info.kind !== TypeKind.Struct &&
+      info.kind !== TypeKind.Array
+    )
+  }
+  return matchesType(value, info)
+}
+
 /**
  * Checks if a value matches a map type info.
  *
@@ -615,10 +994,7 @@ function matchesMapType(value: any, info: TypeInfo): boolean {
         }
       }
 
-      if (
-        info.elemType &&
-        !matchesType(v, normalizeTypeInfo(info.elemType as string | TypeInfo))
-      ) {
+      if (info.elemType && !matchesElementType(v, info.elemType)) {
         return false
       }
     }
@@ -645,12 +1021,7 @@ function matchesArrayOrSliceType(value: any, info: TypeInfo): boolean {
 
     const sampleSize = Math.min(5, arr.length)
     for (let i = 0; i < sampleSize; i++) {
-      if (
-        !matchesType(
-          arr[i],
-          normalizeTypeInfo(info.elemType as string | TypeInfo),
-        )
-      ) {
+      if (!matchesElementType(arr[i], info.elemType)) {
         return false
       }
     }
@@ -779,6 +1150,13 @@ function matchesType(value: any, info: TypeInfo): boolean {
   if (value === null || value === undefined) {
     return false
   }
+  if (value instanceof NamedValue && info.kind !== TypeKind.Interface) {
+    // A boxed value only has its own type
+    return (
+      value.type === info ||
+      (info.name !== undefined && value.type.name === info.name)
+    )
+  }
 
   switch (info.kind) {
     case TypeKind.Basic:
@@ -831,6 +1209,13 @@ export function typeAssert<T>(
   if (isPointerTypeInfo(normalizedType) && value === null) {
     return { value: null as unknown as T, ok: true }
   }
+  if (
+    value instanceof NamedValue &&
+    !isInterfaceTypeInfo(normalizedType) &&
+    matchesType(value, normalizedType)
+  ) {
+    return { value: value.value as T, ok: true }
+  }
 
   // Removed struct matching logic - struct types should use nominal matching
   // via matchesStructType in matchesType, not structural matching here
@@ -838,7 +1223,8 @@ export function typeAssert<T>(
   if (
     isMapTypeInfo(normalizedType) &&
     typeof value === 'object' &&
-    value !== null
+    value !== null &&
+    !(value instanceof NamedValue)
   ) {
     if (normalizedType.keyType || normalizedType.elemType) {
       let entries: [any, any][] = []
@@ -917,11 +1303,14 @@ export function mustTypeAssert<T>(value: any, typeInfo: string | TypeInfo): T {
   const { value: assertedValue, ok } = typeAssert<T>(value, typeInfo)
   if (!ok) {
     const targetTypeName =
-      typeof typeInfo === 'string' ? typeInfo : (
-        typeInfo.name || JSON.stringify(typeInfo)
+      typeof typeInfo === 'string' ? typeString(typeInfo) : (
+        (typeInfo.name && typeString(typeInfo.name)) ||
+        JSON.stringify(typeInfo)
       )
     let valueTypeName: string | 'nil' = typeof value
-    if (value && value.constructor && value.constructor.name) {
+    if (value instanceof NamedValue && value.type.name) {
+      valueTypeName = typeString(value.type.name)
+    } else if (value && value.constructor && value.constructor.name) {
       valueTypeName = value.constructor.name
     }
     if (value === null) {
@@ -14,6 +14,21 @@ export function varRef<T>(v: T): VarRef<T> {
   return { value: v }
 }
 
+/**
+ * Reports whether v is a variable reference, the representation of pointers
+ * to values other than structs.
+ */
+export function isVarRef(v: unknown): v is VarRef<any> {
+  if (v === null || typeof v !== 'object') {
+    return false
+  }
+  if (Object.getPrototypeOf(v) !== Object.prototype) {
+    return false
+  }
+  const keys = Object.keys(v)
+  return keys.length === 1 && keys[0] === 'value'
+}
+
 /** Dereference a variable reference, throws on null → simulates Go panic. */
 export function unref<T>(b: VarRef<T>): T {
   if (b === null) {
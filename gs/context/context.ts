@@ -197,6 +197,7 @@ class cancelContext extends baseContext {
 class timerContext extends cancelContext {
   private deadline: Date
   private timer: any
+  private releaseTimer: (() => void) | null = null
 
   constructor(parent: ContextNonNil, deadline: Date) {
     super(parent)
@@ -217,6 +218,9 @@ class timerContext extends cancelContext {
       return
     }
 
+    // The deadline may unblock a goroutine waiting on Done, so the program
+    // is not reported as deadlocked until it passes or is canceled.
+    this.releaseTimer = $.addWakeup()
     this.timer = setTimeout(() => {
       this.cancel(true, DeadlineExceeded, null)
     }, duration)
@@ -228,6 +232,8 @@ class timerContext extends cancelContext {
       clearTimeout(this.timer)
       this.timer = null
     }
+    this.releaseTimer?.()
+    this.releaseTimer = null
   }
 }
 
@@ -0,0 +1,62 @@
+import { describe, it, expect } from 'vitest'
+import { createHmac } from 'node:crypto'
+import * as $ from '@goscript/builtin/index.js'
+import * as errors from '@goscript/errors/index.js'
+import * as md5 from '@goscript/crypto/md5/index.js'
+import * as sha1 from '@goscript/crypto/sha1/index.js'
+import * as sha256 from '@goscript/crypto/sha256/index.js'
+import * as hmac from './index.js'
+
+function hex(b: $.Bytes): string {
+  const s = Array.from($.bytesToUint8Array(b), (x) => x.toString(16))
+  return s.map((x) => x.padStart(2, '0')).join('')
+}
+
+describe('crypto/hmac', () => {
+  it('computes MACs like Node', () => {
+    const msg = $.stringToBytes('The quick brown fox jumps over the lazy dog')
+    const algs = [
+      [sha256.New, 'sha256'],
+      [sha1.New, 'sha1'],
+      [md5.New, 'md5'],
+    ] as const
+    for (const [newHash, alg] of algs) {
+      for (const n of [0, 3, 64, 100]) {
+        const key = Uint8Array.from({ length: n }, (_, i) => i)
+        const mac = hmac.New(newHash, key)!
+        mac.Write(msg)
+        const want = createHmac(alg, key).update(msg).digest('hex')
+        expect(hex(mac.Sum(null))).toBe(want)
+        expect(hex(mac.Sum(null))).toBe(want)
+        mac.Reset()
+        mac.Write(msg)
+        expect(hex(mac.Sum($.stringToBytes('x')))).toBe('78' + want)
+      }
+    }
+  })
+
+  it('clones', () => {
+    const mac = hmac.New(sha256.New, $.stringToBytes('key')) as any
+    mac.Write($.stringToBytes('a'))
+    const [c, err] = mac.Clone()
+    expect(err).toBeNull()
+    c.Write($.stringToBytes('b'))
+    const want = createHmac('sha256', 'key').update('ab').digest('hex')
+    expect(hex(c.Sum(null))).toBe(want)
+
+    const plain = () => {
+      const h = sha256.New() as any
+      return { ...h, Write: h.Write.bind(h), BlockSize: () => 64 } as any
+    }
+    const [, cerr] = (hmac.New(plain, null) as any).Clone()
+    expect(cerr.Error()).toBe('crypto/hmac: hash does not support hash.Cloner')
+    expect(errors.Is(cerr, errors.ErrUnsupported)).toBe(true)
+  })
+
+  it('compares MACs', () => {
+    const a = new Uint8Array([1, 2, 3])
+    expect(hmac.Equal(a, [1, 2, 3])).toBe(true)
+    expect(hmac.Equal(a, [1, 2, 4])).toBe(false)
+    expect(hmac.Equal(a, [1, 2])).toBe(false)
+  })
+})
@@ -0,0 +1,143 @@
+// Package hmac implements the Keyed-Hash Message Authentication Code (HMAC)
+// as defined in U.S. Federal Information Processing Standards Publication
+// 198. An HMAC is a cryptographic hash that uses a key to sign a message.
+// The receiver verifies the hash by recomputing it using the same key.
+//
+// Receivers should be careful to use Equal to compare MACs in order to
+// avoid timing side-channels.
+
+import * as $ from '@goscript/builtin/index.js'
+import * as errors from '@goscript/errors/index.js'
+import * as hash from '@goscript/hash/index.js'
+import { errorMethods, hmacMethods } from './typeinfo.js'
+
+// key is zero padded to the block size of the hash function
+// ipad = 0x36 byte repeated for key length
+// opad = 0x5c byte repeated for key length
+// hmac = H([key ^ opad] H([key ^ ipad] text))
+
+class hmac {
+  constructor(
+    public opad: Uint8Array,
+    public ipad: Uint8Array,
+    public outer: NonNullable<hash.Hash>,
+    public inner: NonNullable<hash.Hash>,
+  ) {}
+
+  public Sum(b: $.Bytes): $.Bytes {
+    const origLen = $.len(b)
+    const inner = this.inner.Sum(b)
+    this.outer.Reset()
+    this.outer.Write(this.opad)
+    this.outer.Write($.goSlice(inner, origLen, undefined))
+    return this.outer.Sum($.goSlice(inner, undefined, origLen))
+  }
+
+  public Write(p: $.Bytes): [number, $.GoError] {
+    return this.inner.Write(p)
+  }
+
+  public Size(): number {
+    return this.outer.Size()
+  }
+
+  public BlockSize(): number {
+    return this.inner.BlockSize()
+  }
+
+  public Reset(): void {
+    this.inner.Reset()
+    this.inner.Write(this.ipad)
+  }
+
+  // Clone implements hash.Cloner if the underlying hash does.
+  // Otherwise, it returns an error wrapping errors.ErrUnsupported.
+  public Clone(): [hash.Cloner, $.GoError] {
+    const ic = this.inner as hash.Cloner
+    const oc = this.outer as hash.Cloner
+    if (typeof ic?.Clone !== 'function' || typeof oc?.Clone !== 'function') {
+      return [null, new errCloneUnsupported()]
+    }
+    const [inner, ierr] = ic.Clone()
+    if (ierr !== null) {
+      return [null, new errCloneUnsupported()]
+    }
+    const [outer, oerr] = oc.Clone()
+    if (oerr !== null) {
+      return [null, new errCloneUnsupported()]
+    }
+    return [new hmac(this.opad, this.ipad, outer!, inner!), null]
+  }
+
+  static __typeInfo = $.registerStructType(
+    'crypto/hmac.hmac',
+    null,
+    hmacMethods,
+    hmac,
+    [],
+  )
+}
+
+class errCloneUnsupported {
+  public Error(): string {
+    return 'crypto/hmac: hash does not support hash.Cloner'
+  }
+
+  public Unwrap(): $.GoError {
+    return errors.ErrUnsupported
+  }
+
+  static __typeInfo = $.registerStructType(
+    'crypto/hmac.errCloneUnsupported',
+    new errCloneUnsupported(),
+    errorMethods,
+    errCloneUnsupported,
+    [],
+  )
+}
+
+// New returns a new HMAC hash using the given hash.Hash type and key.
+// New functions like crypto/sha256.New can be used as h.
+// h must return a new Hash every time it is called.
+export function New(h: () => hash.Hash, key: $.Bytes): hash.Hash {
+  const outer = h()!
+  const inner = h()!
+  if (outer === inner) {
+    $.panic(
+      'crypto/hmac: hash generation function does not produce unique values',
+    )
+  }
+  const blocksize = inner.BlockSize()
+  const ipad = new Uint8Array(blocksize)
+  const opad = new Uint8Array(blocksize)
+  if ($.len(key) > blocksize) {
+    // If key is too big, hash it.
+    outer.Write(key)
+    key = outer.Sum(null)
+  }
+  $.copy(ipad, key)
+  $.copy(opad, key)
+  for (let i = 0; i < blocksize; i++) {
+    ipad[i] ^= 0x36
+    opad[i] ^= 0x5c
+  }
+  inner.Write(ipad)
+  return new hmac(opad, ipad, outer, inner)
+}
+
+// Equal compares two MACs for equality without leaking timing information.
+export function Equal(mac1: $.Bytes, mac2: $.Bytes): boolean {
+  // We don't have to be constant time if the lengths of the MACs are
+  // different as that suggests that a completely different hash function
+  // was used.
+  const x = $.bytesToUint8Array(mac1)
+  const y = $.bytesToUint8Array(mac2)
+  if (x.length !== y.length) {
+    return false
+  }
+  let v = 0
+  for (let i = 0; i < x.length; i++) {
+    v |= x[i] ^ y[i]
+  }
+  return v === 0
+}
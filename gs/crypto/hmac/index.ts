@@ -0,0 +1,4 @@
+// HMAC wraps any hash.Hash, whose interfaces the hash package registers.
+import '@goscript/hash/index.js'
+
+export { Equal, New } from './hmac.js'
@@ -0,0 +1,34 @@
+import * as $ from '@goscript/builtin/index.js'
+
+// Type information for the methods of the hmac types.
+
+const intType: $.TypeInfo = { kind: $.TypeKind.Basic, name: 'int' }
+const bytesType: $.TypeInfo = {
+  kind: $.TypeKind.Slice,
+  elemType: { kind: $.TypeKind.Basic, name: 'byte' },
+}
+const errorType: $.TypeInfo = {
+  kind: $.TypeKind.Interface,
+  name: 'GoError',
+  methods: [
+    {
+      name: 'Error',
+      args: [],
+      returns: [{ type: { kind: $.TypeKind.Basic, name: 'string' } }],
+    },
+  ],
+}
+
+export const hmacMethods: $.MethodSignature[] = [
+  $.method('BlockSize', [], [intType]),
+  $.method('Clone', [], ['hash.Cloner', errorType]),
+  $.method('Reset', [], []),
+  $.method('Size', [], [intType]),
+  $.method('Sum', [bytesType], [bytesType]),
+  $.method('Write', [bytesType], [intType, errorType]),
+]
+
+export const errorMethods: $.MethodSignature[] = [
+  $.method('Error', [], [{ kind: $.TypeKind.Basic, name: 'string' }]),
+  $.method('Unwrap', [], [errorType]),
+]
@@ -0,0 +1,48 @@
+// Package native computes digests of large inputs with the crypto module
+// of Node, the JavaScript counterpart of crypto/internal/boring.
+//
+// The digest functions of WebCrypto are asynchronous, so they cannot back
+// the synchronous Go API. Where Node's crypto module is not available the
+// digest packages use their TypeScript implementations.
+
+import * as $ from '@goscript/builtin/index.js'
+
+// minSize is the input size from which calling into Node is faster than
+// hashing in TypeScript.
+const minSize = 4096
+
+let nodeCrypto: any = undefined
+
+// load returns the Node crypto module, or null if it is not available.
+function load(): any {
+  if (nodeCrypto === undefined) {
+    nodeCrypto = null
+    const proc = (globalThis as any).process
+    if (typeof proc?.getBuiltinModule === 'function') {
+      try {
+        nodeCrypto = proc.getBuiltinModule('node:crypto') ?? null
+      } catch {
+        nodeCrypto = null
+      }
+    }
+  }
+  return nodeCrypto
+}
+
+// Sum returns the digest of p with the Node hash function algorithm, such
+// as 'sha256', or null if p is small or Node's crypto module is not
+// available.
+export function Sum(algorithm: string, p: $.Bytes): Uint8Array | null {
+  if ($.len(p) < minSize) {
+    return null
+  }
+  const crypto = load()
+  if (crypto === null) {
+    return null
+  }
+  const digest = crypto
+    .createHash(algorithm)
+    .update($.bytesToUint8Array(p))
+    .digest()
+  return new Uint8Array(digest.buffer, digest.byteOffset, digest.length)
+}
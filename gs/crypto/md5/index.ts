@@ -0,0 +1,4 @@
+// Load the hash package to register hash.Hash for the digest New returns.
+import '@goscript/hash/index.js'
+
+export { BlockSize, New, Size, Sum } from './md5.js'
@@ -0,0 +1,38 @@
+import { describe, it, expect } from 'vitest'
+import { createHash } from 'node:crypto'
+import * as $ from '@goscript/builtin/index.js'
+import * as md5 from './index.js'
+
+function hex(b: $.Bytes | number[]): string {
+  const s = Array.from(b as ArrayLike<number>, (x) => x.toString(16))
+  return s.map((x) => x.padStart(2, '0')).join('')
+}
+
+function input(n: number): Uint8Array {
+  return Uint8Array.from({ length: n }, (_, i) => (i * 7 + 3) & 0xff)
+}
+
+describe('crypto/md5', () => {
+  it('sums golden inputs', () => {
+    expect(hex(md5.Sum($.stringToBytes('')))).toBe(
+      'd41d8cd98f00b204e9800998ecf8427e',
+    )
+    expect(hex(md5.Sum($.stringToBytes('abc')))).toBe(
+      '900150983cd24fb0d6963f7d28e17f72',
+    )
+  })
+
+  it('hashes streamed writes like Node', () => {
+    for (const n of [0, 1, 55, 56, 63, 64, 65, 127, 1000, 5000]) {
+      const data = input(n)
+      const h = md5.New()!
+      for (let i = 0; i < n; i += 13) {
+        h.Write(data.subarray(i, i + 13))
+      }
+      const want = createHash('md5').update(data).digest('hex')
+      expect(hex(h.Sum(null))).toBe(want)
+      expect(hex(h.Sum(null))).toBe(want)
+      expect(hex(md5.Sum(data))).toBe(want)
+    }
+  })
+})
@@ -0,0 +1,239 @@
+// Package md5 implements the MD5 hash algorithm as defined in RFC 1321.
+//
+// MD5 is cryptographically broken and should not be used for secure
+// applications.
+//
+// Sum hashes large inputs with Node's crypto module where it is available.
+
+import * as $ from '@goscript/builtin/index.js'
+import * as native from '@goscript/crypto/internal/native/index.js'
+import * as hash from '@goscript/hash/index.js'
+import { digestMethods } from './typeinfo.js'
+
+// The size of an MD5 checksum in bytes.
+export const Size = 16
+
+// The blocksize of MD5 in bytes.
+export const BlockSize = 64
+
+const init = [0x67452301, 0xefcdab89, 0x98badcfe, 0x10325476]
+
+// _K holds the additive constants of the 64 steps.
+const _K = new Int32Array([
+  0xd76aa478, 0xe8c7b756, 0x242070db, 0xc1bdceee, 0xf57c0faf, 0x4787c62a,
+  0xa8304613, 0xfd469501, 0x698098d8, 0x8b44f7af, 0xffff5bb1, 0x895cd7be,
+  0x6b901122, 0xfd987193, 0xa679438e, 0x49b40821, 0xf61e2562, 0xc040b340,
+  0x265e5a51, 0xe9b6c7aa, 0xd62f105d, 0x02441453, 0xd8a1e681, 0xe7d3fbc8,
+  0x21e1cde6, 0xc33707d6, 0xf4d50d87, 0x455a14ed, 0xa9e3e905, 0xfcefa3f8,
+  0x676f02d9, 0x8d2a4c8a, 0xfffa3942, 0x8771f681, 0x6d9d6122, 0xfde5380c,
+  0xa4beea44, 0x4bdecfa9, 0xf6bb4b60, 0xbebfbc70, 0x289b7ec6, 0xeaa127fa,
+  0xd4ef3085, 0x04881d05, 0xd9d4d039, 0xe6db99e5, 0x1fa27cf8, 0xc4ac5665,
+  0xf4292244, 0x432aff97, 0xab9423a7, 0xfc93a039, 0x655b59c3, 0x8f0ccc92,
+  0xffeff47d, 0x85845dd1, 0x6fa87e4f, 0xfe2ce6e0, 0xa3014314, 0x4e0811a1,
+  0xf7537e82, 0xbd3af235, 0x2ad7d2bb, 0xeb86d391,
+])
+
+// shifts holds the rotations of the steps of each round.
+const shifts = [
+  [7, 12, 17, 22],
+  [5, 9, 14, 20],
+  [4, 11, 16, 23],
+  [6, 10, 15, 21],
+]
+
+// x is the input block of block.
+const x = new Int32Array(16)
+
+// block hashes the whole blocks of p into the state dig.
+function block(dig: Int32Array, p: Uint8Array): void {
+  // load state
+  let a = dig[0]
+  let b = dig[1]
+  let c = dig[2]
+  let d = dig[3]
+
+  for (let i = 0; i <= p.length - BlockSize; i += BlockSize) {
+    // save current state
+    const aa = a
+    const bb = b
+    const cc = c
+    const dd = d
+
+    // load input block
+    for (let j = 0; j < 16; j++) {
+      const k = i + j * 4
+      x[j] = p[k] | (p[k + 1] << 8) | (p[k + 2] << 16) | (p[k + 3] << 24)
+    }
+
+    for (let j = 0; j < 64; j++) {
+      let f: number
+      let g: number
+      switch (j >> 4) {
+        case 0:
+          f = ((c ^ d) & b) ^ d
+          g = j
+          break
+        case 1:
+          f = ((b ^ c) & d) ^ c
+          g = (5 * j + 1) & 0xf
+          break
+        case 2:
+          f = b ^ c ^ d
+          g = (3 * j + 5) & 0xf
+          break
+        default:
+          f = c ^ (b | ~d)
+          g = (7 * j) & 0xf
+      }
+      const s = shifts[j >> 4][j & 3]
+      const t = (f + a + x[g] + _K[j]) | 0
+      a = d
+      d = c
+      c = b
+      b = (b + ((t << s) | (t >>> (32 - s)))) | 0
+    }
+
+    // add saved state
+    a = (a + aa) | 0
+    b = (b + bb) | 0
+    c = (c + cc) | 0
+    d = (d + dd) | 0
+  }
+
+  // save state
+  dig[0] = a
+  dig[1] = b
+  dig[2] = c
+  dig[3] = d
+}
+
+// digest represents the partial evaluation of a checksum.
+class digest {
+  public s = new Int32Array(4)
+  public x = new Uint8Array(BlockSize)
+  public nx = 0
+  public len = 0
+
+  constructor() {
+    this.Reset()
+  }
+
+  public Clone(): [hash.Cloner, $.GoError] {
+    return [this.clone(), null]
+  }
+
+  public clone(): digest {
+    const r = new digest()
+    r.s.set(this.s)
+    r.x.set(this.x)
+    r.nx = this.nx
+    r.len = this.len
+    return r
+  }
+
+  public Reset(): void {
+    this.s.set(init)
+    this.nx = 0
+    this.len = 0
+  }
+
+  public Size(): number {
+    return Size
+  }
+
+  public BlockSize(): number {
+    return BlockSize
+  }
+
+  public Write(p: $.Bytes): [number, $.GoError] {
+    let b = $.bytesToUint8Array(p)
+    const nn = b.length
+    this.len += nn
+    if (this.nx > 0) {
+      const n = Math.min(BlockSize - this.nx, b.length)
+      this.x.set(b.subarray(0, n), this.nx)
+      this.nx += n
+      if (this.nx === BlockSize) {
+        block(this.s, this.x)
+        this.nx = 0
+      }
+      b = b.subarray(n)
+    }
+    if (b.length >= BlockSize) {
+      const n = b.length - (b.length % BlockSize)
+      block(this.s, b.subarray(0, n))
+      b = b.subarray(n)
+    }
+    if (b.length > 0) {
+      this.x.set(b)
+      this.nx = b.length
+    }
+    return [nn, null]
+  }
+
+  public Sum(b: $.Bytes): $.Bytes {
+    // Make a copy of d so that caller can keep writing and summing.
+    const d0 = this.clone()
+    return $.append(b, d0.checkSum())
+  }
+
+  public checkSum(): Uint8Array {
+    // Append 0x80 to the end of the message and then append zeros
+    // until the length is a multiple of 56 bytes. Finally append
+    // 8 bytes representing the message length in bits.
+    //
+    // 1 byte end marker :: 0-63 padding bytes :: 8 byte length
+    const tmp = new Uint8Array(1 + 63 + 8)
+    tmp[0] = 0x80
+    const pad = (((55 - this.len) % 64) + 64) % 64 // number of padding bytes
+    const bits = this.len * 8 // append length in bits
+    putUint32(tmp, 1 + pad, bits)
+    putUint32(tmp, 1 + pad + 4, Math.floor(bits / 0x100000000))
+    this.Write(tmp.subarray(0, 1 + pad + 8))
+
+    // The previous write ensures that a whole number of
+    // blocks (i.e. a multiple of 64 bytes) have been hashed.
+    if (this.nx !== 0) {
+      $.panic('d.nx != 0')
+    }
+
+    const sum = new Uint8Array(Size)
+    for (let i = 0; i < 4; i++) {
+      putUint32(sum, i * 4, this.s[i])
+    }
+    return sum
+  }
+
+  static __typeInfo = $.registerStructType(
+    'crypto/md5.digest',
+    new digest(),
+    digestMethods,
+    digest,
+    [],
+  )
+}
+
+// putUint32 stores the little-endian bytes of v at b[i:i+4].
+function putUint32(b: Uint8Array, i: number, v: number): void {
+  b[i] = v
+  b[i + 1] = v >>> 8
+  b[i + 2] = v >>> 16
+  b[i + 3] = v >>> 24
+}
+
+// New returns a new hash.Hash computing the MD5 checksum. The Hash also
+// implements hash.Cloner.
+export function New(): hash.Hash {
+  return new digest()
+}
+
+// Sum returns the MD5 checksum of the data.
+export function Sum(data: $.Bytes): number[] {
+  const sum = native.Sum('md5', data)
+  if (sum !== null) {
+    return Array.from(sum)
+  }
+  const d = new digest()
+  d.Write(data)
+  return Array.from(d.checkSum())
+}
@@ -0,0 +1,5 @@
+// The SHA-1 digest is used through hash.Hash, registered by the hash
+// package.
+import '@goscript/hash/index.js'
+
+export { BlockSize, New, Size, Sum } from './sha1.js'
@@ -0,0 +1,38 @@
+import { describe, it, expect } from 'vitest'
+import { createHash } from 'node:crypto'
+import * as $ from '@goscript/builtin/index.js'
+import * as sha1 from './index.js'
+
+function hex(b: $.Bytes | number[]): string {
+  const s = Array.from(b as ArrayLike<number>, (x) => x.toString(16))
+  return s.map((x) => x.padStart(2, '0')).join('')
+}
+
+function input(n: number): Uint8Array {
+  return Uint8Array.from({ length: n }, (_, i) => (i * 7 + 3) & 0xff)
+}
+
+describe('crypto/sha1', () => {
+  it('sums golden inputs', () => {
+    expect(hex(sha1.Sum($.stringToBytes('')))).toBe(
+      'da39a3ee5e6b4b0d3255bfef95601890afd80709',
+    )
+    expect(hex(sha1.Sum($.stringToBytes('abc')))).toBe(
+      'a9993e364706816aba3e25717850c26c9cd0d89d',
+    )
+  })
+
+  it('hashes streamed writes like Node', () => {
+    for (const n of [0, 1, 55, 56, 63, 64, 65, 127, 1000, 5000]) {
+      const data = input(n)
+      const h = sha1.New()!
+      for (let i = 0; i < n; i += 13) {
+        h.Write(data.subarray(i, i + 13))
+      }
+      const want = createHash('sha1').update(data).digest('hex')
+      expect(hex(h.Sum(null))).toBe(want)
+      expect(hex(h.Sum(null))).toBe(want)
+      expect(hex(sha1.Sum(data))).toBe(want)
+    }
+  })
+})
@@ -0,0 +1,222 @@
+// Package sha1 implements the SHA-1 hash algorithm as defined in RFC 3174.
+//
+// SHA-1 is cryptographically broken and should not be used for secure
+// applications.
+//
+// Sum hashes large inputs with Node's crypto module where it is available.
+
+import * as $ from '@goscript/builtin/index.js'
+import * as native from '@goscript/crypto/internal/native/index.js'
+import * as hash from '@goscript/hash/index.js'
+import { digestMethods } from './typeinfo.js'
+
+// The size of a SHA-1 checksum in bytes.
+export const Size = 20
+
+// The blocksize of SHA-1 in bytes.
+export const BlockSize = 64
+
+const chunk = 64
+
+const init = [0x67452301, 0xefcdab89, 0x98badcfe, 0x10325476, 0xc3d2e1f0]
+
+const _K0 = 0x5a827999
+const _K1 = 0x6ed9eba1
+const _K2 = 0x8f1bbcdc
+const _K3 = 0xca62c1d6
+
+// w is the message schedule of block.
+const w = new Int32Array(16)
+
+// block hashes the whole chunks of p into the state dig.
+function block(dig: Int32Array, p: Uint8Array): void {
+  let h0 = dig[0]
+  let h1 = dig[1]
+  let h2 = dig[2]
+  let h3 = dig[3]
+  let h4 = dig[4]
+  for (let off = 0; off + chunk <= p.length; off += chunk) {
+    for (let i = 0; i < 16; i++) {
+      const j = off + i * 4
+      w[i] = (p[j] << 24) | (p[j + 1] << 16) | (p[j + 2] << 8) | p[j + 3]
+    }
+
+    let a = h0
+    let b = h1
+    let c = h2
+    let d = h3
+    let e = h4
+
+    // Each of the four 20-iteration rounds
+    // differs only in the computation of f and
+    // the choice of K (_K0, _K1, etc).
+    for (let i = 0; i < 80; i++) {
+      if (i >= 16) {
+        const tmp =
+          w[(i - 3) & 0xf] ^ w[(i - 8) & 0xf] ^ w[(i - 14) & 0xf] ^ w[i & 0xf]
+        w[i & 0xf] = (tmp << 1) | (tmp >>> 31)
+      }
+      let f: number
+      let k: number
+      if (i < 20) {
+        f = (b & c) | (~b & d)
+        k = _K0
+      } else if (i < 40) {
+        f = b ^ c ^ d
+        k = _K1
+      } else if (i < 60) {
+        f = ((b | c) & d) | (b & c)
+        k = _K2
+      } else {
+        f = b ^ c ^ d
+        k = _K3
+      }
+      const t = (((a << 5) | (a >>> 27)) + f + e + w[i & 0xf] + k) | 0
+      e = d
+      d = c
+      c = (b << 30) | (b >>> 2)
+      b = a
+      a = t
+    }
+
+    h0 = (h0 + a) | 0
+    h1 = (h1 + b) | 0
+    h2 = (h2 + c) | 0
+    h3 = (h3 + d) | 0
+    h4 = (h4 + e) | 0
+  }
+  dig[0] = h0
+  dig[1] = h1
+  dig[2] = h2
+  dig[3] = h3
+  dig[4] = h4
+}
+
+// digest represents the partial evaluation of a checksum.
+class digest {
+  public h = new Int32Array(5)
+  public x = new Uint8Array(chunk)
+  public nx = 0
+  public len = 0
+
+  constructor() {
+    this.Reset()
+  }
+
+  public Clone(): [hash.Cloner, $.GoError] {
+    return [this.clone(), null]
+  }
+
+  public clone(): digest {
+    const r = new digest()
+    r.h.set(this.h)
+    r.x.set(this.x)
+    r.nx = this.nx
+    r.len = this.len
+    return r
+  }
+
+  public Reset(): void {
+    this.h.set(init)
+    this.nx = 0
+    this.len = 0
+  }
+
+  public Size(): number {
+    return Size
+  }
+
+  public BlockSize(): number {
+    return BlockSize
+  }
+
+  public Write(p: $.Bytes): [number, $.GoError] {
+    let b = $.bytesToUint8Array(p)
+    const nn = b.length
+    this.len += nn
+    if (this.nx > 0) {
+      const n = Math.min(chunk - this.nx, b.length)
+      this.x.set(b.subarray(0, n), this.nx)
+      this.nx += n
+      if (this.nx === chunk) {
+        block(this.h, this.x)
+        this.nx = 0
+      }
+      b = b.subarray(n)
+    }
+    if (b.length >= chunk) {
+      const n = b.length - (b.length % chunk)
+      block(this.h, b.subarray(0, n))
+      b = b.subarray(n)
+    }
+    if (b.length > 0) {
+      this.x.set(b)
+      this.nx = b.length
+    }
+    return [nn, null]
+  }
+
+  public Sum(b: $.Bytes): $.Bytes {
+    // Make a copy of d so that caller can keep writing and summing.
+    const d0 = this.clone()
+    return $.append(b, d0.checkSum())
+  }
+
+  public checkSum(): Uint8Array {
+    const len = this.len
+    // Padding. Add a 1 bit and 0 bits until 56 bytes mod 64.
+    const tmp = new Uint8Array(64 + 8) // padding + length buffer
+    tmp[0] = 0x80
+    const t = len % 64 < 56 ? 56 - (len % 64) : 64 + 56 - (len % 64)
+
+    // Length in bits.
+    const bits = len * 8
+    const padlen = tmp.subarray(0, t + 8)
+    putUint32(padlen, t, Math.floor(bits / 0x100000000))
+    putUint32(padlen, t + 4, bits)
+    this.Write(padlen)
+
+    if (this.nx !== 0) {
+      $.panic('d.nx != 0')
+    }
+
+    const sum = new Uint8Array(Size)
+    for (let i = 0; i < 5; i++) {
+      putUint32(sum, i * 4, this.h[i])
+    }
+    return sum
+  }
+
+  static __typeInfo = $.registerStructType(
+    'crypto/sha1.digest',
+    new digest(),
+    digestMethods,
+    digest,
+    [],
+  )
+}
+
+// putUint32 stores the big-endian bytes of v at b[i:i+4].
+function putUint32(b: Uint8Array, i: number, v: number): void {
+  b[i] = v >>> 24
+  b[i + 1] = v >>> 16
+  b[i + 2] = v >>> 8
+  b[i + 3] = v
+}
+
+// New returns a new hash.Hash computing the SHA1 checksum. The Hash also
+// implements hash.Cloner.
+export function New(): hash.Hash {
+  return new digest()
+}
+
+// Sum returns the SHA-1 checksum of the data.
+export function Sum(data: $.Bytes): number[] {
+  const sum = native.Sum('sha1', data)
+  if (sum !== null) {
+    return Array.from(sum)
+  }
+  const d = new digest()
+  d.Write(data)
+  return Array.from(d.checkSum())
+}
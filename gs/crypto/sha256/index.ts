@@ -0,0 +1,13 @@
+// New and New224 return a hash.Hash; its interface type is registered by
+// the hash package.
+import '@goscript/hash/index.js'
+
+export {
+  BlockSize,
+  New,
+  New224,
+  Size,
+  Size224,
+  Sum224,
+  Sum256,
+} from './sha256.js'
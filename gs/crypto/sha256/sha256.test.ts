@@ -0,0 +1,62 @@
+import { describe, it, expect } from 'vitest'
+import { createHash } from 'node:crypto'
+import * as $ from '@goscript/builtin/index.js'
+import * as sha256 from './index.js'
+
+function hex(b: $.Bytes | number[]): string {
+  const s = Array.from(b as ArrayLike<number>, (x) => x.toString(16))
+  return s.map((x) => x.padStart(2, '0')).join('')
+}
+
+function input(n: number): Uint8Array {
+  return Uint8Array.from({ length: n }, (_, i) => (i * 7 + 3) & 0xff)
+}
+
+describe('crypto/sha256', () => {
+  it('sums golden inputs', () => {
+    expect(hex(sha256.Sum256($.stringToBytes('')))).toBe(
+      'e3b0c44298fc1c149afbf4c8996fb92427ae41e4649b934ca495991b7852b855',
+    )
+    expect(hex(sha256.Sum256($.stringToBytes('abc')))).toBe(
+      'ba7816bf8f01cfea414140de5dae2223b00361a396177a9cb410ff61f20015ad',
+    )
+    expect(hex(sha256.Sum224($.stringToBytes('abc')))).toBe(
+      '23097d223405d8228642a477bda255b32aadbce4bda0b3f7e36c9da7',
+    )
+  })
+
+  it('hashes streamed writes like Node', () => {
+    for (const n of [0, 1, 55, 56, 63, 64, 65, 127, 1000, 5000]) {
+      const data = input(n)
+      for (const [h, alg] of [
+        [sha256.New(), 'sha256'],
+        [sha256.New224(), 'sha224'],
+      ] as const) {
+        for (let i = 0; i < n; i += 13) {
+          h!.Write(data.subarray(i, i + 13))
+        }
+        const want = createHash(alg).update(data).digest('hex')
+        expect(hex(h!.Sum(null))).toBe(want)
+        // Sum does not change the state.
+        expect(hex(h!.Sum($.stringToBytes('x')))).toBe('78' + want)
+      }
+      const want = createHash('sha256').update(data).digest('hex')
+      expect(hex(sha256.Sum256(data))).toBe(want)
+    }
+  })
+
+  it('resets and clones', () => {
+    const h = sha256.New() as any
+    h.Write($.stringToBytes('ab'))
+    const [c, err] = h.Clone()
+    expect(err).toBeNull()
+    h.Write($.stringToBytes('c'))
+    c.Write($.stringToBytes('x'))
+    expect(hex(h.Sum(null))).toBe(hex(sha256.Sum256($.stringToBytes('abc'))))
+    expect(hex(c.Sum(null))).toBe(hex(sha256.Sum256($.stringToBytes('abx'))))
+    h.Reset()
+    expect(hex(h.Sum(null))).toBe(hex(sha256.Sum256(new Uint8Array(0))))
+    expect(h.Size()).toBe(sha256.Size)
+    expect(h.BlockSize()).toBe(sha256.BlockSize)
+  })
+})
@@ -0,0 +1,274 @@
+// Package sha256 implements the SHA224 and SHA256 hash algorithms as defined
+// in FIPS 180-4.
+//
+// Sum224 and Sum256 hash large inputs with Node's crypto module where it is
+// available.
+
+import * as $ from '@goscript/builtin/index.js'
+import * as native from '@goscript/crypto/internal/native/index.js'
+import * as hash from '@goscript/hash/index.js'
+import { digestMethods } from './typeinfo.js'
+
+// The size of a SHA256 checksum in bytes.
+export const Size = 32
+
+// The size of a SHA224 checksum in bytes.
+export const Size224 = 28
+
+// The blocksize of SHA256 and SHA224 in bytes.
+export const BlockSize = 64
+
+const chunk = 64
+
+const init = [
+  0x6a09e667, 0xbb67ae85, 0x3c6ef372, 0xa54ff53a, 0x510e527f, 0x9b05688c,
+  0x1f83d9ab, 0x5be0cd19,
+]
+
+const init224 = [
+  0xc1059ed8, 0x367cd507, 0x3070dd17, 0xf70e5939, 0xffc00b31, 0x68581511,
+  0x64f98fa7, 0xbefa4fa4,
+]
+
+const _K = new Int32Array([
+  0x428a2f98, 0x71374491, 0xb5c0fbcf, 0xe9b5dba5, 0x3956c25b, 0x59f111f1,
+  0x923f82a4, 0xab1c5ed5, 0xd807aa98, 0x12835b01, 0x243185be, 0x550c7dc3,
+  0x72be5d74, 0x80deb1fe, 0x9bdc06a7, 0xc19bf174, 0xe49b69c1, 0xefbe4786,
+  0x0fc19dc6, 0x240ca1cc, 0x2de92c6f, 0x4a7484aa, 0x5cb0a9dc, 0x76f988da,
+  0x983e5152, 0xa831c66d, 0xb00327c8, 0xbf597fc7, 0xc6e00bf3, 0xd5a79147,
+  0x06ca6351, 0x14292967, 0x27b70a85, 0x2e1b2138, 0x4d2c6dfc, 0x53380d13,
+  0x650a7354, 0x766a0abb, 0x81c2c92e, 0x92722c85, 0xa2bfe8a1, 0xa81a664b,
+  0xc24b8b70, 0xc76c51a3, 0xd192e819, 0xd6990624, 0xf40e3585, 0x106aa070,
+  0x19a4c116, 0x1e376c08, 0x2748774c, 0x34b0bcb5, 0x391c0cb3, 0x4ed8aa4a,
+  0x5b9cca4f, 0x682e6ff3, 0x748f82ee, 0x78a5636f, 0x84c87814, 0x8cc70208,
+  0x90befffa, 0xa4506ceb, 0xbef9a3f7, 0xc67178f2,
+])
+
+// w is the message schedule of block.
+const w = new Int32Array(64)
+
+// block hashes the whole chunks of p into the state dig.
+function block(dig: Int32Array, p: Uint8Array): void {
+  let h0 = dig[0]
+  let h1 = dig[1]
+  let h2 = dig[2]
+  let h3 = dig[3]
+  let h4 = dig[4]
+  let h5 = dig[5]
+  let h6 = dig[6]
+  let h7 = dig[7]
+  for (let off = 0; off + chunk <= p.length; off += chunk) {
+    let a = h0
+    let b = h1
+    let c = h2
+    let d = h3
+    let e = h4
+    let f = h5
+    let g = h6
+    let h = h7
+
+    for (let i = 0; i < 64; i++) {
+      if (i < 16) {
+        const j = off + i * 4
+        w[i] = (p[j] << 24) | (p[j + 1] << 16) | (p[j + 2] << 8) | p[j + 3]
+      } else {
+        const v1 = w[i - 2]
+        const t1 =
+          ((v1 >>> 17) | (v1 << 15)) ^ ((v1 >>> 19) | (v1 << 13)) ^ (v1 >>> 10)
+        const v2 = w[i - 15]
+        const t2 =
+          ((v2 >>> 7) | (v2 << 25)) ^ ((v2 >>> 18) | (v2 << 14)) ^ (v2 >>> 3)
+        w[i] = t1 + w[i - 7] + t2 + w[i - 16]
+      }
+
+      const t1 =
+        (h +
+          (((e >>> 6) | (e << 26)) ^
+            ((e >>> 11) | (e << 21)) ^
+            ((e >>> 25) | (e << 7))) +
+          ((e & f) ^ (~e & g)) +
+          _K[i] +
+          w[i]) |
+        0
+      const t2 =
+        ((((a >>> 2) | (a << 30)) ^
+          ((a >>> 13) | (a << 19)) ^
+          ((a >>> 22) | (a << 10))) +
+          ((a & b) ^ (a & c) ^ (b & c))) |
+        0
+
+      h = g
+      g = f
+      f = e
+      e = (d + t1) | 0
+      d = c
+      c = b
+      b = a
+      a = (t1 + t2) | 0
+    }
+
+    h0 = (h0 + a) | 0
+    h1 = (h1 + b) | 0
+    h2 = (h2 + c) | 0
+    h3 = (h3 + d) | 0
+    h4 = (h4 + e) | 0
+    h5 = (h5 + f) | 0
+    h6 = (h6 + g) | 0
+    h7 = (h7 + h) | 0
+  }
+  dig[0] = h0
+  dig[1] = h1
+  dig[2] = h2
+  dig[3] = h3
+  dig[4] = h4
+  dig[5] = h5
+  dig[6] = h6
+  dig[7] = h7
+}
+
+// digest represents the partial evaluation of a checksum.
+class digest {
+  public h = new Int32Array(8)
+  public x = new Uint8Array(chunk)
+  public nx = 0
+  public len = 0
+
+  constructor(public is224: boolean) {
+    this.Reset()
+  }
+
+  public Clone(): [hash.Cloner, $.GoError] {
+    return [this.clone(), null]
+  }
+
+  public clone(): digest {
+    const r = new digest(this.is224)
+    r.h.set(this.h)
+    r.x.set(this.x)
+    r.nx = this.nx
+    r.len = this.len
+    return r
+  }
+
+  public Reset(): void {
+    this.h.set(this.is224 ? init224 : init)
+    this.nx = 0
+    this.len = 0
+  }
+
+  public Size(): number {
+    return this.is224 ? Size224 : Size
+  }
+
+  public BlockSize(): number {
+    return BlockSize
+  }
+
+  public Write(p: $.Bytes): [number, $.GoError] {
+    let b = $.bytesToUint8Array(p)
+    const nn = b.length
+    this.len += nn
+    if (this.nx > 0) {
+      const n = Math.min(chunk - this.nx, b.length)
+      this.x.set(b.subarray(0, n), this.nx)
+      this.nx += n
+      if (this.nx === chunk) {
+        block(this.h, this.x)
+        this.nx = 0
+      }
+      b = b.subarray(n)
+    }
+    if (b.length >= chunk) {
+      const n = b.length - (b.length % chunk)
+      block(this.h, b.subarray(0, n))
+      b = b.subarray(n)
+    }
+    if (b.length > 0) {
+      this.x.set(b)
+      this.nx = b.length
+    }
+    return [nn, null]
+  }
+
+  public Sum(b: $.Bytes): $.Bytes {
+    // Make a copy of d so that caller can keep writing and summing.
+    const d0 = this.clone()
+    const sum = d0.checkSum()
+    return $.append(b, sum.subarray(0, this.Size()))
+  }
+
+  public checkSum(): Uint8Array {
+    const len = this.len
+    // Padding. Add a 1 bit and 0 bits until 56 bytes mod 64.
+    const tmp = new Uint8Array(64 + 8) // padding + length buffer
+    tmp[0] = 0x80
+    const t = len % 64 < 56 ? 56 - (len % 64) : 64 + 56 - (len % 64)
+
+    // Length in bits.
+    const bits = len * 8
+    const padlen = tmp.subarray(0, t + 8)
+    putUint32(padlen, t, Math.floor(bits / 0x100000000))
+    putUint32(padlen, t + 4, bits)
+    this.Write(padlen)
+
+    if (this.nx !== 0) {
+      $.panic('d.nx != 0')
+    }
+
+    const sum = new Uint8Array(Size)
+    for (let i = 0; i < 8; i++) {
+      putUint32(sum, i * 4, this.h[i])
+    }
+    return sum
+  }
+
+  static __typeInfo = $.registerStructType(
+    'crypto/sha256.digest',
+    new digest(false),
+    digestMethods,
+    digest,
+    [],
+  )
+}
+
+// putUint32 stores the big-endian bytes of v at b[i:i+4].
+function putUint32(b: Uint8Array, i: number, v: number): void {
+  b[i] = v >>> 24
+  b[i + 1] = v >>> 16
+  b[i + 2] = v >>> 8
+  b[i + 3] = v
+}
+
+// New returns a new hash.Hash computing the SHA256 checksum. The Hash
+// also implements hash.Cloner.
+export function New(): hash.Hash {
+  return new digest(false)
+}
+
+// New224 returns a new hash.Hash computing the SHA224 checksum. The Hash
+// also implements hash.Cloner.
+export function New224(): hash.Hash {
+  return new digest(true)
+}
+
+// Sum256 returns the SHA256 checksum of the data.
+export function Sum256(data: $.Bytes): number[] {
+  const sum = native.Sum('sha256', data)
+  if (sum !== null) {
+    return Array.from(sum)
+  }
+  const d = new digest(false)
+  d.Write(data)
+  return Array.from(d.checkSum())
+}
+
+// Sum224 returns the SHA224 checksum of the data.
+export function Sum224(data: $.Bytes): number[] {
+  const sum = native.Sum('sha224', data)
+  if (sum !== null) {
+    return Array.from(sum)
+  }
+  const d = new digest(true)
+  d.Write(data)
+  return Array.from(d.checkSum().subarray(0, Size224))
+}
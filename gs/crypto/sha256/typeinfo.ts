@@ -0,0 +1,29 @@
+import * as $ from '@goscript/builtin/index.js'
+
+// Type information for the methods of the sha256 digest.
+
+const intType: $.TypeInfo = { kind: $.TypeKind.Basic, name: 'int' }
+const bytesType: $.TypeInfo = {
+  kind: $.TypeKind.Slice,
+  elemType: { kind: $.TypeKind.Basic, name: 'byte' },
+}
+const errorType: $.TypeInfo = {
+  kind: $.TypeKind.Interface,
+  name: 'GoError',
+  methods: [
+    {
+      name: 'Error',
+      args: [],
+      returns: [{ type: { kind: $.TypeKind.Basic, name: 'string' } }],
+    },
+  ],
+}
+
+export const digestMethods: $.MethodSignature[] = [
+  $.method('BlockSize', [], [intType]),
+  $.method('Clone', [], ['hash.Cloner', errorType]),
+  $.method('Reset', [], []),
+  $.method('Size', [], [intType]),
+  $.method('Sum', [bytesType], [bytesType]),
+  $.method('Write', [bytesType], [intType, errorType]),
+]
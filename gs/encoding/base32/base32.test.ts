@@ -0,0 +1,72 @@
+import { describe, it, expect } from 'vitest'
+import * as $ from '@goscript/builtin/index.js'
+import * as bytes from '@goscript/bytes/index.js'
+import * as io from '@goscript/io/index.js'
+import * as strings from '@goscript/strings/index.js'
+import * as base32 from './index.js'
+
+describe('encoding/base32', () => {
+  it('encodes with the standard and hex alphabets', () => {
+    const data = new Uint8Array([0x00, 0xff, 0x68, 0x65, 0x6c, 0x6c, 0x6f])
+    const cases: [base32.Encoding, string][] = [
+      [base32.StdEncoding, 'AD7WQZLMNRXQ===='],
+      [base32.HexEncoding, '03VMGPBCDHNG===='],
+      [base32.StdEncoding.WithPadding(base32.NoPadding), 'AD7WQZLMNRXQ'],
+    ]
+    for (const [enc, want] of cases) {
+      expect(enc.EncodeToString(data)).toBe(want)
+      expect(enc.EncodedLen(data.length)).toBe(want.length)
+      const [b, err] = enc.DecodeString(want)
+      expect(err).toBeNull()
+      expect(Array.from($.bytesToUint8Array(b))).toEqual(Array.from(data))
+    }
+  })
+
+  it('reports corrupt input like Go', () => {
+    const cases: [string, string, string | null][] = [
+      ['NBSWY3DP', 'hello', null],
+      ['NBSWY3D', '', 'illegal base32 data at input byte 0'],
+      ['NBSW\nY3DP', 'hello', null],
+      ['NBSWY3DPx', 'hello', 'illegal base32 data at input byte 8'],
+      ['N=======', '', 'illegal base32 data at input byte 1'],
+      ['NBSWY3DPEE======', 'hello!', null],
+      ['NBSWY3DPEE=====', 'hello', 'illegal base32 data at input byte 15'],
+      ['NBSWY3DPEB3W64TMMQ======', 'hello world', null],
+    ]
+    for (const [s, want, msg] of cases) {
+      const [b, err] = base32.StdEncoding.DecodeString(s)
+      expect($.bytesToString(b)).toBe(want)
+      expect(err === null ? null : err.Error()).toBe(msg)
+    }
+    const [, err] = base32.StdEncoding.DecodeString('!!!!!!!!')
+    expect(err).toBeInstanceOf(base32.CorruptInputError)
+    expect(Number(err)).toBe(0)
+    const raw = base32.StdEncoding.WithPadding(base32.NoPadding)
+    expect($.bytesToString(raw.DecodeString('NBSWY3D')[0])).toBe('hell')
+  })
+
+  it('streams through encoders and decoders', () => {
+    const buf = new bytes.Buffer()
+    const w = base32.NewEncoder(base32.StdEncoding, buf)
+    w!.Write($.stringToBytes('ab'))
+    w!.Write($.stringToBytes('cdefg'))
+    w!.Close()
+    expect(buf.String()).toBe('MFRGGZDFMZTQ====')
+
+    const r = base32.NewDecoder(
+      base32.StdEncoding,
+      strings.NewReader('MFRGGZDF\nMZTQ===='),
+    )
+    const [out, err] = io.ReadAll(r)
+    expect(err).toBeNull()
+    expect($.bytesToString(out)).toBe('abcdefg')
+
+    const short = base32.NewDecoder(
+      base32.StdEncoding,
+      strings.NewReader('MFRGGZDFMZT'),
+    )
+    const p = new Uint8Array(16)
+    expect(short!.Read(p)).toEqual([5, null])
+    expect(short!.Read(p)).toEqual([0, io.ErrUnexpectedEOF])
+  })
+})
@@ -0,0 +1,695 @@
+// Package base32 implements base32 encoding as specified by RFC 4648.
+
+import * as $ from '@goscript/builtin/index.js'
+import * as io from '@goscript/io/index.js'
+import {
+  byteArrayType,
+  bytesType,
+  encodingPtrType,
+  errorType,
+  intType,
+  runeType,
+  stringType,
+} from './typeinfo.js'
+
+// An Encoding is a radix 32 encoding/decoding scheme, defined by a
+// 32-character alphabet. The most common is the "base32" encoding
+// introduced for SASL GSSAPI and standardized in RFC 4648.
+// The alternate "base32hex" encoding is used in DNSSEC.
+export class Encoding {
+  // mapping of symbol index to symbol byte value
+  public encode: Uint8Array = new Uint8Array(32)
+  // mapping of symbol byte value to symbol index
+  public decodeMap: Uint8Array = new Uint8Array(256)
+  public padChar = 0
+
+  constructor(
+    init?: Partial<{
+      encode: Uint8Array
+      decodeMap: Uint8Array
+      padChar: number
+    }>,
+  ) {
+    if (init) {
+      Object.assign(this, init)
+    }
+  }
+
+  public clone(): Encoding {
+    return new Encoding({
+      encode: this.encode,
+      decodeMap: this.decodeMap,
+      padChar: this.padChar,
+    })
+  }
+
+  // WithPadding creates a new encoding identical to enc except
+  // with a specified padding character, or NoPadding to disable padding.
+  // The padding character must not be '\r' or '\n',
+  // must not be contained in the encoding's alphabet,
+  // must not be negative, and must be a rune equal or below '\xff'.
+  // Padding characters above '\x7f' are encoded as their exact byte value
+  // rather than using the UTF-8 representation of the codepoint.
+  public WithPadding(padding: number): Encoding {
+    if (
+      padding < NoPadding ||
+      padding === 0x0d ||
+      padding === 0x0a ||
+      padding > 0xff
+    ) {
+      $.panic('invalid padding')
+    }
+    if (padding !== NoPadding && this.decodeMap[padding] !== invalidIndex) {
+      $.panic('padding contained in alphabet')
+    }
+    const enc = this.clone()
+    enc.padChar = padding
+    return enc
+  }
+
+  // Encode encodes src using the encoding enc,
+  // writing EncodedLen(len(src)) bytes to dst.
+  //
+  // The encoding pads the output to a multiple of 8 bytes,
+  // so Encode is not appropriate for use on individual blocks
+  // of a large data stream. Use NewEncoder instead.
+  public Encode(dst: $.Bytes, src: $.Bytes): void {
+    const s = $.bytesToUint8Array(src)
+    const out = new Uint8Array(this.EncodedLen(s.length))
+    this.encodeTo(out, s)
+    writeTo(dst, out)
+  }
+
+  // encodeTo encodes src to dst, which has room for the encoding.
+  public encodeTo(dst: Uint8Array, src: Uint8Array): void {
+    const encode = this.encode
+    let si = 0
+    let di = 0
+    for (; src.length - si >= 5; si += 5, di += 8) {
+      const hi =
+        ((src[si] << 24) |
+          (src[si + 1] << 16) |
+          (src[si + 2] << 8) |
+          src[si + 3]) >>>
+        0
+      const lo = ((hi << 8) | src[si + 4]) >>> 0
+
+      dst[di] = encode[(hi >>> 27) & 0x1f]
+      dst[di + 1] = encode[(hi >>> 22) & 0x1f]
+      dst[di + 2] = encode[(hi >>> 17) & 0x1f]
+      dst[di + 3] = encode[(hi >>> 12) & 0x1f]
+      dst[di + 4] = encode[(hi >>> 7) & 0x1f]
+      dst[di + 5] = encode[(hi >>> 2) & 0x1f]
+      dst[di + 6] = encode[(lo >>> 5) & 0x1f]
+      dst[di + 7] = encode[lo & 0x1f]
+    }
+
+    // Add the remaining small block
+    const rem = src.length - si
+    if (rem === 0) {
+      return
+    }
+
+    // Encode the remaining bytes in reverse order.
+    let val = 0
+    switch (rem) {
+      case 4:
+        val |= src[si + 3]
+        dst[di + 6] = encode[(val << 3) & 0x1f]
+        dst[di + 5] = encode[(val >>> 2) & 0x1f]
+      // fallthrough
+      case 3:
+        val |= src[si + 2] << 8
+        dst[di + 4] = encode[(val >>> 7) & 0x1f]
+      // fallthrough
+      case 2:
+        val |= src[si + 1] << 16
+        dst[di + 3] = encode[(val >>> 12) & 0x1f]
+        dst[di + 2] = encode[(val >>> 17) & 0x1f]
+      // fallthrough
+      case 1:
+        val = (val | (src[si] << 24)) >>> 0
+        dst[di + 1] = encode[(val >>> 22) & 0x1f]
+        dst[di] = encode[(val >>> 27) & 0x1f]
+    }
+
+    // Pad the final quantum
+    if (this.padChar !== NoPadding) {
+      const nPad = Math.trunc((rem * 8) / 5) + 1
+      for (let i = nPad; i < 8; i++) {
+        dst[di + i] = this.padChar
+      }
+    }
+  }
+
+  // AppendEncode appends the base32 encoded src to dst
+  // and returns the extended buffer.
+  public AppendEncode(dst: $.Bytes, src: $.Bytes): $.Bytes {
+    const s = $.bytesToUint8Array(src)
+    const out = new Uint8Array(this.EncodedLen(s.length))
+    this.encodeTo(out, s)
+    return $.append(dst, out)
+  }
+
+  // EncodeToString returns the base32 encoding of src.
+  public EncodeToString(src: $.Bytes): string {
+    const s = $.bytesToUint8Array(src)
+    const buf = new Uint8Array(this.EncodedLen(s.length))
+    this.encodeTo(buf, s)
+    return latin1(buf)
+  }
+
+  // EncodedLen returns the length in bytes of the base32 encoding
+  // of an input buffer of length n.
+  public EncodedLen(n: number): number {
+    if (this.padChar === NoPadding) {
+      return Math.trunc(n / 5) * 8 + Math.trunc(((n % 5) * 8 + 4) / 5)
+    }
+    return Math.trunc((n + 4) / 5) * 8
+  }
+
+  // decode is like Decode but returns an additional 'end' value, which
+  // indicates if end-of-message padding was encountered and thus any
+  // additional data is an error. This method assumes that src has been
+  // stripped of all supported whitespace ('\r' and '\n').
+  public decode(
+    dst: Uint8Array,
+    src: Uint8Array,
+  ): [number, boolean, $.GoError] {
+    const padChar = this.padChar
+    let n = 0
+    let end = false
+    let dsti = 0
+    const olen = src.length
+
+    while (src.length > 0 && !end) {
+      // Decode quantum using the base32 alphabet
+      const dbuf = new Uint8Array(8)
+      let dlen = 8
+
+      for (let j = 0; j < 8; ) {
+        if (src.length === 0) {
+          if (padChar !== NoPadding) {
+            // We have reached the end and are missing padding
+            return [n, false, new CorruptInputError(olen - src.length - j)]
+          }
+          // We have reached the end and are not expecting any padding
+          dlen = j
+          end = true
+          break
+        }
+        const inp = src[0]
+        src = src.subarray(1)
+        if (inp === (padChar & 0xff) && j >= 2 && src.length < 8) {
+          // We've reached the end and there's padding
+          if (src.length + j < 8 - 1) {
+            // not enough padding
+            return [n, false, new CorruptInputError(olen)]
+          }
+          for (let k = 0; k < 8 - 1 - j; k++) {
+            if (src.length > k && src[k] !== (padChar & 0xff)) {
+              // incorrect padding
+              return [
+                n,
+                false,
+                new CorruptInputError(olen - src.length + k - 1),
+              ]
+            }
+          }
+          dlen = j
+          end = true
+          // 7, 5 and 2 are not valid padding lengths, and so 1, 3 and 6 are
+          // not valid dlen values. See RFC 4648 Section 6 "Base 32 Encoding"
+          // listing the five valid padding lengths, and Section 9
+          // "Illustrations and Examples" for an illustration for how the
+          // 1st, 3rd and 6th base32 src bytes do not yield enough
+          // information to decode a dst byte.
+          if (dlen === 1 || dlen === 3 || dlen === 6) {
+            return [n, false, new CorruptInputError(olen - src.length - 1)]
+          }
+          break
+        }
+        dbuf[j] = this.decodeMap[inp]
+        if (dbuf[j] === 0xff) {
+          return [n, false, new CorruptInputError(olen - src.length - 1)]
+        }
+        j++
+      }
+
+      // Pack 8x 5-bit source blocks into 5 byte destination
+      // quantum
+      switch (dlen) {
+        case 8:
+          dst[dsti + 4] = (dbuf[6] << 5) | dbuf[7]
+          n++
+        // fallthrough
+        case 7:
+          dst[dsti + 3] = (dbuf[4] << 7) | (dbuf[5] << 2) | (dbuf[6] >> 3)
+          n++
+        // fallthrough
+        case 5:
+          dst[dsti + 2] = (dbuf[3] << 4) | (dbuf[4] >> 1)
+          n++
+        // fallthrough
+        case 4:
+          dst[dsti + 1] = (dbuf[1] << 6) | (dbuf[2] << 1) | (dbuf[3] >> 4)
+          n++
+        // fallthrough
+        case 2:
+          dst[dsti] = (dbuf[0] << 3) | (dbuf[1] >> 2)
+          n++
+      }
+      dsti += 5
+    }
+    return [n, end, null]
+  }
+
+  // Decode decodes src using the encoding enc. It writes at most
+  // DecodedLen(len(src)) bytes to dst and returns the number of bytes
+  // written. The caller must ensure that dst is large enough to hold all
+  // the decoded data. If src contains invalid base32 data, it will return
+  // the number of bytes successfully written and CorruptInputError.
+  // Newline characters (\r and \n) are ignored.
+  public Decode(dst: $.Bytes, src: $.Bytes): [number, $.GoError] {
+    const buf = $.bytesToUint8Array(src).slice()
+    const l = stripNewlines(buf, buf)
+    const out = new Uint8Array(l + 5)
+    const [n, , err] = this.decode(out, buf.subarray(0, l))
+    writeTo(dst, out.subarray(0, n))
+    return [n, err]
+  }
+
+  // AppendDecode appends the base32 decoded src to dst
+  // and returns the extended buffer.
+  // If the input is malformed, it returns the partially decoded src and an
+  // error. New line characters (\r and \n) are ignored.
+  public AppendDecode(dst: $.Bytes, src: $.Bytes): [$.Bytes, $.GoError] {
+    const buf = $.bytesToUint8Array(src).slice()
+    const l = stripNewlines(buf, buf)
+    const out = new Uint8Array(l + 5)
+    const [n, , err] = this.decode(out, buf.subarray(0, l))
+    return [$.append(dst, out.subarray(0, n)), err]
+  }
+
+  // DecodeString returns the bytes represented by the base32 string s.
+  // If the input is malformed, it returns the partially decoded data and
+  // CorruptInputError. New line characters (\r and \n) are ignored.
+  public DecodeString(s: string): [$.Bytes, $.GoError] {
+    const buf = $.stringToBytes(s)
+    const l = stripNewlines(buf, buf)
+    const out = new Uint8Array(l + 5)
+    const [n, , err] = this.decode(out, buf.subarray(0, l))
+    return [out.subarray(0, n), err]
+  }
+
+  // DecodedLen returns the maximum length in bytes of the decoded data
+  // corresponding to n bytes of base32-encoded data.
+  public DecodedLen(n: number): number {
+    return decodedLen(n, this.padChar)
+  }
+
+  // Register this type with the runtime type system
+  static __typeInfo = $.registerStructType(
+    'encoding/base32.Encoding',
+    new Encoding(),
+    [
+      $.method('AppendDecode', [bytesType, bytesType], [bytesType, errorType]),
+      $.method('AppendEncode', [bytesType, bytesType], [bytesType]),
+      $.method('Decode', [bytesType, bytesType], [intType, errorType]),
+      $.method('DecodeString', [stringType], [bytesType, errorType]),
+      $.method('DecodedLen', [intType], [intType]),
+      $.method('Encode', [bytesType, bytesType], []),
+      $.method('EncodeToString', [bytesType], [stringType]),
+      $.method('EncodedLen', [intType], [intType]),
+      $.method('WithPadding', [runeType], [encodingPtrType]),
+    ],
+    Encoding,
+    [
+      { name: 'encode', type: byteArrayType(32) },
+      { name: 'decodeMap', type: byteArrayType(256) },
+      { name: 'padChar', type: runeType },
+    ],
+  )
+}
+
+export const StdPadding = 0x3d // Standard padding character
+export const NoPadding = -1 // No padding
+
+const invalidIndex = 0xff
+
+// NewEncoding returns a new padded Encoding defined by the given alphabet,
+// which must be a 32-byte string that contains unique byte values and
+// does not contain the padding character or CR / LF ('\r', '\n').
+// The alphabet is treated as a sequence of byte values
+// without any special treatment for multi-byte UTF-8.
+// The resulting Encoding uses the default padding character ('='),
+// which may be changed or disabled via Encoding.WithPadding.
+export function NewEncoding(encoder: string): Encoding {
+  const alphabet = $.stringToBytes(encoder)
+  if (alphabet.length !== 32) {
+    $.panic('encoding alphabet is not 32-bytes long')
+  }
+
+  const e = new Encoding()
+  e.padChar = StdPadding
+  e.encode.set(alphabet)
+  e.decodeMap.fill(invalidIndex)
+
+  for (let i = 0; i < alphabet.length; i++) {
+    // Note: While we document that the alphabet cannot contain
+    // the padding character, we do not enforce it since we do not know
+    // if the caller intends to switch the padding from StdPadding later.
+    if (alphabet[i] === 0x0a || alphabet[i] === 0x0d) {
+      $.panic('encoding alphabet contains newline character')
+    }
+    if (e.decodeMap[alphabet[i]] !== invalidIndex) {
+      $.panic('encoding alphabet includes duplicate symbols')
+    }
+    e.decodeMap[alphabet[i]] = i
+  }
+  return e
+}
+
+// StdEncoding is the standard base32 encoding, as defined in RFC 4648.
+export const StdEncoding = NewEncoding('ABCDEFGHIJKLMNOPQRSTUVWXYZ234567')
+
+// HexEncoding is the “Extended Hex Alphabet” defined in RFC 4648.
+// It is typically used in DNS.
+export const HexEncoding = NewEncoding('0123456789ABCDEFGHIJKLMNOPQRSTUV')
+
+// CorruptInputError reports the offset of illegal base32 data. Values of
+// named basic types do not carry their methods at runtime, so the offset is
+// held by an object whose valueOf returns it.
+export class CorruptInputError {
+  constructor(private readonly offset: number = 0) {}
+
+  public valueOf(): number {
+    return this.offset
+  }
+
+  public Error(): string {
+    return CorruptInputError_Error(this.offset)
+  }
+
+  // Register this type with the runtime type system
+  static __typeInfo = $.registerStructType(
+    'encoding/base32.CorruptInputError',
+    new CorruptInputError(),
+    [$.method('Error', [], [stringType])],
+    CorruptInputError,
+    [],
+  )
+}
+
+// CorruptInputError_Error describes the offset of the illegal data.
+export function CorruptInputError_Error(
+  e: CorruptInputError | number,
+): string {
+  return 'illegal base32 data at input byte ' + Number(e)
+}
+
+function decodedLen(n: number, padChar: number): number {
+  if (padChar === NoPadding) {
+    return Math.trunc(n / 8) * 5 + Math.trunc(((n % 8) * 5) / 8)
+  }
+  return Math.trunc(n / 8) * 5
+}
+
+// stripNewlines removes newline characters and returns the number
+// of non-newline characters copied to dst.
+function stripNewlines(dst: Uint8Array, src: Uint8Array): number {
+  let offset = 0
+  for (const b of src) {
+    if (b === 0x0d || b === 0x0a) {
+      continue
+    }
+    dst[offset] = b
+    offset++
+  }
+  return offset
+}
+
+// latin1 returns the string whose characters are the bytes of b, which
+// are ASCII for encoded data.
+function latin1(b: Uint8Array): string {
+  let s = ''
+  for (let i = 0; i < b.length; i += 0x2000) {
+    s += String.fromCharCode(...b.subarray(i, i + 0x2000))
+  }
+  return s
+}
+
+// writeTo copies b to the start of dst, panicking like an out of range index
+// if dst is too short.
+function writeTo(dst: $.Bytes, b: Uint8Array): void {
+  const n = $.len(dst)
+  if (n < b.length) {
+    throw new Error(
+      `runtime error: index out of range [${b.length - 1}] with length ${n}`,
+    )
+  }
+  $.copy(dst, b)
+}
+
+class encoder {
+  private err: $.GoError = null
+  private buf = new Uint8Array(5) // buffered data waiting to be encoded
+  private nbuf = 0 // number of bytes in buf
+  private out = new Uint8Array(1024) // output buffer
+
+  constructor(
+    private readonly enc: Encoding,
+    private readonly w: io.Writer,
+  ) {}
+
+  public Write(data: $.Bytes): [number, $.GoError] {
+    if (this.err !== null) {
+      return [0, this.err]
+    }
+    let p = $.bytesToUint8Array(data)
+    let n = 0
+
+    // Leading fringe.
+    if (this.nbuf > 0) {
+      let i: number
+      for (i = 0; i < p.length && this.nbuf < 5; i++) {
+        this.buf[this.nbuf] = p[i]
+        this.nbuf++
+      }
+      n += i
+      p = p.subarray(i)
+      if (this.nbuf < 5) {
+        return [n, null]
+      }
+      this.enc.encodeTo(this.out, this.buf)
+      ;[, this.err] = this.w!.Write(this.out.slice(0, 8))
+      if (this.err !== null) {
+        return [n, this.err]
+      }
+      this.nbuf = 0
+    }
+
+    // Large interior chunks.
+    while (p.length >= 5) {
+      let nn = (this.out.length / 8) * 5
+      if (nn > p.length) {
+        nn = p.length
+        nn -= nn % 5
+      }
+      this.enc.encodeTo(this.out, p.subarray(0, nn))
+      ;[, this.err] = this.w!.Write(this.out.slice(0, (nn / 5) * 8))
+      if (this.err !== null) {
+        return [n, this.err]
+      }
+      n += nn
+      p = p.subarray(nn)
+    }
+
+    // Trailing fringe.
+    this.buf.set(p)
+    this.nbuf = p.length
+    n += p.length
+    return [n, null]
+  }
+
+  // Close flushes any pending output from the encoder.
+  // It is an error to call Write after calling Close.
+  public Close(): $.GoError {
+    // If there's anything left in the buffer, flush it out
+    if (this.err === null && this.nbuf > 0) {
+      this.enc.encodeTo(this.out, this.buf.subarray(0, this.nbuf))
+      const encodedLen = this.enc.EncodedLen(this.nbuf)
+      this.nbuf = 0
+      ;[, this.err] = this.w!.Write(this.out.slice(0, encodedLen))
+    }
+    return this.err
+  }
+}
+
+// NewEncoder returns a new base32 stream encoder. Data written to
+// the returned writer will be encoded using enc and then written to w.
+// Base32 encodings operate in 5-byte blocks; when finished
+// writing, the caller must Close the returned encoder to flush any
+// partially written blocks.
+export function NewEncoder(enc: Encoding | null, w: io.Writer): io.WriteCloser {
+  return new encoder(enc!, w)
+}
+
+// readEncodedData reads at least min bytes into buf, converting an early
+// EOF into io.ErrUnexpectedEOF.
+function readEncodedData(
+  r: io.Reader,
+  buf: Uint8Array,
+  min: number,
+  expectsPadding: boolean,
+): [number, $.GoError] {
+  let n = 0
+  let err: $.GoError = null
+  while (n < min && err === null) {
+    let nn: number
+    ;[nn, err] = r!.Read(buf.subarray(n))
+    n += nn
+  }
+  // data was read, less than min bytes could be read
+  if (n < min && n > 0 && err === io.EOF) {
+    err = io.ErrUnexpectedEOF
+  }
+  // no data was read, the buffer already contains some data
+  // when padding is disabled this is not an error, as the message can be of
+  // any length
+  if (expectsPadding && min < 8 && n === 0 && err === io.EOF) {
+    err = io.ErrUnexpectedEOF
+  }
+  return [n, err]
+}
+
+class decoder {
+  private err: $.GoError = null
+  private end = false // saw end of message
+  private buf = new Uint8Array(1024) // leftover input
+  private nbuf = 0
+  private outbuf = new Uint8Array((1024 / 8) * 5)
+  private out: Uint8Array = this.outbuf.subarray(0, 0) // leftover output
+
+  constructor(
+    private readonly enc: Encoding,
+    private readonly r: io.Reader,
+  ) {}
+
+  public Read(p: $.Bytes): [number, $.GoError] {
+    const plen = $.len(p)
+
+    // Use leftover decoded output from last read.
+    if (this.out.length > 0) {
+      const n = $.copy(p, this.out)
+      this.out = this.out.subarray(n)
+      if (this.out.length === 0) {
+        return [n, this.err]
+      }
+      return [n, null]
+    }
+
+    if (this.err !== null) {
+      return [0, this.err]
+    }
+
+    // Read a chunk.
+    let nn = Math.trunc((plen + 4) / 5) * 8
+    if (nn < 8) {
+      nn = 8
+    }
+    if (nn > this.buf.length) {
+      nn = this.buf.length
+    }
+
+    // Minimum amount of bytes that needs to be read each cycle
+    let min: number
+    let expectsPadding: boolean
+    if (this.enc.padChar === NoPadding) {
+      min = 1
+      expectsPadding = false
+    } else {
+      min = 8 - this.nbuf
+      expectsPadding = true
+    }
+
+    ;[nn, this.err] = readEncodedData(
+      this.r,
+      this.buf.subarray(this.nbuf, nn),
+      min,
+      expectsPadding,
+    )
+    this.nbuf += nn
+    if (this.nbuf < min) {
+      return [0, this.err]
+    }
+    if (nn > 0 && this.end) {
+      return [0, new CorruptInputError(0)]
+    }
+
+    // Decode chunk into p, or d.out and then p if p is too small.
+    let nr: number
+    if (this.enc.padChar === NoPadding) {
+      nr = this.nbuf
+    } else {
+      nr = Math.trunc(this.nbuf / 8) * 8
+    }
+    let nw = this.enc.DecodedLen(this.nbuf)
+
+    let n: number
+    let err: $.GoError
+    if (nw > plen) {
+      ;[nw, this.end, err] = this.enc.decode(
+        this.outbuf,
+        this.buf.subarray(0, nr),
+      )
+      this.out = this.outbuf.subarray(0, nw)
+      n = $.copy(p, this.out)
+      this.out = this.out.subarray(n)
+    } else {
+      const out = new Uint8Array(nw + 5)
+      ;[n, this.end, err] = this.enc.decode(out, this.buf.subarray(0, nr))
+      $.copy(p, out.subarray(0, n))
+    }
+    this.nbuf -= nr
+    this.buf.copyWithin(0, nr, nr + this.nbuf)
+
+    if (err !== null && (this.err === null || this.err === io.EOF)) {
+      this.err = err
+    }
+
+    if (this.out.length > 0) {
+      // We cannot return all the decoded bytes to the caller in this
+      // invocation of Read, so we return a nil error to ensure that Read
+      // will be called again. The error stored in d.err, if any, will be
+      // returned with the last set of decoded bytes.
+      return [n, null]
+    }
+
+    return [n, this.err]
+  }
+}
+
+class newlineFilteringReader {
+  constructor(private readonly wrapped: io.Reader) {}
+
+  public Read(p: $.Bytes): [number, $.GoError] {
+    const b = p as Uint8Array
+    let [n, err] = this.wrapped!.Read(b)
+    while (n > 0) {
+      const s = b.subarray(0, n)
+      const offset = stripNewlines(s, s)
+      if (err !== null || offset > 0) {
+        return [offset, err]
+      }
+      // Previous buffer entirely whitespace, read again
+      ;[n, err] = this.wrapped!.Read(b)
+    }
+    return [n, err]
+  }
+}
+
+// NewDecoder constructs a new base32 stream decoder.
+export function NewDecoder(enc: Encoding | null, r: io.Reader): io.Reader {
+  return new decoder(enc!, new newlineFilteringReader(r))
+}
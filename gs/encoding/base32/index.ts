@@ -0,0 +1,12 @@
+export {
+  CorruptInputError,
+  CorruptInputError_Error,
+  Encoding,
+  HexEncoding,
+  NewDecoder,
+  NewEncoder,
+  NewEncoding,
+  NoPadding,
+  StdEncoding,
+  StdPadding,
+} from './base32.js'
@@ -0,0 +1,69 @@
+import { describe, it, expect } from 'vitest'
+import * as $ from '@goscript/builtin/index.js'
+import * as bytes from '@goscript/bytes/index.js'
+import * as io from '@goscript/io/index.js'
+import * as strings from '@goscript/strings/index.js'
+import * as base64 from './index.js'
+
+describe('encoding/base64', () => {
+  it('encodes with every standard encoding', () => {
+    const data = $.stringToBytes('\x00ÿhello')
+    const cases: [base64.Encoding, string][] = [
+      [base64.StdEncoding, 'AMO/aGVsbG8='],
+      [base64.URLEncoding, 'AMO_aGVsbG8='],
+      [base64.RawStdEncoding, 'AMO/aGVsbG8'],
+      [base64.RawURLEncoding, 'AMO_aGVsbG8'],
+    ]
+    for (const [enc, want] of cases) {
+      expect(enc.EncodeToString(data)).toBe(want)
+      expect(enc.EncodedLen(data.length)).toBe(want.length)
+      const [b, err] = enc.DecodeString(want)
+      expect(err).toBeNull()
+      expect(Array.from($.bytesToUint8Array(b))).toEqual(Array.from(data))
+    }
+  })
+
+  it('reports corrupt input like Go', () => {
+    const cases: [string, string, string | null][] = [
+      ['aGVsbG8=', 'hello', null],
+      ['aGVsbG8', 'hel', 'illegal base64 data at input byte 4'],
+      ['aGVs\nbG8=', 'hello', null],
+      ['aGVsbG8=x', 'hello', 'illegal base64 data at input byte 8'],
+      ['a===', '', 'illegal base64 data at input byte 1'],
+      ['!!!!', '', 'illegal base64 data at input byte 0'],
+    ]
+    for (const [s, want, msg] of cases) {
+      const [b, err] = base64.StdEncoding.DecodeString(s)
+      expect($.bytesToString(b)).toBe(want)
+      expect(err === null ? null : err.Error()).toBe(msg)
+    }
+    const [, err] = base64.StdEncoding.DecodeString('aGVsbG8')
+    expect(err).toBeInstanceOf(base64.CorruptInputError)
+    expect(Number(err)).toBe(4)
+    expect(base64.RawStdEncoding.DecodeString('aGVsbG8')[1]).toBeNull()
+  })
+
+  it('checks trailing bits in strict mode', () => {
+    const [b, err] = base64.StdEncoding.Strict().DecodeString('aGVsbB==')
+    expect($.bytesToString(b)).toBe('hel')
+    expect(err!.Error()).toBe('illegal base64 data at input byte 6')
+    expect(base64.StdEncoding.DecodeString('aGVsbB==')[1]).toBeNull()
+  })
+
+  it('streams through encoders and decoders', () => {
+    const buf = new bytes.Buffer()
+    const w = base64.NewEncoder(base64.StdEncoding, buf)
+    w!.Write($.stringToBytes('ab'))
+    w!.Write($.stringToBytes('cdefg'))
+    w!.Close()
+    expect(buf.String()).toBe('YWJjZGVmZw==')
+
+    const r = base64.NewDecoder(
+      base64.StdEncoding,
+      strings.NewReader('YWJj\nZGVm\r\nZw=='),
+    )
+    const [out, err] = io.ReadAll(r)
+    expect(err).toBeNull()
+    expect($.bytesToString(out)).toBe('abcdefg')
+  })
+})
@@ -0,0 +1,686 @@
+// Package base64 implements base64 encoding as specified by RFC 4648.
+
+import * as $ from '@goscript/builtin/index.js'
+import * as io from '@goscript/io/index.js'
+import {
+  boolType,
+  byteArrayType,
+  bytesType,
+  encodingPtrType,
+  errorType,
+  intType,
+  runeType,
+  stringType,
+} from './typeinfo.js'
+
+// An Encoding is a radix 64 encoding/decoding scheme, defined by a
+// 64-character alphabet. The most common encoding is the "base64"
+// encoding defined in RFC 4648 and used in MIME (RFC 2045) and PEM
+// (RFC 1421).  RFC 4648 also defines an alternate encoding, which is
+// the standard encoding with - and _ substituted for + and /.
+export class Encoding {
+  // mapping of symbol index to symbol byte value
+  public encode: Uint8Array = new Uint8Array(64)
+  // mapping of symbol byte value to symbol index
+  public decodeMap: Uint8Array = new Uint8Array(256)
+  public padChar = 0
+  public strict = false
+
+  constructor(
+    init?: Partial<{
+      encode: Uint8Array
+      decodeMap: Uint8Array
+      padChar: number
+      strict: boolean
+    }>,
+  ) {
+    if (init) {
+      Object.assign(this, init)
+    }
+  }
+
+  public clone(): Encoding {
+    return new Encoding({
+      encode: this.encode,
+      decodeMap: this.decodeMap,
+      padChar: this.padChar,
+      strict: this.strict,
+    })
+  }
+
+  // WithPadding creates a new encoding identical to enc except
+  // with a specified padding character, or NoPadding to disable padding.
+  // The padding character must not be '\r' or '\n',
+  // must not be contained in the encoding's alphabet,
+  // must not be negative, and must be a rune equal or below '\xff'.
+  // Padding characters above '\x7f' are encoded as their exact byte value
+  // rather than using the UTF-8 representation of the codepoint.
+  public WithPadding(padding: number): Encoding {
+    if (
+      padding < NoPadding ||
+      padding === 0x0d ||
+      padding === 0x0a ||
+      padding > 0xff
+    ) {
+      $.panic('invalid padding')
+    }
+    if (padding !== NoPadding && this.decodeMap[padding] !== invalidIndex) {
+      $.panic('padding contained in alphabet')
+    }
+    const enc = this.clone()
+    enc.padChar = padding
+    return enc
+  }
+
+  // Strict creates a new encoding identical to enc except with
+  // strict decoding enabled. In this mode, the decoder requires that
+  // trailing padding bits are zero, as described in RFC 4648 section 3.5.
+  //
+  // Note that the input is still malleable, as new line characters
+  // (CR and LF) are still ignored.
+  public Strict(): Encoding {
+    const enc = this.clone()
+    enc.strict = true
+    return enc
+  }
+
+  // Encode encodes src using the encoding enc,
+  // writing EncodedLen(len(src)) bytes to dst.
+  //
+  // The encoding pads the output to a multiple of 4 bytes,
+  // so Encode is not appropriate for use on individual blocks
+  // of a large data stream. Use NewEncoder instead.
+  public Encode(dst: $.Bytes, src: $.Bytes): void {
+    const s = $.bytesToUint8Array(src)
+    const out = new Uint8Array(this.EncodedLen(s.length))
+    this.encodeTo(out, s)
+    writeTo(dst, out)
+  }
+
+  // encodeTo encodes src to dst, which has room for the encoding.
+  public encodeTo(dst: Uint8Array, src: Uint8Array): void {
+    const encode = this.encode
+    let si = 0
+    let di = 0
+    for (; src.length - si >= 3; si += 3, di += 4) {
+      // Convert 3x 8bit source bytes into 4 bytes
+      const val = (src[si] << 16) | (src[si + 1] << 8) | src[si + 2]
+      dst[di] = encode[(val >> 18) & 0x3f]
+      dst[di + 1] = encode[(val >> 12) & 0x3f]
+      dst[di + 2] = encode[(val >> 6) & 0x3f]
+      dst[di + 3] = encode[val & 0x3f]
+    }
+
+    // Add the remaining small block (if any).
+    switch (src.length - si) {
+      case 1: {
+        const val = src[si] << 16
+        dst[di] = encode[(val >> 18) & 0x3f]
+        dst[di + 1] = encode[(val >> 12) & 0x3f]
+        if (this.padChar !== NoPadding) {
+          dst[di + 2] = this.padChar
+          dst[di + 3] = this.padChar
+        }
+        break
+      }
+      case 2: {
+        const val = (src[si] << 16) | (src[si + 1] << 8)
+        dst[di] = encode[(val >> 18) & 0x3f]
+        dst[di + 1] = encode[(val >> 12) & 0x3f]
+        dst[di + 2] = encode[(val >> 6) & 0x3f]
+        if (this.padChar !== NoPadding) {
+          dst[di + 3] = this.padChar
+        }
+        break
+      }
+    }
+  }
+
+  // AppendEncode appends the base64 encoded src to dst
+  // and returns the extended buffer.
+  public AppendEncode(dst: $.Bytes, src: $.Bytes): $.Bytes {
+    const s = $.bytesToUint8Array(src)
+    const out = new Uint8Array(this.EncodedLen(s.length))
+    this.encodeTo(out, s)
+    return $.append(dst, out)
+  }
+
+  // EncodeToString returns the base64 encoding of src.
+  public EncodeToString(src: $.Bytes): string {
+    const s = $.bytesToUint8Array(src)
+    const buf = new Uint8Array(this.EncodedLen(s.length))
+    this.encodeTo(buf, s)
+    return latin1(buf)
+  }
+
+  // EncodedLen returns the length in bytes of the base64 encoding
+  // of an input buffer of length n.
+  public EncodedLen(n: number): number {
+    if (this.padChar === NoPadding) {
+      // minimum # chars at 6 bits per char
+      return Math.trunc(n / 3) * 4 + Math.trunc(((n % 3) * 8 + 5) / 6)
+    }
+    return Math.trunc((n + 2) / 3) * 4 // minimum # 4-char quanta, 3 bytes each
+  }
+
+  // decodeQuantum decodes up to 4 base64 bytes. The received parameters are
+  // the destination buffer dst, the source buffer src and an index in the
+  // source buffer si.
+  // It returns the number of bytes read from src, the number of bytes written
+  // to dst, and an error, if any.
+  private decodeQuantum(
+    dst: Uint8Array,
+    src: Uint8Array,
+    si: number,
+  ): [number, number, $.GoError] {
+    // Decode quantum using the base64 alphabet
+    const dbuf = [0, 0, 0, 0]
+    let dlen = 4
+    let err: $.GoError = null
+
+    for (let j = 0; j < dbuf.length; j++) {
+      if (src.length === si) {
+        if (j === 0) {
+          return [si, 0, null]
+        }
+        if (j === 1 || this.padChar !== NoPadding) {
+          return [si, 0, new CorruptInputError(si - j)]
+        }
+        dlen = j
+        break
+      }
+      const inp = src[si]
+      si++
+
+      const out = this.decodeMap[inp]
+      if (out !== 0xff) {
+        dbuf[j] = out
+        continue
+      }
+
+      if (inp === 0x0a || inp === 0x0d) {
+        j--
+        continue
+      }
+
+      if (inp !== this.padChar) {
+        return [si, 0, new CorruptInputError(si - 1)]
+      }
+
+      // We've reached the end and there's padding
+      switch (j) {
+        case 0:
+        case 1:
+          // incorrect padding
+          return [si, 0, new CorruptInputError(si - 1)]
+        case 2:
+          // "==" is expected, the first "=" is already consumed.
+          // skip over newlines
+          while (si < src.length && (src[si] === 0x0a || src[si] === 0x0d)) {
+            si++
+          }
+          if (si === src.length) {
+            // not enough padding
+            return [si, 0, new CorruptInputError(src.length)]
+          }
+          if (src[si] !== this.padChar) {
+            // incorrect padding
+            return [si, 0, new CorruptInputError(si - 1)]
+          }
+
+          si++
+      }
+
+      // skip over newlines
+      while (si < src.length && (src[si] === 0x0a || src[si] === 0x0d)) {
+        si++
+      }
+      if (si < src.length) {
+        // trailing garbage
+        err = new CorruptInputError(si)
+      }
+      dlen = j
+      break
+    }
+
+    // Convert 4x 6bit source bytes into 3 bytes
+    const val = (dbuf[0] << 18) | (dbuf[1] << 12) | (dbuf[2] << 6) | dbuf[3]
+    dbuf[2] = val & 0xff
+    dbuf[1] = (val >> 8) & 0xff
+    dbuf[0] = (val >> 16) & 0xff
+    switch (dlen) {
+      case 4:
+        dst[2] = dbuf[2]
+        dbuf[2] = 0
+      // fallthrough
+      case 3:
+        dst[1] = dbuf[1]
+        if (this.strict && dbuf[2] !== 0) {
+          return [si, 0, new CorruptInputError(si - 1)]
+        }
+        dbuf[1] = 0
+      // fallthrough
+      case 2:
+        dst[0] = dbuf[0]
+        if (this.strict && (dbuf[1] !== 0 || dbuf[2] !== 0)) {
+          return [si, 0, new CorruptInputError(si - 2)]
+        }
+    }
+
+    return [si, dlen - 1, err]
+  }
+
+  // decodeTo decodes src into dst, which has room for the decoded data.
+  public decodeTo(dst: Uint8Array, src: Uint8Array): [number, $.GoError] {
+    let n = 0
+    let si = 0
+    while (si < src.length) {
+      let ninc: number
+      let err: $.GoError
+      ;[si, ninc, err] = this.decodeQuantum(dst.subarray(n), src, si)
+      n += ninc
+      if (err !== null) {
+        return [n, err]
+      }
+    }
+    return [n, null]
+  }
+
+  // AppendDecode appends the base64 decoded src to dst
+  // and returns the extended buffer.
+  // If the input is malformed, it returns the partially decoded src and an
+  // error. New line characters (\r and \n) are ignored.
+  public AppendDecode(dst: $.Bytes, src: $.Bytes): [$.Bytes, $.GoError] {
+    const s = $.bytesToUint8Array(src)
+    // Compute the output size without padding to avoid over allocating.
+    let n = s.length
+    while (n > 0 && s[n - 1] === this.padChar) {
+      n--
+    }
+    const out = new Uint8Array(decodedLen(n, NoPadding))
+    const [m, err] = this.decodeTo(out, s)
+    return [$.append(dst, out.subarray(0, m)), err]
+  }
+
+  // DecodeString returns the bytes represented by the base64 string s.
+  // If the input is malformed, it returns the partially decoded data and
+  // CorruptInputError. New line characters (\r and \n) are ignored.
+  public DecodeString(s: string): [$.Bytes, $.GoError] {
+    const src = $.stringToBytes(s)
+    const dbuf = new Uint8Array(this.DecodedLen(src.length))
+    const [n, err] = this.decodeTo(dbuf, src)
+    return [dbuf.subarray(0, n), err]
+  }
+
+  // Decode decodes src using the encoding enc. It writes at most
+  // DecodedLen(len(src)) bytes to dst and returns the number of bytes
+  // written. The caller must ensure that dst is large enough to hold all
+  // the decoded data. If src contains invalid base64 data, it will return
+  // the number of bytes successfully written and CorruptInputError.
+  // New line characters (\r and \n) are ignored.
+  public Decode(dst: $.Bytes, src: $.Bytes): [number, $.GoError] {
+    const s = $.bytesToUint8Array(src)
+    const out = new Uint8Array(this.DecodedLen(s.length))
+    const [n, err] = this.decodeTo(out, s)
+    writeTo(dst, out.subarray(0, n))
+    return [n, err]
+  }
+
+  // DecodedLen returns the maximum length in bytes of the decoded data
+  // corresponding to n bytes of base64-encoded data.
+  public DecodedLen(n: number): number {
+    return decodedLen(n, this.padChar)
+  }
+
+  // Register this type with the runtime type system
+  static __typeInfo = $.registerStructType(
+    'encoding/base64.Encoding',
+    new Encoding(),
+    [
+      $.method('AppendDecode', [bytesType, bytesType], [bytesType, errorType]),
+      $.method('AppendEncode', [bytesType, bytesType], [bytesType]),
+      $.method('Decode', [bytesType, bytesType], [intType, errorType]),
+      $.method('DecodeString', [stringType], [bytesType, errorType]),
+      $.method('DecodedLen', [intType], [intType]),
+      $.method('Encode', [bytesType, bytesType], []),
+      $.method('EncodeToString', [bytesType], [stringType]),
+      $.method('EncodedLen', [intType], [intType]),
+      $.method('Strict', [], [encodingPtrType]),
+      $.method('WithPadding', [runeType], [encodingPtrType]),
+    ],
+    Encoding,
+    [
+      { name: 'encode', type: byteArrayType(64) },
+      { name: 'decodeMap', type: byteArrayType(256) },
+      { name: 'padChar', type: runeType },
+      { name: 'strict', type: boolType },
+    ],
+  )
+}
+
+export const StdPadding = 0x3d // Standard padding character
+export const NoPadding = -1 // No padding
+
+const invalidIndex = 0xff
+
+// NewEncoding returns a new padded Encoding defined by the given alphabet,
+// which must be a 64-byte string that contains unique byte values and
+// does not contain the padding character or CR / LF ('\r', '\n').
+// The alphabet is treated as a sequence of byte values
+// without any special treatment for multi-byte UTF-8.
+// The resulting Encoding uses the default padding character ('='),
+// which may be changed or disabled via Encoding.WithPadding.
+export function NewEncoding(encoder: string): Encoding {
+  const alphabet = $.stringToBytes(encoder)
+  if (alphabet.length !== 64) {
+    $.panic('encoding alphabet is not 64-bytes long')
+  }
+
+  const e = new Encoding()
+  e.padChar = StdPadding
+  e.encode.set(alphabet)
+  e.decodeMap.fill(invalidIndex)
+
+  for (let i = 0; i < alphabet.length; i++) {
+    // Note: While we document that the alphabet cannot contain
+    // the padding character, we do not enforce it since we do not know
+    // if the caller intends to switch the padding from StdPadding later.
+    if (alphabet[i] === 0x0a || alphabet[i] === 0x0d) {
+      $.panic('encoding alphabet contains newline character')
+    }
+    if (e.decodeMap[alphabet[i]] !== invalidIndex) {
+      $.panic('encoding alphabet includes duplicate symbols')
+    }
+    e.decodeMap[alphabet[i]] = i
+  }
+  return e
+}
+
+// StdEncoding is the standard base64 encoding, as defined in RFC 4648.
+export const StdEncoding = NewEncoding(
+  'ABCDEFGHIJKLMNOPQRSTUVWXYZabcdefghijklmnopqrstuvwxyz0123456789+/',
+)
+
+// URLEncoding is the alternate base64 encoding defined in RFC 4648.
+// It is typically used in URLs and file names.
+export const URLEncoding = NewEncoding(
+  'ABCDEFGHIJKLMNOPQRSTUVWXYZabcdefghijklmnopqrstuvwxyz0123456789-_',
+)
+
+// RawStdEncoding is the standard raw, unpadded base64 encoding,
+// as defined in RFC 4648 section 3.2.
+// This is the same as StdEncoding but omits padding characters.
+export const RawStdEncoding = StdEncoding.WithPadding(NoPadding)
+
+// RawURLEncoding is the unpadded alternate base64 encoding defined in RFC
+// 4648. It is typically used in URLs and file names.
+// This is the same as URLEncoding but omits padding characters.
+export const RawURLEncoding = URLEncoding.WithPadding(NoPadding)
+
+// CorruptInputError reports the offset of illegal base64 data. Values of
+// named basic types do not carry their methods at runtime, so the offset is
+// held by an object whose valueOf returns it.
+export class CorruptInputError {
+  constructor(private readonly offset: number = 0) {}
+
+  public valueOf(): number {
+    return this.offset
+  }
+
+  public Error(): string {
+    return CorruptInputError_Error(this.offset)
+  }
+
+  // Register this type with the runtime type system
+  static __typeInfo = $.registerStructType(
+    'encoding/base64.CorruptInputError',
+    new CorruptInputError(),
+    [$.method('Error', [], [stringType])],
+    CorruptInputError,
+    [],
+  )
+}
+
+// CorruptInputError_Error describes the offset of the illegal data.
+export function CorruptInputError_Error(
+  e: CorruptInputError | number,
+): string {
+  return 'illegal base64 data at input byte ' + Number(e)
+}
+
+function decodedLen(n: number, padChar: number): number {
+  if (padChar === NoPadding) {
+    // Unpadded data may end with partial block of 2-3 characters.
+    return Math.trunc(n / 4) * 3 + Math.trunc(((n % 4) * 6) / 8)
+  }
+  // Padded base64 should always be a multiple of 4 characters in length.
+  return Math.trunc(n / 4) * 3
+}
+
+// latin1 returns the string whose characters are the bytes of b, which
+// are ASCII for encoded data.
+function latin1(b: Uint8Array): string {
+  let s = ''
+  for (let i = 0; i < b.length; i += 0x2000) {
+    s += String.fromCharCode(...b.subarray(i, i + 0x2000))
+  }
+  return s
+}
+
+// writeTo copies b to the start of dst, panicking like an out of range index
+// if dst is too short.
+function writeTo(dst: $.Bytes, b: Uint8Array): void {
+  const n = $.len(dst)
+  if (n < b.length) {
+    throw new Error(
+      `runtime error: index out of range [${b.length - 1}] with length ${n}`,
+    )
+  }
+  $.copy(dst, b)
+}
+
+class encoder {
+  private err: $.GoError = null
+  private buf = new Uint8Array(3) // buffered data waiting to be encoded
+  private nbuf = 0 // number of bytes in buf
+  private out = new Uint8Array(1024) // output buffer
+
+  constructor(
+    private readonly enc: Encoding,
+    private readonly w: io.Writer,
+  ) {}
+
+  public Write(data: $.Bytes): [number, $.GoError] {
+    if (this.err !== null) {
+      return [0, this.err]
+    }
+    let p = $.bytesToUint8Array(data)
+    let n = 0
+
+    // Leading fringe.
+    if (this.nbuf > 0) {
+      let i: number
+      for (i = 0; i < p.length && this.nbuf < 3; i++) {
+        this.buf[this.nbuf] = p[i]
+        this.nbuf++
+      }
+      n += i
+      p = p.subarray(i)
+      if (this.nbuf < 3) {
+        return [n, null]
+      }
+      this.enc.encodeTo(this.out, this.buf)
+      ;[, this.err] = this.w!.Write(this.out.slice(0, 4))
+      if (this.err !== null) {
+        return [n, this.err]
+      }
+      this.nbuf = 0
+    }
+
+    // Large interior chunks.
+    while (p.length >= 3) {
+      let nn = (this.out.length / 4) * 3
+      if (nn > p.length) {
+        nn = p.length
+        nn -= nn % 3
+      }
+      this.enc.encodeTo(this.out, p.subarray(0, nn))
+      ;[, this.err] = this.w!.Write(this.out.slice(0, (nn / 3) * 4))
+      if (this.err !== null) {
+        return [n, this.err]
+      }
+      n += nn
+      p = p.subarray(nn)
+    }
+
+    // Trailing fringe.
+    this.buf.set(p)
+    this.nbuf = p.length
+    n += p.length
+    return [n, null]
+  }
+
+  // Close flushes any pending output from the encoder.
+  // It is an error to call Write after calling Close.
+  public Close(): $.GoError {
+    // If there's anything left in the buffer, flush it out
+    if (this.err === null && this.nbuf > 0) {
+      this.enc.encodeTo(this.out, this.buf.subarray(0, this.nbuf))
+      const n = this.enc.EncodedLen(this.nbuf)
+      ;[, this.err] = this.w!.Write(this.out.slice(0, n))
+      this.nbuf = 0
+    }
+    return this.err
+  }
+}
+
+// NewEncoder returns a new base64 stream encoder. Data written to
+// the returned writer will be encoded using enc and then written to w.
+// Base64 encodings operate in 4-byte blocks; when finished
+// writing, the caller must Close the returned encoder to flush any
+// partially written blocks.
+export function NewEncoder(enc: Encoding | null, w: io.Writer): io.WriteCloser {
+  return new encoder(enc!, w)
+}
+
+class decoder {
+  private err: $.GoError = null
+  private readErr: $.GoError = null // error from r.Read
+  private buf = new Uint8Array(1024) // leftover input
+  private nbuf = 0
+  private outbuf = new Uint8Array((1024 / 4) * 3)
+  private out: Uint8Array = this.outbuf.subarray(0, 0) // leftover output
+
+  constructor(
+    private readonly enc: Encoding,
+    private readonly r: io.Reader,
+  ) {}
+
+  public Read(p: $.Bytes): [number, $.GoError] {
+    const plen = $.len(p)
+
+    // Use leftover decoded output from last read.
+    if (this.out.length > 0) {
+      const n = $.copy(p, this.out)
+      this.out = this.out.subarray(n)
+      return [n, null]
+    }
+
+    if (this.err !== null) {
+      return [0, this.err]
+    }
+
+    // This code assumes that d.r strips supported whitespace ('\r' and '\n').
+
+    // Refill buffer.
+    while (this.nbuf < 4 && this.readErr === null) {
+      let nn = Math.trunc(plen / 3) * 4
+      if (nn < 4) {
+        nn = 4
+      }
+      if (nn > this.buf.length) {
+        nn = this.buf.length
+      }
+      ;[nn, this.readErr] = this.r.Read(this.buf.subarray(this.nbuf, nn))
+      this.nbuf += nn
+    }
+
+    if (this.nbuf < 4) {
+      if (this.enc.padChar === NoPadding && this.nbuf > 0) {
+        // Decode final fragment, without padding.
+        let nw: number
+        ;[nw, this.err] = this.enc.decodeTo(
+          this.outbuf,
+          this.buf.subarray(0, this.nbuf),
+        )
+        this.nbuf = 0
+        this.out = this.outbuf.subarray(0, nw)
+        const n = $.copy(p, this.out)
+        this.out = this.out.subarray(n)
+        if (n > 0 || (plen === 0 && this.out.length > 0)) {
+          return [n, null]
+        }
+        if (this.err !== null) {
+          return [0, this.err]
+        }
+      }
+      this.err = this.readErr
+      if (this.err === io.EOF && this.nbuf > 0) {
+        this.err = io.ErrUnexpectedEOF
+      }
+      return [0, this.err]
+    }
+
+    // Decode chunk into p, or d.out and then p if p is too small.
+    const nr = Math.trunc(this.nbuf / 4) * 4
+    let nw = Math.trunc(this.nbuf / 4) * 3
+    let n: number
+    if (nw > plen) {
+      ;[nw, this.err] = this.enc.decodeTo(
+        this.outbuf,
+        this.buf.subarray(0, nr),
+      )
+      this.out = this.outbuf.subarray(0, nw)
+      n = $.copy(p, this.out)
+      this.out = this.out.subarray(n)
+    } else {
+      const out = new Uint8Array(nw)
+      ;[n, this.err] = this.enc.decodeTo(out, this.buf.subarray(0, nr))
+      $.copy(p, out.subarray(0, n))
+    }
+    this.nbuf -= nr
+    this.buf.copyWithin(0, nr, nr + this.nbuf)
+    return [n, this.err]
+  }
+}
+
+class newlineFilteringReader {
+  constructor(private readonly wrapped: io.Reader) {}
+
+  public Read(p: $.Bytes): [number, $.GoError] {
+    const b = p as Uint8Array
+    let [n, err] = this.wrapped!.Read(b)
+    while (n > 0) {
+      let offset = 0
+      for (let i = 0; i < n; i++) {
+        if (b[i] !== 0x0d && b[i] !== 0x0a) {
+          if (i !== offset) {
+            b[offset] = b[i]
+          }
+          offset++
+        }
+      }
+      if (offset > 0) {
+        return [offset, err]
+      }
+      // Previous buffer entirely whitespace, read again
+      ;[n, err] = this.wrapped!.Read(b)
+    }
+    return [n, err]
+  }
+}
+
+// NewDecoder constructs a new base64 stream decoder.
+export function NewDecoder(enc: Encoding | null, r: io.Reader): io.Reader {
+  return new decoder(enc!, new newlineFilteringReader(r))
+}
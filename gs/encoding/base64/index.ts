@@ -0,0 +1,14 @@
+export {
+  CorruptInputError,
+  CorruptInputError_Error,
+  Encoding,
+  NewDecoder,
+  NewEncoder,
+  NewEncoding,
+  NoPadding,
+  RawStdEncoding,
+  RawURLEncoding,
+  StdEncoding,
+  StdPadding,
+  URLEncoding,
+} from './base64.js'
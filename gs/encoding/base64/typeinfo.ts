@@ -0,0 +1,30 @@
+import * as $ from '@goscript/builtin/index.js'
+
+// Type information for the methods of the base64 types.
+
+export const intType: $.TypeInfo = { kind: $.TypeKind.Basic, name: 'int' }
+export const byteType: $.TypeInfo = { kind: $.TypeKind.Basic, name: 'byte' }
+export const runeType: $.TypeInfo = { kind: $.TypeKind.Basic, name: 'rune' }
+export const boolType: $.TypeInfo = { kind: $.TypeKind.Basic, name: 'bool' }
+export const stringType: $.TypeInfo = {
+  kind: $.TypeKind.Basic,
+  name: 'string',
+}
+export const bytesType: $.TypeInfo = {
+  kind: $.TypeKind.Slice,
+  elemType: byteType,
+}
+export const encodingPtrType: $.TypeInfo = {
+  kind: $.TypeKind.Pointer,
+  elemType: 'encoding/base64.Encoding',
+}
+export const errorType: $.TypeInfo = {
+  kind: $.TypeKind.Interface,
+  name: 'GoError',
+  methods: [{ name: 'Error', args: [], returns: [{ type: stringType }] }],
+}
+
+// byteArrayType returns the type of a byte array of length n.
+export function byteArrayType(length: number): $.TypeInfo {
+  return { kind: $.TypeKind.Array, elemType: byteType, length }
+}
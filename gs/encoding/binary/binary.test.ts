@@ -0,0 +1,129 @@
+import { describe, it, expect } from 'vitest'
+import * as $ from '@goscript/builtin/index.js'
+import * as bytes from '@goscript/bytes/index.js'
+import * as io from '@goscript/io/index.js'
+import * as binary from './index.js'
+
+function hex(b: $.Bytes): string {
+  const s = Array.from($.bytesToUint8Array(b), (x) => x.toString(16))
+  return s.map((x) => x.padStart(2, '0')).join('')
+}
+
+class Hdr {
+  public Magic = [0, 0, 0, 0]
+  public Len = 0
+  public Flags = 0
+  public F = 0
+
+  static __typeInfo = $.registerStructType(
+    'encoding/binary.testHdr',
+    new Hdr(),
+    [],
+    Hdr,
+    [
+      {
+        name: 'Magic',
+        type: { kind: $.TypeKind.Array, length: 4, elemType: 'byte' },
+      },
+      { name: 'Len', type: { kind: $.TypeKind.Basic, name: 'uint32' } },
+      { name: '_', type: { kind: $.TypeKind.Basic, name: 'uint16' } },
+      { name: 'Flags', type: { kind: $.TypeKind.Basic, name: 'int64' } },
+      { name: 'F', type: { kind: $.TypeKind.Basic, name: 'float64' } },
+    ],
+  )
+}
+
+describe('encoding/binary', () => {
+  it('converts with the byte orders', () => {
+    const b = new Uint8Array(8)
+    binary.BigEndian.PutUint32(b, 0xdeadbeef)
+    binary.LittleEndian.PutUint16(b.subarray(4), 0x1234)
+    expect(hex(b)).toBe('deadbeef34120000')
+    expect(binary.LittleEndian.Uint32(b)).toBe(0xefbeadde)
+    expect(binary.BigEndian.Uint16(b)).toBe(0xdead)
+    expect(hex(binary.BigEndian.AppendUint64(new Uint8Array([1]), -2n))).toBe(
+      '01fffffffffffffffe',
+    )
+    expect(binary.NativeEndian.String()).toBe('NativeEndian')
+    expect(() => binary.BigEndian.Uint32(b.subarray(6))).toThrow(
+      'index out of range [3] with length 2',
+    )
+  })
+
+  it('encodes varints like Go', () => {
+    const u: [number | bigint, string][] = [
+      [0, '00'],
+      [300, 'ac02'],
+      [2 ** 40, '808080808020'],
+      [2n ** 64n - 1n, 'ffffffffffffffffff01'],
+    ]
+    for (const [x, want] of u) {
+      const b = binary.AppendUvarint(null, x)
+      expect(hex(b)).toBe(want)
+      expect(binary.Uvarint(b)).toEqual([Number(x), want.length / 2])
+    }
+    const s: [number | bigint, string][] = [
+      [-1, '01'],
+      [-64, '7f'],
+      [64, '8001'],
+      [-(2n ** 63n), 'ffffffffffffffffff01'],
+    ]
+    for (const [x, want] of s) {
+      const b = binary.AppendVarint(null, x)
+      expect(hex(b)).toBe(want)
+      expect(binary.Varint(b)).toEqual([Number(x), want.length / 2])
+    }
+    expect(binary.Uvarint(new Uint8Array([0x80]))).toEqual([0, 0])
+    const overflow = new Uint8Array([...new Array(9).fill(0xff), 2])
+    expect(binary.Uvarint(overflow)).toEqual([0, -10])
+    const r = bytes.NewReader(new Uint8Array([0xac]))
+    expect(binary.ReadUvarint(r)).toEqual([44, io.ErrUnexpectedEOF])
+  })
+
+  it('writes and reads structs', () => {
+    const h = new Hdr()
+    h.Magic = [0x47, 0x53, 0, 1]
+    h.Len = 7
+    h.Flags = -2
+    h.F = 0.1
+    const buf = new bytes.Buffer()
+    expect(binary.Size(h)).toBe(26)
+    expect(binary.Write(buf, binary.BigEndian, h)).toBeNull()
+    expect(hex(buf.Bytes())).toBe(
+      '47530001000000070000fffffffffffffffe3fb999999999999a',
+    )
+    const h2 = new Hdr()
+    const err = binary.Read(bytes.NewReader(buf.Bytes()), binary.BigEndian, h2)
+    expect(err).toBeNull()
+    expect(h2).toEqual(h)
+  })
+
+  it('handles slices, pointers and errors', () => {
+    const u16 = { kind: $.TypeKind.Slice, elemType: 'uint16' }
+    const [out, err] = binary.Append(null, binary.LittleEndian, [1, 2], u16)
+    expect(err).toBeNull()
+    expect(hex(out)).toBe('01000200')
+
+    const x = $.varRef(0)
+    const ptr = { kind: $.TypeKind.Pointer, elemType: 'uint16' }
+    const r = bytes.NewReader(new Uint8Array([1]))
+    expect(binary.Read(r, binary.LittleEndian, x, ptr)).toBe(
+      io.ErrUnexpectedEOF,
+    )
+    expect(
+      binary.Decode(new Uint8Array([1, 2]), binary.BigEndian, x, ptr),
+    ).toEqual([2, null])
+    expect(x.value).toBe(0x0102)
+
+    const ints = { kind: $.TypeKind.Slice, elemType: 'int' }
+    expect(binary.Write(null, binary.BigEndian, [1], ints)!.Error()).toBe(
+      'binary.Write: some values are not fixed-sized in type []int',
+    )
+    const hdr = 'encoding/binary.testHdr'
+    const readErr = binary.Read(null, binary.BigEndian, new Hdr(), hdr)
+    expect(readErr!.Error()).toBe('binary.Read: invalid type binary.testHdr')
+    const small = new Uint8Array(1)
+    const [n, encErr] = binary.Encode(small, binary.BigEndian, x, ptr)
+    expect([n, encErr!.Error()]).toEqual([0, 'buffer too small'])
+  })
+})
@@ -0,0 +1,875 @@
+// Package binary implements simple translation between numbers and byte
+// sequences and encoding and decoding of varints.
+//
+// Numbers are translated by reading and writing fixed-size values.
+// A fixed-size value is either a fixed-size arithmetic
+// type (bool, int8, uint8, int16, float32, complex64, ...)
+// or an array or struct containing only fixed-size values.
+//
+// Values do not carry their Go type at runtime, so calls compiled from Go
+// pass the static type of the data argument to Read, Write, Size, Append,
+// Encode and Decode. Without it the type is taken from the value, which is
+// only possible for structs, byte slices and booleans.
+
+import * as $ from '@goscript/builtin/index.js'
+import * as errors from '@goscript/errors/index.js'
+import * as io from '@goscript/io/index.js'
+import * as reflect from '@goscript/reflect/index.js'
+import {
+  appendByteOrderMethods,
+  byteOrderMethods,
+  endianMethods,
+} from './typeinfo.js'
+
+const errBufferTooSmall = errors.New('buffer too small')
+
+// A ByteOrder specifies how to convert byte slices into
+// 16-, 32-, or 64-bit unsigned integers.
+//
+// It is implemented by LittleEndian, BigEndian, and NativeEndian.
+// 64-bit values are numbers or bigints, as selected by the BigInt64 option.
+export type ByteOrder = null | {
+  PutUint16(b: $.Bytes, v: number): void
+  PutUint32(b: $.Bytes, v: number): void
+  PutUint64(b: $.Bytes, v: number | bigint): void
+  String(): string
+  Uint16(b: $.Bytes): number
+  Uint32(b: $.Bytes): number
+  Uint64(b: $.Bytes): any
+}
+
+$.registerInterfaceType('encoding/binary.ByteOrder', null, byteOrderMethods)
+
+// AppendByteOrder specifies how to append 16-, 32-, or 64-bit unsigned
+// integers into a byte slice.
+//
+// It is implemented by LittleEndian, BigEndian, and NativeEndian.
+export type AppendByteOrder = null | {
+  AppendUint16(b: $.Bytes, v: number): $.Bytes
+  AppendUint32(b: $.Bytes, v: number): $.Bytes
+  AppendUint64(b: $.Bytes, v: number | bigint): $.Bytes
+  String(): string
+}
+
+$.registerInterfaceType(
+  'encoding/binary.AppendByteOrder',
+  null,
+  appendByteOrderMethods,
+)
+
+// checkLen returns b if it holds at least n bytes, and otherwise panics like
+// the bounds check of b[n-1].
+function checkLen(b: $.Bytes, n: number): Uint8Array | number[] {
+  const l = $.len(b)
+  if (l < n) {
+    throw new Error(
+      `runtime error: index out of range [${n - 1}] with length ${l}`,
+    )
+  }
+  return b as Uint8Array | number[]
+}
+
+// getUint returns the n byte unsigned integer at b[off:off+n], n <= 4.
+function getUint(
+  b: Uint8Array | number[],
+  off: number,
+  n: number,
+  big: boolean,
+): number {
+  let v = 0
+  for (let i = 0; i < n; i++) {
+    v = v * 256 + (b[off + (big ? i : n - 1 - i)] & 0xff)
+  }
+  return v
+}
+
+// putUint stores v as an n byte unsigned integer at b[off:off+n], n <= 4.
+function putUint(
+  b: Uint8Array | number[],
+  off: number,
+  n: number,
+  v: number,
+  big: boolean,
+): void {
+  for (let i = 0; i < n; i++) {
+    b[off + (big ? n - 1 - i : i)] = v & 0xff
+    v >>>= 8
+  }
+}
+
+// getUint64 returns the uint64 at b[0:8] in the representation of the
+// current mode.
+function getUint64(b: $.Bytes, big: boolean): any {
+  const s = checkLen(b, 8)
+  const hi = getUint(s, big ? 0 : 4, 4, big)
+  const lo = getUint(s, big ? 4 : 0, 4, big)
+  if ($.isBigInt64Mode()) {
+    return (BigInt(hi) << 32n) | BigInt(lo)
+  }
+  return hi * 0x100000000 + lo
+}
+
+// putUint64 stores the uint64 v at b[0:8].
+function putUint64(b: $.Bytes, v: number | bigint, big: boolean): void {
+  const s = checkLen(b, 8)
+  const x = $.uint64(v)
+  putUint(s, big ? 0 : 4, 4, Number(x >> 32n), big)
+  putUint(s, big ? 4 : 0, 4, Number(x & 0xffffffffn), big)
+}
+
+// appendUint appends the n byte unsigned integer v to b.
+function appendUint(
+  b: $.Bytes,
+  n: number,
+  v: number | bigint,
+  big: boolean,
+): $.Bytes {
+  const out = new Uint8Array(n)
+  if (n === 8) {
+    putUint64(out, v, big)
+  } else {
+    putUint(out, 0, n, Number(v), big)
+  }
+  return $.append(b, out)
+}
+
+class littleEndian {
+  // Uint16 returns the uint16 representation of b[0:2].
+  public Uint16(b: $.Bytes): number {
+    return getUint(checkLen(b, 2), 0, 2, false)
+  }
+
+  // PutUint16 stores v into b[0:2].
+  public PutUint16(b: $.Bytes, v: number): void {
+    putUint(checkLen(b, 2), 0, 2, v, false)
+  }
+
+  // AppendUint16 appends the bytes of v to b and returns the appended slice.
+  public AppendUint16(b: $.Bytes, v: number): $.Bytes {
+    return appendUint(b, 2, v, false)
+  }
+
+  // Uint32 returns the uint32 representation of b[0:4].
+  public Uint32(b: $.Bytes): number {
+    return getUint(checkLen(b, 4), 0, 4, false)
+  }
+
+  // PutUint32 stores v into b[0:4].
+  public PutUint32(b: $.Bytes, v: number): void {
+    putUint(checkLen(b, 4), 0, 4, v, false)
+  }
+
+  // AppendUint32 appends the bytes of v to b and returns the appended slice.
+  public AppendUint32(b: $.Bytes, v: number): $.Bytes {
+    return appendUint(b, 4, v, false)
+  }
+
+  // Uint64 returns the uint64 representation of b[0:8].
+  public Uint64(b: $.Bytes): any {
+    return getUint64(b, false)
+  }
+
+  // PutUint64 stores v into b[0:8].
+  public PutUint64(b: $.Bytes, v: number | bigint): void {
+    putUint64(b, v, false)
+  }
+
+  // AppendUint64 appends the bytes of v to b and returns the appended slice.
+  public AppendUint64(b: $.Bytes, v: number | bigint): $.Bytes {
+    return appendUint(b, 8, v, false)
+  }
+
+  public String(): string {
+    return 'LittleEndian'
+  }
+
+  public GoString(): string {
+    return 'binary.LittleEndian'
+  }
+
+  public clone(): littleEndian {
+    return this
+  }
+
+  // Register this type with the runtime type system
+  static __typeInfo = $.registerStructType(
+    'encoding/binary.littleEndian',
+    new littleEndian(),
+    endianMethods,
+    littleEndian,
+    [],
+  )
+}
+
+class bigEndian {
+  // Uint16 returns the uint16 representation of b[0:2].
+  public Uint16(b: $.Bytes): number {
+    return getUint(checkLen(b, 2), 0, 2, true)
+  }
+
+  // PutUint16 stores v into b[0:2].
+  public PutUint16(b: $.Bytes, v: number): void {
+    putUint(checkLen(b, 2), 0, 2, v, true)
+  }
+
+  // AppendUint16 appends the bytes of v to b and returns the appended slice.
+  public AppendUint16(b: $.Bytes, v: number): $.Bytes {
+    return appendUint(b, 2, v, true)
+  }
+
+  // Uint32 returns the uint32 representation of b[0:4].
+  public Uint32(b: $.Bytes): number {
+    return getUint(checkLen(b, 4), 0, 4, true)
+  }
+
+  // PutUint32 stores v into b[0:4].
+  public PutUint32(b: $.Bytes, v: number): void {
+    putUint(checkLen(b, 4), 0, 4, v, true)
+  }
+
+  // AppendUint32 appends the bytes of v to b and returns the appended slice.
+  public AppendUint32(b: $.Bytes, v: number): $.Bytes {
+    return appendUint(b, 4, v, true)
+  }
+
+  // Uint64 returns the uint64 representation of b[0:8].
+  public Uint64(b: $.Bytes): any {
+    return getUint64(b, true)
+  }
+
+  // PutUint64 stores v into b[0:8].
+  public PutUint64(b: $.Bytes, v: number | bigint): void {
+    putUint64(b, v, true)
+  }
+
+  // AppendUint64 appends the bytes of v to b and returns the appended slice.
+  public AppendUint64(b: $.Bytes, v: number | bigint): $.Bytes {
+    return appendUint(b, 8, v, true)
+  }
+
+  public String(): string {
+    return 'BigEndian'
+  }
+
+  public GoString(): string {
+    return 'binary.BigEndian'
+  }
+
+  public clone(): bigEndian {
+    return this
+  }
+
+  // Register this type with the runtime type system
+  static __typeInfo = $.registerStructType(
+    'encoding/binary.bigEndian',
+    new bigEndian(),
+    endianMethods,
+    bigEndian,
+    [],
+  )
+}
+
+// nativeEndian is little-endian, the byte order of the JavaScript engines
+// goscript targets.
+class nativeEndian extends littleEndian {
+  public String(): string {
+    return 'NativeEndian'
+  }
+
+  public GoString(): string {
+    return 'binary.NativeEndian'
+  }
+
+  public clone(): nativeEndian {
+    return this
+  }
+
+  // Register this type with the runtime type system
+  static __typeInfo = $.registerStructType(
+    'encoding/binary.nativeEndian',
+    new nativeEndian(),
+    endianMethods,
+    nativeEndian,
+    [],
+  )
+}
+
+// LittleEndian is the little-endian implementation of ByteOrder and
+// AppendByteOrder.
+export const LittleEndian = new littleEndian()
+
+// BigEndian is the big-endian implementation of ByteOrder and
+// AppendByteOrder.
+export const BigEndian = new bigEndian()
+
+// NativeEndian is the native-endian implementation of ByteOrder and
+// AppendByteOrder.
+export const NativeEndian = new nativeEndian()
+
+// Read reads structured binary data from r into data.
+// Data must be a pointer to a fixed-size value or a slice
+// of fixed-size values.
+// Bytes read from r are decoded using the specified byte order
+// and written to successive fields of the data.
+// When decoding boolean values, a zero byte is decoded as false, and
+// any other non-zero byte is decoded as true.
+// When reading into structs, the field data for fields with
+// blank (_) field names is skipped; i.e., blank field names
+// may be used for padding.
+//
+// The error is io.EOF only if no bytes were read.
+// If an io.EOF happens after reading some but not all the bytes,
+// Read returns io.ErrUnexpectedEOF.
+export function Read(
+  r: io.Reader,
+  order: ByteOrder,
+  data: any,
+  typ?: $.TypeInfo | string,
+): $.GoError {
+  const d = target(data, typ)
+  if (d === null) {
+    return errors.New('binary.Read: invalid type ' + typeString(data, typ))
+  }
+  const buf = new Uint8Array(d.size)
+  const [, err] = io.ReadFull(r, buf)
+  if (err !== null) {
+    return err
+  }
+  d.store(new decoder(order, buf).value(d.load(), d.info))
+  return null
+}
+
+// Decode decodes binary data from buf into data according to
+// the given byte order.
+// It returns an error if buf is too small, otherwise the number of
+// bytes consumed from buf.
+export function Decode(
+  buf: $.Bytes,
+  order: ByteOrder,
+  data: any,
+  typ?: $.TypeInfo | string,
+): [number, $.GoError] {
+  const d = target(data, typ)
+  if (d === null) {
+    return [
+      0,
+      errors.New('binary.Decode: invalid type ' + typeString(data, typ)),
+    ]
+  }
+  if ($.len(buf) < d.size) {
+    return [0, errBufferTooSmall]
+  }
+  const b = $.bytesToUint8Array(buf)
+  d.store(new decoder(order, b).value(d.load(), d.info))
+  return [d.size, null]
+}
+
+// Write writes the binary representation of data into w.
+// Data must be a fixed-size value or a slice of fixed-size
+// values, or a pointer to such data.
+// Boolean values encode as one byte: 1 for true, and 0 for false.
+// Bytes written to w are encoded using the specified byte order
+// and read from successive fields of the data.
+// When writing structs, zero values are written for fields
+// with blank (_) field names.
+export function Write(
+  w: io.Writer,
+  order: ByteOrder,
+  data: any,
+  typ?: $.TypeInfo | string,
+): $.GoError {
+  const [v, info] = indirect(data, typ)
+  const size = dataSize(v, info)
+  if (size < 0) {
+    return errors.New(
+      'binary.Write: some values are not fixed-sized in type ' +
+        typeString(data, typ),
+    )
+  }
+  const buf = new Uint8Array(size)
+  new encoder(order, buf).value(v, info!)
+  const [, err] = w!.Write(buf)
+  return err
+}
+
+// Encode encodes the binary representation of data into buf according to
+// the given byte order.
+// It returns an error if buf is too small, otherwise the number of
+// bytes written into buf.
+export function Encode(
+  buf: $.Bytes,
+  order: ByteOrder,
+  data: any,
+  typ?: $.TypeInfo | string,
+): [number, $.GoError] {
+  const [v, info] = indirect(data, typ)
+  const size = dataSize(v, info)
+  if (size < 0) {
+    return [
+      0,
+      errors.New(
+        'binary.Encode: some values are not fixed-sized in type ' +
+          typeString(data, typ),
+      ),
+    ]
+  }
+  if ($.len(buf) < size) {
+    return [0, errBufferTooSmall]
+  }
+  const out = new Uint8Array(size)
+  new encoder(order, out).value(v, info!)
+  $.copy(buf, out)
+  return [size, null]
+}
+
+// Append appends the binary representation of data to buf.
+// buf may be nil, in which case a new buffer will be allocated.
+// See Write on which data are acceptable.
+// It returns the (possibly extended) buffer containing data or an error.
+export function Append(
+  buf: $.Bytes,
+  order: ByteOrder,
+  data: any,
+  typ?: $.TypeInfo | string,
+): [$.Bytes, $.GoError] {
+  const [v, info] = indirect(data, typ)
+  const size = dataSize(v, info)
+  if (size < 0) {
+    return [
+      null,
+      errors.New(
+        'binary.Append: some values are not fixed-sized in type ' +
+          typeString(data, typ),
+      ),
+    ]
+  }
+  const out = new Uint8Array(size)
+  new encoder(order, out).value(v, info!)
+  return [$.append(buf, out), null]
+}
+
+// Size returns how many bytes Write would generate to encode the value v,
+// which must be a fixed-size value or a slice of fixed-size values, or a
+// pointer to such data.
+// If v is neither of these, Size returns -1.
+export function Size(v: any, typ?: $.TypeInfo | string): number {
+  const [value, info] = indirect(v, typ)
+  return dataSize(value, info)
+}
+
+// resolve returns the type information for a type name, which is a
+// registered type or a basic type, or the type information itself.
+function resolve(t: $.TypeInfo | string | undefined): $.TypeInfo | undefined {
+  if (typeof t !== 'string') {
+    return t
+  }
+  return $.getTypeByName(t) ?? { kind: $.TypeKind.Basic, name: t }
+}
+
+// structTypeOf returns the type information of a struct value.
+function structTypeOf(v: any): $.TypeInfo | undefined {
+  if (v === null || typeof v !== 'object') {
+    return undefined
+  }
+  const info = (v.constructor as { __typeInfo?: $.TypeInfo } | undefined)
+    ?.__typeInfo
+  return info?.kind === $.TypeKind.Struct ? info : undefined
+}
+
+// typeOf returns the type of data: its static type if the caller passed
+// it, and otherwise the type of the value if that can be determined.
+function typeOf(
+  data: any,
+  typ: $.TypeInfo | string | undefined,
+): $.TypeInfo | undefined {
+  if (typ !== undefined) {
+    return resolve(typ)
+  }
+  if (data instanceof Uint8Array) {
+    return { kind: $.TypeKind.Slice, elemType: 'uint8' }
+  }
+  if (typeof data === 'boolean') {
+    return { kind: $.TypeKind.Basic, name: 'bool' }
+  }
+  // Struct values share their representation with pointers to them, which
+  // are the values Read and Decode accept.
+  const elemType = structTypeOf($.isVarRef(data) ? data.value : data)
+  return elemType && { kind: $.TypeKind.Pointer, elemType }
+}
+
+// typeString returns the Go name of the type of data for error messages.
+function typeString(data: any, typ: $.TypeInfo | string | undefined): string {
+  return reflect.TypeOf(data, typ).String()
+}
+
+// deref returns the value pointed to by v, a pointer to a value of type
+// elem. Pointers to structs and arrays may be the values themselves.
+function deref(v: any, elem: $.TypeInfo | undefined): any {
+  if (v === null) {
+    return null
+  }
+  const kind = elem?.kind
+  if (kind === $.TypeKind.Struct || kind === $.TypeKind.Array) {
+    return $.isVarRef(v) ? v.value : v
+  }
+  return v.value
+}
+
+// indirect returns the value of data and its type, following a pointer.
+// The value is null for nil pointers.
+function indirect(
+  data: any,
+  typ: $.TypeInfo | string | undefined,
+): [any, $.TypeInfo | undefined] {
+  const info = typeOf(data, typ)
+  if (info?.kind !== $.TypeKind.Pointer) {
+    return [data, info]
+  }
+  const elem = resolve(info.elemType)
+  return [deref(data, elem), elem]
+}
+
+// A decoding target is the value Read or Decode decode into.
+interface decodeTarget {
+  info: $.TypeInfo
+  size: number
+  load(): any
+  store(v: any): void
+}
+
+// target returns where Read and Decode store decoded data, which must be a
+// pointer to a fixed-size value or a slice of fixed-size values, or null if
+// data is neither.
+function target(
+  data: any,
+  typ: $.TypeInfo | string | undefined,
+): decodeTarget | null {
+  const info = typeOf(data, typ)
+  if (info?.kind === $.TypeKind.Slice) {
+    const size = dataSize(data, info)
+    if (size < 0) {
+      return null
+    }
+    return { info, size, load: () => data, store: () => {} }
+  }
+  if (info?.kind !== $.TypeKind.Pointer || data === null) {
+    return null
+  }
+  const elem = resolve(info.elemType)
+  const v = deref(data, elem)
+  const size = dataSize(v, elem)
+  if (size < 0) {
+    return null
+  }
+  return {
+    info: elem!,
+    size,
+    load: () => v,
+    store: (x: any) => {
+      if ($.isVarRef(data)) {
+        data.value = x
+      }
+    },
+  }
+}
+
+// dataSize returns the number of bytes the actual data represented by v
+// occupies in memory. For compound structures, it sums the sizes of the
+// elements. Thus, for instance, for a slice it returns the length of the
+// slice times the element size and does not count the memory occupied by
+// the header. If the type of v is not acceptable, dataSize returns -1.
+function dataSize(v: any, info: $.TypeInfo | undefined): number {
+  if (info === undefined || (v === null && info.kind !== $.TypeKind.Slice)) {
+    return -1
+  }
+  if (info.kind === $.TypeKind.Slice) {
+    const s = sizeof(resolve(info.elemType))
+    return s >= 0 ? s * $.len(v) : -1
+  }
+  return sizeof(info)
+}
+
+// The sizes of the fixed-size basic types.
+const basicSizes: Record<string, number> = {
+  bool: 1,
+  int8: 1,
+  uint8: 1,
+  byte: 1,
+  int16: 2,
+  uint16: 2,
+  int32: 4,
+  uint32: 4,
+  rune: 4,
+  float32: 4,
+  int64: 8,
+  uint64: 8,
+  float64: 8,
+  complex64: 8,
+  complex128: 16,
+}
+
+// sizeof returns the size >= 0 of variables for the given type or -1 if the
+// type is not acceptable.
+function sizeof(info: $.TypeInfo | undefined): number {
+  switch (info?.kind) {
+    case $.TypeKind.Array: {
+      const s = sizeof(resolve(info.elemType))
+      return s >= 0 ? s * info.length : -1
+    }
+    case $.TypeKind.Struct: {
+      let sum = 0
+      for (const field of $.structFields(info.fields)) {
+        const s = sizeof(resolve(field.type))
+        if (s < 0) {
+          return -1
+        }
+        sum += s
+      }
+      return sum
+    }
+    case $.TypeKind.Basic:
+      return basicSizes[$.basicTypeName(info) ?? ''] ?? -1
+  }
+  return -1
+}
+
+// A coder reads or writes the bytes of values at an offset of a buffer.
+// The built-in byte orders are handled with a DataView, which keeps 64-bit
+// values exact also when they are represented as numbers; other orders are
+// called through their methods.
+class coder {
+  public offset = 0
+  protected readonly view: DataView
+  // Whether the byte order is little-endian, or undefined if it is not one
+  // of the built-in orders.
+  protected readonly little: boolean | undefined
+
+  constructor(
+    protected readonly order: ByteOrder,
+    protected readonly buf: Uint8Array,
+  ) {
+    this.view = new DataView(buf.buffer, buf.byteOffset, buf.byteLength)
+    this.little =
+      order instanceof littleEndian ? true
+      : order instanceof bigEndian ? false
+      : undefined
+  }
+
+  // next returns the next n bytes of the buffer.
+  protected next(n: number): Uint8Array {
+    const b = this.buf.subarray(this.offset, this.offset + n)
+    this.offset += n
+    return b
+  }
+}
+
+// scratch converts between floats and their bits.
+const scratch = new DataView(new ArrayBuffer(8))
+
+class decoder extends coder {
+  public uint8(): number {
+    return this.next(1)[0]
+  }
+
+  public uint16(): number {
+    if (this.little === undefined) {
+      return this.order!.Uint16(this.next(2))
+    }
+    this.offset += 2
+    return this.view.getUint16(this.offset - 2, this.little)
+  }
+
+  public uint32(): number {
+    if (this.little === undefined) {
+      return this.order!.Uint32(this.next(4))
+    }
+    this.offset += 4
+    return this.view.getUint32(this.offset - 4, this.little)
+  }
+
+  public uint64(): bigint {
+    if (this.little === undefined) {
+      return $.uint64(this.order!.Uint64(this.next(8)))
+    }
+    this.offset += 8
+    return this.view.getBigUint64(this.offset - 8, this.little)
+  }
+
+  public float32(): number {
+    scratch.setUint32(0, this.uint32())
+    return scratch.getFloat32(0)
+  }
+
+  public float64(): number {
+    scratch.setBigUint64(0, this.uint64())
+    return scratch.getFloat64(0)
+  }
+
+  // value decodes a value of type info. Arrays and structs are decoded in
+  // place, and the decoded value is returned.
+  public value(v: any, info: $.TypeInfo): any {
+    switch (info.kind) {
+      case $.TypeKind.Array:
+      case $.TypeKind.Slice: {
+        const elem = resolve(info.elemType)!
+        const n = info.kind === $.TypeKind.Array ? info.length : $.len(v)
+        v ??= new Array(n)
+        for (let i = 0; i < n; i++) {
+          v[i] = this.value(v[i], elem)
+        }
+        return v
+      }
+      case $.TypeKind.Struct: {
+        v ??= {}
+        for (const field of $.structFields(info.fields)) {
+          const t = resolve(field.type)!
+          if (field.name === '_') {
+            this.offset += sizeof(t)
+          } else {
+            v[field.name] = this.value(v[field.name], t)
+          }
+        }
+        return v
+      }
+    }
+    switch ($.basicTypeName(info)) {
+      case 'bool':
+        return this.uint8() !== 0
+      case 'int8':
+        return (this.uint8() << 24) >> 24
+      case 'int16':
+        return (this.uint16() << 16) >> 16
+      case 'int32':
+      case 'rune':
+        return this.uint32() | 0
+      case 'int64':
+        return $.int64Result(BigInt.asIntN(64, this.uint64()))
+      case 'uint8':
+      case 'byte':
+        return this.uint8()
+      case 'uint16':
+        return this.uint16()
+      case 'uint32':
+        return this.uint32()
+      case 'uint64':
+        return $.int64Result(this.uint64())
+      case 'float32':
+        return this.float32()
+      case 'float64':
+        return this.float64()
+      case 'complex64':
+        return $.complex(this.float32(), this.float32())
+      case 'complex128':
+        return $.complex(this.float64(), this.float64())
+    }
+    return v
+  }
+}
+
+class encoder extends coder {
+  public uint8(x: number): void {
+    this.next(1)[0] = x
+  }
+
+  public uint16(x: number): void {
+    if (this.little === undefined) {
+      this.order!.PutUint16(this.next(2), x & 0xffff)
+      return
+    }
+    this.view.setUint16(this.offset, x, this.little)
+    this.offset += 2
+  }
+
+  public uint32(x: number): void {
+    if (this.little === undefined) {
+      this.order!.PutUint32(this.next(4), x >>> 0)
+      return
+    }
+    this.view.setUint32(this.offset, x, this.little)
+    this.offset += 4
+  }
+
+  public uint64(x: number | bigint): void {
+    if (this.little === undefined) {
+      this.order!.PutUint64(this.next(8), $.int64Result($.uint64(x)))
+      return
+    }
+    this.view.setBigUint64(this.offset, $.uint64(x), this.little)
+    this.offset += 8
+  }
+
+  public float32(x: number): void {
+    scratch.setFloat32(0, x)
+    this.uint32(scratch.getUint32(0))
+  }
+
+  public float64(x: number): void {
+    scratch.setFloat64(0, x)
+    this.uint64(scratch.getBigUint64(0))
+  }
+
+  // value encodes v, a value of type info. Missing fields of anonymous
+  // structs are encoded as zero values.
+  public value(v: any, info: $.TypeInfo): void {
+    switch (info.kind) {
+      case $.TypeKind.Array:
+      case $.TypeKind.Slice: {
+        const elem = resolve(info.elemType)!
+        const n = info.kind === $.TypeKind.Array ? info.length : $.len(v)
+        for (let i = 0; i < n; i++) {
+          this.value(v?.[i], elem)
+        }
+        return
+      }
+      case $.TypeKind.Struct:
+        for (const field of $.structFields(info.fields)) {
+          const t = resolve(field.type)!
+          if (field.name === '_') {
+            // Blank fields are written as zeros, which the buffer
+            // already holds.
+            this.offset += sizeof(t)
+          } else {
+            this.value(v?.[field.name], t)
+          }
+        }
+        return
+    }
+    switch ($.basicTypeName(info)) {
+      case 'bool':
+        this.uint8(v ? 1 : 0)
+        return
+      case 'int8':
+      case 'uint8':
+      case 'byte':
+        this.uint8(v ?? 0)
+        return
+      case 'int16':
+      case 'uint16':
+        this.uint16(v ?? 0)
+        return
+      case 'int32':
+      case 'rune':
+      case 'uint32':
+        this.uint32(v ?? 0)
+        return
+      case 'int64':
+      case 'uint64':
+        this.uint64(v ?? 0)
+        return
+      case 'float32':
+        this.float32(v ?? 0)
+        return
+      case 'float64':
+        this.float64(v ?? 0)
+        return
+      case 'complex64':
+        this.float32(v?.re ?? 0)
+        this.float32(v?.im ?? 0)
+        return
+      case 'complex128':
+        this.float64(v?.re ?? 0)
+        this.float64(v?.im ?? 0)
+        return
+    }
+  }
+}
@@ -0,0 +1,25 @@
+export {
+  Append,
+  BigEndian,
+  Decode,
+  Encode,
+  LittleEndian,
+  NativeEndian,
+  Read,
+  Size,
+  Write,
+} from './binary.js'
+export type { AppendByteOrder, ByteOrder } from './binary.js'
+export {
+  AppendUvarint,
+  AppendVarint,
+  MaxVarintLen16,
+  MaxVarintLen32,
+  MaxVarintLen64,
+  PutUvarint,
+  PutVarint,
+  ReadUvarint,
+  ReadVarint,
+  Uvarint,
+  Varint,
+} from './varint.js'
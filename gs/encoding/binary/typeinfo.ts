@@ -0,0 +1,42 @@
+import * as $ from '@goscript/builtin/index.js'
+
+// Type information for the methods of the binary types.
+
+export const byteType: $.TypeInfo = { kind: $.TypeKind.Basic, name: 'byte' }
+export const uint16Type: $.TypeInfo = { kind: $.TypeKind.Basic, name: 'uint16' }
+export const uint32Type: $.TypeInfo = { kind: $.TypeKind.Basic, name: 'uint32' }
+export const uint64Type: $.TypeInfo = { kind: $.TypeKind.Basic, name: 'uint64' }
+export const stringType: $.TypeInfo = {
+  kind: $.TypeKind.Basic,
+  name: 'string',
+}
+export const bytesType: $.TypeInfo = {
+  kind: $.TypeKind.Slice,
+  elemType: byteType,
+}
+
+// The methods of ByteOrder.
+export const byteOrderMethods: $.MethodSignature[] = [
+  $.method('PutUint16', [bytesType, uint16Type], []),
+  $.method('PutUint32', [bytesType, uint32Type], []),
+  $.method('PutUint64', [bytesType, uint64Type], []),
+  $.method('String', [], [stringType]),
+  $.method('Uint16', [bytesType], [uint16Type]),
+  $.method('Uint32', [bytesType], [uint32Type]),
+  $.method('Uint64', [bytesType], [uint64Type]),
+]
+
+// The methods of AppendByteOrder.
+export const appendByteOrderMethods: $.MethodSignature[] = [
+  $.method('AppendUint16', [bytesType, uint16Type], [bytesType]),
+  $.method('AppendUint32', [bytesType, uint32Type], [bytesType]),
+  $.method('AppendUint64', [bytesType, uint64Type], [bytesType]),
+  $.method('String', [], [stringType]),
+]
+
+// The methods of the byte order implementations.
+export const endianMethods: $.MethodSignature[] = [
+  ...appendByteOrderMethods.slice(0, 3),
+  $.method('GoString', [], [stringType]),
+  ...byteOrderMethods,
+]
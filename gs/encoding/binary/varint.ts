@@ -0,0 +1,217 @@
+// This file implements "varint" encoding of 64-bit integers.
+// The encoding is:
+// - unsigned integers are serialized 7 bits at a time, starting with the
+//   least significant bits
+// - the most significant bit (msb) in each output byte indicates if there
+//   is a continuation byte (msb = 1)
+// - signed integers are mapped to unsigned integers using "zig-zag"
+//   encoding: Positive values x are written as 2*x + 0, negative values
+//   are written as 2*(^x) + 1; that is, negative numbers are complemented
+//   and whether to complement is encoded in bit 0.
+//
+// 64-bit values are numbers or bigints, as selected by the BigInt64 option.
+// Numbers that are safe integers are encoded without converting them.
+
+import * as $ from '@goscript/builtin/index.js'
+import * as errors from '@goscript/errors/index.js'
+import * as io from '@goscript/io/index.js'
+
+// MaxVarintLenN is the maximum length of a varint-encoded N-bit integer.
+export const MaxVarintLen16 = 3
+export const MaxVarintLen32 = 5
+export const MaxVarintLen64 = 10
+
+// isSafeUint reports whether x is a uint64 held exactly by a number.
+function isSafeUint(x: number | bigint): x is number {
+  return typeof x === 'number' && x >= 0 && x <= Number.MAX_SAFE_INTEGER
+}
+
+// uvarintBytes returns the varint-encoded form of x.
+function uvarintBytes(x: number | bigint): number[] {
+  const out: number[] = []
+  if (isSafeUint(x)) {
+    while (x >= 0x80) {
+      out.push((x % 0x80) | 0x80)
+      x = Math.floor(x / 0x80)
+    }
+    out.push(x)
+    return out
+  }
+  let ux = $.uint64(x)
+  while (ux >= 0x80n) {
+    out.push(Number(ux & 0x7fn) | 0x80)
+    ux >>= 7n
+  }
+  out.push(Number(ux))
+  return out
+}
+
+// zigzag maps the int64 x to the uint64 that encodes it.
+function zigzag(x: number | bigint): number | bigint {
+  if (typeof x === 'number' && Number.isSafeInteger(2 * x)) {
+    return x < 0 ? -2 * x - 1 : 2 * x
+  }
+  const v = $.int64(x)
+  const ux = BigInt.asUintN(64, v << 1n)
+  return v < 0n ? BigInt.asUintN(64, ~ux) : ux
+}
+
+// unzigzag maps the uint64 ux to the int64 it encodes.
+function unzigzag(ux: number | bigint): any {
+  if (typeof ux === 'number') {
+    const x = Math.floor(ux / 2)
+    return result(ux % 2 !== 0 ? -x - 1 : x)
+  }
+  const x = BigInt.asIntN(64, ux >> 1n)
+  return result(ux & 1n ? ~x : x)
+}
+
+// result returns a 64-bit integer in the representation of the current
+// mode.
+function result(x: number | bigint): any {
+  if (typeof x === 'number' && !$.isBigInt64Mode()) {
+    return x
+  }
+  return $.int64Result(BigInt(x))
+}
+
+// uvarintDecoder accumulates the 7-bit groups of a uvarint, as a number
+// while the value is a safe integer.
+class uvarintDecoder {
+  private x: number | bigint = 0
+  private s = 0
+
+  public add(b: number): void {
+    if (this.s < 49) {
+      this.x = (this.x as number) + b * 2 ** this.s
+    } else {
+      this.x = BigInt(this.x) | (BigInt(b) << BigInt(this.s))
+    }
+    this.s += 7
+  }
+
+  public value(): number | bigint {
+    return this.x
+  }
+}
+
+// AppendUvarint appends the varint-encoded form of x,
+// as generated by PutUvarint, to buf and returns the extended buffer.
+export function AppendUvarint(buf: $.Bytes, x: number | bigint): $.Bytes {
+  return $.append(buf, new Uint8Array(uvarintBytes(x)))
+}
+
+// PutUvarint encodes a uint64 into buf and returns the number of bytes
+// written. If the buffer is too small, PutUvarint will panic.
+export function PutUvarint(buf: $.Bytes, x: number | bigint): number {
+  const b = uvarintBytes(x)
+  const n = $.len(buf)
+  if (n < b.length) {
+    throw new Error(`runtime error: index out of range [${n}] with length ${n}`)
+  }
+  $.copy(buf, new Uint8Array(b))
+  return b.length
+}
+
+// Uvarint decodes a uint64 from buf and returns that value and the
+// number of bytes read (> 0). If an error occurred, the value is 0
+// and the number of bytes n is <= 0 meaning:
+//   - n == 0: buf too small;
+//   - n < 0: value larger than 64 bits (overflow) and -n is the number of
+//     bytes read.
+export function Uvarint(buf: $.Bytes): [any, number] {
+  const [x, n] = uvarint(buf)
+  return [result(x), n]
+}
+
+// uvarint is Uvarint returning the value as a number if it is a safe
+// integer.
+function uvarint(buf: $.Bytes): [number | bigint, number] {
+  const b = $.bytesToUint8Array(buf)
+  const d = new uvarintDecoder()
+  for (let i = 0; i < b.length; i++) {
+    if (i === MaxVarintLen64) {
+      // Catch byte reads past MaxVarintLen64.
+      // See issue https://golang.org/issues/41185
+      return [0, -(i + 1)] // overflow
+    }
+    if (b[i] < 0x80) {
+      if (i === MaxVarintLen64 - 1 && b[i] > 1) {
+        return [0, -(i + 1)] // overflow
+      }
+      d.add(b[i])
+      return [d.value(), i + 1]
+    }
+    d.add(b[i] & 0x7f)
+  }
+  return [0, 0]
+}
+
+// AppendVarint appends the varint-encoded form of x,
+// as generated by PutVarint, to buf and returns the extended buffer.
+export function AppendVarint(buf: $.Bytes, x: number | bigint): $.Bytes {
+  return AppendUvarint(buf, zigzag(x))
+}
+
+// PutVarint encodes an int64 into buf and returns the number of bytes
+// written. If the buffer is too small, PutVarint will panic.
+export function PutVarint(buf: $.Bytes, x: number | bigint): number {
+  return PutUvarint(buf, zigzag(x))
+}
+
+// Varint decodes an int64 from buf and returns that value and the
+// number of bytes read (> 0). If an error occurred, the value is 0
+// and the number of bytes n is <= 0 with the following meaning:
+//   - n == 0: buf too small;
+//   - n < 0: value larger than 64 bits (overflow)
+//     and -n is the number of bytes read.
+export function Varint(buf: $.Bytes): [any, number] {
+  const [ux, n] = uvarint(buf) // ok to continue in presence of error
+  return [unzigzag(ux), n]
+}
+
+const errOverflow = errors.New('binary: varint overflows a 64-bit integer')
+
+// ReadUvarint reads an encoded unsigned integer from r and returns it as a
+// uint64.
+// The error is io.EOF only if no bytes were read.
+// If an io.EOF happens after reading some but not all the bytes,
+// ReadUvarint returns io.ErrUnexpectedEOF.
+export function ReadUvarint(r: io.ByteReader): [any, $.GoError] {
+  const [x, err] = readUvarint(r)
+  return [result(x), err]
+}
+
+// readUvarint is ReadUvarint returning the value as a number if it is a
+// safe integer.
+function readUvarint(r: io.ByteReader): [number | bigint, $.GoError] {
+  const d = new uvarintDecoder()
+  for (let i = 0; i < MaxVarintLen64; i++) {
+    let [b, err] = r!.ReadByte()
+    if (err !== null) {
+      if (i > 0 && err === io.EOF) {
+        err = io.ErrUnexpectedEOF
+      }
+      return [d.value(), err]
+    }
+    if (b < 0x80) {
+      if (i === MaxVarintLen64 - 1 && b > 1) {
+        return [d.value(), errOverflow]
+      }
+      d.add(b)
+      return [d.value(), null]
+    }
+    d.add(b & 0x7f)
+  }
+  return [d.value(), errOverflow]
+}
+
+// ReadVarint reads an encoded signed integer from r and returns it as an
+// int64.
+// The error is io.EOF only if no bytes were read.
+// If an io.EOF happens after reading some but not all the bytes,
+// ReadVarint returns io.ErrUnexpectedEOF.
+export function ReadVarint(r: io.ByteReader): [any, $.GoError] {
+  const [ux, err] = readUvarint(r) // ok to continue in presence of error
+  return [unzigzag(ux), err]
+}
@@ -0,0 +1,62 @@
+import { describe, it, expect } from 'vitest'
+import * as $ from '@goscript/builtin/index.js'
+import * as io from '@goscript/io/index.js'
+import * as strings from '@goscript/strings/index.js'
+import * as hex from './index.js'
+
+describe('encoding/hex', () => {
+  it('encodes and decodes', () => {
+    const data = $.stringToBytes('\x00hello, wörld!\n')
+    expect(hex.EncodeToString(data)).toBe('0068656c6c6f2c2077c3b6726c64210a')
+    const [b, err] = hex.DecodeString('48656C6c6f')
+    expect(err).toBeNull()
+    expect($.bytesToString(b)).toBe('Hello')
+
+    const dst = new Uint8Array(4)
+    expect(hex.Encode(dst, new Uint8Array([0xde, 0xad]))).toBe(4)
+    expect($.bytesToString(dst)).toBe('dead')
+    const out = hex.AppendEncode(new Uint8Array([0x78]), new Uint8Array([1]))
+    expect($.bytesToString(out)).toBe('x01')
+  })
+
+  it('reports invalid input', () => {
+    const cases: [string, string, string][] = [
+      ['0g', '', "encoding/hex: invalid byte: U+0067 'g'"],
+      ['abc', '\xab', 'encoding/hex: odd length hex string'],
+      ['zz', '', "encoding/hex: invalid byte: U+007A 'z'"],
+    ]
+    for (const [s, want, msg] of cases) {
+      const [b, err] = hex.DecodeString(s)
+      expect(Array.from($.bytesToUint8Array(b))).toEqual(
+        Array.from(want, (c) => c.charCodeAt(0)),
+      )
+      expect(err!.Error()).toBe(msg)
+    }
+    const [, err] = hex.DecodeString('0g')
+    expect(err).toBeInstanceOf(hex.InvalidByteError)
+    expect(Number(err)).toBe(0x67)
+    expect(hex.DecodeString('abc')[1]).toBe(hex.ErrLength)
+  })
+
+  it('dumps like hexdump -C', () => {
+    expect(hex.Dump($.stringToBytes('0123456789abcdefXYZ\x01'))).toBe(
+      '00000000  30 31 32 33 34 35 36 37  38 39 61 62 63 64 65 66  ' +
+        '|0123456789abcdef|\n' +
+        '00000010  58 59 5a 01                                       ' +
+        '|XYZ.|\n',
+    )
+  })
+
+  it('streams through readers', () => {
+    const r = hex.NewDecoder(strings.NewReader('48656c6c6f2'))
+    const p = new Uint8Array(16)
+    expect(r!.Read(p)).toEqual([5, null])
+    expect($.bytesToString(p.subarray(0, 5))).toBe('Hello')
+    expect(r!.Read(p)).toEqual([0, io.ErrUnexpectedEOF])
+
+    const buf = new strings.Builder()
+    const w = hex.NewEncoder(buf)
+    expect(w!.Write($.stringToBytes('Hi'))).toEqual([2, null])
+    expect(buf.String()).toBe('4869')
+  })
+})
@@ -0,0 +1,432 @@
+// Package hex implements hexadecimal encoding and decoding.
+
+import * as $ from '@goscript/builtin/index.js'
+import * as errors from '@goscript/errors/index.js'
+import * as io from '@goscript/io/index.js'
+import { IsPrint } from '@goscript/strconv/index.js'
+
+const hextable = '0123456789abcdef'
+
+// reverseHexTable maps a byte to its value as a hexadecimal digit, or 0xff.
+const reverseHexTable = new Uint8Array(256).fill(0xff)
+for (let i = 0; i < 10; i++) {
+  reverseHexTable[0x30 + i] = i
+}
+for (let i = 0; i < 6; i++) {
+  reverseHexTable[0x41 + i] = 10 + i
+  reverseHexTable[0x61 + i] = 10 + i
+}
+
+// EncodedLen returns the length of an encoding of n source bytes.
+// Specifically, it returns n * 2.
+export function EncodedLen(n: number): number {
+  return n * 2
+}
+
+// encode writes the hexadecimal encoding of src to dst.
+function encode(dst: Uint8Array, src: Uint8Array): void {
+  let j = 0
+  for (const v of src) {
+    dst[j] = hextable.charCodeAt(v >> 4)
+    dst[j + 1] = hextable.charCodeAt(v & 0x0f)
+    j += 2
+  }
+}
+
+// Encode encodes src into EncodedLen(len(src))
+// bytes of dst. As a convenience, it returns the number
+// of bytes written to dst, but this value is always EncodedLen(len(src)).
+// Encode implements hexadecimal encoding.
+export function Encode(dst: $.Bytes, src: $.Bytes): number {
+  const s = $.bytesToUint8Array(src)
+  const out = new Uint8Array(EncodedLen(s.length))
+  encode(out, s)
+  writeTo(dst, out)
+  return out.length
+}
+
+// AppendEncode appends the hexadecimally encoded src to dst
+// and returns the extended buffer.
+export function AppendEncode(dst: $.Bytes, src: $.Bytes): $.Bytes {
+  const s = $.bytesToUint8Array(src)
+  const out = new Uint8Array(EncodedLen(s.length))
+  encode(out, s)
+  return $.append(dst, out)
+}
+
+// ErrLength reports an attempt to decode an odd-length input
+// using Decode or DecodeString.
+// The stream-based Decoder returns io.ErrUnexpectedEOF instead of ErrLength.
+export const ErrLength = errors.New('encoding/hex: odd length hex string')
+
+// InvalidByteError values describe errors resulting from an invalid byte in
+// a hex string. Values of named basic types do not carry their methods at
+// runtime, so the byte is held by an object whose valueOf returns it.
+export class InvalidByteError {
+  constructor(private readonly b: number = 0) {}
+
+  public valueOf(): number {
+    return this.b
+  }
+
+  public Error(): string {
+    return InvalidByteError_Error(this.b)
+  }
+
+  // Register this type with the runtime type system
+  static __typeInfo = $.registerStructType(
+    'encoding/hex.InvalidByteError',
+    new InvalidByteError(),
+    [
+      {
+        name: 'Error',
+        args: [],
+        returns: [{ type: { kind: $.TypeKind.Basic, name: 'string' } }],
+      },
+    ],
+    InvalidByteError,
+    [],
+  )
+}
+
+// InvalidByteError_Error formats the invalid byte like %#U.
+export function InvalidByteError_Error(e: InvalidByteError | number): string {
+  const r = Number(e)
+  let s = 'U+' + r.toString(16).toUpperCase().padStart(4, '0')
+  if (IsPrint(r)) {
+    s += " '" + String.fromCodePoint(r) + "'"
+  }
+  return 'encoding/hex: invalid byte: ' + s
+}
+
+// DecodedLen returns the length of a decoding of x source bytes.
+// Specifically, it returns x / 2.
+export function DecodedLen(x: number): number {
+  return Math.trunc(x / 2)
+}
+
+// decode decodes src into dst, returning the number of bytes written.
+function decode(dst: Uint8Array, src: Uint8Array): [number, $.GoError] {
+  let i = 0
+  let j = 0
+  for (; j < src.length - 1; j += 2) {
+    const p = src[j]
+    const q = src[j + 1]
+
+    const a = reverseHexTable[p]
+    const b = reverseHexTable[q]
+    if (a > 0x0f) {
+      return [i, new InvalidByteError(p)]
+    }
+    if (b > 0x0f) {
+      return [i, new InvalidByteError(q)]
+    }
+    dst[i] = (a << 4) | b
+    i++
+  }
+  if (src.length % 2 === 1) {
+    // Check for invalid char before reporting bad length,
+    // since the invalid char (if present) is an earlier problem.
+    if (reverseHexTable[src[j]] > 0x0f) {
+      return [i, new InvalidByteError(src[j])]
+    }
+    return [i, ErrLength]
+  }
+  return [i, null]
+}
+
+// Decode decodes src into DecodedLen(len(src)) bytes,
+// returning the actual number of bytes written to dst.
+//
+// Decode expects that src contains only hexadecimal
+// characters and that src has even length.
+// If the input is malformed, Decode returns the number
+// of bytes decoded before the error.
+export function Decode(dst: $.Bytes, src: $.Bytes): [number, $.GoError] {
+  const s = $.bytesToUint8Array(src)
+  const out = new Uint8Array(DecodedLen(s.length))
+  const [n, err] = decode(out, s)
+  writeTo(dst, out.subarray(0, n))
+  return [n, err]
+}
+
+// AppendDecode appends the hexadecimally decoded src to dst
+// and returns the extended buffer.
+// If the input is malformed, it returns the partially decoded src and an
+// error.
+export function AppendDecode(
+  dst: $.Bytes,
+  src: $.Bytes,
+): [$.Bytes, $.GoError] {
+  const s = $.bytesToUint8Array(src)
+  const out = new Uint8Array(DecodedLen(s.length))
+  const [n, err] = decode(out, s)
+  return [$.append(dst, out.subarray(0, n)), err]
+}
+
+// EncodeToString returns the hexadecimal encoding of src.
+export function EncodeToString(src: $.Bytes): string {
+  const s = $.bytesToUint8Array(src)
+  let out = ''
+  for (const v of s) {
+    out += hextable[v >> 4] + hextable[v & 0x0f]
+  }
+  return out
+}
+
+// DecodeString returns the bytes represented by the hexadecimal string s.
+//
+// DecodeString expects that src contains only hexadecimal
+// characters and that src has even length.
+// If the input is malformed, DecodeString returns
+// the bytes decoded before the error.
+export function DecodeString(s: string): [$.Bytes, $.GoError] {
+  const src = $.stringToBytes(s)
+  const dst = new Uint8Array(DecodedLen(src.length))
+  const [n, err] = decode(dst, src)
+  return [dst.subarray(0, n), err]
+}
+
+// Dump returns a string that contains a hex dump of the given data. The
+// format of the hex dump matches the output of `hexdump -C` on the command
+// line.
+export function Dump(data: $.Bytes): string {
+  if ($.len(data) === 0) {
+    return ''
+  }
+  const buf = new stringWriter()
+  const d = new dumper(buf)
+  d.Write(data)
+  d.Close()
+  return buf.s
+}
+
+// writeTo copies b to the start of dst, panicking like an out of range index
+// if dst is too short.
+function writeTo(dst: $.Bytes, b: Uint8Array): void {
+  const n = $.len(dst)
+  if (n < b.length) {
+    throw new Error(
+      `runtime error: index out of range [${b.length - 1}] with length ${n}`,
+    )
+  }
+  $.copy(dst, b)
+}
+
+// bufferSize is the number of hexadecimal characters to buffer in encoder
+// and decoder.
+const bufferSize = 1024
+
+class encoder {
+  private err: $.GoError = null
+  private out = new Uint8Array(bufferSize) // output buffer
+
+  constructor(private readonly w: io.Writer) {}
+
+  public Write(p: $.Bytes): [number, $.GoError] {
+    let src = $.bytesToUint8Array(p)
+    let n = 0
+    while (src.length > 0 && this.err === null) {
+      const chunkSize = Math.min(bufferSize / 2, src.length)
+      encode(this.out, src.subarray(0, chunkSize))
+      const [written, err] = this.w!.Write(
+        this.out.subarray(0, chunkSize * 2),
+      )
+      this.err = err
+      n += Math.trunc(written / 2)
+      src = src.subarray(chunkSize)
+    }
+    return [n, this.err]
+  }
+}
+
+// NewEncoder returns an io.Writer that writes lowercase hexadecimal
+// characters to w.
+export function NewEncoder(w: io.Writer): io.Writer {
+  return new encoder(w)
+}
+
+class decoder {
+  private err: $.GoError = null
+  private arr = new Uint8Array(bufferSize) // backing array for in
+  private in: Uint8Array = this.arr.subarray(0, 0) // input buffer
+
+  constructor(private readonly r: io.Reader) {}
+
+  public Read(p: $.Bytes): [number, $.GoError] {
+    // Fill internal buffer with sufficient bytes to decode
+    if (this.in.length < 2 && this.err === null) {
+      const numCopy = this.in.length // Copies either 0 or 1 bytes
+      this.arr.set(this.in)
+      const [numRead, err] = this.r!.Read(this.arr.subarray(numCopy))
+      this.err = err
+      this.in = this.arr.subarray(0, numCopy + numRead)
+      if (this.err === io.EOF && this.in.length % 2 !== 0) {
+        const last = this.in[this.in.length - 1]
+        if (reverseHexTable[last] > 0x0f) {
+          this.err = new InvalidByteError(last)
+        } else {
+          this.err = io.ErrUnexpectedEOF
+        }
+      }
+    }
+
+    // Decode internal buffer into output buffer
+    const numAvail = Math.trunc(this.in.length / 2)
+    const out = new Uint8Array(Math.min($.len(p), numAvail))
+    const [numDec, err] = decode(out, this.in.subarray(0, out.length * 2))
+    $.copy(p, out.subarray(0, numDec))
+    this.in = this.in.subarray(2 * numDec)
+    if (err !== null) {
+      // Decode error; discard input remainder
+      this.in = this.arr.subarray(0, 0)
+      this.err = err
+    }
+
+    if (this.in.length < 2) {
+      return [numDec, this.err] // Only expose errors when buffer fully consumed
+    }
+    return [numDec, null]
+  }
+}
+
+// NewDecoder returns an io.Reader that decodes hexadecimal characters from
+// r. NewDecoder expects that r contain only an even number of hexadecimal
+// characters.
+export function NewDecoder(r: io.Reader): io.Reader {
+  return new decoder(r)
+}
+
+// Dumper returns a io.WriteCloser that writes a hex dump of all written
+// data to w. The format of the dump matches the output of `hexdump -C` on
+// the command line.
+export function Dumper(w: io.Writer): io.WriteCloser {
+  return new dumper(w)
+}
+
+// stringWriter collects the text written to it.
+class stringWriter {
+  public s = ''
+
+  public Write(p: $.Bytes): [number, $.GoError] {
+    const b = $.bytesToUint8Array(p)
+    for (const c of b) {
+      this.s += String.fromCharCode(c)
+    }
+    return [b.length, null]
+  }
+}
+
+function toChar(b: number): number {
+  if (b < 32 || b > 126) {
+    return 0x2e // '.'
+  }
+  return b
+}
+
+class dumper {
+  private rightChars = new Uint8Array(18)
+  private buf = new Uint8Array(14)
+  private used = 0 // number of bytes in the current line
+  private n = 0 // number of bytes, total
+  private closed = false
+
+  constructor(private readonly w: io.Writer) {}
+
+  public Write(data: $.Bytes): [number, $.GoError] {
+    if (this.closed) {
+      return [0, errors.New('encoding/hex: dumper closed')]
+    }
+    const b = $.bytesToUint8Array(data)
+    const h = this.buf
+    let n = 0
+
+    // Output lines look like:
+    // 00000010  2e 2f 30 31 32 33 34 35  36 37 38 39 3a 3b 3c 3d  |./0123456789:;<=|
+    // ^ offset                          ^ extra space              ^ ASCII of line.
+    for (let i = 0; i < b.length; i++) {
+      if (this.used === 0) {
+        // At the beginning of a line we print the current
+        // offset in hex.
+        h[0] = this.n >>> 24
+        h[1] = this.n >>> 16
+        h[2] = this.n >>> 8
+        h[3] = this.n
+        encode(h.subarray(4), h.subarray(0, 4))
+        h[12] = 0x20
+        h[13] = 0x20
+        const [, err] = this.w!.Write(h.slice(4))
+        if (err !== null) {
+          return [n, err]
+        }
+      }
+      encode(h, b.subarray(i, i + 1))
+      h[2] = 0x20
+      let l = 3
+      if (this.used === 7) {
+        // There's an additional space after the 8th byte.
+        h[3] = 0x20
+        l = 4
+      } else if (this.used === 15) {
+        // At the end of the line there's an extra space and
+        // the bar for the right column.
+        h[3] = 0x20
+        h[4] = 0x7c
+        l = 5
+      }
+      const [, err] = this.w!.Write(h.slice(0, l))
+      if (err !== null) {
+        return [n, err]
+      }
+      n++
+      this.rightChars[this.used] = toChar(b[i])
+      this.used++
+      this.n++
+      if (this.used === 16) {
+        this.rightChars[16] = 0x7c
+        this.rightChars[17] = 0x0a
+        const [, err] = this.w!.Write(this.rightChars.slice())
+        if (err !== null) {
+          return [n, err]
+        }
+        this.used = 0
+      }
+    }
+    return [n, null]
+  }
+
+  public Close(): $.GoError {
+    // See the comments in Write() for the details of this format.
+    if (this.closed) {
+      return null
+    }
+    this.closed = true
+    if (this.used === 0) {
+      return null
+    }
+    const h = this.buf
+    h[0] = 0x20
+    h[1] = 0x20
+    h[2] = 0x20
+    h[3] = 0x20
+    h[4] = 0x7c
+    const nBytes = this.used
+    while (this.used < 16) {
+      let l = 3
+      if (this.used === 7) {
+        l = 4
+      } else if (this.used === 15) {
+        l = 5
+      }
+      const [, err] = this.w!.Write(h.slice(0, l))
+      if (err !== null) {
+        return err
+      }
+      this.used++
+    }
+    this.rightChars[nBytes] = 0x7c
+    this.rightChars[nBytes + 1] = 0x0a
+    const [, err] = this.w!.Write(this.rightChars.slice(0, nBytes + 2))
+    return err
+  }
+}
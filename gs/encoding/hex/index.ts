@@ -0,0 +1,17 @@
+export {
+  AppendDecode,
+  AppendEncode,
+  Decode,
+  DecodeString,
+  DecodedLen,
+  Dump,
+  Dumper,
+  Encode,
+  EncodeToString,
+  EncodedLen,
+  ErrLength,
+  InvalidByteError,
+  InvalidByteError_Error,
+  NewDecoder,
+  NewEncoder,
+} from './hex.js'
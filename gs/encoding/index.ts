@@ -0,0 +1,129 @@
+// Package encoding defines interfaces shared by other packages that convert
+// data to and from byte-level and textual representations.
+
+import * as $ from '@goscript/builtin/index.js'
+
+const bytesType: $.TypeInfo = {
+  kind: $.TypeKind.Slice,
+  elemType: { kind: $.TypeKind.Basic, name: 'number' },
+}
+
+const errorType: $.TypeInfo = {
+  kind: $.TypeKind.Interface,
+  name: 'GoError',
+  methods: [
+    {
+      name: 'Error',
+      args: [],
+      returns: [{ type: { kind: $.TypeKind.Basic, name: 'string' } }],
+    },
+  ],
+}
+
+// BinaryMarshaler is the interface implemented by an object that can
+// marshal itself into a binary form.
+export type BinaryMarshaler = null | {
+  MarshalBinary(): [$.Bytes, $.GoError]
+}
+
+$.registerInterfaceType(
+  'encoding.BinaryMarshaler',
+  null, // Zero value for interface is null
+  [
+    {
+      name: 'MarshalBinary',
+      args: [],
+      returns: [{ type: bytesType }, { type: errorType }],
+    },
+  ],
+)
+
+// BinaryUnmarshaler is the interface implemented by an object that can
+// unmarshal a binary representation of itself.
+export type BinaryUnmarshaler = null | {
+  UnmarshalBinary(data: $.Bytes): $.GoError
+}
+
+$.registerInterfaceType(
+  'encoding.BinaryUnmarshaler',
+  null, // Zero value for interface is null
+  [
+    {
+      name: 'UnmarshalBinary',
+      args: [{ name: 'data', type: bytesType }],
+      returns: [{ type: errorType }],
+    },
+  ],
+)
+
+// BinaryAppender is the interface implemented by an object that can append
+// the binary representation of itself.
+export type BinaryAppender = null | {
+  AppendBinary(b: $.Bytes): [$.Bytes, $.GoError]
+}
+
+$.registerInterfaceType(
+  'encoding.BinaryAppender',
+  null, // Zero value for interface is null
+  [
+    {
+      name: 'AppendBinary',
+      args: [{ name: 'b', type: bytesType }],
+      returns: [{ type: bytesType }, { type: errorType }],
+    },
+  ],
+)
+
+// TextMarshaler is the interface implemented by an object that can marshal
+// itself into a textual form.
+export type TextMarshaler = null | {
+  MarshalText(): [$.Bytes, $.GoError]
+}
+
+$.registerInterfaceType(
+  'encoding.TextMarshaler',
+  null, // Zero value for interface is null
+  [
+    {
+      name: 'MarshalText',
+      args: [],
+      returns: [{ type: bytesType }, { type: errorType }],
+    },
+  ],
+)
+
+// TextUnmarshaler is the interface implemented by an object that can
+// unmarshal a textual representation of itself.
+export type TextUnmarshaler = null | {
+  UnmarshalText(text: $.Bytes): $.GoError
+}
+
+$.registerInterfaceType(
+  'encoding.TextUnmarshaler',
+  null, // Zero value for interface is null
+  [
+    {
+      name: 'UnmarshalText',
+      args: [{ name: 'text', type: bytesType }],
+      returns: [{ type: errorType }],
+    },
+  ],
+)
+
+// TextAppender is the interface implemented by an object that can append
+// the textual representation of itself.
+export type TextAppender = null | {
+  AppendText(b: $.Bytes): [$.Bytes, $.GoError]
+}
+
+$.registerInterfaceType(
+  'encoding.TextAppender',
+  null, // Zero value for interface is null
+  [
+    {
+      name: 'AppendText',
+      args: [{ name: 'b', type: bytesType }],
+      returns: [{ type: bytesType }, { type: errorType }],
+    },
+  ],
+)
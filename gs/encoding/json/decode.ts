@@ -0,0 +1,937 @@
+import * as $ from '@goscript/builtin/index.js'
+import * as reflect from '@goscript/reflect/index.js'
+import * as strconv from '@goscript/strconv/index.js'
+import { isValidNumber, node, parse, parser, SyntaxError } from './scanner.js'
+import {
+  isNamed,
+  numberType,
+  rawMessageType,
+  lookupField,
+  resolveType,
+  structInfo,
+  structTypeOf,
+  typeFields,
+} from './fields.js'
+
+// Unmarshal parses the JSON-encoded data and stores the result
+// in the value pointed to by v. If v is nil or not a pointer,
+// Unmarshal returns an InvalidUnmarshalError.
+//
+// Struct fields are matched to object keys using the type information
+// registered for the struct, preferring an exact match of the field name
+// or tag but also accepting a case-insensitive match. If a JSON value is
+// not appropriate for a given target type, Unmarshal skips that field and
+// completes the unmarshaling as best it can, returning the first such
+// UnmarshalTypeError.
+export function Unmarshal(data: $.Bytes, v: any): $.GoError {
+  // Check for well-formedness.
+  // Avoids filling out half a data structure
+  // before discovering a JSON syntax error.
+  const [text, n, err] = parse(data)
+  if (err !== null) {
+    return err
+  }
+  return unmarshalNode(text, n!, v, {})
+}
+
+// decodeOptions are the options set on a Decoder.
+export interface decodeOptions {
+  useNumber?: boolean
+  disallowUnknownFields?: boolean
+}
+
+// unmarshalNode stores the parsed JSON value n in the value pointed to by v.
+export function unmarshalNode(
+  text: string,
+  n: node,
+  v: any,
+  opts: decodeOptions,
+): $.GoError {
+  if (v === null || v === undefined) {
+    return new InvalidUnmarshalError()
+  }
+  const d = new decodeState(text, opts)
+  try {
+    if ($.isVarRef(v)) {
+      v.value = d.value(n, undefined, v.value)
+    } else if (typeof v === 'object' && !(v instanceof Map) && !Array.isArray(v)) {
+      // Pointers to structs are the struct values themselves.
+      const info = structTypeOf(v)
+      d.rootStruct = $.splitTypeName(info?.name ?? '')[1]
+      d.value(n, info, v)
+    } else {
+      return new InvalidUnmarshalError({ Type: reflect.TypeOf(v) })
+    }
+  } catch (err) {
+    if (err instanceof decodeError) {
+      return err.err
+    }
+    throw err
+  }
+  return d.savedError
+}
+
+// Unmarshaler is the interface implemented by types
+// that can unmarshal a JSON description of themselves.
+// The input can be assumed to be a valid encoding of
+// a JSON value. UnmarshalJSON must copy the JSON data
+// if it wishes to retain the data after returning.
+export type Unmarshaler = null | {
+  UnmarshalJSON(data: $.Bytes): $.GoError
+}
+
+$.registerInterfaceType(
+  'encoding/json.Unmarshaler',
+  null, // Zero value for interface is null
+  [
+    {
+      name: 'UnmarshalJSON',
+      args: [
+        {
+          name: 'data',
+          type: {
+            kind: $.TypeKind.Slice,
+            elemType: { kind: $.TypeKind.Basic, name: 'number' },
+          },
+        },
+      ],
+      returns: [
+        {
+          type: {
+            kind: $.TypeKind.Interface,
+            name: 'GoError',
+            methods: [
+              {
+                name: 'Error',
+                args: [],
+                returns: [{ type: { kind: $.TypeKind.Basic, name: 'string' } }],
+              },
+            ],
+          },
+        },
+      ],
+    },
+  ],
+)
+
+// An UnmarshalTypeError describes a JSON value that was
+// not appropriate for a value of a specific Go type.
+export class UnmarshalTypeError {
+  // description of JSON value - "bool", "array", "number -5"
+  public Value: string = ''
+  // type of Go value it could not be assigned to
+  public Type: reflect.Type | null = null
+  // error occurred after reading Offset bytes
+  public Offset: number = 0
+  // name of the root type containing the field
+  public Struct: string = ''
+  // the full path from root node to the value
+  public Field: string = ''
+  // may be nil
+  public Err: $.GoError = null
+
+  constructor(
+    init?: Partial<{
+      Value?: string
+      Type?: reflect.Type | null
+      Offset?: number
+      Struct?: string
+      Field?: string
+      Err?: $.GoError
+    }>,
+  ) {
+    if (init) {
+      Object.assign(this, init)
+    }
+  }
+
+  public clone(): UnmarshalTypeError {
+    return new UnmarshalTypeError({
+      Value: this.Value,
+      Type: this.Type,
+      Offset: this.Offset,
+      Struct: this.Struct,
+      Field: this.Field,
+      Err: this.Err,
+    })
+  }
+
+  public Error(): string {
+    let s: string
+    if (this.Struct !== '' || this.Field !== '') {
+      // The design of UnmarshalTypeError overly assumes a struct-based
+      // Go representation for JSON objects.
+      // A JSON array index is not a struct field name.
+      const last = this.Field.slice(this.Field.lastIndexOf('.') + 1)
+      const intoWhat = /^[0-9]+$/.test(last) ? '' : 'Go struct field '
+      s =
+        'json: cannot unmarshal ' +
+        this.Value +
+        ' into ' +
+        intoWhat +
+        this.Struct +
+        '.' +
+        this.Field +
+        ' of type ' +
+        this.Type!.String()
+    } else {
+      s =
+        'json: cannot unmarshal ' +
+        this.Value +
+        ' into Go value of type ' +
+        this.Type!.String()
+    }
+    if (this.Err !== null) {
+      s += ': ' + this.Err.Error()
+    }
+    return s
+  }
+
+  // Unwrap returns the underlying error.
+  public Unwrap(): $.GoError {
+    return this.Err
+  }
+
+  // Register this type with the runtime type system
+  static __typeInfo = $.registerStructType(
+    'encoding/json.UnmarshalTypeError',
+    new UnmarshalTypeError(),
+    [
+      {
+        name: 'Error',
+        args: [],
+        returns: [{ type: { kind: $.TypeKind.Basic, name: 'string' } }],
+      },
+      {
+        name: 'Unwrap',
+        args: [],
+        returns: [{ type: 'error' }],
+      },
+    ],
+    UnmarshalTypeError,
+    [
+      { name: 'Value', type: { kind: $.TypeKind.Basic, name: 'string' } },
+      { name: 'Type', type: 'reflect.Type' },
+      { name: 'Offset', type: { kind: $.TypeKind.Basic, name: 'int64' } },
+      { name: 'Struct', type: { kind: $.TypeKind.Basic, name: 'string' } },
+      { name: 'Field', type: { kind: $.TypeKind.Basic, name: 'string' } },
+      { name: 'Err', type: 'error' },
+    ],
+  )
+}
+
+// An InvalidUnmarshalError describes an invalid argument passed to Unmarshal.
+// (The argument to Unmarshal must be a non-nil pointer.)
+export class InvalidUnmarshalError {
+  public Type: reflect.Type | null = null
+
+  constructor(init?: Partial<{ Type?: reflect.Type | null }>) {
+    if (init) {
+      Object.assign(this, init)
+    }
+  }
+
+  public clone(): InvalidUnmarshalError {
+    return new InvalidUnmarshalError({ Type: this.Type })
+  }
+
+  public Error(): string {
+    if (this.Type === null) {
+      return 'json: Unmarshal(nil)'
+    }
+    if (this.Type.Kind() !== reflect.Ptr) {
+      return 'json: Unmarshal(non-pointer ' + this.Type.String() + ')'
+    }
+    return 'json: Unmarshal(nil ' + this.Type.String() + ')'
+  }
+
+  // Register this type with the runtime type system
+  static __typeInfo = $.registerStructType(
+    'encoding/json.InvalidUnmarshalError',
+    new InvalidUnmarshalError(),
+    [
+      {
+        name: 'Error',
+        args: [],
+        returns: [{ type: { kind: $.TypeKind.Basic, name: 'string' } }],
+      },
+    ],
+    InvalidUnmarshalError,
+    [{ name: 'Type', type: 'reflect.Type' }],
+  )
+}
+
+// A Number represents a JSON number literal.
+export type Number = string
+
+$.registerNamedType(
+  numberType,
+  '',
+  [
+    {
+      name: 'Float64',
+      args: [],
+      returns: [{ type: 'float64' }, { type: 'error' }],
+    },
+    {
+      name: 'Int64',
+      args: [],
+      returns: [{ type: 'int64' }, { type: 'error' }],
+    },
+    { name: 'String', args: [], returns: [{ type: 'string' }] },
+  ],
+  { kind: $.TypeKind.Basic, name: 'string' },
+)
+
+// Number_String returns the literal text of the number.
+export function Number_String(n: Number): string {
+  return n
+}
+
+// Number_Float64 returns the number as a float64.
+export function Number_Float64(n: Number): [number, $.GoError] {
+  return strconv.ParseFloat(n, 64)
+}
+
+// Number_Int64 returns the number as an int64.
+export function Number_Int64(n: Number): [any, $.GoError] {
+  return strconv.ParseInt(n, 10, 64)
+}
+
+// RawMessage is a raw encoded JSON value.
+// It implements Marshaler and Unmarshaler and can
+// be used to delay JSON decoding or precompute a JSON encoding.
+export type RawMessage = $.Bytes
+
+$.registerNamedType(
+  rawMessageType,
+  null,
+  [],
+  {
+    kind: $.TypeKind.Slice,
+    elemType: { kind: $.TypeKind.Basic, name: 'uint8' },
+  },
+)
+
+// RawMessage_MarshalJSON returns m as the JSON encoding of m.
+export function RawMessage_MarshalJSON(m: RawMessage): [$.Bytes, $.GoError] {
+  if (m === null || $.len(m) === 0) {
+    return [$.stringToBytes('null'), null]
+  }
+  return [m, null]
+}
+
+// RawMessage_UnmarshalJSON sets *m to a copy of data.
+export function RawMessage_UnmarshalJSON(
+  m: $.VarRef<RawMessage> | null,
+  data: $.Bytes,
+): $.GoError {
+  if (m === null) {
+    return $.newError('json.RawMessage: UnmarshalJSON on nil pointer')
+  }
+  m.value = Uint8Array.from($.asArray(data as $.Slice<number>))
+  return null
+}
+
+// decodeError carries an error that stops decoding out of the recursive
+// decoder.
+class decodeError {
+  constructor(public readonly err: $.GoError) {}
+}
+
+// intRanges are the ranges of the integer kinds.
+const intRanges: Record<string, [bigint, bigint]> = {
+  int: [-(1n << 63n), (1n << 63n) - 1n],
+  int8: [-(1n << 7n), (1n << 7n) - 1n],
+  int16: [-(1n << 15n), (1n << 15n) - 1n],
+  int32: [-(1n << 31n), (1n << 31n) - 1n],
+  rune: [-(1n << 31n), (1n << 31n) - 1n],
+  int64: [-(1n << 63n), (1n << 63n) - 1n],
+  uint: [0n, (1n << 64n) - 1n],
+  uint8: [0n, (1n << 8n) - 1n],
+  byte: [0n, (1n << 8n) - 1n],
+  uint16: [0n, (1n << 16n) - 1n],
+  uint32: [0n, (1n << 32n) - 1n],
+  uint64: [0n, (1n << 64n) - 1n],
+  uintptr: [0n, (1n << 64n) - 1n],
+}
+
+// base64Decode decodes standard base64 text, returning the error of Go's
+// base64.StdEncoding for corrupt input.
+export function base64Decode(s: string): [Uint8Array, $.GoError] {
+  s = s.replace(/[\r\n]/g, '')
+  const bad = s.search(/[^A-Za-z0-9+/=]|=[^=]|=$(?<!^(?:.{4})*(?:..==|...=))/)
+  if (bad >= 0 || s.length % 4 !== 0) {
+    return [
+      new Uint8Array(0),
+      $.newError(`illegal base64 data at input byte ${Math.max(bad, 0)}`),
+    ]
+  }
+  const binary = atob(s)
+  const b = new Uint8Array(binary.length)
+  for (let i = 0; i < binary.length; i++) {
+    b[i] = binary.charCodeAt(i)
+  }
+  return [b, null]
+}
+
+// target describes the Go type a JSON value is decoded into.
+type target =
+  | 'interface'
+  | 'struct'
+  | 'map'
+  | 'slice'
+  | 'bytes'
+  | 'array'
+  | 'bool'
+  | 'string'
+  | 'number'
+  | 'int'
+  | 'float'
+  | 'invalid'
+
+// decodeState decodes parsed JSON values into Go values.
+class decodeState {
+  // name of the struct Unmarshal decodes into, for errors
+  public rootStruct = ''
+  // first type error, decoding continues after it
+  public savedError: $.GoError = null
+  // path of object keys and array indices to the current value
+  private readonly path: string[] = []
+
+  constructor(
+    private readonly text: string,
+    private readonly opts: decodeOptions,
+  ) {}
+
+  private fail(err: $.GoError): never {
+    throw new decodeError(err)
+  }
+
+  // typeOf returns the reflect type of a target, for errors.
+  private typeOf(t: $.TypeInfo | string | undefined, cur: any): reflect.Type {
+    if (t === undefined && cur !== null && cur !== undefined) {
+      return reflect.TypeOf(cur)
+    }
+    return reflect.typeFromTypeInfo(t)
+  }
+
+  // typeError records an UnmarshalTypeError if none was recorded yet.
+  private typeError(
+    what: string,
+    n: node,
+    t: $.TypeInfo | string | undefined,
+    cur: any,
+    err: $.GoError = null,
+  ): void {
+    if (this.savedError !== null) {
+      return
+    }
+    this.savedError = new UnmarshalTypeError({
+      Value: what,
+      Type: this.typeOf(t, cur),
+      Offset: n.end,
+      Struct: this.path.length > 0 ? this.rootStruct : '',
+      Field: this.path.join('.'),
+      Err: err,
+    })
+  }
+
+  // describe returns the description of a JSON value for type errors.
+  private describe(n: node): string {
+    switch (n.kind) {
+      case 'literal':
+        return n.value === null ? 'null' : 'bool'
+      default:
+        return n.kind
+    }
+  }
+
+  // kind returns the kind of target of the type t, falling back to the
+  // current value for types without registered information.
+  private kind(t: $.TypeInfo | string | undefined, cur: any): target {
+    if (isNamed(t, numberType) && (typeof cur === 'string' || cur == null)) {
+      return 'number'
+    }
+    const info = resolveType(t)
+    if (info === undefined) {
+      if (t !== undefined && typeof t === 'string' && t in intRanges) {
+        return 'int'
+      }
+      return this.kindOfValue(cur)
+    }
+    switch (info.kind) {
+      case $.TypeKind.Basic: {
+        const name = $.basicTypeName(info)
+        switch (name) {
+          case 'bool':
+          case 'boolean':
+            return 'bool'
+          case 'string':
+            return 'string'
+          case 'float32':
+          case 'float64':
+          case 'number':
+            return 'float'
+          case 'bigint':
+            return 'int'
+          case 'any':
+            return 'interface'
+        }
+        return name !== undefined && name in intRanges ?
+            'int'
+          : this.kindOfValue(cur)
+      }
+      case $.TypeKind.Interface:
+        return 'interface'
+      case $.TypeKind.Struct:
+        return 'struct'
+      case $.TypeKind.Map:
+        return 'map'
+      case $.TypeKind.Slice:
+        return cur instanceof Uint8Array ? 'bytes' : 'slice'
+      case $.TypeKind.Array:
+        return cur instanceof Uint8Array ? 'bytes' : 'array'
+    }
+    return 'invalid'
+  }
+
+  // kindOfValue returns the kind of target of a value of unknown type.
+  private kindOfValue(cur: any): target {
+    if (cur === null || cur === undefined) {
+      return 'interface'
+    }
+    switch (typeof cur) {
+      case 'boolean':
+        return 'bool'
+      case 'string':
+        return 'string'
+      case 'number':
+        return 'float'
+      case 'bigint':
+        return 'int'
+    }
+    if (cur instanceof Uint8Array) {
+      return 'bytes'
+    }
+    if (cur instanceof Map) {
+      return 'map'
+    }
+    if (Array.isArray(cur) || $.isSliceProxy(cur)) {
+      return 'slice'
+    }
+    return 'struct'
+  }
+
+  // zero returns the zero value of type t.
+  private zero(t: $.TypeInfo | string | undefined): any {
+    const info = resolveType(t)
+    if (info === undefined) {
+      return null
+    }
+    switch (info.kind) {
+      case $.TypeKind.Struct:
+        return info.ctor ? new info.ctor() : null
+      case $.TypeKind.Array:
+        return Array.from({ length: info.length }, () => this.zero(info.elemType))
+      case $.TypeKind.Basic:
+        switch ($.basicTypeName(info)) {
+          case 'bool':
+          case 'boolean':
+            return false
+          case 'string':
+            return ''
+          case 'bigint':
+            return 0n
+          case 'int64':
+          case 'uint64':
+            return $.isBigInt64Mode() ? 0n : 0
+        }
+        return info.name !== undefined && info.name !== 'any' ? 0 : null
+    }
+    return null
+  }
+
+  // value decodes the JSON value n into a Go value of type t, whose current
+  // value is cur, and returns the new value. Structs are decoded in place.
+  public value(
+    n: node,
+    t: $.TypeInfo | string | undefined,
+    cur: any,
+    quoted: boolean = false,
+  ): any {
+    const info = resolveType(t)
+    const isNull = n.kind === 'literal' && n.value === null
+
+    // Pointers to structs are the struct values themselves, other pointers
+    // are variable references.
+    if (info?.kind === $.TypeKind.Pointer) {
+      if (isNull) {
+        return null
+      }
+      const elem = resolveType(info.elemType)
+      if (elem?.kind === $.TypeKind.Struct) {
+        return this.value(n, elem, cur ?? this.zero(elem))
+      }
+      const ref = cur ?? $.varRef(this.zero(info.elemType))
+      ref.value = this.value(n, info.elemType, ref.value, quoted)
+      return ref
+    }
+
+    // Types that decode themselves.
+    if (cur !== null && typeof cur === 'object') {
+      if (typeof cur.UnmarshalJSON === 'function') {
+        const err = cur.UnmarshalJSON(
+          $.stringToBytes(this.text.slice(n.start, n.end)),
+        )
+        if (err !== null) {
+          this.fail(err)
+        }
+        return cur
+      }
+      if (n.kind === 'string' && typeof cur.UnmarshalText === 'function') {
+        const err = cur.UnmarshalText($.stringToBytes(n.value))
+        if (err !== null) {
+          this.fail(err)
+        }
+        return cur
+      }
+    }
+    if (
+      isNamed(t, rawMessageType) &&
+      (cur === null || cur instanceof Uint8Array || Array.isArray(cur))
+    ) {
+      return $.stringToBytes(this.text.slice(n.start, n.end))
+    }
+
+    const kind = this.kind(t, cur)
+    if (quoted && !isNull && kind !== 'interface') {
+      return this.quotedValue(n, t, cur, kind)
+    }
+
+    switch (n.kind) {
+      case 'object':
+        return this.object(n, t, cur, kind)
+      case 'array':
+        return this.array(n, t, cur, kind)
+      case 'literal':
+        if (n.value === null) {
+          switch (kind) {
+            case 'interface':
+            case 'map':
+            case 'slice':
+              return null
+            case 'bytes':
+              return new Uint8Array(0)
+          }
+          // Otherwise, ignore null for primitives/string/structs.
+          return cur
+        }
+        if (kind === 'bool') {
+          return n.value
+        }
+        if (kind === 'interface') {
+          return this.generic(n)
+        }
+        this.typeError('bool', n, t, cur)
+        return cur
+      case 'string':
+        switch (kind) {
+          case 'string':
+            return n.value
+          case 'interface':
+            return n.value
+          case 'number':
+            if (!isValidNumber(n.value)) {
+              const literal = JSON.stringify(JSON.stringify(n.value))
+              this.fail(
+                $.newError(
+                  `json: invalid number literal, trying to unmarshal ${literal} into Number`,
+                ),
+              )
+            }
+            return n.value
+          case 'bytes': {
+            const [b, err] = base64Decode(n.value)
+            if (err !== null) {
+              this.typeError('string', n, t, cur, err)
+              return cur
+            }
+            return b
+          }
+        }
+        this.typeError('string', n, t, cur)
+        return cur
+      case 'number':
+        return this.number(n, n.text, t, cur, kind)
+    }
+  }
+
+  // number converts the number literal s to the target.
+  private number(
+    n: node,
+    s: string,
+    t: $.TypeInfo | string | undefined,
+    cur: any,
+    kind: target,
+  ): any {
+    switch (kind) {
+      case 'interface':
+        return this.opts.useNumber ? s : Number(s)
+      case 'number':
+        return s
+      case 'float': {
+        const info = resolveType(t)
+        const f = Number(s)
+        const bits32 = info !== undefined && $.basicTypeName(info) === 'float32'
+        if (!Number.isFinite(f) || (bits32 && !Number.isFinite(Math.fround(f)))) {
+          this.typeError('number ' + s, n, t, cur)
+          return cur
+        }
+        return bits32 ? Math.fround(f) : f
+      }
+      case 'int': {
+        const info = resolveType(t)
+        const name =
+          (info && $.basicTypeName(info)) ?? (typeof t === 'string' ? t : 'int')
+        const range = intRanges[name] ?? intRanges.int64
+        if (!/^-?[0-9]+$/.test(s) || BigInt(s) < range[0] || BigInt(s) > range[1]) {
+          this.typeError('number ' + s, n, t, cur)
+          return cur
+        }
+        const bigint =
+          typeof cur === 'bigint' ||
+          name === 'bigint' ||
+          ($.isBigInt64Mode() && (name === 'int64' || name === 'uint64'))
+        return bigint ? BigInt(s) : Number(s)
+      }
+    }
+    this.typeError('number', n, t, cur)
+    return cur
+  }
+
+  // quotedValue decodes a value of a field with the ",string" option,
+  // whose JSON value is a string holding the JSON encoding of the value.
+  private quotedValue(
+    n: node,
+    t: $.TypeInfo | string | undefined,
+    cur: any,
+    kind: target,
+  ): any {
+    if (kind !== 'string' && kind !== 'bool' && kind !== 'int' && kind !== 'float') {
+      return this.value(n, t, cur)
+    }
+    if (n.kind !== 'string') {
+      this.typeError(this.describe(n), n, t, cur)
+      return cur
+    }
+    if (kind !== 'string') {
+      const s = n.value
+      if (kind === 'bool') {
+        if (s === 'true' || s === 'false') {
+          return s === 'true'
+        }
+      } else if (isValidNumber(s)) {
+        return this.number(n, s, t, cur, kind)
+      } else if (s === 'null') {
+        return cur
+      }
+      this.typeError((kind === 'bool' ? 'bool ' : 'number ') + s, n, t, cur)
+      return cur
+    }
+    try {
+      const inner = new parser(n.value).parseTopLevel()
+      if (inner.kind === 'string') {
+        return inner.value
+      }
+      if (inner.kind === 'literal' && inner.value === null) {
+        return cur
+      }
+      this.typeError('string', n, t, cur)
+    } catch (err) {
+      if (!(err instanceof SyntaxError)) {
+        throw err
+      }
+      this.typeError('string', n, t, cur, err)
+    }
+    return cur
+  }
+
+  private object(
+    n: node & { kind: 'object' },
+    t: $.TypeInfo | string | undefined,
+    cur: any,
+    kind: target,
+  ): any {
+    switch (kind) {
+      case 'interface':
+        return this.generic(n)
+      case 'map':
+        return this.map(n, resolveType(t) as $.MapTypeInfo | undefined, cur)
+      case 'struct':
+        break
+      default:
+        this.typeError('object', n, t, cur)
+        return cur
+    }
+
+    const info = structTypeOf(cur) ?? (resolveType(t) as $.StructTypeInfo)
+    if (cur === null || cur === undefined) {
+      cur = this.zero(info)
+      if (cur === null) {
+        this.typeError('object', n, t, cur)
+        return cur
+      }
+    }
+    if (structTypeOf(cur) === undefined) {
+      // Anonymous structs have no type information, decode into the
+      // properties they already have.
+      for (const m of n.members) {
+        const key = Object.keys(cur).find(
+          (k) => k === m.key || k.toLowerCase() === m.key.toLowerCase(),
+        )
+        if (key === undefined) {
+          this.unknownField(m.key)
+          continue
+        }
+        this.path.push(m.key)
+        cur[key] = this.value(m.value, undefined, cur[key])
+        this.path.pop()
+      }
+      return cur
+    }
+
+    const fields = typeFields(info)
+    for (const m of n.members) {
+      const f = lookupField(fields, m.key)
+      if (f === undefined) {
+        this.unknownField(m.key)
+        continue
+      }
+      // Find the struct holding the field, allocating nil embedded
+      // pointers on the way.
+      let holder = cur
+      for (const name of f.path.slice(0, -1)) {
+        if (holder[name] === null) {
+          const sf = $.structFields(structTypeOf(holder)!.fields).find(
+            (sf) => sf.name === name,
+          )!
+          holder[name] = this.zero(structInfo(sf.type))
+        }
+        holder = holder[name]
+      }
+      const name = f.path[f.path.length - 1]
+      this.path.push(f.name)
+      holder[name] = this.value(m.value, f.type, holder[name], f.quoted)
+      this.path.pop()
+    }
+    return cur
+  }
+
+  // unknownField handles an object key without a matching field.
+  private unknownField(key: string): void {
+    if (this.opts.disallowUnknownFields) {
+      this.fail($.newError(`json: unknown field ${JSON.stringify(key)}`))
+    }
+  }
+
+  private map(
+    n: node & { kind: 'object' },
+    info: $.MapTypeInfo | undefined,
+    cur: Map<any, any> | null,
+  ): Map<any, any> {
+    const m = cur ?? new Map<any, any>()
+    const keyInfo = resolveType(info?.keyType)
+    for (const member of n.members) {
+      this.path.push(member.key)
+      let key: any = member.key
+      if (keyInfo?.kind === $.TypeKind.Basic &&
+        $.basicTypeName(keyInfo) !== 'string') {
+        // Integer keys are encoded as strings.
+        key = this.number(n, member.key, keyInfo, undefined, 'int')
+        if (key === undefined) {
+          this.path.pop()
+          continue
+        }
+      } else if (keyInfo?.kind === $.TypeKind.Struct && keyInfo.ctor) {
+        const k = new keyInfo.ctor()
+        if (typeof k.UnmarshalText === 'function') {
+          const err = k.UnmarshalText($.stringToBytes(member.key))
+          if (err !== null) {
+            this.fail(err)
+          }
+          key = k
+        }
+      }
+      const elemType = info?.elemType
+      m.set(key, this.value(member.value, elemType, this.zero(elemType)))
+      this.path.pop()
+    }
+    return m
+  }
+
+  private array(
+    n: node & { kind: 'array' },
+    t: $.TypeInfo | string | undefined,
+    cur: any,
+    kind: target,
+  ): any {
+    const info = resolveType(t) as
+      | $.SliceTypeInfo
+      | $.ArrayTypeInfo
+      | undefined
+    switch (kind) {
+      case 'interface':
+        return this.generic(n)
+      case 'slice':
+      case 'bytes': {
+        const elems = n.elems.map((e, i) => {
+          this.path.push(String(i))
+          const v = this.value(e, info?.elemType, this.zero(info?.elemType))
+          this.path.pop()
+          return v
+        })
+        return kind === 'bytes' ? Uint8Array.from(elems) : elems
+      }
+      case 'array': {
+        const length = (info as $.ArrayTypeInfo).length
+        const arr: any[] = Array.isArray(cur) ? cur : this.zero(info)
+        for (let i = 0; i < length; i++) {
+          if (i < n.elems.length) {
+            this.path.push(String(i))
+            arr[i] = this.value(n.elems[i], info?.elemType, arr[i])
+            this.path.pop()
+          } else {
+            arr[i] = this.zero(info?.elemType)
+          }
+        }
+        return arr
+      }
+    }
+    this.typeError('array', n, t, cur)
+    return cur
+  }
+
+  // generic decodes a JSON value into an interface value: map[string]any
+  // for objects, []any for arrays, float64 (or Number) for numbers, and
+  // string, bool or nil for the others.
+  private generic(n: node): any {
+    switch (n.kind) {
+      case 'object': {
+        const m = new Map<string, any>()
+        for (const member of n.members) {
+          m.set(member.key, this.generic(member.value))
+        }
+        return m
+      }
+      case 'array':
+        return n.elems.map((e) => this.generic(e))
+      case 'string':
+        return n.value
+      case 'number':
+        return this.opts.useNumber ? n.text : Number(n.text)
+      case 'literal':
+        return n.value
+    }
+  }
+}
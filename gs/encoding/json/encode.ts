@@ -0,0 +1,666 @@
+import * as $ from '@goscript/builtin/index.js'
+import * as reflect from '@goscript/reflect/index.js'
+import {
+  compact,
+  indent,
+  isValidNumber,
+  parse,
+  quoteString,
+} from './scanner.js'
+import {
+  isNamed,
+  method,
+  numberType,
+  rawMessageType,
+  resolveType,
+  structTypeOf,
+  typeFields,
+} from './fields.js'
+
+// Marshal returns the JSON encoding of v.
+//
+// Struct values encode as JSON objects. Each exported struct field becomes
+// a member of the object, using the field name as the object key, unless
+// the field is omitted for one of the reasons given by the "json" key in
+// the struct field's tag. The type information registered for the struct
+// drives the encoding, so tags, omitempty, omitzero and embedded structs
+// behave as in Go.
+//
+// Values of named non-struct types, such as \`type Level int\`, do not carry
+// their methods, so their MarshalJSON and MarshalText methods are found
+// through the static type of the struct field, slice element or map key
+// holding them. They are not found for such values passed to Marshal
+// directly or held in an interface.
+export function Marshal(v: any): [$.Bytes, $.GoError] {
+  const [text, err] = marshal(v, true)
+  if (err !== null) {
+    return [null, err]
+  }
+  return [$.stringToBytes(text), null]
+}
+
+// MarshalIndent is like Marshal but applies Indent to format the output.
+// Each JSON element in the output will begin on a new line beginning with
+// prefix followed by one or more copies of indent according to the
+// indentation nesting.
+export function MarshalIndent(
+  v: any,
+  prefix: string,
+  indentation: string,
+): [$.Bytes, $.GoError] {
+  const [text, err] = marshal(v, true)
+  if (err !== null) {
+    return [null, err]
+  }
+  return [$.stringToBytes(indentText(text, prefix, indentation)), null]
+}
+
+// marshal returns the JSON text of v.
+export function marshal(v: any, escapeHTML: boolean): [string, $.GoError] {
+  const e = new encodeState(escapeHTML)
+  try {
+    e.value(v, undefined)
+  } catch (err) {
+    if (err instanceof encodeError) {
+      return ['', err.err]
+    }
+    throw err
+  }
+  return [e.text, null]
+}
+
+// indentText indents valid JSON text.
+export function indentText(
+  text: string,
+  prefix: string,
+  indentation: string,
+): string {
+  const [, n] = parse($.stringToBytes(text))
+  return indent(text, n!, prefix, indentation)
+}
+
+// Marshaler is the interface implemented by types that
+// can marshal themselves into valid JSON.
+export type Marshaler = null | {
+  MarshalJSON(): [$.Bytes, $.GoError]
+}
+
+$.registerInterfaceType(
+  'encoding/json.Marshaler',
+  null, // Zero value for interface is null
+  [
+    {
+      name: 'MarshalJSON',
+      args: [],
+      returns: [
+        {
+          type: {
+            kind: $.TypeKind.Slice,
+            elemType: { kind: $.TypeKind.Basic, name: 'number' },
+          },
+        },
+        {
+          type: {
+            kind: $.TypeKind.Interface,
+            name: 'GoError',
+            methods: [
+              {
+                name: 'Error',
+                args: [],
+                returns: [{ type: { kind: $.TypeKind.Basic, name: 'string' } }],
+              },
+            ],
+          },
+        },
+      ],
+    },
+  ],
+)
+
+// An UnsupportedTypeError is returned by Marshal when attempting
+// to encode an unsupported value type.
+export class UnsupportedTypeError {
+  public Type: reflect.Type | null = null
+
+  constructor(init?: Partial<{ Type?: reflect.Type | null }>) {
+    if (init) {
+      Object.assign(this, init)
+    }
+  }
+
+  public clone(): UnsupportedTypeError {
+    return new UnsupportedTypeError({ Type: this.Type })
+  }
+
+  public Error(): string {
+    return 'json: unsupported type: ' + this.Type!.String()
+  }
+
+  // Register this type with the runtime type system
+  static __typeInfo = $.registerStructType(
+    'encoding/json.UnsupportedTypeError',
+    new UnsupportedTypeError(),
+    [
+      {
+        name: 'Error',
+        args: [],
+        returns: [{ type: { kind: $.TypeKind.Basic, name: 'string' } }],
+      },
+    ],
+    UnsupportedTypeError,
+    [{ name: 'Type', type: 'reflect.Type' }],
+  )
+}
+
+// An UnsupportedValueError is returned by Marshal when attempting
+// to encode an unsupported value.
+export class UnsupportedValueError {
+  public Value: reflect.Value | null = null
+  public Str: string = ''
+
+  constructor(init?: Partial<{ Value?: reflect.Value | null; Str?: string }>) {
+    if (init) {
+      Object.assign(this, init)
+    }
+  }
+
+  public clone(): UnsupportedValueError {
+    return new UnsupportedValueError({ Value: this.Value, Str: this.Str })
+  }
+
+  public Error(): string {
+    return 'json: unsupported value: ' + this.Str
+  }
+
+  // Register this type with the runtime type system
+  static __typeInfo = $.registerStructType(
+    'encoding/json.UnsupportedValueError',
+    new UnsupportedValueError(),
+    [
+      {
+        name: 'Error',
+        args: [],
+        returns: [{ type: { kind: $.TypeKind.Basic, name: 'string' } }],
+      },
+    ],
+    UnsupportedValueError,
+    [
+      { name: 'Value', type: 'reflect.Value' },
+      { name: 'Str', type: { kind: $.TypeKind.Basic, name: 'string' } },
+    ],
+  )
+}
+
+// A MarshalerError represents an error from calling a
+// MarshalJSON or MarshalText method.
+export class MarshalerError {
+  public Type: reflect.Type | null = null
+  public Err: $.GoError = null
+  public sourceFunc: string = ''
+
+  constructor(
+    init?: Partial<{
+      Type?: reflect.Type | null
+      Err?: $.GoError
+      sourceFunc?: string
+    }>,
+  ) {
+    if (init) {
+      Object.assign(this, init)
+    }
+  }
+
+  public clone(): MarshalerError {
+    return new MarshalerError({
+      Type: this.Type,
+      Err: this.Err,
+      sourceFunc: this.sourceFunc,
+    })
+  }
+
+  public Error(): string {
+    const srcFunc = this.sourceFunc || 'MarshalJSON'
+    return (
+      'json: error calling ' +
+      srcFunc +
+      ' for type ' +
+      this.Type!.String() +
+      ': ' +
+      this.Err!.Error()
+    )
+  }
+
+  // Unwrap returns the underlying error.
+  public Unwrap(): $.GoError {
+    return this.Err
+  }
+
+  // Register this type with the runtime type system
+  static __typeInfo = $.registerStructType(
+    'encoding/json.MarshalerError',
+    new MarshalerError(),
+    [
+      {
+        name: 'Error',
+        args: [],
+        returns: [{ type: { kind: $.TypeKind.Basic, name: 'string' } }],
+      },
+      {
+        name: 'Unwrap',
+        args: [],
+        returns: [{ type: 'error' }],
+      },
+    ],
+    MarshalerError,
+    [
+      { name: 'Type', type: 'reflect.Type' },
+      { name: 'Err', type: 'error' },
+      { name: 'sourceFunc', type: { kind: $.TypeKind.Basic, name: 'string' } },
+    ],
+  )
+}
+
+// encodeError carries an error out of the recursive encoder.
+class encodeError {
+  constructor(public readonly err: $.GoError) {}
+}
+
+// base64Encode returns the standard base64 encoding of b.
+export function base64Encode(b: Uint8Array): string {
+  let binary = ''
+  for (let i = 0; i < b.length; i++) {
+    binary += String.fromCharCode(b[i])
+  }
+  return btoa(binary)
+}
+
+// formatFloat formats f like Go's encoding/json: the shortest decimal
+// representation that round trips to the same bits, in exponent form
+// for very small or very large magnitudes.
+function formatFloat(f: number, bits: number): string {
+  if (Object.is(f, -0)) {
+    return '-0'
+  }
+  if (bits === 32) {
+    for (let p = 1; p < 17; p++) {
+      const s = f.toPrecision(p)
+      if (Math.fround(Number(s)) === f) {
+        return String(Number(s))
+      }
+    }
+  }
+  return String(f)
+}
+
+// isEmptyValue reports whether v is empty for the omitempty option.
+function isEmptyValue(v: any, t: $.TypeInfo | string | undefined): boolean {
+  if (v === null || v === undefined) {
+    return true
+  }
+  switch (typeof v) {
+    case 'boolean':
+      return !v
+    case 'number':
+      return v === 0
+    case 'bigint':
+      return v === 0n
+    case 'string':
+      return v === ''
+  }
+  if (v instanceof Map) {
+    return v.size === 0
+  }
+  if (v instanceof Uint8Array || Array.isArray(v) || $.isSliceProxy(v)) {
+    const info = resolveType(t)
+    if (info?.kind === $.TypeKind.Array) {
+      return info.length === 0
+    }
+    return $.len(v) === 0
+  }
+  return false
+}
+
+// isZeroValue reports whether v is the zero value of its type for the
+// omitzero option.
+function isZeroValue(v: any, t: $.TypeInfo | string | undefined): boolean {
+  if (v !== null && typeof v === 'object' && typeof v.IsZero === 'function') {
+    return v.IsZero()
+  }
+  if (v === null || v === undefined) {
+    return true
+  }
+  switch (typeof v) {
+    case 'boolean':
+      return !v
+    case 'number':
+      return v === 0 && !Object.is(v, -0)
+    case 'bigint':
+      return v === 0n
+    case 'string':
+      return v === ''
+  }
+  const info = resolveType(t)
+  if (v instanceof Uint8Array) {
+    // A nil byte slice is represented by an empty array.
+    return v.length === 0 || (info?.kind === $.TypeKind.Array && v.every((b) => b === 0))
+  }
+  if (Array.isArray(v) || $.isSliceProxy(v)) {
+    if (info?.kind !== $.TypeKind.Array) {
+      return false
+    }
+    const elemType = info.elemType
+    return $.asArray(v).every((e) => isZeroValue(e, elemType))
+  }
+  const structInfo = structTypeOf(v)
+  if (structInfo !== undefined) {
+    return $.structFields(structInfo.fields).every(
+      (f) => f.name === '_' || isZeroValue(v[f.name], f.type),
+    )
+  }
+  return false
+}
+
+// encodeState encodes Go values as JSON text.
+class encodeState {
+  public text = ''
+
+  // values being encoded, to detect cycles
+  private readonly seen = new Set<object>()
+
+  constructor(private readonly escapeHTML: boolean) {}
+
+  private fail(err: $.GoError): never {
+    throw new encodeError(err)
+  }
+
+  private unsupportedType(v: any, t?: $.TypeInfo | string): never {
+    const typ = t !== undefined ? reflect.typeFromTypeInfo(t) : reflect.TypeOf(v)
+    this.fail(new UnsupportedTypeError({ Type: typ }))
+  }
+
+  // value writes the JSON encoding of v, whose static type is t when known.
+  public value(
+    v: any,
+    t: $.TypeInfo | string | undefined,
+    quoted: boolean = false,
+  ): void {
+    if (v instanceof $.NamedValue) {
+      // A value of a named type held in an interface
+      t = v.type
+      v = v.value
+    }
+    const info = resolveType(t)
+    if (info?.kind === $.TypeKind.Function || info?.kind === $.TypeKind.Channel) {
+      this.unsupportedType(v, t)
+    }
+    if (v === undefined) {
+      this.text += 'null'
+      return
+    }
+    if (this.marshaler(v, t)) {
+      return
+    }
+    if (v === null) {
+      this.text += 'null'
+      return
+    }
+
+    // Named types with their own encodings.
+    if (typeof v === 'string' && isNamed(t, numberType)) {
+      const num = v === '' ? '0' : v
+      if (!isValidNumber(num)) {
+        this.fail($.newError(`json: invalid number literal ${quoteString(num, false)}`))
+      }
+      this.text += quoted ? '"' + num + '"' : num
+      return
+    }
+    if (isRawMessage(v, t)) {
+      this.marshalerOutput($.len(v) === 0 ? 'null' : $.bytesToString(v), v)
+      return
+    }
+
+    switch (typeof v) {
+      case 'boolean': {
+        const s = v ? 'true' : 'false'
+        this.text += quoted ? '"' + s + '"' : s
+        return
+      }
+      case 'number': {
+        if (!Number.isFinite(v)) {
+          this.fail(
+            new UnsupportedValueError({
+              Value: reflect.ValueOf(v),
+              Str: Number.isNaN(v) ? 'NaN' : v > 0 ? '+Inf' : '-Inf',
+            }),
+          )
+        }
+        const bits =
+          (info && $.basicTypeName(info)) === 'float32' || t === 'float32' ?
+            32
+          : 64
+        const s = formatFloat(v, bits)
+        this.text += quoted ? '"' + s + '"' : s
+        return
+      }
+      case 'bigint':
+        this.text += quoted ? '"' + v.toString() + '"' : v.toString()
+        return
+      case 'string':
+        this.text +=
+          quoted ?
+            quoteString(quoteString(v, false), this.escapeHTML)
+          : quoteString(v, this.escapeHTML)
+        return
+      case 'function':
+        this.unsupportedType(v, t)
+    }
+
+    if (v instanceof $.Complex || typeof v.receive === 'function') {
+      this.unsupportedType(v, t)
+    }
+    if (v instanceof Uint8Array) {
+      // A nil byte slice is represented by an empty array.
+      this.text += v.length === 0 ? 'null' : '"' + base64Encode(v) + '"'
+      return
+    }
+    if ($.isVarRef(v)) {
+      const elemType = info?.kind === $.TypeKind.Pointer ? info.elemType : undefined
+      this.value(v.value, elemType, quoted)
+      return
+    }
+
+    if (this.seen.has(v)) {
+      this.fail(
+        new UnsupportedValueError({
+          Value: reflect.ValueOf(v),
+          Str: 'encountered a cycle via ' + reflect.TypeOf(v).String(),
+        }),
+      )
+    }
+    this.seen.add(v)
+    try {
+      if (v instanceof Map) {
+        this.map(v, info)
+      } else if (Array.isArray(v) || $.isSliceProxy(v)) {
+        this.array($.asArray(v), info)
+      } else {
+        this.struct(v)
+      }
+    } finally {
+      this.seen.delete(v)
+    }
+  }
+
+  // marshaler writes the JSON encoding of v produced by its MarshalJSON or
+  // MarshalText method, if it has one, and reports whether it did. Nil
+  // values only have the methods of named non-struct types, which are found
+  // through t.
+  private marshaler(v: any, t: $.TypeInfo | string | undefined): boolean {
+    const marshalJSON = method(v, t, 'MarshalJSON')
+    if (marshalJSON !== undefined) {
+      const [b, err] = marshalJSON()
+      if (err !== null) {
+        this.fail(new MarshalerError({ Type: typeOf(v, t), Err: err }))
+      }
+      this.marshalerOutput($.bytesToString(b), v, t)
+      return true
+    }
+    const marshalText = method(v, t, 'MarshalText')
+    if (marshalText !== undefined) {
+      const text = this.marshalText(v, t, marshalText)
+      this.text += quoteString(text, this.escapeHTML)
+      return true
+    }
+    return false
+  }
+
+  // marshalText returns the text produced by the MarshalText method of v.
+  private marshalText(
+    v: any,
+    t: $.TypeInfo | string | undefined,
+    marshalText: () => [$.Bytes, $.GoError],
+  ): string {
+    const [b, err] = marshalText()
+    if (err !== null) {
+      this.fail(
+        new MarshalerError({
+          Type: typeOf(v, t),
+          Err: err,
+          sourceFunc: 'MarshalText',
+        }),
+      )
+    }
+    return $.bytesToString(b)
+  }
+
+  // marshalerOutput writes JSON text produced by a marshaler, compacted.
+  private marshalerOutput(
+    text: string,
+    v: any,
+    t?: $.TypeInfo | string,
+  ): void {
+    const [, n, err] = parse($.stringToBytes(text))
+    if (err !== null) {
+      this.fail(new MarshalerError({ Type: typeOf(v, t), Err: err }))
+    }
+    this.text += compact(text, n!, this.escapeHTML)
+  }
+
+  private map(m: Map<any, any>, info: $.TypeInfo | undefined): void {
+    const keyType = info?.kind === $.TypeKind.Map ? info.keyType : undefined
+    const elemType = info?.kind === $.TypeKind.Map ? info.elemType : undefined
+    const entries: [string, any][] = []
+    for (const [k, v] of m) {
+      entries.push([this.mapKey(k, keyType), v])
+    }
+    entries.sort((a, b) => (a[0] < b[0] ? -1 : a[0] > b[0] ? 1 : 0))
+    this.text += '{'
+    entries.forEach(([k, v], i) => {
+      if (i > 0) {
+        this.text += ','
+      }
+      this.text += quoteString(k, this.escapeHTML) + ':'
+      this.value(v, elemType)
+    })
+    this.text += '}'
+  }
+
+  // mapKey returns the object key for a map key of type t.
+  private mapKey(k: any, t: $.TypeInfo | string | undefined): string {
+    const marshalText = method(k, t, 'MarshalText')
+    if (marshalText !== undefined) {
+      return this.marshalText(k, t, marshalText)
+    }
+    switch (typeof k) {
+      case 'string':
+        return k
+      case 'number':
+      case 'bigint':
+        return String(k)
+    }
+    this.unsupportedType(k)
+  }
+
+  private array(elems: any[], info: $.TypeInfo | undefined): void {
+    const elemType =
+      info?.kind === $.TypeKind.Slice || info?.kind === $.TypeKind.Array ?
+        info.elemType
+      : undefined
+    this.text += '['
+    elems.forEach((e, i) => {
+      if (i > 0) {
+        this.text += ','
+      }
+      this.value(e, elemType)
+    })
+    this.text += ']'
+  }
+
+  private struct(v: any): void {
+    const info = structTypeOf(v)
+    if (info === undefined) {
+      this.anonymousStruct(v)
+      return
+    }
+    this.text += '{'
+    let first = true
+    fields: for (const f of typeFields(info)) {
+      let fv = v
+      for (const name of f.path) {
+        if (fv === null) {
+          // The field is promoted through a nil embedded pointer.
+          continue fields
+        }
+        fv = fv[name]
+      }
+      if (f.omitEmpty && isEmptyValue(fv, f.type)) {
+        continue
+      }
+      if (f.omitZero && isZeroValue(fv, f.type)) {
+        continue
+      }
+      this.text += first ? '' : ','
+      first = false
+      this.text += quoteString(f.name, this.escapeHTML) + ':'
+      this.value(fv, f.type, f.quoted)
+    }
+    this.text += '}'
+  }
+
+  // anonymousStruct writes a value without registered type information,
+  // such as an anonymous struct, using its exported properties.
+  private anonymousStruct(v: any): void {
+    this.text += '{'
+    let first = true
+    for (const [name, fv] of Object.entries(v)) {
+      if (!/^\p{Lu}/u.test(name) || typeof fv === 'function') {
+        continue
+      }
+      this.text += first ? '' : ','
+      first = false
+      this.text += quoteString(name, this.escapeHTML) + ':'
+      this.value(fv, undefined)
+    }
+    this.text += '}'
+  }
+}
+
+// typeOf returns the type of v, which is the registered type t for named
+// non-struct types, as their values do not carry their type.
+function typeOf(v: any, t: $.TypeInfo | string | undefined): reflect.Type {
+  if (resolveType(t)?.methodFuncs !== undefined) {
+    return reflect.typeFromTypeInfo(t!)
+  }
+  return reflect.TypeOf(v)
+}
+
+// isRawMessage reports whether v is a RawMessage, which encodes as itself.
+export function isRawMessage(
+  v: any,
+  t: $.TypeInfo | string | undefined,
+): boolean {
+  return (
+    (v instanceof Uint8Array || Array.isArray(v) || $.isSliceProxy(v)) &&
+    isNamed(t, rawMessageType)
+  )
+}
@@ -0,0 +1,299 @@
+import * as $ from '@goscript/builtin/index.js'
+import { StructTag_Get } from '@goscript/reflect/index.js'
+
+// field describes a struct field encoded as a JSON object member.
+export interface field {
+  // JSON object key
+  name: string
+  // name folded to lower case, for case-insensitive matching
+  foldedName: string
+  // true if the name came from a struct tag
+  tag: boolean
+  // indices of the field in the nested structs
+  index: number[]
+  // property names leading from the outer struct to the field
+  path: string[]
+  // field type
+  type: $.TypeInfo | string
+  omitEmpty: boolean
+  omitZero: boolean
+  // the ",string" option: numbers, booleans and strings are quoted
+  quoted: boolean
+}
+
+// The qualified names of the named types encoded as their JSON text.
+export const numberType = 'encoding/json.Number'
+export const rawMessageType = 'encoding/json/jsontext.Value'
+
+// fieldCache caches the JSON fields of registered struct types.
+const fieldCache = new WeakMap<$.StructTypeInfo, field[]>()
+
+// resolveType returns the registered type information for a type name, or
+// the type information itself.
+export function resolveType(
+  t: $.TypeInfo | string | undefined,
+): $.TypeInfo | undefined {
+  if (typeof t === 'string') {
+    return $.getTypeByName(t)
+  }
+  return t
+}
+
+// method returns the method name of v bound to v, if any. The methods of
+// named non-struct types, such as \`type Level int\`, are looked up in the
+// registered type t, as their values do not carry them.
+export function method(
+  v: any,
+  t: $.TypeInfo | string | undefined,
+  name: string,
+): ((...args: any[]) => any) | undefined {
+  const fn = resolveType(t)?.methodFuncs?.[name]
+  if (fn !== undefined) {
+    return (...args) => fn(v, ...args)
+  }
+  if (v !== null && typeof v === 'object' && typeof v[name] === 'function') {
+    return (...args) => v[name](...args)
+  }
+  return undefined
+}
+
+// structInfo returns the struct type information for t, following one level
+// of unnamed pointer indirection.
+export function structInfo(
+  t: $.TypeInfo | string | undefined,
+): $.StructTypeInfo | undefined {
+  let info = resolveType(t)
+  if (info?.kind === $.TypeKind.Pointer) {
+    info = resolveType(info.elemType)
+  }
+  return info?.kind === $.TypeKind.Struct ? info : undefined
+}
+
+// structTypeOf returns the struct type information of a struct value.
+export function structTypeOf(v: any): $.StructTypeInfo | undefined {
+  if (v === null || typeof v !== 'object') {
+    return undefined
+  }
+  const info = (v.constructor as { __typeInfo?: $.TypeInfo } | undefined)
+    ?.__typeInfo
+  return info?.kind === $.TypeKind.Struct ? info : undefined
+}
+
+// isValidTag reports whether s is usable as a JSON object key in a tag.
+function isValidTag(s: string): boolean {
+  if (s === '') {
+    return false
+  }
+  for (const c of s) {
+    if ('!#$%&()*+-./:;<=>?@[]^_{|}~ '.includes(c)) {
+      // Backslash and quote chars are reserved, but
+      // otherwise any punctuation chars are allowed
+      // in a tag name.
+      continue
+    }
+    if (!/[\p{L}\p{N}]/u.test(c)) {
+      return false
+    }
+  }
+  return true
+}
+
+// compareIndex orders fields by their index sequence.
+function compareIndex(a: number[], b: number[]): number {
+  for (let i = 0; i < a.length && i < b.length; i++) {
+    if (a[i] !== b[i]) {
+      return a[i] - b[i]
+    }
+  }
+  return a.length - b.length
+}
+
+// typeFields returns a list of fields that JSON should recognize for the
+// given struct type. The algorithm is breadth-first search over the set of
+// structs to include - the top struct and then any reachable anonymous
+// structs.
+export function typeFields(info: $.StructTypeInfo): field[] {
+  const cached = fieldCache.get(info)
+  if (cached !== undefined) {
+    return cached
+  }
+
+  interface embedded {
+    info: $.StructTypeInfo
+    index: number[]
+    path: string[]
+  }
+
+  let current: embedded[] = []
+  let next: embedded[] = [{ info, index: [], path: [] }]
+
+  // Count of queued names for current level and the next.
+  let count = new Map<$.StructTypeInfo, number>()
+  let nextCount = new Map<$.StructTypeInfo, number>()
+
+  // Types already visited at an earlier level.
+  const visited = new Set<$.StructTypeInfo>()
+
+  let fields: field[] = []
+
+  while (next.length > 0) {
+    current = next
+    next = []
+    count = nextCount
+    nextCount = new Map()
+
+    for (const f of current) {
+      if (visited.has(f.info)) {
+        continue
+      }
+      visited.add(f.info)
+
+      const sfs = $.structFields(f.info.fields)
+      for (let i = 0; i < sfs.length; i++) {
+        const sf = sfs[i]
+        const embeddedInfo = sf.embedded ? structInfo(sf.type) : undefined
+        if (sf.embedded) {
+          if (!sf.exported && embeddedInfo === undefined) {
+            // Ignore embedded fields of unexported non-struct types.
+            continue
+          }
+          // Do not ignore embedded fields of unexported struct types
+          // since they may have exported fields.
+        } else if (!sf.exported) {
+          // Ignore unexported non-embedded fields.
+          continue
+        }
+        const tag = StructTag_Get(sf.tag ?? '', 'json')
+        if (tag === '-') {
+          continue
+        }
+        const comma = tag.indexOf(',')
+        let name = comma < 0 ? tag : tag.slice(0, comma)
+        const opts = comma < 0 ? [] : tag.slice(comma + 1).split(',')
+        if (!isValidTag(name)) {
+          name = ''
+        }
+        const index = [...f.index, i]
+        const path = [...f.path, sf.name]
+
+        // Record found field and index sequence.
+        if (name !== '' || embeddedInfo === undefined) {
+          const tagged = name !== ''
+          if (name === '') {
+            name = sf.name
+          }
+          const found: field = {
+            name,
+            foldedName: name.toLowerCase(),
+            tag: tagged,
+            index,
+            path,
+            type: sf.type,
+            omitEmpty: opts.includes('omitempty'),
+            omitZero: opts.includes('omitzero'),
+            quoted: opts.includes('string'),
+          }
+          fields.push(found)
+          if ((count.get(f.info) ?? 0) > 1) {
+            // If there were multiple instances, add a second,
+            // so that the annihilation code will see a duplicate.
+            // It only cares about the distinction between 1 and 2,
+            // so don't bother generating any more copies.
+            fields.push(found)
+          }
+          continue
+        }
+
+        // Record new anonymous struct to explore in next round.
+        nextCount.set(embeddedInfo, (nextCount.get(embeddedInfo) ?? 0) + 1)
+        if (nextCount.get(embeddedInfo) === 1) {
+          next.push({ info: embeddedInfo, index, path })
+        }
+      }
+    }
+  }
+
+  fields.sort((a, b) => {
+    // sort field by name, breaking ties with depth, then
+    // breaking ties with "name came from json tag", then
+    // breaking ties with index sequence.
+    if (a.name !== b.name) {
+      return a.name < b.name ? -1 : 1
+    }
+    if (a.index.length !== b.index.length) {
+      return a.index.length - b.index.length
+    }
+    if (a.tag !== b.tag) {
+      return a.tag ? -1 : 1
+    }
+    return compareIndex(a.index, b.index)
+  })
+
+  // Delete all fields that are hidden by the Go rules for embedded fields,
+  // except that fields with JSON tags are promoted.
+  const out: field[] = []
+  for (let i = 0, advance = 0; i < fields.length; i += advance) {
+    // One iteration per name.
+    // Find the sequence of fields with the name of this first field.
+    const fi = fields[i]
+    for (advance = 1; i + advance < fields.length; advance++) {
+      if (fields[i + advance].name !== fi.name) {
+        break
+      }
+    }
+    if (advance === 1) {
+      // Only one field with this name
+      out.push(fi)
+      continue
+    }
+    const dominant = dominantField(fields.slice(i, i + advance))
+    if (dominant !== undefined) {
+      out.push(dominant)
+    }
+  }
+
+  fields = out
+  fields.sort((a, b) => compareIndex(a.index, b.index))
+  fieldCache.set(info, fields)
+  return fields
+}
+
+// dominantField looks through the fields, all of which are known to
+// have the same name, to find the single field that dominates the
+// others using Go's embedding rules, modified by the presence of
+// JSON tags. If there are multiple top-level fields, it returns
+// undefined. The fields are sorted in increasing index-length order,
+// then by presence of tag.
+function dominantField(fields: field[]): field | undefined {
+  // If there are multiple top-level fields, and they are either both
+  // tagged or both untagged, then no field dominates.
+  if (
+    fields.length > 1 &&
+    fields[0].index.length === fields[1].index.length &&
+    fields[0].tag === fields[1].tag
+  ) {
+    return undefined
+  }
+  return fields[0]
+}
+
+// lookupField returns the field of a struct matching a JSON object key,
+// preferring an exact match over a case-insensitive one.
+export function lookupField(fields: field[], key: string): field | undefined {
+  const exact = fields.find((f) => f.name === key)
+  if (exact !== undefined) {
+    return exact
+  }
+  const folded = key.toLowerCase()
+  return fields.find((f) => f.foldedName === folded)
+}
+
+// isNamed reports whether t is the named type with one of the given
+// qualified names.
+export function isNamed(
+  t: $.TypeInfo | string | undefined,
+  ...names: string[]
+): boolean {
+  const name = typeof t === 'string' ? t : t?.name
+  return name !== undefined && names.includes(name)
+}
@@ -0,0 +1,45 @@
+import * as $ from '@goscript/builtin/index.js'
+import * as bytes from '@goscript/bytes/index.js'
+import { compact, htmlEscape, indent, parse } from './scanner.js'
+
+// HTMLEscape appends to dst the JSON-encoded src with <, >, &, U+2028 and
+// U+2029 characters inside string literals changed to \u003c, \u003e,
+// \u0026, \u2028, \u2029 so that the JSON will be safe to embed inside
+// HTML <script> tags.
+export function HTMLEscape(dst: bytes.Buffer | null, src: $.Bytes): void {
+  dst!.WriteString(htmlEscape($.bytesToString(src)))
+}
+
+// Compact appends to dst the JSON-encoded src with
+// insignificant space characters elided.
+export function Compact(dst: bytes.Buffer | null, src: $.Bytes): $.GoError {
+  const [text, n, err] = parse(src)
+  if (err !== null) {
+    return err
+  }
+  dst!.WriteString(compact(text, n!, false))
+  return null
+}
+
+// Indent appends to dst an indented form of the JSON-encoded src.
+// Each element in a JSON object or array begins on a new,
+// indented line beginning with prefix followed by one or more
+// copies of indent according to the indentation nesting.
+// The data appended to dst does not begin with the prefix nor
+// any indentation, to make it easier to embed inside other formatted JSON
+// data. Although leading space characters (space, tab, carriage return,
+// newline) at the beginning of src are dropped, trailing space characters
+// at the end of src are preserved and copied to dst.
+export function Indent(
+  dst: bytes.Buffer | null,
+  src: $.Bytes,
+  prefix: string,
+  indentation: string,
+): $.GoError {
+  const [text, n, err] = parse(src)
+  if (err !== null) {
+    return err
+  }
+  dst!.WriteString(indent(text, n!, prefix, indentation) + text.slice(n!.end))
+  return null
+}
@@ -0,0 +1,23 @@
+export {
+  Marshal,
+  MarshalIndent,
+  MarshalerError,
+  UnsupportedTypeError,
+  UnsupportedValueError,
+} from './encode.js'
+export type { Marshaler } from './encode.js'
+export {
+  InvalidUnmarshalError,
+  Number_Float64,
+  Number_Int64,
+  Number_String,
+  RawMessage_MarshalJSON,
+  RawMessage_UnmarshalJSON,
+  Unmarshal,
+  UnmarshalTypeError,
+} from './decode.js'
+export type { Number, RawMessage, Unmarshaler } from './decode.js'
+export { Compact, HTMLEscape, Indent } from './indent.js'
+export { SyntaxError, Valid } from './scanner.js'
+export { Decoder, Delim_String, Encoder, NewDecoder, NewEncoder } from './stream.js'
+export type { Delim, Token } from './stream.js'
@@ -0,0 +1,504 @@
+import * as $ from '@goscript/builtin/index.js'
+
+// A SyntaxError is a description of a JSON syntax error.
+// Unmarshal will return a SyntaxError if the JSON can't be parsed.
+export class SyntaxError {
+  // description of error
+  public msg: string = ''
+  // error occurred after reading Offset bytes
+  public Offset: number = 0
+
+  constructor(init?: Partial<{ msg?: string; Offset?: number }>) {
+    if (init) {
+      Object.assign(this, init)
+    }
+  }
+
+  public clone(): SyntaxError {
+    return new SyntaxError({ msg: this.msg, Offset: this.Offset })
+  }
+
+  public Error(): string {
+    return this.msg
+  }
+
+  // Register this type with the runtime type system
+  static __typeInfo = $.registerStructType(
+    'encoding/json.SyntaxError',
+    new SyntaxError(),
+    [
+      {
+        name: 'Error',
+        args: [],
+        returns: [{ type: { kind: $.TypeKind.Basic, name: 'string' } }],
+      },
+    ],
+    SyntaxError,
+    [
+      { name: 'msg', type: { kind: $.TypeKind.Basic, name: 'string' } },
+      { name: 'Offset', type: { kind: $.TypeKind.Basic, name: 'int64' } },
+    ],
+  )
+}
+
+// node is a parsed JSON value. start and end are the offsets of its text.
+export type node =
+  | { kind: 'object'; start: number; end: number; members: member[] }
+  | { kind: 'array'; start: number; end: number; elems: node[] }
+  | { kind: 'string'; start: number; end: number; value: string }
+  | { kind: 'number'; start: number; end: number; text: string }
+  | { kind: 'literal'; start: number; end: number; value: boolean | null }
+
+// member is a member of a JSON object.
+export interface member {
+  key: string
+  // offsets of the key's string literal
+  keyStart: number
+  keyEnd: number
+  value: node
+}
+
+// eofError is thrown while parsing when the input ends before the value.
+// The Decoder uses it to tell incomplete input from invalid input.
+export class eofError extends SyntaxError {}
+
+// quoteChar formats c as a quoted character literal, like Go's scanner.
+function quoteChar(c: string): string {
+  if (c === "'") {
+    return `'\\''`
+  }
+  if (c === '"') {
+    return `'"'`
+  }
+  const code = c.codePointAt(0)!
+  if (code < 0x20 || code === 0x7f) {
+    return `'\\x${code.toString(16).padStart(2, '0')}'`
+  }
+  return `'${c}'`
+}
+
+// isSpace reports whether c is JSON whitespace.
+function isSpace(c: string): boolean {
+  return c === ' ' || c === '\t' || c === '\n' || c === '\r'
+}
+
+// parser parses JSON text into nodes.
+export class parser {
+  public pos = 0
+
+  constructor(public readonly text: string) {}
+
+  // error throws a SyntaxError with the given message at the current offset.
+  private error(msg: string, offset: number = this.pos): never {
+    throw new SyntaxError({ msg, Offset: offset })
+  }
+
+  // unexpected throws the error for an invalid character at the current
+  // offset, or for the end of input.
+  private unexpected(context: string): never {
+    if (this.pos >= this.text.length) {
+      this.eof()
+    }
+    const c = String.fromCodePoint(this.text.codePointAt(this.pos)!)
+    this.error(`invalid character ${quoteChar(c)} ${context}`, this.pos + 1)
+  }
+
+  private eof(): never {
+    throw new eofError({
+      msg: 'unexpected end of JSON input',
+      Offset: this.text.length,
+    })
+  }
+
+  // skipSpace skips whitespace and returns the next character.
+  public skipSpace(): string {
+    while (this.pos < this.text.length && isSpace(this.text[this.pos])) {
+      this.pos++
+    }
+    return this.text[this.pos] ?? ''
+  }
+
+  // parseTopLevel parses a single JSON value filling the whole text.
+  public parseTopLevel(): node {
+    const value = this.parseValue()
+    if (this.skipSpace() !== '') {
+      this.unexpected('after top-level value')
+    }
+    return value
+  }
+
+  // parseValue parses the JSON value at the current offset.
+  public parseValue(): node {
+    const c = this.skipSpace()
+    const start = this.pos
+    switch (c) {
+      case '{':
+        return this.parseObject()
+      case '[':
+        return this.parseArray()
+      case '"':
+        return { kind: 'string', start, value: this.parseString(), end: this.pos }
+      case 't':
+        this.parseLiteral('true')
+        return { kind: 'literal', start, end: this.pos, value: true }
+      case 'f':
+        this.parseLiteral('false')
+        return { kind: 'literal', start, end: this.pos, value: false }
+      case 'n':
+        this.parseLiteral('null')
+        return { kind: 'literal', start, end: this.pos, value: null }
+    }
+    if (c === '-' || (c >= '0' && c <= '9')) {
+      return this.parseNumber()
+    }
+    this.unexpected('looking for beginning of value')
+  }
+
+  private parseObject(): node {
+    const start = this.pos
+    this.pos++
+    const members: member[] = []
+    if (this.skipSpace() === '}') {
+      this.pos++
+      return { kind: 'object', start, end: this.pos, members }
+    }
+    for (;;) {
+      if (this.skipSpace() !== '"') {
+        this.unexpected('looking for beginning of object key string')
+      }
+      const keyStart = this.pos
+      const key = this.parseString()
+      const keyEnd = this.pos
+      if (this.skipSpace() !== ':') {
+        this.unexpected('after object key')
+      }
+      this.pos++
+      members.push({ key, keyStart, keyEnd, value: this.parseValue() })
+      const c = this.skipSpace()
+      if (c === ',') {
+        this.pos++
+        continue
+      }
+      if (c === '}') {
+        this.pos++
+        return { kind: 'object', start, end: this.pos, members }
+      }
+      this.unexpected('after object key:value pair')
+    }
+  }
+
+  private parseArray(): node {
+    const start = this.pos
+    this.pos++
+    const elems: node[] = []
+    if (this.skipSpace() === ']') {
+      this.pos++
+      return { kind: 'array', start, end: this.pos, elems }
+    }
+    for (;;) {
+      elems.push(this.parseValue())
+      const c = this.skipSpace()
+      if (c === ',') {
+        this.pos++
+        continue
+      }
+      if (c === ']') {
+        this.pos++
+        return { kind: 'array', start, end: this.pos, elems }
+      }
+      this.unexpected('after array element')
+    }
+  }
+
+  // parseString parses a string literal and returns its value.
+  public parseString(): string {
+    const text = this.text
+    this.pos++
+    let value = ''
+    let chunk = this.pos
+    for (;;) {
+      if (this.pos >= text.length) {
+        this.eof()
+      }
+      const c = text[this.pos]
+      if (c === '"') {
+        value += text.slice(chunk, this.pos)
+        this.pos++
+        return value
+      }
+      if (c < ' ') {
+        this.error(`invalid character ${quoteChar(c)} in string`, this.pos + 1)
+      }
+      if (c !== '\\') {
+        this.pos++
+        continue
+      }
+      value += text.slice(chunk, this.pos)
+      const escapeStart = this.pos
+      this.pos++
+      if (this.pos >= text.length) {
+        this.eof()
+      }
+      const e = text[this.pos++]
+      switch (e) {
+        case '"':
+        case '\\':
+        case '/':
+          value += e
+          break
+        case 'b':
+          value += '\b'
+          break
+        case 'f':
+          value += '\f'
+          break
+        case 'n':
+          value += '\n'
+          break
+        case 'r':
+          value += '\r'
+          break
+        case 't':
+          value += '\t'
+          break
+        case 'u': {
+          const hex = text.slice(this.pos, this.pos + 4)
+          if (!/^[0-9a-fA-F]{4}$/.test(hex)) {
+            if (this.pos + 4 > text.length && /^[0-9a-fA-F]*$/.test(hex)) {
+              this.eof()
+            }
+            let end = this.pos
+            while (end < this.pos + 4 && /[0-9a-fA-F]/.test(text[end])) {
+              end++
+            }
+            this.error(
+              `invalid escape sequence \`${text.slice(escapeStart, end + 1)}\` in string`,
+              end,
+            )
+          }
+          // Surrogate pairs are joined by JavaScript strings, lone
+          // surrogates are replaced like invalid UTF-8 in Go.
+          value += String.fromCharCode(parseInt(hex, 16))
+          this.pos += 4
+          break
+        }
+        default:
+          this.error(
+            `invalid escape sequence \`${text.slice(escapeStart, this.pos)}\` in string`,
+            escapeStart,
+          )
+      }
+      chunk = this.pos
+    }
+  }
+
+  private parseNumber(): node {
+    const text = this.text
+    const start = this.pos
+    const digits = () => {
+      while (this.pos < text.length && text[this.pos] >= '0' && text[this.pos] <= '9') {
+        this.pos++
+      }
+    }
+    const needDigit = () => {
+      if (this.pos >= text.length) {
+        this.eof()
+      }
+      const c = text[this.pos]
+      if (c < '0' || c > '9') {
+        this.unexpected('in numeric literal')
+      }
+    }
+    if (text[this.pos] === '-') {
+      this.pos++
+      needDigit()
+    }
+    if (text[this.pos] === '0') {
+      this.pos++
+    } else {
+      digits()
+    }
+    if (text[this.pos] === '.') {
+      this.pos++
+      needDigit()
+      digits()
+    }
+    if (text[this.pos] === 'e' || text[this.pos] === 'E') {
+      this.pos++
+      if (text[this.pos] === '+' || text[this.pos] === '-') {
+        this.pos++
+      }
+      needDigit()
+      digits()
+    }
+    return { kind: 'number', start, end: this.pos, text: text.slice(start, this.pos) }
+  }
+
+  private parseLiteral(literal: string): void {
+    for (let i = 0; i < literal.length; i++, this.pos++) {
+      if (this.pos >= this.text.length) {
+        this.eof()
+      }
+      if (this.text[this.pos] !== literal[i]) {
+        this.unexpected(`in literal ${literal} (expecting ${quoteChar(literal[i])})`)
+      }
+    }
+  }
+}
+
+// parse parses data as a single JSON value, returning the text and its
+// parsed value, or a SyntaxError.
+export function parse(data: $.Bytes): [string, node | null, $.GoError] {
+  const text = $.bytesToString(data)
+  try {
+    return [text, new parser(text).parseTopLevel(), null]
+  } catch (err) {
+    if (err instanceof SyntaxError) {
+      return [text, null, err]
+    }
+    throw err
+  }
+}
+
+// isValidNumber reports whether s is a valid JSON number literal.
+export function isValidNumber(s: string): boolean {
+  return /^-?(0|[1-9][0-9]*)(\.[0-9]+)?([eE][+-]?[0-9]+)?$/.test(s)
+}
+
+// Valid reports whether data is a valid JSON encoding.
+export function Valid(data: $.Bytes): boolean {
+  const [, , err] = parse(data)
+  return err === null
+}
+
+// compact writes the JSON text of n without insignificant space, like Go's
+// Compact. Strings are written as in the source text unless escapeHTML is
+// set.
+export function compact(text: string, n: node, escapeHTML: boolean): string {
+  const literal = (start: number, end: number) =>
+    escapeHTML ? htmlEscape(text.slice(start, end)) : text.slice(start, end)
+  switch (n.kind) {
+    case 'object': {
+      const members = n.members.map(
+        (m) =>
+          literal(m.keyStart, m.keyEnd) + ':' + compact(text, m.value, escapeHTML),
+      )
+      return '{' + members.join(',') + '}'
+    }
+    case 'array':
+      return '[' + n.elems.map((e) => compact(text, e, escapeHTML)).join(',') + ']'
+    case 'string':
+      return literal(n.start, n.end)
+    default:
+      return text.slice(n.start, n.end)
+  }
+}
+
+// indent writes the JSON text of n with each element on a new line, like
+// Go's Indent.
+export function indent(
+  text: string,
+  n: node,
+  prefix: string,
+  indentation: string,
+  depth: number = 0,
+): string {
+  const newline = (d: number) => '\n' + prefix + indentation.repeat(d)
+  switch (n.kind) {
+    case 'object':
+      if (n.members.length === 0) {
+        return '{}'
+      }
+      return (
+        '{' +
+        n.members
+          .map(
+            (m) =>
+              newline(depth + 1) +
+              text.slice(m.keyStart, m.keyEnd) +
+              ': ' +
+              indent(text, m.value, prefix, indentation, depth + 1),
+          )
+          .join(',') +
+        newline(depth) +
+        '}'
+      )
+    case 'array':
+      if (n.elems.length === 0) {
+        return '[]'
+      }
+      return (
+        '[' +
+        n.elems
+          .map(
+            (e) => newline(depth + 1) + indent(text, e, prefix, indentation, depth + 1),
+          )
+          .join(',') +
+        newline(depth) +
+        ']'
+      )
+    default:
+      return text.slice(n.start, n.end)
+  }
+}
+
+// htmlEscape escapes <, >, &, U+2028 and U+2029 in JSON text, so it can be
+// embedded in HTML <script> tags.
+export function htmlEscape(s: string): string {
+  return s.replace(/[<>&\u2028\u2029]/g, (c) => {
+    return '\\u' + c.charCodeAt(0).toString(16).padStart(4, '0')
+  })
+}
+
+// quoteString returns s as a JSON string literal.
+export function quoteString(s: string, escapeHTML: boolean): string {
+  let out = '"'
+  let chunk = 0
+  for (let i = 0; i < s.length; i++) {
+    const c = s.charCodeAt(i)
+    let esc: string | null = null
+    if (c === 0x22) {
+      esc = '\\"'
+    } else if (c === 0x5c) {
+      esc = '\\\\'
+    } else if (c < 0x20) {
+      switch (c) {
+        case 0x08:
+          esc = '\\b'
+          break
+        case 0x0c:
+          esc = '\\f'
+          break
+        case 0x0a:
+          esc = '\\n'
+          break
+        case 0x0d:
+          esc = '\\r'
+          break
+        case 0x09:
+          esc = '\\t'
+          break
+        default:
+          esc = '\\u00' + c.toString(16).padStart(2, '0')
+      }
+    } else if (escapeHTML && (c === 0x3c || c === 0x3e || c === 0x26)) {
+      esc = '\\u00' + c.toString(16)
+    } else if (c === 0x2028 || c === 0x2029) {
+      esc = '\\u' + c.toString(16)
+    } else if (c >= 0xd800 && c <= 0xdfff) {
+      // Lone surrogates are written as the replacement character, like
+      // invalid UTF-8 in Go.
+      const next = s.charCodeAt(i + 1)
+      if (c <= 0xdbff && next >= 0xdc00 && next <= 0xdfff) {
+        i++
+        continue
+      }
+      esc = '\ufffd'
+    }
+    if (esc !== null) {
+      out += s.slice(chunk, i) + esc
+      chunk = i + 1
+    }
+  }
+  return out + s.slice(chunk) + '"'
+}
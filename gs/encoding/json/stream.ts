@@ -0,0 +1,538 @@
+import * as $ from '@goscript/builtin/index.js'
+import * as bytes from '@goscript/bytes/index.js'
+import * as io from '@goscript/io/index.js'
+import { decodeOptions, unmarshalNode } from './decode.js'
+import { indentText, marshal } from './encode.js'
+import { eofError, node, parser, SyntaxError } from './scanner.js'
+
+// token states of a Decoder reading tokens, tracking where in the
+// enclosing objects and arrays the next token is.
+const tokenTopValue = 0
+const tokenArrayStart = 1
+const tokenArrayValue = 2
+const tokenArrayComma = 3
+const tokenObjectStart = 4
+const tokenObjectKey = 5
+const tokenObjectColon = 6
+const tokenObjectValue = 7
+const tokenObjectComma = 8
+
+// A Decoder reads and decodes JSON values from an input stream.
+export class Decoder {
+  private r: io.Reader | null = null
+  // unread data is buf[scanp:]
+  private buf: Uint8Array = new Uint8Array(0)
+  private scanp = 0
+  // amount of data already scanned and dropped from buf
+  private scanned = 0
+  private err: $.GoError = null
+  private opts: decodeOptions = {}
+
+  private tokenState = tokenTopValue
+  private tokenStack: number[] = []
+
+  constructor(init?: Partial<{ r?: io.Reader | null }>) {
+    this.r = init?.r ?? null
+  }
+
+  public clone(): Decoder {
+    const cloned = new Decoder({ r: this.r })
+    cloned.buf = this.buf
+    cloned.scanp = this.scanp
+    cloned.scanned = this.scanned
+    cloned.err = this.err
+    cloned.opts = { ...this.opts }
+    cloned.tokenState = this.tokenState
+    cloned.tokenStack = [...this.tokenStack]
+    return cloned
+  }
+
+  // UseNumber causes the Decoder to unmarshal a number into an
+  // interface value as a Number instead of as a float64.
+  public UseNumber(): void {
+    this.opts.useNumber = true
+  }
+
+  // DisallowUnknownFields causes the Decoder to return an error when the
+  // destination is a struct and the input contains object keys which do
+  // not match any non-ignored, exported fields in the destination.
+  public DisallowUnknownFields(): void {
+    this.opts.disallowUnknownFields = true
+  }
+
+  // Decode reads the next JSON-encoded value from its
+  // input and stores it in the value pointed to by v.
+  public Decode(v: any): $.GoError {
+    const err = this.tokenPrepareForDecode()
+    if (err !== null) {
+      return err
+    }
+
+    if (!this.tokenValueAllowed()) {
+      return new SyntaxError({
+        msg: 'not at beginning of value',
+        Offset: this.InputOffset(),
+      })
+    }
+
+    // Read whole value into buffer.
+    const [text, n, readErr] = this.readValue()
+    if (readErr !== null) {
+      return readErr
+    }
+
+    const decodeErr = unmarshalNode(text, n!, v, this.opts)
+
+    // fixup token streaming state
+    this.tokenValueEnd()
+
+    return decodeErr
+  }
+
+  // Buffered returns a reader of the data remaining in the Decoder's
+  // buffer. The reader is valid until the next call to Decode.
+  public Buffered(): io.Reader {
+    return bytes.NewReader(this.buf.subarray(this.scanp))!
+  }
+
+  // readValue reads a JSON value into the buffer and returns its text and
+  // parsed form.
+  private readValue(): [string, node | null, $.GoError] {
+    for (;;) {
+      const text = $.bytesToString(this.buf.subarray(this.scanp))
+      const p = new parser(text)
+      try {
+        const n = p.parseValue()
+        // A number at the end of the buffer may continue in the next read.
+        if (n.kind !== 'number' || p.pos < text.length || this.err !== null) {
+          this.scanp += $.stringToBytes(text.slice(0, p.pos)).length
+          return [text.slice(0, p.pos), n, null]
+        }
+      } catch (err) {
+        if (!(err instanceof SyntaxError)) {
+          throw err
+        }
+        if (!(err instanceof eofError)) {
+          const offset =
+            this.InputOffset() + $.stringToBytes(text.slice(0, err.Offset)).length
+          return [text, null, new SyntaxError({ msg: err.msg, Offset: offset })]
+        }
+        if (this.err !== null) {
+          if (this.err === io.EOF) {
+            if (p.skipSpace() !== '') {
+              return [text, null, io.ErrUnexpectedEOF]
+            }
+            return [text, null, io.EOF]
+          }
+          return [text, null, this.err]
+        }
+      }
+      this.refill()
+    }
+  }
+
+  // refill reads more data into the buffer, dropping consumed data.
+  private refill(): void {
+    if (this.scanp > 0) {
+      this.scanned += this.scanp
+      this.buf = this.buf.slice(this.scanp)
+      this.scanp = 0
+    }
+    const p = new Uint8Array(512)
+    const [n, err] = this.r!.Read(p)
+    if (n > 0) {
+      const buf = new Uint8Array(this.buf.length + n)
+      buf.set(this.buf)
+      buf.set(p.subarray(0, n), this.buf.length)
+      this.buf = buf
+    }
+    this.err = err
+  }
+
+  // peek returns the next non-space byte of the input, reading more data
+  // as needed.
+  private peek(): [number, $.GoError] {
+    for (;;) {
+      for (let i = this.scanp; i < this.buf.length; i++) {
+        const c = this.buf[i]
+        if (c === 0x20 || c === 0x09 || c === 0x0d || c === 0x0a) {
+          continue
+        }
+        this.scanp = i
+        return [c, null]
+      }
+      this.scanp = this.buf.length
+      // buffer has been scanned, now report any error
+      if (this.err !== null) {
+        return [0, this.err]
+      }
+      this.refill()
+    }
+  }
+
+  // InputOffset returns the input stream byte offset of the current
+  // decoder position. The offset gives the location of the end of the
+  // most recently returned token and the beginning of the next token.
+  public InputOffset(): number {
+    return this.scanned + this.scanp
+  }
+
+  // More reports whether there is another element in the
+  // current array or object being parsed.
+  public More(): boolean {
+    const [c, err] = this.peek()
+    return err === null && c !== 0x5d && c !== 0x7d
+  }
+
+  // advance the tokenizer from the current state to a value position
+  private tokenPrepareForDecode(): $.GoError {
+    // Note: Not calling peek before switch, to avoid
+    // putting peek into the standard Decode path.
+    // peek is only called when using the Token API.
+    switch (this.tokenState) {
+      case tokenArrayComma: {
+        const [c, err] = this.peek()
+        if (err !== null) {
+          return err
+        }
+        if (c !== 0x2c) {
+          return new SyntaxError({
+            msg: 'expected comma after array element',
+            Offset: this.InputOffset(),
+          })
+        }
+        this.scanp++
+        this.tokenState = tokenArrayValue
+        break
+      }
+      case tokenObjectColon: {
+        const [c, err] = this.peek()
+        if (err !== null) {
+          return err
+        }
+        if (c !== 0x3a) {
+          return new SyntaxError({
+            msg: 'expected colon after object key',
+            Offset: this.InputOffset(),
+          })
+        }
+        this.scanp++
+        this.tokenState = tokenObjectValue
+        break
+      }
+    }
+    return null
+  }
+
+  private tokenValueAllowed(): boolean {
+    switch (this.tokenState) {
+      case tokenTopValue:
+      case tokenArrayStart:
+      case tokenArrayValue:
+      case tokenObjectValue:
+        return true
+    }
+    return false
+  }
+
+  private tokenValueEnd(): void {
+    switch (this.tokenState) {
+      case tokenArrayStart:
+      case tokenArrayValue:
+        this.tokenState = tokenArrayComma
+        break
+      case tokenObjectValue:
+        this.tokenState = tokenObjectComma
+        break
+    }
+  }
+
+  // Token returns the next JSON token in the input stream.
+  // At the end of the input stream, Token returns nil, io.EOF.
+  //
+  // Delimiters are returned as Delim values, the code points of
+  // [ ] { and }, so they cannot be told apart from float64 numbers
+  // by their representation.
+  public Token(): [Token, $.GoError] {
+    for (;;) {
+      const [c, err] = this.peek()
+      if (err !== null) {
+        return [null, err]
+      }
+      switch (c) {
+        case 0x5b: // '['
+        case 0x7b: // '{'
+          if (!this.tokenValueAllowed()) {
+            return this.tokenError(c)
+          }
+          this.scanp++
+          this.tokenStack.push(this.tokenState)
+          this.tokenState = c === 0x5b ? tokenArrayStart : tokenObjectStart
+          return [c, null]
+
+        case 0x5d: // ']'
+          if (
+            this.tokenState !== tokenArrayStart &&
+            this.tokenState !== tokenArrayComma
+          ) {
+            return this.tokenError(c)
+          }
+          this.scanp++
+          this.tokenState = this.tokenStack.pop()!
+          this.tokenValueEnd()
+          return [c, null]
+
+        case 0x7d: // '}'
+          if (
+            this.tokenState !== tokenObjectStart &&
+            this.tokenState !== tokenObjectComma
+          ) {
+            return this.tokenError(c)
+          }
+          this.scanp++
+          this.tokenState = this.tokenStack.pop()!
+          this.tokenValueEnd()
+          return [c, null]
+
+        case 0x3a: // ':'
+          if (this.tokenState !== tokenObjectColon) {
+            return this.tokenError(c)
+          }
+          this.scanp++
+          this.tokenState = tokenObjectValue
+          continue
+
+        case 0x2c: // ','
+          if (this.tokenState === tokenArrayComma) {
+            this.scanp++
+            this.tokenState = tokenArrayValue
+            continue
+          }
+          if (this.tokenState === tokenObjectComma) {
+            this.scanp++
+            this.tokenState = tokenObjectKey
+            continue
+          }
+          return this.tokenError(c)
+
+        case 0x22: // '"'
+          if (
+            this.tokenState === tokenObjectStart ||
+            this.tokenState === tokenObjectKey
+          ) {
+            const x = $.varRef('')
+            const old = this.tokenState
+            this.tokenState = tokenTopValue
+            const err = this.Decode(x)
+            this.tokenState = old
+            if (err !== null) {
+              return [null, err]
+            }
+            this.tokenState = tokenObjectColon
+            return [x.value, null]
+          }
+      }
+      if (!this.tokenValueAllowed()) {
+        return this.tokenError(c)
+      }
+      const x = $.varRef<any>(null)
+      const decodeErr = this.Decode(x)
+      if (decodeErr !== null) {
+        return [null, decodeErr]
+      }
+      return [x.value, null]
+    }
+  }
+
+  private tokenError(c: number): [Token, $.GoError] {
+    let context = ''
+    switch (this.tokenState) {
+      case tokenTopValue:
+        context = ' looking for beginning of value'
+        break
+      case tokenArrayStart:
+      case tokenArrayValue:
+      case tokenObjectValue:
+        context = ' looking for beginning of value'
+        break
+      case tokenArrayComma:
+        context = ' after array element'
+        break
+      case tokenObjectKey:
+        context = ' looking for beginning of object key string'
+        break
+      case tokenObjectColon:
+        context = ' after object key'
+        break
+      case tokenObjectComma:
+        context = ' after object key:value pair'
+        break
+    }
+    return [
+      null,
+      new SyntaxError({
+        msg: `invalid character '${String.fromCharCode(c)}'${context}`,
+        Offset: this.InputOffset(),
+      }),
+    ]
+  }
+
+  // Register this type with the runtime type system
+  static __typeInfo = $.registerStructType(
+    'encoding/json.Decoder',
+    new Decoder(),
+    [
+      { name: 'UseNumber', args: [], returns: [] },
+      { name: 'DisallowUnknownFields', args: [], returns: [] },
+      {
+        name: 'Decode',
+        args: [{ name: 'v', type: { kind: $.TypeKind.Interface, methods: [] } }],
+        returns: [{ type: 'error' }],
+      },
+      { name: 'Buffered', args: [], returns: [{ type: 'io.Reader' }] },
+      {
+        name: 'InputOffset',
+        args: [],
+        returns: [{ type: { kind: $.TypeKind.Basic, name: 'int64' } }],
+      },
+      {
+        name: 'More',
+        args: [],
+        returns: [{ type: { kind: $.TypeKind.Basic, name: 'boolean' } }],
+      },
+      {
+        name: 'Token',
+        args: [],
+        returns: [{ type: 'encoding/json.Token' }, { type: 'error' }],
+      },
+    ],
+    Decoder,
+    [],
+  )
+}
+
+// NewDecoder returns a new decoder that reads from r.
+//
+// The decoder introduces its own buffering and may
+// read data from r beyond the JSON values requested.
+export function NewDecoder(r: io.Reader | null): Decoder {
+  return new Decoder({ r })
+}
+
+// An Encoder writes JSON values to an output stream.
+export class Encoder {
+  private w: io.Writer | null = null
+  private err: $.GoError = null
+  private escapeHTML = true
+
+  private indentPrefix = ''
+  private indentValue = ''
+
+  constructor(init?: Partial<{ w?: io.Writer | null }>) {
+    this.w = init?.w ?? null
+  }
+
+  public clone(): Encoder {
+    const cloned = new Encoder({ w: this.w })
+    cloned.err = this.err
+    cloned.escapeHTML = this.escapeHTML
+    cloned.indentPrefix = this.indentPrefix
+    cloned.indentValue = this.indentValue
+    return cloned
+  }
+
+  // Encode writes the JSON encoding of v to the stream,
+  // followed by a newline character.
+  public Encode(v: any): $.GoError {
+    if (this.err !== null) {
+      return this.err
+    }
+    let [text, err] = marshal(v, this.escapeHTML)
+    if (err !== null) {
+      return err
+    }
+    if (this.indentPrefix !== '' || this.indentValue !== '') {
+      text = indentText(text, this.indentPrefix, this.indentValue)
+    }
+
+    // Terminate each value with a newline.
+    // This makes the output look a little nicer
+    // when debugging, and some kind of space
+    // is required if the encoded value was a number,
+    // so that the reader knows there aren't more
+    // digits coming.
+    const [, writeErr] = this.w!.Write($.stringToBytes(text + '\n'))
+    if (writeErr !== null) {
+      this.err = writeErr
+    }
+    return writeErr
+  }
+
+  // SetIndent instructs the encoder to format each subsequent encoded
+  // value as if indented by the package-level function Indent(dst, src,
+  // prefix, indent). Calling SetIndent("", "") disables indentation.
+  public SetIndent(prefix: string, indent: string): void {
+    this.indentPrefix = prefix
+    this.indentValue = indent
+  }
+
+  // SetEscapeHTML specifies whether problematic HTML characters
+  // should be escaped inside JSON quoted strings.
+  // The default behavior is to escape &, <, and > to \u0026, \u003c, and
+  // \u003e to avoid certain safety problems that can arise when embedding
+  // JSON in HTML.
+  public SetEscapeHTML(on: boolean): void {
+    this.escapeHTML = on
+  }
+
+  // Register this type with the runtime type system
+  static __typeInfo = $.registerStructType(
+    'encoding/json.Encoder',
+    new Encoder(),
+    [
+      {
+        name: 'Encode',
+        args: [{ name: 'v', type: { kind: $.TypeKind.Interface, methods: [] } }],
+        returns: [{ type: 'error' }],
+      },
+      {
+        name: 'SetIndent',
+        args: [
+          { name: 'prefix', type: { kind: $.TypeKind.Basic, name: 'string' } },
+          { name: 'indent', type: { kind: $.TypeKind.Basic, name: 'string' } },
+        ],
+        returns: [],
+      },
+      {
+        name: 'SetEscapeHTML',
+        args: [{ name: 'on', type: { kind: $.TypeKind.Basic, name: 'boolean' } }],
+        returns: [],
+      },
+    ],
+    Encoder,
+    [],
+  )
+}
+
+// NewEncoder returns a new encoder that writes to w.
+export function NewEncoder(w: io.Writer | null): Encoder {
+  return new Encoder({ w })
+}
+
+// A Token holds a value of one of these types:
+//
+//   - Delim, for the four JSON delimiters [ ] { }
+//   - bool, for JSON booleans
+//   - float64, for JSON numbers
+//   - Number, for JSON numbers
+//   - string, for JSON string literals
+//   - nil, for JSON null
+export type Token = any
+
+// A Delim is a JSON array or object delimiter, one of [ ] { or }.
+export type Delim = number
+
+// Delim_String returns the delimiter as a string.
+export function Delim_String(d: Delim): string {
+  return String.fromCharCode(d)
+}
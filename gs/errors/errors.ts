@@ -29,18 +29,10 @@ export function Unwrap(err: $.GoError): $.GoError {
   // Check if the error has an Unwrap method
   if (typeof (err as any).Unwrap === 'function') {
     const result = (err as any).Unwrap()
+    // Unwrap returns nil for errors whose Unwrap returns []error
     if (result && typeof result.Error === 'function') {
       return result
     }
-    // Handle case where Unwrap returns []error
-    if (
-      Array.isArray(result) &&
-      result.length > 0 &&
-      result[0] &&
-      typeof result[0].Error === 'function'
-    ) {
-      return result[0]
-    }
   }
 
   return null
@@ -131,7 +123,14 @@ export function Is(err: $.GoError, target: $.GoError): boolean {
 //
 // As panics if target is not a non-nil pointer to either a type that implements
 // error, or to any interface type.
-export function As(err: $.GoError, target: any): boolean {
+//
+// Calls compiled from Go pass the static type of target. Targets pointing to
+// values other than structs are variables, which are matched by that type.
+export function As(
+  err: $.GoError,
+  target: any,
+  typ?: $.TypeInfo | string,
+): boolean {
   if (err === null) {
     return false
   }
@@ -140,6 +139,11 @@ export function As(err: $.GoError, target: any): boolean {
     throw new Error('errors: target cannot be nil')
   }
 
+  const elemType = targetElemType(typ)
+  if (elemType !== undefined && $.isVarRef(target)) {
+    return asType(err, target, elemType)
+  }
+
   // Check if err matches target type
   if (err.constructor === target.constructor) {
     // Copy properties from err to target
@@ -179,6 +183,61 @@ export function As(err: $.GoError, target: any): boolean {
   return false
 }
 
+// targetElemType returns the type target points to.
+function targetElemType(
+  typ: $.TypeInfo | string | undefined,
+): $.TypeInfo | string | undefined {
+  const info = typeof typ === 'string' ? $.getTypeByName(typ) : typ
+  return info?.kind === $.TypeKind.Pointer ? info.elemType : undefined
+}
+
+// asType is As for a target variable holding values of type elemType.
+function asType(
+  err: $.GoError,
+  target: $.VarRef<any>,
+  elemType: $.TypeInfo | string,
+): boolean {
+  if (err === null) {
+    return false
+  }
+  const [value, ok] = matchType(err, elemType)
+  if (ok) {
+    // Variables of named basic types hold the underlying value.
+    const primitive = typeof target.value !== 'object'
+    target.value = primitive ? value.valueOf() : value
+    return true
+  }
+  if (typeof (err as any).As === 'function' && (err as any).As(target)) {
+    return true
+  }
+  const unwrapped = (err as any).Unwrap?.()
+  if (Array.isArray(unwrapped)) {
+    return unwrapped.some((e: $.GoError) => asType(e, target, elemType))
+  }
+  return asType(unwrapped ?? null, target, elemType)
+}
+
+// matchType asserts err to the type elemType. Pointers to structs share
+// their representation with struct values, so they match by constructor.
+function matchType(
+  err: $.GoError,
+  elemType: $.TypeInfo | string,
+): [any, boolean] {
+  const info =
+    typeof elemType === 'string' ? $.getTypeByName(elemType) : elemType
+  if (info?.kind === $.TypeKind.Pointer) {
+    const elem =
+      typeof info.elemType === 'string' ?
+        $.getTypeByName(info.elemType)
+      : info.elemType
+    if (elem?.kind === $.TypeKind.Struct && elem.ctor) {
+      return [err, err instanceof elem.ctor]
+    }
+  }
+  const { value, ok } = $.typeAssert<any>(err, elemType)
+  return [value, ok]
+}
+
 // Join returns an error that wraps the given errors.
 // Any nil error values are discarded.
 // Join returns nil if every value in errs is nil.
@@ -0,0 +1,95 @@
+// Port of Go's fmt/errors.go
+
+import * as $ from '@goscript/builtin/index.js'
+import * as errors from '@goscript/errors/index.js'
+import { newPrinter } from './fmt.js'
+
+// Errorf formats according to a format specifier and returns the string as
+// a value that satisfies error.
+//
+// If the format specifier includes a %w verb with an error operand,
+// the returned error will implement an Unwrap method returning the operand.
+// If there is more than one %w verb, the returned error will implement an
+// Unwrap method returning a []error containing all the %w operands in the
+// order they appear in the arguments.
+// It is invalid to supply the %w verb with an operand that does not implement
+// the error interface. The %w verb is otherwise a synonym for %v.
+export function Errorf(format: string, ...a: any[]): $.GoError {
+  const err = errorf(format, ...a)
+  if (err !== null) {
+    return err
+  }
+  // No formatting was needed. We can avoid some allocations and other work.
+  return errors.New(format)
+}
+
+// errorf formats the error, or returns null if the format has no verbs and
+// there are no arguments.
+function errorf(format: string, ...a: any[]): $.GoError {
+  if (a.length === 0 && !format.includes('%')) {
+    return null
+  }
+  const p = newPrinter()
+  p.wrapErrs = true
+  p.doPrintf(format, a)
+  const s = p.buf.s
+  switch (p.wrappedErrs.length) {
+    case 0:
+      return errors.New(s)
+    case 1: {
+      const arg = a[p.wrappedErrs[0]]
+      return new wrapError(s, isError(arg) ? arg : null)
+    }
+    default: {
+      if (p.reordered) {
+        p.wrappedErrs.sort((x, y) => x - y)
+      }
+      const errs: $.GoError[] = []
+      for (let i = 0; i < p.wrappedErrs.length; i++) {
+        const argNum = p.wrappedErrs[i]
+        if (i > 0 && p.wrappedErrs[i - 1] === argNum) {
+          continue
+        }
+        if (isError(a[argNum])) {
+          errs.push(a[argNum])
+        }
+      }
+      return new wrapErrors(s, errs)
+    }
+  }
+}
+
+// isError reports whether v implements the error interface.
+function isError(v: any): v is NonNullable<$.GoError> {
+  return v !== null && typeof v === 'object' && typeof v.Error === 'function'
+}
+
+class wrapError {
+  constructor(
+    public msg: string,
+    public err: $.GoError,
+  ) {}
+
+  public Error(): string {
+    return this.msg
+  }
+
+  public Unwrap(): $.GoError {
+    return this.err
+  }
+}
+
+class wrapErrors {
+  constructor(
+    public msg: string,
+    public errs: $.GoError[],
+  ) {}
+
+  public Error(): string {
+    return this.msg
+  }
+
+  public Unwrap(): $.GoError[] {
+    return this.errs
+  }
+}
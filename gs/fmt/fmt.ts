@@ -1,200 +1,1418 @@
-// Handwritten TypeScript implementation of Go's fmt package
-// Optimized for JavaScript runtime and simplified for common use cases
+// Port of Go's fmt/print.go. Values are printed from their runtime
+// representation, guided by the type information of struct fields and
+// their elements where the representation is ambiguous, as for numbers.
 
 import * as $ from '@goscript/builtin/index.js'
-import * as errors from '@goscript/errors/index.js'
+import {
+  buffer,
+  fmt,
+  ldigits,
+  runeString,
+  udigits,
+} from './format.js'
+import {
+  isChannel,
+  isIntNumber,
+  resolveType,
+  structTypeOf,
+  typeName,
+  typeOf,
+} from './typename.js'
 
-// Basic interfaces
+// Strings for use with buffer.writeString.
+// This is less overhead than using buffer.write with byte arrays.
+const commaSpaceString = ', '
+const nilAngleString = '<nil>'
+const nilParenString = '(nil)'
+const nilString = 'nil'
+const mapString = 'map['
+const percentBangString = '%!'
+const missingString = '(MISSING)'
+const badIndexString = '(BADINDEX)'
+const panicString = '(PANIC='
+const extraString = '%!(EXTRA '
+const badWidthString = '%!(BADWIDTH)'
+const badPrecString = '%!(BADPREC)'
+const noVerbString = '%!(NOVERB)'
+
+// State represents the printer state passed to custom formatters.
+// It provides access to the io.Writer interface plus information about
+// the flags and options for the operand's format specifier.
+export interface State {
+  // Write is the function to call to emit formatted output to be printed.
+  Write(b: $.Bytes): [number, $.GoError]
+  // Width returns the value of the width option and whether it has been set.
+  Width(): [number, boolean]
+  // Precision returns the value of the precision option and whether it has
+  // been set.
+  Precision(): [number, boolean]
+
+  // Flag reports whether the flag c, a character, has been set.
+  Flag(c: number): boolean
+}
+
+// Formatter is implemented by any value that has a Format method.
+// The implementation controls how State and rune are interpreted,
+// and may call Sprint or Fprint(f) etc. to generate its output.
+export interface Formatter {
+  Format(f: State, verb: number): void
+}
+
+$.registerInterfaceType('fmt.Formatter', null, [
+  {
+    name: 'Format',
+    args: [
+      { name: 'f', type: 'fmt.State' },
+      { name: 'verb', type: { kind: $.TypeKind.Basic, name: 'int32' } },
+    ],
+    returns: [],
+  },
+])
+
+// Stringer is implemented by any value that has a String method,
+// which defines the “native” format for that value.
+// The String method is used to print values passed as an operand
+// to any format that accepts a string or to an unformatted printer
+// such as Print.
 export interface Stringer {
   String(): string
 }
 
+$.registerInterfaceType('fmt.Stringer', null, [
+  {
+    name: 'String',
+    args: [],
+    returns: [{ type: { kind: $.TypeKind.Basic, name: 'string' } }],
+  },
+])
+
+// GoStringer is implemented by any value that has a GoString method,
+// which defines the Go syntax for that value.
+// The GoString method is used to print values passed as an operand
+// to a %#v format.
 export interface GoStringer {
   GoString(): string
 }
 
-export interface Formatter {
-  Format(f: State, verb: number): void
+$.registerInterfaceType('fmt.GoStringer', null, [
+  {
+    name: 'GoString',
+    args: [],
+    returns: [{ type: { kind: $.TypeKind.Basic, name: 'string' } }],
+  },
+])
+
+// FormatString returns a string representing the fully qualified formatting
+// directive captured by the State, followed by the argument verb. (State
+// does not itself contain the verb.) The result has a leading percent sign
+// followed by any flags, the width, and the precision. Missing flags, width,
+// and precision are omitted. This function allows a Formatter to
+// reconstruct the original directive triggering the call to Format.
+export function FormatString(state: State, verb: number): string {
+  let b = '%'
+  for (const c of ' +-#0') {
+    // All known flags
+    if (state.Flag(c.charCodeAt(0))) {
+      b += c
+    }
+  }
+  const [w, wok] = state.Width()
+  if (wok) {
+    b += String(w)
+  }
+  const [p, pok] = state.Precision()
+  if (pok) {
+    b += '.' + String(p)
+  }
+  return b + runeString(verb)
 }
 
-export interface State {
-  Flag(c: number): boolean
-  Precision(): [number, boolean]
-  Width(): [number, boolean]
-  Write(b: Uint8Array): [number, $.GoError | null]
-}
-
-// Simple printf-style formatting implementation
-function formatValue(value: any, verb: string): string {
-  if (value === null || value === undefined) {
-    return '<nil>'
-  }
-
-  switch (verb) {
-    case 'v': // default format
-      return defaultFormat(value)
-    case 'd': // decimal integer
-      return String(Math.floor(Number(value)))
-    case 'f': // decimal point, no exponent
-      return Number(value).toString()
-    case 's': // string
-      return String(value)
-    case 't': // boolean
-      return value ? 'true' : 'false'
-    case 'T': // type
-      return typeof value
-    case 'c': // character (Unicode code point)
-      return String.fromCharCode(Number(value))
-    case 'x': // hexadecimal lowercase
-      return Number(value).toString(16)
-    case 'X': // hexadecimal uppercase
-      return Number(value).toString(16).toUpperCase()
-    case 'o': // octal
-      return Number(value).toString(8)
-    case 'b': // binary
-      return Number(value).toString(2)
-    case 'e': // scientific notation lowercase
-      return Number(value).toExponential()
-    case 'E': // scientific notation uppercase
-      return Number(value).toExponential().toUpperCase()
-    case 'g': // %e for large exponents, %f otherwise
-      return Number(value).toPrecision()
-    case 'G': // %E for large exponents, %F otherwise
-      return Number(value).toPrecision().toUpperCase()
-    case 'q': // quoted string
-      return JSON.stringify(String(value))
-    case 'p': // pointer (address)
-      return '0x' + (value as any)?.__address?.toString(16) || '0'
-    default:
-      return String(value)
-  }
-}
-
-function defaultFormat(value: any): string {
-  if (value === null || value === undefined) return '<nil>'
-  if (typeof value === 'boolean') return value ? 'true' : 'false'
-  if (typeof value === 'number') return value.toString()
-  if (typeof value === 'string') return value
-  if (Array.isArray(value))
-    return '[' + value.map(defaultFormat).join(' ') + ']'
-  if (typeof value === 'object') {
-    // Check for Stringer interface
-    if (value.String && typeof value.String === 'function') {
-      return value.String()
-    }
-    // Default object representation
-    if (value.constructor?.name && value.constructor.name !== 'Object') {
-      return `{${Object.entries(value)
-        .map(([k, v]) => `${k}:${defaultFormat(v)}`)
-        .join(' ')}}`
-    }
-    return JSON.stringify(value)
-  }
-  return String(value)
-}
-
-function parseFormat(format: string, args: any[]): string {
-  let result = ''
-  let argIndex = 0
-
-  for (let i = 0; i < format.length; i++) {
-    if (format[i] === '%') {
-      if (i + 1 < format.length) {
-        const nextChar = format[i + 1]
-        if (nextChar === '%') {
-          result += '%'
-          i++ // skip the next %
-          continue
+// The fake addresses printed for pointers, functions and channels.
+const addresses = new WeakMap<object, number>()
+let nextAddress = 0xc000010000
+
+// addressOf returns the fake address of a reference value.
+function addressOf(v: object): number {
+  let addr = addresses.get(v)
+  if (addr === undefined) {
+    addr = nextAddress
+    nextAddress += 0x10
+    addresses.set(v, addr)
+  }
+  return addr
+}
+
+// isError reports whether v implements the error interface.
+function isError(v: any): boolean {
+  return v !== null && typeof v === 'object' && typeof v.Error === 'function'
+}
+
+// hasMethod reports whether v has a method with the given number of
+// parameters.
+function hasMethod(v: any, name: string, params: number): boolean {
+  return typeof v[name] === 'function' && v[name].length === params
+}
+
+// isFloatVerb reports whether verb formats a number as a float.
+function isFloatVerb(verb: number): boolean {
+  return 'eEfFgG'.includes(String.fromCharCode(verb))
+}
+
+// numberKind returns the kind of number of a basic type, or undefined if
+// it is not a number type.
+function numberKind(
+  name: string | undefined,
+): 'signed' | 'unsigned' | 'float32' | 'float64' | undefined {
+  switch (name) {
+    case 'int':
+    case 'int8':
+    case 'int16':
+    case 'int32':
+    case 'int64':
+    case 'rune':
+    case 'bigint':
+      return 'signed'
+    case 'uint':
+    case 'uint8':
+    case 'uint16':
+    case 'uint32':
+    case 'uint64':
+    case 'uintptr':
+    case 'byte':
+      return 'unsigned'
+    case 'float32':
+      return 'float32'
+    case 'float64':
+      return 'float64'
+  }
+  return undefined
+}
+
+// isByteType reports whether t is the uint8 type.
+function isByteType(t: $.TypeInfo | string | undefined): boolean {
+  const info = resolveType(t)
+  if (info?.kind !== $.TypeKind.Basic) {
+    return false
+  }
+  const name = $.basicTypeName(info)
+  return name === 'uint8' || name === 'byte'
+}
+
+// tooLarge reports whether the magnitude of the integer is
+// too large to be used as a formatting width or precision.
+function tooLarge(x: number): boolean {
+  const max = 1e6
+  return x > max || x < -max
+}
+
+// parsenum converts ASCII to integer. num is 0 (and isnum is false) if no
+// number present.
+function parsenum(
+  s: string,
+  start: number,
+  end: number,
+): [number, boolean, number] {
+  if (start >= end) {
+    return [0, false, end]
+  }
+  let num = 0
+  let isnum = false
+  let newi = start
+  for (; newi < end && s[newi] >= '0' && s[newi] <= '9'; newi++) {
+    if (tooLarge(num)) {
+      return [0, false, end] // Overflow; crazy long number most likely.
+    }
+    num = num * 10 + s.charCodeAt(newi) - 48
+    isnum = true
+  }
+  return [num, isnum, newi]
+}
+
+// intFromArg gets the argNumth element of a. On return, isInt reports
+// whether the argument has integer type.
+function intFromArg(a: any[], argNum: number): [number, boolean, number] {
+  let num = 0
+  let isInt = false
+  let newArgNum = argNum
+  if (argNum < a.length) {
+    const v = a[argNum]
+    if (typeof v === 'number' && Number.isInteger(v)) {
+      num = v
+      isInt = true
+    } else if (typeof v === 'bigint') {
+      num = Number(v)
+      isInt = Number.isSafeInteger(num)
+    }
+    newArgNum = argNum + 1
+    if (tooLarge(num)) {
+      num = 0
+      isInt = false // Argument too large or too small.
+    }
+  }
+  return [num, isInt, newArgNum]
+}
+
+// parseArgNumber returns the value of the bracketed number, minus 1
+// (explicit argument numbers are one-indexed but we want zero-indexed).
+// The opening bracket is known to be present at format[0].
+// The returned values are the index, the number of bytes to consume
+// up to the closing paren, if present, and whether the number parsed
+// ok. The bytes to consume will be 1 if no closing paren is present.
+function parseArgNumber(format: string): [number, number, boolean] {
+  // There must be at least 3 bytes: [n].
+  if (format.length < 3) {
+    return [0, 1, false]
+  }
+
+  // Find closing bracket.
+  for (let i = 1; i < format.length; i++) {
+    if (format[i] === ']') {
+      const [width, ok, newi] = parsenum(format, 1, i)
+      if (!ok || newi !== i) {
+        return [0, i + 1, false]
+      }
+      // arg numbers are one-indexed and skip paren.
+      return [width - 1, i + 1, true]
+    }
+  }
+  return [0, 1, false]
+}
+
+// compareKeys orders map keys like Go's internal/fmtsort: numbers, strings
+// and booleans by value, structs and arrays by their elements and
+// references by address.
+function compareKeys(a: any, b: any): number {
+  if (a === b) {
+    return 0
+  }
+  if (a === null || a === undefined) {
+    return -1
+  }
+  if (b === null || b === undefined) {
+    return 1
+  }
+  if (typeof a !== typeof b) {
+    return typeof a < typeof b ? -1 : 1
+  }
+  switch (typeof a) {
+    case 'number':
+    case 'bigint':
+    case 'string':
+      if (a < b) {
+        return -1
+      }
+      if (a > b) {
+        return 1
+      }
+      // NaNs sort first
+      if (typeof a === 'number' && Number.isNaN(a)) {
+        return Number.isNaN(b) ? 0 : -1
+      }
+      return typeof b === 'number' && Number.isNaN(b) ? 1 : 0
+    case 'boolean':
+      return a ? 1 : -1
+  }
+  if (a instanceof $.Complex) {
+    return compareKeys(a.re, b.re) || compareKeys(a.im, b.im)
+  }
+  if (Array.isArray(a) || a instanceof Uint8Array) {
+    for (let i = 0; i < a.length && i < b.length; i++) {
+      const c = compareKeys(a[i], b[i])
+      if (c !== 0) {
+        return c
+      }
+    }
+    return a.length - b.length
+  }
+  if (a._fields !== undefined && b._fields !== undefined) {
+    for (const name of Object.keys(a._fields)) {
+      const c = compareKeys(a._fields[name].value, b._fields[name]?.value)
+      if (c !== 0) {
+        return c
+      }
+    }
+    return 0
+  }
+  if (typeof a === 'object' && typeof b === 'object') {
+    return addressOf(a) - addressOf(b)
+  }
+  return 0
+}
+
+// pp is used to store a printer's state and implements State.
+export class pp implements State {
+  public buf = new buffer()
+
+  // fmt is the formatter for basic values.
+  public fmt = new fmt(this.buf)
+
+  // reordered records whether the format string used argument reordering.
+  public reordered = false
+  // goodArgNum records whether the most recent reordering directive was
+  // valid.
+  public goodArgNum = false
+  // panicking is set by catchPanic to avoid infinite panic, recover, panic,
+  // ... recursion.
+  public panicking = false
+  // erroring is set when printing an error string to guard against calling
+  // handleMethods.
+  public erroring = false
+  // wrapErrs is set when the format string may contain a %w verb.
+  public wrapErrs = false
+  // wrappedErrs records the targets of the %w verb.
+  public wrappedErrs: number[] = []
+
+  public Width(): [number, boolean] {
+    return [this.fmt.wid, this.fmt.widPresent]
+  }
+
+  public Precision(): [number, boolean] {
+    return [this.fmt.prec, this.fmt.precPresent]
+  }
+
+  public Flag(b: number): boolean {
+    switch (String.fromCharCode(b)) {
+      case '-':
+        return this.fmt.minus
+      case '+':
+        return this.fmt.plus || this.fmt.plusV
+      case '#':
+        return this.fmt.sharp || this.fmt.sharpV
+      case ' ':
+        return this.fmt.space
+      case '0':
+        return this.fmt.zero
+    }
+    return false
+  }
+
+  // Write implements io.Writer so we can call Fprintf on a pp (through
+  // State), for recursive use in custom verbs.
+  public Write(b: $.Bytes): [number, $.GoError] {
+    const bytes = b instanceof Uint8Array ? b : Uint8Array.from($.asArray(b))
+    this.buf.write(bytes)
+    return [bytes.length, null]
+  }
+
+  // WriteString implements io.StringWriter so that we can call
+  // io.WriteString on a pp (through state), for efficiency.
+  public WriteString(s: string): [number, $.GoError] {
+    this.buf.writeString(s)
+    return [$.len(s), null]
+  }
+
+  public unknownType(v: any): void {
+    if (v === null || v === undefined) {
+      this.buf.writeString(nilAngleString)
+      return
+    }
+    this.buf.writeString('?' + typeOf(v) + '?')
+  }
+
+  // badVerb writes an error for a verb that does not apply to the value,
+  // with the type t of the value if known.
+  public badVerb(v: any, verb: number, t?: $.TypeInfo | string): void {
+    this.erroring = true
+    this.buf.writeString(percentBangString)
+    this.buf.writeRune(verb)
+    this.buf.writeString('(')
+    if (v !== null && v !== undefined) {
+      this.buf.writeString(typeOf(v, t) + '=')
+      if (t === undefined) {
+        this.printArg(v, 0x76) // 'v'
+      } else {
+        this.printValue(v, 0x76, 0, t)
+      }
+    } else if (t !== undefined) {
+      this.buf.writeString(typeName(t) + '=')
+      this.printValue(v, 0x76, 0, t)
+    } else {
+      this.buf.writeString(nilAngleString)
+    }
+    this.buf.writeString(')')
+    this.erroring = false
+  }
+
+  public fmtBool(v: boolean, verb: number, t?: $.TypeInfo | string): void {
+    switch (String.fromCharCode(verb)) {
+      case 't':
+      case 'v':
+        this.fmt.fmtBoolean(v)
+        break
+      default:
+        this.badVerb(v, verb, t)
+    }
+  }
+
+  // fmt0x64 formats a uint64 in hexadecimal and prefixes it with 0x or
+  // not, as requested, by temporarily setting the sharp flag.
+  public fmt0x64(v: bigint, leading0x: boolean): void {
+    const sharp = this.fmt.sharp
+    this.fmt.sharp = leading0x
+    this.fmt.fmtInteger(v, 16, false, 0x76, ldigits)
+    this.fmt.sharp = sharp
+  }
+
+  // fmtInteger formats a signed or unsigned integer.
+  public fmtInteger(
+    value: any,
+    v: bigint,
+    isSigned: boolean,
+    verb: number,
+    t?: $.TypeInfo | string,
+  ): void {
+    switch (String.fromCharCode(verb)) {
+      case 'v':
+        if (this.fmt.sharpV && !isSigned) {
+          this.fmt0x64(v, true)
+        } else {
+          this.fmt.fmtInteger(v, 10, isSigned, verb, ldigits)
         }
+        break
+      case 'd':
+        this.fmt.fmtInteger(v, 10, isSigned, verb, ldigits)
+        break
+      case 'b':
+        this.fmt.fmtInteger(v, 2, isSigned, verb, ldigits)
+        break
+      case 'o':
+      case 'O':
+        this.fmt.fmtInteger(v, 8, isSigned, verb, ldigits)
+        break
+      case 'x':
+        this.fmt.fmtInteger(v, 16, isSigned, verb, ldigits)
+        break
+      case 'X':
+        this.fmt.fmtInteger(v, 16, isSigned, verb, udigits)
+        break
+      case 'c':
+        this.fmt.fmtC(v)
+        break
+      case 'q':
+        this.fmt.fmtQc(v)
+        break
+      case 'U':
+        this.fmt.fmtUnicode(v)
+        break
+      default:
+        this.badVerb(value, verb, t)
+    }
+  }
 
-        // Parse format specifier
-        let j = i + 1
-        let width = ''
-        let precision = ''
-        let flags = ''
+  // fmtFloat formats a float. The default precision for each verb
+  // is specified as last argument in the call to fmt_float.
+  public fmtFloat(
+    v: number,
+    size: number,
+    verb: number,
+    t?: $.TypeInfo | string,
+  ): void {
+    switch (String.fromCharCode(verb)) {
+      case 'v':
+        this.fmt.fmtFloat(v, size, 0x67, -1) // 'g'
+        break
+      case 'b':
+      case 'g':
+      case 'G':
+      case 'x':
+      case 'X':
+        this.fmt.fmtFloat(v, size, verb, -1)
+        break
+      case 'f':
+      case 'e':
+      case 'E':
+        this.fmt.fmtFloat(v, size, verb, 6)
+        break
+      case 'F':
+        this.fmt.fmtFloat(v, size, 0x66, 6) // 'f'
+        break
+      default:
+        this.badVerb(v, verb, t ?? (size === 32 ? 'float32' : 'float64'))
+    }
+  }
+
+  // fmtComplex formats a complex number v with
+  // r = real(v) and j = imag(v) as (r+ji) using
+  // fmtFloat for r and j formatting.
+  public fmtComplex(
+    v: $.Complex,
+    size: number,
+    verb: number,
+    t?: $.TypeInfo | string,
+  ): void {
+    // Make sure any unsupported verbs are found before the
+    // calls to fmtFloat to not generate an incorrect error string.
+    switch (String.fromCharCode(verb)) {
+      case 'v':
+      case 'b':
+      case 'g':
+      case 'G':
+      case 'x':
+      case 'X':
+      case 'f':
+      case 'F':
+      case 'e':
+      case 'E': {
+        const oldPlus = this.fmt.plus
+        this.buf.writeString('(')
+        this.fmtFloat(v.re, size / 2, verb)
+        // Imaginary part always has a sign.
+        this.fmt.plus = true
+        this.fmtFloat(v.im, size / 2, verb)
+        this.buf.writeString('i)')
+        this.fmt.plus = oldPlus
+        break
+      }
+      default:
+        this.badVerb(v, verb, t)
+    }
+  }
 
-        // Parse flags (-, +, #, 0, space)
-        while (j < format.length && '+-# 0'.includes(format[j])) {
-          flags += format[j]
-          j++
+  public fmtString(
+    value: any,
+    v: string,
+    verb: number,
+    t?: $.TypeInfo | string,
+  ): void {
+    switch (String.fromCharCode(verb)) {
+      case 'v':
+        if (this.fmt.sharpV) {
+          this.fmt.fmtQ(v)
+        } else {
+          this.fmt.fmtS(v)
         }
+        break
+      case 's':
+        this.fmt.fmtS(v)
+        break
+      case 'x':
+        this.fmt.fmtSx(v, ldigits)
+        break
+      case 'X':
+        this.fmt.fmtSx(v, udigits)
+        break
+      case 'q':
+        this.fmt.fmtQ(v)
+        break
+      default:
+        this.badVerb(value, verb, t)
+    }
+  }
 
-        // Parse width
-        while (j < format.length && format[j] >= '0' && format[j] <= '9') {
-          width += format[j]
-          j++
+  public fmtBytes(
+    v: Uint8Array,
+    isNil: boolean,
+    verb: number,
+    typeString: string,
+  ): void {
+    switch (String.fromCharCode(verb)) {
+      case 'v':
+      case 'd':
+        if (this.fmt.sharpV) {
+          this.buf.writeString(typeString)
+          if (isNil) {
+            this.buf.writeString(nilParenString)
+            return
+          }
+          this.buf.writeString('{')
+          for (let i = 0; i < v.length; i++) {
+            if (i > 0) {
+              this.buf.writeString(commaSpaceString)
+            }
+            this.fmt0x64(BigInt(v[i]), true)
+          }
+          this.buf.writeString('}')
+        } else {
+          this.buf.writeString('[')
+          for (let i = 0; i < v.length; i++) {
+            if (i > 0) {
+              this.buf.writeString(' ')
+            }
+            this.fmt.fmtInteger(BigInt(v[i]), 10, false, verb, ldigits)
+          }
+          this.buf.writeString(']')
         }
+        break
+      case 's':
+        this.fmt.fmtS($.bytesToString(v))
+        break
+      case 'x':
+        this.fmt.fmtSbx(v, ldigits)
+        break
+      case 'X':
+        this.fmt.fmtSbx(v, udigits)
+        break
+      case 'q':
+        this.fmt.fmtQ($.bytesToString(v))
+        break
+      default:
+        this.printList(Array.from(v), isNil, verb, 0, 'uint8', typeString)
+    }
+  }
+
+  // fmtPointer formats a reference value, which has no address in
+  // JavaScript, with a fake address unique to the value.
+  public fmtPointer(value: any, verb: number, t?: $.TypeInfo | string): void {
+    const u =
+      value === null || value === undefined ? 0n : BigInt(addressOf(value))
 
-        // Parse precision
-        if (j < format.length && format[j] === '.') {
-          j++
-          while (j < format.length && format[j] >= '0' && format[j] <= '9') {
-            precision += format[j]
-            j++
+    switch (String.fromCharCode(verb)) {
+      case 'v':
+        if (this.fmt.sharpV) {
+          this.buf.writeString('(' + typeOf(value, t) + ')(')
+          if (u === 0n) {
+            this.buf.writeString(nilString)
+          } else {
+            this.fmt0x64(u, true)
+          }
+          this.buf.writeString(')')
+        } else {
+          if (u === 0n) {
+            this.fmt.pad(nilAngleString)
+          } else {
+            this.fmt0x64(u, !this.fmt.sharp)
           }
         }
+        break
+      case 'p':
+        this.fmt0x64(u, !this.fmt.sharp)
+        break
+      case 'b':
+      case 'o':
+      case 'd':
+      case 'x':
+      case 'X':
+        this.fmtInteger(value, u, false, verb, t)
+        break
+      default:
+        this.badVerb(value, verb, t)
+    }
+  }
 
-        // Get the verb
-        if (j < format.length) {
-          const verb = format[j]
-
-          if (argIndex < args.length) {
-            let formatted = formatValue(args[argIndex], verb)
-
-            // Apply width and precision formatting
-            if (width && !precision) {
-              const w = parseInt(width)
-              if (flags.includes('-')) {
-                formatted = formatted.padEnd(w)
-              } else {
-                formatted = formatted.padStart(
-                  w,
-                  flags.includes('0') ? '0' : ' ',
-                )
-              }
-            } else if (
-              precision &&
-              (verb === 'f' || verb === 'e' || verb === 'g')
-            ) {
-              const p = parseInt(precision)
-              const num = Number(args[argIndex])
-              if (verb === 'f') {
-                formatted = num.toFixed(p)
-              } else if (verb === 'e') {
-                formatted = num.toExponential(p)
-              } else if (verb === 'g') {
-                formatted = num.toPrecision(p)
-              }
+  // catchPanic prints a panic raised by a method of arg called to format
+  // it, or rethrows it while already printing a panic.
+  public catchPanic(thrown: unknown, verb: number, method: string): void {
+    if (this.panicking) {
+      // Nested panics; the recursion in printArg cannot succeed.
+      throw thrown
+    }
 
-              if (width) {
-                const w = parseInt(width)
-                if (flags.includes('-')) {
-                  formatted = formatted.padEnd(w)
-                } else {
-                  formatted = formatted.padStart(w)
-                }
-              }
-            }
+    const oldFlags = this.fmt.flags()
+    // For this output we want default behavior.
+    this.fmt.clearflags()
+
+    this.buf.writeString(percentBangString)
+    this.buf.writeRune(verb)
+    this.buf.writeString(panicString)
+    this.buf.writeString(method)
+    this.buf.writeString(' method: ')
+    this.panicking = true
+    this.printArg($.panicValue(thrown), 0x76) // 'v'
+    this.panicking = false
+    this.buf.writeString(')')
 
-            result += formatted
-            argIndex++
+    this.fmt.setFlags(oldFlags)
+  }
+
+  // callMethod calls a method of the value being printed, printing a panic
+  // in the method instead of its result.
+  public callMethod(verb: number, method: string, call: () => void): void {
+    try {
+      call()
+    } catch (thrown) {
+      this.catchPanic(thrown, verb, method)
+    }
+  }
+
+  // handleMethods formats arg with its Format, GoString, Error or String
+  // method, and reports whether it did.
+  public handleMethods(
+    arg: any,
+    verb: number,
+    t?: $.TypeInfo | string,
+  ): boolean {
+    if (this.erroring) {
+      return false
+    }
+    if (t !== undefined) {
+      // Values of named non-struct types only carry their methods boxed
+      arg = $.namedValue(arg, t)
+    }
+    if (verb === 0x77) {
+      // 'w'
+      // It is invalid to use %w other than with Errorf or with a non-error
+      // arg.
+      if (!isError(arg) || !this.wrapErrs) {
+        this.badVerb(arg, verb, t)
+        return true
+      }
+      // If the arg is a Formatter, pass 'v' as the verb to it.
+      verb = 0x76 // 'v'
+    }
+    if (arg === null || typeof arg !== 'object') {
+      return false
+    }
+
+    // Is it a Formatter?
+    if (hasMethod(arg, 'Format', 2)) {
+      this.callMethod(verb, 'Format', () => arg.Format(this, verb))
+      return true
+    }
+
+    // If we're doing Go syntax and the argument knows how to supply it,
+    // take care of it now.
+    if (this.fmt.sharpV) {
+      if (hasMethod(arg, 'GoString', 0)) {
+        // Print the result of GoString unadorned.
+        this.callMethod(verb, 'GoString', () =>
+          this.fmt.fmtS(arg.GoString()),
+        )
+        return true
+      }
+    } else {
+      // If a string is acceptable according to the format, see if
+      // the value satisfies one of the string-valued interfaces.
+      switch (String.fromCharCode(verb)) {
+        case 'v':
+        case 's':
+        case 'x':
+        case 'X':
+        case 'q':
+          // Is it an error or Stringer?
+          if (isError(arg)) {
+            this.callMethod(verb, 'Error', () =>
+              this.fmtString(arg, arg.Error(), verb, t),
+            )
+            return true
+          }
+          if (hasMethod(arg, 'String', 0)) {
+            this.callMethod(verb, 'String', () =>
+              this.fmtString(arg, arg.String(), verb, t),
+            )
+            return true
+          }
+      }
+    }
+    return false
+  }
+
+  public printArg(arg: any, verb: number): void {
+    if (arg === null || arg === undefined) {
+      switch (String.fromCharCode(verb)) {
+        case 'T':
+        case 'v':
+          this.fmt.pad(nilAngleString)
+          break
+        default:
+          this.badVerb(arg, verb)
+      }
+      return
+    }
+
+    // Special processing considerations.
+    // %T (the value's type) and %p (its address) are special; we always do
+    // them first.
+    switch (String.fromCharCode(verb)) {
+      case 'T': {
+        // A struct without the mark of a struct value is a pointer
+        const structInfo = structTypeOf(arg)
+        this.fmt.fmtS(
+          structInfo !== undefined && !$.isStructValue(arg, true) ?
+            '*' + typeName(structInfo)
+          : typeOf(arg),
+        )
+        return
+      }
+      case 'p':
+        this.fmtPointer(arg, 0x70) // 'p'
+        return
+    }
+
+    if (!this.handleMethods(arg, verb)) {
+      this.printValue(arg, verb, 0)
+    }
+  }
+
+  // printValue formats a value, guided by its type information t if known.
+  // Values of unexported struct fields, for which noMethods is set, are
+  // printed without calling their methods.
+  public printValue(
+    value: any,
+    verb: number,
+    depth: number,
+    t?: $.TypeInfo | string,
+    noMethods = false,
+  ): void {
+    // Handle values with special methods if not already handled by printArg
+    // (depth == 0).
+    if (depth > 0 && !noMethods && value !== null && value !== undefined) {
+      if (this.handleMethods(value, verb, t)) {
+        return
+      }
+    }
+
+    if (value instanceof $.NamedValue) {
+      // A boxed value prints as the value of its type
+      t = value.type
+      value = value.value
+    } else if (
+      t === undefined ||
+      resolveType(t)?.kind === $.TypeKind.Interface
+    ) {
+      t = $.markedType(value) ?? t
+    }
+
+    let info = resolveType(t)
+    if (info?.kind === $.TypeKind.Interface) {
+      // An interface holds a value of another type.
+      if (value === null || value === undefined) {
+        if (this.fmt.sharpV) {
+          this.buf.writeString(typeName(t) + nilParenString)
+        } else {
+          this.buf.writeString(nilAngleString)
+        }
+        return
+      }
+      t = info = undefined
+    }
+
+    if (value === null || value === undefined) {
+      switch (info?.kind) {
+        case $.TypeKind.Slice:
+          if (isByteType(info.elemType)) {
+            this.fmtBytes(new Uint8Array(0), true, verb, typeName(t))
           } else {
-            result += `%!${verb}(MISSING)`
+            this.printList([], true, verb, depth, info.elemType, typeName(t))
           }
+          return
+        case $.TypeKind.Map:
+          this.printMap(new Map(), true, verb, depth, info, typeName(t))
+          return
+        case $.TypeKind.Pointer:
+        case $.TypeKind.Function:
+        case $.TypeKind.Channel:
+          this.fmtPointer(null, verb, t)
+          return
+      }
+      if (depth === 0) {
+        this.unknownType(value)
+        return
+      }
+      switch (String.fromCharCode(verb)) {
+        case 'v':
+          this.buf.writeString(nilAngleString)
+          break
+        default:
+          this.badVerb(value, verb)
+      }
+      return
+    }
 
-          i = j
+    switch (typeof value) {
+      case 'boolean':
+        this.fmtBool(value, verb, t)
+        return
+      case 'number': {
+        const kind = numberKind(info && $.basicTypeName(info))
+        if (kind === 'float32' || kind === 'float64') {
+          this.fmtFloat(value, kind === 'float32' ? 32 : 64, verb, t)
+        } else if (kind !== undefined) {
+          this.fmtInteger(value, BigInt(value), kind === 'signed', verb, t)
+        } else if (isFloatVerb(verb) || !isIntNumber(value)) {
+          this.fmtFloat(value, 64, verb, t)
         } else {
-          result += format[i]
+          this.fmtInteger(value, BigInt(value), true, verb, t)
         }
-      } else {
-        result += format[i]
+        return
+      }
+      case 'bigint': {
+        const kind = numberKind(info && $.basicTypeName(info))
+        this.fmtInteger(value, value, kind !== 'unsigned', verb, t)
+        return
+      }
+      case 'string':
+        this.fmtString(value, value, verb, t)
+        return
+      case 'function':
+        this.fmtPointer(value, verb, t ?? { kind: $.TypeKind.Function })
+        return
+    }
+
+    if (value instanceof $.Complex) {
+      const size = info?.name === 'complex64' ? 64 : 128
+      this.fmtComplex(value, size, verb, t)
+      return
+    }
+    if (value instanceof Uint8Array) {
+      const typeString =
+        t === undefined && depth === 0 ? '[]byte' : typeOf(value, t)
+      this.fmtBytes(value, false, verb, typeString)
+      return
+    }
+    if (Array.isArray(value) || $.isSliceProxy(value)) {
+      const elemType =
+        info?.kind === $.TypeKind.Slice || info?.kind === $.TypeKind.Array ?
+          info.elemType
+        : $.isByteArray(value) ? 'uint8'
+        : undefined
+      const elems = $.asArray(value)
+      switch (String.fromCharCode(verb)) {
+        case 's':
+        case 'q':
+        case 'x':
+        case 'X':
+          // Handle byte and uint8 slices and arrays special for the above
+          // verbs.
+          if (isByteType(elemType)) {
+            this.fmtBytes(Uint8Array.from(elems), false, verb, typeOf(value, t))
+            return
+          }
+      }
+      this.printList(elems, false, verb, depth, elemType, typeOf(value, t))
+      return
+    }
+    if (value instanceof Map) {
+      const mapInfo = info?.kind === $.TypeKind.Map ? info : undefined
+      this.printMap(value, false, verb, depth, mapInfo, typeOf(value, t))
+      return
+    }
+    if ($.isVarRef(value)) {
+      // pointer to array or slice or struct? ok at top level
+      // but not embedded (avoid loops)
+      const target = value.value
+      if (depth === 0 && target !== null && typeof target === 'object') {
+        if (
+          Array.isArray(target) ||
+          $.isSliceProxy(target) ||
+          target instanceof Uint8Array ||
+          target instanceof Map ||
+          structTypeOf(target) !== undefined
+        ) {
+          const elemType =
+            info?.kind === $.TypeKind.Pointer ? info.elemType : undefined
+          this.buf.writeString('&')
+          this.printValue(target, verb, depth + 1, elemType)
+          return
+        }
+      }
+      this.fmtPointer(value, verb, t)
+      return
+    }
+    if (isChannel(value) || info?.kind === $.TypeKind.Pointer) {
+      // A struct is its own pointer, so pointers to structs inside other
+      // values are only known from their type.
+      this.fmtPointer(value, verb, t)
+      return
+    }
+    if (isError(value) && structTypeOf(value) === undefined) {
+      // The errors created by errors.New are pointers.
+      this.fmtPointer(value, verb, t)
+      return
+    }
+    if (
+      depth === 0 &&
+      t === undefined &&
+      structTypeOf(value) !== undefined &&
+      !$.isStructValue(value, true)
+    ) {
+      // A struct without the mark of a struct value is a pointer
+      this.buf.writeString('&')
+      this.printStruct(value, verb, depth + 1, info, t)
+      return
+    }
+    this.printStruct(value, verb, depth, info, t)
+  }
+
+  // printList prints the elements of a slice or an array.
+  public printList(
+    elems: any[],
+    isNil: boolean,
+    verb: number,
+    depth: number,
+    elemType: $.TypeInfo | string | undefined,
+    typeString: string,
+  ): void {
+    if (this.fmt.sharpV) {
+      this.buf.writeString(typeString)
+      if (isNil) {
+        this.buf.writeString(nilParenString)
+        return
+      }
+      this.buf.writeString('{')
+      for (let i = 0; i < elems.length; i++) {
+        if (i > 0) {
+          this.buf.writeString(commaSpaceString)
+        }
+        this.printValue(elems[i], verb, depth + 1, elemType)
+      }
+      this.buf.writeString('}')
+    } else {
+      this.buf.writeString('[')
+      for (let i = 0; i < elems.length; i++) {
+        if (i > 0) {
+          this.buf.writeString(' ')
+        }
+        this.printValue(elems[i], verb, depth + 1, elemType)
+      }
+      this.buf.writeString(']')
+    }
+  }
+
+  // printMap prints the entries of a map sorted by key.
+  public printMap(
+    m: Map<any, any>,
+    isNil: boolean,
+    verb: number,
+    depth: number,
+    info: $.MapTypeInfo | undefined,
+    typeString: string,
+  ): void {
+    if (this.fmt.sharpV) {
+      this.buf.writeString(typeString)
+      if (isNil) {
+        this.buf.writeString(nilParenString)
+        return
       }
+      this.buf.writeString('{')
+    } else {
+      this.buf.writeString(mapString)
+    }
+    const sorted = Array.from(m.entries()).sort((a, b) =>
+      compareKeys(a[0], b[0]),
+    )
+    for (let i = 0; i < sorted.length; i++) {
+      if (i > 0) {
+        if (this.fmt.sharpV) {
+          this.buf.writeString(commaSpaceString)
+        } else {
+          this.buf.writeString(' ')
+        }
+      }
+      this.printValue(sorted[i][0], verb, depth + 1, info?.keyType)
+      this.buf.writeString(':')
+      this.printValue(sorted[i][1], verb, depth + 1, info?.elemType)
+    }
+    if (this.fmt.sharpV) {
+      this.buf.writeString('}')
+    } else {
+      this.buf.writeString(']')
+    }
+  }
+
+  // printStruct prints the fields of a struct, which is a registered struct
+  // type or, for anonymous structs, a plain object.
+  public printStruct(
+    value: any,
+    verb: number,
+    depth: number,
+    info: $.TypeInfo | undefined,
+    t: $.TypeInfo | string | undefined,
+  ): void {
+    const structInfo =
+      structTypeOf(value) ??
+      (info?.kind === $.TypeKind.Struct ? info : undefined)
+    let fields: {
+      name: string
+      type?: $.TypeInfo | string
+      exported?: boolean
+    }[]
+    if (structInfo !== undefined && structInfo.fields !== undefined) {
+      fields = $.structFields(structInfo.fields)
     } else {
-      result += format[i]
+      fields = Object.keys(value._fields ?? value)
+        .filter((name) => typeof value[name] !== 'function')
+        .map((name) => ({ name }))
+    }
+
+    if (this.fmt.sharpV) {
+      this.buf.writeString(
+        structInfo !== undefined ? typeName(structInfo) : typeOf(value, t),
+      )
     }
+    this.buf.writeString('{')
+    for (let i = 0; i < fields.length; i++) {
+      const field = fields[i]
+      if (i > 0) {
+        if (this.fmt.sharpV) {
+          this.buf.writeString(commaSpaceString)
+        } else {
+          this.buf.writeString(' ')
+        }
+      }
+      if (this.fmt.plusV || this.fmt.sharpV) {
+        this.buf.writeString(field.name + ':')
+      }
+      // Blank fields have no storage and print as zero values
+      const ref = value._fields?.[field.name]
+      const fieldValue =
+        field.name === '_' ? $.zeroValue(field.type)
+        : ref !== undefined ? ref.value
+        : value[field.name]
+      this.printValue(
+        fieldValue,
+        verb,
+        depth + 1,
+        field.type,
+        field.exported === false,
+      )
+    }
+    this.buf.writeString('}')
   }
 
-  return result
+  // argNumber returns the next argument to evaluate, which is either the
+  // value of the passed-in argNum or the value of the bracketed integer
+  // that begins format[i:]. It also returns the new value of i, that is,
+  // the index of the next byte of the format to process.
+  public argNumber(
+    argNum: number,
+    format: string,
+    i: number,
+    numArgs: number,
+  ): [number, number, boolean] {
+    if (format.length <= i || format[i] !== '[') {
+      return [argNum, i, false]
+    }
+    this.reordered = true
+    const [index, wid, ok] = parseArgNumber(format.slice(i))
+    if (ok && 0 <= index && index < numArgs) {
+      return [index, i + wid, true]
+    }
+    this.goodArgNum = false
+    return [argNum, i + wid, ok]
+  }
+
+  public badArgNum(verb: number): void {
+    this.buf.writeString(percentBangString)
+    this.buf.writeRune(verb)
+    this.buf.writeString(badIndexString)
+  }
+
+  public missingArg(verb: number): void {
+    this.buf.writeString(percentBangString)
+    this.buf.writeRune(verb)
+    this.buf.writeString(missingString)
+  }
+
+  public doPrintf(format: string, a: any[]): void {
+    const end = format.length
+    let argNum = 0 // we process one argument per non-trivial format
+    let afterIndex = false // previous item in format was an index like [3].
+    this.reordered = false
+    formatLoop: for (let i = 0; i < end; ) {
+      this.goodArgNum = true
+      const lasti = i
+      while (i < end && format[i] !== '%') {
+        i++
+      }
+      if (i > lasti) {
+        this.buf.writeString(format.slice(lasti, i))
+      }
+      if (i >= end) {
+        // done processing format string
+        break
+      }
+
+      // Process one verb
+      i++
+
+      // Do we have flags?
+      this.fmt.clearflags()
+      simpleFormat: for (; i < end; i++) {
+        const c = format[i]
+        switch (c) {
+          case '#':
+            this.fmt.sharp = true
+            break
+          case '0':
+            this.fmt.zero = true
+            break
+          case '+':
+            this.fmt.plus = true
+            break
+          case '-':
+            this.fmt.minus = true
+            break
+          case ' ':
+            this.fmt.space = true
+            break
+          default:
+            // Fast path for common case of ascii lower case simple verbs
+            // without precision or width or argument indices.
+            if ('a' <= c && c <= 'z' && argNum < a.length) {
+              switch (c) {
+                case 'w':
+                  this.wrappedErrs.push(argNum)
+                // fallthrough
+                case 'v':
+                  // Go syntax
+                  this.fmt.sharpV = this.fmt.sharp
+                  this.fmt.sharp = false
+                  // Struct-field syntax
+                  this.fmt.plusV = this.fmt.plus
+                  this.fmt.plus = false
+              }
+              this.printArg(a[argNum], c.charCodeAt(0))
+              argNum++
+              i++
+              continue formatLoop
+            }
+            // Format is more complex than simple flags and a verb or is
+            // malformed.
+            break simpleFormat
+        }
+      }
+
+      // Do we have an explicit argument index?
+      ;[argNum, i, afterIndex] = this.argNumber(argNum, format, i, a.length)
+
+      // Do we have width?
+      if (i < end && format[i] === '*') {
+        i++
+        ;[this.fmt.wid, this.fmt.widPresent, argNum] = intFromArg(a, argNum)
+
+        if (!this.fmt.widPresent) {
+          this.buf.writeString(badWidthString)
+        }
+
+        // We have a negative width, so take its value and ensure
+        // that the minus flag is set
+        if (this.fmt.wid < 0) {
+          this.fmt.wid = -this.fmt.wid
+          this.fmt.minus = true
+          this.fmt.zero = false // Do not pad with zeros to the right.
+        }
+        afterIndex = false
+      } else {
+        ;[this.fmt.wid, this.fmt.widPresent, i] = parsenum(format, i, end)
+        if (afterIndex && this.fmt.widPresent) {
+          // "%[3]2d"
+          this.goodArgNum = false
+        }
+      }
+
+      // Do we have precision?
+      if (i + 1 < end && format[i] === '.') {
+        i++
+        if (afterIndex) {
+          // "%[3].2d"
+          this.goodArgNum = false
+        }
+        ;[argNum, i, afterIndex] = this.argNumber(argNum, format, i, a.length)
+        if (i < end && format[i] === '*') {
+          i++
+          ;[this.fmt.prec, this.fmt.precPresent, argNum] = intFromArg(
+            a,
+            argNum,
+          )
+          // Negative precision arguments don't make sense
+          if (this.fmt.prec < 0) {
+            this.fmt.prec = 0
+            this.fmt.precPresent = false
+          }
+          if (!this.fmt.precPresent) {
+            this.buf.writeString(badPrecString)
+          }
+          afterIndex = false
+        } else {
+          ;[this.fmt.prec, this.fmt.precPresent, i] = parsenum(format, i, end)
+          if (!this.fmt.precPresent) {
+            this.fmt.prec = 0
+            this.fmt.precPresent = true
+          }
+        }
+      }
+
+      if (!afterIndex) {
+        ;[argNum, i, afterIndex] = this.argNumber(argNum, format, i, a.length)
+      }
+
+      if (i >= end) {
+        this.buf.writeString(noVerbString)
+        break
+      }
+
+      const verb = format.codePointAt(i)!
+      i += verb > 0xffff ? 2 : 1
+
+      if (verb === 0x25) {
+        // '%': Percent does not absorb operands and ignores f.wid and
+        // f.prec.
+        this.buf.writeString('%')
+      } else if (!this.goodArgNum) {
+        this.badArgNum(verb)
+      } else if (argNum >= a.length) {
+        // No argument left over to print for the current verb.
+        this.missingArg(verb)
+      } else {
+        if (verb === 0x77) {
+          // 'w'
+          this.wrappedErrs.push(argNum)
+        }
+        if (verb === 0x77 || verb === 0x76) {
+          // 'w', 'v': Go syntax
+          this.fmt.sharpV = this.fmt.sharp
+          this.fmt.sharp = false
+          // Struct-field syntax
+          this.fmt.plusV = this.fmt.plus
+          this.fmt.plus = false
+        }
+        this.printArg(a[argNum], verb)
+        argNum++
+      }
+    }
+
+    // Check for extra arguments unless the call accessed the arguments
+    // out of order, in which case it's too expensive to detect if they've
+    // all been used and arguably OK if they're not.
+    if (!this.reordered && argNum < a.length) {
+      this.fmt.clearflags()
+      this.buf.writeString(extraString)
+      for (let i = argNum; i < a.length; i++) {
+        const arg = a[i]
+        if (i > argNum) {
+          this.buf.writeString(commaSpaceString)
+        }
+        if (arg === null || arg === undefined) {
+          this.buf.writeString(nilAngleString)
+        } else {
+          this.buf.writeString(typeOf(arg) + '=')
+          this.printArg(arg, 0x76) // 'v'
+        }
+      }
+      this.buf.writeString(')')
+    }
+  }
+
+  public doPrint(a: any[]): void {
+    let prevString = false
+    for (let argNum = 0; argNum < a.length; argNum++) {
+      const arg = a[argNum]
+      const isString = typeof arg === 'string'
+      // Add a space between two non-string arguments.
+      if (argNum > 0 && !isString && !prevString) {
+        this.buf.writeString(' ')
+      }
+      this.printArg(arg, 0x76) // 'v'
+      prevString = isString
+    }
+  }
+
+  // doPrintln is like doPrint but always adds a space between arguments
+  // and a newline after the last argument.
+  public doPrintln(a: any[]): void {
+    for (let argNum = 0; argNum < a.length; argNum++) {
+      if (argNum > 0) {
+        this.buf.writeString(' ')
+      }
+      this.printArg(a[argNum], 0x76) // 'v'
+    }
+    this.buf.writeString('\n')
+  }
+}
+
+// newPrinter allocates a new pp struct.
+export function newPrinter(): pp {
+  return new pp()
 }
 
 // Global stdout simulation for Print functions
@@ -207,7 +1425,8 @@ let stdout = {
       process.stdout &&
       process.stdout.write
     ) {
-      process.stdout.write(data)
+      // Write the bytes so that strings holding invalid UTF-8 are exact.
+      process.stdout.write($.stringToBytes(data))
     } else {
       // In browser environments, we need to use console.log but handle newlines carefully
       // If the data already ends with \n, we should strip it to avoid double newlines
@@ -225,210 +1444,139 @@ let stdout = {
   },
 }
 
-// Print functions
-export function Print(...a: any[]): [number, $.GoError | null] {
-  const result = a.map(defaultFormat).join(' ')
-  stdout.write(result)
-  return [result.length, null]
-}
-
-export function Printf(
-  format: string,
-  ...a: any[]
-): [number, $.GoError | null] {
-  const result = parseFormat(format, a)
-  stdout.write(result)
-  return [result.length, null]
+// writeStdout writes printed text to standard output and returns the
+// number of bytes written.
+function writeStdout(s: string): [number, $.GoError] {
+  stdout.write(s)
+  return [$.len(s), null]
 }
 
-export function Println(...a: any[]): [number, $.GoError | null] {
-  const result = a.map(defaultFormat).join(' ') + '\n'
-  stdout.write(result)
-  return [result.length, null]
-}
-
-// Sprint functions (return strings)
-export function Sprint(...a: any[]): string {
-  return a.map(defaultFormat).join(' ')
-}
-
-export function Sprintf(format: string, ...a: any[]): string {
-  return parseFormat(format, a)
-}
-
-export function Sprintln(...a: any[]): string {
-  return a.map(defaultFormat).join(' ') + '\n'
-}
-
-// Fprint functions (write to Writer) - simplified implementation
-export function Fprint(w: any, ...a: any[]): [number, $.GoError | null] {
-  const result = a.map(defaultFormat).join(' ')
+// writeTo writes printed text to w.
+function writeTo(w: any, s: string): [number, $.GoError] {
   if (w && w.Write) {
-    return w.Write(new TextEncoder().encode(result))
+    return w.Write($.stringToBytes(s))
   }
   return [0, $.newError('Writer does not implement Write method')]
 }
 
-export function Fprintf(
-  w: any,
-  format: string,
-  ...a: any[]
-): [number, $.GoError | null] {
-  const result = parseFormat(format, a)
-  if (w && w.Write) {
-    return w.Write(new TextEncoder().encode(result))
-  }
-  return [0, $.newError('Writer does not implement Write method')]
-}
-
-export function Fprintln(w: any, ...a: any[]): [number, $.GoError | null] {
-  const result = a.map(defaultFormat).join(' ') + '\n'
-  if (w && w.Write) {
-    return w.Write(new TextEncoder().encode(result))
-  }
-  return [0, $.newError('Writer does not implement Write method')]
+// appendTo appends printed text to the byte slice b.
+function appendTo(b: $.Bytes, s: string): $.Bytes {
+  return $.append(b, $.stringToBytes(s))
 }
 
-// Append functions (append to byte slice)
-export function Append(b: Uint8Array, ...a: any[]): Uint8Array {
-  const result = a.map(defaultFormat).join(' ')
-  const encoded = new TextEncoder().encode(result)
-  const newArray = new Uint8Array(b.length + encoded.length)
-  newArray.set(b)
-  newArray.set(encoded, b.length)
-  return newArray
-}
+// Functions for Printf etc.
 
-export function Appendf(
-  b: Uint8Array,
+// Fprintf formats according to a format specifier and writes to w.
+// It returns the number of bytes written and any write error encountered.
+export function Fprintf(
+  w: any,
   format: string,
   ...a: any[]
-): Uint8Array {
-  const result = parseFormat(format, a)
-  const encoded = new TextEncoder().encode(result)
-  const newArray = new Uint8Array(b.length + encoded.length)
-  newArray.set(b)
-  newArray.set(encoded, b.length)
-  return newArray
+): [number, $.GoError] {
+  const p = newPrinter()
+  p.doPrintf(format, a)
+  return writeTo(w, p.buf.s)
 }
 
-export function Appendln(b: Uint8Array, ...a: any[]): Uint8Array {
-  const result = a.map(defaultFormat).join(' ') + '\n'
-  const encoded = new TextEncoder().encode(result)
-  const newArray = new Uint8Array(b.length + encoded.length)
-  newArray.set(b)
-  newArray.set(encoded, b.length)
-  return newArray
+// Printf formats according to a format specifier and writes to standard
+// output. It returns the number of bytes written and any write error
+// encountered.
+export function Printf(format: string, ...a: any[]): [number, $.GoError] {
+  const p = newPrinter()
+  p.doPrintf(format, a)
+  return writeStdout(p.buf.s)
 }
 
-// Error creation
-export function Errorf(format: string, ...a: any[]): any {
-  const message = parseFormat(format, a)
-  return errors.New(message)
-}
-
-// FormatString - simplified implementation
-export function FormatString(state: State, verb: number): string {
-  let result = '%'
-
-  // Add flags
-  if (state.Flag(32)) result += ' ' // space
-  if (state.Flag(43)) result += '+' // plus
-  if (state.Flag(45)) result += '-' // minus
-  if (state.Flag(35)) result += '#' // hash
-  if (state.Flag(48)) result += '0' // zero
-
-  // Add width
-  const [width, hasWidth] = state.Width()
-  if (hasWidth) {
-    result += width.toString()
-  }
-
-  // Add precision
-  const [precision, hasPrecision] = state.Precision()
-  if (hasPrecision) {
-    result += '.' + precision.toString()
-  }
-
-  // Add verb
-  result += String.fromCharCode(verb)
-
-  return result
+// Sprintf formats according to a format specifier and returns the resulting
+// string.
+export function Sprintf(format: string, ...a: any[]): string {
+  const p = newPrinter()
+  p.doPrintf(format, a)
+  return p.buf.s
 }
 
-// Scanning functions - stubbed for now
-export function Scan(..._a: any[]): [number, $.GoError | null] {
-  // TODO: Implement scanning from stdin
-  return [0, $.newError('Scan not implemented')]
+// Appendf formats according to a format specifier, appends the result to
+// the byte slice, and returns the updated slice.
+export function Appendf(b: $.Bytes, format: string, ...a: any[]): $.Bytes {
+  const p = newPrinter()
+  p.doPrintf(format, a)
+  return appendTo(b, p.buf.s)
 }
 
-export function Scanf(
-  _format: string,
-  ..._a: any[]
-): [number, $.GoError | null] {
-  // TODO: Implement formatted scanning from stdin
-  return [0, $.newError('Scanf not implemented')]
-}
+// Functions for Print etc.
 
-export function Scanln(..._a: any[]): [number, $.GoError | null] {
-  // TODO: Implement line scanning from stdin
-  return [0, $.newError('Scanln not implemented')]
+// Fprint formats using the default formats for its operands and writes to
+// w. Spaces are added between operands when neither is a string.
+// It returns the number of bytes written and any write error encountered.
+export function Fprint(w: any, ...a: any[]): [number, $.GoError] {
+  const p = newPrinter()
+  p.doPrint(a)
+  return writeTo(w, p.buf.s)
 }
 
-export function Sscan(_str: string, ..._a: any[]): [number, $.GoError | null] {
-  // TODO: Implement scanning from string
-  return [0, $.newError('Sscan not implemented')]
+// Print formats using the default formats for its operands and writes to
+// standard output. Spaces are added between operands when neither is a
+// string. It returns the number of bytes written and any write error
+// encountered.
+export function Print(...a: any[]): [number, $.GoError] {
+  const p = newPrinter()
+  p.doPrint(a)
+  return writeStdout(p.buf.s)
 }
 
-export function Sscanf(
-  _str: string,
-  _format: string,
-  ..._a: any[]
-): [number, $.GoError | null] {
-  // TODO: Implement formatted scanning from string
-  return [0, $.newError('Sscanf not implemented')]
+// Sprint formats using the default formats for its operands and returns
+// the resulting string. Spaces are added between operands when neither is
+// a string.
+export function Sprint(...a: any[]): string {
+  const p = newPrinter()
+  p.doPrint(a)
+  return p.buf.s
 }
 
-export function Sscanln(
-  _str: string,
-  ..._a: any[]
-): [number, $.GoError | null] {
-  // TODO: Implement line scanning from string
-  return [0, $.newError('Sscanln not implemented')]
+// Append formats using the default formats for its operands, appends the
+// result to the byte slice, and returns the updated slice.
+export function Append(b: $.Bytes, ...a: any[]): $.Bytes {
+  const p = newPrinter()
+  p.doPrint(a)
+  return appendTo(b, p.buf.s)
 }
 
-export function Fscan(_r: any, ..._a: any[]): [number, $.GoError | null] {
-  // TODO: Implement scanning from Reader
-  return [0, $.newError('Fscan not implemented')]
-}
+// Functions for Println etc.
 
-export function Fscanf(
-  _r: any,
-  _format: string,
-  ..._a: any[]
-): [number, $.GoError | null] {
-  // TODO: Implement formatted scanning from Reader
-  return [0, $.newError('Fscanf not implemented')]
+// Fprintln formats using the default formats for its operands and writes
+// to w. Spaces are always added between operands and a newline is
+// appended. It returns the number of bytes written and any write error
+// encountered.
+export function Fprintln(w: any, ...a: any[]): [number, $.GoError] {
+  const p = newPrinter()
+  p.doPrintln(a)
+  return writeTo(w, p.buf.s)
 }
 
-export function Fscanln(_r: any, ..._a: any[]): [number, $.GoError | null] {
-  // TODO: Implement line scanning from Reader
-  return [0, $.newError('Fscanln not implemented')]
+// Println formats using the default formats for its operands and writes
+// to standard output. Spaces are always added between operands and a
+// newline is appended. It returns the number of bytes written and any
+// write error encountered.
+export function Println(...a: any[]): [number, $.GoError] {
+  const p = newPrinter()
+  p.doPrintln(a)
+  return writeStdout(p.buf.s)
 }
 
-// Scanner and ScanState interfaces - stubbed
-export interface Scanner {
-  Scan(state: ScanState, verb: number): $.GoError | null
+// Sprintln formats using the default formats for its operands and returns
+// the resulting string. Spaces are always added between operands and a
+// newline is appended.
+export function Sprintln(...a: any[]): string {
+  const p = newPrinter()
+  p.doPrintln(a)
+  return p.buf.s
 }
 
-export interface ScanState {
-  ReadRune(): [number, number, $.GoError | null]
-  UnreadRune(): $.GoError | null
-  SkipSpace(): void
-  Token(
-    skipSpace: boolean,
-    f: (r: number) => boolean,
-  ): [Uint8Array, $.GoError | null]
-  Width(): [number, boolean]
-  Read(buf: $.Bytes): [number, $.GoError | null]
+// Appendln formats using the default formats for its operands, appends the
+// result to the byte slice, and returns the updated slice. Spaces are
+// always added between operands and a newline is appended.
+export function Appendln(b: $.Bytes, ...a: any[]): $.Bytes {
+  const p = newPrinter()
+  p.doPrintln(a)
+  return appendTo(b, p.buf.s)
 }
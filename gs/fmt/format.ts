@@ -0,0 +1,473 @@
+// Port of Go's fmt/format.go: the low-level formatting of basic values
+// with width, precision and flags
+
+import * as $ from '@goscript/builtin/index.js'
+import * as strconv from '@goscript/strconv/index.js'
+
+export const ldigits = '0123456789abcdefx'
+export const udigits = '0123456789ABCDEFX'
+
+// buffer accumulates the output of a printer.
+export class buffer {
+  public s = ''
+
+  public write(b: Uint8Array): void {
+    this.s = $.concatString(this.s, $.bytesToString(b))
+  }
+
+  public writeString(s: string): void {
+    this.s += s
+  }
+
+  public writeRune(r: number): void {
+    this.s += runeString(r)
+  }
+}
+
+// runeString returns the UTF-8 text of a rune, or U+FFFD if it is invalid.
+export function runeString(r: number): string {
+  if (r < 0 || r > 0x10ffff || (r >= 0xd800 && r <= 0xdfff)) {
+    r = 0xfffd
+  }
+  return String.fromCodePoint(r)
+}
+
+// runeCount returns the number of runes in s.
+function runeCount(s: string): number {
+  let n = 0
+  for (const _ of s) {
+    n++
+  }
+  return n
+}
+
+// fmtFlags holds the flags of a verb, placed in a separate class for easy
+// clearing and saving.
+export class fmtFlags {
+  public widPresent = false
+  public precPresent = false
+  public minus = false
+  public plus = false
+  public sharp = false
+  public space = false
+  public zero = false
+
+  // For the formats %+v %#v, we set the plusV/sharpV flags
+  // and clear the plus/sharp flags since %+v and %#v are in effect
+  // different, flagless formats set at the top level.
+  public plusV = false
+  public sharpV = false
+}
+
+// fmt is the raw formatter used by Printf etc.
+// It prints into a buffer that must be set up separately.
+export class fmt extends fmtFlags {
+  public wid = 0 // width
+  public prec = 0 // precision
+
+  constructor(public buf: buffer) {
+    super()
+  }
+
+  public clearflags(): void {
+    this.setFlags(new fmtFlags())
+    this.wid = 0
+    this.prec = 0
+  }
+
+  // flags returns a copy of the flags.
+  public flags(): fmtFlags {
+    const flags = new fmtFlags()
+    flags.widPresent = this.widPresent
+    flags.precPresent = this.precPresent
+    flags.minus = this.minus
+    flags.plus = this.plus
+    flags.sharp = this.sharp
+    flags.space = this.space
+    flags.zero = this.zero
+    flags.plusV = this.plusV
+    flags.sharpV = this.sharpV
+    return flags
+  }
+
+  // setFlags restores flags saved by flags.
+  public setFlags(flags: fmtFlags): void {
+    this.widPresent = flags.widPresent
+    this.precPresent = flags.precPresent
+    this.minus = flags.minus
+    this.plus = flags.plus
+    this.sharp = flags.sharp
+    this.space = flags.space
+    this.zero = flags.zero
+    this.plusV = flags.plusV
+    this.sharpV = flags.sharpV
+  }
+
+  // writePadding generates n bytes of padding.
+  public writePadding(n: number): void {
+    if (n <= 0) {
+      // No padding bytes needed.
+      return
+    }
+    // Decide which byte the padding should be filled with.
+    const padByte = this.zero && !this.minus ? '0' : ' '
+    this.buf.writeString(padByte.repeat(n))
+  }
+
+  // pad appends s to the buffer, padded on the left (!minus) or right
+  // (minus) to the width, counted in runes.
+  public pad(s: string): void {
+    if (!this.widPresent || this.wid === 0) {
+      this.buf.writeString(s)
+      return
+    }
+    const width = this.wid - runeCount(s)
+    if (!this.minus) {
+      // left padding
+      this.writePadding(width)
+      this.buf.writeString(s)
+    } else {
+      // right padding
+      this.buf.writeString(s)
+      this.writePadding(width)
+    }
+  }
+
+  // fmtBoolean formats a boolean.
+  public fmtBoolean(v: boolean): void {
+    this.pad(v ? 'true' : 'false')
+  }
+
+  // fmtUnicode formats a uint64 as "U+0078" or with f.sharp set as
+  // "U+0078 'x'".
+  public fmtUnicode(u: bigint): void {
+    let prec = 4
+    // If precision is set explicitly use it.
+    if (this.precPresent && this.prec > 4) {
+      prec = this.prec
+    }
+    let s = 'U+' + u.toString(16).toUpperCase().padStart(prec, '0')
+    if (this.sharp && u <= 0x10ffffn && strconv.IsPrint(Number(u))) {
+      s += " '" + String.fromCodePoint(Number(u)) + "'"
+    }
+    const oldZero = this.zero
+    this.zero = false
+    this.pad(s)
+    this.zero = oldZero
+  }
+
+  // fmtInteger formats signed and unsigned integers.
+  public fmtInteger(
+    u: bigint,
+    base: number,
+    isSigned: boolean,
+    verb: number,
+    digits: string,
+  ): void {
+    if (!isSigned) {
+      u = BigInt.asUintN(64, u)
+    }
+    const negative = isSigned && u < 0n
+    if (negative) {
+      u = -u
+    }
+
+    // Two ways to ask for extra leading zero digits: %.3d or %03d.
+    // If both are specified the f.zero flag is ignored and
+    // padding with spaces is used instead.
+    let prec = 0
+    if (this.precPresent) {
+      prec = this.prec
+      // Precision of 0 and value of 0 means "print nothing" but padding.
+      if (prec === 0 && u === 0n) {
+        const oldZero = this.zero
+        this.zero = false
+        this.writePadding(this.wid)
+        this.zero = oldZero
+        return
+      }
+    } else if (this.zero && !this.minus && this.widPresent) {
+      // Zero padding is allowed only to the left.
+      prec = this.wid
+      if (negative || this.plus || this.space) {
+        prec-- // leave room for sign
+      }
+    }
+
+    let s = u.toString(base)
+    if (digits === udigits) {
+      s = s.toUpperCase()
+    }
+    // Pad with zeros up to the precision.
+    if (prec > s.length) {
+      s = '0'.repeat(prec - s.length) + s
+    }
+
+    // Various prefixes: 0x, -, etc.
+    if (this.sharp) {
+      switch (base) {
+        case 2:
+          // Add a leading 0b.
+          s = '0b' + s
+          break
+        case 8:
+          if (s[0] !== '0') {
+            s = '0' + s
+          }
+          break
+        case 16:
+          // Add a leading 0x or 0X.
+          s = '0' + digits[16] + s
+          break
+      }
+    }
+    if (verb === 0x4f) {
+      // 'O'
+      s = '0o' + s
+    }
+
+    if (negative) {
+      s = '-' + s
+    } else if (this.plus) {
+      s = '+' + s
+    } else if (this.space) {
+      s = ' ' + s
+    }
+
+    // Left padding with zeros has already been handled like precision
+    // earlier or the f.zero flag is ignored due to an explicit precision.
+    const oldZero = this.zero
+    this.zero = false
+    this.pad(s)
+    this.zero = oldZero
+  }
+
+  // truncate truncates the string s to the specified precision, if present.
+  public truncate(s: string): string {
+    if (this.precPresent) {
+      let n = this.prec
+      let i = 0
+      for (const c of s) {
+        n--
+        if (n < 0) {
+          return s.slice(0, i)
+        }
+        i += c.length
+      }
+    }
+    return s
+  }
+
+  // fmtS formats a string.
+  public fmtS(s: string): void {
+    this.pad(this.truncate(s))
+  }
+
+  // fmtSbx formats a string or byte slice as a hexadecimal encoding of its
+  // bytes.
+  public fmtSbx(b: Uint8Array, digits: string): void {
+    let length = b.length
+    // Set length to not process more bytes than the precision demands.
+    if (this.precPresent && this.prec < length) {
+      length = this.prec
+    }
+    // Compute width of the encoding taking into account the f.sharp and
+    // f.space flag.
+    let width = 2 * length
+    if (width > 0) {
+      if (this.space) {
+        // Each element encoded by two hexadecimals will get a leading 0x
+        // or 0X.
+        if (this.sharp) {
+          width *= 2
+        }
+        // Elements will be separated by a space.
+        width += length - 1
+      } else if (this.sharp) {
+        // Only a leading 0x or 0X will be added for the whole string.
+        width += 2
+      }
+    } else {
+      // The byte slice or string that should be encoded is empty.
+      if (this.widPresent) {
+        this.writePadding(this.wid)
+      }
+      return
+    }
+    // Handle padding to the left.
+    if (this.widPresent && this.wid > width && !this.minus) {
+      this.writePadding(this.wid - width)
+    }
+    // Write the encoding directly into the output buffer.
+    let s = ''
+    if (this.sharp) {
+      // Add leading 0x or 0X.
+      s += '0' + digits[16]
+    }
+    for (let i = 0; i < length; i++) {
+      if (this.space && i > 0) {
+        // Separate elements with a space.
+        s += ' '
+        if (this.sharp) {
+          // Add leading 0x or 0X for each element.
+          s += '0' + digits[16]
+        }
+      }
+      const c = b[i]
+      s += digits[c >> 4] + digits[c & 0xf]
+    }
+    this.buf.writeString(s)
+    // Handle padding to the right.
+    if (this.widPresent && this.wid > width && this.minus) {
+      this.writePadding(this.wid - width)
+    }
+  }
+
+  // fmtSx formats a string as a hexadecimal encoding of its bytes.
+  public fmtSx(s: string, digits: string): void {
+    this.fmtSbx($.stringToBytes(s), digits)
+  }
+
+  // fmtQ formats a string as a double-quoted, escaped Go string constant.
+  // If f.sharp is set a raw (backquoted) string may be returned instead
+  // if the string does not contain any control characters other than tab.
+  public fmtQ(s: string): void {
+    s = this.truncate(s)
+    if (this.sharp && strconv.CanBackquote(s)) {
+      this.pad('`' + s + '`')
+      return
+    }
+    if (this.plus) {
+      this.pad(strconv.QuoteToASCII(s))
+    } else {
+      this.pad(strconv.Quote(s))
+    }
+  }
+
+  // fmtC formats an integer as a Unicode character.
+  // If the character is not valid Unicode, it will print '�'.
+  public fmtC(c: bigint): void {
+    this.pad(runeString(c > 0x10ffffn || c < 0n ? 0xfffd : Number(c)))
+  }
+
+  // fmtQc formats an integer as a single-quoted, escaped Go character
+  // constant. If the character is not valid Unicode, it will print '�'.
+  public fmtQc(c: bigint): void {
+    const r = c > 0x10ffffn || c < 0n ? 0xfffd : Number(c)
+    if (this.plus) {
+      this.pad(strconv.QuoteRuneToASCII(r))
+    } else {
+      this.pad(strconv.QuoteRune(r))
+    }
+  }
+
+  // fmtFloat formats a float64. It assumes that verb is a valid format
+  // specifier for strconv.FormatFloat and therefore fits into a byte.
+  public fmtFloat(v: number, size: number, verb: number, prec: number): void {
+    // Format number, reserving space for leading + sign if needed.
+    if (this.precPresent) {
+      prec = this.prec
+    }
+    let num = strconv.FormatFloat(v, verb, prec, size)
+    if (num[0] !== '-' && num[0] !== '+') {
+      num = '+' + num
+    }
+    // Use a space instead of a plus sign if asked for.
+    if (this.space && num[0] === '+' && !this.plus) {
+      num = ' ' + num.slice(1)
+    }
+    // Special handling for infinities and NaN,
+    // which don't look like a number so shouldn't be padded with zeros.
+    if (num[1] === 'I' || num[1] === 'N') {
+      const oldZero = this.zero
+      this.zero = false
+      // Remove sign before NaN if not asked for.
+      if (num[1] === 'N' && !this.space && !this.plus) {
+        num = num.slice(1)
+      }
+      this.pad(num)
+      this.zero = oldZero
+      return
+    }
+    // The sharp flag forces printing a decimal point but removes
+    // trailing zeros for %e, %f with the sharp flag.
+    if (this.sharp && verb !== 0x62) {
+      // 'b'
+      let digits = 0
+      switch (String.fromCharCode(verb)) {
+        case 'v':
+        case 'g':
+        case 'G':
+        case 'x':
+          digits = prec
+          // If no precision is set explicitly use a precision of 6.
+          if (digits === -1) {
+            digits = 6
+          }
+      }
+
+      // Buffer pre-allocated with enough room for
+      // exponent notations of the form "e+123" or "p-1023".
+      let tail = ''
+
+      let hasDecimalPoint = false
+      let sawNonzeroDigit = false
+      // Starting from i = 1 to skip sign at num[0].
+      for (let i = 1; i < num.length; i++) {
+        const c = num[i]
+        if (c === '.') {
+          hasDecimalPoint = true
+          continue
+        }
+        if (c === 'p' || c === 'P') {
+          tail = num.slice(i)
+          num = num.slice(0, i)
+          break
+        }
+        if ((c === 'e' || c === 'E') && verb !== 0x78 && verb !== 0x58) {
+          tail = num.slice(i)
+          num = num.slice(0, i)
+          break
+        }
+        if (c !== '0') {
+          sawNonzeroDigit = true
+        }
+        // Count significant digits after the first non-zero digit.
+        if (sawNonzeroDigit) {
+          digits--
+        }
+      }
+      if (!hasDecimalPoint) {
+        // Leading digit 0 should contribute once to digits.
+        if (num.length === 2 && num[1] === '0') {
+          digits--
+        }
+        num += '.'
+      }
+      while (digits > 0) {
+        num += '0'
+        digits--
+      }
+      num += tail
+    }
+    // We want a sign if asked for and if the sign is not positive.
+    if (this.plus || num[0] !== '+') {
+      // If we're zero padding to the left we want the sign before the
+      // leading zeros. Achieve this by writing the sign out and then
+      // padding the unsigned number. Zero padding is allowed only to
+      // the left.
+      const width = num.length
+      if (this.zero && !this.minus && this.widPresent && this.wid > width) {
+        this.buf.writeString(num[0])
+        this.writePadding(this.wid - num.length)
+        this.buf.writeString(num.slice(1))
+        return
+      }
+      this.pad(num)
+      return
+    }
+    // No sign to show and the number is positive; just print the unsigned
+    // number.
+    this.pad(num.slice(1))
+  }
+}
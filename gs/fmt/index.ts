@@ -1,5 +1,6 @@
+export { Errorf } from './errors.js'
+
 export {
-  Errorf,
   Print,
   Printf,
   Println,
@@ -13,6 +14,9 @@ export {
   Appendf,
   Appendln,
   FormatString,
+} from './fmt.js'
+
+export {
   Scan,
   Scanf,
   Scanln,
@@ -22,7 +26,7 @@ export {
   Fscan,
   Fscanf,
   Fscanln,
-} from './fmt.js'
+} from './scan.js'
 
 // Re-export types for TypeScript compilation
 export type {
@@ -30,6 +34,5 @@ export type {
   GoStringer,
   Stringer,
   State,
-  Scanner,
-  ScanState,
 } from './fmt.js'
+export type { Scanner, ScanState } from './scan.js'
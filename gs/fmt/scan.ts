@@ -0,0 +1,1213 @@
+// Handwritten TypeScript implementation of Go's fmt scanning functions.
+// Ported from Go's fmt/scan.go.
+
+import * as $ from '@goscript/builtin/index.js'
+import * as errors from '@goscript/errors/index.js'
+import * as io from '@goscript/io/index.js'
+import * as strconv from '@goscript/strconv/index.js'
+
+// Scanner is implemented by any value that has a Scan method, which scans
+// the input for the representation of a value and stores the result in the
+// receiver.
+export interface Scanner {
+  Scan(state: ScanState, verb: number): $.GoError | null
+}
+
+// ScanState represents the scanner state passed to custom scanners.
+export interface ScanState {
+  ReadRune(): [number, number, $.GoError | null]
+  UnreadRune(): $.GoError | null
+  SkipSpace(): void
+  Token(
+    skipSpace: boolean,
+    f: ((r: number) => boolean) | null,
+  ): [Uint8Array, $.GoError | null]
+  Width(): [number, boolean]
+  Read(buf: $.Bytes): [number, $.GoError | null]
+}
+
+// runeScanner is the subset of io.RuneScanner the scanner reads from.
+interface runeScanner {
+  ReadRune(): [number, number, $.GoError | null]
+  UnreadRune(): $.GoError | null
+}
+
+// Scan scans text read from standard input, storing successive
+// space-separated values into successive arguments. Newlines count as space.
+export function Scan(...a: any[]): [number, $.GoError | null] {
+  return Fscan(stdin, ...a)
+}
+
+// Scanln is similar to Scan, but stops scanning at a newline.
+export function Scanln(...a: any[]): [number, $.GoError | null] {
+  return Fscanln(stdin, ...a)
+}
+
+// Scanf scans text read from standard input, storing successive
+// space-separated values into successive arguments as determined by the
+// format.
+export function Scanf(
+  format: string,
+  ...a: any[]
+): [number, $.GoError | null] {
+  return Fscanf(stdin, format, ...a)
+}
+
+// Sscan scans the argument string, storing successive space-separated values
+// into successive arguments. Newlines count as space.
+export function Sscan(str: string, ...a: any[]): [number, $.GoError | null] {
+  return new ss(new stringReader(str), true, false).doScan(a)
+}
+
+// Sscanln is similar to Sscan, but stops scanning at a newline.
+export function Sscanln(
+  str: string,
+  ...a: any[]
+): [number, $.GoError | null] {
+  return new ss(new stringReader(str), false, true).doScan(a)
+}
+
+// Sscanf scans the argument string, storing successive space-separated
+// values into successive arguments as determined by the format.
+export function Sscanf(
+  str: string,
+  format: string,
+  ...a: any[]
+): [number, $.GoError | null] {
+  return new ss(new stringReader(str), false, false).doScanf(format, a)
+}
+
+// Fscan scans text read from r, storing successive space-separated values
+// into successive arguments. Newlines count as space.
+export function Fscan(r: any, ...a: any[]): [number, $.GoError | null] {
+  return new ss(runeScannerOf(r), true, false).doScan(a)
+}
+
+// Fscanln is similar to Fscan, but stops scanning at a newline.
+export function Fscanln(r: any, ...a: any[]): [number, $.GoError | null] {
+  return new ss(runeScannerOf(r), false, true).doScan(a)
+}
+
+// Fscanf scans text read from r, storing successive space-separated values
+// into successive arguments as determined by the format.
+export function Fscanf(
+  r: any,
+  format: string,
+  ...a: any[]
+): [number, $.GoError | null] {
+  return new ss(runeScannerOf(r), false, false).doScanf(format, a)
+}
+
+// scanError is thrown to unwind a scan; the entry points turn it back into
+// an error result.
+class scanError {
+  constructor(public err: $.GoError) {}
+}
+
+const eof = -1
+const hugeWid = 1 << 30
+
+const binaryDigits = '01'
+const octalDigits = '01234567'
+const decimalDigits = '0123456789'
+const hexadecimalDigits = '0123456789aAbBcCdDeEfF'
+const sign = '+-'
+const period = '.'
+const exponent = 'eEpP'
+const floatVerbs = 'beEfFgGv'
+
+const errComplex = errors.New('syntax error scanning complex number')
+const errBool = errors.New('syntax error scanning boolean')
+
+// ss is the scanner state shared by all the scanning functions.
+class ss implements ScanState {
+  private buf = '' // token accumulator
+  private count = 0 // runes consumed so far
+  private atEOF = false // already read EOF
+  private argLimit = hugeWid // max value of count for this arg
+  private limit = hugeWid // max value of count
+  private maxWid = hugeWid // width of this arg
+
+  constructor(
+    private rs: runeScanner,
+    private nlIsSpace: boolean, // whether newline counts as white space
+    private nlIsEnd: boolean, // whether newline terminates scan
+  ) {}
+
+  // Read is only here to satisfy ScanState; scanners must use ReadRune.
+  public Read(_buf: $.Bytes): [number, $.GoError | null] {
+    return [
+      0,
+      errors.New("ScanState's Read should not be called. Use ReadRune"),
+    ]
+  }
+
+  public ReadRune(): [number, number, $.GoError | null] {
+    if (this.atEOF || this.count >= this.argLimit) {
+      return [0, 0, io.EOF]
+    }
+    const [r, size, err] = this.rs.ReadRune()
+    if (err === null) {
+      this.count++
+      if (this.nlIsEnd && r === 0x0a) {
+        this.atEOF = true
+      }
+    } else if (err === io.EOF) {
+      this.atEOF = true
+    }
+    return [r, size, err]
+  }
+
+  public Width(): [number, boolean] {
+    if (this.maxWid === hugeWid) {
+      return [0, false]
+    }
+    return [this.maxWid, true]
+  }
+
+  public UnreadRune(): $.GoError | null {
+    this.rs.UnreadRune()
+    this.atEOF = false
+    this.count--
+    return null
+  }
+
+  public SkipSpace(): void {
+    for (;;) {
+      const r = this.getRune()
+      if (r === eof) {
+        return
+      }
+      if (r === 0x0d && this.peek('\n')) {
+        continue
+      }
+      if (r === 0x0a) {
+        if (this.nlIsSpace) {
+          continue
+        }
+        this.errorString('unexpected newline')
+      }
+      if (!isSpace(r)) {
+        this.UnreadRune()
+        break
+      }
+    }
+  }
+
+  public Token(
+    skipSpace: boolean,
+    f: ((r: number) => boolean) | null,
+  ): [Uint8Array, $.GoError | null] {
+    try {
+      this.buf = ''
+      const tok = this.token(skipSpace, f ?? notSpace)
+      return [$.stringToBytes(tok), null]
+    } catch (e) {
+      if (e instanceof scanError) {
+        return [new Uint8Array(0), e.err]
+      }
+      throw e
+    }
+  }
+
+  // getRune returns the next rune value in the input, or eof.
+  private getRune(): number {
+    const [r, , err] = this.ReadRune()
+    if (err !== null) {
+      if (err === io.EOF) {
+        return eof
+      }
+      this.error(err)
+    }
+    return r
+  }
+
+  // mustReadRune turns io.EOF into an unexpected EOF error.
+  private mustReadRune(): number {
+    const r = this.getRune()
+    if (r === eof) {
+      this.error(io.ErrUnexpectedEOF)
+    }
+    return r
+  }
+
+  private error(err: $.GoError): never {
+    throw new scanError(err)
+  }
+
+  private errorString(err: string): never {
+    throw new scanError(errors.New(err))
+  }
+
+  private token(skipSpace: boolean, f: (r: number) => boolean): string {
+    if (skipSpace) {
+      this.SkipSpace()
+    }
+    for (;;) {
+      const r = this.getRune()
+      if (r === eof) {
+        break
+      }
+      if (!f(r)) {
+        this.UnreadRune()
+        break
+      }
+      this.buf += String.fromCodePoint(r)
+    }
+    return this.buf
+  }
+
+  // consume reads the next rune in the input and reports whether it is in
+  // the ok string. If accept is true, it puts the character into the buffer.
+  private consume(ok: string, accept: boolean): boolean {
+    const r = this.getRune()
+    if (r === eof) {
+      return false
+    }
+    if (indexRune(ok, r)) {
+      if (accept) {
+        this.buf += String.fromCodePoint(r)
+      }
+      return true
+    }
+    if (accept) {
+      this.UnreadRune()
+    }
+    return false
+  }
+
+  // peek reports whether the next character is in the ok string, without
+  // consuming it.
+  private peek(ok: string): boolean {
+    const r = this.getRune()
+    if (r !== eof) {
+      this.UnreadRune()
+    }
+    return indexRune(ok, r)
+  }
+
+  private notEOF(): void {
+    if (this.getRune() === eof) {
+      this.error(io.EOF)
+    }
+    this.UnreadRune()
+  }
+
+  // accept checks the next rune in the input and puts it into the buffer
+  // if it is in the ok string.
+  private accept(ok: string): boolean {
+    return this.consume(ok, true)
+  }
+
+  private okVerb(verb: number, okVerbs: string, typ: string): boolean {
+    if (indexRune(okVerbs, verb)) {
+      return true
+    }
+    this.errorString(
+      "bad verb '%" + String.fromCodePoint(verb) + "' for " + typ,
+    )
+  }
+
+  // scanBool returns the value of the boolean represented by the next token.
+  private scanBool(verb: number): boolean {
+    this.SkipSpace()
+    this.notEOF()
+    if (!this.okVerb(verb, 'tv', 'boolean')) {
+      return false
+    }
+    switch (String.fromCodePoint(this.getRune())) {
+      case '0':
+        return false
+      case '1':
+        return true
+      case 't':
+      case 'T':
+        if (
+          this.accept('rR') &&
+          (!this.accept('uU') || !this.accept('eE'))
+        ) {
+          this.error(errBool)
+        }
+        return true
+      case 'f':
+      case 'F':
+        if (
+          this.accept('aA') &&
+          (!this.accept('lL') || !this.accept('sS') || !this.accept('eE'))
+        ) {
+          this.error(errBool)
+        }
+        return false
+    }
+    return false
+  }
+
+  // getBase returns the numeric base represented by the verb and its digit
+  // string.
+  private getBase(verb: number): [number, string] {
+    this.okVerb(verb, 'bdoUxXv', 'integer')
+    switch (String.fromCodePoint(verb)) {
+      case 'b':
+        return [2, binaryDigits]
+      case 'o':
+        return [8, octalDigits]
+      case 'x':
+      case 'X':
+      case 'U':
+        return [16, hexadecimalDigits]
+    }
+    return [10, decimalDigits]
+  }
+
+  // scanNumber returns the numerical string with specified digits starting
+  // here.
+  private scanNumber(digits: string, haveDigits: boolean): string {
+    if (!haveDigits) {
+      this.notEOF()
+      if (!this.accept(digits)) {
+        this.errorString('expected integer')
+      }
+    }
+    while (this.accept(digits)) {}
+    return this.buf
+  }
+
+  // scanRune returns the next rune value in the input.
+  private scanRune(): number {
+    this.notEOF()
+    return this.getRune()
+  }
+
+  // scanBasePrefix reports whether the integer begins with a base prefix
+  // and returns the base, digit string, and whether a zero was found.
+  // It is called only if the verb is %v.
+  private scanBasePrefix(): [number, string, boolean] {
+    if (!this.peek('0')) {
+      return [0, decimalDigits + '_', false]
+    }
+    this.accept('0')
+    if (this.peek('bB')) {
+      this.consume('bB', true)
+      return [0, binaryDigits + '_', true]
+    }
+    if (this.peek('oO')) {
+      this.consume('oO', true)
+      return [0, octalDigits + '_', true]
+    }
+    if (this.peek('xX')) {
+      this.consume('xX', true)
+      return [0, hexadecimalDigits + '_', true]
+    }
+    return [0, octalDigits + '_', true]
+  }
+
+  // scanInt returns the value of the integer represented by the next token.
+  private scanInt(verb: number): bigint {
+    if (verb === 0x63) {
+      // 'c'
+      return BigInt(this.scanRune())
+    }
+    this.SkipSpace()
+    this.notEOF()
+    let [base, digits] = this.getBase(verb)
+    let haveDigits = false
+    if (verb === 0x55) {
+      // 'U'
+      if (!this.consume('U', false) || !this.consume('+', false)) {
+        this.errorString('bad unicode format ')
+      }
+    } else {
+      this.accept(sign)
+      if (verb === 0x76) {
+        // 'v'
+        ;[base, digits, haveDigits] = this.scanBasePrefix()
+      }
+    }
+    const tok = this.scanNumber(digits, haveDigits)
+    const [i, err] = strconv.ParseInt(tok, base, 64)
+    if (err !== null) {
+      this.error(err)
+    }
+    return BigInt(i)
+  }
+
+  // scanIntOrFloat scans a number for %v into a target that may hold either
+  // an integer or a float. Integer syntax is scanned as for %v; a fraction
+  // or exponent following decimal digits continues it as a float.
+  private scanIntOrFloat(): number {
+    this.SkipSpace()
+    this.notEOF()
+    if (this.peek('nNiI')) {
+      return this.convertFloat(this.floatToken(), 64)
+    }
+    this.accept(sign)
+    const [, digits, haveDigits] = this.scanBasePrefix()
+    if (haveDigits && digits !== octalDigits + '_') {
+      return Number(this.parseInt(this.scanNumber(digits, haveDigits)))
+    }
+    if (!haveDigits && !this.peek(decimalDigits)) {
+      this.errorString('expected integer')
+    }
+    while (this.accept(decimalDigits + '_')) {}
+    if (!this.peek(period + 'eE')) {
+      return Number(this.parseInt(this.buf))
+    }
+    if (this.accept(period)) {
+      while (this.accept(decimalDigits + '_')) {}
+    }
+    if (this.accept('eE')) {
+      this.accept(sign)
+      while (this.accept(decimalDigits + '_')) {}
+    }
+    return this.convertFloat(this.buf, 64)
+  }
+
+  private parseInt(tok: string): bigint {
+    const [i, err] = strconv.ParseInt(tok, 0, 64)
+    if (err !== null) {
+      this.error(err)
+    }
+    return BigInt(i)
+  }
+
+  // floatToken returns the floating-point number starting here.
+  private floatToken(): string {
+    this.buf = ''
+    // NaN?
+    if (this.accept('nN') && this.accept('aA') && this.accept('nN')) {
+      return this.buf
+    }
+    // leading sign?
+    this.accept(sign)
+    // Inf?
+    if (this.accept('iI') && this.accept('nN') && this.accept('fF')) {
+      return this.buf
+    }
+    let digits = decimalDigits + '_'
+    let exp = exponent
+    if (this.accept('0') && this.accept('xX')) {
+      digits = hexadecimalDigits + '_'
+      exp = 'pP'
+    }
+    // digits?
+    while (this.accept(digits)) {}
+    // decimal point?
+    if (this.accept(period)) {
+      // fraction?
+      while (this.accept(digits)) {}
+    }
+    // exponent?
+    if (this.accept(exp)) {
+      // leading sign?
+      this.accept(sign)
+      // digits?
+      while (this.accept(decimalDigits + '_')) {}
+    }
+    return this.buf
+  }
+
+  // complexTokens returns the real and imaginary parts of the complex number
+  // starting here, in the format (N+Ni).
+  private complexTokens(): [string, string] {
+    const parens = this.accept('(')
+    const real = this.floatToken()
+    this.buf = ''
+    // Must now have a sign.
+    if (!this.accept('+-')) {
+      this.error(errComplex)
+    }
+    const imagSign = this.buf
+    const imag = this.floatToken()
+    if (!this.accept('i')) {
+      this.error(errComplex)
+    }
+    if (parens && !this.accept(')')) {
+      this.error(errComplex)
+    }
+    return [real, imagSign + imag]
+  }
+
+  // convertFloat converts the string to a float64 value.
+  private convertFloat(str: string, n: number): number {
+    // strconv.ParseFloat does not handle the non-standard decimal+binary
+    // exponent mix (1.2p4), so evaluate it here.
+    const p = str.indexOf('p')
+    if (p >= 0 && !/[xX]/.test(str)) {
+      const [f, err] = strconv.ParseFloat(str.slice(0, p), n)
+      if (err !== null) {
+        ;(err as strconv.NumError).Num = str
+        this.error(err)
+      }
+      const [m, err2] = strconv.Atoi(str.slice(p + 1))
+      if (err2 !== null) {
+        ;(err2 as strconv.NumError).Num = str
+        this.error(err2)
+      }
+      return f * Math.pow(2, m)
+    }
+    const [f, err] = strconv.ParseFloat(str, n)
+    if (err !== null) {
+      this.error(err)
+    }
+    return f
+  }
+
+  // scanComplex converts the next token to a complex value.
+  private scanComplex(verb: number): $.Complex {
+    this.okVerb(verb, floatVerbs, 'complex')
+    this.SkipSpace()
+    this.notEOF()
+    const [sreal, simag] = this.complexTokens()
+    return new $.Complex(
+      this.convertFloat(sreal, 64),
+      this.convertFloat(simag, 64),
+    )
+  }
+
+  // convertString returns the string represented by the next input
+  // characters. The format of the input is determined by the verb.
+  private convertString(verb: number): string {
+    const bytes = this.convertBytes(verb)
+    if (typeof bytes === 'string') {
+      return bytes
+    }
+    return $.bytesToString(bytes)
+  }
+
+  // convertBytes is convertString for byte slice targets. Hex input is
+  // returned as raw bytes so that it survives without UTF-8 decoding.
+  private convertBytes(verb: number): string | Uint8Array {
+    this.okVerb(verb, 'svqxX', 'string')
+    this.SkipSpace()
+    this.notEOF()
+    switch (String.fromCodePoint(verb)) {
+      case 'q':
+        return this.quotedString()
+      case 'x':
+      case 'X':
+        return this.hexString()
+    }
+    // %s and %v just return the next word
+    return this.token(true, notSpace)
+  }
+
+  // quotedString returns the double- or back-quoted string represented by
+  // the next input characters.
+  private quotedString(): string {
+    this.notEOF()
+    const quote = this.getRune()
+    switch (quote) {
+      case 0x60: {
+        // Back-quoted: Anything goes until EOF or back quote.
+        for (;;) {
+          const r = this.mustReadRune()
+          if (r === quote) {
+            break
+          }
+          this.buf += String.fromCodePoint(r)
+        }
+        return this.buf
+      }
+      case 0x22: {
+        // Double-quoted: Include the quotes and let strconv.Unquote do the
+        // backslash escapes.
+        this.buf += '"'
+        for (;;) {
+          const r = this.mustReadRune()
+          this.buf += String.fromCodePoint(r)
+          if (r === 0x5c) {
+            // Only the character immediately after the escape can itself be
+            // a backslash or quote.
+            this.buf += String.fromCodePoint(this.mustReadRune())
+          } else if (r === 0x22) {
+            break
+          }
+        }
+        const [result, err] = strconv.Unquote(this.buf)
+        if (err !== null) {
+          this.error(err)
+        }
+        return result
+      }
+    }
+    this.errorString('expected quoted string')
+  }
+
+  // hexByte returns the next hex-encoded (two-character) byte from the
+  // input, or -1 if the next bytes in the input do not encode a hex byte.
+  private hexByte(): number {
+    const rune1 = this.getRune()
+    if (rune1 === eof) {
+      return -1
+    }
+    const value1 = hexDigit(rune1)
+    if (value1 < 0) {
+      this.UnreadRune()
+      return -1
+    }
+    const value2 = hexDigit(this.mustReadRune())
+    if (value2 < 0) {
+      this.errorString('illegal hex digit')
+    }
+    return (value1 << 4) | value2
+  }
+
+  // hexString returns the space-delimited hexpair-encoded string.
+  private hexString(): Uint8Array {
+    this.notEOF()
+    const out: number[] = []
+    for (;;) {
+      const b = this.hexByte()
+      if (b < 0) {
+        break
+      }
+      out.push(b)
+    }
+    if (out.length === 0) {
+      this.errorString('no hex data for %x string')
+    }
+    return new Uint8Array(out)
+  }
+
+  // scanPercent scans a literal percent character.
+  private scanPercent(): void {
+    this.SkipSpace()
+    this.notEOF()
+    if (!this.accept('%')) {
+      this.errorString('missing literal %')
+    }
+  }
+
+  // scanOne scans a single value into arg. Pointers reach us as VarRefs,
+  // so the kind of the target is taken from the value it currently holds.
+  private scanOne(verb: number, arg: any): void {
+    this.buf = ''
+    // If the parameter has its own Scan method, use that.
+    if (typeof arg?.Scan === 'function') {
+      let err = (arg as Scanner).Scan(this, verb)
+      if (err !== null) {
+        if (err === io.EOF) {
+          err = io.ErrUnexpectedEOF
+        }
+        this.error(err)
+      }
+      return
+    }
+
+    if (!$.isVarRef(arg)) {
+      this.errorString('type not a pointer: ' + typeName(arg))
+    }
+    const cur = arg.value
+    switch (typeof cur) {
+      case 'boolean':
+        arg.value = this.scanBool(verb)
+        return
+      case 'string':
+        arg.value = this.convertString(verb)
+        return
+      case 'bigint':
+        arg.value = this.scanInt(verb)
+        return
+      case 'number':
+        arg.value = this.scanNumberInto(verb, cur)
+        return
+    }
+    if (cur === null || cur instanceof Uint8Array) {
+      // We scan to a fresh copy so the result does not alias the input.
+      const bytes = this.convertBytes(verb)
+      arg.value =
+        typeof bytes === 'string' ? $.stringToBytes(bytes) : bytes
+      return
+    }
+    if (cur instanceof $.Complex) {
+      arg.value = this.scanComplex(verb)
+      return
+    }
+    this.errorString("can't scan type: " + typeName(cur))
+  }
+
+  // scanNumberInto scans a value for a number target. Integer and float
+  // variables are both numbers at runtime, so the verb picks the syntax,
+  // and %v accepts either unless the target already holds a fraction.
+  private scanNumberInto(verb: number, cur: number): number {
+    if (indexRune('eEfFgG', verb) || !Number.isInteger(cur)) {
+      this.okVerb(verb, floatVerbs, 'float64')
+      this.SkipSpace()
+      this.notEOF()
+      return this.convertFloat(this.floatToken(), 64)
+    }
+    if (verb === 0x76) {
+      return this.scanIntOrFloat()
+    }
+    return Number(this.scanInt(verb))
+  }
+
+  // doScan does the real work for scanning without a format string.
+  public doScan(a: any[]): [number, $.GoError | null] {
+    let numProcessed = 0
+    try {
+      for (const arg of a) {
+        this.scanOne(0x76, arg)
+        numProcessed++
+      }
+      // Check for newline (or EOF) if required (Scanln etc.).
+      if (this.nlIsEnd) {
+        for (;;) {
+          const r = this.getRune()
+          if (r === 0x0a || r === eof) {
+            break
+          }
+          if (!isSpace(r)) {
+            this.errorString('expected newline')
+          }
+        }
+      }
+    } catch (e) {
+      return [numProcessed, errorOf(e)]
+    }
+    return [numProcessed, null]
+  }
+
+  // advance determines whether the next characters in the input match those
+  // of the format. It returns the number of code units consumed in the
+  // format. All runs of space characters in either input or format behave
+  // as a single space. Newlines are special, though: newlines in the format
+  // must match those in the input and vice versa. This routine also handles
+  // the %% case. If the return value is zero, either format starts with a %
+  // (with no following %) or the input is empty. If it is negative, the
+  // input did not match the string.
+  private advance(format: string): number {
+    let i = 0
+    while (i < format.length) {
+      let fmtc = format.codePointAt(i)!
+      let w = runeWidth(fmtc)
+
+      // Space processing.
+      if (isSpace(fmtc)) {
+        let newlines = 0
+        let trailingSpace = false
+        while (isSpace(fmtc) && i < format.length) {
+          if (fmtc === 0x0a) {
+            newlines++
+            trailingSpace = false
+          } else {
+            trailingSpace = true
+          }
+          i += w
+          fmtc = i < format.length ? format.codePointAt(i)! : eof
+          w = runeWidth(fmtc)
+        }
+        for (let j = 0; j < newlines; j++) {
+          let inputc = this.getRune()
+          while (isSpace(inputc) && inputc !== 0x0a) {
+            inputc = this.getRune()
+          }
+          if (inputc !== 0x0a && inputc !== eof) {
+            this.errorString('newline in format does not match input')
+          }
+        }
+        if (trailingSpace) {
+          let inputc = this.getRune()
+          if (newlines === 0) {
+            // If the trailing space stood alone (did not follow a newline),
+            // it must find at least one space to consume.
+            if (!isSpace(inputc) && inputc !== eof) {
+              this.errorString('expected space in input to match format')
+            }
+            if (inputc === 0x0a) {
+              this.errorString('newline in input does not match format')
+            }
+          }
+          while (isSpace(inputc) && inputc !== 0x0a) {
+            inputc = this.getRune()
+          }
+          if (inputc !== eof) {
+            this.UnreadRune()
+          }
+        }
+        continue
+      }
+
+      // Verbs.
+      if (fmtc === 0x25) {
+        // % at end of string is an error.
+        if (i + w === format.length) {
+          this.errorString('missing verb: % at end of format string')
+        }
+        // %% acts like a real percent
+        if (format[i + w] !== '%') {
+          return i
+        }
+        i += w // skip the first %
+      }
+
+      // Literals.
+      const inputc = this.mustReadRune()
+      if (fmtc !== inputc) {
+        this.UnreadRune()
+        return -1
+      }
+      i += w
+    }
+    return i
+  }
+
+  // doScanf does the real work when scanning with a format string.
+  public doScanf(format: string, a: any[]): [number, $.GoError | null] {
+    let numProcessed = 0
+    try {
+      const end = format.length - 1
+      // We process one item per non-trivial format
+      for (let i = 0; i <= end; ) {
+        const w = this.advance(format.slice(i))
+        if (w > 0) {
+          i += w
+          continue
+        }
+        // Either we failed to advance, we have a percent character, or we
+        // ran out of input.
+        if (format[i] !== '%') {
+          // Can't advance format. Why not?
+          if (w < 0) {
+            this.errorString('input does not match format')
+          }
+          // Otherwise at EOF; "too many operands" error handled below
+          break
+        }
+        i++ // % is one code unit
+
+        // do we have 20 (width)?
+        let widPresent: boolean
+        ;[this.maxWid, widPresent, i] = parsenum(format, i, end)
+        if (!widPresent) {
+          this.maxWid = hugeWid
+        }
+
+        const c = format.codePointAt(i) ?? eof
+        const cw = runeWidth(c)
+        i += cw
+
+        if (c !== 0x63) {
+          // 'c'
+          this.SkipSpace()
+        }
+        if (c === 0x25) {
+          this.scanPercent()
+          continue // Do not consume an argument.
+        }
+        this.argLimit = this.limit
+        const f = this.count + this.maxWid
+        if (f < this.argLimit) {
+          this.argLimit = f
+        }
+
+        if (numProcessed >= a.length) {
+          // out of operands
+          this.errorString(
+            "too few operands for format '%" + format.slice(i - cw) + "'",
+          )
+        }
+        this.scanOne(c, a[numProcessed])
+        numProcessed++
+        this.argLimit = this.limit
+      }
+      if (numProcessed < a.length) {
+        this.errorString('too many operands')
+      }
+    } catch (e) {
+      return [numProcessed, errorOf(e)]
+    }
+    return [numProcessed, null]
+  }
+}
+
+// errorOf turns a value thrown during a scan into its error result.
+// Anything that is not a scan error is a real panic and keeps unwinding.
+function errorOf(e: unknown): $.GoError {
+  if (e instanceof scanError) {
+    return e.err
+  }
+  throw e
+}
+
+// stringReader reads the runes of a string for the Sscan functions.
+class stringReader implements runeScanner {
+  private pos = 0
+  private prev = -1
+
+  constructor(private s: string) {}
+
+  public ReadRune(): [number, number, $.GoError | null] {
+    if (this.pos >= this.s.length) {
+      this.prev = -1
+      return [0, 0, io.EOF]
+    }
+    const r = this.s.codePointAt(this.pos)!
+    this.prev = this.pos
+    this.pos += runeWidth(r)
+    return [r, utf8Len(r), null]
+  }
+
+  public UnreadRune(): $.GoError | null {
+    if (this.prev < 0) {
+      return errors.New(
+        'fmt: scanning called UnreadRune with no rune available',
+      )
+    }
+    this.pos = this.prev
+    this.prev = -1
+    return null
+  }
+}
+
+// readRune is a structure to enable reading UTF-8 encoded code points from
+// an io.Reader. It is used if the Reader given to the scanner does not
+// already implement io.RuneScanner.
+class readRune implements runeScanner {
+  private pendBuf: number[] = [] // bytes left over from bad UTF-8
+  private peekRune = -1 // if >=0 next rune; when <0 is ~(previous rune)
+  private one = new Uint8Array(1)
+
+  constructor(private reader: any) {}
+
+  // readByte returns the next byte from the input, which may be left over
+  // from a previous read if the UTF-8 was ill-formed.
+  private readByte(): [number, $.GoError | null] {
+    if (this.pendBuf.length > 0) {
+      return [this.pendBuf.shift()!, null]
+    }
+    for (;;) {
+      const [n, err] = this.reader.Read(this.one)
+      if (n === 1) {
+        return [this.one[0], null]
+      }
+      if (err !== null) {
+        return [0, err]
+      }
+    }
+  }
+
+  public ReadRune(): [number, number, $.GoError | null] {
+    if (this.peekRune >= 0) {
+      const rr = this.peekRune
+      this.peekRune = ~this.peekRune
+      return [rr, utf8Len(rr), null]
+    }
+    const [b0, err] = this.readByte()
+    if (err !== null) {
+      return [0, 0, err]
+    }
+    if (b0 < 0x80) {
+      // fast check for common ASCII case
+      this.peekRune = ~b0
+      return [b0, 1, null]
+    }
+    const buf = [b0]
+    while (buf.length < 4 && !fullRune(buf)) {
+      const [b, err] = this.readByte()
+      if (err !== null) {
+        if (err === io.EOF) {
+          break
+        }
+        return [0, 0, err]
+      }
+      buf.push(b)
+    }
+    const [rr, size] = decodeRune(buf)
+    if (size < buf.length) {
+      // an error, save the bytes for the next read
+      this.pendBuf.push(...buf.slice(size))
+    }
+    this.peekRune = ~rr
+    return [rr, size, null]
+  }
+
+  public UnreadRune(): $.GoError | null {
+    if (this.peekRune >= 0) {
+      return errors.New(
+        'fmt: scanning called UnreadRune with no rune available',
+      )
+    }
+    this.peekRune = ~this.peekRune
+    return null
+  }
+}
+
+// runeScannerOf returns r itself when it implements io.RuneScanner and
+// otherwise wraps it so that runes are read one byte at a time.
+function runeScannerOf(r: any): runeScanner {
+  if (
+    r !== null &&
+    typeof r.ReadRune === 'function' &&
+    typeof r.UnreadRune === 'function'
+  ) {
+    return r as runeScanner
+  }
+  return new readRune(r)
+}
+
+// stdin reads standard input for the Scan functions. Outside of Node there
+// is no standard input and every read reports EOF.
+const stdin = {
+  Read(b: $.Bytes): [number, $.GoError | null] {
+    const buf = b as Uint8Array
+    if (
+      typeof process === 'undefined' ||
+      typeof (process as any).getBuiltinModule !== 'function'
+    ) {
+      return [0, io.EOF]
+    }
+    const fs = (process as any).getBuiltinModule('node:fs')
+    try {
+      const n = fs.readSync(0, buf, 0, buf.length, null)
+      return n === 0 ? [0, io.EOF] : [n, null]
+    } catch {
+      return [0, io.EOF]
+    }
+  },
+}
+
+// typeName describes the type of v for error messages.
+function typeName(v: any): string {
+  if (v === null || v === undefined) {
+    return '<nil>'
+  }
+  if (typeof v === 'object' && v.constructor?.__typeInfo?.name) {
+    return v.constructor.__typeInfo.name
+  }
+  return typeof v
+}
+
+// space is the set of Unicode white space ranges recognized by the scanner.
+const space: [number, number][] = [
+  [0x0009, 0x000d],
+  [0x0020, 0x0020],
+  [0x0085, 0x0085],
+  [0x00a0, 0x00a0],
+  [0x1680, 0x1680],
+  [0x2000, 0x200a],
+  [0x2028, 0x2029],
+  [0x202f, 0x202f],
+  [0x205f, 0x205f],
+  [0x3000, 0x3000],
+]
+
+function isSpace(r: number): boolean {
+  if (r >= 1 << 16) {
+    return false
+  }
+  for (const [lo, hi] of space) {
+    if (r < lo) {
+      return false
+    }
+    if (r <= hi) {
+      return true
+    }
+  }
+  return false
+}
+
+function notSpace(r: number): boolean {
+  return !isSpace(r)
+}
+
+// indexRune reports whether the rune r occurs in s.
+function indexRune(s: string, r: number): boolean {
+  return r >= 0 && s.includes(String.fromCodePoint(r))
+}
+
+// hexDigit returns the value of the hexadecimal digit, or -1.
+function hexDigit(d: number): number {
+  if (d >= 0x30 && d <= 0x39) {
+    return d - 0x30
+  }
+  if (d >= 0x61 && d <= 0x66) {
+    return 10 + d - 0x61
+  }
+  if (d >= 0x41 && d <= 0x46) {
+    return 10 + d - 0x41
+  }
+  return -1
+}
+
+// parsenum converts ASCII to integer. isnum is false if there are no
+// digits.
+function parsenum(
+  s: string,
+  start: number,
+  end: number,
+): [number, boolean, number] {
+  if (start >= end) {
+    return [0, false, end]
+  }
+  let num = 0
+  let isnum = false
+  let newi = start
+  for (; newi < end && s[newi] >= '0' && s[newi] <= '9'; newi++) {
+    if (num > 1e6) {
+      return [0, false, end] // Overflow; crazy long number most likely.
+    }
+    num = num * 10 + (s.charCodeAt(newi) - 0x30)
+    isnum = true
+  }
+  return [num, isnum, newi]
+}
+
+// runeWidth returns the number of UTF-16 code units used by the rune r.
+function runeWidth(r: number): number {
+  return r > 0xffff ? 2 : 1
+}
+
+// utf8Len returns the number of bytes in the UTF-8 encoding of r.
+function utf8Len(r: number): number {
+  if (r < 0x80) {
+    return 1
+  }
+  if (r < 0x800) {
+    return 2
+  }
+  if (r < 0x10000) {
+    return 3
+  }
+  return 4
+}
+
+// fullRune reports whether the bytes in buf begin with a full UTF-8
+// encoding of a rune, or an encoding that can never become valid.
+function fullRune(buf: number[]): boolean {
+  const b0 = buf[0]
+  const need = b0 >= 0xf0 ? 4 : b0 >= 0xe0 ? 3 : b0 >= 0xc0 ? 2 : 1
+  if (buf.length >= need) {
+    return true
+  }
+  for (let i = 1; i < buf.length; i++) {
+    if ((buf[i] & 0xc0) !== 0x80) {
+      return true
+    }
+  }
+  return false
+}
+
+// decodeRune decodes the first UTF-8 encoding in buf, returning the
+// replacement character and a size of 1 for invalid input.
+function decodeRune(buf: number[]): [number, number] {
+  const b0 = buf[0]
+  const need = b0 >= 0xf8 ? 0 : b0 >= 0xf0 ? 4 : b0 >= 0xe0 ? 3 : 2
+  if (b0 < 0xc2 || need === 0 || buf.length < need) {
+    return [0xfffd, 1]
+  }
+  let r = b0 & (0x7f >> need)
+  for (let i = 1; i < need; i++) {
+    if ((buf[i] & 0xc0) !== 0x80) {
+      return [0xfffd, 1]
+    }
+    r = (r << 6) | (buf[i] & 0x3f)
+  }
+  if (r < [0, 0, 0x80, 0x800, 0x10000][need] || r > 0x10ffff) {
+    return [0xfffd, 1]
+  }
+  if (r >= 0xd800 && r <= 0xdfff) {
+    return [0xfffd, 1]
+  }
+  return [r, need]
+}
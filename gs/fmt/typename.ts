@@ -0,0 +1,230 @@
+// Go type names of runtime values, as reflect.Type.String reports them,
+// for the %T verb, %#v and the type in bad verb errors
+
+import * as $ from '@goscript/builtin/index.js'
+
+// The TypeScript names of basic types in type information, and the Go
+// types of their values.
+const tsBasicNames: Record<string, string> = {
+  number: 'int',
+  bigint: 'int64',
+  boolean: 'bool',
+  any: 'interface {}',
+  GoError: 'error',
+}
+
+// resolveType returns the registered type information for a type name, or
+// the type information itself.
+export function resolveType(
+  t: $.TypeInfo | string | undefined,
+): $.TypeInfo | undefined {
+  if (typeof t === 'string') {
+    if (t === 'error') {
+      return errorType
+    }
+    return $.getTypeByName(t) ?? { kind: $.TypeKind.Basic, name: t }
+  }
+  return t
+}
+
+// The predeclared error interface.
+const errorType: $.TypeInfo = {
+  kind: $.TypeKind.Interface,
+  name: 'GoError',
+  methods: [],
+}
+
+// typeName returns the Go name of the type described by t.
+export function typeName(t: $.TypeInfo | string | undefined): string {
+  if (t === undefined) {
+    return 'interface {}'
+  }
+  if (typeof t === 'string') {
+    return tsBasicNames[t] ?? $.typeString(t)
+  }
+  if (t.name !== undefined) {
+    return tsBasicNames[t.name] ?? $.typeString(t.name)
+  }
+  switch (t.kind) {
+    case $.TypeKind.Slice:
+      return '[]' + typeName(t.elemType)
+    case $.TypeKind.Array:
+      return '[' + t.length + ']' + typeName(t.elemType)
+    case $.TypeKind.Pointer:
+      return '*' + typeName(t.elemType)
+    case $.TypeKind.Map:
+      return 'map[' + typeName(t.keyType) + ']' + typeName(t.elemType)
+    case $.TypeKind.Channel:
+      switch (t.direction) {
+        case 'send':
+          return 'chan<- ' + typeName(t.elemType)
+        case 'receive':
+          return '<-chan ' + typeName(t.elemType)
+      }
+      return 'chan ' + typeName(t.elemType)
+    case $.TypeKind.Function:
+      return 'func' + signature(t.params ?? [], t.results ?? [])
+    case $.TypeKind.Interface: {
+      if (t.methods.length === 0) {
+        return 'interface {}'
+      }
+      const methods = t.methods.map(
+        (m) =>
+          m.name +
+          signature(
+            m.args.map((a) => a.type),
+            m.returns.map((r) => r.type),
+          ),
+      )
+      return 'interface { ' + methods.join('; ') + ' }'
+    }
+    case $.TypeKind.Struct: {
+      const fields = $.structFields(t.fields)
+      if (fields.length === 0) {
+        return 'struct {}'
+      }
+      const decls = fields.map((f) =>
+        f.embedded ? typeName(f.type) : f.name + ' ' + typeName(f.type),
+      )
+      return 'struct { ' + decls.join('; ') + ' }'
+    }
+  }
+  return 'interface {}'
+}
+
+// signature returns the parameters and results of a function type.
+function signature(
+  params: ($.TypeInfo | string)[],
+  results: ($.TypeInfo | string)[],
+): string {
+  let s = '(' + params.map(typeName).join(', ') + ')'
+  if (results.length === 1) {
+    s += ' ' + typeName(results[0])
+  } else if (results.length > 1) {
+    s += ' (' + results.map(typeName).join(', ') + ')'
+  }
+  return s
+}
+
+// isIntNumber reports whether a number without type information is taken
+// to be an int rather than a float64.
+export function isIntNumber(v: number): boolean {
+  return Number.isInteger(v) && Math.abs(v) < 2 ** 63 && !Object.is(v, -0)
+}
+
+// isChannel reports whether v is a channel or a directional reference to
+// one.
+export function isChannel(v: any): boolean {
+  if (v === null || typeof v !== 'object') {
+    return false
+  }
+  return (
+    typeof v.selectReceive === 'function' ||
+    v instanceof $.BidirectionalChannelRef ||
+    v instanceof $.SendOnlyChannelRef ||
+    v instanceof $.ReceiveOnlyChannelRef
+  )
+}
+
+// structTypeOf returns the type information of a struct value.
+export function structTypeOf(v: any): $.StructTypeInfo | undefined {
+  if (v === null || typeof v !== 'object') {
+    return undefined
+  }
+  const info = (v.constructor as { __typeInfo?: $.TypeInfo } | undefined)
+    ?.__typeInfo
+  return info?.kind === $.TypeKind.Struct ? info : undefined
+}
+
+// typeOf returns the Go type name of a value, from its type information t
+// if known. Without type information the type is inferred from the value,
+// and numbers are ints or float64s.
+export function typeOf(v: any, t?: $.TypeInfo | string): string {
+  const info = resolveType(t)
+  if (info !== undefined && info.kind !== $.TypeKind.Interface) {
+    return typeName(t)
+  }
+  if (v === null || v === undefined) {
+    return typeName(t)
+  }
+  if (v instanceof $.NamedValue) {
+    return typeName(v.type)
+  }
+  const marked = $.markedType(v)
+  if (marked !== undefined) {
+    return typeName(marked)
+  }
+  switch (typeof v) {
+    case 'boolean':
+      return 'bool'
+    case 'string':
+      return 'string'
+    case 'number':
+      return isIntNumber(v) ? 'int' : 'float64'
+    case 'bigint':
+      return 'int64'
+    case 'function':
+      return 'func()'
+  }
+  if (v instanceof $.Complex) {
+    return 'complex128'
+  }
+  if (v instanceof Uint8Array) {
+    return '[]uint8'
+  }
+  if ($.isByteArray(v)) {
+    return '[' + v.length + ']uint8'
+  }
+  if (Array.isArray(v) || $.isSliceProxy(v)) {
+    return '[]' + commonType($.asArray(v))
+  }
+  if (v instanceof Map) {
+    return (
+      'map[' +
+      commonType(Array.from(v.keys())) +
+      ']' +
+      commonType(Array.from(v.values()))
+    )
+  }
+  if ($.isVarRef(v)) {
+    return '*' + typeOf(v.value)
+  }
+  if (isChannel(v)) {
+    return 'chan interface {}'
+  }
+  const structInfo = structTypeOf(v)
+  if (structInfo !== undefined) {
+    return typeName(structInfo)
+  }
+  const named = v.constructor?.__typeInfo as $.TypeInfo | undefined
+  if (named?.name !== undefined) {
+    return typeName(named)
+  }
+  if (typeof v.Error === 'function') {
+    // errors.New returns a plain object
+    return '*errors.errorString'
+  }
+  if (Object.getPrototypeOf(v) === Object.prototype) {
+    // An anonymous struct
+    const fields = Object.entries(v).map(([k, f]) => k + ' ' + typeOf(f))
+    return fields.length === 0 ?
+        'struct {}'
+      : 'struct { ' + fields.join('; ') + ' }'
+  }
+  return v.constructor?.name ?? 'interface {}'
+}
+
+// commonType returns the type of the values if they all have the same
+// type, and interface {} otherwise.
+function commonType(values: any[]): string {
+  if (values.length === 0) {
+    return 'interface {}'
+  }
+  const t = typeOf(values[0])
+  for (const v of values) {
+    if (v === null || v === undefined || typeOf(v) !== t) {
+      return 'interface {}'
+    }
+  }
+  return t
+}
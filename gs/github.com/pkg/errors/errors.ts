@@ -99,7 +99,7 @@ class fundamental {
 
   // Register this type with the runtime type system
   static __typeInfo = $.registerStructType(
-    'fundamental',
+    'github.com/pkg/errors.fundamental',
     new fundamental(),
     [
       {
@@ -111,7 +111,7 @@ class fundamental {
     fundamental,
     {
       msg: { kind: $.TypeKind.Basic, name: 'string' },
-      stack: { kind: $.TypeKind.Pointer, elemType: 'stack' },
+      stack: { kind: $.TypeKind.Pointer, elemType: 'github.com/pkg/errors.stack' },
     },
   )
 }
@@ -184,7 +184,7 @@ class withStack {
 
   // Register this type with the runtime type system
   static __typeInfo = $.registerStructType(
-    'withStack',
+    'github.com/pkg/errors.withStack',
     new withStack(),
     [
       {
@@ -243,7 +243,7 @@ class withStack {
           },
         ],
       },
-      stack: { kind: $.TypeKind.Pointer, elemType: 'stack' },
+      stack: { kind: $.TypeKind.Pointer, elemType: 'github.com/pkg/errors.stack' },
     },
   )
 }
@@ -353,7 +353,7 @@ class withMessage {
 
   // Register this type with the runtime type system
   static __typeInfo = $.registerStructType(
-    'withMessage',
+    'github.com/pkg/errors.withMessage',
     new withMessage(),
     [
       {
@@ -439,7 +439,7 @@ export function Cause(err: $.GoError): $.GoError {
   }
 
   $.registerInterfaceType(
-    'causer',
+    'github.com/pkg/errors.causer',
     null, // Zero value for interface is null
     [
       {
@@ -467,7 +467,7 @@ export function Cause(err: $.GoError): $.GoError {
   )
 
   for (; err != null; ) {
-    let { value: cause, ok: ok } = $.typeAssert<causer>(err, 'causer')
+    let { value: cause, ok: ok } = $.typeAssert<causer>(err, 'github.com/pkg/errors.causer')
     if (!ok) {
       break
     }
@@ -0,0 +1,24 @@
+import { describe, it, expect } from 'vitest'
+import * as $ from '@goscript/builtin/index.js'
+import * as adler32 from './index.js'
+
+describe('hash/adler32', () => {
+  it('checksums like Go', () => {
+    expect(adler32.Checksum(null)).toBe(1)
+    expect(adler32.Checksum($.stringToBytes('a'))).toBe(6422626)
+    expect(adler32.Checksum($.stringToBytes('foobar'))).toBe(145425018)
+    // Long inputs reduce the sums modulo 65521 in chunks.
+    const long = new Uint8Array(100000).fill(0xff)
+    const h = adler32.New()!
+    h.Write(long.subarray(0, 7000))
+    h.Write(long.subarray(7000))
+    expect(h.Sum32()).toBe(345649196)
+    expect(adler32.Checksum(long)).toBe(345649196)
+    expect(Array.from($.bytesToUint8Array(h.Sum(null)))).toEqual([
+      h.Sum32() >>> 24,
+      (h.Sum32() >>> 16) & 0xff,
+      (h.Sum32() >>> 8) & 0xff,
+      h.Sum32() & 0xff,
+    ])
+  })
+})
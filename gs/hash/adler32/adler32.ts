@@ -0,0 +1,98 @@
+// Package adler32 implements the Adler-32 checksum.
+//
+// It is defined in RFC 1950:
+//
+//	Adler-32 is composed of two sums accumulated per byte: s1 is
+//	the sum of all bytes, s2 is the sum of all s1 values. Both sums
+//	are done modulo 65521. s1 is initialized to 1, s2 to zero.  The
+//	Adler-32 checksum is stored as s2*65536 + s1 in most-
+//	significant-byte first (network) order.
+
+import * as $ from '@goscript/builtin/index.js'
+import * as hash from '@goscript/hash/index.js'
+import { digestMethods } from './typeinfo.js'
+
+// mod is the largest prime that is less than 65536.
+const mod = 65521
+
+// nmax is the largest n such that
+// 255 * n * (n+1) / 2 + (n+1) * (mod-1) <= 2^32-1.
+// It is mentioned in RFC 1950 (search for "5552").
+const nmax = 5552
+
+// The size of an Adler-32 checksum in bytes.
+export const Size = 4
+
+// digest represents the partial evaluation of a checksum.
+// The low 16 bits are s1, the high 16 bits are s2.
+class digest {
+  public d = 1
+
+  public Reset(): void {
+    this.d = 1
+  }
+
+  public Size(): number {
+    return Size
+  }
+
+  public BlockSize(): number {
+    return 4
+  }
+
+  public Clone(): [hash.Cloner, $.GoError] {
+    const r = new digest()
+    r.d = this.d
+    return [r, null]
+  }
+
+  public Write(p: $.Bytes): [number, $.GoError] {
+    this.d = update(this.d, $.bytesToUint8Array(p))
+    return [$.len(p), null]
+  }
+
+  public Sum32(): number {
+    return this.d
+  }
+
+  public Sum(b: $.Bytes): $.Bytes {
+    const s = this.d
+    return $.append(b, new Uint8Array([s >>> 24, s >>> 16, s >>> 8, s]))
+  }
+
+  static __typeInfo = $.registerStructType(
+    'hash/adler32.digest',
+    new digest(),
+    digestMethods,
+    digest,
+    [],
+  )
+}
+
+// update adds p to the running checksum d.
+function update(d: number, p: Uint8Array): number {
+  let s1 = d & 0xffff
+  let s2 = d >>> 16
+  for (let i = 0; i < p.length; ) {
+    const end = Math.min(i + nmax, p.length)
+    for (; i < end; i++) {
+      s1 += p[i]
+      s2 += s1
+    }
+    s1 %= mod
+    s2 %= mod
+  }
+  return ((s2 << 16) | s1) >>> 0
+}
+
+// New returns a new hash.Hash32 computing the Adler-32 checksum. Its
+// Sum method will lay the value out in big-endian byte order. The
+// returned Hash32 also implements hash.Cloner.
+export function New(): hash.Hash32 {
+  return new digest()
+}
+
+// Checksum returns the Adler-32 checksum of data.
+export function Checksum(data: $.Bytes): number {
+  return update(1, $.bytesToUint8Array(data))
+}
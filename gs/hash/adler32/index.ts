@@ -0,0 +1,5 @@
+// Callers assert the digest to hash.Hash32 and hash.Cloner, which the hash
+// package registers.
+import '@goscript/hash/index.js'
+
+export { Checksum, New, Size } from './adler32.js'
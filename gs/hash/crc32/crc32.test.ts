@@ -0,0 +1,45 @@
+import { describe, it, expect } from 'vitest'
+import * as $ from '@goscript/builtin/index.js'
+import * as crc32 from './index.js'
+
+const fox =
+  'The quick brown fox jumps over the lazy dog, again and again and again'
+
+describe('hash/crc32', () => {
+  it('checksums like Go', () => {
+    const cases: [string, number, number, number][] = [
+      ['', 0, 0, 0],
+      ['a', 3904355907, 3251651376, 228764298],
+      ['foobar', 2666930069, 224353407, 2302501373],
+      [fox, 1207563472, 2712902829, 106979599],
+    ]
+    const castagnoli = crc32.MakeTable(crc32.Castagnoli)
+    const koopman = crc32.MakeTable(crc32.Koopman)
+    for (const [s, ieee, c, k] of cases) {
+      const data = $.stringToBytes(s)
+      expect(crc32.ChecksumIEEE(data)).toBe(ieee)
+      expect(crc32.Checksum(data, castagnoli)).toBe(c)
+      expect(crc32.Checksum(data, koopman)).toBe(k)
+    }
+    expect(crc32.MakeTable(crc32.IEEE)).toBe(crc32.IEEETable)
+  })
+
+  it('streams writes', () => {
+    const data = $.stringToBytes(fox)
+    const h = crc32.NewIEEE()!
+    for (let i = 0; i < data.length; i += 5) {
+      h.Write(data.subarray(i, i + 5))
+    }
+    expect(h.Sum32()).toBe(1207563472)
+    expect(Array.from($.bytesToUint8Array(h.Sum(null)))).toEqual([
+      0x47, 0xf9, 0xf4, 0xd0,
+    ])
+    const [c] = (h as any).Clone()
+    h.Reset()
+    expect(h.Sum32()).toBe(0)
+    expect(c.Sum32()).toBe(1207563472)
+    const head = crc32.ChecksumIEEE(data.subarray(0, 20))
+    const rest = data.subarray(20)
+    expect(crc32.Update(head, crc32.IEEETable, rest)).toBe(1207563472)
+  })
+})
@@ -0,0 +1,219 @@
+// Package crc32 implements the 32-bit cyclic redundancy check, or CRC-32,
+// checksum. See https://en.wikipedia.org/wiki/Cyclic_redundancy_check for
+// information.
+//
+// Polynomials are represented in LSB-first form also known as reversed
+// representation.
+//
+// See https://en.wikipedia.org/wiki/Mathematics_of_cyclic_redundancy_checks#Reversed_representations_and_reciprocal_polynomials
+// for information.
+
+import * as $ from '@goscript/builtin/index.js'
+import * as hash from '@goscript/hash/index.js'
+import { digestMethods } from './typeinfo.js'
+
+// The size of a CRC-32 checksum in bytes.
+export const Size = 4
+
+// Predefined polynomials.
+
+// IEEE is by far and away the most common CRC-32 polynomial.
+// Used by ethernet (IEEE 802.3), v.42, fddi, gzip, zip, png, ...
+export const IEEE = 0xedb88320
+
+// Castagnoli's polynomial, used in iSCSI.
+// Has better error detection characteristics than IEEE.
+// https://dx.doi.org/10.1109/26.231911
+export const Castagnoli = 0x82f63b78
+
+// Koopman's polynomial.
+// Also has better error detection characteristics than IEEE.
+// https://dx.doi.org/10.1109/DSN.2002.1028931
+export const Koopman = 0xeb31d82e
+
+// Table is a 256-word table representing the polynomial for efficient
+// processing.
+export type Table = number[]
+
+// Use slicing-by-8 when payload >= this value.
+const slicing8Cutoff = 16
+
+// slicing8Tables holds the tables of the slicing-by-8 algorithm of the
+// predefined tables, which are built when first used.
+const slicing8Tables = new Map<Table, Uint32Array[]>()
+
+// simpleMakeTable constructs a Table for the specified polynomial, suitable
+// for use with simpleUpdate.
+function simpleMakeTable(poly: number): Table {
+  const t: Table = new Array(256)
+  for (let i = 0; i < 256; i++) {
+    let crc = i
+    for (let j = 0; j < 8; j++) {
+      if (crc & 1) {
+        crc = (crc >>> 1) ^ poly
+      } else {
+        crc >>>= 1
+      }
+    }
+    t[i] = crc >>> 0
+  }
+  return t
+}
+
+// IEEETable is the table for the IEEE polynomial.
+export const IEEETable: Table = simpleMakeTable(IEEE)
+
+const castagnoliTable: Table = simpleMakeTable(Castagnoli)
+
+// simpleUpdate uses the simple algorithm to update the CRC, given a table
+// that was previously computed using simpleMakeTable.
+function simpleUpdate(crc: number, tab: Table, p: Uint8Array): number {
+  crc = ~crc
+  for (let i = 0; i < p.length; i++) {
+    crc = tab[(crc ^ p[i]) & 0xff] ^ (crc >>> 8)
+  }
+  return ~crc >>> 0
+}
+
+// slicingMakeTable constructs the tables of the slicing-by-8 algorithm
+// (slicingUpdate) for tab.
+function slicingMakeTable(tab: Table): Uint32Array[] {
+  const t = Array.from({ length: 8 }, () => new Uint32Array(256))
+  t[0].set(tab)
+  for (let i = 0; i < 256; i++) {
+    let crc = t[0][i]
+    for (let j = 1; j < 8; j++) {
+      crc = t[0][crc & 0xff] ^ (crc >>> 8)
+      t[j][i] = crc
+    }
+  }
+  return t
+}
+
+// slicingUpdate uses the slicing-by-8 algorithm to update the CRC.
+function slicingUpdate(
+  crc: number,
+  tab: Uint32Array[],
+  p: Uint8Array,
+): number {
+  const [t0, t1, t2, t3, t4, t5, t6, t7] = tab
+  crc = ~crc
+  let i = 0
+  for (; p.length - i > 8; i += 8) {
+    crc ^= p[i] | (p[i + 1] << 8) | (p[i + 2] << 16) | (p[i + 3] << 24)
+    crc =
+      t0[p[i + 7]] ^
+      t1[p[i + 6]] ^
+      t2[p[i + 5]] ^
+      t3[p[i + 4]] ^
+      t4[crc >>> 24] ^
+      t5[(crc >>> 16) & 0xff] ^
+      t6[(crc >>> 8) & 0xff] ^
+      t7[crc & 0xff]
+  }
+  for (; i < p.length; i++) {
+    crc = t0[(crc ^ p[i]) & 0xff] ^ (crc >>> 8)
+  }
+  return ~crc >>> 0
+}
+
+// MakeTable returns a Table constructed from the specified polynomial.
+// The contents of this Table must not be modified.
+export function MakeTable(poly: number): Table {
+  switch (poly >>> 0) {
+    case IEEE:
+      return IEEETable
+    case Castagnoli:
+      return castagnoliTable
+    default:
+      return simpleMakeTable(poly)
+  }
+}
+
+// digest represents the partial evaluation of a checksum.
+class digest {
+  constructor(
+    public crc: number,
+    public tab: Table,
+  ) {}
+
+  public Size(): number {
+    return Size
+  }
+
+  public BlockSize(): number {
+    return 1
+  }
+
+  public Reset(): void {
+    this.crc = 0
+  }
+
+  public Clone(): [hash.Cloner, $.GoError] {
+    return [new digest(this.crc, this.tab), null]
+  }
+
+  public Write(p: $.Bytes): [number, $.GoError] {
+    this.crc = Update(this.crc, this.tab, p)
+    return [$.len(p), null]
+  }
+
+  public Sum32(): number {
+    return this.crc
+  }
+
+  public Sum(b: $.Bytes): $.Bytes {
+    const s = this.Sum32()
+    return $.append(b, new Uint8Array([s >>> 24, s >>> 16, s >>> 8, s]))
+  }
+
+  static __typeInfo = $.registerStructType(
+    'hash/crc32.digest',
+    new digest(0, IEEETable),
+    digestMethods,
+    digest,
+    [],
+  )
+}
+
+// New creates a new hash.Hash32 computing the CRC-32 checksum using the
+// polynomial represented by the Table. Its Sum method will lay the
+// value out in big-endian byte order. The returned Hash32 also
+// implements hash.Cloner.
+export function New(tab: Table): hash.Hash32 {
+  return new digest(0, tab)
+}
+
+// NewIEEE creates a new hash.Hash32 computing the CRC-32 checksum using
+// the IEEE polynomial. Its Sum method will lay the value out in
+// big-endian byte order. The returned Hash32 also implements hash.Cloner.
+export function NewIEEE(): hash.Hash32 {
+  return New(IEEETable)
+}
+
+// Update returns the result of adding the bytes in p to the crc.
+export function Update(crc: number, tab: Table, p: $.Bytes): number {
+  const b = $.bytesToUint8Array(p)
+  const predefined = tab === IEEETable || tab === castagnoliTable
+  if (b.length < slicing8Cutoff || !predefined) {
+    return simpleUpdate(crc, tab, b)
+  }
+  let t = slicing8Tables.get(tab)
+  if (t === undefined) {
+    t = slicingMakeTable(tab)
+    slicing8Tables.set(tab, t)
+  }
+  return slicingUpdate(crc, t, b)
+}
+
+// Checksum returns the CRC-32 checksum of data
+// using the polynomial represented by the Table.
+export function Checksum(data: $.Bytes, tab: Table): number {
+  return Update(0, tab, data)
+}
+
+// ChecksumIEEE returns the CRC-32 checksum of data
+// using the IEEE polynomial.
+export function ChecksumIEEE(data: $.Bytes): number {
+  return Update(0, IEEETable, data)
+}
@@ -0,0 +1,18 @@
+// New and NewIEEE return a hash.Hash32 that also implements hash.Cloner;
+// loading the hash package registers both interfaces.
+import '@goscript/hash/index.js'
+
+export {
+  Castagnoli,
+  Checksum,
+  ChecksumIEEE,
+  IEEE,
+  IEEETable,
+  Koopman,
+  MakeTable,
+  New,
+  NewIEEE,
+  Size,
+  Update,
+} from './crc32.js'
+export type { Table } from './crc32.js'
@@ -0,0 +1,99 @@
+import { describe, it, expect } from 'vitest'
+import * as $ from '@goscript/builtin/index.js'
+import * as fnv from './index.js'
+
+function hex(b: $.Bytes): string {
+  const s = Array.from($.bytesToUint8Array(b), (x) => x.toString(16))
+  return s.map((x) => x.padStart(2, '0')).join('')
+}
+
+// Sums computed with Go.
+const golden: [string, string[]][] = [
+  [
+    '',
+    [
+      '811c9dc5',
+      '811c9dc5',
+      'cbf29ce484222325',
+      'cbf29ce484222325',
+      '6c62272e07bb014262b821756295c58d',
+      '6c62272e07bb014262b821756295c58d',
+    ],
+  ],
+  [
+    'foobar',
+    [
+      '31f0b262',
+      'bf9cf968',
+      '340d8765a4dda9c2',
+      '85944171f73967e8',
+      '7896bfea9c3c64bf6dc58353d2c293aa',
+      '343e1662793c64bf6f0d3597ba446f18',
+    ],
+  ],
+]
+
+const constructors = [
+  fnv.New32,
+  fnv.New32a,
+  fnv.New64,
+  fnv.New64a,
+  fnv.New128,
+  fnv.New128a,
+]
+
+describe('hash/fnv', () => {
+  it('sums like Go', () => {
+    for (const [s, sums] of golden) {
+      constructors.forEach((newHash, i) => {
+        const h = newHash()!
+        for (const c of $.stringToBytes(s)) {
+          h.Write(new Uint8Array([c]))
+        }
+        expect(hex(h.Sum(null))).toBe(sums[i])
+        h.Reset()
+        h.Write($.stringToBytes(s))
+        expect(hex(h.Sum($.stringToBytes('x')))).toBe('78' + sums[i])
+        expect(h.Size()).toBe(sums[i].length / 2)
+      })
+    }
+  })
+
+  it('returns 64-bit sums in the representation of the mode', () => {
+    const h = fnv.New64a()!
+    h.Write($.stringToBytes('a'))
+    expect(fnv.New32()!.Sum32()).toBe(2166136261)
+    expect(h.Sum64()).toBe(12638187200555641996)
+    $.setBigInt64Mode(true)
+    try {
+      expect(h.Sum64()).toBe(12638187200555641996n)
+    } finally {
+      $.setBigInt64Mode(false)
+    }
+  })
+
+  it('keeps the exact sum in the bytes outside bigint64 mode', () => {
+    const h = fnv.New64()!
+    h.Write($.stringToBytes('goscript'))
+    const exact = BigInt('0x' + hex(h.Sum(null)))
+    expect(exact).toBe(0x414d0c6cbef1f88en)
+    expect(h.Sum64()).toBe(Number(exact))
+    expect(BigInt(h.Sum64())).not.toBe(exact)
+    $.setBigInt64Mode(true)
+    try {
+      expect(h.Sum64()).toBe(exact)
+    } finally {
+      $.setBigInt64Mode(false)
+    }
+  })
+
+  it('clones', () => {
+    const h = fnv.New128a() as any
+    h.Write($.stringToBytes('foo'))
+    const [c, err] = h.Clone()
+    expect(err).toBeNull()
+    c.Write($.stringToBytes('bar'))
+    expect(hex(c.Sum(null))).toBe(golden[1][1][5])
+    expect(hex(h.Sum(null))).not.toBe(golden[1][1][5])
+  })
+})
@@ -0,0 +1,318 @@
+// Package fnv implements FNV-1 and FNV-1a, non-cryptographic hash functions
+// created by Glenn Fowler, Landon Curt Noll, and Phong Vo.
+// See
+// https://en.wikipedia.org/wiki/Fowler-Noll-Vo_hash_function.
+//
+// All the hash.Hash implementations returned by this package also
+// implement hash.Cloner.
+//
+// The 64 and 128-bit states are held in 32-bit words, so the sums are
+// exact. Sum64 returns a number or a bigint, as selected by the BigInt64
+// option. Without BigInt64, uint64 values are numbers, so Sum64 returns the
+// exact sum rounded to the nearest number, which loses the low bits of sums
+// above 2^53. Sum returns the exact bytes in both modes.
+
+import * as $ from '@goscript/builtin/index.js'
+import * as hash from '@goscript/hash/index.js'
+import {
+  digest128Methods,
+  digest32Methods,
+  digest64Methods,
+} from './typeinfo.js'
+
+const offset32 = 2166136261
+const prime32 = 16777619
+
+// offset64 and offset128 as 32-bit words, least significant first.
+const offset64 = [0x84222325, 0xcbf29ce4]
+const offset128 = [0x6295c58d, 0x62b82175, 0x07bb0142, 0x6c62272e]
+
+// The primes are 2^40 + prime64Lower and 2^88 + prime128Lower.
+const prime64Lower = 0x1b3
+const prime128Lower = 0x13b
+
+const two32 = 0x100000000
+
+// mul64 multiplies the 64-bit state s by the 64-bit prime.
+function mul64(s: Uint32Array): void {
+  const [s0, s1] = s
+  const r0 = s0 * prime64Lower
+  const r1 = s1 * prime64Lower + Math.floor(r0 / two32) + ((s0 << 8) >>> 0)
+  s[0] = r0 % two32
+  s[1] = r1 % two32
+}
+
+// mul128 multiplies the 128-bit state s by the 128-bit prime.
+function mul128(s: Uint32Array): void {
+  const [s0, s1, s2, s3] = s
+  const r0 = s0 * prime128Lower
+  const r1 = s1 * prime128Lower + Math.floor(r0 / two32)
+  const r2 =
+    s2 * prime128Lower + Math.floor(r1 / two32) + ((s0 << 24) >>> 0)
+  const r3 =
+    s3 * prime128Lower +
+    Math.floor(r2 / two32) +
+    (((s0 >>> 8) | (s1 << 24)) >>> 0)
+  s[0] = r0 % two32
+  s[1] = r1 % two32
+  s[2] = r2 % two32
+  s[3] = r3 % two32
+}
+
+// appendWords appends the 32-bit words of s to b in big-endian byte order,
+// most significant first.
+function appendWords(b: $.Bytes, s: ArrayLike<number>): $.Bytes {
+  const out = new Uint8Array(s.length * 4)
+  for (let i = 0; i < s.length; i++) {
+    const w = s[s.length - 1 - i]
+    out[i * 4] = w >>> 24
+    out[i * 4 + 1] = w >>> 16
+    out[i * 4 + 2] = w >>> 8
+    out[i * 4 + 3] = w
+  }
+  return $.append(b, out)
+}
+
+class sum32 {
+  public s = offset32
+
+  public Reset(): void {
+    this.s = offset32
+  }
+
+  public Sum32(): number {
+    return this.s
+  }
+
+  public Write(data: $.Bytes): [number, $.GoError] {
+    const b = $.bytesToUint8Array(data)
+    let hash = this.s
+    for (let i = 0; i < b.length; i++) {
+      hash = Math.imul(hash, prime32)
+      hash ^= b[i]
+    }
+    this.s = hash >>> 0
+    return [b.length, null]
+  }
+
+  public Size(): number {
+    return 4
+  }
+
+  public BlockSize(): number {
+    return 1
+  }
+
+  public Sum(b: $.Bytes): $.Bytes {
+    return appendWords(b, [this.s])
+  }
+
+  public Clone(): [hash.Cloner, $.GoError] {
+    const r = new (this.constructor as typeof sum32)()
+    r.s = this.s
+    return [r, null]
+  }
+
+  static __typeInfo = $.registerStructType(
+    'hash/fnv.sum32',
+    new sum32(),
+    digest32Methods,
+    sum32,
+    [],
+  )
+}
+
+class sum32a extends sum32 {
+  public Write(data: $.Bytes): [number, $.GoError] {
+    const b = $.bytesToUint8Array(data)
+    let hash = this.s
+    for (let i = 0; i < b.length; i++) {
+      hash ^= b[i]
+      hash = Math.imul(hash, prime32)
+    }
+    this.s = hash >>> 0
+    return [b.length, null]
+  }
+
+  static __typeInfo = $.registerStructType(
+    'hash/fnv.sum32a',
+    new sum32a(),
+    digest32Methods,
+    sum32a,
+    [],
+  )
+}
+
+class sum64 {
+  public s = Uint32Array.from(offset64)
+
+  public Reset(): void {
+    this.s.set(offset64)
+  }
+
+  // Sum64 returns the sum as a bigint in BigInt64 mode, else as the nearest
+  // number, see the package comment.
+  public Sum64(): any {
+    const [lo, hi] = this.s
+    if ($.isBigInt64Mode()) {
+      return (BigInt(hi) << 32n) | BigInt(lo)
+    }
+    return hi * two32 + lo
+  }
+
+  public Write(data: $.Bytes): [number, $.GoError] {
+    const b = $.bytesToUint8Array(data)
+    const s = this.s
+    for (let i = 0; i < b.length; i++) {
+      mul64(s)
+      s[0] ^= b[i]
+    }
+    return [b.length, null]
+  }
+
+  public Size(): number {
+    return 8
+  }
+
+  public BlockSize(): number {
+    return 1
+  }
+
+  public Sum(b: $.Bytes): $.Bytes {
+    return appendWords(b, this.s)
+  }
+
+  public Clone(): [hash.Cloner, $.GoError] {
+    const r = new (this.constructor as typeof sum64)()
+    r.s.set(this.s)
+    return [r, null]
+  }
+
+  static __typeInfo = $.registerStructType(
+    'hash/fnv.sum64',
+    new sum64(),
+    digest64Methods,
+    sum64,
+    [],
+  )
+}
+
+class sum64a extends sum64 {
+  public Write(data: $.Bytes): [number, $.GoError] {
+    const b = $.bytesToUint8Array(data)
+    const s = this.s
+    for (let i = 0; i < b.length; i++) {
+      s[0] ^= b[i]
+      mul64(s)
+    }
+    return [b.length, null]
+  }
+
+  static __typeInfo = $.registerStructType(
+    'hash/fnv.sum64a',
+    new sum64a(),
+    digest64Methods,
+    sum64a,
+    [],
+  )
+}
+
+class sum128 {
+  public s = Uint32Array.from(offset128)
+
+  public Reset(): void {
+    this.s.set(offset128)
+  }
+
+  public Write(data: $.Bytes): [number, $.GoError] {
+    const b = $.bytesToUint8Array(data)
+    const s = this.s
+    for (let i = 0; i < b.length; i++) {
+      mul128(s)
+      s[0] ^= b[i]
+    }
+    return [b.length, null]
+  }
+
+  public Size(): number {
+    return 16
+  }
+
+  public BlockSize(): number {
+    return 1
+  }
+
+  public Sum(b: $.Bytes): $.Bytes {
+    return appendWords(b, this.s)
+  }
+
+  public Clone(): [hash.Cloner, $.GoError] {
+    const r = new (this.constructor as typeof sum128)()
+    r.s.set(this.s)
+    return [r, null]
+  }
+
+  static __typeInfo = $.registerStructType(
+    'hash/fnv.sum128',
+    new sum128(),
+    digest128Methods,
+    sum128,
+    [],
+  )
+}
+
+class sum128a extends sum128 {
+  public Write(data: $.Bytes): [number, $.GoError] {
+    const b = $.bytesToUint8Array(data)
+    const s = this.s
+    for (let i = 0; i < b.length; i++) {
+      s[0] ^= b[i]
+      mul128(s)
+    }
+    return [b.length, null]
+  }
+
+  static __typeInfo = $.registerStructType(
+    'hash/fnv.sum128a',
+    new sum128a(),
+    digest128Methods,
+    sum128a,
+    [],
+  )
+}
+
+// New32 returns a new 32-bit FNV-1 hash.Hash.
+// Its Sum method will lay the value out in big-endian byte order.
+export function New32(): hash.Hash32 {
+  return new sum32()
+}
+
+// New32a returns a new 32-bit FNV-1a hash.Hash.
+// Its Sum method will lay the value out in big-endian byte order.
+export function New32a(): hash.Hash32 {
+  return new sum32a()
+}
+
+// New64 returns a new 64-bit FNV-1 hash.Hash.
+// Its Sum method will lay the value out in big-endian byte order.
+export function New64(): hash.Hash64 {
+  return new sum64()
+}
+
+// New64a returns a new 64-bit FNV-1a hash.Hash.
+// Its Sum method will lay the value out in big-endian byte order.
+export function New64a(): hash.Hash64 {
+  return new sum64a()
+}
+
+// New128 returns a new 128-bit FNV-1 hash.Hash.
+// Its Sum method will lay the value out in big-endian byte order.
+export function New128(): hash.Hash {
+  return new sum128()
+}
+
+// New128a returns a new 128-bit FNV-1a hash.Hash.
+// Its Sum method will lay the value out in big-endian byte order.
+export function New128a(): hash.Hash {
+  return new sum128a()
+}
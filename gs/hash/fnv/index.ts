@@ -0,0 +1,5 @@
+// Register hash.Hash32, hash.Hash64 and hash.Cloner for the digests
+// returned by the New functions.
+import '@goscript/hash/index.js'
+
+export { New128, New128a, New32, New32a, New64, New64a } from './fnv.js'
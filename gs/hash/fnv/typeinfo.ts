@@ -0,0 +1,49 @@
+import * as $ from '@goscript/builtin/index.js'
+
+// Type information for the methods of the fnv digests.
+
+const intType: $.TypeInfo = { kind: $.TypeKind.Basic, name: 'int' }
+const bytesType: $.TypeInfo = {
+  kind: $.TypeKind.Slice,
+  elemType: { kind: $.TypeKind.Basic, name: 'byte' },
+}
+const uint32Type: $.TypeInfo = {
+  kind: $.TypeKind.Basic,
+  name: 'uint32',
+}
+const uint64Type: $.TypeInfo = {
+  kind: $.TypeKind.Basic,
+  name: 'uint64',
+}
+const errorType: $.TypeInfo = {
+  kind: $.TypeKind.Interface,
+  name: 'GoError',
+  methods: [
+    {
+      name: 'Error',
+      args: [],
+      returns: [{ type: { kind: $.TypeKind.Basic, name: 'string' } }],
+    },
+  ],
+}
+
+const hashMethods: $.MethodSignature[] = [
+  $.method('BlockSize', [], [intType]),
+  $.method('Clone', [], ['hash.Cloner', errorType]),
+  $.method('Reset', [], []),
+  $.method('Size', [], [intType]),
+  $.method('Sum', [bytesType], [bytesType]),
+  $.method('Write', [bytesType], [intType, errorType]),
+]
+
+export const digest32Methods: $.MethodSignature[] = [
+  ...hashMethods,
+  $.method('Sum32', [], [uint32Type]),
+]
+
+export const digest64Methods: $.MethodSignature[] = [
+  ...hashMethods,
+  $.method('Sum64', [], [uint64Type]),
+]
+
+export const digest128Methods = hashMethods
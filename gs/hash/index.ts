@@ -0,0 +1,149 @@
+// Package hash provides interfaces for hash functions.
+//
+// 64-bit sums are numbers or bigints, as selected by the BigInt64 option.
+
+import * as $ from '@goscript/builtin/index.js'
+import * as io from '@goscript/io/index.js'
+
+const intType: $.TypeInfo = { kind: $.TypeKind.Basic, name: 'int' }
+
+const bytesType: $.TypeInfo = {
+  kind: $.TypeKind.Slice,
+  elemType: { kind: $.TypeKind.Basic, name: 'byte' },
+}
+
+const errorType: $.TypeInfo = {
+  kind: $.TypeKind.Interface,
+  name: 'GoError',
+  methods: [
+    {
+      name: 'Error',
+      args: [],
+      returns: [{ type: { kind: $.TypeKind.Basic, name: 'string' } }],
+    },
+  ],
+}
+
+const hashMethods: $.MethodSignature[] = [
+  {
+    name: 'BlockSize',
+    args: [],
+    returns: [{ type: intType }],
+  },
+  {
+    name: 'Reset',
+    args: [],
+    returns: [],
+  },
+  {
+    name: 'Size',
+    args: [],
+    returns: [{ type: intType }],
+  },
+  {
+    name: 'Sum',
+    args: [{ name: 'b', type: bytesType }],
+    returns: [{ type: bytesType }],
+  },
+  {
+    name: 'Write',
+    args: [{ name: 'p', type: bytesType }],
+    returns: [{ type: intType }, { type: errorType }],
+  },
+]
+
+// Hash is the common interface implemented by all hash functions.
+//
+// Hash implementations in the standard library (e.g. hash/crc32 and
+// crypto/sha256) implement the Cloner interface.
+export type Hash = null | (io.Writer & {
+  // Sum appends the current hash to b and returns the resulting slice.
+  // It does not change the underlying hash state.
+  Sum(b: $.Bytes): $.Bytes
+
+  // Reset resets the Hash to its initial state.
+  Reset(): void
+
+  // Size returns the number of bytes Sum will return.
+  Size(): number
+
+  // BlockSize returns the hash's underlying block size.
+  // The Write method must be able to accept any amount
+  // of data, but it may operate more efficiently if all writes
+  // are a multiple of the block size.
+  BlockSize(): number
+})
+
+$.registerInterfaceType('hash.Hash', null, hashMethods)
+
+// Hash32 is the common interface implemented by all 32-bit hash functions.
+export type Hash32 = null | (NonNullable<Hash> & {
+  Sum32(): number
+})
+
+$.registerInterfaceType('hash.Hash32', null, [
+  ...hashMethods,
+  {
+    name: 'Sum32',
+    args: [],
+    returns: [{ type: { kind: $.TypeKind.Basic, name: 'uint32' } }],
+  },
+])
+
+// Hash64 is the common interface implemented by all 64-bit hash functions.
+export type Hash64 = null | (NonNullable<Hash> & {
+  Sum64(): any
+})
+
+$.registerInterfaceType('hash.Hash64', null, [
+  ...hashMethods,
+  {
+    name: 'Sum64',
+    args: [],
+    returns: [{ type: { kind: $.TypeKind.Basic, name: 'uint64' } }],
+  },
+])
+
+// A Cloner is a hash function whose state can be cloned, returning a value
+// with equivalent and independent state.
+//
+// All Hash implementations in the standard library implement this
+// interface.
+//
+// If a hash can only determine at runtime if it can be cloned (e.g. if it
+// wraps another hash), Clone may return an error wrapping
+// errors.ErrUnsupported. Otherwise, Clone must always return a nil error.
+export type Cloner = null | (NonNullable<Hash> & {
+  Clone(): [Cloner, $.GoError]
+})
+
+$.registerInterfaceType('hash.Cloner', null, [
+  ...hashMethods,
+  {
+    name: 'Clone',
+    args: [],
+    returns: [{ type: 'hash.Cloner' }, { type: errorType }],
+  },
+])
+
+// XOF (extendable output function) is a hash function with arbitrary or
+// unlimited output length.
+export type XOF = null | (io.Writer &
+  io.Reader & {
+    // Reset resets the XOF to its initial state.
+    Reset(): void
+
+    // BlockSize returns the XOF's underlying block size.
+    BlockSize(): number
+  })
+
+$.registerInterfaceType('hash.XOF', null, [
+  hashMethods[0],
+  {
+    name: 'Read',
+    args: [{ name: 'p', type: bytesType }],
+    returns: [{ type: intType }, { type: errorType }],
+  },
+  hashMethods[1],
+  hashMethods[4],
+])
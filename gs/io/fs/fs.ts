@@ -32,7 +32,7 @@ export type FS = null | {
 }
 
 $.registerInterfaceType(
-  'FS',
+  'io/fs.FS',
   null, // Zero value for interface is null
   [
     {
@@ -41,7 +41,7 @@ $.registerInterfaceType(
         { name: 'name', type: { kind: $.TypeKind.Basic, name: 'string' } },
       ],
       returns: [
-        { type: 'File' },
+        { type: 'io/fs.File' },
         {
           type: {
             kind: $.TypeKind.Interface,
@@ -112,7 +112,7 @@ export type File = null | {
 }
 
 $.registerInterfaceType(
-  'File',
+  'io/fs.File',
   null, // Zero value for interface is null
   [
     {
@@ -166,7 +166,7 @@ $.registerInterfaceType(
       name: 'Stat',
       args: [],
       returns: [
-        { type: 'FileInfo' },
+        { type: 'io/fs.FileInfo' },
         {
           type: {
             kind: $.TypeKind.Interface,
@@ -205,14 +205,14 @@ export type DirEntry = null | {
 }
 
 $.registerInterfaceType(
-  'DirEntry',
+  'io/fs.DirEntry',
   null, // Zero value for interface is null
   [
     {
       name: 'Info',
       args: [],
       returns: [
-        { type: 'FileInfo' },
+        { type: 'io/fs.FileInfo' },
         {
           type: {
             kind: $.TypeKind.Interface,
@@ -238,7 +238,7 @@ $.registerInterfaceType(
       args: [],
       returns: [{ type: { kind: $.TypeKind.Basic, name: 'string' } }],
     },
-    { name: 'Type', args: [], returns: [{ type: 'FileMode' }] },
+    { name: 'Type', args: [], returns: [{ type: 'io/fs.FileMode' }] },
   ],
 )
 
@@ -264,14 +264,14 @@ export type ReadDirFile =
     } & File)
 
 $.registerInterfaceType(
-  'ReadDirFile',
+  'io/fs.ReadDirFile',
   null, // Zero value for interface is null
   [
     {
       name: 'ReadDir',
       args: [{ name: 'n', type: { kind: $.TypeKind.Basic, name: 'number' } }],
       returns: [
-        { type: { kind: $.TypeKind.Slice, elemType: 'DirEntry' } },
+        { type: { kind: $.TypeKind.Slice, elemType: 'io/fs.DirEntry' } },
         {
           type: {
             kind: $.TypeKind.Interface,
@@ -341,7 +341,7 @@ export type FileInfo = null | {
 }
 
 $.registerInterfaceType(
-  'FileInfo',
+  'io/fs.FileInfo',
   null, // Zero value for interface is null
   [
     {
@@ -349,8 +349,8 @@ $.registerInterfaceType(
       args: [],
       returns: [{ type: { kind: $.TypeKind.Basic, name: 'boolean' } }],
     },
-    { name: 'ModTime', args: [], returns: [{ type: 'Time' }] },
-    { name: 'Mode', args: [], returns: [{ type: 'FileMode' }] },
+    { name: 'ModTime', args: [], returns: [{ type: 'time.Time' }] },
+    { name: 'Mode', args: [], returns: [{ type: 'io/fs.FileMode' }] },
     {
       name: 'Name',
       args: [],
@@ -371,6 +371,19 @@ $.registerInterfaceType(
 
 export type FileMode = number
 
+$.registerNamedType(
+  'io/fs.FileMode',
+  0,
+  [
+    { name: 'IsDir', args: [], returns: [{ type: 'bool' }] },
+    { name: 'IsRegular', args: [], returns: [{ type: 'bool' }] },
+    { name: 'Perm', args: [], returns: [{ type: 'io/fs.FileMode' }] },
+    { name: 'String', args: [], returns: [{ type: 'string' }] },
+    { name: 'Type', args: [], returns: [{ type: 'io/fs.FileMode' }] },
+  ],
+  { kind: $.TypeKind.Basic, name: 'uint32' },
+)
+
 // IsDir reports whether m describes a directory.
 // That is, it tests for the ModeDir bit being set in m.
 export function FileMode_IsDir(receiver: FileMode): boolean {
@@ -582,7 +595,7 @@ export class PathError {
 
   // Register this type with the runtime type system
   static __typeInfo = $.registerStructType(
-    'PathError',
+    'io/fs.PathError',
     new PathError(),
     [
       {
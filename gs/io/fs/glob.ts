@@ -15,7 +15,7 @@ export type GlobFS =
     } & FS)
 
 $.registerInterfaceType(
-  'GlobFS',
+  'io/fs.GlobFS',
   null, // Zero value for interface is null
   [
     {
@@ -79,7 +79,7 @@ export function globWithLimit(
       return [null, path.ErrBadPattern]
     }
     {
-      let { value: fsysTyped, ok: ok } = $.typeAssert<GlobFS>(fsys, 'GlobFS')
+      let { value: fsysTyped, ok: ok } = $.typeAssert<GlobFS>(fsys, 'io/fs.GlobFS')
       if (ok) {
         return fsysTyped!.Glob(pattern)
       }
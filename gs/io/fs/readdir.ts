@@ -21,7 +21,7 @@ export type ReadDirFS =
     } & FS)
 
 $.registerInterfaceType(
-  'ReadDirFS',
+  'io/fs.ReadDirFS',
   null, // Zero value for interface is null
   [
     {
@@ -30,7 +30,7 @@ $.registerInterfaceType(
         { name: 'name', type: { kind: $.TypeKind.Basic, name: 'string' } },
       ],
       returns: [
-        { type: { kind: $.TypeKind.Slice, elemType: 'DirEntry' } },
+        { type: { kind: $.TypeKind.Slice, elemType: 'io/fs.DirEntry' } },
         {
           type: {
             kind: $.TypeKind.Interface,
@@ -63,7 +63,7 @@ export function ReadDir(
   {
     let { value: fsysTyped, ok: ok } = $.typeAssert<ReadDirFS>(
       fsys,
-      'ReadDirFS',
+      'io/fs.ReadDirFS',
     )
     if (ok) {
       return fsysTyped!.ReadDir(name)
@@ -78,7 +78,7 @@ export function ReadDir(
     file!.Close()
   })
 
-  let { value: dir, ok: ok } = $.typeAssert<ReadDirFile>(file, 'ReadDirFile')
+  let { value: dir, ok: ok } = $.typeAssert<ReadDirFile>(file, 'io/fs.ReadDirFile')
   if (!ok) {
     return [
       null,
@@ -151,7 +151,7 @@ class dirInfo {
 
   // Register this type with the runtime type system
   static __typeInfo = $.registerStructType(
-    'dirInfo',
+    'io/fs.dirInfo',
     new dirInfo(),
     [
       {
@@ -159,12 +159,12 @@ class dirInfo {
         args: [],
         returns: [{ type: { kind: $.TypeKind.Basic, name: 'boolean' } }],
       },
-      { name: 'Type', args: [], returns: [{ type: 'FileMode' }] },
+      { name: 'Type', args: [], returns: [{ type: 'io/fs.FileMode' }] },
       {
         name: 'Info',
         args: [],
         returns: [
-          { type: 'FileInfo' },
+          { type: 'io/fs.FileInfo' },
           {
             type: {
               kind: $.TypeKind.Interface,
@@ -10,7 +10,7 @@ export type StatFS =
     } & FS)
 
 $.registerInterfaceType(
-  'StatFS',
+  'io/fs.StatFS',
   null, // Zero value for interface is null
   [
     {
@@ -19,7 +19,7 @@ $.registerInterfaceType(
         { name: 'name', type: { kind: $.TypeKind.Basic, name: 'string' } },
       ],
       returns: [
-        { type: 'FileInfo' },
+        { type: 'io/fs.FileInfo' },
         {
           type: {
             kind: $.TypeKind.Interface,
@@ -45,7 +45,7 @@ $.registerInterfaceType(
 export function Stat(fsys: FS, name: string): [FileInfo, $.GoError] {
   using __defer = new $.DisposableStack()
   {
-    let { value: fsysTyped, ok: ok } = $.typeAssert<StatFS>(fsys, 'StatFS')
+    let { value: fsysTyped, ok: ok } = $.typeAssert<StatFS>(fsys, 'io/fs.StatFS')
     if (ok) {
       return fsysTyped!.Stat(name)
     }
@@ -16,14 +16,14 @@ export type SubFS =
     } & FS)
 
 $.registerInterfaceType(
-  'SubFS',
+  'io/fs.SubFS',
   null, // Zero value for interface is null
   [
     {
       name: 'Sub',
       args: [{ name: 'dir', type: { kind: $.TypeKind.Basic, name: 'string' } }],
       returns: [
-        { type: 'FS' },
+        { type: 'io/fs.FS' },
         {
           type: {
             kind: $.TypeKind.Interface,
@@ -64,7 +64,7 @@ export function Sub(fsys: FS, dir: string): [FS, $.GoError] {
     return [fsys, null]
   }
   {
-    let { value: fsysTyped, ok: ok } = $.typeAssert<SubFS>(fsys, 'SubFS')
+    let { value: fsysTyped, ok: ok } = $.typeAssert<SubFS>(fsys, 'io/fs.SubFS')
     if (ok) {
       return fsysTyped!.Sub(dir)
     }
@@ -139,7 +139,7 @@ class subFS {
     {
       let { value: e, ok: ok } = $.typeAssert<PathError | null>(err, {
         kind: $.TypeKind.Pointer,
-        elemType: 'PathError',
+        elemType: 'io/fs.PathError',
       })
       if (ok) {
         {
@@ -236,7 +236,7 @@ class subFS {
 
   // Register this type with the runtime type system
   static __typeInfo = $.registerStructType(
-    'subFS',
+    'io/fs.subFS',
     new subFS(),
     [
       {
@@ -318,7 +318,7 @@ class subFS {
           { name: 'name', type: { kind: $.TypeKind.Basic, name: 'string' } },
         ],
         returns: [
-          { type: 'File' },
+          { type: 'io/fs.File' },
           {
             type: {
               kind: $.TypeKind.Interface,
@@ -342,7 +342,7 @@ class subFS {
           { name: 'name', type: { kind: $.TypeKind.Basic, name: 'string' } },
         ],
         returns: [
-          { type: { kind: $.TypeKind.Slice, elemType: 'DirEntry' } },
+          { type: { kind: $.TypeKind.Slice, elemType: 'io/fs.DirEntry' } },
           {
             type: {
               kind: $.TypeKind.Interface,
@@ -424,7 +424,7 @@ class subFS {
           { name: 'dir', type: { kind: $.TypeKind.Basic, name: 'string' } },
         ],
         returns: [
-          { type: 'FS' },
+          { type: 'io/fs.FS' },
           {
             type: {
               kind: $.TypeKind.Interface,
@@ -135,7 +135,7 @@ export function WriteString(w: Writer, s: string): [number, $.GoError] {
   }
 
   // Convert string to bytes and write
-  const bytes = new TextEncoder().encode(s)
+  const bytes = $.stringToBytes(s)
   return w.Write(bytes)
 }
 
@@ -389,20 +389,18 @@ export function ReadAtLeast(
   }
 
   let n = 0
-  while (n < min) {
-    const [nn, err] = r.Read($.goSlice(buf, n))
+  let err: $.GoError = null
+  while (n < min && err === null) {
+    let nn: number
+    ;[nn, err] = r.Read($.goSlice(buf, n))
     n += nn
-    if (err !== null) {
-      if (err === EOF && n >= min) {
-        return [n, null]
-      }
-      if (err === EOF && n < min) {
-        return [n, ErrUnexpectedEOF]
-      }
-      return [n, err]
-    }
   }
-  return [n, null]
+  if (n >= min) {
+    err = null
+  } else if (n > 0 && err === EOF) {
+    err = ErrUnexpectedEOF
+  }
+  return [n, err]
 }
 
 // ReadFull reads exactly len(buf) bytes from r into buf
@@ -0,0 +1,72 @@
+import { describe, it, expect } from 'vitest'
+import * as $ from '@goscript/builtin/index.js'
+import * as cmplx from './index.js'
+
+// Expected values are the results of the Go implementation.
+function expectComplex(c: $.Complex, re: number, im: number) {
+  expect(c.re).toBeCloseTo(re, 14)
+  expect(c.im).toBeCloseTo(im, 14)
+}
+
+const x = $.complex(3, 4)
+const y = $.complex(-1.5, 0.5)
+
+describe('cmplx', () => {
+  it('should compute the modulus and phase', () => {
+    expect(cmplx.Abs(x)).toBe(5)
+    expect(cmplx.Phase(y)).toBeCloseTo(2.819842099193151, 14)
+    expectComplex(cmplx.Rect(2, 1), 1.0806046117362795, 1.682941969615793)
+    expectComplex(cmplx.Conj(x), 3, -4)
+  })
+
+  it('should compute square roots, exponentials and logarithms', () => {
+    expectComplex(cmplx.Sqrt(x), 2, 1)
+    expectComplex(cmplx.Sqrt($.complex(-4, 0)), 0, 2)
+    expectComplex(cmplx.Exp(y), 0.1958151375780682, 0.10697429720800304)
+    expectComplex(cmplx.Log(x), 1.6094379124341003, 0.9272952180016122)
+    expectComplex(cmplx.Log10(x), 0.6989700043360187, 0.4027191962733731)
+    expectComplex(cmplx.Pow(x, y), 0.046865038508157694, -0.03112309205687009)
+    expectComplex(cmplx.Pow($.complex(0, 0), $.complex(0, 0)), 1, 0)
+  })
+
+  it('should compute trigonometric functions', () => {
+    expectComplex(cmplx.Sin(y), -1.1248012470579227, 0.03686082371280446)
+    expectComplex(cmplx.Cos(y), 0.07976510530654189, 0.5197899547729212)
+    expectComplex(cmplx.Tan(y), -0.2551492218136517, 2.1247991277429965)
+    expectComplex(cmplx.Cot(y), -0.05571098848654156, -0.4639428601838284)
+    expectComplex(cmplx.Asin(x), 0.6339838656391772, 2.3055090312434685)
+    expectComplex(cmplx.Acos(x), 0.9368124611557194, -2.3055090312434685)
+    expectComplex(cmplx.Atan(x), 1.4483069952314644, 0.15899719167999918)
+  })
+
+  it('should use the series expansion near the poles of Tan', () => {
+    const t = cmplx.Tan($.complex(1.5707963267948966, 0.01))
+    expect(t.re).toBeCloseTo(6.123029892018991e-13, 20)
+    expect(t.im).toBeCloseTo(100.00333331111133, 10)
+    expectComplex(
+      cmplx.Atan($.complex(3e9, 1e9)),
+      1.5707963264948965,
+      9.999995274288588e-11,
+    )
+    // Large arguments are reduced with the Payne-Hanek algorithm
+    const c = cmplx.Cot($.complex(2.000000002e9, 0.01))
+    expect(c.re).toBeCloseTo(48.130405891212106, 6)
+    expect(c.im).toBeCloseTo(-36.474086465898736, 6)
+  })
+
+  it('should compute hyperbolic functions', () => {
+    expectComplex(cmplx.Sinh(y), -1.868618519182647, 1.1278052468057)
+    expectComplex(cmplx.Cosh(y), 2.0644336567607153, -1.0208309495976968)
+    expectComplex(cmplx.Tanh(y), -0.9443729864226216, 0.07932445480395668)
+    expectComplex(cmplx.Asinh(y), -1.2264568712514052, 0.2734872901415568)
+    expectComplex(cmplx.Acosh(y), 1.0693110431581108, 2.7419535862532687)
+    expectComplex(cmplx.Atanh(y), -0.6412373393653842, 1.2767950250211129)
+  })
+
+  it('should handle infinities and NaN', () => {
+    expect(cmplx.IsInf(cmplx.Inf())).toBe(true)
+    expect(cmplx.IsNaN(cmplx.NaN())).toBe(true)
+    expect(cmplx.IsNaN($.complex(NaN, Infinity))).toBe(false)
+    expect(cmplx.IsInf($.complexDiv(x, $.complex(0, 0)))).toBe(true)
+  })
+})
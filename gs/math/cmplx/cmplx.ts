@@ -0,0 +1,606 @@
+// Handwritten TypeScript implementation of Go's math/cmplx package, ported
+// from the Go sources so that special cases and results match.
+
+import * as $ from '@goscript/builtin/index.js'
+import * as math from '@goscript/math/index.js'
+
+// Abs returns the absolute value (also called the modulus) of x.
+export function Abs(x: $.Complex): number {
+  return math.Hypot(x.re, x.im)
+}
+
+// Phase returns the phase (also called the argument) of x.
+// The returned value is in the range [-Pi, Pi].
+export function Phase(x: $.Complex): number {
+  return math.Atan2(x.im, x.re)
+}
+
+// Polar returns the absolute value r and phase θ of x,
+// such that x = r * e**θi.
+// The phase is in the range [-Pi, Pi].
+export function Polar(x: $.Complex): [number, number] {
+  return [Abs(x), Phase(x)]
+}
+
+// Rect returns the complex number x with polar coordinates r, θ.
+export function Rect(r: number, θ: number): $.Complex {
+  const [s, c] = math.Sincos(θ)
+  return $.complex(r * c, r * s)
+}
+
+// Conj returns the complex conjugate of x.
+export function Conj(x: $.Complex): $.Complex {
+  return $.complex(x.re, -x.im)
+}
+
+// IsInf reports whether either real(x) or imag(x) is an infinity.
+export function IsInf(x: $.Complex): boolean {
+  return math.IsInf(x.re, 0) || math.IsInf(x.im, 0)
+}
+
+// Inf returns a complex infinity, complex(+Inf, +Inf).
+export function Inf(): $.Complex {
+  const inf = math.Inf(1)
+  return $.complex(inf, inf)
+}
+
+// IsNaN reports whether either real(x) or imag(x) is NaN
+// and neither is an infinity.
+export function IsNaN(x: $.Complex): boolean {
+  if (IsInf(x)) {
+    return false
+  }
+  return math.IsNaN(x.re) || math.IsNaN(x.im)
+}
+
+// NaN returns a complex “not-a-number” value.
+export function NaN(): $.Complex {
+  const nan = math.NaN()
+  return $.complex(nan, nan)
+}
+
+// Sqrt returns the square root of x.
+// The result r is chosen so that real(r) ≥ 0 and imag(r) has the same sign as imag(x).
+export function Sqrt(x: $.Complex): $.Complex {
+  if (x.im === 0) {
+    // Ensure that imag(r) has the same sign as imag(x) for imag(x) == signed zero.
+    if (x.re === 0) {
+      return $.complex(0, x.im)
+    }
+    if (x.re < 0) {
+      return $.complex(0, math.Copysign(math.Sqrt(-x.re), x.im))
+    }
+    return $.complex(math.Sqrt(x.re), x.im)
+  } else if (math.IsInf(x.im, 0)) {
+    return $.complex(math.Inf(1), x.im)
+  }
+  if (x.re === 0) {
+    if (x.im < 0) {
+      const r = math.Sqrt(-0.5 * x.im)
+      return $.complex(r, -r)
+    }
+    const r = math.Sqrt(0.5 * x.im)
+    return $.complex(r, r)
+  }
+  let a = x.re
+  let b = x.im
+  let scale: number
+  // Rescale to avoid internal overflow or underflow.
+  if (Math.abs(a) > 4 || Math.abs(b) > 4) {
+    a *= 0.25
+    b *= 0.25
+    scale = 2
+  } else {
+    a *= 1.8014398509481984e16 // 2**54
+    b *= 1.8014398509481984e16
+    scale = 7.450580596923828125e-9 // 2**-27
+  }
+  let r = math.Hypot(a, b)
+  let t: number
+  if (a > 0) {
+    t = math.Sqrt(0.5 * r + 0.5 * a)
+    r = scale * Math.abs((0.5 * b) / t)
+    t *= scale
+  } else {
+    r = math.Sqrt(0.5 * r - 0.5 * a)
+    t = scale * Math.abs((0.5 * b) / r)
+    r *= scale
+  }
+  if (b < 0) {
+    return $.complex(t, -r)
+  }
+  return $.complex(t, r)
+}
+
+// Exp returns e**x, the base-e exponential of x.
+export function Exp(x: $.Complex): $.Complex {
+  const re = x.re
+  const im = x.im
+  if (math.IsInf(re, 0)) {
+    if (re > 0 && im === 0) {
+      return x
+    }
+    if (math.IsInf(im, 0) || math.IsNaN(im)) {
+      if (re < 0) {
+        return $.complex(0, math.Copysign(0, im))
+      }
+      return $.complex(math.Inf(1), math.NaN())
+    }
+  } else if (math.IsNaN(re)) {
+    if (im === 0) {
+      return $.complex(math.NaN(), im)
+    }
+  }
+  const r = math.Exp(x.re)
+  const [s, c] = math.Sincos(x.im)
+  return $.complex(r * c, r * s)
+}
+
+// Log returns the natural logarithm of x.
+export function Log(x: $.Complex): $.Complex {
+  return $.complex(math.Log(Abs(x)), Phase(x))
+}
+
+// Log10 returns the decimal logarithm of x.
+export function Log10(x: $.Complex): $.Complex {
+  const z = Log(x)
+  return $.complex(math.Log10E * z.re, math.Log10E * z.im)
+}
+
+// Pow returns x**y, the base-x exponential of y.
+// For generalized compatibility with math.Pow:
+//
+//	Pow(0, ±0) returns 1+0i
+//	Pow(0, c) for real(c)<0 returns Inf+0i if imag(c) is zero, otherwise Inf+Inf i.
+export function Pow(x: $.Complex, y: $.Complex): $.Complex {
+  if (x.re === 0 && x.im === 0) {
+    // Guaranteed also true for x == -0.
+    if (IsNaN(y)) {
+      return NaN()
+    }
+    const r = y.re
+    const i = y.im
+    if (r === 0) {
+      return $.complex(1, 0)
+    }
+    if (r < 0) {
+      if (i === 0) {
+        return $.complex(math.Inf(1), 0)
+      }
+      return Inf()
+    }
+    if (r > 0) {
+      return $.complex(0, 0)
+    }
+    $.panic('not reached')
+  }
+  const modulus = Abs(x)
+  if (modulus === 0) {
+    return $.complex(0, 0)
+  }
+  let r = math.Pow(modulus, y.re)
+  const arg = Phase(x)
+  let theta = y.re * arg
+  if (y.im !== 0) {
+    r *= math.Exp(-y.im * arg)
+    theta += y.im * math.Log(modulus)
+  }
+  const [s, c] = math.Sincos(theta)
+  return $.complex(r * c, r * s)
+}
+
+// Sin returns the sine of x.
+export function Sin(x: $.Complex): $.Complex {
+  const re = x.re
+  const im = x.im
+  if (im === 0 && (math.IsInf(re, 0) || math.IsNaN(re))) {
+    return $.complex(math.NaN(), im)
+  } else if (math.IsInf(im, 0)) {
+    if (re === 0) {
+      return x
+    }
+    if (math.IsInf(re, 0) || math.IsNaN(re)) {
+      return $.complex(math.NaN(), im)
+    }
+  } else if (re === 0 && math.IsNaN(im)) {
+    return x
+  }
+  const [s, c] = math.Sincos(x.re)
+  const [sh, ch] = sinhcosh(x.im)
+  return $.complex(s * ch, c * sh)
+}
+
+// Sinh returns the hyperbolic sine of x.
+export function Sinh(x: $.Complex): $.Complex {
+  const re = x.re
+  const im = x.im
+  if (re === 0 && (math.IsInf(im, 0) || math.IsNaN(im))) {
+    return $.complex(re, math.NaN())
+  } else if (math.IsInf(re, 0)) {
+    if (im === 0) {
+      return $.complex(re, im)
+    }
+    if (math.IsInf(im, 0) || math.IsNaN(im)) {
+      return $.complex(re, math.NaN())
+    }
+  } else if (im === 0 && math.IsNaN(re)) {
+    return $.complex(math.NaN(), im)
+  }
+  const [s, c] = math.Sincos(x.im)
+  const [sh, ch] = sinhcosh(x.re)
+  return $.complex(c * sh, s * ch)
+}
+
+// Cos returns the cosine of x.
+export function Cos(x: $.Complex): $.Complex {
+  const re = x.re
+  const im = x.im
+  if (im === 0 && (math.IsInf(re, 0) || math.IsNaN(re))) {
+    return $.complex(math.NaN(), -im * math.Copysign(0, re))
+  } else if (math.IsInf(im, 0)) {
+    if (re === 0) {
+      return $.complex(math.Inf(1), -re * math.Copysign(0, im))
+    }
+    if (math.IsInf(re, 0) || math.IsNaN(re)) {
+      return $.complex(math.Inf(1), math.NaN())
+    }
+  } else if (re === 0 && math.IsNaN(im)) {
+    return $.complex(math.NaN(), 0)
+  }
+  const [s, c] = math.Sincos(x.re)
+  const [sh, ch] = sinhcosh(x.im)
+  return $.complex(c * ch, -s * sh)
+}
+
+// Cosh returns the hyperbolic cosine of x.
+export function Cosh(x: $.Complex): $.Complex {
+  const re = x.re
+  const im = x.im
+  if (re === 0 && (math.IsInf(im, 0) || math.IsNaN(im))) {
+    return $.complex(math.NaN(), re * math.Copysign(0, im))
+  } else if (math.IsInf(re, 0)) {
+    if (im === 0) {
+      return $.complex(math.Inf(1), im * math.Copysign(0, re))
+    }
+    if (math.IsInf(im, 0) || math.IsNaN(im)) {
+      return $.complex(math.Inf(1), math.NaN())
+    }
+  } else if (im === 0 && math.IsNaN(re)) {
+    return $.complex(math.NaN(), im)
+  }
+  const [s, c] = math.Sincos(x.im)
+  const [sh, ch] = sinhcosh(x.re)
+  return $.complex(c * ch, s * sh)
+}
+
+// sinhcosh calculates sinh and cosh.
+function sinhcosh(x: number): [number, number] {
+  if (Math.abs(x) <= 0.5) {
+    return [math.Sinh(x), math.Cosh(x)]
+  }
+  let e = math.Exp(x)
+  const ei = 0.5 / e
+  e *= 0.5
+  return [e - ei, e + ei]
+}
+
+// Tan returns the tangent of x.
+export function Tan(x: $.Complex): $.Complex {
+  const re = x.re
+  const im = x.im
+  if (math.IsInf(im, 0)) {
+    if (math.IsInf(re, 0) || math.IsNaN(re)) {
+      return $.complex(math.Copysign(0, re), math.Copysign(1, im))
+    }
+    return $.complex(math.Copysign(0, math.Sin(2 * re)), math.Copysign(1, im))
+  } else if (re === 0 && math.IsNaN(im)) {
+    return x
+  }
+  let d = math.Cos(2 * x.re) + math.Cosh(2 * x.im)
+  if (Math.abs(d) < 0.25) {
+    d = tanSeries(x)
+  }
+  if (d === 0) {
+    return Inf()
+  }
+  return $.complex(math.Sin(2 * x.re) / d, math.Sinh(2 * x.im) / d)
+}
+
+// Tanh returns the hyperbolic tangent of x.
+export function Tanh(x: $.Complex): $.Complex {
+  const re = x.re
+  const im = x.im
+  if (math.IsInf(re, 0)) {
+    if (math.IsInf(im, 0) || math.IsNaN(im)) {
+      return $.complex(math.Copysign(1, re), math.Copysign(0, im))
+    }
+    return $.complex(math.Copysign(1, re), math.Copysign(0, math.Sin(2 * im)))
+  } else if (im === 0 && math.IsNaN(re)) {
+    return x
+  }
+  const d = math.Cosh(2 * x.re) + math.Cos(2 * x.im)
+  if (d === 0) {
+    return Inf()
+  }
+  return $.complex(math.Sinh(2 * x.re) / d, math.Sin(2 * x.im) / d)
+}
+
+// Cot returns the cotangent of x.
+export function Cot(x: $.Complex): $.Complex {
+  let d = math.Cosh(2 * x.im) - math.Cos(2 * x.re)
+  if (Math.abs(d) < 0.25) {
+    d = tanSeries(x)
+  }
+  if (d === 0) {
+    return Inf()
+  }
+  return $.complex(math.Sin(2 * x.re) / d, -math.Sinh(2 * x.im) / d)
+}
+
+// mPi is the binary digits of 1/Pi as a bigint array, used by the
+// Payne-Hanek reduction of large arguments.
+const mPi = [
+  0x0000000000000000n,
+  0x517cc1b727220a94n,
+  0xfe13abe8fa9a6ee0n,
+  0x6db14acc9e21c820n,
+  0xff28b1d5ef5de2b0n,
+  0xdb92371d2126e970n,
+  0x0324977504e8c90en,
+  0x7f0ef58e5894d39fn,
+  0x74411afa975da242n,
+  0x74ce38135a2fbf20n,
+  0x9cc8eb1cc1a99cfan,
+  0x4e422fc5defc941dn,
+  0x8ffc4bffef02cc07n,
+  0xf79788c5ad05368fn,
+  0xb69b3f6793e584dbn,
+  0xa7a31fb34f2ff516n,
+  0xba93dd63f5f2f8bdn,
+  0x9e839cfbc5294975n,
+  0x35fdafd88fc6ae84n,
+  0x2b0198237e3db5d5n,
+]
+
+const float64View = new DataView(new ArrayBuffer(8))
+
+// reducePi reduces the input argument x to the range (-Pi/2, Pi/2].
+// x must be greater than or equal to 0. For small arguments the
+// Cody-Waite reduction is used; otherwise, the Payne-Hanek range reduction
+// with the 64-bit arithmetic done on bigints.
+function reducePi(x: number): number {
+  // reduceThreshold is the maximum value of x where the reduction using
+  // Cody-Waite reduction still gives accurate results.
+  const reduceThreshold = 1 << 30
+  if (Math.abs(x) < reduceThreshold) {
+    // PI1, PI2 and PI3 comprise an extended precision value of PI
+    // such that PI ~= PI1 + PI2 + PI3.
+    const PI1 = 3.141592502593994 // 0x400921fb40000000
+    const PI2 = 1.5099578831723193e-7 // 0x3e84442d00000000
+    const PI3 = 1.0780605716316238e-14 // 0x3d08469898cc5170
+    let t = x / Math.PI
+    t += 0.5
+    t = Math.trunc(t) // the multiple
+    return x - t * PI1 - t * PI2 - t * PI3
+  }
+  // Must apply Payne-Hanek range reduction
+  const mask = 0x7ffn
+  const shift = 64n - 11n - 1n
+  const bias = 1023n
+  const fracMask = (1n << shift) - 1n
+  const mask64 = (1n << 64n) - 1n
+
+  float64View.setFloat64(0, x)
+  let ix = float64View.getBigUint64(0)
+  const exp = ((ix >> shift) & mask) - bias - shift
+  ix &= fracMask
+  ix |= 1n << shift
+
+  // Use the exponent to extract the 3 appropriate uint64 digits from mPi,
+  // bitshift(B) = (exp + 64) % 64.
+  const digit = Number((exp + 64n) / 64n)
+  const bitshift = (exp + 64n) % 64n
+  const z0 =
+    ((mPi[digit] << bitshift) | (mPi[digit + 1] >> (64n - bitshift))) & mask64
+  const z1 =
+    ((mPi[digit + 1] << bitshift) | (mPi[digit + 2] >> (64n - bitshift))) &
+    mask64
+  const z2 =
+    ((mPi[digit + 2] << bitshift) | (mPi[digit + 3] >> (64n - bitshift))) &
+    mask64
+  // Multiply mantissa by the digits and extract the upper two digits (hi, lo).
+  const z2hi = (z2 * ix) >> 64n
+  const z1prod = z1 * ix
+  const z1hi = z1prod >> 64n
+  const z1lo = z1prod & mask64
+  const z0lo = (z0 * ix) & mask64
+  const loSum = z1lo + z2hi
+  const lo = loSum & mask64
+  const carry = loSum >> 64n
+  let hi = (z0lo + z1hi + carry) & mask64
+  // Find the magnitude of the fraction.
+  let lz = 0n
+  while (lz < 64n && ((hi >> (63n - lz)) & 1n) === 0n) {
+    lz++
+  }
+  const e = (bias - (lz + 1n)) & mask64
+  // Clear implicit mantissa bit and shift into place.
+  hi = ((hi << (lz + 1n)) | (lo >> (64n - (lz + 1n)))) & mask64
+  hi >>= 64n - shift
+  // Include the exponent and convert to a float.
+  hi |= e << shift
+  float64View.setBigUint64(0, hi & mask64)
+  x = float64View.getFloat64(0)
+  // map to (-Pi/2, Pi/2]
+  if (x > 0.5) {
+    x--
+  }
+  return Math.PI * x
+}
+
+// tanSeries computes the Taylor series expansion for cosh(2y) - cos(2x).
+function tanSeries(z: $.Complex): number {
+  const MACHEP = 1.0 / 2 ** 53
+  let x = Math.abs(2 * z.re)
+  let y = Math.abs(2 * z.im)
+  x = reducePi(x)
+  x = x * x
+  y = y * y
+  let x2 = 1.0
+  let y2 = 1.0
+  let f = 1.0
+  let rn = 0.0
+  let d = 0.0
+  for (;;) {
+    rn++
+    f *= rn
+    rn++
+    f *= rn
+    x2 *= x
+    y2 *= y
+    let t = y2 + x2
+    t /= f
+    d += t
+
+    rn++
+    f *= rn
+    rn++
+    f *= rn
+    x2 *= x
+    y2 *= y
+    t = y2 - x2
+    t /= f
+    d += t
+    // Caution: Use ! and > instead of <= for correct behavior if t/d is NaN.
+    if (!(Math.abs(t / d) > MACHEP)) {
+      break
+    }
+  }
+  return d
+}
+
+// Asin returns the inverse sine of x.
+export function Asin(x: $.Complex): $.Complex {
+  const re = x.re
+  const im = x.im
+  if (im === 0 && Math.abs(re) <= 1) {
+    return $.complex(math.Asin(re), im)
+  } else if (re === 0 && Math.abs(im) <= 1) {
+    return $.complex(re, math.Asinh(im))
+  } else if (math.IsNaN(im)) {
+    if (re === 0) {
+      return $.complex(re, math.NaN())
+    }
+    if (math.IsInf(re, 0)) {
+      return $.complex(math.NaN(), re)
+    }
+    return NaN()
+  } else if (math.IsInf(im, 0)) {
+    if (math.IsNaN(re)) {
+      return x
+    }
+    if (math.IsInf(re, 0)) {
+      return $.complex(math.Copysign(Math.PI / 4, re), im)
+    }
+    return $.complex(math.Copysign(0, re), im)
+  } else if (math.IsInf(re, 0)) {
+    return $.complex(math.Copysign(Math.PI / 2, re), math.Copysign(re, im))
+  }
+  const ct = $.complex(-x.im, x.re) // i * x
+  const xx = $.complexMul(x, x)
+  const x1 = $.complex(1 - xx.re, -xx.im) // 1 - x*x
+  const x2 = Sqrt(x1) // x2 = sqrt(1 - x*x)
+  const w = Log($.complexAdd(ct, x2))
+  return $.complex(w.im, -w.re) // -i * w
+}
+
+// Asinh returns the inverse hyperbolic sine of x.
+export function Asinh(x: $.Complex): $.Complex {
+  const re = x.re
+  const im = x.im
+  if (im === 0 && Math.abs(re) <= 1) {
+    return $.complex(math.Asinh(re), im)
+  } else if (re === 0 && Math.abs(im) <= 1) {
+    return $.complex(re, math.Asin(im))
+  } else if (math.IsInf(re, 0)) {
+    if (math.IsInf(im, 0)) {
+      return $.complex(re, math.Copysign(Math.PI / 4, im))
+    }
+    if (math.IsNaN(im)) {
+      return x
+    }
+    return $.complex(re, math.Copysign(0.0, im))
+  } else if (math.IsNaN(re)) {
+    if (im === 0) {
+      return x
+    }
+    if (math.IsInf(im, 0)) {
+      return $.complex(im, re)
+    }
+    return NaN()
+  } else if (math.IsInf(im, 0)) {
+    return $.complex(math.Copysign(im, re), math.Copysign(Math.PI / 2, im))
+  }
+  const xx = $.complexMul(x, x)
+  const x1 = $.complex(1 + xx.re, xx.im) // 1 + x*x
+  return Log($.complexAdd(x, Sqrt(x1))) // log(x + sqrt(1 + x*x))
+}
+
+// Acos returns the inverse cosine of x.
+export function Acos(x: $.Complex): $.Complex {
+  const w = Asin(x)
+  return $.complex(Math.PI / 2 - w.re, -w.im)
+}
+
+// Acosh returns the inverse hyperbolic cosine of x.
+export function Acosh(x: $.Complex): $.Complex {
+  if (x.re === 0 && x.im === 0) {
+    return $.complex(0, math.Copysign(Math.PI / 2, x.im))
+  }
+  const w = Acos(x)
+  if (w.im <= 0) {
+    return $.complex(-w.im, w.re) // i * w
+  }
+  return $.complex(w.im, -w.re) // -i * w
+}
+
+// Atan returns the inverse tangent of x.
+export function Atan(x: $.Complex): $.Complex {
+  const re = x.re
+  const im = x.im
+  if (im === 0) {
+    return $.complex(math.Atan(re), im)
+  } else if (re === 0 && Math.abs(im) <= 1) {
+    return $.complex(re, math.Atanh(im))
+  } else if (math.IsInf(im, 0) || math.IsInf(re, 0)) {
+    if (math.IsNaN(re)) {
+      return $.complex(math.NaN(), math.Copysign(0, im))
+    }
+    return $.complex(math.Copysign(Math.PI / 2, re), math.Copysign(0, im))
+  } else if (math.IsNaN(re) || math.IsNaN(im)) {
+    return NaN()
+  }
+  const x2 = x.re * x.re
+  const a = 1 - x2 - x.im * x.im
+  if (a === 0) {
+    return NaN()
+  }
+  let t = 0.5 * math.Atan2(2 * x.re, a)
+  const w = reducePi(t)
+
+  t = x.im - 1
+  const b = x2 + t * t
+  if (b === 0) {
+    return NaN()
+  }
+  t = x.im + 1
+  const c = (x2 + t * t) / b
+  return $.complex(w, 0.25 * math.Log(c))
+}
+
+// Atanh returns the inverse hyperbolic tangent of x.
+export function Atanh(x: $.Complex): $.Complex {
+  let z = $.complex(-x.im, x.re) // z = i * x
+  z = Atan(z)
+  return $.complex(z.im, -z.re) // z = -i * z
+}
@@ -0,0 +1,29 @@
+export {
+  Abs,
+  Acos,
+  Acosh,
+  Asin,
+  Asinh,
+  Atan,
+  Atanh,
+  Conj,
+  Cos,
+  Cosh,
+  Cot,
+  Exp,
+  Inf,
+  IsInf,
+  IsNaN,
+  Log,
+  Log10,
+  NaN,
+  Phase,
+  Polar,
+  Pow,
+  Rect,
+  Sin,
+  Sinh,
+  Sqrt,
+  Tan,
+  Tanh,
+} from './cmplx.js'
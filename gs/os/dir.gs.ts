@@ -1,5 +1,6 @@
 import * as $ from "@goscript/builtin/index.js";
 import { ErrUnimplemented } from "./error.gs.js";
+import { Open } from "./file_js.gs.js";
 
 import * as fs from "@goscript/io/fs/index.js"
 
@@ -11,8 +12,15 @@ type DirEntry = fs.DirEntry;
 // ReadDir returns the entries it was able to read before the error,
 // along with the error.
 export function ReadDir(name: string): [$.Slice<DirEntry>, $.GoError] {
-	// Directory reading not supported in JavaScript environment
-	return [null, ErrUnimplemented]
+	let [f, err] = Open(name)
+	if (err != null) {
+		return [null, err]
+	}
+	let [dirs, err2] = f!.ReadDir(-1)
+	f!.Close()
+	const sorted = $.asArray(dirs)
+	sorted.sort((a, b) => (a!.Name() < b!.Name() ? -1 : a!.Name() > b!.Name() ? 1 : 0))
+	return [$.arrayToSlice(sorted), err2]
 }
 
 // CopyFS copies the file system fsys into the directory dir,
@@ -1,4 +1,5 @@
 import * as $ from "@goscript/builtin/index.js";
+import { LinkError } from "./file_constants_js.gs.js";
 
 // import * as poll from "@goscript/internal/poll/index.js" // Not available in JavaScript
 
@@ -52,7 +53,7 @@ type timeout = null | {
 }
 
 $.registerInterfaceType(
-  'timeout',
+  'os.timeout',
   null, // Zero value for interface is null
   [{ name: "Timeout", args: [], returns: [{ type: { kind: $.TypeKind.Basic, name: "boolean" } }] }]
 );
@@ -109,13 +110,13 @@ export class SyscallError {
 	// Timeout reports whether this error represents a timeout.
 	public Timeout(): boolean {
 		const e = this
-		let { value: t, ok: ok } = $.typeAssert<timeout>(e.Err, 'timeout')
+		let { value: t, ok: ok } = $.typeAssert<timeout>(e.Err, 'os.timeout')
 		return ok && t!.Timeout()
 	}
 
 	// Register this type with the runtime type system
 	static __typeInfo = $.registerStructType(
-	  'SyscallError',
+	  'os.SyscallError',
 	  new SyscallError(),
 	  [{ name: "Error", args: [], returns: [{ type: { kind: $.TypeKind.Basic, name: "string" } }] }, { name: "Unwrap", args: [], returns: [{ type: { kind: $.TypeKind.Interface, name: 'GoError', methods: [{ name: 'Error', args: [], returns: [{ type: { kind: $.TypeKind.Basic, name: 'string' } }] }] } }] }, { name: "Timeout", args: [], returns: [{ type: { kind: $.TypeKind.Basic, name: "boolean" } }] }],
 	  SyscallError,
@@ -172,7 +173,7 @@ export function IsPermission(err: $.GoError): boolean {
 // New code should use errors.Is with a value appropriate to the call
 // returning the error, such as [os.ErrDeadlineExceeded].
 export function IsTimeout(err: $.GoError): boolean {
-	let { value: terr, ok: ok } = $.typeAssert<timeout>(underlyingError(err), 'timeout')
+	let { value: terr, ok: ok } = $.typeAssert<timeout>(underlyingError(err), 'os.timeout')
 	return ok && terr!.Timeout()
 }
 
@@ -185,21 +186,15 @@ export function underlyingErrorIs(err: $.GoError, target: $.GoError): boolean {
 		return true
 	}
 	// To preserve prior behavior, only examine syscall errors.
-	let { value: e, ok: ok } = $.typeAssert<any>(err, 'syscallErrorType')
-	return ok && e.Is(target)
+	let e = err as any
+	return e != null && typeof e.Errno === 'function' && e.Is(target)
 }
 
 // underlyingError returns the underlying error for known os error types.
 export function underlyingError(err: $.GoError): $.GoError {
-	$.typeSwitch(err, [{ types: [{kind: $.TypeKind.Pointer, elemType: 'PathError'}], body: (err) => {
-		return err!.Err
-	}},
-	{ types: [{kind: $.TypeKind.Pointer, elemType: 'LinkError'}], body: (err) => {
-		return err!.Err
-	}},
-	{ types: [{kind: $.TypeKind.Pointer, elemType: 'SyscallError'}], body: (err) => {
-		return err!.Err
-	}}])
+	if (err instanceof PathError || err instanceof LinkError || err instanceof SyscallError) {
+		return err.Err
+	}
 	return err
 }
 
@@ -1,5 +1,8 @@
 import * as $ from "@goscript/builtin/index.js";
-import { ErrUnimplemented } from "./error.gs.js";
+import { ErrUnimplemented, PathError } from "./error.gs.js";
+import { GetFileSystem } from "./vfs.js";
+
+import * as syscall from "@goscript/syscall/index.js"
 
 // JavaScript-specific stubs for file constants and operations
 // These provide the required constants and stub implementations
@@ -76,13 +79,24 @@ export class LinkError {
 	}
 }
 
-// Directory and file operation stubs
+// Readlink returns the destination of the named symbolic link.
+// If there is an error, it will be of type [*PathError].
 export function Readlink(name: string): [string, $.GoError] {
-	return ["", ErrUnimplemented]
+	let [s, err] = GetFileSystem().readlink(name)
+	if (err != null) {
+		return ["", new PathError({Op: "readlink", Path: name, Err: err})]
+	}
+	return [s, null]
 }
 
+// TempDir returns the default directory to use for temporary files,
+// $TMPDIR if non-empty, else /tmp.
 export function TempDir(): string {
-	return "/tmp"
+	let [dir] = syscall.Getenv("TMPDIR")
+	if (dir == "") {
+		dir = "/tmp"
+	}
+	return dir
 }
 
 export function UserCacheDir(): [string, $.GoError] {
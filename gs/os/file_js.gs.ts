@@ -1,99 +1,256 @@
 import * as $ from "@goscript/builtin/index.js";
-import { ErrUnimplemented } from "./error.gs.js";
-import { File } from "./types_js.gs.js";
+import { ErrInvalid, PathError } from "./error.gs.js";
+import { LinkError, O_APPEND, O_CREATE, O_RDONLY, O_RDWR, O_TRUNC, O_WRONLY } from "./file_constants_js.gs.js";
+import { Lstat, Stat } from "./stat_js.gs.js";
+import { File, SameFile, file } from "./types_js.gs.js";
+import { ReadDir } from "./dir.gs.js";
+import { GetFileSystem } from "./vfs.js";
 
 import * as fs from "@goscript/io/fs/index.js"
+import * as io from "@goscript/io/index.js"
+import * as syscall from "@goscript/syscall/index.js"
 
-// JavaScript-specific implementations for filesystem operations
-// These functions stub filesystem operations that cannot be implemented 
-// in a JavaScript environment
+// JavaScript-specific implementations for filesystem operations.
+// These functions operate on the filesystem returned by GetFileSystem.
 
-// File operations - stub implementations
+// Open opens the named file for reading. If successful, methods on
+// the returned file can be used for reading; the associated file
+// descriptor has mode O_RDONLY.
+// If there is an error, it will be of type *PathError.
 export function Open(name: string): [File | null, $.GoError] {
-  return [null, ErrUnimplemented]
+	return OpenFile(name, O_RDONLY, 0)
 }
 
+// Create creates or truncates the named file. If the file already exists,
+// it is truncated. If the file does not exist, it is created with mode 0o666
+// (before umask). If successful, methods on the returned File can
+// be used for I/O; the associated file descriptor has mode O_RDWR.
+// If there is an error, it will be of type *PathError.
 export function Create(name: string): [File | null, $.GoError] {
-  return [null, ErrUnimplemented]
+	return OpenFile(name, O_RDWR | O_CREATE | O_TRUNC, 0o666)
 }
 
+// OpenFile is the generalized open call; most users will use Open
+// or Create instead. It opens the named file with specified flag
+// (O_RDONLY etc.). If the file does not exist, and the O_CREATE flag
+// is passed, it is created with mode perm (before umask).
+// If successful, methods on the returned File can be used for I/O.
+// If there is an error, it will be of type *PathError.
 export function OpenFile(name: string, flag: number, perm: number): [File | null, $.GoError] {
-  return [null, ErrUnimplemented]
+	const fsys = GetFileSystem()
+	let [handle, err] = fsys.open(name, flag, perm)
+	if (err != null) {
+		return [null, new PathError({Op: "open", Path: name, Err: err})]
+	}
+	return [new File({file: new file(name, handle!, fsys, (flag & O_APPEND) != 0)}), null]
 }
 
+// ReadFile reads the named file and returns the contents.
+// A successful call returns err == nil, not err == EOF.
+// Because ReadFile reads the whole file, it does not treat an EOF from Read
+// as an error to be reported.
 export function ReadFile(name: string): [$.Bytes, $.GoError] {
-  return [null, ErrUnimplemented]
+	let [f, err] = Open(name)
+	if (err != null) {
+		return [null, err]
+	}
+	using __defer = new $.DisposableStack();
+	__defer.defer(() => {
+		f!.Close()
+	});
+
+	let size = 0
+	let [info, err2] = f!.Stat()
+	if (err2 == null) {
+		size = info!.Size()
+	}
+	// Read into a buffer of the expected size, growing it if the file
+	// changes size while it is read.
+	let data = new Uint8Array(Math.max(size + 1, 512))
+	let n = 0
+	for (;;) {
+		if (n == data.length) {
+			const grown = new Uint8Array(data.length * 2)
+			grown.set(data)
+			data = grown
+		}
+		let [m, rerr] = f!.Read(data.subarray(n))
+		n += m
+		if (rerr != null) {
+			if (rerr == io.EOF) {
+				rerr = null
+			}
+			return [data.subarray(0, n), rerr]
+		}
+	}
 }
 
+// WriteFile writes data to the named file, creating it if necessary.
+// If the file does not exist, WriteFile creates it with permissions perm (before umask);
+// otherwise WriteFile truncates it before writing, without changing permissions.
+// Since WriteFile requires multiple system calls to complete, a failure mid-operation
+// can leave the file in a partially written state.
 export function WriteFile(name: string, data: $.Bytes, perm: number): $.GoError {
-  return ErrUnimplemented
+	let [f, err] = OpenFile(name, O_WRONLY | O_CREATE | O_TRUNC, perm)
+	if (err != null) {
+		return err
+	}
+	let [, err1] = f!.Write(data)
+	let err2 = f!.Close()
+	if (err1 == null) {
+		err1 = err2
+	}
+	return err1
 }
 
-// Directory operations - stub implementations  
+// Mkdir creates a new directory with the specified name and permission
+// bits (before umask).
+// If there is an error, it will be of type *PathError.
 export function Mkdir(name: string, perm: number): $.GoError {
-  return ErrUnimplemented
-}
-
-export function MkdirAll(path: string, perm: number): $.GoError {
-  return ErrUnimplemented
-}
-
-export function Remove(name: string): $.GoError {
-  return ErrUnimplemented
-}
-
-export function RemoveAll(path: string): $.GoError {
-  return ErrUnimplemented
+	let err = GetFileSystem().mkdir(name, perm)
+	if (err != null) {
+		return new PathError({Op: "mkdir", Path: name, Err: err})
+	}
+	return null
 }
 
+// Chdir changes the current working directory to the named directory.
+// If there is an error, it will be of type *PathError.
 export function Chdir(dir: string): $.GoError {
-  return ErrUnimplemented
+	let err = GetFileSystem().chdir(dir)
+	if (err != null) {
+		return new PathError({Op: "chdir", Path: dir, Err: err})
+	}
+	return null
 }
 
+// Chmod changes the mode of the named file to mode.
+// If the file is a symbolic link, it changes the mode of the link's target.
+// If there is an error, it will be of type *PathError.
 export function Chmod(name: string, mode: number): $.GoError {
-  return ErrUnimplemented
+	let err = GetFileSystem().chmod(name, mode)
+	if (err != null) {
+		return new PathError({Op: "chmod", Path: name, Err: err})
+	}
+	return null
 }
 
+// Rename renames (moves) oldpath to newpath.
+// If newpath already exists and is not a directory, Rename replaces it.
+// If newpath already exists and is a directory, Rename returns an error.
+// If there is an error, it will be of type *LinkError.
 export function Rename(oldpath: string, newpath: string): $.GoError {
-  return ErrUnimplemented
-}
-
-// File information operations - stub implementations
-export function Stat(name: string): [fs.FileInfo, $.GoError] {
-  return [null, ErrUnimplemented]
+	let [fi, err] = Lstat(newpath)
+	if (err == null && fi!.IsDir()) {
+		// There are two independent errors this function can return:
+		// one for a bad oldname, and one for a bad newname.
+		// At this point we've determined the newname is bad.
+		// But just in case oldname is also bad, prioritize returning
+		// the oldname error because that's what we did historically.
+		// However, if the old name and new name are not the same, yet
+		// they refer to the same file, it implies a case-only
+		// rename on a case-insensitive filesystem, which is ok.
+		let [ofi, err2] = Lstat(oldpath)
+		if (err2 != null) {
+			let pe = err2 as PathError
+			return new LinkError({Op: "rename", Old: oldpath, New: newpath, Err: pe.Err})
+		}
+		if (oldpath == newpath || !SameFile(fi, ofi)) {
+			return new LinkError({Op: "rename", Old: oldpath, New: newpath, Err: syscall.EEXIST})
+		}
+	}
+	let err3 = GetFileSystem().rename(oldpath, newpath)
+	if (err3 != null) {
+		return new LinkError({Op: "rename", Old: oldpath, New: newpath, Err: err3})
+	}
+	return null
 }
 
-export function Lstat(name: string): [fs.FileInfo, $.GoError] {
-  return [null, ErrUnimplemented]
+// DirFS returns a file system (an fs.FS) for the tree of files rooted at the directory dir.
+//
+// Note that DirFS("/prefix") only guarantees that the Open calls it makes to the
+// operating system will begin with "/prefix": DirFS("/prefix").Open("file") is the
+// same as os.Open("/prefix/file").
+export function DirFS(dir: string): fs.FS {
+	return new dirFS(dir)
 }
 
-// Link operations - stub implementations  
-export function Link(oldname: string, newname: string): $.GoError {
-  return ErrUnimplemented
-}
+class dirFS {
+	constructor(public dir: string) {}
 
-export function Symlink(oldname: string, newname: string): $.GoError {
-  return ErrUnimplemented
-}
+	public Open(name: string): [fs.File, $.GoError] {
+		let [fullname, err] = this.join(name)
+		if (err != null) {
+			return [null, new PathError({Op: "open", Path: name, Err: err})]
+		}
+		let [f, err2] = Open(fullname)
+		if (err2 != null) {
+			// DirFS takes a string appropriate for GOOS,
+			// while the name argument here is always slash separated.
+			// dir.join will have mixed the two; undo that for
+			// error reporting.
+			(err2 as PathError).Path = name
+			return [null, err2]
+		}
+		return [f, null]
+	}
 
-export function Readlink(name: string): [string, $.GoError] {
-  return ["", ErrUnimplemented]
-}
+	// The ReadFile method calls the [ReadFile] function for the file
+	// with the given name in the directory, if that file exists.
+	public ReadFile(name: string): [$.Bytes, $.GoError] {
+		let [fullname, err] = this.join(name)
+		if (err != null) {
+			return [null, new PathError({Op: "readfile", Path: name, Err: err})]
+		}
+		let [b, err2] = ReadFile(fullname)
+		if (err2 != null) {
+			(err2 as PathError).Op = "readfile";
+			(err2 as PathError).Path = name
+			return [null, err2]
+		}
+		return [b, null]
+	}
 
-export function Truncate(name: string, size: number): $.GoError {
-  return ErrUnimplemented
-}
+	// ReadDir reads the named directory, returning all its directory entries sorted
+	// by filename. Through this method, dirFS implements [io/fs.ReadDirFS].
+	public ReadDir(name: string): [$.Slice<fs.DirEntry>, $.GoError] {
+		let [fullname, err] = this.join(name)
+		if (err != null) {
+			return [null, new PathError({Op: "readdir", Path: name, Err: err})]
+		}
+		let [entries, err2] = ReadDir(fullname)
+		if (err2 != null) {
+			(err2 as PathError).Op = "readdir";
+			(err2 as PathError).Path = name
+			return [null, err2]
+		}
+		return [entries, null]
+	}
 
-// File system information - stub implementations
-export function DirFS(dir: string): fs.FS {
-  // Return a stub filesystem that always returns ErrUnimplemented
-  return new stubFS()
-}
+	public Stat(name: string): [fs.FileInfo, $.GoError] {
+		let [fullname, err] = this.join(name)
+		if (err != null) {
+			return [null, new PathError({Op: "stat", Path: name, Err: err})]
+		}
+		let [f, err2] = Stat(fullname)
+		if (err2 != null) {
+			(err2 as PathError).Path = name
+			return [null, err2]
+		}
+		return [f, null]
+	}
 
-class stubFS {
-  public Open(name: string): [fs.File, $.GoError] {
-    return [null, ErrUnimplemented]
-  }
+	// join returns the path for name in dir.
+	public join(name: string): [string, $.GoError] {
+		if (this.dir == "") {
+			return ["", $.newError("os: DirFS with empty root")]
+		}
+		if (!fs.ValidPath(name)) {
+			return ["", ErrInvalid]
+		}
+		if (name == ".") {
+			return [this.dir, null]
+		}
+		return [this.dir + "/" + name, null]
+	}
 }
-
- 
\ No newline at end of file
@@ -1,11 +1,15 @@
 import * as $ from "@goscript/builtin/index.js";
-import { ErrUnimplemented } from "./error.gs.js";
+import { ErrUnimplemented, PathError } from "./error.gs.js";
+import { LinkError, O_WRONLY } from "./file_constants_js.gs.js";
+import { OpenFile } from "./file_js.gs.js";
 import { File } from "./types_js.gs.js";
+import { GetFileSystem } from "./vfs.js";
 
 import * as fs from "@goscript/io/fs/index.js"
 
-// JavaScript-specific implementations for Unix file operations
-// These functions stub Unix-specific operations that cannot be implemented in JavaScript
+// JavaScript-specific implementations for Unix file operations.
+// Operations on paths use the filesystem returned by GetFileSystem, the
+// remaining Unix-specific operations are stubbed.
 
 // Device null path - stub for JavaScript
 export const DevNull = "/dev/null"
@@ -20,21 +24,48 @@ export function NewFile(fd: number, name: string): File | null {
 	return null
 }
 
-// File operations that need to be stubbed
+// Remove removes the named file or (empty) directory.
+// If there is an error, it will be of type [*PathError].
 export function Remove(name: string): $.GoError {
-	return ErrUnimplemented
+	let err = GetFileSystem().remove(name)
+	if (err != null) {
+		return new PathError({Op: "remove", Path: name, Err: err})
+	}
+	return null
 }
 
+// Link creates newname as a hard link to the oldname file.
+// If there is an error, it will be of type *LinkError.
 export function Link(oldname: string, newname: string): $.GoError {
-	return ErrUnimplemented
+	let err = GetFileSystem().link(oldname, newname)
+	if (err != null) {
+		return new LinkError({Op: "link", Old: oldname, New: newname, Err: err})
+	}
+	return null
 }
 
+// Symlink creates newname as a symbolic link to oldname.
+// If there is an error, it will be of type *LinkError.
 export function Symlink(oldname: string, newname: string): $.GoError {
-	return ErrUnimplemented
+	let err = GetFileSystem().symlink(oldname, newname)
+	if (err != null) {
+		return new LinkError({Op: "symlink", Old: oldname, New: newname, Err: err})
+	}
+	return null
 }
 
+// Truncate changes the size of the named file.
+// If the file is a symbolic link, it changes the size of the link's target.
+// If there is an error, it will be of type *PathError.
 export function Truncate(name: string, size: number): $.GoError {
-	return ErrUnimplemented
+	let [f, err] = OpenFile(name, O_WRONLY, 0)
+	if (err != null) {
+		(err as PathError).Op = "truncate"
+		return err
+	}
+	let err2 = f!.Truncate(size)
+	f!.Close()
+	return err2
 }
 
 // Internal stub functions that may be referenced by other files
@@ -55,7 +86,7 @@ export function tempDir(): string {
 }
 
 export function readlink(name: string): [string, $.GoError] {
-	return ["", ErrUnimplemented]
+	return GetFileSystem().readlink(name)
 }
 
 // File class methods that need to be stubbed for unix compatibility
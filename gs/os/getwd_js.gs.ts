@@ -1,21 +1,18 @@
 import * as $ from "@goscript/builtin/index.js";
+import { PathError } from "./error.gs.js";
+import { GetFileSystem } from "./vfs.js";
 
 import * as fs from "@goscript/io/fs/index.js"
 
-// JavaScript-specific implementation of Getwd
-// Working directories are not a concept in JavaScript environments
-// Store working directory in a global variable
-
-// Global working directory variable, defaults to test directory
-let currentWorkingDir: string = "/"
-
+// Getwd returns an absolute path name corresponding to the
+// current directory. The working directory is that of the filesystem
+// returned by GetFileSystem.
 export function Getwd(): [string, $.GoError] {
-	return [currentWorkingDir, null]
-}
-
-// Set the working directory (for internal use)
-export function setWorkingDir(dir: string): void {
-	currentWorkingDir = dir
+	let [dir, err] = GetFileSystem().getwd()
+	if (err != null) {
+		return ["", new PathError({Op: "getwd", Path: "", Err: err})]
+	}
+	return [dir, null]
 }
 
 // Additional functions that may be imported by other files
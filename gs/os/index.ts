@@ -49,8 +49,6 @@ export {
   ReadFile,
   Rename,
   WriteFile,
-  MkdirAll,
-  RemoveAll,
 } from './file_js.gs.js'
 export {
   LinkError,
@@ -84,6 +82,7 @@ export {
 } from './file_unix_js.gs.js'
 export { Chown, Chtimes, Lchown } from './file_posix_js.gs.js'
 export { Getwd } from './getwd_js.gs.js'
+export { MkdirAll, RemoveAll } from './path.gs.js'
 export {
   IsPathSeparator,
   PathListSeparator,
@@ -131,5 +130,10 @@ export {
   SameFile,
 } from './types_js.gs.js'
 
+export { GetFileSystem, SetFileSystem } from './vfs.js'
+export type { FileHandle, FileStat, FileSystem } from './vfs.js'
+export { MemFS } from './memfs.js'
+export { NodeFS } from './nodefs.js'
+
 // Export FileMode as a type
 export type { FileMode } from './types_js.gs.js'
@@ -0,0 +1,418 @@
+import * as $ from '@goscript/builtin/index.js'
+import * as fs from '@goscript/io/fs/index.js'
+import * as syscall from '@goscript/syscall/index.js'
+
+import {
+  O_APPEND,
+  O_CREATE,
+  O_EXCL,
+  O_RDWR,
+  O_TRUNC,
+  O_WRONLY,
+} from './file_constants_js.gs.js'
+import type { FileHandle, FileStat, FileSystem } from './vfs.js'
+
+// maxSymlinks is the number of symbolic links followed while resolving a
+// path before giving up with ELOOP, as on Linux.
+const maxSymlinks = 40
+
+// umask is applied to the permission bits of created files like the
+// typical process umask.
+const umask = 0o022
+
+// memNode is a file, directory or symbolic link in a MemFS. Hard links share
+// the node.
+class memNode {
+  public modTime = Date.now()
+  // data holds the contents of a file, its first size bytes are used.
+  public data = new Uint8Array(0)
+  public size = 0
+  // children holds the entries of a directory, it is null for other files.
+  public children: Map<string, memNode> | null = null
+  // target is the destination of a symbolic link.
+  public target = ''
+
+  constructor(public mode: number) {}
+
+  public isDir(): boolean {
+    return this.children !== null
+  }
+
+  public isSymlink(): boolean {
+    return (this.mode & fs.ModeSymlink) !== 0
+  }
+
+  public stat(name: string): FileStat {
+    return {
+      name,
+      size: this.size,
+      mode: this.mode,
+      modTime: this.modTime,
+      id: this,
+    }
+  }
+
+  // resize changes the size of a file, zeroing any bytes it adds.
+  public resize(size: number): void {
+    if (size > this.data.length) {
+      const data = new Uint8Array(Math.max(size, this.data.length * 2))
+      data.set(this.data.subarray(0, this.size))
+      this.data = data
+    } else if (size < this.size) {
+      this.data.fill(0, size, this.size)
+    }
+    this.size = size
+    this.modTime = Date.now()
+  }
+}
+
+function newDir(perm: number): memNode {
+  const n = new memNode(fs.ModeDir | (perm & fs.ModePerm))
+  n.children = new Map()
+  return n
+}
+
+// resolved is the result of looking up a path in a MemFS.
+interface resolved {
+  // dir is the directory containing the final element, null for the root.
+  dir: memNode | null
+  // name is the final element of the path.
+  name: string
+  // node is the file found, null if it does not exist.
+  node: memNode | null
+  // path is the absolute path with symbolic links in directories resolved.
+  path: string
+}
+
+/**
+ * MemFS is an in-memory FileSystem. It starts out with the directories / and
+ * /tmp, and the working directory /.
+ */
+export class MemFS implements FileSystem {
+  private root = newDir(0o755)
+  private cwd = '/'
+
+  constructor() {
+    this.root.children!.set('tmp', newDir(0o777 | fs.ModeSticky))
+  }
+
+  // resolve looks up name. Symbolic links are followed in directories, and
+  // for the final element if follow is set.
+  private resolve(name: string, follow: boolean): [resolved | null, $.GoError] {
+    if (name === '') {
+      return [null, syscall.ENOENT]
+    }
+    let path = name.startsWith('/') ? name : this.cwd + '/' + name
+    let links = 0
+    restart: for (;;) {
+      const parts = path.split('/').filter((p) => p !== '' && p !== '.')
+      const stack: memNode[] = [this.root]
+      const names: string[] = []
+      for (let i = 0; i < parts.length; i++) {
+        const part = parts[i]
+        if (part === '..') {
+          if (stack.length > 1) {
+            stack.pop()
+            names.pop()
+          }
+          continue
+        }
+        const dir = stack[stack.length - 1]
+        if (!dir.isDir()) {
+          return [null, syscall.ENOTDIR]
+        }
+        const last = i === parts.length - 1
+        const child = dir.children!.get(part)
+        if (child === undefined) {
+          if (!last) {
+            return [null, syscall.ENOENT]
+          }
+          const p = '/' + [...names, part].join('/')
+          return [{ dir, name: part, node: null, path: p }, null]
+        }
+        if (child.isSymlink() && (!last || follow)) {
+          if (++links > maxSymlinks) {
+            return [null, syscall.ELOOP]
+          }
+          const base = child.target.startsWith('/') ? '' : '/' + names.join('/')
+          path = [base, child.target, ...parts.slice(i + 1)].join('/')
+          continue restart
+        }
+        stack.push(child)
+        names.push(part)
+      }
+      const node = stack[stack.length - 1]
+      const dir = stack.length > 1 ? stack[stack.length - 2] : null
+      const base = names.length > 0 ? names[names.length - 1] : '/'
+      return [{ dir, name: base, node, path: '/' + names.join('/') }, null]
+    }
+  }
+
+  // lookup resolves name and fails with ENOENT if it does not exist.
+  private lookup(name: string, follow: boolean): [resolved | null, $.GoError] {
+    const [r, err] = this.resolve(name, follow)
+    if (err !== null) {
+      return [null, err]
+    }
+    if (r!.node === null) {
+      return [null, syscall.ENOENT]
+    }
+    return [r, null]
+  }
+
+  public open(
+    name: string,
+    flag: number,
+    perm: number,
+  ): [FileHandle | null, $.GoError] {
+    const [r, err] = this.resolve(name, true)
+    if (err !== null) {
+      return [null, err]
+    }
+    const writable = (flag & (O_WRONLY | O_RDWR)) !== 0
+    let node = r!.node
+    if (node === null) {
+      if ((flag & O_CREATE) === 0) {
+        return [null, syscall.ENOENT]
+      }
+      node = new memNode(perm & fs.ModePerm & ~umask)
+      r!.dir!.children!.set(r!.name, node)
+      r!.dir!.modTime = Date.now()
+    } else if ((flag & O_CREATE) !== 0 && (flag & O_EXCL) !== 0) {
+      return [null, syscall.EEXIST]
+    } else if (node.isDir() && writable) {
+      return [null, syscall.EISDIR]
+    } else if ((flag & O_TRUNC) !== 0 && writable) {
+      node.resize(0)
+    }
+    return [new memHandle(node, r!.name, writable, (flag & O_APPEND) !== 0), null]
+  }
+
+  public stat(name: string): [FileStat | null, $.GoError] {
+    const [r, err] = this.lookup(name, true)
+    if (err !== null) {
+      return [null, err]
+    }
+    return [r!.node!.stat(r!.name), null]
+  }
+
+  public lstat(name: string): [FileStat | null, $.GoError] {
+    const [r, err] = this.lookup(name, false)
+    if (err !== null) {
+      return [null, err]
+    }
+    return [r!.node!.stat(r!.name), null]
+  }
+
+  public readDir(name: string): [FileStat[] | null, $.GoError] {
+    const [r, err] = this.lookup(name, true)
+    if (err !== null) {
+      return [null, err]
+    }
+    const dir = r!.node!
+    if (!dir.isDir()) {
+      return [null, syscall.ENOTDIR]
+    }
+    const entries: FileStat[] = []
+    for (const [child, node] of dir.children!) {
+      entries.push(node.stat(child))
+    }
+    return [entries, null]
+  }
+
+  // create adds a new node at name, which must not exist yet.
+  private create(name: string, node: memNode): $.GoError {
+    const [r, err] = this.resolve(name, false)
+    if (err !== null) {
+      return err
+    }
+    if (r!.node !== null) {
+      return syscall.EEXIST
+    }
+    r!.dir!.children!.set(r!.name, node)
+    r!.dir!.modTime = Date.now()
+    return null
+  }
+
+  public mkdir(name: string, perm: number): $.GoError {
+    return this.create(name, newDir(perm & ~umask))
+  }
+
+  public remove(name: string): $.GoError {
+    const [r, err] = this.lookup(name, false)
+    if (err !== null) {
+      return err
+    }
+    if (r!.dir === null) {
+      return syscall.EBUSY
+    }
+    const node = r!.node!
+    if (node.isDir() && node.children!.size > 0) {
+      return syscall.ENOTEMPTY
+    }
+    r!.dir.children!.delete(r!.name)
+    r!.dir.modTime = Date.now()
+    return null
+  }
+
+  public rename(oldname: string, newname: string): $.GoError {
+    const [from, err] = this.lookup(oldname, false)
+    if (err !== null) {
+      return err
+    }
+    const [to, err2] = this.resolve(newname, false)
+    if (err2 !== null) {
+      return err2
+    }
+    if (from!.dir === null || to!.dir === null) {
+      return syscall.EBUSY
+    }
+    const node = from!.node!
+    if (to!.node === node) {
+      return null
+    }
+    if (node.isDir() && to!.path.startsWith(from!.path + '/')) {
+      return syscall.EINVAL
+    }
+    if (to!.node !== null) {
+      if (to!.node.isDir()) {
+        if (!node.isDir()) {
+          return syscall.EISDIR
+        }
+        if (to!.node.children!.size > 0) {
+          return syscall.ENOTEMPTY
+        }
+      } else if (node.isDir()) {
+        return syscall.ENOTDIR
+      }
+    }
+    from!.dir.children!.delete(from!.name)
+    to!.dir.children!.set(to!.name, node)
+    from!.dir.modTime = to!.dir.modTime = Date.now()
+    return null
+  }
+
+  public chmod(name: string, mode: number): $.GoError {
+    const [r, err] = this.lookup(name, true)
+    if (err !== null) {
+      return err
+    }
+    const bits = fs.ModePerm | fs.ModeSetuid | fs.ModeSetgid | fs.ModeSticky
+    r!.node!.mode = (r!.node!.mode & ~bits) | (mode & bits)
+    return null
+  }
+
+  public link(oldname: string, newname: string): $.GoError {
+    const [r, err] = this.lookup(oldname, false)
+    if (err !== null) {
+      return err
+    }
+    if (r!.node!.isDir()) {
+      return syscall.EPERM
+    }
+    return this.create(newname, r!.node!)
+  }
+
+  public symlink(oldname: string, newname: string): $.GoError {
+    const node = new memNode(fs.ModeSymlink | 0o777)
+    node.target = oldname
+    node.size = oldname.length
+    return this.create(newname, node)
+  }
+
+  public readlink(name: string): [string, $.GoError] {
+    const [r, err] = this.lookup(name, false)
+    if (err !== null) {
+      return ['', err]
+    }
+    if (!r!.node!.isSymlink()) {
+      return ['', syscall.EINVAL]
+    }
+    return [r!.node!.target, null]
+  }
+
+  public getwd(): [string, $.GoError] {
+    return [this.cwd, null]
+  }
+
+  public chdir(dir: string): $.GoError {
+    const [r, err] = this.lookup(dir, true)
+    if (err !== null) {
+      return err
+    }
+    if (!r!.node!.isDir()) {
+      return syscall.ENOTDIR
+    }
+    this.cwd = r!.path
+    return null
+  }
+}
+
+// memHandle is an open file in a MemFS.
+class memHandle implements FileHandle {
+  private closed = false
+
+  constructor(
+    private node: memNode,
+    private name: string,
+    private writable: boolean,
+    private append: boolean,
+  ) {}
+
+  public readAt(b: Uint8Array, off: number): [number, $.GoError] {
+    if (this.closed) {
+      return [0, syscall.EBADF]
+    }
+    if (this.node.isDir()) {
+      return [0, syscall.EISDIR]
+    }
+    if (off >= this.node.size) {
+      return [0, null]
+    }
+    const n = Math.min(b.length, this.node.size - off)
+    b.set(this.node.data.subarray(off, off + n))
+    return [n, null]
+  }
+
+  public writeAt(b: Uint8Array, off: number): [number, $.GoError] {
+    if (this.closed || !this.writable) {
+      return [0, syscall.EBADF]
+    }
+    if (this.append) {
+      off = this.node.size
+    }
+    if (off + b.length > this.node.size) {
+      this.node.resize(off + b.length)
+    }
+    this.node.data.set(b, off)
+    this.node.modTime = Date.now()
+    return [b.length, null]
+  }
+
+  public stat(): [FileStat | null, $.GoError] {
+    if (this.closed) {
+      return [null, syscall.EBADF]
+    }
+    return [this.node.stat(this.name), null]
+  }
+
+  public truncate(size: number): $.GoError {
+    if (this.closed || !this.writable) {
+      return syscall.EINVAL
+    }
+    this.node.resize(size)
+    return null
+  }
+
+  public sync(): $.GoError {
+    return this.closed ? syscall.EBADF : null
+  }
+
+  public close(): $.GoError {
+    if (this.closed) {
+      return syscall.EBADF
+    }
+    this.closed = true
+    return null
+  }
+}
@@ -0,0 +1,281 @@
+import * as $ from '@goscript/builtin/index.js'
+import * as fs from '@goscript/io/fs/index.js'
+import * as syscall from '@goscript/syscall/index.js'
+
+import {
+  O_APPEND,
+  O_CREATE,
+  O_EXCL,
+  O_RDWR,
+  O_SYNC,
+  O_TRUNC,
+  O_WRONLY,
+} from './file_constants_js.gs.js'
+import type { FileHandle, FileStat, FileSystem } from './vfs.js'
+
+/**
+ * isNode reports whether the program runs under Node, where the host
+ * filesystem is available.
+ */
+export function isNode(): boolean {
+  return (
+    typeof process !== 'undefined' &&
+    typeof (process as any).getBuiltinModule === 'function'
+  )
+}
+
+// nodeFs returns the Node fs module. It is loaded on demand so that the
+// package can be bundled for the browser.
+function nodeFs(): any {
+  return (process as any).getBuiltinModule('node:fs')
+}
+
+// errnos maps the error codes reported by Node to syscall errors.
+const errnos: Record<string, syscall.Errno> = {
+  EACCES: syscall.EACCES,
+  EBADF: syscall.EBADF,
+  EBUSY: syscall.EBUSY,
+  EEXIST: syscall.EEXIST,
+  EINVAL: syscall.EINVAL,
+  EISDIR: syscall.EISDIR,
+  ELOOP: syscall.ELOOP,
+  EMFILE: syscall.EMFILE,
+  ENAMETOOLONG: syscall.ENAMETOOLONG,
+  ENOENT: syscall.ENOENT,
+  ENOSPC: syscall.ENOSPC,
+  ENOTDIR: syscall.ENOTDIR,
+  ENOTEMPTY: syscall.ENOTEMPTY,
+  EPERM: syscall.EPERM,
+  EROFS: syscall.EROFS,
+  EXDEV: syscall.EXDEV,
+}
+
+// toError converts an exception thrown by the Node fs module to an error.
+function toError(e: any): $.GoError {
+  const errno = errnos[e?.code]
+  if (errno !== undefined) {
+    return errno
+  }
+  return $.newError(e instanceof Error ? e.message : String(e))
+}
+
+// call invokes fn and returns an exception it throws as an error.
+function call(fn: () => void): $.GoError {
+  try {
+    fn()
+    return null
+  } catch (e) {
+    return toError(e)
+  }
+}
+
+// modeBits maps the file type bits of a Node stat mode to fs.FileMode bits.
+const modeBits: [number, number][] = [
+  [0o040000, fs.ModeDir],
+  [0o120000, fs.ModeSymlink],
+  [0o010000, fs.ModeNamedPipe],
+  [0o140000, fs.ModeSocket],
+  [0o020000, fs.ModeDevice | fs.ModeCharDevice],
+  [0o060000, fs.ModeDevice],
+]
+
+// toFileStat converts a Node fs.Stats to a FileStat.
+function toFileStat(name: string, st: any): FileStat {
+  let mode = st.mode & fs.ModePerm
+  const type = st.mode & 0o170000
+  for (const [bits, m] of modeBits) {
+    if (type === bits) {
+      mode |= m
+    }
+  }
+  if (st.mode & 0o4000) mode |= fs.ModeSetuid
+  if (st.mode & 0o2000) mode |= fs.ModeSetgid
+  if (st.mode & 0o1000) mode |= fs.ModeSticky
+  return {
+    name,
+    size: st.size,
+    mode,
+    modTime: st.mtimeMs,
+    id: `${st.dev}:${st.ino}`,
+  }
+}
+
+function basename(name: string): string {
+  const trimmed = name.replace(/\/+$/, '')
+  if (trimmed === '') {
+    return name === '' ? '' : '/'
+  }
+  return trimmed.slice(trimmed.lastIndexOf('/') + 1)
+}
+
+/**
+ * NodeFS is a FileSystem backed by the host filesystem through Node's fs
+ * module.
+ */
+export class NodeFS implements FileSystem {
+  private fs = nodeFs()
+
+  // toNodeFlags converts os.O_* flags to the flags of the host.
+  private toNodeFlags(flag: number): number {
+    const c = this.fs.constants
+    const flags: [number, number][] = [
+      [O_WRONLY, c.O_WRONLY],
+      [O_RDWR, c.O_RDWR],
+      [O_APPEND, c.O_APPEND],
+      [O_CREATE, c.O_CREAT],
+      [O_EXCL, c.O_EXCL],
+      [O_SYNC, c.O_SYNC],
+      [O_TRUNC, c.O_TRUNC],
+    ]
+    let result = 0
+    for (const [goFlag, nodeFlag] of flags) {
+      if ((flag & goFlag) === goFlag) {
+        result |= nodeFlag
+      }
+    }
+    return result
+  }
+
+  public open(
+    name: string,
+    flag: number,
+    perm: number,
+  ): [FileHandle | null, $.GoError] {
+    try {
+      const fd = this.fs.openSync(name, this.toNodeFlags(flag), perm & fs.ModePerm)
+      return [new nodeHandle(this.fs, fd, basename(name)), null]
+    } catch (e) {
+      return [null, toError(e)]
+    }
+  }
+
+  public stat(name: string): [FileStat | null, $.GoError] {
+    try {
+      return [toFileStat(basename(name), this.fs.statSync(name)), null]
+    } catch (e) {
+      return [null, toError(e)]
+    }
+  }
+
+  public lstat(name: string): [FileStat | null, $.GoError] {
+    try {
+      return [toFileStat(basename(name), this.fs.lstatSync(name)), null]
+    } catch (e) {
+      return [null, toError(e)]
+    }
+  }
+
+  public readDir(name: string): [FileStat[] | null, $.GoError] {
+    try {
+      const entries: FileStat[] = []
+      for (const entry of this.fs.readdirSync(name)) {
+        const st = this.fs.lstatSync(name.replace(/\/*$/, '/') + entry)
+        entries.push(toFileStat(entry, st))
+      }
+      return [entries, null]
+    } catch (e) {
+      return [null, toError(e)]
+    }
+  }
+
+  public mkdir(name: string, perm: number): $.GoError {
+    return call(() => this.fs.mkdirSync(name, perm & fs.ModePerm))
+  }
+
+  public remove(name: string): $.GoError {
+    // Like Go, try removing a file first and then a directory.
+    let err = call(() => this.fs.unlinkSync(name))
+    if (err === null) {
+      return null
+    }
+    const err2 = call(() => this.fs.rmdirSync(name))
+    if (err2 === null) {
+      return null
+    }
+    if (err2 !== syscall.ENOTDIR) {
+      err = err2
+    }
+    return err
+  }
+
+  public rename(oldname: string, newname: string): $.GoError {
+    return call(() => this.fs.renameSync(oldname, newname))
+  }
+
+  public chmod(name: string, mode: number): $.GoError {
+    let bits = mode & fs.ModePerm
+    if (mode & fs.ModeSetuid) bits |= 0o4000
+    if (mode & fs.ModeSetgid) bits |= 0o2000
+    if (mode & fs.ModeSticky) bits |= 0o1000
+    return call(() => this.fs.chmodSync(name, bits))
+  }
+
+  public link(oldname: string, newname: string): $.GoError {
+    return call(() => this.fs.linkSync(oldname, newname))
+  }
+
+  public symlink(oldname: string, newname: string): $.GoError {
+    return call(() => this.fs.symlinkSync(oldname, newname))
+  }
+
+  public readlink(name: string): [string, $.GoError] {
+    try {
+      return [this.fs.readlinkSync(name), null]
+    } catch (e) {
+      return ['', toError(e)]
+    }
+  }
+
+  public getwd(): [string, $.GoError] {
+    return [process.cwd(), null]
+  }
+
+  public chdir(dir: string): $.GoError {
+    return call(() => process.chdir(dir))
+  }
+}
+
+// nodeHandle is a file descriptor opened by a NodeFS.
+class nodeHandle implements FileHandle {
+  constructor(
+    private fs: any,
+    private fd: number,
+    private name: string,
+  ) {}
+
+  public readAt(b: Uint8Array, off: number): [number, $.GoError] {
+    try {
+      return [this.fs.readSync(this.fd, b, 0, b.length, off), null]
+    } catch (e) {
+      return [0, toError(e)]
+    }
+  }
+
+  public writeAt(b: Uint8Array, off: number): [number, $.GoError] {
+    try {
+      return [this.fs.writeSync(this.fd, b, 0, b.length, off), null]
+    } catch (e) {
+      return [0, toError(e)]
+    }
+  }
+
+  public stat(): [FileStat | null, $.GoError] {
+    try {
+      return [toFileStat(this.name, this.fs.fstatSync(this.fd)), null]
+    } catch (e) {
+      return [null, toError(e)]
+    }
+  }
+
+  public truncate(size: number): $.GoError {
+    return call(() => this.fs.ftruncateSync(this.fd, size))
+  }
+
+  public sync(): $.GoError {
+    return call(() => this.fs.fsyncSync(this.fd))
+  }
+
+  public close(): $.GoError {
+    return call(() => this.fs.closeSync(this.fd))
+  }
+}
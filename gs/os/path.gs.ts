@@ -1,7 +1,13 @@
 import * as $ from "@goscript/builtin/index.js";
-import { ErrUnimplemented } from "./error.gs.js";
+import { IsNotExist, PathError } from "./error.gs.js";
+import { Mkdir } from "./file_js.gs.js";
+import { Remove } from "./file_unix_js.gs.js";
+import { Lstat, Stat } from "./stat_js.gs.js";
+import { GetFileSystem } from "./vfs.js";
 import { IsPathSeparator } from "./path_unix.gs.js";
 
+import * as syscall from "@goscript/syscall/index.js"
+
 // MkdirAll creates a directory named path,
 // along with any necessary parents, and returns nil,
 // or else returns an error.
@@ -10,7 +16,52 @@ import { IsPathSeparator } from "./path_unix.gs.js";
 // If path is already a directory, MkdirAll does nothing
 // and returns nil.
 export function MkdirAll(path: string, perm: number): $.GoError {
-	return ErrUnimplemented
+	// Fast path: if we can tell whether path is a directory or file, stop with success or error.
+	let [dir, err] = Stat(path)
+	if (err == null) {
+		if (dir!.IsDir()) {
+			return null
+		}
+		return new PathError({Op: "mkdir", Path: path, Err: syscall.ENOTDIR})
+	}
+
+	// Slow path: make sure parent exists and then call Mkdir for path.
+
+	// Extract the parent folder from path by first removing any trailing
+	// path separator and then scanning backward until finding a path
+	// separator or reaching the beginning of the string.
+	let i = $.len(path) - 1
+	while (i >= 0 && IsPathSeparator($.indexString(path, i))) {
+		i--
+	}
+	while (i >= 0 && !IsPathSeparator($.indexString(path, i))) {
+		i--
+	}
+	if (i < 0) {
+		i = 0
+	}
+
+	// If there is a parent directory, recurse to ensure parent directory exists.
+	let parent = $.sliceString(path, undefined, i)
+	if ($.len(parent) > 0) {
+		err = MkdirAll(parent, perm)
+		if (err != null) {
+			return err
+		}
+	}
+
+	// Parent now exists; invoke Mkdir and use its result.
+	err = Mkdir(path, perm)
+	if (err != null) {
+		// Handle arguments like "foo/." by
+		// double-checking that directory doesn't exist.
+		let [dir1, err1] = Lstat(path)
+		if (err1 == null && dir1!.IsDir()) {
+			return null
+		}
+		return err
+	}
+	return null
 }
 
 // RemoveAll removes path and any children it contains.
@@ -19,7 +70,70 @@ export function MkdirAll(path: string, perm: number): $.GoError {
 // returns nil (no error).
 // If there is an error, it will be of type [*PathError].
 export function RemoveAll(path: string): $.GoError {
-	return ErrUnimplemented
+	if (path == "") {
+		// fail silently to retain compatibility with previous behavior
+		// of RemoveAll. See issue 28830.
+		return null
+	}
+
+	// Strip trailing /s from the path, so RemoveAll("not_a_directory/") succeeds.
+	while ($.len(path) > 1 && IsPathSeparator($.indexString(path, $.len(path) - 1))) {
+		path = $.sliceString(path, undefined, $.len(path) - 1)
+	}
+
+	// The rmdir system call permits removing "." on Plan 9,
+	// so we don't permit it to remain consistent with the
+	// "at" implementation of RemoveAll.
+	if (endsWithDot(path)) {
+		return new PathError({Op: "RemoveAll", Path: path, Err: syscall.EINVAL})
+	}
+
+	// Simple case: if Remove works, we're done.
+	let err = Remove(path)
+	if (err == null || IsNotExist(err)) {
+		return null
+	}
+
+	// Otherwise, is this a directory we need to recurse into?
+	let [dir, serr] = Lstat(path)
+	if (serr != null) {
+		let perr = serr as PathError
+		if (IsNotExist(perr.Err) || perr.Err == syscall.ENOTDIR) {
+			return null
+		}
+		return serr
+	}
+	if (!dir!.IsDir()) {
+		// Not a directory; return the error from Remove.
+		return err
+	}
+
+	// Remove contents & return first error.
+	let [entries, rerr] = GetFileSystem().readDir(path)
+	if (rerr != null) {
+		if (rerr == syscall.ENOENT) {
+			// Already deleted by someone else.
+			return null
+		}
+		return new PathError({Op: "readdirent", Path: path, Err: rerr})
+	}
+	err = null
+	for (const entry of entries!) {
+		let err1 = RemoveAll(path + "/" + entry.name)
+		if (err == null) {
+			err = err1
+		}
+	}
+
+	// Remove directory.
+	let err1 = Remove(path)
+	if (err1 == null || IsNotExist(err1)) {
+		return null
+	}
+	if (err == null) {
+		err = err1
+	}
+	return err
 }
 
 // endsWithDot reports whether the final component of path is ".".
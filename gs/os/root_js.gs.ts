@@ -73,7 +73,7 @@ export class Root {
 
 	// Register this type with the runtime type system
 	static __typeInfo = $.registerStructType(
-		'Root',
+		'os.Root',
 		new Root(),
 		[
 			{ name: "Name", args: [], returns: [{ type: { kind: $.TypeKind.Basic, name: 'string' } }] },
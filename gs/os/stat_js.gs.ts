@@ -1,14 +1,16 @@
 import * as $ from "@goscript/builtin/index.js";
-import { ErrUnimplemented } from "./error.gs.js";
+import { PathError } from "./error.gs.js";
+import { fileStat } from "./types_js.gs.js";
+import { GetFileSystem } from "./vfs.js";
 
 import * as fs from "@goscript/io/fs/index.js"
 
-// JavaScript-specific stubs for stat operations
+// JavaScript-specific implementations of stat operations
 
 // Stat returns a [FileInfo] describing the named file.
 // If there is an error, it will be of type [*PathError].
 export function Stat(name: string): [fs.FileInfo, $.GoError] {
-	return [null, ErrUnimplemented]
+	return statNolog(name)
 }
 
 // Lstat returns a [FileInfo] describing the named file.
@@ -16,15 +18,23 @@ export function Stat(name: string): [fs.FileInfo, $.GoError] {
 // describes the symbolic link. Lstat makes no attempt to follow the link.
 // If there is an error, it will be of type [*PathError].
 export function Lstat(name: string): [fs.FileInfo, $.GoError] {
-	return [null, ErrUnimplemented]
+	return lstatNolog(name)
 }
 
 // statNolog is the same as Stat, for use in DirFS.
 export function statNolog(name: string): [fs.FileInfo, $.GoError] {
-	return [null, ErrUnimplemented]
+	let [st, err] = GetFileSystem().stat(name)
+	if (err != null) {
+		return [null, new PathError({Op: "stat", Path: name, Err: err})]
+	}
+	return [new fileStat(st!), null]
 }
 
 // lstatNolog is the same as Lstat, for use in DirFS.
 export function lstatNolog(name: string): [fs.FileInfo, $.GoError] {
-	return [null, ErrUnimplemented]
-} 
\ No newline at end of file
+	let [st, err] = GetFileSystem().lstat(name)
+	if (err != null) {
+		return [null, new PathError({Op: "lstat", Path: name, Err: err})]
+	}
+	return [new fileStat(st!), null]
+}
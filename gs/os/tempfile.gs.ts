@@ -1,34 +1,113 @@
 import * as $ from "@goscript/builtin/index.js";
-import { ErrUnimplemented } from "./error.gs.js";
-import { File } from "./types.gs.js";
-
-// joinPath joins directory and file paths - simplified implementation for JavaScript
-export function joinPath(dir: string, file: string): string {
-  if (dir === "" || dir === ".") {
-    return file
-  }
-  if (file === "") {
-    return dir
-  }
-  // Remove trailing slash from dir if present
-  if (dir.endsWith("/")) {
-    dir = dir.slice(0, -1)
-  }
-  // Remove leading slash from file if present  
-  if (file.startsWith("/")) {
-    file = file.slice(1)
-  }
-  return dir + "/" + file
+import { ErrExist, IsExist, IsNotExist, PathError } from "./error.gs.js";
+import { O_CREATE, O_EXCL, O_RDWR, TempDir } from "./file_constants_js.gs.js";
+import { Mkdir, OpenFile } from "./file_js.gs.js";
+import { IsPathSeparator } from "./path_unix.gs.js";
+import { Stat } from "./stat_js.gs.js";
+import { File } from "./types_js.gs.js";
+
+// nextRandom returns a random decimal string for temporary names.
+export function nextRandom(): string {
+	return String(Math.floor(Math.random() * 0x100000000))
 }
 
-// CreateTemp creates a new temporary file in the directory dir.
-// Stubbed implementation for JavaScript environment.
+// CreateTemp creates a new temporary file in the directory dir,
+// opens the file for reading and writing, and returns the resulting file.
+// The filename is generated by taking pattern and adding a random string to the end.
+// If pattern includes a "*", the random string replaces the last "*".
+// The file is created with mode 0o600 (before umask).
+// If dir is the empty string, CreateTemp uses the default directory for temporary files, as returned by [TempDir].
+// Multiple programs or goroutines calling CreateTemp simultaneously will not choose the same file.
+// The caller can use the file's Name method to find the pathname of the file.
+// It is the caller's responsibility to remove the file when it is no longer needed.
 export function CreateTemp(dir: string, pattern: string): [File | null, $.GoError] {
-  return [null, ErrUnimplemented]
+	if (dir == "") {
+		dir = TempDir()
+	}
+
+	let [prefix, suffix, err] = prefixAndSuffix(pattern)
+	if (err != null) {
+		return [null, new PathError({Op: "createtemp", Path: pattern, Err: err})]
+	}
+	prefix = joinPath(dir, prefix)
+
+	let tries = 0
+	for (;;) {
+		let name = prefix + nextRandom() + suffix
+		let [f, err2] = OpenFile(name, O_RDWR | O_CREATE | O_EXCL, 0o600)
+		if (IsExist(err2)) {
+			if (++tries < 10000) {
+				continue
+			}
+			return [null, new PathError({Op: "createtemp", Path: prefix + "*" + suffix, Err: ErrExist})]
+		}
+		return [f, err2]
+	}
+}
+
+let errPatternHasSeparator: $.GoError = $.newError("pattern contains path separator")
+
+// prefixAndSuffix splits pattern by the last wildcard "*", if applicable,
+// returning prefix as the part before "*" and suffix as the part after "*".
+export function prefixAndSuffix(pattern: string): [string, string, $.GoError] {
+	for (let i = 0; i < $.len(pattern); i++) {
+		if (IsPathSeparator($.indexString(pattern, i))) {
+			return ["", "", errPatternHasSeparator]
+		}
+	}
+	let pos = pattern.lastIndexOf("*")
+	if (pos != -1) {
+		return [pattern.slice(0, pos), pattern.slice(pos + 1), null]
+	}
+	return [pattern, "", null]
 }
 
-// MkdirTemp creates a new temporary directory.
-// Stubbed implementation for JavaScript environment.
+// MkdirTemp creates a new temporary directory in the directory dir
+// and returns the pathname of the new directory.
+// The new directory's name is generated by adding a random string to the end of pattern.
+// If pattern includes a "*", the random string replaces the last "*" instead.
+// The directory is created with mode 0o700 (before umask).
+// If dir is the empty string, MkdirTemp uses the default directory for temporary files, as returned by TempDir.
+// Multiple programs or goroutines calling MkdirTemp simultaneously will not choose the same directory.
+// It is the caller's responsibility to remove the directory when it is no longer needed.
 export function MkdirTemp(dir: string, pattern: string): [string, $.GoError] {
-  return ["", ErrUnimplemented]
-} 
\ No newline at end of file
+	if (dir == "") {
+		dir = TempDir()
+	}
+
+	let [prefix, suffix, err] = prefixAndSuffix(pattern)
+	if (err != null) {
+		return ["", new PathError({Op: "mkdirtemp", Path: pattern, Err: err})]
+	}
+	prefix = joinPath(dir, prefix)
+
+	let tries = 0
+	for (;;) {
+		let name = prefix + nextRandom() + suffix
+		let err2 = Mkdir(name, 0o700)
+		if (err2 == null) {
+			return [name, null]
+		}
+		if (IsExist(err2)) {
+			if (++tries < 10000) {
+				continue
+			}
+			return ["", new PathError({Op: "mkdirtemp", Path: prefix + "*" + suffix, Err: ErrExist})]
+		}
+		if (IsNotExist(err2)) {
+			let [, err3] = Stat(dir)
+			if (IsNotExist(err3)) {
+				return ["", err3]
+			}
+		}
+		return ["", err2]
+	}
+}
+
+// joinPath joins directory and file names with a path separator.
+export function joinPath(dir: string, name: string): string {
+	if ($.len(dir) > 0 && IsPathSeparator($.indexString(dir, $.len(dir) - 1))) {
+		return dir + name
+	}
+	return dir + "/" + name
+}
@@ -1,5 +1,6 @@
 import * as $ from "@goscript/builtin/index.js";
-import { ErrUnimplemented } from "./error.gs.js";
+import { ErrClosed, ErrInvalid, ErrNoDeadline, ErrUnimplemented, PathError } from "./error.gs.js";
+import type { FileHandle, FileStat, FileSystem } from "./vfs.js";
 
 import * as fs from "@goscript/io/fs/index.js"
 import * as io from "@goscript/io/index.js"
@@ -25,13 +26,15 @@ export function Getpagesize(): number {
 	return 4096
 }
 
-// Stub implementation of File for JavaScript environment
+// File represents an open file descriptor.
+//
+// The methods of File are safe for concurrent use.
 export class File {
 	public get file(): file | null {
-		return null
+		return this._fields.file.value
 	}
 	public set file(value: file | null) {
-		// No-op
+		this._fields.file.value = value
 	}
 
 	public _fields: {
@@ -40,146 +43,491 @@ export class File {
 
 	constructor(init?: Partial<{file?: file | null}>) {
 		this._fields = {
-			file: $.varRef(null)
+			file: $.varRef(init?.file ?? null)
 		}
 	}
 
 	public clone(): File {
-		return new File()
+		const cloned = new File()
+		cloned._fields = {
+			file: $.varRef(this._fields.file.value)
+		}
+		return cloned
 	}
 
-	// All File methods return ErrUnimplemented in JavaScript environment
+	// Readdir reads the contents of the directory associated with file and
+	// returns a slice of up to n [FileInfo] values, as would be returned
+	// by [Lstat], in directory order.
 	public Readdir(n: number): [$.Slice<fs.FileInfo>, $.GoError] {
-		return [null, ErrUnimplemented]
+		let [, , infos, err] = this.readdir(n, readdirFileInfo)
+		return [infos, err]
 	}
 
+	// Readdirnames reads the contents of the directory associated with file
+	// and returns a slice of up to n names of files in the directory,
+	// in directory order.
 	public Readdirnames(n: number): [$.Slice<string>, $.GoError] {
-		return [null, ErrUnimplemented]
-	}
-
+		let [names, , , err] = this.readdir(n, readdirName)
+		return [names, err]
+	}
+
+	// ReadDir reads the contents of the directory associated with the file f
+	// and returns a slice of [DirEntry] values in directory order.
+	// Subsequent calls on the same file will yield later DirEntry records in the directory.
+	//
+	// If n > 0, ReadDir returns at most n DirEntry records.
+	// In this case, if ReadDir returns an empty slice, it will return an error explaining why.
+	// At the end of a directory, the error is [io.EOF].
+	//
+	// If n <= 0, ReadDir returns all the DirEntry records remaining in the directory.
+	// When it succeeds, it returns a nil error (not io.EOF).
 	public ReadDir(n: number): [$.Slice<fs.DirEntry>, $.GoError] {
-		return [null, ErrUnimplemented]
+		let [, dirents, , err] = this.readdir(n, readdirDirEntry)
+		return [dirents, err]
 	}
 
 	public readdir(n: number, mode: readdirMode): [$.Slice<string>, $.Slice<fs.DirEntry>, $.Slice<fs.FileInfo>, $.GoError] {
-		return [null, null, null, ErrUnimplemented]
+		let err = this.checkValid("readdir")
+		if (err != null) {
+			return [null, null, null, err]
+		}
+		const f = this.file!
+		if (f.dirinfo === null) {
+			let [entries, e] = f.fsys.readDir(f.name)
+			if (e != null) {
+				return [null, null, null, this.wrapErr("readdirent", e)]
+			}
+			f.dirinfo = entries
+		}
+		const batch = f.dirinfo!.splice(0, n > 0 ? n : f.dirinfo!.length)
+		if (n > 0 && batch.length === 0) {
+			err = io.EOF
+		}
+		switch (mode) {
+			case readdirName:
+				return [$.arrayToSlice(batch.map((st) => st.name)), null, null, err]
+			case readdirDirEntry:
+				return [null, $.arrayToSlice(batch.map((st) => fs.FileInfoToDirEntry(new fileStat(st)))), null, err]
+			default:
+				return [null, null, $.arrayToSlice<fs.FileInfo>(batch.map((st) => new fileStat(st))), err]
+		}
 	}
 
+	// Name returns the name of the file as presented to Open.
+	//
+	// It is safe to call Name after [Close].
 	public Name(): string {
-		return ""
+		return this.file!.name
 	}
 
+	// Read reads up to len(b) bytes from the File and stores them in b.
+	// It returns the number of bytes read and any error encountered.
+	// At end of file, Read returns 0, io.EOF.
 	public Read(b: $.Bytes): [number, $.GoError] {
-		return [0, ErrUnimplemented]
+		let err = this.checkValid("read")
+		if (err != null) {
+			return [0, err]
+		}
+		if ($.len(b) === 0) {
+			return [0, null]
+		}
+		const f = this.file!
+		let [n, e] = this.pread(b, f.offset, false)
+		f.offset += n
+		return [n, this.wrapErr("read", e)]
 	}
 
+	// ReadAt reads len(b) bytes from the File starting at byte offset off.
+	// It returns the number of bytes read and the error, if any.
+	// ReadAt always returns a non-nil error when n < len(b).
+	// At end of file, that error is io.EOF.
 	public ReadAt(b: $.Bytes, off: number): [number, $.GoError] {
-		return [0, ErrUnimplemented]
+		let err = this.checkValid("read")
+		if (err != null) {
+			return [0, err]
+		}
+		if (off < 0) {
+			return [0, new PathError({Op: "readat", Path: this.file!.name, Err: $.newError("negative offset")})]
+		}
+		let [n, e] = this.pread(b, off, true)
+		return [n, this.wrapErr("read", e)]
+	}
+
+	// pread reads into b from offset off. If full is set it reads until b is
+	// full. Reading nothing at the end of the file reports io.EOF.
+	public pread(b: $.Bytes, off: number, full: boolean): [number, $.GoError] {
+		const buf = b instanceof Uint8Array ? b : new Uint8Array($.len(b))
+		let n = 0
+		let err: $.GoError = null
+		while (n < buf.length) {
+			let [m, e] = this.file!.handle.readAt(buf.subarray(n), off + n)
+			n += m
+			if (e != null) {
+				err = e
+				break
+			}
+			if (m === 0) {
+				err = io.EOF
+				break
+			}
+			if (!full) {
+				break
+			}
+		}
+		if (buf !== b) {
+			$.copy(b as $.Slice<number>, buf.subarray(0, n) as any)
+		}
+		return [n, err]
 	}
 
+	// ReadFrom implements io.ReaderFrom.
 	public ReadFrom(r: io.Reader): [number, $.GoError] {
-		return [0, ErrUnimplemented]
+		let err = this.checkValid("write")
+		if (err != null) {
+			return [0, err]
+		}
+		const buf = new Uint8Array(32 * 1024)
+		let total = 0
+		for (;;) {
+			let [nr, er] = r!.Read(buf)
+			if (nr > 0) {
+				let [nw, ew] = this.Write(buf.subarray(0, nr))
+				total += nw
+				if (ew != null) {
+					return [total, ew]
+				}
+			}
+			if (er != null) {
+				return [total, er == io.EOF ? null : er]
+			}
+		}
 	}
 
+	// Write writes len(b) bytes from b to the File.
+	// It returns the number of bytes written and an error, if any.
+	// Write returns a non-nil error when n != len(b).
 	public Write(b: $.Bytes): [number, $.GoError] {
-		return [0, ErrUnimplemented]
+		let err = this.checkValid("write")
+		if (err != null) {
+			return [0, err]
+		}
+		const f = this.file!
+		let off = f.offset
+		if (f.appendMode) {
+			let [st, e] = f.handle.stat()
+			if (e != null) {
+				return [0, this.wrapErr("write", e)]
+			}
+			off = st!.size
+		}
+		const data = $.bytesToUint8Array(b)
+		let [n, e] = this.pwrite(data, off)
+		f.offset = off + n
+		if (e != null) {
+			return [n, this.wrapErr("write", e)]
+		}
+		return [n, null]
 	}
 
+	// WriteAt writes len(b) bytes to the File starting at byte offset off.
+	// It returns the number of bytes written and an error, if any.
+	// WriteAt returns a non-nil error when n != len(b).
+	//
+	// If file was opened with the O_APPEND flag, WriteAt returns an error.
 	public WriteAt(b: $.Bytes, off: number): [number, $.GoError] {
-		return [0, ErrUnimplemented]
+		let err = this.checkValid("write")
+		if (err != null) {
+			return [0, err]
+		}
+		if (this.file!.appendMode) {
+			return [0, errWriteAtInAppendMode]
+		}
+		if (off < 0) {
+			return [0, new PathError({Op: "writeat", Path: this.file!.name, Err: $.newError("negative offset")})]
+		}
+		let [n, e] = this.pwrite($.bytesToUint8Array(b), off)
+		return [n, this.wrapErr("write", e)]
+	}
+
+	// pwrite writes all of data at offset off.
+	public pwrite(data: Uint8Array, off: number): [number, $.GoError] {
+		let n = 0
+		while (n < data.length) {
+			let [m, e] = this.file!.handle.writeAt(data.subarray(n), off + n)
+			n += m
+			if (e != null) {
+				return [n, e]
+			}
+			if (m === 0) {
+				return [n, io.ErrShortWrite]
+			}
+		}
+		return [n, null]
 	}
 
+	// WriteTo implements io.WriterTo.
 	public WriteTo(w: io.Writer): [number, $.GoError] {
-		return [0, ErrUnimplemented]
+		let err = this.checkValid("read")
+		if (err != null) {
+			return [0, err]
+		}
+		const buf = new Uint8Array(32 * 1024)
+		let total = 0
+		for (;;) {
+			let [nr, er] = this.Read(buf)
+			if (nr > 0) {
+				let [nw, ew] = w!.Write(buf.subarray(0, nr))
+				total += nw
+				if (ew != null) {
+					return [total, ew]
+				}
+				if (nw !== nr) {
+					return [total, io.ErrShortWrite]
+				}
+			}
+			if (er != null) {
+				return [total, er == io.EOF ? null : er]
+			}
+		}
 	}
 
+	// Seek sets the offset for the next Read or Write on file to offset, interpreted
+	// according to whence: 0 means relative to the origin of the file, 1 means
+	// relative to the current offset, and 2 means relative to the end.
+	// It returns the new offset and an error, if any.
 	public Seek(offset: number, whence: number): [number, $.GoError] {
-		return [0, ErrUnimplemented]
+		let err = this.checkValid("seek")
+		if (err != null) {
+			return [0, err]
+		}
+		const f = this.file!
+		let base = 0
+		switch (whence) {
+			case 0:
+				break
+			case 1:
+				base = f.offset
+				break
+			case 2: {
+				let [st, e] = f.handle.stat()
+				if (e != null) {
+					return [0, this.wrapErr("seek", e)]
+				}
+				base = st!.size
+				break
+			}
+			default:
+				return [0, this.wrapErr("seek", syscall.EINVAL)]
+		}
+		const pos = base + offset
+		if (pos < 0) {
+			return [0, this.wrapErr("seek", syscall.EINVAL)]
+		}
+		f.offset = pos
+		f.dirinfo = null
+		return [pos, null]
 	}
 
+	// WriteString is like Write, but writes the contents of string s rather than
+	// a slice of bytes.
 	public WriteString(s: string): [number, $.GoError] {
-		return [0, ErrUnimplemented]
+		return this.Write($.stringToBytes(s))
 	}
 
+	// Chmod changes the mode of the file to mode.
 	public Chmod(mode: number): $.GoError {
-		return ErrUnimplemented
+		let err = this.checkValid("chmod")
+		if (err != null) {
+			return err
+		}
+		return this.wrapErr("chmod", this.file!.fsys.chmod(this.file!.name, mode))
 	}
 
 	public SetDeadline(t: time.Time): $.GoError {
-		return ErrUnimplemented
+		return ErrNoDeadline
 	}
 
 	public SetReadDeadline(t: time.Time): $.GoError {
-		return ErrUnimplemented
+		return ErrNoDeadline
 	}
 
 	public SetWriteDeadline(t: time.Time): $.GoError {
-		return ErrUnimplemented
+		return ErrNoDeadline
 	}
 
 	public SyscallConn(): [any, $.GoError] {
 		return [null, ErrUnimplemented]
 	}
 
+	// Close closes the [File], rendering it unusable for I/O.
+	// Close will return an error if it has already been called.
 	public Close(): $.GoError {
-		return ErrUnimplemented
+		let err = this.checkValid("close")
+		if (err != null) {
+			return err
+		}
+		this.file!.closed = true
+		return this.wrapErr("close", this.file!.handle.close())
 	}
 
 	public Chown(uid: number, gid: number): $.GoError {
 		return ErrUnimplemented
 	}
 
+	// Truncate changes the size of the file.
+	// It does not change the I/O offset.
 	public Truncate(size: number): $.GoError {
-		return ErrUnimplemented
+		let err = this.checkValid("truncate")
+		if (err != null) {
+			return err
+		}
+		return this.wrapErr("truncate", this.file!.handle.truncate(size))
 	}
 
+	// Sync commits the current contents of the file to stable storage.
 	public Sync(): $.GoError {
-		return ErrUnimplemented
+		let err = this.checkValid("sync")
+		if (err != null) {
+			return err
+		}
+		return this.wrapErr("sync", this.file!.handle.sync())
 	}
 
+	// Chdir changes the current working directory to the file,
+	// which must be a directory.
 	public Chdir(): $.GoError {
-		return ErrUnimplemented
+		let err = this.checkValid("chdir")
+		if (err != null) {
+			return err
+		}
+		return this.wrapErr("chdir", this.file!.fsys.chdir(this.file!.name))
 	}
 
 	public Fd(): syscall.uintptr {
 		return 0
 	}
 
+	// Stat returns the [FileInfo] structure describing file.
 	public Stat(): [fs.FileInfo, $.GoError] {
-		return [null, ErrUnimplemented]
+		let err = this.checkValid("stat")
+		if (err != null) {
+			return [null, err]
+		}
+		let [st, e] = this.file!.handle.stat()
+		if (e != null) {
+			return [null, this.wrapErr("stat", e)]
+		}
+		return [new fileStat(st!), null]
 	}
 
-	// Internal methods
+	// checkValid checks whether f is valid for use.
+	// If not, it returns an appropriate error, perhaps incorporating the operation name op.
 	public checkValid(op: string): $.GoError {
-		return ErrUnimplemented
+		if (this.file == null) {
+			return ErrInvalid
+		}
+		if (this.file.closed) {
+			return new PathError({Op: op, Path: this.file.name, Err: ErrClosed})
+		}
+		return null
 	}
 
+	// wrapErr wraps an error that occurred during an operation on an open file.
+	// It passes io.EOF through unchanged, otherwise wraps it in a PathError.
 	public wrapErr(op: string, err: $.GoError): $.GoError {
-		return err
+		if (err == null || err == io.EOF) {
+			return err
+		}
+		return new PathError({Op: op, Path: this.file!.name, Err: err})
 	}
 
 	// Register this type with the runtime type system
 	static __typeInfo = $.registerStructType(
-		'File',
+		'os.File',
 		new File(),
 		[
-			{ name: "Readdir", args: [{ name: "n", type: { kind: $.TypeKind.Basic, name: "number" } }], returns: [{ type: { kind: $.TypeKind.Slice, elemType: "FileInfo" } }, { type: { kind: $.TypeKind.Interface, name: 'GoError', methods: [{ name: 'Error', args: [], returns: [{ type: { kind: $.TypeKind.Basic, name: 'string' } }] }] } }] },
+			{ name: "Readdir", args: [{ name: "n", type: { kind: $.TypeKind.Basic, name: "number" } }], returns: [{ type: { kind: $.TypeKind.Slice, elemType: "io/fs.FileInfo" } }, { type: { kind: $.TypeKind.Interface, name: 'GoError', methods: [{ name: 'Error', args: [], returns: [{ type: { kind: $.TypeKind.Basic, name: 'string' } }] }] } }] },
+			{ name: "ReadDir", args: [{ name: "n", type: { kind: $.TypeKind.Basic, name: "number" } }], returns: [{ type: { kind: $.TypeKind.Slice, elemType: "io/fs.DirEntry" } }, { type: { kind: $.TypeKind.Interface, name: 'GoError', methods: [{ name: 'Error', args: [], returns: [{ type: { kind: $.TypeKind.Basic, name: 'string' } }] }] } }] },
 			{ name: "Read", args: [{ name: "b", type: { kind: $.TypeKind.Slice, elemType: { kind: $.TypeKind.Basic, name: "number" } } }], returns: [{ type: { kind: $.TypeKind.Basic, name: "number" } }, { type: { kind: $.TypeKind.Interface, name: 'GoError', methods: [{ name: 'Error', args: [], returns: [{ type: { kind: $.TypeKind.Basic, name: 'string' } }] }] } }] },
+			{ name: "Write", args: [{ name: "b", type: { kind: $.TypeKind.Slice, elemType: { kind: $.TypeKind.Basic, name: "number" } } }], returns: [{ type: { kind: $.TypeKind.Basic, name: "number" } }, { type: { kind: $.TypeKind.Interface, name: 'GoError', methods: [{ name: 'Error', args: [], returns: [{ type: { kind: $.TypeKind.Basic, name: 'string' } }] }] } }] },
+			{ name: "Seek", args: [{ name: "offset", type: { kind: $.TypeKind.Basic, name: "number" } }, { name: "whence", type: { kind: $.TypeKind.Basic, name: "number" } }], returns: [{ type: { kind: $.TypeKind.Basic, name: "number" } }, { type: { kind: $.TypeKind.Interface, name: 'GoError', methods: [{ name: 'Error', args: [], returns: [{ type: { kind: $.TypeKind.Basic, name: 'string' } }] }] } }] },
+			{ name: "Stat", args: [], returns: [{ type: "io/fs.FileInfo" }, { type: { kind: $.TypeKind.Interface, name: 'GoError', methods: [{ name: 'Error', args: [], returns: [{ type: { kind: $.TypeKind.Basic, name: 'string' } }] }] } }] },
 			{ name: "Close", args: [], returns: [{ type: { kind: $.TypeKind.Interface, name: 'GoError', methods: [{ name: 'Error', args: [], returns: [{ type: { kind: $.TypeKind.Basic, name: 'string' } }] }] } }] }
 		],
 		File,
-		{ "file": { kind: $.TypeKind.Pointer, elemType: "file" } }
+		{ "file": { kind: $.TypeKind.Pointer, elemType: "os.file" } }
 	);
 }
 
-// Stub file type for internal use
-class file {
-	constructor() {}
+// file is the real representation of *File: an open handle on the
+// filesystem it was opened from, and the current offset.
+export class file {
+	public offset = 0
+	public closed = false
+	// dirinfo holds the directory entries not yet returned by ReadDir.
+	public dirinfo: FileStat[] | null = null
+
+	constructor(
+		public name: string,
+		public handle: FileHandle,
+		public fsys: FileSystem,
+		public appendMode: boolean,
+	) {}
 }
 
-// Stub readdirMode type
 type readdirMode = number
 
+const readdirName: readdirMode = 0
+const readdirDirEntry: readdirMode = 1
+const readdirFileInfo: readdirMode = 2
+
+let errWriteAtInAppendMode: $.GoError = $.newError("os: invalid use of WriteAt on file opened with O_APPEND")
+
+// A fileStat is the implementation of FileInfo returned by Stat and Lstat.
+export class fileStat {
+	constructor(public st: FileStat) {}
+
+	public Name(): string {
+		return this.st.name
+	}
+
+	public Size(): number {
+		return this.st.size
+	}
+
+	public Mode(): fs.FileMode {
+		return this.st.mode
+	}
+
+	public ModTime(): time.Time {
+		return time.UnixMilli(Math.floor(this.st.modTime))
+	}
+
+	public IsDir(): boolean {
+		return fs.FileMode_IsDir(this.st.mode)
+	}
+
+	public Sys(): null | any {
+		return null
+	}
+
+	// Register this type with the runtime type system
+	static __typeInfo = $.registerStructType(
+		'os.fileStat',
+		new fileStat({name: "", size: 0, mode: 0, modTime: 0, id: null}),
+		[
+			{ name: "Name", args: [], returns: [{ type: { kind: $.TypeKind.Basic, name: "string" } }] },
+			{ name: "Size", args: [], returns: [{ type: { kind: $.TypeKind.Basic, name: "number" } }] },
+			{ name: "Mode", args: [], returns: [{ type: "io/fs.FileMode" }] },
+			{ name: "ModTime", args: [], returns: [{ type: "time.Time" }] },
+			{ name: "IsDir", args: [], returns: [{ type: { kind: $.TypeKind.Basic, name: "boolean" } }] },
+			{ name: "Sys", args: [], returns: [{ type: { kind: $.TypeKind.Interface, methods: [] } }] }
+		],
+		fileStat,
+		{}
+	);
+}
+
 // File mode constants
 export let ModeDir: fs.FileMode = fs.ModeDir
 export let ModeAppend: fs.FileMode = fs.ModeAppend
@@ -199,9 +547,13 @@ export let ModeType: fs.FileMode = fs.ModeType
 export let ModePerm: fs.FileMode = fs.ModePerm
 
 // SameFile reports whether fi1 and fi2 describe the same file.
+// SameFile only applies to results returned by this package's [Stat].
+// It returns false in other cases.
 export function SameFile(fi1: fs.FileInfo, fi2: fs.FileInfo): boolean {
-	// In JavaScript environment, always return false as we can't compare files
-	return false
+	if (!(fi1 instanceof fileStat) || !(fi2 instanceof fileStat)) {
+		return false
+	}
+	return fi1.st.id === fi2.st.id
 }
 
 // FileMode wrapper functions - re-export from fs module
@@ -0,0 +1,145 @@
+import { describe, it, expect } from 'vitest'
+import * as $ from '@goscript/builtin/index.js'
+import * as io from '@goscript/io/index.js'
+import * as fs from '@goscript/io/fs/index.js'
+import * as errors from '@goscript/errors/index.js'
+import * as os from './index.js'
+
+const text = (b: $.Bytes) => new TextDecoder().decode($.bytesToUint8Array(b))
+const bytes = (s: string) => $.stringToBytes(s)
+
+// useMemFS installs a fresh MemFS so tests never touch the host filesystem.
+const useMemFS = () => os.SetFileSystem(new os.MemFS())
+
+describe('os on MemFS', () => {
+  it('writes and reads files', () => {
+    useMemFS()
+    expect(os.WriteFile('/tmp/a.txt', bytes('hello'), 0o644)).toBeNull()
+    const [data, err] = os.ReadFile('/tmp/a.txt')
+    expect(err).toBeNull()
+    expect(text(data)).toBe('hello')
+
+    const [info, serr] = os.Stat('/tmp/a.txt')
+    expect(serr).toBeNull()
+    expect(info!.Name()).toBe('a.txt')
+    expect(info!.Size()).toBe(5)
+    expect(info!.Mode()).toBe(0o644)
+    expect(info!.IsDir()).toBe(false)
+  })
+
+  it('reports missing files as *PathError wrapping ErrNotExist', () => {
+    useMemFS()
+    const [, err] = os.ReadFile('/missing')
+    expect(err).toBeInstanceOf(os.PathError)
+    expect(err!.Error()).toBe('open /missing: no such file or directory')
+    expect(os.IsNotExist(err)).toBe(true)
+    expect(errors.Is(err, fs.ErrNotExist)).toBe(true)
+  })
+
+  it('reads, writes and seeks through File', () => {
+    useMemFS()
+    const [f, err] = os.Create('/tmp/f')
+    expect(err).toBeNull()
+    expect(f!.WriteString('hello world')).toEqual([11, null])
+    expect(f!.Seek(-5, io.SeekEnd)).toEqual([6, null])
+
+    const buf = new Uint8Array(10)
+    expect(f!.Read(buf)).toEqual([5, null])
+    expect(text(buf.subarray(0, 5))).toBe('world')
+    expect(f!.Read(buf)).toEqual([0, io.EOF])
+
+    expect(f!.WriteAt(bytes('HELLO'), 0)).toEqual([5, null])
+    const at = new Uint8Array(8)
+    const [n, rerr] = f!.ReadAt(at, 6)
+    expect(n).toBe(5)
+    expect(rerr).toBe(io.EOF)
+
+    expect(f!.Close()).toBeNull()
+    const cerr = f!.Close()
+    expect(cerr!.Error()).toBe('close /tmp/f: file already closed')
+    expect(text(os.ReadFile('/tmp/f')[0])).toBe('HELLO world')
+  })
+
+  it('appends with O_APPEND', () => {
+    useMemFS()
+    os.WriteFile('/log', bytes('a'), 0o600)
+    const [f] = os.OpenFile('/log', os.O_WRONLY | os.O_APPEND, 0)
+    f!.WriteString('b')
+    f!.WriteString('c')
+    f!.Close()
+    expect(text(os.ReadFile('/log')[0])).toBe('abc')
+  })
+
+  it('creates, lists and removes directories', () => {
+    useMemFS()
+    expect(os.MkdirAll('/x/y/z', 0o755)).toBeNull()
+    os.WriteFile('/x/b', bytes(''), 0o644)
+    os.WriteFile('/x/a', bytes(''), 0o644)
+
+    const [entries, err] = os.ReadDir('/x')
+    expect(err).toBeNull()
+    expect($.asArray(entries).map((e) => e!.Name())).toEqual(['a', 'b', 'y'])
+    expect($.asArray(entries)[2]!.IsDir()).toBe(true)
+
+    expect(os.IsExist(os.Mkdir('/x', 0o755))).toBe(true)
+    expect(os.Remove('/x')!.Error()).toBe('remove /x: directory not empty')
+    expect(os.RemoveAll('/x')).toBeNull()
+    expect(os.IsNotExist(os.Stat('/x')[1])).toBe(true)
+  })
+
+  it('pages directory entries with File.ReadDir', () => {
+    useMemFS()
+    os.Mkdir('/d', 0o755)
+    for (const name of ['1', '2', '3']) {
+      os.WriteFile('/d/' + name, bytes(''), 0o644)
+    }
+    const [f] = os.Open('/d')
+    expect($.len(f!.ReadDir(2)[0])).toBe(2)
+    expect($.len(f!.ReadDir(2)[0])).toBe(1)
+    expect(f!.ReadDir(2)).toEqual([$.arrayToSlice([]), io.EOF])
+  })
+
+  it('renames files and follows the working directory', () => {
+    useMemFS()
+    os.Mkdir('/w', 0o755)
+    expect(os.Chdir('/w')).toBeNull()
+    expect(os.Getwd()).toEqual(['/w', null])
+    os.WriteFile('old', bytes('x'), 0o644)
+    expect(os.Rename('old', 'new')).toBeNull()
+    expect(text(os.ReadFile('/w/new')[0])).toBe('x')
+    expect(os.Rename('new', '/')!.Error()).toBe('rename new /: file exists')
+  })
+
+  it('resolves symbolic links', () => {
+    useMemFS()
+    os.WriteFile('/target', bytes('data'), 0o644)
+    expect(os.Symlink('/target', '/link')).toBeNull()
+    expect(os.Readlink('/link')).toEqual(['/target', null])
+    expect(text(os.ReadFile('/link')[0])).toBe('data')
+    const [info] = os.Lstat('/link')
+    expect(info!.Mode() & os.ModeSymlink).not.toBe(0)
+    expect(os.SameFile(os.Stat('/link')[0], os.Stat('/target')[0])).toBe(true)
+  })
+
+  it('creates temporary files and directories', () => {
+    useMemFS()
+    const [dir, err] = os.MkdirTemp('', 'test-*')
+    expect(err).toBeNull()
+    expect(dir.startsWith('/tmp/test-')).toBe(true)
+    const [f, ferr] = os.CreateTemp(dir, '*.txt')
+    expect(ferr).toBeNull()
+    expect(f!.Name().endsWith('.txt')).toBe(true)
+    expect(os.Stat(f!.Name())[0]!.Mode()).toBe(0o600)
+  })
+
+  it('serves files through DirFS', () => {
+    useMemFS()
+    os.MkdirAll('/root/sub', 0o755)
+    os.WriteFile('/root/sub/f', bytes('fs'), 0o644)
+    const fsys = os.DirFS('/root')
+    const [b, err] = fs.ReadFile(fsys, 'sub/f')
+    expect(err).toBeNull()
+    expect(text(b)).toBe('fs')
+    expect(fsys!.Open('../f')[1]!.Error()).toBe('open ../f: invalid argument')
+  })
+})
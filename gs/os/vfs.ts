@@ -0,0 +1,91 @@
+import * as $ from '@goscript/builtin/index.js'
+
+import { MemFS } from './memfs.js'
+import { NodeFS, isNode } from './nodefs.js'
+
+// The os package performs its file operations on a pluggable FileSystem.
+// Under Node it defaults to the host filesystem, elsewhere to an in-memory
+// filesystem. Hosts can install their own with SetFileSystem.
+//
+// Errors returned by a FileSystem are syscall errors like syscall.ENOENT;
+// the os package wraps them in a *PathError naming the operation and path.
+
+/**
+ * FileStat describes a file in a FileSystem.
+ */
+export interface FileStat {
+  // name is the base name of the file.
+  name: string
+  // size is the length in bytes for regular files.
+  size: number
+  // mode holds the Go fs.FileMode bits of the file.
+  mode: number
+  // modTime is the modification time in milliseconds since the Unix epoch.
+  modTime: number
+  // id identifies the underlying file, files with the same id are the same
+  // file for os.SameFile.
+  id: unknown
+}
+
+/**
+ * FileHandle is an open file in a FileSystem. Reads and writes are
+ * positional, the os.File tracks the current offset.
+ */
+export interface FileHandle {
+  // readAt reads into b from offset off, returning 0 at the end of the file.
+  readAt(b: Uint8Array, off: number): [number, $.GoError]
+  // writeAt writes b at offset off, extending the file as needed.
+  writeAt(b: Uint8Array, off: number): [number, $.GoError]
+  stat(): [FileStat | null, $.GoError]
+  truncate(size: number): $.GoError
+  sync(): $.GoError
+  close(): $.GoError
+}
+
+/**
+ * FileSystem is the interface the os package uses to access files. Paths
+ * are slash separated and relative paths are relative to getwd.
+ */
+export interface FileSystem {
+  // open opens the named file with the os.O_* flags, creating it with the
+  // permission bits perm if O_CREATE is set.
+  open(name: string, flag: number, perm: number): [FileHandle | null, $.GoError]
+  // stat describes the named file, following symbolic links.
+  stat(name: string): [FileStat | null, $.GoError]
+  // lstat describes the named file without following a final symbolic link.
+  lstat(name: string): [FileStat | null, $.GoError]
+  // readDir describes the entries of the named directory like lstat, in no
+  // particular order.
+  readDir(name: string): [FileStat[] | null, $.GoError]
+  mkdir(name: string, perm: number): $.GoError
+  // remove removes the named file or empty directory.
+  remove(name: string): $.GoError
+  rename(oldname: string, newname: string): $.GoError
+  chmod(name: string, mode: number): $.GoError
+  link(oldname: string, newname: string): $.GoError
+  symlink(oldname: string, newname: string): $.GoError
+  readlink(name: string): [string, $.GoError]
+  getwd(): [string, $.GoError]
+  chdir(dir: string): $.GoError
+}
+
+let fileSystem: FileSystem | null = null
+
+/**
+ * SetFileSystem replaces the filesystem used by the os package. Files that
+ * are already open keep using the filesystem they were opened on.
+ */
+export function SetFileSystem(fsys: FileSystem): void {
+  fileSystem = fsys
+}
+
+/**
+ * GetFileSystem returns the filesystem used by the os package, creating the
+ * default one on first use.
+ */
+export function GetFileSystem(): FileSystem {
+  if (fileSystem === null) {
+    fileSystem = isNode() ? new NodeFS() : new MemFS()
+  }
+  return fileSystem
+}
@@ -14,6 +14,7 @@ export {
   RecvDir,
   SendDir,
   BothDir,
+  typeFromTypeInfo,
 } from './type.js'
 export type { Type, ChanDir, Kind } from './type.js'
 export { DeepEqual } from './deepequal.js'
@@ -29,10 +30,13 @@ export {
   Select,
 } from './value.js'
 export { Swapper } from './swapper.js'
+export { VisibleFields } from './visiblefields.js'
 
 // Export new types and constants
 export {
-  StructTag,
+  StructField,
+  StructTag_Get,
+  StructTag_Lookup,
   ValueError,
   SelectDir,
   SelectSend,
@@ -42,8 +46,7 @@ export {
 } from './types.js'
 export type {
   uintptr,
-  Pointer,
-  StructField,
+  StructTag,
   Method,
   SelectCase,
   SliceHeader,
@@ -76,6 +79,7 @@ export {
   Interface,
   Map,
   Ptr,
+  Pointer,
   Slice,
   String,
   Struct,
@@ -0,0 +1,26 @@
+import { describe, it, expect } from 'vitest'
+import { StructTag_Get, StructTag_Lookup } from './types.js'
+
+describe('StructTag', () => {
+  it('should look up conventional key:"value" pairs', () => {
+    const tag = 'json:"name,omitempty" xml:"n" empty:""'
+
+    expect(StructTag_Get(tag, 'json')).toBe('name,omitempty')
+    expect(StructTag_Get(tag, 'xml')).toBe('n')
+    expect(StructTag_Lookup(tag, 'empty')).toEqual(['', true])
+    expect(StructTag_Lookup(tag, 'missing')).toEqual(['', false])
+  })
+
+  it('should unquote escaped values', () => {
+    const tag = 'a:"x\\"y" b:"tab\\there" c:"\\u00e9"'
+
+    expect(StructTag_Get(tag, 'a')).toBe('x"y')
+    expect(StructTag_Get(tag, 'b')).toBe('tab\there')
+    expect(StructTag_Get(tag, 'c')).toBe('é')
+  })
+
+  it('should stop at malformed tags', () => {
+    expect(StructTag_Lookup('bad a:"x"', 'a')).toEqual(['', false])
+    expect(StructTag_Lookup('a:x', 'a')).toEqual(['', false])
+  })
+})
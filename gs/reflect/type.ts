@@ -1,5 +1,5 @@
-import { ReflectValue, StructField } from './types.js'
-import { MapIter } from './map.js'
+import * as $ from '@goscript/builtin/index.js'
+import { ReflectValue, StructField, ValueError } from './types.js'
 
 // rtype is the common implementation of most values
 export class rtype {
@@ -156,6 +156,7 @@ export const Func: Kind = 19
 export const Interface: Kind = 20
 export const Map: Kind = 21
 export const Ptr: Kind = 22
+export const Pointer: Kind = Ptr
 export const Slice: Kind = 23
 export const String: Kind = 24
 export const Struct: Kind = 25
@@ -188,115 +189,119 @@ export interface Type {
   common?(): rtype
 }
 
-// Value is the reflection interface to a Go value - consolidated from all implementations
+// flagAddr marks addressable values, which can be set and have their
+// address taken.
+const flagAddr = 1
+
+// flagRO marks values obtained through unexported struct fields, which can be
+// read but not set.
+const flagRO = 2
+
+// Value is the reflection interface to a Go value. Values obtained through
+// pointers, struct fields and slice elements refer to the variable holding
+// them, so that setting them writes through to compiled code.
 export class Value {
   constructor(
-    private _value: ReflectValue,
-    private _type: Type,
+    private _value: ReflectValue = null,
+    private _type: Type = invalidType,
+    // _ref is the variable holding the value, if it is obtained through one
+    private _ref: $.VarRef<ReflectValue> | null = null,
+    private _flag: number = 0,
   ) {}
 
   public clone(): Value {
-    return new Value(this._value, this._type)
+    return new Value(this._value, this._type, this._ref, this._flag)
   }
 
-  // Methods required by godoc.txt and used throughout the codebase
-  public Int(): number {
-    if (typeof this._value === 'number' && Number.isInteger(this._value)) {
-      return this._value
+  // load returns the current value, reading through the variable holding it.
+  private load(): ReflectValue {
+    return this._ref !== null ? this._ref.value : this._value
+  }
+
+  // mustBe panics if the kind of v is not one of kinds.
+  private mustBe(method: string, ...kinds: Kind[]): void {
+    if (!kinds.includes(this.Kind())) {
+      throw new ValueError({ Method: method, Kind: this.Kind() })
     }
-    throw new Error(
-      'reflect: call of reflect.Value.Int on ' +
-        Kind_String(this._type.Kind()) +
-        ' Value',
-    )
   }
 
-  public Uint(): number {
-    if (typeof this._value === 'number' && this._value >= 0) {
-      return this._value
+  // mustBeAssignable panics if v cannot be set.
+  private mustBeAssignable(method: string): void {
+    if (this._flag & flagRO) {
+      $.panic(
+        'reflect: reflect.Value.' +
+          method +
+          ' using value obtained using unexported field',
+      )
+    }
+    if (!(this._flag & flagAddr)) {
+      $.panic('reflect: reflect.Value.' + method + ' using unaddressable value')
     }
-    throw new Error(
-      'reflect: call of reflect.Value.Uint on ' +
-        Kind_String(this._type.Kind()) +
-        ' Value',
-    )
   }
 
-  public Float(): number {
-    if (typeof this._value === 'number') {
-      return this._value
+  // store sets the variable holding v to x.
+  private store(x: ReflectValue): void {
+    const cur = this._ref!.value
+    // Structs are copied into the existing struct, which pointers to it
+    // share.
+    if (this.Kind() === Struct && isStructInstance(cur)) {
+      copyStruct(cur, x as StructInstance)
+      return
     }
-    throw new Error(
-      'reflect: call of reflect.Value.Float on ' +
-        Kind_String(this._type.Kind()) +
-        ' Value',
-    )
+    this._ref!.value = representAs(this._type, x)
+  }
+
+  public Int(): number {
+    this.mustBe('Int', Int, Int8, Int16, Int32, Int64)
+    return Number(this.load())
+  }
+
+  public Uint(): number {
+    this.mustBe('Uint', Uint, Uint8, Uint16, Uint32, Uint64, Uintptr)
+    return Number(this.load())
+  }
+
+  public Float(): number {
+    this.mustBe('Float', Float32, Float64)
+    return this.load() as number
   }
 
   public Bool(): boolean {
-    if (typeof this._value === 'boolean') {
-      return this._value
-    }
-    throw new Error(
-      'reflect: call of reflect.Value.Bool on ' +
-        Kind_String(this._type.Kind()) +
-        ' Value',
-    )
+    this.mustBe('Bool', Bool)
+    return this.load() as boolean
   }
 
+  // String returns the string v holds, or a string of the form "<T Value>"
+  // if v is not a string.
   public String(): string {
-    if (typeof this._value === 'string') {
-      return this._value
+    if (this.Kind() === String) {
+      return this.load() as string
     }
-    // Special case for bool values - display as <bool Value>
-    if (this._type.Kind() === Bool) {
-      return '<bool Value>'
+    if (this.Kind() === Invalid) {
+      return '<invalid Value>'
     }
-    return this._type.String()
+    return '<' + this._type.String() + ' Value>'
   }
 
   public Len(): number {
-    // Check for slice objects created by $.arrayToSlice
-    if (
-      this._value &&
-      typeof this._value === 'object' &&
-      '__meta__' in this._value
-    ) {
-      const meta = (this._value as { __meta__?: { length?: number } }).__meta__
-      if (meta && typeof meta.length === 'number') {
-        return meta.length
-      }
-    }
-
-    // Check for typed arrays
-    if (
-      this._value instanceof Uint8Array ||
-      this._value instanceof Int8Array ||
-      this._value instanceof Uint16Array ||
-      this._value instanceof Int16Array ||
-      this._value instanceof Uint32Array ||
-      this._value instanceof Int32Array ||
-      this._value instanceof Float32Array ||
-      this._value instanceof Float64Array
-    ) {
-      return this._value.length
+    this.mustBe('Len', Array, Chan, Map, Slice, String)
+    const v = this.load()
+    if (this.Kind() === Chan) {
+      return (v as { length?: number } | null)?.length ?? 0
     }
+    return $.len(v as $.Slice<unknown> | string | Map<unknown, unknown>)
+  }
 
-    // Check for regular arrays
-    if (globalThis.Array.isArray(this._value)) {
-      return this._value.length
+  public Cap(): number {
+    this.mustBe('Cap', Array, Chan, Slice)
+    const v = this.load()
+    if (this.Kind() === Array) {
+      return $.len(v as unknown[])
     }
-
-    // Check for strings
-    if (typeof this._value === 'string') {
-      return this._value.length
+    if (this.Kind() === Chan) {
+      return (v as { capacity?: number } | null)?.capacity ?? 0
     }
-
-    throw new Error(
-      'reflect: call of reflect.Value.Len on ' +
-        Kind_String(this._type.Kind()) +
-        ' Value',
-    )
+    return $.cap(v as $.Slice<unknown>)
   }
 
   public Kind(): Kind {
@@ -308,71 +313,395 @@ export class Value {
   }
 
   public IsValid(): boolean {
-    return this._value !== null && this._value !== undefined
+    return this.Kind() !== Invalid
   }
 
   public IsNil(): boolean {
-    return this._value === null || this._value === undefined
+    this.mustBe('IsNil', Chan, Func, Interface, Map, Ptr, Slice, UnsafePointer)
+    const v = this.load()
+    return v === null || v === undefined
+  }
+
+  // IsZero reports whether v is the zero value for its type.
+  public IsZero(): boolean {
+    const v = this.load()
+    switch (this.Kind()) {
+      case Invalid:
+        $.panic('reflect: call of reflect.Value.IsZero on zero Value')
+        break
+      case Bool:
+        return v === false
+      case String:
+        return v === ''
+      case Array:
+        for (let i = 0; i < this.Len(); i++) {
+          if (!this.Index(i).IsZero()) {
+            return false
+          }
+        }
+        return true
+      case Struct:
+        for (let i = 0; i < this.NumField(); i++) {
+          if (!this.Field(i).IsZero()) {
+            return false
+          }
+        }
+        return true
+      default:
+        if (isNumberKind(this.Kind())) {
+          return Number(v) === 0 && !Object.is(v, -0)
+        }
+    }
+    return v === null || v === undefined
+  }
+
+  // CanAddr reports whether the value's address can be obtained with Addr.
+  public CanAddr(): boolean {
+    return (this._flag & flagAddr) !== 0
+  }
+
+  // CanSet reports whether the value of v can be changed.
+  public CanSet(): boolean {
+    return (this._flag & (flagAddr | flagRO)) === flagAddr
+  }
+
+  public CanInterface(): boolean {
+    return this.IsValid() && (this._flag & flagRO) === 0
+  }
+
+  // Interface returns v's current value as an interface value.
+  public Interface(): ReflectValue {
+    if (!this.IsValid()) {
+      $.panic(new ValueError({ Method: 'Interface', Kind: Invalid }))
+    }
+    if (this._flag & flagRO) {
+      $.panic(
+        'reflect.Value.Interface: cannot return value obtained from ' +
+          'unexported field or method',
+      )
+    }
+    return this.load()
   }
 
   public Index(i: number): Value {
-    if (globalThis.Array.isArray(this._value)) {
-      return new Value(this._value[i], getTypeOf(this._value[i]))
+    this.mustBe('Index', Array, Slice, String)
+    const v = this.load()
+    if (this.Kind() === String) {
+      const s = v as string
+      if (i < 0 || i >= $.len(s)) {
+        $.panic('reflect: string index out of range')
+      }
+      return new Value(
+        $.indexString(s, i),
+        typeFromTypeInfo('uint8'),
+        null,
+        this._flag & flagRO,
+      )
     }
-    throw new Error(
-      'reflect: call of reflect.Value.Index on ' +
-        Kind_String(this._type.Kind()) +
-        ' Value',
-    )
+    if (i < 0 || i >= $.len(v as $.Slice<unknown>)) {
+      $.panic('reflect: ' + Kind_String(this.Kind()) + ' index out of range')
+    }
+    const ref = elemRef(v as $.Slice<ReflectValue>, i)
+    const elemType = this._type.Elem() ?? getTypeOf(ref.value)
+    // Slice elements are always addressable, array elements only if the
+    // array is.
+    let flag = this._flag & flagRO
+    if (this.Kind() === Slice || this._flag & flagAddr) {
+      flag |= flagAddr
+    }
+    return new Value(null, elemType, ref, flag)
   }
 
   public Bytes(): Uint8Array {
-    if (this._value instanceof Uint8Array) {
-      return this._value
+    const v = this.load()
+    if (v instanceof Uint8Array) {
+      return v
+    }
+    if (v === null && this.Kind() === Slice) {
+      return null as unknown as Uint8Array
     }
-    throw new Error(
-      'reflect: call of reflect.Value.Bytes on ' +
-        Kind_String(this._type.Kind()) +
-        ' Value',
-    )
+    throw new ValueError({ Method: 'Bytes', Kind: this.Kind() })
   }
 
+  // Elem returns the value that the interface v contains or that the pointer
+  // v points to. It returns the zero Value if v is nil.
   public Elem(): Value {
-    // For pointers and interfaces, return the element
-    return new Value(this._value, this._type)
+    const v = this.load()
+    switch (this.Kind()) {
+      case Interface:
+        if (v === null || v === undefined) {
+          return new Value()
+        }
+        return valueOfDynamic(v)
+      case Ptr: {
+        if (v === null || v === undefined) {
+          return new Value()
+        }
+        const elemType = this._type.Elem() ?? getTypeOf(v)
+        const flag = flagAddr | (this._flag & flagRO)
+        // Pointers to structs are the struct values themselves.
+        if (isStructInstance(v)) {
+          return new Value(null, elemType, structRef(v), flag)
+        }
+        return new Value(null, elemType, v as $.VarRef<ReflectValue>, flag)
+      }
+      case Struct:
+        // Pointers to structs are the struct values themselves, so a struct
+        // held in an interface may have been a pointer to it.
+        if (isStructInstance(v)) {
+          const flag = flagAddr | (this._flag & flagRO)
+          return new Value(null, this._type, structRef(v), flag)
+        }
+    }
+    throw new ValueError({ Method: 'Elem', Kind: this.Kind() })
+  }
+
+  // Addr returns a pointer value representing the address of v.
+  public Addr(): Value {
+    if (!(this._flag & flagAddr)) {
+      $.panic('reflect.Value.Addr of unaddressable value')
+    }
+    const ptrType = PointerTo(this._type)
+    const v = this._ref!.value
+    if (this.Kind() === Struct && isStructInstance(v)) {
+      return new Value(v, ptrType, null, this._flag & flagRO)
+    }
+    return new Value(this._ref, ptrType, null, this._flag & flagRO)
   }
 
   public NumField(): number {
+    this.mustBe('NumField', Struct)
     return this._type.NumField()
   }
 
-  public Field(_i: number): Value {
-    // Simplified implementation for struct field access
-    return new Value(null, this._type)
+  // Field returns the i'th field of the struct v.
+  public Field(i: number): Value {
+    this.mustBe('Field', Struct)
+    const field = this._type.Field!(i)!
+    let flag = this._flag & (flagAddr | flagRO)
+    if (field.PkgPath !== '') {
+      flag |= flagRO
+    }
+    // Blank fields have no storage and read as zero values
+    const ref =
+      field.Name === '_' ?
+        $.varRef(zeroOf(field.Type))
+      : fieldRef(this.load(), field.Name)
+    return new Value(null, field.Type, ref, flag)
+  }
+
+  // FieldByIndex returns the nested field corresponding to index.
+  public FieldByIndex(index: number[] | $.Slice<number>): Value {
+    let v: Value = this
+    for (const [i, x] of $.asArray(index as $.Slice<number>).entries()) {
+      if (i > 0 && v.Kind() === Ptr && v._type.Elem()?.Kind() === Struct) {
+        if (v.IsNil()) {
+          $.panic('reflect: indirection through nil pointer to embedded struct')
+        }
+        v = v.Elem()
+      }
+      v = v.Field(x)
+    }
+    return v
+  }
+
+  // FieldByName returns the struct field with the given name, including
+  // fields promoted from embedded structs. It returns the zero Value if no
+  // field was found.
+  public FieldByName(name: string): Value {
+    this.mustBe('FieldByName', Struct)
+    const embedded: Value[] = []
+    for (let i = 0; i < this.NumField(); i++) {
+      const field = this._type.Field!(i)!
+      if (field.Name === name) {
+        return this.Field(i)
+      }
+      if (field.Anonymous) {
+        embedded.push(this.Field(i))
+      }
+    }
+    for (let f of embedded) {
+      if (f.Kind() === Ptr) {
+        if (f.IsNil()) {
+          continue
+        }
+        f = f.Elem()
+      }
+      if (f.Kind() === Struct) {
+        const v = f.FieldByName(name)
+        if (v.IsValid()) {
+          return v
+        }
+      }
+    }
+    return new Value()
+  }
+
+  // Set assigns x to the value v.
+  public Set(x: Value): void {
+    this.mustBeAssignable('Set')
+    if (!assignable(x._type, this._type)) {
+      $.panic(
+        'reflect.Set: value of type ' +
+          x._type.String() +
+          ' is not assignable to type ' +
+          this._type.String(),
+      )
+    }
+    this.store(x.load())
+  }
+
+  public SetInt(x: number): void {
+    this.mustBeAssignable('SetInt')
+    this.mustBe('SetInt', Int, Int8, Int16, Int32, Int64)
+    this.store(x)
+  }
+
+  public SetUint(x: number): void {
+    this.mustBeAssignable('SetUint')
+    this.mustBe('SetUint', Uint, Uint8, Uint16, Uint32, Uint64, Uintptr)
+    this.store(x)
+  }
+
+  public SetFloat(x: number): void {
+    this.mustBeAssignable('SetFloat')
+    this.mustBe('SetFloat', Float32, Float64)
+    this.store(x)
+  }
+
+  public SetBool(x: boolean): void {
+    this.mustBeAssignable('SetBool')
+    this.mustBe('SetBool', Bool)
+    this.store(x)
+  }
+
+  public SetString(x: string): void {
+    this.mustBeAssignable('SetString')
+    this.mustBe('SetString', String)
+    this.store(x)
+  }
+
+  public SetBytes(x: Uint8Array | null): void {
+    this.mustBeAssignable('SetBytes')
+    this.mustBe('SetBytes', Slice)
+    this.store(x)
+  }
+
+  // SetZero sets v to be the zero value of v's type.
+  public SetZero(): void {
+    this.mustBeAssignable('SetZero')
+    this.store(zeroOf(this._type))
+  }
+
+  // SetLen sets v's length to n.
+  public SetLen(n: number): void {
+    this.mustBeAssignable('SetLen')
+    this.mustBe('SetLen', Slice)
+    const s = this.load() as $.Slice<ReflectValue>
+    if (n < 0 || n > $.cap(s)) {
+      $.panic('reflect: slice length out of range in SetLen')
+    }
+    this.store($.goSlice(s, 0, n))
+  }
+
+  // Call calls the function v with the input arguments in and returns its
+  // results as Values.
+  public Call(args: $.Slice<Value>): $.Slice<Value> {
+    this.mustBe('Call', Func)
+    const fn = this.load() as ((...args: unknown[]) => unknown) | null
+    if (fn === null || fn === undefined) {
+      $.panic('reflect: call of nil function')
+    }
+    const result = fn(...$.asArray(args).map((arg) => arg.load()))
+    const out = (this._type as Partial<FunctionType>).outTypes?.() ?? null
+    let results: ReflectValue[]
+    if (out === null) {
+      results = result === undefined ? [] : [result as ReflectValue]
+    } else if (out.length === 1) {
+      results = [result as ReflectValue]
+    } else {
+      results = out.length === 0 ? [] : (result as ReflectValue[])
+    }
+    return results.map((r, i) => new Value(r, out?.[i] ?? getTypeOf(r)))
+  }
+
+  // MapIndex returns the value associated with key in the map v, or the
+  // zero Value if key is not found in the map.
+  public MapIndex(key: Value): Value {
+    this.mustBe('MapIndex', Map)
+    const m = this.load() as globalThis.Map<unknown, ReflectValue> | null
+    const k = key.load()
+    if (m === null || !m.has(k)) {
+      return new Value()
+    }
+    const v = m.get(k)!
+    const elemType = this._type.Elem() ?? getTypeOf(v)
+    return new Value(v, elemType, null, this._flag & flagRO)
+  }
+
+  // SetMapIndex sets the element associated with key in the map v to elem.
+  // If elem is the zero Value, SetMapIndex deletes the key from the map.
+  public SetMapIndex(key: Value, elem: Value): void {
+    this.mustBe('SetMapIndex', Map)
+    const m = this.load() as globalThis.Map<unknown, ReflectValue> | null
+    if (!elem.IsValid()) {
+      m?.delete(key.load())
+      return
+    }
+    if (m === null) {
+      $.panic('assignment to entry in nil map')
+    }
+    const elemType = this._type.Elem() ?? elem._type
+    m.set(key.load(), representAs(elemType, elem.load()))
+  }
+
+  // MapKeys returns the keys of the map v, in unspecified order.
+  public MapKeys(): Value[] {
+    this.mustBe('MapKeys', Map)
+    const m = this.load() as globalThis.Map<unknown, ReflectValue> | null
+    const keyType = (this._type as Partial<MapType>).Key?.() ?? null
+    const keys: Value[] = []
+    for (const [k] of $.mapEntries(m)) {
+      const key = k as ReflectValue
+      keys.push(new Value(key, keyType ?? getTypeOf(key)))
+    }
+    return keys
+  }
+
+  // MapRange returns an iterator over the entries of the map v.
+  public MapRange(): mapIter {
+    this.mustBe('MapRange', Map)
+    return new mapIter(this)
   }
 
   // Additional methods needed by various parts of the codebase
   public UnsafePointer(): unknown {
-    return this._value
+    return this.load()
   }
 
   public pointer(): unknown {
-    return this._value
+    return this.load()
   }
 
   public get ptr(): unknown {
-    return this._value
+    return this.load()
   }
 
   // Internal method to access the underlying value
   public get value(): ReflectValue {
-    return this._value
+    return this.load()
   }
 
-  // Convert method needed by iter.ts
+  // Convert returns the value v converted to type t.
   public Convert(t: Type): Value {
-    // Simple conversion - in a real implementation this would do type conversion
-    return new Value(this._value, t)
+    let v = this.load()
+    if (
+      isNumberKind(t.Kind()) &&
+      (typeof v === 'number' || typeof v === 'bigint')
+    ) {
+      v = convertNumber(t.Kind(), v)
+    }
+    return new Value(v, t)
   }
 
   // Additional methods from deleted reflect.gs.ts
@@ -381,22 +710,12 @@ export class Value {
   }
 
   public get flag(): number {
-    return 0
-  }
-
-  public MapRange(): MapIter<unknown, unknown> | null {
-    // Placeholder for map iteration
-    return null
-  }
-
-  public MapIndex(_key: Value): Value {
-    // Placeholder for map access
-    return new Value(null, new BasicType(Invalid, 'invalid'))
+    return this._flag
   }
 
   public Complex(): number | { real: number; imag: number } | null {
     // Placeholder for complex number support
-    return this._value as number | { real: number; imag: number } | null
+    return this.load() as number | { real: number; imag: number } | null
   }
 
   // Send sends a value to a channel
@@ -406,13 +725,13 @@ export class Value {
     }
 
     // Get the underlying channel
-    const channel = this._value
+    const channel = this.load()
     if (!channel || typeof channel !== 'object') {
       throw new Error('reflect: send on invalid channel')
     }
 
     // Extract the value to send
-    const valueToSend = (x as unknown as { value: ReflectValue }).value
+    const valueToSend = x.load()
 
     // For synchronous operation, we'll use a simplified send
     // In the real implementation, this would need proper async handling
@@ -428,6 +747,55 @@ export class Value {
   }
 }
 
+// mapIter is an iterator for ranging over a map, returned by Value.MapRange.
+class mapIter {
+  private _entries: Iterator<[unknown, unknown]> | null = null
+  private _current: [unknown, unknown] | null = null
+
+  constructor(private _m: Value) {}
+
+  // Next advances the iterator and reports whether there is another entry.
+  public Next(): boolean {
+    if (this._entries === null) {
+      const m = this._m.value as globalThis.Map<unknown, unknown> | null
+      this._entries = $.mapEntries(m)[Symbol.iterator]()
+    }
+    const next = this._entries.next()
+    this._current = next.done ? null : next.value
+    return this._current !== null
+  }
+
+  // Key returns the key of the iterator's current map entry.
+  public Key(): Value {
+    if (this._current === null) {
+      $.panic('MapIter.Key called before Next')
+    }
+    return this.entryValue(0)
+  }
+
+  // Value returns the value of the iterator's current map entry.
+  public Value(): Value {
+    if (this._current === null) {
+      $.panic('MapIter.Value called before Next')
+    }
+    return this.entryValue(1)
+  }
+
+  // Reset modifies the iterator to iterate over v.
+  public Reset(v: Value): void {
+    this._m = v
+    this._entries = null
+    this._current = null
+  }
+
+  private entryValue(i: 0 | 1): Value {
+    const typ = this._m.Type()
+    const v = this._current![i] as ReflectValue
+    const t = i === 0 ? (typ as Partial<MapType>).Key?.() : typ.Elem()
+    return new Value(v, t ?? getTypeOf(v))
+  }
+}
+
 // Basic type implementation - exported for compatibility
 export class BasicType implements Type {
   constructor(
@@ -581,7 +949,36 @@ class PointerType implements Type {
 
 // Function type implementation
 class FunctionType implements Type {
-  constructor(private _signature: string) {}
+  constructor(
+    private _signature: string,
+    private _in: Type[] | null = null,
+    private _out: Type[] | null = null,
+  ) {}
+
+  // NumIn returns the number of input parameters of the function type.
+  public NumIn(): number {
+    return this._in?.length ?? 0
+  }
+
+  // NumOut returns the number of results of the function type.
+  public NumOut(): number {
+    return this._out?.length ?? 0
+  }
+
+  // In returns the type of the i'th input parameter.
+  public In(i: number): Type {
+    return this._in![i]
+  }
+
+  // Out returns the type of the i'th result.
+  public Out(i: number): Type {
+    return this._out![i]
+  }
+
+  // outTypes returns the result types, or null if they are not known.
+  public outTypes(): Type[] | null {
+    return this._out
+  }
 
   public String(): string {
     return this._signature
@@ -664,11 +1061,39 @@ class MapType implements Type {
 class StructType implements Type {
   constructor(
     private _name: string,
-    private _fields: Array<{ name: string; type: Type }> = [],
+    private _fields: $.StructFieldInfo[] = [],
+    private _ctor?: new () => unknown,
   ) {}
 
+  // zero returns a new zero value of the struct type.
+  public zero(): ReflectValue {
+    if (this._ctor) {
+      return new this._ctor() as ReflectValue
+    }
+    const obj: Record<string, ReflectValue> = {}
+    for (let i = 0; i < this._fields.length; i++) {
+      obj[this._fields[i].name] = zeroOf(this.Field(i).Type)
+    }
+    return obj
+  }
+
   public String(): string {
-    return this._name
+    if (this._name !== '') {
+      return $.typeString(this._name)
+    }
+    // Anonymous struct types are printed with their fields like Go does
+    if (this._fields.length === 0) {
+      return 'struct {}'
+    }
+    const fields = this._fields.map((_, i) => {
+      const f = this.Field(i)
+      let s = f.Anonymous ? f.Type.String() : f.Name + ' ' + f.Type.String()
+      if (f.Tag !== '') {
+        s += ' ' + JSON.stringify(f.Tag)
+      }
+      return s
+    })
+    return 'struct { ' + fields.join('; ') + ' }'
   }
 
   public Kind(): Kind {
@@ -677,7 +1102,11 @@ class StructType implements Type {
 
   public Size(): number {
     // Struct size is implementation-defined, we'll use a reasonable default
-    return this._fields.reduce((sum, field) => sum + field.type.Size(), 0)
+    let size = 0
+    for (let i = 0; i < this._fields.length; i++) {
+      size += this.Field(i).Type.Size()
+    }
+    return size
   }
 
   public Elem(): Type | null {
@@ -689,12 +1118,29 @@ class StructType implements Type {
   }
 
   public PkgPath?(): string {
-    return ''
+    return $.splitTypeName(this._name)[0]
   }
 
-  public Field?(_i: number): any {
-    // Stub implementation
-    return null
+  // Field returns the i'th field of the struct type.
+  public Field(i: number): StructField {
+    if (i < 0 || i >= this._fields.length) {
+      $.panic('reflect: Field index out of bounds')
+    }
+    const f = this._fields[i]
+    const typ = typeFromTypeInfo(f.type)
+    let offset = 0
+    for (let j = 0; j < i; j++) {
+      offset += typeFromTypeInfo(this._fields[j].type).Size()
+    }
+    return new StructField({
+      Name: f.name,
+      PkgPath: f.exported ? '' : this.PkgPath!() || 'main',
+      Type: typ,
+      Tag: f.tag ?? '',
+      Offset: offset,
+      Index: [i],
+      Anonymous: f.embedded ?? false,
+    })
   }
 
   public common?(): rtype {
@@ -756,10 +1202,143 @@ class ChannelType implements Type {
   }
 }
 
+// basicTypes maps the names of basic types in runtime type information to
+// their reflect types. Types represented as JavaScript numbers without a Go
+// name are treated as int.
+const basicTypes: Record<string, [Kind, number]> = {
+  bool: [Bool, 1],
+  boolean: [Bool, 1],
+  int: [Int, 8],
+  int8: [Int8, 1],
+  int16: [Int16, 2],
+  int32: [Int32, 4],
+  int64: [Int64, 8],
+  uint: [Uint, 8],
+  uint8: [Uint8, 1],
+  uint16: [Uint16, 2],
+  uint32: [Uint32, 4],
+  uint64: [Uint64, 8],
+  uintptr: [Uintptr, 8],
+  float32: [Float32, 4],
+  float64: [Float64, 8],
+  complex64: [Complex64, 8],
+  complex128: [Complex128, 16],
+  string: [String, 16],
+  number: [Int, 8],
+  bigint: [Int64, 8],
+  Pointer: [UnsafePointer, 8],
+}
+
+// namedTypeOf returns the type of a named type with the given qualified
+// name, which has the methods of its underlying type.
+function namedTypeOf(name: string, underlying: Type): Type {
+  const [pkgPath] = $.splitTypeName(name)
+  return Object.assign(Object.create(underlying) as Type, {
+    String: () => $.typeString(name),
+    PkgPath: () => pkgPath,
+  })
+}
+
+// namedTypes caches the types of registered type information, so each named
+// type has a single Type.
+const namedTypes = new WeakMap<$.TypeInfo, Type>()
+
+// typeFromTypeInfo returns the reflect type for runtime type information.
+export function typeFromTypeInfo(info: $.TypeInfo | string | undefined): Type {
+  if (info === undefined) {
+    return new BasicType(Interface, 'interface {}', 16)
+  }
+  if (typeof info === 'string') {
+    const registered = $.getTypeByName(info)
+    if (registered !== undefined) {
+      return typeFromTypeInfo(registered)
+    }
+    if (info in basicTypes) {
+      const [kind, size] = basicTypes[info]
+      return new BasicType(kind, info === 'number' ? 'int' : info, size)
+    }
+    if (info === 'error' || info === 'any') {
+      return new BasicType(Interface, info === 'any' ? 'interface {}' : info, 16)
+    }
+    return new BasicType(Invalid, $.typeString(info))
+  }
+
+  const cached = namedTypes.get(info)
+  if (cached !== undefined) {
+    return cached
+  }
+  let typ: Type
+  if (info.name && info.underlying) {
+    typ = namedTypeOf(info.name, typeFromTypeInfo(info.underlying))
+    namedTypes.set(info, typ)
+    return typ
+  }
+  switch (info.kind) {
+    case $.TypeKind.Basic:
+      typ = typeFromTypeInfo(info.name ?? 'any')
+      break
+    case $.TypeKind.Struct:
+      typ = new StructType(
+        info.name ?? '',
+        $.structFields(info.fields),
+        info.ctor as (new () => unknown) | undefined,
+      )
+      break
+    case $.TypeKind.Pointer:
+      typ = new PointerType(typeFromTypeInfo(info.elemType))
+      break
+    case $.TypeKind.Slice:
+      typ = new SliceType(typeFromTypeInfo(info.elemType))
+      break
+    case $.TypeKind.Array:
+      typ = new ArrayType(typeFromTypeInfo(info.elemType), info.length)
+      break
+    case $.TypeKind.Map:
+      typ = new MapType(
+        typeFromTypeInfo(info.keyType),
+        typeFromTypeInfo(info.elemType),
+      )
+      break
+    case $.TypeKind.Channel: {
+      const dirs = { send: SendDir, receive: RecvDir, both: BothDir }
+      typ = new ChannelType(
+        typeFromTypeInfo(info.elemType),
+        dirs[info.direction ?? 'both'],
+      )
+      break
+    }
+    case $.TypeKind.Function: {
+      const params = (info.params ?? []).map((t) => typeFromTypeInfo(t))
+      const results = (info.results ?? []).map((t) => typeFromTypeInfo(t))
+      const names = (list: Type[]) => list.map((t) => t.String()).join(', ')
+      let signature = `func(${names(params)})`
+      if (results.length === 1) {
+        signature += ` ${names(results)}`
+      } else if (results.length > 1) {
+        signature += ` (${names(results)})`
+      }
+      typ = new FunctionType(signature, params, results)
+      break
+    }
+    default:
+      typ = new BasicType(Interface, 'interface {}', 16)
+      if (info.name) {
+        typ = namedTypeOf(info.name, typ)
+      }
+  }
+  if (info.name) {
+    namedTypes.set(info, typ)
+  }
+  return typ
+}
+
 function getTypeOf(value: ReflectValue): Type {
   if (value === null || value === undefined) {
     return new BasicType(Interface, 'interface{}', 16)
   }
+  if (value instanceof $.NamedValue) {
+    return typeFromTypeInfo(value.type)
+  }
 
   switch (typeof value) {
     case 'boolean':
@@ -880,6 +1459,11 @@ function getTypeOf(value: ReflectValue): Type {
       if (value instanceof Float64Array)
         return new SliceType(new BasicType(Float64, 'float64', 8))
 
+      // Pointers to values other than structs are variable references
+      if ($.isVarRef(value)) {
+        return new PointerType(getTypeOf(value.value))
+      }
+
       // Check for Maps
       if (value instanceof globalThis.Map) {
         if (value.size === 0) {
@@ -933,16 +1517,10 @@ function getTypeOf(value: ReflectValue): Type {
         value.constructor &&
         '__typeInfo' in value.constructor
       ) {
-        const typeInfo = (
-          value.constructor as { __typeInfo?: { name?: string } }
-        ).__typeInfo
+        const typeInfo = (value.constructor as { __typeInfo?: $.TypeInfo })
+          .__typeInfo
         if (typeInfo && typeInfo.name) {
-          // Add package prefix for struct types if not already present
-          const typeName =
-            typeInfo.name.includes('.') ?
-              typeInfo.name
-            : `main.${typeInfo.name}`
-          return new StructType(typeName)
+          return typeFromTypeInfo(typeInfo)
         }
       }
 
@@ -952,21 +1530,40 @@ function getTypeOf(value: ReflectValue): Type {
         return new StructType(constructorName)
       }
 
-      // Default to struct type for plain objects
-      return new StructType('struct')
+      // Default to an anonymous struct type for plain objects
+      return new StructType('')
     }
     default:
       return new BasicType(Interface, 'interface{}', 16)
   }
 }
 
-// Exported functions as required by godoc.txt
-export function TypeOf(i: ReflectValue): Type {
-  return getTypeOf(i)
+// staticType returns the type for the static type information the compiler
+// passes to TypeOf and ValueOf, or null if there is none.
+function staticType(info: $.TypeInfo | string | undefined): Type | null {
+  if (info === undefined) {
+    return null
+  }
+  const typ = typeFromTypeInfo(info)
+  return typ.Kind() === Invalid ? null : typ
+}
+
+// TypeOf returns the reflection Type of i. Calls compiled from Go pass the
+// static type of the argument if it is not an interface, otherwise the type
+// is determined from the value.
+export function TypeOf(i: ReflectValue, typ?: $.TypeInfo | string): Type {
+  return staticType(typ) ?? getTypeOf(i)
 }
 
-export function ValueOf(i: ReflectValue): Value {
-  return new Value(i, getTypeOf(i))
+// ValueOf returns a new Value initialized to the concrete value stored in
+// i. Calls compiled from Go pass the static type of the argument if it is
+// not an interface, otherwise the type is determined from the value.
+export function ValueOf(i: ReflectValue, typ?: $.TypeInfo | string): Value {
+  const t = staticType(typ)
+  if (t === null) {
+    return valueOfDynamic(i)
+  }
+  return new Value(i, t)
 }
 
 export function ArrayOf(length: number, elem: Type): Type {
@@ -1014,3 +1611,192 @@ export function funcLayout(
     OutCount: 0,
   }
 }
+
+// The type of the zero Value.
+const invalidType: Type = new BasicType(Invalid, 'invalid', 0)
+
+// StructInstance is the representation of a compiled Go struct.
+type StructInstance = {
+  _fields: Record<string, $.VarRef<ReflectValue>>
+  clone(): StructInstance
+}
+
+// isStructInstance reports whether v is an instance of a compiled struct.
+function isStructInstance(v: unknown): v is StructInstance {
+  return (
+    v !== null &&
+    typeof v === 'object' &&
+    '_fields' in v &&
+    typeof (v as { clone?: unknown }).clone === 'function'
+  )
+}
+
+// copyStruct copies the fields of the struct src into dst, like assigning a
+// struct value in Go.
+function copyStruct(dst: StructInstance, src: StructInstance): void {
+  const copied = src.clone()
+  for (const [name, ref] of Object.entries(copied._fields)) {
+    dst._fields[name].value = ref.value
+  }
+}
+
+// structRef returns a variable holding the struct s. Pointers to structs are
+// the struct values themselves, so setting it copies into s.
+function structRef(s: StructInstance): $.VarRef<ReflectValue> {
+  return {
+    get value(): ReflectValue {
+      return s
+    },
+    set value(v: ReflectValue) {
+      copyStruct(s, v as StructInstance)
+    },
+  }
+}
+
+// fieldRef returns the variable holding a field of the struct s. Fields of
+// named structs are variable references, anonymous structs are plain
+// objects.
+function fieldRef(s: ReflectValue, name: string): $.VarRef<ReflectValue> {
+  if (isStructInstance(s)) {
+    return s._fields[name]
+  }
+  const obj = s as Record<string, ReflectValue>
+  return {
+    get value(): ReflectValue {
+      return obj[name]
+    },
+    set value(v: ReflectValue) {
+      obj[name] = v
+    },
+  }
+}
+
+// elemRef returns a variable holding the i'th element of a slice or array.
+function elemRef(
+  s: $.Slice<ReflectValue>,
+  i: number,
+): $.VarRef<ReflectValue> {
+  if ($.isSliceProxy(s)) {
+    const meta = s.__meta__
+    return {
+      get value(): ReflectValue {
+        return meta.backing[meta.offset + i]
+      },
+      set value(v: ReflectValue) {
+        meta.backing[meta.offset + i] = v
+      },
+    }
+  }
+  const arr = s as ReflectValue[]
+  return {
+    get value(): ReflectValue {
+      return arr[i]
+    },
+    set value(v: ReflectValue) {
+      arr[i] = v
+    },
+  }
+}
+
+// isNumberKind reports whether k is an integer or floating-point kind.
+function isNumberKind(k: Kind): boolean {
+  return k >= Int && k <= Float64
+}
+
+// convertNumber converts a number to the representation and range of the
+// numeric kind k. 64-bit integers are bigint in code compiled with the
+// BigInt64 option.
+function convertNumber(k: Kind, x: number | bigint): number | bigint {
+  const bits: Partial<Record<Kind, number>> = {
+    [Int8]: 8,
+    [Int16]: 16,
+    [Int32]: 32,
+    [Uint8]: 8,
+    [Uint16]: 16,
+    [Uint32]: 32,
+  }
+  switch (k) {
+    case Float32:
+      return Math.fround(Number(x))
+    case Float64:
+      return Number(x)
+    case Int64:
+      return $.isBigInt64Mode() ? $.int64(x) : Number($.int64(x))
+    case Uint64:
+      return $.isBigInt64Mode() ? $.uint64(x) : Number($.uint64(x))
+    case Int:
+      return Number($.int64(x))
+    case Uint:
+    case Uintptr:
+      return Number($.uint64(x))
+  }
+  const n = BigInt(typeof x === 'bigint' ? x : Math.trunc(x))
+  return k >= Uint ?
+      Number(BigInt.asUintN(bits[k]!, n))
+    : Number(BigInt.asIntN(bits[k]!, n))
+}
+
+// representAs returns x in the runtime representation of type t.
+function representAs(t: Type, x: ReflectValue): ReflectValue {
+  if (
+    isNumberKind(t.Kind()) &&
+    (typeof x === 'number' || typeof x === 'bigint')
+  ) {
+    return convertNumber(t.Kind(), x)
+  }
+  if (t.Kind() === Struct && isStructInstance(x)) {
+    return x.clone()
+  }
+  return x
+}
+
+// assignable reports whether a value of type from can be assigned to a
+// variable of type to. Values only carry their representation at runtime, so
+// values of the same kind are assignable, except for distinct structs.
+function assignable(from: Type, to: Type): boolean {
+  if (to.Kind() === Interface || from.String() === to.String()) {
+    return true
+  }
+  if (isNumberKind(from.Kind()) && isNumberKind(to.Kind())) {
+    return true
+  }
+  return from.Kind() === to.Kind() && from.Kind() !== Struct
+}
+
+// zeroOf returns the zero value of a type in its runtime representation.
+export function zeroOf(t: Type): ReflectValue {
+  const k = t.Kind()
+  if (isNumberKind(k)) {
+    return convertNumber(k, 0)
+  }
+  switch (k) {
+    case Bool:
+      return false
+    case String:
+      return ''
+    case Array:
+      return globalThis.Array.from({ length: (t as ArrayType).Len() }, () =>
+        zeroOf(t.Elem()!),
+      )
+    case Struct:
+      return (t as StructType).zero()
+  }
+  return null
+}
+
+// valueOfDynamic returns a Value for a value whose type is only known at
+// runtime. Pointers to structs are the struct values themselves, so a struct
+// is addressable like the target of a pointer.
+function valueOfDynamic(v: ReflectValue): Value {
+  if (v instanceof $.NamedValue) {
+    return new Value(v.value, typeFromTypeInfo(v.type))
+  }
+  const typ = getTypeOf(v)
+  if (typ.Kind() === Invalid) {
+    return new Value()
+  }
+  if (isStructInstance(v)) {
+    return new Value(null, typ, structRef(v), flagAddr)
+  }
+  return new Value(v, typ)
+}
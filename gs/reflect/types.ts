@@ -37,14 +37,17 @@ export type ReflectValue =
 // Import Type and Kind from the main type module
 import { Type, Kind, Value, Kind_String, ChanDir } from './type.js'
 
-// Struct field representation
+// A StructField describes a single field in a struct.
 export class StructField {
   public Name: string = ''
+  // PkgPath is the package path that qualifies a lower case (unexported)
+  // field name. It is empty for upper case (exported) field names.
+  public PkgPath: string = ''
   public Type!: Type
-  public Tag?: StructTag
-  public Offset?: uintptr
-  public Index?: number[]
-  public Anonymous?: boolean
+  public Tag: StructTag = ''
+  public Offset: uintptr = 0
+  public Index: number[] | null = null
+  public Anonymous: boolean = false
 
   constructor(init?: Partial<StructField>) {
     if (init) {
@@ -52,40 +55,161 @@ export class StructField {
     }
   }
 
+  // IsExported reports whether the field is exported.
+  public IsExported(): boolean {
+    return this.PkgPath === ''
+  }
+
   public clone(): StructField {
     return new StructField({
       Name: this.Name,
+      PkgPath: this.PkgPath,
       Type: this.Type,
       Tag: this.Tag,
       Offset: this.Offset,
-      Index: this.Index ? [...this.Index] : undefined,
+      Index: this.Index ? [...this.Index] : null,
       Anonymous: this.Anonymous,
     })
   }
 }
 
-// Struct tag type
-export class StructTag {
-  constructor(private _value: string) {}
+// A StructTag is the tag string in a struct field.
+//
+// By convention, tag strings are a concatenation of
+// optionally space-separated key:"value" pairs.
+// Each key is a non-empty string consisting of non-control
+// characters other than space (U+0020 ' '), quote (U+0022 '"'),
+// and colon (U+003A ':').  Each value is quoted using U+0022 '"'
+// characters and Go string literal syntax.
+export type StructTag = string
 
-  toString(): string {
-    return this._value
+// StructTag_Get returns the value associated with key in the tag string.
+// If there is no such key in the tag, Get returns the empty string.
+// If the tag does not have the conventional format, the value
+// returned by Get is unspecified. To determine whether a tag is
+// explicitly set to the empty string, use Lookup.
+export function StructTag_Get(tag: StructTag, key: string): string {
+  const [v] = StructTag_Lookup(tag, key)
+  return v
+}
+
+// StructTag_Lookup returns the value associated with key in the tag string.
+// If the key is present in the tag the value (which may be empty)
+// is returned. Otherwise the returned value will be the empty string.
+// The ok return value reports whether the value was explicitly set in
+// the tag string. If the tag does not have the conventional format,
+// the value returned by Lookup is unspecified.
+export function StructTag_Lookup(
+  tag: StructTag,
+  key: string,
+): [string, boolean] {
+  // When modifying this code, also update the validateStructTag code
+  // in cmd/vet/structtag.go.
+
+  while (tag !== '') {
+    // Skip leading space.
+    let i = 0
+    while (i < tag.length && tag[i] === ' ') {
+      i++
+    }
+    tag = tag.slice(i)
+    if (tag === '') {
+      break
+    }
+
+    // Scan to colon. A space, a quote or a control character is a syntax error.
+    // Strictly speaking, control chars include the range [0x7f, 0x9f], not just
+    // [0x00, 0x1f], but in practice, we ignore the multi-byte control characters
+    // as it is simpler to inspect the tag's bytes than the tag's runes.
+    i = 0
+    while (
+      i < tag.length &&
+      tag.charCodeAt(i) > 0x20 &&
+      tag[i] !== ':' &&
+      tag[i] !== '"' &&
+      tag.charCodeAt(i) !== 0x7f
+    ) {
+      i++
+    }
+    if (i === 0 || i + 1 >= tag.length || tag[i] !== ':' || tag[i + 1] !== '"') {
+      break
+    }
+    const name = tag.slice(0, i)
+    tag = tag.slice(i + 1)
+
+    // Scan quoted string to find value.
+    i = 1
+    while (i < tag.length && tag[i] !== '"') {
+      if (tag[i] === '\\') {
+        i++
+      }
+      i++
+    }
+    if (i >= tag.length) {
+      break
+    }
+    const qvalue = tag.slice(0, i + 1)
+    tag = tag.slice(i + 1)
+
+    if (key === name) {
+      const value = unquote(qvalue)
+      if (value === null) {
+        break
+      }
+      return [value, true]
+    }
   }
+  return ['', false]
+}
 
-  Get(key: string): string {
-    // Simple tag parsing - in a real implementation this would be more sophisticated
-    const parts = this._value.split(' ')
-    for (const part of parts) {
-      if (part.startsWith(key + ':')) {
-        const value = part.substring(key.length + 1)
-        if (value.startsWith('"') && value.endsWith('"')) {
-          return value.slice(1, -1)
-        }
-        return value
+// unquote interprets a double-quoted Go string literal, returning null if it
+// is not valid.
+function unquote(s: string): string | null {
+  const simple: Record<string, string> = {
+    a: '\x07',
+    b: '\b',
+    f: '\f',
+    n: '\n',
+    r: '\r',
+    t: '\t',
+    v: '\v',
+    '\\': '\\',
+    "'": "'",
+    '"': '"',
+  }
+  const hexLen: Record<string, number> = { x: 2, u: 4, U: 8 }
+  let out = ''
+  for (let i = 1; i < s.length - 1; i++) {
+    const c = s[i]
+    if (c === '\n' || c === '"') {
+      return null
+    }
+    if (c !== '\\') {
+      out += c
+      continue
+    }
+    const e = s[++i]
+    if (e in simple) {
+      out += simple[e]
+    } else if (e in hexLen) {
+      const digits = s.slice(i + 1, i + 1 + hexLen[e])
+      if (!/^[0-9a-fA-F]+$/.test(digits) || digits.length !== hexLen[e]) {
+        return null
+      }
+      out += String.fromCodePoint(parseInt(digits, 16))
+      i += hexLen[e]
+    } else if (e >= '0' && e <= '7') {
+      const digits = s.slice(i, i + 3)
+      if (!/^[0-7]{3}$/.test(digits)) {
+        return null
       }
+      out += String.fromCharCode(parseInt(digits, 8))
+      i += 2
+    } else {
+      return null
     }
-    return ''
   }
+  return out
 }
 
 // Method representation
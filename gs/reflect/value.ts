@@ -1,32 +1,27 @@
 import * as $ from '@goscript/builtin/index.js'
 import {
   Array,
-  Bool,
-  Float32,
-  Float64,
-  Int,
-  Int16,
-  Int32,
-  Int64,
-  Int8,
   Map,
   PointerTo,
   Ptr,
   Slice,
-  String,
   Type,
-  Uint,
-  Uint16,
-  Uint32,
-  Uint64,
   Uint8,
-  Uintptr,
   Value,
   Chan,
   BasicType,
   Invalid,
+  Interface,
+  Struct,
+  zeroOf,
 } from './type.js'
-import { ReflectValue, SelectCase, SelectRecv, SelectDefault } from './types.js'
+import {
+  ReflectValue,
+  SelectCase,
+  SelectRecv,
+  SelectDefault,
+  ValueError,
+} from './types.js'
 
 interface ChannelObject {
   _sendQueue?: unknown[]
@@ -35,151 +30,97 @@ interface ChannelObject {
 
 // Zero returns a Value representing the zero value for the specified type.
 export function Zero(typ: Type): Value {
-  let zeroValue: ReflectValue
-
-  switch (typ.Kind()) {
-    case Bool:
-      zeroValue = false
-      break
-    case Int:
-    case Int8:
-    case Int16:
-    case Int32:
-    case Int64:
-    case Uint:
-    case Uint8:
-    case Uint16:
-    case Uint32:
-    case Uint64:
-    case Uintptr:
-    case Float32:
-    case Float64:
-      zeroValue = 0
-      break
-    case String:
-      zeroValue = ''
-      break
-    case Slice:
-    case Array:
-      zeroValue = []
-      break
-    default:
-      zeroValue = null
-      break
-  }
-
-  return new Value(zeroValue, typ)
+  return new Value(zeroOf(typ), typ)
 }
 
 // Copy copies the contents of src to dst until either dst has been filled
 // or src has been exhausted. It returns the number of elements copied.
 export function Copy(dst: Value, src: Value): number {
-  // Extract the underlying arrays from the Value objects
-  const dstArray = getArrayFromValue(dst)
-  const srcArray = getArrayFromValue(src)
-
-  if (!dstArray || !srcArray) {
-    return 0
-  }
-
-  const count = Math.min(dstArray.length, srcArray.length)
-  for (let i = 0; i < count; i++) {
-    dstArray[i] = srcArray[i]
-  }
-  return count
-}
-
-// Helper function to extract the underlying array from a Value
-function getArrayFromValue(value: Value): unknown[] | null {
-  const val = (value as unknown as { value: ReflectValue }).value
-
-  // Check for GoScript slice objects created by $.arrayToSlice
-  if (val && typeof val === 'object' && '__meta__' in val) {
-    const meta = (val as { __meta__?: { backing?: unknown[] } }).__meta__
-    if (meta && meta.backing && globalThis.Array.isArray(meta.backing)) {
-      return meta.backing
-    }
-  }
-
-  // Check for regular JavaScript arrays
-  if (globalThis.Array.isArray(val)) {
-    return val
-  }
-
-  return null
+  return $.copy(
+    dst.value as $.Slice<unknown>,
+    src.value as $.Slice<unknown> | string,
+  )
 }
 
-// Indirect returns the value that v points to.
+// Indirect returns the value that v points to. If v is a nil pointer,
+// Indirect returns a zero Value. If v is not a pointer, Indirect returns v.
 export function Indirect(v: Value): Value {
-  // Check if this is a pointer type
-  const type = v.Type()
-  if (type.Kind() === Ptr) {
-    // Ptr kind
-    const elemType = type.Elem()
-    if (elemType) {
-      // Return a new Value with the same underlying value but the element type
-      return new Value(
-        (v as unknown as { value: ReflectValue }).value,
-        elemType,
-      )
-    }
+  if (v.Kind() !== Ptr) {
+    return v
   }
-  // For non-pointer types, just return the value as-is
-  return v
+  return v.Elem()
 }
 
-// New returns a Value representing a pointer to a new zero value for the specified type.
+// New returns a Value representing a pointer to a new zero value for the
+// specified type.
 export function New(typ: Type): Value {
-  const ptrType = PointerTo(typ)
-  // For the pointer value, we'll use the zero value but with pointer type
-  // In a real implementation, this would be a pointer to the zero value
-  return new Value(null, ptrType) // null represents the pointer value
+  const zero = zeroOf(typ)
+  // Pointers to structs are the struct values themselves.
+  if (typ.Kind() === Struct && zero !== null && typeof zero === 'object') {
+    return new Value(zero, PointerTo(typ))
+  }
+  return new Value($.varRef(zero), PointerTo(typ))
 }
 
-// MakeSlice returns a Value representing a new slice with the specified type, length, and capacity.
-export function MakeSlice(typ: Type, len: number, _cap: number): Value {
+// MakeSlice returns a Value representing a new slice with the specified
+// type, length, and capacity.
+export function MakeSlice(typ: Type, len: number, cap: number): Value {
   if (typ.Kind() !== Slice) {
-    throw new Error('reflect.MakeSlice of non-slice type')
+    $.panic('reflect.MakeSlice of non-slice type')
   }
-
-  // Create a slice with the specified length, filled with zero values
-  const elemType = typ.Elem()
-  if (!elemType) {
-    throw new Error('slice type missing element type')
+  if (len < 0 || cap < len) {
+    $.panic('reflect.MakeSlice: len out of range')
   }
-
-  const zeroValue = Zero(elemType)
-  const zeroVal = (zeroValue as unknown as { value: ReflectValue }).value
-  const array = new globalThis.Array(len).fill(zeroVal)
-
-  return new Value(array, typ)
+  const elemType = typ.Elem()!
+  if (elemType.Kind() === Uint8) {
+    return new Value($.makeSlice<number>(len, cap, 'byte'), typ)
+  }
+  const slice = $.makeSlice<ReflectValue>(len, cap) as ReflectValue[]
+  for (let i = 0; i < len; i++) {
+    slice[i] = zeroOf(elemType)
+  }
+  return new Value(slice, typ)
 }
 
 // MakeMap returns a Value representing a new map with the specified type.
 export function MakeMap(typ: Type): Value {
   if (typ.Kind() !== Map) {
-    throw new Error('reflect.MakeMap of non-map type')
+    $.panic('reflect.MakeMap of non-map type')
   }
-
-  const map = new globalThis.Map()
-  return new Value(map, typ)
+  // Maps keyed by structs, arrays and interfaces compare keys by Go
+  // equality.
+  const keyKind = (typ as { Key?: () => Type }).Key?.().Kind()
+  if (keyKind === Interface) {
+    return new Value($.makeInterfaceKeyMap(), typ)
+  }
+  if (keyKind === Struct || keyKind === Array) {
+    return new Value($.makeHashMap(), typ)
+  }
+  return new Value($.makeMap(), typ)
 }
 
-// Append appends the values x to a slice and returns the resulting slice.
-export function Append(s: Value, x: Value): Value {
+// Append appends the values x to a slice s and returns the resulting slice.
+export function Append(s: Value, ...x: Value[]): Value {
   if (s.Kind() !== Slice) {
-    throw new Error('reflect.Append of non-slice')
-  }
+    throw new ValueError({ Method: 'Append', Kind: s.Kind() })
+  }
+  const elemType = s.Type().Elem()
+  const elems = x.map((v) =>
+    elemType !== null ? v.Convert(elemType).value : v.value,
+  )
+  const slice = $.append(s.value as $.Slice<ReflectValue>, ...elems)
+  return new Value(slice, s.Type())
+}
 
-  const array = getArrayFromValue(s)
-  if (!array) {
-    throw new Error('cannot get array from slice value')
+// AppendSlice appends a slice t to a slice s and returns the resulting
+// slice.
+export function AppendSlice(s: Value, t: Value): Value {
+  if (s.Kind() !== Slice) {
+    throw new ValueError({ Method: 'AppendSlice', Kind: s.Kind() })
   }
-
-  const newValue = (x as unknown as { value: ReflectValue }).value
-  const newArray = [...array, newValue]
-
-  return new Value(newArray, s.Type())
+  const elems = $.asArray(t.value as $.Slice<ReflectValue>)
+  const slice = $.append(s.value as $.Slice<ReflectValue>, ...elems)
+  return new Value(slice, s.Type())
 }
 
 // MakeChan returns a Value representing a new channel with the specified type.
@@ -127,34 +127,53 @@ class visibleFieldsWalker {
     }
     $.mapSet(w.visiting, t, true)
     for (let i = 0; i < t!.NumField!(); i++) {
-      if (!t!.Field) continue
-      const field = t!.Field(i)
-      if (field) {
-        const f = field.clone()
-        f.Index = $.append(null, w.index) as number[]
-        if (f.Anonymous) {
-          if (f.Type && f.Type.Kind() === Ptr) {
-            const elemType = f.Type.Elem!()
-            if (elemType) {
-              f.Type = elemType
-            }
-          }
-          if (f.Type && f.Type.Kind() === Struct) {
-            w.walk(f.Type)
-          }
+      const f = t!.Field!(i)!
+      w.index = $.append(w.index, i)
+      let add = true
+      const [oldIndex, ok] = $.mapGet(w.byName, f.Name, 0)
+      if (ok) {
+        const old = w.fields![oldIndex]
+        if ($.len(w.index) == $.len(old.Index)) {
+          // Fields with the same name at the same depth
+          // cancel one another out. Set the field name
+          // to empty to signify that has happened, and
+          // there's no need to add this field.
+          old.Name = ''
+          add = false
+        } else if ($.len(w.index) < $.len(old.Index)) {
+          // The old field loses because it's deeper than the new one.
+          old.Name = ''
         } else {
-          w.fields = $.append(w.fields, f)
+          // The old field wins because it's shallower than the new one.
+          add = false
         }
       }
+      if (add) {
+        // Copy the index so that it's not overwritten
+        // by the other appends.
+        f.Index = [...$.asArray(w.index)]
+        $.mapSet(w.byName, f.Name, $.len(w.fields))
+        w.fields = $.append(w.fields, f)
+      }
+      if (f.Anonymous) {
+        let ft = f.Type
+        if (ft.Kind() === Ptr) {
+          ft = ft.Elem!()!
+        }
+        if (ft.Kind() === Struct) {
+          w.walk(ft)
+        }
+      }
+      w.index = $.goSlice(w.index, undefined, $.len(w.index) - 1)
     }
     $.deleteMapEntry(w.visiting, t)
   }
 
   // Register this type with the runtime type system
   static __typeInfo = $.registerStructType(
-    'visibleFieldsWalker',
+    'reflect.visibleFieldsWalker',
     new visibleFieldsWalker(),
-    [{ name: 'walk', args: [{ name: 't', type: 'Type' }], returns: [] }],
+    [{ name: 'walk', args: [{ name: 't', type: 'reflect.Type' }], returns: [] }],
     visibleFieldsWalker,
     {
       byName: {
@@ -164,10 +183,10 @@ class visibleFieldsWalker {
       },
       visiting: {
         kind: $.TypeKind.Map,
-        keyType: 'Type',
+        keyType: 'reflect.Type',
         elemType: { kind: $.TypeKind.Basic, name: 'boolean' },
       },
-      fields: { kind: $.TypeKind.Slice, elemType: 'StructField' },
+      fields: { kind: $.TypeKind.Slice, elemType: 'reflect.StructField' },
       index: {
         kind: $.TypeKind.Slice,
         elemType: { kind: $.TypeKind.Basic, name: 'number' },
@@ -0,0 +1,582 @@
+import * as $ from '@goscript/builtin/index.js'
+import * as io from '@goscript/io/index.js'
+
+import * as syntax from './syntax/index.js'
+import type { Regexp } from './regexp.js'
+
+export const endOfText = -1
+
+// input abstracts different representations of the input text. It provides
+// one-character lookahead.
+export interface input {
+  step(pos: number): [number, number] // advance one rune
+  canCheckPrefix(): boolean // can we look ahead without losing info?
+  hasPrefix(re: Regexp): boolean
+  index(re: Regexp, pos: number): number
+  context(pos: number): lazyFlag
+}
+
+// inputBytes scans the UTF-8 bytes of the input. String inputs are
+// encoded once up front so that every position is a byte offset, as in Go.
+export class inputBytes implements input {
+  constructor(public str: Uint8Array) {}
+
+  public step(pos: number): [number, number] {
+    if (pos < this.str.length) {
+      return decodeRune(this.str, pos, this.str.length)
+    }
+    return [endOfText, 0]
+  }
+
+  public canCheckPrefix(): boolean {
+    return true
+  }
+
+  public hasPrefix(re: Regexp): boolean {
+    return indexBytes(this.str, 0, re.prefixBytes) === 0
+  }
+
+  public index(re: Regexp, pos: number): number {
+    const i = indexBytes(this.str, pos, re.prefixBytes)
+    return i < 0 ? -1 : i - pos
+  }
+
+  public context(pos: number): lazyFlag {
+    let r1 = endOfText
+    let r2 = endOfText
+    // 0 < pos && pos <= len(i.str)
+    if (0 < pos && pos <= this.str.length) {
+      r1 = decodeLastRune(this.str, pos)
+    }
+    // 0 <= pos && pos < len(i.str)
+    if (0 <= pos && pos < this.str.length) {
+      r2 = decodeRune(this.str, pos, this.str.length)[0]
+    }
+    return new lazyFlag(r1, r2)
+  }
+}
+
+// inputReader scans a RuneReader.
+export class inputReader implements input {
+  public atEOT = false
+  public pos = 0
+
+  constructor(public r: io.RuneReader) {}
+
+  public step(pos: number): [number, number] {
+    if (!this.atEOT && pos !== this.pos) {
+      return [endOfText, 0]
+    }
+    const [r, w, err] = this.r.ReadRune()
+    if (err !== null) {
+      this.atEOT = true
+      return [endOfText, 0]
+    }
+    this.pos += w
+    return [r, w]
+  }
+
+  public canCheckPrefix(): boolean {
+    return false
+  }
+
+  public hasPrefix(_re: Regexp): boolean {
+    return false
+  }
+
+  public index(_re: Regexp, _pos: number): number {
+    return -1
+  }
+
+  public context(_pos: number): lazyFlag {
+    return new lazyFlag(0, 0) // not used
+  }
+}
+
+// decodeRune decodes the UTF-8 sequence at b[i:end] like utf8.DecodeRune,
+// treating each byte of an invalid sequence as U+FFFD.
+export function decodeRune(
+  b: Uint8Array,
+  i: number,
+  end: number,
+): [number, number] {
+  const b0 = b[i]
+  if (b0 < 0x80) {
+    return [b0, 1]
+  }
+  const n = end - i
+  let lo = 0x80
+  let hi = 0xbf
+  let size: number
+  if (b0 >= 0xc2 && b0 <= 0xdf) {
+    size = 2
+  } else if (b0 >= 0xe0 && b0 <= 0xef) {
+    size = 3
+    if (b0 === 0xe0) {
+      lo = 0xa0
+    } else if (b0 === 0xed) {
+      hi = 0x9f
+    }
+  } else if (b0 >= 0xf0 && b0 <= 0xf4) {
+    size = 4
+    if (b0 === 0xf0) {
+      lo = 0x90
+    } else if (b0 === 0xf4) {
+      hi = 0x8f
+    }
+  } else {
+    return [0xfffd, 1]
+  }
+  if (n < size) {
+    return [0xfffd, 1]
+  }
+  const b1 = b[i + 1]
+  if (b1 < lo || hi < b1) {
+    return [0xfffd, 1]
+  }
+  if (size === 2) {
+    return [((b0 & 0x1f) << 6) | (b1 & 0x3f), 2]
+  }
+  const b2 = b[i + 2]
+  if (b2 < 0x80 || 0xbf < b2) {
+    return [0xfffd, 1]
+  }
+  if (size === 3) {
+    return [((b0 & 0x0f) << 12) | ((b1 & 0x3f) << 6) | (b2 & 0x3f), 3]
+  }
+  const b3 = b[i + 3]
+  if (b3 < 0x80 || 0xbf < b3) {
+    return [0xfffd, 1]
+  }
+  return [
+    ((b0 & 0x07) << 18) |
+      ((b1 & 0x3f) << 12) |
+      ((b2 & 0x3f) << 6) |
+      (b3 & 0x3f),
+    4,
+  ]
+}
+
+// decodeLastRune decodes the last rune of b[:end] like utf8.DecodeLastRune.
+function decodeLastRune(b: Uint8Array, end: number): number {
+  let start = end - 1
+  if (b[start] < 0x80) {
+    return b[start]
+  }
+  const lim = Math.max(end - 4, 0)
+  for (start--; start >= lim; start--) {
+    if ((b[start] & 0xc0) !== 0x80) {
+      break
+    }
+  }
+  if (start < 0) {
+    start = 0
+  }
+  const [r, size] = decodeRune(b, start, end)
+  if (start + size !== end) {
+    return 0xfffd
+  }
+  return r
+}
+
+// indexBytes returns the index of the first instance of sep in s at or
+// after pos, or -1 if sep is not present.
+function indexBytes(s: Uint8Array, pos: number, sep: Uint8Array): number {
+  const n = sep.length
+  if (n === 0) {
+    return pos
+  }
+  const c0 = sep[0]
+  for (let i = s.indexOf(c0, pos); i >= 0; i = s.indexOf(c0, i + 1)) {
+    if (i + n > s.length) {
+      return -1
+    }
+    let j = 1
+    while (j < n && s[i + j] === sep[j]) {
+      j++
+    }
+    if (j === n) {
+      return i
+    }
+  }
+  return -1
+}
+
+// A lazyFlag is a lazily-evaluated syntax.EmptyOp,
+// for checking zero-width flags like ^ $ \A \z \B \b.
+// It records the pair of relevant runes and does not
+// determine the implied flags until absolutely necessary
+// (most of the time, that means never).
+export class lazyFlag {
+  constructor(
+    public r1: number,
+    public r2: number,
+  ) {}
+
+  public match(op: syntax.EmptyOp): boolean {
+    if (op === 0) {
+      return true
+    }
+    const r1 = this.r1
+    if ((op & syntax.EmptyBeginLine) !== 0) {
+      if (r1 !== 0x0a && r1 >= 0) {
+        return false
+      }
+      op &= ~syntax.EmptyBeginLine
+    }
+    if ((op & syntax.EmptyBeginText) !== 0) {
+      if (r1 >= 0) {
+        return false
+      }
+      op &= ~syntax.EmptyBeginText
+    }
+    if (op === 0) {
+      return true
+    }
+    const r2 = this.r2
+    if ((op & syntax.EmptyEndLine) !== 0) {
+      if (r2 !== 0x0a && r2 >= 0) {
+        return false
+      }
+      op &= ~syntax.EmptyEndLine
+    }
+    if ((op & syntax.EmptyEndText) !== 0) {
+      if (r2 >= 0) {
+        return false
+      }
+      op &= ~syntax.EmptyEndText
+    }
+    if (op === 0) {
+      return true
+    }
+    if (syntax.IsWordChar(r1) !== syntax.IsWordChar(r2)) {
+      op &= ~syntax.EmptyWordBoundary
+    } else {
+      op &= ~syntax.EmptyNoWordBoundary
+    }
+    return op === 0
+  }
+}
+
+// A queue is a 'sparse array' holding pending threads of execution.
+// See https://research.swtch.com/2008/03/using-uninitialized-memory-for-fun-and.html
+class queue {
+  public sparse: Uint32Array
+  public dense: entry[] = []
+  public size = 0 // number of entries of dense in use
+
+  constructor(n: number) {
+    this.sparse = new Uint32Array(n)
+  }
+}
+
+// An entry is an entry on a queue.
+// It holds both the instruction pc and the actual thread.
+// Some queue entries are just place holders so that the machine
+// knows it has considered that pc. Such entries have t == nil.
+interface entry {
+  pc: number
+  t: thread | null
+}
+
+// A thread is the state of a single path through the machine:
+// an instruction and a corresponding capture array.
+// See https://swtch.com/~rsc/regexp/regexp2.html
+interface thread {
+  inst: syntax.Inst
+  cap: number[]
+}
+
+// A machine holds all the state during an NFA simulation for p.
+export class machine {
+  public q0: queue
+  public q1: queue
+  public pool: thread[] = [] // pool of available threads
+  public matched = false // whether a match was found
+  public matchcap: number[] = [] // capture information for the match
+
+  constructor(
+    public re: Regexp, // corresponding Regexp
+    public p: syntax.Prog, // compiled program
+  ) {
+    const n = p.Inst.length
+    this.q0 = new queue(n)
+    this.q1 = new queue(n)
+  }
+
+  public init(ncap: number): void {
+    for (const t of this.pool) {
+      t.cap.length = ncap
+    }
+    this.matchcap.length = ncap
+  }
+
+  // alloc allocates a new thread with the given instruction.
+  // It uses the free pool if possible.
+  public alloc(i: syntax.Inst): thread {
+    const t = this.pool.pop()
+    if (t !== undefined) {
+      t.inst = i
+      return t
+    }
+    return { inst: i, cap: new Array<number>(this.matchcap.length).fill(0) }
+  }
+
+  // match runs the machine over the input starting at pos.
+  // It reports whether a match was found.
+  // If so, m.matchcap holds the submatch information.
+  public match(i: input, pos: number): boolean {
+    const startCond = this.re.cond
+    if (startCond === 0xff) {
+      // impossible
+      return false
+    }
+    this.matched = false
+    this.matchcap.fill(-1)
+    let runq = this.q0
+    let nextq = this.q1
+    let r = endOfText
+    let r1 = endOfText
+    let width = 0
+    let width1 = 0
+    ;[r, width] = i.step(pos)
+    if (r !== endOfText) {
+      ;[r1, width1] = i.step(pos + width)
+    }
+    let flag: lazyFlag
+    if (pos === 0) {
+      flag = new lazyFlag(-1, r)
+    } else {
+      flag = i.context(pos)
+    }
+    for (;;) {
+      if (runq.size === 0) {
+        if ((startCond & syntax.EmptyBeginText) !== 0 && pos !== 0) {
+          // Anchored match, past beginning of text.
+          break
+        }
+        if (this.matched) {
+          // Have match; finished exploring alternatives.
+          break
+        }
+        if (
+          this.re.prefix.length > 0 &&
+          r1 !== this.re.prefixRune &&
+          i.canCheckPrefix()
+        ) {
+          // Match requires literal prefix; fast search for it.
+          const advance = i.index(this.re, pos)
+          if (advance < 0) {
+            break
+          }
+          pos += advance
+          ;[r, width] = i.step(pos)
+          ;[r1, width1] = i.step(pos + width)
+        }
+      }
+      if (!this.matched) {
+        if (this.matchcap.length > 0) {
+          this.matchcap[0] = pos
+        }
+        this.add(runq, this.p.Start, pos, this.matchcap, flag, null)
+      }
+      flag = new lazyFlag(r, r1)
+      this.step(runq, nextq, pos, pos + width, r, flag)
+      if (width === 0) {
+        break
+      }
+      if (this.matchcap.length === 0 && this.matched) {
+        // Found a match and not paying attention
+        // to where it is, so any match will do.
+        break
+      }
+      pos += width
+      r = r1
+      width = width1
+      if (r !== endOfText) {
+        ;[r1, width1] = i.step(pos + width)
+      }
+      ;[runq, nextq] = [nextq, runq]
+    }
+    this.clear(nextq)
+    return this.matched
+  }
+
+  // clear frees all threads on the thread queue.
+  public clear(q: queue): void {
+    for (let j = 0; j < q.size; j++) {
+      const t = q.dense[j].t
+      if (t !== null) {
+        this.pool.push(t)
+      }
+    }
+    q.size = 0
+  }
+
+  // step executes one step of the machine, running each of the threads
+  // on runq and appending new threads to nextq.
+  // The step processes the rune c (which may be endOfText),
+  // which starts at position pos and ends at nextPos.
+  // nextCond gives the setting for the empty-width flags after c.
+  public step(
+    runq: queue,
+    nextq: queue,
+    pos: number,
+    nextPos: number,
+    c: number,
+    nextCond: lazyFlag,
+  ): void {
+    const longest = this.re.longest
+    for (let j = 0; j < runq.size; j++) {
+      let t = runq.dense[j].t
+      if (t === null) {
+        continue
+      }
+      if (
+        longest &&
+        this.matched &&
+        t.cap.length > 0 &&
+        this.matchcap[0] < t.cap[0]
+      ) {
+        this.pool.push(t)
+        continue
+      }
+      const i = t.inst
+      let add = false
+      switch (i.Op) {
+        default:
+          $.panic('bad inst')
+          break
+
+        case syntax.InstMatch:
+          if (
+            t.cap.length > 0 &&
+            (!longest || !this.matched || this.matchcap[1] < pos)
+          ) {
+            t.cap[1] = pos
+            copyInts(this.matchcap, t.cap)
+          }
+          if (!longest) {
+            // First-match mode: cut off all lower-priority threads.
+            for (let k = j + 1; k < runq.size; k++) {
+              const d = runq.dense[k]
+              if (d.t !== null) {
+                this.pool.push(d.t)
+              }
+            }
+            runq.size = 0
+          }
+          this.matched = true
+          break
+
+        case syntax.InstRune:
+          add = i.MatchRune(c)
+          break
+        case syntax.InstRune1:
+          add = c === i.Rune![0]
+          break
+        case syntax.InstRuneAny:
+          add = true
+          break
+        case syntax.InstRuneAnyNotNL:
+          add = c !== 0x0a
+          break
+      }
+      if (add) {
+        t = this.add(nextq, i.Out, nextPos, t.cap, nextCond, t)
+      }
+      if (t !== null) {
+        this.pool.push(t)
+      }
+    }
+    runq.size = 0
+  }
+
+  // add adds an entry to q for pc, unless the q already has such an entry.
+  // It also recursively adds an entry for all instructions reachable from pc by following
+  // empty-width conditions satisfied by cond.  pos gives the current position
+  // in the input.
+  public add(
+    q: queue,
+    pc: number,
+    pos: number,
+    cap: number[],
+    cond: lazyFlag,
+    t: thread | null,
+  ): thread | null {
+    for (;;) {
+      if (pc === 0) {
+        return t
+      }
+      const k = q.sparse[pc]
+      if (k < q.size && q.dense[k].pc === pc) {
+        return t
+      }
+
+      const j = q.size++
+      if (j === q.dense.length) {
+        q.dense.push({ pc: 0, t: null })
+      }
+      const d = q.dense[j]
+      d.t = null
+      d.pc = pc
+      q.sparse[pc] = j
+
+      const i = this.p.Inst[pc]
+      switch (i.Op) {
+        default:
+          $.panic('unhandled')
+          return t
+        case syntax.InstFail:
+          // nothing
+          return t
+        case syntax.InstAlt:
+        case syntax.InstAltMatch:
+          t = this.add(q, i.Out, pos, cap, cond, t)
+          pc = i.Arg
+          continue
+        case syntax.InstEmptyWidth:
+          if (cond.match(i.Arg)) {
+            pc = i.Out
+            continue
+          }
+          return t
+        case syntax.InstNop:
+          pc = i.Out
+          continue
+        case syntax.InstCapture:
+          if (i.Arg < cap.length) {
+            const opos = cap[i.Arg]
+            cap[i.Arg] = pos
+            this.add(q, i.Out, pos, cap, cond, null)
+            cap[i.Arg] = opos
+            return t
+          }
+          pc = i.Out
+          continue
+        case syntax.InstMatch:
+        case syntax.InstRune:
+        case syntax.InstRune1:
+        case syntax.InstRuneAny:
+        case syntax.InstRuneAnyNotNL:
+          if (t === null) {
+            t = this.alloc(i)
+          } else {
+            t.inst = i
+          }
+          if (cap.length > 0 && t.cap !== cap) {
+            copyInts(t.cap, cap)
+          }
+          d.t = t
+          return null
+      }
+    }
+  }
+}
+
+function copyInts(dst: number[], src: number[]): void {
+  const n = Math.min(dst.length, src.length)
+  for (let i = 0; i < n; i++) {
+    dst[i] = src[i]
+  }
+}
@@ -0,0 +1,11 @@
+export {
+  Compile,
+  CompilePOSIX,
+  Match,
+  MatchReader,
+  MatchString,
+  MustCompile,
+  MustCompilePOSIX,
+  QuoteMeta,
+  Regexp,
+} from './regexp.js'
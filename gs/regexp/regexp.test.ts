@@ -0,0 +1,154 @@
+import { describe, it, expect } from 'vitest'
+import * as $ from '@goscript/builtin/index.js'
+import * as strings from '@goscript/strings/index.js'
+import {
+  Compile,
+  CompilePOSIX,
+  MatchString,
+  MustCompile,
+  QuoteMeta,
+} from './regexp.js'
+
+describe('regexp', () => {
+  it('reports byte offsets for non-ASCII input', () => {
+    const re = MustCompile('b+')
+    expect(re.FindStringIndex('héllo wörld bbb')).toEqual([14, 17])
+    expect(re.FindString('héllo wörld bbb')).toBe('bbb')
+
+    const word = MustCompile('\\p{Greek}+')
+    expect(word.FindAllStringIndex('αβγ abc δε', -1)).toEqual([
+      [0, 6],
+      [11, 15],
+    ])
+  })
+
+  it('finds submatches and named groups', () => {
+    const re = MustCompile('(?P<key>\\w+)=(?<value>\\w*)')
+    expect(re.NumSubexp()).toBe(2)
+    expect(re.SubexpNames()).toEqual(['', 'key', 'value'])
+    expect(re.SubexpIndex('value')).toBe(2)
+    expect(re.SubexpIndex('missing')).toBe(-1)
+    expect(re.FindStringSubmatch('a=1 b=')).toEqual(['a=1', 'a', '1'])
+    expect(re.FindAllStringSubmatchIndex('a=1 b=', -1)).toEqual([
+      [0, 3, 0, 1, 2, 3],
+      [4, 6, 4, 5, 6, 6],
+    ])
+    expect(re.FindStringSubmatch('no match')).toBeNull()
+
+    const opt = MustCompile('a(x)?b')
+    expect(opt.FindStringSubmatchIndex('ab')).toEqual([0, 2, -1, -1])
+  })
+
+  it('prefers leftmost-first unless Longest is set', () => {
+    const re = MustCompile('a|ab')
+    expect(re.FindString('ab')).toBe('a')
+    re.Longest()
+    expect(re.FindString('ab')).toBe('ab')
+
+    const posix = CompilePOSIX('a+|a+b')[0]!
+    expect(posix.FindString('aaab')).toBe('aaab')
+  })
+
+  it('handles empty matches like Go', () => {
+    const re = MustCompile('a*')
+    expect(re.FindAllString('baaab', -1)).toEqual(['', 'aaa', ''])
+    expect(re.ReplaceAllString('baaab', '-')).toBe('-b-b-')
+    expect(re.Split('abaabaccadaaae', 5)).toEqual([
+      '',
+      'b',
+      'b',
+      'c',
+      'cadaaae',
+    ])
+    expect(MustCompile('x').FindAllString('abc', -1)).toBeNull()
+  })
+
+  it('expands templates and replacement functions', () => {
+    const re = MustCompile('(\\w+)@(?P<host>\\w+)')
+    expect(re.ReplaceAllString('me@home, you@work', '$host:${1}')).toBe(
+      'home:me, work:you',
+    )
+    expect(re.ReplaceAllString('me@home', '$1x $$1')).toBe(' $1')
+    expect(re.ReplaceAllLiteralString('me@home', '$1')).toBe('$1')
+    expect(
+      re.ReplaceAllStringFunc('me@home', (s: string) => strings.ToUpper(s)),
+    ).toBe('ME@HOME')
+
+    const match = re.FindStringSubmatchIndex('ü me@x')
+    expect(match).toEqual([3, 7, 3, 5, 6, 7])
+    const dst = re.ExpandString(null, '[$2]', 'ü me@x', match)
+    expect($.bytesToString(dst!)).toBe('[x]')
+  })
+
+  it('works on byte slices', () => {
+    const re = MustCompile('[0-9]+')
+    const b = $.stringToBytes('ab 12 cd 345')
+    expect($.bytesToString(re.Find(b)!)).toBe('12')
+    expect(re.FindIndex(b)).toEqual([3, 5])
+    expect(re.FindAll(b, -1)!.map((m) => $.bytesToString(m))).toEqual([
+      '12',
+      '345',
+    ])
+    expect(
+      $.bytesToString(re.ReplaceAll(b, $.stringToBytes('<$0>'))),
+    ).toBe('ab <12> cd <345>')
+    expect(re.Match($.stringToBytes('none'))).toBe(false)
+  })
+
+  it('matches case-insensitively with Unicode folding', () => {
+    const re = MustCompile('(?i)straße|k')
+    expect(re.MatchString('STRASSE')).toBe(false)
+    expect(re.MatchString('STRAßE')).toBe(true)
+    // U+212A KELVIN SIGN folds to k.
+    expect(re.MatchString('K')).toBe(true)
+    expect(MustCompile('(?i)σ').FindAllString('Σςσ', -1)).toEqual([
+      'Σ',
+      'ς',
+      'σ',
+    ])
+  })
+
+  it('uses Go semantics for anchors and classes', () => {
+    expect(MustCompile('(?m)^\\w+$').FindAllString('ab\ncd', -1)).toEqual([
+      'ab',
+      'cd',
+    ])
+    expect(MustCompile('^\\w+$').MatchString('ab\ncd')).toBe(false)
+    expect(MustCompile('\\bé').MatchString('é')).toBe(false)
+    expect(MustCompile('[[:alpha:]]+').FindString('12abc3')).toBe('abc')
+    expect(MustCompile('\\x{1F600}').MatchString('😀')).toBe(true)
+    expect(MustCompile('.').FindStringIndex('😀')).toEqual([0, 4])
+    expect(MustCompile('\\Qa.b\\E').MatchString('axb')).toBe(false)
+  })
+
+  it('reports parse errors like Go', () => {
+    const cases: [string, string][] = [
+      ['a(b', 'missing closing ): `a(b`'],
+      ['a)', 'unexpected ): `a)`'],
+      ['x**', 'invalid nested repetition operator: `**`'],
+      ['[z-a]', 'invalid character class range: `z-a`'],
+      ['\\8', 'invalid escape sequence: `\\8`'],
+      ['(?<n!>x)', 'invalid named capture: `(?<n!>`'],
+      ['\\p{Foo}', 'invalid character class range: `\\p{Foo}`'],
+      ['a{1001}', 'invalid repeat count: `{1001}`'],
+      ['(?=x)', 'invalid or unsupported Perl syntax: `(?=`'],
+    ]
+    for (const [expr, msg] of cases) {
+      const [re, err] = Compile(expr)
+      expect(re).toBeNull()
+      expect(err!.Error()).toBe('error parsing regexp: ' + msg)
+    }
+    expect(() => MustCompile('(')).toThrow(
+      'regexp: Compile(`(`): error parsing regexp: missing closing ): `(`',
+    )
+  })
+
+  it('round-trips through String and QuoteMeta', () => {
+    expect(QuoteMeta('1.5-2.0?')).toBe('1\\.5-2\\.0\\?')
+    const [ok] = MatchString(QuoteMeta('[a]'), 'x[a]y')
+    expect(ok).toBe(true)
+    const [prefix, complete] = MustCompile('abc+').LiteralPrefix()
+    expect([prefix, complete]).toEqual(['abc', false])
+    expect(MustCompile('a.b').String()).toBe('a.b')
+  })
+})
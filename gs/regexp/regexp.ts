@@ -0,0 +1,1202 @@
+import * as $ from '@goscript/builtin/index.js'
+import * as io from '@goscript/io/index.js'
+import { CanBackquote, Quote } from '@goscript/strconv/index.js'
+
+import {
+  decodeRune,
+  input,
+  inputBytes,
+  inputReader,
+  machine,
+} from './exec.js'
+import * as syntax from './syntax/index.js'
+import {
+  boolType,
+  bytesFuncType,
+  bytesSlice2Type,
+  bytesSliceType,
+  bytesType,
+  errorType,
+  intType,
+  intsSliceType,
+  intsType,
+  regexpPtrType,
+  stringFuncType,
+  stringType,
+  stringsSliceType,
+  stringsType,
+} from './typeinfo.js'
+
+// Regexp is the representation of a compiled regular expression.
+// A Regexp is safe for concurrent use by multiple goroutines,
+// except for configuration methods, such as [Regexp.Longest].
+export class Regexp {
+  public expr = '' // as passed to Compile
+  public prog: syntax.Prog = new syntax.Prog() // compiled program
+  public numSubexp = 0
+  public subexpNames: string[] = []
+  public prefix = '' // required prefix in unanchored matches
+  public prefixBytes: Uint8Array = new Uint8Array(0) // prefix, as a []byte
+  public prefixRune = 0 // first rune in prefix
+  public prefixComplete = false // prefix is the entire regexp
+  // empty-width conditions required at start of match
+  public cond: syntax.EmptyOp = 0
+  public minInputLen = 0 // minimum length of the input in bytes
+
+  // This field can be modified by the Longest method,
+  // but it is otherwise read-only.
+  public longest = false // whether regexp prefers leftmost-longest match
+
+  // machines is the pool of idle machines for this Regexp. Matching can
+  // re-enter the same Regexp from a ReplaceAllStringFunc callback,
+  // so each search takes its own machine.
+  private machines: machine[] = []
+
+  constructor(
+    init?: Partial<{
+      expr: string
+      prog: syntax.Prog
+      numSubexp: number
+      subexpNames: string[]
+      prefix: string
+      prefixBytes: Uint8Array
+      prefixRune: number
+      prefixComplete: boolean
+      cond: syntax.EmptyOp
+      minInputLen: number
+      longest: boolean
+    }>,
+  ) {
+    if (init) {
+      Object.assign(this, init)
+    }
+  }
+
+  public clone(): Regexp {
+    return new Regexp({
+      expr: this.expr,
+      prog: this.prog,
+      numSubexp: this.numSubexp,
+      subexpNames: this.subexpNames,
+      prefix: this.prefix,
+      prefixBytes: this.prefixBytes,
+      prefixRune: this.prefixRune,
+      prefixComplete: this.prefixComplete,
+      cond: this.cond,
+      minInputLen: this.minInputLen,
+      longest: this.longest,
+    })
+  }
+
+  // String returns the source text used to compile the regular expression.
+  public String(): string {
+    return this.expr
+  }
+
+  // Copy returns a new [Regexp] object copied from re.
+  // Calling [Regexp.Longest] on one copy does not affect another.
+  //
+  // Deprecated: In earlier releases, when using a [Regexp] in multiple goroutines,
+  // giving each goroutine its own copy helped to avoid lock contention.
+  // As of Go 1.12, using Copy is no longer necessary to avoid lock contention.
+  // Copy may still be appropriate if the reason for its use is to make
+  // two copies with different [Regexp.Longest] settings.
+  public Copy(): Regexp {
+    return this.clone()
+  }
+
+  // Longest makes future searches prefer the leftmost-longest match.
+  // That is, when matching against text, the regexp returns a match that
+  // begins as early as possible in the input (leftmost), and among those
+  // it chooses a match that is as long as possible.
+  // This method modifies the [Regexp] and may not be called concurrently
+  // with any other methods.
+  public Longest(): void {
+    this.longest = true
+  }
+
+  // get returns a machine to use for matching re.
+  // It uses the re's machine cache if possible, to avoid
+  // unnecessary allocation.
+  private get(): machine {
+    return this.machines.pop() ?? new machine(this, this.prog)
+  }
+
+  // put returns a machine to the machine pool.
+  private put(m: machine): void {
+    this.machines.push(m)
+  }
+
+  // NumSubexp returns the number of parenthesized subexpressions in this [Regexp].
+  public NumSubexp(): number {
+    return this.numSubexp
+  }
+
+  // SubexpNames returns the names of the parenthesized subexpressions
+  // in this [Regexp]. The name for the first sub-expression is names[1],
+  // so that if m is a match slice, the name for m[i] is SubexpNames()[i].
+  // Since the Regexp as a whole cannot be named, names[0] is always
+  // the empty string. The slice should not be modified.
+  public SubexpNames(): string[] {
+    return this.subexpNames
+  }
+
+  // SubexpIndex returns the index of the first subexpression with the given name,
+  // or -1 if there is no subexpression with that name.
+  //
+  // Note that multiple subexpressions can be written using the same name, as in
+  // (?P<bob>a+)(?P<bob>b+), which declares two subexpressions named "bob".
+  // In this case, SubexpIndex returns the index of the leftmost such subexpression
+  // in the regular expression.
+  public SubexpIndex(name: string): number {
+    if (name !== '') {
+      return this.subexpNames.indexOf(name)
+    }
+    return -1
+  }
+
+  // LiteralPrefix returns a literal string that must begin any match
+  // of the regular expression re. It returns the boolean true if the
+  // literal string comprises the entire regular expression.
+  public LiteralPrefix(): [string, boolean] {
+    return [this.prefix, this.prefixComplete]
+  }
+
+  // MatchReader reports whether the text returned by the [io.RuneReader]
+  // contains any match of the regular expression re.
+  public MatchReader(r: io.RuneReader): boolean {
+    return this.find(r, null, 0, 0) !== null
+  }
+
+  // MatchString reports whether the string s
+  // contains any match of the regular expression re.
+  public MatchString(s: string): boolean {
+    return this.find(null, $.stringToBytes(s), 0, 0) !== null
+  }
+
+  // Match reports whether the byte slice b
+  // contains any match of the regular expression re.
+  public Match(b: $.Bytes | null): boolean {
+    return this.find(null, $.bytesToUint8Array(b), 0, 0) !== null
+  }
+
+  // find finds the leftmost match in the input and returns the position
+  // of its subexpressions, or null if no matches are found. The input is
+  // r if non-nil, otherwise the UTF-8 bytes b.
+  private find(
+    r: io.RuneReader | null,
+    b: Uint8Array | null,
+    pos: number,
+    ncap: number,
+  ): number[] | null {
+    if (r === null && b!.length < this.minInputLen) {
+      return null
+    }
+
+    const m = this.get()
+    const i: input = r !== null ? new inputReader(r) : new inputBytes(b!)
+
+    m.init(ncap)
+    if (!m.match(i, pos)) {
+      this.put(m)
+      return null
+    }
+
+    const dstCap = m.matchcap.slice()
+    this.put(m)
+    return dstCap
+  }
+
+  // ReplaceAllString returns a copy of src, replacing matches of the [Regexp]
+  // with the replacement string repl.
+  // Inside repl, $ signs are interpreted as in [Regexp.Expand].
+  public ReplaceAllString(src: string, repl: string): string {
+    let n = 2
+    if (repl.includes('$')) {
+      n = 2 * (this.numSubexp + 1)
+    }
+    const bsrc = $.stringToBytes(src)
+    const b = this.replaceAll(bsrc, n, (dst, match) => {
+      this.expand(dst, repl, bsrc, match)
+    })
+    return $.bytesToString(b)
+  }
+
+  // ReplaceAllLiteralString returns a copy of src, replacing matches of the [Regexp]
+  // with the replacement string repl. The replacement repl is substituted directly,
+  // without using [Regexp.Expand].
+  public ReplaceAllLiteralString(src: string, repl: string): string {
+    const brepl = $.stringToBytes(repl)
+    const b = this.replaceAll($.stringToBytes(src), 2, (dst) => {
+      dst.write(brepl)
+    })
+    return $.bytesToString(b)
+  }
+
+  // ReplaceAllStringFunc returns a copy of src in which all matches of the
+  // [Regexp] have been replaced by the return value of function repl applied
+  // to the matched substring. The replacement returned by repl is substituted
+  // directly, without using [Regexp.Expand].
+  public ReplaceAllStringFunc(
+    src: string,
+    repl: ((s: string) => string) | null,
+  ): string {
+    const bsrc = $.stringToBytes(src)
+    const b = this.replaceAll(bsrc, 2, (dst, match) => {
+      const s = $.bytesToString(bsrc.subarray(match[0], match[1]))
+      dst.write($.stringToBytes(repl!(s)))
+    })
+    return $.bytesToString(b)
+  }
+
+  private replaceAll(
+    bsrc: Uint8Array,
+    nmatch: number,
+    repl: (dst: buffer, m: number[]) => void,
+  ): Uint8Array {
+    let lastMatchEnd = 0 // end position of the most recent match
+    let searchPos = 0 // position where we next look for a match
+    const buf = new buffer()
+    const endPos = bsrc.length
+    if (nmatch > this.prog.NumCap) {
+      nmatch = this.prog.NumCap
+    }
+
+    while (searchPos <= endPos) {
+      const a = this.find(null, bsrc, searchPos, nmatch)
+      if (a === null || a.length === 0) {
+        break // no more matches
+      }
+
+      // Copy the unmatched characters before this match.
+      buf.write(bsrc.subarray(lastMatchEnd, a[0]))
+
+      // Now insert a copy of the replacement string, but not for a
+      // match of the empty string immediately after another match.
+      // (Otherwise, we get double replacement for patterns that
+      // match both empty and nonempty strings.)
+      if (a[1] > lastMatchEnd || a[0] === 0) {
+        repl(buf, a)
+      }
+      lastMatchEnd = a[1]
+
+      // Advance past this match; always advance at least one character.
+      let width = 0
+      if (searchPos < endPos) {
+        width = decodeRune(bsrc, searchPos, endPos)[1]
+      }
+      if (searchPos + width > a[1]) {
+        searchPos += width
+      } else if (searchPos + 1 > a[1]) {
+        // This clause is only needed at the end of the input
+        // string. In that case, DecodeRuneInString returns width=0.
+        searchPos++
+      } else {
+        searchPos = a[1]
+      }
+    }
+
+    // Copy the unmatched characters after the last match.
+    buf.write(bsrc.subarray(lastMatchEnd))
+
+    return buf.bytes()
+  }
+
+  // ReplaceAll returns a copy of src, replacing matches of the [Regexp]
+  // with the replacement text repl.
+  // Inside repl, $ signs are interpreted as in [Regexp.Expand].
+  public ReplaceAll(src: $.Bytes | null, repl: $.Bytes | null): $.Bytes {
+    const brepl = $.bytesToUint8Array(repl)
+    let n = 2
+    if (brepl.indexOf(0x24) >= 0) {
+      n = 2 * (this.numSubexp + 1)
+    }
+    const srepl = $.bytesToString(brepl)
+    const bsrc = $.bytesToUint8Array(src)
+    return this.replaceAll(bsrc, n, (dst, match) => {
+      this.expand(dst, srepl, bsrc, match)
+    })
+  }
+
+  // ReplaceAllLiteral returns a copy of src, replacing matches of the [Regexp]
+  // with the replacement bytes repl. The replacement repl is substituted directly,
+  // without using [Regexp.Expand].
+  public ReplaceAllLiteral(
+    src: $.Bytes | null,
+    repl: $.Bytes | null,
+  ): $.Bytes {
+    const brepl = $.bytesToUint8Array(repl)
+    return this.replaceAll($.bytesToUint8Array(src), 2, (dst) => {
+      dst.write(brepl)
+    })
+  }
+
+  // ReplaceAllFunc returns a copy of src in which all matches of the
+  // [Regexp] have been replaced by the return value of function repl applied
+  // to the matched byte slice. The replacement returned by repl is substituted
+  // directly, without using [Regexp.Expand].
+  public ReplaceAllFunc(
+    src: $.Bytes | null,
+    repl: ((b: $.Bytes) => $.Bytes | null) | null,
+  ): $.Bytes {
+    return this.replaceAll($.bytesToUint8Array(src), 2, (dst, match) => {
+      const b = $.goSlice(src!, match[0], match[1])
+      dst.write($.bytesToUint8Array(repl!(b)))
+    })
+  }
+
+  // The number of capture values in the program may correspond
+  // to fewer capturing expressions than are in the regexp.
+  // For example, "(a){0}" turns into an empty program, so the
+  // maximum capture in the program is 0 but we need to return
+  // an expression for \1.  Pad appends -1s to the slice a as needed.
+  private pad(a: number[] | null): number[] | null {
+    if (a === null) {
+      // No match.
+      return null
+    }
+    const n = (1 + this.numSubexp) * 2
+    while (a.length < n) {
+      a.push(-1)
+    }
+    return a
+  }
+
+  // matches calls yield with the location of successive matches in the
+  // UTF-8 input b until yield returns false.
+  private matches(
+    b: Uint8Array,
+    max: number,
+    ncap: number,
+    yieldMatch: (m: number[]) => boolean,
+  ): void {
+    if (max === 0) {
+      return
+    }
+    const end = b.length
+    for (let pos = 0, prevMatchEnd = -1; pos <= end; ) {
+      const matches = this.find(null, b, pos, ncap)
+      if (matches === null || matches.length === 0) {
+        break
+      }
+
+      let accept = true
+      if (matches[1] === pos) {
+        // We've found an empty match.
+        if (matches[0] === prevMatchEnd) {
+          // We don't allow an empty match right
+          // after a previous match, so ignore it.
+          accept = false
+        }
+        let width = 0
+        if (pos < end) {
+          width = decodeRune(b, pos, end)[1]
+        }
+        if (width > 0) {
+          pos += width
+        } else {
+          pos = end + 1
+        }
+      } else {
+        pos = matches[1]
+      }
+      prevMatchEnd = matches[1]
+
+      if (accept) {
+        if (!yieldMatch(this.pad(matches)!)) {
+          return
+        }
+        if (max > 0) {
+          if (--max === 0) {
+            return
+          }
+        }
+      }
+    }
+  }
+
+  // Find returns the text of the leftmost match for re in b.
+  // The return value is nil for no match.
+  public Find(b: $.Bytes | null): $.Bytes | null {
+    const a = this.find(null, $.bytesToUint8Array(b), 0, 2)
+    if (a === null || b === null) {
+      return null
+    }
+    return $.goSlice(b, a[0], a[1], a[1])
+  }
+
+  // FindString returns the text of the leftmost match for re in s.
+  // The return value is the empty string both for an empty match and for no match.
+  // To distinguish those two cases, use [Regexp.FindStringIndex] or [Regexp.FindStringSubmatch].
+  public FindString(s: string): string {
+    const bs = $.stringToBytes(s)
+    const a = this.find(null, bs, 0, 2)
+    if (a === null) {
+      return ''
+    }
+    return $.bytesToString(bs.subarray(a[0], a[1]))
+  }
+
+  // FindIndex returns the location of the leftmost match for re in b.
+  // The match itself is at b[m[0]:m[1]].
+  // The return value is nil for no match.
+  public FindIndex(b: $.Bytes | null): number[] | null {
+    const m = this.find(null, $.bytesToUint8Array(b), 0, 2)
+    if (m === null) {
+      return null
+    }
+    return m.slice(0, 2)
+  }
+
+  // FindStringIndex returns the location of the leftmost match for re in s.
+  // The match itself is at s[m[0]:m[1]].
+  // The return value is nil for no match.
+  public FindStringIndex(s: string): number[] | null {
+    const m = this.find(null, $.stringToBytes(s), 0, 2)
+    if (m === null) {
+      return null
+    }
+    return m.slice(0, 2)
+  }
+
+  // FindReaderIndex returns the location of the leftmost match for re in r.
+  // The match starts at byte index m[0] and ends just before byte index m[1].
+  // The return value is nil for no match.
+  //
+  // FindReaderIndex may read arbitrarily far from r,
+  // including reading beyond the returned match.
+  public FindReaderIndex(r: io.RuneReader): number[] | null {
+    const m = this.find(r, null, 0, 2)
+    if (m === null) {
+      return null
+    }
+    return m.slice(0, 2)
+  }
+
+  // FindSubmatch returns the first match for re in b, including submatches.
+  // The overall match is m[0], the first submatch is m[1], and so on.
+  // The return value is nil for no match.
+  public FindSubmatch(b: $.Bytes | null): ($.Bytes | null)[] | null {
+    const m = this.find(null, $.bytesToUint8Array(b), 0, this.prog.NumCap)
+    if (m === null) {
+      return null
+    }
+    const sub: ($.Bytes | null)[] = []
+    for (let i = 0; i < 1 + this.numSubexp; i++) {
+      sub.push(null)
+      if (2 * i < m.length && m[2 * i] >= 0 && b !== null) {
+        sub[i] = $.goSlice(b, m[2 * i], m[2 * i + 1], m[2 * i + 1])
+      }
+    }
+    return sub
+  }
+
+  // FindStringSubmatch returns the first match for re in s, including submatches.
+  // The overall match is s[0], the first submatch is s[1], and so on.
+  // The return value is nil for no match.
+  public FindStringSubmatch(s: string): string[] | null {
+    const bs = $.stringToBytes(s)
+    const a = this.find(null, bs, 0, this.prog.NumCap)
+    if (a === null) {
+      return null
+    }
+    const ret: string[] = []
+    for (let i = 0; i < 1 + this.numSubexp; i++) {
+      ret.push('')
+      if (2 * i < a.length && a[2 * i] >= 0) {
+        ret[i] = $.bytesToString(bs.subarray(a[2 * i], a[2 * i + 1]))
+      }
+    }
+    return ret
+  }
+
+  // FindSubmatchIndex returns the first match for re in b, including submatches.
+  // The overall match is b[m[0]:m[1]], the first submatch is b[m[2]:m[3]], and so on.
+  // The return value is nil for no match.
+  public FindSubmatchIndex(b: $.Bytes | null): number[] | null {
+    return this.pad(
+      this.find(null, $.bytesToUint8Array(b), 0, this.prog.NumCap),
+    )
+  }
+
+  // FindStringSubmatchIndex returns the first match for re in s, including submatches.
+  // The overall match is s[m[0]:m[1]], the first submatch is s[m[2]:m[3]], and so on.
+  // The return value is nil for no match.
+  public FindStringSubmatchIndex(s: string): number[] | null {
+    return this.pad(
+      this.find(null, $.stringToBytes(s), 0, this.prog.NumCap),
+    )
+  }
+
+  // FindReaderSubmatchIndex returns the first match for re in r, including submatches.
+  // The overall match is at byte index m[0] up to m[1],
+  // the first submatch is at byte index m[2] up to m[3], and so on.
+  // The return value is nil for no match.
+  //
+  // FindReaderSubmatchIndex may read arbitrarily far from r,
+  // including reading beyond the returned match.
+  public FindReaderSubmatchIndex(r: io.RuneReader): number[] | null {
+    return this.pad(this.find(r, null, 0, this.prog.NumCap))
+  }
+
+  // FindAll returns all the matches for re in b.
+  // If n >= 0, FindAll returns no more than n matches.
+  public FindAll(b: $.Bytes | null, n: number): $.Bytes[] | null {
+    const result: $.Bytes[] = []
+    this.matches($.bytesToUint8Array(b), n, 2, (m) => {
+      result.push($.goSlice(b!, m[0], m[1], m[1]))
+      return true
+    })
+    return result.length === 0 ? null : result
+  }
+
+  // FindAllString returns all the matches for re in s.
+  // If n >= 0, FindAllString returns no more than n matches.
+  public FindAllString(s: string, n: number): string[] | null {
+    const bs = $.stringToBytes(s)
+    const result: string[] = []
+    this.matches(bs, n, 2, (m) => {
+      result.push($.bytesToString(bs.subarray(m[0], m[1])))
+      return true
+    })
+    return result.length === 0 ? null : result
+  }
+
+  // FindAllIndex returns the locations of all matches for re in b.
+  // If n >= 0, FindAllIndex returns no more than n matches.
+  public FindAllIndex(b: $.Bytes | null, n: number): number[][] | null {
+    const result: number[][] = []
+    this.matches($.bytesToUint8Array(b), n, 2, (m) => {
+      result.push([m[0], m[1]])
+      return true
+    })
+    return result.length === 0 ? null : result
+  }
+
+  // FindAllStringIndex returns the locations of all matches for re in s.
+  // If n >= 0, FindAllStringIndex returns no more than n matches.
+  public FindAllStringIndex(s: string, n: number): number[][] | null {
+    const result: number[][] = []
+    this.matches($.stringToBytes(s), n, 2, (m) => {
+      result.push([m[0], m[1]])
+      return true
+    })
+    return result.length === 0 ? null : result
+  }
+
+  // FindAllSubmatch returns the locations of all matches for re in b,
+  // including submatch locations.
+  // In each returned match m, the overall match is m[0],
+  // the first submatch is m[1], and so on.
+  // If n >= 0, FindAllSubmatch returns no more than n matches.
+  public FindAllSubmatch(
+    b: $.Bytes | null,
+    n: number,
+  ): ($.Bytes | null)[][] | null {
+    const result: ($.Bytes | null)[][] = []
+    this.matches($.bytesToUint8Array(b), n, this.prog.NumCap, (m) => {
+      const sub: ($.Bytes | null)[] = []
+      for (let i = 0; i < m.length / 2; i++) {
+        sub.push(null)
+        if (m[2 * i] >= 0) {
+          sub[i] = $.goSlice(b!, m[2 * i], m[2 * i + 1], m[2 * i + 1])
+        }
+      }
+      result.push(sub)
+      return true
+    })
+    return result.length === 0 ? null : result
+  }
+
+  // FindAllStringSubmatch returns the locations of all matches for re in s,
+  // including submatch locations.
+  // In each returned match m, m[0] is the overall match,
+  // m[1] is the first submatch, and so on.
+  // If n >= 0, FindAllStringSubmatch returns no more than n matches.
+  public FindAllStringSubmatch(s: string, n: number): string[][] | null {
+    const bs = $.stringToBytes(s)
+    const result: string[][] = []
+    this.matches(bs, n, this.prog.NumCap, (m) => {
+      const sub: string[] = []
+      for (let i = 0; i < m.length / 2; i++) {
+        sub.push('')
+        if (m[2 * i] >= 0) {
+          sub[i] = $.bytesToString(bs.subarray(m[2 * i], m[2 * i + 1]))
+        }
+      }
+      result.push(sub)
+      return true
+    })
+    return result.length === 0 ? null : result
+  }
+
+  // FindAllSubmatchIndex returns the locations of all matches for re in b,
+  // including submatch locations.
+  // In each returned match m, the overall match is b[m[0]:m[1]],
+  // the first submatch is b[m[2]:m[3]], and so on.
+  // If n >= 0, FindAllSubmatchIndex returns no more than n matches.
+  public FindAllSubmatchIndex(b: $.Bytes | null, n: number): number[][] | null {
+    const result: number[][] = []
+    this.matches($.bytesToUint8Array(b), n, this.prog.NumCap, (m) => {
+      result.push(m)
+      return true
+    })
+    return result.length === 0 ? null : result
+  }
+
+  // FindAllStringSubmatchIndex returns the locations of all matches for re in s,
+  // including submatch locations.
+  // In each returned match m, the overall match is s[m[0]:m[1]],
+  // the first submatch is s[m[2]:m[3]], and so on.
+  // If n >= 0, FindAllStringSubmatchIndex returns no more than n matches.
+  public FindAllStringSubmatchIndex(s: string, n: number): number[][] | null {
+    const result: number[][] = []
+    this.matches($.stringToBytes(s), n, this.prog.NumCap, (m) => {
+      result.push(m)
+      return true
+    })
+    return result.length === 0 ? null : result
+  }
+
+  // Expand appends template to dst and returns the result; during the
+  // append, Expand replaces variables in the template with corresponding
+  // matches drawn from src. The match slice should have been returned by
+  // [Regexp.FindSubmatchIndex].
+  //
+  // In the template, a variable is denoted by a substring of the form
+  // $name or ${name}, where name is a non-empty sequence of letters,
+  // digits, and underscores. A purely numeric name like $1 refers to
+  // the submatch with the corresponding index; other names refer to
+  // capturing parentheses named with the (?P<name>...) syntax. A
+  // reference to an out of range or unmatched index or a name that is not
+  // present in the regular expression is replaced with an empty slice.
+  //
+  // In the $name form, name is taken to be as long as possible: $1x is
+  // equivalent to ${1x}, not ${1}x, and, $10 is equivalent to ${10}, not ${1}0.
+  //
+  // To insert a literal $ in the output, use $$ in the template.
+  public Expand(
+    dst: $.Bytes | null,
+    template: $.Bytes | null,
+    src: $.Bytes | null,
+    match: number[] | null,
+  ): $.Bytes | null {
+    const buf = new buffer()
+    this.expand(
+      buf,
+      $.bytesToString($.bytesToUint8Array(template)),
+      $.bytesToUint8Array(src),
+      match ?? [],
+    )
+    return appendBuffer(dst, buf)
+  }
+
+  // ExpandString is like [Regexp.Expand] but the template and source are strings.
+  // It appends to and returns a byte slice in order to give the calling
+  // code control over allocation.
+  public ExpandString(
+    dst: $.Bytes | null,
+    template: string,
+    src: string,
+    match: number[] | null,
+  ): $.Bytes | null {
+    const buf = new buffer()
+    this.expand(buf, template, $.stringToBytes(src), match ?? [])
+    return appendBuffer(dst, buf)
+  }
+
+  private expand(
+    dst: buffer,
+    template: string,
+    bsrc: Uint8Array,
+    match: number[],
+  ): void {
+    while (template.length > 0) {
+      const i = template.indexOf('$')
+      if (i < 0) {
+        break
+      }
+      dst.writeString(template.slice(0, i))
+      template = template.slice(i + 1)
+      if (template !== '' && template[0] === '$') {
+        // Treat $$ as $.
+        dst.writeString('$')
+        template = template.slice(1)
+        continue
+      }
+      const [name, num, rest, ok] = extract(template)
+      if (!ok) {
+        // Malformed; treat $ as raw text.
+        dst.writeString('$')
+        continue
+      }
+      template = rest
+      if (num >= 0) {
+        if (2 * num + 1 < match.length && match[2 * num] >= 0) {
+          dst.write(bsrc.subarray(match[2 * num], match[2 * num + 1]))
+        }
+      } else {
+        for (let i = 0; i < this.subexpNames.length; i++) {
+          if (
+            name === this.subexpNames[i] &&
+            2 * i + 1 < match.length &&
+            match[2 * i] >= 0
+          ) {
+            dst.write(bsrc.subarray(match[2 * i], match[2 * i + 1]))
+            break
+          }
+        }
+      }
+    }
+    dst.writeString(template)
+  }
+
+  // Split slices s into substrings separated by the expression and returns a slice of
+  // the substrings between those expression matches.
+  //
+  // The slice returned by this method consists of all the substrings of s
+  // not contained in the slice returned by [Regexp.FindAllString]. When called on an expression
+  // that contains no metacharacters, it is equivalent to [strings.SplitN].
+  //
+  // Example:
+  //
+  //	s := regexp.MustCompile("a*").Split("abaabaccadaaae", 5)
+  //	// s: ["", "b", "b", "c", "cadaaae"]
+  //
+  // The count determines the number of substrings to return:
+  //   - n > 0: at most n substrings; the last substring will be the unsplit remainder;
+  //   - n == 0: the result is nil (zero substrings);
+  //   - n < 0: all substrings.
+  public Split(s: string, n: number): string[] | null {
+    if (n === 0) {
+      return null
+    }
+    if (this.expr.length > 0 && s.length === 0) {
+      return ['']
+    }
+
+    const bs = $.stringToBytes(s)
+    const strings: string[] = []
+    let beg = 0
+    let end = 0
+    this.matches(bs, n, 2, (match) => {
+      if (n > 0 && strings.length >= n - 1) {
+        return false
+      }
+
+      end = match[0]
+      if (match[1] !== 0) {
+        strings.push($.bytesToString(bs.subarray(beg, end)))
+      }
+      beg = match[1]
+      return true
+    })
+
+    if (end !== bs.length) {
+      strings.push($.bytesToString(bs.subarray(beg)))
+    }
+
+    return strings
+  }
+
+  // AppendText implements [encoding.TextAppender]. The output
+  // matches that of calling the [Regexp.String] method.
+  //
+  // Note that the output is lossy in some cases: This method does not indicate
+  // POSIX regular expressions (i.e. those compiled by calling [CompilePOSIX]), or
+  // those for which the [Regexp.Longest] method has been called.
+  public AppendText(b: $.Bytes | null): [$.Bytes, $.GoError] {
+    return [$.append(b as Uint8Array, $.stringToBytes(this.String())), null]
+  }
+
+  // MarshalText implements [encoding.TextMarshaler]. The output
+  // matches that of calling the [Regexp.AppendText] method.
+  //
+  // See [Regexp.AppendText] for more information.
+  public MarshalText(): [$.Bytes, $.GoError] {
+    return this.AppendText(null)
+  }
+
+  // UnmarshalText implements [encoding.TextUnmarshaler] by calling
+  // [Compile] on the encoded value.
+  public UnmarshalText(text: $.Bytes | null): $.GoError {
+    const [newRE, err] = Compile($.bytesToString($.bytesToUint8Array(text)))
+    if (err !== null) {
+      return err
+    }
+    Object.assign(this, newRE!.clone())
+    return null
+  }
+
+  // Register this type with the runtime type system
+  static __typeInfo = $.registerStructType(
+    'regexp.Regexp',
+    new Regexp(),
+    [
+      $.method('String', [], [stringType]),
+      $.method('Copy', [], [regexpPtrType]),
+      $.method('Longest', [], []),
+      $.method('NumSubexp', [], [intType]),
+      $.method('SubexpNames', [], [stringsType]),
+      $.method('SubexpIndex', [stringType], [intType]),
+      $.method('LiteralPrefix', [], [stringType, boolType]),
+      $.method('MatchReader', ['io.RuneReader'], [boolType]),
+      $.method('MatchString', [stringType], [boolType]),
+      $.method('Match', [bytesType], [boolType]),
+      $.method('ReplaceAllString', [stringType, stringType], [stringType]),
+      $.method(
+        'ReplaceAllLiteralString',
+        [stringType, stringType],
+        [stringType],
+      ),
+      $.method(
+        'ReplaceAllStringFunc',
+        [stringType, stringFuncType],
+        [stringType],
+      ),
+      $.method('ReplaceAll', [bytesType, bytesType], [bytesType]),
+      $.method('ReplaceAllLiteral', [bytesType, bytesType], [bytesType]),
+      $.method('ReplaceAllFunc', [bytesType, bytesFuncType], [bytesType]),
+      $.method('Find', [bytesType], [bytesType]),
+      $.method('FindString', [stringType], [stringType]),
+      $.method('FindIndex', [bytesType], [intsType]),
+      $.method('FindStringIndex', [stringType], [intsType]),
+      $.method('FindReaderIndex', ['io.RuneReader'], [intsType]),
+      $.method('FindSubmatch', [bytesType], [bytesSliceType]),
+      $.method('FindStringSubmatch', [stringType], [stringsType]),
+      $.method('FindSubmatchIndex', [bytesType], [intsType]),
+      $.method('FindStringSubmatchIndex', [stringType], [intsType]),
+      $.method('FindReaderSubmatchIndex', ['io.RuneReader'], [intsType]),
+      $.method('FindAll', [bytesType, intType], [bytesSliceType]),
+      $.method('FindAllString', [stringType, intType], [stringsType]),
+      $.method('FindAllIndex', [bytesType, intType], [intsSliceType]),
+      $.method('FindAllStringIndex', [stringType, intType], [intsSliceType]),
+      $.method('FindAllSubmatch', [bytesType, intType], [bytesSlice2Type]),
+      $.method(
+        'FindAllStringSubmatch',
+        [stringType, intType],
+        [stringsSliceType],
+      ),
+      $.method('FindAllSubmatchIndex', [bytesType, intType], [intsSliceType]),
+      $.method(
+        'FindAllStringSubmatchIndex',
+        [stringType, intType],
+        [intsSliceType],
+      ),
+      $.method(
+        'Expand',
+        [bytesType, bytesType, bytesType, intsType],
+        [bytesType],
+      ),
+      $.method(
+        'ExpandString',
+        [bytesType, stringType, stringType, intsType],
+        [bytesType],
+      ),
+      $.method('Split', [stringType, intType], [stringsType]),
+      $.method('AppendText', [bytesType], [bytesType, errorType]),
+      $.method('MarshalText', [], [bytesType, errorType]),
+      $.method('UnmarshalText', [bytesType], [errorType]),
+    ],
+    Regexp,
+    [],
+  )
+}
+
+// Compile parses a regular expression and returns, if successful,
+// a [Regexp] object that can be used to match against text.
+//
+// When matching against text, the regexp returns a match that
+// begins as early as possible in the input (leftmost), and among those
+// it chooses the one that a backtracking search would have found first.
+// This so-called leftmost-first matching is the same semantics
+// that Perl, Python, and other implementations use, although this
+// package implements it without the expense of backtracking.
+// For POSIX leftmost-longest matching, see [CompilePOSIX].
+export function Compile(expr: string): [Regexp | null, $.GoError] {
+  return compile(expr, syntax.Perl, false)
+}
+
+// CompilePOSIX is like [Compile] but restricts the regular expression
+// to POSIX ERE (egrep) syntax and changes the match semantics to
+// leftmost-longest.
+//
+// That is, when matching against text, the regexp returns a match that
+// begins as early as possible in the input (leftmost), and among those
+// it chooses a match that is as long as possible.
+// This so-called leftmost-longest matching is the same semantics
+// that early regular expression implementations used and that POSIX
+// specifies.
+//
+// However, there can be multiple leftmost-longest matches, with different
+// submatch choices, and here this package diverges from POSIX.
+// Among the possible leftmost-longest matches, this package chooses
+// the one that a backtracking search would have found first, while POSIX
+// specifies that the match be chosen to maximize the length of the first
+// subexpression, then the second, and so on from left to right.
+// The POSIX rule is computationally prohibitive and not even well-defined.
+// See https://swtch.com/~rsc/regexp/regexp2.html#posix for details.
+export function CompilePOSIX(expr: string): [Regexp | null, $.GoError] {
+  return compile(expr, syntax.POSIX, true)
+}
+
+function compile(
+  expr: string,
+  mode: syntax.Flags,
+  longest: boolean,
+): [Regexp | null, $.GoError] {
+  let [re, err] = syntax.Parse(expr, mode)
+  if (err !== null) {
+    return [null, err]
+  }
+  const maxCap = re!.MaxCap()
+  const capNames = re!.CapNames()
+
+  re = re!.Simplify()
+  const [prog, err2] = syntax.Compile(re)
+  if (err2 !== null) {
+    return [null, err2]
+  }
+  const regexp = new Regexp({
+    expr,
+    prog: prog!,
+    numSubexp: maxCap,
+    subexpNames: capNames,
+    cond: prog!.StartCond(),
+    longest,
+    minInputLen: minInputLen(re),
+  })
+  ;[regexp.prefix, regexp.prefixComplete] = prog!.Prefix()
+  if (regexp.prefix !== '') {
+    regexp.prefixBytes = $.stringToBytes(regexp.prefix)
+    regexp.prefixRune = regexp.prefix.codePointAt(0)!
+  }
+
+  return [regexp, null]
+}
+
+// minInputLen walks the regexp to find the minimum length of any matchable input.
+function minInputLen(re: syntax.Regexp): number {
+  switch (re.Op) {
+    default:
+      return 0
+    case syntax.OpAnyChar:
+    case syntax.OpAnyCharNotNL:
+    case syntax.OpCharClass:
+      return 1
+    case syntax.OpLiteral: {
+      let l = 0
+      for (const r of re.Rune) {
+        if (r < 0x80 || r === 0xfffd) {
+          l++
+        } else if (r < 0x800) {
+          l += 2
+        } else if (r < 0x10000) {
+          l += 3
+        } else {
+          l += 4
+        }
+      }
+      return l
+    }
+    case syntax.OpCapture:
+    case syntax.OpPlus:
+      return minInputLen(re.Sub[0])
+    case syntax.OpRepeat:
+      return re.Min * minInputLen(re.Sub[0])
+    case syntax.OpConcat: {
+      let l = 0
+      for (const sub of re.Sub) {
+        l += minInputLen(sub)
+      }
+      return l
+    }
+    case syntax.OpAlternate: {
+      let l = minInputLen(re.Sub[0])
+      for (const sub of re.Sub.slice(1)) {
+        l = Math.min(l, minInputLen(sub))
+      }
+      return l
+    }
+  }
+}
+
+// MustCompile is like [Compile] but panics if the expression cannot be parsed.
+// It simplifies safe initialization of global variables holding compiled regular
+// expressions.
+export function MustCompile(str: string): Regexp {
+  const [regexp, err] = Compile(str)
+  if (err !== null) {
+    $.panic('regexp: Compile(' + quote(str) + '): ' + err.Error())
+  }
+  return regexp!
+}
+
+// MustCompilePOSIX is like [CompilePOSIX] but panics if the expression cannot be parsed.
+// It simplifies safe initialization of global variables holding compiled regular
+// expressions.
+export function MustCompilePOSIX(str: string): Regexp {
+  const [regexp, err] = CompilePOSIX(str)
+  if (err !== null) {
+    $.panic('regexp: CompilePOSIX(' + quote(str) + '): ' + err.Error())
+  }
+  return regexp!
+}
+
+function quote(s: string): string {
+  if (CanBackquote(s)) {
+    return '`' + s + '`'
+  }
+  return Quote(s)
+}
+
+// MatchReader reports whether the text returned by the [io.RuneReader]
+// contains any match of the regular expression pattern.
+// More complicated queries need to use [Compile] and the full [Regexp] interface.
+export function MatchReader(
+  pattern: string,
+  r: io.RuneReader,
+): [boolean, $.GoError] {
+  const [re, err] = Compile(pattern)
+  if (err !== null) {
+    return [false, err]
+  }
+  return [re!.MatchReader(r), null]
+}
+
+// MatchString reports whether the string s
+// contains any match of the regular expression pattern.
+// More complicated queries need to use [Compile] and the full [Regexp] interface.
+export function MatchString(
+  pattern: string,
+  s: string,
+): [boolean, $.GoError] {
+  const [re, err] = Compile(pattern)
+  if (err !== null) {
+    return [false, err]
+  }
+  return [re!.MatchString(s), null]
+}
+
+// Match reports whether the byte slice b
+// contains any match of the regular expression pattern.
+// More complicated queries need to use [Compile] and the full [Regexp] interface.
+export function Match(
+  pattern: string,
+  b: $.Bytes | null,
+): [boolean, $.GoError] {
+  const [re, err] = Compile(pattern)
+  if (err !== null) {
+    return [false, err]
+  }
+  return [re!.Match(b), null]
+}
+
+// QuoteMeta returns a string that escapes all regular expression metacharacters
+// inside the argument text; the returned string is a regular expression matching
+// the literal text.
+export function QuoteMeta(s: string): string {
+  let b = ''
+  for (const c of s) {
+    if (special.includes(c)) {
+      b += '\\'
+    }
+    b += c
+  }
+  return b
+}
+
+// special lists the bytes that QuoteMeta escapes.
+const special = '\\.+*?()|[]{}^$'
+
+// extract returns the name from a leading "name" or "{name}" in str.
+// (The $ has already been removed by the caller.)
+// If it is a number, extract returns num set to that number; otherwise num = -1.
+function extract(str: string): [string, number, string, boolean] {
+  if (str === '') {
+    return ['', 0, '', false]
+  }
+  let brace = false
+  if (str[0] === '{') {
+    brace = true
+    str = str.slice(1)
+  }
+  let i = 0
+  while (i < str.length) {
+    const rune = String.fromCodePoint(str.codePointAt(i)!)
+    if (!isLetter.test(rune) && !isDigit.test(rune) && rune !== '_') {
+      break
+    }
+    i += rune.length
+  }
+  if (i === 0) {
+    // empty name is not okay
+    return ['', 0, '', false]
+  }
+  const name = str.slice(0, i)
+  if (brace) {
+    if (i >= str.length || str[i] !== '}') {
+      // missing closing brace
+      return ['', 0, '', false]
+    }
+    i++
+  }
+
+  // Parse number.
+  let num = 0
+  for (let j = 0; j < name.length; j++) {
+    if (name[j] < '0' || '9' < name[j] || num >= 1e8) {
+      num = -1
+      break
+    }
+    num = num * 10 + name.charCodeAt(j) - 0x30
+  }
+  // Disallow leading zeros.
+  if (name[0] === '0' && name.length > 1) {
+    num = -1
+  }
+
+  return [name, num, str.slice(i), true]
+}
+
+const isLetter = /^\p{L}$/u
+const isDigit = /^\p{Nd}$/u
+
+// buffer accumulates the output of the replace and expand methods.
+class buffer {
+  private chunks: Uint8Array[] = []
+  private n = 0
+
+  public write(b: Uint8Array): void {
+    if (b.length > 0) {
+      this.chunks.push(b)
+      this.n += b.length
+    }
+  }
+
+  public writeString(s: string): void {
+    this.write($.stringToBytes(s))
+  }
+
+  public get length(): number {
+    return this.n
+  }
+
+  public bytes(): Uint8Array {
+    const out = new Uint8Array(this.n)
+    let off = 0
+    for (const c of this.chunks) {
+      out.set(c, off)
+      off += c.length
+    }
+    return out
+  }
+}
+
+// appendBuffer appends the contents of buf to dst like append(dst, buf...).
+function appendBuffer(dst: $.Bytes | null, buf: buffer): $.Bytes | null {
+  if (buf.length === 0) {
+    return dst
+  }
+  return $.append(dst as Uint8Array, buf.bytes())
+}
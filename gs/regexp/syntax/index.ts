@@ -0,0 +1,85 @@
+export {
+  ClassNL,
+  DotNL,
+  Error,
+  ErrInternalError,
+  ErrInvalidCharClass,
+  ErrInvalidCharRange,
+  ErrInvalidEscape,
+  ErrInvalidNamedCapture,
+  ErrInvalidPerlOp,
+  ErrInvalidRepeatOp,
+  ErrInvalidRepeatSize,
+  ErrInvalidUTF8,
+  ErrLarge,
+  ErrMissingBracket,
+  ErrMissingParen,
+  ErrMissingRepeatArgument,
+  ErrNestingDepth,
+  ErrTrailingBackslash,
+  ErrUnexpectedParen,
+  ErrorCode_String,
+  FoldCase,
+  Literal,
+  MatchNL,
+  NonGreedy,
+  OneLine,
+  POSIX,
+  Parse,
+  Perl,
+  PerlX,
+  Simple,
+  UnicodeGroups,
+  WasDollar,
+} from './parse.js'
+export type { ErrorCode, Flags } from './parse.js'
+export {
+  OpAlternate,
+  OpAnyChar,
+  OpAnyCharNotNL,
+  OpBeginLine,
+  OpBeginText,
+  OpCapture,
+  OpCharClass,
+  OpConcat,
+  OpEmptyMatch,
+  OpEndLine,
+  OpEndText,
+  OpLiteral,
+  OpNoMatch,
+  OpNoWordBoundary,
+  OpPlus,
+  OpQuest,
+  OpRepeat,
+  OpStar,
+  OpWordBoundary,
+  Op_String,
+  Regexp,
+} from './regexp.js'
+export type { Op } from './regexp.js'
+export {
+  EmptyBeginLine,
+  EmptyBeginText,
+  EmptyEndLine,
+  EmptyEndText,
+  EmptyNoWordBoundary,
+  EmptyOpContext,
+  EmptyWordBoundary,
+  Inst,
+  InstAlt,
+  InstAltMatch,
+  InstCapture,
+  InstEmptyWidth,
+  InstFail,
+  InstMatch,
+  InstNop,
+  InstOp_String,
+  InstRune,
+  InstRune1,
+  InstRuneAny,
+  InstRuneAnyNotNL,
+  IsWordChar,
+  Prog,
+} from './prog.js'
+export type { EmptyOp, InstOp } from './prog.js'
+export { Compile } from './compile.js'
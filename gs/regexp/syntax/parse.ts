@@ -0,0 +1,2180 @@
+import * as $ from '@goscript/builtin/index.js'
+
+import { charGroup, perlGroup, posixGroup } from './perl_groups.js'
+import {
+  Op,
+  OpAlternate,
+  OpAnyChar,
+  OpAnyCharNotNL,
+  OpBeginLine,
+  OpBeginText,
+  OpCapture,
+  OpCharClass,
+  OpConcat,
+  OpEmptyMatch,
+  OpEndLine,
+  OpEndText,
+  OpLiteral,
+  OpNoMatch,
+  OpNoWordBoundary,
+  OpPlus,
+  OpQuest,
+  OpRepeat,
+  OpStar,
+  OpWordBoundary,
+  Regexp,
+  opPseudo,
+} from './regexp.js'
+import { stringType } from './typeinfo.js'
+import {
+  MaxRune,
+  foldTable,
+  lookupTable,
+  maxFold,
+  minFold,
+  simpleFold,
+} from './unicode.js'
+
+// An ErrorCode describes a failure to parse a regular expression.
+export type ErrorCode = string
+
+// Unexpected error
+export const ErrInternalError: ErrorCode = 'regexp/syntax: internal error'
+
+// Parse errors
+export const ErrInvalidCharClass: ErrorCode = 'invalid character class'
+export const ErrInvalidCharRange: ErrorCode = 'invalid character class range'
+export const ErrInvalidEscape: ErrorCode = 'invalid escape sequence'
+export const ErrInvalidNamedCapture: ErrorCode = 'invalid named capture'
+export const ErrInvalidPerlOp: ErrorCode = 'invalid or unsupported Perl syntax'
+export const ErrInvalidRepeatOp: ErrorCode =
+  'invalid nested repetition operator'
+export const ErrInvalidRepeatSize: ErrorCode = 'invalid repeat count'
+export const ErrInvalidUTF8: ErrorCode = 'invalid UTF-8'
+export const ErrMissingBracket: ErrorCode = 'missing closing ]'
+export const ErrMissingParen: ErrorCode = 'missing closing )'
+export const ErrMissingRepeatArgument: ErrorCode =
+  'missing argument to repetition operator'
+export const ErrTrailingBackslash: ErrorCode =
+  'trailing backslash at end of expression'
+export const ErrUnexpectedParen: ErrorCode = 'unexpected )'
+export const ErrNestingDepth: ErrorCode = 'expression nests too deeply'
+export const ErrLarge: ErrorCode = 'expression too large'
+
+export function ErrorCode_String(e: ErrorCode): string {
+  return e
+}
+
+$.registerNamedType(
+  'regexp/syntax.ErrorCode',
+  '',
+  [$.method('String', [], [stringType])],
+  stringType,
+)
+
+// An Error describes a failure to parse a regular expression
+// and gives the offending expression.
+export class Error {
+  public Code: ErrorCode = ''
+  public Expr = ''
+
+  constructor(init?: Partial<{ Code: ErrorCode; Expr: string }>) {
+    if (init) {
+      Object.assign(this, init)
+    }
+  }
+
+  public clone(): Error {
+    return new Error({ Code: this.Code, Expr: this.Expr })
+  }
+
+  public Error(): string {
+    return 'error parsing regexp: ' + this.Code + ': `' + this.Expr + '`'
+  }
+
+  // Register this type with the runtime type system
+  static __typeInfo = $.registerStructType(
+    'regexp/syntax.Error',
+    new Error(),
+    [$.method('Error', [], [stringType])],
+    Error,
+    { Code: 'regexp/syntax.ErrorCode', Expr: stringType },
+  )
+}
+
+// Flags control the behavior of the parser and record information about regexp context.
+export type Flags = number
+
+export const FoldCase: Flags = 1 << 0 // case-insensitive match
+export const Literal: Flags = 1 << 1 // treat pattern as literal string
+export const ClassNL: Flags = 1 << 2 // allow character classes like [^a-z] and [[:space:]] to match newline
+export const DotNL: Flags = 1 << 3 // allow . to match newline
+export const OneLine: Flags = 1 << 4 // treat ^ and $ as only matching at beginning and end of text
+export const NonGreedy: Flags = 1 << 5 // make repetition operators default to non-greedy
+export const PerlX: Flags = 1 << 6 // allow Perl extensions
+export const UnicodeGroups: Flags = 1 << 7 // allow \p{Han}, \P{Han} for Unicode group and negation
+export const WasDollar: Flags = 1 << 8 // regexp OpEndText was $, not \z
+export const Simple: Flags = 1 << 9 // regexp contains no counted repetition
+
+export const MatchNL: Flags = ClassNL | DotNL
+
+export const Perl: Flags = ClassNL | OneLine | PerlX | UnicodeGroups // as close to Perl as possible
+export const POSIX: Flags = 0 // POSIX syntax
+
+$.registerNamedType('regexp/syntax.Flags', 0, [], {
+  kind: $.TypeKind.Basic,
+  name: 'uint16',
+})
+
+// Pseudo-ops for parsing stack.
+const opLeftParen: Op = opPseudo
+const opVerticalBar: Op = opPseudo + 1
+
+// maxHeight is the maximum height of a regexp parse tree.
+// It is somewhat arbitrarily chosen, but the idea is to be large enough
+// that no one will actually hit in real use but at the same time small enough
+// that recursion on the Regexp tree will not overflow the stack.
+const maxHeight = 1000
+
+// maxSize is the maximum size of a compiled regexp in Insts.
+// It too is somewhat arbitrarily chosen, but the idea is to be large enough
+// to allow significant regexps while at the same time small enough that
+// the compiled form will not take up too much memory.
+const instSize = 5 * 8 // byte, 2 uint32, slice is 5 64-bit words
+const maxSize = Math.floor((128 << 20) / instSize)
+
+// maxRunes is the maximum number of runes allowed in a regexp tree
+// counting the runes in all the nodes.
+const runeSize = 4 // rune is int32
+const maxRunes = (128 << 20) / runeSize
+
+// limitPanic carries ErrLarge or ErrNestingDepth out of the parser,
+// like the panics of Go's parser.
+class limitPanic {
+  constructor(public code: ErrorCode) {}
+}
+
+class parser {
+  public flags: Flags = 0 // parse mode flags
+  public stack: Regexp[] = [] // stack of parsed expressions
+  public numCap = 0 // number of capturing groups seen
+  public wholeRegexp = ''
+  public numRegexp = 0 // number of regexps allocated
+  public numRunes = 0 // number of runes in char classes
+  public repeats = 0 // product of all repetitions seen
+  public height: Map<Regexp, number> | null = null // regexp height, for height limit check
+  public size: Map<Regexp, number> | null = null // regexp compiled size, for size limit check
+
+  public newRegexp(op: Op): Regexp {
+    this.numRegexp++
+    return new Regexp({ Op: op })
+  }
+
+  public reuse(re: Regexp): void {
+    if (this.height !== null) {
+      this.height.delete(re)
+    }
+  }
+
+  public checkLimits(re: Regexp): void {
+    if (this.numRunes > maxRunes) {
+      throw new limitPanic(ErrLarge)
+    }
+    this.checkSize(re)
+    this.checkHeight(re)
+  }
+
+  public checkSize(re: Regexp): void {
+    if (this.size === null) {
+      // We haven't started tracking size yet.
+      // Do a relatively cheap check to see if we need to start.
+      // Maintain the product of all the repeats we've seen
+      // and don't track if the total number of regexp nodes
+      // we've seen times the repeat product is in budget.
+      if (this.repeats === 0) {
+        this.repeats = 1
+      }
+      if (re.Op === OpRepeat) {
+        let n = re.Max
+        if (n === -1) {
+          n = re.Min
+        }
+        if (n <= 0) {
+          n = 1
+        }
+        if (n > maxSize / this.repeats) {
+          this.repeats = maxSize
+        } else {
+          this.repeats *= n
+        }
+      }
+      if (this.numRegexp < maxSize / this.repeats) {
+        return
+      }
+
+      // We need to start tracking size.
+      // Make the map and belatedly populate it
+      // with info about everything we've constructed so far.
+      this.size = new Map()
+      for (const re of this.stack) {
+        this.checkSize(re)
+      }
+    }
+
+    if (this.calcSize(re, true) > maxSize) {
+      throw new limitPanic(ErrLarge)
+    }
+  }
+
+  public calcSize(re: Regexp, force: boolean): number {
+    if (!force) {
+      const size = this.size!.get(re)
+      if (size !== undefined) {
+        return size
+      }
+    }
+
+    let size = 0
+    switch (re.Op) {
+      case OpLiteral:
+        size = re.Rune.length
+        break
+      case OpCapture:
+      case OpStar:
+        // star can be 1+ or 2+; assume 2 pessimistically
+        size = 2 + this.calcSize(re.Sub[0], false)
+        break
+      case OpPlus:
+      case OpQuest:
+        size = 1 + this.calcSize(re.Sub[0], false)
+        break
+      case OpConcat:
+        for (const sub of re.Sub) {
+          size += this.calcSize(sub, false)
+        }
+        break
+      case OpAlternate:
+        for (const sub of re.Sub) {
+          size += this.calcSize(sub, false)
+        }
+        if (re.Sub.length > 1) {
+          size += re.Sub.length - 1
+        }
+        break
+      case OpRepeat: {
+        const sub = this.calcSize(re.Sub[0], false)
+        if (re.Max === -1) {
+          if (re.Min === 0) {
+            size = 2 + sub // x*
+          } else {
+            size = 1 + re.Min * sub // xxx+
+          }
+          break
+        }
+        // x{2,5} = xx(x(x(x)?)?)?
+        size = re.Max * sub + (re.Max - re.Min)
+        break
+      }
+    }
+
+    size = Math.max(1, size)
+    this.size!.set(re, size)
+    return size
+  }
+
+  public checkHeight(re: Regexp): void {
+    if (this.numRegexp < maxHeight) {
+      return
+    }
+    if (this.height === null) {
+      this.height = new Map()
+      for (const re of this.stack) {
+        this.checkHeight(re)
+      }
+    }
+    if (this.calcHeight(re, true) > maxHeight) {
+      throw new limitPanic(ErrNestingDepth)
+    }
+  }
+
+  public calcHeight(re: Regexp, force: boolean): number {
+    if (!force) {
+      const h = this.height!.get(re)
+      if (h !== undefined) {
+        return h
+      }
+    }
+    let h = 1
+    for (const sub of re.Sub) {
+      const hsub = this.calcHeight(sub, false)
+      if (h < 1 + hsub) {
+        h = 1 + hsub
+      }
+    }
+    this.height!.set(re, h)
+    return h
+  }
+
+  // Parse stack manipulation.
+
+  // push pushes the regexp re onto the parse stack and returns the regexp.
+  public push(re: Regexp): Regexp | null {
+    this.numRunes += re.Rune.length
+    const r = re.Rune
+    if (re.Op === OpCharClass && r.length === 2 && r[0] === r[1]) {
+      // Single rune.
+      if (this.maybeConcat(r[0], this.flags & ~FoldCase)) {
+        return null
+      }
+      re.Op = OpLiteral
+      re.Rune = [r[0]]
+      re.Flags = this.flags & ~FoldCase
+    } else if (
+      (re.Op === OpCharClass &&
+        r.length === 4 &&
+        r[0] === r[1] &&
+        r[2] === r[3] &&
+        simpleFold(r[0]) === r[2] &&
+        simpleFold(r[2]) === r[0]) ||
+      (re.Op === OpCharClass &&
+        r.length === 2 &&
+        r[0] + 1 === r[1] &&
+        simpleFold(r[0]) === r[1] &&
+        simpleFold(r[1]) === r[0])
+    ) {
+      // Case-insensitive rune like [Aa] or [Δδ].
+      if (this.maybeConcat(r[0], this.flags | FoldCase)) {
+        return null
+      }
+
+      // Rewrite as (case-insensitive) literal.
+      re.Op = OpLiteral
+      re.Rune = [r[0]]
+      re.Flags = this.flags | FoldCase
+    } else {
+      // Incremental concatenation.
+      this.maybeConcat(-1, 0)
+    }
+
+    this.stack.push(re)
+    this.checkLimits(re)
+    return re
+  }
+
+  // maybeConcat implements incremental concatenation
+  // of literal runes into string nodes. The parser calls this
+  // before each push, so only the top fragment of the stack
+  // might need processing. Since this is called before a push,
+  // the topmost literal is no longer subject to operators like *
+  // (Otherwise ab* would turn into (ab)*.)
+  // If r >= 0 and there's a node left over, maybeConcat uses it
+  // to push r with the given flags.
+  // maybeConcat reports whether r was pushed.
+  public maybeConcat(r: number, flags: Flags): boolean {
+    const n = this.stack.length
+    if (n < 2) {
+      return false
+    }
+
+    const re1 = this.stack[n - 1]
+    const re2 = this.stack[n - 2]
+    if (
+      re1.Op !== OpLiteral ||
+      re2.Op !== OpLiteral ||
+      (re1.Flags & FoldCase) !== (re2.Flags & FoldCase)
+    ) {
+      return false
+    }
+
+    // Push re1 into re2.
+    re2.Rune = re2.Rune.concat(re1.Rune)
+
+    // Reuse re1 if possible.
+    if (r >= 0) {
+      re1.Rune = [r]
+      re1.Flags = flags
+      return true
+    }
+
+    this.stack.pop()
+    this.reuse(re1)
+    return false // did not push r
+  }
+
+  // literal pushes a literal regexp for the rune r on the stack.
+  public literal(r: number): void {
+    const re = this.newRegexp(OpLiteral)
+    re.Flags = this.flags
+    if ((this.flags & FoldCase) !== 0) {
+      r = minFoldRune(r)
+    }
+    re.Rune = [r]
+    this.push(re)
+  }
+
+  // op pushes a regexp with the given op onto the stack
+  // and returns that regexp.
+  public op(op: Op): Regexp {
+    const re = this.newRegexp(op)
+    re.Flags = this.flags
+    return this.push(re)!
+  }
+
+  // repeat replaces the top stack element with itself repeated according to op, min, max.
+  // before is the regexp suffix starting at the repetition operator.
+  // after is the regexp suffix following after the repetition operator.
+  // repeat returns an updated 'after' and an error, if any.
+  public repeat(
+    op: Op,
+    min: number,
+    max: number,
+    before: string,
+    after: string,
+    lastRepeat: string,
+  ): [string, $.GoError] {
+    let flags = this.flags
+    if ((this.flags & PerlX) !== 0) {
+      if (after.length > 0 && after[0] === '?') {
+        after = after.slice(1)
+        flags ^= NonGreedy
+      }
+      if (lastRepeat !== '') {
+        // In Perl it is not allowed to stack repetition operators:
+        // a** is a syntax error, not a doubled star, and a++ means
+        // something else entirely, which we don't support!
+        return [
+          '',
+          new Error({
+            Code: ErrInvalidRepeatOp,
+            Expr: lastRepeat.slice(0, lastRepeat.length - after.length),
+          }),
+        ]
+      }
+    }
+    const n = this.stack.length
+    if (n === 0) {
+      return [
+        '',
+        new Error({
+          Code: ErrMissingRepeatArgument,
+          Expr: before.slice(0, before.length - after.length),
+        }),
+      ]
+    }
+    const sub = this.stack[n - 1]
+    if (sub.Op >= opPseudo) {
+      return [
+        '',
+        new Error({
+          Code: ErrMissingRepeatArgument,
+          Expr: before.slice(0, before.length - after.length),
+        }),
+      ]
+    }
+
+    const re = this.newRegexp(op)
+    re.Min = min
+    re.Max = max
+    re.Flags = flags
+    re.Sub = [sub]
+    this.stack[n - 1] = re
+    this.checkLimits(re)
+
+    if (
+      op === OpRepeat &&
+      (min >= 2 || max >= 2) &&
+      !repeatIsValid(re, 1000)
+    ) {
+      return [
+        '',
+        new Error({
+          Code: ErrInvalidRepeatSize,
+          Expr: before.slice(0, before.length - after.length),
+        }),
+      ]
+    }
+
+    return [after, null]
+  }
+
+  // concat replaces the top of the stack (above the topmost '|' or '(') with its concatenation.
+  public concat(): Regexp | null {
+    this.maybeConcat(-1, 0)
+
+    // Scan down to find pseudo-operator | or (.
+    let i = this.stack.length
+    while (i > 0 && this.stack[i - 1].Op < opPseudo) {
+      i--
+    }
+    const subs = this.stack.slice(i)
+    this.stack.length = i
+
+    // Empty concatenation is special case.
+    if (subs.length === 0) {
+      return this.push(this.newRegexp(OpEmptyMatch))
+    }
+
+    return this.push(this.collapse(subs, OpConcat))
+  }
+
+  // alternate replaces the top of the stack (above the topmost '(') with its alternation.
+  public alternate(): Regexp | null {
+    // Scan down to find pseudo-operator (.
+    // There are no | above (.
+    let i = this.stack.length
+    while (i > 0 && this.stack[i - 1].Op < opPseudo) {
+      i--
+    }
+    const subs = this.stack.slice(i)
+    this.stack.length = i
+
+    // Make sure top class is clean.
+    // All the others already are (see swapVerticalBar).
+    if (subs.length > 0) {
+      cleanAlt(subs[subs.length - 1])
+    }
+
+    // Empty alternate is special case
+    // (shouldn't happen but easy to handle).
+    if (subs.length === 0) {
+      return this.push(this.newRegexp(OpNoMatch))
+    }
+
+    return this.push(this.collapse(subs, OpAlternate))
+  }
+
+  // collapse returns the result of applying op to sub.
+  // If sub contains op nodes, they all get hoisted up
+  // so that there is never a concat of a concat or an
+  // alternate of an alternate.
+  public collapse(subs: Regexp[], op: Op): Regexp {
+    if (subs.length === 1) {
+      return subs[0]
+    }
+    let re = this.newRegexp(op)
+    for (const sub of subs) {
+      if (sub.Op === op) {
+        re.Sub.push(...sub.Sub)
+        this.reuse(sub)
+      } else {
+        re.Sub.push(sub)
+      }
+    }
+    if (op === OpAlternate) {
+      re.Sub = this.factor(re.Sub)
+      if (re.Sub.length === 1) {
+        const old = re
+        re = re.Sub[0]
+        this.reuse(old)
+      }
+    }
+    return re
+  }
+
+  // factor factors common prefixes from the alternation list sub.
+  // It returns a replacement list and passes any removed Regexps
+  // to p.reuse.
+  //
+  // For example,
+  //
+  //	ABC|ABD|AEF|BCX|BCY
+  //
+  // simplifies by literal prefix extraction to
+  //
+  //	A(B(C|D)|EF)|BC(X|Y)
+  //
+  // which simplifies by character class introduction to
+  //
+  //	A(B[CD]|EF)|BC[XY]
+  public factor(sub: Regexp[]): Regexp[] {
+    if (sub.length < 2) {
+      return sub
+    }
+
+    // Round 1: Factor out common literal prefixes.
+    let str: number[] = []
+    let strflags: Flags = 0
+    let start = 0
+    let out: Regexp[] = []
+    for (let i = 0; i <= sub.length; i++) {
+      // Invariant: sub[start:i] consists of regexps that all begin
+      // with str as modified by strflags.
+      let istr: number[] = []
+      let iflags: Flags = 0
+      if (i < sub.length) {
+        ;[istr, iflags] = this.leadingString(sub[i])
+        if (iflags === strflags) {
+          let same = 0
+          while (
+            same < str.length &&
+            same < istr.length &&
+            str[same] === istr[same]
+          ) {
+            same++
+          }
+          if (same > 0) {
+            // Matches at least one rune in current range.
+            // Keep going around.
+            str = str.slice(0, same)
+            continue
+          }
+        }
+      }
+
+      // Found end of a run with common leading literal string:
+      // sub[start:i] all begin with str[:len(str)], but sub[i]
+      // does not even begin with str[0].
+      //
+      // Factor out common string and append factored expression to out.
+      if (i === start) {
+        // Nothing to do - run of length 0.
+      } else if (i === start + 1) {
+        // Just one: don't bother factoring.
+        out.push(sub[start])
+      } else {
+        // Construct factored form: prefix(suffix1|suffix2|...)
+        const prefix = this.newRegexp(OpLiteral)
+        prefix.Flags = strflags
+        prefix.Rune = str.slice()
+
+        for (let j = start; j < i; j++) {
+          sub[j] = this.removeLeadingString(sub[j], str.length)
+          this.checkLimits(sub[j])
+        }
+        const suffix = this.collapse(sub.slice(start, i), OpAlternate) // recurse
+
+        const re = this.newRegexp(OpConcat)
+        re.Sub = [prefix, suffix]
+        out.push(re)
+      }
+
+      // Prepare for next iteration.
+      start = i
+      str = istr
+      strflags = iflags
+    }
+    sub = out
+
+    // Round 2: Factor out common simple prefixes,
+    // just the first piece of each concatenation.
+    // This will be good enough a lot of the time.
+    //
+    // Complex subexpressions (e.g. involving quantifiers)
+    // are not safe to factor because that collapses their
+    // distinct paths through the automaton, which affects
+    // correctness in some cases.
+    start = 0
+    out = []
+    let first: Regexp | null = null
+    for (let i = 0; i <= sub.length; i++) {
+      // Invariant: sub[start:i] consists of regexps that all begin with ifirst.
+      let ifirst: Regexp | null = null
+      if (i < sub.length) {
+        ifirst = this.leadingRegexp(sub[i])
+        if (
+          first !== null &&
+          first.Equal(ifirst) &&
+          // first must be a character class OR a fixed repeat of a character class.
+          (isCharClass(first) ||
+            (first.Op === OpRepeat &&
+              first.Min === first.Max &&
+              isCharClass(first.Sub[0])))
+        ) {
+          continue
+        }
+      }
+
+      // Found end of a run with common leading regexp:
+      // sub[start:i] all begin with first but sub[i] does not.
+      //
+      // Factor out common regexp and append factored expression to out.
+      if (i === start) {
+        // Nothing to do - run of length 0.
+      } else if (i === start + 1) {
+        // Just one: don't bother factoring.
+        out.push(sub[start])
+      } else {
+        // Construct factored form: prefix(suffix1|suffix2|...)
+        const prefix = first!
+        for (let j = start; j < i; j++) {
+          const reuse = j !== start // prefix came from sub[start]
+          sub[j] = this.removeLeadingRegexp(sub[j], reuse)
+          this.checkLimits(sub[j])
+        }
+        const suffix = this.collapse(sub.slice(start, i), OpAlternate) // recurse
+
+        const re = this.newRegexp(OpConcat)
+        re.Sub = [prefix, suffix]
+        out.push(re)
+      }
+
+      // Prepare for next iteration.
+      start = i
+      first = ifirst
+    }
+    sub = out
+
+    // Round 3: Collapse runs of single literals into character classes.
+    start = 0
+    out = []
+    for (let i = 0; i <= sub.length; i++) {
+      // Invariant: sub[start:i] consists of regexps that are either
+      // literal runes or character classes.
+      if (i < sub.length && isCharClass(sub[i])) {
+        continue
+      }
+
+      // sub[i] is not a char or char class;
+      // emit char class for sub[start:i]...
+      if (i === start) {
+        // Nothing to do - run of length 0.
+      } else if (i === start + 1) {
+        out.push(sub[start])
+      } else {
+        // Make new char class.
+        // Start with most complex regexp in sub[start].
+        let max = start
+        for (let j = start + 1; j < i; j++) {
+          if (
+            sub[max].Op < sub[j].Op ||
+            (sub[max].Op === sub[j].Op &&
+              sub[max].Rune.length < sub[j].Rune.length)
+          ) {
+            max = j
+          }
+        }
+        ;[sub[start], sub[max]] = [sub[max], sub[start]]
+
+        for (let j = start + 1; j < i; j++) {
+          mergeCharClass(sub[start], sub[j])
+          this.reuse(sub[j])
+        }
+        cleanAlt(sub[start])
+        out.push(sub[start])
+      }
+
+      // ... and then emit sub[i].
+      if (i < sub.length) {
+        out.push(sub[i])
+      }
+      start = i + 1
+    }
+    sub = out
+
+    // Round 4: Collapse runs of empty matches into a single empty match.
+    out = []
+    for (let i = 0; i < sub.length; i++) {
+      if (
+        i + 1 < sub.length &&
+        sub[i].Op === OpEmptyMatch &&
+        sub[i + 1].Op === OpEmptyMatch
+      ) {
+        continue
+      }
+      out.push(sub[i])
+    }
+    return out
+  }
+
+  // leadingString returns the leading literal string that re begins with.
+  // The string refers to storage in re or its children.
+  public leadingString(re: Regexp): [number[], Flags] {
+    if (re.Op === OpConcat && re.Sub.length > 0) {
+      re = re.Sub[0]
+    }
+    if (re.Op !== OpLiteral) {
+      return [[], 0]
+    }
+    return [re.Rune, re.Flags & FoldCase]
+  }
+
+  // removeLeadingString removes the first n leading runes
+  // from the beginning of re. It returns the replacement for re.
+  public removeLeadingString(re: Regexp, n: number): Regexp {
+    if (re.Op === OpConcat && re.Sub.length > 0) {
+      // Removing a leading string in a concatenation
+      // might simplify the concatenation.
+      const sub = this.removeLeadingString(re.Sub[0], n)
+      re.Sub[0] = sub
+      if (sub.Op === OpEmptyMatch) {
+        this.reuse(sub)
+        switch (re.Sub.length) {
+          case 0:
+          case 1:
+            // Impossible but handle.
+            re.Op = OpEmptyMatch
+            re.Sub = []
+            break
+          case 2: {
+            const old = re
+            re = re.Sub[1]
+            this.reuse(old)
+            break
+          }
+          default:
+            re.Sub = re.Sub.slice(1)
+        }
+      }
+      return re
+    }
+
+    if (re.Op === OpLiteral) {
+      re.Rune = re.Rune.slice(n)
+      if (re.Rune.length === 0) {
+        re.Op = OpEmptyMatch
+      }
+    }
+    return re
+  }
+
+  // leadingRegexp returns the leading regexp that re begins with.
+  // The regexp refers to storage in re or its children.
+  public leadingRegexp(re: Regexp): Regexp | null {
+    if (re.Op === OpEmptyMatch) {
+      return null
+    }
+    if (re.Op === OpConcat && re.Sub.length > 0) {
+      const sub = re.Sub[0]
+      if (sub.Op === OpEmptyMatch) {
+        return null
+      }
+      return sub
+    }
+    return re
+  }
+
+  // removeLeadingRegexp removes the leading regexp in re.
+  // It returns the replacement for re.
+  // If reuse is true, it passes the removed regexp (if no longer needed) to p.reuse.
+  public removeLeadingRegexp(re: Regexp, reuse: boolean): Regexp {
+    if (re.Op === OpConcat && re.Sub.length > 0) {
+      if (reuse) {
+        this.reuse(re.Sub[0])
+      }
+      re.Sub = re.Sub.slice(1)
+      switch (re.Sub.length) {
+        case 0:
+          re.Op = OpEmptyMatch
+          re.Sub = []
+          break
+        case 1: {
+          const old = re
+          re = re.Sub[0]
+          this.reuse(old)
+          break
+        }
+      }
+      return re
+    }
+    if (reuse) {
+      this.reuse(re)
+    }
+    return this.newRegexp(OpEmptyMatch)
+  }
+
+  // parseRepeat parses {min} (max=min) or {min,} (max=-1) or {min,max}.
+  // If s is not of that form, it returns ok == false.
+  // If s has the right form but the values are too big, it returns min == -1, ok == true.
+  public parseRepeat(s: string): [number, number, string, boolean] {
+    const fail: [number, number, string, boolean] = [0, 0, '', false]
+    if (s === '' || s[0] !== '{') {
+      return fail
+    }
+    s = s.slice(1)
+    let min: number
+    let max: number
+    let ok1: boolean
+    ;[min, s, ok1] = this.parseInt(s)
+    if (!ok1) {
+      return fail
+    }
+    if (s === '') {
+      return fail
+    }
+    if (s[0] !== ',') {
+      max = min
+    } else {
+      s = s.slice(1)
+      if (s === '') {
+        return fail
+      }
+      if (s[0] === '}') {
+        max = -1
+      } else {
+        ;[max, s, ok1] = this.parseInt(s)
+        if (!ok1) {
+          return fail
+        } else if (max < 0) {
+          // parseInt found too big a number
+          min = -1
+        }
+      }
+    }
+    if (s === '' || s[0] !== '}') {
+      return fail
+    }
+    return [min, max, s.slice(1), true]
+  }
+
+  // parsePerlFlags parses a Perl flag setting or non-capturing group or both,
+  // like (?i) or (?: or (?i:.  It removes the prefix from s and updates the parse state.
+  // The caller must have ensured that s begins with "(?".
+  public parsePerlFlags(s: string): [string, $.GoError] {
+    let t = s
+
+    // Check for named captures, first introduced in Python's regexp library.
+    // As usual, there are three slightly different syntaxes:
+    //
+    //   (?P<name>expr)   the original, introduced by Python
+    //   (?<name>expr)    the .NET alteration, adopted by Perl 5.10
+    //   (?'name'expr)    another .NET alteration, adopted by Perl 5.10
+    //
+    // Perl 5.10 gave in and implemented the Python version too,
+    // but they claim that the last two are the preferred forms.
+    // PCRE and languages based on it (specifically, PHP and Ruby)
+    // support all three as well. EcmaScript 4 uses only the Python form.
+    //
+    // In both the open source world (via Code Search) and the
+    // Google source tree, (?P<expr>name) and (?<expr>name) are the
+    // dominant forms of named captures and both are supported.
+    const startsWithP = t.length > 4 && t[2] === 'P' && t[3] === '<'
+    const startsWithName = t.length > 3 && t[2] === '<'
+
+    if (startsWithP || startsWithName) {
+      // position of expr start
+      const exprStartPos = startsWithName ? 3 : 4
+
+      // Pull out name.
+      const end = t.indexOf('>')
+      if (end < 0) {
+        const err = checkUTF8(t)
+        if (err !== null) {
+          return ['', err]
+        }
+        return ['', new Error({ Code: ErrInvalidNamedCapture, Expr: s })]
+      }
+
+      const capture = t.slice(0, end + 1) // "(?P<name>" or "(?<name>"
+      const name = t.slice(exprStartPos, end) // "name"
+      const err = checkUTF8(name)
+      if (err !== null) {
+        return ['', err]
+      }
+      if (!isValidCaptureName(name)) {
+        return [
+          '',
+          new Error({ Code: ErrInvalidNamedCapture, Expr: capture }),
+        ]
+      }
+
+      // Like ordinary capture, but named.
+      this.numCap++
+      const re = this.op(opLeftParen)
+      re.Cap = this.numCap
+      re.Name = name
+      return [t.slice(end + 1), null]
+    }
+
+    // Non-capturing group. Might also twiddle Perl flags.
+    t = t.slice(2) // skip (?
+    let flags = this.flags
+    let sign = +1
+    let sawFlag = false
+    Loop: while (t !== '') {
+      const [c, rest, err] = nextRune(t)
+      if (err !== null) {
+        return ['', err]
+      }
+      t = rest
+      switch (String.fromCodePoint(c)) {
+        default:
+          break Loop
+
+        // Flags.
+        case 'i':
+          flags |= FoldCase
+          sawFlag = true
+          break
+        case 'm':
+          flags &= ~OneLine
+          sawFlag = true
+          break
+        case 's':
+          flags |= DotNL
+          sawFlag = true
+          break
+        case 'U':
+          flags |= NonGreedy
+          sawFlag = true
+          break
+
+        // Switch to negation.
+        case '-':
+          if (sign < 0) {
+            break Loop
+          }
+          sign = -1
+          // Invert flags so that | above turn into &^ and vice versa.
+          // We'll invert flags again before using it below.
+          flags = ~flags
+          sawFlag = false
+          break
+
+        // End of flags, starting group or not.
+        case ':':
+        case ')':
+          if (sign < 0) {
+            if (!sawFlag) {
+              break Loop
+            }
+            flags = ~flags
+          }
+          if (c === 0x3a) {
+            // Open new group
+            this.op(opLeftParen)
+          }
+          this.flags = flags
+          return [t, null]
+      }
+    }
+
+    return [
+      '',
+      new Error({
+        Code: ErrInvalidPerlOp,
+        Expr: s.slice(0, s.length - t.length),
+      }),
+    ]
+  }
+
+  // parseInt parses a decimal integer.
+  public parseInt(s: string): [number, string, boolean] {
+    if (s === '' || s[0] < '0' || '9' < s[0]) {
+      return [0, '', false]
+    }
+    // Disallow leading zeros.
+    if (s.length >= 2 && s[0] === '0' && '0' <= s[1] && s[1] <= '9') {
+      return [0, '', false]
+    }
+    let i = 0
+    while (i < s.length && '0' <= s[i] && s[i] <= '9') {
+      i++
+    }
+    // Have digits, compute value.
+    let n = 0
+    for (let j = 0; j < i; j++) {
+      // Avoid overflow.
+      if (n >= 1e8) {
+        n = -1
+        break
+      }
+      n = n * 10 + s.charCodeAt(j) - 0x30
+    }
+    return [n, s.slice(i), true]
+  }
+
+  // parseVerticalBar handles a | in the input.
+  public parseVerticalBar(): void {
+    this.concat()
+
+    // The concatenation we just parsed is on top of the stack.
+    // If it sits above an opVerticalBar, swap it below
+    // (things below an opVerticalBar become an alternation).
+    // Otherwise, push a new vertical bar.
+    if (!this.swapVerticalBar()) {
+      this.op(opVerticalBar)
+    }
+  }
+
+  // If the top of the stack is an element followed by an opVerticalBar
+  // swapVerticalBar swaps the two and returns true.
+  // Otherwise it returns false.
+  public swapVerticalBar(): boolean {
+    const stack = this.stack
+    // If above and below vertical bar are literal or char class,
+    // can merge into a single char class.
+    const n = stack.length
+    if (
+      n >= 3 &&
+      stack[n - 2].Op === opVerticalBar &&
+      isCharClass(stack[n - 1]) &&
+      isCharClass(stack[n - 3])
+    ) {
+      let re1 = stack[n - 1]
+      let re3 = stack[n - 3]
+      // Make re3 the more complex of the two.
+      if (re1.Op > re3.Op) {
+        ;[re1, re3] = [re3, re1]
+        stack[n - 3] = re3
+      }
+      mergeCharClass(re3, re1)
+      this.reuse(re1)
+      stack.pop()
+      return true
+    }
+
+    if (n >= 2) {
+      const re1 = stack[n - 1]
+      const re2 = stack[n - 2]
+      if (re2.Op === opVerticalBar) {
+        if (n >= 3) {
+          // Now out of reach.
+          // Clean opportunistically.
+          cleanAlt(stack[n - 3])
+        }
+        stack[n - 2] = re1
+        stack[n - 1] = re2
+        return true
+      }
+    }
+    return false
+  }
+
+  // parseRightParen handles a ) in the input.
+  public parseRightParen(): $.GoError {
+    this.concat()
+    if (this.swapVerticalBar()) {
+      // pop vertical bar
+      this.stack.pop()
+    }
+    this.alternate()
+
+    const n = this.stack.length
+    if (n < 2) {
+      return new Error({ Code: ErrUnexpectedParen, Expr: this.wholeRegexp })
+    }
+    const re1 = this.stack[n - 1]
+    const re2 = this.stack[n - 2]
+    this.stack.length = n - 2
+    if (re2.Op !== opLeftParen) {
+      return new Error({ Code: ErrUnexpectedParen, Expr: this.wholeRegexp })
+    }
+    // Restore flags at time of paren.
+    this.flags = re2.Flags
+    if (re2.Cap === 0) {
+      // Just for grouping.
+      this.push(re1)
+    } else {
+      re2.Op = OpCapture
+      re2.Sub = [re1]
+      this.push(re2)
+    }
+    return null
+  }
+
+  // parseEscape parses an escape sequence at the beginning of s
+  // and returns the rune.
+  public parseEscape(s: string): [number, string, $.GoError] {
+    let t = s.slice(1)
+    if (t === '') {
+      return [0, '', new Error({ Code: ErrTrailingBackslash, Expr: '' })]
+    }
+    let c: number
+    let err: $.GoError
+    ;[c, t, err] = nextRune(t)
+    if (err !== null) {
+      return [0, '', err]
+    }
+
+    Switch: switch (String.fromCodePoint(c)) {
+      default:
+        if (c < 0x80 && !isalnum(c)) {
+          // Escaped non-word characters are always themselves.
+          // PCRE is not quite so rigorous: it accepts things like
+          // \q, but we don't. We once rejected \_, but too many
+          // programs and people insist on using it, so allow \_.
+          return [c, t, null]
+        }
+        break
+
+      // Octal escapes.
+      case '1':
+      case '2':
+      case '3':
+      case '4':
+      case '5':
+      case '6':
+      case '7':
+        // Single non-zero digit is a backreference; not supported
+        if (t === '' || t[0] < '0' || t[0] > '7') {
+          break
+        }
+      // falls through
+      case '0': {
+        // Consume up to three octal digits; already have one.
+        let r = c - 0x30
+        for (let i = 1; i < 3; i++) {
+          if (t === '' || t[0] < '0' || t[0] > '7') {
+            break
+          }
+          r = r * 8 + t.charCodeAt(0) - 0x30
+          t = t.slice(1)
+        }
+        return [r, t, null]
+      }
+
+      // Hexadecimal escapes.
+      case 'x': {
+        if (t === '') {
+          break
+        }
+        ;[c, t, err] = nextRune(t)
+        if (err !== null) {
+          return [0, '', err]
+        }
+        if (c === 0x7b) {
+          // '{': any number of digits in braces.
+          // Perl accepts any text at all; it ignores all text
+          // after the first non-hex digit. We require only hex digits,
+          // and at least one.
+          let nhex = 0
+          let r = 0
+          for (;;) {
+            if (t === '') {
+              break Switch
+            }
+            ;[c, t, err] = nextRune(t)
+            if (err !== null) {
+              return [0, '', err]
+            }
+            if (c === 0x7d) {
+              break
+            }
+            const v = unhex(c)
+            if (v < 0) {
+              break Switch
+            }
+            r = r * 16 + v
+            if (r > MaxRune) {
+              break Switch
+            }
+            nhex++
+          }
+          if (nhex === 0) {
+            break Switch
+          }
+          return [r, t, null]
+        }
+
+        // Easy case: two hex digits.
+        const x = unhex(c)
+        ;[c, t, err] = nextRune(t)
+        if (err !== null) {
+          return [0, '', err]
+        }
+        const y = unhex(c)
+        if (x < 0 || y < 0) {
+          break
+        }
+        return [x * 16 + y, t, null]
+      }
+
+      // C escapes. There is no case 'b', to avoid misparsing
+      // the Perl word-boundary \b as the C backspace \b
+      // when in POSIX mode. In Perl, /\b/ means word-boundary
+      // but /[\b]/ means backspace. We don't support that.
+      // If you want a backspace, embed a literal backspace
+      // character or use \x08.
+      case 'a':
+        return [0x07, t, null]
+      case 'f':
+        return [0x0c, t, null]
+      case 'n':
+        return [0x0a, t, null]
+      case 'r':
+        return [0x0d, t, null]
+      case 't':
+        return [0x09, t, null]
+      case 'v':
+        return [0x0b, t, null]
+    }
+    return [
+      0,
+      '',
+      new Error({
+        Code: ErrInvalidEscape,
+        Expr: s.slice(0, s.length - t.length),
+      }),
+    ]
+  }
+
+  // parseClassChar parses a character class character at the beginning of s
+  // and returns it.
+  public parseClassChar(
+    s: string,
+    wholeClass: string,
+  ): [number, string, $.GoError] {
+    if (s === '') {
+      return [0, '', new Error({ Code: ErrMissingBracket, Expr: wholeClass })]
+    }
+
+    // Allow regular escape sequences even though
+    // many need not be escaped in this context.
+    if (s[0] === '\\') {
+      return this.parseEscape(s)
+    }
+
+    return nextRune(s)
+  }
+
+  // parsePerlClassEscape parses a leading Perl character class escape like \d
+  // from the beginning of s. If one is present, it appends the characters to r
+  // and returns the new class r and the remainder of the string.
+  public parsePerlClassEscape(
+    s: string,
+    r: number[],
+  ): [number[] | null, string] {
+    if ((this.flags & PerlX) === 0 || s.length < 2 || s[0] !== '\\') {
+      return [null, '']
+    }
+    const g = perlGroup.get(s.slice(0, 2))
+    if (g === undefined) {
+      return [null, '']
+    }
+    return [this.appendGroup(r, g), s.slice(2)]
+  }
+
+  // parseNamedClass parses a leading POSIX named character class like [:alnum:]
+  // from the beginning of s. If one is present, it appends the characters to r
+  // and returns the new class r and the remainder of the string.
+  public parseNamedClass(
+    s: string,
+    r: number[],
+  ): [number[] | null, string, $.GoError] {
+    if (s.length < 2 || s[0] !== '[' || s[1] !== ':') {
+      return [null, '', null]
+    }
+
+    let i = s.indexOf(':]', 2)
+    if (i < 0) {
+      return [null, '', null]
+    }
+    const name = s.slice(0, i + 2)
+    s = s.slice(i + 2)
+    const g = posixGroup.get(name)
+    if (g === undefined) {
+      return [null, '', new Error({ Code: ErrInvalidCharRange, Expr: name })]
+    }
+    return [this.appendGroup(r, g), s, null]
+  }
+
+  public appendGroup(r: number[], g: charGroup): number[] {
+    if ((this.flags & FoldCase) === 0) {
+      if (g.sign < 0) {
+        r = appendNegatedClass(r, g.class)
+      } else {
+        r = appendClass(r, g.class)
+      }
+    } else {
+      const tmp = cleanClass(appendFoldedClass([], g.class))
+      if (g.sign < 0) {
+        r = appendNegatedClass(r, tmp)
+      } else {
+        r = appendClass(r, tmp)
+      }
+    }
+    return r
+  }
+
+  // parseUnicodeClass parses a leading Unicode character class like \p{Han}
+  // from the beginning of s. If one is present, it appends the characters to r
+  // and returns the new class r and the remainder of the string.
+  public parseUnicodeClass(
+    s: string,
+    r: number[],
+  ): [number[] | null, string, $.GoError] {
+    if (
+      (this.flags & UnicodeGroups) === 0 ||
+      s.length < 2 ||
+      s[0] !== '\\' ||
+      (s[1] !== 'p' && s[1] !== 'P')
+    ) {
+      return [null, '', null]
+    }
+
+    // Committed to parse or return error.
+    let sign = +1
+    if (s[1] === 'P') {
+      sign = -1
+    }
+    const [c, rest, err] = nextRune(s.slice(2))
+    if (err !== null) {
+      return [null, '', err]
+    }
+    let t = rest
+    let seq: string
+    let name: string
+    if (c !== 0x7b) {
+      // Single-letter name.
+      seq = s.slice(0, s.length - t.length)
+      name = seq.slice(2)
+    } else {
+      // Name is in braces.
+      const end = s.indexOf('}')
+      if (end < 0) {
+        const err = checkUTF8(s)
+        if (err !== null) {
+          return [null, '', err]
+        }
+        return [null, '', new Error({ Code: ErrInvalidCharRange, Expr: s })]
+      }
+      seq = s.slice(0, end + 1)
+      t = s.slice(end + 1)
+      name = s.slice(3, end)
+      const err = checkUTF8(name)
+      if (err !== null) {
+        return [null, '', err]
+      }
+    }
+
+    // Group can have leading negation too.  \p{^Han} == \P{Han}, \P{^Han} == \p{Han}.
+    if (name !== '' && name[0] === '^') {
+      sign = -sign
+      name = name.slice(1)
+    }
+
+    const tab = lookupTable(name)
+    if (tab === null) {
+      return [null, '', new Error({ Code: ErrInvalidCharRange, Expr: seq })]
+    }
+    if (tab.sign < 0) {
+      sign = -sign
+    }
+
+    if ((this.flags & FoldCase) === 0) {
+      if (sign > 0) {
+        r = appendClass(r, tab.tab)
+      } else {
+        r = appendNegatedClass(r, tab.tab)
+      }
+    } else {
+      // Merge and clean tab and fold in a temporary buffer.
+      // This is necessary for the negative case and just tidy
+      // for the positive case.
+      const tmp = cleanClass(appendClass(tab.tab.slice(), foldTable(tab)))
+      if (sign > 0) {
+        r = appendClass(r, tmp)
+      } else {
+        r = appendNegatedClass(r, tmp)
+      }
+    }
+    return [r, t, null]
+  }
+
+  // parseClass parses a character class at the beginning of s
+  // and pushes it onto the parse stack.
+  public parseClass(s: string): [string, $.GoError] {
+    let t = s.slice(1) // chop [
+    const re = this.newRegexp(OpCharClass)
+    re.Flags = this.flags
+
+    let sign = +1
+    if (t !== '' && t[0] === '^') {
+      sign = -1
+      t = t.slice(1)
+
+      // If character class does not match \n, add it here,
+      // so that negation later will do the right thing.
+      if ((this.flags & ClassNL) === 0) {
+        re.Rune.push(0x0a, 0x0a)
+      }
+    }
+
+    let cls = re.Rune
+    let first = true // ] and - are okay as first char in class
+    while (t === '' || t[0] !== ']' || first) {
+      // POSIX: - is only okay unescaped as first or last in class.
+      // Perl: - is okay anywhere.
+      if (
+        t !== '' &&
+        t[0] === '-' &&
+        (this.flags & PerlX) === 0 &&
+        !first &&
+        (t.length === 1 || t[1] !== ']')
+      ) {
+        const size = t.length > 1 ? runeLen(t.codePointAt(1)!) : 0
+        return [
+          '',
+          new Error({ Code: ErrInvalidCharRange, Expr: t.slice(0, 1 + size) }),
+        ]
+      }
+      first = false
+
+      // Look for POSIX [:alnum:] etc.
+      if (t.length > 2 && t[0] === '[' && t[1] === ':') {
+        const [nclass, nt, err] = this.parseNamedClass(t, cls)
+        if (err !== null) {
+          return ['', err]
+        }
+        if (nclass !== null) {
+          cls = nclass
+          t = nt
+          continue
+        }
+      }
+
+      // Look for Unicode character group like \p{Han}.
+      {
+        const [nclass, nt, err] = this.parseUnicodeClass(t, cls)
+        if (err !== null) {
+          return ['', err]
+        }
+        if (nclass !== null) {
+          cls = nclass
+          t = nt
+          continue
+        }
+      }
+
+      // Look for Perl character class symbols (extension).
+      {
+        const [nclass, nt] = this.parsePerlClassEscape(t, cls)
+        if (nclass !== null) {
+          cls = nclass
+          t = nt
+          continue
+        }
+      }
+
+      // Single character or simple range.
+      let rng = t
+      let lo: number
+      let hi: number
+      let err: $.GoError
+      ;[lo, t, err] = this.parseClassChar(t, s)
+      if (err !== null) {
+        return ['', err]
+      }
+      hi = lo
+      // [a-] means (a|-) so check for final ].
+      if (t.length >= 2 && t[0] === '-' && t[1] !== ']') {
+        t = t.slice(1)
+        ;[hi, t, err] = this.parseClassChar(t, s)
+        if (err !== null) {
+          return ['', err]
+        }
+        if (hi < lo) {
+          rng = rng.slice(0, rng.length - t.length)
+          return ['', new Error({ Code: ErrInvalidCharRange, Expr: rng })]
+        }
+      }
+      if ((this.flags & FoldCase) === 0) {
+        cls = appendRange(cls, lo, hi)
+      } else {
+        cls = appendFoldedRange(cls, lo, hi)
+      }
+    }
+    t = t.slice(1) // chop ]
+
+    cls = cleanClass(cls)
+    if (sign < 0) {
+      cls = negateClass(cls)
+    }
+    re.Rune = cls
+    this.push(re)
+    return [t, null]
+  }
+}
+
+// minFoldRune returns the minimum rune fold-equivalent to r.
+function minFoldRune(r: number): number {
+  if (r < minFold || r > maxFold) {
+    return r
+  }
+  let m = r
+  const r0 = r
+  for (r = simpleFold(r); r !== r0; r = simpleFold(r)) {
+    m = Math.min(m, r)
+  }
+  return m
+}
+
+// repeatIsValid reports whether the repetition re is valid.
+// Valid means that the combination of the top-level repetition
+// and any inner repetitions does not exceed n copies of the
+// innermost thing.
+// This function rewalks the regexp tree and is called for every repetition,
+// so we have to worry about inducing quadratic behavior in the parser.
+// We avoid this by only calling repeatIsValid when min or max >= 2.
+// In that case the depth of any >= 2 nesting can only get to 9 without
+// triggering a parse error, so each subtree can only be rewalked 9 times.
+function repeatIsValid(re: Regexp, n: number): boolean {
+  if (re.Op === OpRepeat) {
+    let m = re.Max
+    if (m === 0) {
+      return true
+    }
+    if (m < 0) {
+      m = re.Min
+    }
+    if (m > n) {
+      return false
+    }
+    if (m > 0) {
+      n = Math.floor(n / m)
+    }
+  }
+  for (const sub of re.Sub) {
+    if (!repeatIsValid(sub, n)) {
+      return false
+    }
+  }
+  return true
+}
+
+// cleanAlt cleans re for eventual inclusion in an alternation.
+function cleanAlt(re: Regexp): void {
+  if (re.Op === OpCharClass) {
+    re.Rune = cleanClass(re.Rune)
+    const r = re.Rune
+    if (r.length === 2 && r[0] === 0 && r[1] === MaxRune) {
+      re.Rune = []
+      re.Op = OpAnyChar
+      return
+    }
+    if (
+      r.length === 4 &&
+      r[0] === 0 &&
+      r[1] === 0x0a - 1 &&
+      r[2] === 0x0a + 1 &&
+      r[3] === MaxRune
+    ) {
+      re.Rune = []
+      re.Op = OpAnyCharNotNL
+      return
+    }
+  }
+}
+
+function literalRegexp(s: string, flags: Flags): Regexp {
+  const re = new Regexp({ Op: OpLiteral, Flags: flags })
+  for (const c of s) {
+    re.Rune.push(c.codePointAt(0)!)
+  }
+  return re
+}
+
+// Parsing.
+
+// Parse parses a regular expression string s, controlled by the specified
+// Flags, and returns a regular expression parse tree. The syntax is
+// described in the top-level comment.
+export function Parse(s: string, flags: Flags): [Regexp | null, $.GoError] {
+  try {
+    return parse(s, flags)
+  } catch (e) {
+    if (e instanceof limitPanic) {
+      return [null, new Error({ Code: e.code, Expr: s })]
+    }
+    throw e
+  }
+}
+
+function parse(s: string, flags: Flags): [Regexp | null, $.GoError] {
+  if ((flags & Literal) !== 0) {
+    // Trivial parser for literal string.
+    const err = checkUTF8(s)
+    if (err !== null) {
+      return [null, err]
+    }
+    return [literalRegexp(s, flags), null]
+  }
+
+  // Otherwise, must do real work.
+  const p = new parser()
+  let c: number
+  let op: Op
+  let err: $.GoError
+  let lastRepeat = ''
+  p.flags = flags
+  p.wholeRegexp = s
+  let t = s
+  while (t !== '') {
+    let repeat = ''
+    BigSwitch: switch (t[0]) {
+      default:
+        ;[c, t, err] = nextRune(t)
+        if (err !== null) {
+          return [null, err]
+        }
+        p.literal(c)
+        break
+
+      case '(':
+        if ((p.flags & PerlX) !== 0 && t.length >= 2 && t[1] === '?') {
+          // Flag changes and non-capturing groups.
+          ;[t, err] = p.parsePerlFlags(t)
+          if (err !== null) {
+            return [null, err]
+          }
+          break
+        }
+        p.numCap++
+        p.op(opLeftParen).Cap = p.numCap
+        t = t.slice(1)
+        break
+      case '|':
+        p.parseVerticalBar()
+        t = t.slice(1)
+        break
+      case ')':
+        err = p.parseRightParen()
+        if (err !== null) {
+          return [null, err]
+        }
+        t = t.slice(1)
+        break
+      case '^':
+        if ((p.flags & OneLine) !== 0) {
+          p.op(OpBeginText)
+        } else {
+          p.op(OpBeginLine)
+        }
+        t = t.slice(1)
+        break
+      case '$':
+        if ((p.flags & OneLine) !== 0) {
+          p.op(OpEndText).Flags |= WasDollar
+        } else {
+          p.op(OpEndLine)
+        }
+        t = t.slice(1)
+        break
+      case '.':
+        if ((p.flags & DotNL) !== 0) {
+          p.op(OpAnyChar)
+        } else {
+          p.op(OpAnyCharNotNL)
+        }
+        t = t.slice(1)
+        break
+      case '[':
+        ;[t, err] = p.parseClass(t)
+        if (err !== null) {
+          return [null, err]
+        }
+        break
+      case '*':
+      case '+':
+      case '?': {
+        const before = t
+        switch (t[0]) {
+          case '*':
+            op = OpStar
+            break
+          case '+':
+            op = OpPlus
+            break
+          default:
+            op = OpQuest
+            break
+        }
+        let after = t.slice(1)
+        ;[after, err] = p.repeat(op, 0, 0, before, after, lastRepeat)
+        if (err !== null) {
+          return [null, err]
+        }
+        repeat = before
+        t = after
+        break
+      }
+      case '{': {
+        op = OpRepeat
+        const before = t
+        let [min, max, after, ok] = p.parseRepeat(t)
+        if (!ok) {
+          // If the repeat cannot be parsed, { is a literal.
+          p.literal(0x7b)
+          t = t.slice(1)
+          break
+        }
+        if (min < 0 || min > 1000 || max > 1000 || (max >= 0 && min > max)) {
+          // Numbers were too big, or max is present and min > max.
+          return [
+            null,
+            new Error({
+              Code: ErrInvalidRepeatSize,
+              Expr: before.slice(0, before.length - after.length),
+            }),
+          ]
+        }
+        ;[after, err] = p.repeat(op, min, max, before, after, lastRepeat)
+        if (err !== null) {
+          return [null, err]
+        }
+        repeat = before
+        t = after
+        break
+      }
+      case '\\': {
+        if ((p.flags & PerlX) !== 0 && t.length >= 2) {
+          switch (t[1]) {
+            case 'A':
+              p.op(OpBeginText)
+              t = t.slice(2)
+              break BigSwitch
+            case 'b':
+              p.op(OpWordBoundary)
+              t = t.slice(2)
+              break BigSwitch
+            case 'B':
+              p.op(OpNoWordBoundary)
+              t = t.slice(2)
+              break BigSwitch
+            case 'C':
+              // any byte; not supported
+              return [
+                null,
+                new Error({ Code: ErrInvalidEscape, Expr: t.slice(0, 2) }),
+              ]
+            case 'Q': {
+              // \Q ... \E: the ... is always literals
+              let lit: string
+              const i = t.indexOf('\\E', 2)
+              if (i < 0) {
+                lit = t.slice(2)
+                t = ''
+              } else {
+                lit = t.slice(2, i)
+                t = t.slice(i + 2)
+              }
+              while (lit !== '') {
+                const [c, rest, err] = nextRune(lit)
+                if (err !== null) {
+                  return [null, err]
+                }
+                p.literal(c)
+                lit = rest
+              }
+              break BigSwitch
+            }
+            case 'z':
+              p.op(OpEndText)
+              t = t.slice(2)
+              break BigSwitch
+          }
+        }
+
+        const re = p.newRegexp(OpCharClass)
+        re.Flags = p.flags
+
+        // Look for Unicode character group like \p{Han}
+        if (t.length >= 2 && (t[1] === 'p' || t[1] === 'P')) {
+          const [r, rest, err] = p.parseUnicodeClass(t, [])
+          if (err !== null) {
+            return [null, err]
+          }
+          if (r !== null) {
+            re.Rune = r
+            t = rest
+            p.push(re)
+            break BigSwitch
+          }
+        }
+
+        // Perl character class escape.
+        const [r, rest] = p.parsePerlClassEscape(t, [])
+        if (r !== null) {
+          re.Rune = r
+          t = rest
+          p.push(re)
+          break BigSwitch
+        }
+        p.reuse(re)
+
+        // Ordinary single-character escape.
+        ;[c, t, err] = p.parseEscape(t)
+        if (err !== null) {
+          return [null, err]
+        }
+        p.literal(c)
+        break
+      }
+    }
+    lastRepeat = repeat
+  }
+
+  p.concat()
+  if (p.swapVerticalBar()) {
+    // pop vertical bar
+    p.stack.pop()
+  }
+  p.alternate()
+
+  const n = p.stack.length
+  if (n !== 1) {
+    return [null, new Error({ Code: ErrMissingParen, Expr: s })]
+  }
+  return [p.stack[0], null]
+}
+
+// isValidCaptureName reports whether name
+// is a valid capture name: [A-Za-z0-9_]+.
+// PCRE limits names to 32 bytes.
+// Python rejects names starting with digits.
+// We don't enforce either of those.
+function isValidCaptureName(name: string): boolean {
+  if (name === '') {
+    return false
+  }
+  for (const c of name) {
+    if (c !== '_' && !isalnum(c.codePointAt(0)!)) {
+      return false
+    }
+  }
+  return true
+}
+
+// can this be represented as a character class?
+// single-rune literal string, char class, ., and .|\n.
+function isCharClass(re: Regexp): boolean {
+  return (
+    (re.Op === OpLiteral && re.Rune.length === 1) ||
+    re.Op === OpCharClass ||
+    re.Op === OpAnyCharNotNL ||
+    re.Op === OpAnyChar
+  )
+}
+
+// does re match r?
+function matchRune(re: Regexp, r: number): boolean {
+  switch (re.Op) {
+    case OpLiteral:
+      return re.Rune.length === 1 && re.Rune[0] === r
+    case OpCharClass:
+      for (let i = 0; i < re.Rune.length; i += 2) {
+        if (re.Rune[i] <= r && r <= re.Rune[i + 1]) {
+          return true
+        }
+      }
+      return false
+    case OpAnyCharNotNL:
+      return r !== 0x0a
+    case OpAnyChar:
+      return true
+  }
+  return false
+}
+
+// mergeCharClass makes dst = dst|src.
+// The caller must ensure that dst.Op >= src.Op,
+// to reduce the amount of copying.
+function mergeCharClass(dst: Regexp, src: Regexp): void {
+  switch (dst.Op) {
+    case OpAnyChar:
+      // src doesn't add anything.
+      break
+    case OpAnyCharNotNL:
+      // src might add \n
+      if (matchRune(src, 0x0a)) {
+        dst.Op = OpAnyChar
+      }
+      break
+    case OpCharClass:
+      // src is simpler, so either literal or char class
+      if (src.Op === OpLiteral) {
+        dst.Rune = appendLiteral(dst.Rune, src.Rune[0], src.Flags)
+      } else {
+        dst.Rune = appendClass(dst.Rune, src.Rune)
+      }
+      break
+    case OpLiteral:
+      // both literal
+      if (src.Rune[0] === dst.Rune[0] && src.Flags === dst.Flags) {
+        break
+      }
+      dst.Op = OpCharClass
+      dst.Rune = appendLiteral([], dst.Rune[0], dst.Flags)
+      dst.Rune = appendLiteral(dst.Rune, src.Rune[0], src.Flags)
+      break
+  }
+}
+
+// cleanClass sorts the ranges (pairs of elements of r),
+// merges them, and eliminates duplicates.
+export function cleanClass(r: number[]): number[] {
+  // Sort by lo increasing, hi decreasing to break ties.
+  const pairs: [number, number][] = []
+  for (let i = 0; i < r.length; i += 2) {
+    pairs.push([r[i], r[i + 1]])
+  }
+  pairs.sort((a, b) => a[0] - b[0] || b[1] - a[1])
+
+  if (pairs.length < 1) {
+    return []
+  }
+
+  // Merge abutting, overlapping.
+  const out = [pairs[0][0], pairs[0][1]]
+  for (let i = 1; i < pairs.length; i++) {
+    const [lo, hi] = pairs[i]
+    if (lo <= out[out.length - 1] + 1) {
+      // merge with previous range
+      if (hi > out[out.length - 1]) {
+        out[out.length - 1] = hi
+      }
+      continue
+    }
+    // new disjoint range
+    out.push(lo, hi)
+  }
+
+  return out
+}
+
+// appendLiteral returns the result of appending the literal x to the class r.
+function appendLiteral(r: number[], x: number, flags: Flags): number[] {
+  if ((flags & FoldCase) !== 0) {
+    return appendFoldedRange(r, x, x)
+  }
+  return appendRange(r, x, x)
+}
+
+// appendRange returns the result of appending the range lo-hi to the class r.
+function appendRange(r: number[], lo: number, hi: number): number[] {
+  // Expand last range or next to last range if it overlaps or abuts.
+  // Checking two ranges helps when appending case-folded
+  // alphabets, so that one range can be expanding A-Z and the
+  // other expanding a-z.
+  const n = r.length
+  for (let i = 2; i <= 4; i += 2) {
+    // twice, using i=2, i=4
+    if (n >= i) {
+      const rlo = r[n - i]
+      const rhi = r[n - i + 1]
+      if (lo <= rhi + 1 && rlo <= hi + 1) {
+        if (lo < rlo) {
+          r[n - i] = lo
+        }
+        if (hi > rhi) {
+          r[n - i + 1] = hi
+        }
+        return r
+      }
+    }
+  }
+
+  r.push(lo, hi)
+  return r
+}
+
+// appendFoldedRange returns the result of appending the range lo-hi
+// and its case folding-equivalent runes to the class r.
+function appendFoldedRange(r: number[], lo: number, hi: number): number[] {
+  // Optimizations.
+  if (lo <= minFold && hi >= maxFold) {
+    // Range is full: folding can't add more.
+    return appendRange(r, lo, hi)
+  }
+  if (hi < minFold || lo > maxFold) {
+    // Range is outside folding possibilities.
+    return appendRange(r, lo, hi)
+  }
+  if (lo < minFold) {
+    // [lo, minFold-1] needs no folding.
+    r = appendRange(r, lo, minFold - 1)
+    lo = minFold
+  }
+  if (hi > maxFold) {
+    // [maxFold+1, hi] needs no folding.
+    r = appendRange(r, maxFold + 1, hi)
+    hi = maxFold
+  }
+
+  // Brute force. Depend on appendRange to coalesce ranges on the fly.
+  for (let c = lo; c <= hi; c++) {
+    r = appendRange(r, c, c)
+    for (let f = simpleFold(c); f !== c; f = simpleFold(f)) {
+      r = appendRange(r, f, f)
+    }
+  }
+  return r
+}
+
+// appendClass returns the result of appending the class x to the class r.
+// It assume x is clean.
+function appendClass(r: number[], x: number[]): number[] {
+  for (let i = 0; i < x.length; i += 2) {
+    r = appendRange(r, x[i], x[i + 1])
+  }
+  return r
+}
+
+// appendFoldedClass returns the result of appending the case folding of the class x to the class r.
+function appendFoldedClass(r: number[], x: number[]): number[] {
+  for (let i = 0; i < x.length; i += 2) {
+    r = appendFoldedRange(r, x[i], x[i + 1])
+  }
+  return r
+}
+
+// appendNegatedClass returns the result of appending the negation of the class x to the class r.
+// It assumes x is clean.
+function appendNegatedClass(r: number[], x: number[]): number[] {
+  let nextLo = 0
+  for (let i = 0; i < x.length; i += 2) {
+    const lo = x[i]
+    const hi = x[i + 1]
+    if (nextLo <= lo - 1) {
+      r = appendRange(r, nextLo, lo - 1)
+    }
+    nextLo = hi + 1
+  }
+  if (nextLo <= MaxRune) {
+    r = appendRange(r, nextLo, MaxRune)
+  }
+  return r
+}
+
+// negateClass returns the negation of the class r.
+// It assumes the class r is already clean.
+function negateClass(r: number[]): number[] {
+  return appendNegatedClass([], r)
+}
+
+function checkUTF8(s: string): $.GoError {
+  while (s !== '') {
+    const [, rest, err] = nextRune(s)
+    if (err !== null) {
+      return err
+    }
+    s = rest
+  }
+  return null
+}
+
+// nextRune returns the first rune of s and the rest of s. A lone
+// surrogate, which has no UTF-8 encoding, is reported as invalid UTF-8.
+function nextRune(s: string): [number, string, $.GoError] {
+  const c = s.codePointAt(0)!
+  if (c >= 0xd800 && c <= 0xdfff) {
+    return [0, '', new Error({ Code: ErrInvalidUTF8, Expr: s })]
+  }
+  return [c, s.slice(c > 0xffff ? 2 : 1), null]
+}
+
+// runeLen returns the number of UTF-16 code units of the rune c.
+function runeLen(c: number): number {
+  return c > 0xffff ? 2 : 1
+}
+
+function isalnum(c: number): boolean {
+  return (
+    (0x30 <= c && c <= 0x39) ||
+    (0x41 <= c && c <= 0x5a) ||
+    (0x61 <= c && c <= 0x7a)
+  )
+}
+
+function unhex(c: number): number {
+  if (0x30 <= c && c <= 0x39) {
+    return c - 0x30
+  }
+  if (0x61 <= c && c <= 0x66) {
+    return c - 0x61 + 10
+  }
+  if (0x41 <= c && c <= 0x46) {
+    return c - 0x41 + 10
+  }
+  return -1
+}
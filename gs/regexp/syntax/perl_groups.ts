@@ -0,0 +1,82 @@
+// Perl and POSIX character classes, from Go's perl_groups.go.
+
+export interface charGroup {
+  sign: number
+  class: number[]
+}
+
+const code1 = [/* \d */ 0x30, 0x39]
+
+const code2 = [/* \s */ 0x9, 0xa, 0xc, 0xd, 0x20, 0x20]
+
+const code3 = [/* \w */ 0x30, 0x39, 0x41, 0x5a, 0x5f, 0x5f, 0x61, 0x7a]
+
+export const perlGroup: Map<string, charGroup> = new Map([
+  ['\\d', { sign: +1, class: code1 }],
+  ['\\D', { sign: -1, class: code1 }],
+  ['\\s', { sign: +1, class: code2 }],
+  ['\\S', { sign: -1, class: code2 }],
+  ['\\w', { sign: +1, class: code3 }],
+  ['\\W', { sign: -1, class: code3 }],
+])
+
+const code4 = [/* [:alnum:] */ 0x30, 0x39, 0x41, 0x5a, 0x61, 0x7a]
+
+const code5 = [/* [:alpha:] */ 0x41, 0x5a, 0x61, 0x7a]
+
+const code6 = [/* [:ascii:] */ 0x0, 0x7f]
+
+const code7 = [/* [:blank:] */ 0x9, 0x9, 0x20, 0x20]
+
+const code8 = [/* [:cntrl:] */ 0x0, 0x1f, 0x7f, 0x7f]
+
+const code9 = [/* [:digit:] */ 0x30, 0x39]
+
+const code10 = [/* [:graph:] */ 0x21, 0x7e]
+
+const code11 = [/* [:lower:] */ 0x61, 0x7a]
+
+const code12 = [/* [:print:] */ 0x20, 0x7e]
+
+const code13 = [
+  /* [:punct:] */ 0x21, 0x2f, 0x3a, 0x40, 0x5b, 0x60, 0x7b, 0x7e,
+]
+
+const code14 = [/* [:space:] */ 0x9, 0xd, 0x20, 0x20]
+
+const code15 = [/* [:upper:] */ 0x41, 0x5a]
+
+const code16 = [/* [:word:] */ 0x30, 0x39, 0x41, 0x5a, 0x5f, 0x5f, 0x61, 0x7a]
+
+const code17 = [/* [:xdigit:] */ 0x30, 0x39, 0x41, 0x46, 0x61, 0x66]
+
+export const posixGroup: Map<string, charGroup> = new Map([
+  ['[:alnum:]', { sign: +1, class: code4 }],
+  ['[:^alnum:]', { sign: -1, class: code4 }],
+  ['[:alpha:]', { sign: +1, class: code5 }],
+  ['[:^alpha:]', { sign: -1, class: code5 }],
+  ['[:ascii:]', { sign: +1, class: code6 }],
+  ['[:^ascii:]', { sign: -1, class: code6 }],
+  ['[:blank:]', { sign: +1, class: code7 }],
+  ['[:^blank:]', { sign: -1, class: code7 }],
+  ['[:cntrl:]', { sign: +1, class: code8 }],
+  ['[:^cntrl:]', { sign: -1, class: code8 }],
+  ['[:digit:]', { sign: +1, class: code9 }],
+  ['[:^digit:]', { sign: -1, class: code9 }],
+  ['[:graph:]', { sign: +1, class: code10 }],
+  ['[:^graph:]', { sign: -1, class: code10 }],
+  ['[:lower:]', { sign: +1, class: code11 }],
+  ['[:^lower:]', { sign: -1, class: code11 }],
+  ['[:print:]', { sign: +1, class: code12 }],
+  ['[:^print:]', { sign: -1, class: code12 }],
+  ['[:punct:]', { sign: +1, class: code13 }],
+  ['[:^punct:]', { sign: -1, class: code13 }],
+  ['[:space:]', { sign: +1, class: code14 }],
+  ['[:^space:]', { sign: -1, class: code14 }],
+  ['[:upper:]', { sign: +1, class: code15 }],
+  ['[:^upper:]', { sign: -1, class: code15 }],
+  ['[:word:]', { sign: +1, class: code16 }],
+  ['[:^word:]', { sign: -1, class: code16 }],
+  ['[:xdigit:]', { sign: +1, class: code17 }],
+  ['[:^xdigit:]', { sign: -1, class: code17 }],
+])
@@ -0,0 +1,453 @@
+import * as $ from '@goscript/builtin/index.js'
+import { QuoteToASCII } from '@goscript/strconv/index.js'
+
+import { FoldCase } from './parse.js'
+import { simpleFold } from './unicode.js'
+import {
+  boolType,
+  intType,
+  runeType,
+  runesType,
+  stringType,
+} from './typeinfo.js'
+
+// Compiled program.
+// May not belong in this package, but convenient for now.
+
+// An InstOp is an instruction opcode.
+export type InstOp = number
+
+export const InstAlt: InstOp = 0
+export const InstAltMatch: InstOp = 1
+export const InstCapture: InstOp = 2
+export const InstEmptyWidth: InstOp = 3
+export const InstMatch: InstOp = 4
+export const InstFail: InstOp = 5
+export const InstNop: InstOp = 6
+export const InstRune: InstOp = 7
+export const InstRune1: InstOp = 8
+export const InstRuneAny: InstOp = 9
+export const InstRuneAnyNotNL: InstOp = 10
+
+const instOpNames = [
+  'InstAlt',
+  'InstAltMatch',
+  'InstCapture',
+  'InstEmptyWidth',
+  'InstMatch',
+  'InstFail',
+  'InstNop',
+  'InstRune',
+  'InstRune1',
+  'InstRuneAny',
+  'InstRuneAnyNotNL',
+]
+
+export function InstOp_String(i: InstOp): string {
+  if (i < 0 || i >= instOpNames.length) {
+    return ''
+  }
+  return instOpNames[i]
+}
+
+$.registerNamedType(
+  'regexp/syntax.InstOp',
+  0,
+  [$.method('String', [], [stringType])],
+  { kind: $.TypeKind.Basic, name: 'uint8' },
+)
+
+// An EmptyOp specifies a kind or mixture of zero-width assertions.
+export type EmptyOp = number
+
+export const EmptyBeginLine: EmptyOp = 1 << 0
+export const EmptyEndLine: EmptyOp = 1 << 1
+export const EmptyBeginText: EmptyOp = 1 << 2
+export const EmptyEndText: EmptyOp = 1 << 3
+export const EmptyWordBoundary: EmptyOp = 1 << 4
+export const EmptyNoWordBoundary: EmptyOp = 1 << 5
+
+$.registerNamedType('regexp/syntax.EmptyOp', 0, [], {
+  kind: $.TypeKind.Basic,
+  name: 'uint8',
+})
+
+// EmptyOpContext returns the zero-width assertions
+// satisfied at the position between the runes r1 and r2.
+// Passing r1 == -1 indicates that the position is
+// at the beginning of the text.
+// Passing r2 == -1 indicates that the position is
+// at the end of the text.
+export function EmptyOpContext(r1: number, r2: number): EmptyOp {
+  let op: EmptyOp = EmptyNoWordBoundary
+  let boundary = 0
+  if (IsWordChar(r1)) {
+    boundary = 1
+  } else if (r1 === 0x0a) {
+    op |= EmptyBeginLine
+  } else if (r1 < 0) {
+    op |= EmptyBeginText | EmptyBeginLine
+  }
+  if (IsWordChar(r2)) {
+    boundary ^= 1
+  } else if (r2 === 0x0a) {
+    op |= EmptyEndLine
+  } else if (r2 < 0) {
+    op |= EmptyEndText | EmptyEndLine
+  }
+  if (boundary !== 0) {
+    // IsWordChar(r1) != IsWordChar(r2)
+    op ^= EmptyWordBoundary | EmptyNoWordBoundary
+  }
+  return op
+}
+
+// IsWordChar reports whether r is considered a “word character”
+// during the evaluation of the \b and \B zero-width assertions.
+// These assertions are ASCII-only: the word characters are [A-Za-z0-9_].
+export function IsWordChar(r: number): boolean {
+  // Test for lowercase letters first, as these occur more
+  // frequently than uppercase letters in common cases.
+  return (
+    (0x61 <= r && r <= 0x7a) ||
+    (0x41 <= r && r <= 0x5a) ||
+    (0x30 <= r && r <= 0x39) ||
+    r === 0x5f
+  )
+}
+
+const noMatch = -1
+
+// An Inst is a single instruction in a regular expression program.
+export class Inst {
+  public Op: InstOp = 0
+  public Out = 0 // all but InstMatch, InstFail
+  public Arg = 0 // InstAlt, InstAltMatch, InstCapture, InstEmptyWidth
+  public Rune: number[] | null = null
+
+  constructor(
+    init?: Partial<{
+      Op: InstOp
+      Out: number
+      Arg: number
+      Rune: number[] | null
+    }>,
+  ) {
+    if (init) {
+      Object.assign(this, init)
+    }
+  }
+
+  public clone(): Inst {
+    return new Inst({
+      Op: this.Op,
+      Out: this.Out,
+      Arg: this.Arg,
+      Rune: this.Rune,
+    })
+  }
+
+  // op returns i.Op but merges all the Rune special cases into InstRune
+  public op(): InstOp {
+    const op = this.Op
+    switch (op) {
+      case InstRune1:
+      case InstRuneAny:
+      case InstRuneAnyNotNL:
+        return InstRune
+    }
+    return op
+  }
+
+  // MatchRune reports whether the instruction matches (and consumes) r.
+  // It should only be called when i.Op == [InstRune].
+  public MatchRune(r: number): boolean {
+    return this.MatchRunePos(r) !== noMatch
+  }
+
+  // MatchRunePos checks whether the instruction matches (and consumes) r.
+  // If so, MatchRunePos returns the index of the matching rune pair
+  // (or, when len(i.Rune) == 1, rune singleton).
+  // If not, MatchRunePos returns -1.
+  // MatchRunePos should only be called when i.Op == [InstRune].
+  public MatchRunePos(r: number): number {
+    const rune = this.Rune ?? []
+
+    switch (rune.length) {
+      case 0:
+        return noMatch
+
+      case 1: {
+        // Special case: single-rune slice is from literal string, not char class.
+        const r0 = rune[0]
+        if (r === r0) {
+          return 0
+        }
+        if ((this.Arg & FoldCase) !== 0) {
+          for (let r1 = simpleFold(r0); r1 !== r0; r1 = simpleFold(r1)) {
+            if (r === r1) {
+              return 0
+            }
+          }
+        }
+        return noMatch
+      }
+
+      case 2:
+        if (r >= rune[0] && r <= rune[1]) {
+          return 0
+        }
+        return noMatch
+
+      case 4:
+      case 6:
+      case 8:
+        // Linear search for a few pairs.
+        // Should handle ASCII well.
+        for (let j = 0; j < rune.length; j += 2) {
+          if (r < rune[j]) {
+            return noMatch
+          }
+          if (r <= rune[j + 1]) {
+            return j / 2
+          }
+        }
+        return noMatch
+    }
+
+    // Otherwise binary search.
+    let lo = 0
+    let hi = rune.length / 2
+    while (lo < hi) {
+      const m = (lo + hi) >>> 1
+      const c = rune[2 * m]
+      if (c <= r) {
+        if (r <= rune[2 * m + 1]) {
+          return m
+        }
+        lo = m + 1
+      } else {
+        hi = m
+      }
+    }
+    return noMatch
+  }
+
+  // MatchEmptyWidth reports whether the instruction matches
+  // an empty string between the runes before and after.
+  // It should only be called when i.Op == [InstEmptyWidth].
+  public MatchEmptyWidth(before: number, after: number): boolean {
+    switch (this.Arg) {
+      case EmptyBeginLine:
+        return before === 0x0a || before === -1
+      case EmptyEndLine:
+        return after === 0x0a || after === -1
+      case EmptyBeginText:
+        return before === -1
+      case EmptyEndText:
+        return after === -1
+      case EmptyWordBoundary:
+        return IsWordChar(before) !== IsWordChar(after)
+      case EmptyNoWordBoundary:
+        return IsWordChar(before) === IsWordChar(after)
+    }
+    $.panic('unknown empty width arg')
+    return false
+  }
+
+  public String(): string {
+    return dumpInst(this)
+  }
+
+  // Register this type with the runtime type system
+  static __typeInfo = $.registerStructType(
+    'regexp/syntax.Inst',
+    new Inst(),
+    [
+      $.method('MatchEmptyWidth', [runeType, runeType], [boolType]),
+      $.method('MatchRune', [runeType], [boolType]),
+      $.method('MatchRunePos', [runeType], [intType]),
+      $.method('String', [], [stringType]),
+    ],
+    Inst,
+    {
+      Op: 'regexp/syntax.InstOp',
+      Out: { kind: $.TypeKind.Basic, name: 'uint32' },
+      Arg: { kind: $.TypeKind.Basic, name: 'uint32' },
+      Rune: runesType,
+    },
+  )
+}
+
+// A Prog is a compiled regular expression program.
+export class Prog {
+  public Inst: Inst[] = []
+  public Start = 0 // index of start instruction
+  public NumCap = 0 // number of InstCapture insts in re
+
+  constructor(
+    init?: Partial<{ Inst: Inst[]; Start: number; NumCap: number }>,
+  ) {
+    if (init) {
+      Object.assign(this, init)
+    }
+  }
+
+  public clone(): Prog {
+    return new Prog({
+      Inst: this.Inst.slice(),
+      Start: this.Start,
+      NumCap: this.NumCap,
+    })
+  }
+
+  public String(): string {
+    return dumpProg(this)
+  }
+
+  // skipNop follows any no-op or capturing instructions.
+  public skipNop(pc: number): Inst {
+    let i = this.Inst[pc]
+    while (i.Op === InstNop || i.Op === InstCapture) {
+      i = this.Inst[i.Out]
+    }
+    return i
+  }
+
+  // Prefix returns a literal string that all matches for the
+  // regexp must start with. Complete is true if the prefix
+  // is the entire match.
+  public Prefix(): [string, boolean] {
+    let i = this.skipNop(this.Start)
+
+    // Avoid allocation of buffer if prefix is empty.
+    if (i.op() !== InstRune || i.Rune?.length !== 1) {
+      return ['', i.Op === InstMatch]
+    }
+
+    // Have prefix; gather characters.
+    let buf = ''
+    while (
+      i.op() === InstRune &&
+      i.Rune?.length === 1 &&
+      (i.Arg & FoldCase) === 0 &&
+      i.Rune[0] !== 0xfffd
+    ) {
+      buf += String.fromCodePoint(i.Rune[0])
+      i = this.skipNop(i.Out)
+    }
+    return [buf, i.Op === InstMatch]
+  }
+
+  // StartCond returns the leading empty-width conditions that must
+  // be true in any match. It returns ^EmptyOp(0) if no matches are possible.
+  public StartCond(): EmptyOp {
+    let flag: EmptyOp = 0
+    let pc = this.Start
+    let i = this.Inst[pc]
+    Loop: for (;;) {
+      switch (i.Op) {
+        case InstEmptyWidth:
+          flag |= i.Arg
+          break
+        case InstFail:
+          return 0xff
+        case InstCapture:
+        case InstNop:
+          // skip
+          break
+        default:
+          break Loop
+      }
+      pc = i.Out
+      i = this.Inst[pc]
+    }
+    return flag
+  }
+
+  // Register this type with the runtime type system
+  static __typeInfo = $.registerStructType(
+    'regexp/syntax.Prog',
+    new Prog(),
+    [
+      $.method('Prefix', [], [stringType, boolType]),
+      $.method('StartCond', [], ['regexp/syntax.EmptyOp']),
+      $.method('String', [], [stringType]),
+    ],
+    Prog,
+    {
+      Inst: { kind: $.TypeKind.Slice, elemType: 'regexp/syntax.Inst' },
+      Start: intType,
+      NumCap: intType,
+    },
+  )
+}
+
+function dumpProg(p: Prog): string {
+  let b = ''
+  for (let j = 0; j < p.Inst.length; j++) {
+    const i = p.Inst[j]
+    let pc = String(j)
+    if (pc.length < 3) {
+      b += '   '.slice(pc.length)
+    }
+    if (j === p.Start) {
+      pc += '*'
+    }
+    b += pc + '\t' + dumpInst(i) + '\n'
+  }
+  return b
+}
+
+function dumpInst(i: Inst): string {
+  switch (i.Op) {
+    case InstAlt:
+      return 'alt -> ' + i.Out + ', ' + i.Arg
+    case InstAltMatch:
+      return 'altmatch -> ' + i.Out + ', ' + i.Arg
+    case InstCapture:
+      return 'cap ' + i.Arg + ' -> ' + i.Out
+    case InstEmptyWidth:
+      return 'empty ' + i.Arg + ' -> ' + i.Out
+    case InstMatch:
+      return 'match'
+    case InstFail:
+      return 'fail'
+    case InstNop:
+      return 'nop -> ' + i.Out
+    case InstRune: {
+      let b = ''
+      if (i.Rune === null) {
+        // shouldn't happen
+        b += 'rune <nil>'
+      }
+      b += 'rune ' + QuoteToASCII(runesToString(i.Rune ?? []))
+      if ((i.Arg & FoldCase) !== 0) {
+        b += '/i'
+      }
+      return b + ' -> ' + i.Out
+    }
+    case InstRune1:
+      return (
+        'rune1 ' +
+        QuoteToASCII(runesToString(i.Rune ?? [])) +
+        ' -> ' +
+        i.Out
+      )
+    case InstRuneAny:
+      return 'any -> ' + i.Out
+    case InstRuneAnyNotNL:
+      return 'anynotnl -> ' + i.Out
+  }
+  return ''
+}
+
+// runesToString converts runes to a string like Go's string([]rune),
+// which turns surrogate halves into U+FFFD.
+function runesToString(runes: number[]): string {
+  let s = ''
+  for (const r of runes) {
+    s += String.fromCodePoint(r >= 0xd800 && r <= 0xdfff ? 0xfffd : r)
+  }
+  return s
+}
@@ -0,0 +1,657 @@
+import * as $ from '@goscript/builtin/index.js'
+
+import { FoldCase, Flags, NonGreedy, WasDollar } from './parse.js'
+import { simplify } from './simplify.js'
+import { MaxRune, isPrint, maxFold, minFold, simpleFold } from './unicode.js'
+import {
+  boolType,
+  intType,
+  regexpPtrType,
+  runesType,
+  stringType,
+  stringsType,
+} from './typeinfo.js'
+
+// An Op is a single regular expression operator.
+// Operators are listed in precedence order, tightest binding to weakest.
+// Character class operators are listed simplest to most complex
+// (OpLiteral, OpCharClass, OpAnyCharNotNL, OpAnyChar).
+export type Op = number
+
+export const OpNoMatch: Op = 1 // matches no strings
+export const OpEmptyMatch: Op = 2 // matches empty string
+export const OpLiteral: Op = 3 // matches Runes sequence
+export const OpCharClass: Op = 4 // matches Runes interpreted as range pair list
+export const OpAnyCharNotNL: Op = 5 // matches any character except newline
+export const OpAnyChar: Op = 6 // matches any character
+export const OpBeginLine: Op = 7 // matches empty string at beginning of line
+export const OpEndLine: Op = 8 // matches empty string at end of line
+export const OpBeginText: Op = 9 // matches empty string at beginning of text
+export const OpEndText: Op = 10 // matches empty string at end of text
+export const OpWordBoundary: Op = 11 // matches word boundary `\b`
+export const OpNoWordBoundary: Op = 12 // matches word non-boundary `\B`
+export const OpCapture: Op = 13 // capturing subexpression with index Cap, optional name Name
+export const OpStar: Op = 14 // matches Sub[0] zero or more times
+export const OpPlus: Op = 15 // matches Sub[0] one or more times
+export const OpQuest: Op = 16 // matches Sub[0] zero or one times
+export const OpRepeat: Op = 17 // matches Sub[0] at least Min times, at most Max (Max == -1 is no limit)
+export const OpConcat: Op = 18 // matches concatenation of Subs
+export const OpAlternate: Op = 19 // matches alternation of Subs
+
+export const opPseudo: Op = 128 // where pseudo-ops start
+
+const opNames = [
+  'NoMatch',
+  'EmptyMatch',
+  'Literal',
+  'CharClass',
+  'AnyCharNotNL',
+  'AnyChar',
+  'BeginLine',
+  'EndLine',
+  'BeginText',
+  'EndText',
+  'WordBoundary',
+  'NoWordBoundary',
+  'Capture',
+  'Star',
+  'Plus',
+  'Quest',
+  'Repeat',
+  'Concat',
+  'Alternate',
+]
+
+export function Op_String(i: Op): string {
+  if (1 <= i && i <= 19) {
+    return opNames[i - 1]
+  }
+  if (i === opPseudo) {
+    return 'opPseudo'
+  }
+  return 'Op(' + i + ')'
+}
+
+$.registerNamedType(
+  'regexp/syntax.Op',
+  0,
+  [$.method('String', [], [stringType])],
+  { kind: $.TypeKind.Basic, name: 'uint8' },
+)
+
+// A Regexp is a node in a regular expression syntax tree.
+export class Regexp {
+  public Op: Op = 0
+  public Flags: Flags = 0
+  public Sub: Regexp[] = [] // subexpressions, if any
+  public Rune: number[] = [] // matched runes, for OpLiteral, OpCharClass
+  public Min = 0 // min for OpRepeat
+  public Max = 0 // max for OpRepeat
+  public Cap = 0 // capturing index, for OpCapture
+  public Name = '' // capturing name, for OpCapture
+
+  constructor(
+    init?: Partial<{
+      Op: Op
+      Flags: Flags
+      Sub: Regexp[]
+      Rune: number[]
+      Min: number
+      Max: number
+      Cap: number
+      Name: string
+    }>,
+  ) {
+    if (init) {
+      Object.assign(this, init)
+    }
+  }
+
+  public clone(): Regexp {
+    return new Regexp({
+      Op: this.Op,
+      Flags: this.Flags,
+      Sub: this.Sub.slice(),
+      Rune: this.Rune.slice(),
+      Min: this.Min,
+      Max: this.Max,
+      Cap: this.Cap,
+      Name: this.Name,
+    })
+  }
+
+  // Equal reports whether x and y have identical structure.
+  public Equal(y: Regexp | null): boolean {
+    const x = this
+    if (y === null) {
+      return false
+    }
+    if (x.Op !== y.Op) {
+      return false
+    }
+    switch (x.Op) {
+      case OpEndText:
+        // The parse flags remember whether this is \z or \Z.
+        if ((x.Flags & WasDollar) !== (y.Flags & WasDollar)) {
+          return false
+        }
+        break
+
+      case OpLiteral:
+      case OpCharClass:
+        return (
+          (x.Flags & FoldCase) === (y.Flags & FoldCase) &&
+          x.Rune.length === y.Rune.length &&
+          x.Rune.every((r, i) => r === y.Rune[i])
+        )
+
+      case OpAlternate:
+      case OpConcat:
+        return (
+          x.Sub.length === y.Sub.length &&
+          x.Sub.every((sub, i) => sub.Equal(y.Sub[i]))
+        )
+
+      case OpStar:
+      case OpPlus:
+      case OpQuest:
+        if (
+          (x.Flags & NonGreedy) !== (y.Flags & NonGreedy) ||
+          !x.Sub[0].Equal(y.Sub[0])
+        ) {
+          return false
+        }
+        break
+
+      case OpRepeat:
+        if (
+          (x.Flags & NonGreedy) !== (y.Flags & NonGreedy) ||
+          x.Min !== y.Min ||
+          x.Max !== y.Max ||
+          !x.Sub[0].Equal(y.Sub[0])
+        ) {
+          return false
+        }
+        break
+
+      case OpCapture:
+        if (x.Cap !== y.Cap || x.Name !== y.Name || !x.Sub[0].Equal(y.Sub[0])) {
+          return false
+        }
+        break
+    }
+    return true
+  }
+
+  // String returns a string representation of re.
+  public String(): string {
+    const re = this
+    const flags = new Map<Regexp, printFlags>()
+    let [must, cant] = calcFlags(re, flags)
+    must |= (cant & ~flagI) << negShift
+    if (must !== 0) {
+      must |= flagOff
+    }
+    const b: string[] = []
+    writeRegexp(b, re, must, flags)
+    return b.join('')
+  }
+
+  // MaxCap walks the regexp to find the maximum capture index.
+  public MaxCap(): number {
+    const re = this
+    let m = 0
+    if (re.Op === OpCapture) {
+      m = re.Cap
+    }
+    for (const sub of re.Sub) {
+      const n = sub.MaxCap()
+      if (m < n) {
+        m = n
+      }
+    }
+    return m
+  }
+
+  // CapNames walks the regexp to find the names of capturing groups.
+  public CapNames(): string[] {
+    const names = new Array<string>(this.MaxCap() + 1).fill('')
+    this.capNames(names)
+    return names
+  }
+
+  private capNames(names: string[]): void {
+    if (this.Op === OpCapture) {
+      names[this.Cap] = this.Name
+    }
+    for (const sub of this.Sub) {
+      sub.capNames(names)
+    }
+  }
+
+  // Simplify returns a regexp equivalent to re but without counted repetitions
+  // and with various other simplifications, such as rewriting /(?:a+)+/ to /a+/.
+  // The resulting regexp will execute correctly but its string representation
+  // will not produce the same parse tree, because capturing parentheses
+  // may have been duplicated or removed. For example, the simplified form
+  // for /(x){1,2}/ is /(x)(x)?/ but both parentheses capture as $1.
+  // The returned regexp may share structure with or be the original.
+  public Simplify(): Regexp {
+    return simplify(this)
+  }
+
+  // Register this type with the runtime type system
+  static __typeInfo = $.registerStructType(
+    'regexp/syntax.Regexp',
+    new Regexp(),
+    [
+      $.method('Equal', [regexpPtrType], [boolType]),
+      $.method('String', [], [stringType]),
+      $.method('MaxCap', [], [intType]),
+      $.method('CapNames', [], [stringsType]),
+      $.method('Simplify', [], [regexpPtrType]),
+    ],
+    Regexp,
+    {
+      Op: 'regexp/syntax.Op',
+      Flags: 'regexp/syntax.Flags',
+      Sub: { kind: $.TypeKind.Slice, elemType: regexpPtrType },
+      Rune: runesType,
+      Min: intType,
+      Max: intType,
+      Cap: intType,
+      Name: stringType,
+    },
+  )
+}
+
+// printFlags is a bit set indicating which flags (including non-capturing parens) to print around a regexp.
+type printFlags = number
+
+const flagI: printFlags = 1 << 0 // (?i:
+const flagM: printFlags = 1 << 1 // (?m:
+const flagS: printFlags = 1 << 2 // (?s:
+const flagOff: printFlags = 1 << 3 // )
+const flagPrec: printFlags = 1 << 4 // (?: )
+const negShift = 5 // flagI<<negShift is (?-i:
+
+// addSpan enables the flags f around start..last,
+// by setting flags[start] = f and flags[last] = flagOff.
+function addSpan(
+  start: Regexp,
+  last: Regexp,
+  f: printFlags,
+  flags: Map<Regexp, printFlags>,
+) {
+  flags.set(start, f)
+  flags.set(last, (flags.get(last) ?? 0) | flagOff) // maybe start==last
+}
+
+// inCharClass reports whether r is in the class.
+// It assumes the class has been cleaned by cleanClass.
+export function inCharClass(r: number, cls: number[]): boolean {
+  let lo = 0
+  let hi = cls.length / 2
+  while (lo < hi) {
+    const m = (lo + hi) >> 1
+    if (r > cls[2 * m + 1]) {
+      lo = m + 1
+    } else if (r < cls[2 * m]) {
+      hi = m
+    } else {
+      return true
+    }
+  }
+  return false
+}
+
+// calcFlags calculates the flags to print around each subexpression in re,
+// storing that information in flags as needed.
+// The return value is the flags that must be set around re (must)
+// and the flags that can't be set (cant).
+// See comment in writeRegexp for more.
+function calcFlags(
+  re: Regexp,
+  flags: Map<Regexp, printFlags>,
+): [printFlags, printFlags] {
+  switch (re.Op) {
+    default:
+      return [0, 0]
+
+    case OpLiteral:
+      // If literal is fold-sensitive, return (flagI, 0) or (0, flagI)
+      // according to whether (?i) is active.
+      // If literal is not fold-sensitive, return 0, 0.
+      for (const r of re.Rune) {
+        if (minFold <= r && r <= maxFold && simpleFold(r) !== r) {
+          if ((re.Flags & FoldCase) !== 0) {
+            return [flagI, 0]
+          } else {
+            return [0, flagI]
+          }
+        }
+      }
+      return [0, 0]
+
+    case OpCharClass:
+      // If literal is fold-sensitive, return 0, flagI - (?i) has been compiled out.
+      // If literal is not fold-sensitive, return 0, 0.
+      for (let i = 0; i < re.Rune.length; i += 2) {
+        const lo = Math.max(minFold, re.Rune[i])
+        const hi = Math.min(maxFold, re.Rune[i + 1])
+        for (let r = lo; r <= hi; r++) {
+          for (let f = simpleFold(r); f !== r; f = simpleFold(f)) {
+            if (!(lo <= f && f <= hi) && !inCharClass(f, re.Rune)) {
+              return [0, flagI]
+            }
+          }
+        }
+      }
+      return [0, 0]
+
+    case OpAnyCharNotNL: // (?-s).
+      return [0, flagS]
+
+    case OpAnyChar: // (?s).
+      return [flagS, 0]
+
+    case OpBeginLine:
+    case OpEndLine: // (?m)^ (?m)$
+      return [flagM, 0]
+
+    case OpEndText:
+      if ((re.Flags & WasDollar) !== 0) {
+        // (?-m)$
+        return [0, flagM]
+      }
+      return [0, 0]
+
+    case OpCapture:
+    case OpStar:
+    case OpPlus:
+    case OpQuest:
+    case OpRepeat:
+      return calcFlags(re.Sub[0], flags)
+
+    case OpConcat:
+    case OpAlternate: {
+      // Gather the must and cant for each subexpression.
+      // When we find a conflicting subexpression, insert the necessary
+      // flags around the previously identified span and start over.
+      let must = 0
+      let cant = 0
+      let allCant = 0
+      let start = 0
+      let last = 0
+      let did = false
+      for (let i = 0; i < re.Sub.length; i++) {
+        const [subMust, subCant] = calcFlags(re.Sub[i], flags)
+        if ((must & subCant) !== 0 || (subMust & cant) !== 0) {
+          if (must !== 0) {
+            addSpan(re.Sub[start], re.Sub[last], must, flags)
+          }
+          must = 0
+          cant = 0
+          start = i
+          did = true
+        }
+        must |= subMust
+        cant |= subCant
+        allCant |= subCant
+        if (subMust !== 0) {
+          last = i
+        }
+        if (must === 0 && start === i) {
+          start++
+        }
+      }
+      if (!did) {
+        // No conflicts: pass the accumulated must and cant upward.
+        return [must, cant]
+      }
+      if (must !== 0) {
+        // Conflicts found; need to finish final span.
+        addSpan(re.Sub[start], re.Sub[last], must, flags)
+      }
+      return [0, allCant]
+    }
+  }
+}
+
+// writeRegexp writes the Perl syntax for the regular expression re to b.
+function writeRegexp(
+  b: string[],
+  re: Regexp,
+  f: printFlags,
+  flags: Map<Regexp, printFlags>,
+) {
+  f |= flags.get(re) ?? 0
+  if (
+    (f & flagPrec) !== 0 &&
+    (f & ~(flagOff | flagPrec)) !== 0 &&
+    (f & flagOff) !== 0
+  ) {
+    // flagPrec is redundant with other flags being added and terminated
+    f &= ~flagPrec
+  }
+  if ((f & ~(flagOff | flagPrec)) !== 0) {
+    b.push('(?')
+    if ((f & flagI) !== 0) {
+      b.push('i')
+    }
+    if ((f & flagM) !== 0) {
+      b.push('m')
+    }
+    if ((f & flagS) !== 0) {
+      b.push('s')
+    }
+    if ((f & ((flagM | flagS) << negShift)) !== 0) {
+      b.push('-')
+      if ((f & (flagM << negShift)) !== 0) {
+        b.push('m')
+      }
+      if ((f & (flagS << negShift)) !== 0) {
+        b.push('s')
+      }
+    }
+    b.push(':')
+  }
+  if ((f & flagPrec) !== 0) {
+    b.push('(?:')
+  }
+
+  switch (re.Op) {
+    default:
+      b.push('<invalid op' + re.Op + '>')
+      break
+    case OpNoMatch:
+      b.push('[^\\x00-\\x{10FFFF}]')
+      break
+    case OpEmptyMatch:
+      b.push('(?:)')
+      break
+    case OpLiteral:
+      for (const r of re.Rune) {
+        escape(b, r, false)
+      }
+      break
+    case OpCharClass: {
+      if (re.Rune.length % 2 !== 0) {
+        b.push('[invalid char class]')
+        break
+      }
+      const rs = re.Rune
+      b.push('[')
+      if (rs.length === 0) {
+        b.push('^\\x00-\\x{10FFFF}')
+      } else if (
+        rs[0] === 0 &&
+        rs[rs.length - 1] === MaxRune &&
+        rs.length > 2
+      ) {
+        // Contains 0 and MaxRune. Probably a negated class.
+        // Print the gaps.
+        b.push('^')
+        for (let i = 1; i < rs.length - 1; i += 2) {
+          const lo = rs[i] + 1
+          const hi = rs[i + 1] - 1
+          writeRange(b, lo, hi)
+        }
+      } else {
+        for (let i = 0; i < rs.length; i += 2) {
+          writeRange(b, rs[i], rs[i + 1])
+        }
+      }
+      b.push(']')
+      break
+    }
+    case OpAnyCharNotNL:
+    case OpAnyChar:
+      b.push('.')
+      break
+    case OpBeginLine:
+      b.push('^')
+      break
+    case OpEndLine:
+      b.push('$')
+      break
+    case OpBeginText:
+      b.push('\\A')
+      break
+    case OpEndText:
+      if ((re.Flags & WasDollar) !== 0) {
+        b.push('$')
+      } else {
+        b.push('\\z')
+      }
+      break
+    case OpWordBoundary:
+      b.push('\\b')
+      break
+    case OpNoWordBoundary:
+      b.push('\\B')
+      break
+    case OpCapture:
+      if (re.Name !== '') {
+        b.push('(?P<', re.Name, '>')
+      } else {
+        b.push('(')
+      }
+      if (re.Sub[0].Op !== OpEmptyMatch) {
+        writeRegexp(b, re.Sub[0], flags.get(re.Sub[0]) ?? 0, flags)
+      }
+      b.push(')')
+      break
+    case OpStar:
+    case OpPlus:
+    case OpQuest:
+    case OpRepeat: {
+      let p: printFlags = 0
+      const sub = re.Sub[0]
+      if (sub.Op > OpCapture || (sub.Op === OpLiteral && sub.Rune.length > 1)) {
+        p = flagPrec
+      }
+      writeRegexp(b, sub, p, flags)
+
+      switch (re.Op) {
+        case OpStar:
+          b.push('*')
+          break
+        case OpPlus:
+          b.push('+')
+          break
+        case OpQuest:
+          b.push('?')
+          break
+        case OpRepeat:
+          b.push('{', String(re.Min))
+          if (re.Max !== re.Min) {
+            b.push(',')
+            if (re.Max >= 0) {
+              b.push(String(re.Max))
+            }
+          }
+          b.push('}')
+          break
+      }
+      if ((re.Flags & NonGreedy) !== 0) {
+        b.push('?')
+      }
+      break
+    }
+    case OpConcat:
+      for (const sub of re.Sub) {
+        let p: printFlags = 0
+        if (sub.Op === OpAlternate) {
+          p = flagPrec
+        }
+        writeRegexp(b, sub, p, flags)
+      }
+      break
+    case OpAlternate:
+      for (let i = 0; i < re.Sub.length; i++) {
+        if (i > 0) {
+          b.push('|')
+        }
+        writeRegexp(b, re.Sub[i], 0, flags)
+      }
+      break
+  }
+
+  if ((f & flagPrec) !== 0) {
+    b.push(')')
+  }
+  if ((f & flagOff) !== 0) {
+    b.push(')')
+  }
+}
+
+function writeRange(b: string[], lo: number, hi: number) {
+  escape(b, lo, lo === 0x2d)
+  if (lo !== hi) {
+    if (hi !== lo + 1) {
+      b.push('-')
+    }
+    escape(b, hi, hi === 0x2d)
+  }
+}
+
+const meta = '\\.+*?()|[]{}^$'
+
+function escape(b: string[], r: number, force: boolean) {
+  if (isPrint(r)) {
+    const s = String.fromCodePoint(r)
+    if (meta.includes(s) || force) {
+      b.push('\\')
+    }
+    b.push(s)
+    return
+  }
+
+  switch (r) {
+    case 0x07:
+      b.push('\\a')
+      break
+    case 0x0c:
+      b.push('\\f')
+      break
+    case 0x0a:
+      b.push('\\n')
+      break
+    case 0x0d:
+      b.push('\\r')
+      break
+    case 0x09:
+      b.push('\\t')
+      break
+    case 0x0b:
+      b.push('\\v')
+      break
+    default:
+      if (r < 0x100) {
+        b.push('\\x', r.toString(16).padStart(2, '0'))
+        break
+      }
+      b.push('\\x{', r.toString(16), '}')
+  }
+}
@@ -0,0 +1,169 @@
+import { Flags, NonGreedy } from './parse.js'
+import {
+  Op,
+  OpAlternate,
+  OpCapture,
+  OpConcat,
+  OpEmptyMatch,
+  OpNoMatch,
+  OpPlus,
+  OpQuest,
+  OpRepeat,
+  OpStar,
+  Regexp,
+} from './regexp.js'
+
+// simplify implements Regexp.Simplify.
+export function simplify(re: Regexp): Regexp {
+  switch (re.Op) {
+    case OpCapture:
+    case OpConcat:
+    case OpAlternate: {
+      // Simplify children, building new Regexp if children change.
+      let nre = re
+      for (let i = 0; i < re.Sub.length; i++) {
+        const sub = re.Sub[i]
+        const nsub = simplify(sub)
+        if (nre === re && nsub !== sub) {
+          // Start a copy.
+          nre = new Regexp({
+            Op: re.Op,
+            Flags: re.Flags,
+            Min: re.Min,
+            Max: re.Max,
+            Cap: re.Cap,
+            Name: re.Name,
+            Sub: re.Sub.slice(0, i),
+          })
+        }
+        if (nre !== re) {
+          nre.Sub.push(nsub)
+        }
+      }
+      return nre
+    }
+
+    case OpStar:
+    case OpPlus:
+    case OpQuest: {
+      const sub = simplify(re.Sub[0])
+      return simplify1(re.Op, re.Flags, sub, re)
+    }
+
+    case OpRepeat: {
+      // Special special case: x{0} matches the empty string
+      // and doesn't even need to consider x.
+      if (re.Min === 0 && re.Max === 0) {
+        return new Regexp({ Op: OpEmptyMatch })
+      }
+
+      // The fun begins.
+      const sub = simplify(re.Sub[0])
+
+      // x{n,} means at least n matches of x.
+      if (re.Max === -1) {
+        // Special case: x{0,} is x*.
+        if (re.Min === 0) {
+          return simplify1(OpStar, re.Flags, sub, null)
+        }
+
+        // Special case: x{1,} is x+.
+        if (re.Min === 1) {
+          return simplify1(OpPlus, re.Flags, sub, null)
+        }
+
+        // General case: x{4,} is xxxx+.
+        const nre = new Regexp({ Op: OpConcat })
+        for (let i = 0; i < re.Min - 1; i++) {
+          nre.Sub.push(sub)
+        }
+        nre.Sub.push(simplify1(OpPlus, re.Flags, sub, null))
+        return nre
+      }
+
+      // Special case x{0} handled above.
+
+      // Special case: x{1} is just x.
+      if (re.Min === 1 && re.Max === 1) {
+        return sub
+      }
+
+      // General case: x{n,m} means n copies of x and m copies of x?
+      // The machine will do less work if we nest the final m copies,
+      // so that x{2,5} = xx(x(x(x)?)?)?
+
+      // Build leading prefix: xx.
+      let prefix: Regexp | null = null
+      if (re.Min > 0) {
+        prefix = new Regexp({ Op: OpConcat })
+        for (let i = 0; i < re.Min; i++) {
+          prefix.Sub.push(sub)
+        }
+      }
+
+      // Build and attach suffix: (x(x(x)?)?)?
+      if (re.Max > re.Min) {
+        let suffix = simplify1(OpQuest, re.Flags, sub, null)
+        for (let i = re.Min + 1; i < re.Max; i++) {
+          const nre2 = new Regexp({ Op: OpConcat, Sub: [sub, suffix] })
+          suffix = simplify1(OpQuest, re.Flags, nre2, null)
+        }
+        if (prefix === null) {
+          return suffix
+        }
+        prefix.Sub.push(suffix)
+      }
+      if (prefix !== null) {
+        return prefix
+      }
+
+      // Some degenerate case like min > max or min < max < 0.
+      // Handle as impossible match.
+      return new Regexp({ Op: OpNoMatch })
+    }
+  }
+
+  return re
+}
+
+// simplify1 implements Simplify for the unary OpStar,
+// OpPlus, and OpQuest operators. It returns the simple regexp
+// equivalent to
+//
+//	Regexp{Op: op, Flags: flags, Sub: {sub}}
+//
+// under the assumption that sub is already simple, and
+// without first allocating that structure. If the regexp
+// to be returned turns out to be equivalent to re, simplify1
+// returns re instead.
+//
+// simplify1 is factored out of Simplify because the implementation
+// for other operators generates these unary expressions.
+// Letting them call simplify1 makes sure the expressions they
+// generate are simple.
+function simplify1(
+  op: Op,
+  flags: Flags,
+  sub: Regexp,
+  re: Regexp | null,
+): Regexp {
+  // Special case: repeat the empty string as much as
+  // you want, but it's going to match only once.
+  if (sub.Op === OpEmptyMatch) {
+    return sub
+  }
+  // The operators are idempotent if the flags match.
+  if (op === sub.Op && (flags & NonGreedy) === (sub.Flags & NonGreedy)) {
+    return sub
+  }
+  if (
+    re !== null &&
+    re.Op === op &&
+    (re.Flags & NonGreedy) === (flags & NonGreedy) &&
+    sub === re.Sub[0]
+  ) {
+    return re
+  }
+
+  return new Regexp({ Op: op, Flags: flags, Sub: [sub] })
+}
@@ -0,0 +1,60 @@
+import { describe, it, expect } from 'vitest'
+import {
+  Compile,
+  ErrInvalidRepeatOp,
+  Error as SyntaxError,
+  POSIX,
+  Parse,
+  Perl,
+} from './index.js'
+
+describe('regexp/syntax', () => {
+  it('parses, prints and simplifies like Go', () => {
+    const cases: [string, string, string][] = [
+      ['a{2,3}', 'a{2,3}', 'aaa?'],
+      ['(?i)abc', '(?i:ABC)', '(?i:ABC)'],
+      ['[a-c]|[d-f]', '[a-f]', '[a-f]'],
+      ['(?:ab)*c+?', '(?:ab)*c+?', '(?:ab)*c+?'],
+      ['x{2}y{0,}', 'x{2}y{0,}', 'xxy*'],
+    ]
+    for (const [expr, str, simple] of cases) {
+      const [re, err] = Parse(expr, Perl)
+      expect(err).toBeNull()
+      expect(re!.String()).toBe(str)
+      expect(re!.Simplify().String()).toBe(simple)
+      expect(re!.MaxCap()).toBe(0)
+    }
+  })
+
+  it('compiles programs', () => {
+    const [re] = Parse('(?P<n>a+)|b', Perl)
+    expect(re!.CapNames()).toEqual(['', 'n'])
+    const [prog, err] = Compile(re!.Simplify())
+    expect(err).toBeNull()
+    expect(prog!.String()).toBe(
+      '  0\tfail\n' +
+        '  1\tcap 2 -> 2\n' +
+        '  2\trune1 "a" -> 3\n' +
+        '  3\talt -> 2, 4\n' +
+        '  4\tcap 3 -> 7\n' +
+        '  5\trune1 "b" -> 7\n' +
+        '  6*\talt -> 1, 5\n' +
+        '  7\tmatch\n',
+    )
+    expect(prog!.Prefix()).toEqual(['', false])
+    expect(prog!.StartCond()).toBe(0)
+  })
+
+  it('reports errors with codes', () => {
+    const [, err] = Parse('a**', Perl)
+    expect(err).toBeInstanceOf(SyntaxError)
+    expect((err as SyntaxError).Code).toBe(ErrInvalidRepeatOp)
+    expect(err!.Error()).toBe(
+      'error parsing regexp: invalid nested repetition operator: `**`',
+    )
+    const [, posixErr] = Parse('\\pL', POSIX)
+    expect(posixErr!.Error()).toBe(
+      'error parsing regexp: invalid escape sequence: `\\p`',
+    )
+  })
+})
@@ -0,0 +1,28 @@
+import * as $ from '@goscript/builtin/index.js'
+
+// Type information for the methods of the syntax types.
+
+export const intType: $.TypeInfo = { kind: $.TypeKind.Basic, name: 'int' }
+export const runeType: $.TypeInfo = { kind: $.TypeKind.Basic, name: 'rune' }
+export const boolType: $.TypeInfo = { kind: $.TypeKind.Basic, name: 'bool' }
+export const stringType: $.TypeInfo = {
+  kind: $.TypeKind.Basic,
+  name: 'string',
+}
+export const stringsType: $.TypeInfo = {
+  kind: $.TypeKind.Slice,
+  elemType: stringType,
+}
+export const runesType: $.TypeInfo = {
+  kind: $.TypeKind.Slice,
+  elemType: runeType,
+}
+export const regexpPtrType: $.TypeInfo = {
+  kind: $.TypeKind.Pointer,
+  elemType: 'regexp/syntax.Regexp',
+}
+export const errorType: $.TypeInfo = {
+  kind: $.TypeKind.Interface,
+  name: 'GoError',
+  methods: [{ name: 'Error', args: [], returns: [{ type: stringType }] }],
+}
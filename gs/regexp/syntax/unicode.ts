@@ -0,0 +1,398 @@
+// Unicode tables for the parser.
+//
+// Go's regexp/syntax reads the tables of package unicode. Here the classes
+// are derived from the property escapes of JavaScript regular expressions
+// and the case folding orbits from the simple case folding that the
+// JavaScript engine applies under the i and u flags. Both are computed on
+// first use and cached.
+
+export const MaxRune = 0x10ffff
+
+// minimum and maximum runes involved in folding.
+export const minFold = 0x0041
+export const maxFold = 0x1e943
+
+// foldNext maps each rune that has case folding equivalents to the next
+// rune of its orbit, as unicode.SimpleFold does.
+let foldNext: Map<number, number> | null = null
+
+// singleRune returns the code point of s if s holds exactly one, or -1.
+function singleRune(s: string): number {
+  const c = s.codePointAt(0)!
+  return s.length === (c > 0xffff ? 2 : 1) ? c : -1
+}
+
+function buildFolds(): Map<number, number> {
+  // Group the runes by a representative of their orbit.
+  const groups = new Map<number, number[]>()
+  for (let c = minFold; c <= maxFold; c++) {
+    if (c >= 0xd800 && c <= 0xdfff) {
+      continue
+    }
+    const s = String.fromCodePoint(c)
+    const upper = s.toUpperCase()
+    const lower = s.toLowerCase()
+    if (upper === s && lower === s) {
+      continue
+    }
+    let key = singleRune(upper) >= 0 ? singleRune(upper.toLowerCase()) : -1
+    if (key < 0) {
+      key = singleRune(lower)
+    }
+    if (key < 0) {
+      continue
+    }
+    const group = groups.get(key)
+    if (group === undefined) {
+      groups.set(key, [c])
+    } else {
+      group.push(c)
+    }
+  }
+
+  // Keep the runes that really fold to the representative: the grouping
+  // above would also join runes like U+0131 (ı) and i, which only differ
+  // by a full, not a simple, case mapping.
+  const next = new Map<number, number>()
+  for (const [key, group] of groups) {
+    if (group.length < 2) {
+      continue
+    }
+    const re = new RegExp('^\\u{' + key.toString(16) + '}$', 'iu')
+    const orbit = group.filter(
+      (c) => c === key || re.test(String.fromCodePoint(c)),
+    )
+    if (orbit.length < 2) {
+      continue
+    }
+    for (let i = 0; i < orbit.length; i++) {
+      next.set(orbit[i], orbit[(i + 1) % orbit.length])
+    }
+  }
+  return next
+}
+
+// simpleFold iterates over Unicode code points equivalent under
+// the Unicode-defined simple case folding, like unicode.SimpleFold:
+// it returns the smallest rune > r in the orbit of r if one exists,
+// or else the smallest rune >= 0 in the orbit.
+export function simpleFold(r: number): number {
+  if (r < minFold || r > maxFold) {
+    return r
+  }
+  if (foldNext === null) {
+    foldNext = buildFolds()
+  }
+  return foldNext.get(r) ?? r
+}
+
+// isPrint reports whether r is printable, like unicode.IsPrint:
+// letters, marks, numbers, punctuation, symbols and the ASCII space.
+export function isPrint(r: number): boolean {
+  if (r === 0x20) {
+    return true
+  }
+  if (r < 0 || r > MaxRune || (r >= 0xd800 && r <= 0xdfff)) {
+    return false
+  }
+  return /^[\p{L}\p{M}\p{N}\p{P}\p{S}]$/u.test(String.fromCodePoint(r))
+}
+
+// Strings holding every code point, split at the surrogates, which can not
+// be matched on their own. The supplementary planes take two code units
+// per code point.
+let planes: [string, string, string] | null = null
+
+function codeUnits(lo: number, hi: number, astral: boolean): string {
+  const chunks: string[] = []
+  const buf = new Uint16Array(astral ? 8192 : 4096)
+  let n = 0
+  for (let c = lo; c <= hi; c++) {
+    if (astral) {
+      const v = c - 0x10000
+      buf[n++] = 0xd800 + (v >> 10)
+      buf[n++] = 0xdc00 + (v & 0x3ff)
+    } else {
+      buf[n++] = c
+    }
+    if (n === buf.length) {
+      chunks.push(String.fromCharCode(...buf))
+      n = 0
+    }
+  }
+  chunks.push(String.fromCharCode(...buf.subarray(0, n)))
+  return chunks.join('')
+}
+
+// propertyClass returns the runes matched by the JavaScript class
+// expression prop, like \p{Lu}, as a clean class of lo, hi pairs,
+// or null if the engine does not know the property.
+function propertyClass(prop: string): number[] | null {
+  let re: RegExp
+  try {
+    re = new RegExp('[' + prop + ']+', 'gu')
+  } catch {
+    return null
+  }
+  if (planes === null) {
+    planes = [
+      codeUnits(0, 0xd7ff, false),
+      codeUnits(0xe000, 0xffff, false),
+      codeUnits(0x10000, MaxRune, true),
+    ]
+  }
+  const r: number[] = []
+  const add = (lo: number, hi: number) => {
+    if (r.length > 0 && r[r.length - 1] + 1 === lo) {
+      r[r.length - 1] = hi
+    } else {
+      r.push(lo, hi)
+    }
+  }
+  const scan = (s: string, base: number, width: number) => {
+    re.lastIndex = 0
+    for (const m of s.matchAll(re)) {
+      const lo = base + m.index! / width
+      add(lo, lo + m[0].length / width - 1)
+    }
+  }
+  scan(planes[0], 0, 1)
+  if (new RegExp('^[' + prop + ']$', 'u').test('\ud800')) {
+    add(0xd800, 0xdfff)
+  }
+  scan(planes[1], 0xe000, 1)
+  scan(planes[2], 0x10000, 2)
+  return r
+}
+
+// Go's category and script names, which the JavaScript engine also uses.
+const categories = new Set(
+  (
+    'C Cc Cf Cn Co Cs L LC Ll Lm Lo Lt Lu M Mc Me Mn N Nd Nl No ' +
+    'P Pc Pd Pe Pf Pi Po Ps S Sc Sk Sm So Z Zl Zp Zs'
+  ).split(' '),
+)
+
+const categoryAliases: Record<string, string> = {
+  Cased_Letter: 'LC',
+  Close_Punctuation: 'Pe',
+  Combining_Mark: 'M',
+  Connector_Punctuation: 'Pc',
+  Control: 'Cc',
+  Currency_Symbol: 'Sc',
+  Dash_Punctuation: 'Pd',
+  Decimal_Number: 'Nd',
+  Enclosing_Mark: 'Me',
+  Final_Punctuation: 'Pf',
+  Format: 'Cf',
+  Initial_Punctuation: 'Pi',
+  Letter: 'L',
+  Letter_Number: 'Nl',
+  Line_Separator: 'Zl',
+  Lowercase_Letter: 'Ll',
+  Mark: 'M',
+  Math_Symbol: 'Sm',
+  Modifier_Letter: 'Lm',
+  Modifier_Symbol: 'Sk',
+  Nonspacing_Mark: 'Mn',
+  Number: 'N',
+  Open_Punctuation: 'Ps',
+  Other: 'C',
+  Other_Letter: 'Lo',
+  Other_Number: 'No',
+  Other_Punctuation: 'Po',
+  Other_Symbol: 'So',
+  Paragraph_Separator: 'Zp',
+  Private_Use: 'Co',
+  Punctuation: 'P',
+  Separator: 'Z',
+  Space_Separator: 'Zs',
+  Spacing_Mark: 'Mc',
+  Surrogate: 'Cs',
+  Symbol: 'S',
+  Titlecase_Letter: 'Lt',
+  Unassigned: 'Cn',
+  Uppercase_Letter: 'Lu',
+  cntrl: 'Cc',
+  digit: 'Nd',
+  punct: 'P',
+}
+
+const scripts = (
+  'Adlam Ahom Anatolian_Hieroglyphs Arabic Armenian Avestan Balinese ' +
+  'Bamum Bassa_Vah Batak Bengali Beria_Erfe Bhaiksuki Bopomofo Brahmi ' +
+  'Braille Buginese Buhid Canadian_Aboriginal Carian Caucasian_Albanian ' +
+  'Chakma Cham Cherokee Chorasmian Common Coptic Cuneiform Cypriot ' +
+  'Cypro_Minoan Cyrillic Deseret Devanagari Dives_Akuru Dogra Duployan ' +
+  'Egyptian_Hieroglyphs Elbasan Elymaic Ethiopic Garay Georgian ' +
+  'Glagolitic Gothic Grantha Greek Gujarati Gunjala_Gondi Gurmukhi ' +
+  'Gurung_Khema Han Hangul Hanifi_Rohingya Hanunoo Hatran Hebrew ' +
+  'Hiragana Imperial_Aramaic Inherited Inscriptional_Pahlavi ' +
+  'Inscriptional_Parthian Javanese Kaithi Kannada Katakana Kawi Kayah_Li ' +
+  'Kharoshthi Khitan_Small_Script Khmer Khojki Khudawadi Kirat_Rai Lao ' +
+  'Latin Lepcha Limbu Linear_A Linear_B Lisu Lycian Lydian Mahajani ' +
+  'Makasar Malayalam Mandaic Manichaean Marchen Masaram_Gondi Medefaidrin ' +
+  'Meetei_Mayek Mende_Kikakui Meroitic_Cursive Meroitic_Hieroglyphs Miao ' +
+  'Modi Mongolian Mro Multani Myanmar Nabataean Nag_Mundari Nandinagari ' +
+  'New_Tai_Lue Newa Nko Nushu Nyiakeng_Puachue_Hmong Ogham Ol_Chiki ' +
+  'Ol_Onal Old_Hungarian Old_Italic Old_North_Arabian Old_Permic ' +
+  'Old_Persian Old_Sogdian Old_South_Arabian Old_Turkic Old_Uyghur Oriya ' +
+  'Osage Osmanya Pahawh_Hmong Palmyrene Pau_Cin_Hau Phags_Pa Phoenician ' +
+  'Psalter_Pahlavi Rejang Runic Samaritan Saurashtra Sharada Shavian ' +
+  'Siddham Sidetic SignWriting Sinhala Sogdian Sora_Sompeng Soyombo ' +
+  'Sundanese Sunuwar Syloti_Nagri Syriac Tagalog Tagbanwa Tai_Le Tai_Tham ' +
+  'Tai_Viet Tai_Yo Takri Tamil Tangsa Tangut Telugu Thaana Thai Tibetan ' +
+  'Tifinagh Tirhuta Todhri Tolong_Siki Toto Tulu_Tigalari Ugaritic Vai ' +
+  'Vithkuqi Wancho Warang_Citi Yezidi Yi Zanabazar_Square'
+).split(' ')
+
+// aliases is a lazily constructed copy of the category aliases and scripts
+// but with the keys passed through canonicalName, to support inexact matches.
+let aliases: {
+  categories: Map<string, string>
+  scripts: Map<string, string>
+} | null = null
+
+function initAliases() {
+  aliases = { categories: new Map(), scripts: new Map() }
+  for (const name of Object.keys(categoryAliases)) {
+    aliases.categories.set(canonicalName(name), categoryAliases[name])
+  }
+  for (const name of scripts) {
+    aliases.scripts.set(canonicalName(name), name)
+  }
+}
+
+// canonicalName returns the canonical lookup string for name.
+// The canonical name has a leading uppercase letter and then lowercase letters,
+// and it omits all underscores, spaces, and hyphens.
+function canonicalName(name: string): string {
+  let b = ''
+  let first = true
+  for (const c of name) {
+    if (c === '_' || c === '-' || c === ' ') {
+      continue
+    }
+    if (first) {
+      b += 'a' <= c && c <= 'z' ? c.toUpperCase() : c
+      first = false
+    } else {
+      b += 'A' <= c && c <= 'Z' ? c.toLowerCase() : c
+    }
+  }
+  return b
+}
+
+// A unicodeTable is a class named by \p{...}, with the runes outside the
+// class that fold to runes in it, and the sign of the class.
+export interface unicodeTable {
+  tab: number[]
+  fold: number[] | null
+  sign: number
+}
+
+const tableCache = new Map<string, unicodeTable | null>()
+
+// foldClosure returns the runes outside the clean class tab that are
+// case folding equivalent to runes in it.
+function foldClosure(tab: number[]): number[] {
+  const extra: number[] = []
+  for (let i = 0; i < tab.length; i += 2) {
+    const lo = Math.max(tab[i], minFold)
+    const hi = Math.min(tab[i + 1], maxFold)
+    for (let c = lo; c <= hi; c++) {
+      for (let f = simpleFold(c); f !== c; f = simpleFold(f)) {
+        if (!inClass(f, tab)) {
+          extra.push(f, f)
+        }
+      }
+    }
+  }
+  return extra
+}
+
+function inClass(r: number, tab: number[]): boolean {
+  let lo = 0
+  let hi = tab.length / 2
+  while (lo < hi) {
+    const m = (lo + hi) >> 1
+    if (r < tab[2 * m]) {
+      hi = m
+    } else if (r > tab[2 * m + 1]) {
+      lo = m + 1
+    } else {
+      return true
+    }
+  }
+  return false
+}
+
+function makeTable(prop: string, sign: number): unicodeTable | null {
+  const tab = propertyClass(prop)
+  if (tab === null) {
+    return null
+  }
+  return { tab, fold: null, sign }
+}
+
+// lookupTable returns the unicodeTable identified by name.
+// If the result's sign is < 0, it should be inverted.
+export function lookupTable(name: string): unicodeTable | null {
+  name = canonicalName(name)
+  if (tableCache.has(name)) {
+    return tableCache.get(name)!
+  }
+
+  let t: unicodeTable | null = null
+  switch (name) {
+    case 'Any':
+      t = { tab: [0, MaxRune], fold: [], sign: +1 }
+      break
+    case 'Assigned':
+      t = makeTable('\\p{Cn}', -1) // invert Cn (unassigned)
+      break
+    case 'Ascii':
+      // Old English long s (ſ) and the Kelvin sign fold into ASCII.
+      t = { tab: [0, 0x7f], fold: [0x17f, 0x17f, 0x212a, 0x212a], sign: +1 }
+      break
+    case 'Lc':
+      t = makeTable('\\p{LC}', +1)
+      break
+    default: {
+      let actual = ''
+      let isScript = false
+      if (categories.has(name)) {
+        actual = name
+      } else if (scripts.includes(name)) {
+        actual = name
+        isScript = true
+      } else {
+        if (aliases === null) {
+          initAliases()
+        }
+        actual = aliases!.categories.get(name) ?? ''
+        if (actual === '') {
+          actual = aliases!.scripts.get(name) ?? ''
+          isScript = actual !== ''
+        }
+      }
+      if (actual !== '') {
+        const prop = isScript
+          ? '\\p{Script=' + actual + '}'
+          : '\\p{General_Category=' + actual + '}'
+        // Scripts newer than the JavaScript engine match nothing.
+        t = makeTable(prop, +1) ?? { tab: [], fold: [], sign: +1 }
+      }
+    }
+  }
+  tableCache.set(name, t)
+  return t
+}
+
+// foldTable returns the runes outside t that fold into it.
+export function foldTable(t: unicodeTable): number[] {
+  if (t.fold === null) {
+    t.fold = foldClosure(t.tab)
+  }
+  return t.fold
+}
@@ -0,0 +1,58 @@
+import * as $ from '@goscript/builtin/index.js'
+
+// Type information for the methods of the regexp types.
+
+export const intType: $.TypeInfo = { kind: $.TypeKind.Basic, name: 'int' }
+export const byteType: $.TypeInfo = { kind: $.TypeKind.Basic, name: 'byte' }
+export const boolType: $.TypeInfo = { kind: $.TypeKind.Basic, name: 'bool' }
+export const stringType: $.TypeInfo = {
+  kind: $.TypeKind.Basic,
+  name: 'string',
+}
+export const bytesType: $.TypeInfo = {
+  kind: $.TypeKind.Slice,
+  elemType: byteType,
+}
+export const intsType: $.TypeInfo = {
+  kind: $.TypeKind.Slice,
+  elemType: intType,
+}
+export const stringsType: $.TypeInfo = {
+  kind: $.TypeKind.Slice,
+  elemType: stringType,
+}
+export const bytesSliceType: $.TypeInfo = {
+  kind: $.TypeKind.Slice,
+  elemType: bytesType,
+}
+export const bytesSlice2Type: $.TypeInfo = {
+  kind: $.TypeKind.Slice,
+  elemType: bytesSliceType,
+}
+export const intsSliceType: $.TypeInfo = {
+  kind: $.TypeKind.Slice,
+  elemType: intsType,
+}
+export const stringsSliceType: $.TypeInfo = {
+  kind: $.TypeKind.Slice,
+  elemType: stringsType,
+}
+export const regexpPtrType: $.TypeInfo = {
+  kind: $.TypeKind.Pointer,
+  elemType: 'regexp.Regexp',
+}
+export const stringFuncType: $.TypeInfo = {
+  kind: $.TypeKind.Function,
+  params: [stringType],
+  results: [stringType],
+}
+export const bytesFuncType: $.TypeInfo = {
+  kind: $.TypeKind.Function,
+  params: [bytesType],
+  results: [bytesType],
+}
+export const errorType: $.TypeInfo = {
+  kind: $.TypeKind.Interface,
+  name: 'GoError',
+  methods: [{ name: 'Error', args: [], returns: [{ type: stringType }] }],
+}
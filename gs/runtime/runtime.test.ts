@@ -0,0 +1,51 @@
+import { describe, it, expect } from 'vitest'
+import * as $ from '@goscript/builtin/index.js'
+import * as runtime from './runtime.js'
+
+// callerLine returns the line the caller of callerLine runs at.
+function callerLine(): number {
+  return $.callers(callerLine)[0].line
+}
+
+describe('runtime', () => {
+  it('reports callers without Go source locations as unknown', () => {
+    expect(runtime.Caller(0)).toEqual([0, '', 0, false])
+    expect(runtime.FuncForPC(0)).toBe(null)
+  })
+
+  it('walks the frames of the callers', () => {
+    const pcs = [0, 0, 0, 0]
+    const n = runtime.Callers(0, pcs)
+    expect(n).toBeGreaterThan(1)
+    const frames = runtime.CallersFrames(pcs.slice(0, n))
+    const [frame, more] = frames.Next()
+    expect(frame.Function).toBe('runtime.Callers')
+    expect(frame.Func!.Name()).toBe('runtime.Callers')
+    expect(more).toBe(true)
+    const [caller] = frames.Next()
+    expect(caller.PC).toBe(pcs[1])
+    expect(caller.Line).toBeGreaterThan(0)
+  })
+
+  it('formats the stack of the calling goroutine', () => {
+    const buf = new Uint8Array(4096)
+    const n = runtime.Stack(buf, false)
+    const stack = new TextDecoder().decode(buf.subarray(0, n))
+    expect(stack).toMatch(/^goroutine 1 \[running\]:\n/)
+    expect(runtime.NumGoroutine()).toBe(1)
+  })
+
+  // Registering the module's source locations maps all of its frames, so
+  // this test runs last.
+  it('reports the caller', () => {
+    // Every line of this module maps to main.go:10 in main.main.
+    const files = ['example.com/m/main.go']
+    const funcs = ['main.main']
+    $.registerSourceFile(callerLine(), files, funcs, [1, 10, 0, 0])
+    const [pc, file, line, ok] = runtime.Caller(0)
+    expect([file, line, ok]).toEqual(['example.com/m/main.go', 10, true])
+    expect(runtime.FuncForPC(pc)!.Name()).toBe('main.main')
+    expect(runtime.FuncForPC(pc)!.FileLine(pc)).toEqual([file, line])
+    expect(runtime.Caller(1000)).toEqual([0, '', 0, false])
+  })
+})
@@ -1,3 +1,5 @@
+import * as $ from '@goscript/builtin/index.js'
+
 // Runtime constants for the JavaScript/WebAssembly target
 export const GOOS = 'js'
 export const GOARCH = 'wasm'
@@ -40,51 +42,159 @@ export function GC(): void {
 }
 
 // Gosched yields the processor, allowing other goroutines to run.
-// In JavaScript, we can use setTimeout(0) or queueMicrotask for similar effect
+// It yields to the macrotask queue, so timers and I/O callbacks run too.
 export function Gosched(): Promise<void> {
-  return new Promise((resolve) => {
-    queueMicrotask(resolve)
-  })
+  return $.gosched()
 }
 
 // NumGoroutine returns the number of goroutines that currently exist.
-// In goscript, this is informational only
-let goroutineCount = 1 // Start with main goroutine
-
 export function NumGoroutine(): number {
-  return goroutineCount
+  return $.numGoroutine()
+}
+
+// Program counters are not meaningful in JavaScript. The frames returned by
+// Caller and Callers are instead interned, and identified by their index
+// plus one, so that CallersFrames and FuncForPC can look them up.
+const pcFrames: $.StackFrame[] = []
+const pcIndex = new Map<string, number>()
+
+// framePC returns the program counter identifying the frame f.
+function framePC(f: $.StackFrame): number {
+  const key = `${f.function}\n${f.file}\n${f.line}`
+  let pc = pcIndex.get(key)
+  if (pc === undefined) {
+    pcFrames.push(f)
+    pc = pcFrames.length
+    pcIndex.set(key, pc)
+  }
+  return pc
+}
+
+// Caller reports file and line number information about function invocations on
+// the calling goroutine's stack. The argument skip is the number of stack frames
+// to ascend, with 0 identifying the caller of Caller.
+//
+// Go files and lines are reported for code compiled with source locations
+// that runs with the line numbers it was generated with. Otherwise, as when
+// the code is bundled, the location is unknown and ok is false.
+export function Caller(skip: number): [number, string, number, boolean] {
+  const f = $.callers(Caller)[skip]
+  if (f === undefined || !f.mapped) {
+    return [0, '', 0, false]
+  }
+  return [framePC(f), f.file, f.line, true]
 }
 
-// Internal function to track goroutine creation (called by goscript runtime)
-export function _incrementGoroutineCount(): void {
-  goroutineCount++
+// Callers fills the slice pc with the return program counters of function
+// invocations on the calling goroutine's stack. The argument skip is the number
+// of stack frames to skip before recording in pc, with 0 identifying the frame
+// for Callers itself and 1 identifying the caller of Callers.
+// It returns the number of entries written to pc.
+export function Callers(skip: number, pc: $.Slice<number>): number {
+  const frames = $.callers(Callers)
+  frames.unshift({
+    function: 'runtime.Callers',
+    file: '',
+    line: 0,
+    mapped: false,
+  })
+  return $.copy(pc, frames.slice(skip).map(framePC))
 }
 
-// Internal function to track goroutine completion (called by goscript runtime)
-export function _decrementGoroutineCount(): void {
-  if (goroutineCount > 0) {
-    goroutineCount--
+// Frame is the information returned by Frames for each call frame.
+export class Frame {
+  // PC is the program counter for the location in this frame.
+  public PC: number = 0
+  // Func for this frame, or null if unknown.
+  public Func: Func | null = null
+  // Function is the package path-qualified function name of this call frame.
+  public Function: string = ''
+  // File and Line are the file name and line number of the location in this
+  // frame.
+  public File: string = ''
+  public Line: number = 0
+  // Entry point program counter for the function, identical to PC as
+  // frames are not split into functions.
+  public Entry: number = 0
+
+  constructor(init?: Partial<Frame>) {
+    Object.assign(this, init)
+  }
+
+  public clone(): Frame {
+    return new Frame(this)
+  }
+}
+
+// Frames may be used to get function/file/line information for a slice of PC
+// values returned by Callers.
+export class Frames {
+  constructor(private callers: number[]) {}
+
+  // Next returns a Frame representing the next call frame in the slice of PC
+  // values, and whether there are more frames after it.
+  public Next(): [Frame, boolean] {
+    const pc = this.callers.shift()
+    const f = pc === undefined ? undefined : pcFrames[pc - 1]
+    if (pc === undefined || f === undefined) {
+      return [new Frame(), false]
+    }
+    const frame = new Frame({
+      PC: pc,
+      Func: new Func(pc),
+      Function: f.function,
+      File: f.file,
+      Line: f.line,
+      Entry: pc,
+    })
+    return [frame, this.callers.length !== 0]
+  }
+}
+
+// CallersFrames takes a slice of PCs returned by Callers and prepares to
+// return function/file/line information.
+export function CallersFrames(callers: $.Slice<number>): Frames {
+  const pcs: number[] = []
+  for (let i = 0; i < $.len(callers); i++) {
+    pcs.push(callers![i])
+  }
+  return new Frames(pcs)
+}
+
+// Func represents a function in the running binary.
+export class Func {
+  constructor(private pc: number) {}
+
+  // Name returns the name of the function.
+  public Name(): string {
+    return pcFrames[this.pc - 1]?.function ?? ''
+  }
+
+  // Entry returns the entry address of the function.
+  public Entry(): number {
+    return this.pc
+  }
+
+  // FileLine returns the file name and line number of the source code
+  // corresponding to the program counter pc.
+  public FileLine(pc: number): [string, number] {
+    const f = pcFrames[pc - 1]
+    return f === undefined ? ['', 0] : [f.file, f.line]
   }
 }
 
-// Caller returns details about the calling goroutine's stack.
-// This is a simplified version for goscript
-export function Caller(_skip: number): [number, string, number, boolean] {
-  // In JavaScript, we can use Error stack trace, but it's limited
-  // Return dummy values for goscript compatibility
-  const pc = 0 // program counter (not meaningful in JS)
-  const file = 'unknown'
-  const line = 0
-  const ok = false // indicate we don't have real stack info
-  return [pc, file, line, ok]
+// FuncForPC returns a *Func describing the function that contains the
+// given program counter address, or else nil.
+export function FuncForPC(pc: number): Func | null {
+  return pcFrames[pc - 1] === undefined ? null : new Func(pc)
 }
 
-// Stack returns a formatted stack trace of the calling goroutine.
-// In JavaScript, we use Error.stack
-export function Stack(): Uint8Array {
-  const stack = new Error().stack || 'stack trace unavailable'
-  const encoder = new TextEncoder()
-  return encoder.encode(stack)
+// Stack formats a stack trace of the calling goroutine into buf
+// and returns the number of bytes written to buf.
+// If all is true, Stack formats stack traces of all other goroutines
+// into buf after the trace for the current goroutine.
+export function Stack(buf: $.Bytes, all: boolean): number {
+  return $.copy(buf, $.goroutineStack(Stack, all))
 }
 
 // MemStats represents memory allocation statistics
@@ -64,7 +64,7 @@ export class NumError {
 
 	// Register this type with the runtime type system
 	static __typeInfo = $.registerStructType(
-		'NumError',
+		'strconv.NumError',
 		new NumError(),
 		[{ name: "Error", args: [], returns: [{ type: { kind: $.TypeKind.Basic, name: "string" } }] }, { name: "Unwrap", args: [], returns: [{ type: { kind: $.TypeKind.Interface, name: 'GoError', methods: [{ name: 'Error', args: [], returns: [{ type: { kind: $.TypeKind.Basic, name: 'string' } }] }] } }] }],
 		NumError,
@@ -98,9 +98,16 @@ function lower(c: number): number {
 
 // ParseUint is like ParseInt but for unsigned numbers.
 // A sign prefix is not permitted.
-export function ParseUint(s: string, base: number, bitSize: number): [number, $.GoError] {
+// The result is a bigint when 64-bit integers are represented as bigint.
+export function ParseUint(s: string, base: number, bitSize: number): [any, $.GoError] {
+	const [un, err] = parseUint(s, base, bitSize);
+	return [$.int64Result(un), err];
+}
+
+// parseUint implements ParseUint with exact 64-bit arithmetic.
+function parseUint(s: string, base: number, bitSize: number): [bigint, $.GoError] {
 	if (s === "") {
-		return [0, syntaxError("ParseUint", s)];
+		return [0n, syntaxError("ParseUint", s)];
 	}
 
 	const base0 = base === 0;
@@ -108,114 +115,123 @@ export function ParseUint(s: string, base: number, bitSize: number): [number, $.
 
 	// Handle base validation
 	if (base < 0 || base === 1 || base > 36) {
-		return [0, baseError("ParseUint", s0, base)];
+		return [0n, baseError("ParseUint", s0, base)];
 	}
 
 	// Handle base inference
 	if (base === 0) {
 		base = 10;
-		if (s.length >= 3) {
-			if (s[0] === '0') {
-				const prefix = s[1].toLowerCase();
-				if (prefix === 'b') {
-					base = 2;
-					s = s.slice(2);
-				} else if (prefix === 'o') {
-					base = 8;
-					s = s.slice(2);
-				} else if (prefix === 'x') {
-					base = 16;
-					s = s.slice(2);
-				} else {
-					base = 8;
-					s = s.slice(1);
-				}
+		if (s[0] === '0') {
+			if (s.length >= 3 && lower(s.charCodeAt(1)) === 98) { // 'b'
+				base = 2;
+				s = s.slice(2);
+			} else if (s.length >= 3 && lower(s.charCodeAt(1)) === 111) { // 'o'
+				base = 8;
+				s = s.slice(2);
+			} else if (s.length >= 3 && lower(s.charCodeAt(1)) === 120) { // 'x'
+				base = 16;
+				s = s.slice(2);
+			} else {
+				base = 8;
+				s = s.slice(1);
 			}
-		} else if (s.length >= 2 && s[0] === '0') {
-			base = 8;
-			s = s.slice(1);
 		}
 	}
 
 	// Validate bitSize
 	if (bitSize === 0) {
-		bitSize = 64;
+		bitSize = IntSize;
 	} else if (bitSize < 0 || bitSize > 64) {
-		return [0, bitSizeError("ParseUint", s0, bitSize)];
+		return [0n, bitSizeError("ParseUint", s0, bitSize)];
 	}
 
-	// Check for underscores only if base0 (auto-detected base)
-	if (base0 && s.includes('_')) {
-		if (!underscoreOK(s)) {
-			return [0, syntaxError("ParseUint", s0)];
+	const maxVal = (1n << BigInt(bitSize)) - 1n;
+	const bigBase = BigInt(base);
+	let underscores = false;
+	let n = 0n;
+	for (let i = 0; i < s.length; i++) {
+		const c = s.charCodeAt(i);
+		let d: number;
+		if (c === 95 && base0) { // '_'
+			underscores = true;
+			continue;
+		} else if (c >= 48 && c <= 57) { // '0'-'9'
+			d = c - 48;
+		} else if (lower(c) >= 97 && lower(c) <= 122) { // 'a'-'z'
+			d = lower(c) - 97 + 10;
+		} else {
+			return [0n, syntaxError("ParseUint", s0)];
+		}
+		if (d >= base) {
+			return [0n, syntaxError("ParseUint", s0)];
+		}
+		n = n * bigBase + BigInt(d);
+		if (n > maxVal) {
+			// n overflows the bit size
+			return [maxVal, rangeError("ParseUint", s0)];
 		}
-		s = s.replace(/_/g, '');
-	}
-
-	// Use JavaScript's parseInt
-	const result = parseInt(s, base);
-	
-	if (isNaN(result) || !isFinite(result)) {
-		return [0, syntaxError("ParseUint", s0)];
-	}
-
-	if (result < 0) {
-		return [0, syntaxError("ParseUint", s0)];
 	}
 
-	// Check range for the specified bit size
-	const maxVal = (1 << bitSize) - 1;
-	if (result > maxVal) {
-		return [0, rangeError("ParseUint", s0)];
+	if (underscores && !underscoreOK(s0)) {
+		return [0n, syntaxError("ParseUint", s0)];
 	}
 
-	return [result, null];
+	return [n, null];
 }
 
 // ParseInt interprets a string s in the given base (0, 2 to 36) and
 // bit size (0 to 64) and returns the corresponding value i.
-export function ParseInt(s: string, base: number, bitSize: number): [number, $.GoError] {
+// The result is a bigint when 64-bit integers are represented as bigint.
+export function ParseInt(s: string, base: number, bitSize: number): [any, $.GoError] {
+	const [i, err] = parseInt64(s, base, bitSize);
+	return [$.int64Result(i), err];
+}
+
+// parseInt64 implements ParseInt with exact 64-bit arithmetic.
+function parseInt64(s: string, base: number, bitSize: number): [bigint, $.GoError] {
 	if (s === "") {
-		return [0, syntaxError("ParseInt", s)];
+		return [0n, syntaxError("ParseInt", s)];
 	}
 
+	// Pick off leading sign.
+	const s0 = s;
 	let neg = false;
 	if (s[0] === '+' || s[0] === '-') {
 		neg = s[0] === '-';
 		s = s.slice(1);
-		if (s.length < 1) {
-			return [0, syntaxError("ParseInt", s)];
-		}
 	}
 
-	// Convert to unsigned first
-	const [un, err] = ParseUint(s, base, bitSize);
-	if (err !== null) {
+	// Convert unsigned and check range.
+	const [un, err] = parseUint(s, base, bitSize);
+	if (err !== null && (err as NumError).Err !== ErrRange) {
 		const numErr = err as NumError;
 		numErr.Func = "ParseInt";
-		return [0, err];
+		numErr.Num = s0;
+		return [0n, err];
 	}
 
 	if (bitSize === 0) {
-		bitSize = 64;
+		bitSize = IntSize;
 	}
 
-	const cutoff = 1 << (bitSize - 1);
+	const cutoff = 1n << BigInt(bitSize - 1);
 	if (!neg && un >= cutoff) {
-		return [0, rangeError("ParseInt", s)];
+		return [cutoff - 1n, rangeError("ParseInt", s0)];
 	}
 	if (neg && un > cutoff) {
-		return [0, rangeError("ParseInt", s)];
+		return [-cutoff, rangeError("ParseInt", s0)];
 	}
 
-	const result = neg ? -un : un;
-	return [result, null];
+	return [neg ? -un : un, null];
 }
 
 // Atoi is equivalent to ParseInt(s, 10, 0), converted to type int.
 export function Atoi(s: string): [number, $.GoError] {
-	const [i64, err] = ParseInt(s, 10, 0);
-	return [i64, err];
+	const [i64, err] = parseInt64(s, 10, 0);
+	if (err !== null) {
+		(err as NumError).Func = "Atoi";
+	}
+	return [Number(i64), err];
 }
 
 // underscoreOK reports whether the underscores in s are allowed.
@@ -1,14 +1,34 @@
 import * as $ from "@goscript/builtin/index.js";
 
+// decimalDigits is a decimal representation of a non-negative float:
+// the value is 0.d[0]d[1]...d[nd-1] * 10^dp. Zero has no digits.
+interface decimalDigits {
+	d: string;
+	dp: number;
+}
+
 // FormatFloat converts the floating-point number f to a string,
 // according to the format fmt and precision prec. It rounds the
 // result assuming that the original was obtained from a floating-point
 // value of bitSize bits (32 for float32, 64 for float64).
+//
+// The format fmt is one of
+// 'b' (-ddddp±ddd, a binary exponent),
+// 'e' (-d.dddde±dd, a decimal exponent),
+// 'E' (-d.ddddE±dd, a decimal exponent),
+// 'f' (-ddd.dddd, no exponent),
+// 'g' ('e' for large exponents, 'f' otherwise),
+// 'G' ('E' for large exponents, 'f' otherwise),
+// 'x' (-0xd.ddddp±ddd, a hexadecimal fraction and binary exponent), or
+// 'X' (-0Xd.ddddP±ddd, a hexadecimal fraction and binary exponent).
+//
+// The special precision -1 uses the smallest number of digits
+// necessary such that ParseFloat will return f exactly.
 export function FormatFloat(f: number, fmt: number, prec: number, bitSize: number): string {
-	const fmtChar = String.fromCharCode(fmt);
-	
-	// Handle special cases
-	if (isNaN(f)) {
+	if (bitSize === 32) {
+		f = Math.fround(f);
+	}
+	if (Number.isNaN(f)) {
 		return "NaN";
 	}
 	if (f === Infinity) {
@@ -17,45 +37,57 @@ export function FormatFloat(f: number, fmt: number, prec: number, bitSize: numbe
 	if (f === -Infinity) {
 		return "-Inf";
 	}
-	
-	// Convert to appropriate precision for float32
-	if (bitSize === 32) {
-		f = Math.fround(f);
+
+	const neg = f < 0 || Object.is(f, -0);
+	const fmtChar = String.fromCharCode(fmt);
+	if (fmtChar === "b") {
+		return (neg ? "-" : "") + fmtB(Math.abs(f), bitSize);
 	}
-	
-	switch (fmtChar.toLowerCase()) {
-		case 'e':
-			// Exponential notation
-			if (prec < 0) {
-				return f.toExponential();
-			}
-			return f.toExponential(prec);
-			
-		case 'f':
-			// Fixed-point notation
-			if (prec < 0) {
-				return f.toString();
-			}
-			return f.toFixed(prec);
-			
-		case 'g':
-			// Use the more compact of 'e' or 'f'
-			if (prec < 0) {
-				return f.toPrecision();
-			}
-			return f.toPrecision(prec + 1);
-			
-		case 'x':
-			// Hexadecimal notation (simplified)
-			return f.toString(16);
-			
-		default:
-			// Default to 'g' format
-			if (prec < 0) {
-				return f.toString();
-			}
-			return f.toPrecision(prec + 1);
+	if (fmtChar === "x" || fmtChar === "X") {
+		return fmtX(Math.abs(f), prec, fmtChar, neg, bitSize);
+	}
+	if (!"eEfgG".includes(fmtChar)) {
+		return "%" + fmtChar;
+	}
+
+	const abs = Math.abs(f);
+	let digs: decimalDigits;
+	const shortest = prec < 0;
+	if (shortest) {
+		digs = shortestDecimal(abs, bitSize);
+		switch (fmtChar) {
+			case "e":
+			case "E":
+				prec = Math.max(digs.d.length - 1, 0);
+				break;
+			case "f":
+				prec = Math.max(digs.d.length - digs.dp, 0);
+				break;
+			case "g":
+			case "G":
+				prec = digs.d.length;
+				break;
+		}
+	} else {
+		digs = exactDecimal(abs);
+		switch (fmtChar) {
+			case "e":
+			case "E":
+				digs = roundDecimal(digs, prec + 1);
+				break;
+			case "f":
+				digs = roundDecimal(digs, digs.dp + prec);
+				break;
+			case "g":
+			case "G":
+				if (prec === 0) {
+					prec = 1;
+				}
+				digs = roundDecimal(digs, prec);
+				break;
+		}
 	}
+	return formatDigits(neg, digs, prec, fmtChar, shortest);
 }
 
 // AppendFloat appends the string form of the floating-point number f,
@@ -63,4 +95,238 @@ export function FormatFloat(f: number, fmt: number, prec: number, bitSize: numbe
 export function AppendFloat(dst: $.Bytes, f: number, fmt: number, prec: number, bitSize: number): $.Bytes {
 	const str = FormatFloat(f, fmt, prec, bitSize);
 	return $.append(dst, ...$.stringToBytes(str)!);
-} 
\ No newline at end of file
+}
+
+// formatDigits writes the digits of a decimal in the given format.
+function formatDigits(neg: boolean, digs: decimalDigits, prec: number, fmt: string, shortest: boolean): string {
+	switch (fmt) {
+		case "e":
+		case "E":
+			return fmtE(neg, digs, prec, fmt);
+		case "f":
+			return fmtF(neg, digs, prec);
+	}
+	// %e is used if the exponent from the conversion
+	// is less than -4 or greater than or equal to the precision.
+	// if precision was the shortest possible, use precision 6 for this decision.
+	const nd = digs.d.length;
+	let eprec = prec;
+	if (eprec > nd && nd >= digs.dp) {
+		eprec = nd;
+	}
+	if (shortest) {
+		eprec = 6;
+	}
+	const exp = digs.dp - 1;
+	if (exp < -4 || exp >= eprec) {
+		if (prec > nd) {
+			prec = nd;
+		}
+		return fmtE(neg, digs, prec - 1, fmt === "g" ? "e" : "E");
+	}
+	if (prec > digs.dp) {
+		prec = nd;
+	}
+	return fmtF(neg, digs, Math.max(prec - digs.dp, 0));
+}
+
+// fmtE formats a decimal as -d.ddddde±dd.
+function fmtE(neg: boolean, digs: decimalDigits, prec: number, fmt: string): string {
+	let s = neg ? "-" : "";
+	const nd = digs.d.length;
+	s += nd === 0 ? "0" : digs.d[0];
+	if (prec > 0) {
+		s += ".";
+		const end = Math.min(nd, prec + 1);
+		if (end > 1) {
+			s += digs.d.slice(1, end);
+		}
+		s += "0".repeat(prec + 1 - Math.max(end, 1));
+	}
+	s += fmt;
+	let exp = nd === 0 ? 0 : digs.dp - 1;
+	if (exp < 0) {
+		s += "-";
+		exp = -exp;
+	} else {
+		s += "+";
+	}
+	return s + (exp < 10 ? "0" + exp : String(exp));
+}
+
+// fmtF formats a decimal as -ddddddd.ddddd.
+function fmtF(neg: boolean, digs: decimalDigits, prec: number): string {
+	let s = neg ? "-" : "";
+	const nd = digs.d.length;
+	if (digs.dp > 0) {
+		const m = Math.min(nd, digs.dp);
+		s += digs.d.slice(0, m) + "0".repeat(digs.dp - m);
+	} else {
+		s += "0";
+	}
+	if (prec > 0) {
+		s += ".";
+		for (let i = 1; i <= prec; i++) {
+			const j = digs.dp + i - 1;
+			s += j >= 0 && j < nd ? digs.d[j] : "0";
+		}
+	}
+	return s;
+}
+
+// floatBits returns the mantissa, including the implicit bit, and the
+// unbiased binary exponent of a non-negative finite float.
+function floatBits(f: number, bitSize: number): [bigint, number] {
+	const mantbits = bitSize === 32 ? 23 : 52;
+	const bias = bitSize === 32 ? -127 : -1023;
+	let bits: bigint;
+	if (bitSize === 32) {
+		const view = new DataView(new ArrayBuffer(4));
+		view.setFloat32(0, f);
+		bits = BigInt(view.getUint32(0));
+	} else {
+		const view = new DataView(new ArrayBuffer(8));
+		view.setFloat64(0, f);
+		bits = view.getBigUint64(0);
+	}
+	let exp = Number(bits >> BigInt(mantbits)) & (bitSize === 32 ? 0xff : 0x7ff);
+	let mant = bits & ((1n << BigInt(mantbits)) - 1n);
+	if (exp === 0) {
+		exp++;
+	} else {
+		mant |= 1n << BigInt(mantbits);
+	}
+	return [mant, exp + bias];
+}
+
+// fmtB formats a float as ddddp±ddd, a decimal mantissa and a binary exponent.
+function fmtB(f: number, bitSize: number): string {
+	const [mant, exp] = floatBits(f, bitSize);
+	const e = exp - (bitSize === 32 ? 23 : 52);
+	return mant.toString() + "p" + (e >= 0 ? "+" : "") + e;
+}
+
+// fmtX formats a float as -0xd.ddddp±ddd, a hexadecimal fraction and a
+// binary exponent.
+function fmtX(f: number, prec: number, fmt: string, neg: boolean, bitSize: number): string {
+	const mantbits = bitSize === 32 ? 23 : 52;
+	let [mant, exp] = floatBits(f, bitSize);
+	if (mant === 0n) {
+		exp = 0;
+	}
+	const mask64 = (1n << 64n) - 1n;
+	// Shift digits so leading 1 (if any) is at bit 1<<60.
+	mant <<= BigInt(60 - mantbits);
+	while (mant !== 0n && (mant & (1n << 60n)) === 0n) {
+		mant <<= 1n;
+		exp--;
+	}
+	// Round if requested.
+	if (prec >= 0 && prec < 15) {
+		const shift = BigInt(prec * 4);
+		const extra = (mant << shift) & ((1n << 60n) - 1n);
+		mant >>= 60n - shift;
+		if ((extra | (mant & 1n)) > 1n << 59n) {
+			mant++;
+		}
+		mant <<= 60n - shift;
+		if ((mant & (1n << 61n)) !== 0n) {
+			// Wrapped around.
+			mant >>= 1n;
+			exp++;
+		}
+	}
+	const hex = fmt === "X" ? "0123456789ABCDEF" : "0123456789abcdef";
+	let s = (neg ? "-" : "") + "0" + fmt + String(Number((mant >> 60n) & 1n));
+	// .fraction
+	mant = (mant << 4n) & mask64; // remove leading 0 or 1
+	if (prec < 0 && mant !== 0n) {
+		s += ".";
+		while (mant !== 0n) {
+			s += hex[Number((mant >> 60n) & 15n)];
+			mant = (mant << 4n) & mask64;
+		}
+	} else if (prec > 0) {
+		s += ".";
+		for (let i = 0; i < prec; i++) {
+			s += hex[Number((mant >> 60n) & 15n)];
+			mant = (mant << 4n) & mask64;
+		}
+	}
+	s += fmt === "X" ? "P" : "p";
+	if (exp < 0) {
+		s += "-";
+		exp = -exp;
+	} else {
+		s += "+";
+	}
+	return s + (exp < 10 ? "0" + exp : String(exp));
+}
+
+// exactDecimal returns the exact decimal digits of a non-negative float.
+function exactDecimal(f: number): decimalDigits {
+	if (f === 0) {
+		return { d: "", dp: 0 };
+	}
+	const [mant, exp] = floatBits(f, 64);
+	const e = exp - 52;
+	let s: string;
+	let dp: number;
+	if (e >= 0) {
+		s = (mant << BigInt(e)).toString();
+		dp = s.length;
+	} else {
+		// mant * 2^e = mant * 5^-e / 10^-e
+		s = (mant * 5n ** BigInt(-e)).toString();
+		dp = s.length + e;
+	}
+	return { d: s.replace(/0+$/, ""), dp };
+}
+
+// shortestDecimal returns the shortest decimal digits that parse back to the
+// non-negative float f at the given bit size.
+function shortestDecimal(f: number, bitSize: number): decimalDigits {
+	if (f === 0) {
+		return { d: "", dp: 0 };
+	}
+	let s = f.toExponential();
+	if (bitSize === 32) {
+		for (let p = 0; p < 9; p++) {
+			const t = f.toExponential(p);
+			if (Math.fround(Number(t)) === f) {
+				s = t;
+				break;
+			}
+		}
+	}
+	const [mant, exp] = s.split("e");
+	return { d: mant.replace(".", "").replace(/0+$/, ""), dp: Number(exp) + 1 };
+}
+
+// roundDecimal rounds a decimal to nd digits, rounding halfway cases to even.
+function roundDecimal(digs: decimalDigits, nd: number): decimalDigits {
+	const d = digs.d;
+	if (nd < 0 || nd >= d.length) {
+		return digs;
+	}
+	let up: boolean;
+	if (d[nd] === "5" && nd + 1 === d.length) {
+		// exactly halfway - round to even
+		up = nd > 0 && (d.charCodeAt(nd - 1) - 48) % 2 === 1;
+	} else {
+		up = d[nd] >= "5";
+	}
+	if (!up) {
+		return { d: d.slice(0, nd).replace(/0+$/, ""), dp: digs.dp };
+	}
+	// round up, carrying through nines
+	let i = nd - 1;
+	while (i >= 0 && d[i] === "9") {
+		i--;
+	}
+	if (i < 0) {
+		// all nines: becomes 1 followed by zeros
+		return { d: "1", dp: digs.dp + 1 };
+	}
+	return { d: d.slice(0, i) + String.fromCharCode(d.charCodeAt(i) + 1), dp: digs.dp };
+}
@@ -3,21 +3,27 @@ import * as $ from "@goscript/builtin/index.js";
 // FormatUint returns the string representation of i in the given base,
 // for 2 <= base <= 36. The result uses the lower-case letters 'a' to 'z'
 // for digit values >= 10.
-export function FormatUint(i: number, base: number): string {
+export function FormatUint(i: number | bigint, base: number): string {
 	if (base < 2 || base > 36) {
 		throw new Error("FormatUint: illegal base");
 	}
 	// JavaScript's toString() handles bases 2-36 natively
+	if (typeof i === "bigint") {
+		return BigInt.asUintN(64, i).toString(base);
+	}
 	return Math.floor(Math.abs(i)).toString(base);
 }
 
 // FormatInt returns the string representation of i in the given base,
 // for 2 <= base <= 36. The result uses the lower-case letters 'a' to 'z'
 // for digit values >= 10.
-export function FormatInt(i: number, base: number): string {
+export function FormatInt(i: number | bigint, base: number): string {
 	if (base < 2 || base > 36) {
 		throw new Error("FormatInt: illegal base");
 	}
+	if (typeof i === "bigint") {
+		return i.toString(base);
+	}
 	return Math.floor(i).toString(base);
 }
 
@@ -28,14 +34,14 @@ export function Itoa(i: number): string {
 
 // AppendInt appends the string form of the integer i,
 // as generated by FormatInt, to dst and returns the extended buffer.
-export function AppendInt(dst: $.Bytes, i: number, base: number): $.Bytes {
+export function AppendInt(dst: $.Bytes, i: number | bigint, base: number): $.Bytes {
 	const str = FormatInt(i, base);
 	return $.append(dst, ...$.stringToBytes(str)!);
 }
 
 // AppendUint appends the string form of the unsigned integer i,
 // as generated by FormatUint, to dst and returns the extended buffer.
-export function AppendUint(dst: $.Bytes, i: number, base: number): $.Bytes {
+export function AppendUint(dst: $.Bytes, i: number | bigint, base: number): $.Bytes {
 	const str = FormatUint(i, base);
 	return $.append(dst, ...$.stringToBytes(str)!);
 } 
\ No newline at end of file
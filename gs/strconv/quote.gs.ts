@@ -4,65 +4,104 @@ import { ErrSyntax } from "./atoi.gs.js";
 // Quote returns a double-quoted Go string literal representing s.
 // The returned string uses Go escape sequences (\t, \n, \xFF, \u0100) for control characters and non-printable characters.
 export function Quote(s: string): string {
-	return JSON.stringify(s);
+	return quoteWith(s, 34, false, false);
 }
 
 // QuoteToASCII returns a double-quoted Go string literal representing s.
 // The returned string uses Go escape sequences (\t, \n, \xFF, \u0100) for control characters and non-ASCII characters.
 export function QuoteToASCII(s: string): string {
-	// For simplicity, use JSON.stringify and then escape non-ASCII
-	const quoted = JSON.stringify(s);
-	return quoted.replace(/[\u0080-\uFFFF]/g, (match) => {
-		const code = match.charCodeAt(0);
-		if (code <= 0xFF) {
-			return '\\x' + code.toString(16).padStart(2, '0');
-		} else {
-			return '\\u' + code.toString(16).padStart(4, '0');
-		}
-	});
+	return quoteWith(s, 34, true, false);
 }
 
 // QuoteToGraphic returns a double-quoted Go string literal representing s.
 // The returned string leaves Unicode graphic characters unchanged.
 export function QuoteToGraphic(s: string): string {
-	return Quote(s); // Simplified
+	return quoteWith(s, 34, false, true);
 }
 
 // QuoteRune returns a single-quoted Go character literal representing the rune.
 export function QuoteRune(r: number): string {
-	const char = String.fromCharCode(r);
-	if (r === 39) { // single quote
-		return "'\\'";
-	}
-	if (r === 92) { // backslash  
-		return "'\\\\'";
-	}
-	if (r >= 32 && r <= 126) { // printable ASCII
-		return "'" + char + "'";
-	}
-	// Use escape sequences for non-printable
-	if (r <= 0xFF) {
-		return "'\\x" + r.toString(16).padStart(2, '0') + "'";
-	}
-	return "'\\u" + r.toString(16).padStart(4, '0') + "'";
+	return quoteRuneWith(r, 39, false, false);
 }
 
 // QuoteRuneToASCII returns a single-quoted Go character literal representing the rune.
 export function QuoteRuneToASCII(r: number): string {
-	return QuoteRune(r); // Same as QuoteRune for simplicity
+	return quoteRuneWith(r, 39, true, false);
 }
 
 // QuoteRuneToGraphic returns a single-quoted Go character literal representing the rune.
 export function QuoteRuneToGraphic(r: number): string {
-	return QuoteRune(r); // Same as QuoteRune for simplicity
+	return quoteRuneWith(r, 39, false, true);
+}
+
+function quoteWith(s: string, quote: number, ASCIIonly: boolean, graphicOnly: boolean): string {
+	let buf = String.fromCharCode(quote);
+	for (const c of s) {
+		buf += escapedRune(c.codePointAt(0)!, quote, ASCIIonly, graphicOnly);
+	}
+	return buf + String.fromCharCode(quote);
+}
+
+function quoteRuneWith(r: number, quote: number, ASCIIonly: boolean, graphicOnly: boolean): string {
+	if (!validRune(r)) {
+		r = 0xfffd;
+	}
+	const q = String.fromCharCode(quote);
+	return q + escapedRune(r, quote, ASCIIonly, graphicOnly) + q;
+}
+
+function escapedRune(r: number, quote: number, ASCIIonly: boolean, graphicOnly: boolean): string {
+	if (r === quote || r === 92) { // always backslashed
+		return "\\" + String.fromCodePoint(r);
+	}
+	if (ASCIIonly) {
+		if (r < 0x80 && IsPrint(r)) {
+			return String.fromCharCode(r);
+		}
+	} else if (IsPrint(r) || (graphicOnly && IsGraphic(r))) {
+		return String.fromCodePoint(r);
+	}
+	switch (r) {
+		case 7:
+			return "\\a";
+		case 8:
+			return "\\b";
+		case 12:
+			return "\\f";
+		case 10:
+			return "\\n";
+		case 13:
+			return "\\r";
+		case 9:
+			return "\\t";
+		case 11:
+			return "\\v";
+	}
+	if (r < 32 || r === 0x7f) {
+		return "\\x" + r.toString(16).padStart(2, "0");
+	}
+	if (!validRune(r)) {
+		r = 0xfffd;
+	}
+	if (r < 0x10000) {
+		return "\\u" + r.toString(16).padStart(4, "0");
+	}
+	return "\\U" + r.toString(16).padStart(8, "0");
+}
+
+// validRune reports whether r can be legally encoded as UTF-8.
+function validRune(r: number): boolean {
+	return (r >= 0 && r < 0xd800) || (r > 0xdfff && r <= 0x10ffff);
 }
 
 // CanBackquote reports whether the string s can be represented unchanged as a single-line backquoted string.
 export function CanBackquote(s: string): boolean {
-	// Check if string contains backticks or control characters
-	for (let i = 0; i < s.length; i++) {
-		const c = s.charCodeAt(i);
-		if (c === 96 || c < 32 || c === 127) { // backtick or control character
+	for (const ch of s) {
+		const c = ch.codePointAt(0)!;
+		if (c === 0xfeff || (c >= 0xd800 && c <= 0xdfff)) {
+			return false;
+		}
+		if ((c < 32 && c !== 9) || c === 96 || c === 127) { // control character or backtick
 			return false;
 		}
 	}
@@ -233,13 +272,25 @@ export function AppendQuoteRuneToGraphic(dst: $.Bytes, r: number): $.Bytes {
 	return $.append(dst, ...$.stringToBytes(quoted)!);
 }
 
-// IsPrint reports whether the rune is defined as printable by Go.
+// IsPrint reports whether the rune is defined as printable by Go, with
+// the same definition as unicode.IsPrint: letters, numbers, punctuation,
+// symbols and ASCII space.
 export function IsPrint(r: number): boolean {
-	// Simplified: consider ASCII printable characters
-	return r >= 32 && r <= 126;
+	if (r < 0x80) {
+		return r >= 32 && r < 0x7f;
+	}
+	return validRune(r) && printRE.test(String.fromCodePoint(r));
 }
 
 // IsGraphic reports whether the rune is defined as a Graphic by Unicode.
+// Such characters include letters, marks, numbers, punctuation, symbols, and
+// spaces, from categories L, M, N, P, S, and Zs.
 export function IsGraphic(r: number): boolean {
-	return IsPrint(r); // Simplified
-} 
\ No newline at end of file
+	if (IsPrint(r)) {
+		return true;
+	}
+	return validRune(r) && graphicRE.test(String.fromCodePoint(r));
+}
+
+const printRE = /^[\p{L}\p{M}\p{N}\p{P}\p{S}]$/u;
+const graphicRE = /^\p{Zs}$/u;
@@ -114,7 +114,7 @@ describe('strings/Builder', () => {
       const text = 'Hello 🌟'
       b.WriteString(text)
       expect(b.String()).toBe(text)
-      expect(b.Len()).toBe(text.length)
+      expect(b.Len()).toBe(10) // bytes in UTF-8
     })
   })
 })
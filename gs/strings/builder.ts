@@ -30,7 +30,7 @@ export class Builder {
 
   // Len returns the number of accumulated bytes; b.Len() == len(b.String()).
   public Len(): number {
-    return this._content.length
+    return $.len(this._content)
   }
 
   // Cap returns the capacity of the builder's underlying byte slice. It is the
@@ -38,7 +38,7 @@ export class Builder {
   // already written.
   public Cap(): number {
     // For simplicity, return the current length since JavaScript strings are dynamic
-    return this._content.length
+    return $.len(this._content)
   }
 
   // Reset resets the Builder to be empty.
@@ -62,9 +62,8 @@ export class Builder {
   // Write always returns len(p), nil.
   public Write(p: Uint8Array): [number, $.GoError] {
     this.copyCheck()
-    // Convert byte array to string
-    const str = new TextDecoder('utf-8').decode(p)
-    this._content += str
+    // Joining keeps UTF-8 sequences split between writes intact.
+    this._content = $.concatString(this._content, $.bytesToString(p))
     return [p.length, null]
   }
 
@@ -72,7 +71,10 @@ export class Builder {
   // The returned error is always nil.
   public WriteByte(c: number): $.GoError {
     this.copyCheck()
-    this._content += String.fromCharCode(c)
+    this._content = $.concatString(
+      this._content,
+      $.bytesToString(Uint8Array.of(c)),
+    )
     return null
   }
 
@@ -80,24 +82,23 @@ export class Builder {
   // It returns the length of r and a nil error.
   public WriteRune(r: number): [number, $.GoError] {
     this.copyCheck()
-    const str = String.fromCodePoint(r)
+    const str = $.runeOrStringToString(r)
     this._content += str
     // Return the byte length of the UTF-8 encoding
-    const byteLength = new TextEncoder().encode(str).length
-    return [byteLength, null]
+    return [$.len(str), null]
   }
 
   // WriteString appends the contents of s to b's buffer.
   // It returns the length of s and a nil error.
   public WriteString(s: string): [number, $.GoError] {
     this.copyCheck()
-    this._content += s
-    return [s.length, null]
+    this._content = $.concatString(this._content, s)
+    return [$.len(s), null]
   }
 
   // Register this type with the runtime type system
   static __typeInfo = $.registerStructType(
-    'Builder',
+    'strings.Builder',
     new Builder(),
     [
       {
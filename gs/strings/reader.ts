@@ -219,7 +219,7 @@ export class Reader {
 
   // Register this type with the runtime type system
   static __typeInfo = $.registerStructType(
-    'Reader',
+    'strings.Reader',
     new Reader(),
     [
       {
@@ -407,7 +407,7 @@ export class Reader {
       },
       {
         name: 'WriteTo',
-        args: [{ name: 'w', type: 'Writer' }],
+        args: [{ name: 'w', type: 'io.Writer' }],
         returns: [
           { type: { kind: $.TypeKind.Basic, name: 'number' } },
           {
@@ -168,11 +168,11 @@ export class Replacer {
 
   // Register this type with the runtime type system
   static __typeInfo = $.registerStructType(
-    'Replacer',
+    'strings.Replacer',
     new Replacer(),
     [
       { name: 'buildOnce', args: [], returns: [] },
-      { name: 'build', args: [], returns: [{ type: 'replacer' }] },
+      { name: 'build', args: [], returns: [{ type: 'strings.replacer' }] },
       {
         name: 'Replace',
         args: [{ name: 's', type: { kind: $.TypeKind.Basic, name: 'string' } }],
@@ -181,7 +181,7 @@ export class Replacer {
       {
         name: 'WriteString',
         args: [
-          { name: 'w', type: 'Writer' },
+          { name: 'w', type: 'io.Writer' },
           { name: 's', type: { kind: $.TypeKind.Basic, name: 'string' } },
         ],
         returns: [
@@ -222,7 +222,7 @@ type replacer = null | {
 }
 
 $.registerInterfaceType(
-  'replacer',
+  'strings.replacer',
   null, // Zero value for interface is null
   [
     {
@@ -233,7 +233,7 @@ $.registerInterfaceType(
     {
       name: 'WriteString',
       args: [
-        { name: 'w', type: 'Writer' },
+        { name: 'w', type: 'io.Writer' },
         { name: 's', type: { kind: $.TypeKind.Basic, name: 'string' } },
       ],
       returns: [
@@ -54,33 +54,37 @@ export function ContainsFunc(
   return false
 }
 
+// byteIndex converts the UTF-16 index i of s to the byte index of Go.
+function byteIndex(s: string, i: number): number {
+  return i < 0 ? i : $.len(s.slice(0, i))
+}
+
 // Index returns the index of the first instance of substr in s, or -1 if substr is not present in s.
 export function Index(s: string, substr: string): number {
-  return s.indexOf(substr)
+  return byteIndex(s, s.indexOf(substr))
 }
 
 // LastIndex returns the index of the last instance of substr in s, or -1 if substr is not present in s.
 export function LastIndex(s: string, substr: string): number {
-  return s.lastIndexOf(substr)
+  return byteIndex(s, s.lastIndexOf(substr))
 }
 
 // IndexByte returns the index of the first instance of c in s, or -1 if c is not present in s.
 export function IndexByte(s: string, c: number): number {
-  const char = String.fromCharCode(c)
-  return s.indexOf(char)
+  return $.stringToBytes(s).indexOf(c & 0xff)
 }
 
 // IndexRune returns the index of the first instance of the Unicode code point r, or -1 if rune is not present in s.
 export function IndexRune(s: string, r: number): number {
-  const char = String.fromCodePoint(r)
-  return s.indexOf(char)
+  const char = $.runeOrStringToString(r)
+  return byteIndex(s, s.indexOf(char))
 }
 
 // IndexAny returns the index of the first instance of any Unicode code point from chars in s, or -1 if no Unicode code point from chars is present in s.
 export function IndexAny(s: string, chars: string): number {
   for (let i = 0; i < s.length; i++) {
     if (chars.includes(s[i])) {
-      return i
+      return byteIndex(s, i)
     }
   }
   return -1
@@ -90,7 +94,7 @@ export function IndexAny(s: string, chars: string): number {
 export function LastIndexAny(s: string, chars: string): number {
   for (let i = s.length - 1; i >= 0; i--) {
     if (chars.includes(s[i])) {
-      return i
+      return byteIndex(s, i)
     }
   }
   return -1
@@ -98,8 +102,7 @@ export function LastIndexAny(s: string, chars: string): number {
 
 // LastIndexByte returns the index of the last instance of c in s, or -1 if c is not present in s.
 export function LastIndexByte(s: string, c: number): number {
-  const char = String.fromCharCode(c)
-  return s.lastIndexOf(char)
+  return $.stringToBytes(s).lastIndexOf(c & 0xff)
 }
 
 // IndexFunc returns the index into s of the first Unicode code point satisfying f(c), or -1 if none do.
@@ -108,13 +111,10 @@ export function IndexFunc(
   f: ((r: number) => boolean) | null,
 ): number {
   if (!f) return -1
-  let index = 0
-  for (const char of s) {
-    const codePoint = char.codePointAt(0)
-    if (codePoint !== undefined && f(codePoint)) {
-      return index
+  for (const [i, r] of $.rangeString(s)) {
+    if (f(r)) {
+      return i
     }
-    index += char.length
   }
   return -1
 }
@@ -125,12 +125,10 @@ export function LastIndexFunc(
   f: ((r: number) => boolean) | null,
 ): number {
   if (!f) return -1
-  const chars = [...s]
-  for (let i = chars.length - 1; i >= 0; i--) {
-    const codePoint = chars[i].codePointAt(0)
-    if (codePoint !== undefined && f(codePoint)) {
-      // Calculate byte index
-      return chars.slice(0, i).join('').length
+  const runes = [...$.rangeString(s)]
+  for (let i = runes.length - 1; i >= 0; i--) {
+    if (f(runes[i][1])) {
+      return runes[i][0]
     }
   }
   return -1
@@ -3,6 +3,8 @@
 // low-level library routines. Higher-level synchronization is better done via
 // channels and communication.
 
+import * as $ from '@goscript/builtin/index.js'
+
 // Locker represents an object that can be locked and unlocked
 export interface Locker {
   Lock(): Promise<void>
@@ -26,11 +28,14 @@ export class Mutex implements Locker {
       return
     }
 
-    // In a real implementation, this would block the goroutine
-    // Use Promise to simulate blocking behavior like channels
-    return new Promise<void>((resolve) => {
-      this._waitQueue.push(resolve)
-    })
+    // Park the goroutine until Unlock hands the mutex over
+    return $.park(
+      new Promise<void>((resolve) => {
+        this._waitQueue.push(resolve)
+      }),
+      'sync.Mutex.Lock',
+      this.Lock,
+    )
   }
 
   // TryLock tries to lock m and reports whether it succeeded
@@ -83,9 +88,13 @@ export class RWMutex {
       return
     }
 
-    return new Promise<void>((resolve) => {
-      this._writerWaitQueue.push(resolve)
-    })
+    return $.park(
+      new Promise<void>((resolve) => {
+        this._writerWaitQueue.push(resolve)
+      }),
+      'sync.RWMutex.Lock',
+      this.Lock,
+    )
   }
 
   // TryLock tries to lock rw for writing and reports whether it succeeded
@@ -114,12 +123,16 @@ export class RWMutex {
       return
     }
 
-    return new Promise<void>((resolve) => {
-      this._readerWaitQueue.push(() => {
-        this._readers++
-        resolve()
-      })
-    })
+    return $.park(
+      new Promise<void>((resolve) => {
+        this._readerWaitQueue.push(() => {
+          this._readers++
+          resolve()
+        })
+      }),
+      'sync.RWMutex.RLock',
+      this.RLock,
+    )
   }
 
   // TryRLock tries to lock rw for reading and reports whether it succeeded
@@ -199,9 +212,13 @@ export class WaitGroup {
       return
     }
 
-    return new Promise<void>((resolve) => {
-      this._waiters.push(resolve)
-    })
+    return $.park(
+      new Promise<void>((resolve) => {
+        this._waiters.push(resolve)
+      }),
+      'sync.WaitGroup.Wait',
+      this.Wait,
+    )
   }
 
   // clone returns a copy of this WaitGroup instance
@@ -271,12 +288,15 @@ export class Cond {
   public async Wait(): Promise<void> {
     this._l.Unlock()
 
-    return new Promise<void>((resolve) => {
-      this._waiters.push(async () => {
-        await this._l.Lock()
-        resolve()
-      })
-    })
+    // Wait to be woken, then relock
+    await $.park(
+      new Promise<void>((resolve) => {
+        this._waiters.push(resolve)
+      }),
+      'sync.Cond.Wait',
+      this.Wait,
+    )
+    await this._l.Lock()
   }
 
   // clone returns a copy of this Cond instance
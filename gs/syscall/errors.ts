@@ -1,15 +1,21 @@
 import * as $ from '@goscript/builtin/index.js'
+import * as oserror from '@goscript/internal/oserror/index.js'
 import { Errno } from './types.js'
 
+// Like in Go, Errno values match the os errors they imply in errors.Is, for
+// example ENOENT matches ErrNotExist.
+
 export const EPERM: Errno = {
   Error: () => 'operation not permitted',
-  Is: (target: $.GoError) => target === EPERM,
+  Is: (target: $.GoError) =>
+    target === EPERM || target === oserror.ErrPermission,
   Errno: () => 1,
 }
 
 export const ENOENT: Errno = {
   Error: () => 'no such file or directory',
-  Is: (target: $.GoError) => target === ENOENT,
+  Is: (target: $.GoError) =>
+    target === ENOENT || target === oserror.ErrNotExist,
   Errno: () => 2,
 }
 
@@ -75,7 +81,8 @@ export const ENOMEM: Errno = {
 
 export const EACCES: Errno = {
   Error: () => 'permission denied',
-  Is: (target: $.GoError) => target === EACCES,
+  Is: (target: $.GoError) =>
+    target === EACCES || target === oserror.ErrPermission,
   Errno: () => 13,
 }
 
@@ -93,7 +100,8 @@ export const EBUSY: Errno = {
 
 export const EEXIST: Errno = {
   Error: () => 'file exists',
-  Is: (target: $.GoError) => target === EEXIST,
+  Is: (target: $.GoError) =>
+    target === EEXIST || target === oserror.ErrExist,
   Errno: () => 17,
 }
 
@@ -219,7 +227,8 @@ export const ENOSYS: Errno = {
 
 export const ENOTEMPTY: Errno = {
   Error: () => 'directory not empty',
-  Is: (target: $.GoError) => target === ENOTEMPTY,
+  Is: (target: $.GoError) =>
+    target === ENOTEMPTY || target === oserror.ErrExist,
   Errno: () => 39,
 }
 
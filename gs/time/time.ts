@@ -1,4 +1,6 @@
 import { makeChannel, ChannelRef, makeChannelRef } from '../builtin/channel.js'
+import { addWakeup, park } from '../builtin/scheduler.js'
+import { registerNamedType, TypeKind } from '../builtin/type.js'
 
 // Time represents a time instant with nanosecond precision
 export class Time {
@@ -579,6 +581,8 @@ export class Time {
 // Duration represents a span of time (nanoseconds)
 export type Duration = number
 
+registerNamedType('time.Duration', 0, [], { kind: TypeKind.Basic, name: 'int64' })
+
 // Duration comparison function
 export function Duration_lt(receiver: Duration, other: Duration): boolean {
   return receiver < other
@@ -692,17 +696,24 @@ export class Timer {
   private _timeout: NodeJS.Timeout | number
   private _duration: Duration
   private _callback?: () => void
+  private _release: () => void = () => {}
 
   constructor(duration: Duration, callback?: () => void) {
     this._duration = duration
     this._callback = callback
-    const ms = duration / 1000000 // Convert nanoseconds to milliseconds
+    this._timeout = this._start(duration)
+  }
 
-    if (callback) {
-      this._timeout = setTimeout(callback, ms)
-    } else {
-      this._timeout = setTimeout(() => {}, ms)
-    }
+  // _start starts the timer. Until it fires or is stopped, the program is
+  // not reported as deadlocked, as the timer may wake a goroutine.
+  private _start(d: Duration): NodeJS.Timeout | number {
+    const ms = d / 1000000 // Convert nanoseconds to milliseconds
+    const release = addWakeup()
+    this._release = release
+    return setTimeout(() => {
+      release()
+      this._callback?.()
+    }, ms)
   }
 
   // Stop prevents the Timer from firing
@@ -712,18 +723,14 @@ export class Timer {
     } else {
       clearTimeout(this._timeout)
     }
+    this._release()
     return true
   }
 
   // Reset changes the timer to expire after duration d
   public Reset(d: Duration): boolean {
     this.Stop()
-    const ms = d / 1000000
-    if (this._callback) {
-      this._timeout = setTimeout(this._callback, ms)
-    } else {
-      this._timeout = setTimeout(() => {}, ms)
-    }
+    this._timeout = this._start(d)
     return true
   }
 }
@@ -733,6 +740,9 @@ export class Ticker {
   private _interval: NodeJS.Timeout | number
   private _duration: Duration
   private _stopped: boolean = false
+  // A running ticker may wake a goroutine, so the program is not reported
+  // as deadlocked until it is stopped.
+  private _release: () => void = addWakeup()
 
   constructor(duration: Duration) {
     this._duration = duration
@@ -748,12 +758,14 @@ export class Ticker {
     } else {
       clearInterval(this._interval)
     }
+    this._release()
   }
 
   // Reset stops a ticker and resets its period to the specified duration
   public Reset(d: Duration): void {
     this.Stop()
     this._stopped = false
+    this._release = addWakeup()
     this._duration = d
     const ms = d / 1000000
     this._interval = setInterval(() => {}, ms)
@@ -862,7 +874,18 @@ export function Until(t: Time): Duration {
 // Sleep pauses the current execution for at least the duration d
 export async function Sleep(d: Duration): Promise<void> {
   const ms = d / 1000000 // Convert nanoseconds to milliseconds
-  return new Promise((resolve) => setTimeout(resolve, ms))
+  const release = addWakeup()
+  return park(
+    new Promise<void>((resolve) =>
+      setTimeout(() => {
+        release()
+        resolve()
+      }, ms),
+    ),
+    'sleep',
+    Sleep,
+    false,
+  )
 }
 
 // Export month constants
@@ -1012,7 +1035,9 @@ export function After(d: Duration): ChannelRef<Time> {
   const channel = makeChannel(1, new Time(), 'both')
 
   // Start a timer that will send the current time after the duration
+  const release = addWakeup()
   setTimeout(async () => {
+    release()
     channel.send(Now()).catch(() => {})
   }, ms)
 
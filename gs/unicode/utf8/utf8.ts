@@ -49,44 +49,83 @@ export function DecodeLastRuneInString(s: string): [number, number] {
     return [RuneError, 0]
   }
 
-  // Use JavaScript's built-in Unicode handling
-  const chars = [...s]
-  if (chars.length === 0) {
-    return [RuneError, 0]
-  }
-
-  const lastChar = chars[chars.length - 1]
-  const codePoint = lastChar.codePointAt(0) || RuneError
-  const encoder = new TextEncoder()
-  const encoded = encoder.encode(lastChar)
-  return [codePoint, encoded.length]
+  // The last rune is held in the last UTFMax code units at most.
+  return DecodeLastRune($.stringToBytes(s.slice(-UTFMax)))
 }
 
 // DecodeRune unpacks the first UTF-8 encoding in p and returns the rune and its width in bytes.
+// If p is empty it returns (RuneError, 0). Otherwise, if the encoding is
+// invalid, it returns (RuneError, 1).
 export function DecodeRune(p: $.Bytes): [number, number] {
-  if (!p?.length) {
+  const n = $.len(p)
+  if (n < 1) {
     return [RuneError, 0]
   }
-
-  if (p![0] < RuneSelf) {
-    return [p![0], 1]
+  const p0 = p![0]
+  if (p0 < RuneSelf) {
+    return [p0, 1]
   }
-
-  // Convert p to Uint8Array to satisfy AllowsSharedBufferSource requirement
-  const bytes = $.normalizeBytes(p)
-
-  // Convert bytes to string and decode
-  const decoder = new TextDecoder('utf-8', { fatal: false })
-  const str = decoder.decode(bytes.slice(0, Math.min(4, bytes.length)))
-  if (str.length === 0 || str === '\uFFFD') {
+  const lead = leadingByte(p0)
+  if (lead === null) {
+    return [RuneError, 1]
+  }
+  const [sz, lo, hi] = lead
+  if (n < sz) {
     return [RuneError, 1]
   }
+  const b1 = p![1]
+  if (b1 < lo || hi < b1) {
+    return [RuneError, 1]
+  }
+  if (sz <= 2) {
+    return [((p0 & 0x1f) << 6) | (b1 & 0x3f), 2]
+  }
+  const b2 = p![2]
+  if (b2 < 0x80 || 0xbf < b2) {
+    return [RuneError, 1]
+  }
+  if (sz <= 3) {
+    return [((p0 & 0x0f) << 12) | ((b1 & 0x3f) << 6) | (b2 & 0x3f), 3]
+  }
+  const b3 = p![3]
+  if (b3 < 0x80 || 0xbf < b3) {
+    return [RuneError, 1]
+  }
+  return [
+    ((p0 & 0x07) << 18) |
+      ((b1 & 0x3f) << 12) |
+      ((b2 & 0x3f) << 6) |
+      (b3 & 0x3f),
+    4,
+  ]
+}
 
-  const codePoint = str.codePointAt(0) || RuneError
-  const char = String.fromCodePoint(codePoint)
-  const encoder = new TextEncoder()
-  const encoded = encoder.encode(char)
-  return [codePoint, encoded.length]
+// leadingByte returns the encoded length of a rune that starts with the
+// non-ASCII byte c and the accepted range of its second byte, or null if c
+// cannot start a rune.
+function leadingByte(c: number): [number, number, number] | null {
+  if (c < 0xc2 || c > 0xf4) {
+    return null
+  }
+  if (c < 0xe0) {
+    return [2, 0x80, 0xbf]
+  }
+  if (c < 0xf0) {
+    if (c === 0xe0) {
+      return [3, 0xa0, 0xbf]
+    }
+    if (c === 0xed) {
+      return [3, 0x80, 0x9f]
+    }
+    return [3, 0x80, 0xbf]
+  }
+  if (c === 0xf0) {
+    return [4, 0x90, 0xbf]
+  }
+  if (c === 0xf4) {
+    return [4, 0x80, 0x8f]
+  }
+  return [4, 0x80, 0xbf]
 }
 
 // DecodeRuneInString is like DecodeRune but its input is a string.
@@ -100,12 +139,9 @@ export function DecodeRuneInString(s: string): [number, number] {
     return [c, 1]
   }
 
-  // Use JavaScript's built-in Unicode handling
-  const codePoint = s.codePointAt(0) || RuneError
-  const char = String.fromCodePoint(codePoint)
-  const encoder = new TextEncoder()
-  const encoded = encoder.encode(char)
-  return [codePoint, encoded.length]
+  // The first rune is held in the first UTFMax code units at most. Decoding
+  // their bytes handles the bytes of invalid UTF-8 held in s.
+  return DecodeRune($.stringToBytes(s.slice(0, UTFMax)))
 }
 
 // EncodeRune writes into p (which must be large enough) the UTF-8 encoding of the rune.
@@ -137,17 +173,29 @@ export function EncodeRune(p: Uint8Array | $.Slice<number>, r: number): number {
 }
 
 // FullRune reports whether the bytes in p begin with a full UTF-8 encoding of a rune.
-export function FullRune(p: Uint8Array): boolean {
-  if (p.length === 0) {
+// An invalid encoding is considered a full Rune since it will convert as a width-1 error rune.
+export function FullRune(p: $.Bytes): boolean {
+  const n = $.len(p)
+  if (n === 0) {
     return false
   }
-
-  if (p[0] < RuneSelf) {
+  const p0 = p![0]
+  if (p0 < RuneSelf) {
     return true
   }
-
-  const [, size] = DecodeRune(p)
-  return size <= p.length && size > 0
+  const lead = leadingByte(p0)
+  if (lead === null || n >= lead[0]) {
+    return true
+  }
+  // A bad continuation byte ends the rune early as an error.
+  const [, lo, hi] = lead
+  if (n > 1 && (p![1] < lo || hi < p![1])) {
+    return true
+  }
+  if (n > 2 && (p![2] < 0x80 || 0xbf < p![2])) {
+    return true
+  }
+  return false
 }
 
 // FullRuneInString is like FullRune but its input is a string.